@@ -0,0 +1,235 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// DefaultName is the name of the implicit default account. All assets
+	// that aren't owned by a named account belong to the default account.
+	DefaultName = "default"
+
+	// FirstKeyFamily is the key family of the first named account. Each
+	// new account is assigned the next unused key family after this one,
+	// which keeps the key scopes of the accounts clear of the key families
+	// used by lnd and the other daemons that share its wallet.
+	FirstKeyFamily keychain.KeyFamily = 2120
+
+	// MaxNameLength is the maximum length of an account name.
+	MaxNameLength = 64
+
+	// tenantRootKeyIDPrefix is the prefix of the macaroon root key IDs of
+	// tenants. Account names can't contain a colon, so the root key ID of
+	// a tenant can't collide with any of the other root key IDs.
+	tenantRootKeyIDPrefix = "tenant:"
+)
+
+var (
+	// ErrNotFound is returned when an account with the given name doesn't
+	// exist.
+	ErrNotFound = errors.New("account not found")
+
+	// ErrExists is returned when an account with the given name already
+	// exists.
+	ErrExists = errors.New("account already exists")
+
+	// ErrTenantIsolation is returned if a call that is scoped to a tenant
+	// references the assets of another account.
+	ErrTenantIsolation = errors.New("account not accessible by tenant")
+
+	// validName is the pattern all account names must match.
+	validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// Account is a named asset account. Each account derives the script keys and
+// anchor internal keys of its assets from its own key family, which gives each
+// account a separate key scope and balance view within the same daemon.
+type Account struct {
+	// Name is the unique name of the account.
+	Name string
+
+	// KeyFamily is the key family all keys of the account are derived
+	// from.
+	KeyFamily keychain.KeyFamily
+
+	// CreatedAt is the time the account was created at. This is the zero
+	// time for the default account.
+	CreatedAt time.Time
+
+	// Tenant is true if the account belongs to a tenant. A tenant accesses
+	// the daemon with macaroons of its own root key, which only give access
+	// to the assets of its account.
+	Tenant bool
+}
+
+// Default is the implicit default account. It uses the regular Taproot Assets
+// key family and owns all assets that aren't owned by a named account.
+var Default = Account{
+	Name:      DefaultName,
+	KeyFamily: asset.TaprootAssetsKeyFamily,
+}
+
+// IsDefault returns true if the account is the default account.
+func (a Account) IsDefault() bool {
+	return a.Name == DefaultName
+}
+
+// ValidateName checks that the given name can be used for a new account.
+func ValidateName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("account name cannot be empty")
+
+	case len(name) > MaxNameLength:
+		return fmt.Errorf("account name cannot be longer than %d "+
+			"characters", MaxNameLength)
+
+	case name == DefaultName:
+		return fmt.Errorf("account name %q is reserved", DefaultName)
+
+	case !validName.MatchString(name):
+		return fmt.Errorf("account name %q may only contain letters, "+
+			"digits, dashes and underscores", name)
+	}
+
+	return nil
+}
+
+// Store is the interface of the persistent storage of named accounts.
+type Store interface {
+	// NewAccount creates a new account with the given name and assigns it
+	// the next unused key family. ErrExists is returned if an account with
+	// the same name already exists.
+	NewAccount(ctx context.Context, name string) (*Account, error)
+
+	// NewTenant creates a new tenant account with the given name and
+	// assigns it the next unused key family. ErrExists is returned if an
+	// account with the same name already exists.
+	NewTenant(ctx context.Context, name string) (*Account, error)
+
+	// FetchAccount returns the account with the given name. ErrNotFound is
+	// returned if no such account exists.
+	FetchAccount(ctx context.Context, name string) (*Account, error)
+
+	// ListAccounts returns all named accounts, ordered by their key family.
+	ListAccounts(ctx context.Context) ([]Account, error)
+}
+
+// Resolve returns the account with the given name. An empty name or the name
+// of the default account resolve to the default account, unless the call of
+// the given context is scoped to a tenant, in which case an empty name
+// resolves to the account of the tenant.
+func Resolve(ctx context.Context, store Store, name string) (Account,
+	error) {
+
+	name, err := ScopeName(ctx, name)
+	if err != nil {
+		return Account{}, err
+	}
+
+	// The account of a tenant is already known from the context.
+	tenant := TenantFromContext(ctx)
+	if tenant.IsSome() {
+		return tenant.UnwrapOr(Account{}), nil
+	}
+
+	if name == "" || name == DefaultName {
+		return Default, nil
+	}
+
+	acct, err := store.FetchAccount(ctx, name)
+	if err != nil {
+		return Account{}, err
+	}
+
+	return *acct, nil
+}
+
+// TenantRootKeyID returns the macaroon root key ID of the tenant with the given
+// name. Each tenant has its own root key, so the macaroons of a tenant can be
+// told apart from all other macaroons and be revoked independently.
+func TenantRootKeyID(name string) []byte {
+	return []byte(tenantRootKeyIDPrefix + name)
+}
+
+// TenantFromRootKeyID returns the name of the tenant the given macaroon root
+// key ID belongs to. False is returned if the root key ID isn't the one of a
+// tenant.
+func TenantFromRootKeyID(rootKeyID []byte) (string, bool) {
+	name, ok := strings.CutPrefix(string(rootKeyID), tenantRootKeyIDPrefix)
+	if !ok || name == "" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// tenantCtxKey is the context key the tenant of a call is stored under.
+type tenantCtxKey struct{}
+
+// WithTenant returns a copy of the given context that scopes all calls made
+// with it to the account of the given tenant.
+func WithTenant(ctx context.Context, tenant Account) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant the calls made with the given context
+// are scoped to, if any.
+func TenantFromContext(ctx context.Context) fn.Option[Account] {
+	tenant, ok := ctx.Value(tenantCtxKey{}).(Account)
+	if !ok {
+		return fn.None[Account]()
+	}
+
+	return fn.Some(tenant)
+}
+
+// ScopeName returns the name of the account a call with the given context
+// refers to with the given account name. A call that is scoped to a tenant
+// always refers to the account of the tenant, and ErrTenantIsolation is
+// returned if it names any other account.
+func ScopeName(ctx context.Context, name string) (string, error) {
+	tenant := TenantFromContext(ctx)
+	if tenant.IsNone() {
+		return name, nil
+	}
+
+	tenantName := tenant.UnwrapOr(Account{}).Name
+	if name != "" && name != tenantName {
+		return "", fmt.Errorf("%w: %s", ErrTenantIsolation, name)
+	}
+
+	return tenantName, nil
+}
+
+// ScopeFilter restricts the given account filter to the tenant the call of
+// the given context is scoped to, if any. Without a filter, the call is
+// restricted to the account of the tenant, and ErrTenantIsolation is returned
+// if the filter is for any other account.
+func ScopeFilter(ctx context.Context,
+	acct fn.Option[Account]) (fn.Option[Account], error) {
+
+	tenant := TenantFromContext(ctx)
+	if tenant.IsNone() {
+		return acct, nil
+	}
+
+	tenantAcct := tenant.UnwrapOr(Account{})
+	name := fn.MapOptionZ(acct, func(a Account) string {
+		return a.Name
+	})
+	if _, err := ScopeName(ctx, name); err != nil {
+		return fn.None[Account](), err
+	}
+
+	return fn.Some(tenantAcct), nil
+}
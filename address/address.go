@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -18,6 +19,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
@@ -72,6 +74,29 @@ var (
 	// ErrUnknownVersion is returned when encountering an address with an
 	// unrecognised version number.
 	ErrUnknownVersion = errors.New("address: unknown version number")
+
+	// ErrFieldRequiresV2 is returned when attempting to create or decode an
+	// address of a version prior to V2 that has fallback proof courier
+	// addresses or an expiry set, or that is marked as reusable.
+	ErrFieldRequiresV2 = errors.New("address: fallback proof couriers, " +
+		"expiry and reusability require address version 2")
+
+	// ErrTooManyFallbackCouriers is returned when attempting to create or
+	// decode an address with more than MaxFallbackProofCourierAddrs
+	// fallback proof courier addresses.
+	ErrTooManyFallbackCouriers = errors.New("address: too many fallback " +
+		"proof courier addresses")
+
+	// ErrAddrExpired is returned when attempting to send to an address
+	// that has expired.
+	ErrAddrExpired = errors.New("address: expired")
+)
+
+const (
+	// MaxFallbackProofCourierAddrs is the maximum number of fallback proof
+	// courier addresses that can be embedded in an address. This keeps the
+	// length of the encoded address reasonable.
+	MaxFallbackProofCourierAddrs = 8
 )
 
 // Version denotes the version of a Taproot Asset address format.
@@ -84,8 +109,13 @@ const (
 	// V1 addresses use V2 Taproot Asset commitments.
 	V1 Version = 1
 
+	// V2 addresses use V2 Taproot Asset commitments and can embed an
+	// ordered list of fallback proof courier addresses and an expiry
+	// timestamp.
+	V2 Version = 2
+
 	// LatestVersion is the latest supported Taproot Asset address version.
-	latestVersion = V1
+	latestVersion = V2
 )
 
 // Tap represents a Taproot Asset address. Taproot Asset addresses specify an
@@ -132,6 +162,23 @@ type Tap struct {
 	// used to distribute related proofs for this address.
 	ProofCourierAddr url.URL
 
+	// FallbackProofCourierAddrs is an ordered list of proof courier
+	// addresses that are used if the proof can't be delivered through
+	// ProofCourierAddr. This is only supported by V2 addresses.
+	FallbackProofCourierAddrs []url.URL
+
+	// Expiry is the time after which assets should no longer be sent to
+	// this address. A zero value means the address doesn't expire. This is
+	// only supported by V2 addresses.
+	Expiry time.Time
+
+	// Reusable indicates that the address can be used for multiple
+	// deposits. The ScriptKey of a reusable address is the raw base key
+	// that the sender tweaks with a per-transfer nonce to derive the actual
+	// script key of each deposit, so the same on-chain output key is never
+	// used twice. This is only supported by V2 addresses.
+	Reusable bool
+
 	// UnknownOddTypes is a map of unknown odd types that were encountered
 	// during decoding. This map is used to preserve unknown types that we
 	// don't know of yet, so we can still encode them back when serializing.
@@ -145,12 +192,28 @@ type Tap struct {
 // created.
 type newAddrOptions struct {
 	assetVersion asset.Version
+
+	// keyFamily is the key family the script and internal keys of the
+	// address are derived from if the keys are derived by the address
+	// book.
+	keyFamily keychain.KeyFamily
+
+	// fallbackProofCourierAddrs is the ordered list of fallback proof
+	// courier addresses to embed in the address.
+	fallbackProofCourierAddrs []url.URL
+
+	// expiry is the expiry timestamp to embed in the address.
+	expiry time.Time
+
+	// reusable indicates that a reusable address should be created.
+	reusable bool
 }
 
 // defaultNewAddrOptions returns a newAddrOptions struct with default values.`
 func defaultNewAddrOptions() *newAddrOptions {
 	return &newAddrOptions{
 		assetVersion: asset.V0,
+		keyFamily:    asset.TaprootAssetsKeyFamily,
 	}
 }
 
@@ -166,6 +229,43 @@ func WithAssetVersion(v asset.Version) NewAddrOpt {
 	}
 }
 
+// WithKeyFamily is a new address option that allows callers to specify the
+// key family the address book derives the script and internal keys of the
+// address from. This is used to create addresses that belong to a named asset
+// account.
+func WithKeyFamily(keyFamily keychain.KeyFamily) NewAddrOpt {
+	return func(o *newAddrOptions) {
+		o.keyFamily = keyFamily
+	}
+}
+
+// WithFallbackProofCourierAddrs is a new address option that allows callers to
+// embed an ordered list of fallback proof courier addresses in a V2 address.
+func WithFallbackProofCourierAddrs(addrs ...url.URL) NewAddrOpt {
+	return func(o *newAddrOptions) {
+		o.fallbackProofCourierAddrs = addrs
+	}
+}
+
+// WithExpiry is a new address option that allows callers to embed an expiry
+// timestamp in a V2 address. The timestamp is encoded with a precision of one
+// second.
+func WithExpiry(expiry time.Time) NewAddrOpt {
+	return func(o *newAddrOptions) {
+		o.expiry = expiry
+	}
+}
+
+// WithReusable is a new address option that allows callers to create a
+// reusable V2 address. The script key of a reusable address is expected to be
+// a raw, untweaked key, as the sender derives a distinct script key from it
+// for each deposit.
+func WithReusable() NewAddrOpt {
+	return func(o *newAddrOptions) {
+		o.reusable = true
+	}
+}
+
 // New creates an address for receiving a Taproot asset.
 //
 // TODO(ffranr): This function takes many arguments. Add a struct to better
@@ -221,6 +321,14 @@ func New(version Version, genesis asset.Genesis, groupKey *btcec.PublicKey,
 		return nil, fmt.Errorf("address: missing group signature")
 	}
 
+	// The expiry is encoded as a Unix timestamp, so we drop anything more
+	// precise than a second to make sure the address doesn't change when
+	// it is encoded and decoded again.
+	var expiry time.Time
+	if !options.expiry.IsZero() {
+		expiry = time.Unix(options.expiry.Unix(), 0).UTC()
+	}
+
 	payload := Tap{
 		Version:          version,
 		ChainParams:      net,
@@ -233,10 +341,42 @@ func New(version Version, genesis asset.Genesis, groupKey *btcec.PublicKey,
 		Amount:           amt,
 		assetGen:         genesis,
 		ProofCourierAddr: proofCourierAddr,
+		Expiry:           expiry,
+		Reusable:         options.reusable,
+	}
+	if len(options.fallbackProofCourierAddrs) > 0 {
+		payload.FallbackProofCourierAddrs = fn.CopySlice(
+			options.fallbackProofCourierAddrs,
+		)
 	}
+
+	if err := payload.validateV2Fields(); err != nil {
+		return nil, err
+	}
+
 	return &payload, nil
 }
 
+// validateV2Fields makes sure the fields that were introduced with V2
+// addresses are only set for V2 addresses and are within their limits.
+func (a *Tap) validateV2Fields() error {
+	hasV2Fields := len(a.FallbackProofCourierAddrs) > 0 ||
+		!a.Expiry.IsZero() || a.Reusable
+	if hasV2Fields && a.Version < V2 {
+		return ErrFieldRequiresV2
+	}
+
+	if len(a.FallbackProofCourierAddrs) > MaxFallbackProofCourierAddrs {
+		return ErrTooManyFallbackCouriers
+	}
+
+	if !a.Expiry.IsZero() && a.Expiry.Unix() <= 0 {
+		return fmt.Errorf("address: invalid expiry %v", a.Expiry)
+	}
+
+	return nil
+}
+
 // Copy returns a deep copy of an Address.
 func (a *Tap) Copy() *Tap {
 	addressCopy := *a
@@ -246,6 +386,12 @@ func (a *Tap) Copy() *Tap {
 		addressCopy.GroupKey = &groupPubKey
 	}
 
+	if a.FallbackProofCourierAddrs != nil {
+		addressCopy.FallbackProofCourierAddrs = fn.CopySlice(
+			a.FallbackProofCourierAddrs,
+		)
+	}
+
 	return &addressCopy
 }
 
@@ -259,7 +405,7 @@ func CommitmentVersion(vers Version) (*commitment.TapCommitmentVersion,
 	// can't know without accessing all leaves of the commitment itself.
 	case V0:
 		return nil, nil
-	case V1:
+	case V1, V2:
 		return fn.Ptr(commitment.TapCommitmentV2), nil
 	default:
 		return nil, ErrUnknownVersion
@@ -272,6 +418,22 @@ func (a *Tap) Net() (*ChainParams, error) {
 	return Net(a.ChainParams.TapHRP)
 }
 
+// ProofCourierAddrs returns all proof courier addresses of the address in order
+// of their priority, starting with the main proof courier address followed by
+// the fallback proof courier addresses.
+func (a *Tap) ProofCourierAddrs() []url.URL {
+	addrs := make([]url.URL, 0, 1+len(a.FallbackProofCourierAddrs))
+	addrs = append(addrs, a.ProofCourierAddr)
+
+	return append(addrs, a.FallbackProofCourierAddrs...)
+}
+
+// IsExpired returns true if the address has an expiry that lies before the
+// given time.
+func (a *Tap) IsExpired(now time.Time) bool {
+	return !a.Expiry.IsZero() && now.After(a.Expiry)
+}
+
 // AssetType returns the type of asset that this address was generated for.
 func (a *Tap) AssetType() asset.Type {
 	return a.assetGen.Type
@@ -367,7 +529,7 @@ func (a *Tap) TaprootOutputKey() (*btcec.PublicKey, error) {
 // EncodeRecords determines the non-nil records to include when encoding an
 // address at runtime.
 func (a *Tap) EncodeRecords() []tlv.Record {
-	records := make([]tlv.Record, 0, 9)
+	records := make([]tlv.Record, 0, 12)
 	records = append(records, newAddressVersionRecord(&a.Version))
 	records = append(records, newAddressAssetVersionRecord(&a.AssetVersion))
 	records = append(records, newAddressAssetID(&a.AssetID))
@@ -389,6 +551,18 @@ func (a *Tap) EncodeRecords() []tlv.Record {
 		records, newProofCourierAddrRecord(&a.ProofCourierAddr),
 	)
 
+	if len(a.FallbackProofCourierAddrs) > 0 {
+		records = append(records, newFallbackProofCourierAddrsRecord(
+			&a.FallbackProofCourierAddrs,
+		))
+	}
+	if !a.Expiry.IsZero() {
+		records = append(records, newAddressExpiryRecord(&a.Expiry))
+	}
+	if a.Reusable {
+		records = append(records, newAddressReusableRecord(&a.Reusable))
+	}
+
 	// Add any unknown odd types that were encountered during decoding.
 	return asset.CombineRecords(records, a.UnknownOddTypes)
 }
@@ -406,6 +580,11 @@ func (a *Tap) DecodeRecords() []tlv.Record {
 		newAddressTapscriptSiblingRecord(&a.TapscriptSibling),
 		newAddressAmountRecord(&a.Amount),
 		newProofCourierAddrRecord(&a.ProofCourierAddr),
+		newFallbackProofCourierAddrsRecord(
+			&a.FallbackProofCourierAddrs,
+		),
+		newAddressExpiryRecord(&a.Expiry),
+		newAddressReusableRecord(&a.Reusable),
 	}
 }
 
@@ -434,7 +613,7 @@ func (a *Tap) Decode(r io.Reader) error {
 
 	a.UnknownOddTypes = unknownOddTypes
 
-	return nil
+	return a.validateV2Fields()
 }
 
 // EncodeAddress returns a bech32m string encoding of a Taproot Asset address.
@@ -473,7 +652,7 @@ func (a *Tap) String() string {
 // this implementation of tap.
 func IsUnknownVersion(v Version) bool {
 	switch v {
-	case V0, V1:
+	case V0, V1, V2:
 		return false
 	default:
 		return true
@@ -3,7 +3,10 @@ package address
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -15,6 +18,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/tlv"
 	"github.com/stretchr/testify/require"
 )
@@ -96,6 +100,24 @@ func randAddress(t *testing.T, net *ChainParams, v *Version, groupPubKey,
 	)
 }
 
+// randFallbackCourierAddrs returns the given number of distinct fallback proof
+// courier addresses.
+func randFallbackCourierAddrs(t *testing.T, num int) []url.URL {
+	t.Helper()
+
+	addrs := make([]url.URL, 0, num)
+	for i := 0; i < num; i++ {
+		addr, err := url.ParseRequestURI(fmt.Sprintf(
+			"universerpc://fallback-%d.proof.courier:10029", i,
+		))
+		require.NoError(t, err)
+
+		addrs = append(addrs, *addr)
+	}
+
+	return addrs
+}
+
 func randEncodedAddress(t *testing.T, net *ChainParams, groupPubKey,
 	sibling bool, assetType asset.Type,
 	addrOpts ...NewAddrOpt) (*Tap, string, error) {
@@ -128,6 +150,10 @@ func assertAddressEqual(t *testing.T, a, b *Tap) {
 	require.Equal(t, a.TapscriptSibling, b.TapscriptSibling)
 	require.Equal(t, a.Amount, b.Amount)
 	require.Equal(t, a.ProofCourierAddr, b.ProofCourierAddr)
+	require.Equal(
+		t, a.FallbackProofCourierAddrs, b.FallbackProofCourierAddrs,
+	)
+	require.Equal(t, a.Expiry, b.Expiry)
 }
 
 // TestNewAddress tests edge cases around creating a new address.
@@ -231,6 +257,75 @@ func TestNewAddress(t *testing.T) {
 			},
 			err: ErrUnknownVersion,
 		},
+		{
+			name: "v2 address with fallback couriers and expiry",
+			f: func() (*Tap, error) {
+				return randAddress(
+					t, &TestNet3Tap, fn.Ptr(V2), false,
+					false, nil, asset.Normal,
+					WithFallbackProofCourierAddrs(
+						randFallbackCourierAddrs(
+							t, 2,
+						)...,
+					),
+					WithExpiry(time.Now().Add(time.Hour)),
+				)
+			},
+			err: nil,
+		},
+		{
+			name: "v1 address with fallback couriers",
+			f: func() (*Tap, error) {
+				return randAddress(
+					t, &TestNet3Tap, fn.Ptr(V1), false,
+					false, nil, asset.Normal,
+					WithFallbackProofCourierAddrs(
+						randFallbackCourierAddrs(
+							t, 1,
+						)...,
+					),
+				)
+			},
+			err: ErrFieldRequiresV2,
+		},
+		{
+			name: "v0 address with expiry",
+			f: func() (*Tap, error) {
+				return randAddress(
+					t, &TestNet3Tap, fn.Ptr(V0), false,
+					false, nil, asset.Normal,
+					WithExpiry(time.Now().Add(time.Hour)),
+				)
+			},
+			err: ErrFieldRequiresV2,
+		},
+		{
+			name: "v2 address with too many fallback couriers",
+			f: func() (*Tap, error) {
+				numAddrs := MaxFallbackProofCourierAddrs + 1
+				return randAddress(
+					t, &TestNet3Tap, fn.Ptr(V2), false,
+					false, nil, asset.Normal,
+					WithFallbackProofCourierAddrs(
+						randFallbackCourierAddrs(
+							t, numAddrs,
+						)...,
+					),
+				)
+			},
+			err: ErrTooManyFallbackCouriers,
+		},
+		{
+			name: "v1 reusable address",
+			f: func() (*Tap, error) {
+				return randAddress(
+					t, &TestNet3Tap, fn.Ptr(V1), false,
+					false, nil, asset.Normal,
+					WithReusable(),
+				)
+			},
+			err: ErrFieldRequiresV2,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -386,6 +481,73 @@ func TestAddressEncoding(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "v2 address",
+			f: func() (*Tap, string, error) {
+				addr, err := randAddress(
+					t, &TestNet3Tap, fn.Ptr(V2), false,
+					false, nil, asset.Normal,
+				)
+				return addr, "", err
+			},
+			err: nil,
+		},
+		{
+			name: "v2 address with fallback couriers and expiry",
+			f: func() (*Tap, string, error) {
+				expiry := time.Unix(1893456000, 0)
+				addr, err := randAddress(
+					t, &MainNetTap, fn.Ptr(V2), true,
+					true, nil, asset.Collectible,
+					WithFallbackProofCourierAddrs(
+						randFallbackCourierAddrs(
+							t, 3,
+						)...,
+					),
+					WithExpiry(expiry),
+				)
+				return addr, "", err
+			},
+			err: nil,
+		},
+		{
+			name: "v2 reusable address",
+			f: func() (*Tap, string, error) {
+				addr, err := randAddress(
+					t, &TestNet3Tap, fn.Ptr(V2), false,
+					false, nil, asset.Normal,
+					WithReusable(),
+				)
+				return addr, "", err
+			},
+			err: nil,
+		},
+		{
+			name: "v1 address with v2 fields",
+			f: func() (*Tap, string, error) {
+				newAddr, err := randAddress(
+					t, &TestNet3Tap, fn.Ptr(V1), false,
+					false, nil, asset.Normal,
+				)
+				require.NoError(t, err)
+
+				// Patch the V2 fields into the address, which
+				// we don't expect to fail when encoding.
+				newAddr.FallbackProofCourierAddrs =
+					randFallbackCourierAddrs(t, 1)
+				newAddr.Expiry = time.Unix(1893456000, 0)
+				encodedAddr, err := newAddr.EncodeAddress()
+				require.NoError(t, err)
+
+				// We expect an error to occur here when
+				// decoding.
+				_, err = DecodeAddress(
+					encodedAddr, &TestNet3Tap,
+				)
+				return newAddr, "", err
+			},
+			err: ErrFieldRequiresV2,
+		},
 		{
 			name: "unsupported hrp",
 			f: func() (*Tap, string, error) {
@@ -639,13 +801,6 @@ func runBIPTestVector(t *testing.T, testVectors *TestVectors) {
 	}
 }
 
-func FuzzAddressDecode(f *testing.F) {
-	f.Fuzz(func(t *testing.T, data []byte) {
-		a := &Tap{}
-		_ = a.Decode(bytes.NewReader(data))
-	})
-}
-
 // TestAddressUnknownOddType tests that an unknown odd type is allowed in an
 // address and that we can still arrive at the correct leaf hash with it.
 func TestAddressUnknownOddType(t *testing.T) {
@@ -688,3 +843,125 @@ func TestAddressUnknownOddType(t *testing.T) {
 		},
 	)
 }
+
+// TestAddressExpiry tests the expiry and the proof courier addresses of V2
+// addresses.
+func TestAddressExpiry(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Now().Add(time.Hour)
+	fallbackAddrs := randFallbackCourierAddrs(t, 2)
+	addr, err := randAddress(
+		t, &TestNet3Tap, fn.Ptr(V2), false, false, nil, asset.Normal,
+		WithFallbackProofCourierAddrs(fallbackAddrs...),
+		WithExpiry(expiry),
+	)
+	require.NoError(t, err)
+
+	// The expiry is truncated to full seconds.
+	require.Equal(t, expiry.Unix(), addr.Expiry.Unix())
+	require.Zero(t, addr.Expiry.Nanosecond())
+
+	require.False(t, addr.IsExpired(time.Now()))
+	require.True(t, addr.IsExpired(expiry.Add(time.Second)))
+
+	// The main proof courier always comes first.
+	require.Equal(
+		t, append([]url.URL{addr.ProofCourierAddr}, fallbackAddrs...),
+		addr.ProofCourierAddrs(),
+	)
+
+	// An address without an expiry never expires.
+	addr, err = randAddress(
+		t, &TestNet3Tap, fn.Ptr(V2), false, false, nil, asset.Normal,
+	)
+	require.NoError(t, err)
+	require.False(t, addr.IsExpired(time.Now().Add(100*365*24*time.Hour)))
+	require.Equal(
+		t, []url.URL{addr.ProofCourierAddr}, addr.ProofCourierAddrs(),
+	)
+}
+
+// TestReusableAddress tests the derivation of the per-deposit script keys and
+// addresses of reusable addresses.
+func TestReusableAddress(t *testing.T) {
+	t.Parallel()
+
+	rawKey := test.RandPubKey(t)
+	addr, err := New(
+		V2, asset.RandGenesis(t, asset.Normal), nil, nil, *rawKey,
+		*test.RandPubKey(t), 100, nil, &TestNet3Tap,
+		RandProofCourierAddr(t), WithReusable(),
+	)
+	require.NoError(t, err)
+	require.True(t, addr.Reusable)
+
+	prevOut := wire.OutPoint{Hash: test.RandHash(), Index: 1}
+	deposit, err := addr.DepositAddr(prevOut, 2)
+	require.NoError(t, err)
+
+	// A deposit address isn't reusable itself and only differs in its
+	// script key.
+	require.False(t, deposit.Reusable)
+	require.Equal(t, addr.Amount, deposit.Amount)
+	require.Equal(t, addr.InternalKey, deposit.InternalKey)
+	require.False(t, deposit.ScriptKey.IsEqual(&addr.ScriptKey))
+
+	// The derivation is deterministic, so the sender and the receiver
+	// arrive at the same script key.
+	require.True(t, deposit.ScriptKey.IsEqual(DeriveReusableScriptKey(
+		&addr.ScriptKey, prevOut, 2,
+	)))
+	require.True(t, addr.IsDepositScriptKey(
+		&deposit.ScriptKey, []wire.OutPoint{{}, prevOut}, 2,
+	))
+	require.False(t, addr.IsDepositScriptKey(
+		&deposit.ScriptKey, []wire.OutPoint{prevOut}, 1,
+	))
+
+	// Each part of the nonce results in a different script key and
+	// therefore in a different on-chain output key.
+	depositKey, err := deposit.TaprootOutputKey()
+	require.NoError(t, err)
+	for _, other := range []struct {
+		prevOut  wire.OutPoint
+		outIndex uint32
+	}{
+		{prevOut: wire.OutPoint{Hash: prevOut.Hash}, outIndex: 2},
+		{prevOut: wire.OutPoint{Index: 1}, outIndex: 2},
+		{prevOut: prevOut, outIndex: 3},
+	} {
+		otherDeposit, err := addr.DepositAddr(
+			other.prevOut, other.outIndex,
+		)
+		require.NoError(t, err)
+
+		otherKey, err := otherDeposit.TaprootOutputKey()
+		require.NoError(t, err)
+		require.False(t, depositKey.IsEqual(otherKey))
+	}
+
+	// The deposit script key can be signed for with the raw key and the
+	// tweak only.
+	addrWithKeys := &AddrWithKeyInfo{
+		Tap: addr,
+		ScriptKeyTweak: asset.TweakedScriptKey{
+			RawKey: keychain.KeyDescriptor{
+				PubKey: rawKey,
+			},
+		},
+	}
+	scriptKey, err := addrWithKeys.DepositScriptKey(prevOut, 2)
+	require.NoError(t, err)
+	require.True(t, deposit.ScriptKey.IsEqual(scriptKey.PubKey))
+	require.Equal(
+		t, schnorr.SerializePubKey(scriptKey.PubKey),
+		schnorr.SerializePubKey(txscript.ComputeTaprootOutputKey(
+			rawKey, scriptKey.Tweak,
+		)),
+	)
+
+	// A regular address can't be used to derive deposits.
+	_, err = deposit.DepositAddr(prevOut, 2)
+	require.ErrorIs(t, err, ErrNotReusable)
+}
@@ -126,6 +126,10 @@ type Storage interface {
 	// this key in the asset list and balances).
 	InsertScriptKey(ctx context.Context, scriptKey asset.ScriptKey,
 		declareAsKnown bool) error
+
+	// ScriptKeyReserver makes sure script keys used for addresses aren't
+	// also used by any other subsystem of the daemon.
+	ScriptKeyReserver
 }
 
 // KeyRing is used to create script and internal keys for Taproot Asset
@@ -267,7 +271,16 @@ func (b *Book) NewAddress(ctx context.Context, addrVersion Version,
 			"asset %x: %w", assetID[:], err)
 	}
 
-	rawScriptKeyDesc, err := b.cfg.KeyRing.DeriveNextTaprootAssetKey(ctx)
+	// The keys are derived from the key family given in the options,
+	// which defaults to the Taproot Assets key family.
+	options := defaultNewAddrOptions()
+	for _, opt := range addrOpts {
+		opt(options)
+	}
+
+	rawScriptKeyDesc, err := b.cfg.KeyRing.DeriveNextKey(
+		ctx, options.keyFamily,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to gen key: %w", err)
 	}
@@ -277,7 +290,21 @@ func (b *Book) NewAddress(ctx context.Context, addrVersion Version,
 	// used with a plain key spend.
 	scriptKey := asset.NewScriptKeyBip86(rawScriptKeyDesc)
 
-	internalKeyDesc, err := b.cfg.KeyRing.DeriveNextTaprootAssetKey(ctx)
+	// A reusable address carries the raw key instead, as the sender tweaks
+	// it with a per-deposit tweak to derive the actual script key of each
+	// deposit.
+	if options.reusable {
+		scriptKey = asset.ScriptKey{
+			PubKey: rawScriptKeyDesc.PubKey,
+			TweakedScriptKey: &asset.TweakedScriptKey{
+				RawKey: rawScriptKeyDesc,
+			},
+		}
+	}
+
+	internalKeyDesc, err := b.cfg.KeyRing.DeriveNextKey(
+		ctx, options.keyFamily,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to gen key: %w", err)
 	}
@@ -331,6 +358,15 @@ func (b *Book) NewAddressWithKeys(ctx context.Context, addrVersion Version,
 			" %w", err)
 	}
 
+	// Make sure the script key isn't already in use by another subsystem,
+	// as we'd otherwise not be able to tell the received assets apart.
+	err = b.cfg.Store.ReserveScriptKey(
+		ctx, scriptKey.PubKey, ScriptKeyOwnerAddress,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reserve script key: %w", err)
+	}
+
 	// We also want to import the two keys, so we can identify them as
 	// belonging to the wallet later on.
 	err = b.cfg.Store.InsertInternalKey(ctx, internalKeyDesc)
@@ -1,8 +1,11 @@
 package address
 
 import (
+	"fmt"
 	"io"
+	"math"
 	"net/url"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightningnetwork/lnd/tlv"
@@ -71,6 +74,134 @@ func UrlDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
 	return tlv.NewTypeForDecodingErr(val, "*url.URL", l, l)
 }
 
+// UrlSliceEncoder encodes a slice of url.URL as a BigSize count followed by
+// each URL as a variable length byte slice.
+func UrlSliceEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*[]url.URL); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+
+		for idx := range *t {
+			addrBytes := []byte((*t)[idx].String())
+			err := tlv.WriteVarInt(w, uint64(len(addrBytes)), buf)
+			if err != nil {
+				return err
+			}
+			err = tlv.EVarBytes(w, &addrBytes, buf)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+	return tlv.NewTypeForEncodingErr(val, "*[]url.URL")
+}
+
+// UrlSliceDecoder decodes a slice of url.URL that was encoded with
+// UrlSliceEncoder.
+func UrlSliceDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
+	if t, ok := val.(*[]url.URL); ok {
+		// We limit the reader to the length of the record, so we can't
+		// be tricked into allocating more than that.
+		lr := io.LimitReader(r, int64(l))
+
+		numAddrs, err := tlv.ReadVarInt(lr, buf)
+		if err != nil {
+			return err
+		}
+		if numAddrs > MaxFallbackProofCourierAddrs {
+			return ErrTooManyFallbackCouriers
+		}
+
+		addrs := make([]url.URL, 0, numAddrs)
+		for i := uint64(0); i < numAddrs; i++ {
+			addrLen, err := tlv.ReadVarInt(lr, buf)
+			if err != nil {
+				return err
+			}
+			if addrLen > l {
+				return fmt.Errorf("proof courier address "+
+					"length %d exceeds record length %d",
+					addrLen, l)
+			}
+
+			var addrBytes []byte
+			err = tlv.DVarBytes(lr, &addrBytes, buf, addrLen)
+			if err != nil {
+				return err
+			}
+
+			addr, err := url.ParseRequestURI(string(addrBytes))
+			if err != nil {
+				return err
+			}
+			addrs = append(addrs, *addr)
+		}
+
+		*t = addrs
+
+		return nil
+	}
+	return tlv.NewTypeForDecodingErr(val, "*[]url.URL", l, l)
+}
+
+// UnixTimeEncoder encodes a time.Time as a Unix timestamp in seconds.
+func UnixTimeEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*time.Time); ok {
+		unixTime := uint64(t.Unix())
+		return tlv.EUint64T(w, unixTime, buf)
+	}
+	return tlv.NewTypeForEncodingErr(val, "*time.Time")
+}
+
+// UnixTimeDecoder decodes a Unix timestamp in seconds as a time.Time.
+func UnixTimeDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
+	if t, ok := val.(*time.Time); ok {
+		var unixTime uint64
+		if err := tlv.DUint64(r, &unixTime, buf, l); err != nil {
+			return err
+		}
+		if unixTime > math.MaxInt64 {
+			return fmt.Errorf("invalid unix timestamp %d",
+				unixTime)
+		}
+
+		*t = time.Unix(int64(unixTime), 0).UTC()
+
+		return nil
+	}
+	return tlv.NewTypeForDecodingErr(val, "*time.Time", l, 8)
+}
+
+// BoolEncoder encodes a boolean as a single byte.
+func BoolEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*bool); ok {
+		var intVal uint8
+		if *t {
+			intVal = 1
+		}
+
+		return tlv.EUint8(w, &intVal, buf)
+	}
+	return tlv.NewTypeForEncodingErr(val, "*bool")
+}
+
+// BoolDecoder decodes a boolean from a single byte.
+func BoolDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
+	if t, ok := val.(*bool); ok {
+		var intVal uint8
+		if err := tlv.DUint8(r, &intVal, buf, l); err != nil {
+			return err
+		}
+
+		*t = intVal == 1
+		return nil
+	}
+	return tlv.NewTypeForDecodingErr(val, "*bool", l, 1)
+}
+
 func VersionEncoder(w io.Writer, val any, buf *[8]byte) error {
 	if t, ok := val.(*Version); ok {
 		return tlv.EUint8T(w, uint8(*t), buf)
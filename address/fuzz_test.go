@@ -0,0 +1,60 @@
+package address
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// validTestVectorAddrs returns the bech32m encoded addresses of all valid test
+// vectors.
+func validTestVectorAddrs(f *testing.F) []string {
+	testVectors := &TestVectors{}
+	test.ParseTestVectors(f, generatedTestVectorName, &testVectors)
+
+	addrs := make([]string, 0, len(testVectors.ValidTestCases))
+	for _, validCase := range testVectors.ValidTestCases {
+		addrs = append(addrs, validCase.Expected)
+	}
+
+	return addrs
+}
+
+func FuzzAddressDecode(f *testing.F) {
+	// We seed the corpus with the raw TLV bytes of the test vector
+	// addresses.
+	for _, addr := range validTestVectorAddrs(f) {
+		_, data, err := bech32.DecodeNoLimit(addr)
+		require.NoError(f, err)
+
+		converted, err := bech32.ConvertBits(data, 5, 8, false)
+		require.NoError(f, err)
+
+		f.Add(converted)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		a := &Tap{}
+		_ = a.Decode(bytes.NewReader(data))
+	})
+}
+
+func FuzzDecodeAddressString(f *testing.F) {
+	for _, addr := range validTestVectorAddrs(f) {
+		f.Add(addr)
+	}
+
+	f.Fuzz(func(t *testing.T, addr string) {
+		// We don't know which network the fuzzed address is for, so we
+		// try all of them.
+		for _, net := range []*ChainParams{
+			&MainNetTap, &TestNet3Tap, &RegressionNetTap,
+			&SigNetTap, &SimNetTap,
+		} {
+			_, _ = DecodeAddress(addr, net)
+		}
+	})
+}
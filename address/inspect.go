@@ -0,0 +1,111 @@
+package address
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+)
+
+// AddrInspection is the result of inspecting an encoded Taproot Asset address.
+type AddrInspection struct {
+	// Addr is the decoded address.
+	Addr *Tap
+
+	// Net is the network the address was encoded for, which might not be
+	// the network the address book is active on.
+	Net *ChainParams
+
+	// AssetGroup holds the genesis and group information of the asset
+	// the address is for. This is nil if the asset is neither known
+	// locally nor could be found in the universe servers of our
+	// federation.
+	AssetGroup *asset.AssetGroup
+
+	// Warnings is a list of human-readable warnings about problems with
+	// the address that don't prevent it from being decoded.
+	Warnings []string
+}
+
+// addWarning adds a formatted human-readable warning to the inspection.
+func (i *AddrInspection) addWarning(format string, args ...any) {
+	i.Warnings = append(i.Warnings, fmt.Sprintf(format, args...))
+}
+
+// InspectAddr fully decodes the given address string, even if it was encoded
+// for a different network than the address book is active on. If the asset of
+// the address isn't known locally, we attempt to fetch its genesis and group
+// information from the universe servers of our federation. Any problems that
+// would prevent the address from being used are reported as warnings instead
+// of an error.
+func (b *Book) InspectAddr(ctx context.Context,
+	encoded string) (*AddrInspection, error) {
+
+	oneIndex := strings.LastIndexByte(encoded, '1')
+	if oneIndex <= 0 {
+		return nil, ErrInvalidBech32m
+	}
+
+	// We decode the address for the network its HRP belongs to, so we
+	// can still show its content if it's for a different network.
+	hrp := strings.ToLower(encoded[:oneIndex])
+	net := &b.cfg.Chain
+	if !IsForNet(hrp, net) {
+		var err error
+		net, err = Net(hrp)
+		if err != nil {
+			return nil, ErrUnsupportedHRP
+		}
+	}
+
+	addr, err := DecodeAddress(encoded, net)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &AddrInspection{
+		Addr: addr,
+		Net:  net,
+	}
+
+	if net != &b.cfg.Chain {
+		inspection.addWarning("address is for network %s, but this "+
+			"node is on network %s", net.Name, b.cfg.Chain.Name)
+	}
+
+	if addr.IsExpired(time.Now()) {
+		inspection.addWarning("address expired at %v",
+			addr.Expiry.UTC())
+	}
+
+	assetGroup, err := b.QueryAssetInfo(ctx, addr.AssetID)
+	if err != nil {
+		inspection.addWarning("asset %v is unknown: %v", addr.AssetID,
+			err)
+
+		return inspection, nil
+	}
+
+	inspection.AssetGroup = assetGroup
+	addr.AttachGenesis(*assetGroup.Genesis)
+
+	// The group key of the address must match the group the asset
+	// belongs to, otherwise the sender won't be able to create a valid
+	// transfer.
+	var groupKeyMatches bool
+	switch {
+	case assetGroup.GroupKey == nil:
+		groupKeyMatches = addr.GroupKey == nil
+
+	case addr.GroupKey != nil:
+		groupKeyMatches = assetGroup.GroupPubKey.IsEqual(addr.GroupKey)
+	}
+	if !groupKeyMatches {
+		inspection.addWarning("group key of address doesn't match "+
+			"group of asset %v", addr.AssetID)
+	}
+
+	return inspection, nil
+}
@@ -79,13 +79,25 @@ func RandAddr(t testing.TB, params *ChainParams,
 		require.NoError(t, err)
 	}
 
-	addrVersion = test.RandFlip(V0, V1)
+	addrVersion = Version(test.RandIntn(int(latestVersion) + 1))
+
+	addrOpts := []NewAddrOpt{WithAssetVersion(assetVersion)}
+	if addrVersion >= V2 && test.RandBool() {
+		fallbackAddr, err := url.ParseRequestURI(
+			"universerpc://rand.universe.proof.courier:10029",
+		)
+		require.NoError(t, err)
+
+		addrOpts = append(
+			addrOpts, WithFallbackProofCourierAddrs(*fallbackAddr),
+			WithExpiry(time.Now().Add(time.Hour)),
+		)
+	}
 
 	tapAddr, err := New(
 		addrVersion, genesis, groupPubKey, groupWitness,
 		*scriptKey.PubKey, *internalKey.PubKey(), amount,
-		tapscriptSibling, params, proofCourierAddr,
-		WithAssetVersion(assetVersion),
+		tapscriptSibling, params, proofCourierAddr, addrOpts...,
 	)
 	require.NoError(t, err)
 
@@ -143,6 +155,18 @@ func NewTestFromAddress(t testing.TB, a *Tap) *TestAddress {
 		ta.GroupKey = test.HexPubKey(a.GroupKey)
 	}
 
+	for _, courierAddr := range a.FallbackProofCourierAddrs {
+		ta.FallbackProofCourierAddrs = append(
+			ta.FallbackProofCourierAddrs, courierAddr.String(),
+		)
+	}
+
+	if !a.Expiry.IsZero() {
+		ta.Expiry = uint64(a.Expiry.Unix())
+	}
+
+	ta.Reusable = a.Reusable
+
 	if a.TapscriptSibling != nil {
 		ta.TapscriptSibling = commitment.HexTapscriptSibling(
 			t, a.TapscriptSibling,
@@ -153,17 +177,20 @@ func NewTestFromAddress(t testing.TB, a *Tap) *TestAddress {
 }
 
 type TestAddress struct {
-	Version          uint8       `json:"version"`
-	ChainParamsHRP   string      `json:"chain_params_hrp"`
-	AssetVersion     uint8       `json:"asset_version"`
-	AssetID          string      `json:"asset_id"`
-	GroupKey         string      `json:"group_key"`
-	ScriptKey        string      `json:"script_key"`
-	InternalKey      string      `json:"internal_key"`
-	TapscriptSibling string      `json:"tapscript_sibling"`
-	Amount           uint64      `json:"amount"`
-	ProofCourierAddr string      `json:"proof_courier_addr"`
-	UnknownOddTypes  tlv.TypeMap `json:"unknown_odd_types"`
+	Version                   uint8       `json:"version"`
+	ChainParamsHRP            string      `json:"chain_params_hrp"`
+	AssetVersion              uint8       `json:"asset_version"`
+	AssetID                   string      `json:"asset_id"`
+	GroupKey                  string      `json:"group_key"`
+	ScriptKey                 string      `json:"script_key"`
+	InternalKey               string      `json:"internal_key"`
+	TapscriptSibling          string      `json:"tapscript_sibling"`
+	Amount                    uint64      `json:"amount"`
+	ProofCourierAddr          string      `json:"proof_courier_addr"`
+	UnknownOddTypes           tlv.TypeMap `json:"unknown_odd_types"`
+	FallbackProofCourierAddrs []string    `json:"fallback_proof_courier_addrs,omitempty"`
+	Expiry                    uint64      `json:"expiry,omitempty"`
+	Reusable                  bool        `json:"reusable,omitempty"`
 }
 
 func (ta *TestAddress) ToAddress(t testing.TB) *Tap {
@@ -234,5 +261,22 @@ func (ta *TestAddress) ToAddress(t testing.TB) *Tap {
 		)
 	}
 
+	for _, addrStr := range ta.FallbackProofCourierAddrs {
+		courierAddr, err := url.ParseRequestURI(addrStr)
+		if err != nil {
+			panic(err)
+		}
+
+		a.FallbackProofCourierAddrs = append(
+			a.FallbackProofCourierAddrs, *courierAddr,
+		)
+	}
+
+	if ta.Expiry != 0 {
+		a.Expiry = time.Unix(int64(ta.Expiry), 0).UTC()
+	}
+
+	a.Reusable = ta.Reusable
+
 	return a
 }
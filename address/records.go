@@ -3,6 +3,7 @@ package address
 import (
 	"bytes"
 	"net/url"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightninglabs/taproot-assets/asset"
@@ -42,6 +43,19 @@ const (
 
 	// addrProofCourierType is the TLV type of the proof courier address.
 	addrProofCourierAddrType addressTLVType = 12
+
+	// addrFallbackProofCourierAddrsType is the TLV type of the ordered list
+	// of fallback proof courier addresses.
+	addrFallbackProofCourierAddrsType addressTLVType = 14
+
+	// addrExpiryType is the TLV type of the expiry timestamp.
+	addrExpiryType addressTLVType = 16
+
+	// addrReusableType is the TLV type of the flag that marks an address
+	// as reusable. This is an even type, so implementations that don't
+	// know how to derive the per-deposit script keys refuse to decode
+	// the address instead of sending to the raw base key.
+	addrReusableType addressTLVType = 18
 )
 
 // KnownAddressTypes is a set of all known address TLV types. This set is
@@ -50,6 +64,7 @@ var KnownAddressTypes = fn.NewSet(
 	addrVersionType, addrAssetVersionType, addrAssetIDType,
 	addrGroupKeyType, addrScriptKeyType, addrInternalKeyType,
 	addrTapscriptSiblingType, addrAmountType, addrProofCourierAddrType,
+	addrFallbackProofCourierAddrsType, addrExpiryType, addrReusableType,
 )
 
 func newAddressVersionRecord(version *Version) tlv.Record {
@@ -134,3 +149,33 @@ func newProofCourierAddrRecord(addr *url.URL) tlv.Record {
 		UrlEncoder, UrlDecoder,
 	)
 }
+
+func newFallbackProofCourierAddrsRecord(addrs *[]url.URL) tlv.Record {
+	recordSize := func() uint64 {
+		var (
+			b   bytes.Buffer
+			buf [8]byte
+		)
+		if err := UrlSliceEncoder(&b, addrs, &buf); err != nil {
+			panic(err)
+		}
+		return uint64(len(b.Bytes()))
+	}
+
+	return tlv.MakeDynamicRecord(
+		addrFallbackProofCourierAddrsType, addrs, recordSize,
+		UrlSliceEncoder, UrlSliceDecoder,
+	)
+}
+
+func newAddressExpiryRecord(expiry *time.Time) tlv.Record {
+	return tlv.MakeStaticRecord(
+		addrExpiryType, expiry, 8, UnixTimeEncoder, UnixTimeDecoder,
+	)
+}
+
+func newAddressReusableRecord(reusable *bool) tlv.Record {
+	return tlv.MakeStaticRecord(
+		addrReusableType, reusable, 1, BoolEncoder, BoolDecoder,
+	)
+}
@@ -0,0 +1,134 @@
+package address
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+)
+
+var (
+	// ErrNotReusable is returned when attempting to derive a deposit from
+	// an address that isn't reusable.
+	ErrNotReusable = errors.New("address: not a reusable address")
+
+	// reusableTweakTag is the tag of the tagged hash that is used to
+	// derive the per-deposit script key tweak of a reusable address.
+	reusableTweakTag = []byte("taproot-assets/reusable-addr")
+)
+
+// ReusableScriptKeyTweak returns the tweak that is applied to the base script
+// key of a reusable address to derive the script key of a single deposit. The
+// per-transfer nonce the tweak commits to consists of the outpoint of an asset
+// input spent by the transfer and the index of the anchor output that carries
+// the deposit. Because an outpoint can only be spent once, the nonce is unique
+// for each deposit. And because both parts of the nonce are visible on chain,
+// the receiver can find its deposits by scanning transactions for outputs that
+// commit to a script key derived this way.
+func ReusableScriptKeyTweak(baseKey *btcec.PublicKey, prevOut wire.OutPoint,
+	anchorOutputIndex uint32) []byte {
+
+	var nonce [chainhash.HashSize + 8]byte
+	copy(nonce[:chainhash.HashSize], prevOut.Hash[:])
+	binary.BigEndian.PutUint32(nonce[chainhash.HashSize:], prevOut.Index)
+	binary.BigEndian.PutUint32(
+		nonce[chainhash.HashSize+4:], anchorOutputIndex,
+	)
+
+	tweak := chainhash.TaggedHash(
+		reusableTweakTag, schnorr.SerializePubKey(baseKey), nonce[:],
+	)
+
+	return tweak[:]
+}
+
+// DeriveReusableScriptKey derives the script key of a single deposit to a
+// reusable address with the given base script key. The base key is tweaked
+// like a Taproot internal key, with the per-deposit tweak taking the place of
+// the script root. This allows the receiver to sign for the deposit with the
+// raw base key and the tweak only.
+func DeriveReusableScriptKey(baseKey *btcec.PublicKey, prevOut wire.OutPoint,
+	anchorOutputIndex uint32) *btcec.PublicKey {
+
+	tweak := ReusableScriptKeyTweak(baseKey, prevOut, anchorOutputIndex)
+	scriptKey := asset.NewScriptKey(
+		txscript.ComputeTaprootOutputKey(baseKey, tweak),
+	)
+
+	return scriptKey.PubKey
+}
+
+// DepositAddr returns the address of a single deposit to a reusable address,
+// made by a transfer that spends the given asset input outpoint and anchors
+// the deposit in the given output index. The returned address has the derived
+// script key of the deposit and isn't reusable itself.
+func (a *Tap) DepositAddr(prevOut wire.OutPoint,
+	anchorOutputIndex uint32) (*Tap, error) {
+
+	if !a.Reusable {
+		return nil, ErrNotReusable
+	}
+
+	depositAddr := a.Copy()
+	depositAddr.ScriptKey = *DeriveReusableScriptKey(
+		&a.ScriptKey, prevOut, anchorOutputIndex,
+	)
+	depositAddr.Reusable = false
+
+	return depositAddr, nil
+}
+
+// DepositScriptKey returns the script key of a single deposit to a reusable
+// address, made by a transfer that spends the given asset input outpoint and
+// anchors the deposit in the given output index. The returned script key
+// carries the raw base key and the per-deposit tweak, so it can be used for
+// signing.
+func (a *AddrWithKeyInfo) DepositScriptKey(prevOut wire.OutPoint,
+	anchorOutputIndex uint32) (asset.ScriptKey, error) {
+
+	if !a.Reusable {
+		return asset.ScriptKey{}, ErrNotReusable
+	}
+
+	return asset.ScriptKey{
+		PubKey: DeriveReusableScriptKey(
+			&a.ScriptKey, prevOut, anchorOutputIndex,
+		),
+		TweakedScriptKey: &asset.TweakedScriptKey{
+			RawKey: a.ScriptKeyTweak.RawKey,
+			Tweak: ReusableScriptKeyTweak(
+				&a.ScriptKey, prevOut, anchorOutputIndex,
+			),
+		},
+	}, nil
+}
+
+// IsDepositScriptKey returns true if the given script key is the script key of
+// a deposit to the reusable address, made by a transfer that spends any of the
+// given asset input outpoints and anchors the deposit in the given output
+// index.
+func (a *Tap) IsDepositScriptKey(scriptKey *btcec.PublicKey,
+	prevOuts []wire.OutPoint, anchorOutputIndex uint32) bool {
+
+	if !a.Reusable || scriptKey == nil {
+		return false
+	}
+
+	targetKey := schnorr.SerializePubKey(scriptKey)
+	for _, prevOut := range prevOuts {
+		depositKey := DeriveReusableScriptKey(
+			&a.ScriptKey, prevOut, anchorOutputIndex,
+		)
+		if bytes.Equal(schnorr.SerializePubKey(depositKey), targetKey) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+var (
+	// ErrScriptKeyReserved is returned when a subsystem attempts to
+	// reserve a script key that was already reserved by a different
+	// subsystem.
+	ErrScriptKeyReserved = errors.New("script key already reserved by " +
+		"another subsystem")
+)
+
+// ScriptKeyOwner denotes the subsystem of the daemon that claimed a script
+// key. A script key must only ever be used by a single subsystem, otherwise
+// assets could be attributed to the wrong owner (e.g. an asset sent to an
+// address could show up as channel funds or vice versa).
+type ScriptKeyOwner uint8
+
+const (
+	// ScriptKeyOwnerAddress indicates that the script key is used by a
+	// Taproot Asset address to receive assets.
+	ScriptKeyOwnerAddress ScriptKeyOwner = 0
+
+	// ScriptKeyOwnerChannelFunding indicates that the script key is used
+	// by the asset level funding output of an asset channel.
+	ScriptKeyOwnerChannelFunding ScriptKeyOwner = 1
+
+	// ScriptKeyOwnerMint indicates that the script key is used by an asset
+	// that is being minted.
+	ScriptKeyOwnerMint ScriptKeyOwner = 2
+)
+
+// String returns a human-readable representation of the script key owner.
+func (o ScriptKeyOwner) String() string {
+	switch o {
+	case ScriptKeyOwnerAddress:
+		return "address"
+
+	case ScriptKeyOwnerChannelFunding:
+		return "channel_funding"
+
+	case ScriptKeyOwnerMint:
+		return "mint"
+
+	default:
+		return fmt.Sprintf("unknown <%d>", o)
+	}
+}
+
+// ScriptKeyReserver is used to make sure a script key is only ever used by a
+// single subsystem of the daemon.
+type ScriptKeyReserver interface {
+	// ReserveScriptKey reserves the given tweaked script key for the given
+	// owner. Reserving a key that is already reserved by the same owner is
+	// a no-op. If the key is already reserved by a different owner,
+	// ErrScriptKeyReserved is returned.
+	ReserveScriptKey(ctx context.Context, scriptKey *btcec.PublicKey,
+		owner ScriptKeyOwner) error
+}
@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity is the severity level of an alert.
+type Severity uint8
+
+const (
+	// SeverityInfo is the severity of alerts that are purely
+	// informational.
+	SeverityInfo Severity = iota
+
+	// SeverityWarning is the severity of alerts that might need attention,
+	// but don't indicate an immediate risk to funds.
+	SeverityWarning
+
+	// SeverityCritical is the severity of alerts that indicate a possible
+	// loss of funds and need immediate attention.
+	SeverityCritical
+)
+
+// String returns the human-readable name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+
+	case SeverityWarning:
+		return "warning"
+
+	case SeverityCritical:
+		return "critical"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// ParseSeverity parses the human-readable name of a severity.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return SeverityInfo, nil
+
+	case "warning":
+		return SeverityWarning, nil
+
+	case "critical":
+		return SeverityCritical, nil
+
+	default:
+		return 0, fmt.Errorf("unknown alert severity: %s", s)
+	}
+}
+
+// Type is the type of event an alert was triggered by.
+type Type string
+
+const (
+	// TypeUnexpectedAnchorSpend is the type of alerts that are triggered
+	// by an on-chain spend of an anchor output holding our assets that
+	// wasn't made by the daemon itself.
+	TypeUnexpectedAnchorSpend Type = "unexpected_anchor_spend"
+
+	// TypeProofVerificationFailure is the type of alerts that are
+	// triggered by a proof for an asset sent to us that failed to verify.
+	TypeProofVerificationFailure Type = "proof_verification_failure"
+
+	// TypeUniverseEquivocation is the type of alerts that are triggered by
+	// two conflicting proofs for the same universe leaf.
+	TypeUniverseEquivocation Type = "universe_equivocation"
+
+	// TypeOracleDeviation is the type of alerts that are triggered by a
+	// peer quoting an asset rate that deviates too far from the price
+	// oracle's rate.
+	TypeOracleDeviation Type = "oracle_price_deviation"
+)
+
+// Alert is a single security-relevant event that should be brought to the
+// attention of the node operator.
+type Alert struct {
+	// Type is the type of event that triggered the alert.
+	Type Type `json:"type"`
+
+	// Severity is the severity level of the alert.
+	Severity Severity `json:"-"`
+
+	// Message is the human-readable description of the event.
+	Message string `json:"message"`
+
+	// DedupKey identifies alerts about the same underlying issue. Only the
+	// first of multiple alerts with the same type and key is delivered
+	// within the configured deduplication interval. If empty, the message
+	// is used as the key.
+	DedupKey string `json:"dedup_key,omitempty"`
+
+	// Timestamp is the time the alert was raised at.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// dedupKey returns the key used to deduplicate the alert.
+func (a *Alert) dedupKey() string {
+	key := a.DedupKey
+	if key == "" {
+		key = a.Message
+	}
+
+	return string(a.Type) + ":" + key
+}
+
+// Sender is the interface subsystems use to raise alerts.
+type Sender interface {
+	// SendAlert raises the given alert. This method must not block.
+	SendAlert(alert Alert)
+}
+
+// SendIfSet raises the given alert using the sender, if the sender is set.
+func SendIfSet(sender Sender, alert Alert) {
+	if sender == nil {
+		return
+	}
+
+	sender.SendAlert(alert)
+}
@@ -0,0 +1,109 @@
+package alert
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// DefaultMinSeverity is the default minimum severity of alerts that
+	// are delivered.
+	DefaultMinSeverity = "warning"
+
+	// DefaultDedupInterval is the default interval within which duplicate
+	// alerts are suppressed.
+	DefaultDedupInterval = time.Hour
+
+	// DefaultNotifyTimeout is the default maximum time a single notifier
+	// can take to deliver an alert.
+	DefaultNotifyTimeout = 30 * time.Second
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// alerting subsystem.
+//
+// nolint: lll
+type CliConfig struct {
+	WebhookURL string `long:"webhook-url" description:"The URL that alerts are sent to as a JSON encoded HTTP POST request"`
+
+	Command string `long:"command" description:"A shell command that is executed for every alert, for example to send an email; the JSON encoded alert is passed on stdin and in the TAPD_ALERT_TYPE, TAPD_ALERT_SEVERITY and TAPD_ALERT_MESSAGE environment variables"`
+
+	MinSeverity string `long:"min-severity" description:"The minimum severity of alerts that are delivered" choice:"info" choice:"warning" choice:"critical"`
+
+	DedupInterval time.Duration `long:"dedup-interval" description:"The interval within which only the first of multiple alerts about the same event is delivered"`
+
+	Timeout time.Duration `long:"timeout" description:"The maximum time the webhook or command can take to deliver a single alert"`
+}
+
+// DefaultCliConfig returns the default alerting configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		MinSeverity:   DefaultMinSeverity,
+		DedupInterval: DefaultDedupInterval,
+		Timeout:       DefaultNotifyTimeout,
+	}
+}
+
+// Enabled returns true if at least one alert destination is configured.
+func (c *CliConfig) Enabled() bool {
+	return c.WebhookURL != "" || c.Command != ""
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if c.WebhookURL != "" {
+		u, err := url.Parse(c.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid alert webhook URL: %w", err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("alert webhook URL must use http or " +
+				"https")
+		}
+	}
+
+	if _, err := ParseSeverity(c.MinSeverity); err != nil {
+		return err
+	}
+
+	if c.DedupInterval < 0 {
+		return fmt.Errorf("alert dedup interval must not be negative")
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("alert timeout must be positive")
+	}
+
+	return nil
+}
+
+// NewManagerFromConfig creates an alert manager with the notifiers described
+// by the given configuration.
+func NewManagerFromConfig(cfg *CliConfig) (*Manager, error) {
+	minSeverity, err := ParseSeverity(cfg.MinSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifiers []Notifier
+	if cfg.WebhookURL != "" {
+		notifiers = append(
+			notifiers, NewWebhookNotifier(cfg.WebhookURL),
+		)
+	}
+	if cfg.Command != "" {
+		notifiers = append(notifiers, NewCommandNotifier(cfg.Command))
+	}
+
+	return NewManager(ManagerConfig{
+		Notifiers:     notifiers,
+		MinSeverity:   minSeverity,
+		DedupInterval: cfg.DedupInterval,
+		NotifyTimeout: cfg.Timeout,
+		Clock:         clock.NewDefaultClock(),
+	}), nil
+}
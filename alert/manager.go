@@ -0,0 +1,186 @@
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// alertQueueSize is the number of alerts that can be queued for
+	// delivery before new alerts are dropped.
+	alertQueueSize = 100
+)
+
+// ManagerConfig is the configuration of the alert manager.
+type ManagerConfig struct {
+	// Notifiers is the set of destinations alerts are delivered to.
+	Notifiers []Notifier
+
+	// MinSeverity is the minimum severity an alert must have to be
+	// delivered.
+	MinSeverity Severity
+
+	// DedupInterval is the interval within which only the first of
+	// multiple alerts with the same deduplication key is delivered.
+	DedupInterval time.Duration
+
+	// NotifyTimeout is the maximum time a single notifier can take to
+	// deliver an alert.
+	NotifyTimeout time.Duration
+
+	// Clock is the clock used to timestamp and deduplicate alerts.
+	Clock clock.Clock
+}
+
+// Manager receives alerts from the daemon's subsystems, filters and
+// deduplicates them and then delivers them to all configured notifiers.
+type Manager struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg ManagerConfig
+
+	// alerts is the queue of alerts waiting to be delivered.
+	alerts chan Alert
+
+	// lastSent maps the deduplication key of an alert to the time an
+	// alert with that key was last delivered.
+	lastSent map[string]time.Time
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewManager creates a new alert manager.
+func NewManager(cfg ManagerConfig) *Manager {
+	return &Manager{
+		cfg:      cfg,
+		alerts:   make(chan Alert, alertQueueSize),
+		lastSent: make(map[string]time.Time),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: cfg.NotifyTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start starts the alert manager.
+func (m *Manager) Start() error {
+	m.startOnce.Do(func() {
+		log.Infof("Starting alert manager with %d notifier(s)",
+			len(m.cfg.Notifiers))
+
+		m.Wg.Add(1)
+		go m.dispatchAlerts()
+	})
+
+	return nil
+}
+
+// Stop stops the alert manager. Alerts that haven't been delivered yet are
+// dropped.
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		log.Info("Stopping alert manager")
+
+		close(m.Quit)
+		m.Wg.Wait()
+	})
+
+	return nil
+}
+
+// SendAlert raises the given alert. Alerts below the configured minimum
+// severity are ignored. This method never blocks; if the delivery queue is
+// full, the alert is only logged.
+//
+// NOTE: This is part of the Sender interface.
+func (m *Manager) SendAlert(alert Alert) {
+	if alert.Timestamp.IsZero() {
+		alert.Timestamp = m.cfg.Clock.Now()
+	}
+
+	log.Warnf("Alert raised (type=%v, severity=%v): %v", alert.Type,
+		alert.Severity, alert.Message)
+
+	if alert.Severity < m.cfg.MinSeverity {
+		return
+	}
+
+	select {
+	case m.alerts <- alert:
+	default:
+		log.Errorf("Alert queue full, dropping alert of type %v",
+			alert.Type)
+	}
+}
+
+// isDuplicate returns true if an alert with the same deduplication key was
+// delivered within the deduplication interval. Otherwise, the alert is
+// recorded as delivered.
+func (m *Manager) isDuplicate(alert Alert) bool {
+	now := m.cfg.Clock.Now()
+
+	// Prune entries that are outside the deduplication interval, so the
+	// map doesn't grow without bound.
+	for key, sentAt := range m.lastSent {
+		if now.Sub(sentAt) >= m.cfg.DedupInterval {
+			delete(m.lastSent, key)
+		}
+	}
+
+	key := alert.dedupKey()
+	if _, ok := m.lastSent[key]; ok {
+		return true
+	}
+
+	m.lastSent[key] = now
+
+	return false
+}
+
+// dispatchAlerts delivers queued alerts to all notifiers until the manager is
+// stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *Manager) dispatchAlerts() {
+	defer m.Wg.Done()
+
+	for {
+		select {
+		case alert := <-m.alerts:
+			if m.isDuplicate(alert) {
+				log.Debugf("Suppressing duplicate alert of "+
+					"type %v", alert.Type)
+				continue
+			}
+
+			m.deliver(alert)
+
+		case <-m.Quit:
+			return
+		}
+	}
+}
+
+// deliver sends the given alert to all notifiers.
+func (m *Manager) deliver(alert Alert) {
+	for _, notifier := range m.cfg.Notifiers {
+		ctx, cancel := m.WithCtxQuit()
+		err := notifier.Notify(ctx, alert)
+		cancel()
+
+		if err != nil {
+			log.Errorf("Unable to deliver alert of type %v using "+
+				"%v notifier: %v", alert.Type, notifier.Name(),
+				err)
+		}
+	}
+}
+
+// A compile-time assertion to ensure Manager meets the Sender interface.
+var _ Sender = (*Manager)(nil)
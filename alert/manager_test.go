@@ -0,0 +1,164 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockNotifier is a notifier that forwards all alerts to a channel.
+type mockNotifier struct {
+	alerts chan Alert
+}
+
+func (m *mockNotifier) Name() string {
+	return "mock"
+}
+
+func (m *mockNotifier) Notify(_ context.Context, alert Alert) error {
+	m.alerts <- alert
+	return nil
+}
+
+// TestManagerFilterAndDedup tests that the alert manager drops alerts below
+// the minimum severity and suppresses duplicates within the dedup interval.
+func TestManagerFilterAndDedup(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1_000_000, 0))
+	notifier := &mockNotifier{alerts: make(chan Alert, 10)}
+	manager := NewManager(ManagerConfig{
+		Notifiers:     []Notifier{notifier},
+		MinSeverity:   SeverityWarning,
+		DedupInterval: time.Minute,
+		NotifyTimeout: time.Second,
+		Clock:         testClock,
+	})
+	require.NoError(t, manager.Start())
+	t.Cleanup(func() {
+		require.NoError(t, manager.Stop())
+	})
+
+	expectAlert := func(expected Alert) {
+		select {
+		case alert := <-notifier.alerts:
+			require.Equal(t, expected.Type, alert.Type)
+			require.Equal(t, expected.DedupKey, alert.DedupKey)
+			require.Equal(t, testClock.Now(), alert.Timestamp)
+
+		case <-time.After(time.Second):
+			t.Fatalf("alert not delivered")
+		}
+	}
+	expectNoAlert := func() {
+		select {
+		case alert := <-notifier.alerts:
+			t.Fatalf("unexpected alert: %v", alert)
+
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// An informational alert is below the minimum severity.
+	manager.SendAlert(Alert{
+		Type:     TypeOracleDeviation,
+		Severity: SeverityInfo,
+		DedupKey: "a",
+	})
+	expectNoAlert()
+
+	// The first warning is delivered, the duplicate isn't, but an alert
+	// with a different key is.
+	first := Alert{
+		Type:     TypeOracleDeviation,
+		Severity: SeverityWarning,
+		DedupKey: "a",
+	}
+	manager.SendAlert(first)
+	expectAlert(first)
+
+	manager.SendAlert(first)
+	expectNoAlert()
+
+	second := Alert{
+		Type:     TypeUniverseEquivocation,
+		Severity: SeverityCritical,
+		DedupKey: "a",
+	}
+	manager.SendAlert(second)
+	expectAlert(second)
+
+	// Once the dedup interval has passed, the alert is delivered again.
+	testClock.SetTime(testClock.Now().Add(time.Minute))
+	manager.SendAlert(first)
+	expectAlert(first)
+}
+
+// TestWebhookNotifier tests that the webhook notifier posts the JSON encoded
+// alert.
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(body, &payload))
+			received <- payload
+		},
+	))
+	t.Cleanup(server.Close)
+
+	notifier := NewWebhookNotifier(server.URL)
+	err := notifier.Notify(context.Background(), Alert{
+		Type:     TypeUnexpectedAnchorSpend,
+		Severity: SeverityCritical,
+		Message:  "anchor spent",
+	})
+	require.NoError(t, err)
+
+	payload := <-received
+	require.Equal(t, "unexpected_anchor_spend", payload["type"])
+	require.Equal(t, "critical", payload["severity"])
+	require.Equal(t, "anchor spent", payload["message"])
+}
+
+// TestCommandNotifier tests that the command notifier passes the alert to
+// the executed command.
+func TestCommandNotifier(t *testing.T) {
+	t.Parallel()
+
+	outFile := filepath.Join(t.TempDir(), "alert")
+	notifier := NewCommandNotifier(
+		"echo \"$TAPD_ALERT_SEVERITY $TAPD_ALERT_TYPE\" > " + outFile,
+	)
+	err := notifier.Notify(context.Background(), Alert{
+		Type:     TypeProofVerificationFailure,
+		Severity: SeverityWarning,
+		Message:  "invalid proof",
+	})
+	require.NoError(t, err)
+
+	output, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	require.Equal(
+		t, "warning proof_verification_failure\n", string(output),
+	)
+
+	// A failing command results in an error.
+	notifier = NewCommandNotifier("exit 1")
+	err = notifier.Notify(context.Background(), Alert{})
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+// Notifier is a destination alerts are delivered to.
+type Notifier interface {
+	// Name returns the human-readable name of the notifier.
+	Name() string
+
+	// Notify delivers the given alert.
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// alertPayload is the JSON representation of an alert that is delivered to
+// the notifiers.
+type alertPayload struct {
+	Alert
+
+	// Severity is the human-readable severity of the alert.
+	Severity string `json:"severity"`
+}
+
+// encodeAlert returns the JSON encoding of the given alert.
+func encodeAlert(alert Alert) ([]byte, error) {
+	return json.Marshal(alertPayload{
+		Alert:    alert,
+		Severity: alert.Severity.String(),
+	})
+}
+
+// WebhookNotifier delivers alerts by sending them as a JSON encoded HTTP POST
+// request to a webhook URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new notifier that posts alerts to the given
+// URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+// Name returns the human-readable name of the notifier.
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Notify delivers the given alert.
+func (w *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	payload, err := encodeAlert(alert)
+	if err != nil {
+		return fmt.Errorf("unable to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, w.url, bytes.NewReader(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be re-used.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// A compile-time assertion to ensure WebhookNotifier meets the Notifier
+// interface.
+var _ Notifier = (*WebhookNotifier)(nil)
+
+// CommandNotifier delivers alerts by executing a shell command, for example
+// one that sends an email. The JSON encoded alert is passed to the command on
+// its standard input, the individual fields are also available as the
+// TAPD_ALERT_TYPE, TAPD_ALERT_SEVERITY and TAPD_ALERT_MESSAGE environment
+// variables.
+type CommandNotifier struct {
+	command string
+}
+
+// NewCommandNotifier creates a new notifier that executes the given shell
+// command for every alert.
+func NewCommandNotifier(command string) *CommandNotifier {
+	return &CommandNotifier{
+		command: command,
+	}
+}
+
+// Name returns the human-readable name of the notifier.
+func (c *CommandNotifier) Name() string {
+	return "command"
+}
+
+// Notify delivers the given alert.
+func (c *CommandNotifier) Notify(ctx context.Context, alert Alert) error {
+	payload, err := encodeAlert(alert)
+	if err != nil {
+		return fmt.Errorf("unable to encode alert: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", c.command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(
+		os.Environ(),
+		"TAPD_ALERT_TYPE="+string(alert.Type),
+		"TAPD_ALERT_SEVERITY="+alert.Severity.String(),
+		"TAPD_ALERT_MESSAGE="+alert.Message,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("alert command failed: %w (output: %s)", err,
+			bytes.TrimSpace(output))
+	}
+
+	return nil
+}
+
+// A compile-time assertion to ensure CommandNotifier meets the Notifier
+// interface.
+var _ Notifier = (*CommandNotifier)(nil)
@@ -0,0 +1,814 @@
+package anchorwallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// anchorInputWeight is the maximum weight of a template input of a
+	// PSBT we ask bitcoind to fund. Template inputs are the asset anchor
+	// inputs, which are always spent through the taproot key path. The
+	// weight can't be estimated by bitcoind itself, as the inputs don't
+	// belong to its wallet.
+	anchorInputWeight = input.InputSize*blockchain.WitnessScaleFactor +
+		input.TaprootKeyPathCustomSighashWitnessSize
+
+	// changeAddressType is the bitcoind address type of the change output
+	// of a funded PSBT.
+	changeAddressType = "bech32m"
+
+	// feeReserveAddressType is the bitcoind address type of the addresses
+	// returned by NextChangeAddr. The fee reserve outputs that pay to them
+	// are sized as P2WPKH outputs.
+	feeReserveAddressType = "bech32"
+)
+
+// PsbtSigner signs all inputs of a PSBT packet it has the keys for.
+type PsbtSigner interface {
+	// SignPsbt signs all inputs of the packet that carry a BIP-0032
+	// derivation path of a key of the signer.
+	SignPsbt(ctx context.Context, packet *psbt.Packet) (*psbt.Packet, error)
+}
+
+// BitcoindWalletAnchor is a wallet anchor that funds and signs anchor
+// transactions with a wallet of a bitcoind node. The asset anchor inputs are
+// signed by the given key signer, as their keys are derived by the key ring
+// of tapd, which bitcoind doesn't know about.
+type BitcoindWalletAnchor struct {
+	client *rpcclient.Client
+
+	keySigner PsbtSigner
+
+	chainParams *chaincfg.Params
+
+	pollInterval time.Duration
+}
+
+// A compile-time assertion to ensure BitcoindWalletAnchor meets the
+// tapgarden.WalletAnchor and tapfreighter.WalletAnchor interfaces.
+var _ tapgarden.WalletAnchor = (*BitcoindWalletAnchor)(nil)
+var _ tapfreighter.WalletAnchor = (*BitcoindWalletAnchor)(nil)
+
+// NewBitcoindWalletAnchor creates a new wallet anchor that connects to the
+// bitcoind RPC server of the given config.
+func NewBitcoindWalletAnchor(cfg *BitcoindConfig, keySigner PsbtSigner,
+	chainParams *chaincfg.Params) (*BitcoindWalletAnchor, error) {
+
+	// Requests for a specific wallet are sent to the wallet endpoint of
+	// the RPC server.
+	host := cfg.RPCHost
+	if cfg.Wallet != "" {
+		host += "/wallet/" + url.PathEscape(cfg.Wallet)
+	}
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         host,
+		User:         cfg.RPCUser,
+		Pass:         cfg.RPCPass,
+		DisableTLS:   !cfg.RPCTLS,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create bitcoind RPC client: "+
+			"%w", err)
+	}
+
+	return &BitcoindWalletAnchor{
+		client:       client,
+		keySigner:    keySigner,
+		chainParams:  chainParams,
+		pollInterval: cfg.PollInterval,
+	}, nil
+}
+
+// outPoint is the JSON representation of an outpoint used by the bitcoind
+// wallet RPCs.
+type outPoint struct {
+	TxID string `json:"txid"`
+	Vout uint32 `json:"vout"`
+}
+
+// fundInput is a template input of the walletcreatefundedpsbt RPC.
+type fundInput struct {
+	TxID     string `json:"txid"`
+	Vout     uint32 `json:"vout"`
+	Sequence uint32 `json:"sequence"`
+}
+
+// inputWeight is the maximum weight of an external input of the
+// walletcreatefundedpsbt RPC.
+type inputWeight struct {
+	TxID   string `json:"txid"`
+	Vout   uint32 `json:"vout"`
+	Weight int64  `json:"weight"`
+}
+
+// fundOptions are the options of the walletcreatefundedpsbt RPC.
+type fundOptions struct {
+	AddInputs      bool          `json:"add_inputs"`
+	ChangePosition int           `json:"changePosition"`
+	ChangeType     string        `json:"change_type"`
+	FeeRate        float64       `json:"fee_rate"`
+	LockUnspents   bool          `json:"lockUnspents"`
+	MinConf        uint32        `json:"minconf"`
+	InputWeights   []inputWeight `json:"input_weights,omitempty"`
+}
+
+// fundResult is the result of the walletcreatefundedpsbt RPC.
+type fundResult struct {
+	Psbt      string `json:"psbt"`
+	ChangePos int32  `json:"changepos"`
+}
+
+// processResult is the result of the walletprocesspsbt RPC.
+type processResult struct {
+	Psbt string `json:"psbt"`
+}
+
+// unspentResult is a single entry of the result of the listunspent RPC.
+type unspentResult struct {
+	TxID          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	Amount        float64 `json:"amount"`
+	Confirmations int64   `json:"confirmations"`
+	Spendable     bool    `json:"spendable"`
+}
+
+// walletTxResult is the result of the gettransaction RPC.
+type walletTxResult struct {
+	TxID          string  `json:"txid"`
+	Hex           string  `json:"hex"`
+	Amount        float64 `json:"amount"`
+	Fee           float64 `json:"fee"`
+	Confirmations int32   `json:"confirmations"`
+	BlockHash     string  `json:"blockhash"`
+	BlockHeight   int32   `json:"blockheight"`
+	Time          int64   `json:"time"`
+}
+
+// listSinceBlockResult is the result of the listsinceblock RPC.
+type listSinceBlockResult struct {
+	Transactions []struct {
+		TxID string `json:"txid"`
+	} `json:"transactions"`
+	LastBlock string `json:"lastblock"`
+}
+
+// FundPsbt attaches enough inputs of the bitcoind wallet to the target PSBT
+// packet for it to be valid. If changeIdx is negative, a new change output is
+// added as the last output, otherwise the change is added to the value of the
+// output with the given index.
+func (b *BitcoindWalletAnchor) FundPsbt(ctx context.Context,
+	packet *psbt.Packet, minConfs uint32, feeRate chainfee.SatPerKWeight,
+	changeIdx int32) (*tapsend.FundedPsbt, error) {
+
+	tx := packet.UnsignedTx
+	if changeIdx >= int32(len(tx.TxOut)) {
+		return nil, fmt.Errorf("change index %d out of range",
+			changeIdx)
+	}
+
+	inputs := make([]fundInput, len(tx.TxIn))
+	weights := make([]inputWeight, len(tx.TxIn))
+	for idx, txIn := range tx.TxIn {
+		op := txIn.PreviousOutPoint
+		inputs[idx] = fundInput{
+			TxID:     op.Hash.String(),
+			Vout:     op.Index,
+			Sequence: txIn.Sequence,
+		}
+		weights[idx] = inputWeight{
+			TxID:   op.Hash.String(),
+			Vout:   op.Index,
+			Weight: anchorInputWeight,
+		}
+	}
+
+	outputs, err := fundOutputs(tx.TxOut, b.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	options := fundOptions{
+		AddInputs:      true,
+		ChangePosition: len(tx.TxOut),
+		ChangeType:     changeAddressType,
+		FeeRate:        float64(feeRate.FeePerKVByte()) / 1000,
+		LockUnspents:   true,
+		MinConf:        minConfs,
+		InputWeights:   weights,
+	}
+
+	var result fundResult
+	err = b.call(
+		ctx, "walletcreatefundedpsbt", &result, inputs, outputs,
+		tx.LockTime, options, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fund psbt: %w", err)
+	}
+
+	funded, err := psbt.NewFromRawBytes(
+		strings.NewReader(result.Psbt), true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode funded psbt: %w", err)
+	}
+
+	return mergeFundedPsbt(packet, funded, result.ChangePos, changeIdx)
+}
+
+// fundOutputs returns the outputs of the walletcreatefundedpsbt RPC for the
+// given template outputs. Each output is a single entry object to retain the
+// order of the outputs.
+func fundOutputs(txOuts []*wire.TxOut,
+	chainParams *chaincfg.Params) ([]map[string]float64, error) {
+
+	outputs := make([]map[string]float64, len(txOuts))
+	for idx, txOut := range txOuts {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			txOut.PkScript, chainParams,
+		)
+		if err != nil || len(addrs) != 1 {
+			return nil, fmt.Errorf("unable to extract address of "+
+				"output %d", idx)
+		}
+
+		outputs[idx] = map[string]float64{
+			addrs[0].EncodeAddress(): btcutil.Amount(
+				txOut.Value,
+			).ToBTC(),
+		}
+	}
+
+	return outputs, nil
+}
+
+// mergeFundedPsbt merges the inputs and the change output bitcoind added to
+// the template packet into a copy of the template. This retains all
+// information of the template inputs and outputs, which bitcoind doesn't
+// necessarily carry over.
+func mergeFundedPsbt(template, funded *psbt.Packet, fundedChangeIdx,
+	changeIdx int32) (*tapsend.FundedPsbt, error) {
+
+	pkt := &psbt.Packet{
+		UnsignedTx: template.UnsignedTx.Copy(),
+		Inputs:     slices.Clone(template.Inputs),
+		Outputs:    slices.Clone(template.Outputs),
+		Unknowns:   template.Unknowns,
+	}
+
+	fundedTx := funded.UnsignedTx
+	if len(funded.Inputs) != len(fundedTx.TxIn) ||
+		len(funded.Outputs) != len(fundedTx.TxOut) {
+
+		return nil, fmt.Errorf("invalid funded psbt")
+	}
+
+	// All inputs that aren't part of the template were added by the
+	// wallet and are locked until the transaction is broadcast.
+	var lockedUTXOs []wire.OutPoint
+	for idx, txIn := range fundedTx.TxIn {
+		op := txIn.PreviousOutPoint
+		if tapsend.HasInput(template.UnsignedTx, op) {
+			continue
+		}
+
+		pkt.UnsignedTx.AddTxIn(txIn)
+		pkt.Inputs = append(pkt.Inputs, funded.Inputs[idx])
+		lockedUTXOs = append(lockedUTXOs, op)
+	}
+
+	resultChangeIdx := changeIdx
+	switch {
+	// The wallet didn't need a change output, as the change would have
+	// been dust.
+	case fundedChangeIdx < 0:
+
+	case int(fundedChangeIdx) >= len(fundedTx.TxOut):
+		return nil, fmt.Errorf("change index %d out of range",
+			fundedChangeIdx)
+
+	// The change goes to an existing output of the template.
+	case changeIdx >= 0:
+		changeOut := fundedTx.TxOut[fundedChangeIdx]
+		pkt.UnsignedTx.TxOut[changeIdx].Value += changeOut.Value
+
+	default:
+		pkt.UnsignedTx.AddTxOut(fundedTx.TxOut[fundedChangeIdx])
+		pkt.Outputs = append(
+			pkt.Outputs, funded.Outputs[fundedChangeIdx],
+		)
+		resultChangeIdx = int32(len(pkt.UnsignedTx.TxOut) - 1)
+	}
+
+	return &tapsend.FundedPsbt{
+		Pkt:               pkt,
+		ChangeOutputIndex: resultChangeIdx,
+		LockedUTXOs:       lockedUTXOs,
+	}, nil
+}
+
+// SignPsbt signs all inputs of the bitcoind wallet and then asks the key
+// signer to sign the asset anchor inputs, which carry a BIP-0032 derivation
+// path. The packet isn't finalized.
+func (b *BitcoindWalletAnchor) SignPsbt(ctx context.Context,
+	packet *psbt.Packet) (*psbt.Packet, error) {
+
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode psbt: %w", err)
+	}
+
+	var result processResult
+	err = b.call(
+		ctx, "walletprocesspsbt", &result, encoded, true, "DEFAULT",
+		false, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign psbt: %w", err)
+	}
+
+	signed, err := psbt.NewFromRawBytes(
+		strings.NewReader(result.Psbt), true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode signed psbt: %w", err)
+	}
+	if len(signed.Inputs) != len(packet.Inputs) {
+		return nil, fmt.Errorf("signed psbt has %d inputs, expected %d",
+			len(signed.Inputs), len(packet.Inputs))
+	}
+
+	// We restore the asset anchor inputs as we passed them in, so the key
+	// signer gets all the information it needs to sign them.
+	var numAnchorInputs int
+	for idx := range packet.Inputs {
+		if len(packet.Inputs[idx].Bip32Derivation) == 0 {
+			continue
+		}
+
+		signed.Inputs[idx] = packet.Inputs[idx]
+		numAnchorInputs++
+	}
+
+	if numAnchorInputs == 0 {
+		return signed, nil
+	}
+
+	return b.keySigner.SignPsbt(ctx, signed)
+}
+
+// SignAndFinalizePsbt fully signs and finalizes the target PSBT packet.
+func (b *BitcoindWalletAnchor) SignAndFinalizePsbt(ctx context.Context,
+	pkt *psbt.Packet) (*psbt.Packet, error) {
+
+	signed, err := b.SignPsbt(ctx, pkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := psbt.MaybeFinalizeAll(signed); err != nil {
+		return nil, fmt.Errorf("unable to finalize psbt: %w", err)
+	}
+
+	return signed, nil
+}
+
+// ImportTaprootOutput returns the P2TR address of the given output key.
+// bitcoind looks up the previous outputs of inputs that don't belong to its
+// wallet in its UTXO set when funding a PSBT, so the key doesn't need to be
+// imported.
+func (b *BitcoindWalletAnchor) ImportTaprootOutput(_ context.Context,
+	pub *btcec.PublicKey) (btcutil.Address, error) {
+
+	return btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(pub), b.chainParams,
+	)
+}
+
+// UnlockInput unlocks the set of target inputs after a batch or send
+// transaction is abandoned.
+func (b *BitcoindWalletAnchor) UnlockInput(ctx context.Context,
+	op wire.OutPoint) error {
+
+	var locked []outPoint
+	if err := b.call(ctx, "listlockunspent", &locked); err != nil {
+		return fmt.Errorf("error listing locked outputs: %w", err)
+	}
+
+	for _, lock := range locked {
+		if lock.TxID != op.Hash.String() || lock.Vout != op.Index {
+			continue
+		}
+
+		err := b.call(
+			ctx, "lockunspent", nil, true, []outPoint{lock},
+		)
+		if err != nil {
+			return fmt.Errorf("error unlocking output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListUnspentImportScripts lists all UTXOs of the imported Taproot scripts.
+// No scripts are imported into the bitcoind wallet, so the list is always
+// empty.
+func (b *BitcoindWalletAnchor) ListUnspentImportScripts(
+	context.Context) ([]*lnwallet.Utxo, error) {
+
+	return nil, nil
+}
+
+// ListUnspent lists all confirmed and unconfirmed spendable UTXOs of the
+// bitcoind wallet that aren't locked.
+func (b *BitcoindWalletAnchor) ListUnspent(
+	ctx context.Context) ([]*lnwallet.Utxo, error) {
+
+	var unspents []unspentResult
+	err := b.call(ctx, "listunspent", &unspents, 0, math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list unspent outputs: %w",
+			err)
+	}
+
+	utxos := make([]*lnwallet.Utxo, 0, len(unspents))
+	for _, unspent := range unspents {
+		if !unspent.Spendable {
+			continue
+		}
+
+		hash, err := chainhash.NewHashFromStr(unspent.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txid: %w", err)
+		}
+
+		pkScript, err := hex.DecodeString(unspent.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pk script: %w", err)
+		}
+
+		value, err := btcutil.NewAmount(unspent.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount: %w", err)
+		}
+
+		utxos = append(utxos, &lnwallet.Utxo{
+			AddressType:   addressType(pkScript),
+			Value:         value,
+			Confirmations: unspent.Confirmations,
+			PkScript:      pkScript,
+			OutPoint: wire.OutPoint{
+				Hash:  *hash,
+				Index: unspent.Vout,
+			},
+		})
+	}
+
+	return utxos, nil
+}
+
+// addressType returns the lnwallet address type of the given pk script.
+func addressType(pkScript []byte) lnwallet.AddressType {
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.WitnessV0PubKeyHashTy:
+		return lnwallet.WitnessPubKey
+
+	case txscript.ScriptHashTy:
+		return lnwallet.NestedWitnessPubKey
+
+	case txscript.WitnessV1TaprootTy:
+		return lnwallet.TaprootPubkey
+
+	default:
+		return lnwallet.UnknownAddressType
+	}
+}
+
+// NextChangeAddr returns a new P2WKH change address of the bitcoind wallet.
+func (b *BitcoindWalletAnchor) NextChangeAddr(
+	ctx context.Context) (btcutil.Address, error) {
+
+	var addr string
+	err := b.call(ctx, "getrawchangeaddress", &addr, feeReserveAddressType)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get change address: %w", err)
+	}
+
+	return btcutil.DecodeAddress(addr, b.chainParams)
+}
+
+// SubscribeTransactions creates a uni-directional stream from the server to
+// the client in which any newly discovered transactions relevant to the
+// wallet are sent over. bitcoind has no transaction notifications on its RPC
+// interface, so the wallet is polled for new transactions. Unconfirmed
+// transactions are sent once when they're discovered and once more when they
+// confirm.
+func (b *BitcoindWalletAnchor) SubscribeTransactions(
+	ctx context.Context) (<-chan lndclient.Transaction, <-chan error,
+	error) {
+
+	var lastBlock string
+	if err := b.call(ctx, "getbestblockhash", &lastBlock); err != nil {
+		return nil, nil, fmt.Errorf("unable to get best block: %w", err)
+	}
+
+	txChan := make(chan lndclient.Transaction)
+	errChan := make(chan error, 1)
+	go func() {
+		ticker := time.NewTicker(b.pollInterval)
+		defer ticker.Stop()
+
+		unconfirmed := make(map[string]struct{})
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			var result listSinceBlockResult
+			err := b.call(ctx, "listsinceblock", &result, lastBlock)
+			if err != nil {
+				errChan <- fmt.Errorf("unable to list wallet "+
+					"transactions: %w", err)
+				return
+			}
+
+			txns, err := b.walletTxns(ctx, result)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			for _, tx := range txns {
+				_, known := unconfirmed[tx.TxHash]
+				switch {
+				case tx.Confirmations == 0 && known:
+					continue
+
+				case tx.Confirmations == 0:
+					unconfirmed[tx.TxHash] = struct{}{}
+
+				default:
+					delete(unconfirmed, tx.TxHash)
+				}
+
+				select {
+				case txChan <- tx:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			lastBlock = result.LastBlock
+		}
+	}()
+
+	return txChan, errChan, nil
+}
+
+// ListTransactions returns all known transactions of the bitcoind wallet. It
+// takes a start and end block height which can be used to limit the block
+// range that we query over. These values can be left as zero to include all
+// blocks. To include unconfirmed transactions in the query, endHeight must be
+// set to -1. bitcoind wallets have no accounts, so the account is ignored.
+func (b *BitcoindWalletAnchor) ListTransactions(ctx context.Context,
+	startHeight, endHeight int32, _ string) ([]lndclient.Transaction,
+	error) {
+
+	var result listSinceBlockResult
+	if err := b.call(ctx, "listsinceblock", &result); err != nil {
+		return nil, fmt.Errorf("unable to list wallet transactions: %w",
+			err)
+	}
+
+	txns, err := b.walletTxns(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]lndclient.Transaction, 0, len(txns))
+	for _, tx := range txns {
+		if inHeightRange(tx, startHeight, endHeight) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	return filtered, nil
+}
+
+// inHeightRange returns true if the given transaction is within the given
+// block height range. The range follows the semantics of ListTransactions.
+func inHeightRange(tx lndclient.Transaction, startHeight,
+	endHeight int32) bool {
+
+	if tx.Confirmations == 0 {
+		return endHeight == -1
+	}
+
+	if tx.BlockHeight < startHeight {
+		return false
+	}
+
+	return endHeight <= 0 || tx.BlockHeight <= endHeight
+}
+
+// walletTxns fetches the details of the transactions of the given
+// listsinceblock result. bitcoind lists a transaction once for every output
+// that is relevant to the wallet, so the result is de-duplicated. Conflicted
+// transactions are skipped.
+func (b *BitcoindWalletAnchor) walletTxns(ctx context.Context,
+	result listSinceBlockResult) ([]lndclient.Transaction, error) {
+
+	seen := make(map[string]struct{}, len(result.Transactions))
+	txns := make([]lndclient.Transaction, 0, len(result.Transactions))
+	for _, entry := range result.Transactions {
+		if _, ok := seen[entry.TxID]; ok {
+			continue
+		}
+		seen[entry.TxID] = struct{}{}
+
+		tx, err := b.walletTx(ctx, entry.TxID)
+		if err != nil {
+			return nil, err
+		}
+
+		if tx.Confirmations < 0 {
+			continue
+		}
+
+		txns = append(txns, tx)
+	}
+
+	return txns, nil
+}
+
+// walletTx fetches the wallet transaction with the given ID. The amount of
+// the transaction is the net change of the wallet balance, including the
+// fee.
+func (b *BitcoindWalletAnchor) walletTx(ctx context.Context,
+	txid string) (lndclient.Transaction, error) {
+
+	var result walletTxResult
+	if err := b.call(ctx, "gettransaction", &result, txid); err != nil {
+		return lndclient.Transaction{}, fmt.Errorf("unable to get "+
+			"transaction %s: %w", txid, err)
+	}
+
+	rawTx, err := hex.DecodeString(result.Hex)
+	if err != nil {
+		return lndclient.Transaction{}, fmt.Errorf("invalid "+
+			"transaction %s: %w", txid, err)
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return lndclient.Transaction{}, fmt.Errorf("invalid "+
+			"transaction %s: %w", txid, err)
+	}
+
+	amount, err := btcutil.NewAmount(result.Amount)
+	if err != nil {
+		return lndclient.Transaction{}, fmt.Errorf("invalid amount: "+
+			"%w", err)
+	}
+
+	// bitcoind reports the fee of transactions we sent as a negative
+	// amount and omits it for received transactions.
+	fee, err := btcutil.NewAmount(result.Fee)
+	if err != nil {
+		return lndclient.Transaction{}, fmt.Errorf("invalid fee: %w",
+			err)
+	}
+
+	return lndclient.Transaction{
+		Tx:            tx,
+		TxHash:        result.TxID,
+		Timestamp:     time.Unix(result.Time, 0),
+		Amount:        amount + fee,
+		Fee:           -fee,
+		Confirmations: result.Confirmations,
+		BlockHash:     result.BlockHash,
+		BlockHeight:   result.BlockHeight,
+	}, nil
+}
+
+// MinRelayFee returns the current minimum relay fee of the bitcoind node in
+// sat/kw.
+func (b *BitcoindWalletAnchor) MinRelayFee(
+	ctx context.Context) (chainfee.SatPerKWeight, error) {
+
+	var info struct {
+		RelayFee float64 `json:"relayfee"`
+	}
+	if err := b.call(ctx, "getnetworkinfo", &info); err != nil {
+		return 0, fmt.Errorf("unable to get network info: %w", err)
+	}
+
+	relayFee, err := btcutil.NewAmount(info.RelayFee)
+	if err != nil {
+		return 0, fmt.Errorf("invalid relay fee: %w", err)
+	}
+
+	feeRate := chainfee.SatPerKVByte(relayFee).FeePerKWeight()
+	if feeRate < chainfee.FeePerKwFloor {
+		feeRate = chainfee.FeePerKwFloor
+	}
+
+	return feeRate, nil
+}
+
+// Stop shuts down the RPC client.
+func (b *BitcoindWalletAnchor) Stop() {
+	b.client.Shutdown()
+}
+
+// call executes the given RPC method with the given parameters and decodes
+// its result into the given value, unless it's nil.
+func (b *BitcoindWalletAnchor) call(ctx context.Context, method string,
+	result any, params ...any) error {
+
+	rawParams := make([]json.RawMessage, len(params))
+	for idx, param := range params {
+		rawParam, err := json.Marshal(param)
+		if err != nil {
+			return fmt.Errorf("unable to encode %s params: %w",
+				method, err)
+		}
+		rawParams[idx] = rawParam
+	}
+
+	future := b.client.RawRequestAsync(method, rawParams)
+
+	var (
+		rawResult json.RawMessage
+		err       error
+	)
+	if err := waitForResult(ctx, func() {
+		rawResult, err = future.Receive()
+	}); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(rawResult, result); err != nil {
+		return fmt.Errorf("unable to decode %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// waitForResult waits for the given receive function to return, unless the
+// context is canceled first.
+func waitForResult(ctx context.Context, receive func()) error {
+	done := make(chan struct{})
+	go func() {
+		receive()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,204 @@
+package anchorwallet
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/stretchr/testify/require"
+)
+
+// newPacket creates a PSBT packet that spends the given outpoints to the given
+// outputs.
+func newPacket(t *testing.T, ops []wire.OutPoint,
+	txOuts []*wire.TxOut) *psbt.Packet {
+
+	tx := wire.NewMsgTx(2)
+	for _, op := range ops {
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: op,
+			Sequence:         wire.MaxTxInSequenceNum,
+		})
+	}
+	for _, txOut := range txOuts {
+		tx.AddTxOut(txOut)
+	}
+
+	pkt, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+
+	return pkt
+}
+
+// TestMergeFundedPsbt tests that the inputs and the change output added by
+// bitcoind are merged into the template packet without losing any of the
+// information of the template.
+func TestMergeFundedPsbt(t *testing.T) {
+	t.Parallel()
+
+	anchorOp := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	walletOp := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0}
+	anchorOut := tapsend.CreateDummyOutput()
+	changeOut := &wire.TxOut{Value: 5_000, PkScript: []byte{0x51}}
+
+	newTemplate := func() *psbt.Packet {
+		template := newPacket(
+			t, []wire.OutPoint{anchorOp},
+			[]*wire.TxOut{anchorOut, tapsend.CreateDummyOutput()},
+		)
+		template.Inputs[0].Bip32Derivation = []*psbt.Bip32Derivation{{
+			PubKey: []byte{2},
+		}}
+		template.Outputs[0].TaprootInternalKey = []byte{3}
+		template.Outputs[1].TaprootInternalKey = []byte{4}
+
+		return template
+	}
+
+	// bitcoind keeps the template inputs first, but doesn't know about
+	// their derivation paths.
+	funded := newPacket(
+		t, []wire.OutPoint{anchorOp, walletOp},
+		[]*wire.TxOut{
+			anchorOut, tapsend.CreateDummyOutput(), changeOut,
+		},
+	)
+	funded.Inputs[1].WitnessUtxo = &wire.TxOut{Value: 10_000}
+
+	testCases := []struct {
+		name            string
+		fundedChangeIdx int32
+		changeIdx       int32
+		expectedOuts    int
+		expectedIdx     int32
+		expectedValue   int64
+	}{{
+		name:            "new change output",
+		fundedChangeIdx: 2,
+		changeIdx:       -1,
+		expectedOuts:    3,
+		expectedIdx:     2,
+		expectedValue:   changeOut.Value,
+	}, {
+		name:            "existing change output",
+		fundedChangeIdx: 2,
+		changeIdx:       1,
+		expectedOuts:    2,
+		expectedIdx:     1,
+		expectedValue:   anchorOut.Value + changeOut.Value,
+	}, {
+		name:            "no change",
+		fundedChangeIdx: -1,
+		changeIdx:       -1,
+		expectedOuts:    2,
+		expectedIdx:     -1,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := newTemplate()
+			result, err := mergeFundedPsbt(
+				template, funded, tc.fundedChangeIdx,
+				tc.changeIdx,
+			)
+			require.NoError(t, err)
+
+			pkt := result.Pkt
+			require.Equal(t, []wire.OutPoint{walletOp},
+				result.LockedUTXOs)
+			require.Len(t, pkt.UnsignedTx.TxIn, 2)
+			require.Equal(t, template.Inputs[0], pkt.Inputs[0])
+			require.Equal(t, funded.Inputs[1], pkt.Inputs[1])
+			require.Equal(t, []byte{3},
+				pkt.Outputs[0].TaprootInternalKey)
+
+			require.Len(t, pkt.UnsignedTx.TxOut, tc.expectedOuts)
+			require.Len(t, pkt.Outputs, tc.expectedOuts)
+			require.Equal(
+				t, tc.expectedIdx, result.ChangeOutputIndex,
+			)
+			if tc.expectedIdx >= 0 {
+				txOut := pkt.UnsignedTx.TxOut[tc.expectedIdx]
+				require.Equal(
+					t, tc.expectedValue, txOut.Value,
+				)
+			}
+
+			// The template itself must not be modified.
+			require.Len(t, template.UnsignedTx.TxIn, 1)
+			require.Equal(
+				t, anchorOut.Value,
+				template.UnsignedTx.TxOut[1].Value,
+			)
+		})
+	}
+
+	// A change index reported by bitcoind that doesn't exist is rejected.
+	_, err := mergeFundedPsbt(newTemplate(), funded, 3, -1)
+	require.ErrorContains(t, err, "out of range")
+}
+
+// TestFundOutputs tests that template outputs are converted to bitcoind
+// outputs in order, and that outputs without an address are rejected.
+func TestFundOutputs(t *testing.T) {
+	t.Parallel()
+
+	params := &chaincfg.RegressionNetParams
+	outputs, err := fundOutputs(
+		[]*wire.TxOut{tapsend.CreateDummyOutput()}, params,
+	)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	for addr, amount := range outputs[0] {
+		require.Contains(t, addr, params.Bech32HRPSegwit)
+		require.Equal(t, float64(tapsend.DummyAmtSats)/1e8, amount)
+	}
+
+	_, err = fundOutputs(
+		[]*wire.TxOut{{Value: 1_000, PkScript: []byte{0x6a}}}, params,
+	)
+	require.ErrorContains(t, err, "unable to extract address")
+}
+
+// TestInHeightRange tests the block height filter of ListTransactions.
+func TestInHeightRange(t *testing.T) {
+	t.Parallel()
+
+	unconfirmed := lndclient.Transaction{}
+	confirmed := lndclient.Transaction{
+		Confirmations: 3,
+		BlockHeight:   100,
+	}
+
+	require.True(t, inHeightRange(unconfirmed, 0, -1))
+	require.False(t, inHeightRange(unconfirmed, 0, 0))
+	require.True(t, inHeightRange(confirmed, 0, 0))
+	require.True(t, inHeightRange(confirmed, 0, -1))
+	require.True(t, inHeightRange(confirmed, 100, 100))
+	require.False(t, inHeightRange(confirmed, 101, -1))
+	require.False(t, inHeightRange(confirmed, 0, 99))
+}
+
+// TestValidate tests the validation of the anchor wallet config.
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultCliConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Backend = BackendBitcoind
+	require.ErrorContains(t, cfg.Validate(), "RPC host must be set")
+
+	cfg.Bitcoind.RPCHost = "localhost:8332"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Bitcoind.PollInterval = 0
+	require.ErrorContains(t, cfg.Validate(), "poll interval")
+
+	cfg.Backend = "electrum"
+	require.ErrorContains(t, cfg.Validate(), "unknown anchor wallet")
+}
@@ -0,0 +1,84 @@
+package anchorwallet
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// BackendLnd is the anchor wallet backend that uses the wallet of the
+	// connected lnd node to fund and sign anchor transactions.
+	BackendLnd = "lnd"
+
+	// BackendBitcoind is the anchor wallet backend that uses a wallet of a
+	// bitcoind node to fund and sign anchor transactions through its PSBT
+	// RPC interface.
+	BackendBitcoind = "bitcoind"
+
+	// DefaultPollInterval is the default interval in which the bitcoind
+	// wallet is polled for new transactions.
+	DefaultPollInterval = 30 * time.Second
+)
+
+// BitcoindConfig holds the configuration options for the bitcoind anchor
+// wallet backend.
+//
+// nolint: lll
+type BitcoindConfig struct {
+	RPCHost string `long:"rpchost" description:"The host:port of the bitcoind RPC server"`
+
+	RPCUser string `long:"rpcuser" description:"The username for the bitcoind RPC server"`
+
+	RPCPass string `long:"rpcpass" description:"The password for the bitcoind RPC server"`
+
+	RPCTLS bool `long:"rpctls" description:"If true, TLS is used to connect to the bitcoind RPC server"`
+
+	Wallet string `long:"wallet" description:"The name of the bitcoind wallet to use; if empty, the default wallet of the node is used"`
+
+	PollInterval time.Duration `long:"pollinterval" description:"The interval in which the bitcoind wallet is polled for new transactions"`
+}
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// wallet that funds and signs the BTC level anchor transactions of mints and
+// transfers.
+//
+// nolint: lll
+type CliConfig struct {
+	Backend string `long:"backend" description:"The wallet used to fund and sign anchor transactions; lnd uses the wallet of the connected lnd node, bitcoind uses the given bitcoind wallet and only asks lnd to sign for the asset anchor inputs" choice:"lnd" choice:"bitcoind"`
+
+	Bitcoind *BitcoindConfig `group:"bitcoind" namespace:"bitcoind"`
+}
+
+// DefaultCliConfig returns the default anchor wallet configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		Backend: BackendLnd,
+		Bitcoind: &BitcoindConfig{
+			PollInterval: DefaultPollInterval,
+		},
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	switch c.Backend {
+	case BackendLnd:
+		return nil
+
+	case BackendBitcoind:
+		if c.Bitcoind.RPCHost == "" {
+			return fmt.Errorf("bitcoind RPC host must be set")
+		}
+
+		if c.Bitcoind.PollInterval <= 0 {
+			return fmt.Errorf("bitcoind poll interval must be " +
+				"positive")
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown anchor wallet backend: %v",
+			c.Backend)
+	}
+}
@@ -1111,6 +1111,16 @@ func TestUnknownVersion(t *testing.T) {
 }
 
 func FuzzAssetDecode(f *testing.F) {
+	// Seed the corpus with the encoded assets of the valid test vectors.
+	testVectors := &TestVectors{}
+	test.ParseTestVectors(f, generatedTestVectorName, &testVectors)
+	for _, validCase := range testVectors.ValidTestCases {
+		assetBytes, err := hex.DecodeString(validCase.Expected)
+		require.NoError(f, err)
+
+		f.Add(assetBytes)
+	}
+
 	f.Fuzz(func(t *testing.T, data []byte) {
 		r := bytes.NewReader(data)
 		a := &Asset{}
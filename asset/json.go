@@ -0,0 +1,431 @@
+package asset
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// The JSON types in this file define a canonical JSON encoding of an asset
+// that can be used alongside the binary TLV encoding. The field names follow
+// the ones used in the BIP test vectors. Byte strings, keys and hashes are
+// encoded as lowercase hex and outpoints as <txid>:<index>. Unknown odd TLV
+// types are carried over as they are, so encoding an asset to JSON and back
+// results in the same TLV encoding.
+
+var (
+	// ErrInvalidJSON is returned when the JSON representation of an asset
+	// or one of its components can't be converted back.
+	ErrInvalidJSON = errors.New("invalid JSON encoding")
+)
+
+// JSONAsset is the JSON representation of an asset.
+type JSONAsset struct {
+	Version             uint8           `json:"version"`
+	GenesisFirstPrevOut string          `json:"genesis_first_prev_out"`
+	GenesisTag          string          `json:"genesis_tag"`
+	GenesisMetaHash     string          `json:"genesis_meta_hash"`
+	GenesisOutputIndex  uint32          `json:"genesis_output_index"`
+	GenesisType         uint8           `json:"genesis_type"`
+	Amount              uint64          `json:"amount"`
+	LockTime            uint64          `json:"lock_time"`
+	RelativeLockTime    uint64          `json:"relative_lock_time"`
+	PrevWitnesses       []*JSONWitness  `json:"prev_witnesses"`
+	SplitCommitmentRoot *mssmt.JSONNode `json:"split_commitment_root"`
+	ScriptVersion       uint16          `json:"script_version"`
+	ScriptKey           string          `json:"script_key"`
+	GroupKey            *JSONGroupKey   `json:"group_key"`
+	UnknownOddTypes     tlv.TypeMap     `json:"unknown_odd_types"`
+}
+
+// NewJSONAsset creates the JSON representation of the given asset.
+func NewJSONAsset(a *Asset) (*JSONAsset, error) {
+	ja := &JSONAsset{
+		Version:             uint8(a.Version),
+		GenesisFirstPrevOut: a.Genesis.FirstPrevOut.String(),
+		GenesisTag:          a.Genesis.Tag,
+		GenesisMetaHash:     hex.EncodeToString(a.Genesis.MetaHash[:]),
+		GenesisOutputIndex:  a.Genesis.OutputIndex,
+		GenesisType:         uint8(a.Genesis.Type),
+		Amount:              a.Amount,
+		LockTime:            a.LockTime,
+		RelativeLockTime:    a.RelativeLockTime,
+		ScriptVersion:       uint16(a.ScriptVersion),
+		ScriptKey:           HexPubKey(a.ScriptKey.PubKey),
+		UnknownOddTypes:     a.UnknownOddTypes,
+	}
+
+	for idx := range a.PrevWitnesses {
+		jw, err := NewJSONWitness(&a.PrevWitnesses[idx])
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode prev witness "+
+				"%d: %w", idx, err)
+		}
+
+		ja.PrevWitnesses = append(ja.PrevWitnesses, jw)
+	}
+
+	if a.SplitCommitmentRoot != nil {
+		ja.SplitCommitmentRoot = mssmt.NewJSONNode(
+			a.SplitCommitmentRoot,
+		)
+	}
+
+	if a.GroupKey != nil {
+		ja.GroupKey = &JSONGroupKey{
+			GroupKey: HexPubKey(&a.GroupKey.GroupPubKey),
+		}
+	}
+
+	return ja, nil
+}
+
+// ToAsset converts the JSON representation back into an asset.
+func (ja *JSONAsset) ToAsset() (*Asset, error) {
+	if ja.GenesisFirstPrevOut == "" || ja.GenesisMetaHash == "" {
+		return nil, fmt.Errorf("%w: missing genesis fields",
+			ErrInvalidJSON)
+	}
+
+	genesis, err := (&JSONGenesis{
+		FirstPrevOut: ja.GenesisFirstPrevOut,
+		Tag:          ja.GenesisTag,
+		MetaHash:     ja.GenesisMetaHash,
+		OutputIndex:  ja.GenesisOutputIndex,
+		Type:         ja.GenesisType,
+	}).ToGenesis()
+	if err != nil {
+		return nil, err
+	}
+
+	if ja.ScriptKey == "" {
+		return nil, fmt.Errorf("%w: missing script key", ErrInvalidJSON)
+	}
+	scriptKey, err := ParseHexPubKey(ja.ScriptKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script key: %w", err)
+	}
+
+	a := &Asset{
+		Version:          Version(ja.Version),
+		Genesis:          *genesis,
+		Amount:           ja.Amount,
+		LockTime:         ja.LockTime,
+		RelativeLockTime: ja.RelativeLockTime,
+		ScriptVersion:    ScriptVersion(ja.ScriptVersion),
+		ScriptKey: ScriptKey{
+			PubKey: scriptKey,
+		},
+		UnknownOddTypes: ja.UnknownOddTypes,
+	}
+
+	for idx, jw := range ja.PrevWitnesses {
+		w, err := jw.ToWitness()
+		if err != nil {
+			return nil, fmt.Errorf("invalid prev witness %d: %w",
+				idx, err)
+		}
+
+		a.PrevWitnesses = append(a.PrevWitnesses, *w)
+	}
+
+	if ja.SplitCommitmentRoot != nil {
+		root, err := ja.SplitCommitmentRoot.ToNode()
+		if err != nil {
+			return nil, fmt.Errorf("invalid split commitment "+
+				"root: %w", err)
+		}
+
+		a.SplitCommitmentRoot = root
+	}
+
+	if ja.GroupKey != nil {
+		if ja.GroupKey.GroupKey == "" {
+			return nil, fmt.Errorf("%w: missing group key",
+				ErrInvalidJSON)
+		}
+
+		groupKey, err := ParseHexPubKey(ja.GroupKey.GroupKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group key: %w", err)
+		}
+
+		a.GroupKey = &GroupKey{
+			GroupPubKey: *groupKey,
+		}
+	}
+
+	return a, nil
+}
+
+// JSONWitness is the JSON representation of an asset witness.
+type JSONWitness struct {
+	PrevID          *JSONPrevID          `json:"prev_id"`
+	TxWitness       []string             `json:"tx_witness"`
+	SplitCommitment *JSONSplitCommitment `json:"split_commitment"`
+}
+
+// NewJSONWitness creates the JSON representation of the given witness.
+func NewJSONWitness(w *Witness) (*JSONWitness, error) {
+	jw := &JSONWitness{}
+
+	if w.PrevID != nil {
+		jw.PrevID = NewJSONPrevID(w.PrevID)
+	}
+
+	for _, witness := range w.TxWitness {
+		jw.TxWitness = append(jw.TxWitness, hex.EncodeToString(witness))
+	}
+
+	if w.SplitCommitment != nil {
+		proofHex, err := mssmt.EncodeHexProof(
+			&w.SplitCommitment.Proof,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode split "+
+				"commitment proof: %w", err)
+		}
+
+		rootAsset, err := NewJSONAsset(&w.SplitCommitment.RootAsset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode split root "+
+				"asset: %w", err)
+		}
+
+		jw.SplitCommitment = &JSONSplitCommitment{
+			Proof:     proofHex,
+			RootAsset: rootAsset,
+		}
+	}
+
+	return jw, nil
+}
+
+// ToWitness converts the JSON representation back into a witness.
+func (jw *JSONWitness) ToWitness() (*Witness, error) {
+	w := &Witness{}
+
+	if jw.PrevID != nil {
+		prevID, err := jw.PrevID.ToPrevID()
+		if err != nil {
+			return nil, err
+		}
+
+		w.PrevID = prevID
+	}
+
+	for _, witness := range jw.TxWitness {
+		witnessBytes, err := hex.DecodeString(witness)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx witness: %w", err)
+		}
+
+		w.TxWitness = append(w.TxWitness, witnessBytes)
+	}
+
+	if jw.SplitCommitment != nil {
+		sc := jw.SplitCommitment
+		if sc.Proof == "" || sc.RootAsset == nil {
+			return nil, fmt.Errorf("%w: incomplete split "+
+				"commitment", ErrInvalidJSON)
+		}
+
+		proof, err := mssmt.DecodeHexProof(sc.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("invalid split commitment "+
+				"proof: %w", err)
+		}
+
+		rootAsset, err := sc.RootAsset.ToAsset()
+		if err != nil {
+			return nil, fmt.Errorf("invalid split root asset: %w",
+				err)
+		}
+
+		w.SplitCommitment = &SplitCommitment{
+			Proof:     *proof,
+			RootAsset: *rootAsset,
+		}
+	}
+
+	return w, nil
+}
+
+// JSONPrevID is the JSON representation of the previous input of an asset.
+type JSONPrevID struct {
+	OutPoint  string `json:"out_point"`
+	AssetID   string `json:"asset_id"`
+	ScriptKey string `json:"script_key"`
+}
+
+// NewJSONPrevID creates the JSON representation of the given previous input
+// identifier.
+func NewJSONPrevID(prevID *PrevID) *JSONPrevID {
+	return &JSONPrevID{
+		OutPoint:  prevID.OutPoint.String(),
+		AssetID:   hex.EncodeToString(prevID.ID[:]),
+		ScriptKey: hex.EncodeToString(prevID.ScriptKey[:]),
+	}
+}
+
+// ToPrevID converts the JSON representation back into a previous input
+// identifier. If all fields are empty, nil is returned.
+func (jp *JSONPrevID) ToPrevID() (*PrevID, error) {
+	if jp.OutPoint == "" && jp.AssetID == "" && jp.ScriptKey == "" {
+		return nil, nil
+	}
+
+	outPoint, err := wire.NewOutPointFromString(jp.OutPoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prev ID outpoint: %w", err)
+	}
+
+	prevID := &PrevID{
+		OutPoint: *outPoint,
+	}
+
+	err = decodeFixedHex(jp.AssetID, prevID.ID[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid prev ID asset ID: %w", err)
+	}
+
+	err = decodeFixedHex(jp.ScriptKey, prevID.ScriptKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid prev ID script key: %w", err)
+	}
+
+	return prevID, nil
+}
+
+// JSONSplitCommitment is the JSON representation of a split commitment.
+type JSONSplitCommitment struct {
+	Proof     string     `json:"proof"`
+	RootAsset *JSONAsset `json:"root_asset"`
+}
+
+// JSONGroupKey is the JSON representation of an asset group key. Only the
+// tweaked group public key is part of the asset encoding.
+type JSONGroupKey struct {
+	GroupKey string `json:"group_key"`
+}
+
+// JSONGenesis is the JSON representation of an asset genesis.
+type JSONGenesis struct {
+	FirstPrevOut string `json:"first_prev_out"`
+	Tag          string `json:"tag"`
+	MetaHash     string `json:"meta_hash"`
+	OutputIndex  uint32 `json:"output_index"`
+	Type         uint8  `json:"type"`
+}
+
+// NewJSONGenesis creates the JSON representation of the given genesis.
+func NewJSONGenesis(g *Genesis) *JSONGenesis {
+	return &JSONGenesis{
+		FirstPrevOut: g.FirstPrevOut.String(),
+		Tag:          g.Tag,
+		MetaHash:     hex.EncodeToString(g.MetaHash[:]),
+		OutputIndex:  g.OutputIndex,
+		Type:         uint8(g.Type),
+	}
+}
+
+// ToGenesis converts the JSON representation back into a genesis.
+func (jg *JSONGenesis) ToGenesis() (*Genesis, error) {
+	firstPrevOut, err := wire.NewOutPointFromString(jg.FirstPrevOut)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis outpoint: %w", err)
+	}
+
+	genesis := &Genesis{
+		FirstPrevOut: *firstPrevOut,
+		Tag:          jg.Tag,
+		OutputIndex:  jg.OutputIndex,
+		Type:         Type(jg.Type),
+	}
+
+	err = decodeFixedHex(jg.MetaHash, genesis.MetaHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis meta hash: %w", err)
+	}
+
+	return genesis, nil
+}
+
+// JSONGroupKeyReveal is the JSON representation of a group key reveal.
+type JSONGroupKeyReveal struct {
+	RawKey        string `json:"raw_key"`
+	TapscriptRoot string `json:"tapscript_root"`
+}
+
+// NewJSONGroupKeyReveal creates the JSON representation of the given group
+// key reveal.
+func NewJSONGroupKeyReveal(g *GroupKeyReveal) *JSONGroupKeyReveal {
+	return &JSONGroupKeyReveal{
+		RawKey:        hex.EncodeToString(g.RawKey[:]),
+		TapscriptRoot: hex.EncodeToString(g.TapscriptRoot),
+	}
+}
+
+// ToGroupKeyReveal converts the JSON representation back into a group key
+// reveal.
+func (jg *JSONGroupKeyReveal) ToGroupKeyReveal() (*GroupKeyReveal, error) {
+	rawKey, err := ParseHexPubKey(jg.RawKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key reveal raw key: %w",
+			err)
+	}
+
+	tapscriptRoot, err := hex.DecodeString(jg.TapscriptRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key reveal tapscript "+
+			"root: %w", err)
+	}
+
+	return &GroupKeyReveal{
+		RawKey:        ToSerialized(rawKey),
+		TapscriptRoot: tapscriptRoot,
+	}, nil
+}
+
+// HexPubKey returns the hex encoded compressed serialization of the given
+// public key, or an empty string if the key is nil.
+func HexPubKey(pubKey *btcec.PublicKey) string {
+	if pubKey == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(pubKey.SerializeCompressed())
+}
+
+// ParseHexPubKey parses a hex encoded compressed public key.
+func ParseHexPubKey(pubKeyHex string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(pubKeyBytes)
+}
+
+// decodeFixedHex decodes the given hex string into the target byte slice,
+// making sure the decoded value has exactly the length of the target.
+func decodeFixedHex(hexStr string, target []byte) error {
+	decoded, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return err
+	}
+
+	if len(decoded) != len(target) {
+		return fmt.Errorf("%w: expected %d bytes, got %d",
+			ErrInvalidJSON, len(target), len(decoded))
+	}
+
+	copy(target, decoded)
+
+	return nil
+}
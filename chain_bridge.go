@@ -11,6 +11,7 @@ import (
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/neutrino/cache/lru"
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/chainmux"
 	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/rfq"
 	"github.com/lightninglabs/taproot-assets/tapchannel"
@@ -18,6 +19,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/funding"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
@@ -47,6 +49,10 @@ func (c cacheableTimestamp) Size() (uint64, error) {
 type LndRpcChainBridge struct {
 	lnd *lndclient.LndServices
 
+	// notifier coalesces identical chain notification subscriptions, so
+	// lnd only serves a single one of each.
+	notifier *chainmux.Multiplexer
+
 	blockTimestampCache *lru.Cache[uint32, cacheableTimestamp]
 
 	assetStore *tapdb.AssetStore
@@ -59,6 +65,9 @@ func NewLndRpcChainBridge(lnd *lndclient.LndServices,
 
 	return &LndRpcChainBridge{
 		lnd: lnd,
+		notifier: chainmux.NewMultiplexer(
+			chainmux.NewLndNotifier(lnd.ChainNotifier),
+		),
 		blockTimestampCache: lru.NewCache[uint32, cacheableTimestamp](
 			maxNumBlocksInCache,
 		),
@@ -74,17 +83,10 @@ func (l *LndRpcChainBridge) RegisterConfirmationsNtfn(ctx context.Context,
 	reOrgChan chan struct{}) (*chainntnfs.ConfirmationEvent, chan error,
 	error) {
 
-	opts := []lndclient.NotifierOption{
-		lndclient.WithReOrgChan(reOrgChan),
-	}
-	if includeBlock {
-		opts = append(opts, lndclient.WithIncludeBlock())
-	}
-
 	ctx, cancel := context.WithCancel(ctx) // nolint:govet
-	confChan, errChan, err := l.lnd.ChainNotifier.RegisterConfirmationsNtfn(
-		ctx, txid, pkScript, int32(numConfs), int32(heightHint),
-		opts...,
+	confChan, errChan, err := l.notifier.RegisterConfirmationsNtfn(
+		ctx, txid, pkScript, numConfs, heightHint, includeBlock,
+		reOrgChan,
 	)
 	if err != nil {
 		cancel()
@@ -99,12 +101,21 @@ func (l *LndRpcChainBridge) RegisterConfirmationsNtfn(ctx context.Context,
 	}, errChan, nil
 }
 
+// RegisterSpendNtfn registers an intent to be notified once the given
+// outpoint is spent on-chain.
+func (l *LndRpcChainBridge) RegisterSpendNtfn(ctx context.Context,
+	outpoint *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (chan *chainntnfs.SpendDetail, chan error, error) {
+
+	return l.notifier.RegisterSpendNtfn(ctx, outpoint, pkScript, heightHint)
+}
+
 // RegisterBlockEpochNtfn registers an intent to be notified of each new block
 // connected to the main chain.
 func (l *LndRpcChainBridge) RegisterBlockEpochNtfn(
 	ctx context.Context) (chan int32, chan error, error) {
 
-	return l.lnd.ChainNotifier.RegisterBlockEpochNtfn(ctx)
+	return l.notifier.RegisterBlockEpochNtfn(ctx)
 }
 
 // GetBlock returns a chain block given its hash.
@@ -264,6 +275,10 @@ func (l *LndRpcChainBridge) GenProofChainLookup(
 // tapgarden.ChainBridge interface.
 var _ tapgarden.ChainBridge = (*LndRpcChainBridge)(nil)
 
+// A compile time assertion to ensure LndRpcChainBridge meets the
+// tapgarden.SpendNotifier interface.
+var _ tapgarden.SpendNotifier = (*LndRpcChainBridge)(nil)
+
 // LndMsgTransportClient is an LND RPC message transport client.
 type LndMsgTransportClient struct {
 	lnd *lndclient.LndServices
@@ -376,9 +391,19 @@ func (l *LndRouterClient) DeleteLocalAlias(ctx context.Context, alias,
 	return l.lnd.Router.XDeleteLocalChanAlias(ctx, alias, baseScid)
 }
 
-// Ensure LndRouterClient implements the rfq.HtlcInterceptor interface.
+// SubscribeHtlcEvents subscribes to a stream of HTLC events from the router.
+func (l *LndRouterClient) SubscribeHtlcEvents(
+	ctx context.Context) (<-chan *routerrpc.HtlcEvent, <-chan error,
+	error) {
+
+	return l.lnd.Router.SubscribeHtlcEvents(ctx)
+}
+
+// Ensure LndRouterClient implements the rfq.HtlcInterceptor,
+// rfq.ScidAliasManager and rfq.HtlcEventSubscriber interfaces.
 var _ rfq.HtlcInterceptor = (*LndRouterClient)(nil)
 var _ rfq.ScidAliasManager = (*LndRouterClient)(nil)
+var _ rfq.HtlcEventSubscriber = (*LndRouterClient)(nil)
 
 // LndInvoicesClient is an LND invoices RPC client.
 type LndInvoicesClient struct {
@@ -0,0 +1,305 @@
+package chainmux
+
+import (
+	"context"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// confKey identifies confirmation subscriptions that can share an upstream
+// subscription.
+type confKey struct {
+	txid         chainhash.Hash
+	pkScript     string
+	numConfs     uint32
+	includeBlock bool
+	reOrgAware   bool
+}
+
+// spendKey identifies spend subscriptions that can share an upstream
+// subscription.
+type spendKey struct {
+	outpoint wire.OutPoint
+	pkScript string
+}
+
+// Stats is a snapshot of the subscriptions of the multiplexer.
+type Stats struct {
+	// Subscriptions is the number of active subscriptions.
+	Subscriptions int
+
+	// UpstreamSubscriptions is the number of active subscriptions with the
+	// upstream notifier that serve the active subscriptions.
+	UpstreamSubscriptions int
+}
+
+// Multiplexer sits in front of a chain notifier and coalesces identical
+// notification subscriptions. Subscriptions that watch the same transaction,
+// outpoint or the chain tip share a single upstream subscription, whose
+// events are fanned out internally. The upstream subscription is cancelled
+// once its last subscriber's context is cancelled.
+//
+// A subscription only joins an existing upstream subscription that was
+// registered with a height hint not greater than its own, since the upstream
+// notifier doesn't look for events before the height hint.
+//
+// NOTE: Events of subscriptions that deliver more than one event are fanned
+// out in order, so a subscriber that doesn't read its channels holds up the
+// other subscribers of the same upstream subscription until its context is
+// cancelled.
+type Multiplexer struct {
+	notifier Notifier
+
+	mu sync.Mutex
+
+	// confStreams are the active confirmation streams.
+	confStreams map[confKey][]*stream[*chainntnfs.TxConfirmation]
+
+	// spendStreams are the active spend streams.
+	spendStreams map[spendKey][]*stream[*chainntnfs.SpendDetail]
+
+	// blockStream is the active block epoch stream, if any.
+	blockStream *stream[int32]
+}
+
+// NewMultiplexer creates a new multiplexer for the given upstream notifier.
+func NewMultiplexer(notifier Notifier) *Multiplexer {
+	return &Multiplexer{
+		notifier: notifier,
+		confStreams: make(
+			map[confKey][]*stream[*chainntnfs.TxConfirmation],
+		),
+		spendStreams: make(
+			map[spendKey][]*stream[*chainntnfs.SpendDetail],
+		),
+	}
+}
+
+// findStream returns the first unfinished stream that was registered with a
+// height hint not greater than the given one.
+//
+// NOTE: The mutex must be held when calling this function.
+func findStream[T any](streams []*stream[T], heightHint uint32) *stream[T] {
+	for _, s := range streams {
+		if !s.finished && s.heightHint <= heightHint {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// removeStream removes the given stream from the slice of streams.
+func removeStream[T any](streams []*stream[T], s *stream[T]) []*stream[T] {
+	for idx := range streams {
+		if streams[idx] == s {
+			return append(streams[:idx], streams[idx+1:]...)
+		}
+	}
+
+	return streams
+}
+
+// RegisterConfirmationsNtfn registers an intent to be notified once
+// txid reaches numConfs confirmations.
+func (m *Multiplexer) RegisterConfirmationsNtfn(ctx context.Context,
+	txid *chainhash.Hash, pkScript []byte, numConfs, heightHint uint32,
+	includeBlock bool,
+	reOrgChan chan struct{}) (chan *chainntnfs.TxConfirmation, chan error,
+	error) {
+
+	key := confKey{
+		pkScript:     string(pkScript),
+		numConfs:     numConfs,
+		includeBlock: includeBlock,
+		reOrgAware:   reOrgChan != nil,
+	}
+	if txid != nil {
+		key.txid = *txid
+	}
+
+	m.mu.Lock()
+	if s := findStream(m.confStreams[key], heightHint); s != nil {
+		sub := s.joinLocked(ctx, reOrgChan)
+		m.mu.Unlock()
+
+		log.Tracef("Coalesced confirmation subscription for txid=%v, "+
+			"num_confs=%d", txid, numConfs)
+
+		return sub.events, sub.errChan, nil
+	}
+	m.mu.Unlock()
+
+	// We don't hold the mutex while registering with the upstream notifier.
+	// If an identical subscription is registered concurrently, we end up
+	// with two upstream subscriptions, which is harmless.
+	upstreamCtx, cancel := context.WithCancel(context.Background())
+
+	// Without a re-org channel, the upstream subscription finishes after
+	// the first confirmation. Otherwise, it stays active and the latest
+	// confirmation is replayed to late subscribers, as the upstream
+	// notifier would do for a new subscription.
+	var upstreamReOrgChan chan struct{}
+	if reOrgChan != nil {
+		upstreamReOrgChan = make(chan struct{})
+	}
+	confChan, errChan, err := m.notifier.RegisterConfirmationsNtfn(
+		upstreamCtx, txid, pkScript, numConfs, heightHint,
+		includeBlock, upstreamReOrgChan,
+	)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	s := newStream[*chainntnfs.TxConfirmation](
+		&m.mu, heightHint, reOrgChan == nil, reOrgChan != nil, cancel,
+	)
+	s.remove = func() {
+		m.confStreams[key] = removeStream(m.confStreams[key], s)
+		if len(m.confStreams[key]) == 0 {
+			delete(m.confStreams, key)
+		}
+	}
+
+	m.mu.Lock()
+	m.confStreams[key] = append(m.confStreams[key], s)
+	sub := s.joinLocked(ctx, reOrgChan)
+	m.mu.Unlock()
+
+	go s.run(upstreamCtx, confChan, errChan, upstreamReOrgChan)
+
+	return sub.events, sub.errChan, nil
+}
+
+// RegisterSpendNtfn registers an intent to be notified once the given
+// outpoint is spent on-chain.
+func (m *Multiplexer) RegisterSpendNtfn(ctx context.Context,
+	outpoint *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (chan *chainntnfs.SpendDetail, chan error, error) {
+
+	key := spendKey{
+		pkScript: string(pkScript),
+	}
+	if outpoint != nil {
+		key.outpoint = *outpoint
+	}
+
+	m.mu.Lock()
+	if s := findStream(m.spendStreams[key], heightHint); s != nil {
+		sub := s.joinLocked(ctx, nil)
+		m.mu.Unlock()
+
+		log.Tracef("Coalesced spend subscription for outpoint=%v",
+			outpoint)
+
+		return sub.events, sub.errChan, nil
+	}
+	m.mu.Unlock()
+
+	upstreamCtx, cancel := context.WithCancel(context.Background())
+	spendChan, errChan, err := m.notifier.RegisterSpendNtfn(
+		upstreamCtx, outpoint, pkScript, heightHint,
+	)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	// The upstream subscription finishes once the outpoint is spent.
+	s := newStream[*chainntnfs.SpendDetail](
+		&m.mu, heightHint, true, false, cancel,
+	)
+	s.remove = func() {
+		m.spendStreams[key] = removeStream(m.spendStreams[key], s)
+		if len(m.spendStreams[key]) == 0 {
+			delete(m.spendStreams, key)
+		}
+	}
+
+	m.mu.Lock()
+	m.spendStreams[key] = append(m.spendStreams[key], s)
+	sub := s.joinLocked(ctx, nil)
+	m.mu.Unlock()
+
+	go s.run(upstreamCtx, spendChan, errChan, nil)
+
+	return sub.events, sub.errChan, nil
+}
+
+// RegisterBlockEpochNtfn registers an intent to be notified of each new block
+// connected to the main chain.
+func (m *Multiplexer) RegisterBlockEpochNtfn(
+	ctx context.Context) (chan int32, chan error, error) {
+
+	m.mu.Lock()
+	if s := m.blockStream; s != nil && !s.finished {
+		sub := s.joinLocked(ctx, nil)
+		m.mu.Unlock()
+
+		log.Tracef("Coalesced block epoch subscription")
+
+		return sub.events, sub.errChan, nil
+	}
+	m.mu.Unlock()
+
+	upstreamCtx, cancel := context.WithCancel(context.Background())
+	blockChan, errChan, err := m.notifier.RegisterBlockEpochNtfn(
+		upstreamCtx,
+	)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	// The upstream notifier delivers the current tip right after
+	// registration, so we replay the latest height to late subscribers.
+	s := newStream[int32](&m.mu, 0, false, true, cancel)
+	s.remove = func() {
+		if m.blockStream == s {
+			m.blockStream = nil
+		}
+	}
+
+	m.mu.Lock()
+	m.blockStream = s
+	sub := s.joinLocked(ctx, nil)
+	m.mu.Unlock()
+
+	go s.run(upstreamCtx, blockChan, errChan, nil)
+
+	return sub.events, sub.errChan, nil
+}
+
+// Stats returns a snapshot of the current subscriptions of the multiplexer.
+func (m *Multiplexer) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stats Stats
+	for _, streams := range m.confStreams {
+		for _, s := range streams {
+			stats.Subscriptions += len(s.subscribers)
+			stats.UpstreamSubscriptions++
+		}
+	}
+	for _, streams := range m.spendStreams {
+		for _, s := range streams {
+			stats.Subscriptions += len(s.subscribers)
+			stats.UpstreamSubscriptions++
+		}
+	}
+	if m.blockStream != nil {
+		stats.Subscriptions += len(m.blockStream.subscribers)
+		stats.UpstreamSubscriptions++
+	}
+
+	return stats
+}
+
+// A compile-time assertion to ensure Multiplexer meets the Notifier
+// interface.
+var _ Notifier = (*Multiplexer)(nil)
@@ -0,0 +1,362 @@
+package chainmux
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTimeout = 5 * time.Second
+)
+
+// registration is a single upstream registration with the mock notifier.
+type registration struct {
+	ctx        context.Context
+	heightHint uint32
+	confChan   chan *chainntnfs.TxConfirmation
+	spendChan  chan *chainntnfs.SpendDetail
+	blockChan  chan int32
+	errChan    chan error
+	reOrgChan  chan struct{}
+}
+
+// mockNotifier is a mock upstream notifier that records all registrations.
+type mockNotifier struct {
+	sync.Mutex
+	registrations []*registration
+}
+
+func (m *mockNotifier) register(r *registration) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.registrations = append(m.registrations, r)
+}
+
+func (m *mockNotifier) numRegistrations() int {
+	m.Lock()
+	defer m.Unlock()
+
+	return len(m.registrations)
+}
+
+func (m *mockNotifier) last() *registration {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.registrations[len(m.registrations)-1]
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(ctx context.Context,
+	_ *chainhash.Hash, _ []byte, _, heightHint uint32, _ bool,
+	reOrgChan chan struct{}) (chan *chainntnfs.TxConfirmation, chan error,
+	error) {
+
+	r := &registration{
+		ctx:        ctx,
+		heightHint: heightHint,
+		confChan:   make(chan *chainntnfs.TxConfirmation, 1),
+		errChan:    make(chan error, 1),
+		reOrgChan:  reOrgChan,
+	}
+	m.register(r)
+
+	return r.confChan, r.errChan, nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(ctx context.Context,
+	_ *wire.OutPoint, _ []byte,
+	heightHint uint32) (chan *chainntnfs.SpendDetail, chan error, error) {
+
+	r := &registration{
+		ctx:        ctx,
+		heightHint: heightHint,
+		spendChan:  make(chan *chainntnfs.SpendDetail, 1),
+		errChan:    make(chan error, 1),
+	}
+	m.register(r)
+
+	return r.spendChan, r.errChan, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn(
+	ctx context.Context) (chan int32, chan error, error) {
+
+	r := &registration{
+		ctx:       ctx,
+		blockChan: make(chan int32),
+		errChan:   make(chan error, 1),
+	}
+	m.register(r)
+
+	return r.blockChan, r.errChan, nil
+}
+
+// receive waits for a value on the given channel.
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(testTimeout):
+		t.Fatalf("timeout waiting for value")
+	}
+
+	var zero T
+	return zero
+}
+
+// requireStats waits until the multiplexer has the given number of
+// subscriptions and upstream subscriptions.
+func requireStats(t *testing.T, m *Multiplexer, subs, upstream int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return m.Stats() == Stats{
+			Subscriptions:         subs,
+			UpstreamSubscriptions: upstream,
+		}
+	}, testTimeout, 10*time.Millisecond)
+}
+
+// TestConfirmationCoalescing tests that identical confirmation subscriptions
+// share an upstream subscription and that the height hint is respected.
+func TestConfirmationCoalescing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	notifier := &mockNotifier{}
+	m := NewMultiplexer(notifier)
+
+	txid := chainhash.Hash{1}
+	pkScript := []byte{2}
+
+	// The second subscription has a higher height hint and can join the
+	// first one.
+	conf1, _, err := m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 1, 100, false, nil,
+	)
+	require.NoError(t, err)
+	conf2, _, err := m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 1, 120, false, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, notifier.numRegistrations())
+
+	// A subscription with a lower height hint, a different number of
+	// confirmations or a different transaction needs its own upstream
+	// subscription.
+	conf3, _, err := m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 1, 90, false, nil,
+	)
+	require.NoError(t, err)
+	_, _, err = m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 3, 100, false, nil,
+	)
+	require.NoError(t, err)
+	_, _, err = m.RegisterConfirmationsNtfn(
+		ctx, &chainhash.Hash{3}, pkScript, 1, 100, false, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 4, notifier.numRegistrations())
+	requireStats(t, m, 5, 4)
+
+	// A confirmation of the first upstream subscription is delivered to
+	// both of its subscribers.
+	upstream := notifier.registrations[0]
+	conf := &chainntnfs.TxConfirmation{BlockHeight: 123}
+	upstream.confChan <- conf
+
+	require.Equal(t, conf, receive(t, conf1))
+	require.Equal(t, conf, receive(t, conf2))
+
+	select {
+	case <-conf3:
+		t.Fatalf("unexpected confirmation")
+	default:
+	}
+
+	// The upstream subscription is finished now, so a new subscription
+	// joins the one with the lower height hint, unless its own height hint
+	// is even lower.
+	requireStats(t, m, 3, 3)
+	_, _, err = m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 1, 120, false, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 4, notifier.numRegistrations())
+
+	_, _, err = m.RegisterConfirmationsNtfn(
+		ctx, &txid, pkScript, 1, 80, false, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 5, notifier.numRegistrations())
+	requireStats(t, m, 5, 4)
+}
+
+// TestReOrgAwareConfirmations tests that re-org aware confirmation
+// subscriptions receive the latest confirmation when joining late and are
+// notified about re-orgs.
+func TestReOrgAwareConfirmations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	notifier := &mockNotifier{}
+	m := NewMultiplexer(notifier)
+
+	txid := chainhash.Hash{1}
+	reOrg1 := make(chan struct{}, 1)
+	conf1, _, err := m.RegisterConfirmationsNtfn(
+		ctx, &txid, nil, 1, 100, true, reOrg1,
+	)
+	require.NoError(t, err)
+
+	// A subscription without a re-org channel doesn't share the upstream
+	// subscription, since that one never finishes.
+	_, _, err = m.RegisterConfirmationsNtfn(
+		ctx, &txid, nil, 1, 100, true, nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, notifier.numRegistrations())
+
+	upstream := notifier.registrations[0]
+	require.NotNil(t, upstream.reOrgChan)
+
+	conf := &chainntnfs.TxConfirmation{BlockHeight: 123}
+	upstream.confChan <- conf
+	require.Equal(t, conf, receive(t, conf1))
+
+	// A late subscriber receives the latest confirmation right away.
+	reOrg2 := make(chan struct{}, 1)
+	conf2, _, err := m.RegisterConfirmationsNtfn(
+		ctx, &txid, nil, 1, 100, true, reOrg2,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, notifier.numRegistrations())
+	require.Equal(t, conf, receive(t, conf2))
+
+	// A re-org is delivered to both subscribers, followed by the new
+	// confirmation.
+	upstream.reOrgChan <- struct{}{}
+	receive(t, reOrg1)
+	receive(t, reOrg2)
+
+	newConf := &chainntnfs.TxConfirmation{BlockHeight: 124}
+	upstream.confChan <- newConf
+	require.Equal(t, newConf, receive(t, conf1))
+	require.Equal(t, newConf, receive(t, conf2))
+}
+
+// TestCancellation tests that the upstream subscription is only cancelled
+// once all its subscribers are gone.
+func TestCancellation(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotifier{}
+	m := NewMultiplexer(notifier)
+
+	outpoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	_, _, err := m.RegisterSpendNtfn(ctx1, &outpoint, nil, 100)
+	require.NoError(t, err)
+	spend2, _, err := m.RegisterSpendNtfn(ctx2, &outpoint, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, notifier.numRegistrations())
+	requireStats(t, m, 2, 1)
+
+	upstream := notifier.last()
+
+	cancel1()
+	requireStats(t, m, 1, 1)
+	require.NoError(t, upstream.ctx.Err())
+
+	// The remaining subscriber still receives the spend.
+	spend := &chainntnfs.SpendDetail{SpendingHeight: 123}
+	upstream.spendChan <- spend
+	require.Equal(t, spend, receive(t, spend2))
+	requireStats(t, m, 0, 0)
+
+	// Cancelling the last subscriber cancels the upstream subscription.
+	ctx3, cancel3 := context.WithCancel(context.Background())
+	_, _, err = m.RegisterSpendNtfn(ctx3, &outpoint, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, 2, notifier.numRegistrations())
+
+	upstream = notifier.last()
+	cancel3()
+	receive(t, upstream.ctx.Done())
+	requireStats(t, m, 0, 0)
+}
+
+// TestBlockEpochs tests that all block epoch subscriptions share a single
+// upstream subscription.
+func TestBlockEpochs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	notifier := &mockNotifier{}
+	m := NewMultiplexer(notifier)
+
+	blocks1, _, err := m.RegisterBlockEpochNtfn(ctx)
+	require.NoError(t, err)
+
+	upstream := notifier.last()
+	upstream.blockChan <- 100
+	require.EqualValues(t, 100, receive(t, blocks1))
+
+	// A late subscriber receives the current height right away, just like
+	// a new upstream subscription would.
+	blocks2, _, err := m.RegisterBlockEpochNtfn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, notifier.numRegistrations())
+	require.EqualValues(t, 100, receive(t, blocks2))
+
+	for _, height := range []int32{101, 102} {
+		upstream.blockChan <- height
+		require.Equal(t, height, receive(t, blocks1))
+		require.Equal(t, height, receive(t, blocks2))
+	}
+}
+
+// TestUpstreamError tests that an error of the upstream subscription is
+// delivered to all its subscribers.
+func TestUpstreamError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	notifier := &mockNotifier{}
+	m := NewMultiplexer(notifier)
+
+	errChans := fn.Map([]int{0, 1, 2}, func(int) chan error {
+		_, errChan, err := m.RegisterBlockEpochNtfn(ctx)
+		require.NoError(t, err)
+
+		return errChan
+	})
+	require.Equal(t, 1, notifier.numRegistrations())
+
+	upstreamErr := fmt.Errorf("stream closed")
+	notifier.last().errChan <- upstreamErr
+	for _, errChan := range errChans {
+		require.ErrorIs(t, receive(t, errChan), upstreamErr)
+	}
+	requireStats(t, m, 0, 0)
+
+	// A new subscription registers a new upstream subscription.
+	_, _, err := m.RegisterBlockEpochNtfn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, notifier.numRegistrations())
+}
@@ -0,0 +1,96 @@
+package chainmux
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// Notifier is a source of chain notifications. It is implemented by the
+// multiplexer itself as well as by the lnd chain notifier it multiplexes.
+type Notifier interface {
+	// RegisterConfirmationsNtfn registers an intent to be notified once
+	// txid reaches numConfs confirmations. If the re-org channel is set,
+	// the notification stays active after the first confirmation and the
+	// channel is sent on if the transaction is re-organized out of the
+	// chain.
+	RegisterConfirmationsNtfn(ctx context.Context, txid *chainhash.Hash,
+		pkScript []byte, numConfs, heightHint uint32,
+		includeBlock bool,
+		reOrgChan chan struct{}) (chan *chainntnfs.TxConfirmation,
+		chan error, error)
+
+	// RegisterSpendNtfn registers an intent to be notified once the given
+	// outpoint is spent on-chain.
+	RegisterSpendNtfn(ctx context.Context, outpoint *wire.OutPoint,
+		pkScript []byte,
+		heightHint uint32) (chan *chainntnfs.SpendDetail, chan error,
+		error)
+
+	// RegisterBlockEpochNtfn registers an intent to be notified of each
+	// new block connected to the main chain. The height of the current
+	// tip is delivered right after registration.
+	RegisterBlockEpochNtfn(ctx context.Context) (chan int32, chan error,
+		error)
+}
+
+// LndNotifier is an implementation of the Notifier interface backed by the
+// chain notifier of an active remote lnd node.
+type LndNotifier struct {
+	client lndclient.ChainNotifierClient
+}
+
+// NewLndNotifier creates a new notifier from an lnd chain notifier client.
+func NewLndNotifier(client lndclient.ChainNotifierClient) *LndNotifier {
+	return &LndNotifier{
+		client: client,
+	}
+}
+
+// RegisterConfirmationsNtfn registers an intent to be notified once
+// txid reaches numConfs confirmations.
+func (l *LndNotifier) RegisterConfirmationsNtfn(ctx context.Context,
+	txid *chainhash.Hash, pkScript []byte, numConfs, heightHint uint32,
+	includeBlock bool,
+	reOrgChan chan struct{}) (chan *chainntnfs.TxConfirmation, chan error,
+	error) {
+
+	var opts []lndclient.NotifierOption
+	if includeBlock {
+		opts = append(opts, lndclient.WithIncludeBlock())
+	}
+	if reOrgChan != nil {
+		opts = append(opts, lndclient.WithReOrgChan(reOrgChan))
+	}
+
+	return l.client.RegisterConfirmationsNtfn(
+		ctx, txid, pkScript, int32(numConfs), int32(heightHint),
+		opts...,
+	)
+}
+
+// RegisterSpendNtfn registers an intent to be notified once the given
+// outpoint is spent on-chain.
+func (l *LndNotifier) RegisterSpendNtfn(ctx context.Context,
+	outpoint *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (chan *chainntnfs.SpendDetail, chan error, error) {
+
+	return l.client.RegisterSpendNtfn(
+		ctx, outpoint, pkScript, int32(heightHint),
+	)
+}
+
+// RegisterBlockEpochNtfn registers an intent to be notified of each new block
+// connected to the main chain.
+func (l *LndNotifier) RegisterBlockEpochNtfn(
+	ctx context.Context) (chan int32, chan error, error) {
+
+	return l.client.RegisterBlockEpochNtfn(ctx)
+}
+
+// A compile-time assertion to ensure LndNotifier meets the Notifier
+// interface.
+var _ Notifier = (*LndNotifier)(nil)
@@ -0,0 +1,246 @@
+package chainmux
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriber is a single caller that subscribed to the events of a stream.
+type subscriber[T any] struct {
+	// ctx is the context of the subscriber. The subscriber is removed from
+	// the stream once it is cancelled.
+	ctx context.Context
+
+	// events is the channel the events of the stream are delivered on.
+	events chan T
+
+	// errChan is the channel an error of the upstream subscription is
+	// delivered on.
+	errChan chan error
+
+	// reOrgChan is the optional channel the subscriber is notified on
+	// about re-orgs.
+	reOrgChan chan struct{}
+}
+
+// stream is a single upstream notification subscription whose events are
+// fanned out to one or more subscribers.
+type stream[T any] struct {
+	// mu is the mutex of the multiplexer that owns the stream. It guards
+	// all fields below.
+	mu *sync.Mutex
+
+	// heightHint is the height hint the upstream subscription was
+	// registered with.
+	heightHint uint32
+
+	// oneShot indicates that the upstream subscription finishes after
+	// delivering its first event.
+	oneShot bool
+
+	// replay indicates that the latest event should be delivered to
+	// subscribers that join after it was received. This is used for
+	// streams whose upstream subscription delivers the current state right
+	// after registration.
+	replay bool
+
+	// latest is the latest event received from upstream. It is only set
+	// if replay is true and is reset on a re-org.
+	latest T
+
+	// haveLatest indicates whether latest is set.
+	haveLatest bool
+
+	// nextID is the ID the next subscriber is assigned.
+	nextID uint64
+
+	// subscribers are the current subscribers of the stream.
+	subscribers map[uint64]*subscriber[T]
+
+	// finished is set once the stream no longer accepts subscribers.
+	finished bool
+
+	// done is closed once the stream is finished.
+	done chan struct{}
+
+	// remove removes the stream from the multiplexer once it is finished.
+	remove func()
+
+	// cancel cancels the upstream subscription.
+	cancel context.CancelFunc
+}
+
+// newStream creates a new stream for an upstream subscription that is
+// cancelled with the given cancel function.
+func newStream[T any](mu *sync.Mutex, heightHint uint32, oneShot,
+	replay bool, cancel context.CancelFunc) *stream[T] {
+
+	return &stream[T]{
+		mu:          mu,
+		heightHint:  heightHint,
+		oneShot:     oneShot,
+		replay:      replay,
+		subscribers: make(map[uint64]*subscriber[T]),
+		done:        make(chan struct{}),
+		remove:      func() {},
+		cancel:      cancel,
+	}
+}
+
+// joinLocked adds a new subscriber to the stream and returns it. The stream
+// must not be finished yet.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *stream[T]) joinLocked(ctx context.Context,
+	reOrgChan chan struct{}) *subscriber[T] {
+
+	sub := &subscriber[T]{
+		ctx:       ctx,
+		events:    make(chan T, 1),
+		errChan:   make(chan error, 1),
+		reOrgChan: reOrgChan,
+	}
+
+	// The channel was just created, so replaying the latest event can't
+	// block.
+	if s.haveLatest {
+		sub.events <- s.latest
+	}
+
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = sub
+
+	go s.watchSubscriber(id, sub)
+
+	return sub
+}
+
+// watchSubscriber removes the subscriber with the given ID once its context
+// is cancelled. If it was the last subscriber, the upstream subscription is
+// cancelled as well.
+func (s *stream[T]) watchSubscriber(id uint64, sub *subscriber[T]) {
+	select {
+	case <-sub.ctx.Done():
+	case <-s.done:
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscribers, id)
+	if len(s.subscribers) == 0 && !s.finished {
+		s.finishLocked()
+		s.cancel()
+	}
+}
+
+// finishLocked marks the stream as finished and removes it from the
+// multiplexer, so new subscribers register a new upstream subscription.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *stream[T]) finishLocked() {
+	s.finished = true
+	s.remove()
+	close(s.done)
+}
+
+// snapshotLocked returns the current subscribers of the stream.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *stream[T]) snapshotLocked() []*subscriber[T] {
+	subs := make([]*subscriber[T], 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		subs = append(subs, sub)
+	}
+
+	return subs
+}
+
+// run forwards the events of the upstream subscription to the subscribers of
+// the stream until it finishes or the upstream subscription is cancelled.
+//
+// NOTE: This method must be run as a goroutine.
+func (s *stream[T]) run(ctx context.Context, events <-chan T,
+	errChan <-chan error, reOrgChan <-chan struct{}) {
+
+	for {
+		select {
+		case event := <-events:
+			s.mu.Lock()
+			if s.replay {
+				s.latest = event
+				s.haveLatest = true
+			}
+			if s.oneShot && !s.finished {
+				s.finishLocked()
+			}
+			subs := s.snapshotLocked()
+			s.mu.Unlock()
+
+			// A one-shot stream is only cancelled below, once
+			// we've delivered its single event.
+			for _, sub := range subs {
+				select {
+				case sub.events <- event:
+				case <-sub.ctx.Done():
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if s.oneShot {
+				s.cancel()
+				return
+			}
+
+		case <-reOrgChan:
+			s.mu.Lock()
+			var zero T
+			s.latest = zero
+			s.haveLatest = false
+			subs := s.snapshotLocked()
+			s.mu.Unlock()
+
+			for _, sub := range subs {
+				if sub.reOrgChan == nil {
+					continue
+				}
+
+				select {
+				case sub.reOrgChan <- struct{}{}:
+				case <-sub.ctx.Done():
+				case <-ctx.Done():
+					return
+				}
+			}
+
+		case err := <-errChan:
+			// If we cancelled the upstream subscription ourselves,
+			// the error is just the result of that.
+			if ctx.Err() != nil {
+				return
+			}
+
+			s.mu.Lock()
+			if !s.finished {
+				s.finishLocked()
+			}
+			subs := s.snapshotLocked()
+			s.mu.Unlock()
+
+			// The error channel of each subscriber is only ever
+			// sent on here, so this can't block.
+			for _, sub := range subs {
+				sub.errChan <- err
+			}
+
+			s.cancel()
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
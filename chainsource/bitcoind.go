@@ -0,0 +1,110 @@
+package chainsource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// BitcoindSource is a header source that queries the RPC interface of a
+// bitcoind node.
+type BitcoindSource struct {
+	client *rpcclient.Client
+}
+
+// A compile-time assertion to ensure BitcoindSource meets the HeaderSource
+// interface.
+var _ HeaderSource = (*BitcoindSource)(nil)
+
+// NewBitcoindSource creates a new header source that connects to the bitcoind
+// RPC server of the given config.
+func NewBitcoindSource(cfg *BitcoindConfig) (*BitcoindSource, error) {
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         cfg.RPCHost,
+		User:         cfg.RPCUser,
+		Pass:         cfg.RPCPass,
+		DisableTLS:   !cfg.RPCTLS,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create bitcoind RPC client: "+
+			"%w", err)
+	}
+
+	return &BitcoindSource{
+		client: client,
+	}, nil
+}
+
+// BlockHash returns the hash of the block at the given height of the main
+// chain.
+func (b *BitcoindSource) BlockHash(ctx context.Context,
+	height uint32) (chainhash.Hash, error) {
+
+	result := b.client.GetBlockHashAsync(int64(height))
+
+	var (
+		hash *chainhash.Hash
+		err  error
+	)
+	if err := waitForResult(ctx, func() {
+		hash, err = result.Receive()
+	}); err != nil {
+		return chainhash.Hash{}, err
+	}
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("unable to get block hash "+
+			"at height %d: %w", height, err)
+	}
+
+	return *hash, nil
+}
+
+// BlockHeader returns the header of the block with the given hash.
+func (b *BitcoindSource) BlockHeader(ctx context.Context,
+	hash chainhash.Hash) (*wire.BlockHeader, error) {
+
+	result := b.client.GetBlockHeaderAsync(&hash)
+
+	var (
+		header *wire.BlockHeader
+		err    error
+	)
+	if err := waitForResult(ctx, func() {
+		header, err = result.Receive()
+	}); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get block header %s: %w",
+			hash, err)
+	}
+
+	return header, nil
+}
+
+// Stop shuts down the RPC client.
+func (b *BitcoindSource) Stop() {
+	b.client.Shutdown()
+}
+
+// waitForResult waits for the given receive function to return, unless the
+// context is canceled first.
+func waitForResult(ctx context.Context, receive func()) error {
+	done := make(chan struct{})
+	go func() {
+		receive()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,122 @@
+package chainsource
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// BackendLnd is the chain backend that uses the connected lnd node to
+	// look up block headers.
+	BackendLnd = "lnd"
+
+	// BackendBitcoind is the chain backend that uses the RPC interface of
+	// a bitcoind node to look up block headers.
+	BackendBitcoind = "bitcoind"
+
+	// BackendEsplora is the chain backend that uses the HTTP API of an
+	// Esplora instance to look up block headers.
+	BackendEsplora = "esplora"
+
+	// DefaultTimeout is the default timeout for a single lookup against
+	// the chain backend.
+	DefaultTimeout = 30 * time.Second
+)
+
+// BitcoindConfig holds the configuration options for the bitcoind chain
+// backend.
+//
+// nolint: lll
+type BitcoindConfig struct {
+	RPCHost string `long:"rpchost" description:"The host:port of the bitcoind RPC server"`
+
+	RPCUser string `long:"rpcuser" description:"The username for the bitcoind RPC server"`
+
+	RPCPass string `long:"rpcpass" description:"The password for the bitcoind RPC server"`
+
+	RPCTLS bool `long:"rpctls" description:"If true, TLS is used to connect to the bitcoind RPC server"`
+}
+
+// EsploraConfig holds the configuration options for the Esplora chain
+// backend.
+//
+// nolint: lll
+type EsploraConfig struct {
+	URL string `long:"url" description:"The base URL of the Esplora HTTP API, for example https://blockstream.info/api"`
+}
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// chain backend that is used to verify the block headers of proofs.
+//
+// nolint: lll
+type CliConfig struct {
+	Backend string `long:"backend" description:"The chain backend used to verify the block headers of proofs; lnd uses the connected lnd node, bitcoind and esplora query the given endpoint directly" choice:"lnd" choice:"bitcoind" choice:"esplora"`
+
+	Timeout time.Duration `long:"timeout" description:"The timeout for a single lookup against the chain backend"`
+
+	Bitcoind *BitcoindConfig `group:"bitcoind" namespace:"bitcoind"`
+
+	Esplora *EsploraConfig `group:"esplora" namespace:"esplora"`
+}
+
+// DefaultCliConfig returns the default chain backend configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		Backend:  BackendLnd,
+		Timeout:  DefaultTimeout,
+		Bitcoind: &BitcoindConfig{},
+		Esplora:  &EsploraConfig{},
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if c.Timeout <= 0 {
+		return fmt.Errorf("chain backend timeout must be positive")
+	}
+
+	switch c.Backend {
+	case BackendLnd:
+		return nil
+
+	case BackendBitcoind:
+		if c.Bitcoind.RPCHost == "" {
+			return fmt.Errorf("bitcoind RPC host must be set")
+		}
+
+		return nil
+
+	case BackendEsplora:
+		u, err := url.Parse(c.Esplora.URL)
+		if err != nil {
+			return fmt.Errorf("invalid Esplora URL: %w", err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("Esplora URL must use http or https")
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown chain backend: %v", c.Backend)
+	}
+}
+
+// NewHeaderSource creates the header source for the configured chain backend.
+// The lnd backend has no direct header source, so an error is returned for
+// it.
+func (c *CliConfig) NewHeaderSource() (HeaderSource, error) {
+	switch c.Backend {
+	case BackendBitcoind:
+		return NewBitcoindSource(c.Bitcoind)
+
+	case BackendEsplora:
+		return NewEsploraSource(c.Esplora.URL, c.Timeout), nil
+
+	default:
+		return nil, fmt.Errorf("chain backend %v has no direct header "+
+			"source", c.Backend)
+	}
+}
@@ -0,0 +1,124 @@
+package chainsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// maxResponseSize is the maximum size of a response body we read from
+	// an Esplora instance. A block header is 80 bytes, so its hex encoding
+	// easily fits.
+	maxResponseSize = 1024
+)
+
+// EsploraSource is a header source that queries the HTTP API of an Esplora
+// instance.
+type EsploraSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// A compile-time assertion to ensure EsploraSource meets the HeaderSource
+// interface.
+var _ HeaderSource = (*EsploraSource)(nil)
+
+// NewEsploraSource creates a new header source for the Esplora API at the
+// given base URL.
+func NewEsploraSource(baseURL string, timeout time.Duration) *EsploraSource {
+	return &EsploraSource{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// BlockHash returns the hash of the block at the given height of the main
+// chain.
+func (e *EsploraSource) BlockHash(ctx context.Context,
+	height uint32) (chainhash.Hash, error) {
+
+	body, err := e.get(
+		ctx, "/block-height/"+strconv.FormatUint(uint64(height), 10),
+	)
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("unable to get block hash "+
+			"at height %d: %w", height, err)
+	}
+
+	hash, err := chainhash.NewHashFromStr(body)
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("invalid block hash at "+
+			"height %d: %w", height, err)
+	}
+
+	return *hash, nil
+}
+
+// BlockHeader returns the header of the block with the given hash.
+func (e *EsploraSource) BlockHeader(ctx context.Context,
+	hash chainhash.Hash) (*wire.BlockHeader, error) {
+
+	body, err := e.get(ctx, "/block/"+hash.String()+"/header")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get block header %s: %w",
+			hash, err)
+	}
+
+	headerBytes, err := hex.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block header %s: %w", hash,
+			err)
+	}
+
+	var header wire.BlockHeader
+	err = header.Deserialize(bytes.NewReader(headerBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode block header %s: %w",
+			hash, err)
+	}
+
+	return &header, nil
+}
+
+// get fetches the given path of the Esplora API and returns the trimmed
+// response body.
+func (e *EsploraSource) get(ctx context.Context, path string) (string,
+	error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, e.baseURL+path, nil,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
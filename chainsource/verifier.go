@@ -0,0 +1,72 @@
+package chainsource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+// HeaderSource is a source of block data of the main chain that can be
+// queried without a full lnd node.
+type HeaderSource interface {
+	// BlockHash returns the hash of the block at the given height of the
+	// main chain.
+	BlockHash(ctx context.Context, height uint32) (chainhash.Hash, error)
+
+	// BlockHeader returns the header of the block with the given hash.
+	BlockHeader(ctx context.Context,
+		hash chainhash.Hash) (*wire.BlockHeader, error)
+}
+
+// NewHeaderVerifier returns a header verifier that checks block headers
+// against the given header source. The checks are the same as the ones done
+// with the lnd chain backend.
+func NewHeaderVerifier(ctx context.Context, src HeaderSource,
+	timeout time.Duration) proof.HeaderVerifier {
+
+	return func(header wire.BlockHeader, height uint32) error {
+		ctxt, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		blockHash := header.BlockHash()
+
+		// Proofs without an assigned height can only be checked for
+		// the existence of the block.
+		if height == 0 {
+			_, err := src.BlockHeader(ctxt, blockHash)
+			return err
+		}
+
+		// Ensure that the block hash matches the hash of the block
+		// found at the given height.
+		hash, err := src.BlockHash(ctxt, height)
+		if err != nil {
+			return err
+		}
+
+		if hash != blockHash {
+			return fmt.Errorf("block hash and block height "+
+				"mismatch; (height: %d, hashAtHeight: %s, "+
+				"expectedHash: %s)", height, hash, blockHash)
+		}
+
+		// Ensure that the block header the source knows for the hash
+		// is the one we were given.
+		srcHeader, err := src.BlockHeader(ctxt, blockHash)
+		if err != nil {
+			return err
+		}
+
+		if srcHeader.BlockHash() != blockHash {
+			return fmt.Errorf("chain backend returned header %s "+
+				"for block %s", srcHeader.BlockHash(),
+				blockHash)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package chainsource
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSource is a header source that serves a fixed set of headers.
+type mockSource struct {
+	headers map[uint32]wire.BlockHeader
+}
+
+// BlockHash returns the hash of the block at the given height.
+func (m *mockSource) BlockHash(_ context.Context,
+	height uint32) (chainhash.Hash, error) {
+
+	header, ok := m.headers[height]
+	if !ok {
+		return chainhash.Hash{}, fmt.Errorf("unknown height %d", height)
+	}
+
+	return header.BlockHash(), nil
+}
+
+// BlockHeader returns the header of the block with the given hash.
+func (m *mockSource) BlockHeader(_ context.Context,
+	hash chainhash.Hash) (*wire.BlockHeader, error) {
+
+	for _, header := range m.headers {
+		if header.BlockHash() == hash {
+			return &header, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown block %s", hash)
+}
+
+// TestHeaderVerifier tests that the header verifier accepts headers that are
+// part of the chain of the header source and rejects all others.
+func TestHeaderVerifier(t *testing.T) {
+	t.Parallel()
+
+	genesis := chaincfg.MainNetParams.GenesisBlock.Header
+	other := genesis
+	other.Nonce++
+
+	src := &mockSource{
+		headers: map[uint32]wire.BlockHeader{
+			1: genesis,
+		},
+	}
+	verifier := NewHeaderVerifier(context.Background(), src, time.Second)
+
+	// The header at the correct height is valid, as is the header without
+	// an assigned height.
+	require.NoError(t, verifier(genesis, 1))
+	require.NoError(t, verifier(genesis, 0))
+
+	// The header at a different height is invalid.
+	err := verifier(other, 1)
+	require.ErrorContains(t, err, "mismatch")
+
+	// An unknown header or height is invalid.
+	require.Error(t, verifier(other, 0))
+	require.Error(t, verifier(genesis, 2))
+}
+
+// TestEsploraSource tests that the Esplora header source queries and decodes
+// the block hash and header endpoints correctly.
+func TestEsploraSource(t *testing.T) {
+	t.Parallel()
+
+	header := chaincfg.MainNetParams.GenesisBlock.Header
+	hash := header.BlockHash()
+
+	var headerBuf strings.Builder
+	require.NoError(t, header.Serialize(hex.NewEncoder(&headerBuf)))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/block-height/0", func(w http.ResponseWriter,
+		_ *http.Request) {
+
+		_, _ = w.Write([]byte(hash.String()))
+	})
+	mux.HandleFunc("/api/block/"+hash.String()+"/header", func(
+		w http.ResponseWriter, _ *http.Request) {
+
+		_, _ = w.Write([]byte(headerBuf.String() + "\n"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	src := NewEsploraSource(server.URL+"/api/", time.Second)
+
+	srcHash, err := src.BlockHash(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, hash, srcHash)
+
+	srcHeader, err := src.BlockHeader(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, header, *srcHeader)
+
+	// Unknown blocks result in an error.
+	_, err = src.BlockHash(ctx, 1)
+	require.ErrorContains(t, err, "unexpected status 404")
+
+	_, err = src.BlockHeader(ctx, chainhash.Hash{})
+	require.Error(t, err)
+}
+
+// TestCliConfigValidate tests the validation of the chain backend config.
+func TestCliConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultCliConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Backend = BackendBitcoind
+	require.ErrorContains(t, cfg.Validate(), "RPC host")
+
+	cfg.Bitcoind.RPCHost = "localhost:8332"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Backend = BackendEsplora
+	require.ErrorContains(t, cfg.Validate(), "http or https")
+
+	cfg.Esplora.URL = "https://blockstream.info/api"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Timeout = 0
+	require.ErrorContains(t, cfg.Validate(), "timeout")
+}
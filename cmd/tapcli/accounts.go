@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+)
+
+var accountCommands = []cli.Command{
+	{
+		Name:      "accounts",
+		ShortName: "ac",
+		Usage:     "Interact with named asset accounts.",
+		Category:  "Assets",
+		Subcommands: []cli.Command{
+			newAccountCommand,
+			listAccountsCommand,
+		},
+	},
+}
+
+const (
+	accountName = "account"
+
+	tenantName = "tenant"
+
+	tenantMacaroonName = "tenant_macaroon"
+)
+
+var newAccountCommand = cli.Command{
+	Name:      "new",
+	ShortName: "n",
+	Usage:     "create a new named asset account",
+	Description: `
+	Create a new named asset account. Each account derives the keys of its
+	addresses and transfers from its own key family, which gives it a
+	separate key scope and balance view. The account can then be passed to
+	the --account flag of the 'addrs new', 'assets send' and
+	'assets balance' commands.
+
+	If --tenant is set, the account is created for a tenant and a macaroon
+	is baked for it with a root key of its own. All calls made with the
+	tenant macaroon are restricted to the assets of the account.
+	`,
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  tenantName,
+			Usage: "create the account for a tenant",
+		},
+		cli.StringFlag{
+			Name: tenantMacaroonName,
+			Usage: "the file to save the macaroon of the tenant " +
+				"to; required if --tenant is set",
+		},
+	},
+	Action: newAccount,
+}
+
+func newAccount(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	tenant := ctx.Bool(tenantName)
+	macFile := lncfg.CleanAndExpandPath(ctx.String(tenantMacaroonName))
+	switch {
+	case tenant && macFile == "":
+		return fmt.Errorf("--%s is required for a tenant",
+			tenantMacaroonName)
+
+	case !tenant && macFile != "":
+		return fmt.Errorf("--%s can only be set for a tenant",
+			tenantMacaroonName)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.NewAccount(ctxc, &wrpc.NewAccountRequest{
+		Name:   ctx.Args().First(),
+		Tenant: tenant,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create account: %w", err)
+	}
+
+	if tenant {
+		macBytes, err := hex.DecodeString(resp.TenantMacaroon)
+		if err != nil {
+			return fmt.Errorf("unable to decode tenant macaroon: "+
+				"%w", err)
+		}
+
+		if err := os.WriteFile(macFile, macBytes, 0600); err != nil {
+			return fmt.Errorf("unable to write macaroon file %s: "+
+				"%w", macFile, err)
+		}
+
+		// The macaroon is a secret of the tenant, so we don't print
+		// it along with the account.
+		resp.TenantMacaroon = ""
+		fmt.Printf("Tenant macaroon saved to %s\n", macFile)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listAccountsCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "list all named asset accounts",
+	Action:    listAccounts,
+}
+
+func listAccounts(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListAccounts(ctxc, &wrpc.ListAccountsRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list accounts: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -21,6 +21,7 @@ var addrCommands = []cli.Command{
 			newAddrCommand,
 			queryAddrsCommand,
 			decodeAddrCommand,
+			inspectAddrCommand,
 			receivesAddrCommand,
 		},
 	},
@@ -29,9 +30,13 @@ var addrCommands = []cli.Command{
 const (
 	groupKeyName         = "group_key"
 	amtName              = "amt"
+	displayAmtName       = "display_amt"
 	assetVersionName     = "asset_version"
 	addressVersionName   = "address_version"
 	proofCourierAddrName = "proof_courier_addr"
+	fallbackCourierName  = "fallback_proof_courier_addr"
+	expiryName           = "expiry"
+	reusableName         = "reusable"
 )
 
 var newAddrCommand = cli.Command{
@@ -49,6 +54,13 @@ var newAddrCommand = cli.Command{
 			Name:  amtName,
 			Usage: "the amt of the asset to receive",
 		},
+		cli.StringFlag{
+			Name: displayAmtName,
+			Usage: "the amt of the asset to receive in decimal " +
+				"display units (e.g. 1.25); can be used " +
+				"instead of --amt if the daemon is " +
+				"configured to accept decimal display amounts",
+		},
 		cli.Uint64Flag{
 			Name:  assetVersionName,
 			Usage: "the asset version of the asset to receive",
@@ -64,6 +76,32 @@ var newAddrCommand = cli.Command{
 				"default proof courier should be " +
 				"overwritten; format: protocol://host:port",
 		},
+		cli.StringSliceFlag{
+			Name: fallbackCourierName,
+			Usage: "(optional) the address of a fallback proof " +
+				"courier the sender can deliver the proof " +
+				"to, can be specified multiple times; " +
+				"requires a V2 address",
+		},
+		cli.DurationFlag{
+			Name: expiryName,
+			Usage: "(optional) the duration after which the " +
+				"address expires and should no longer be " +
+				"sent to (e.g. 24h); requires a V2 address",
+		},
+		cli.BoolFlag{
+			Name: reusableName,
+			Usage: "(optional) create a reusable address that " +
+				"can receive multiple deposits without " +
+				"on-chain address reuse; requires a V2 " +
+				"address",
+		},
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account the " +
+				"address should belong to; the default " +
+				"account is used if not set",
+		},
 	},
 	Action: newAddr,
 }
@@ -96,17 +134,31 @@ func newAddr(ctx *cli.Context) error {
 		addrVersion = taprpc.AddrVersion_ADDR_VERSION_V0
 	case "v1", "V1":
 		addrVersion = taprpc.AddrVersion_ADDR_VERSION_V1
+	case "v2", "V2":
+		addrVersion = taprpc.AddrVersion_ADDR_VERSION_V2
 	default:
 		return fmt.Errorf("unknown address version: %s",
 			ctx.String(addressVersionName))
 	}
 
+	var expiry int64
+	if ctx.IsSet(expiryName) {
+		expiry = time.Now().Add(ctx.Duration(expiryName)).Unix()
+	}
+
 	addr, err := client.NewAddr(ctxc, &taprpc.NewAddrRequest{
 		AssetId:          assetID,
 		Amt:              ctx.Uint64(amtName),
+		DisplayAmt:       ctx.String(displayAmtName),
 		AssetVersion:     assetVersion,
 		ProofCourierAddr: ctx.String(proofCourierAddrName),
 		AddressVersion:   addrVersion,
+		Account:          ctx.String(accountName),
+		FallbackProofCourierAddrs: ctx.StringSlice(
+			fallbackCourierName,
+		),
+		ExpiryTimestamp: expiry,
+		Reusable:        ctx.Bool(reusableName),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to make addr: %w", err)
@@ -236,6 +288,57 @@ func decodeAddr(ctx *cli.Context) error {
 	return nil
 }
 
+var inspectAddrCommand = cli.Command{
+	Name:      "inspect",
+	ShortName: "i",
+	ArgsUsage: "[--addr | addr]",
+	Usage: "Fully decode a taproot asset addr and report any problems " +
+		"found with it",
+	Description: `
+	Decodes a taproot asset address, even if it is for a different network
+	than the one this node runs on. If the asset of the address is not
+	known to this node, its genesis information is fetched from the
+	universe servers of the federation. Problems that would prevent the
+	address from being used, like an unknown asset, an expired address or
+	an invalid proof courier address, are shown as warnings.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  addrName,
+			Usage: "the address to inspect",
+		},
+	},
+	Action: inspectAddr,
+}
+
+func inspectAddr(ctx *cli.Context) error {
+	var addr string
+	switch {
+	case ctx.String(addrName) != "":
+		addr = ctx.String(addrName)
+
+	case len(ctx.Args()) > 0:
+		addr = ctx.Args().First()
+
+	default:
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.InspectAddr(ctxc, &taprpc.InspectAddrRequest{
+		Addr: addr,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to inspect addr: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var receivesAddrCommand = cli.Command{
 	Name:      "receives",
 	ShortName: "r",
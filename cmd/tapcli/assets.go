@@ -2,15 +2,21 @@ package main
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"strconv"
+	"time"
 
 	taprootassets "github.com/lightninglabs/taproot-assets"
+	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapcfg"
 	"github.com/lightninglabs/taproot-assets/taprpc"
 	"github.com/lightninglabs/taproot-assets/taprpc/mintrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/urfave/cli"
 )
@@ -25,13 +31,18 @@ var assetsCommands = []cli.Command{
 			mintAssetCommand,
 			listAssetsCommand,
 			listUtxosCommand,
+			exportAnchorDescriptorsCommand,
 			listGroupsCommand,
 			listAssetBalancesCommand,
 			sendAssetsCommand,
 			burnAssetsCommand,
 			listBurnsCommand,
 			listTransfersCommand,
+			exportLedgerCommand,
 			fetchMetaCommand,
+			metaBlobCommand,
+			recoverAssetsCommand,
+			consolidateAssetsCommand,
 		},
 	},
 }
@@ -51,6 +62,11 @@ var (
 	assetShowLeasedName          = "show_leased"
 	assetIncludeLeasedName       = "include_leased"
 	assetShowUnconfMintsName     = "show_unconfirmed_mints"
+	assetOnlySpentName           = "only_spent"
+	assetOnlyLeasedName          = "only_leased"
+	assetMinAmountName           = "min_amount"
+	assetSortDescendingName      = "descending"
+	assetListCursorName          = "cursor"
 	assetGroupKeyName            = "group_key"
 	assetGroupAnchorName         = "group_anchor"
 	anchorTxidName               = "anchor_txid"
@@ -60,9 +76,58 @@ var (
 	shortResponseName            = "short"
 	feeRateName                  = "sat_per_vbyte"
 	assetAmountName              = "amount"
+	assetDisplayAmountName       = "display_amount"
 	burnOverrideConfirmationName = "override_confirmation_destroy_assets"
+	skipProofDeliveryName        = "skip_proof_delivery"
+	additionalCourierAddrName    = "additional_proof_courier_addr"
+	proofCourierModeName         = "proof_courier_mode"
+	dryRunName                   = "dry_run"
+	vanityAssetName              = "vanity_asset"
+	vanityPrefixName             = "vanity_prefix"
+	vanityTimeoutName            = "vanity_timeout"
+	assetMetaSchemaIDName        = "meta_schema_id"
 )
 
+// vanityFlags are the flags to request a vanity asset ID when funding a batch.
+var vanityFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: vanityAssetName,
+		Usage: "the name of the asset in the batch whose ID should " +
+			"start with the vanity prefix",
+	},
+	cli.StringFlag{
+		Name: vanityPrefixName,
+		Usage: "if set, the hex prefix of at most 4 characters the " +
+			"ID of the vanity asset should start with",
+	},
+	cli.DurationFlag{
+		Name: vanityTimeoutName,
+		Usage: "the maximum time to search for a minting " +
+			"transaction that produces the vanity prefix",
+		Value: 30 * time.Second,
+	},
+}
+
+// parseVanityAssetID parses the vanity asset ID flags, returning nil if no
+// vanity prefix was requested.
+func parseVanityAssetID(ctx *cli.Context) (*mintrpc.VanityAssetId, error) {
+	if !ctx.IsSet(vanityPrefixName) {
+		return nil, nil
+	}
+
+	if !ctx.IsSet(vanityAssetName) {
+		return nil, fmt.Errorf("%s must be set together with %s",
+			vanityAssetName, vanityPrefixName)
+	}
+
+	timeout := ctx.Duration(vanityTimeoutName)
+	return &mintrpc.VanityAssetId{
+		AssetName:      ctx.String(vanityAssetName),
+		Prefix:         ctx.String(vanityPrefixName),
+		TimeoutSeconds: uint32(timeout.Seconds()),
+	}, nil
+}
+
 var mintAssetCommand = cli.Command{
 	Name:        "mint",
 	ShortName:   "m",
@@ -108,9 +173,17 @@ var mintAssetCommand = cli.Command{
 		cli.StringFlag{
 			Name: assetMetaTypeName,
 			Usage: "the type of the meta data for the asset, must " +
-				"be either: opaque or json",
+				"be either: opaque, json or blob_ref; the " +
+				"hex encoded meta of a blob_ref is returned " +
+				"by 'assets metablob upload'",
 			Value: "opaque",
 		},
+		cli.StringFlag{
+			Name: assetMetaSchemaIDName,
+			Usage: "the optional ID of the JSON schema the " +
+				"asset meta must conform to, e.g. " +
+				"taproot-assets/fungible-token/v1",
+		},
 		cli.BoolFlag{
 			Name: assetNewGroupedAssetName,
 			Usage: "if true, then the asset supports on going " +
@@ -142,10 +215,14 @@ var mintAssetCommand = cli.Command{
 	Action: mintAsset,
 	Subcommands: []cli.Command{
 		listBatchesCommand,
+		mintCollectionCommand,
 		fundBatchCommand,
 		sealBatchCommand,
+		exportGroupPsbtsCommand,
+		submitGroupSigsCommand,
 		finalizeBatchCommand,
 		cancelBatchCommand,
+		metaSchemasCommand,
 	},
 }
 
@@ -173,6 +250,9 @@ func parseMetaType(metaType string) (taprpc.AssetMetaType, error) {
 	case "json":
 		return taprpc.AssetMetaType_META_TYPE_JSON, nil
 
+	case "blob_ref":
+		return taprpc.AssetMetaType_META_TYPE_BLOB_REF, nil
+
 	// Otherwise, this is a custom meta type, we may not understand it, but
 	// we want to support specifying arbitrary meta types.
 	default:
@@ -274,6 +354,21 @@ func mintAsset(ctx *cli.Context) error {
 
 	// One of meta bytes or the meta path can be set.
 	switch {
+	// A blob reference is binary data, so it is passed hex encoded, as
+	// returned by the upload command.
+	case metaType == taprpc.AssetMetaType_META_TYPE_BLOB_REF &&
+		ctx.String(assetMetaBytesName) != "":
+
+		blobRef, err := hex.DecodeString(ctx.String(assetMetaBytesName))
+		if err != nil {
+			return fmt.Errorf("invalid blob reference: %w", err)
+		}
+
+		assetMeta = &taprpc.AssetMeta{
+			Data: blobRef,
+			Type: metaType,
+		}
+
 	case ctx.String(assetMetaBytesName) != "":
 		assetMeta = &taprpc.AssetMeta{
 			Data: []byte(ctx.String(assetMetaBytesName)),
@@ -332,6 +427,7 @@ func mintAsset(ctx *cli.Context) error {
 			Name:            ctx.String(assetTagName),
 			AssetMeta:       assetMeta,
 			DecimalDisplay:  uint32(decDisplay),
+			MetaSchemaId:    ctx.String(assetMetaSchemaIDName),
 			Amount:          amount,
 			NewGroupedAsset: ctx.Bool(assetNewGroupedAssetName),
 			GroupedAsset:    ctx.Bool(assetGroupedAssetName),
@@ -351,6 +447,136 @@ func mintAsset(ctx *cli.Context) error {
 	return nil
 }
 
+const (
+	editionsFileName = "editions_file"
+)
+
+var mintCollectionCommand = cli.Command{
+	Name:  "collection",
+	Usage: "mint a collection of collectibles",
+	Description: `
+	Add a collection of collectibles to the pending batch. Each edition is
+	minted as a collectible with an amount of one, and all editions are
+	linked under a single asset group. Unless an existing group key is
+	given, the first edition anchors a new asset group.
+
+	The editions are read from a JSON file that contains a list of objects
+	with a "name" and an optional "meta" field. The meta field can be any
+	JSON value and is used as the JSON asset meta of the edition:
+
+	[{"name": "punk-1", "meta": {"image": "ipfs://..."}}, ...]
+
+	The batch is funded if it isn't funded yet, so the IDs of the editions'
+	assets can be returned.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  editionsFileName,
+			Usage: "the JSON file to read the editions from",
+		},
+		cli.StringFlag{
+			Name: assetGroupKeyName,
+			Usage: "the optional key of an existing group to " +
+				"mint the collection into",
+		},
+		cli.StringFlag{
+			Name: assetMetaSchemaIDName,
+			Usage: "the optional ID of the JSON schema the " +
+				"meta of every edition must conform to",
+		},
+		cli.Uint64Flag{
+			Name:  assetVersionName,
+			Usage: "the version of the assets to mint",
+		},
+		cli.Uint64Flag{
+			Name: feeRateName,
+			Usage: "if set, the fee rate in sat/vB to use for " +
+				"funding the batch",
+		},
+		cli.BoolFlag{
+			Name: shortResponseName,
+			Usage: "if true, then the current assets within the " +
+				"batch will not be returned in the response " +
+				"in order to avoid printing a large amount " +
+				"of data in case of large batches",
+		},
+	},
+	Action: mintCollection,
+}
+
+// collectionEdition is an edition of a collection as read from the editions
+// file.
+type collectionEdition struct {
+	Name string          `json:"name"`
+	Meta json.RawMessage `json:"meta"`
+}
+
+func mintCollection(ctx *cli.Context) error {
+	if ctx.String(editionsFileName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	editionsJSON, err := readFile(ctx.String(editionsFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read editions: %w", err)
+	}
+
+	var fileEditions []collectionEdition
+	if err := json.Unmarshal(editionsJSON, &fileEditions); err != nil {
+		return fmt.Errorf("unable to parse editions: %w", err)
+	}
+
+	editions := make([]*mintrpc.CollectionEdition, 0, len(fileEditions))
+	for _, fileEdition := range fileEditions {
+		edition := &mintrpc.CollectionEdition{
+			Name: fileEdition.Name,
+		}
+
+		if len(fileEdition.Meta) != 0 {
+			edition.AssetMeta = &taprpc.AssetMeta{
+				Data: fileEdition.Meta,
+				Type: taprpc.AssetMetaType_META_TYPE_JSON,
+			}
+		}
+
+		editions = append(editions, edition)
+	}
+
+	var groupKey []byte
+	if keyStr := ctx.String(assetGroupKeyName); keyStr != "" {
+		groupKey, err = hex.DecodeString(keyStr)
+		if err != nil {
+			return fmt.Errorf("invalid group key: %w", err)
+		}
+	}
+
+	feeRate, err := parseFeeRate(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getMintClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.MintCollection(ctxc, &mintrpc.MintCollectionRequest{
+		AssetVersion: taprpc.AssetVersion(
+			ctx.Uint64(assetVersionName),
+		),
+		GroupKey:      groupKey,
+		Editions:      editions,
+		MetaSchemaId:  ctx.String(assetMetaSchemaIDName),
+		FeeRate:       feeRate,
+		ShortResponse: ctx.Bool(shortResponseName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to mint collection: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var fundBatchCommand = cli.Command{
 	Name:  "fund",
 	Usage: "fund a batch",
@@ -358,8 +584,12 @@ var fundBatchCommand = cli.Command{
 	Attempt to fund a pending batch, or create a new funded batch if no
 	batch exists yet. This is only needed if batch funding should happen
 	separately from batch finalization. Otherwise, finalize can be used.
+
+	A vanity asset ID can be requested for an asset that is already part of
+	the batch. The ID then starts with the given hex prefix, which is found
+	by trying different wallet inputs for the minting transaction.
 	`,
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name: shortResponseName,
 			Usage: "if true, then the current assets within the " +
@@ -372,7 +602,7 @@ var fundBatchCommand = cli.Command{
 			Usage: "if set, the fee rate in sat/vB to use for " +
 				"the minting transaction",
 		},
-	},
+	}, vanityFlags...),
 	Action: fundBatch,
 }
 
@@ -386,9 +616,15 @@ func fundBatch(ctx *cli.Context) error {
 		return err
 	}
 
+	vanityAssetID, err := parseVanityAssetID(ctx)
+	if err != nil {
+		return err
+	}
+
 	resp, err := client.FundBatch(ctxc, &mintrpc.FundBatchRequest{
 		ShortResponse: ctx.Bool(shortResponseName),
 		FeeRate:       feeRate,
+		VanityAssetId: vanityAssetID,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to fund batch: %w", err)
@@ -436,11 +672,337 @@ func sealBatch(ctx *cli.Context) error {
 	return nil
 }
 
+const (
+	groupPsbtFileName = "group_psbt_file"
+)
+
+var exportGroupPsbtsCommand = cli.Command{
+	Name:  "grouppsbts",
+	Usage: "export the group witnesses of a batch to be signed externally",
+	Description: `
+	Export the asset group witnesses of the funded pending batch that must
+	be signed by an external signer, because the group internal key is not
+	held by the wallet. Each witness is exported as a PSBT to be signed by
+	the external signer, after which it can be submitted with the
+	submitgroupsigs command.
+	`,
+	Action: exportGroupPsbts,
+}
+
+func exportGroupPsbts(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getMintClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ExportGroupPsbts(
+		ctxc, &mintrpc.ExportGroupPsbtsRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to export group PSBTs: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var submitGroupSigsCommand = cli.Command{
+	Name:  "submitgroupsigs",
+	Usage: "seal a batch with externally signed group witnesses",
+	Description: `
+	Seal the pending batch with the asset group witnesses of the given
+	group PSBTs, which were exported with the grouppsbts command and signed
+	by an external signer. Afterwards, the batch can be finalized.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: groupPsbtFileName,
+			Usage: "the file to read a binary signed group PSBT " +
+				"from; can be specified multiple times",
+		},
+		cli.BoolFlag{
+			Name: shortResponseName,
+			Usage: "if true, then the current assets within the " +
+				"batch will not be returned in the response " +
+				"in order to avoid printing a large amount " +
+				"of data in case of large batches",
+		},
+	},
+	Action: submitGroupSigs,
+}
+
+func submitGroupSigs(ctx *cli.Context) error {
+	fileNames := ctx.StringSlice(groupPsbtFileName)
+	if len(fileNames) == 0 {
+		return fmt.Errorf("at least one signed group PSBT is required")
+	}
+
+	signedPsbts := make([][]byte, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		psbtBytes, err := readFile(fileName)
+		if err != nil {
+			return fmt.Errorf("unable to read PSBT: %w", err)
+		}
+
+		signedPsbts = append(signedPsbts, psbtBytes)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getMintClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SubmitGroupSignatures(
+		ctxc, &mintrpc.SubmitGroupSignaturesRequest{
+			ShortResponse:    ctx.Bool(shortResponseName),
+			SignedGroupPsbts: signedPsbts,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to submit group signatures: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+const (
+	blobFileName = "file"
+	blobHashName = "blob_hash"
+	blobSizeName = "size"
+)
+
+var metaBlobCommand = cli.Command{
+	Name:  "metablob",
+	Usage: "manage asset meta that is stored as a content-addressed blob",
+	Subcommands: []cli.Command{
+		uploadMetaBlobCommand,
+		fetchMetaBlobCommand,
+	},
+}
+
+var uploadMetaBlobCommand = cli.Command{
+	Name:  "upload",
+	Usage: "upload a meta blob to the local meta blob store",
+	Description: `
+	Upload metadata that is too large to be committed to directly. The
+	returned asset_meta only references the blob and can be used to mint an
+	asset that commits to the blob, by passing its hex encoded data as
+	--meta_bytes together with --meta_type=blob_ref. The blob is delivered
+	alongside the asset's proofs by universe RPC proof couriers.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  blobFileName,
+			Usage: "the file to read the blob from",
+		},
+	},
+	Action: uploadMetaBlob,
+}
+
+func uploadMetaBlob(ctx *cli.Context) error {
+	if ctx.String(blobFileName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	blobFile, err := os.Open(lncfg.CleanAndExpandPath(
+		ctx.String(blobFileName),
+	))
+	if err != nil {
+		return fmt.Errorf("unable to open blob file: %w", err)
+	}
+	defer blobFile.Close()
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.UploadMetaBlob(ctxc)
+	if err != nil {
+		return fmt.Errorf("unable to upload meta blob: %w", err)
+	}
+
+	err = proof.SendBlobChunks(blobFile, func(chunk []byte) error {
+		return stream.Send(&taprpc.UploadMetaBlobRequest{
+			Chunk: chunk,
+		})
+	})
+
+	// If the daemon rejects the blob while it is being sent, sending
+	// returns io.EOF and the actual error is returned when receiving the
+	// response.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to upload meta blob: %w", err)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("unable to upload meta blob: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var fetchMetaBlobCommand = cli.Command{
+	Name:  "fetch",
+	Usage: "fetch a meta blob from the local meta blob store",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  blobHashName,
+			Usage: "the hex encoded SHA-256 hash of the blob",
+		},
+		cli.Uint64Flag{
+			Name:  blobSizeName,
+			Usage: "the size of the blob in bytes",
+		},
+		cli.StringFlag{
+			Name: blobFileName,
+			Usage: "the file to write the blob to; use the dash " +
+				"character (-) to write to stdout",
+		},
+	},
+	Action: fetchMetaBlob,
+}
+
+func fetchMetaBlob(ctx *cli.Context) error {
+	if ctx.String(blobHashName) == "" || ctx.Uint64(blobSizeName) == 0 ||
+		ctx.String(blobFileName) == "" {
+
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	blobHash, err := hex.DecodeString(ctx.String(blobHashName))
+	if err != nil {
+		return fmt.Errorf("invalid blob hash: %w", err)
+	}
+
+	out := os.Stdout
+	if fileName := ctx.String(blobFileName); fileName != "-" {
+		out, err = os.Create(lncfg.CleanAndExpandPath(fileName))
+		if err != nil {
+			return fmt.Errorf("unable to create blob file: %w", err)
+		}
+		defer out.Close()
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.FetchMetaBlob(ctxc, &taprpc.FetchMetaBlobRequest{
+		BlobHash: blobHash,
+		Size:     ctx.Uint64(blobSizeName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to fetch meta blob: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+
+		case err != nil:
+			return fmt.Errorf("unable to fetch meta blob: %w", err)
+		}
+
+		if _, err := out.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("unable to write blob: %w", err)
+		}
+	}
+}
+
+const (
+	schemaIDName   = "schema_id"
+	schemaFileName = "schema_file"
+)
+
+var metaSchemasCommand = cli.Command{
+	Name:  "schemas",
+	Usage: "manage the JSON schemas asset meta can be validated against",
+	Subcommands: []cli.Command{
+		registerMetaSchemaCommand,
+		listMetaSchemasCommand,
+	},
+}
+
+var registerMetaSchemaCommand = cli.Command{
+	Name:  "register",
+	Usage: "register a custom JSON schema for asset meta",
+	Description: `
+	Register a custom JSON schema. Its ID can then be passed as the
+	--meta_schema_id when minting an asset, to make sure the JSON asset
+	meta conforms to the schema. Registered schemas can't be replaced.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  schemaIDName,
+			Usage: "the unique ID of the schema",
+		},
+		cli.StringFlag{
+			Name: schemaFileName,
+			Usage: "the file to read the JSON schema definition " +
+				"from",
+		},
+	},
+	Action: registerMetaSchema,
+}
+
+func registerMetaSchema(ctx *cli.Context) error {
+	if ctx.String(schemaIDName) == "" || ctx.String(schemaFileName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	definition, err := readFile(ctx.String(schemaFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read schema: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getMintClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.RegisterMetaSchema(
+		ctxc, &mintrpc.RegisterMetaSchemaRequest{
+			SchemaId:   ctx.String(schemaIDName),
+			Definition: definition,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to register meta schema: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listMetaSchemasCommand = cli.Command{
+	Name:   "list",
+	Usage:  "list the built-in and custom JSON schemas for asset meta",
+	Action: listMetaSchemas,
+}
+
+func listMetaSchemas(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getMintClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListMetaSchemas(
+		ctxc, &mintrpc.ListMetaSchemasRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to list meta schemas: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var finalizeBatchCommand = cli.Command{
 	Name:        "finalize",
 	Usage:       "finalize a batch",
 	Description: "Attempt to finalize a pending batch.",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name: shortResponseName,
 			Usage: "if true, then the current assets within the " +
@@ -453,7 +1015,13 @@ var finalizeBatchCommand = cli.Command{
 			Usage: "if set, the fee rate in sat/vB to use for " +
 				"the minting transaction",
 		},
-	},
+		cli.BoolFlag{
+			Name: dryRunName,
+			Usage: "if true, the batch is not finalized; instead " +
+				"the size, fee and output layout of the " +
+				"minting transaction are shown",
+		},
+	}, vanityFlags...),
 	Action: finalizeBatch,
 }
 
@@ -467,9 +1035,16 @@ func finalizeBatch(ctx *cli.Context) error {
 		return err
 	}
 
+	vanityAssetID, err := parseVanityAssetID(ctx)
+	if err != nil {
+		return err
+	}
+
 	resp, err := client.FinalizeBatch(ctxc, &mintrpc.FinalizeBatchRequest{
 		ShortResponse: ctx.Bool(shortResponseName),
 		FeeRate:       feeRate,
+		DryRun:        ctx.Bool(dryRunName),
+		VanityAssetId: vanityAssetID,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to finalize batch: %w", err)
@@ -568,6 +1143,55 @@ var listAssetsCommand = cli.Command{
 			Usage: "include freshly minted and not yet confirmed " +
 				"assets in the list",
 		},
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account to list " +
+				"the assets of; use 'default' for the " +
+				"assets that don't belong to a named " +
+				"account, leave empty for the assets of " +
+				"all accounts",
+		},
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "(optional) only list the assets with this ID",
+		},
+		cli.StringFlag{
+			Name: assetGroupKeyName,
+			Usage: "(optional) only list the assets of the group " +
+				"with this tweaked group key",
+		},
+		cli.Uint64Flag{
+			Name: assetMinAmountName,
+			Usage: "(optional) only list the assets with at " +
+				"least this amount",
+		},
+		cli.BoolFlag{
+			Name:  assetOnlySpentName,
+			Usage: "only list fully spent assets",
+		},
+		cli.BoolFlag{
+			Name:  assetOnlyLeasedName,
+			Usage: "only list leased assets",
+		},
+		cli.StringFlag{
+			Name: sortByName,
+			Usage: "(optional) the field to sort the assets by, " +
+				"[--sort_by=amount|anchor_height|asset_id]",
+		},
+		cli.BoolFlag{
+			Name:  assetSortDescendingName,
+			Usage: "sort the assets in descending order",
+		},
+		cli.StringFlag{
+			Name: assetListCursorName,
+			Usage: "(optional) the cursor returned by the " +
+				"previous call to continue the list after",
+		},
+		cli.UintFlag{
+			Name: limitName,
+			Usage: "(optional) the maximum number of assets to " +
+				"list",
+		},
 	},
 	Action: listAssets,
 }
@@ -579,11 +1203,58 @@ func listAssets(ctx *cli.Context) error {
 
 	// TODO(roasbeef): need to reverse txid
 
+	var sortBy taprpc.AssetSortKey
+	switch ctx.String(sortByName) {
+	case "":
+		sortBy = taprpc.AssetSortKey_ASSET_SORT_KEY_NONE
+
+	case "amount":
+		sortBy = taprpc.AssetSortKey_ASSET_SORT_KEY_AMOUNT
+
+	case "anchor_height":
+		sortBy = taprpc.AssetSortKey_ASSET_SORT_KEY_ANCHOR_HEIGHT
+
+	case "asset_id":
+		sortBy = taprpc.AssetSortKey_ASSET_SORT_KEY_ASSET_ID
+
+	default:
+		return fmt.Errorf("invalid sort_by value: %v",
+			ctx.String(sortByName))
+	}
+
+	var (
+		assetID  []byte
+		groupKey []byte
+		err      error
+	)
+	if ctx.IsSet(assetIDName) {
+		assetID, err = hex.DecodeString(ctx.String(assetIDName))
+		if err != nil {
+			return fmt.Errorf("invalid asset ID: %w", err)
+		}
+	}
+	if ctx.IsSet(assetGroupKeyName) {
+		groupKey, err = hex.DecodeString(ctx.String(assetGroupKeyName))
+		if err != nil {
+			return fmt.Errorf("invalid group key: %w", err)
+		}
+	}
+
 	resp, err := client.ListAssets(ctxc, &taprpc.ListAssetRequest{
 		WithWitness:             ctx.Bool(assetShowWitnessName),
 		IncludeSpent:            ctx.Bool(assetShowSpentName),
 		IncludeLeased:           ctx.Bool(assetShowLeasedName),
 		IncludeUnconfirmedMints: ctx.Bool(assetShowUnconfMintsName),
+		Account:                 ctx.String(accountName),
+		AssetId:                 assetID,
+		GroupKey:                groupKey,
+		MinAmount:               ctx.Uint64(assetMinAmountName),
+		OnlySpent:               ctx.Bool(assetOnlySpentName),
+		OnlyLeased:              ctx.Bool(assetOnlyLeasedName),
+		SortBy:                  sortBy,
+		SortDescending:          ctx.Bool(assetSortDescendingName),
+		Cursor:                  ctx.String(assetListCursorName),
+		Limit:                   uint32(ctx.Uint(limitName)),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to list assets: %w", err)
@@ -613,6 +1284,43 @@ func listUtxos(ctx *cli.Context) error {
 	return nil
 }
 
+var exportAnchorDescriptorsCommand = cli.Command{
+	Name:      "descriptors",
+	ShortName: "d",
+	Usage:     "export the output descriptors of all asset anchor outputs",
+	Description: `
+	Export the output descriptors of all on-chain anchor outputs that hold
+	assets owned by the daemon. The descriptors can be imported into an
+	external, descriptor based bitcoin wallet or monitoring system to watch
+	the outputs for unexpected spends.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: assetIncludeLeasedName,
+			Usage: "include anchor outputs that are leased to " +
+				"other applications",
+		},
+	},
+	Action: exportAnchorDescriptors,
+}
+
+func exportAnchorDescriptors(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &taprpc.ExportAnchorDescriptorsRequest{
+		IncludeLeased: ctx.Bool(assetIncludeLeasedName),
+	}
+	resp, err := client.ExportAnchorDescriptors(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to export anchor descriptors: %w",
+			err)
+	}
+	printRespJSON(resp)
+	return nil
+}
+
 var listGroupsCommand = cli.Command{
 	Name:        "groups",
 	ShortName:   "g",
@@ -660,6 +1368,14 @@ var listAssetBalancesCommand = cli.Command{
 				"balance query against. Must be used " +
 				"together with --by_group",
 		},
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account to query " +
+				"the balances of; use 'default' for the " +
+				"assets that don't belong to a named " +
+				"account, leave empty for the balances of " +
+				"all accounts",
+		},
 	},
 }
 
@@ -672,6 +1388,7 @@ func listAssetBalances(ctx *cli.Context) error {
 
 	req := &taprpc.ListBalancesRequest{
 		IncludeLeased: ctx.Bool(assetIncludeLeasedName),
+		Account:       ctx.String(accountName),
 	}
 
 	if !ctx.Bool(groupByGroupName) {
@@ -727,8 +1444,41 @@ var sendAssetsCommand = cli.Command{
 			Usage: "if set, the fee rate in sat/vB to use for " +
 				"the anchor transaction",
 		},
-		// TODO(roasbeef): add arg for file name to write sender proof
-		// blob
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account to spend " +
+				"the assets from; the default account is " +
+				"used if not set",
+		},
+		cli.StringFlag{
+			Name: proofCourierAddrName,
+			Usage: "(optional) the proof courier address to " +
+				"deliver the proofs to instead of the one of " +
+				"the addresses",
+		},
+		cli.BoolFlag{
+			Name: skipProofDeliveryName,
+			Usage: "if set, the proofs aren't delivered to the " +
+				"recipients; they must be exported with " +
+				"'proofs export' once the transfer confirmed " +
+				"and delivered out of band",
+		},
+		cli.StringSliceFlag{
+			Name: additionalCourierAddrName,
+			Usage: "(optional) an additional proof courier " +
+				"address to deliver the proofs to; can be " +
+				"specified multiple times, in order of " +
+				"priority",
+		},
+		cli.StringFlag{
+			Name: proofCourierModeName,
+			Usage: "the mode the proofs are delivered to the " +
+				"proof couriers with if additional couriers " +
+				"are given; 'failover' delivers to the " +
+				"couriers in order until one succeeds, " +
+				"'all' delivers to all of them",
+			Value: "failover",
+		},
 	},
 	Action: sendAssets,
 }
@@ -748,9 +1498,29 @@ func sendAssets(ctx *cli.Context) error {
 		return err
 	}
 
+	var courierMode taprpc.ProofCourierMode
+	switch ctx.String(proofCourierModeName) {
+	case "failover":
+		courierMode = taprpc.ProofCourierModeFailover
+
+	case "all":
+		courierMode = taprpc.ProofCourierModeAll
+
+	default:
+		return fmt.Errorf("unknown proof courier mode %q",
+			ctx.String(proofCourierModeName))
+	}
+
 	resp, err := client.SendAsset(ctxc, &taprpc.SendAssetRequest{
-		TapAddrs: addrs,
-		FeeRate:  feeRate,
+		TapAddrs:          addrs,
+		FeeRate:           feeRate,
+		Account:           ctx.String(accountName),
+		ProofCourierAddr:  ctx.String(proofCourierAddrName),
+		SkipProofDelivery: ctx.Bool(skipProofDeliveryName),
+		AdditionalProofCourierAddrs: ctx.StringSlice(
+			additionalCourierAddrName,
+		),
+		ProofCourierMode: courierMode,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to send assets: %w", err)
@@ -782,6 +1552,13 @@ var burnAssetsCommand = cli.Command{
 			Name:  assetAmountName,
 			Usage: "the amount of units to burn/destroy",
 		},
+		cli.StringFlag{
+			Name: assetDisplayAmountName,
+			Usage: "the amount to burn/destroy in decimal " +
+				"display units (e.g. 1.25); can be used " +
+				"instead of --amount if the daemon is " +
+				"configured to accept decimal display amounts",
+		},
 		cli.BoolFlag{
 			Name: burnOverrideConfirmationName,
 			Usage: "if set, the confirmation prompt will be " +
@@ -804,10 +1581,16 @@ func burnAssets(ctx *cli.Context) error {
 	}
 
 	burnAmount := ctx.Uint64(assetAmountName)
-	if burnAmount == 0 {
+	displayBurnAmount := ctx.String(assetDisplayAmountName)
+	if burnAmount == 0 && displayBurnAmount == "" {
 		return fmt.Errorf("invalid burn amount")
 	}
 
+	burnAmountStr := fmt.Sprintf("%d", burnAmount)
+	if displayBurnAmount != "" {
+		burnAmountStr = displayBurnAmount
+	}
+
 	ctxc := getContext()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
@@ -834,11 +1617,11 @@ func burnAssets(ctx *cli.Context) error {
 
 		msg := fmt.Sprintf("Please confirm destructive action.\n"+
 			"Asset ID: %x\nCurrent available balance: %d\n"+
-			"Amount to burn: %d\n Are you sure you want to "+
+			"Amount to burn: %s\n Are you sure you want to "+
 			"irreversibly burn (destroy, remove from circulation) "+
 			"the specified amount of assets?\nPlease answer 'yes' "+
 			"or 'no' and press enter: ", assetIDBytes,
-			assetBalance.Balance, burnAmount)
+			assetBalance.Balance, burnAmountStr)
 
 		if !promptForConfirmation(msg) {
 			return nil
@@ -849,8 +1632,9 @@ func burnAssets(ctx *cli.Context) error {
 		Asset: &taprpc.BurnAssetRequest_AssetId{
 			AssetId: assetIDBytes,
 		},
-		AmountToBurn:     burnAmount,
-		ConfirmationText: taprootassets.AssetBurnConfirmationText,
+		AmountToBurn:        burnAmount,
+		DisplayAmountToBurn: displayBurnAmount,
+		ConfirmationText:    taprootassets.AssetBurnConfirmationText,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to send assets: %w", err)
@@ -938,6 +1722,14 @@ var listTransfersCommand = cli.Command{
 			Usage: "A specific asset ID to list outgoing " +
 				"transfers for",
 		},
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account to list " +
+				"the transfers of; use 'default' for the " +
+				"assets that don't belong to a named " +
+				"account, leave empty for the transfers of " +
+				"all accounts",
+		},
 	},
 }
 
@@ -946,7 +1738,9 @@ func listTransfers(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &taprpc.ListTransfersRequest{}
+	req := &taprpc.ListTransfersRequest{
+		Account: ctx.String(accountName),
+	}
 	resp, err := client.ListTransfers(ctxc, req)
 	if err != nil {
 		return fmt.Errorf("unable to list asset transfers: %w", err)
@@ -956,6 +1750,99 @@ func listTransfers(ctx *cli.Context) error {
 	return nil
 }
 
+const (
+	ledgerFormatName           = "format"
+	ledgerCursorName           = "cursor"
+	ledgerIncludeValuationName = "include_valuation"
+	ledgerOutputFileName       = "output_file"
+)
+
+var exportLedgerCommand = cli.Command{
+	Name:  "ledger",
+	Usage: "export a ledger of all asset movements",
+	Description: `
+	Export a time-ordered ledger of all mints, sends, receives to addresses
+	and burns of assets as CSV or JSON. Large ledgers are exported in pages,
+	the cursor to continue the export with is printed once the page was
+	written.
+	`,
+	Action: exportLedger,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  ledgerFormatName,
+			Usage: "the format of the ledger; csv or json",
+			Value: "csv",
+		},
+		cli.StringFlag{
+			Name: ledgerCursorName,
+			Usage: "(optional) the cursor returned by the " +
+				"previous export to continue after",
+		},
+		cli.UintFlag{
+			Name: limitName,
+			Usage: "(optional) the maximum number of entries to " +
+				"export",
+		},
+		cli.BoolFlag{
+			Name: ledgerIncludeValuationName,
+			Usage: "value each entry using the configured price " +
+				"oracle",
+		},
+		cli.StringFlag{
+			Name: ledgerOutputFileName,
+			Usage: "the file to write the ledger to; use the " +
+				"dash character (-) to write to stdout",
+			Value: "-",
+		},
+	},
+}
+
+func exportLedger(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var format taprpc.LedgerFormat
+	switch ctx.String(ledgerFormatName) {
+	case "csv":
+		format = taprpc.LedgerFormat_LEDGER_FORMAT_CSV
+
+	case "json":
+		format = taprpc.LedgerFormat_LEDGER_FORMAT_JSON
+
+	default:
+		return fmt.Errorf("unknown ledger format: %v",
+			ctx.String(ledgerFormatName))
+	}
+
+	resp, err := client.ExportLedger(ctxc, &taprpc.ExportLedgerRequest{
+		Format:           format,
+		Cursor:           ctx.String(ledgerCursorName),
+		Limit:            uint32(ctx.Uint(limitName)),
+		IncludeValuation: ctx.Bool(ledgerIncludeValuationName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to export ledger: %w", err)
+	}
+
+	fileName := ctx.String(ledgerOutputFileName)
+	if fileName != "-" {
+		fileName = lncfg.CleanAndExpandPath(fileName)
+	}
+	if err := writeToFile(fileName, resp.Ledger); err != nil {
+		return err
+	}
+
+	// We print the cursor to stderr, so it doesn't end up in a ledger
+	// that is written to stdout.
+	if resp.NextCursor != "" {
+		fmt.Fprintf(os.Stderr, "More entries available, continue "+
+			"with --%s=%s\n", ledgerCursorName, resp.NextCursor)
+	}
+
+	return nil
+}
+
 const (
 	metaName = "asset_meta"
 )
@@ -1020,3 +1907,172 @@ func fetchMeta(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+const gapLimitName = "gap_limit"
+
+var recoverAssetsCommand = cli.Command{
+	Name:  "recover",
+	Usage: "recover the assets of a wallet restored from its seed",
+	Description: `
+	Start a background job that recovers the assets of a wallet whose lnd
+	node was restored from its seed. The script keys derived from the seed
+	are scanned up to the gap limit after the last used key, and the given
+	universe is queried for proofs paying them. The proofs of all unspent
+	assets found are imported.
+
+	The returned job ID can be used to follow the progress of the recovery
+	with 'tapcli jobs watch' or to cancel it with 'tapcli jobs cancel'.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: universeHostName,
+			Usage: "the host:port of the universe to scan for " +
+				"proofs",
+		},
+		cli.Uint64Flag{
+			Name: gapLimitName,
+			Usage: "the number of consecutive unused keys after " +
+				"the last used key to scan",
+			Value: 1000,
+		},
+	},
+	Action: recoverAssets,
+}
+
+func recoverAssets(ctx *cli.Context) error {
+	if !ctx.IsSet(universeHostName) {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.RecoverAssets(ctxc, &taprpc.RecoverAssetsRequest{
+		UniverseHost: ctx.String(universeHostName),
+		GapLimit:     uint32(ctx.Uint64(gapLimitName)),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to start asset recovery: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+const (
+	maxInputsName    = "max_inputs"
+	maxProofSizeName = "max_proof_size"
+	noteName         = "note"
+)
+
+var consolidateAssetsCommand = cli.Command{
+	Name:  "consolidate",
+	Usage: "merge many small asset UTXOs into fewer outputs",
+	Description: `
+	Merge the UTXOs of an asset or asset group into as few outputs as
+	possible by sending them to the wallet itself in a single anchor
+	transaction. For an asset group, the UTXOs of each asset ID are merged
+	into a separate output.
+
+	The smallest UTXOs are merged first, until either the maximum number of
+	inputs or the maximum proof file size of a merged output is reached.
+	Use --dry_run to only show the size and fee of the consolidation.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the asset ID of the asset to consolidate",
+		},
+		cli.StringFlag{
+			Name: assetGroupKeyName,
+			Usage: "the group key of the asset group to " +
+				"consolidate",
+		},
+		cli.StringFlag{
+			Name: accountName,
+			Usage: "(optional) the name of the account whose " +
+				"assets are consolidated; the default " +
+				"account is used if not set",
+		},
+		cli.Uint64Flag{
+			Name:  maxInputsName,
+			Usage: "the maximum number of UTXOs to merge",
+			Value: 100,
+		},
+		cli.Uint64Flag{
+			Name: maxProofSizeName,
+			Usage: "the maximum size of the proof file of a " +
+				"merged output in bytes",
+			Value: 10 * 1024 * 1024,
+		},
+		cli.Uint64Flag{
+			Name: feeRateName,
+			Usage: "if set, the fee rate in sat/vB to use for " +
+				"the anchor transaction",
+		},
+		cli.BoolFlag{
+			Name: dryRunName,
+			Usage: "only estimate the size and fee of the " +
+				"consolidation without spending anything",
+		},
+		cli.StringFlag{
+			Name: noteName,
+			Usage: "(optional) a note to store with the " +
+				"transfer",
+		},
+	},
+	Action: consolidateAssets,
+}
+
+func consolidateAssets(ctx *cli.Context) error {
+	if !ctx.IsSet(assetIDName) && !ctx.IsSet(assetGroupKeyName) {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	var (
+		assetID  []byte
+		groupKey []byte
+		err      error
+	)
+	if ctx.IsSet(assetIDName) {
+		assetID, err = hex.DecodeString(ctx.String(assetIDName))
+		if err != nil {
+			return fmt.Errorf("invalid asset ID: %w", err)
+		}
+	}
+	if ctx.IsSet(assetGroupKeyName) {
+		groupKey, err = hex.DecodeString(ctx.String(assetGroupKeyName))
+		if err != nil {
+			return fmt.Errorf("invalid group key: %w", err)
+		}
+	}
+
+	feeRate, err := parseFeeRate(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ConsolidateAssets(
+		ctxc, &taprpc.ConsolidateAssetsRequest{
+			AssetId:      assetID,
+			GroupKey:     groupKey,
+			Account:      ctx.String(accountName),
+			MaxInputs:    uint32(ctx.Uint64(maxInputsName)),
+			MaxProofSize: ctx.Uint64(maxProofSizeName),
+			FeeRate:      feeRate,
+			DryRun:       ctx.Bool(dryRunName),
+			Note:         ctx.String(noteName),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to consolidate assets: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/lightninglabs/taproot-assets/taprpc"
@@ -131,3 +134,116 @@ func getInfo(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var compactCommand = cli.Command{
+	Name:  "compact",
+	Usage: "Prune old data from the database.",
+	Description: `
+	Prune the proofs of spent assets, the proofs of delivered transfer
+	outputs together with the proof courier log, and universe events that
+	are older than the retention age configured in the retention section of
+	the daemon config. Each age can be overridden with the flags below,
+	which also allows data to be pruned that is otherwise kept forever.`,
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "spent_proof_age",
+			Usage: "(optional) prune the proofs of assets spent " +
+				"longer ago than this, for example 720h",
+		},
+		cli.DurationFlag{
+			Name: "delivered_proof_age",
+			Usage: "(optional) prune the proofs of delivered " +
+				"transfer outputs older than this",
+		},
+		cli.DurationFlag{
+			Name: "universe_event_age",
+			Usage: "(optional) prune universe events older than " +
+				"this; pruned events are no longer included " +
+				"in universe statistics",
+		},
+	},
+	Action: compact,
+}
+
+func compact(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &taprpc.CompactRequest{
+		SpentProofAgeSeconds: int64(
+			ctx.Duration("spent_proof_age").Seconds(),
+		),
+		DeliveredProofAgeSeconds: int64(
+			ctx.Duration("delivered_proof_age").Seconds(),
+		),
+		UniverseEventAgeSeconds: int64(
+			ctx.Duration("universe_event_age").Seconds(),
+		),
+	}
+	resp, err := client.Compact(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var unlockCommand = cli.Command{
+	Name:  "unlock",
+	Usage: "Unlock an encrypted database.",
+	Description: `
+	Unlock a database whose macaroon root keys and proofs are encrypted with
+	a key derived from a passphrase, which is the case if the daemon runs
+	with sqlite.encryption=passphrase. The daemon only accepts other calls
+	once the database is unlocked. No macaroon is required for this call.
+
+	On the first unlock, the existing data is encrypted with the given
+	passphrase, which then needs to be supplied on every start.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "stdin",
+			Usage: "read the passphrase from standard input " +
+				"instead of prompting for it",
+		},
+	},
+	Action: unlock,
+}
+
+func unlock(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, true)
+	defer conn.Close()
+
+	var (
+		passphrase []byte
+		err        error
+	)
+	switch {
+	case ctx.Bool("stdin"):
+		reader := bufio.NewReader(os.Stdin)
+		passphrase, err = reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("unable to read passphrase: %w", err)
+		}
+		passphrase = bytes.TrimRight(passphrase, "\r\n")
+
+	default:
+		passphrase, err = readPassword("Input database passphrase: ")
+		if err != nil {
+			return fmt.Errorf("unable to read passphrase: %w", err)
+		}
+	}
+
+	client := taprpc.NewTaprootAssetsClient(conn)
+	_, err = client.UnlockDatabase(ctxc, &taprpc.UnlockDatabaseRequest{
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Database unlocked")
+	return nil
+}
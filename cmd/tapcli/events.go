@@ -9,6 +9,10 @@ import (
 	"github.com/urfave/cli"
 )
 
+const (
+	proofAttemptsName = "proof_attempts"
+)
+
 var eventCommands = []cli.Command{
 	{
 		Name:      "events",
@@ -38,6 +42,11 @@ var receiveEventsCommand = cli.Command{
 				"for; if not set, events for all addresses " +
 				"will be shown",
 		},
+		cli.BoolFlag{
+			Name: proofAttemptsName,
+			Usage: "also show an event for every failed attempt " +
+				"to retrieve a proof from the proof courier",
+		},
 	},
 	Action: receiveEvents,
 }
@@ -50,6 +59,9 @@ func receiveEvents(ctx *cli.Context) error {
 	addr, err := client.SubscribeReceiveEvents(
 		ctxc, &taprpc.SubscribeReceiveEventsRequest{
 			FilterAddr: ctx.String(addrName),
+			IncludeProofRetrievalAttempts: ctx.Bool(
+				proofAttemptsName,
+			),
 		},
 	)
 	if err != nil {
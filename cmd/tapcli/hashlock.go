@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+)
+
+var hashLockCommands = []cli.Command{
+	{
+		Name:      "hashlock",
+		ShortName: "hl",
+		Usage: "Lock assets to HTLC-style script keys to swap them " +
+			"atomically.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			newHashLockScriptKeyCommand,
+			sweepHashLockCommand,
+		},
+	},
+}
+
+const (
+	paymentHashName = "payment_hash"
+
+	claimKeyName = "claim_key"
+
+	refundKeyName = "refund_key"
+
+	csvDelayName = "csv_delay"
+
+	preimageName = "preimage"
+
+	timeoutName = "timeout"
+)
+
+var hashLockFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  paymentHashName,
+		Usage: "the hex encoded 32-byte SHA256 payment hash",
+	},
+	cli.StringFlag{
+		Name: claimKeyName,
+		Usage: "the hex encoded 33-byte public key that can sweep " +
+			"the assets by revealing the preimage",
+	},
+	cli.StringFlag{
+		Name: refundKeyName,
+		Usage: "the hex encoded 33-byte public key that can sweep " +
+			"the assets once the CSV delay has passed",
+	},
+	cli.Uint64Flag{
+		Name: csvDelayName,
+		Usage: "the number of blocks after which the refund key " +
+			"can sweep the assets",
+	},
+}
+
+// parseHashLock parses the hash lock flags.
+func parseHashLock(ctx *cli.Context) (*wrpc.HashLock, error) {
+	paymentHash, err := hex.DecodeString(ctx.String(paymentHashName))
+	if err != nil || len(paymentHash) == 0 {
+		return nil, fmt.Errorf("invalid payment hash: %v", err)
+	}
+
+	claimKey, err := hex.DecodeString(ctx.String(claimKeyName))
+	if err != nil || len(claimKey) == 0 {
+		return nil, fmt.Errorf("invalid claim key: %v", err)
+	}
+
+	refundKey, err := hex.DecodeString(ctx.String(refundKeyName))
+	if err != nil || len(refundKey) == 0 {
+		return nil, fmt.Errorf("invalid refund key: %v", err)
+	}
+
+	return &wrpc.HashLock{
+		PaymentHash: paymentHash,
+		ClaimKey:    claimKey,
+		RefundKey:   refundKey,
+		CsvDelay:    uint32(ctx.Uint64(csvDelayName)),
+	}, nil
+}
+
+var newHashLockScriptKeyCommand = cli.Command{
+	Name:      "newscriptkey",
+	ShortName: "n",
+	Usage:     "create an HTLC-style script key",
+	Description: `
+	Create an HTLC-style script key and declare it to the wallet. Assets
+	locked to the script key can either be swept by the claim key by
+	revealing the preimage of the payment hash, or by the refund key once
+	the CSV delay has passed. Locking assets to the script key and BTC to
+	an on-chain HTLC with the same payment hash allows the two to be
+	swapped atomically.
+	`,
+	Flags:  hashLockFlags,
+	Action: newHashLockScriptKey,
+}
+
+func newHashLockScriptKey(ctx *cli.Context) error {
+	hashLock, err := parseHashLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.NewHashLockScriptKey(
+		ctxc, &wrpc.NewHashLockScriptKeyRequest{
+			HashLock: hashLock,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var sweepHashLockCommand = cli.Command{
+	Name:      "sweep",
+	ShortName: "s",
+	Usage:     "sweep assets locked to an HTLC-style script key",
+	Description: `
+	Sweep all assets of the given asset ID that are locked to the hash
+	lock script key to a new script key of the wallet. By default, the
+	assets are swept by revealing the preimage. With --timeout, they are
+	swept back by the refund key, which is only possible once the CSV
+	delay has passed.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the ID of the assets to sweep",
+		},
+		cli.StringFlag{
+			Name: preimageName,
+			Usage: "the hex encoded 32-byte preimage of the " +
+				"payment hash",
+		},
+		cli.BoolFlag{
+			Name: timeoutName,
+			Usage: "sweep through the timeout path with the " +
+				"refund key",
+		},
+	}, hashLockFlags...),
+	Action: sweepHashLock,
+}
+
+func sweepHashLock(ctx *cli.Context) error {
+	hashLock, err := parseHashLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	assetID, err := hex.DecodeString(ctx.String(assetIDName))
+	if err != nil {
+		return fmt.Errorf("invalid asset ID: %w", err)
+	}
+
+	preimage, err := hex.DecodeString(ctx.String(preimageName))
+	if err != nil {
+		return fmt.Errorf("invalid preimage: %w", err)
+	}
+
+	path := wrpc.HashLockPath_HASH_LOCK_PATH_SUCCESS
+	if ctx.Bool(timeoutName) {
+		path = wrpc.HashLockPath_HASH_LOCK_PATH_TIMEOUT
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SweepHashLock(ctxc, &wrpc.SweepHashLockRequest{
+		HashLock: hashLock,
+		AssetId:  assetID,
+		Path:     path,
+		Preimage: preimage,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to sweep assets: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+)
+
+var issuerPolicyCommands = []cli.Command{
+	{
+		Name:      "issuerpolicy",
+		ShortName: "ip",
+		Usage: "Manage regulated assets that are co-controlled by " +
+			"their issuer.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			newIssuerPolicyScriptKeyCommand,
+			signIssuerPolicyPsbtCommand,
+			freezeScriptKeyCommand,
+			unfreezeScriptKeyCommand,
+			listFrozenScriptKeysCommand,
+		},
+	},
+}
+
+const (
+	holderKeyName = "holder_key"
+
+	issuerKeyName = "issuer_key"
+
+	clawbackName = "clawback"
+
+	psbtFileName = "psbt_file"
+
+	reasonName = "reason"
+)
+
+var issuerPolicyKeyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: holderKeyName,
+		Usage: "the hex encoded 33-byte public key of the asset's " +
+			"holder",
+	},
+	cli.StringFlag{
+		Name: issuerKeyName,
+		Usage: "the hex encoded 33-byte public key of the asset's " +
+			"issuer",
+	},
+}
+
+// parseIssuerPolicyKeys parses the holder and issuer key flags.
+func parseIssuerPolicyKeys(ctx *cli.Context) ([]byte, []byte, error) {
+	holderKey, err := hex.DecodeString(ctx.String(holderKeyName))
+	if err != nil || len(holderKey) == 0 {
+		return nil, nil, fmt.Errorf("invalid holder key: %v", err)
+	}
+
+	issuerKey, err := hex.DecodeString(ctx.String(issuerKeyName))
+	if err != nil || len(issuerKey) == 0 {
+		return nil, nil, fmt.Errorf("invalid issuer key: %v", err)
+	}
+
+	return holderKey, issuerKey, nil
+}
+
+var newIssuerPolicyScriptKeyCommand = cli.Command{
+	Name:      "newscriptkey",
+	ShortName: "n",
+	Usage:     "create the script key of a regulated asset",
+	Description: `
+	Create the script key of a regulated asset that is controlled by both
+	its holder and its issuer, and declare it to the wallet. Regular
+	transfers require a signature of both, while the issuer alone can claw
+	the asset back. The script key can be used to mint assets or to create
+	addresses.
+	`,
+	Flags:  issuerPolicyKeyFlags,
+	Action: newIssuerPolicyScriptKey,
+}
+
+func newIssuerPolicyScriptKey(ctx *cli.Context) error {
+	holderKey, issuerKey, err := parseIssuerPolicyKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.NewIssuerPolicyScriptKey(
+		ctxc, &wrpc.NewIssuerPolicyScriptKeyRequest{
+			HolderKey: holderKey,
+			IssuerKey: issuerKey,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var signIssuerPolicyPsbtCommand = cli.Command{
+	Name:      "sign",
+	ShortName: "s",
+	Usage:     "sign the transfer of a regulated asset",
+	Description: `
+	Sign a funded virtual PSBT that spends regulated assets with all keys
+	of the issuer policy that the wallet controls. The signed PSBT is
+	written to the output file and can be passed on to the other signer
+	until it is fully signed. The issuer refuses to co-sign the transfer
+	of frozen assets.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name: psbtFileName,
+			Usage: "the file to read the binary virtual PSBT " +
+				"from; use the dash character (-) to read " +
+				"from stdin instead",
+			Value: "-",
+		},
+		cli.BoolFlag{
+			Name: clawbackName,
+			Usage: "sign for the clawback path that only " +
+				"requires the issuer's signature",
+		},
+		cli.StringFlag{
+			Name: outputFileName,
+			Usage: "the file to write the signed virtual PSBT " +
+				"to; use the dash character (-) to write to " +
+				"stdout instead",
+			Value: "-",
+		},
+	}, issuerPolicyKeyFlags...),
+	Action: signIssuerPolicyPsbt,
+}
+
+func signIssuerPolicyPsbt(ctx *cli.Context) error {
+	holderKey, issuerKey, err := parseIssuerPolicyKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	psbtBytes, err := readFile(ctx.String(psbtFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read PSBT: %w", err)
+	}
+
+	path := wrpc.IssuerPolicyPath_ISSUER_POLICY_PATH_CO_SIGN
+	if ctx.Bool(clawbackName) {
+		path = wrpc.IssuerPolicyPath_ISSUER_POLICY_PATH_CLAWBACK
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SignIssuerPolicyPsbt(
+		ctxc, &wrpc.SignIssuerPolicyPsbtRequest{
+			Psbt:      psbtBytes,
+			HolderKey: holderKey,
+			IssuerKey: issuerKey,
+			Path:      path,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to sign PSBT: %w", err)
+	}
+
+	err = writeToFile(ctx.String(outputFileName), resp.SignedPsbt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Fully signed: %v\n", resp.FullySigned)
+
+	return nil
+}
+
+var freezeScriptKeyCommand = cli.Command{
+	Name:      "freeze",
+	ShortName: "f",
+	Usage:     "refuse to co-sign transfers of a regulated asset",
+	Description: `
+	Freeze an issuer policy script key, which makes the issuer refuse to
+	co-sign regular transfers of the assets locked to it. The assets can
+	still be clawed back.
+	`,
+	ArgsUsage: "script_key",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  reasonName,
+			Usage: "the optional reason of the freeze",
+		},
+	},
+	Action: freezeScriptKey,
+}
+
+func freezeScriptKey(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	scriptKey, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid script key: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.FreezeScriptKey(ctxc, &wrpc.FreezeScriptKeyRequest{
+		ScriptKey: scriptKey,
+		Reason:    ctx.String(reasonName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to freeze script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var unfreezeScriptKeyCommand = cli.Command{
+	Name:      "unfreeze",
+	ShortName: "u",
+	Usage:     "unfreeze a frozen issuer policy script key",
+	ArgsUsage: "script_key",
+	Action:    unfreezeScriptKey,
+}
+
+func unfreezeScriptKey(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	scriptKey, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid script key: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.UnfreezeScriptKey(
+		ctxc, &wrpc.UnfreezeScriptKeyRequest{
+			ScriptKey: scriptKey,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to unfreeze script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listFrozenScriptKeysCommand = cli.Command{
+	Name:      "frozen",
+	ShortName: "l",
+	Usage:     "list all frozen issuer policy script keys",
+	Action:    listFrozenScriptKeys,
+}
+
+func listFrozenScriptKeys(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListFrozenScriptKeys(
+		ctxc, &wrpc.ListFrozenScriptKeysRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to list frozen script keys: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/urfave/cli"
+)
+
+const (
+	jobIDName = "job_id"
+
+	activeOnlyName = "active_only"
+)
+
+var jobCommands = []cli.Command{
+	{
+		Name:      "jobs",
+		ShortName: "j",
+		Usage:     "Interact with long-running jobs.",
+		Category:  "Jobs",
+		Subcommands: []cli.Command{
+			listJobsCommand,
+			cancelJobCommand,
+			watchJobsCommand,
+		},
+	},
+}
+
+var listJobsCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "list long-running jobs",
+	Description: "List the long-running jobs tracked by the daemon, " +
+		"including their state and progress.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  activeOnlyName,
+			Usage: "only list jobs that haven't finished yet",
+		},
+	},
+	Action: listJobs,
+}
+
+func listJobs(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListJobs(ctxc, &taprpc.ListJobsRequest{
+		ActiveOnly: ctx.Bool(activeOnlyName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list jobs: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var cancelJobCommand = cli.Command{
+	Name:      "cancel",
+	ShortName: "c",
+	Usage:     "cancel a running job",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  jobIDName,
+			Usage: "the ID of the job to cancel",
+		},
+	},
+	Action: cancelJob,
+}
+
+func cancelJob(ctx *cli.Context) error {
+	if !ctx.IsSet(jobIDName) {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CancelJob(ctxc, &taprpc.CancelJobRequest{
+		JobId: ctx.Uint64(jobIDName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to cancel job: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var watchJobsCommand = cli.Command{
+	Name:      "watch",
+	ShortName: "w",
+	Usage:     "watch the progress of jobs",
+	Description: "Get live updates on the state and progress of jobs. If " +
+		"a job ID is given, this command returns once that job has " +
+		"finished. Otherwise it will block until aborted manually " +
+		"by hitting Ctrl+C.",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: jobIDName,
+			Usage: "(optional) the ID of the job to watch; if " +
+				"not set, updates of all jobs will be shown",
+		},
+	},
+	Action: watchJobs,
+}
+
+func watchJobs(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.SubscribeJobUpdates(
+		ctxc, &taprpc.SubscribeJobUpdatesRequest{
+			JobId: ctx.Uint64(jobIDName),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to job updates: %w",
+			err)
+	}
+
+	for {
+		job, err := stream.Recv()
+		if err != nil {
+			// The stream of a single job ends once the job has
+			// finished.
+			if ctx.IsSet(jobIDName) && errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("unable to receive job update: %w",
+				err)
+		}
+
+		printRespJSON(job)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/rpcperms"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/urfave/cli"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	universeReadOnlyName = "universe_read_only"
+)
+
+var macaroonCommands = []cli.Command{
+	{
+		Name:     "macaroon",
+		Usage:    "Work with macaroons.",
+		Category: "Daemon",
+		Subcommands: []cli.Command{
+			constrainMacaroonCommand,
+		},
+	},
+}
+
+var constrainMacaroonCommand = cli.Command{
+	Name:  "constrain",
+	Usage: "restrict an existing macaroon to a set of assets",
+	ArgsUsage: "[--asset_id=] [--group_key=] [--universe_read_only] " +
+		"input-macaroon-file constrained-macaroon-file",
+	Description: `
+	Add one or more caveats to an existing macaroon that restrict it to
+	calls that only reference the given assets or asset groups, or to
+	read-only universe calls. Calls that don't reference any asset are
+	rejected for a macaroon restricted to a set of assets. The caveats are
+	added locally, the daemon doesn't need to be running.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: assetIDName,
+			Usage: "restrict the macaroon to the asset with this " +
+				"hex encoded ID; can be specified multiple " +
+				"times",
+		},
+		cli.StringSliceFlag{
+			Name: groupKeyName,
+			Usage: "restrict the macaroon to the assets of the " +
+				"group with this hex encoded group key; can " +
+				"be specified multiple times",
+		},
+		cli.BoolFlag{
+			Name: universeReadOnlyName,
+			Usage: "restrict the macaroon to calls that only " +
+				"read from the universe",
+		},
+	},
+	Action: constrainMacaroon,
+}
+
+func constrainMacaroon(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	var constraints []macaroons.Constraint
+
+	if ctx.IsSet(assetIDName) {
+		var ids []asset.ID
+		for _, idStr := range ctx.StringSlice(assetIDName) {
+			idBytes, err := hex.DecodeString(idStr)
+			if err != nil {
+				return fmt.Errorf("invalid asset ID %q: %w",
+					idStr, err)
+			}
+
+			var id asset.ID
+			if len(idBytes) != len(id) {
+				return fmt.Errorf("invalid asset ID length "+
+					"of %q", idStr)
+			}
+			copy(id[:], idBytes)
+
+			ids = append(ids, id)
+		}
+
+		constraints = append(
+			constraints, rpcperms.AssetIDConstraint(ids...),
+		)
+	}
+
+	if ctx.IsSet(groupKeyName) {
+		var keys []*btcec.PublicKey
+		for _, keyStr := range ctx.StringSlice(groupKeyName) {
+			keyBytes, err := hex.DecodeString(keyStr)
+			if err != nil {
+				return fmt.Errorf("invalid group key %q: %w",
+					keyStr, err)
+			}
+
+			key, err := btcec.ParsePubKey(keyBytes)
+			if err != nil {
+				return fmt.Errorf("invalid group key %q: %w",
+					keyStr, err)
+			}
+
+			keys = append(keys, key)
+		}
+
+		constraints = append(
+			constraints, rpcperms.GroupKeyConstraint(keys...),
+		)
+	}
+
+	if ctx.Bool(universeReadOnlyName) {
+		constraints = append(
+			constraints, rpcperms.UniverseReadOnlyConstraint(),
+		)
+	}
+
+	if len(constraints) == 0 {
+		return fmt.Errorf("at least one of --%s, --%s or --%s must be "+
+			"set", assetIDName, groupKeyName, universeReadOnlyName)
+	}
+
+	args := ctx.Args()
+	sourceFile := lncfg.CleanAndExpandPath(args.Get(0))
+	destFile := lncfg.CleanAndExpandPath(args.Get(1))
+
+	sourceBytes, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("unable to read macaroon file %s: %w",
+			sourceFile, err)
+	}
+
+	sourceMac := &macaroon.Macaroon{}
+	if err := sourceMac.UnmarshalBinary(sourceBytes); err != nil {
+		return fmt.Errorf("unable to decode macaroon file %s: %w",
+			sourceFile, err)
+	}
+
+	constrainedMac, err := macaroons.AddConstraints(
+		sourceMac, constraints...,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to constrain macaroon: %w", err)
+	}
+
+	destBytes, err := constrainedMac.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("unable to encode macaroon: %w", err)
+	}
+
+	if err := os.WriteFile(destFile, destBytes, 0600); err != nil {
+		return fmt.Errorf("unable to write macaroon file %s: %w",
+			destFile, err)
+	}
+
+	fmt.Printf("Macaroon saved to %s\n", destFile)
+
+	return nil
+}
@@ -340,13 +340,25 @@ func main() {
 		debugLevelCommand,
 		profileSubCommand,
 		getInfoCommand,
+		compactCommand,
+		unlockCommand,
 	}
 	app.Commands = append(app.Commands, assetsCommands...)
 	app.Commands = append(app.Commands, addrCommands...)
+	app.Commands = append(app.Commands, accountCommands...)
+	app.Commands = append(app.Commands, issuerPolicyCommands...)
+	app.Commands = append(app.Commands, muSig2Commands...)
+	app.Commands = append(app.Commands, hashLockCommands...)
+	app.Commands = append(app.Commands, timeLockCommands...)
+	app.Commands = append(app.Commands, swapCommands...)
+	app.Commands = append(app.Commands, sessionCommands...)
 	app.Commands = append(app.Commands, eventCommands...)
 	app.Commands = append(app.Commands, proofCommands...)
 	app.Commands = append(app.Commands, rfqCommands...)
 	app.Commands = append(app.Commands, universeCommands...)
+	app.Commands = append(app.Commands, jobCommands...)
+	app.Commands = append(app.Commands, rpcJournalCommands...)
+	app.Commands = append(app.Commands, macaroonCommands...)
 	app.Commands = append(app.Commands, devCommands...)
 
 	if err := app.Run(os.Args); err != nil {
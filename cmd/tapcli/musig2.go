@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+)
+
+var muSig2Commands = []cli.Command{
+	{
+		Name:      "musig2",
+		ShortName: "m2",
+		Usage: "Hold and spend assets cooperatively under a MuSig2 " +
+			"aggregate key.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			newMuSig2ScriptKeyCommand,
+			createMuSig2NonceCommand,
+			signMuSig2InputCommand,
+			combineMuSig2SigsCommand,
+		},
+	},
+}
+
+const (
+	participantKeyName = "participant_key"
+
+	tapscriptRootName = "tapscript_root"
+
+	inputIndexName = "input_index"
+
+	sessionIDName = "session_id"
+)
+
+var muSig2ParticipantFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name: participantKeyName,
+		Usage: "the hex encoded 33-byte public key of a participant; " +
+			"must be specified once for each participant",
+	},
+	cli.StringFlag{
+		Name: tapscriptRootName,
+		Usage: "the optional hex encoded 32-byte root of the " +
+			"tapscript tree the aggregate key is tweaked with",
+	},
+}
+
+var muSig2PsbtFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: psbtFileName,
+		Usage: "the file to read the binary virtual PSBT from; use " +
+			"the dash character (-) to read from stdin instead",
+		Value: "-",
+	},
+	cli.Uint64Flag{
+		Name:  inputIndexName,
+		Usage: "the index of the input locked to the MuSig2 key",
+	},
+	cli.StringFlag{
+		Name: outputFileName,
+		Usage: "the file to write the updated virtual PSBT to; use " +
+			"the dash character (-) to write to stdout instead",
+		Value: "-",
+	},
+}
+
+// parseMuSig2Participants parses the participant key and tapscript root flags.
+func parseMuSig2Participants(ctx *cli.Context) ([][]byte, []byte, error) {
+	var keys [][]byte
+	for _, keyHex := range ctx.StringSlice(participantKeyName) {
+		key, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid participant key: "+
+				"%w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	tapscriptRoot, err := hex.DecodeString(ctx.String(tapscriptRootName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid tapscript root: %w", err)
+	}
+
+	return keys, tapscriptRoot, nil
+}
+
+var newMuSig2ScriptKeyCommand = cli.Command{
+	Name:      "newscriptkey",
+	ShortName: "n",
+	Usage:     "create a MuSig2 script key",
+	Description: `
+	Create the script key that is the MuSig2 aggregate of the given
+	participant keys, and declare it to the wallet. Assets locked to the
+	script key can only be spent if all participants sign cooperatively.
+	The script key can be used to mint assets or to create addresses.
+	`,
+	Flags:  muSig2ParticipantFlags,
+	Action: newMuSig2ScriptKey,
+}
+
+func newMuSig2ScriptKey(ctx *cli.Context) error {
+	keys, tapscriptRoot, err := parseMuSig2Participants(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.NewMuSig2ScriptKey(
+		ctxc, &wrpc.NewMuSig2ScriptKeyRequest{
+			ParticipantKeys: keys,
+			TapscriptRoot:   tapscriptRoot,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var createMuSig2NonceCommand = cli.Command{
+	Name:      "nonce",
+	ShortName: "o",
+	Usage:     "add the wallet's MuSig2 nonce to a virtual PSBT",
+	Description: `
+	Start a MuSig2 signing session for an input of a funded virtual PSBT
+	and add the wallet's public nonce to it. The first participant also
+	needs to specify the participant keys. The updated PSBT is written to
+	the output file and the session ID, which is needed to sign, is
+	printed to stderr.
+	`,
+	Flags:  append(muSig2PsbtFlags, muSig2ParticipantFlags...),
+	Action: createMuSig2Nonce,
+}
+
+func createMuSig2Nonce(ctx *cli.Context) error {
+	keys, tapscriptRoot, err := parseMuSig2Participants(ctx)
+	if err != nil {
+		return err
+	}
+
+	psbtBytes, err := readFile(ctx.String(psbtFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read PSBT: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CreateMuSig2Nonce(
+		ctxc, &wrpc.CreateMuSig2NonceRequest{
+			VirtualPsbt:     psbtBytes,
+			InputIndex:      uint32(ctx.Uint64(inputIndexName)),
+			ParticipantKeys: keys,
+			TapscriptRoot:   tapscriptRoot,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create nonce: %w", err)
+	}
+
+	err = writeToFile(ctx.String(outputFileName), resp.VirtualPsbt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Session ID: %x\n", resp.SessionId)
+
+	return nil
+}
+
+var signMuSig2InputCommand = cli.Command{
+	Name:      "sign",
+	ShortName: "s",
+	Usage:     "add the wallet's partial signature to a virtual PSBT",
+	Description: `
+	Create the wallet's partial signature for an input of a virtual PSBT
+	that contains the nonces of all participants. The session ID is the
+	one returned when the wallet's nonce was created.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  sessionIDName,
+			Usage: "the hex encoded MuSig2 session ID",
+		},
+	}, muSig2PsbtFlags...),
+	Action: signMuSig2Input,
+}
+
+func signMuSig2Input(ctx *cli.Context) error {
+	sessionID, err := hex.DecodeString(ctx.String(sessionIDName))
+	if err != nil {
+		return fmt.Errorf("invalid session ID: %w", err)
+	}
+
+	psbtBytes, err := readFile(ctx.String(psbtFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read PSBT: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SignMuSig2Input(ctxc, &wrpc.SignMuSig2InputRequest{
+		VirtualPsbt: psbtBytes,
+		InputIndex:  uint32(ctx.Uint64(inputIndexName)),
+		SessionId:   sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to sign: %w", err)
+	}
+
+	return writeToFile(ctx.String(outputFileName), resp.VirtualPsbt)
+}
+
+var combineMuSig2SigsCommand = cli.Command{
+	Name:      "combine",
+	ShortName: "c",
+	Usage:     "combine the MuSig2 partial signatures of a virtual PSBT",
+	Description: `
+	Combine the partial signatures of all participants of an input of a
+	virtual PSBT into the input's final witness.
+	`,
+	Flags:  muSig2PsbtFlags,
+	Action: combineMuSig2Sigs,
+}
+
+func combineMuSig2Sigs(ctx *cli.Context) error {
+	psbtBytes, err := readFile(ctx.String(psbtFileName))
+	if err != nil {
+		return fmt.Errorf("unable to read PSBT: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CombineMuSig2Sigs(
+		ctxc, &wrpc.CombineMuSig2SigsRequest{
+			VirtualPsbt: psbtBytes,
+			InputIndex:  uint32(ctx.Uint64(inputIndexName)),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to combine signatures: %w", err)
+	}
+
+	err = writeToFile(ctx.String(outputFileName), resp.VirtualPsbt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Fully signed: %v\n", resp.FullySigned)
+
+	return nil
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/taprpc"
 	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
 	"github.com/lightningnetwork/lnd/lncfg"
@@ -21,8 +25,11 @@ var proofCommands = []cli.Command{
 		Category:  "Proofs",
 		Subcommands: []cli.Command{
 			verifyProofCommand,
+			compatibilityReportCommand,
 			decodeProofCommand,
 			exportProofCommand,
+			exportAllProofsCommand,
+			importProofArchiveCommand,
 			proveOwnershipCommand,
 			verifyOwnershipCommand,
 		},
@@ -32,6 +39,10 @@ var proofCommands = []cli.Command{
 const (
 	proofPathName = "proof_file"
 
+	offlineName          = "offline"
+	headersFileName      = "headers_file"
+	trustedGroupKeysName = "trusted_group_keys"
+
 	proofAtDepthName      = "proof_at_depth"
 	withPrevWitnessesName = "latest_proof"
 	withMetaRevealName    = "meta_reveal"
@@ -47,6 +58,13 @@ var verifyProofCommand = cli.Command{
 	prove that the creator of the proof can actually also spend the asset.
 	To verify ownership, use the "verifyownership" command with a separate
 	ownership proof.
+
+	With --offline, the proof is verified locally without a running tapd.
+	The anchor blocks of the proofs are then checked against the block
+	headers of the given headers file. Each line of that file contains a
+	block height and the hex encoded block header, separated by a space.
+	Group keys are accepted if the proof file reveals them or if they're
+	passed as trusted group keys.
 `,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -54,6 +72,24 @@ var verifyProofCommand = cli.Command{
 			Usage: "the path to the proof file on disk; use the " +
 				"dash character (-) to read from stdin instead",
 		},
+		cli.BoolFlag{
+			Name: offlineName,
+			Usage: "verify the proof locally against the block " +
+				"headers of the headers file instead of " +
+				"asking tapd",
+		},
+		cli.StringFlag{
+			Name: headersFileName,
+			Usage: "the path to the file with the block headers " +
+				"to verify against in offline mode",
+		},
+		cli.StringSliceFlag{
+			Name: trustedGroupKeysName,
+			Usage: "a hex encoded group key that is accepted in " +
+				"offline mode even though the proof file " +
+				"doesn't reveal it; can be specified " +
+				"multiple times",
+		},
 	},
 	Action: verifyProof,
 }
@@ -69,6 +105,10 @@ func verifyProof(ctx *cli.Context) error {
 		return fmt.Errorf("unable to read proof file: %w", err)
 	}
 
+	if ctx.Bool(offlineName) {
+		return verifyProofOffline(ctx, rawFile)
+	}
+
 	ctxc := getContext()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
@@ -84,6 +124,134 @@ func verifyProof(ctx *cli.Context) error {
 	return nil
 }
 
+var compatibilityReportCommand = cli.Command{
+	Name:      "compatibility",
+	ShortName: "c",
+	Usage: "show the VM versions supported by tapd and check a proof " +
+		"file against them",
+	Description: `
+	Show the versions of the consensus rules (VM versions) that tapd knows
+	how to enforce. If a proof file is given, also show the VM versions its
+	proofs declare and whether tapd is able to validate all of them.
+`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: proofPathName,
+			Usage: "the optional path to a proof file on disk; " +
+				"use the dash character (-) to read from " +
+				"stdin instead",
+		},
+	},
+	Action: compatibilityReport,
+}
+
+func compatibilityReport(ctx *cli.Context) error {
+	req := &taprpc.CompatibilityReportRequest{}
+	if ctx.String(proofPathName) != "" {
+		filePath := lncfg.CleanAndExpandPath(ctx.String(proofPathName))
+		rawFile, err := readFile(filePath)
+		if err != nil {
+			return fmt.Errorf("unable to read proof file: %w", err)
+		}
+
+		req.RawProofFile = rawFile
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CompatibilityReport(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to get compatibility report: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// offlineVerifyResult is the result of an offline proof verification.
+type offlineVerifyResult struct {
+	Valid             bool   `json:"valid"`
+	AssetID           string `json:"asset_id"`
+	Amount            uint64 `json:"amount"`
+	ScriptKey         string `json:"script_key"`
+	GroupKey          string `json:"group_key,omitempty"`
+	AnchorOutpoint    string `json:"anchor_outpoint"`
+	AnchorBlockHash   string `json:"anchor_block_hash"`
+	AnchorBlockHeight uint32 `json:"anchor_block_height"`
+}
+
+func verifyProofOffline(ctx *cli.Context, rawFile []byte) error {
+	if ctx.String(headersFileName) == "" {
+		return fmt.Errorf("the --%s flag is required in offline mode",
+			headersFileName)
+	}
+
+	headersPath := lncfg.CleanAndExpandPath(ctx.String(headersFileName))
+	headersFile, err := os.Open(headersPath)
+	if err != nil {
+		return fmt.Errorf("unable to open headers file: %w", err)
+	}
+	defer headersFile.Close()
+
+	headers, err := proof.ParseHeaderSet(headersFile)
+	if err != nil {
+		return fmt.Errorf("unable to parse headers file: %w", err)
+	}
+
+	var trustedKeys []*btcec.PublicKey
+	for _, keyHex := range ctx.StringSlice(trustedGroupKeysName) {
+		keyBytes, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return fmt.Errorf("invalid group key %s: %w", keyHex,
+				err)
+		}
+
+		key, err := btcec.ParsePubKey(keyBytes)
+		if err != nil {
+			return fmt.Errorf("invalid group key %s: %w", keyHex,
+				err)
+		}
+
+		trustedKeys = append(trustedKeys, key)
+	}
+
+	var f proof.File
+	if err := f.Decode(bytes.NewReader(rawFile)); err != nil {
+		return fmt.Errorf("unable to decode proof file: %w", err)
+	}
+
+	snapshot, err := proof.VerifyOffline(
+		getContext(), &f, headers,
+		proof.WithTrustedGroupKeys(trustedKeys...),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to verify proof file: %w", err)
+	}
+
+	a := snapshot.Asset
+	result := offlineVerifyResult{
+		Valid:   true,
+		AssetID: a.ID().String(),
+		Amount:  a.Amount,
+		ScriptKey: hex.EncodeToString(
+			a.ScriptKey.PubKey.SerializeCompressed(),
+		),
+		AnchorOutpoint:    snapshot.OutPoint.String(),
+		AnchorBlockHash:   snapshot.AnchorBlockHash.String(),
+		AnchorBlockHeight: snapshot.AnchorBlockHeight,
+	}
+	if a.GroupKey != nil {
+		result.GroupKey = hex.EncodeToString(
+			a.GroupKey.GroupPubKey.SerializeCompressed(),
+		)
+	}
+
+	printJSON(result)
+	return nil
+}
+
 var decodeProofCommand = cli.Command{
 	Name:      "decode",
 	ShortName: "d",
@@ -389,3 +557,136 @@ func writeToFile(fileName string, content []byte) error {
 
 	return nil
 }
+
+const (
+	archiveFileName = "archive_file"
+)
+
+var exportAllProofsCommand = cli.Command{
+	Name:  "exportall",
+	Usage: "export the proofs, addresses and keys of the wallet",
+	Description: `
+	Export a gzip compressed tarball that contains the proof files of all
+	unspent assets owned by the wallet, the address book and the
+	descriptors of the script and internal keys of the assets. The archive
+	can be imported with the "importarchive" command to migrate the wallet
+	to another tapd instance or database backend. The target instance must
+	use the same lnd wallet, as the archive only contains the derivation
+	paths of the keys.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: archiveFileName,
+			Usage: "the file to write the archive to; use the " +
+				"dash character (-) to write to stdout",
+		},
+	},
+	Action: exportAllProofs,
+}
+
+func exportAllProofs(ctx *cli.Context) error {
+	if ctx.String(archiveFileName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	var (
+		out = os.Stdout
+		err error
+	)
+	if fileName := ctx.String(archiveFileName); fileName != "-" {
+		out, err = os.Create(lncfg.CleanAndExpandPath(fileName))
+		if err != nil {
+			return fmt.Errorf("unable to create archive file: %w",
+				err)
+		}
+		defer out.Close()
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.ExportAllProofs(
+		ctxc, &taprpc.ExportAllProofsRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to export proofs: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+
+		case err != nil:
+			return fmt.Errorf("unable to export proofs: %w", err)
+		}
+
+		if _, err := out.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("unable to write archive: %w", err)
+		}
+	}
+}
+
+var importProofArchiveCommand = cli.Command{
+	Name:  "importarchive",
+	Usage: "import an archive created by the exportall command",
+	Description: `
+	Import the key descriptors, proofs and addresses of an archive that
+	was created with the "exportall" command. The proofs are verified
+	against the chain. Proofs and addresses that are already known are
+	skipped, so an import can safely be repeated.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  archiveFileName,
+			Usage: "the archive file to import",
+		},
+	},
+	Action: importProofArchive,
+}
+
+func importProofArchive(ctx *cli.Context) error {
+	if ctx.String(archiveFileName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	archiveFile, err := os.Open(lncfg.CleanAndExpandPath(
+		ctx.String(archiveFileName),
+	))
+	if err != nil {
+		return fmt.Errorf("unable to open archive file: %w", err)
+	}
+	defer archiveFile.Close()
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.ImportProofArchive(ctxc)
+	if err != nil {
+		return fmt.Errorf("unable to import archive: %w", err)
+	}
+
+	err = proof.SendBlobChunks(archiveFile, func(chunk []byte) error {
+		return stream.Send(&taprpc.ProofArchiveChunk{
+			Chunk: chunk,
+		})
+	})
+
+	// If the daemon rejects the archive while it is being sent, sending
+	// returns io.EOF and the actual error is returned when receiving the
+	// response.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to import archive: %w", err)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("unable to import archive: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/lightninglabs/taproot-assets/taprpc/rfqrpc"
@@ -15,6 +16,11 @@ var rfqCommands = []cli.Command{
 		Category:  "Channels",
 		Subcommands: []cli.Command{
 			acceptedQuotesCommand,
+			peerReputationsCommand,
+			settlementStatsCommand,
+			quotesCommand,
+			quoteVolumeCommand,
+			inventoryCommand,
 		},
 	},
 }
@@ -45,3 +51,390 @@ func acceptedQuotes(ctx *cli.Context) error {
 
 	return nil
 }
+
+var peerReputationsCommand = cli.Command{
+	Name:      "peerreputations",
+	ShortName: "p",
+	Usage:     "show the reputation of the node's RFQ peers",
+	Description: `
+	Lists the reputation scores and the underlying metrics of all peers the
+	node requested quotes from.
+`,
+	Action: peerReputations,
+}
+
+func peerReputations(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getRfqClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.QueryPeerReputations(
+		ctxc, &rfqrpc.QueryPeerReputationsRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to query peer reputations: %w", err)
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+const (
+	peerPubKeyName = "peer_pubkey"
+)
+
+var settlementStatsCommand = cli.Command{
+	Name:      "settlementstats",
+	ShortName: "s",
+	Usage:     "show the settled asset HTLC volume per asset, peer and day",
+	Description: `
+	Lists the number of settled HTLCs, the settled asset and BTC amounts
+	and the average realized rate of the HTLCs the node accepted under an
+	RFQ quote, grouped by asset, peer and day.
+`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: assetIDName,
+			Usage: "(optional) only show the stats of this " +
+				"asset ID",
+		},
+		cli.StringFlag{
+			Name: groupKeyName,
+			Usage: "(optional) only show the stats of this asset " +
+				"group key",
+		},
+		cli.StringFlag{
+			Name:  peerPubKeyName,
+			Usage: "(optional) only show the stats of this peer",
+		},
+		cli.Int64Flag{
+			Name: startTime,
+			Usage: "(optional) the unix timestamp to start " +
+				"querying from; if not specified, will query " +
+				"from last 30 days by default",
+		},
+		cli.Int64Flag{
+			Name: endTime,
+			Usage: "(optional) the unix timestamp to end " +
+				"querying at; if not specified, will query " +
+				"until now by default",
+		},
+	},
+	Action: settlementStats,
+}
+
+func settlementStats(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getRfqClient(ctx)
+	defer cleanUp()
+
+	req := &rfqrpc.QuerySettlementStatsRequest{
+		StartTime: ctx.Int64(startTime),
+		EndTime:   ctx.Int64(endTime),
+	}
+
+	assetSpecifier, err := parseRfqAssetSpecifier(ctx)
+	if err != nil {
+		return err
+	}
+	req.AssetSpecifier = assetSpecifier
+
+	req.PeerPubKey, err = parsePeerPubKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.QuerySettlementStats(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to query settlement stats: %w", err)
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// parseRfqAssetSpecifier parses the optional asset ID or group key flags into
+// an RFQ asset specifier. Nil is returned if neither is set.
+func parseRfqAssetSpecifier(ctx *cli.Context) (*rfqrpc.AssetSpecifier,
+	error) {
+
+	switch {
+	case ctx.IsSet(assetIDName) && ctx.IsSet(groupKeyName):
+		return nil, fmt.Errorf("only one of --%s and --%s can be set",
+			assetIDName, groupKeyName)
+
+	case ctx.IsSet(assetIDName):
+		assetID, err := hex.DecodeString(ctx.String(assetIDName))
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset ID: %w", err)
+		}
+
+		return &rfqrpc.AssetSpecifier{
+			Id: &rfqrpc.AssetSpecifier_AssetId{
+				AssetId: assetID,
+			},
+		}, nil
+
+	case ctx.IsSet(groupKeyName):
+		groupKey, err := hex.DecodeString(ctx.String(groupKeyName))
+		if err != nil {
+			return nil, fmt.Errorf("invalid group key: %w", err)
+		}
+
+		return &rfqrpc.AssetSpecifier{
+			Id: &rfqrpc.AssetSpecifier_GroupKey{
+				GroupKey: groupKey,
+			},
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// parsePeerPubKey parses the optional peer public key flag. Nil is returned if
+// it isn't set.
+func parsePeerPubKey(ctx *cli.Context) ([]byte, error) {
+	if !ctx.IsSet(peerPubKeyName) {
+		return nil, nil
+	}
+
+	peer, err := hex.DecodeString(ctx.String(peerPubKeyName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer public key: %w", err)
+	}
+
+	return peer, nil
+}
+
+const (
+	quoteDirectionName = "direction"
+
+	quoteAcceptorName = "acceptor"
+
+	quoteStateName = "state"
+)
+
+// quoteFilterFlags are the flags of the commands that query recorded quotes.
+var quoteFilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  assetIDName,
+		Usage: "(optional) only include quotes of this asset ID",
+	},
+	cli.StringFlag{
+		Name: groupKeyName,
+		Usage: "(optional) only include quotes of this asset group " +
+			"key",
+	},
+	cli.StringFlag{
+		Name:  peerPubKeyName,
+		Usage: "(optional) only include quotes of this peer",
+	},
+	cli.StringFlag{
+		Name: quoteDirectionName,
+		Usage: "(optional) only include quotes of this direction, " +
+			"either 'buy' or 'sell'",
+	},
+	cli.StringFlag{
+		Name: quoteAcceptorName,
+		Usage: "(optional) only include quotes accepted by this " +
+			"node, either 'local' or 'peer'",
+	},
+	cli.StringFlag{
+		Name: quoteStateName,
+		Usage: "(optional) only include quotes in this state, " +
+			"either 'active' or 'expired'",
+	},
+	cli.Int64Flag{
+		Name: startTime,
+		Usage: "(optional) the unix timestamp to start querying " +
+			"from; if not specified, will query from last 30 " +
+			"days by default",
+	},
+	cli.Int64Flag{
+		Name: endTime,
+		Usage: "(optional) the unix timestamp to end querying at; " +
+			"if not specified, will query until now by default",
+	},
+}
+
+// parseQuoteFilter parses the quote filter flags.
+func parseQuoteFilter(ctx *cli.Context) (*rfqrpc.QuoteFilter, error) {
+	filter := &rfqrpc.QuoteFilter{
+		StartTime: ctx.Int64(startTime),
+		EndTime:   ctx.Int64(endTime),
+	}
+
+	var err error
+	filter.AssetSpecifier, err = parseRfqAssetSpecifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.PeerPubKey, err = parsePeerPubKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ctx.String(quoteDirectionName) {
+	case "":
+		// No filter.
+
+	case "buy":
+		filter.Direction = rfqrpc.QuoteDirection_QUOTE_DIRECTION_BUY
+
+	case "sell":
+		filter.Direction = rfqrpc.QuoteDirection_QUOTE_DIRECTION_SELL
+
+	default:
+		return nil, fmt.Errorf("invalid quote direction %q",
+			ctx.String(quoteDirectionName))
+	}
+
+	switch ctx.String(quoteAcceptorName) {
+	case "":
+		// No filter.
+
+	case "local":
+		filter.Acceptor = rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_LOCAL
+
+	case "peer":
+		filter.Acceptor = rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_PEER
+
+	default:
+		return nil, fmt.Errorf("invalid quote acceptor %q",
+			ctx.String(quoteAcceptorName))
+	}
+
+	switch ctx.String(quoteStateName) {
+	case "":
+		// No filter.
+
+	case "active":
+		filter.State = rfqrpc.QuoteState_QUOTE_STATE_ACTIVE
+
+	case "expired":
+		filter.State = rfqrpc.QuoteState_QUOTE_STATE_EXPIRED
+
+	default:
+		return nil, fmt.Errorf("invalid quote state %q",
+			ctx.String(quoteStateName))
+	}
+
+	return filter, nil
+}
+
+var quotesCommand = cli.Command{
+	Name:  "quotes",
+	Usage: "show the active and historical quotes of the node",
+	Description: `
+	Lists the quotes that were accepted by the node or by its peers,
+	together with the number of settled HTLCs and the asset and BTC amounts
+	realized under them. The realized volume is only tracked for quotes
+	the node accepted.
+`,
+	Flags: append(quoteFilterFlags,
+		cli.UintFlag{
+			Name:  offsetName,
+			Usage: "(optional) the number of quotes to skip",
+		},
+		cli.UintFlag{
+			Name: limitName,
+			Usage: "(optional) the maximum number of quotes to " +
+				"show; defaults to 1000",
+		},
+	),
+	Action: quotes,
+}
+
+func quotes(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getRfqClient(ctx)
+	defer cleanUp()
+
+	filter, err := parseQuoteFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.QueryQuotes(ctxc, &rfqrpc.QueryQuotesRequest{
+		Filter: filter,
+		Offset: uint32(ctx.Uint(offsetName)),
+		Limit:  uint32(ctx.Uint(limitName)),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to query quotes: %w", err)
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var quoteVolumeCommand = cli.Command{
+	Name:  "quotevolume",
+	Usage: "show the volume realized under the node's quotes per asset",
+	Description: `
+	Lists the number of quotes, the number of settled HTLCs, the settled
+	asset and BTC amounts and the average realized rate of the quotes
+	matching the filters, aggregated per asset.
+`,
+	Flags:  quoteFilterFlags,
+	Action: quoteVolume,
+}
+
+func quoteVolume(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getRfqClient(ctx)
+	defer cleanUp()
+
+	filter, err := parseQuoteFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.QueryQuoteVolume(
+		ctxc, &rfqrpc.QueryQuoteVolumeRequest{
+			Filter: filter,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to query quote volume: %w", err)
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var inventoryCommand = cli.Command{
+	Name:      "inventory",
+	ShortName: "i",
+	Usage:     "show the node's exposure per asset",
+	Description: `
+	Lists the current exposure of the node per asset from the open quotes
+	it accepted, the in-flight HTLCs and the asset channel balances, and
+	compares the projected balance against the configured inventory
+	targets.
+`,
+	Action: inventory,
+}
+
+func inventory(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getRfqClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.QueryInventoryRisk(
+		ctxc, &rfqrpc.QueryInventoryRiskRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to query inventory: %w", err)
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+)
+
+const (
+	startTimeName = "start_time"
+
+	endTimeName = "end_time"
+
+	journalMethodName = "method"
+
+	formatName = "format"
+
+	outputFileName = "output_file"
+
+	formatJSON = "json"
+
+	formatCSV = "csv"
+
+	// journalPageSize is the number of journal entries that are fetched
+	// per call.
+	journalPageSize = 1000
+)
+
+var rpcJournalCommands = []cli.Command{
+	{
+		Name:     "rpcjournal",
+		Usage:    "Interact with the RPC journal.",
+		Category: "Daemon",
+		Subcommands: []cli.Command{
+			exportRpcJournalCommand,
+		},
+	},
+}
+
+var exportRpcJournalCommand = cli.Command{
+	Name:  "export",
+	Usage: "export the entries of the RPC journal",
+	Description: "Export all mutating RPC calls recorded by the daemon. " +
+		"Calls are only recorded if the daemon runs with " +
+		"--rpcjournal.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: startTimeName,
+			Usage: "(optional) only export calls that were " +
+				"received at or after this Unix timestamp",
+		},
+		cli.Int64Flag{
+			Name: endTimeName,
+			Usage: "(optional) only export calls that were " +
+				"received at or before this Unix timestamp",
+		},
+		cli.StringFlag{
+			Name: journalMethodName,
+			Usage: "(optional) only export calls of this full " +
+				"gRPC method name, for example " +
+				"/taprpc.TaprootAssets/SendAsset",
+		},
+		cli.StringFlag{
+			Name:  formatName,
+			Usage: "the output format, either json or csv",
+			Value: formatJSON,
+		},
+		cli.StringFlag{
+			Name: outputFileName,
+			Usage: "the file to write the export to; use the " +
+				"dash character (-) to write to stdout",
+			Value: "-",
+		},
+	},
+	Action: exportRpcJournal,
+}
+
+func exportRpcJournal(ctx *cli.Context) error {
+	format := ctx.String(formatName)
+	if format != formatJSON && format != formatCSV {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// The entries are fetched page by page, so a large journal doesn't
+	// exceed the maximum message size.
+	var entries []*taprpc.RpcJournalEntry
+	for {
+		resp, err := client.ExportRpcJournal(
+			ctxc, &taprpc.ExportRpcJournalRequest{
+				StartTimestamp: ctx.Int64(startTimeName),
+				EndTimestamp:   ctx.Int64(endTimeName),
+				Method:         ctx.String(journalMethodName),
+				Offset:         int32(len(entries)),
+				Limit:          journalPageSize,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to export RPC journal: %w",
+				err)
+		}
+
+		entries = append(entries, resp.Entries...)
+		if len(resp.Entries) < journalPageSize {
+			break
+		}
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+	switch format {
+	case formatJSON:
+		content, err = taprpc.ProtoJSONMarshalOpts.Marshal(
+			&taprpc.ExportRpcJournalResponse{
+				Entries: entries,
+			},
+		)
+
+	case formatCSV:
+		content, err = rpcJournalCSV(entries)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to encode RPC journal: %w", err)
+	}
+
+	fileName := ctx.String(outputFileName)
+	if fileName != "-" {
+		fileName = lncfg.CleanAndExpandPath(fileName)
+	}
+
+	return writeToFile(fileName, append(content, '\n'))
+}
+
+// rpcJournalCSV encodes the given journal entries as CSV, with one row per
+// entry.
+func rpcJournalCSV(entries []*taprpc.RpcJournalEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.Write([]string{
+		"start_time", "end_time", "method", "caller", "peer_addr",
+		"status_code", "error_msg", "params",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	formatTime := func(us int64) string {
+		return time.UnixMicro(us).UTC().Format(time.RFC3339Nano)
+	}
+	for _, entry := range entries {
+		err := w.Write([]string{
+			formatTime(entry.StartTimestampUs),
+			formatTime(entry.EndTimestampUs),
+			entry.Method,
+			entry.Caller,
+			entry.PeerAddr,
+			entry.StatusCode,
+			entry.ErrorMsg,
+			entry.Params,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
@@ -0,0 +1,546 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+)
+
+var sessionCommands = []cli.Command{
+	{
+		Name:      "sessions",
+		ShortName: "ss",
+		Usage: "Construct and sign virtual transactions together " +
+			"with other parties.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			createSessionCommand,
+			contributeToSessionCommand,
+			startSessionSigningCommand,
+			submitSessionSignaturesCommand,
+			finalizeSessionCommand,
+			anchorSessionCommand,
+			publishSessionCommand,
+			getSessionCommand,
+		},
+	},
+}
+
+const (
+	musig2InputName = "musig2_input"
+
+	nonceName = "nonce"
+
+	partialSigName = "partial_sig"
+)
+
+var sessionPsbtFlag = cli.StringSliceFlag{
+	Name: psbtFileName,
+	Usage: "the file to read a binary PSBT from; can be specified " +
+		"multiple times",
+}
+
+// readSessionPsbts reads all PSBT files passed through the PSBT file flag.
+func readSessionPsbts(ctx *cli.Context) ([][]byte, error) {
+	fileNames := ctx.StringSlice(psbtFileName)
+	if len(fileNames) == 0 {
+		return nil, fmt.Errorf("at least one PSBT file is required")
+	}
+
+	psbts := make([][]byte, len(fileNames))
+	for idx, fileName := range fileNames {
+		var err error
+		psbts[idx], err = readFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read PSBT %v: %w",
+				fileName, err)
+		}
+	}
+
+	return psbts, nil
+}
+
+// parseSessionIDArg parses the hex encoded session ID passed as the first
+// argument.
+func parseSessionIDArg(ctx *cli.Context) ([]byte, error) {
+	if ctx.NArg() != 1 {
+		return nil, fmt.Errorf("session ID argument missing")
+	}
+
+	sessionID, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// parseSessionInputRef parses an input reference of the form
+// packet_index:input_index.
+func parseSessionInputRef(packetIdx,
+	inputIdx string) (*wrpc.SessionInputRef, error) {
+
+	packetIndex, err := strconv.ParseUint(packetIdx, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet index: %w", err)
+	}
+
+	inputIndex, err := strconv.ParseUint(inputIdx, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input index: %w", err)
+	}
+
+	return &wrpc.SessionInputRef{
+		PacketIndex: uint32(packetIndex),
+		InputIndex:  uint32(inputIndex),
+	}, nil
+}
+
+// parseSessionValue parses a flag value of the form
+// packet_index:input_index:hex_key:hex_value.
+func parseSessionValue(value string) (*wrpc.SessionInputRef, []byte, []byte,
+	error) {
+
+	parts := strings.Split(value, ":")
+	if len(parts) != 4 {
+		return nil, nil, nil, fmt.Errorf("invalid value %v, expected "+
+			"packet_index:input_index:key:value", value)
+	}
+
+	ref, err := parseSessionInputRef(parts[0], parts[1])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid participant key: %w",
+			err)
+	}
+
+	rawValue, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	return ref, key, rawValue, nil
+}
+
+var createSessionCommand = cli.Command{
+	Name:      "create",
+	ShortName: "c",
+	Usage:     "create a new signing session",
+	Description: `
+	Create a new multi-party signing session from the funded virtual PSBTs
+	of this party. The returned session ID can be shared with the other
+	parties, which contribute their own inputs and outputs to the session.
+	`,
+	Flags:  []cli.Flag{sessionPsbtFlag},
+	Action: createSession,
+}
+
+func createSession(ctx *cli.Context) error {
+	psbts, err := readSessionPsbts(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CreateSigningSession(
+		ctxc, &wrpc.CreateSigningSessionRequest{
+			VirtualPsbts: psbts,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var contributeToSessionCommand = cli.Command{
+	Name:      "contribute",
+	ShortName: "ct",
+	Usage:     "contribute inputs and outputs to a signing session",
+	Description: `
+	Add the inputs and outputs of the funded virtual PSBTs of another
+	party to an open signing session. Packets of an asset that is already
+	part of the session are merged into the existing packet.
+	`,
+	ArgsUsage: "session_id",
+	Flags:     []cli.Flag{sessionPsbtFlag},
+	Action:    contributeToSession,
+}
+
+func contributeToSession(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	psbts, err := readSessionPsbts(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ContributeToSession(
+		ctxc, &wrpc.ContributeToSessionRequest{
+			SessionId:    sessionID,
+			VirtualPsbts: psbts,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to contribute to session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var startSessionSigningCommand = cli.Command{
+	Name:      "startsigning",
+	ShortName: "st",
+	Usage:     "close a signing session for contributions",
+	Description: `
+	Close a signing session for contributions and prepare its virtual
+	transactions for signing. Inputs that are signed by several
+	participants through MuSig2 are declared with the musig2_input flag
+	in the form packet_index:input_index:key1,key2[,...][:tapscript_root].
+	`,
+	ArgsUsage: "session_id",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: musig2InputName,
+			Usage: "an input signed through MuSig2; can be " +
+				"specified multiple times",
+		},
+	},
+	Action: startSessionSigning,
+}
+
+func startSessionSigning(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	var specs []*wrpc.MuSig2InputSpec
+	for _, value := range ctx.StringSlice(musig2InputName) {
+		parts := strings.Split(value, ":")
+		if len(parts) != 3 && len(parts) != 4 {
+			return fmt.Errorf("invalid MuSig2 input %v", value)
+		}
+
+		ref, err := parseSessionInputRef(parts[0], parts[1])
+		if err != nil {
+			return err
+		}
+
+		spec := &wrpc.MuSig2InputSpec{
+			Input: ref,
+		}
+		for _, hexKey := range strings.Split(parts[2], ",") {
+			key, err := hex.DecodeString(hexKey)
+			if err != nil {
+				return fmt.Errorf("invalid participant key: "+
+					"%w", err)
+			}
+
+			spec.ParticipantKeys = append(
+				spec.ParticipantKeys, key,
+			)
+		}
+
+		if len(parts) == 4 {
+			spec.TapscriptRoot, err = hex.DecodeString(parts[3])
+			if err != nil {
+				return fmt.Errorf("invalid tapscript root: %w",
+					err)
+			}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.StartSessionSigning(
+		ctxc, &wrpc.StartSessionSigningRequest{
+			SessionId:    sessionID,
+			Musig2Inputs: specs,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to start signing: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var submitSessionSignaturesCommand = cli.Command{
+	Name:      "submit",
+	ShortName: "sb",
+	Usage:     "submit signatures to a signing session",
+	Description: `
+	Submit the partially signed virtual PSBTs, MuSig2 nonces and MuSig2
+	partial signatures of a party to a signing session. Nonces and partial
+	signatures are given in the form
+	packet_index:input_index:participant_key:value, all hex encoded.
+	`,
+	ArgsUsage: "session_id",
+	Flags: []cli.Flag{
+		sessionPsbtFlag,
+		cli.StringSliceFlag{
+			Name: nonceName,
+			Usage: "a MuSig2 public nonce; can be specified " +
+				"multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: partialSigName,
+			Usage: "a MuSig2 partial signature; can be " +
+				"specified multiple times",
+		},
+	},
+	Action: submitSessionSignatures,
+}
+
+func submitSessionSignatures(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &wrpc.SubmitSessionSignaturesRequest{
+		SessionId: sessionID,
+	}
+	if len(ctx.StringSlice(psbtFileName)) > 0 {
+		req.SignedVirtualPsbts, err = readSessionPsbts(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, value := range ctx.StringSlice(nonceName) {
+		ref, key, nonce, err := parseSessionValue(value)
+		if err != nil {
+			return err
+		}
+
+		req.Nonces = append(req.Nonces, &wrpc.MuSig2Nonce{
+			Input:          ref,
+			ParticipantKey: key,
+			PubNonce:       nonce,
+		})
+	}
+
+	for _, value := range ctx.StringSlice(partialSigName) {
+		ref, key, sig, err := parseSessionValue(value)
+		if err != nil {
+			return err
+		}
+
+		req.PartialSigs = append(
+			req.PartialSigs, &wrpc.MuSig2PartialSig{
+				Input:          ref,
+				ParticipantKey: key,
+				PartialSig:     sig,
+			},
+		)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SubmitSessionSignatures(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to submit signatures: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var finalizeSessionCommand = cli.Command{
+	Name:      "finalize",
+	ShortName: "f",
+	Usage:     "finalize the virtual transactions of a signing session",
+	Description: `
+	Combine the MuSig2 partial signatures of a signing session and
+	validate the witnesses of all its virtual transactions.
+	`,
+	ArgsUsage: "session_id",
+	Action:    finalizeSession,
+}
+
+func finalizeSession(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.FinalizeSession(ctxc, &wrpc.FinalizeSessionRequest{
+		SessionId: sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to finalize session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var anchorSessionCommand = cli.Command{
+	Name:      "anchor",
+	ShortName: "a",
+	Usage:     "commit a signing session to an anchor transaction",
+	Description: `
+	Commit the finalized virtual transactions of a signing session to an
+	anchor transaction that is funded by this wallet. The returned anchor
+	PSBT must be signed by the parties owning its inputs before it can be
+	published.
+	`,
+	ArgsUsage: "session_id",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks the anchor transaction " +
+				"should confirm in",
+		},
+		cli.Uint64Flag{
+			Name: feeRateName,
+			Usage: "the fee rate of the anchor transaction in " +
+				"sat/vB",
+		},
+	},
+	Action: anchorSession,
+}
+
+func anchorSession(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &wrpc.AnchorSessionRequest{
+		SessionId: sessionID,
+	}
+	switch {
+	case ctx.IsSet("conf_target"):
+		req.Fees = &wrpc.AnchorSessionRequest_TargetConf{
+			TargetConf: uint32(ctx.Uint64("conf_target")),
+		}
+
+	case ctx.IsSet(feeRateName):
+		req.Fees = &wrpc.AnchorSessionRequest_SatPerVbyte{
+			SatPerVbyte: ctx.Uint64(feeRateName),
+		}
+
+	default:
+		return fmt.Errorf("either conf_target or %v must be set",
+			feeRateName)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.AnchorSession(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to anchor session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var publishSessionCommand = cli.Command{
+	Name:      "publish",
+	ShortName: "p",
+	Usage:     "publish the anchor transaction of a signing session",
+	Description: `
+	Add the signatures of the given signed anchor PSBTs to the anchor
+	transaction of a signing session. Once it is fully signed, the anchor
+	transaction is published and the transfer is logged.
+	`,
+	ArgsUsage: "session_id",
+	Flags:     []cli.Flag{sessionPsbtFlag},
+	Action:    publishSession,
+}
+
+func publishSession(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	psbts, err := readSessionPsbts(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.PublishSession(ctxc, &wrpc.PublishSessionRequest{
+		SessionId:         sessionID,
+		SignedAnchorPsbts: psbts,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to publish session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var getSessionCommand = cli.Command{
+	Name:      "get",
+	ShortName: "g",
+	Usage:     "show the current state of a signing session",
+	ArgsUsage: "session_id",
+	Action:    getSession,
+}
+
+func getSession(ctx *cli.Context) error {
+	sessionID, err := parseSessionIDArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.GetSigningSession(
+		ctxc, &wrpc.GetSigningSessionRequest{
+			SessionId: sessionID,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to fetch session: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+	"google.golang.org/protobuf/proto"
+)
+
+var swapCommands = []cli.Command{
+	{
+		Name:      "swaps",
+		ShortName: "sw",
+		Usage: "Atomically swap assets for BTC or other assets " +
+			"with another daemon.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			createSwapOfferCommand,
+			acceptSwapOfferCommand,
+			proposeSwapCommand,
+			fundSwapCommand,
+			publishSwapCommand,
+			completeSwapCommand,
+			listSwapsCommand,
+		},
+	},
+}
+
+const (
+	priceSatName = "price_sat"
+
+	priceAssetIDName = "price_asset_id"
+
+	priceAssetAmountName = "price_asset_amount"
+
+	expirySecondsName = "expiry_seconds"
+
+	messageFileName = "message_file"
+)
+
+var swapMessageFlag = cli.StringFlag{
+	Name: messageFileName,
+	Usage: "the file to read the JSON encoded message of the other " +
+		"party from; use - to read from stdin",
+}
+
+// readSwapMessage reads the JSON encoded swap message passed through the
+// message file flag into the given message.
+func readSwapMessage(ctx *cli.Context, msg proto.Message) error {
+	fileName := ctx.String(messageFileName)
+	if fileName == "" {
+		return fmt.Errorf("%v must be set", messageFileName)
+	}
+
+	jsonBytes, err := readFile(fileName)
+	if err != nil {
+		return fmt.Errorf("unable to read message: %w", err)
+	}
+
+	err = taprpc.ProtoJSONUnmarshalOpts.Unmarshal(jsonBytes, msg)
+	if err != nil {
+		return fmt.Errorf("unable to decode message: %w", err)
+	}
+
+	return nil
+}
+
+var createSwapOfferCommand = cli.Command{
+	Name:      "offer",
+	ShortName: "o",
+	Usage:     "offer to sell an asset for BTC or another asset",
+	Description: `
+	Create an offer to sell the given amount of an asset for the given
+	price. The price is either paid in satoshis or, if a price asset ID is
+	set, in units of another asset. The quote of the returned swap must be
+	sent to the buyer, who accepts it with the 'accept' command.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the ID of the asset to sell",
+		},
+		cli.Uint64Flag{
+			Name:  assetAmountName,
+			Usage: "the amount of asset units to sell",
+		},
+		cli.Int64Flag{
+			Name:  priceSatName,
+			Usage: "the price of the asset units in satoshis",
+		},
+		cli.StringFlag{
+			Name: priceAssetIDName,
+			Usage: "the ID of the asset the price is paid in; " +
+				"leave empty to be paid in BTC",
+		},
+		cli.Uint64Flag{
+			Name: priceAssetAmountName,
+			Usage: "the price of the asset units in units of the " +
+				"price asset",
+		},
+		cli.Uint64Flag{
+			Name: expirySecondsName,
+			Usage: "the number of seconds after which the quote " +
+				"expires; defaults to ten minutes",
+		},
+	},
+	Action: createSwapOffer,
+}
+
+func createSwapOffer(ctx *cli.Context) error {
+	assetID, err := hex.DecodeString(ctx.String(assetIDName))
+	if err != nil {
+		return fmt.Errorf("invalid asset ID: %w", err)
+	}
+
+	priceAssetID, err := hex.DecodeString(ctx.String(priceAssetIDName))
+	if err != nil {
+		return fmt.Errorf("invalid price asset ID: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CreateSwapOffer(
+		ctxc, &wrpc.CreateSwapOfferRequest{
+			AssetId:          assetID,
+			AssetAmount:      ctx.Uint64(assetAmountName),
+			PriceSat:         ctx.Int64(priceSatName),
+			PriceAssetId:     priceAssetID,
+			PriceAssetAmount: ctx.Uint64(priceAssetAmountName),
+			ExpirySeconds:    ctx.Uint64(expirySecondsName),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create swap offer: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var acceptSwapOfferCommand = cli.Command{
+	Name:      "accept",
+	ShortName: "a",
+	Usage:     "accept the swap quote of a seller",
+	Description: `
+	Accept the JSON encoded swap quote of a seller. The acceptance of the
+	returned swap must be sent back to the seller, who answers it with the
+	'propose' command.
+	`,
+	Flags:  []cli.Flag{swapMessageFlag},
+	Action: acceptSwapOffer,
+}
+
+func acceptSwapOffer(ctx *cli.Context) error {
+	quote := &wrpc.SwapQuote{}
+	if err := readSwapMessage(ctx, quote); err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.AcceptSwapOffer(
+		ctxc, &wrpc.AcceptSwapOfferRequest{
+			Quote: quote,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to accept swap offer: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var proposeSwapCommand = cli.Command{
+	Name:      "propose",
+	ShortName: "p",
+	Usage:     "sign the transfer of the asset to the buyer",
+	Description: `
+	Fund and sign the virtual transaction that sends the asset to the keys
+	of the given JSON encoded acceptance of the buyer. The proposal of the
+	returned swap must be sent to the buyer, who answers it with the
+	'fund' command.
+	`,
+	Flags:  []cli.Flag{swapMessageFlag},
+	Action: proposeSwap,
+}
+
+func proposeSwap(ctx *cli.Context) error {
+	acceptance := &wrpc.SwapAcceptance{}
+	if err := readSwapMessage(ctx, acceptance); err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ProposeSwap(ctxc, &wrpc.ProposeSwapRequest{
+		Acceptance: acceptance,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to propose swap: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var fundSwapCommand = cli.Command{
+	Name:      "fund",
+	ShortName: "f",
+	Usage:     "fund the anchor transaction of a swap",
+	Description: `
+	Validate the given JSON encoded proposal of the seller and fund an
+	anchor transaction that pays the seller the agreed price and anchors
+	the asset. The funding of the returned swap must be sent to the
+	seller, who publishes it with the 'publish' command.
+	`,
+	Flags: []cli.Flag{
+		swapMessageFlag,
+		cli.Uint64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks the anchor transaction " +
+				"should confirm in",
+		},
+		cli.Uint64Flag{
+			Name: feeRateName,
+			Usage: "the fee rate of the anchor transaction in " +
+				"sat/vB",
+		},
+	},
+	Action: fundSwap,
+}
+
+func fundSwap(ctx *cli.Context) error {
+	proposal := &wrpc.SwapProposal{}
+	if err := readSwapMessage(ctx, proposal); err != nil {
+		return err
+	}
+
+	req := &wrpc.FundSwapRequest{
+		Proposal: proposal,
+	}
+	switch {
+	case ctx.IsSet("conf_target"):
+		req.Fees = &wrpc.FundSwapRequest_TargetConf{
+			TargetConf: uint32(ctx.Uint64("conf_target")),
+		}
+
+	case ctx.IsSet(feeRateName):
+		req.Fees = &wrpc.FundSwapRequest_SatPerVbyte{
+			SatPerVbyte: ctx.Uint64(feeRateName),
+		}
+
+	default:
+		return fmt.Errorf("either conf_target or %v must be set",
+			feeRateName)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.FundSwap(ctxc, req)
+	if err != nil {
+		return fmt.Errorf("unable to fund swap: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var publishSwapCommand = cli.Command{
+	Name:      "publish",
+	ShortName: "pub",
+	Usage:     "publish the anchor transaction of a swap",
+	Description: `
+	Validate the given JSON encoded funding of the buyer and, if the
+	anchor transaction pays the agreed price, sign its remaining inputs,
+	publish it and log the transfer.
+	`,
+	Flags:  []cli.Flag{swapMessageFlag},
+	Action: publishSwap,
+}
+
+func publishSwap(ctx *cli.Context) error {
+	funding := &wrpc.SwapFunding{}
+	if err := readSwapMessage(ctx, funding); err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.PublishSwap(ctxc, &wrpc.PublishSwapRequest{
+		Funding: funding,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to publish swap: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var completeSwapCommand = cli.Command{
+	Name:      "complete",
+	ShortName: "c",
+	Usage:     "import the asset received in a swap",
+	Description: `
+	Wait for the anchor transaction of a swap to confirm and import the
+	proof of the asset that was received. The buyer completes a funded
+	swap, the seller of an asset-for-asset swap completes a published swap
+	to receive the price asset.
+	`,
+	ArgsUsage: "swap_id",
+	Action:    completeSwap,
+}
+
+func completeSwap(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("swap ID argument missing")
+	}
+
+	swapID, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid swap ID: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.CompleteSwap(ctxc, &wrpc.CompleteSwapRequest{
+		SwapId: swapID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to complete swap: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listSwapsCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "list all swaps",
+	Action:    listSwaps,
+}
+
+func listSwaps(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListSwaps(ctxc, &wrpc.ListSwapsRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list swaps: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -17,10 +17,13 @@ var devCommands = []cli.Command{
 		Category: "Dev",
 		Subcommands: []cli.Command{
 			importProofCommand,
+			dumpCommitmentsCommand,
 		},
 	},
 }
 
+const chanPointName = "chan_point"
+
 func getDevClient(ctx *cli.Context) (tapdevrpc.TapDevClient, func()) {
 	conn := getClientConn(ctx, false)
 
@@ -77,3 +80,43 @@ func importProof(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var dumpCommitmentsCommand = cli.Command{
+	Name:      "dumpcommitments",
+	ShortName: "dc",
+	Usage:     "dump the asset commitments of asset channels",
+	Description: `
+	Dumps the current and previous asset commitment allocations of both
+	sides of an asset channel, including the asset balances of in-flight
+	HTLCs and their auxiliary leaves. If no channel point is given, the
+	commitments of all asset channels are dumped. Only commitments that
+	were signed or received since the daemon was started are known.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: chanPointName,
+			Usage: "the channel point of the channel to dump, in " +
+				"the form of txid:output_index",
+		},
+	},
+	Action: dumpCommitments,
+}
+
+func dumpCommitments(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getDevClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.DumpChannelCommitments(
+		ctxc, &tapdevrpc.DumpChannelCommitmentsRequest{
+			ChanPoint: ctx.String(chanPointName),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to dump channel commitments: %w",
+			err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
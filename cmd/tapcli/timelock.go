@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	wrpc "github.com/lightninglabs/taproot-assets/taprpc/assetwalletrpc"
+	"github.com/urfave/cli"
+)
+
+var timeLockCommands = []cli.Command{
+	{
+		Name:      "timelock",
+		ShortName: "tl",
+		Usage: "Lock assets to script keys that can only be swept " +
+			"once a lock time has passed.",
+		Category: "Assets",
+		Subcommands: []cli.Command{
+			newTimeLockScriptKeyCommand,
+			sendTimeLockedCommand,
+			sweepTimeLockCommand,
+		},
+	},
+}
+
+const (
+	timeLockKeyName = "key"
+
+	lockTimeName = "lock_time"
+
+	recipientName = "recipient"
+)
+
+var timeLockFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: timeLockKeyName,
+		Usage: "the hex encoded 33-byte public key that can sweep " +
+			"the assets once the lock times have passed",
+	},
+	cli.Uint64Flag{
+		Name: lockTimeName,
+		Usage: "the block height from which on the assets can be " +
+			"swept",
+	},
+	cli.Uint64Flag{
+		Name: csvDelayName,
+		Usage: "the number of blocks the assets must have been " +
+			"confirmed for before they can be swept",
+	},
+}
+
+// parseTimeLock parses the time lock flags. The key is optional, so a new key
+// of the wallet can be used instead.
+func parseTimeLock(ctx *cli.Context) (*wrpc.TimeLock, error) {
+	key, err := hex.DecodeString(ctx.String(timeLockKeyName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	return &wrpc.TimeLock{
+		Key:      key,
+		LockTime: uint32(ctx.Uint64(lockTimeName)),
+		CsvDelay: uint32(ctx.Uint64(csvDelayName)),
+	}, nil
+}
+
+// parseTimeLockedRecipient parses a recipient of the format
+// key:amount:lock_time:csv_delay[:anchor_internal_key].
+func parseTimeLockedRecipient(
+	recipient string) (*wrpc.TimeLockedRecipient, error) {
+
+	parts := strings.Split(recipient, ":")
+	if len(parts) != 4 && len(parts) != 5 {
+		return nil, fmt.Errorf("invalid recipient %s, expected "+
+			"key:amount:lock_time:csv_delay[:anchor_internal_key]",
+			recipient)
+	}
+
+	key, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	amount, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	lockTime, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lock time: %w", err)
+	}
+
+	csvDelay, err := strconv.ParseUint(parts[3], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV delay: %w", err)
+	}
+
+	var internalKey []byte
+	if len(parts) == 5 {
+		internalKey, err = hex.DecodeString(parts[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchor internal key: "+
+				"%w", err)
+		}
+	}
+
+	return &wrpc.TimeLockedRecipient{
+		TimeLock: &wrpc.TimeLock{
+			Key:      key,
+			LockTime: uint32(lockTime),
+			CsvDelay: uint32(csvDelay),
+		},
+		Amount:            amount,
+		AnchorInternalKey: internalKey,
+	}, nil
+}
+
+var newTimeLockScriptKeyCommand = cli.Command{
+	Name:      "newscriptkey",
+	ShortName: "n",
+	Usage:     "create a time lock script key",
+	Description: `
+	Create a script key that locks assets to a key until the absolute lock
+	time and/or the CSV delay have passed. If no key is given, a new key of
+	the wallet is derived. The script key is declared to the wallet if the
+	wallet controls the key.
+	`,
+	Flags:  timeLockFlags,
+	Action: newTimeLockScriptKey,
+}
+
+func newTimeLockScriptKey(ctx *cli.Context) error {
+	timeLock, err := parseTimeLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.NewTimeLockScriptKey(
+		ctxc, &wrpc.NewTimeLockScriptKeyRequest{
+			TimeLock: timeLock,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create script key: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var sendTimeLockedCommand = cli.Command{
+	Name:  "send",
+	Usage: "send assets to multiple time locked recipients",
+	Description: `
+	Send an asset to one or more recipients in a single transfer, each of
+	which receives its amount locked to its own time lock script key, for
+	example to vest assets. Each recipient is specified as
+	key:amount:lock_time:csv_delay[:anchor_internal_key], where a lock time
+	or CSV delay of zero disables the respective lock.
+
+	The proofs of recipients that aren't part of this wallet must be
+	exported with 'tapcli proofs export' once the transfer has confirmed
+	and handed over out of band.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the ID of the asset to send",
+		},
+		cli.StringSliceFlag{
+			Name: recipientName,
+			Usage: "a recipient of the format key:amount:" +
+				"lock_time:csv_delay[:anchor_internal_key]; " +
+				"must be specified once for each recipient",
+		},
+		cli.Uint64Flag{
+			Name:  assetVersionName,
+			Usage: "the asset version of the recipient outputs",
+		},
+		cli.Uint64Flag{
+			Name: feeRateName,
+			Usage: "if set, the fee rate in sat/vB to use for " +
+				"the anchor transaction",
+		},
+		cli.StringFlag{
+			Name:  noteName,
+			Usage: "an optional note to store with the transfer",
+		},
+	},
+	Action: sendTimeLocked,
+}
+
+func sendTimeLocked(ctx *cli.Context) error {
+	assetID, err := hex.DecodeString(ctx.String(assetIDName))
+	if err != nil {
+		return fmt.Errorf("invalid asset ID: %w", err)
+	}
+
+	recipientStrs := ctx.StringSlice(recipientName)
+	if len(recipientStrs) == 0 {
+		return fmt.Errorf("at least one recipient must be specified")
+	}
+
+	recipients := make([]*wrpc.TimeLockedRecipient, len(recipientStrs))
+	for idx, recipientStr := range recipientStrs {
+		recipients[idx], err = parseTimeLockedRecipient(recipientStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	assetVersion, err := taprpc.MarshalAssetVersion(
+		asset.Version(ctx.Uint64(assetVersionName)),
+	)
+	if err != nil {
+		return err
+	}
+
+	feeRate, err := parseFeeRate(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SendTimeLocked(ctxc, &wrpc.SendTimeLockedRequest{
+		AssetId:      assetID,
+		Recipients:   recipients,
+		AssetVersion: assetVersion,
+		FeeRate:      feeRate,
+		Note:         ctx.String(noteName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to send assets: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var sweepTimeLockCommand = cli.Command{
+	Name:      "sweep",
+	ShortName: "s",
+	Usage:     "sweep assets locked to a time lock script key",
+	Description: `
+	Sweep all assets of the given asset ID that are locked to the time lock
+	script key to a new script key of the wallet. This is only possible
+	once the lock times have passed for all of the assets.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the ID of the assets to sweep",
+		},
+	}, timeLockFlags...),
+	Action: sweepTimeLock,
+}
+
+func sweepTimeLock(ctx *cli.Context) error {
+	timeLock, err := parseTimeLock(ctx)
+	if err != nil {
+		return err
+	}
+
+	assetID, err := hex.DecodeString(ctx.String(assetIDName))
+	if err != nil {
+		return fmt.Errorf("invalid asset ID: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SweepTimeLock(ctxc, &wrpc.SweepTimeLockRequest{
+		TimeLock: timeLock,
+		AssetId:  assetID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to sweep assets: %w", err)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"os"
 
 	"github.com/btcsuite/btcd/wire"
 	tap "github.com/lightninglabs/taproot-assets"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tapcfg"
+	"github.com/lightninglabs/taproot-assets/taprpc"
 	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/lncfg"
@@ -43,11 +47,13 @@ var universeCommands = []cli.Command{
 			universeDeleteRootCommand,
 			universeLeavesCommand,
 			universeKeysCommand,
+			universeSubtreeCommand,
 			universeProofCommand,
 			universeSyncCommand,
 			universeFederationCommand,
 			universeInfoCommand,
 			universeStatsCommand,
+			universeMetaUpdatesCommand,
 		},
 	},
 }
@@ -343,6 +349,92 @@ func universeKeys(ctx *cli.Context) error {
 	return nil
 }
 
+const (
+	pathKeyName = "path_key"
+
+	pathDepthName = "path_depth"
+
+	levelsName = "levels"
+)
+
+var universeSubtreeCommand = cli.Command{
+	Name:      "subtree",
+	ShortName: "st",
+	Usage:     "return the inner nodes of a Universe tree",
+	Description: `
+	Query for the inner nodes of the MS-SMT tree of a given asset universe.
+	The nodes that are found the given number of levels below the node at
+	the given path are returned. By default, the nodes below the root of the
+	tree are returned.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  assetIDName,
+			Usage: "the asset ID of the universe to query for",
+		},
+		cli.StringFlag{
+			Name:  groupKeyName,
+			Usage: "the group key of the universe to query for",
+		},
+		cli.StringFlag{
+			Name: proofTypeName,
+			Usage: "the type of proof to show the nodes for, " +
+				"either 'issuance' or 'transfer'",
+			Value: universe.ProofTypeIssuance.String(),
+		},
+		cli.StringFlag{
+			Name: pathKeyName,
+			Usage: "the hex encoded key of the path to the " +
+				"node to query the subtree of; all bits " +
+				"after the path depth must be zero",
+		},
+		cli.UintFlag{
+			Name:  pathDepthName,
+			Usage: "the depth of the node to query the subtree of",
+		},
+		cli.UintFlag{
+			Name:  levelsName,
+			Usage: "the number of levels below the node to query",
+			Value: 1,
+		},
+	},
+	Action: universeSubtree,
+}
+
+func universeSubtree(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	universeID, err := parseUniverseID(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	pathKey := make([]byte, sha256.Size)
+	if ctx.IsSet(pathKeyName) {
+		pathKey, err = hex.DecodeString(ctx.String(pathKeyName))
+		if err != nil {
+			return fmt.Errorf("invalid path key: %w", err)
+		}
+	}
+
+	resp, err := client.SubtreeNodes(ctxc, &unirpc.SubtreeNodesRequest{
+		Id: universeID,
+		Paths: []*unirpc.SubtreePath{{
+			Key:   pathKey,
+			Depth: uint32(ctx.Uint(pathDepthName)),
+		}},
+		Levels: uint32(ctx.Uint(levelsName)),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var universeLeavesCommand = cli.Command{
 	Name:      "leaves",
 	ShortName: "l",
@@ -576,7 +668,10 @@ func universeProofInsert(ctx *cli.Context) error {
 			Proof: rawProof,
 		},
 	}
-	resp, err := client.InsertProof(ctxc, req)
+
+	// If the universe server requires proof-of-work for proof uploads,
+	// the client solves it before inserting the proof.
+	resp, err := proof.NewUploadPoWClient(client).InsertProof(ctxc, req)
 	if err != nil {
 		return err
 	}
@@ -728,7 +823,9 @@ var universeFederationCommand = cli.Command{
 		universeFederationListCommand,
 		universeFederationAddCommand,
 		universeFederationDelCommand,
+		universeFederationScopeCommand,
 		universeFederationConfigCommand,
+		universeFederationSyncCommand,
 	},
 }
 
@@ -800,6 +897,35 @@ func universeFederationAdd(ctx *cli.Context) error {
 	return nil
 }
 
+var universeFederationSyncCommand = cli.Command{
+	Name:      "sync",
+	ShortName: "s",
+	Usage:     "fully sync with all servers of the Federation",
+	Description: `
+	Start a background job that fully syncs the local Universe with all
+	servers of the Federation. The returned job ID can be used to follow
+	the progress of the sync with 'tapcli jobs watch' or to cancel it with
+	'tapcli jobs cancel'.
+	`,
+	Action: universeFederationSync,
+}
+
+func universeFederationSync(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SyncFederation(
+		ctxc, &unirpc.SyncFederationRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 const universeServerID = "server_id"
 
 var universeServerArgs = []cli.Flag{
@@ -854,6 +980,77 @@ func universeFederationDel(ctx *cli.Context) error {
 	return nil
 }
 
+var universeFederationScopeCommand = cli.Command{
+	Name:      "scope",
+	ShortName: "sc",
+	Usage: "restrict the sync with a server of the Federation to a set " +
+		"of assets",
+	Description: `
+	Restrict the sync with a server of the Federation to the universes of
+	the given assets. Only proofs of these assets will be synced from and
+	pushed to the server. Assets that belong to an asset group must be
+	specified by their group key. Calling the command without any asset
+	IDs or group keys removes any restriction.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: universeHostName,
+			Usage: "the host:port or just host of the remote " +
+				"universe",
+		},
+		cli.StringSliceFlag{
+			Name: assetIDName,
+			Usage: "the ID of an asset without a group to sync " +
+				"with the server; can be specified multiple " +
+				"times",
+		},
+		cli.StringSliceFlag{
+			Name: groupKeyName,
+			Usage: "the key of an asset group to sync with the " +
+				"server; can be specified multiple times",
+		},
+	},
+	Action: universeFederationScope,
+}
+
+func universeFederationScope(ctx *cli.Context) error {
+	if ctx.String(universeHostName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	req := &unirpc.SetFederationServerScopeRequest{
+		Host: ctx.String(universeHostName),
+	}
+	for _, assetIDHex := range ctx.StringSlice(assetIDName) {
+		assetID, err := hex.DecodeString(assetIDHex)
+		if err != nil {
+			return fmt.Errorf("invalid asset ID: %w", err)
+		}
+
+		req.AssetIds = append(req.AssetIds, assetID)
+	}
+	for _, groupKeyHex := range ctx.StringSlice(groupKeyName) {
+		groupKey, err := hex.DecodeString(groupKeyHex)
+		if err != nil {
+			return fmt.Errorf("invalid group key: %w", err)
+		}
+
+		req.GroupKeys = append(req.GroupKeys, groupKey)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SetFederationServerScope(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var universeFederationConfigCommand = cli.Command{
 	Name:      "config",
 	ShortName: "c",
@@ -1309,6 +1506,9 @@ var universeStatsCommand = cli.Command{
 	Subcommands: []cli.Command{
 		universeAssetStatsCommand,
 		universeEventStatsCommand,
+		universeDailyStatsCommand,
+		universeServerSyncStatsCommand,
+		universeTopTransfersCommand,
 	},
 }
 
@@ -1486,3 +1686,331 @@ func universeEventStatsQueryCommand(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+// statsTimeRangeFlags are the flags that specify the time period of a stats
+// query.
+var statsTimeRangeFlags = []cli.Flag{
+	cli.Int64Flag{
+		Name: startTime,
+		Usage: "(optional) the unix timestamp to start " +
+			"querying from; if not specified, will query " +
+			"from last 30 days by default",
+	},
+	cli.Int64Flag{
+		Name: endTime,
+		Usage: "(optional) the unix timestamp to end " +
+			"querying at; if not specified, will query " +
+			"until now by default",
+	},
+}
+
+var universeDailyStatsCommand = cli.Command{
+	Name:      "daily",
+	ShortName: "d",
+	Usage:     "query the daily number of new assets and proofs",
+	Description: `
+	Query for the number of new assets and ingested proofs of the local
+	Universe for a given time period, grouped by day.
+	`,
+	Flags:  statsTimeRangeFlags,
+	Action: universeDailyStatsQueryCommand,
+}
+
+func universeDailyStatsQueryCommand(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	req := &unirpc.QueryDailyStatsRequest{
+		StartTimestamp: ctx.Int64(startTime),
+		EndTimestamp:   ctx.Int64(endTime),
+	}
+	resp, err := client.QueryDailyStats(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var universeServerSyncStatsCommand = cli.Command{
+	Name:      "servers",
+	ShortName: "s",
+	Usage:     "query the daily sync volume per federation server",
+	Description: `
+	Query for the number of syncs and synced leaves with each server of
+	the federation for a given time period, grouped by day.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name: universeHostName,
+			Usage: "(optional) the host of the federation server " +
+				"to query the sync volume for",
+		},
+	}, statsTimeRangeFlags...),
+	Action: universeServerSyncStatsQueryCommand,
+}
+
+func universeServerSyncStatsQueryCommand(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	req := &unirpc.QueryServerSyncStatsRequest{
+		StartTimestamp: ctx.Int64(startTime),
+		EndTimestamp:   ctx.Int64(endTime),
+		ServerHost:     ctx.String(universeHostName),
+	}
+	resp, err := client.QueryServerSyncStats(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var universeTopTransfersCommand = cli.Command{
+	Name:      "top",
+	ShortName: "t",
+	Usage:     "query the universes with the most transfers",
+	Description: `
+	Query for the transfer universes of the local Universe with the most
+	transfer proofs, most transfers first.
+	`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  limitName,
+			Usage: "the max number of universes to return",
+			Value: 10,
+		},
+	},
+	Action: universeTopTransfersQueryCommand,
+}
+
+func universeTopTransfersQueryCommand(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	req := &unirpc.QueryTopTransferUniversesRequest{
+		Limit: int32(ctx.Int(limitName)),
+	}
+	resp, err := client.QueryTopTransferUniverses(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var universeMetaUpdatesCommand = cli.Command{
+	Name:      "metaupdates",
+	ShortName: "mu",
+	Usage:     "manage signed metadata updates of asset groups",
+	Description: `
+	Publish, query and sync signed metadata updates of asset groups. A
+	metadata update replaces the metadata of an asset group and is signed
+	by the issuer of the group, so it can be verified by anyone that knows
+	the group's genesis.
+	`,
+	Subcommands: []cli.Command{
+		universeMetaUpdatesPublishCommand,
+		universeMetaUpdatesQueryCommand,
+		universeMetaUpdatesSyncCommand,
+	},
+}
+
+var universeMetaUpdatesPublishCommand = cli.Command{
+	Name:      "publish",
+	ShortName: "p",
+	Usage:     "publish a new metadata update for an owned asset group",
+	Description: `
+	Sign and publish a new metadata update for an asset group that was
+	issued by this node. The update is assigned the next version number of
+	the group.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  groupKeyName,
+			Usage: "the group key of the asset group to update",
+		},
+		cli.StringFlag{
+			Name:  assetMetaBytesName,
+			Usage: "the new metadata of the asset group",
+		},
+		cli.StringFlag{
+			Name: assetMetaFilePathName,
+			Usage: "a path to a file containing the new metadata " +
+				"of the asset group",
+		},
+		cli.StringFlag{
+			Name: assetMetaTypeName,
+			Usage: "the type of the metadata, either 'opaque' " +
+				"or 'json'",
+			Value: "opaque",
+		},
+	},
+	Action: universeMetaUpdatesPublish,
+}
+
+func universeMetaUpdatesPublish(ctx *cli.Context) error {
+	groupKeyStr := ctx.String(groupKeyName)
+	if groupKeyStr == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	groupKey, err := hex.DecodeString(groupKeyStr)
+	if err != nil {
+		return fmt.Errorf("invalid group key: %w", err)
+	}
+
+	metaType, err := parseMetaType(ctx.String(assetMetaTypeName))
+	if err != nil {
+		return fmt.Errorf("unable to parse meta type: %w", err)
+	}
+
+	var metaData []byte
+	switch {
+	case ctx.String(assetMetaBytesName) != "" &&
+		ctx.String(assetMetaFilePathName) != "":
+
+		return fmt.Errorf("meta bytes and meta file path cannot both " +
+			"be set")
+
+	case ctx.String(assetMetaBytesName) != "":
+		metaData = []byte(ctx.String(assetMetaBytesName))
+
+	case ctx.String(assetMetaFilePathName) != "":
+		metaPath := tapcfg.CleanAndExpandPath(
+			ctx.String(assetMetaFilePathName),
+		)
+		metaData, err = os.ReadFile(metaPath)
+		if err != nil {
+			return fmt.Errorf("unable to read meta file: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("either meta bytes or meta file path must " +
+			"be set")
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.PublishMetaUpdate(
+		ctxc, &unirpc.PublishMetaUpdateRequest{
+			GroupKey: groupKey,
+			Meta: &taprpc.AssetMeta{
+				Data: metaData,
+				Type: metaType,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+const latestOnlyName = "latest_only"
+
+var universeMetaUpdatesQueryCommand = cli.Command{
+	Name:      "query",
+	ShortName: "q",
+	Usage:     "query the known metadata updates of an asset group",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  groupKeyName,
+			Usage: "the group key of the asset group to query",
+		},
+		cli.BoolFlag{
+			Name:  latestOnlyName,
+			Usage: "only return the latest metadata update",
+		},
+	},
+	Action: universeMetaUpdatesQuery,
+}
+
+func universeMetaUpdatesQuery(ctx *cli.Context) error {
+	groupKeyStr := ctx.String(groupKeyName)
+	if groupKeyStr == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.QueryMetaUpdates(
+		ctxc, &unirpc.QueryMetaUpdatesRequest{
+			Group: &unirpc.QueryMetaUpdatesRequest_GroupKeyStr{
+				GroupKeyStr: groupKeyStr,
+			},
+			LatestOnly: ctx.Bool(latestOnlyName),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var universeMetaUpdatesSyncCommand = cli.Command{
+	Name:      "sync",
+	ShortName: "s",
+	Usage:     "fetch the metadata updates of an asset group from a remote",
+	Description: `
+	Fetch the metadata updates of an asset group from a remote universe.
+	Each update is verified against the group key before it is stored
+	locally.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  groupKeyName,
+			Usage: "the group key of the asset group to sync",
+		},
+		cli.StringFlag{
+			Name: universeHostName,
+			Usage: "the host:port or just host of the remote " +
+				"universe",
+		},
+	},
+	Action: universeMetaUpdatesSync,
+}
+
+func universeMetaUpdatesSync(ctx *cli.Context) error {
+	groupKeyStr := ctx.String(groupKeyName)
+	if groupKeyStr == "" || ctx.String(universeHostName) == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	groupKey, err := hex.DecodeString(groupKeyStr)
+	if err != nil {
+		return fmt.Errorf("invalid group key: %w", err)
+	}
+
+	ctxc := getContext()
+	client, cleanUp := getUniverseClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.SyncMetaUpdates(
+		ctxc, &unirpc.SyncMetaUpdatesRequest{
+			GroupKey:     groupKey,
+			UniverseHost: ctx.String(universeHostName),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/btcsuite/btclog"
+	"github.com/lightninglabs/taproot-assets/tapdb"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+)
+
+const (
+	sqliteFileName       = "sqlite.dbfile"
+	postgresHostName     = "postgres.host"
+	postgresPortName     = "postgres.port"
+	postgresUserName     = "postgres.user"
+	postgresPasswordName = "postgres.password"
+	postgresDBName       = "postgres.dbname"
+	postgresSSLName      = "postgres.requiressl"
+
+	// defaultPostgresPort is the default port of a Postgres server.
+	defaultPostgresPort = 5432
+)
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "[tapmigrate] %v\n", err)
+	os.Exit(1)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "tapmigrate"
+	app.Usage = "Migrate the Taproot Assets daemon database between " +
+		"database backends"
+	app.Commands = []cli.Command{
+		sqliteToPostgresCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fatal(err)
+	}
+}
+
+var sqliteToPostgresCommand = cli.Command{
+	Name:  "sqlite-to-postgres",
+	Usage: "copy all data of a SQLite database to a Postgres database",
+	Description: `
+	Copy all tables of a tapd SQLite database to an empty Postgres database.
+	The SQLite database must be at the latest schema version, which is the
+	case after tapd was started with it once. The Postgres database is
+	migrated to the latest schema version before the data is copied.
+
+	The data is copied within a single transaction that is only committed
+	once the row counts of all tables match and the root of every MS-SMT
+	was recomputed from the copied nodes. The SQLite database is never
+	modified.
+
+	tapd must be stopped while the migration is in progress. Once it
+	completed, tapd can be started with --databasebackend=postgres and the
+	same Postgres settings.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  sqliteFileName,
+			Usage: "the full path to the SQLite database file",
+		},
+		cli.StringFlag{
+			Name:  postgresHostName,
+			Usage: "the Postgres server hostname",
+			Value: "localhost",
+		},
+		cli.IntFlag{
+			Name:  postgresPortName,
+			Usage: "the Postgres server port",
+			Value: defaultPostgresPort,
+		},
+		cli.StringFlag{
+			Name:  postgresUserName,
+			Usage: "the Postgres database user",
+		},
+		cli.StringFlag{
+			Name:   postgresPasswordName,
+			Usage:  "the Postgres database user's password",
+			EnvVar: "TAPMIGRATE_POSTGRES_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:  postgresDBName,
+			Usage: "the Postgres database name",
+		},
+		cli.BoolFlag{
+			Name: postgresSSLName,
+			Usage: "require using SSL to connect to the " +
+				"Postgres server",
+		},
+	},
+	Action: sqliteToPostgres,
+}
+
+func sqliteToPostgres(ctx *cli.Context) error {
+	if !ctx.IsSet(sqliteFileName) || !ctx.IsSet(postgresDBName) {
+		return cli.ShowCommandHelp(ctx, "sqlite-to-postgres")
+	}
+
+	logger := btclog.NewBackend(os.Stdout).Logger(tapdb.Subsystem)
+	logger.SetLevel(btclog.LevelInfo)
+	tapdb.UseLogger(logger)
+
+	dbFile := lncfg.CleanAndExpandPath(ctx.String(sqliteFileName))
+	if _, err := os.Stat(dbFile); err != nil {
+		return fmt.Errorf("unable to open SQLite database: %w", err)
+	}
+
+	// The source database is opened without applying any migrations, so
+	// it is never modified.
+	src, err := tapdb.NewSqliteStore(&tapdb.SqliteConfig{
+		SkipMigrations:   true,
+		DatabaseFileName: dbFile,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to open SQLite database: %w", err)
+	}
+	defer src.DB.Close()
+
+	dst, err := tapdb.NewPostgresStore(&tapdb.PostgresConfig{
+		Host:       ctx.String(postgresHostName),
+		Port:       ctx.Int(postgresPortName),
+		User:       ctx.String(postgresUserName),
+		Password:   ctx.String(postgresPasswordName),
+		DBName:     ctx.String(postgresDBName),
+		RequireSSL: ctx.Bool(postgresSSLName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to open Postgres database: %w", err)
+	}
+	defer dst.DB.Close()
+
+	stats, err := tapdb.MigrateSqliteToPostgres(
+		context.Background(), src, dst,
+	)
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	var numRows int64
+	for _, table := range stats.Tables {
+		fmt.Printf("%-45s %d rows\n", table, stats.RowsCopied[table])
+		numRows += stats.RowsCopied[table]
+	}
+	fmt.Printf("Copied %d rows in %d tables, verified %d MS-SMT roots\n",
+		numRows, len(stats.Tables), stats.MssmtRootsVerified)
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/lightninglabs/taproot-assets/conformance"
+	"github.com/urfave/cli"
+)
+
+const (
+	// defaultFilePerms is the default permission set we use when creating
+	// files. It is equal to rw-r--r--.
+	defaultFilePerms = 0644
+)
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "[tapvectors] %v\n", err)
+	os.Exit(1)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "tapvectors"
+	app.Usage = "Generate and verify Taproot Assets protocol conformance " +
+		"test vectors"
+	app.Commands = []cli.Command{
+		generateCommand,
+		verifyCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fatal(err)
+	}
+}
+
+var generateCommand = cli.Command{
+	Name:  "generate",
+	Usage: "generate the conformance test vectors",
+	Description: `
+	Generate the latest version of the conformance test vectors. Each vector
+	pairs the encoding of an address, proof, HTLC record, RFQ message or
+	vPSBT with its expected decoding. The vectors are deterministic, so they
+	can be regenerated and compared across releases.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "out",
+			Usage: "the file to write the vectors to; if " +
+				"not set, the vectors are written to stdout",
+		},
+	},
+	Action: generate,
+}
+
+func generate(ctx *cli.Context) error {
+	vectors, err := conformance.Generate()
+	if err != nil {
+		return fmt.Errorf("unable to generate vectors: %w", err)
+	}
+
+	vectorsBytes, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet("out") {
+		fmt.Println(string(vectorsBytes))
+		return nil
+	}
+
+	return os.WriteFile(ctx.String("out"), vectorsBytes, defaultFilePerms)
+}
+
+var verifyCommand = cli.Command{
+	Name:  "verify",
+	Usage: "verify an implementation against the conformance test vectors",
+	Description: `
+	Verify the results of an external implementation against the given
+	conformance test vectors. The results must use the same format as the
+	vectors and contain a vector of the same kind and name for each expected
+	vector, with the encoding and decoding the implementation produced.
+
+	If no results are given, the vectors themselves are checked against
+	this implementation instead. This can be used to validate vectors that
+	were generated by another implementation.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "vectors",
+			Usage: "the file containing the expected vectors",
+		},
+		cli.StringFlag{
+			Name:  "results",
+			Usage: "the file containing the results to verify",
+		},
+	},
+	Action: verify,
+}
+
+// readVectors reads and decodes the vectors in the given file.
+func readVectors(fileName string) (*conformance.Vectors, error) {
+	vectorsBytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", fileName, err)
+	}
+
+	return conformance.DecodeVectors(vectorsBytes)
+}
+
+func verify(ctx *cli.Context) error {
+	if !ctx.IsSet("vectors") {
+		return fmt.Errorf("vectors file must be specified")
+	}
+
+	vectors, err := readVectors(ctx.String("vectors"))
+	if err != nil {
+		return err
+	}
+
+	var failures []*conformance.Failure
+	switch {
+	case ctx.IsSet("results"):
+		results, err := readVectors(ctx.String("results"))
+		if err != nil {
+			return err
+		}
+
+		failures = conformance.Verify(vectors, results)
+
+	default:
+		failures = conformance.Check(vectors)
+	}
+
+	for _, failure := range failures {
+		fmt.Println(failure)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d vectors failed", len(failures),
+			len(vectors.Vectors))
+	}
+
+	fmt.Printf("All %d vectors passed\n", len(vectors.Vectors))
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package commitment
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// JSONProof is the JSON representation of a Taproot Asset commitment proof.
+type JSONProof struct {
+	AssetProof        *JSONAssetProof        `json:"asset_proof"`
+	TaprootAssetProof *JSONTaprootAssetProof `json:"taproot_asset_proof"`
+	UnknownOddTypes   tlv.TypeMap            `json:"unknown_odd_types"`
+}
+
+// JSONAssetProof is the JSON representation of an asset commitment proof.
+type JSONAssetProof struct {
+	Proof           string      `json:"proof"`
+	Version         uint8       `json:"version"`
+	TapKey          string      `json:"tap_key"`
+	UnknownOddTypes tlv.TypeMap `json:"unknown_odd_types"`
+}
+
+// JSONTaprootAssetProof is the JSON representation of a Taproot Asset
+// commitment proof.
+type JSONTaprootAssetProof struct {
+	Proof           string      `json:"proof"`
+	Version         uint8       `json:"version"`
+	UnknownOddTypes tlv.TypeMap `json:"unknown_odd_types"`
+}
+
+// NewJSONProof creates the JSON representation of the given commitment proof.
+func NewJSONProof(p *Proof) (*JSONProof, error) {
+	tapProofHex, err := mssmt.EncodeHexProof(&p.TaprootAssetProof.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode taproot asset "+
+			"proof: %w", err)
+	}
+
+	jp := &JSONProof{
+		TaprootAssetProof: &JSONTaprootAssetProof{
+			Proof:           tapProofHex,
+			Version:         uint8(p.TaprootAssetProof.Version),
+			UnknownOddTypes: p.TaprootAssetProof.UnknownOddTypes,
+		},
+		UnknownOddTypes: p.UnknownOddTypes,
+	}
+
+	if p.AssetProof != nil {
+		assetProofHex, err := mssmt.EncodeHexProof(&p.AssetProof.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode asset proof: "+
+				"%w", err)
+		}
+
+		tapKey := p.AssetProof.TapKey
+		jp.AssetProof = &JSONAssetProof{
+			Proof:           assetProofHex,
+			Version:         uint8(p.AssetProof.Version),
+			TapKey:          hex.EncodeToString(tapKey[:]),
+			UnknownOddTypes: p.AssetProof.UnknownOddTypes,
+		}
+	}
+
+	return jp, nil
+}
+
+// ToProof converts the JSON representation back into a commitment proof.
+func (jp *JSONProof) ToProof() (*Proof, error) {
+	if jp.TaprootAssetProof == nil {
+		return nil, fmt.Errorf("%w: missing taproot asset proof",
+			asset.ErrInvalidJSON)
+	}
+
+	tapProof, err := mssmt.DecodeHexProof(jp.TaprootAssetProof.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid taproot asset proof: %w", err)
+	}
+
+	p := &Proof{
+		TaprootAssetProof: TaprootAssetProof{
+			Proof: *tapProof,
+			Version: TapCommitmentVersion(
+				jp.TaprootAssetProof.Version,
+			),
+			UnknownOddTypes: jp.TaprootAssetProof.UnknownOddTypes,
+		},
+		UnknownOddTypes: jp.UnknownOddTypes,
+	}
+
+	if jp.AssetProof != nil {
+		assetProof, err := mssmt.DecodeHexProof(jp.AssetProof.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset proof: %w", err)
+		}
+
+		tapKey, err := hex.DecodeString(jp.AssetProof.TapKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset proof tap "+
+				"key: %w", err)
+		}
+		if len(tapKey) != 32 {
+			return nil, fmt.Errorf("%w: invalid asset proof tap "+
+				"key length %d", asset.ErrInvalidJSON,
+				len(tapKey))
+		}
+
+		p.AssetProof = &AssetProof{
+			Proof:           *assetProof,
+			Version:         asset.Version(jp.AssetProof.Version),
+			UnknownOddTypes: jp.AssetProof.UnknownOddTypes,
+		}
+		copy(p.AssetProof.TapKey[:], tapKey)
+	}
+
+	return p, nil
+}
+
+// EncodeHexTapscriptPreimage returns the hex encoded serialization of the
+// given tapscript preimage, or an empty string if the preimage is empty.
+func EncodeHexTapscriptPreimage(t *TapscriptPreimage) (string, error) {
+	if t.IsEmpty() {
+		return "", nil
+	}
+
+	preimageBytes, _, err := MaybeEncodeTapscriptPreimage(t)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(preimageBytes), nil
+}
+
+// DecodeHexTapscriptPreimage parses the hex encoded serialization of a
+// tapscript preimage. An empty string results in a nil preimage.
+func DecodeHexTapscriptPreimage(preimageHex string) (*TapscriptPreimage,
+	error) {
+
+	preimageBytes, err := hex.DecodeString(preimageHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tapscript preimage: %w", err)
+	}
+
+	preimage, _, err := MaybeDecodeTapscriptPreimage(preimageBytes)
+	return preimage, err
+}
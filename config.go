@@ -8,13 +8,25 @@ import (
 
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/explorer"
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/lightninglabs/taproot-assets/lnurl"
+	"github.com/lightninglabs/taproot-assets/metaschema"
 	"github.com/lightninglabs/taproot-assets/monitoring"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/retention"
 	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
 	"github.com/lightninglabs/taproot-assets/tapchannel"
 	"github.com/lightninglabs/taproot-assets/tapdb"
 	"github.com/lightninglabs/taproot-assets/tapfreighter"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsession"
+	"github.com/lightninglabs/taproot-assets/tapswap"
+	"github.com/lightninglabs/taproot-assets/tracing"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd"
 	"github.com/lightningnetwork/lnd/build"
@@ -66,6 +78,11 @@ type RPCConfig struct {
 type DatabaseConfig struct {
 	RootKeyStore *tapdb.RootKeyStore
 
+	// FieldCipher encrypts the sensitive fields of the database. If nil,
+	// field encryption is disabled. If set and locked, the database needs
+	// to be unlocked with the UnlockDatabase RPC before the daemon starts.
+	FieldCipher *tapdb.FieldCipher
+
 	MintingStore tapgarden.MintingStore
 
 	AssetStore *tapdb.AssetStore
@@ -75,6 +92,37 @@ type DatabaseConfig struct {
 	Multiverse *tapdb.MultiverseStore
 
 	FederationDB *tapdb.UniverseFederationDB
+
+	// MetaUpdates stores the signed metadata updates of asset groups.
+	MetaUpdates *tapdb.AssetMetaUpdates
+
+	// Accounts stores the named asset accounts.
+	Accounts *tapdb.AssetAccounts
+
+	// ChannelBackups stores the restored asset channel backups.
+	ChannelBackups *tapdb.ChannelBackups
+
+	// Freezes stores the issuer policy script keys the issuer refuses to
+	// co-sign transfers for.
+	Freezes *tapdb.ScriptKeyFreezes
+
+	// MetaSchemas is the registry of the JSON schemas asset metadata can be
+	// validated against at mint time.
+	MetaSchemas *metaschema.Registry
+
+	// MetaBlobs is the content-addressed store of the meta blobs that are
+	// referenced by asset metas of type MetaBlobRef.
+	MetaBlobs proof.MetaBlobStore
+
+	// DBEventBus is the optional event bus that distributes notifications
+	// about database changes. This is only set when running on Postgres
+	// with the event bus enabled.
+	DBEventBus *tapdb.PostgresEventBus
+
+	// DBProofNotifier is the optional proof notifier that announces the
+	// proofs stored by other tapd instances using the same database. This
+	// is only set if the DBEventBus is set.
+	DBProofNotifier *tapdb.DBEventProofNotifier
 }
 
 // UniversePublicAccessStatus is a type that indicates the status of public
@@ -152,10 +200,25 @@ type Config struct {
 	// channel functionality.
 	EnableChannelFeatures bool
 
+	// DecimalDisplayAmounts indicates that asset amounts should be
+	// rendered in decimal display units in RPC responses, and may be given
+	// in decimal display units in RPC requests.
+	DecimalDisplayAmounts bool
+
+	// ShutdownDrainTimeout is the maximum time we wait on shutdown for
+	// in-flight operations to reach a safe checkpoint after new RPC calls
+	// are no longer accepted. If this is zero, we don't wait at all.
+	ShutdownDrainTimeout time.Duration
+
 	ChainParams address.ChainParams
 
 	Lnd *lndclient.LndServices
 
+	// SignerLnd is the connection to the lnd node that derives the script
+	// and internal keys and signs for them. This is the same node as Lnd,
+	// unless the daemon runs in remote signer mode.
+	SignerLnd *lndclient.LndServices
+
 	SignalInterceptor signal.Interceptor
 
 	ReOrgWatcher *tapgarden.ReOrgWatcher
@@ -175,10 +238,18 @@ type Config struct {
 
 	DefaultProofCourierAddr *url.URL
 
+	// ProofCourierDispatcher is the dispatcher that creates the proof
+	// couriers. It keeps track of the statistics of all proof transfers.
+	ProofCourierDispatcher *proof.URLDispatch
+
 	ProofArchive proof.Archiver
 
 	AssetWallet tapfreighter.Wallet
 
+	// VirtualTxSigner is used to sign virtual transactions with keys of the
+	// backing lnd wallet.
+	VirtualTxSigner tapscript.Signer
+
 	CoinSelect *tapfreighter.CoinSelect
 
 	ChainPorter tapfreighter.Porter
@@ -189,12 +260,84 @@ type Config struct {
 
 	UniverseFederation *universe.FederationEnvoy
 
+	// UniverseRootSigner signs the universe roots that are published by
+	// the local universe server with its operator key.
+	UniverseRootSigner universe.RootSigner
+
 	// UniFedSyncAllAssets is a flag that indicates whether the
 	// universe federation syncer should default to syncing all assets.
 	UniFedSyncAllAssets bool
 
+	// UniverseMirror indicates that the local universe is a read-only
+	// mirror of a remote universe server, so calls that would write to it
+	// are rejected.
+	UniverseMirror bool
+
 	RfqManager *rfq.Manager
 
+	// PriceOracle is the optional price oracle used to value assets. It is
+	// nil if no price oracle is configured.
+	PriceOracle rfq.PriceOracle
+
+	// AlertManager delivers alerts about security-relevant events to the
+	// configured notifiers.
+	AlertManager *alert.Manager
+
+	// TraceProvider exports the traces of the send and receive pipelines
+	// to an OpenTelemetry collector. It is nil if no traces are exported.
+	TraceProvider *tracing.Provider
+
+	// JobManager runs long-running operations as persisted jobs with
+	// progress reporting and cancellation.
+	JobManager *jobs.Manager
+
+	// SessionManager keeps track of the in-memory multi-party virtual
+	// transaction signing sessions.
+	SessionManager *tapsession.Manager
+
+	// SwapManager keeps track of the in-memory atomic asset-for-BTC swaps
+	// this daemon takes part in.
+	SwapManager *tapswap.Manager
+
+	// Pruner prunes old data from the database according to the
+	// configured retention policy.
+	Pruner *retention.Pruner
+
+	// Lnurl is the optional configuration of the LNURL-pay server. The
+	// server is only started if this is set.
+	Lnurl *lnurl.Config
+
+	// Explorer is the optional configuration of the read-only explorer
+	// API. The server is only started if this is set.
+	Explorer *explorer.Config
+
+	// AnchorSpendWatcher is the optional watcher that raises an alert if
+	// an anchor output holding our assets is spent unexpectedly. This is
+	// only set if alert notifiers are configured.
+	AnchorSpendWatcher *tapgarden.AnchorSpendWatcher
+
+	// ConfDepthTracker is used to report the current confirmation depth of
+	// anchor transactions.
+	ConfDepthTracker *tapgarden.ConfDepthTracker
+
+	// RPCJournal is the optional journal that records all mutating RPC
+	// calls. This is only set if the journal is enabled.
+	RPCJournal *rpcjournal.Journal
+
+	// RPCJournalStore is the store the entries of the RPC journal are
+	// exported from.
+	RPCJournalStore rpcjournal.Store
+
+	// ReplicationLeader is the optional replication leader that records
+	// the state changes of this node and streams them to followers. This
+	// is only set if the node is configured as a replication leader.
+	ReplicationLeader *replication.Leader
+
+	// ReplicationFollower is the optional replication follower that
+	// replicates the state changes of a leader to this node. This is only
+	// set if the node is configured as a replication follower.
+	ReplicationFollower *replication.Follower
+
 	UniverseStats universe.Telemetry
 
 	AuxLeafSigner *tapchannel.AuxLeafSigner
@@ -209,6 +352,10 @@ type Config struct {
 
 	AuxSweeper *tapchannel.AuxSweeper
 
+	// AuxCommitmentRecorder keeps a record of the most recent asset
+	// commitments of each channel for inspection.
+	AuxCommitmentRecorder *tapchannel.CommitmentRecorder
+
 	// UniversePublicAccess is a field that indicates the status of public
 	// access (i.e. read/write) to the universe server.
 	//
@@ -225,6 +372,11 @@ type Config struct {
 	// limiting.
 	UniverseQueriesBurst int
 
+	// UniverseUploadLimits is the configuration of the per-IP and per-key
+	// rate limiting and the proof-of-work requirement for proof uploads to
+	// the universe server.
+	UniverseUploadLimits universe.UploadLimiterConfig
+
 	Prometheus monitoring.PrometheusConfig
 
 	// LogWriter is the root logger that all of the daemon's subloggers are
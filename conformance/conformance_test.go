@@ -0,0 +1,179 @@
+package conformance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// generatedVectorsName is the name of the file the generated vectors
+	// are written to.
+	generatedVectorsName = "conformance_vectors_v1_generated.json"
+)
+
+// TestGenerate tests that the generated vectors are deterministic, cover all
+// vector kinds and pass the checks of this implementation.
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	vectors, err := Generate()
+	require.NoError(t, err)
+	require.Equal(t, LatestVectorsVersion, vectors.Version)
+
+	kinds := make(map[Kind]int)
+	for _, v := range vectors.Vectors {
+		kinds[v.Kind]++
+	}
+	for _, kind := range []Kind{
+		KindAddress, KindProof, KindHtlc, KindRfqMessage, KindVPacket,
+	} {
+		require.NotZero(t, kinds[kind], "no vectors of kind %s", kind)
+	}
+
+	// Generating the vectors again must result in the same vectors.
+	vectorsBytes, err := json.Marshal(vectors)
+	require.NoError(t, err)
+
+	vectors2, err := Generate()
+	require.NoError(t, err)
+	vectors2Bytes, err := json.Marshal(vectors2)
+	require.NoError(t, err)
+	require.JSONEq(t, string(vectorsBytes), string(vectors2Bytes))
+
+	// The vectors survive a round trip through their JSON encoding.
+	decodedVectors, err := DecodeVectors(vectorsBytes)
+	require.NoError(t, err)
+	require.Empty(t, Check(decodedVectors))
+	require.Empty(t, Verify(vectors, decodedVectors))
+
+	// Write test vectors to file. This is a no-op if the "gen_test_vectors"
+	// build tag is not set.
+	test.WriteTestVectors(t, generatedVectorsName, vectors)
+}
+
+// TestGeneratedVectors tests that the published vectors still match the
+// vectors this implementation generates, which makes sure encodings don't
+// change by accident.
+func TestGeneratedVectors(t *testing.T) {
+	t.Parallel()
+
+	var published Vectors
+	test.ParseTestVectors(t, generatedVectorsName, &published)
+	require.Empty(t, Check(&published))
+
+	vectors, err := Generate()
+	require.NoError(t, err)
+	require.Empty(t, Verify(&published, vectors))
+	require.Len(t, vectors.Vectors, len(published.Vectors))
+}
+
+// TestVerify tests that deviating results are reported as failures.
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	expected, err := Generate()
+	require.NoError(t, err)
+
+	// newResults returns a deep copy of the expected vectors that can be
+	// modified to simulate a deviating implementation.
+	newResults := func() *Vectors {
+		vectorsBytes, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		results, err := DecodeVectors(vectorsBytes)
+		require.NoError(t, err)
+
+		return results
+	}
+
+	// findVector returns the index of the first vector of the given kind.
+	findVector := func(vectors *Vectors, kind Kind) int {
+		for idx, v := range vectors.Vectors {
+			if v.Kind == kind {
+				return idx
+			}
+		}
+
+		t.Fatalf("no vector of kind %s", kind)
+		return 0
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(results *Vectors)
+		reason string
+	}{{
+		name: "missing vector",
+		modify: func(results *Vectors) {
+			idx := findVector(results, KindHtlc)
+			results.Vectors = append(
+				results.Vectors[:idx],
+				results.Vectors[idx+1:]...,
+			)
+		},
+		reason: "missing from results",
+	}, {
+		name: "wrong encoding",
+		modify: func(results *Vectors) {
+			idx := findVector(results, KindProof)
+			results.Vectors[idx].Encoded = "00"
+		},
+		reason: "encoding mismatch, result can't be decoded",
+	}, {
+		name: "encoding of different object",
+		modify: func(results *Vectors) {
+			htlcs := findVector(results, KindHtlc)
+			results.Vectors[htlcs].Encoded =
+				results.Vectors[htlcs+1].Encoded
+		},
+		reason: "encoding mismatch, result decodes to",
+	}, {
+		name: "wrong decoding",
+		modify: func(results *Vectors) {
+			idx := findVector(results, KindAddress)
+			results.Vectors[idx].Decoded = json.RawMessage(`{}`)
+		},
+		reason: "decoding mismatch",
+	}, {
+		name: "wrong version",
+		modify: func(results *Vectors) {
+			results.Version++
+		},
+		reason: "doesn't match vectors version",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := newResults()
+			tc.modify(results)
+
+			failures := Verify(expected, results)
+			require.Len(t, failures, 1)
+			require.Contains(t, failures[0].Reason, tc.reason)
+		})
+	}
+
+	// A decoding that only differs in formatting and key order is
+	// accepted.
+	results := newResults()
+	idx := findVector(results, KindRfqMessage)
+	var decoded map[string]any
+	err = json.Unmarshal(results.Vectors[idx].Decoded, &decoded)
+	require.NoError(t, err)
+	reformatted, err := json.MarshalIndent(decoded, "", "    ")
+	require.NoError(t, err)
+	results.Vectors[idx].Decoded = reformatted
+	require.Empty(t, Verify(expected, results))
+
+	// Check also detects vectors that don't decode to their expected
+	// decoding.
+	results = newResults()
+	idx = findVector(results, KindVPacket)
+	results.Vectors[idx].Decoded = results.Vectors[idx+1].Decoded
+	failures := Check(results)
+	require.Len(t, failures, 1)
+	require.Equal(t, KindVPacket, failures[0].Kind)
+}
@@ -0,0 +1,530 @@
+package conformance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// keyDomain is the domain separation prefix of the deterministic keys
+	// and identifiers used in the generated vectors.
+	keyDomain = "taproot-assets/conformance/"
+
+	// proofCourierAddr is the proof courier address used in the generated
+	// addresses and virtual packets.
+	proofCourierAddr = "universerpc://localhost:10029"
+)
+
+var (
+	// quoteExpiry is the fixed expiry of all RFQ quotes in the generated
+	// vectors.
+	quoteExpiry = time.Unix(1_900_000_000, 0).UTC()
+)
+
+// Generate creates the conformance test vectors of the latest version. The
+// vectors are fully deterministic, so generating them twice results in the
+// same vectors. The expected decodings are created by decoding the generated
+// encodings with this implementation.
+func Generate() (*Vectors, error) {
+	var vectors []*Vector
+	generators := []func() ([]*Vector, error){
+		addressVectors, proofVectors, htlcVectors, rfqVectors,
+		vPacketVectors,
+	}
+	for _, generator := range generators {
+		newVectors, err := generator()
+		if err != nil {
+			return nil, err
+		}
+
+		vectors = append(vectors, newVectors...)
+	}
+
+	d := newDecoder(vectors)
+	for _, v := range vectors {
+		decoded, err := d.decode(v.Kind, v.Encoded)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode vector %s: %w",
+				v.key(), err)
+		}
+
+		v.Decoded = decoded
+	}
+
+	return &Vectors{
+		Version: LatestVectorsVersion,
+		Vectors: vectors,
+	}, nil
+}
+
+// privKey derives a deterministic private key from the given label.
+func privKey(label string) *btcec.PrivateKey {
+	keyBytes := sha256.Sum256([]byte(keyDomain + label))
+	priv, _ := btcec.PrivKeyFromBytes(keyBytes[:])
+
+	return priv
+}
+
+// pubKey derives a deterministic public key from the given label.
+func pubKey(label string) *btcec.PublicKey {
+	return privKey(label).PubKey()
+}
+
+// hash derives a deterministic hash from the given label.
+func hash(label string) [32]byte {
+	return sha256.Sum256([]byte(keyDomain + label))
+}
+
+// genesis creates a deterministic asset genesis with the given tag and type.
+func genesis(tag string, assetType asset.Type) asset.Genesis {
+	return asset.Genesis{
+		FirstPrevOut: wire.OutPoint{
+			Hash:  hash("genesis-outpoint/" + tag),
+			Index: 1,
+		},
+		Tag:         tag,
+		OutputIndex: 0,
+		Type:        assetType,
+	}
+}
+
+// courierAddr returns the parsed proof courier address.
+func courierAddr() (*url.URL, error) {
+	return url.Parse(proofCourierAddr)
+}
+
+// addressVectors creates the Taproot Asset address vectors.
+func addressVectors() ([]*Vector, error) {
+	courier, err := courierAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := txscript.NewBaseTapLeaf([]byte{txscript.OP_TRUE})
+	sibling, err := commitment.NewPreimageFromLeaf(leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases := []struct {
+		name     string
+		comment  string
+		version  address.Version
+		genesis  asset.Genesis
+		groupKey *btcec.PublicKey
+		amount   uint64
+		sibling  *commitment.TapscriptPreimage
+		net      *address.ChainParams
+	}{{
+		name:    "v0_mainnet_normal",
+		comment: "version 0 address for a normal asset on mainnet",
+		version: address.V0,
+		genesis: genesis("normal", asset.Normal),
+		amount:  1000,
+		net:     &address.MainNetTap,
+	}, {
+		name:    "v1_testnet_collectible",
+		comment: "version 1 address for a collectible on testnet",
+		version: address.V1,
+		genesis: genesis("collectible", asset.Collectible),
+		amount:  1,
+		net:     &address.TestNet3Tap,
+	}, {
+		name:     "v1_regtest_group_key",
+		comment:  "version 1 address for a grouped asset on regtest",
+		version:  address.V1,
+		genesis:  genesis("grouped", asset.Normal),
+		groupKey: pubKey("group-key"),
+		amount:   21_000_000,
+		net:      &address.RegressionNetTap,
+	}, {
+		name:    "v1_signet_tapscript_sibling",
+		comment: "version 1 address with a tapscript sibling on signet",
+		version: address.V1,
+		genesis: genesis("normal", asset.Normal),
+		amount:  42,
+		sibling: sibling,
+		net:     &address.SigNetTap,
+	}}
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		// The group witness isn't part of the encoded address, but it
+		// must be present when creating an address for a group key.
+		var groupWitness wire.TxWitness
+		if tc.groupKey != nil {
+			witness := hash("group-witness/" + tc.name)
+			groupWitness = wire.TxWitness{witness[:]}
+		}
+
+		addr, err := address.New(
+			tc.version, tc.genesis, tc.groupKey, groupWitness,
+			*pubKey("script-key/" + tc.name),
+			*pubKey("internal-key/" + tc.name), tc.amount,
+			tc.sibling, tc.net, *courier,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create address "+
+				"%s: %w", tc.name, err)
+		}
+
+		encoded, err := addr.EncodeAddress()
+		if err != nil {
+			return nil, err
+		}
+
+		vectors = append(vectors, &Vector{
+			Kind:    KindAddress,
+			Name:    tc.name,
+			Comment: tc.comment,
+			Encoded: encoded,
+		})
+	}
+
+	return vectors, nil
+}
+
+// genesisProof creates a deterministic genesis proof for the given asset.
+func genesisProof(name string, gen asset.Genesis, amount uint64,
+	meta *proof.MetaReveal) (*proof.Proof, error) {
+
+	if meta != nil {
+		gen.MetaHash = meta.MetaHash()
+	}
+
+	scriptKey := pubKey("script-key/" + name)
+	mintCommitment, assets, err := commitment.Mint(
+		nil, gen, nil, &commitment.AssetDetails{
+			Type: gen.Type,
+			ScriptKey: keychain.KeyDescriptor{
+				PubKey: scriptKey,
+			},
+			Amount: &amount,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the tweaked script key is serialized, so we drop the raw key
+	// and make sure the key is x-only.
+	proofAsset := assets[0]
+	proofAsset.ScriptKey.PubKey, err = schnorr.ParsePubKey(
+		schnorr.SerializePubKey(proofAsset.ScriptKey.PubKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+	proofAsset.ScriptKey.TweakedScriptKey = nil
+
+	_, commitmentProof, err := mintCommitment.Proof(
+		proofAsset.TapCommitmentKey(), proofAsset.AssetCommitmentKey(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The anchor transaction spends the genesis outpoint and commits to
+	// the minted assets in its first output.
+	internalKey := pubKey("anchor-internal-key/" + name)
+	tapscriptRoot := mintCommitment.TapscriptRoot(nil)
+	outputKey := txscript.ComputeTaprootOutputKey(
+		internalKey, tapscriptRoot[:],
+	)
+	pkScript, err := txscript.PayToTaprootScript(outputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: gen.FirstPrevOut,
+	})
+	anchorTx.AddTxOut(&wire.TxOut{
+		Value:    1000,
+		PkScript: pkScript,
+	})
+
+	txs := []*wire.MsgTx{anchorTx}
+	merkleProof, err := proof.NewTxMerkleProof(txs, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	merkleRoot := blockchain.CalcMerkleRoot(
+		[]*btcutil.Tx{btcutil.NewTx(anchorTx)}, false,
+	)
+	header := wire.NewBlockHeader(
+		4, (*chainhash.Hash)(fn.Ptr(hash("prev-block/"+name))),
+		&merkleRoot, 0x1d00ffff, 0,
+	)
+	header.Timestamp = time.Unix(1_700_000_000, 0)
+
+	return &proof.Proof{
+		PrevOut:       gen.FirstPrevOut,
+		BlockHeader:   *header,
+		BlockHeight:   800_000,
+		AnchorTx:      *anchorTx,
+		TxMerkleProof: *merkleProof,
+		Asset:         *proofAsset,
+		InclusionProof: proof.TaprootProof{
+			OutputIndex: 0,
+			InternalKey: internalKey,
+			CommitmentProof: &proof.CommitmentProof{
+				Proof: *commitmentProof,
+			},
+		},
+		MetaReveal:    meta,
+		GenesisReveal: &gen,
+	}, nil
+}
+
+// proofVectors creates the state transition proof vectors.
+func proofVectors() ([]*Vector, error) {
+	testCases := []struct {
+		name    string
+		comment string
+		genesis asset.Genesis
+		amount  uint64
+		meta    *proof.MetaReveal
+	}{{
+		name:    "genesis_normal",
+		comment: "genesis proof of a normal asset",
+		genesis: genesis("normal", asset.Normal),
+		amount:  5000,
+	}, {
+		name:    "genesis_collectible_with_meta",
+		comment: "genesis proof of a collectible with a meta reveal",
+		genesis: genesis("collectible", asset.Collectible),
+		amount:  1,
+		meta: &proof.MetaReveal{
+			Type: proof.MetaOpaque,
+			Data: []byte("conformance"),
+		},
+	}}
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		p, err := genesisProof(tc.name, tc.genesis, tc.amount, tc.meta)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create proof %s: %w",
+				tc.name, err)
+		}
+
+		proofBytes, err := proof.Encode(p)
+		if err != nil {
+			return nil, err
+		}
+
+		vectors = append(vectors, &Vector{
+			Kind:    KindProof,
+			Name:    tc.name,
+			Comment: tc.comment,
+			Encoded: hex.EncodeToString(proofBytes),
+		})
+	}
+
+	return vectors, nil
+}
+
+// htlcVectors creates the asset HTLC custom record vectors.
+func htlcVectors() ([]*Vector, error) {
+	assetID1 := genesis("normal", asset.Normal).ID()
+	assetID2 := genesis("grouped", asset.Normal).ID()
+
+	testCases := []struct {
+		name    string
+		comment string
+		htlc    *rfqmsg.Htlc
+	}{{
+		name:    "single_balance_with_rfq_id",
+		comment: "HTLC carrying a single asset with an RFQ ID",
+		htlc: rfqmsg.NewHtlc(
+			[]*rfqmsg.AssetBalance{
+				rfqmsg.NewAssetBalance(assetID1, 1000),
+			}, fn.Some(rfqmsg.ID(hash("rfq-id/htlc"))),
+		),
+	}, {
+		name:    "multiple_balances",
+		comment: "HTLC carrying two assets without an RFQ ID",
+		htlc: rfqmsg.NewHtlc(
+			[]*rfqmsg.AssetBalance{
+				rfqmsg.NewAssetBalance(assetID1, 1000),
+				rfqmsg.NewAssetBalance(assetID2, 2000),
+			}, fn.None[rfqmsg.ID](),
+		),
+	}}
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		vectors = append(vectors, &Vector{
+			Kind:    KindHtlc,
+			Name:    tc.name,
+			Comment: tc.comment,
+			Encoded: hex.EncodeToString(tc.htlc.Bytes()),
+		})
+	}
+
+	return vectors, nil
+}
+
+// rfqVectors creates the RFQ peer message vectors.
+func rfqVectors() ([]*Vector, error) {
+	var (
+		peer     = route.NewVertex(pubKey("rfq-peer"))
+		assetID1 = genesis("normal", asset.Normal).ID()
+		assetID2 = genesis("grouped", asset.Normal).ID()
+		rate     = rfqmsg.NewAssetRate(
+			rfqmath.NewBigIntFixedPoint(42_000_000, 3), quoteExpiry,
+		)
+	)
+
+	buyReq, err := rfqmsg.NewBuyRequest(
+		peer, asset.NewSpecifierFromId(assetID1), 100, fn.Some(rate),
+	)
+	if err != nil {
+		return nil, err
+	}
+	buyReq.ID = hash("rfq-id/buy")
+
+	sellReq, err := rfqmsg.NewSellRequest(
+		peer, asset.NewSpecifierFromId(assetID2),
+		lnwire.MilliSatoshi(250_000), fn.Some(rate),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sellReq.ID = hash("rfq-id/sell")
+
+	testCases := []struct {
+		name    string
+		comment string
+		msg     rfqmsg.OutgoingMsg
+	}{{
+		name:    "buy_request",
+		comment: "buy request with a rate hint",
+		msg:     buyReq,
+	}, {
+		name:    "sell_request",
+		comment: "sell request with a rate hint",
+		msg:     sellReq,
+	}, {
+		name:    "buy_accept",
+		comment: "accept of the buy request",
+		msg:     rfqmsg.NewBuyAcceptFromRequest(*buyReq, rate),
+	}, {
+		name:    "sell_accept",
+		comment: "accept of the sell request",
+		msg:     rfqmsg.NewSellAcceptFromRequest(*sellReq, rate),
+	}, {
+		name:    "reject",
+		comment: "reject of the buy request",
+		msg: rfqmsg.NewReject(
+			peer, buyReq.ID, rfqmsg.ErrPriceOracleUnavailable,
+		),
+	}}
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		wireMsg, err := tc.msg.ToWire()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode RFQ message "+
+				"%s: %w", tc.name, err)
+		}
+
+		vectors = append(vectors, &Vector{
+			Kind:    KindRfqMessage,
+			Name:    tc.name,
+			Comment: tc.comment,
+			Encoded: encodeRfqWireMsg(wireMsg),
+		})
+	}
+
+	return vectors, nil
+}
+
+// vPacketVectors creates the virtual packet vectors.
+func vPacketVectors() ([]*Vector, error) {
+	courier, err := courierAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	gen := genesis("normal", asset.Normal)
+	addr, err := address.New(
+		address.V1, gen, nil, nil, *pubKey("script-key/vpsbt"),
+		*pubKey("internal-key/vpsbt"), 100, nil,
+		&address.RegressionNetTap, *courier,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	addrPacket, err := tappsbt.FromAddresses([]*address.Tap{addr}, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	interactivePacket := tappsbt.ForInteractiveSend(
+		gen.ID(), 500,
+		asset.NewScriptKey(pubKey("script-key/interactive")), 0, 0, 0,
+		keychain.KeyDescriptor{
+			PubKey: pubKey("internal-key/interactive"),
+		}, asset.V1, &address.RegressionNetTap,
+	)
+
+	testCases := []struct {
+		name    string
+		comment string
+		packet  *tappsbt.VPacket
+	}{{
+		name:    "address_send",
+		comment: "unfunded virtual packet for an address send",
+		packet:  addrPacket,
+	}, {
+		name:    "interactive_send",
+		comment: "unfunded virtual packet for an interactive send",
+		packet:  interactivePacket,
+	}}
+
+	vectors := make([]*Vector, 0, len(testCases))
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		if err := tc.packet.Serialize(&buf); err != nil {
+			return nil, fmt.Errorf("unable to encode virtual "+
+				"packet %s: %w", tc.name, err)
+		}
+
+		vectors = append(vectors, &Vector{
+			Kind:    KindVPacket,
+			Name:    tc.name,
+			Comment: tc.comment,
+			Encoded: hex.EncodeToString(buf.Bytes()),
+		})
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,445 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// VectorsV1 is the first version of the conformance test vector
+	// format.
+	VectorsV1 uint32 = 1
+
+	// LatestVectorsVersion is the latest version of the conformance test
+	// vector format.
+	LatestVectorsVersion = VectorsV1
+)
+
+// Kind denotes the type of protocol object a test vector covers.
+type Kind string
+
+const (
+	// KindAddress is the kind of vectors that cover Taproot Asset
+	// addresses. The encoded form is the bech32m address string.
+	KindAddress Kind = "address"
+
+	// KindProof is the kind of vectors that cover single state transition
+	// proofs. The encoded form is the hex encoded TLV proof.
+	KindProof Kind = "proof"
+
+	// KindHtlc is the kind of vectors that cover the asset HTLC custom
+	// records. The encoded form is the hex encoded TLV record blob.
+	KindHtlc Kind = "htlc"
+
+	// KindRfqMessage is the kind of vectors that cover RFQ peer messages.
+	// The encoded form is the hex encoded big endian 2-byte message type,
+	// followed by the message data, as it is sent over the wire.
+	KindRfqMessage Kind = "rfq_message"
+
+	// KindVPacket is the kind of vectors that cover virtual packets. The
+	// encoded form is the hex encoded vPSBT.
+	KindVPacket Kind = "vpsbt"
+)
+
+// Vector is a single conformance test vector. It pairs the encoding of a
+// protocol object with its expected decoding.
+type Vector struct {
+	// Kind is the type of protocol object the vector covers.
+	Kind Kind `json:"kind"`
+
+	// Name uniquely identifies the vector among the vectors of the same
+	// kind.
+	Name string `json:"name"`
+
+	// Comment is an optional description of what the vector covers.
+	Comment string `json:"comment,omitempty"`
+
+	// Encoded is the encoded form of the protocol object.
+	Encoded string `json:"encoded"`
+
+	// Decoded is the expected JSON decoding of the protocol object. Proofs
+	// and virtual packets use their canonical JSON encoding.
+	Decoded json.RawMessage `json:"decoded"`
+}
+
+// key returns the key that identifies the vector within a set of vectors.
+func (v *Vector) key() string {
+	return fmt.Sprintf("%s/%s", v.Kind, v.Name)
+}
+
+// Vectors is a versioned set of conformance test vectors.
+type Vectors struct {
+	// Version is the version of the vector format.
+	Version uint32 `json:"version"`
+
+	// Vectors are the individual test vectors.
+	Vectors []*Vector `json:"vectors"`
+}
+
+// DecodeVectors decodes a set of test vectors from its JSON encoding.
+func DecodeVectors(jsonBytes []byte) (*Vectors, error) {
+	var vectors Vectors
+	if err := json.Unmarshal(jsonBytes, &vectors); err != nil {
+		return nil, fmt.Errorf("unable to decode vectors: %w", err)
+	}
+
+	if vectors.Version != VectorsV1 {
+		return nil, fmt.Errorf("unknown vectors version %d",
+			vectors.Version)
+	}
+
+	return &vectors, nil
+}
+
+// JSONAddress is the expected decoding of a Taproot Asset address.
+type JSONAddress struct {
+	Version          uint8  `json:"version"`
+	ChainParamsHRP   string `json:"chain_params_hrp"`
+	AssetVersion     uint8  `json:"asset_version"`
+	AssetID          string `json:"asset_id"`
+	GroupKey         string `json:"group_key,omitempty"`
+	ScriptKey        string `json:"script_key"`
+	InternalKey      string `json:"internal_key"`
+	TapscriptSibling string `json:"tapscript_sibling,omitempty"`
+	Amount           uint64 `json:"amount"`
+	ProofCourierAddr string `json:"proof_courier_addr"`
+}
+
+// JSONRfqMessage is the expected decoding of an RFQ peer message. Only the
+// fields that apply to the message type are set.
+type JSONRfqMessage struct {
+	Type            string `json:"type"`
+	Version         uint8  `json:"version"`
+	ID              string `json:"id"`
+	AssetID         string `json:"asset_id,omitempty"`
+	GroupKey        string `json:"group_key,omitempty"`
+	AssetMaxAmt     uint64 `json:"asset_max_amt,omitempty"`
+	PaymentMaxMsat  uint64 `json:"payment_max_msat,omitempty"`
+	RateCoefficient string `json:"rate_coefficient,omitempty"`
+	RateScale       uint8  `json:"rate_scale,omitempty"`
+	Expiry          int64  `json:"expiry,omitempty"`
+	RejectCode      uint8  `json:"reject_code,omitempty"`
+	RejectMsg       string `json:"reject_msg,omitempty"`
+}
+
+// RFQ message types as they appear in the expected decoding. The quote accept
+// messages are told apart by the request they respond to.
+const (
+	rfqTypeBuyRequest  = "buy_request"
+	rfqTypeSellRequest = "sell_request"
+	rfqTypeBuyAccept   = "buy_accept"
+	rfqTypeSellAccept  = "sell_accept"
+	rfqTypeReject      = "reject"
+)
+
+// decoder decodes the encoded form of test vectors with this implementation.
+type decoder struct {
+	// requests are the RFQ quote requests of the vector set, which are
+	// needed to decode quote accept messages.
+	requests map[rfqmsg.ID]rfqmsg.OutgoingMsg
+}
+
+// newDecoder creates a decoder for the given set of vectors. The RFQ quote
+// requests of the set are decoded first, so the quote accept messages that
+// respond to them can be decoded as well.
+func newDecoder(vectors []*Vector) *decoder {
+	d := &decoder{
+		requests: make(map[rfqmsg.ID]rfqmsg.OutgoingMsg),
+	}
+
+	for _, v := range vectors {
+		if v.Kind != KindRfqMessage {
+			continue
+		}
+
+		wireMsg, err := decodeRfqWireMsg(v.Encoded)
+		if err != nil || wireMsg.MsgType != rfqmsg.MsgTypeRequest {
+			continue
+		}
+
+		msg, err := rfqmsg.NewIncomingRequestFromWire(wireMsg)
+		if err != nil {
+			continue
+		}
+
+		switch req := msg.(type) {
+		case *rfqmsg.BuyRequest:
+			d.requests[req.ID] = req
+
+		case *rfqmsg.SellRequest:
+			d.requests[req.ID] = req
+		}
+	}
+
+	return d
+}
+
+// decode decodes the given encoded protocol object of the given kind and
+// returns its JSON decoding.
+func (d *decoder) decode(kind Kind, encoded string) ([]byte, error) {
+	switch kind {
+	case KindAddress:
+		return decodeAddress(encoded)
+
+	case KindProof:
+		proofBytes, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := proof.Decode(proofBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return proof.EncodeJSON(p)
+
+	case KindHtlc:
+		htlcBytes, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		htlc, err := rfqmsg.DecodeHtlc(htlcBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return htlc.AsJson()
+
+	case KindRfqMessage:
+		return d.decodeRfqMessage(encoded)
+
+	case KindVPacket:
+		packetBytes, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		packet, err := tappsbt.Decode(packetBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return tappsbt.EncodeJSON(packet)
+
+	default:
+		return nil, fmt.Errorf("unknown vector kind %q", kind)
+	}
+}
+
+// decodeAddress decodes the given bech32m encoded Taproot Asset address.
+func decodeAddress(encoded string) ([]byte, error) {
+	// The network of the address is derived from its human-readable part,
+	// which is everything before the last separator.
+	sepIdx := strings.LastIndex(encoded, "1")
+	if sepIdx < 1 {
+		return nil, fmt.Errorf("invalid address %q", encoded)
+	}
+
+	chainParams, err := address.Net(encoded[:sepIdx])
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := address.DecodeAddress(encoded, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(newJSONAddress(addr))
+}
+
+// newJSONAddress creates the expected decoding of the given address.
+func newJSONAddress(addr *address.Tap) *JSONAddress {
+	j := &JSONAddress{
+		Version:        uint8(addr.Version),
+		ChainParamsHRP: addr.ChainParams.TapHRP,
+		AssetVersion:   uint8(addr.AssetVersion),
+		AssetID:        addr.AssetID.String(),
+		ScriptKey: pubKeyHex(
+			addr.ScriptKey.SerializeCompressed(),
+		),
+		InternalKey: pubKeyHex(
+			addr.InternalKey.SerializeCompressed(),
+		),
+		Amount:           addr.Amount,
+		ProofCourierAddr: addr.ProofCourierAddr.String(),
+	}
+
+	if addr.GroupKey != nil {
+		j.GroupKey = pubKeyHex(addr.GroupKey.SerializeCompressed())
+	}
+
+	if addr.TapscriptSibling != nil {
+		// The sibling was decoded from the address, so it is known to
+		// be encodable.
+		j.TapscriptSibling, _ = commitment.EncodeHexTapscriptPreimage(
+			addr.TapscriptSibling,
+		)
+	}
+
+	return j
+}
+
+// pubKeyHex returns the hex encoding of a serialized public key.
+func pubKeyHex(pubKey []byte) string {
+	return hex.EncodeToString(pubKey)
+}
+
+// encodeRfqWireMsg encodes the given RFQ wire message as its message type,
+// followed by its data.
+func encodeRfqWireMsg(wireMsg rfqmsg.WireMessage) string {
+	var msgType [2]byte
+	binary.BigEndian.PutUint16(msgType[:], uint16(wireMsg.MsgType))
+
+	return hex.EncodeToString(append(msgType[:], wireMsg.Data...))
+}
+
+// decodeRfqWireMsg decodes an RFQ wire message from its message type,
+// followed by its data.
+func decodeRfqWireMsg(encoded string) (rfqmsg.WireMessage, error) {
+	msgBytes, err := hex.DecodeString(encoded)
+	if err != nil {
+		return rfqmsg.WireMessage{}, err
+	}
+
+	if len(msgBytes) < 2 {
+		return rfqmsg.WireMessage{}, fmt.Errorf("RFQ message too short")
+	}
+
+	return rfqmsg.WireMessage{
+		MsgType: lnwire.MessageType(
+			binary.BigEndian.Uint16(msgBytes[:2]),
+		),
+		Data: msgBytes[2:],
+	}, nil
+}
+
+// decodeRfqMessage decodes the given encoded RFQ peer message.
+func (d *decoder) decodeRfqMessage(encoded string) ([]byte, error) {
+	wireMsg, err := decodeRfqWireMsg(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(id rfqmsg.ID) (rfqmsg.OutgoingMsg, bool) {
+		req, ok := d.requests[id]
+		return req, ok
+	}
+	msg, err := rfqmsg.NewIncomingMsgFromWire(wireMsg, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	var j *JSONRfqMessage
+	switch m := msg.(type) {
+	case *rfqmsg.BuyRequest:
+		j = &JSONRfqMessage{
+			Type:        rfqTypeBuyRequest,
+			Version:     uint8(m.Version),
+			ID:          hex.EncodeToString(m.ID[:]),
+			AssetMaxAmt: m.AssetMaxAmt,
+		}
+		setSpecifier(j, m.AssetSpecifier)
+		m.AssetRateHint.WhenSome(func(rate rfqmsg.AssetRate) {
+			setRate(j, rate)
+		})
+
+	case *rfqmsg.SellRequest:
+		j = &JSONRfqMessage{
+			Type:           rfqTypeSellRequest,
+			Version:        uint8(m.Version),
+			ID:             hex.EncodeToString(m.ID[:]),
+			PaymentMaxMsat: uint64(m.PaymentMaxAmt),
+		}
+		setSpecifier(j, m.AssetSpecifier)
+		m.AssetRateHint.WhenSome(func(rate rfqmsg.AssetRate) {
+			setRate(j, rate)
+		})
+
+	case *rfqmsg.BuyAccept:
+		j = &JSONRfqMessage{
+			Type:    rfqTypeBuyAccept,
+			Version: uint8(m.Version),
+			ID:      hex.EncodeToString(m.ID[:]),
+		}
+		setRate(j, m.AssetRate)
+
+	case *rfqmsg.SellAccept:
+		j = &JSONRfqMessage{
+			Type:    rfqTypeSellAccept,
+			Version: uint8(m.Version),
+			ID:      hex.EncodeToString(m.ID[:]),
+		}
+		setRate(j, m.AssetRate)
+
+	case *rfqmsg.Reject:
+		j = &JSONRfqMessage{
+			Type:       rfqTypeReject,
+			Version:    uint8(m.Version.Val),
+			ID:         hex.EncodeToString(m.ID.Val[:]),
+			RejectCode: m.Err.Val.Code,
+			RejectMsg:  m.Err.Val.Msg,
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown RFQ message %T", msg)
+	}
+
+	return json.Marshal(j)
+}
+
+// setSpecifier sets the asset ID or group key of the given RFQ message
+// decoding from the given asset specifier.
+func setSpecifier(j *JSONRfqMessage, specifier asset.Specifier) {
+	specifier.WhenId(func(id asset.ID) {
+		j.AssetID = id.String()
+	})
+	specifier.WhenGroupPubKey(func(groupKey btcec.PublicKey) {
+		j.GroupKey = pubKeyHex(groupKey.SerializeCompressed())
+	})
+}
+
+// setRate sets the asset rate of the given RFQ message decoding.
+func setRate(j *JSONRfqMessage, rate rfqmsg.AssetRate) {
+	j.RateCoefficient = rate.Rate.Coefficient.String()
+	j.RateScale = rate.Rate.Scale
+	j.Expiry = rate.Expiry.Unix()
+}
+
+// jsonEqual returns true if the two JSON documents are semantically equal,
+// meaning they only differ in formatting and the order of object keys.
+func jsonEqual(a, b []byte) bool {
+	normalize := func(doc []byte) ([]byte, error) {
+		var v any
+		decoder := json.NewDecoder(bytes.NewReader(doc))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(v)
+	}
+
+	normA, errA := normalize(a)
+	normB, errB := normalize(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return bytes.Equal(normA, normB)
+}
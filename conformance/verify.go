@@ -0,0 +1,116 @@
+package conformance
+
+import (
+	"fmt"
+)
+
+// Failure describes a test vector that an implementation doesn't conform to.
+type Failure struct {
+	// Kind is the kind of the failed vector.
+	Kind Kind
+
+	// Name is the name of the failed vector.
+	Name string
+
+	// Reason describes why the vector failed.
+	Reason string
+}
+
+// String returns a human-readable description of the failure.
+func (f *Failure) String() string {
+	return fmt.Sprintf("%s/%s: %s", f.Kind, f.Name, f.Reason)
+}
+
+// Check verifies that this implementation decodes the encoded form of each of
+// the given vectors into its expected decoding. This can be used to validate
+// vectors that were produced by another implementation.
+func Check(vectors *Vectors) []*Failure {
+	var (
+		d        = newDecoder(vectors.Vectors)
+		failures []*Failure
+	)
+	for _, v := range vectors.Vectors {
+		decoded, err := d.decode(v.Kind, v.Encoded)
+		switch {
+		case err != nil:
+			failures = append(failures, &Failure{
+				Kind: v.Kind,
+				Name: v.Name,
+				Reason: fmt.Sprintf("unable to decode: %v",
+					err),
+			})
+
+		case !jsonEqual(decoded, v.Decoded):
+			failures = append(failures, &Failure{
+				Kind: v.Kind,
+				Name: v.Name,
+				Reason: fmt.Sprintf("decoding mismatch, "+
+					"expected %s, got %s", v.Decoded,
+					decoded),
+			})
+		}
+	}
+
+	return failures
+}
+
+// Verify checks the results an external implementation produced for the
+// expected vectors. For each expected vector, the results must contain a
+// vector of the same kind and name with the same encoding and a semantically
+// equal decoding. An implementation that only decodes can copy the expected
+// encodings into its results, one that only encodes can copy the expected
+// decodings.
+func Verify(expected, results *Vectors) []*Failure {
+	if results.Version != expected.Version {
+		return []*Failure{{
+			Reason: fmt.Sprintf("results version %d doesn't match "+
+				"vectors version %d", results.Version,
+				expected.Version),
+		}}
+	}
+
+	resultsByKey := make(map[string]*Vector, len(results.Vectors))
+	for _, v := range results.Vectors {
+		resultsByKey[v.key()] = v
+	}
+
+	var (
+		d        = newDecoder(expected.Vectors)
+		failures []*Failure
+	)
+	for _, v := range expected.Vectors {
+		fail := func(format string, args ...any) {
+			failures = append(failures, &Failure{
+				Kind:   v.Kind,
+				Name:   v.Name,
+				Reason: fmt.Sprintf(format, args...),
+			})
+		}
+
+		result, ok := resultsByKey[v.key()]
+		if !ok {
+			fail("missing from results")
+			continue
+		}
+
+		if result.Encoded != v.Encoded {
+			// To make the mismatch easier to track down, we show
+			// what the wrong encoding decodes to.
+			decoded, err := d.decode(v.Kind, result.Encoded)
+			if err != nil {
+				fail("encoding mismatch, result can't be "+
+					"decoded: %v", err)
+			} else {
+				fail("encoding mismatch, result decodes to %s",
+					decoded)
+			}
+		}
+
+		if !jsonEqual(result.Decoded, v.Decoded) {
+			fail("decoding mismatch, expected %s, got %s",
+				v.Decoded, result.Decoded)
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,139 @@
+package explorer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/neutrino/cache/lru"
+	"golang.org/x/time/rate"
+)
+
+// cachedResponse is an encoded response body that can be stored in an LRU
+// cache.
+type cachedResponse struct {
+	body   []byte
+	expiry time.Time
+}
+
+// Size returns the size of the cached response. Since we scale the cache by
+// the number of items and not the total memory size, we can simply return 1
+// here to count each response as 1 item.
+func (c *cachedResponse) Size() (uint64, error) {
+	return 1, nil
+}
+
+// responseCache is a bounded cache of encoded responses that expire after a
+// fixed duration.
+type responseCache struct {
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	responses *lru.Cache[string, *cachedResponse]
+}
+
+// newResponseCache creates a new response cache. If the max age is zero, nil
+// is returned, which caches nothing.
+func newResponseCache(maxAge time.Duration, size uint64) *responseCache {
+	if maxAge == 0 {
+		return nil
+	}
+
+	return &responseCache{
+		maxAge:    maxAge,
+		responses: lru.NewCache[string, *cachedResponse](size),
+	}
+}
+
+// get returns the cached response body for the given key, if there is one
+// that hasn't expired yet.
+func (c *responseCache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, err := c.responses.Get(key)
+	if err != nil {
+		return nil, false
+	}
+
+	if time.Now().After(resp.expiry) {
+		c.responses.Delete(key)
+		return nil, false
+	}
+
+	return resp.body, true
+}
+
+// put stores the given response body for the given key.
+func (c *responseCache) put(key string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, _ = c.responses.Put(key, &cachedResponse{
+		body:   body,
+		expiry: time.Now().Add(c.maxAge),
+	})
+}
+
+// cachedLimiter is a rate limiter that can be stored in an LRU cache.
+type cachedLimiter struct {
+	*rate.Limiter
+}
+
+// Size returns the size of the cached limiter. Since we scale the cache by the
+// number of items and not the total memory size, we can simply return 1 here
+// to count each limiter as 1 item.
+func (c cachedLimiter) Size() (uint64, error) {
+	return 1, nil
+}
+
+// peerLimiter is a bounded set of rate limiters, one for each IP address.
+type peerLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters *lru.Cache[string, cachedLimiter]
+}
+
+// newPeerLimiter creates a new per-IP rate limiter. If the limit is zero, nil
+// is returned, which allows all requests.
+func newPeerLimiter(limit rate.Limit, burst int, size uint64) *peerLimiter {
+	if limit == 0 {
+		return nil
+	}
+
+	return &peerLimiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: lru.NewCache[string, cachedLimiter](size),
+	}
+}
+
+// allow returns true if a request from the given IP address is permitted right
+// now.
+func (l *peerLimiter) allow(ip string) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, err := l.limiters.Get(ip)
+	if err != nil {
+		limiter = cachedLimiter{
+			Limiter: rate.NewLimiter(l.limit, l.burst),
+		}
+		_, _ = l.limiters.Put(ip, limiter)
+	}
+
+	return limiter.Allow()
+}
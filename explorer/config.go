@@ -0,0 +1,133 @@
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/universe"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultListenAddr is the default address the explorer API listens
+	// on.
+	DefaultListenAddr = "127.0.0.1:8093"
+
+	// DefaultCacheMaxAge is the default duration for which responses are
+	// cached by the server and may be cached by clients.
+	DefaultCacheMaxAge = time.Minute
+
+	// DefaultCacheSize is the default maximum number of responses that are
+	// cached by the server.
+	DefaultCacheSize = 1_000
+
+	// DefaultRequestRate is the default maximum number of requests per
+	// second that are permitted from a single IP address.
+	DefaultRequestRate = 5
+
+	// DefaultRequestBurst is the default burst budget for the per-IP rate
+	// limiting.
+	DefaultRequestBurst = 20
+
+	// DefaultMaxHolderScan is the default maximum number of transfer
+	// proofs that are inspected to estimate the number of holders of an
+	// asset.
+	DefaultMaxHolderScan = 1_000
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// explorer API.
+//
+// nolint: lll
+type CliConfig struct {
+	Active bool `long:"active" description:"If true, a read-only HTTP JSON API for block explorers is started that serves public data of the local universe on a separate listener"`
+
+	ListenAddr string `long:"listenaddr" description:"The interface the explorer API should listen on"`
+
+	CacheMaxAge time.Duration `long:"cachemaxage" description:"The duration for which responses are cached by the server and may be cached by clients"`
+
+	CacheSize uint64 `long:"cachesize" description:"The maximum number of responses that are cached by the server"`
+
+	RequestRate rate.Limit `long:"requestrate" description:"The maximum number of requests per second that are permitted from a single IP address; a value of zero disables rate limiting"`
+
+	RequestBurst int `long:"requestburst" description:"The burst budget for the per-IP rate limiting"`
+
+	MaxHolderScan uint32 `long:"maxholderscan" description:"The maximum number of transfer proofs that are inspected to estimate the number of holders of an asset"`
+}
+
+// DefaultCliConfig returns the default explorer API configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		ListenAddr:    DefaultListenAddr,
+		CacheMaxAge:   DefaultCacheMaxAge,
+		CacheSize:     DefaultCacheSize,
+		RequestRate:   DefaultRequestRate,
+		RequestBurst:  DefaultRequestBurst,
+		MaxHolderScan: DefaultMaxHolderScan,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if !c.Active {
+		return nil
+	}
+
+	switch {
+	case c.ListenAddr == "":
+		return fmt.Errorf("explorer listen address must be set")
+
+	case c.CacheMaxAge < 0:
+		return fmt.Errorf("explorer cache max age must not be negative")
+
+	case c.CacheMaxAge > 0 && c.CacheSize == 0:
+		return fmt.Errorf("explorer cache size must be set")
+
+	case c.RequestRate < 0:
+		return fmt.Errorf("explorer request rate must not be negative")
+
+	case c.RequestRate > 0 && c.RequestBurst < 1:
+		return fmt.Errorf("explorer request burst must be at least 1")
+	}
+
+	return nil
+}
+
+// UniverseReader provides read access to the trees of the local universe.
+type UniverseReader interface {
+	// RootNode returns the root node of the universe with the given ID.
+	RootNode(ctx context.Context,
+		id universe.Identifier) (universe.Root, error)
+
+	// UniverseLeafKeys returns the leaf keys of a universe.
+	UniverseLeafKeys(ctx context.Context,
+		q universe.UniverseLeafKeysQuery) ([]universe.LeafKey, error)
+
+	// FetchProofLeaf returns the proofs of the leaf with the given key.
+	FetchProofLeaf(ctx context.Context, id universe.Identifier,
+		key universe.LeafKey) ([]*universe.Proof, error)
+}
+
+// StatsReader provides the aggregated statistics of the local universe.
+type StatsReader interface {
+	// AggregateSyncStats returns stats aggregated over all assets within
+	// the universe.
+	AggregateSyncStats(ctx context.Context) (universe.AggregateStats,
+		error)
+
+	// QuerySyncStats returns the stats of the assets matching the query.
+	QuerySyncStats(ctx context.Context,
+		q universe.SyncStatsQuery) (*universe.AssetSyncStats, error)
+}
+
+// Config is the configuration of the explorer API server.
+type Config struct {
+	*CliConfig
+
+	// Universe is used to read the issuance and transfer trees.
+	Universe UniverseReader
+
+	// Stats is used to read the asset and universe statistics.
+	Stats StatsReader
+}
@@ -0,0 +1,772 @@
+package explorer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+)
+
+const (
+	// APIPrefix is the URL path prefix of all explorer API endpoints.
+	APIPrefix = "/v1"
+
+	// defaultPageLimit is the number of items returned by list endpoints
+	// if the client doesn't specify a limit.
+	defaultPageLimit = 50
+
+	// maxPageLimit is the maximum number of items returned by list
+	// endpoints.
+	maxPageLimit = 100
+
+	// leafKeyPageSize is the number of leaf keys that are fetched at once
+	// when scanning a universe tree.
+	leafKeyPageSize = 500
+
+	// peerLimiterCacheSize is the maximum number of IP addresses that are
+	// tracked for rate limiting.
+	peerLimiterCacheSize = 10_000
+
+	// requestTimeout is the maximum time it may take to serve a single
+	// request.
+	requestTimeout = 30 * time.Second
+
+	// shutdownTimeout is the maximum time we wait for pending requests to
+	// finish when shutting down.
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// errNotFound is returned if the requested asset is unknown to the
+	// local universe.
+	errNotFound = errors.New("asset not found")
+)
+
+// StatsResponse contains the aggregate stats of the universe.
+type StatsResponse struct {
+	// NumAssets is the total number of assets in the universe.
+	NumAssets uint64 `json:"num_assets"`
+
+	// NumGroups is the total number of asset groups in the universe.
+	NumGroups uint64 `json:"num_groups"`
+
+	// NumProofs is the total number of proofs in the universe.
+	NumProofs uint64 `json:"num_proofs"`
+}
+
+// AssetSummary contains the public information about an asset.
+type AssetSummary struct {
+	// AssetID is the hex encoded asset ID.
+	AssetID string `json:"asset_id"`
+
+	// Name is the name of the asset.
+	Name string `json:"name"`
+
+	// Type is the type of the asset, either "Normal" or "Collectible".
+	Type string `json:"type"`
+
+	// GroupKey is the hex encoded group key of the asset, if it belongs to
+	// a group.
+	GroupKey string `json:"group_key,omitempty"`
+
+	// TotalSupply is the total issued supply of the asset ID.
+	TotalSupply uint64 `json:"total_supply"`
+
+	// GroupSupply is the total issued supply of the asset group, if the
+	// asset belongs to a group.
+	GroupSupply uint64 `json:"group_supply,omitempty"`
+
+	// GenesisPoint is the outpoint that was spent to create the asset.
+	GenesisPoint string `json:"genesis_point"`
+
+	// GenesisHeight is the block height at which the asset was issued.
+	GenesisHeight uint32 `json:"genesis_height"`
+
+	// NumProofs is the number of proofs of the asset in the universe.
+	NumProofs uint64 `json:"num_proofs"`
+}
+
+// AssetResponse is the response of the asset page endpoint. For assets that
+// belong to a group, the transfer and holder counts cover the whole group.
+type AssetResponse struct {
+	AssetSummary
+
+	// NumTransfers is the number of transfer proofs in the universe.
+	NumTransfers uint64 `json:"num_transfers"`
+
+	// HolderCountEstimate is the estimated number of distinct script keys
+	// that currently hold the asset, based on the outputs of the issuance
+	// and transfer proofs that haven't been spent by another transfer
+	// proof in the universe. Outputs spent in transfers that weren't
+	// pushed to the universe are still counted.
+	HolderCountEstimate uint64 `json:"holder_count_estimate"`
+
+	// HolderCountComplete is true if all proofs were inspected to estimate
+	// the holder count, and false if the scan was cut off.
+	HolderCountComplete bool `json:"holder_count_complete"`
+}
+
+// AssetEvent is an issuance or transfer of an asset.
+type AssetEvent struct {
+	// AssetID is the hex encoded asset ID. For assets that belong to a
+	// group, this can differ from the asset ID that was requested.
+	AssetID string `json:"asset_id"`
+
+	// OutPoint is the anchor outpoint the asset was issued or transferred
+	// to.
+	OutPoint string `json:"outpoint"`
+
+	// Amount is the amount of asset units of the output.
+	Amount uint64 `json:"amount"`
+
+	// BlockHeight is the height of the block the anchor transaction was
+	// confirmed in.
+	BlockHeight uint32 `json:"block_height"`
+
+	// BlockTime is the unix timestamp of the block the anchor transaction
+	// was confirmed in.
+	BlockTime int64 `json:"block_time"`
+}
+
+// AssetListResponse is the response of the asset list endpoint.
+type AssetListResponse struct {
+	// Assets is the list of assets, most recently issued first.
+	Assets []AssetSummary `json:"assets"`
+}
+
+// EventListResponse is the response of the issuance and transfer history
+// endpoints.
+type EventListResponse struct {
+	// Events is the list of events, most recently inserted into the
+	// universe first.
+	Events []AssetEvent `json:"events"`
+}
+
+// ErrorResponse is the response returned if a request cannot be served.
+type ErrorResponse struct {
+	// Error is a human-readable description of the error.
+	Error string `json:"error"`
+}
+
+// httpError is an error with the HTTP status code it should be reported with.
+type httpError struct {
+	status int
+	err    error
+}
+
+// Error returns the error message.
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// badRequest returns an error that is reported with a bad request status.
+func badRequest(format string, args ...any) error {
+	return &httpError{
+		status: http.StatusBadRequest,
+		err:    fmt.Errorf(format, args...),
+	}
+}
+
+// handlerFunc is an explorer API handler that returns the response to encode.
+type handlerFunc func(ctx context.Context, r *http.Request) (any, error)
+
+// Server is an HTTP server that serves a read-only JSON API of the public data
+// of the local universe, tailored for block explorers. Only data that is
+// already public through universe proofs is served, but script keys are left
+// out to avoid making it trivial to track individual holders.
+type Server struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *Config
+
+	cache *responseCache
+
+	limiter *peerLimiter
+
+	httpServer *http.Server
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewServer creates a new explorer API server.
+func NewServer(cfg *Config) *Server {
+	s := &Server{
+		cfg:   cfg,
+		cache: newResponseCache(cfg.CacheMaxAge, cfg.CacheSize),
+		limiter: newPeerLimiter(
+			cfg.RequestRate, cfg.RequestBurst, peerLimiterCacheSize,
+		),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: requestTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+APIPrefix+"/stats", s.handler(s.stats))
+	mux.Handle("GET "+APIPrefix+"/assets", s.handler(s.assets))
+	mux.Handle(
+		"GET "+APIPrefix+"/assets/{asset_id}", s.handler(s.assetPage),
+	)
+	mux.Handle(
+		"GET "+APIPrefix+"/assets/{asset_id}/issuances",
+		s.handler(s.eventsOf(universe.ProofTypeIssuance)),
+	)
+	mux.Handle(
+		"GET "+APIPrefix+"/assets/{asset_id}/transfers",
+		s.handler(s.eventsOf(universe.ProofTypeTransfer)),
+	)
+
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// Start starts the explorer API server.
+func (s *Server) Start() error {
+	var startErr error
+	s.startOnce.Do(func() {
+		log.Info("Starting explorer API server")
+
+		lis, err := net.Listen("tcp", s.cfg.ListenAddr)
+		if err != nil {
+			startErr = fmt.Errorf("unable to listen on %v: %w",
+				s.cfg.ListenAddr, err)
+			return
+		}
+
+		s.Wg.Add(1)
+		go func() {
+			defer s.Wg.Done()
+
+			err := s.httpServer.Serve(lis)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Errorf("Explorer API server error: %v", err)
+			}
+		}()
+
+		log.Infof("Explorer API server listening on %v", lis.Addr())
+	})
+
+	return startErr
+}
+
+// Stop stops the explorer API server.
+func (s *Server) Stop() error {
+	var stopErr error
+	s.stopOnce.Do(func() {
+		log.Info("Stopping explorer API server")
+
+		close(s.Quit)
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(), shutdownTimeout,
+		)
+		defer cancel()
+
+		stopErr = s.httpServer.Shutdown(ctx)
+		s.Wg.Wait()
+	})
+
+	return stopErr
+}
+
+// handler wraps the given handler function with the rate limiting, caching
+// and encoding that is common to all endpoints.
+func (s *Server) handler(f handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		if !s.limiter.allow(ip) {
+			writeError(
+				w, http.StatusTooManyRequests,
+				"rate limit exceeded",
+			)
+			return
+		}
+
+		cacheKey := r.URL.RequestURI()
+		if body, ok := s.cache.get(cacheKey); ok {
+			s.writeBody(w, body)
+			return
+		}
+
+		ctx, cancel := s.WithCtxQuit()
+		defer cancel()
+
+		resp, err := f(ctx, r)
+		var httpErr *httpError
+		switch {
+		case errors.As(err, &httpErr):
+			writeError(w, httpErr.status, httpErr.Error())
+			return
+
+		case errors.Is(err, errNotFound):
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+
+		case err != nil:
+			log.Errorf("Unable to serve %v: %v", r.URL.Path, err)
+			writeError(
+				w, http.StatusInternalServerError,
+				"internal error",
+			)
+			return
+		}
+
+		body, err := json.Marshal(resp)
+		if err != nil {
+			log.Errorf("Unable to encode response: %v", err)
+			writeError(
+				w, http.StatusInternalServerError,
+				"internal error",
+			)
+			return
+		}
+
+		s.cache.put(cacheKey, body)
+		s.writeBody(w, body)
+	})
+}
+
+// writeBody writes a successful response with the given encoded body,
+// allowing clients to cache it for the configured duration.
+func (s *Server) writeBody(w http.ResponseWriter, body []byte) {
+	if s.cfg.CacheMaxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf(
+			"public, max-age=%d", int(s.cfg.CacheMaxAge.Seconds()),
+		))
+	}
+
+	writeHeaders(w, http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Errorf("Unable to write response: %v", err)
+	}
+}
+
+// stats serves the aggregate stats of the universe.
+func (s *Server) stats(ctx context.Context, _ *http.Request) (any, error) {
+	stats, err := s.cfg.Stats.AggregateSyncStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query stats: %w", err)
+	}
+
+	return &StatsResponse{
+		NumAssets: stats.NumTotalAssets,
+		NumGroups: stats.NumTotalGroups,
+		NumProofs: stats.NumTotalProofs,
+	}, nil
+}
+
+// assets serves the list of known assets, most recently issued first.
+func (s *Server) assets(ctx context.Context, r *http.Request) (any, error) {
+	offset, limit, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.cfg.Stats.QuerySyncStats(ctx, universe.SyncStatsQuery{
+		SortBy:        universe.SortByGenesisHeight,
+		SortDirection: universe.SortDescending,
+		Offset:        int(offset),
+		Limit:         int(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query assets: %w", err)
+	}
+
+	return &AssetListResponse{
+		Assets: fn.Map(stats.SyncStats, newAssetSummary),
+	}, nil
+}
+
+// assetPage serves the details of a single asset.
+func (s *Server) assetPage(ctx context.Context, r *http.Request) (any, error) {
+	snapshot, err := s.assetSnapshot(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	transferID := universeID(snapshot, universe.ProofTypeTransfer)
+	var numTransfers uint64
+	root, err := s.cfg.Universe.RootNode(ctx, transferID)
+	switch {
+	case err == nil:
+		numTransfers = root.NodeSum()
+
+	case !errors.Is(err, universe.ErrNoUniverseRoot):
+		return nil, fmt.Errorf("unable to fetch transfer root: %w", err)
+	}
+
+	numHolders, complete, err := s.estimateHolders(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AssetResponse{
+		AssetSummary:        newAssetSummary(*snapshot),
+		NumTransfers:        numTransfers,
+		HolderCountEstimate: numHolders,
+		HolderCountComplete: complete,
+	}, nil
+}
+
+// eventsOf returns a handler that serves the issuance or transfer history of
+// an asset, most recent first.
+func (s *Server) eventsOf(proofType universe.ProofType) handlerFunc {
+	return func(ctx context.Context, r *http.Request) (any, error) {
+		offset, limit, err := parsePage(r)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot, err := s.assetSnapshot(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		id := universeID(snapshot, proofType)
+		keys, err := s.cfg.Universe.UniverseLeafKeys(
+			ctx, universe.UniverseLeafKeysQuery{
+				Id:            id,
+				SortDirection: universe.SortDescending,
+				Offset:        offset,
+				Limit:         limit,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch leaf keys: %w",
+				err)
+		}
+
+		events := make([]AssetEvent, 0, len(keys))
+		for _, key := range keys {
+			leaf, err := s.fetchLeaf(ctx, id, key)
+			if err != nil {
+				return nil, err
+			}
+
+			event, err := newAssetEvent(leaf)
+			if err != nil {
+				return nil, err
+			}
+
+			events = append(events, *event)
+		}
+
+		return &EventListResponse{
+			Events: events,
+		}, nil
+	}
+}
+
+// assetSnapshot returns the stats snapshot of the asset in the request path.
+func (s *Server) assetSnapshot(ctx context.Context,
+	r *http.Request) (*universe.AssetSyncSnapshot, error) {
+
+	assetID, err := parseAssetID(r.PathValue("asset_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.cfg.Stats.QuerySyncStats(ctx, universe.SyncStatsQuery{
+		AssetIDFilter: assetID,
+		Limit:         1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query asset: %w", err)
+	}
+
+	if len(stats.SyncStats) == 0 {
+		return nil, errNotFound
+	}
+
+	return &stats.SyncStats[0], nil
+}
+
+// fetchLeaf returns the universe leaf with the given key.
+func (s *Server) fetchLeaf(ctx context.Context, id universe.Identifier,
+	key universe.LeafKey) (*universe.Leaf, error) {
+
+	proofs, err := s.cfg.Universe.FetchProofLeaf(ctx, id, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch proof leaf: %w", err)
+	}
+
+	if len(proofs) == 0 || proofs[0].Leaf == nil {
+		return nil, fmt.Errorf("no proof found for leaf %v",
+			key.OutPoint)
+	}
+
+	return proofs[0].Leaf, nil
+}
+
+// outputKey identifies an asset output by its anchor outpoint and script key.
+type outputKey struct {
+	outPoint  wire.OutPoint
+	scriptKey asset.SerializedKey
+}
+
+// estimateHolders estimates the number of distinct script keys that currently
+// hold the asset (or asset group) of the given snapshot. All issued and
+// transferred outputs that aren't spent by another transfer proof in the
+// universe are counted. At most MaxHolderScan leaves are inspected, and false
+// is returned if the scan was cut off before all leaves were seen.
+func (s *Server) estimateHolders(ctx context.Context,
+	snapshot *universe.AssetSyncSnapshot) (uint64, bool, error) {
+
+	var (
+		budget  = s.cfg.MaxHolderScan
+		outputs = make(map[outputKey]struct{})
+		spent   = make(map[outputKey]struct{})
+	)
+
+	// The issuance leaves are all outputs with a non-zero amount, so we
+	// don't need to fetch their proofs.
+	issuanceID := universeID(snapshot, universe.ProofTypeIssuance)
+	complete, err := s.scanLeafKeys(
+		ctx, issuanceID, &budget, func(key universe.LeafKey) error {
+			outputs[newOutputKey(key)] = struct{}{}
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	transferID := universeID(snapshot, universe.ProofTypeTransfer)
+	transfersComplete, err := s.scanLeafKeys(
+		ctx, transferID, &budget, func(key universe.LeafKey) error {
+			leaf, err := s.fetchLeaf(ctx, transferID, key)
+			if err != nil {
+				return err
+			}
+
+			for _, prevID := range spentPrevIDs(leaf.Asset) {
+				spent[outputKey{
+					outPoint:  prevID.OutPoint,
+					scriptKey: prevID.ScriptKey,
+				}] = struct{}{}
+			}
+
+			a := leaf.Asset
+			if a.Amount > 0 && !a.IsBurn() && !a.IsUnSpendable() {
+				outputs[newOutputKey(key)] = struct{}{}
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	holders := make(map[asset.SerializedKey]struct{})
+	for output := range outputs {
+		if _, ok := spent[output]; ok {
+			continue
+		}
+
+		holders[output.scriptKey] = struct{}{}
+	}
+
+	return uint64(len(holders)), complete && transfersComplete, nil
+}
+
+// scanLeafKeys calls the given function for each leaf key of the universe with
+// the given ID, until the budget is used up. True is returned if all leaf keys
+// were scanned.
+func (s *Server) scanLeafKeys(ctx context.Context, id universe.Identifier,
+	budget *uint32, f func(universe.LeafKey) error) (bool, error) {
+
+	var offset int32
+	for {
+		if *budget == 0 {
+			return false, nil
+		}
+
+		limit := int32(min(*budget, leafKeyPageSize))
+		keys, err := s.cfg.Universe.UniverseLeafKeys(
+			ctx, universe.UniverseLeafKeysQuery{
+				Id:     id,
+				Offset: offset,
+				Limit:  limit,
+			},
+		)
+		if err != nil {
+			return false, fmt.Errorf("unable to fetch leaf keys: "+
+				"%w", err)
+		}
+
+		for _, key := range keys {
+			if err := f(key); err != nil {
+				return false, err
+			}
+		}
+
+		*budget -= uint32(len(keys))
+		if len(keys) < int(limit) {
+			return true, nil
+		}
+
+		offset += int32(len(keys))
+	}
+}
+
+// universeID returns the ID of the universe of the given proof type the asset
+// of the given snapshot is tracked in.
+func universeID(snapshot *universe.AssetSyncSnapshot,
+	proofType universe.ProofType) universe.Identifier {
+
+	return universe.Identifier{
+		AssetID:   snapshot.AssetID,
+		GroupKey:  snapshot.GroupKey,
+		ProofType: proofType,
+	}
+}
+
+// newOutputKey returns the output key of the given universe leaf key.
+func newOutputKey(key universe.LeafKey) outputKey {
+	return outputKey{
+		outPoint:  key.OutPoint,
+		scriptKey: asset.ToSerialized(key.ScriptKey.PubKey),
+	}
+}
+
+// spentPrevIDs returns the previous outputs spent by the given asset. For
+// split outputs, these are the inputs of the split root asset.
+func spentPrevIDs(a *asset.Asset) []asset.PrevID {
+	witnesses := a.PrevWitnesses
+	if a.HasSplitCommitmentWitness() {
+		rootAsset := a.PrevWitnesses[0].SplitCommitment.RootAsset
+		witnesses = rootAsset.PrevWitnesses
+	}
+
+	var prevIDs []asset.PrevID
+	for _, witness := range witnesses {
+		if witness.PrevID == nil {
+			continue
+		}
+
+		prevIDs = append(prevIDs, *witness.PrevID)
+	}
+
+	return prevIDs
+}
+
+// newAssetSummary converts a stats snapshot into an asset summary.
+func newAssetSummary(snapshot universe.AssetSyncSnapshot) AssetSummary {
+	summary := AssetSummary{
+		AssetID:       snapshot.AssetID.String(),
+		Name:          snapshot.AssetName,
+		Type:          snapshot.AssetType.String(),
+		TotalSupply:   snapshot.TotalSupply,
+		GenesisPoint:  snapshot.GenesisPoint.String(),
+		GenesisHeight: snapshot.GenesisHeight,
+		NumProofs:     snapshot.TotalProofs,
+	}
+
+	if snapshot.GroupKey != nil {
+		summary.GroupKey = hex.EncodeToString(
+			schnorr.SerializePubKey(snapshot.GroupKey),
+		)
+		summary.GroupSupply = snapshot.GroupSupply
+	}
+
+	return summary
+}
+
+// newAssetEvent converts a universe leaf into an asset event.
+func newAssetEvent(leaf *universe.Leaf) (*AssetEvent, error) {
+	p, err := proof.Decode(leaf.RawProof)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode proof: %w", err)
+	}
+
+	return &AssetEvent{
+		AssetID:     leaf.Asset.ID().String(),
+		OutPoint:    p.OutPoint().String(),
+		Amount:      leaf.Asset.Amount,
+		BlockHeight: p.BlockHeight,
+		BlockTime:   p.BlockHeader.Timestamp.Unix(),
+	}, nil
+}
+
+// parsePage parses the offset and limit query parameters of list endpoints.
+func parsePage(r *http.Request) (int32, int32, error) {
+	query := r.URL.Query()
+
+	var offset, limit int32 = 0, defaultPageLimit
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		val, err := strconv.ParseUint(offsetStr, 10, 31)
+		if err != nil {
+			return 0, 0, badRequest("invalid offset")
+		}
+		offset = int32(val)
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		val, err := strconv.ParseUint(limitStr, 10, 31)
+		if err != nil || val == 0 || val > maxPageLimit {
+			return 0, 0, badRequest("limit must be between 1 and "+
+				"%d", maxPageLimit)
+		}
+		limit = int32(val)
+	}
+
+	return offset, limit, nil
+}
+
+// parseAssetID parses a hex encoded asset ID.
+func parseAssetID(idStr string) (asset.ID, error) {
+	var assetID asset.ID
+
+	idBytes, err := hex.DecodeString(idStr)
+	if err != nil || len(idBytes) != len(assetID) {
+		return assetID, badRequest("invalid asset ID")
+	}
+	copy(assetID[:], idBytes)
+
+	return assetID, nil
+}
+
+// writeHeaders writes the headers that are common to all responses.
+func writeHeaders(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+}
+
+// writeError writes an error response with the given message.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeHeaders(w, status)
+
+	err := json.NewEncoder(w).Encode(&ErrorResponse{
+		Error: msg,
+	})
+	if err != nil {
+		log.Errorf("Unable to write response: %v", err)
+	}
+}
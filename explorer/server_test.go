@@ -0,0 +1,296 @@
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUniverse is a mock implementation of the UniverseReader interface that
+// serves the leaves of a single asset.
+type mockUniverse struct {
+	leaves map[universe.ProofType][]*universe.Leaf
+	keys   map[universe.ProofType][]universe.LeafKey
+}
+
+// addLeaf adds a leaf with the given key to the universe of the given type.
+func (m *mockUniverse) addLeaf(proofType universe.ProofType,
+	key universe.LeafKey, leaf *universe.Leaf) {
+
+	m.keys[proofType] = append(m.keys[proofType], key)
+	m.leaves[proofType] = append(m.leaves[proofType], leaf)
+}
+
+// RootNode returns a root node that sums up the leaves of the universe.
+func (m *mockUniverse) RootNode(_ context.Context,
+	id universe.Identifier) (universe.Root, error) {
+
+	if len(m.keys[id.ProofType]) == 0 {
+		return universe.Root{}, universe.ErrNoUniverseRoot
+	}
+
+	return universe.Root{
+		ID: id,
+		Node: mssmt.NewLeafNode(
+			nil, uint64(len(m.keys[id.ProofType])),
+		),
+	}, nil
+}
+
+// UniverseLeafKeys returns a page of the leaf keys of the universe.
+func (m *mockUniverse) UniverseLeafKeys(_ context.Context,
+	q universe.UniverseLeafKeysQuery) ([]universe.LeafKey, error) {
+
+	keys := m.keys[q.Id.ProofType]
+	start := min(int(q.Offset), len(keys))
+	end := min(start+int(q.Limit), len(keys))
+
+	return keys[start:end], nil
+}
+
+// FetchProofLeaf returns the leaf with the given key.
+func (m *mockUniverse) FetchProofLeaf(_ context.Context, id universe.Identifier,
+	key universe.LeafKey) ([]*universe.Proof, error) {
+
+	for idx, k := range m.keys[id.ProofType] {
+		if k.UniverseKey() != key.UniverseKey() {
+			continue
+		}
+
+		return []*universe.Proof{{
+			Leaf: m.leaves[id.ProofType][idx],
+		}}, nil
+	}
+
+	return nil, universe.ErrNoUniverseProofFound
+}
+
+// mockStats is a mock implementation of the StatsReader interface that knows
+// about a single asset.
+type mockStats struct {
+	snapshot universe.AssetSyncSnapshot
+	queries  int
+}
+
+// AggregateSyncStats returns fixed aggregate stats.
+func (m *mockStats) AggregateSyncStats(
+	context.Context) (universe.AggregateStats, error) {
+
+	m.queries++
+
+	return universe.AggregateStats{
+		NumTotalAssets: 1,
+		NumTotalProofs: 4,
+		NumTotalSyncs:  1_000,
+	}, nil
+}
+
+// QuerySyncStats returns the snapshot of the known asset if it matches the
+// query.
+func (m *mockStats) QuerySyncStats(_ context.Context,
+	q universe.SyncStatsQuery) (*universe.AssetSyncStats, error) {
+
+	m.queries++
+
+	stats := &universe.AssetSyncStats{
+		Query: q,
+	}
+	var emptyID asset.ID
+	if q.AssetIDFilter == emptyID || q.AssetIDFilter == m.snapshot.AssetID {
+		stats.SyncStats = append(stats.SyncStats, m.snapshot)
+	}
+
+	return stats, nil
+}
+
+// testBlock returns a block with a single transaction with two outputs.
+func testBlock(t *testing.T) wire.MsgBlock {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: test.RandOp(t),
+	})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+
+	return wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Timestamp: time.Unix(1_700_000_000, 0),
+		},
+		Transactions: []*wire.MsgTx{tx},
+	}
+}
+
+// TestServer tests the endpoints of the explorer API.
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	genesis := asset.RandGenesis(t, asset.Normal)
+	assetID := genesis.ID()
+
+	// The asset was issued to two holders A and B. A then transferred
+	// their output to C, so B and C are the current holders.
+	var (
+		keyA = asset.RandScriptKey(t)
+		keyB = asset.RandScriptKey(t)
+		keyC = asset.RandScriptKey(t)
+		opA  = test.RandOp(t)
+		opB  = test.RandOp(t)
+	)
+
+	block := testBlock(t)
+	issuanceProof := proof.RandProof(t, genesis, keyA.PubKey, block, 0, 1)
+	rawProof, err := proof.Encode(&issuanceProof)
+	require.NoError(t, err)
+
+	uni := &mockUniverse{
+		leaves: make(map[universe.ProofType][]*universe.Leaf),
+		keys:   make(map[universe.ProofType][]universe.LeafKey),
+	}
+	issuedAsset := func(key asset.ScriptKey) *universe.Leaf {
+		return &universe.Leaf{
+			RawProof: rawProof,
+			Asset: asset.NewAssetNoErr(
+				t, genesis, 50, 0, 0, key, nil,
+			),
+			Amt: 50,
+		}
+	}
+	uni.addLeaf(universe.ProofTypeIssuance, universe.LeafKey{
+		OutPoint:  opA,
+		ScriptKey: &keyA,
+	}, issuedAsset(keyA))
+	uni.addLeaf(universe.ProofTypeIssuance, universe.LeafKey{
+		OutPoint:  opB,
+		ScriptKey: &keyB,
+	}, issuedAsset(keyB))
+
+	transferred := asset.NewAssetNoErr(t, genesis, 50, 0, 0, keyC, nil)
+	transferred.PrevWitnesses = []asset.Witness{{
+		PrevID: &asset.PrevID{
+			OutPoint:  opA,
+			ID:        assetID,
+			ScriptKey: asset.ToSerialized(keyA.PubKey),
+		},
+	}}
+	uni.addLeaf(universe.ProofTypeTransfer, universe.LeafKey{
+		OutPoint:  test.RandOp(t),
+		ScriptKey: &keyC,
+	}, &universe.Leaf{
+		RawProof: rawProof,
+		Asset:    transferred,
+		Amt:      50,
+	})
+
+	stats := &mockStats{
+		snapshot: universe.AssetSyncSnapshot{
+			AssetID:       assetID,
+			GenesisPoint:  genesis.FirstPrevOut,
+			AssetName:     genesis.Tag,
+			AssetType:     asset.Normal,
+			TotalSupply:   100,
+			GenesisHeight: 42,
+			TotalProofs:   3,
+		},
+	}
+
+	cliCfg := DefaultCliConfig()
+	cliCfg.Active = true
+	cliCfg.RequestRate = 0.01
+	cliCfg.RequestBurst = 7
+	require.NoError(t, cliCfg.Validate())
+
+	s := NewServer(&Config{
+		CliConfig: cliCfg,
+		Universe:  uni,
+		Stats:     stats,
+	})
+
+	httpServer := httptest.NewServer(s.httpServer.Handler)
+	t.Cleanup(httpServer.Close)
+
+	get := func(path string, resp any) int {
+		httpResp, err := http.Get(httpServer.URL + path)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+
+		require.NoError(t, json.NewDecoder(httpResp.Body).Decode(resp))
+
+		if httpResp.StatusCode == http.StatusOK {
+			require.Equal(
+				t, "public, max-age=60",
+				httpResp.Header.Get("Cache-Control"),
+			)
+		}
+
+		return httpResp.StatusCode
+	}
+
+	// The aggregate stats don't expose the sync counts.
+	var statsResp map[string]any
+	require.Equal(t, http.StatusOK, get(APIPrefix+"/stats", &statsResp))
+	require.Equal(t, map[string]any{
+		"num_assets": float64(1),
+		"num_groups": float64(0),
+		"num_proofs": float64(4),
+	}, statsResp)
+
+	// The asset page counts the transfer and estimates the holders.
+	assetPath := APIPrefix + "/assets/" + assetID.String()
+	var assetResp AssetResponse
+	require.Equal(t, http.StatusOK, get(assetPath, &assetResp))
+	require.Equal(t, assetID.String(), assetResp.AssetID)
+	require.EqualValues(t, 100, assetResp.TotalSupply)
+	require.EqualValues(t, 1, assetResp.NumTransfers)
+	require.EqualValues(t, 2, assetResp.HolderCountEstimate)
+	require.True(t, assetResp.HolderCountComplete)
+
+	// A repeated request is served from the cache.
+	numQueries := stats.queries
+	require.Equal(t, http.StatusOK, get(assetPath, &assetResp))
+	require.Equal(t, numQueries, stats.queries)
+
+	// The issuance history is decoded from the proofs.
+	var eventsResp EventListResponse
+	require.Equal(
+		t, http.StatusOK, get(assetPath+"/issuances", &eventsResp),
+	)
+	require.Len(t, eventsResp.Events, 2)
+	require.Equal(t, AssetEvent{
+		AssetID:     assetID.String(),
+		OutPoint:    issuanceProof.OutPoint().String(),
+		Amount:      50,
+		BlockHeight: 42,
+		BlockTime:   1_700_000_000,
+	}, eventsResp.Events[0])
+
+	// Invalid requests are rejected.
+	var errResp ErrorResponse
+	require.Equal(
+		t, http.StatusBadRequest,
+		get(assetPath+"/transfers?limit=1000", &errResp),
+	)
+	require.Equal(
+		t, http.StatusBadRequest,
+		get(APIPrefix+"/assets/1234", &errResp),
+	)
+
+	unknownPath := APIPrefix + "/assets/" + asset.RandID(t).String()
+	require.Equal(t, http.StatusNotFound, get(unknownPath, &errResp))
+
+	// The burst budget is used up by now, so the next request is rate
+	// limited.
+	require.Equal(
+		t, http.StatusTooManyRequests, get(APIPrefix+"/assets", &errResp),
+	)
+}
@@ -0,0 +1,54 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+var (
+	// ErrNotFrozen is returned when a script key that isn't frozen is
+	// looked up or unfrozen.
+	ErrNotFrozen = errors.New("script key not frozen")
+
+	// ErrFrozen is returned when the issuer is asked to co-sign the
+	// transfer of an asset with a frozen script key.
+	ErrFrozen = errors.New("script key is frozen")
+)
+
+// Freeze records that the issuer refuses to co-sign transfers of the assets
+// locked to an issuer policy script key.
+type Freeze struct {
+	// ScriptKey is the tweaked issuer policy script key that is frozen.
+	ScriptKey *btcec.PublicKey
+
+	// Reason is the optional human-readable reason of the freeze.
+	Reason string
+
+	// FrozenAt is the time the script key was frozen at.
+	FrozenAt time.Time
+}
+
+// Store is the interface of the persistent storage of frozen script keys.
+type Store interface {
+	// FreezeScriptKey freezes the given script key. Freezing a script key
+	// that is already frozen updates the reason of the freeze.
+	FreezeScriptKey(ctx context.Context, scriptKey *btcec.PublicKey,
+		reason string) (*Freeze, error)
+
+	// UnfreezeScriptKey unfreezes the given script key. ErrNotFrozen is
+	// returned if the script key isn't frozen.
+	UnfreezeScriptKey(ctx context.Context,
+		scriptKey *btcec.PublicKey) error
+
+	// FetchFreeze returns the freeze of the given script key. ErrNotFrozen
+	// is returned if the script key isn't frozen.
+	FetchFreeze(ctx context.Context,
+		scriptKey *btcec.PublicKey) (*Freeze, error)
+
+	// ListFreezes returns all frozen script keys, ordered by the time they
+	// were frozen at.
+	ListFreezes(ctx context.Context) ([]Freeze, error)
+}
@@ -79,6 +79,19 @@ func testChannelRPCs(t *harnessTest) {
 	_, err = stream.Recv()
 	require.ErrorContains(t.t, err, "no asset channel balance found")
 
+	// The simplified invoice payment RPC goes through the same path and
+	// should fail for the same reason.
+	payStream, err := t.tapd.PayAssetInvoice(
+		ctx, &tchrpc.PayAssetInvoiceRequest{
+			AssetId:        dummyByteArr[:],
+			PaymentRequest: invoiceResp.PaymentRequest,
+		},
+	)
+	require.NoError(t.t, err)
+
+	_, err = payStream.Recv()
+	require.ErrorContains(t.t, err, "no asset channel balance found")
+
 	// We can't add an invoice either, because we have no peers to do RFQ
 	// negotiation with.
 	_, err = t.tapd.AddInvoice(ctx, &tchrpc.AddInvoiceRequest{
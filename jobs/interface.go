@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrJobNotFound is returned if a job with the given ID doesn't exist.
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrUnknownKind is returned if a job of a kind is submitted that no
+	// handler is registered for.
+	ErrUnknownKind = errors.New("unknown job kind")
+
+	// ErrJobFinished is returned if a job that already finished is
+	// cancelled.
+	ErrJobFinished = errors.New("job already finished")
+)
+
+// ID is the unique ID of a job.
+type ID uint64
+
+// Kind is the kind of operation a job runs. Each kind has a handler that is
+// registered with the manager.
+type Kind string
+
+const (
+	// KindFederationSync is the kind of job that fully syncs the local
+	// universe with all servers of the universe federation.
+	KindFederationSync Kind = "federation_sync"
+
+	// KindAssetRecovery is the kind of job that recovers the assets of a
+	// wallet that was restored from its seed by scanning a universe for
+	// proofs paying the keys of the wallet.
+	KindAssetRecovery Kind = "asset_recovery"
+)
+
+// State is the state of a job.
+type State uint8
+
+const (
+	// StatePending is the state of a job that was submitted but didn't
+	// start running yet.
+	StatePending State = 0
+
+	// StateRunning is the state of a job that is currently running. Jobs
+	// that are in this state when the daemon shuts down are resumed on the
+	// next start.
+	StateRunning State = 1
+
+	// StateCompleted is the state of a job that finished successfully.
+	StateCompleted State = 2
+
+	// StateFailed is the state of a job that finished with an error.
+	StateFailed State = 3
+
+	// StateCancelled is the state of a job that was cancelled by the user.
+	StateCancelled State = 4
+)
+
+// IsFinal returns true if the state is one of the final states a job can't
+// leave anymore.
+func (s State) IsFinal() bool {
+	return s >= StateCompleted
+}
+
+// String returns the human-readable name of the state.
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+
+	case StateRunning:
+		return "running"
+
+	case StateCompleted:
+		return "completed"
+
+	case StateFailed:
+		return "failed"
+
+	case StateCancelled:
+		return "cancelled"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// Job is a long-running operation that is tracked by the manager.
+type Job struct {
+	// ID is the unique ID of the job.
+	ID ID
+
+	// Kind is the kind of operation the job runs.
+	Kind Kind
+
+	// Params are the opaque, kind specific parameters of the job. They are
+	// handed to the job's handler each time the job is (re-)started.
+	Params []byte
+
+	// State is the current state of the job.
+	State State
+
+	// Progress is the progress of the job in percent, between 0 and 100.
+	Progress uint8
+
+	// ErrorMsg is the error the job failed with, if it is in the failed
+	// state.
+	ErrorMsg string
+
+	// CreatedAt is the time the job was submitted.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time the state or progress of the job was last
+	// updated.
+	UpdatedAt time.Time
+}
+
+// ProgressFunc is used by a job handler to report the progress of the job in
+// percent. Values above 100 are capped.
+type ProgressFunc func(percent uint8)
+
+// Handler runs a job of a specific kind with the given parameters. The handler
+// must return as soon as possible once the context is cancelled. Since jobs
+// are restarted from the beginning after a restart of the daemon, handlers
+// should be idempotent.
+type Handler func(ctx context.Context, params []byte,
+	report ProgressFunc) error
+
+// Store is the persistent storage for jobs.
+type Store interface {
+	// InsertJob stores a new job and returns its ID.
+	InsertJob(ctx context.Context, job *Job) (ID, error)
+
+	// UpdateJob updates the state, progress, error message and update
+	// time of an existing job.
+	UpdateJob(ctx context.Context, job *Job) error
+
+	// FetchJob returns the job with the given ID. ErrJobNotFound is
+	// returned if no such job exists.
+	FetchJob(ctx context.Context, id ID) (*Job, error)
+
+	// QueryJobs returns all jobs ordered by their ID. If activeOnly is set,
+	// only jobs that aren't in a final state are returned.
+	QueryJobs(ctx context.Context, activeOnly bool) ([]*Job, error)
+}
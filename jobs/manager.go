@@ -0,0 +1,398 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// defaultStoreTimeout is the default timeout used for database
+	// operations of the manager.
+	defaultStoreTimeout = 30 * time.Second
+
+	// maxProgress is the maximum progress of a job in percent.
+	maxProgress = 100
+)
+
+// ManagerConfig is the configuration of the job manager.
+type ManagerConfig struct {
+	// Store is the persistent storage for jobs.
+	Store Store
+
+	// Clock is the clock used to timestamp job updates.
+	Clock clock.Clock
+}
+
+// runningJob is a job that is currently executed by the manager.
+type runningJob struct {
+	// job is the latest state of the job.
+	job Job
+
+	// cancel cancels the context the job's handler is executed with.
+	cancel context.CancelFunc
+
+	// cancelRequested is set if the job was cancelled by the user, as
+	// opposed to being interrupted by a shutdown.
+	cancelRequested bool
+}
+
+// Manager runs long-running operations as jobs. Each job is persisted, so its
+// state and progress can be queried, and jobs that were interrupted by a
+// shutdown are resumed on the next start.
+type Manager struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg ManagerConfig
+
+	// handlers maps each registered job kind to its handler.
+	handlers map[Kind]Handler
+
+	// running contains all jobs that are currently being executed, keyed
+	// by their ID.
+	running map[ID]*runningJob
+
+	// runningMtx guards the running map and the jobs within.
+	runningMtx sync.Mutex
+
+	// subscribers is the set of subscribers that are notified about all
+	// job updates.
+	subscribers *fn.EventDistributor[Job]
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewManager creates a new job manager.
+func NewManager(cfg ManagerConfig) *Manager {
+	return &Manager{
+		cfg:         cfg,
+		handlers:    make(map[Kind]Handler),
+		running:     make(map[ID]*runningJob),
+		subscribers: fn.NewEventDistributor[Job](),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: defaultStoreTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// RegisterHandler registers the handler for the given job kind.
+//
+// NOTE: All handlers must be registered before the manager is started, so
+// that interrupted jobs can be resumed.
+func (m *Manager) RegisterHandler(kind Kind, handler Handler) {
+	m.handlers[kind] = handler
+}
+
+// Start starts the job manager and resumes all jobs that were interrupted by
+// the last shutdown.
+func (m *Manager) Start() error {
+	var startErr error
+	m.startOnce.Do(func() {
+		log.Info("Starting job manager")
+
+		startErr = m.resumeJobs()
+	})
+
+	return startErr
+}
+
+// Stop stops the job manager. All running jobs are interrupted and will be
+// resumed on the next start.
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		log.Info("Stopping job manager")
+
+		close(m.Quit)
+		m.Wg.Wait()
+	})
+
+	return nil
+}
+
+// resumeJobs restarts all jobs that didn't finish before the last shutdown.
+// Jobs of a kind that no handler is registered for anymore are marked as
+// failed.
+func (m *Manager) resumeJobs() error {
+	ctx, cancel := m.WithCtxQuit()
+	defer cancel()
+
+	activeJobs, err := m.cfg.Store.QueryJobs(ctx, true)
+	if err != nil {
+		return fmt.Errorf("unable to query active jobs: %w", err)
+	}
+
+	for _, job := range activeJobs {
+		if _, ok := m.handlers[job.Kind]; !ok {
+			log.Warnf("Unable to resume job %d, no handler for "+
+				"kind %v", job.ID, job.Kind)
+
+			job.State = StateFailed
+			job.ErrorMsg = fmt.Sprintf("%v: %v", ErrUnknownKind,
+				job.Kind)
+			job.UpdatedAt = m.cfg.Clock.Now().UTC()
+			if err := m.cfg.Store.UpdateJob(ctx, job); err != nil {
+				return fmt.Errorf("unable to update job %d: %w",
+					job.ID, err)
+			}
+
+			continue
+		}
+
+		log.Infof("Resuming job %d of kind %v (progress=%d%%)",
+			job.ID, job.Kind, job.Progress)
+
+		m.launch(*job)
+	}
+
+	return nil
+}
+
+// Submit persists a new job of the given kind and starts running it.
+func (m *Manager) Submit(ctx context.Context, kind Kind,
+	params []byte) (*Job, error) {
+
+	if _, ok := m.handlers[kind]; !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownKind, kind)
+	}
+
+	now := m.cfg.Clock.Now().UTC()
+	job := Job{
+		Kind:      kind,
+		Params:    params,
+		State:     StatePending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	id, err := m.cfg.Store.InsertJob(ctx, &job)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store job: %w", err)
+	}
+	job.ID = id
+
+	log.Infof("Submitted job %d of kind %v", job.ID, job.Kind)
+
+	m.launch(job)
+
+	return &job, nil
+}
+
+// Cancel cancels the job with the given ID. The job's handler is notified
+// through its context and the job ends up in the cancelled state once the
+// handler returns.
+func (m *Manager) Cancel(ctx context.Context, id ID) error {
+	m.runningMtx.Lock()
+	rj, ok := m.running[id]
+	if ok {
+		rj.cancelRequested = true
+		rj.cancel()
+	}
+	m.runningMtx.Unlock()
+
+	if ok {
+		log.Infof("Cancelling job %d", id)
+		return nil
+	}
+
+	job, err := m.cfg.Store.FetchJob(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if job.State.IsFinal() {
+		return fmt.Errorf("%w: job %d is %v", ErrJobFinished, id,
+			job.State)
+	}
+
+	// The job isn't final but also isn't running, which means it was never
+	// resumed. We can directly mark it as cancelled.
+	job.State = StateCancelled
+	job.UpdatedAt = m.cfg.Clock.Now().UTC()
+
+	return m.cfg.Store.UpdateJob(ctx, job)
+}
+
+// FetchJob returns the current state of the job with the given ID.
+func (m *Manager) FetchJob(ctx context.Context, id ID) (*Job, error) {
+	m.runningMtx.Lock()
+	rj, ok := m.running[id]
+	if ok {
+		job := rj.job
+		m.runningMtx.Unlock()
+
+		return &job, nil
+	}
+	m.runningMtx.Unlock()
+
+	return m.cfg.Store.FetchJob(ctx, id)
+}
+
+// ListJobs returns all jobs ordered by their ID. If activeOnly is set, only
+// jobs that aren't in a final state are returned.
+func (m *Manager) ListJobs(ctx context.Context, activeOnly bool) ([]*Job,
+	error) {
+
+	return m.cfg.Store.QueryJobs(ctx, activeOnly)
+}
+
+// RegisterSubscriber adds a new subscriber that is notified about each update
+// of any job. If deliverExisting is set, the current state of all running jobs
+// is delivered to the subscriber right away.
+func (m *Manager) RegisterSubscriber(receiver *fn.EventReceiver[Job],
+	deliverExisting bool, _ bool) error {
+
+	m.subscribers.RegisterSubscriber(receiver)
+
+	if !deliverExisting {
+		return nil
+	}
+
+	m.runningMtx.Lock()
+	defer m.runningMtx.Unlock()
+
+	for _, rj := range m.running {
+		receiver.NewItemCreated.ChanIn() <- rj.job
+	}
+
+	return nil
+}
+
+// RemoveSubscriber removes the given subscriber and also stops it from
+// processing events.
+func (m *Manager) RemoveSubscriber(subscriber *fn.EventReceiver[Job]) error {
+	return m.subscribers.RemoveSubscriber(subscriber)
+}
+
+// launch starts executing the given job in a new goroutine.
+func (m *Manager) launch(job Job) {
+	ctx, cancel := m.WithCtxQuitNoTimeout()
+
+	m.runningMtx.Lock()
+	m.running[job.ID] = &runningJob{
+		job:    job,
+		cancel: cancel,
+	}
+	m.runningMtx.Unlock()
+
+	m.Wg.Add(1)
+	go m.runJob(ctx, cancel, job)
+}
+
+// runJob executes the handler of the given job and records its result.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *Manager) runJob(ctx context.Context, cancel context.CancelFunc,
+	job Job) {
+
+	defer m.Wg.Done()
+	defer cancel()
+
+	m.updateJob(job.ID, func(j *Job) bool {
+		j.State = StateRunning
+		j.Progress = 0
+		j.ErrorMsg = ""
+
+		return true
+	})
+
+	report := func(percent uint8) {
+		percent = min(percent, maxProgress)
+
+		m.updateJob(job.ID, func(j *Job) bool {
+			if j.Progress == percent {
+				return false
+			}
+
+			j.Progress = percent
+
+			return true
+		})
+	}
+
+	handler := m.handlers[job.Kind]
+	err := handler(ctx, job.Params, report)
+
+	m.runningMtx.Lock()
+	cancelRequested := m.running[job.ID].cancelRequested
+	m.runningMtx.Unlock()
+
+	// If the job was interrupted by a shutdown, we leave it in the
+	// running state, so it is resumed on the next start.
+	if err != nil && !cancelRequested {
+		select {
+		case <-m.Quit:
+			log.Infof("Job %d interrupted by shutdown", job.ID)
+
+			m.removeRunning(job.ID)
+			return
+
+		default:
+		}
+	}
+
+	m.updateJob(job.ID, func(j *Job) bool {
+		switch {
+		case err == nil:
+			j.State = StateCompleted
+			j.Progress = maxProgress
+
+		case cancelRequested:
+			j.State = StateCancelled
+
+		default:
+			j.State = StateFailed
+			j.ErrorMsg = err.Error()
+		}
+
+		return true
+	})
+
+	if err != nil && !cancelRequested {
+		log.Errorf("Job %d of kind %v failed: %v", job.ID, job.Kind,
+			err)
+	} else {
+		log.Infof("Job %d of kind %v finished", job.ID, job.Kind)
+	}
+
+	m.removeRunning(job.ID)
+}
+
+// updateJob applies the given update to a running job, persists it and
+// notifies all subscribers. If the update function returns false, nothing was
+// changed and the update is skipped.
+func (m *Manager) updateJob(id ID, update func(j *Job) bool) {
+	m.runningMtx.Lock()
+	rj, ok := m.running[id]
+	if !ok || !update(&rj.job) {
+		m.runningMtx.Unlock()
+		return
+	}
+
+	rj.job.UpdatedAt = m.cfg.Clock.Now().UTC()
+	job := rj.job
+	m.runningMtx.Unlock()
+
+	ctx, cancel := m.WithCtxQuit()
+	defer cancel()
+
+	if err := m.cfg.Store.UpdateJob(ctx, &job); err != nil {
+		log.Errorf("Unable to persist update of job %d: %v", id, err)
+	}
+
+	m.subscribers.NotifySubscribers(job)
+}
+
+// removeRunning removes the given job from the set of running jobs.
+func (m *Manager) removeRunning(id ID) {
+	m.runningMtx.Lock()
+	delete(m.running, id)
+	m.runningMtx.Unlock()
+}
@@ -0,0 +1,225 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKind Kind = "test"
+
+	testTimeout = 5 * time.Second
+)
+
+// newTestManager creates a new started job manager with the given store and
+// handler for the test job kind.
+func newTestManager(t *testing.T, store Store, handler Handler) *Manager {
+	t.Helper()
+
+	m := NewManager(ManagerConfig{
+		Store: store,
+		Clock: clock.NewTestClock(time.Unix(1_000_000, 0)),
+	})
+	if handler != nil {
+		m.RegisterHandler(testKind, handler)
+	}
+
+	require.NoError(t, m.Start())
+	t.Cleanup(func() {
+		require.NoError(t, m.Stop())
+	})
+
+	return m
+}
+
+// waitForState waits until the job with the given ID reaches the given state.
+func waitForState(t *testing.T, m *Manager, id ID, state State) *Job {
+	t.Helper()
+
+	var job *Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = m.FetchJob(context.Background(), id)
+		require.NoError(t, err)
+
+		return job.State == state
+	}, testTimeout, 10*time.Millisecond)
+
+	return job
+}
+
+// TestManagerJobLifecycle tests that a job reports its progress to subscribers
+// and ends up in the expected final state.
+func TestManagerJobLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	handlerErr := errors.New("boom")
+	m := newTestManager(
+		t, NewMockStore(),
+		func(_ context.Context, params []byte,
+			report ProgressFunc) error {
+
+			report(50)
+			report(50)
+			report(150)
+
+			if string(params) == "fail" {
+				return handlerErr
+			}
+
+			return nil
+		},
+	)
+
+	receiver := fn.NewEventReceiver[Job](fn.DefaultQueueSize)
+	require.NoError(t, m.RegisterSubscriber(receiver, false, false))
+	defer func() {
+		require.NoError(t, m.RemoveSubscriber(receiver))
+	}()
+
+	job, err := m.Submit(ctx, testKind, []byte("ok"))
+	require.NoError(t, err)
+
+	// Duplicate progress reports are skipped and progress above 100% is
+	// capped.
+	var progress []uint8
+	for {
+		select {
+		case update := <-receiver.NewItemCreated.ChanOut():
+			require.Equal(t, job.ID, update.ID)
+			progress = append(progress, update.Progress)
+
+		case <-time.After(testTimeout):
+			t.Fatalf("no job update received")
+		}
+
+		if len(progress) == 4 {
+			break
+		}
+	}
+	require.Equal(t, []uint8{0, 50, 100, 100}, progress)
+
+	completed := waitForState(t, m, job.ID, StateCompleted)
+	require.EqualValues(t, maxProgress, completed.Progress)
+
+	failedJob, err := m.Submit(ctx, testKind, []byte("fail"))
+	require.NoError(t, err)
+
+	failed := waitForState(t, m, failedJob.ID, StateFailed)
+	require.Equal(t, handlerErr.Error(), failed.ErrorMsg)
+
+	_, err = m.Submit(ctx, "unknown", nil)
+	require.ErrorIs(t, err, ErrUnknownKind)
+
+	err = m.Cancel(ctx, job.ID)
+	require.ErrorIs(t, err, ErrJobFinished)
+
+	jobs, err := m.ListJobs(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	jobs, err = m.ListJobs(ctx, true)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
+
+// TestManagerCancel tests that a running job can be cancelled.
+func TestManagerCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := newTestManager(
+		t, NewMockStore(),
+		func(ctx context.Context, _ []byte, _ ProgressFunc) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	job, err := m.Submit(ctx, testKind, nil)
+	require.NoError(t, err)
+
+	waitForState(t, m, job.ID, StateRunning)
+	require.NoError(t, m.Cancel(ctx, job.ID))
+
+	cancelled := waitForState(t, m, job.ID, StateCancelled)
+	require.Empty(t, cancelled.ErrorMsg)
+}
+
+// TestManagerResume tests that jobs interrupted by a shutdown are resumed on
+// the next start, and that jobs without a handler are marked as failed.
+func TestManagerResume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMockStore()
+
+	m := NewManager(ManagerConfig{
+		Store: store,
+		Clock: clock.NewDefaultClock(),
+	})
+	m.RegisterHandler(testKind, func(ctx context.Context, _ []byte,
+		report ProgressFunc) error {
+
+		report(30)
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	require.NoError(t, m.Start())
+
+	job, err := m.Submit(ctx, testKind, []byte("params"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		stored, err := store.FetchJob(ctx, job.ID)
+		require.NoError(t, err)
+
+		return stored.Progress == 30
+	}, testTimeout, 10*time.Millisecond)
+
+	require.NoError(t, m.Stop())
+
+	// The job must still be running after the shutdown.
+	stored, err := store.FetchJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, StateRunning, stored.State)
+
+	// A manager without a handler for the kind marks it as failed, so
+	// we'll use a copy of the store for that.
+	storeCopy := NewMockStore()
+	_, err = storeCopy.InsertJob(ctx, stored)
+	require.NoError(t, err)
+
+	newTestManager(t, storeCopy, nil)
+	failed, err := storeCopy.FetchJob(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, StateFailed, failed.State)
+	require.Contains(t, failed.ErrorMsg, ErrUnknownKind.Error())
+
+	// A manager with the handler resumes the job with the same params.
+	resumed := make(chan []byte, 1)
+	m2 := newTestManager(t, store, func(_ context.Context, params []byte,
+		_ ProgressFunc) error {
+
+		resumed <- params
+		return nil
+	})
+
+	select {
+	case params := <-resumed:
+		require.Equal(t, []byte("params"), params)
+
+	case <-time.After(testTimeout):
+		t.Fatalf("job not resumed")
+	}
+
+	waitForState(t, m2, job.ID, StateCompleted)
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+)
+
+// MockStore is an in-memory implementation of the job store.
+type MockStore struct {
+	mtx    sync.Mutex
+	jobs   map[ID]Job
+	nextID ID
+}
+
+// NewMockStore creates a new in-memory job store.
+func NewMockStore() *MockStore {
+	return &MockStore{
+		jobs:   make(map[ID]Job),
+		nextID: 1,
+	}
+}
+
+// InsertJob stores a new job and returns its ID.
+func (s *MockStore) InsertJob(_ context.Context, job *Job) (ID, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	id := s.nextID
+	s.nextID++
+
+	stored := *job
+	stored.ID = id
+	s.jobs[id] = stored
+
+	return id, nil
+}
+
+// UpdateJob updates the state, progress, error message and update time of an
+// existing job.
+func (s *MockStore) UpdateJob(_ context.Context, job *Job) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stored, ok := s.jobs[job.ID]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	stored.State = job.State
+	stored.Progress = job.Progress
+	stored.ErrorMsg = job.ErrorMsg
+	stored.UpdatedAt = job.UpdatedAt
+	s.jobs[job.ID] = stored
+
+	return nil
+}
+
+// FetchJob returns the job with the given ID.
+func (s *MockStore) FetchJob(_ context.Context, id ID) (*Job, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stored, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	return &stored, nil
+}
+
+// QueryJobs returns all jobs ordered by their ID.
+func (s *MockStore) QueryJobs(_ context.Context, activeOnly bool) ([]*Job,
+	error) {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var result []*Job
+	for id := ID(1); id < s.nextID; id++ {
+		stored, ok := s.jobs[id]
+		if !ok || (activeOnly && stored.State.IsFinal()) {
+			continue
+		}
+
+		result = append(result, &stored)
+	}
+
+	return result, nil
+}
+
+// A compile-time assertion to ensure MockStore meets the Store interface.
+var _ Store = (*MockStore)(nil)
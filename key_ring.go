@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightningnetwork/lnd/keychain"
 )
@@ -106,6 +108,27 @@ func (l *LndRpcKeyRing) IsLocalKey(ctx context.Context,
 	return derived.PubKey.IsEqual(desc.PubKey)
 }
 
+// SignMailboxMsg returns a Schnorr signature over the SHA-256 hash of the
+// given message, created with the private key of the given key descriptor.
+func (l *LndRpcKeyRing) SignMailboxMsg(ctx context.Context,
+	keyDesc keychain.KeyDescriptor, msg []byte) (*schnorr.Signature,
+	error) {
+
+	sigBytes, err := l.lnd.Signer.SignMessage(
+		ctx, msg, keyDesc.KeyLocator, lndclient.SignSchnorr(nil),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign mailbox message: %w",
+			err)
+	}
+
+	return schnorr.ParseSignature(sigBytes)
+}
+
 // A compile time assertion to ensure LndRpcKeyRing meets the
 // tapgarden.KeyRing interface.
 var _ tapgarden.KeyRing = (*LndRpcKeyRing)(nil)
+
+// A compile time assertion to ensure LndRpcKeyRing meets the
+// proof.MailboxSigner interface.
+var _ proof.MailboxSigner = (*LndRpcKeyRing)(nil)
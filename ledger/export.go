@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Format is the serialization format of an exported ledger.
+type Format uint8
+
+const (
+	// FormatCSV encodes the ledger as CSV with a header row.
+	FormatCSV Format = 0
+
+	// FormatJSON encodes the ledger as a JSON array of entries.
+	FormatJSON Format = 1
+)
+
+// csvHeader is the header row of a ledger exported as CSV. The columns match
+// the fields of jsonEntry.
+var csvHeader = []string{
+	"timestamp", "type", "asset_id", "amount", "anchor_outpoint",
+	"asset_rate", "value_msat", "valuation_timestamp",
+}
+
+// jsonEntry is the JSON representation of a ledger entry.
+type jsonEntry struct {
+	Timestamp          string `json:"timestamp"`
+	Type               string `json:"type"`
+	AssetID            string `json:"asset_id"`
+	Amount             uint64 `json:"amount"`
+	AnchorOutpoint     string `json:"anchor_outpoint"`
+	AssetRate          string `json:"asset_rate,omitempty"`
+	ValueMsat          uint64 `json:"value_msat,omitempty"`
+	ValuationTimestamp string `json:"valuation_timestamp,omitempty"`
+}
+
+// newJSONEntry converts a ledger entry into its serializable form. All
+// timestamps are encoded as RFC 3339 in UTC.
+func newJSONEntry(e *Entry) jsonEntry {
+	entry := jsonEntry{
+		Timestamp:      e.Timestamp.UTC().Format(time.RFC3339),
+		Type:           e.Type.String(),
+		AssetID:        e.AssetID.String(),
+		Amount:         e.Amount,
+		AnchorOutpoint: e.AnchorOutpoint.String(),
+	}
+
+	e.Valuation.WhenSome(func(v Valuation) {
+		entry.AssetRate = v.AssetRate.String()
+		entry.ValueMsat = uint64(v.Value)
+		entry.ValuationTimestamp = v.Time.UTC().Format(time.RFC3339)
+	})
+
+	return entry
+}
+
+// Encode writes the given ledger entries to the writer in the given format.
+func Encode(w io.Writer, entries []*Entry, format Format) error {
+	switch format {
+	case FormatCSV:
+		return encodeCSV(w, entries)
+
+	case FormatJSON:
+		jsonEntries := make([]jsonEntry, len(entries))
+		for idx := range entries {
+			jsonEntries[idx] = newJSONEntry(entries[idx])
+		}
+
+		return json.NewEncoder(w).Encode(jsonEntries)
+
+	default:
+		return fmt.Errorf("unknown ledger format: %d", format)
+	}
+}
+
+// encodeCSV writes the given ledger entries to the writer as CSV.
+func encodeCSV(w io.Writer, entries []*Entry) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for idx := range entries {
+		entry := newJSONEntry(entries[idx])
+
+		var value string
+		if entry.AssetRate != "" {
+			value = strconv.FormatUint(entry.ValueMsat, 10)
+		}
+
+		err := csvWriter.Write([]string{
+			entry.Timestamp, entry.Type, entry.AssetID,
+			strconv.FormatUint(entry.Amount, 10),
+			entry.AnchorOutpoint, entry.AssetRate, value,
+			entry.ValuationTimestamp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,289 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultPageSize is the default maximum number of entries that are
+	// returned in a single page of the ledger.
+	DefaultPageSize = 1000
+)
+
+var (
+	// ErrInvalidCursor is returned if a pagination cursor can't be
+	// decoded.
+	ErrInvalidCursor = errors.New("invalid ledger cursor")
+)
+
+// EntryType is the type of asset movement a ledger entry records.
+type EntryType uint8
+
+const (
+	// EntryTypeMint denotes assets that were minted by the daemon.
+	EntryTypeMint EntryType = 0
+
+	// EntryTypeSend denotes assets that were sent to another party.
+	EntryTypeSend EntryType = 1
+
+	// EntryTypeReceive denotes assets that were received to one of the
+	// daemon's addresses.
+	EntryTypeReceive EntryType = 2
+
+	// EntryTypeBurn denotes assets that were burned.
+	EntryTypeBurn EntryType = 3
+)
+
+// String returns a human-readable string for the entry type.
+func (t EntryType) String() string {
+	switch t {
+	case EntryTypeMint:
+		return "mint"
+
+	case EntryTypeSend:
+		return "send"
+
+	case EntryTypeReceive:
+		return "receive"
+
+	case EntryTypeBurn:
+		return "burn"
+
+	default:
+		return fmt.Sprintf("<unknown_type(%d)>", t)
+	}
+}
+
+// Valuation is a snapshot of the value of a ledger entry in BTC terms, as
+// reported by a price oracle.
+type Valuation struct {
+	// AssetRate is the number of asset units per BTC the value is based
+	// on.
+	AssetRate rfqmath.BigIntFixedPoint
+
+	// Value is the value of the entry's amount at the asset rate.
+	Value lnwire.MilliSatoshi
+
+	// Time is the time the asset rate was queried at.
+	Time time.Time
+}
+
+// Entry is a single asset movement in the ledger.
+type Entry struct {
+	// ID uniquely identifies the entry within the ledger.
+	ID string
+
+	// Timestamp is the time the movement was recorded at.
+	Timestamp time.Time
+
+	// Type is the type of the movement.
+	Type EntryType
+
+	// AssetID is the ID of the moved asset.
+	AssetID asset.ID
+
+	// Amount is the number of asset units that were moved.
+	Amount uint64
+
+	// AnchorOutpoint is the on-chain outpoint the moved assets were
+	// anchored to by the movement.
+	AnchorOutpoint wire.OutPoint
+
+	// Valuation is the optional value of the entry's amount.
+	Valuation fn.Option[Valuation]
+}
+
+// Cursor returns the pagination cursor that points to the entry. A page that
+// starts at the cursor contains the entries following this one.
+func (e *Entry) Cursor() string {
+	raw := fmt.Sprintf("%d/%s", e.Timestamp.UnixNano(), e.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// before returns true if the entry is ordered before the other entry in the
+// ledger.
+func (e *Entry) before(other *Entry) bool {
+	if !e.Timestamp.Equal(other.Timestamp) {
+		return e.Timestamp.Before(other.Timestamp)
+	}
+
+	return e.ID < other.ID
+}
+
+// Sort orders the given entries by time. Entries with the same timestamp are
+// ordered by their ID, which makes the order stable across exports.
+func Sort(entries []*Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].before(entries[j])
+	})
+}
+
+// Page returns the entries of the sorted ledger that follow the given cursor,
+// limited to the given number of entries. An empty cursor starts at the
+// beginning of the ledger. The returned cursor points to the last returned
+// entry and is empty if there are no more entries.
+func Page(entries []*Entry, cursor string, limit int) ([]*Entry, string,
+	error) {
+
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	start := 0
+	if cursor != "" {
+		last, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		start = sort.Search(len(entries), func(i int) bool {
+			return last.before(entries[i])
+		})
+	}
+
+	end := start + limit
+	if end >= len(entries) {
+		return entries[start:], "", nil
+	}
+
+	page := entries[start:end]
+	return page, page[len(page)-1].Cursor(), nil
+}
+
+// decodeCursor decodes the sort key of the entry a cursor points to.
+func decodeCursor(cursor string) (*Entry, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	timestamp, id, ok := strings.Cut(string(raw), "/")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return &Entry{
+		ID:        id,
+		Timestamp: time.Unix(0, nanos),
+	}, nil
+}
+
+// MintEntries returns the ledger entries for the assets minted in the given
+// batch. Batches that weren't broadcast yet or were cancelled don't have any
+// entries.
+func MintEntries(batch *tapgarden.MintingBatch) []*Entry {
+	switch batch.State() {
+	case tapgarden.BatchStateBroadcast, tapgarden.BatchStateConfirmed,
+		tapgarden.BatchStateFinalized:
+
+	default:
+		return nil
+	}
+
+	if batch.RootAssetCommitment == nil || batch.GenesisPacket == nil {
+		return nil
+	}
+
+	anchorPoint := batch.GenesisAnchorOutPoint()
+
+	var entries []*Entry
+	for _, newAsset := range batch.RootAssetCommitment.CommittedAssets() {
+		assetID := newAsset.ID()
+		entries = append(entries, &Entry{
+			ID: fmt.Sprintf("%v:%v:%v", EntryTypeMint,
+				anchorPoint, assetID),
+			Timestamp:      batch.CreationTime,
+			Type:           EntryTypeMint,
+			AssetID:        assetID,
+			Amount:         newAsset.Amount,
+			AnchorOutpoint: anchorPoint,
+		})
+	}
+
+	return entries
+}
+
+// ReceiveEntry returns the ledger entry for the given address event. Only
+// completed events have an entry.
+func ReceiveEntry(event *address.Event) fn.Option[*Entry] {
+	if event.Status != address.StatusCompleted {
+		return fn.None[*Entry]()
+	}
+
+	return fn.Some(&Entry{
+		ID: fmt.Sprintf("%v:%v:%v", EntryTypeReceive, event.Outpoint,
+			event.Addr.AssetID),
+		Timestamp:      event.CreationTime,
+		Type:           EntryTypeReceive,
+		AssetID:        event.Addr.AssetID,
+		Amount:         event.Addr.Amount,
+		AnchorOutpoint: event.Outpoint,
+	})
+}
+
+// TransferEntries returns the ledger entries for the outputs of the given
+// outbound transfer. Outputs that send assets to another party are recorded
+// as sends and burn outputs as burns. Change outputs that stay with the daemon
+// don't have an entry.
+func TransferEntries(parcel *tapfreighter.OutboundParcel) ([]*Entry, error) {
+	var entries []*Entry
+	for idx := range parcel.Outputs {
+		out := &parcel.Outputs[idx]
+
+		// We need the asset of the output to find out its ID, which is
+		// only recorded in the output's proof.
+		var outProof proof.Proof
+		err := outProof.Decode(bytes.NewReader(out.ProofSuffix))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode proof of "+
+				"output %d: %w", idx, err)
+		}
+
+		var entryType EntryType
+		switch {
+		case outProof.Asset.IsBurn():
+			entryType = EntryTypeBurn
+
+		case !out.ScriptKeyLocal:
+			entryType = EntryTypeSend
+
+		default:
+			continue
+		}
+
+		assetID := outProof.Asset.ID()
+		entries = append(entries, &Entry{
+			ID: fmt.Sprintf("%v:%v:%v:%d", entryType,
+				out.Anchor.OutPoint, assetID, out.Position),
+			Timestamp:      parcel.TransferTime,
+			Type:           entryType,
+			AssetID:        assetID,
+			Amount:         out.Amount,
+			AnchorOutpoint: out.Anchor.OutPoint,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,281 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// randEntries creates the given number of ledger entries. Every two entries
+// share the same timestamp, to make sure the order of entries with the same
+// timestamp is stable.
+func randEntries(t *testing.T, num int) []*Entry {
+	start := time.Unix(1700000000, 0)
+
+	entries := make([]*Entry, num)
+	for idx := range entries {
+		offset := time.Duration(idx/2) * time.Minute
+		entries[idx] = &Entry{
+			ID:        fmt.Sprintf("entry-%03d", idx),
+			Timestamp: start.Add(offset),
+			Type:      EntryType(idx % 4),
+			AssetID:   asset.RandID(t),
+			Amount:    uint64(idx + 1),
+		}
+	}
+
+	return entries
+}
+
+// TestPage tests that a sorted ledger can be paged through with cursors.
+func TestPage(t *testing.T) {
+	t.Parallel()
+
+	entries := randEntries(t, 25)
+
+	// The entries are sorted regardless of the order they're collected in.
+	shuffled := fn.CopySlice(entries)
+	shuffled[0], shuffled[24] = shuffled[24], shuffled[0]
+	shuffled[3], shuffled[10] = shuffled[10], shuffled[3]
+	Sort(shuffled)
+	require.Equal(t, entries, shuffled)
+
+	var (
+		exported []*Entry
+		cursor   string
+		numPages int
+	)
+	for {
+		page, nextCursor, err := Page(entries, cursor, 10)
+		require.NoError(t, err)
+
+		exported = append(exported, page...)
+		numPages++
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	require.Equal(t, 3, numPages)
+	require.Equal(t, entries, exported)
+
+	// Without a limit, the default page size is used.
+	page, nextCursor, err := Page(entries, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, entries, page)
+	require.Empty(t, nextCursor)
+
+	// A cursor of the last entry results in an empty page.
+	page, nextCursor, err = Page(entries, entries[24].Cursor(), 10)
+	require.NoError(t, err)
+	require.Empty(t, page)
+	require.Empty(t, nextCursor)
+
+	_, _, err = Page(entries, "not a cursor", 10)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+// TestEncode tests that ledger entries are encoded as CSV and JSON.
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	entries := randEntries(t, 2)
+	entries[1].Valuation = fn.Some(Valuation{
+		AssetRate: rfqmath.NewBigIntFixedPoint(100_000, 0),
+		Value:     1234,
+		Time:      time.Unix(1700000600, 0),
+	})
+
+	var csvBuf bytes.Buffer
+	require.NoError(t, Encode(&csvBuf, entries, FormatCSV))
+
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, csvHeader, records[0])
+	require.Equal(t, "2023-11-14T22:13:20Z", records[1][0])
+	require.Equal(t, "mint", records[1][1])
+	require.Equal(t, entries[0].AssetID.String(), records[1][2])
+	require.Equal(t, "1", records[1][3])
+	require.Empty(t, records[1][6])
+	require.Equal(t, "send", records[2][1])
+	require.Equal(t, "100000", records[2][5])
+	require.Equal(t, "1234", records[2][6])
+	require.Equal(t, "2023-11-14T22:23:20Z", records[2][7])
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, Encode(&jsonBuf, entries, FormatJSON))
+
+	var jsonEntries []jsonEntry
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &jsonEntries))
+	require.Equal(t, []jsonEntry{
+		newJSONEntry(entries[0]), newJSONEntry(entries[1]),
+	}, jsonEntries)
+
+	require.Error(t, Encode(&jsonBuf, entries, Format(99)))
+}
+
+// TestValuer tests that entries are valued at the rate of the price oracle.
+func TestValuer(t *testing.T) {
+	t.Parallel()
+
+	// The oracle values one BTC at 100k asset units, so 1k units are worth
+	// 0.01 BTC.
+	oracle := rfq.NewMockPriceOracle(3600, 100_000)
+	testClock := clock.NewTestClock(time.Unix(1700000000, 0))
+
+	entries := randEntries(t, 2)
+	entries[0].Amount = 1_000
+	entries[1].Amount = 50_000
+
+	NewValuer(oracle, testClock).Value(context.Background(), entries)
+
+	valuation, err := entries[0].Valuation.UnwrapOrErr(
+		fmt.Errorf("missing valuation"),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000_000, valuation.Value)
+	require.Equal(t, testClock.Now(), valuation.Time)
+
+	valuation, err = entries[1].Valuation.UnwrapOrErr(
+		fmt.Errorf("missing valuation"),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 50_000_000_000, valuation.Value)
+}
+
+// TestReceiveEntry tests that only completed address events are recorded in
+// the ledger.
+func TestReceiveEntry(t *testing.T) {
+	t.Parallel()
+
+	assetID := asset.RandID(t)
+	event := &address.Event{
+		CreationTime: time.Unix(1700000000, 0),
+		Addr: &address.AddrWithKeyInfo{
+			Tap: &address.Tap{
+				AssetID: assetID,
+				Amount:  42,
+			},
+		},
+		Status:   address.StatusTransactionConfirmed,
+		Outpoint: wire.OutPoint{Hash: test.RandHash(), Index: 1},
+	}
+	require.True(t, ReceiveEntry(event).IsNone())
+
+	event.Status = address.StatusCompleted
+	entry, err := ReceiveEntry(event).UnwrapOrErr(
+		fmt.Errorf("missing entry"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, EntryTypeReceive, entry.Type)
+	require.Equal(t, assetID, entry.AssetID)
+	require.EqualValues(t, 42, entry.Amount)
+	require.Equal(t, event.Outpoint, entry.AnchorOutpoint)
+	require.Equal(t, event.CreationTime, entry.Timestamp)
+}
+
+// TestTransferEntries tests that the outputs of a transfer are recorded as
+// sends and burns, while change outputs are skipped.
+func TestTransferEntries(t *testing.T) {
+	t.Parallel()
+
+	genesis := asset.RandGenesis(t, asset.Normal)
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{})
+	anchorTx.AddTxOut(&wire.TxOut{PkScript: test.RandBytes(34)})
+	block := wire.MsgBlock{
+		Transactions: []*wire.MsgTx{anchorTx},
+	}
+
+	// encodeProof creates a proof suffix for an output with the given
+	// script key and witness.
+	encodeProof := func(scriptKey asset.ScriptKey,
+		witnesses []asset.Witness) []byte {
+
+		p := proof.RandProof(
+			t, genesis, test.RandPubKey(t), block, 0, 0,
+		)
+		p.Asset.ScriptKey = scriptKey
+		p.Asset.PrevWitnesses = witnesses
+
+		var buf bytes.Buffer
+		require.NoError(t, p.Encode(&buf))
+
+		return buf.Bytes()
+	}
+
+	prevID := asset.PrevID{
+		OutPoint: wire.OutPoint{Hash: test.RandHash()},
+		ID:       genesis.ID(),
+	}
+	burnKey := asset.NewScriptKey(asset.DeriveBurnKey(prevID))
+	witnesses := []asset.Witness{{PrevID: &prevID}}
+
+	anchor := tapfreighter.Anchor{
+		OutPoint: wire.OutPoint{Hash: anchorTx.TxHash()},
+	}
+	parcel := &tapfreighter.OutboundParcel{
+		AnchorTx:     anchorTx,
+		TransferTime: time.Unix(1700000000, 0),
+		Outputs: []tapfreighter.TransferOutput{{
+			Anchor:    anchor,
+			ScriptKey: asset.RandScriptKey(t),
+			Amount:    10,
+			ProofSuffix: encodeProof(
+				asset.RandScriptKey(t), witnesses,
+			),
+		}, {
+			Anchor:         anchor,
+			ScriptKey:      asset.RandScriptKey(t),
+			ScriptKeyLocal: true,
+			Amount:         20,
+			ProofSuffix: encodeProof(
+				asset.RandScriptKey(t), witnesses,
+			),
+			Position: 1,
+		}, {
+			Anchor:         anchor,
+			ScriptKey:      burnKey,
+			ScriptKeyLocal: true,
+			Amount:         5,
+			ProofSuffix:    encodeProof(burnKey, witnesses),
+			Position:       2,
+		}},
+	}
+
+	entries, err := TransferEntries(parcel)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, EntryTypeSend, entries[0].Type)
+	require.Equal(t, genesis.ID(), entries[0].AssetID)
+	require.EqualValues(t, 10, entries[0].Amount)
+	require.Equal(t, parcel.TransferTime, entries[0].Timestamp)
+
+	require.Equal(t, EntryTypeBurn, entries[1].Type)
+	require.EqualValues(t, 5, entries[1].Amount)
+	require.NotEqual(t, entries[0].ID, entries[1].ID)
+
+	// An output without a valid proof can't be recorded.
+	parcel.Outputs[0].ProofSuffix = []byte{0x01}
+	_, err = TransferEntries(parcel)
+	require.Error(t, err)
+}
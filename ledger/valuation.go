@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// Valuer values ledger entries using the asset rates of a price oracle. The
+// oracle is queried once per asset, so all entries of the same asset are
+// valued at the same rate snapshot.
+type Valuer struct {
+	oracle rfq.PriceOracle
+	clock  clock.Clock
+
+	// rates caches the rate snapshot of each asset. Assets that the oracle
+	// couldn't price are cached as None.
+	rates map[asset.ID]fn.Option[rfqmsg.AssetRate]
+}
+
+// NewValuer creates a new valuer that queries the given price oracle.
+func NewValuer(oracle rfq.PriceOracle, clock clock.Clock) *Valuer {
+	return &Valuer{
+		oracle: oracle,
+		clock:  clock,
+		rates:  make(map[asset.ID]fn.Option[rfqmsg.AssetRate]),
+	}
+}
+
+// Value sets the valuation of the given entries. Entries of assets that the
+// oracle has no rate for are left without a valuation.
+func (v *Valuer) Value(ctx context.Context, entries []*Entry) {
+	for _, entry := range entries {
+		rate := v.assetRate(ctx, entry.AssetID)
+		entry.Valuation = fn.MapOption(
+			func(r rfqmsg.AssetRate) Valuation {
+				units := rfqmath.NewBigIntFixedPoint(
+					entry.Amount, 0,
+				)
+
+				return Valuation{
+					AssetRate: r.Rate,
+					Value: rfqmath.UnitsToMilliSatoshi(
+						units, r.Rate,
+					),
+					Time: v.clock.Now(),
+				}
+			},
+		)(rate)
+	}
+}
+
+// assetRate returns the rate snapshot of the given asset, querying the oracle
+// if the asset wasn't priced before.
+func (v *Valuer) assetRate(ctx context.Context,
+	assetID asset.ID) fn.Option[rfqmsg.AssetRate] {
+
+	if rate, ok := v.rates[assetID]; ok {
+		return rate
+	}
+
+	rate, err := v.queryRate(ctx, assetID)
+	if err != nil {
+		log.Warnf("Unable to value ledger entries of asset %v: %v",
+			assetID, err)
+	}
+
+	v.rates[assetID] = rate
+	return rate
+}
+
+// queryRate queries the price oracle for the current rate of the given asset.
+func (v *Valuer) queryRate(ctx context.Context,
+	assetID asset.ID) (fn.Option[rfqmsg.AssetRate], error) {
+
+	none := fn.None[rfqmsg.AssetRate]()
+
+	resp, err := v.oracle.QueryBidPrice(
+		ctx, asset.NewSpecifierFromId(assetID), fn.None[uint64](),
+		fn.None[lnwire.MilliSatoshi](), fn.None[rfqmsg.AssetRate](),
+	)
+	if err != nil {
+		return none, fmt.Errorf("unable to query price oracle: %w",
+			err)
+	}
+	if resp.Err != nil {
+		return none, fmt.Errorf("price oracle returned error: %w",
+			resp.Err)
+	}
+
+	// A zero rate can't be used for a valuation, as we'd divide by it.
+	zero := rfqmath.NewBigIntFromUint64(0)
+	if resp.AssetRate.Rate.Coefficient.Equals(zero) {
+		return none, fmt.Errorf("price oracle did not return a rate")
+	}
+
+	return fn.Some(resp.AssetRate), nil
+}
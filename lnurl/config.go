@@ -0,0 +1,140 @@
+package lnurl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// DefaultListenAddr is the default address the LNURL-pay server
+	// listens on.
+	DefaultListenAddr = "127.0.0.1:8092"
+
+	// DefaultDescription is the default description that is shown to
+	// payers.
+	DefaultDescription = "Payment in Taproot Assets"
+
+	// DefaultMinSendableMsat is the default minimum amount a payer can
+	// send.
+	DefaultMinSendableMsat = 1_000
+
+	// DefaultMaxSendableMsat is the default maximum amount a payer can
+	// send.
+	DefaultMaxSendableMsat = 1_000_000_000
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// LNURL-pay server.
+//
+// nolint: lll
+type CliConfig struct {
+	Active bool `long:"active" description:"If true, an LNURL-pay server is started that creates asset invoices on the fly"`
+
+	ListenAddr string `long:"listenaddr" description:"The interface the LNURL-pay server should listen on"`
+
+	BaseURL string `long:"baseurl" description:"The public base URL under which the LNURL-pay server is reachable, for example https://pay.example.com; payers will use <baseurl>/lnurlp/<asset_id> to pay in the given asset"`
+
+	Description string `long:"description" description:"The description of the payment that is shown to payers"`
+
+	MinSendableMsat uint64 `long:"minsendablemsat" description:"The minimum amount in milli-satoshi a payer can send"`
+
+	MaxSendableMsat uint64 `long:"maxsendablemsat" description:"The maximum amount in milli-satoshi a payer can send"`
+
+	PeerPubKey string `long:"peerpubkey" description:"The hex encoded public key of the asset channel peer to request quotes from; required if there are asset channels with multiple peers"`
+}
+
+// DefaultCliConfig returns the default LNURL-pay server configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		ListenAddr:      DefaultListenAddr,
+		Description:     DefaultDescription,
+		MinSendableMsat: DefaultMinSendableMsat,
+		MaxSendableMsat: DefaultMaxSendableMsat,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if !c.Active {
+		return nil
+	}
+
+	if c.ListenAddr == "" {
+		return fmt.Errorf("LNURL-pay listen address must be set")
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid LNURL-pay base URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("LNURL-pay base URL must use http or https")
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("LNURL-pay base URL must contain a host")
+	}
+
+	if c.MinSendableMsat == 0 {
+		return fmt.Errorf("LNURL-pay minimum sendable amount must be " +
+			"positive")
+	}
+
+	if c.MinSendableMsat > c.MaxSendableMsat {
+		return fmt.Errorf("LNURL-pay minimum sendable amount must " +
+			"not exceed the maximum sendable amount")
+	}
+
+	if _, err := c.peer(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// peer returns the parsed peer public key, or nil if none is configured.
+func (c *CliConfig) peer() (*route.Vertex, error) {
+	if c.PeerPubKey == "" {
+		return nil, nil
+	}
+
+	peer, err := route.NewVertexFromStr(c.PeerPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LNURL-pay peer public key: %w",
+			err)
+	}
+
+	return &peer, nil
+}
+
+// InvoiceCreator is an interface that allows the LNURL-pay server to create
+// asset invoices.
+type InvoiceCreator interface {
+	// AddAssetInvoice negotiates a quote with the given peer (or the
+	// single asset channel peer if nil) for receiving up to maxUnits of
+	// the given asset and creates an invoice over the exact amount that
+	// commits to the given description hash. The payment request of the
+	// invoice is returned.
+	AddAssetInvoice(ctx context.Context, assetID asset.ID,
+		peer *route.Vertex, maxUnits uint64, amt lnwire.MilliSatoshi,
+		descHash []byte) (string, error)
+}
+
+// Config is the configuration of the LNURL-pay server.
+type Config struct {
+	*CliConfig
+
+	// PriceOracle is used to estimate the number of asset units that a
+	// quote must cover for a requested amount.
+	PriceOracle rfq.PriceOracle
+
+	// InvoiceCreator is used to create the asset invoices.
+	InvoiceCreator InvoiceCreator
+}
@@ -0,0 +1,366 @@
+package lnurl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// PayPathPrefix is the URL path prefix under which the LNURL-pay
+	// endpoint of an asset is served. The hex encoded asset ID follows the
+	// prefix.
+	PayPathPrefix = "/lnurlp/"
+
+	// callbackPathSuffix is the URL path suffix of the callback that
+	// creates the invoice.
+	callbackPathSuffix = "/callback"
+
+	// payRequestTag is the tag that identifies an LNURL-pay response.
+	payRequestTag = "payRequest"
+
+	// quoteMarginPercent is the margin that is added to the estimated
+	// number of asset units a quote must cover, to account for a
+	// difference between the price of our oracle and the rate of the
+	// peer.
+	quoteMarginPercent = 5
+
+	// requestTimeout is the maximum time it may take to serve a single
+	// request, including the quote negotiation.
+	requestTimeout = time.Minute
+
+	// shutdownTimeout is the maximum time we wait for pending requests to
+	// finish when shutting down.
+	shutdownTimeout = 5 * time.Second
+)
+
+// PayResponse is the response of the LNURL-pay endpoint as defined in LUD-06.
+type PayResponse struct {
+	// Callback is the URL the payer calls to request an invoice.
+	Callback string `json:"callback"`
+
+	// MinSendable is the minimum amount in milli-satoshi that can be sent.
+	MinSendable uint64 `json:"minSendable"`
+
+	// MaxSendable is the maximum amount in milli-satoshi that can be sent.
+	MaxSendable uint64 `json:"maxSendable"`
+
+	// Metadata is the JSON encoded metadata of the payment. The invoice
+	// commits to its SHA256 hash.
+	Metadata string `json:"metadata"`
+
+	// Tag identifies the response as an LNURL-pay response.
+	Tag string `json:"tag"`
+}
+
+// InvoiceResponse is the response of the LNURL-pay callback as defined in
+// LUD-06.
+type InvoiceResponse struct {
+	// PaymentRequest is the BOLT11 payment request of the invoice.
+	PaymentRequest string `json:"pr"`
+
+	// Routes is always empty and only present for compatibility.
+	Routes []string `json:"routes"`
+}
+
+// ErrorResponse is the response returned if a request cannot be served.
+type ErrorResponse struct {
+	// Status is always "ERROR".
+	Status string `json:"status"`
+
+	// Reason is a human-readable description of the error.
+	Reason string `json:"reason"`
+}
+
+// Server is an HTTP server that serves LNURL-pay requests for assets. The
+// invoices are created on the fly using the RFQ pipeline, so a single static
+// LNURL can be used to receive payments in an asset.
+type Server struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *Config
+
+	// peer is the parsed peer public key, if configured.
+	peer *route.Vertex
+
+	httpServer *http.Server
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewServer creates a new LNURL-pay server.
+func NewServer(cfg *Config) (*Server, error) {
+	peer, err := cfg.peer()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		cfg:  cfg,
+		peer: peer,
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: requestTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET "+PayPathPrefix+"{asset_id}", s.handlePayRequest,
+	)
+	mux.HandleFunc(
+		"GET "+PayPathPrefix+"{asset_id}"+callbackPathSuffix,
+		s.handleCallback,
+	)
+
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s, nil
+}
+
+// PayURL returns the LNURL-pay URL for the given asset.
+func (s *Server) PayURL(assetID asset.ID) string {
+	return strings.TrimSuffix(s.cfg.BaseURL, "/") + PayPathPrefix +
+		assetID.String()
+}
+
+// Start starts the LNURL-pay server.
+func (s *Server) Start() error {
+	var startErr error
+	s.startOnce.Do(func() {
+		log.Info("Starting LNURL-pay server")
+
+		lis, err := net.Listen("tcp", s.cfg.ListenAddr)
+		if err != nil {
+			startErr = fmt.Errorf("unable to listen on %v: %w",
+				s.cfg.ListenAddr, err)
+			return
+		}
+
+		s.Wg.Add(1)
+		go func() {
+			defer s.Wg.Done()
+
+			err := s.httpServer.Serve(lis)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Errorf("LNURL-pay server error: %v", err)
+			}
+		}()
+
+		log.Infof("LNURL-pay server listening on %v, pay URLs have "+
+			"the format %s", lis.Addr(),
+			strings.TrimSuffix(s.cfg.BaseURL, "/")+PayPathPrefix+
+				"<asset_id>")
+	})
+
+	return startErr
+}
+
+// Stop stops the LNURL-pay server.
+func (s *Server) Stop() error {
+	var stopErr error
+	s.stopOnce.Do(func() {
+		log.Info("Stopping LNURL-pay server")
+
+		close(s.Quit)
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(), shutdownTimeout,
+		)
+		defer cancel()
+
+		stopErr = s.httpServer.Shutdown(ctx)
+		s.Wg.Wait()
+	})
+
+	return stopErr
+}
+
+// metadata returns the LNURL-pay metadata for the given asset.
+func (s *Server) metadata(assetID asset.ID) (string, error) {
+	description := fmt.Sprintf("%s (asset %s)", s.cfg.Description,
+		assetID.String())
+
+	metadata, err := json.Marshal([][]string{
+		{"text/plain", description},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(metadata), nil
+}
+
+// handlePayRequest serves the first step of the LNURL-pay protocol, which
+// returns the payment parameters and the callback URL.
+func (s *Server) handlePayRequest(w http.ResponseWriter, r *http.Request) {
+	assetID, err := parseAssetID(r.PathValue("asset_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metadata, err := s.metadata(assetID)
+	if err != nil {
+		log.Errorf("Unable to encode metadata: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &PayResponse{
+		Callback:    s.PayURL(assetID) + callbackPathSuffix,
+		MinSendable: s.cfg.MinSendableMsat,
+		MaxSendable: s.cfg.MaxSendableMsat,
+		Metadata:    metadata,
+		Tag:         payRequestTag,
+	})
+}
+
+// handleCallback serves the second step of the LNURL-pay protocol, which
+// creates an asset invoice over the requested amount.
+func (s *Server) handleCallback(w http.ResponseWriter, r *http.Request) {
+	assetID, err := parseAssetID(r.PathValue("asset_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	amtStr := r.URL.Query().Get("amount")
+	amt, err := strconv.ParseUint(amtStr, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+
+	if amt < s.cfg.MinSendableMsat || amt > s.cfg.MaxSendableMsat {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("amount "+
+			"must be between %d and %d msat",
+			s.cfg.MinSendableMsat, s.cfg.MaxSendableMsat))
+		return
+	}
+
+	metadata, err := s.metadata(assetID)
+	if err != nil {
+		log.Errorf("Unable to encode metadata: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	descHash := sha256.Sum256([]byte(metadata))
+
+	ctx, cancel := s.WithCtxQuit()
+	defer cancel()
+
+	payReq, err := s.createInvoice(
+		ctx, assetID, lnwire.MilliSatoshi(amt), descHash[:],
+	)
+	if err != nil {
+		log.Errorf("Unable to create invoice over %d msat for asset "+
+			"%v: %v", amt, assetID, err)
+		writeError(
+			w, http.StatusInternalServerError, "unable to create "+
+				"invoice",
+		)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &InvoiceResponse{
+		PaymentRequest: payReq,
+		Routes:         []string{},
+	})
+}
+
+// createInvoice estimates the number of asset units needed for the given
+// amount and creates an asset invoice over it.
+func (s *Server) createInvoice(ctx context.Context, assetID asset.ID,
+	amt lnwire.MilliSatoshi, descHash []byte) (string, error) {
+
+	resp, err := s.cfg.PriceOracle.QueryBidPrice(
+		ctx, asset.NewSpecifierFromId(assetID), fn.None[uint64](),
+		fn.Some(amt), fn.None[rfqmsg.AssetRate](),
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to query price oracle: %w", err)
+	}
+	if resp.Err != nil {
+		return "", fmt.Errorf("price oracle returned error: %w",
+			resp.Err)
+	}
+	if resp.AssetRate.Rate.ToUint64() == 0 {
+		return "", fmt.Errorf("price oracle did not return a rate")
+	}
+
+	maxUnits := estimateUnits(amt, resp.AssetRate.Rate)
+
+	log.Debugf("Creating invoice over %d msat for asset %v with quote "+
+		"for up to %d units", amt, assetID, maxUnits)
+
+	return s.cfg.InvoiceCreator.AddAssetInvoice(
+		ctx, assetID, s.peer, maxUnits, amt, descHash,
+	)
+}
+
+// estimateUnits returns the number of asset units a quote must cover to
+// receive the given amount at the given rate, including a safety margin.
+func estimateUnits(amt lnwire.MilliSatoshi,
+	rate rfqmath.BigIntFixedPoint) uint64 {
+
+	units := rfqmath.MilliSatoshiToUnits(amt, rate).ScaleTo(0).ToUint64()
+
+	// We round up and add the margin, so the quote covers the amount even
+	// if the peer's rate is slightly worse than our oracle's.
+	return units + units*quoteMarginPercent/100 + 1
+}
+
+// parseAssetID parses a hex encoded asset ID.
+func parseAssetID(idStr string) (asset.ID, error) {
+	var assetID asset.ID
+
+	idBytes, err := hex.DecodeString(idStr)
+	if err != nil || len(idBytes) != len(assetID) {
+		return assetID, fmt.Errorf("invalid asset ID")
+	}
+	copy(assetID[:], idBytes)
+
+	return assetID, nil
+}
+
+// writeJSON writes the given response as JSON.
+func writeJSON(w http.ResponseWriter, status int, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("Unable to write response: %v", err)
+	}
+}
+
+// writeError writes an LNURL error response with the given reason.
+func writeError(w http.ResponseWriter, status int, reason string) {
+	writeJSON(w, status, &ErrorResponse{
+		Status: "ERROR",
+		Reason: reason,
+	})
+}
@@ -0,0 +1,184 @@
+package lnurl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// mockInvoiceCreator is a mock implementation of the InvoiceCreator interface
+// that records the last request.
+type mockInvoiceCreator struct {
+	assetID  asset.ID
+	maxUnits uint64
+	amt      lnwire.MilliSatoshi
+	descHash []byte
+}
+
+// AddAssetInvoice records the request and returns a fake payment request.
+func (m *mockInvoiceCreator) AddAssetInvoice(_ context.Context,
+	assetID asset.ID, _ *route.Vertex, maxUnits uint64,
+	amt lnwire.MilliSatoshi, descHash []byte) (string, error) {
+
+	m.assetID = assetID
+	m.maxUnits = maxUnits
+	m.amt = amt
+	m.descHash = descHash
+
+	return "lnbc1fake", nil
+}
+
+// TestServer tests the LNURL-pay flow of the server.
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	creator := &mockInvoiceCreator{}
+	cliCfg := DefaultCliConfig()
+	cliCfg.Active = true
+	cliCfg.BaseURL = "https://pay.example.com/"
+	require.NoError(t, cliCfg.Validate())
+
+	// The mock oracle prices the asset at 100k units per BTC, which is
+	// one unit per 1000 sat.
+	s, err := NewServer(&Config{
+		CliConfig:      cliCfg,
+		PriceOracle:    rfq.NewMockPriceOracle(3600, 100_000),
+		InvoiceCreator: creator,
+	})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(s.httpServer.Handler)
+	t.Cleanup(httpServer.Close)
+
+	get := func(path string, resp any) int {
+		httpResp, err := http.Get(httpServer.URL + path)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+
+		require.NoError(t, json.NewDecoder(httpResp.Body).Decode(resp))
+
+		return httpResp.StatusCode
+	}
+
+	assetID := asset.RandID(t)
+	payPath := PayPathPrefix + assetID.String()
+	require.Equal(
+		t, "https://pay.example.com"+payPath, s.PayURL(assetID),
+	)
+
+	// The first step returns the payment parameters.
+	var payResp PayResponse
+	require.Equal(t, http.StatusOK, get(payPath, &payResp))
+	require.Equal(t, payRequestTag, payResp.Tag)
+	require.Equal(t, s.PayURL(assetID)+callbackPathSuffix, payResp.Callback)
+	require.EqualValues(t, DefaultMinSendableMsat, payResp.MinSendable)
+	require.EqualValues(t, DefaultMaxSendableMsat, payResp.MaxSendable)
+
+	var metadata [][]string
+	require.NoError(t, json.Unmarshal([]byte(payResp.Metadata), &metadata))
+	require.Len(t, metadata, 1)
+	require.Equal(t, "text/plain", metadata[0][0])
+	require.Contains(t, metadata[0][1], assetID.String())
+
+	// The callback creates an invoice over the exact amount, committing
+	// to the metadata.
+	var invoiceResp InvoiceResponse
+	status := get(
+		payPath+callbackPathSuffix+"?amount=50000000", &invoiceResp,
+	)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "lnbc1fake", invoiceResp.PaymentRequest)
+	require.NotNil(t, invoiceResp.Routes)
+
+	descHash := sha256.Sum256([]byte(payResp.Metadata))
+	require.Equal(t, assetID, creator.assetID)
+	require.EqualValues(t, 50_000_000, creator.amt)
+	require.Equal(t, descHash[:], creator.descHash)
+
+	// 50k sat are 50 units, plus the margin and rounding.
+	require.EqualValues(t, 53, creator.maxUnits)
+
+	// Invalid requests are rejected with an LNURL error.
+	invalid := []string{
+		PayPathPrefix + "abcd",
+		payPath + callbackPathSuffix,
+		payPath + callbackPathSuffix + "?amount=1",
+		payPath + callbackPathSuffix + fmt.Sprintf(
+			"?amount=%d", DefaultMaxSendableMsat+1,
+		),
+	}
+	for _, path := range invalid {
+		var errResp ErrorResponse
+		require.Equal(t, http.StatusBadRequest, get(path, &errResp))
+		require.Equal(t, "ERROR", errResp.Status)
+		require.NotEmpty(t, errResp.Reason)
+	}
+}
+
+// TestCliConfigValidate tests the validation of the LNURL-pay config.
+func TestCliConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	// An inactive config is always valid.
+	require.NoError(t, DefaultCliConfig().Validate())
+
+	testCases := []struct {
+		name   string
+		modify func(*CliConfig)
+		err    string
+	}{{
+		name:   "valid",
+		modify: func(*CliConfig) {},
+	}, {
+		name: "missing base URL",
+		modify: func(c *CliConfig) {
+			c.BaseURL = ""
+		},
+		err: "must use http or https",
+	}, {
+		name: "invalid scheme",
+		modify: func(c *CliConfig) {
+			c.BaseURL = "ftp://pay.example.com"
+		},
+		err: "must use http or https",
+	}, {
+		name: "min above max",
+		modify: func(c *CliConfig) {
+			c.MinSendableMsat = c.MaxSendableMsat + 1
+		},
+		err: "must not exceed",
+	}, {
+		name: "invalid peer",
+		modify: func(c *CliConfig) {
+			c.PeerPubKey = "abcd"
+		},
+		err: "invalid LNURL-pay peer public key",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultCliConfig()
+			cfg.Active = true
+			cfg.BaseURL = "https://pay.example.com"
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
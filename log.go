@@ -3,15 +3,30 @@ package taprootassets
 import (
 	"github.com/btcsuite/btclog"
 	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/anchorwallet"
+	"github.com/lightninglabs/taproot-assets/chainmux"
+	"github.com/lightninglabs/taproot-assets/chainsource"
 	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/explorer"
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/lightninglabs/taproot-assets/ledger"
+	"github.com/lightninglabs/taproot-assets/lnurl"
+	"github.com/lightninglabs/taproot-assets/metablob"
 	"github.com/lightninglabs/taproot-assets/monitoring"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/recovery"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/retention"
 	"github.com/lightninglabs/taproot-assets/rfq"
 	"github.com/lightninglabs/taproot-assets/tapchannel"
 	"github.com/lightninglabs/taproot-assets/tapdb"
 	"github.com/lightninglabs/taproot-assets/tapfreighter"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/tapsession"
+	"github.com/lightninglabs/taproot-assets/tapswap"
+	"github.com/lightninglabs/taproot-assets/tracing"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/signal"
@@ -110,9 +125,43 @@ func SetupLoggers(root *build.RotatingLogWriter, interceptor signal.Interceptor)
 		root, monitoring.Subsystem, interceptor, monitoring.UseLogger,
 	)
 	AddSubLogger(root, rfq.Subsystem, interceptor, rfq.UseLogger)
+	AddSubLogger(root, alert.Subsystem, interceptor, alert.UseLogger)
+	AddSubLogger(root, tracing.Subsystem, interceptor, tracing.UseLogger)
+	AddSubLogger(root, jobs.Subsystem, interceptor, jobs.UseLogger)
+	AddSubLogger(
+		root, tapsession.Subsystem, interceptor, tapsession.UseLogger,
+	)
+	AddSubLogger(root, tapswap.Subsystem, interceptor, tapswap.UseLogger)
+	AddSubLogger(root, lnurl.Subsystem, interceptor, lnurl.UseLogger)
+	AddSubLogger(root, ledger.Subsystem, interceptor, ledger.UseLogger)
+	AddSubLogger(
+		root, replication.Subsystem, interceptor, replication.UseLogger,
+	)
+	AddSubLogger(
+		root, chainmux.Subsystem, interceptor, chainmux.UseLogger,
+	)
+	AddSubLogger(
+		root, explorer.Subsystem, interceptor, explorer.UseLogger,
+	)
+	AddSubLogger(
+		root, chainsource.Subsystem, interceptor, chainsource.UseLogger,
+	)
+	AddSubLogger(
+		root, anchorwallet.Subsystem, interceptor,
+		anchorwallet.UseLogger,
+	)
 	AddSubLogger(
 		root, tapchannel.Subsystem, interceptor, tapchannel.UseLogger,
 	)
+	AddSubLogger(
+		root, metablob.Subsystem, interceptor, metablob.UseLogger,
+	)
+	AddSubLogger(
+		root, retention.Subsystem, interceptor, retention.UseLogger,
+	)
+	AddSubLogger(
+		root, recovery.Subsystem, interceptor, recovery.UseLogger,
+	)
 }
 
 // AddSubLogger is a helper method to conveniently create and register the
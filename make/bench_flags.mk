@@ -0,0 +1,48 @@
+BENCHPKG = mssmt proof rfqmsg tapdb
+BENCH_COUNT = 5
+BENCH_TIME = 1s
+BENCH_TIMEOUT = 60m
+BENCH_RAW_OUTPUT = $(shell pwd)/bench_output.txt
+BENCH_REPORT = $(shell pwd)/bench_report.json
+BENCH_BASELINE =
+BENCH_THRESHOLD = 10
+
+# If specific package is being benchmarked, construct the full name of the
+# subpackage.
+ifneq ($(pkg),)
+BENCHPKG := $(pkg)
+endif
+
+# The number of times each benchmark is run. The report contains the median of
+# all runs, so a higher count gives more stable results.
+ifneq ($(count),)
+BENCH_COUNT := $(count)
+endif
+
+# Overwrites the minimum run time (e.g. 5s) or iteration count (e.g. 100x) of
+# each benchmark.
+ifneq ($(benchtime),)
+BENCH_TIME := $(benchtime)
+endif
+
+# If the timeout needs to be increased, overwrite the default value.
+ifneq ($(timeout),)
+BENCH_TIMEOUT := $(timeout)
+endif
+
+# Overwrites the file the JSON report is written to.
+ifneq ($(report),)
+BENCH_REPORT := $(report)
+endif
+
+# A previously created JSON report to compare the results against. If set, the
+# target fails if any benchmark got slower by more than the threshold.
+ifneq ($(baseline),)
+BENCH_BASELINE := $(baseline)
+endif
+
+# Overwrites the maximum allowed slowdown in percent when comparing against a
+# baseline report.
+ifneq ($(threshold),)
+BENCH_THRESHOLD := $(threshold)
+endif
@@ -0,0 +1,138 @@
+package metablob
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+const (
+	// BackendFS is the blob store backend that stores blobs in a directory
+	// of the local file system.
+	BackendFS = "fs"
+
+	// BackendS3 is the blob store backend that stores blobs in a bucket of
+	// an S3-compatible object store.
+	BackendS3 = "s3"
+
+	// DefaultMaxSize is the default maximum size of a single meta blob.
+	DefaultMaxSize = 64 * 1024 * 1024
+
+	// DefaultS3Timeout is the default timeout for a single request against
+	// an S3-compatible object store.
+	DefaultS3Timeout = 5 * time.Minute
+)
+
+// S3Config holds the configuration options for the S3-compatible blob store
+// backend.
+//
+// nolint: lll
+type S3Config struct {
+	Endpoint string `long:"endpoint" description:"The base URL of the S3-compatible object store, for example https://s3.us-east-1.amazonaws.com; objects are addressed path-style"`
+
+	Bucket string `long:"bucket" description:"The bucket the blobs are stored in"`
+
+	Region string `long:"region" description:"The region of the bucket, used to sign requests"`
+
+	Prefix string `long:"prefix" description:"An optional prefix for the object keys of the blobs"`
+
+	AccessKeyID string `long:"accesskeyid" description:"The access key ID used to sign requests"`
+
+	SecretAccessKey string `long:"secretaccesskey" description:"The secret access key used to sign requests"`
+
+	Timeout time.Duration `long:"timeout" description:"The timeout for a single request against the object store"`
+}
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// content-addressed store of meta blobs.
+//
+// nolint: lll
+type CliConfig struct {
+	Backend string `long:"backend" description:"The backend meta blobs are stored in; fs uses a directory of the local file system, s3 an S3-compatible object store" choice:"fs" choice:"s3"`
+
+	Dir string `long:"dir" description:"The directory meta blobs are stored in if the fs backend is used"`
+
+	MaxSize uint64 `long:"maxsize" description:"The maximum size in bytes of a single meta blob"`
+
+	S3 *S3Config `group:"s3" namespace:"s3"`
+}
+
+// DefaultCliConfig returns the default meta blob store configuration. The
+// directory of the fs backend is set by the caller, as it depends on the
+// active network.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		Backend: BackendFS,
+		MaxSize: DefaultMaxSize,
+		S3: &S3Config{
+			Timeout: DefaultS3Timeout,
+		},
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if c.MaxSize == 0 {
+		return fmt.Errorf("maximum meta blob size must be positive")
+	}
+
+	switch c.Backend {
+	case BackendFS:
+		if c.Dir == "" {
+			return fmt.Errorf("meta blob directory must be set")
+		}
+
+		return nil
+
+	case BackendS3:
+		return c.S3.Validate()
+
+	default:
+		return fmt.Errorf("unknown meta blob backend: %v", c.Backend)
+	}
+}
+
+// Validate returns an error if the S3 configuration is invalid.
+func (c *S3Config) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("S3 endpoint must use http or https")
+	}
+
+	switch {
+	case c.Bucket == "":
+		return fmt.Errorf("S3 bucket must be set")
+
+	case c.Region == "":
+		return fmt.Errorf("S3 region must be set")
+
+	case c.AccessKeyID == "" || c.SecretAccessKey == "":
+		return fmt.Errorf("S3 credentials must be set")
+
+	case c.Timeout <= 0:
+		return fmt.Errorf("S3 timeout must be positive")
+	}
+
+	return nil
+}
+
+// NewStore creates the blob store for the configured backend.
+func (c *CliConfig) NewStore() (proof.MetaBlobStore, error) {
+	switch c.Backend {
+	case BackendFS:
+		return NewFSStore(c.Dir, c.MaxSize)
+
+	case BackendS3:
+		return NewS3Store(c.S3, c.MaxSize), nil
+
+	default:
+		return nil, fmt.Errorf("unknown meta blob backend: %v",
+			c.Backend)
+	}
+}
@@ -0,0 +1,126 @@
+package metablob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+const (
+	// tmpDirName is the name of the directory within the blob directory
+	// blobs are spooled to before they are moved into place. It lives on
+	// the same file system as the blobs, so the move is atomic.
+	tmpDirName = "tmp"
+)
+
+// FSStore is a blob store that stores blobs in a directory of the local file
+// system. Each blob is stored in a file named after the hex encoded hash of
+// the blob, in a sub directory named after the first byte of the hash.
+type FSStore struct {
+	dir     string
+	maxSize uint64
+}
+
+// A compile-time assertion to ensure FSStore meets the MetaBlobStore
+// interface.
+var _ proof.MetaBlobStore = (*FSStore)(nil)
+
+// NewFSStore creates a new file system blob store in the given directory,
+// which is created if it doesn't exist yet.
+func NewFSStore(dir string, maxSize uint64) (*FSStore, error) {
+	err := os.MkdirAll(filepath.Join(dir, tmpDirName), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create meta blob directory: "+
+			"%w", err)
+	}
+
+	return &FSStore{
+		dir:     dir,
+		maxSize: maxSize,
+	}, nil
+}
+
+// blobPath returns the path of the file the blob with the given reference is
+// stored in.
+func (s *FSStore) blobPath(ref proof.BlobRef) string {
+	name := ref.String()
+	return filepath.Join(s.dir, name[:2], name)
+}
+
+// PutBlob stores the blob read from the given reader and returns its
+// reference. If an expected reference is given, the blob is only stored if it
+// matches the reference.
+func (s *FSStore) PutBlob(_ context.Context, r io.Reader,
+	expected fn.Option[proof.BlobRef]) (proof.BlobRef, error) {
+
+	f, ref, err := spool(
+		r, filepath.Join(s.dir, tmpDirName), s.maxSize, expected,
+	)
+	if err != nil {
+		return proof.BlobRef{}, err
+	}
+	defer func() {
+		// The rename below moves the file away on success, so the
+		// removal is a no-op in that case.
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	if err := f.Sync(); err != nil {
+		return proof.BlobRef{}, fmt.Errorf("unable to sync blob: %w",
+			err)
+	}
+
+	blobPath := s.blobPath(ref)
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0700); err != nil {
+		return proof.BlobRef{}, fmt.Errorf("unable to create blob "+
+			"directory: %w", err)
+	}
+
+	// Blobs are content-addressed, so replacing an existing file with the
+	// same name doesn't change its content.
+	if err := os.Rename(f.Name(), blobPath); err != nil {
+		return proof.BlobRef{}, fmt.Errorf("unable to store blob: %w",
+			err)
+	}
+
+	log.Debugf("Stored meta blob %v (%d bytes)", ref, ref.Size)
+
+	return ref, nil
+}
+
+// GetBlob returns a reader for the blob with the given reference.
+func (s *FSStore) GetBlob(_ context.Context,
+	ref proof.BlobRef) (io.ReadCloser, error) {
+
+	f, err := os.Open(s.blobPath(ref))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("%w: %v", proof.ErrBlobNotFound, ref)
+
+	case err != nil:
+		return nil, fmt.Errorf("unable to open blob: %w", err)
+	}
+
+	return proof.NewBlobVerifier(f, ref), nil
+}
+
+// HasBlob returns true if the blob with the given reference is stored.
+func (s *FSStore) HasBlob(_ context.Context, ref proof.BlobRef) (bool, error) {
+	info, err := os.Stat(s.blobPath(ref))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return false, nil
+
+	case err != nil:
+		return false, fmt.Errorf("unable to stat blob: %w", err)
+	}
+
+	return uint64(info.Size()) == ref.Size, nil
+}
@@ -0,0 +1,346 @@
+package metablob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+const (
+	// sigV4Algorithm is the identifier of the AWS signature version 4
+	// algorithm.
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+
+	// sigV4Service is the service name requests are signed for.
+	sigV4Service = "s3"
+
+	// amzDateFormat is the format of the timestamp of a signed request.
+	amzDateFormat = "20060102T150405Z"
+
+	// maxErrorBodySize is the maximum size of an error response body we
+	// include in an error.
+	maxErrorBodySize = 1024
+)
+
+var (
+	// emptyPayloadHash is the hex encoded SHA-256 hash of an empty request
+	// body.
+	emptyPayloadHash = hex.EncodeToString(sha256.New().Sum(nil))
+)
+
+// S3Store is a blob store that stores blobs as objects in a bucket of an
+// S3-compatible object store. Each blob is stored under the hex encoded hash
+// of the blob, and requests are authenticated with AWS signature version 4.
+type S3Store struct {
+	cfg     *S3Config
+	maxSize uint64
+	client  *http.Client
+
+	// now returns the current time, used to sign requests.
+	now func() time.Time
+}
+
+// A compile-time assertion to ensure S3Store meets the MetaBlobStore
+// interface.
+var _ proof.MetaBlobStore = (*S3Store)(nil)
+
+// NewS3Store creates a new blob store for the given S3 bucket.
+func NewS3Store(cfg *S3Config, maxSize uint64) *S3Store {
+	return &S3Store{
+		cfg:     cfg,
+		maxSize: maxSize,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+		now: time.Now,
+	}
+}
+
+// objectURL returns the path-style URL of the object the blob with the given
+// reference is stored in.
+func (s *S3Store) objectURL(ref proof.BlobRef) string {
+	key := s.cfg.Prefix + ref.String()
+
+	return strings.TrimSuffix(s.cfg.Endpoint, "/") + "/" +
+		uriEncode(s.cfg.Bucket, true) + "/" + uriEncode(key, false)
+}
+
+// do signs and sends the given request.
+func (s *S3Store) do(req *http.Request,
+	payloadHash string) (*http.Response, error) {
+
+	signV4(
+		req, payloadHash, s.cfg.Region, s.cfg.AccessKeyID,
+		s.cfg.SecretAccessKey, s.now(),
+	)
+
+	return s.client.Do(req)
+}
+
+// PutBlob stores the blob read from the given reader and returns its
+// reference. If an expected reference is given, the blob is only stored if it
+// matches the reference.
+func (s *S3Store) PutBlob(ctx context.Context, r io.Reader,
+	expected fn.Option[proof.BlobRef]) (proof.BlobRef, error) {
+
+	// The blob is spooled to a temporary file first, as its hash and size
+	// must be known before the request is sent.
+	f, ref, err := spool(r, "", s.maxSize, expected)
+	if err != nil {
+		return proof.BlobRef{}, err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, s.objectURL(ref), io.NopCloser(f),
+	)
+	if err != nil {
+		return proof.BlobRef{}, err
+	}
+	req.ContentLength = int64(ref.Size)
+
+	resp, err := s.do(req, hex.EncodeToString(ref.Hash[:]))
+	if err != nil {
+		return proof.BlobRef{}, fmt.Errorf("unable to upload blob: %w",
+			err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return proof.BlobRef{}, fmt.Errorf("unable to upload blob: %w",
+			statusError(resp))
+	}
+
+	log.Debugf("Stored meta blob %v (%d bytes) in bucket %v", ref,
+		ref.Size, s.cfg.Bucket)
+
+	return ref, nil
+}
+
+// GetBlob returns a reader for the blob with the given reference.
+func (s *S3Store) GetBlob(ctx context.Context,
+	ref proof.BlobRef) (io.ReadCloser, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, s.objectURL(ref), nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download blob: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return proof.NewBlobVerifier(resp.Body, ref), nil
+
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %v", proof.ErrBlobNotFound, ref)
+
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("unable to download blob: %w",
+			statusError(resp))
+	}
+}
+
+// HasBlob returns true if the blob with the given reference is stored.
+func (s *S3Store) HasBlob(ctx context.Context,
+	ref proof.BlobRef) (bool, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodHead, s.objectURL(ref), nil,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return false, fmt.Errorf("unable to look up blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.ContentLength == int64(ref.Size), nil
+
+	case http.StatusNotFound:
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unable to look up blob: %w",
+			statusError(resp))
+	}
+}
+
+// statusError returns an error for an unexpected response status, including
+// the start of the response body, which usually describes the error.
+func statusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	return fmt.Errorf("unexpected status %v: %s", resp.Status,
+		strings.TrimSpace(string(body)))
+}
+
+// signV4 signs the given request with AWS signature version 4, using the
+// given hex encoded hash of the request payload. The host header, the range
+// header if present and all x-amz-* headers are signed.
+func signV4(req *http.Request, payloadHash, region, accessKeyID,
+	secretAccessKey string, now time.Time) {
+
+	amzDate := now.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{
+		"host": host,
+	}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name != "range" && !strings.HasPrefix(name, "x-amz-") {
+			continue
+		}
+
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{
+		date, region, sigV4Service, "aws4_request",
+	}, "/")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, sigV4Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// canonicalQuery returns the canonical query string of the given request, as
+// defined by AWS signature version 4.
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+
+		for _, value := range values {
+			params = append(
+				params, uriEncode(key, true)+"="+
+					uriEncode(value, true),
+			)
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes the given string as defined by AWS signature version 4,
+// which only leaves unreserved characters unencoded. Slashes are only encoded
+// if encodeSlash is true.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z',
+			c >= '0' && c <= '9', c == '-', c == '_', c == '.',
+			c == '~':
+
+			b.WriteByte(c)
+
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the given data with the given key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
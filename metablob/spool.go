@@ -0,0 +1,100 @@
+package metablob
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+var (
+	// ErrTooLarge is returned when a blob exceeds the maximum blob size.
+	ErrTooLarge = errors.New("meta blob too large")
+)
+
+// spool copies the blob read from the given reader into a temporary file in
+// the given directory while hashing it. The blob is rejected if it is empty,
+// exceeds the maximum size or doesn't match the expected reference. On
+// success, the caller owns the returned file, which is positioned at the
+// start of the blob, and must close and remove it.
+func spool(r io.Reader, dir string, maxSize uint64,
+	expected fn.Option[proof.BlobRef]) (*os.File, proof.BlobRef, error) {
+
+	// An expected blob larger than the maximum is rejected right away.
+	err := fn.MapOptionZ(expected, func(ref proof.BlobRef) error {
+		if ref.Size > maxSize {
+			return fmt.Errorf("%w: %d bytes exceeds maximum of %d",
+				ErrTooLarge, ref.Size, maxSize)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, proof.BlobRef{}, err
+	}
+
+	f, err := os.CreateTemp(dir, "blob-*.tmp")
+	if err != nil {
+		return nil, proof.BlobRef{}, fmt.Errorf("unable to create "+
+			"temporary blob file: %w", err)
+	}
+
+	cleanup := func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+
+	// We read one byte more than the maximum, so we can tell whether the
+	// blob exceeds it.
+	hasher := sha256.New()
+	w := io.MultiWriter(f, hasher)
+	n, err := io.Copy(w, io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		cleanup()
+		return nil, proof.BlobRef{}, fmt.Errorf("unable to write "+
+			"blob: %w", err)
+	}
+
+	switch {
+	case n == 0:
+		cleanup()
+		return nil, proof.BlobRef{}, fmt.Errorf("%w: blob must not be "+
+			"empty", proof.ErrInvalidBlobRef)
+
+	case uint64(n) > maxSize:
+		cleanup()
+		return nil, proof.BlobRef{}, fmt.Errorf("%w: blob exceeds "+
+			"maximum of %d bytes", ErrTooLarge, maxSize)
+	}
+
+	ref := proof.BlobRef{
+		Size: uint64(n),
+	}
+	copy(ref.Hash[:], hasher.Sum(nil))
+
+	err = fn.MapOptionZ(expected, func(expectedRef proof.BlobRef) error {
+		if ref != expectedRef {
+			return fmt.Errorf("%w: expected %v (%d bytes), got %v "+
+				"(%d bytes)", proof.ErrBlobMismatch,
+				expectedRef, expectedRef.Size, ref, ref.Size)
+		}
+
+		return nil
+	})
+	if err != nil {
+		cleanup()
+		return nil, proof.BlobRef{}, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, proof.BlobRef{}, fmt.Errorf("unable to rewind "+
+			"blob: %w", err)
+	}
+
+	return f, ref, nil
+}
@@ -0,0 +1,298 @@
+package metaschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// FungibleTokenID is the ID of the built-in schema for the metadata of
+	// fungible tokens.
+	FungibleTokenID = "taproot-assets/fungible-token/v1"
+
+	// MaxIDLength is the maximum length of a schema ID.
+	MaxIDLength = 128
+
+	// MaxDefinitionSize is the maximum size of a schema definition in
+	// bytes.
+	MaxDefinitionSize = 64 * 1024
+)
+
+var (
+	// ErrNotFound is returned when a schema that isn't registered is
+	// looked up.
+	ErrNotFound = errors.New("meta schema not found")
+
+	// ErrExists is returned when a schema is registered with an ID that is
+	// already taken.
+	ErrExists = errors.New("meta schema already exists")
+
+	// ErrInvalidMeta is returned when a meta blob doesn't conform to the
+	// schema it is validated against.
+	ErrInvalidMeta = errors.New("asset meta does not match schema")
+
+	// idPattern is the pattern schema IDs must match.
+	idPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/-]*$`)
+)
+
+// fungibleTokenDefinition is the definition of the built-in fungible token
+// schema. The decimal display field is the one tapd itself uses to display
+// asset amounts.
+var fungibleTokenDefinition = []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "Taproot Assets fungible token",
+  "type": "object",
+  "properties": {
+    "name": {
+      "type": "string",
+      "minLength": 1,
+      "maxLength": 64
+    },
+    "ticker": {
+      "type": "string",
+      "pattern": "^[A-Z0-9]{1,11}$"
+    },
+    "decimal_display": {
+      "type": "integer",
+      "minimum": 0,
+      "maximum": 12
+    },
+    "description": {
+      "type": "string"
+    }
+  },
+  "required": ["name", "ticker", "decimal_display"]
+}`)
+
+// Schema is a JSON schema that asset metadata can be validated against.
+type Schema struct {
+	// ID is the unique ID of the schema that is declared at mint time.
+	ID string
+
+	// Definition is the JSON schema definition.
+	Definition []byte
+
+	// Builtin indicates whether the schema is shipped with tapd, rather
+	// than registered by the user.
+	Builtin bool
+
+	// RegisteredAt is the time a custom schema was registered at. It is
+	// the zero time for built-in schemas.
+	RegisteredAt time.Time
+}
+
+// Builtins returns the schemas shipped with tapd.
+func Builtins() []Schema {
+	return []Schema{{
+		ID:         FungibleTokenID,
+		Definition: fungibleTokenDefinition,
+		Builtin:    true,
+	}}
+}
+
+// Store is the interface of the persistent storage of custom schemas.
+type Store interface {
+	// AddSchema stores a new schema. ErrExists is returned if a schema
+	// with the same ID is already stored.
+	AddSchema(ctx context.Context, id string,
+		definition []byte) (*Schema, error)
+
+	// FetchSchema returns the schema with the given ID. ErrNotFound is
+	// returned if no such schema is stored.
+	FetchSchema(ctx context.Context, id string) (*Schema, error)
+
+	// ListSchemas returns all stored schemas, ordered by the time they
+	// were registered at.
+	ListSchemas(ctx context.Context) ([]Schema, error)
+}
+
+// Registry combines the built-in schemas with the custom schemas of a store,
+// and validates asset metadata against them.
+type Registry struct {
+	store Store
+}
+
+// NewRegistry creates a new schema registry backed by the given store.
+func NewRegistry(store Store) *Registry {
+	return &Registry{
+		store: store,
+	}
+}
+
+// builtin returns the built-in schema with the given ID, if there is one.
+func builtin(id string) (*Schema, bool) {
+	for _, schema := range Builtins() {
+		if schema.ID == id {
+			return &schema, true
+		}
+	}
+
+	return nil, false
+}
+
+// Register checks and stores a custom schema. Schemas can't be replaced once
+// registered, as assets may already have been minted with them.
+func (r *Registry) Register(ctx context.Context, id string,
+	definition []byte) (*Schema, error) {
+
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+
+	if _, ok := builtin(id); ok {
+		return nil, fmt.Errorf("%w: %v is a built-in schema", ErrExists,
+			id)
+	}
+
+	if _, err := compile(definition); err != nil {
+		return nil, err
+	}
+
+	return r.store.AddSchema(ctx, id, definition)
+}
+
+// Fetch returns the built-in or custom schema with the given ID.
+func (r *Registry) Fetch(ctx context.Context, id string) (*Schema, error) {
+	if schema, ok := builtin(id); ok {
+		return schema, nil
+	}
+
+	return r.store.FetchSchema(ctx, id)
+}
+
+// List returns the built-in schemas, followed by the custom schemas.
+func (r *Registry) List(ctx context.Context) ([]Schema, error) {
+	custom, err := r.store.ListSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(Builtins(), custom...), nil
+}
+
+// ValidateMeta validates the given asset meta against the schema with the
+// given ID. Only JSON metadata can be validated against a schema.
+func (r *Registry) ValidateMeta(ctx context.Context, id string,
+	meta *proof.MetaReveal) error {
+
+	schema, err := r.Fetch(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return schema.ValidateMeta(meta)
+}
+
+// ValidateMeta validates the given asset meta against the schema.
+func (s *Schema) ValidateMeta(meta *proof.MetaReveal) error {
+	if meta == nil || meta.Type != proof.MetaJson {
+		return fmt.Errorf("%w: schema %v requires JSON asset meta",
+			ErrInvalidMeta, s.ID)
+	}
+
+	compiled, err := compile(s.Definition)
+	if err != nil {
+		return err
+	}
+
+	result, err := compiled.Validate(gojsonschema.NewBytesLoader(meta.Data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMeta, err)
+	}
+
+	if !result.Valid() {
+		violations := make([]string, 0, len(result.Errors()))
+		for _, violation := range result.Errors() {
+			violations = append(violations, violation.String())
+		}
+
+		return fmt.Errorf("%w %v: %v", ErrInvalidMeta, s.ID,
+			strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// ValidateID returns an error if the given schema ID is invalid.
+func ValidateID(id string) error {
+	switch {
+	case len(id) == 0:
+		return fmt.Errorf("schema ID must be set")
+
+	case len(id) > MaxIDLength:
+		return fmt.Errorf("schema ID must be at most %d characters",
+			MaxIDLength)
+
+	case !idPattern.MatchString(id):
+		return fmt.Errorf("schema ID %q contains invalid characters",
+			id)
+	}
+
+	return nil
+}
+
+// compile parses the given schema definition. To avoid the daemon fetching
+// remote documents while validating metadata, schemas may only reference
+// their own definitions.
+func compile(definition []byte) (*gojsonschema.Schema, error) {
+	if len(definition) > MaxDefinitionSize {
+		return nil, fmt.Errorf("schema definition exceeds %d bytes",
+			MaxDefinitionSize)
+	}
+
+	var parsed any
+	if err := json.Unmarshal(definition, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid schema definition: %w", err)
+	}
+
+	if err := checkLocalRefs(parsed); err != nil {
+		return nil, err
+	}
+
+	compiled, err := gojsonschema.NewSchema(
+		gojsonschema.NewBytesLoader(definition),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema definition: %w", err)
+	}
+
+	return compiled, nil
+}
+
+// checkLocalRefs returns an error if the given parsed JSON document contains
+// a reference to another document.
+func checkLocalRefs(node any) error {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			ref, isString := value.(string)
+			if key == "$ref" && isString &&
+				!strings.HasPrefix(ref, "#") {
+
+				return fmt.Errorf("invalid schema definition: "+
+					"remote reference %q not allowed", ref)
+			}
+
+			if err := checkLocalRefs(value); err != nil {
+				return err
+			}
+		}
+
+	case []any:
+		for _, value := range n {
+			if err := checkLocalRefs(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
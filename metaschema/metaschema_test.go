@@ -0,0 +1,171 @@
+package metaschema
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is an in-memory schema store.
+type mockStore struct {
+	schemas []Schema
+}
+
+func (m *mockStore) AddSchema(_ context.Context, id string,
+	definition []byte) (*Schema, error) {
+
+	for _, schema := range m.schemas {
+		if schema.ID == id {
+			return nil, fmt.Errorf("%w: %v", ErrExists, id)
+		}
+	}
+
+	schema := Schema{
+		ID:         id,
+		Definition: definition,
+	}
+	m.schemas = append(m.schemas, schema)
+
+	return &schema, nil
+}
+
+func (m *mockStore) FetchSchema(_ context.Context,
+	id string) (*Schema, error) {
+
+	for _, schema := range m.schemas {
+		if schema.ID == id {
+			return &schema, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrNotFound, id)
+}
+
+func (m *mockStore) ListSchemas(_ context.Context) ([]Schema, error) {
+	return m.schemas, nil
+}
+
+// jsonMeta returns a JSON asset meta with the given data.
+func jsonMeta(data string) *proof.MetaReveal {
+	return &proof.MetaReveal{
+		Type: proof.MetaJson,
+		Data: []byte(data),
+	}
+}
+
+// TestFungibleTokenSchema tests validating asset meta against the built-in
+// fungible token schema.
+func TestFungibleTokenSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := NewRegistry(&mockStore{})
+
+	testCases := []struct {
+		name  string
+		meta  *proof.MetaReveal
+		valid bool
+	}{{
+		name: "valid",
+		meta: jsonMeta(`{"name": "Tether", "ticker": "USDT", ` +
+			`"decimal_display": 6}`),
+		valid: true,
+	}, {
+		name: "missing ticker",
+		meta: jsonMeta(`{"name": "Tether", "decimal_display": 6}`),
+	}, {
+		name: "decimal display too large",
+		meta: jsonMeta(`{"name": "Tether", "ticker": "USDT", ` +
+			`"decimal_display": 13}`),
+	}, {
+		name: "lower case ticker",
+		meta: jsonMeta(`{"name": "Tether", "ticker": "usdt", ` +
+			`"decimal_display": 6}`),
+	}, {
+		name: "not json",
+		meta: jsonMeta(`name=Tether`),
+	}, {
+		name: "opaque meta",
+		meta: &proof.MetaReveal{
+			Type: proof.MetaOpaque,
+			Data: []byte(`{"name": "Tether", "ticker": "USDT", ` +
+				`"decimal_display": 6}`),
+		},
+	}, {
+		name: "no meta",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := registry.ValidateMeta(
+				ctx, FungibleTokenID, tc.meta,
+			)
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidMeta)
+		})
+	}
+}
+
+// TestRegistry tests registering custom schemas and validating asset meta
+// against them.
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := NewRegistry(&mockStore{})
+
+	// Built-in schemas can't be replaced.
+	_, err := registry.Register(ctx, FungibleTokenID, []byte(`{}`))
+	require.ErrorIs(t, err, ErrExists)
+
+	// Invalid IDs and definitions are rejected.
+	_, err = registry.Register(ctx, "", []byte(`{}`))
+	require.ErrorContains(t, err, "must be set")
+	_, err = registry.Register(ctx, "nft v1", []byte(`{}`))
+	require.ErrorContains(t, err, "invalid characters")
+	_, err = registry.Register(ctx, "nft/v1", []byte(`{"type":`))
+	require.ErrorContains(t, err, "invalid schema definition")
+	_, err = registry.Register(ctx, "nft/v1", []byte(`{"type": 5}`))
+	require.ErrorContains(t, err, "invalid schema definition")
+
+	// Schemas must not reference other documents.
+	_, err = registry.Register(ctx, "nft/v1", []byte(`{"properties": `+
+		`{"image": {"$ref": "https://example.com/image.json"}}}`))
+	require.ErrorContains(t, err, "remote reference")
+
+	nftDef := []byte(`{
+		"type": "object",
+		"definitions": {"uri": {"type": "string", "minLength": 1}},
+		"properties": {"image": {"$ref": "#/definitions/uri"}},
+		"required": ["image"]
+	}`)
+	_, err = registry.Register(ctx, "nft/v1", nftDef)
+	require.NoError(t, err)
+
+	_, err = registry.Register(ctx, "nft/v1", nftDef)
+	require.ErrorIs(t, err, ErrExists)
+
+	schemas, err := registry.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, schemas, 2)
+	require.True(t, schemas[0].Builtin)
+	require.Equal(t, FungibleTokenID, schemas[0].ID)
+	require.False(t, schemas[1].Builtin)
+	require.Equal(t, "nft/v1", schemas[1].ID)
+
+	err = registry.ValidateMeta(ctx, "nft/v1", jsonMeta(`{"image": "a"}`))
+	require.NoError(t, err)
+
+	err = registry.ValidateMeta(ctx, "nft/v1", jsonMeta(`{"image": ""}`))
+	require.ErrorIs(t, err, ErrInvalidMeta)
+
+	err = registry.ValidateMeta(ctx, "ticket/v1", jsonMeta(`{}`))
+	require.ErrorIs(t, err, ErrNotFound)
+}
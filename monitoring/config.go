@@ -1,6 +1,9 @@
 package monitoring
 
 import (
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/tapchannel"
 	"github.com/lightninglabs/taproot-assets/tapdb"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightninglabs/taproot-assets/universe"
@@ -34,6 +37,22 @@ type PrometheusConfig struct {
 	// asset minter.
 	AssetMinter tapgarden.Planter
 
+	// AuxInvoiceManager is used to collect any stats that are relevant to
+	// the processing of invoice HTLCs.
+	AuxInvoiceManager *tapchannel.AuxInvoiceManager
+
+	// Multiverse is used to collect the hit rates of the multiverse
+	// caches.
+	Multiverse *tapdb.MultiverseStore
+
+	// RfqManager is used to collect the metrics of the quote negotiations
+	// with our peers.
+	RfqManager *rfq.Manager
+
+	// ProofCourierDispatch is used to collect the statistics of the proof
+	// transfers of the proof couriers.
+	ProofCourierDispatch *proof.URLDispatch
+
 	// PerfHistograms indicates if the additional histogram information for
 	// latency, and handling time of gRPC calls should be enabled. This
 	// generates additional data, and consume more memory for the
@@ -0,0 +1,152 @@
+package monitoring
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// htlcModifierCollector is a Prometheus collector that exports metrics about
+// the processing of invoice HTLC modification requests.
+type htlcModifierCollector struct {
+	collectMx sync.Mutex
+
+	cfg      *PrometheusConfig
+	registry *prometheus.Registry
+
+	inFlight          prometheus.Gauge
+	maxProcessingTime prometheus.Gauge
+
+	numProcessed        *prometheus.Desc
+	totalProcessingTime *prometheus.Desc
+	numModified         *prometheus.Desc
+	numCancelled        *prometheus.Desc
+	totalAssetUnits     *prometheus.Desc
+	totalConvertedMsat  *prometheus.Desc
+}
+
+func newHtlcModifierCollector(cfg *PrometheusConfig,
+	registry *prometheus.Registry) (*htlcModifierCollector, error) {
+
+	if cfg == nil {
+		return nil, errors.New("htlc modifier collector prometheus " +
+			"cfg is nil")
+	}
+
+	if cfg.AuxInvoiceManager == nil {
+		return nil, errors.New("htlc modifier collector aux invoice " +
+			"manager is nil")
+	}
+
+	return &htlcModifierCollector{
+		cfg:      cfg,
+		registry: registry,
+		inFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "htlc_modifier_in_flight",
+				Help: "Number of invoice HTLC modification " +
+					"requests being processed",
+			},
+		),
+		maxProcessingTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "htlc_modifier_max_processing_seconds",
+				Help: "Longest time it took to process a " +
+					"single invoice HTLC modification " +
+					"request",
+			},
+		),
+		numProcessed: prometheus.NewDesc(
+			"htlc_modifier_processed_total",
+			"Total number of processed invoice HTLC modification "+
+				"requests", nil, nil,
+		),
+		totalProcessingTime: prometheus.NewDesc(
+			"htlc_modifier_processing_seconds_total",
+			"Total time spent processing invoice HTLC "+
+				"modification requests", nil, nil,
+		),
+		numModified: prometheus.NewDesc(
+			"htlc_modifier_modified_total",
+			"Total number of asset HTLCs whose amount was "+
+				"converted to milli-satoshis", nil, nil,
+		),
+		numCancelled: prometheus.NewDesc(
+			"htlc_modifier_cancelled_total",
+			"Total number of HTLCs that cancelled the HTLC set of "+
+				"an asset invoice", nil, nil,
+		),
+		totalAssetUnits: prometheus.NewDesc(
+			"htlc_modifier_converted_asset_units_total",
+			"Total asset units of the converted asset HTLCs",
+			nil, nil,
+		),
+		totalConvertedMsat: prometheus.NewDesc(
+			"htlc_modifier_converted_msat_total",
+			"Total milli-satoshis the asset HTLCs were converted "+
+				"to", nil, nil,
+		),
+	}, nil
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector to the provided channel and returns once the
+// last descriptor has been sent.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (h *htlcModifierCollector) Describe(ch chan<- *prometheus.Desc) {
+	h.collectMx.Lock()
+	defer h.collectMx.Unlock()
+
+	h.inFlight.Describe(ch)
+	h.maxProcessingTime.Describe(ch)
+
+	ch <- h.numProcessed
+	ch <- h.totalProcessingTime
+	ch <- h.numModified
+	ch <- h.numCancelled
+	ch <- h.totalAssetUnits
+	ch <- h.totalConvertedMsat
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (h *htlcModifierCollector) Collect(ch chan<- prometheus.Metric) {
+	h.collectMx.Lock()
+	defer h.collectMx.Unlock()
+
+	stats := h.cfg.AuxInvoiceManager.HtlcModifierStats()
+
+	h.inFlight.Set(float64(stats.InFlight))
+	h.maxProcessingTime.Set(stats.MaxProcessingTime.Seconds())
+
+	h.inFlight.Collect(ch)
+	h.maxProcessingTime.Collect(ch)
+
+	ch <- prometheus.MustNewConstMetric(
+		h.numProcessed, prometheus.CounterValue,
+		float64(stats.NumProcessed),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		h.totalProcessingTime, prometheus.CounterValue,
+		stats.TotalProcessingTime.Seconds(),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		h.numModified, prometheus.CounterValue,
+		float64(stats.NumModified),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		h.numCancelled, prometheus.CounterValue,
+		float64(stats.NumCancelled),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		h.totalAssetUnits, prometheus.CounterValue,
+		float64(stats.TotalAssetUnits),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		h.totalConvertedMsat, prometheus.CounterValue,
+		float64(stats.TotalConvertedMsat),
+	)
+}
@@ -0,0 +1,72 @@
+package monitoring
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// multiverseCacheCollector is a Prometheus collector that exports the hit and
+// miss counters of the multiverse caches.
+type multiverseCacheCollector struct {
+	cfg      *PrometheusConfig
+	registry *prometheus.Registry
+
+	hits   *prometheus.Desc
+	misses *prometheus.Desc
+}
+
+func newMultiverseCacheCollector(cfg *PrometheusConfig,
+	registry *prometheus.Registry) (*multiverseCacheCollector, error) {
+
+	if cfg == nil {
+		return nil, errors.New("multiverse cache collector " +
+			"prometheus cfg is nil")
+	}
+
+	if cfg.Multiverse == nil {
+		return nil, errors.New("multiverse cache collector " +
+			"multiverse store is nil")
+	}
+
+	return &multiverseCacheCollector{
+		cfg:      cfg,
+		registry: registry,
+		hits: prometheus.NewDesc(
+			"multiverse_cache_hits_total",
+			"Total number of multiverse lookups served by the "+
+				"cache", []string{"cache"}, nil,
+		),
+		misses: prometheus.NewDesc(
+			"multiverse_cache_misses_total",
+			"Total number of multiverse lookups not served by the "+
+				"cache", []string{"cache"}, nil,
+		),
+	}, nil
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector to the provided channel and returns once the
+// last descriptor has been sent.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (m *multiverseCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.hits
+	ch <- m.misses
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (m *multiverseCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range m.cfg.Multiverse.CacheStats() {
+		ch <- prometheus.MustNewConstMetric(
+			m.hits, prometheus.CounterValue, float64(stats.Hits),
+			stats.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.misses, prometheus.CounterValue,
+			float64(stats.Misses), stats.Name,
+		)
+	}
+}
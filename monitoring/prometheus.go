@@ -79,6 +79,36 @@ func (p *PrometheusExporter) Start() error {
 	}
 	p.registry.MustRegister(dbCollector)
 
+	htlcModifierCollector, err := newHtlcModifierCollector(
+		p.config, p.registry,
+	)
+	if err != nil {
+		return err
+	}
+	p.registry.MustRegister(htlcModifierCollector)
+
+	multiverseCacheCollector, err := newMultiverseCacheCollector(
+		p.config, p.registry,
+	)
+	if err != nil {
+		return err
+	}
+	p.registry.MustRegister(multiverseCacheCollector)
+
+	rfqCollector, err := newRfqCollector(p.config, p.registry)
+	if err != nil {
+		return err
+	}
+	p.registry.MustRegister(rfqCollector)
+
+	proofCourierCollector, err := newProofCourierCollector(
+		p.config, p.registry,
+	)
+	if err != nil {
+		return err
+	}
+	p.registry.MustRegister(proofCourierCollector)
+
 	// Make ensure that all metrics exist when collecting and querying.
 	serverMetrics.InitializeMetrics(p.config.RPCServer)
 
@@ -0,0 +1,91 @@
+package monitoring
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// proofCourierCollector is a Prometheus collector that exports metrics about
+// the proof transfers of the proof couriers.
+type proofCourierCollector struct {
+	cfg      *PrometheusConfig
+	registry *prometheus.Registry
+
+	attempts *prometheus.Desc
+	outcomes *prometheus.Desc
+}
+
+func newProofCourierCollector(cfg *PrometheusConfig,
+	registry *prometheus.Registry) (*proofCourierCollector, error) {
+
+	if cfg == nil {
+		return nil, errors.New("proof courier collector prometheus " +
+			"cfg is nil")
+	}
+
+	if cfg.ProofCourierDispatch == nil {
+		return nil, errors.New("proof courier collector courier " +
+			"dispatch is nil")
+	}
+
+	return &proofCourierCollector{
+		cfg:      cfg,
+		registry: registry,
+		attempts: prometheus.NewDesc(
+			"proof_courier_attempts_total",
+			"Total number of proof transfer attempts, including "+
+				"retries, by transfer direction",
+			[]string{"direction"}, nil,
+		),
+		outcomes: prometheus.NewDesc(
+			"proof_courier_transfers_total",
+			"Total number of finished proof transfers, by "+
+				"transfer direction and outcome",
+			[]string{"direction", "outcome"}, nil,
+		),
+	}, nil
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector to the provided channel and returns once the
+// last descriptor has been sent.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (p *proofCourierCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.attempts
+	ch <- p.outcomes
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (p *proofCourierCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := p.cfg.ProofCourierDispatch.Stats()
+
+	ch <- prometheus.MustNewConstMetric(
+		p.attempts, prometheus.CounterValue,
+		float64(stats.DeliveryAttempts), "send",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		p.attempts, prometheus.CounterValue,
+		float64(stats.ReceiveAttempts), "receive",
+	)
+
+	outcomes := []struct {
+		direction string
+		outcome   string
+		count     uint64
+	}{
+		{"send", "success", stats.DeliverySuccesses},
+		{"send", "failure", stats.DeliveryFailures},
+		{"receive", "success", stats.ReceiveSuccesses},
+		{"receive", "failure", stats.ReceiveFailures},
+	}
+	for _, o := range outcomes {
+		ch <- prometheus.MustNewConstMetric(
+			p.outcomes, prometheus.CounterValue, float64(o.count),
+			o.direction, o.outcome,
+		)
+	}
+}
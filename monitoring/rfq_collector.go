@@ -0,0 +1,113 @@
+package monitoring
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rfqCollector is a Prometheus collector that exports metrics about the quote
+// negotiations of the RFQ manager.
+type rfqCollector struct {
+	cfg      *PrometheusConfig
+	registry *prometheus.Registry
+
+	requestsSent     *prometheus.Desc
+	requestsReceived *prometheus.Desc
+	responses        *prometheus.Desc
+	responseLatency  *prometheus.Desc
+}
+
+func newRfqCollector(cfg *PrometheusConfig,
+	registry *prometheus.Registry) (*rfqCollector, error) {
+
+	if cfg == nil {
+		return nil, errors.New("rfq collector prometheus cfg is nil")
+	}
+
+	if cfg.RfqManager == nil {
+		return nil, errors.New("rfq collector rfq manager is nil")
+	}
+
+	return &rfqCollector{
+		cfg:      cfg,
+		registry: registry,
+		requestsSent: prometheus.NewDesc(
+			"rfq_quote_requests_sent_total",
+			"Total number of quote requests sent to peers",
+			nil, nil,
+		),
+		requestsReceived: prometheus.NewDesc(
+			"rfq_quote_requests_received_total",
+			"Total number of quote requests received from peers",
+			nil, nil,
+		),
+		responses: prometheus.NewDesc(
+			"rfq_quote_responses_total",
+			"Total number of responses to quote requests, by the "+
+				"responding party and the outcome",
+			[]string{"responder", "outcome"}, nil,
+		),
+		responseLatency: prometheus.NewDesc(
+			"rfq_quote_response_latency_seconds",
+			"Time it took peers to respond to our quote requests",
+			nil, nil,
+		),
+	}, nil
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector to the provided channel and returns once the
+// last descriptor has been sent.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (r *rfqCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.requestsSent
+	ch <- r.requestsReceived
+	ch <- r.responses
+	ch <- r.responseLatency
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (r *rfqCollector) Collect(ch chan<- prometheus.Metric) {
+	metrics := r.cfg.RfqManager.QuoteMetrics()
+
+	ch <- prometheus.MustNewConstMetric(
+		r.requestsSent, prometheus.CounterValue,
+		float64(metrics.RequestsSent),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		r.requestsReceived, prometheus.CounterValue,
+		float64(metrics.RequestsReceived),
+	)
+
+	responses := []struct {
+		responder string
+		outcome   string
+		count     uint64
+	}{
+		{"peer", "accepted", metrics.PeerAccepted},
+		{"peer", "invalid", metrics.PeerInvalid},
+		{"peer", "rejected", metrics.PeerRejected},
+		{"local", "accepted", metrics.LocalAccepted},
+		{"local", "rejected", metrics.LocalRejected},
+	}
+	for _, resp := range responses {
+		ch <- prometheus.MustNewConstMetric(
+			r.responses, prometheus.CounterValue,
+			float64(resp.count), resp.responder, resp.outcome,
+		)
+	}
+
+	latency := metrics.ResponseLatency
+	buckets := make(map[float64]uint64, len(latency.Buckets))
+	for idx, bucket := range latency.Buckets {
+		buckets[bucket.Seconds()] = latency.Counts[idx]
+	}
+	ch <- prometheus.MustNewConstHistogram(
+		r.responseLatency, latency.Count, latency.Sum.Seconds(),
+		buckets,
+	)
+}
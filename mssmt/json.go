@@ -0,0 +1,75 @@
+package mssmt
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// JSONNode is the JSON representation of a MS-SMT node. The sum is encoded as
+// a decimal string, since JSON numbers can't represent all uint64 values
+// without losing precision in many implementations.
+type JSONNode struct {
+	Hash string `json:"hash"`
+	Sum  string `json:"sum"`
+}
+
+// NewJSONNode creates the JSON representation of the given node.
+func NewJSONNode(node Node) *JSONNode {
+	nodeHash := node.NodeHash()
+	return &JSONNode{
+		Hash: nodeHash.String(),
+		Sum:  strconv.FormatUint(node.NodeSum(), 10),
+	}
+}
+
+// ToNode parses the JSON representation of a node.
+func (n *JSONNode) ToNode() (ComputedNode, error) {
+	hashBytes, err := hex.DecodeString(n.Hash)
+	if err != nil {
+		return ComputedNode{}, fmt.Errorf("invalid node hash: %w", err)
+	}
+	if len(hashBytes) != hashSize {
+		return ComputedNode{}, fmt.Errorf("invalid node hash length "+
+			"%d, expected %d", len(hashBytes), hashSize)
+	}
+
+	sum, err := strconv.ParseUint(n.Sum, 10, 64)
+	if err != nil {
+		return ComputedNode{}, fmt.Errorf("invalid node sum: %w", err)
+	}
+
+	var hash NodeHash
+	copy(hash[:], hashBytes)
+
+	return NewComputedNode(hash, sum), nil
+}
+
+// EncodeHexProof returns the hex encoded compressed serialization of the given
+// merkle proof, which is how merkle proofs are represented in JSON.
+func EncodeHexProof(p *Proof) (string, error) {
+	var buf bytes.Buffer
+	if err := p.Compress().Encode(&buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeHexProof parses the hex encoded compressed serialization of a merkle
+// proof.
+func DecodeHexProof(proofHex string) (*Proof, error) {
+	proofBytes, err := hex.DecodeString(proofHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle proof: %w", err)
+	}
+
+	var compressedProof CompressedProof
+	err = compressedProof.Decode(bytes.NewReader(proofBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode merkle proof: %w", err)
+	}
+
+	return compressedProof.Decompress()
+}
@@ -68,6 +68,19 @@ func NewLeafNode(value []byte, sum uint64) *LeafNode {
 	}
 }
 
+// NewComputedLeafNode constructs a new leaf node with a known node hash. This
+// allows a store to hold a leaf of which the value is only a reference to the
+// data the node hash commits to.
+func NewComputedLeafNode(value []byte, sum uint64,
+	nodeHash NodeHash) *LeafNode {
+
+	return &LeafNode{
+		nodeHash: &nodeHash,
+		Value:    value,
+		sum:      sum,
+	}
+}
+
 // NodeHash returns the unique identifier for a MS-SMT node. It represents the
 // hash of the leaf committing to its internal data.
 func (n *LeafNode) NodeHash() NodeHash {
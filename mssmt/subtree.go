@@ -0,0 +1,306 @@
+package mssmt
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// MaxSubtreeLevels is the maximum number of levels below a node that
+	// can be queried at once with SubtreeNodes. This limits the number of
+	// nodes returned for a single path to 2^MaxSubtreeLevels.
+	MaxSubtreeLevels = 8
+)
+
+// NodePath identifies a node within an MS-SMT by the path that leads from the
+// root to the node. The path is given by the first Depth bits of Key, using the
+// same bit order the tree uses to walk down to a leaf.
+type NodePath struct {
+	// Key holds the bits of the path. All bits after the first Depth bits
+	// are zero.
+	Key [hashSize]byte
+
+	// Depth is the number of levels below the root the node is at. The
+	// root node has a depth of zero, leaves have a depth of MaxTreeLevels.
+	Depth int
+}
+
+// RootPath is the path of the root node of a tree.
+var RootPath = NodePath{}
+
+// Child returns the path of the left (bit 0) or right (bit 1) child of the
+// node at this path.
+func (p NodePath) Child(bit byte) NodePath {
+	child := NodePath{
+		Key:   p.Key,
+		Depth: p.Depth + 1,
+	}
+	if bit == 1 {
+		child.Key[p.Depth/8] |= 1 << (p.Depth % 8)
+	}
+
+	return child
+}
+
+// Descendants returns the paths of all nodes found the given number of levels
+// below the node at this path, ordered from left to right.
+func (p NodePath) Descendants(levels int) []NodePath {
+	paths := []NodePath{p}
+	for i := 0; i < levels; i++ {
+		children := make([]NodePath, 0, len(paths)*2)
+		for _, path := range paths {
+			children = append(
+				children, path.Child(0), path.Child(1),
+			)
+		}
+		paths = children
+	}
+
+	return paths
+}
+
+// IsPrefixOf returns true if the given key lies within the subtree of the node
+// at this path.
+func (p NodePath) IsPrefixOf(key [hashSize]byte) bool {
+	for i := 0; i < p.Depth; i++ {
+		if bitIndex(uint8(i), &p.Key) != bitIndex(uint8(i), &key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate returns an error if the path isn't a valid node path.
+func (p NodePath) Validate() error {
+	if p.Depth < 0 || p.Depth > MaxTreeLevels {
+		return fmt.Errorf("invalid path depth %d", p.Depth)
+	}
+
+	// All bits after the path depth must be zero, so two equal paths
+	// always have the same key.
+	for i := p.Depth; i < MaxTreeLevels; i++ {
+		if bitIndex(uint8(i), &p.Key) != 0 {
+			return fmt.Errorf("path key has bits set after depth "+
+				"%d", p.Depth)
+		}
+	}
+
+	return nil
+}
+
+// SubtreeNode is a node of an MS-SMT together with its position in the tree.
+type SubtreeNode struct {
+	// Path is the path of the node within the tree.
+	Path NodePath
+
+	// Node is the node found at the path.
+	Node Node
+
+	// LeafKey is the key of the only leaf within the subtree rooted at this
+	// node. It is nil if the subtree is empty or holds more than one leaf.
+	LeafKey *[hashSize]byte
+}
+
+// IsEmpty returns true if the subtree rooted at the node is empty.
+func (s *SubtreeNode) IsEmpty() bool {
+	return s.Node.NodeHash() == EmptyTree[s.Path.Depth].NodeHash()
+}
+
+// HoldsLeaf returns true if the subtree rooted at the node only holds the given
+// leaf node at the node's leaf key.
+func (s *SubtreeNode) HoldsLeaf(leaf Node) bool {
+	if s.LeafKey == nil {
+		return false
+	}
+
+	// Recreate the branches between the leaf and the subtree root, as it
+	// is done for compacted leaves.
+	current := leaf
+	for i := lastBitIndex; i >= s.Path.Depth; i-- {
+		if bitIndex(uint8(i), s.LeafKey) == 0 {
+			current = NewBranch(current, EmptyTree[i+1])
+		} else {
+			current = NewBranch(EmptyTree[i+1], current)
+		}
+	}
+
+	return IsEqualNode(current, s.Node)
+}
+
+// SubtreeRoot returns the root of the subtree that is made up of the given
+// nodes. The nodes must all be at the same depth and ordered from left to
+// right, as returned by SubtreeNodes.
+func SubtreeRoot(nodes []Node) (Node, error) {
+	if len(nodes) == 0 || len(nodes)&(len(nodes)-1) != 0 {
+		return nil, fmt.Errorf("invalid number of subtree nodes %d, "+
+			"must be a power of two", len(nodes))
+	}
+
+	level := nodes
+	for len(level) > 1 {
+		parents := make([]Node, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			parents = append(
+				parents, NewBranch(level[i], level[i+1]),
+			)
+		}
+		level = parents
+	}
+
+	return level[0], nil
+}
+
+// SubtreeNodes returns the nodes found the given number of levels below the
+// node at the given path, ordered from left to right. Comparing these nodes to
+// the ones of another tree allows to find the subtrees the two trees differ in
+// without transferring the whole tree.
+func (t *CompactedTree) SubtreeNodes(ctx context.Context, path NodePath,
+	levels int) ([]SubtreeNode, error) {
+
+	if err := path.Validate(); err != nil {
+		return nil, err
+	}
+	if levels < 0 || levels > MaxSubtreeLevels {
+		return nil, fmt.Errorf("invalid number of levels %d, must be "+
+			"between 0 and %d", levels, MaxSubtreeLevels)
+	}
+
+	targetDepth := path.Depth + levels
+	if targetDepth > MaxTreeLevels {
+		return nil, fmt.Errorf("invalid number of levels %d for path "+
+			"depth %d", levels, path.Depth)
+	}
+
+	nodes := make([]SubtreeNode, 0, 1<<levels)
+	err := t.store.View(ctx, func(tx TreeStoreViewTx) error {
+		root, err := tx.RootNode()
+		if err != nil {
+			return err
+		}
+
+		nodes = nodes[:0]
+		return collectSubtreeNodes(
+			tx, root, RootPath, path, targetDepth, &nodes,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// collectSubtreeNodes walks down the tree from the given node to all nodes at
+// the target depth that lie below the given path and adds them to the result.
+func collectSubtreeNodes(tx TreeStoreViewTx, node Node, nodePath,
+	path NodePath, targetDepth int, result *[]SubtreeNode) error {
+
+	depth := nodePath.Depth
+
+	// Until we've reached the depth of the requested path, we only follow
+	// the path itself. Below it, we visit all nodes.
+	if depth <= path.Depth && !nodePath.isAncestorOf(path) {
+		return nil
+	}
+
+	switch n := node.(type) {
+	// A compacted leaf means the subtree holds exactly one leaf, so we
+	// don't need to query any further nodes.
+	case *CompactedLeafNode:
+		leafKey := n.Key()
+		addCompactedSubtree(
+			n, leafKey, nodePath, path, targetDepth, result,
+		)
+
+		return nil
+
+	// Leaves can only be found at the maximum depth, where the node path
+	// is the key of the leaf.
+	case *LeafNode:
+		leafKey := nodePath.Key
+		subtreeNode := SubtreeNode{
+			Path: nodePath,
+			Node: n,
+		}
+		if !IsEqualNode(n, EmptyLeafNode) {
+			subtreeNode.LeafKey = &leafKey
+		}
+		*result = append(*result, subtreeNode)
+
+		return nil
+	}
+
+	if depth == targetDepth {
+		*result = append(*result, SubtreeNode{
+			Path: nodePath,
+			Node: node,
+		})
+
+		return nil
+	}
+
+	// If the whole subtree is empty, there's no need to query the
+	// children from the store.
+	var left, right Node
+	if node.NodeHash() == EmptyTree[depth].NodeHash() {
+		left, right = EmptyTree[depth+1], EmptyTree[depth+1]
+	} else {
+		var err error
+		left, right, err = tx.GetChildren(depth, node.NodeHash())
+		if err != nil {
+			return err
+		}
+	}
+
+	err := collectSubtreeNodes(
+		tx, left, nodePath.Child(0), path, targetDepth, result,
+	)
+	if err != nil {
+		return err
+	}
+
+	return collectSubtreeNodes(
+		tx, right, nodePath.Child(1), path, targetDepth, result,
+	)
+}
+
+// addCompactedSubtree adds all nodes at the target depth below the given path
+// to the result, for a subtree at nodePath that is represented by the given
+// compacted leaf.
+func addCompactedSubtree(leaf *CompactedLeafNode, leafKey [hashSize]byte,
+	nodePath, path NodePath, targetDepth int, result *[]SubtreeNode) {
+
+	// The nodes are added for the deeper one of the two paths.
+	start := nodePath
+	if path.Depth > nodePath.Depth {
+		start = path
+	}
+
+	descendants := start.Descendants(targetDepth - start.Depth)
+	for _, descendant := range descendants {
+		// Only the node on the path to the leaf is part of the subtree,
+		// all other nodes are empty.
+		if !descendant.IsPrefixOf(leafKey) {
+			*result = append(*result, SubtreeNode{
+				Path: descendant,
+				Node: EmptyTree[targetDepth],
+			})
+
+			continue
+		}
+
+		key := leafKey
+		*result = append(*result, SubtreeNode{
+			Path:    descendant,
+			Node:    leaf.Extract(targetDepth - 1),
+			LeafKey: &key,
+		})
+	}
+}
+
+// isAncestorOf returns true if the node at this path is an ancestor of the node
+// at the given path.
+func (p NodePath) isAncestorOf(other NodePath) bool {
+	return p.Depth <= other.Depth && p.IsPrefixOf(other.Key)
+}
@@ -0,0 +1,180 @@
+package mssmt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/stretchr/testify/require"
+)
+
+// subtreeRoot returns the root of the subtree made up of the given nodes.
+func subtreeRoot(t *testing.T, nodes []mssmt.SubtreeNode) mssmt.Node {
+	root, err := mssmt.SubtreeRoot(
+		fn.Map(nodes, func(n mssmt.SubtreeNode) mssmt.Node {
+			return n.Node
+		}),
+	)
+	require.NoError(t, err)
+
+	return root
+}
+
+// diffTrees returns the keys of all leaves in the remote tree that are missing
+// or different in the local tree, by only comparing subtree nodes.
+func diffTrees(t *testing.T, local, remote *mssmt.CompactedTree,
+	levels int) [][hashSize]byte {
+
+	ctx := context.Background()
+
+	var (
+		missing [][hashSize]byte
+		paths   = []mssmt.NodePath{mssmt.RootPath}
+	)
+	for len(paths) > 0 {
+		var nextPaths []mssmt.NodePath
+		for _, path := range paths {
+			depth := min(levels, mssmt.MaxTreeLevels-path.Depth)
+
+			remoteNodes, err := remote.SubtreeNodes(
+				ctx, path, depth,
+			)
+			require.NoError(t, err)
+			localNodes, err := local.SubtreeNodes(
+				ctx, path, depth,
+			)
+			require.NoError(t, err)
+			require.Len(t, localNodes, len(remoteNodes))
+
+			for i, remoteNode := range remoteNodes {
+				localNode := localNodes[i]
+				require.Equal(
+					t, remoteNode.Path, localNode.Path,
+				)
+
+				switch {
+				case mssmt.IsEqualNode(
+					remoteNode.Node, localNode.Node,
+				):
+					continue
+
+				case remoteNode.IsEmpty():
+					continue
+
+				// The remote subtree only holds a single leaf.
+				// The local subtree might still contain that
+				// same leaf next to others, so we look it up
+				// directly.
+				case remoteNode.LeafKey != nil:
+					leafPath := mssmt.NodePath{
+						Key:   *remoteNode.LeafKey,
+						Depth: mssmt.MaxTreeLevels,
+					}
+					leaves, err := local.SubtreeNodes(
+						ctx, leafPath, 0,
+					)
+					require.NoError(t, err)
+
+					leaf := leaves[0].Node
+					if remoteNode.HoldsLeaf(leaf) {
+						continue
+					}
+
+					missing = append(
+						missing, *remoteNode.LeafKey,
+					)
+
+				default:
+					nextPaths = append(
+						nextPaths, remoteNode.Path,
+					)
+				}
+			}
+		}
+
+		paths = nextPaths
+	}
+
+	return missing
+}
+
+// TestSubtreeNodes tests that the subtree nodes returned by a compacted tree
+// are consistent with its root and can be used to find the leaves two trees
+// differ in.
+func TestSubtreeNodes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	shared := randTree(200)
+	localOnly := randTree(5)
+	remoteOnly := randTree(10)
+
+	local := mssmt.NewCompactedTree(mssmt.NewDefaultStore())
+	remote := mssmt.NewCompactedTree(mssmt.NewDefaultStore())
+	for _, item := range append(shared, localOnly...) {
+		_, err := local.Insert(ctx, item.key, item.leaf)
+		require.NoError(t, err)
+	}
+	for _, item := range append(shared, remoteOnly...) {
+		_, err := remote.Insert(ctx, item.key, item.leaf)
+		require.NoError(t, err)
+	}
+
+	// The nodes a few levels below the root must hash up to the root.
+	for levels := 0; levels <= mssmt.MaxSubtreeLevels; levels++ {
+		nodes, err := remote.SubtreeNodes(ctx, mssmt.RootPath, levels)
+		require.NoError(t, err)
+		require.Len(t, nodes, 1<<levels)
+
+		root, err := remote.Root(ctx)
+		require.NoError(t, err)
+		require.True(t, mssmt.IsEqualNode(root, subtreeRoot(t, nodes)))
+	}
+
+	// The nodes below an inner node must hash up to that node.
+	nodes, err := remote.SubtreeNodes(ctx, mssmt.RootPath, 3)
+	require.NoError(t, err)
+	for _, node := range nodes {
+		children, err := remote.SubtreeNodes(ctx, node.Path, 5)
+		require.NoError(t, err)
+		require.True(t, mssmt.IsEqualNode(
+			node.Node, subtreeRoot(t, children),
+		))
+	}
+
+	// Invalid requests are rejected.
+	_, err = remote.SubtreeNodes(
+		ctx, mssmt.RootPath, mssmt.MaxSubtreeLevels+1,
+	)
+	require.Error(t, err)
+	_, err = remote.SubtreeNodes(
+		ctx, mssmt.NodePath{Depth: mssmt.MaxTreeLevels}, 1,
+	)
+	require.Error(t, err)
+	_, err = remote.SubtreeNodes(
+		ctx, mssmt.NodePath{Key: [hashSize]byte{0xff}, Depth: 4}, 1,
+	)
+	require.Error(t, err)
+
+	// Diffing the two trees only yields the leaves that are missing in
+	// the local tree.
+	for _, levels := range []int{1, 4, mssmt.MaxSubtreeLevels} {
+		missing := diffTrees(t, local, remote, levels)
+
+		expected := fn.Map(remoteOnly, func(l treeLeaf) [hashSize]byte {
+			return l.key
+		})
+		require.ElementsMatch(t, expected, missing)
+	}
+
+	// A single leaf path can also be queried directly.
+	leaf := shared[0]
+	leafPath := mssmt.NodePath{Key: leaf.key, Depth: mssmt.MaxTreeLevels}
+	nodes, err = remote.SubtreeNodes(ctx, leafPath, 0)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.True(t, mssmt.IsEqualNode(leaf.leaf, nodes[0].Node))
+	require.Equal(t, leaf.key, *nodes[0].LeafKey)
+}
@@ -28,6 +28,10 @@ var (
 			Entity: "assets",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/ExportAnchorDescriptors": {{
+			Entity: "assets",
+			Action: "read",
+		}},
 		"/taprpc.TaprootAssets/ListGroups": {{
 			Entity: "assets",
 			Action: "read",
@@ -40,6 +44,10 @@ var (
 			Entity: "assets",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/ExportLedger": {{
+			Entity: "assets",
+			Action: "read",
+		}},
 		"/taprpc.TaprootAssets/QueryAddrs": {{
 			Entity: "addresses",
 			Action: "read",
@@ -52,6 +60,10 @@ var (
 			Entity: "addresses",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/InspectAddr": {{
+			Entity: "addresses",
+			Action: "read",
+		}},
 		"/taprpc.TaprootAssets/AddrReceives": {{
 			Entity: "addresses",
 			Action: "read",
@@ -60,6 +72,10 @@ var (
 			Entity: "proofs",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/CompatibilityReport": {{
+			Entity: "proofs",
+			Action: "read",
+		}},
 		"/taprpc.TaprootAssets/DecodeProof": {{
 			Entity: "proofs",
 			Action: "read",
@@ -68,6 +84,20 @@ var (
 			Entity: "proofs",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/ExportAllProofs": {{
+			Entity: "proofs",
+			Action: "read",
+		}, {
+			Entity: "addresses",
+			Action: "read",
+		}},
+		"/taprpc.TaprootAssets/ImportProofArchive": {{
+			Entity: "proofs",
+			Action: "write",
+		}, {
+			Entity: "addresses",
+			Action: "write",
+		}},
 		"/taprpc.TaprootAssets/SendAsset": {{
 			Entity: "assets",
 			Action: "write",
@@ -84,6 +114,14 @@ var (
 			Entity: "assets",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/UploadMetaBlob": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/taprpc.TaprootAssets/FetchMetaBlob": {{
+			Entity: "assets",
+			Action: "read",
+		}},
 		"/taprpc.TaprootAssets/SubscribeReceiveEvents": {{
 			Entity: "assets",
 			Action: "read",
@@ -92,6 +130,48 @@ var (
 			Entity: "assets",
 			Action: "read",
 		}},
+		"/taprpc.TaprootAssets/ListJobs": {{
+			Entity: "daemon",
+			Action: "read",
+		}},
+		"/taprpc.TaprootAssets/CancelJob": {{
+			Entity: "daemon",
+			Action: "write",
+		}},
+		"/taprpc.TaprootAssets/SubscribeJobUpdates": {{
+			Entity: "daemon",
+			Action: "read",
+		}},
+		"/taprpc.TaprootAssets/ExportRpcJournal": {{
+			Entity: "daemon",
+			Action: "read",
+		}},
+		"/taprpc.TaprootAssets/SubscribeReplicationChanges": {{
+			Entity: "assets",
+			Action: "read",
+		}, {
+			Entity: "addresses",
+			Action: "read",
+		}, {
+			Entity: "proofs",
+			Action: "read",
+		}},
+		"/taprpc.TaprootAssets/Compact": {{
+			Entity: "daemon",
+			Action: "write",
+		}},
+		"/taprpc.TaprootAssets/UnlockDatabase": {{
+			Entity: "daemon",
+			Action: "write",
+		}},
+		"/taprpc.TaprootAssets/RecoverAssets": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/taprpc.TaprootAssets/ConsolidateAssets": {{
+			Entity: "assets",
+			Action: "write",
+		}},
 		"/assetwalletrpc.AssetWallet/FundVirtualPsbt": {{
 			Entity: "assets",
 			Action: "write",
@@ -144,10 +224,138 @@ var (
 			Entity: "assets",
 			Action: "write",
 		}},
+		"/assetwalletrpc.AssetWallet/NewAccount": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/ListAccounts": {{
+			Entity: "assets",
+			Action: "read",
+		}},
+		"/assetwalletrpc.AssetWallet/NewIssuerPolicyScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SignIssuerPolicyPsbt": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/FreezeScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/UnfreezeScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/ListFrozenScriptKeys": {{
+			Entity: "assets",
+			Action: "read",
+		}},
+		"/assetwalletrpc.AssetWallet/NewMuSig2ScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/CreateMuSig2Nonce": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SignMuSig2Input": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/CombineMuSig2Sigs": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/NewHashLockScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SweepHashLock": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/NewTimeLockScriptKey": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SendTimeLocked": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SweepTimeLock": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/CreateSigningSession": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/ContributeToSession": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/StartSessionSigning": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/SubmitSessionSignatures": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/FinalizeSession": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/AnchorSession": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/PublishSession": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/GetSigningSession": {{
+			Entity: "assets",
+			Action: "read",
+		}},
+		"/assetwalletrpc.AssetWallet/CreateSwapOffer": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/AcceptSwapOffer": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/ProposeSwap": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/FundSwap": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/PublishSwap": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/CompleteSwap": {{
+			Entity: "assets",
+			Action: "write",
+		}},
+		"/assetwalletrpc.AssetWallet/ListSwaps": {{
+			Entity: "assets",
+			Action: "read",
+		}},
 		"/mintrpc.Mint/MintAsset": {{
 			Entity: "mint",
 			Action: "write",
 		}},
+		"/mintrpc.Mint/MintCollection": {{
+			Entity: "mint",
+			Action: "write",
+		}},
 		"/mintrpc.Mint/FundBatch": {{
 			Entity: "mint",
 			Action: "write",
@@ -156,6 +364,22 @@ var (
 			Entity: "mint",
 			Action: "write",
 		}},
+		"/mintrpc.Mint/ExportGroupPsbts": {{
+			Entity: "mint",
+			Action: "read",
+		}},
+		"/mintrpc.Mint/SubmitGroupSignatures": {{
+			Entity: "mint",
+			Action: "write",
+		}},
+		"/mintrpc.Mint/RegisterMetaSchema": {{
+			Entity: "mint",
+			Action: "write",
+		}},
+		"/mintrpc.Mint/ListMetaSchemas": {{
+			Entity: "mint",
+			Action: "read",
+		}},
 		"/mintrpc.Mint/FinalizeBatch": {{
 			Entity: "mint",
 			Action: "write",
@@ -192,6 +416,10 @@ var (
 			Entity: "universe",
 			Action: "read",
 		}},
+		"/universerpc.Universe/SubtreeNodes": {{
+			Entity: "universe",
+			Action: "read",
+		}},
 		"/universerpc.Universe/AssetLeaves": {{
 			Entity: "universe",
 			Action: "read",
@@ -208,10 +436,22 @@ var (
 			Entity: "universe",
 			Action: "read",
 		}},
+		"/universerpc.Universe/PushMetaBlob": {{
+			Entity: "universe",
+			Action: "write",
+		}},
+		"/universerpc.Universe/PullMetaBlob": {{
+			Entity: "universe",
+			Action: "read",
+		}},
 		"/universerpc.Universe/SyncUniverse": {{
 			Entity: "universe",
 			Action: "write",
 		}},
+		"/universerpc.Universe/SyncFederation": {{
+			Entity: "universe",
+			Action: "write",
+		}},
 		"/universerpc.Universe/ListFederationServers": {{
 			Entity: "universe",
 			Action: "read",
@@ -224,6 +464,10 @@ var (
 			Entity: "universe",
 			Action: "write",
 		}},
+		"/universerpc.Universe/SetFederationServerScope": {{
+			Entity: "universe",
+			Action: "write",
+		}},
 		"/universerpc.Universe/UniverseStats": {{
 			Entity: "universe",
 			Action: "read",
@@ -236,6 +480,18 @@ var (
 			Entity: "universe",
 			Action: "read",
 		}},
+		"/universerpc.Universe/QueryDailyStats": {{
+			Entity: "universe",
+			Action: "read",
+		}},
+		"/universerpc.Universe/QueryServerSyncStats": {{
+			Entity: "universe",
+			Action: "read",
+		}},
+		"/universerpc.Universe/QueryTopTransferUniverses": {{
+			Entity: "universe",
+			Action: "read",
+		}},
 		"/universerpc.Universe/SetFederationSyncConfig": {{
 			Entity: "universe",
 			Action: "write",
@@ -244,6 +500,22 @@ var (
 			Entity: "universe",
 			Action: "read",
 		}},
+		"/universerpc.Universe/PublishMetaUpdate": {{
+			Entity: "universe",
+			Action: "write",
+		}},
+		"/universerpc.Universe/InsertMetaUpdate": {{
+			Entity: "universe",
+			Action: "write",
+		}},
+		"/universerpc.Universe/QueryMetaUpdates": {{
+			Entity: "universe",
+			Action: "read",
+		}},
+		"/universerpc.Universe/SyncMetaUpdates": {{
+			Entity: "universe",
+			Action: "write",
+		}},
 		"/rfqrpc.Rfq/AddAssetBuyOrder": {{
 			Entity: "rfq",
 			Action: "write",
@@ -264,6 +536,26 @@ var (
 			Entity: "rfq",
 			Action: "read",
 		}},
+		"/rfqrpc.Rfq/QueryPeerReputations": {{
+			Entity: "rfq",
+			Action: "read",
+		}},
+		"/rfqrpc.Rfq/QuerySettlementStats": {{
+			Entity: "rfq",
+			Action: "read",
+		}},
+		"/rfqrpc.Rfq/QueryQuotes": {{
+			Entity: "rfq",
+			Action: "read",
+		}},
+		"/rfqrpc.Rfq/QueryQuoteVolume": {{
+			Entity: "rfq",
+			Action: "read",
+		}},
+		"/rfqrpc.Rfq/QueryInventoryRisk": {{
+			Entity: "rfq",
+			Action: "read",
+		}},
 		"/rfqrpc.Rfq/SubscribeRfqEventNtfns": {{
 			Entity: "rfq",
 			Action: "write",
@@ -280,6 +572,25 @@ var (
 			Entity: "channels",
 			Action: "write",
 		}},
+		"/tapchannelrpc.TaprootAssetChannels/PayAssetInvoice": {{
+			Entity: "channels",
+			Action: "write",
+		}},
+		//nolint:lll
+		"/tapchannelrpc.TaprootAssetChannels/ExportAssetChannelBackup": {{
+			Entity: "channels",
+			Action: "read",
+		}},
+		//nolint:lll
+		"/tapchannelrpc.TaprootAssetChannels/RestoreAssetChannelBackup": {{
+			Entity: "channels",
+			Action: "write",
+		}},
+		//nolint:lll
+		"/tapchannelrpc.TaprootAssetChannels/QueryAssetPaymentLimits": {{
+			Entity: "channels",
+			Action: "read",
+		}},
 		"/tapchannelrpc.TaprootAssetChannels/EncodeCustomRecords": {
 			// This RPC is completely stateless and doesn't require
 			// any permissions to use.
@@ -296,6 +607,10 @@ var (
 			Entity: "assets",
 			Action: "write",
 		}},
+		"/tapdevrpc.TapDev/DumpChannelCommitments": {{
+			Entity: "channels",
+			Action: "read",
+		}},
 	}
 
 	// defaultMacaroonWhitelist defines a default set of RPC endpoints that
@@ -308,6 +623,7 @@ var (
 		"/universerpc.Universe/AssetRoots":      {},
 		"/universerpc.Universe/QueryAssetRoots": {},
 		"/universerpc.Universe/AssetLeafKeys":   {},
+		"/universerpc.Universe/SubtreeNodes":    {},
 		"/universerpc.Universe/AssetLeaves":     {},
 		"/universerpc.Universe/Info":            {},
 	}
@@ -325,21 +641,45 @@ func MacaroonWhitelist(allowUniPublicAccessRead bool,
 		whitelist[k] = v
 	}
 
-	// Conditionally whitelist universe server read methods.
+	// The database can only be unlocked without a macaroon, as the macaroon
+	// root keys are encrypted with the database encryption key.
+	whitelist["/taprpc.TaprootAssets/UnlockDatabase"] = struct{}{}
+
+	// Conditionally whitelist universe server read methods. Meta blobs are
+	// delivered by the proof courier alongside the proofs.
 	if allowUniPublicAccessRead || allowPublicUniProofCourier {
 		whitelist["/universerpc.Universe/QueryProof"] = struct{}{}
+		whitelist["/universerpc.Universe/PullMetaBlob"] = struct{}{}
+	}
+
+	// Signed metadata updates are served to anyone who can read from the
+	// universe server. Inserting them is safe to allow publicly as well,
+	// since only updates signed by the issuer of a known group are
+	// accepted.
+	if allowUniPublicAccessRead {
+		whitelist["/universerpc.Universe/QueryMetaUpdates"] = struct{}{}
+	}
+	if allowUniPublicAccessWrite {
+		whitelist["/universerpc.Universe/InsertMetaUpdate"] = struct{}{}
 	}
 
 	// Conditionally whitelist universe server write methods.
 	if allowUniPublicAccessWrite || allowPublicUniProofCourier {
 		whitelist["/universerpc.Universe/InsertProof"] = struct{}{}
+		whitelist["/universerpc.Universe/PushMetaBlob"] = struct{}{}
 	}
 
-	// Conditionally add public stats RPC endpoints to the whitelist.
+	// Conditionally add public stats RPC endpoints to the whitelist. The
+	// sync volume with the federation servers isn't made public.
 	if allowPublicStats {
 		whitelist["/universerpc.Universe/QueryAssetStats"] = struct{}{}
 		whitelist["/universerpc.Universe/UniverseStats"] = struct{}{}
 		whitelist["/universerpc.Universe/QueryEvents"] = struct{}{}
+		whitelist["/universerpc.Universe/QueryDailyStats"] = struct{}{}
+
+		topTransfers := "/universerpc.Universe/" +
+			"QueryTopTransferUniverses"
+		whitelist[topTransfers] = struct{}{}
 	}
 
 	return whitelist
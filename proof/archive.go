@@ -906,19 +906,36 @@ func (m *MultiArchiver) FetchProofs(ctx context.Context,
 // ImportProofs attempts to store fully populated proofs on disk. The previous
 // outpoint of the first state transition will be used as the Genesis point.
 // The final resting place of the asset will be used as the script key itself.
+// A proof file that only contains a delta (suffix) of the provenance of an
+// asset is completed with the prefix that is already known to the archive.
 func (m *MultiArchiver) ImportProofs(ctx context.Context,
 	headerVerifier HeaderVerifier, merkleVerifier MerkleVerifier,
 	groupVerifier GroupVerifier, chainLookupGen ChainLookupGenerator,
 	replace bool, proofs ...*AnnotatedProof) error {
 
+	// Proofs imported together (e.g. during a wallet restore) are often
+	// anchored in the same blocks, so we share the chain lookups of the
+	// header and merkle proof verification between them.
+	cache := NewVerifierCache(headerVerifier, merkleVerifier)
+
 	// Before we import the proofs into the archive, we want to make sure
 	// that they're all valid. Along the way, we may augment the locator
 	// for each proof accordingly.
 	f := func(c context.Context, proof *AnnotatedProof) error {
+		// If we only received a delta of the proof file, we complete
+		// it with the prefix we already have, so we store and verify
+		// the full provenance of the asset.
+		fullBlob, err := ResolveDelta(c, m, proof.Blob)
+		if err != nil {
+			return fmt.Errorf("unable to resolve proof delta: %w",
+				err)
+		}
+		proof.Blob = fullBlob
+
 		// First, we'll decode and then also verify the proof.
 		finalStateTransition, err := m.proofVerifier.Verify(
-			c, bytes.NewReader(proof.Blob), headerVerifier,
-			merkleVerifier, groupVerifier, chainLookupGen,
+			c, bytes.NewReader(proof.Blob), cache.VerifyHeader,
+			cache.VerifyMerkleProof, groupVerifier, chainLookupGen,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to verify proof: %w", err)
@@ -0,0 +1,207 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"golang.org/x/sync/errgroup"
+)
+
+// headerCacheKey identifies a block header that was verified at a certain
+// height.
+type headerCacheKey struct {
+	blockHash chainhash.Hash
+	height    uint32
+}
+
+// merkleCacheKey identifies a merkle proof of a transaction for a certain
+// merkle root.
+type merkleCacheKey struct {
+	txHash     chainhash.Hash
+	merkleRoot [32]byte
+	proofHash  [32]byte
+}
+
+// VerifierCache caches the results of successful block header and merkle
+// proof verifications. Proofs of different files that are anchored in the
+// same block or transaction then only need to look up the chain data once.
+// Failed verifications are never cached, as they might be caused by a
+// temporary failure of the chain backend. A cache is meant to be used for a
+// single batch of proofs and is safe for concurrent use.
+type VerifierCache struct {
+	headerVerifier HeaderVerifier
+	merkleVerifier MerkleVerifier
+
+	mu           sync.RWMutex
+	headers      map[headerCacheKey]struct{}
+	merkleProofs map[merkleCacheKey]struct{}
+}
+
+// NewVerifierCache creates a new verifier cache that wraps the given header
+// and merkle verifiers.
+func NewVerifierCache(headerVerifier HeaderVerifier,
+	merkleVerifier MerkleVerifier) *VerifierCache {
+
+	return &VerifierCache{
+		headerVerifier: headerVerifier,
+		merkleVerifier: merkleVerifier,
+		headers:        make(map[headerCacheKey]struct{}),
+		merkleProofs:   make(map[merkleCacheKey]struct{}),
+	}
+}
+
+// VerifyHeader verifies the given block header at the given height, unless
+// the same header was already verified successfully before.
+func (c *VerifierCache) VerifyHeader(header wire.BlockHeader,
+	height uint32) error {
+
+	key := headerCacheKey{
+		blockHash: header.BlockHash(),
+		height:    height,
+	}
+
+	c.mu.RLock()
+	_, ok := c.headers[key]
+	c.mu.RUnlock()
+	if ok {
+		return nil
+	}
+
+	if err := c.headerVerifier(header, height); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.headers[key] = struct{}{}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// VerifyMerkleProof verifies the given merkle proof of the transaction,
+// unless the same proof was already verified successfully before.
+func (c *VerifierCache) VerifyMerkleProof(tx *wire.MsgTx,
+	proof *TxMerkleProof, merkleRoot [32]byte) error {
+
+	var proofBuf bytes.Buffer
+	if err := proof.Encode(&proofBuf); err != nil {
+		return fmt.Errorf("unable to encode merkle proof: %w", err)
+	}
+
+	key := merkleCacheKey{
+		txHash:     tx.TxHash(),
+		merkleRoot: merkleRoot,
+		proofHash:  sha256.Sum256(proofBuf.Bytes()),
+	}
+
+	c.mu.RLock()
+	_, ok := c.merkleProofs[key]
+	c.mu.RUnlock()
+	if ok {
+		return nil
+	}
+
+	if err := c.merkleVerifier(tx, proof, merkleRoot); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.merkleProofs[key] = struct{}{}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// BatchResult is the result of verifying a single proof file of a batch.
+type BatchResult struct {
+	// Snapshot is the snapshot of the final state transition of the proof
+	// file. It is only set if the file is valid.
+	Snapshot *AssetSnapshot
+
+	// Err is the error the verification of the proof file failed with, if
+	// any.
+	Err error
+}
+
+// batchVerifyParams is the set of parameters used when verifying a batch of
+// proof files.
+type batchVerifyParams struct {
+	numWorkers int
+}
+
+// BatchVerifyOption is an option that may be applied on *batchVerifyParams.
+type BatchVerifyOption func(p *batchVerifyParams)
+
+// WithNumWorkers sets the maximum number of proof files that are verified
+// concurrently. By default, the number of available CPUs is used.
+func WithNumWorkers(numWorkers int) BatchVerifyOption {
+	return func(p *batchVerifyParams) {
+		p.numWorkers = numWorkers
+	}
+}
+
+// VerifyBatch verifies the given independent proof files concurrently, using
+// a bounded pool of workers. The header and merkle proof verifications are
+// shared between all files through a VerifierCache. One result is returned for
+// each file, in the same order as the files were given. An invalid file
+// doesn't abort the verification of the other files, so an error is only
+// returned if the context is canceled before all files were verified.
+func VerifyBatch(ctx context.Context, files []*File,
+	headerVerifier HeaderVerifier, merkleVerifier MerkleVerifier,
+	groupVerifier GroupVerifier, chainLookupGen ChainLookupGenerator,
+	opts ...BatchVerifyOption) ([]BatchResult, error) {
+
+	params := batchVerifyParams{
+		numWorkers: runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	if params.numWorkers < 1 {
+		return nil, fmt.Errorf("invalid number of workers: %d",
+			params.numWorkers)
+	}
+
+	cache := NewVerifierCache(headerVerifier, merkleVerifier)
+
+	var errGroup errgroup.Group
+	errGroup.SetLimit(params.numWorkers)
+
+	results := make([]BatchResult, len(files))
+	for idx := range files {
+		// We stop handing out new work once the context is canceled.
+		// The files that are already being verified will notice the
+		// cancellation themselves.
+		if ctx.Err() != nil {
+			break
+		}
+
+		errGroup.Go(func() error {
+			f := files[idx]
+			chainLookup := chainLookupGen.GenFileChainLookup(f)
+			results[idx].Snapshot, results[idx].Err = f.Verify(
+				ctx, cache.VerifyHeader,
+				cache.VerifyMerkleProof, groupVerifier,
+				chainLookup,
+			)
+
+			return nil
+		})
+	}
+
+	// The workers never return an error, as we want to collect the result
+	// of every file.
+	_ = errGroup.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,98 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyBatch tests that a batch of proof files is verified concurrently
+// and that the header and merkle proof verifications are shared between them.
+func TestVerifyBatch(t *testing.T) {
+	t.Parallel()
+
+	proofHex, err := os.ReadFile(proofFileHexFileName)
+	require.NoError(t, err)
+
+	fileBytes, err := hex.DecodeString(
+		strings.Trim(string(proofHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	const numFiles = 8
+	files := make([]*File, numFiles)
+	for idx := range files {
+		files[idx] = &File{}
+		require.NoError(
+			t, files[idx].Decode(bytes.NewReader(fileBytes)),
+		)
+	}
+
+	var numHeaderCalls, numMerkleCalls atomic.Int32
+	headerVerifier := func(wire.BlockHeader, uint32) error {
+		numHeaderCalls.Add(1)
+		return nil
+	}
+	merkleVerifier := func(*wire.MsgTx, *TxMerkleProof, [32]byte) error {
+		numMerkleCalls.Add(1)
+		return nil
+	}
+
+	// With a single worker, the chain data of the identical files is only
+	// looked up once.
+	ctx := context.Background()
+	results, err := VerifyBatch(
+		ctx, files, headerVerifier, merkleVerifier, MockGroupVerifier,
+		MockChainLookup, WithNumWorkers(1),
+	)
+	require.NoError(t, err)
+	require.Len(t, results, numFiles)
+
+	numProofs := int32(files[0].NumProofs())
+	require.LessOrEqual(t, numHeaderCalls.Load(), numProofs)
+	require.LessOrEqual(t, numMerkleCalls.Load(), numProofs)
+
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		require.NotNil(t, result.Snapshot)
+	}
+
+	// A failed verification isn't cached and only affects the result of
+	// the invalid files.
+	errInvalidHeader := errors.New("invalid header")
+	failingVerifier := func(wire.BlockHeader, uint32) error {
+		return errInvalidHeader
+	}
+	results, err = VerifyBatch(
+		ctx, files, failingVerifier, merkleVerifier, MockGroupVerifier,
+		MockChainLookup,
+	)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.ErrorIs(t, result.Err, errInvalidHeader)
+		require.Nil(t, result.Snapshot)
+	}
+
+	// A canceled context aborts the whole batch.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = VerifyBatch(
+		cancelCtx, files, headerVerifier, merkleVerifier,
+		MockGroupVerifier, MockChainLookup,
+	)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = VerifyBatch(
+		ctx, files, headerVerifier, merkleVerifier, MockGroupVerifier,
+		MockChainLookup, WithNumWorkers(0),
+	)
+	require.ErrorContains(t, err, "invalid number of workers")
+}
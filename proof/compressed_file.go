@@ -0,0 +1,414 @@
+package proof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// chunkIndexEntrySize is the size of a single entry in the chunk index
+	// of a V1 proof file: the offset of the chunk (8 bytes), its compressed
+	// (4 bytes) and uncompressed (4 bytes) length and the chained hash of
+	// the proof (32 bytes).
+	chunkIndexEntrySize = 8 + 4 + 4 + sha256.Size
+)
+
+var (
+	// zstdInitOnce makes sure the zstd encoder and decoder are only
+	// created once. Both are safe for concurrent use.
+	zstdInitOnce sync.Once
+
+	// zstdEncoder is the encoder used to compress the proofs of V1 files.
+	zstdEncoder *zstd.Encoder
+
+	// zstdDecoder is the decoder used to decompress the proofs of V1
+	// files.
+	zstdDecoder *zstd.Decoder
+
+	// zstdInitErr is the error that occurred while creating the zstd
+	// encoder or decoder, if any.
+	zstdInitErr error
+)
+
+// initZstd creates the zstd encoder and decoder if they don't exist yet.
+func initZstd() error {
+	zstdInitOnce.Do(func() {
+		zstdEncoder, zstdInitErr = zstd.NewWriter(
+			nil, zstd.WithEncoderConcurrency(1),
+		)
+		if zstdInitErr != nil {
+			return
+		}
+
+		// We never decompress more than the uncompressed length that
+		// is declared in the chunk index, which in turn is capped.
+		zstdDecoder, zstdInitErr = zstd.NewReader(
+			nil, zstd.WithDecoderConcurrency(0),
+			zstd.WithDecodeAllCapLimit(true),
+			zstd.WithDecoderMaxMemory(FileMaxProofSizeBytes),
+		)
+	})
+
+	return zstdInitErr
+}
+
+// compressProof compresses the given raw proof.
+func compressProof(proofBytes []byte) ([]byte, error) {
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+
+	return zstdEncoder.EncodeAll(proofBytes, nil), nil
+}
+
+// decompressProof decompresses the given proof chunk, which must decompress to
+// exactly the given number of bytes.
+func decompressProof(chunk []byte, numProofBytes uint32) ([]byte, error) {
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+
+	proofBytes, err := zstdDecoder.DecodeAll(
+		chunk, make([]byte, 0, numProofBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decompress proof: %v",
+			ErrProofFileInvalid, err)
+	}
+
+	if len(proofBytes) != int(numProofBytes) {
+		return nil, fmt.Errorf("%w: proof decompressed to %d bytes, "+
+			"expected %d", ErrProofFileInvalid, len(proofBytes),
+			numProofBytes)
+	}
+
+	return proofBytes, nil
+}
+
+// chunkIndexEntry is an entry of the chunk index of a V1 proof file that
+// locates a single compressed proof.
+type chunkIndexEntry struct {
+	// offset is the offset of the compressed proof, relative to the start
+	// of the first chunk.
+	offset uint64
+
+	// compressedLen is the length of the compressed proof.
+	compressedLen uint32
+
+	// uncompressedLen is the length of the raw proof.
+	uncompressedLen uint32
+
+	// hash is the chained hash of the proof, SHA256(prev_hash || proof).
+	hash [sha256.Size]byte
+}
+
+// encode writes the index entry to the given writer.
+func (e *chunkIndexEntry) encode(w io.Writer) error {
+	var entry [chunkIndexEntrySize]byte
+	binary.BigEndian.PutUint64(entry[:8], e.offset)
+	binary.BigEndian.PutUint32(entry[8:12], e.compressedLen)
+	binary.BigEndian.PutUint32(entry[12:16], e.uncompressedLen)
+	copy(entry[16:], e.hash[:])
+
+	_, err := w.Write(entry[:])
+	return err
+}
+
+// decode reads the index entry from the given reader and makes sure the
+// lengths don't exceed the proof size limit.
+func (e *chunkIndexEntry) decode(r io.Reader) error {
+	var entry [chunkIndexEntrySize]byte
+	if _, err := io.ReadFull(r, entry[:]); err != nil {
+		return err
+	}
+
+	e.offset = binary.BigEndian.Uint64(entry[:8])
+	e.compressedLen = binary.BigEndian.Uint32(entry[8:12])
+	e.uncompressedLen = binary.BigEndian.Uint32(entry[12:16])
+	copy(e.hash[:], entry[16:])
+
+	// We cap the size of an individual proof. See the comment for
+	// FileMaxProofSizeBytes for the reasoning behind the value chosen.
+	if e.compressedLen > FileMaxProofSizeBytes ||
+		e.uncompressedLen > FileMaxProofSizeBytes {
+
+		return fmt.Errorf("%w: proof in file too large",
+			ErrProofFileInvalid)
+	}
+
+	return nil
+}
+
+// encodeCompressed encodes the proofs of the file in the V1 encoding. Each
+// proof is compressed on its own, and the compressed proofs are preceded by
+// an index that allows locating them without reading the rest of the file:
+//
+//	num_proofs || index_entry* || compressed_proof*
+//
+// with each index entry being
+//
+//	offset (8 bytes) || compressed_len (4 bytes) ||
+//	uncompressed_len (4 bytes) || hash (32 bytes)
+func (f *File) encodeCompressed(w io.Writer) error {
+	var (
+		index  = make([]chunkIndexEntry, len(f.proofs))
+		chunks = make([][]byte, len(f.proofs))
+		offset uint64
+	)
+	for idx, proof := range f.proofs {
+		chunk, err := compressProof(proof.proofBytes)
+		if err != nil {
+			return err
+		}
+
+		if len(chunk) > FileMaxProofSizeBytes ||
+			len(proof.proofBytes) > FileMaxProofSizeBytes {
+
+			return fmt.Errorf("proof %d in file too large", idx)
+		}
+
+		index[idx] = chunkIndexEntry{
+			offset:          offset,
+			compressedLen:   uint32(len(chunk)),
+			uncompressedLen: uint32(len(proof.proofBytes)),
+			hash:            proof.hash,
+		}
+		chunks[idx] = chunk
+		offset += uint64(len(chunk))
+	}
+
+	var tlvBuf [8]byte
+	err := tlv.WriteVarInt(w, uint64(len(f.proofs)), &tlvBuf)
+	if err != nil {
+		return err
+	}
+	for idx := range index {
+		if err := index[idx].encode(w); err != nil {
+			return err
+		}
+	}
+	for _, chunk := range chunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readChunkIndex reads the number of proofs and the chunk index of a V1 proof
+// file.
+func readChunkIndex(r io.Reader) ([]chunkIndexEntry, error) {
+	var tlvBuf [8]byte
+	numProofs, err := tlv.ReadVarInt(r, &tlvBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cap the number of proofs there can be within a single file to avoid
+	// OOM attacks. See the comment for FileMaxNumProofs for the reasoning
+	// behind the value chosen.
+	if numProofs > FileMaxNumProofs {
+		return nil, fmt.Errorf("%w: too many proofs in file",
+			ErrProofFileInvalid)
+	}
+
+	index := make([]chunkIndexEntry, numProofs)
+	for idx := range index {
+		if err := index[idx].decode(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return index, nil
+}
+
+// decodeCompressed decodes the proofs of a file in the V1 encoding, verifying
+// the chained hash of each of them.
+func (f *File) decodeCompressed(r io.Reader) error {
+	index, err := readChunkIndex(r)
+	if err != nil {
+		return err
+	}
+
+	var (
+		prevHash [sha256.Size]byte
+		offset   uint64
+	)
+	f.proofs = make([]*hashedProof, len(index))
+	for idx, entry := range index {
+		// The chunks must follow each other without any gaps, in the
+		// order of the index, so we can read them sequentially.
+		if entry.offset != offset {
+			return fmt.Errorf("%w: unexpected offset %d of proof "+
+				"%d", ErrProofFileInvalid, entry.offset, idx)
+		}
+		offset += uint64(entry.compressedLen)
+
+		chunk := make([]byte, entry.compressedLen)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return err
+		}
+
+		proofBytes, err := decompressProof(
+			chunk, entry.uncompressedLen,
+		)
+		if err != nil {
+			return err
+		}
+
+		currentHash := hashProof(proofBytes, prevHash)
+		if entry.hash != currentHash {
+			return ErrInvalidChecksum
+		}
+
+		f.proofs[idx] = &hashedProof{
+			proofBytes: proofBytes,
+			hash:       currentHash,
+		}
+		prevHash = currentHash
+	}
+
+	return nil
+}
+
+// CompressedFileReader provides random access to the proofs of a proof file
+// in the V1 encoding. Only the chunk index is read when the reader is created,
+// the individual proofs are read and decompressed on demand.
+type CompressedFileReader struct {
+	r io.ReaderAt
+
+	// index is the chunk index of the file.
+	index []chunkIndexEntry
+
+	// chunksOffset is the offset of the first chunk within the file.
+	chunksOffset int64
+}
+
+// NewCompressedFileReader creates a new reader for the proof file in the V1
+// encoding that can be read from the given reader.
+func NewCompressedFileReader(r io.ReaderAt,
+	size int64) (*CompressedFileReader, error) {
+
+	sr := io.NewSectionReader(r, 0, size)
+
+	var prefixMagicBytes [PrefixMagicBytesLength]byte
+	if _, err := io.ReadFull(sr, prefixMagicBytes[:]); err != nil {
+		return nil, err
+	}
+	if prefixMagicBytes != FilePrefixMagicBytes {
+		return nil, fmt.Errorf("invalid prefix magic bytes, expected "+
+			"%s, got %s", string(FilePrefixMagicBytes[:]),
+			string(prefixMagicBytes[:]))
+	}
+
+	var version uint32
+	if err := binary.Read(sr, binary.BigEndian, &version); err != nil {
+		return nil, err
+	}
+	if Version(version) != V1 {
+		return nil, fmt.Errorf("%w: random access requires version "+
+			"%d, got %d", ErrUnknownVersion, V1, version)
+	}
+
+	index, err := readChunkIndex(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The section reader is now positioned at the first chunk.
+	chunksOffset, err := sr.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure all chunks are within the file, so we don't need to check
+	// this for each proof we read.
+	for idx, entry := range index {
+		end := uint64(chunksOffset) + entry.offset +
+			uint64(entry.compressedLen)
+		if end > uint64(size) {
+			return nil, fmt.Errorf("%w: proof %d exceeds file",
+				ErrProofFileInvalid, idx)
+		}
+	}
+
+	return &CompressedFileReader{
+		r:            r,
+		index:        index,
+		chunksOffset: chunksOffset,
+	}, nil
+}
+
+// NumProofs returns the number of proofs contained in the file.
+func (c *CompressedFileReader) NumProofs() int {
+	return len(c.index)
+}
+
+// RawProofAt returns the raw proof at the given index. The proof is checked
+// against its chained hash in the index.
+func (c *CompressedFileReader) RawProofAt(index uint32) ([]byte, error) {
+	if len(c.index) == 0 {
+		return nil, ErrNoProofAvailable
+	}
+
+	if index >= uint32(len(c.index)) {
+		return nil, fmt.Errorf("invalid index %d", index)
+	}
+
+	entry := c.index[index]
+	chunk := make([]byte, entry.compressedLen)
+	n, err := c.r.ReadAt(chunk, c.chunksOffset+int64(entry.offset))
+	if n != len(chunk) {
+		return nil, fmt.Errorf("unable to read proof %d: %w", index,
+			err)
+	}
+
+	proofBytes, err := decompressProof(chunk, entry.uncompressedLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without reading all previous proofs we can't verify the whole hash
+	// chain, but we can make sure the proof matches its own link in it.
+	var prevHash [sha256.Size]byte
+	if index > 0 {
+		prevHash = c.index[index-1].hash
+	}
+	if hashProof(proofBytes, prevHash) != entry.hash {
+		return nil, ErrInvalidChecksum
+	}
+
+	return proofBytes, nil
+}
+
+// ProofAt returns the decoded proof at the given index.
+func (c *CompressedFileReader) ProofAt(index uint32) (*Proof, error) {
+	proofBytes, err := c.RawProofAt(index)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &Proof{}
+	if err := proof.Decode(bytes.NewReader(proofBytes)); err != nil {
+		return nil, fmt.Errorf("error decoding proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// LastProof returns the last proof in the file.
+func (c *CompressedFileReader) LastProof() (*Proof, error) {
+	if len(c.index) == 0 {
+		return nil, ErrNoProofAvailable
+	}
+
+	return c.ProofAt(uint32(len(c.index)) - 1)
+}
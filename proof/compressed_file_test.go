@@ -0,0 +1,144 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeFile encodes the given file and returns its raw bytes.
+func encodeFile(t *testing.T, f *File) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, f.Encode(&buf))
+
+	return buf.Bytes()
+}
+
+// TestCompressedFileEncoding tests that a proof file survives a round trip
+// through the compressed V1 encoding and that it is smaller than the V0
+// encoding.
+func TestCompressedFileEncoding(t *testing.T) {
+	t.Parallel()
+
+	proofHex, err := os.ReadFile(proofFileHexFileName)
+	require.NoError(t, err)
+
+	v0Bytes, err := hex.DecodeString(
+		strings.Trim(string(proofHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	f := &File{}
+	require.NoError(t, f.Decode(bytes.NewReader(v0Bytes)))
+	require.Equal(t, V0, f.Version)
+
+	// Converting the file only requires changing its version.
+	f.Version = V1
+	v1Bytes := encodeFile(t, f)
+	require.Less(t, len(v1Bytes), len(v0Bytes))
+
+	f2 := &File{}
+	require.NoError(t, f2.Decode(bytes.NewReader(v1Bytes)))
+	require.Equal(t, V1, f2.Version)
+	require.Equal(t, f.proofs, f2.proofs)
+	require.NoError(t, f2.IsValid())
+
+	// The encoding is deterministic.
+	require.Equal(t, v1Bytes, encodeFile(t, f2))
+
+	// And converting back results in the original encoding.
+	f2.Version = V0
+	require.Equal(t, v0Bytes, encodeFile(t, f2))
+
+	// An empty file can be encoded as well.
+	emptyBytes := encodeFile(t, NewEmptyFile(V1))
+	f3 := &File{}
+	require.NoError(t, f3.Decode(bytes.NewReader(emptyBytes)))
+	require.True(t, f3.IsEmpty())
+}
+
+// TestCompressedFileReader tests random access to the proofs of a compressed
+// proof file.
+func TestCompressedFileReader(t *testing.T) {
+	t.Parallel()
+
+	const numProofs = 50
+	proofs := make([]Proof, numProofs)
+	for i := 0; i < numProofs; i++ {
+		amt := uint64(i + 1)
+		proofs[i], _ = genRandomGenesisWithProof(
+			t, asset.Normal, &amt, nil, false, nil, nil, nil, nil,
+			asset.V0,
+		)
+	}
+
+	f, err := NewFile(V1, proofs...)
+	require.NoError(t, err)
+	fileBytes := encodeFile(t, f)
+
+	r, err := NewCompressedFileReader(
+		bytes.NewReader(fileBytes), int64(len(fileBytes)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, numProofs, r.NumProofs())
+
+	for _, idx := range []uint32{
+		0, numProofs - 1, uint32(test.RandIntn(numProofs)),
+	} {
+		rawProof, err := r.RawProofAt(idx)
+		require.NoError(t, err)
+
+		expectedRawProof, err := f.RawProofAt(idx)
+		require.NoError(t, err)
+		require.Equal(t, expectedRawProof, rawProof)
+
+		p, err := r.ProofAt(idx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(idx+1), p.Asset.Amount)
+	}
+
+	lastProof, err := r.LastProof()
+	require.NoError(t, err)
+	require.Equal(t, uint64(numProofs), lastProof.Asset.Amount)
+
+	_, err = r.ProofAt(numProofs)
+	require.ErrorContains(t, err, "invalid index")
+
+	// A corrupted chunk is detected when reading it, as well as when
+	// decoding the whole file, while the other proofs can still be read.
+	corrupted := bytes.Clone(fileBytes)
+	corrupted[len(corrupted)-2] ^= 0xff
+
+	r, err = NewCompressedFileReader(
+		bytes.NewReader(corrupted), int64(len(corrupted)),
+	)
+	require.NoError(t, err)
+
+	_, err = r.LastProof()
+	require.Error(t, err)
+	_, err = r.ProofAt(0)
+	require.NoError(t, err)
+
+	require.Error(t, (&File{}).Decode(bytes.NewReader(corrupted)))
+
+	// A truncated file is rejected right away.
+	truncated := fileBytes[:len(fileBytes)-1]
+	_, err = NewCompressedFileReader(
+		bytes.NewReader(truncated), int64(len(truncated)),
+	)
+	require.ErrorIs(t, err, ErrProofFileInvalid)
+
+	// Random access is only supported for the compressed encoding.
+	f.Version = V0
+	v0Bytes := encodeFile(t, f)
+	_, err = NewCompressedFileReader(
+		bytes.NewReader(v0Bytes), int64(len(v0Bytes)),
+	)
+	require.ErrorIs(t, err, ErrUnknownVersion)
+}
@@ -3,9 +3,12 @@ package proof
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/sha512"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"sync"
 	"time"
@@ -90,6 +93,17 @@ type CourierCfg struct {
 	// LocalArchive is an archive that can be used to fetch proofs from the
 	// local archive.
 	LocalArchive Archiver
+
+	// MailboxSigner is used by the hashmail courier to sign the mailbox
+	// claims and delivery receipts of proofs we receive. If this is nil,
+	// only plain ACKs are sent.
+	MailboxSigner MailboxSigner
+
+	// MetaBlobs is the store of the meta blobs referenced by asset metas.
+	// The universe RPC courier delivers them alongside the proofs, the
+	// hashmail courier doesn't support delivering meta blobs. If this is
+	// nil, meta blobs aren't delivered.
+	MetaBlobs MetaBlobStore
 }
 
 // CourierConnStatus is an enum that represents the different states a courier
@@ -172,37 +186,72 @@ type CourierDispatch interface {
 // scheme to determine which courier service to use.
 type URLDispatch struct {
 	cfg *CourierCfg
+
+	// stats counts the proof transfers of all couriers created by the
+	// dispatch.
+	stats *courierStats
 }
 
 // NewCourierDispatch creates a new proof courier dispatch.
 func NewCourierDispatch(cfg *CourierCfg) *URLDispatch {
 	return &URLDispatch{
-		cfg: cfg,
+		cfg:   cfg,
+		stats: &courierStats{},
 	}
 }
 
+// Stats returns a snapshot of the statistics of the proof transfers of all
+// couriers created by the dispatch.
+func (u *URLDispatch) Stats() CourierStats {
+	return u.stats.snapshot()
+}
+
 // NewCourier instantiates a new courier service handle given a service URL
 // address.
 func (u *URLDispatch) NewCourier(ctx context.Context, addr *url.URL,
 	lazyConnect bool) (Courier, error) {
+
+	// We count the transfer attempts the couriers log, so we can report
+	// them as part of the courier stats.
+	var transferLog TransferLog
+	if u.cfg.TransferLog != nil {
+		transferLog = &statsTransferLog{
+			TransferLog: u.cfg.TransferLog,
+			stats:       u.stats,
+		}
+	}
+
+	var (
+		courier Courier
+		err     error
+	)
+
 	// Create new courier addr based on URL scheme.
 	switch addr.Scheme {
 	case HashmailCourierType:
-		return NewHashMailCourier(
-			ctx, u.cfg.HashMailCfg, u.cfg.TransferLog, addr,
-			lazyConnect,
+		courier, err = NewHashMailCourier(
+			ctx, u.cfg.HashMailCfg, transferLog,
+			u.cfg.MailboxSigner, addr, lazyConnect,
 		)
 
 	case UniverseRpcCourierType:
-		return NewUniverseRpcCourier(
-			ctx, u.cfg.UniverseRpcCfg, u.cfg.TransferLog,
-			u.cfg.LocalArchive, addr, lazyConnect,
+		courier, err = NewUniverseRpcCourier(
+			ctx, u.cfg.UniverseRpcCfg, transferLog,
+			u.cfg.LocalArchive, u.cfg.MetaBlobs, addr, lazyConnect,
 		)
 
 	default:
 		return nil, fmt.Errorf("unknown courier address protocol "+
 			"(consider updating tapd): %v", addr.Scheme)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumentedCourier{
+		Courier: courier,
+		stats:   u.stats,
+	}, nil
 }
 
 // A compile-time assertion to ensure that the URLDispatch meets the
@@ -261,8 +310,14 @@ type ProofMailbox interface {
 	// has been received.
 	AckProof(ctx context.Context, sid streamID) error
 
-	// RecvAck waits for the sender to receive the ack from the receiver.
-	RecvAck(ctx context.Context, sid streamID) error
+	// WriteAuth writes a signed mailbox claim or delivery receipt of the
+	// receiver to the mailbox specified by the sid.
+	WriteAuth(ctx context.Context, sid streamID, auth []byte) error
+
+	// RecvAck waits for the next message the receiver writes to its ACK
+	// mailbox, which is either a plain ACK or a signed mailbox claim or
+	// delivery receipt.
+	RecvAck(ctx context.Context, sid streamID) ([]byte, error)
 
 	// CleanUp attempts to tear down the mailbox as specified by the passed
 	// sid.
@@ -362,9 +417,9 @@ func (h *HashMailBox) Init(ctx context.Context, sid streamID) error {
 	return nil
 }
 
-// WriteProof writes the proof to the mailbox specified by the sid.
-func (h *HashMailBox) WriteProof(ctx context.Context, sid streamID,
-	proof Blob) error {
+// writeMsg writes the given message to the mailbox specified by the sid.
+func (h *HashMailBox) writeMsg(ctx context.Context, sid streamID,
+	msg []byte) error {
 
 	writeStream, err := h.client.SendStream(ctx)
 	if err != nil {
@@ -375,7 +430,7 @@ func (h *HashMailBox) WriteProof(ctx context.Context, sid streamID,
 		Desc: &hashmailrpc.CipherBoxDesc{
 			StreamId: sid[:],
 		},
-		Msg: proof[:],
+		Msg: msg,
 	})
 	if err != nil {
 		return err
@@ -384,6 +439,13 @@ func (h *HashMailBox) WriteProof(ctx context.Context, sid streamID,
 	return writeStream.CloseSend()
 }
 
+// WriteProof writes the proof to the mailbox specified by the sid.
+func (h *HashMailBox) WriteProof(ctx context.Context, sid streamID,
+	proof Blob) error {
+
+	return h.writeMsg(ctx, sid, proof[:])
+}
+
 // ReadProof reads a proof from the mailbox. This is a blocking method.
 func (h *HashMailBox) ReadProof(ctx context.Context,
 	sid streamID) (Blob, error) {
@@ -412,46 +474,37 @@ var ackMsg = []byte("ack")
 // AckProof sends an ACK from the receiver to the sender that a proof has been
 // received.
 func (h *HashMailBox) AckProof(ctx context.Context, sid streamID) error {
-	writeStream, err := h.client.SendStream(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to create send stream: %w", err)
-	}
+	return h.writeMsg(ctx, sid, ackMsg)
+}
 
-	err = writeStream.Send(&hashmailrpc.CipherBox{
-		Desc: &hashmailrpc.CipherBoxDesc{
-			StreamId: sid[:],
-		},
-		Msg: ackMsg,
-	})
-	if err != nil {
-		return err
-	}
+// WriteAuth writes a signed mailbox claim or delivery receipt of the receiver
+// to the mailbox specified by the sid.
+func (h *HashMailBox) WriteAuth(ctx context.Context, sid streamID,
+	auth []byte) error {
 
-	return writeStream.CloseSend()
+	return h.writeMsg(ctx, sid, auth)
 }
 
-// RecvAck waits for the sender to receive the ack from the receiver.
-func (h *HashMailBox) RecvAck(ctx context.Context, sid streamID) error {
+// RecvAck waits for the next message the receiver writes to its ACK mailbox,
+// which is either a plain ACK or a signed mailbox claim or delivery receipt.
+func (h *HashMailBox) RecvAck(ctx context.Context,
+	sid streamID) ([]byte, error) {
+
 	readStream, err := h.client.RecvStream(ctx, &hashmailrpc.CipherBoxDesc{
 		StreamId: sid[:],
 	})
 	if err != nil {
-		return fmt.Errorf("unable to create read stream: %w", err)
+		return nil, fmt.Errorf("unable to create read stream: %w", err)
 	}
 
 	log.Debugf("Exec stream Recv for receiver ACK (sid=%x)", sid[:])
 	msg, err := readStream.Recv()
 	if err != nil {
-		return fmt.Errorf("failed on stream Recv (sid=%x): %w", sid[:],
-			err)
+		return nil, fmt.Errorf("failed on stream Recv (sid=%x): %w",
+			sid[:], err)
 	}
 
-	if bytes.Equal(msg.Msg, ackMsg) {
-		log.Debugf("Received ACK from sender (sid=%x)", sid[:])
-		return nil
-	}
-
-	return fmt.Errorf("expected ACK from hashmail service, got %x", msg.Msg)
+	return msg.Msg, nil
 }
 
 // CleanUp attempts to tear down the mailbox as specified by the passed sid.
@@ -527,6 +580,11 @@ type Recipient struct {
 	// Amount is the amount of the asset that is being transferred. This is
 	// used for logging purposes only.
 	Amount uint64
+
+	// ScriptKeyTweak holds the raw key and the tweak the script key was
+	// derived from. This is only known to the receiver, which uses it to
+	// sign its mailbox claims and delivery receipts.
+	ScriptKeyTweak *asset.TweakedScriptKey
 }
 
 // BackoffExecError is an error returned when the backoff execution fails.
@@ -694,6 +752,7 @@ func (b *BackoffHandler) Exec(ctx context.Context, proofLocator Locator,
 			break
 		}
 		// Store execution error in case this is the last attempt.
+		attemptErr := errExec
 		errExec = fmt.Errorf("error executing backoff procedure: "+
 			"%w", &BackoffExecError{execErr: errExec})
 
@@ -706,7 +765,7 @@ func (b *BackoffHandler) Exec(ctx context.Context, proofLocator Locator,
 		// The target delivery function execution failed. Notify
 		// subscribers that a backoff wait event is about to commence.
 		waitEvent := NewBackoffWaitEvent(
-			backoff, int64(i+1), transferType,
+			backoff, int64(i+1), transferType, attemptErr,
 		)
 		subscriberEvent(waitEvent)
 
@@ -764,6 +823,12 @@ type HashMailCourierCfg struct {
 	// acknowledge the proof.
 	ReceiverAckTimeout time.Duration `long:"receiveracktimeout" description:"The maximum time to wait for the receiver to acknowledge the proof. Valid time units are {s, m, h}."`
 
+	// RequireSignedReceipts indicates that a proof is only considered to
+	// be delivered once the receiver returned a delivery receipt signed
+	// with the key of its script key. Plain ACKs, as sent by receivers
+	// running older versions, are ignored.
+	RequireSignedReceipts bool `long:"requiresignedreceipts" description:"Only consider a proof delivered once the receiver returned a delivery receipt signed with the key of its script key. Plain ACKs, which can be forged by anyone with access to the mailbox and are sent by receivers running older versions, are ignored."`
+
 	// BackoffCfg configures the behaviour of the proof delivery
 	// functionality.
 	BackoffCfg *BackoffCfg
@@ -782,6 +847,10 @@ type HashMailCourier struct {
 	// attempts.
 	transferLog TransferLog
 
+	// signer is used to sign the mailbox claims and delivery receipts of
+	// proofs we receive. If this is nil, only plain ACKs are sent.
+	signer MailboxSigner
+
 	// backoffHandle is a handle to the backoff procedure used in proof
 	// delivery.
 	backoffHandle *BackoffHandler
@@ -801,13 +870,14 @@ type HashMailCourier struct {
 
 // NewHashMailCourier creates a new hashmail proof courier service handle.
 func NewHashMailCourier(ctx context.Context, cfg *HashMailCourierCfg,
-	transferLog TransferLog, courierAddr *url.URL,
+	transferLog TransferLog, signer MailboxSigner, courierAddr *url.URL,
 	lazyConnect bool) (*HashMailCourier, error) {
 
 	courier := HashMailCourier{
 		cfg:           cfg,
 		addr:          courierAddr,
 		transferLog:   transferLog,
+		signer:        signer,
 		backoffHandle: NewBackoffHandler(cfg.BackoffCfg, transferLog),
 		subscribers:   make(map[uint64]*fn.EventReceiver[fn.Event]),
 	}
@@ -934,7 +1004,9 @@ func (h *HashMailCourier) DeliverProof(ctx context.Context,
 			ctx, h.cfg.ReceiverAckTimeout,
 		)
 		defer cancel()
-		err = h.mailbox.RecvAck(ctxTimeout, receiverStreamID)
+		err = h.waitForReceipt(
+			ctxTimeout, receiverStreamID, recipient, proof.Blob,
+		)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve proof transfer "+
 				"receiver ACK within timeout (sid=%x): %w",
@@ -972,6 +1044,88 @@ func (h *HashMailCourier) DeliverProof(ctx context.Context,
 	return nil
 }
 
+// waitForReceipt reads the messages in the receiver's ACK mailbox until the
+// receiver confirmed the delivery of the given proof. Signed claims and
+// receipts are verified against the recipient's script key, so messages of
+// third parties with access to the mailbox are discarded. Plain ACKs are only
+// accepted if signed receipts aren't required.
+func (h *HashMailCourier) waitForReceipt(ctx context.Context, sid streamID,
+	recipient Recipient, proof Blob) error {
+
+	proofHash := sha256.Sum256(proof)
+
+	var claimed bool
+	for {
+		msg, err := h.mailbox.RecvAck(ctx, sid)
+		switch {
+		// If the receiver claimed its mailbox, but we don't get its
+		// receipt, the proof was likely taken from the mailbox by
+		// someone else, or the receiver rejected it.
+		case err != nil && claimed:
+			return fmt.Errorf("receiver claimed mailbox but "+
+				"didn't confirm delivery: %w", err)
+
+		case err != nil:
+			return err
+
+		case bytes.Equal(msg, ackMsg):
+			if h.cfg.RequireSignedReceipts {
+				log.Debugf("Ignoring unsigned ACK (sid=%x)",
+					sid[:])
+				continue
+			}
+
+			log.Debugf("Received ACK from receiver (sid=%x)",
+				sid[:])
+			return nil
+
+		case !isMailboxAuthMsg(msg):
+			log.Warnf("Discarding unexpected message in ACK "+
+				"mailbox (sid=%x, len=%d)", sid[:], len(msg))
+			continue
+		}
+
+		auth, err := decodeMailboxAuth(msg)
+		if err == nil && auth.streamID != sid {
+			err = fmt.Errorf("auth for mailbox %x",
+				auth.streamID[:])
+		}
+		if err == nil {
+			err = auth.verify(recipient.ScriptKey)
+		}
+		if err != nil {
+			log.Warnf("Discarding invalid mailbox auth message "+
+				"(sid=%x): %v", sid[:], err)
+			continue
+		}
+
+		switch auth.authType {
+		case mailboxAuthClaim:
+			log.Debugf("Receiver claimed mailbox (sid=%x)", sid[:])
+			claimed = true
+
+		case mailboxAuthReceipt:
+			// The receipt of an earlier delivery attempt might
+			// still be in the mailbox, so we only accept it if it
+			// is for the proof we're delivering now.
+			if auth.proofHash != proofHash {
+				log.Debugf("Ignoring receipt for proof %x "+
+					"(sid=%x)", auth.proofHash[:], sid[:])
+				continue
+			}
+
+			log.Debugf("Received signed delivery receipt from "+
+				"receiver (sid=%x)", sid[:])
+			return nil
+
+		default:
+			log.Warnf("Discarding mailbox auth message of "+
+				"unknown type %v (sid=%x)", auth.authType,
+				sid[:])
+		}
+	}
+}
+
 // initMailboxes initializes the mailboxes for the sender and receiver.
 func (h *HashMailCourier) initMailboxes(ctx context.Context,
 	senderStreamID streamID, receiverStreamID streamID) error {
@@ -1073,6 +1227,10 @@ type BackoffWaitEvent struct {
 	// proof from the transfer counterparty. Note that the transfer
 	// counterparty is usually the proof courier service.
 	TransferType TransferType
+
+	// Error is the error of the failed transfer attempt that caused the
+	// backoff wait.
+	Error error
 }
 
 // Timestamp returns the timestamp of the event.
@@ -1083,13 +1241,14 @@ func (e *BackoffWaitEvent) Timestamp() time.Time {
 // NewBackoffWaitEvent creates a new BackoffWaitEvent.
 func NewBackoffWaitEvent(
 	backoff time.Duration, triesCounter int64,
-	transferType TransferType) *BackoffWaitEvent {
+	transferType TransferType, err error) *BackoffWaitEvent {
 
 	return &BackoffWaitEvent{
 		timestamp:    time.Now().UTC(),
 		Backoff:      backoff,
 		TriesCounter: triesCounter,
 		TransferType: transferType,
+		Error:        err,
 	}
 }
 
@@ -1111,18 +1270,53 @@ func (h *HashMailCourier) ReceiveProof(ctx context.Context, recipient Recipient,
 		return nil, err
 	}
 
+	// If we know the key our script key was derived from, we authenticate
+	// ourselves to the sender. We claim our ACK mailbox right away, so the
+	// sender can tell that we're waiting for the proof.
+	receiverStreamID := deriveReceiverStreamID(recipient)
+	authenticated := h.signer != nil && recipient.ScriptKeyTweak != nil
+	if authenticated {
+		log.Infof("Claiming ACK mailbox via sid=%x", receiverStreamID)
+		if err := h.mailbox.Init(ctx, receiverStreamID); err != nil {
+			return nil, err
+		}
+
+		err := h.writeMailboxAuth(
+			ctx, mailboxAuthClaim, receiverStreamID, recipient,
+			[sha256.Size]byte{},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	log.Infof("Attempting to receive proof via sid=%x", senderStreamID)
 
 	// To receiver the proof from the sender, we'll derive the stream ID
 	// they'll use to send the proof, and then wait to receive it.
-	proof, err := h.mailbox.ReadProof(ctx, senderStreamID)
-	if err != nil {
-		return nil, err
+	var proof Blob
+	for {
+		proof, err = h.mailbox.ReadProof(ctx, senderStreamID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Anyone can write to the mailbox, so we discard messages that
+		// aren't a proof for our script key, instead of acknowledging
+		// them.
+		if !authenticated || isProofForRecipient(proof, recipient) {
+			break
+		}
+
+		log.Warnf("Discarding unexpected message in proof mailbox "+
+			"(sid=%x, len=%d)", senderStreamID, len(proof))
 	}
 
 	// Now that we've read the proof, we'll create our mailbox (which might
-	// already exist) to send an ACK back to the sender.
-	receiverStreamID := deriveReceiverStreamID(recipient)
+	// already exist) to send an ACK back to the sender. For compatibility
+	// with senders running older versions, which only read the first
+	// message after the proof, we send a plain ACK before the signed
+	// delivery receipt.
 	log.Infof("Sending ACK to sender via sid=%x", receiverStreamID)
 	if err := h.mailbox.Init(ctx, receiverStreamID); err != nil {
 		return nil, err
@@ -1130,6 +1324,15 @@ func (h *HashMailCourier) ReceiveProof(ctx context.Context, recipient Recipient,
 	if err := h.mailbox.AckProof(ctx, receiverStreamID); err != nil {
 		return nil, err
 	}
+	if authenticated {
+		err := h.writeMailboxAuth(
+			ctx, mailboxAuthReceipt, receiverStreamID, recipient,
+			sha256.Sum256(proof),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Finally, we'll return the proof state back to the caller.
 	return &AnnotatedProof{
@@ -1138,6 +1341,32 @@ func (h *HashMailCourier) ReceiveProof(ctx context.Context, recipient Recipient,
 	}, nil
 }
 
+// writeMailboxAuth signs a mailbox claim or delivery receipt and writes it to
+// the mailbox specified by the sid.
+func (h *HashMailCourier) writeMailboxAuth(ctx context.Context,
+	authType mailboxAuthType, sid streamID, recipient Recipient,
+	proofHash [sha256.Size]byte) error {
+
+	auth, err := newMailboxAuth(
+		ctx, h.signer, authType, sid, recipient, proofHash,
+	)
+	if err != nil {
+		return err
+	}
+
+	authBytes, err := auth.encode()
+	if err != nil {
+		return err
+	}
+
+	if err := h.mailbox.WriteAuth(ctx, sid, authBytes); err != nil {
+		return fmt.Errorf("unable to write mailbox %v: %w", authType,
+			err)
+	}
+
+	return nil
+}
+
 // SetSubscribers sets the subscribers for the courier. This method is
 // thread-safe.
 func (h *HashMailCourier) SetSubscribers(
@@ -1173,6 +1402,10 @@ type UniverseRpcCourier struct {
 	// store and query for proofs.
 	localArchive Archiver
 
+	// metaBlobs is the optional store of the meta blobs the courier
+	// delivers alongside the proofs.
+	metaBlobs MetaBlobStore
+
 	// rawConn is the raw connection that the courier will use to interact
 	// with the remote gRPC service.
 	rawConn *grpc.ClientConn
@@ -1197,13 +1430,15 @@ type UniverseRpcCourier struct {
 // NewUniverseRpcCourier creates a new universe RPC proof courier service
 // handle.
 func NewUniverseRpcCourier(ctx context.Context, cfg *UniverseRpcCourierCfg,
-	transferLog TransferLog, localArchive Archiver, addr *url.URL,
+	transferLog TransferLog, localArchive Archiver,
+	metaBlobs MetaBlobStore, addr *url.URL,
 	lazyConnect bool) (*UniverseRpcCourier, error) {
 
 	courier := UniverseRpcCourier{
 		cfg:           cfg,
 		addr:          addr,
 		localArchive:  localArchive,
+		metaBlobs:     metaBlobs,
 		backoffHandle: NewBackoffHandler(cfg.BackoffCfg, transferLog),
 		subscribers:   make(map[uint64]*fn.EventReceiver[fn.Event]),
 	}
@@ -1264,7 +1499,7 @@ func (c *UniverseRpcCourier) ensureConnect(ctx context.Context) error {
 		return err
 	}
 
-	c.client = unirpc.NewUniverseClient(conn)
+	c.client = NewUploadPoWClient(unirpc.NewUniverseClient(conn))
 	c.rawConn = conn
 
 	return nil
@@ -1358,7 +1593,8 @@ func (c *UniverseRpcCourier) DeliverProof(ctx context.Context,
 					"attempt: %w", err)
 			}
 
-			// Submit proof to courier.
+			// Submit proof to courier. The client adds the
+			// proof-of-work the courier service requires, if any.
 			_, err = c.client.InsertProof(ctx, &unirpc.AssetProof{
 				Key:       &universeKey,
 				AssetLeaf: &assetLeaf,
@@ -1381,9 +1617,150 @@ func (c *UniverseRpcCourier) DeliverProof(ctx context.Context,
 		}
 	}
 
+	// If the asset's metadata is stored as a blob, we deliver the blob
+	// as well. The proofs were delivered at this point, so we only log a
+	// failure to deliver the blob, which can be pushed again later.
+	c.genesisBlobRef(proofFile).WhenSome(func(ref BlobRef) {
+		if err := c.pushMetaBlob(ctx, ref); err != nil {
+			log.Warnf("Unable to deliver meta blob %v: %v", ref,
+				err)
+		}
+	})
+
 	return err
 }
 
+// genesisBlobRef returns the meta blob reference of the asset in the given
+// proof file, if the courier has a meta blob store and the genesis proof
+// reveals a meta of type MetaBlobRef.
+func (c *UniverseRpcCourier) genesisBlobRef(
+	proofFile *File) fn.Option[BlobRef] {
+
+	if c.metaBlobs == nil || proofFile.NumProofs() == 0 {
+		return fn.None[BlobRef]()
+	}
+
+	genesisProof, err := proofFile.ProofAt(0)
+	if err != nil {
+		return fn.None[BlobRef]()
+	}
+
+	return genesisProof.MetaReveal.BlobRef()
+}
+
+// pushMetaBlob delivers the meta blob with the given reference to the courier
+// service, if it is stored locally.
+func (c *UniverseRpcCourier) pushMetaBlob(ctx context.Context,
+	ref BlobRef) error {
+
+	has, err := c.metaBlobs.HasBlob(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if !has {
+		log.Debugf("Meta blob %v not stored locally, not delivering "+
+			"it", ref)
+		return nil
+	}
+
+	blob, err := c.metaBlobs.GetBlob(ctx, ref)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	if err := c.ensureConnect(ctx); err != nil {
+		return fmt.Errorf("unable to connect to courier service: %w",
+			err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.PushMetaBlob(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Only the first message needs to identify the blob.
+	firstChunk := true
+	err = SendBlobChunks(blob, func(chunk []byte) error {
+		req := &unirpc.PushMetaBlobRequest{
+			Chunk: chunk,
+		}
+		if firstChunk {
+			req.BlobHash = ref.Hash[:]
+			req.Size = ref.Size
+			firstChunk = false
+		}
+
+		return stream.Send(req)
+	})
+
+	// The courier service ends the stream early if it already stored the
+	// blob, in which case sending returns io.EOF and the actual outcome is
+	// returned when receiving the response.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Delivered meta blob %v (%d bytes, already_stored=%v)", ref,
+		ref.Size, resp.AlreadyStored)
+
+	return nil
+}
+
+// pullMetaBlob retrieves the meta blob with the given reference from the
+// courier service and stores it locally, if it isn't stored yet.
+func (c *UniverseRpcCourier) pullMetaBlob(ctx context.Context,
+	ref BlobRef) error {
+
+	has, err := c.metaBlobs.HasBlob(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if has {
+		return nil
+	}
+
+	if err := c.ensureConnect(ctx); err != nil {
+		return fmt.Errorf("unable to connect to courier service: %w",
+			err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.PullMetaBlob(ctx, &unirpc.PullMetaBlobRequest{
+		BlobHash: ref.Hash[:],
+		Size:     ref.Size,
+	})
+	if err != nil {
+		return err
+	}
+
+	blob := NewBlobChunkReader(func() ([]byte, error) {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Chunk, nil
+	})
+	if _, err := c.metaBlobs.PutBlob(ctx, blob, fn.Some(ref)); err != nil {
+		return err
+	}
+
+	log.Infof("Retrieved meta blob %v (%d bytes)", ref, ref.Size)
+
+	return nil
+}
+
 // ReceiveProof attempts to obtain a proof file from the courier service. The
 // final proof in the target proof file is identified by the given locator.
 func (c *UniverseRpcCourier) ReceiveProof(ctx context.Context,
@@ -1454,6 +1831,16 @@ func (c *UniverseRpcCourier) ReceiveProof(ctx context.Context,
 			err)
 	}
 
+	// If the asset's metadata is stored as a blob, we retrieve the blob
+	// as well. The proofs don't depend on the blob, so we only log a
+	// failure to retrieve it.
+	c.genesisBlobRef(proofFile).WhenSome(func(ref BlobRef) {
+		if err := c.pullMetaBlob(ctx, ref); err != nil {
+			log.Warnf("Unable to retrieve meta blob %v: %v", ref,
+				err)
+		}
+	})
+
 	// Encode the full proof file.
 	var buf bytes.Buffer
 	if err := proofFile.Encode(&buf); err != nil {
@@ -0,0 +1,294 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// mailboxAuthMagic is the prefix of all authentication messages that are
+// written to a hashmail mailbox. It allows the counterparty to distinguish
+// them from proofs and plain ACKs.
+var mailboxAuthMagic = []byte("tapmbxauth")
+
+// mailboxAuthSigPrefix is the domain separator of the messages the receiver
+// signs to authenticate itself.
+const mailboxAuthSigPrefix = "taproot-assets-mailbox-auth"
+
+var (
+	// ErrMailboxAuthInvalidSig is returned if the signature of a mailbox
+	// claim or delivery receipt is invalid.
+	ErrMailboxAuthInvalidSig = errors.New("invalid mailbox auth " +
+		"signature")
+
+	// ErrMailboxAuthKeyMismatch is returned if the key of a mailbox claim
+	// or delivery receipt doesn't belong to the recipient's script key.
+	ErrMailboxAuthKeyMismatch = errors.New("mailbox auth key doesn't " +
+		"match recipient script key")
+)
+
+// MailboxSigner creates the signatures the receiver of a proof uses to
+// authenticate its mailbox claims and delivery receipts.
+type MailboxSigner interface {
+	// SignMailboxMsg returns a Schnorr signature over the SHA-256 hash of
+	// the given message, created with the private key of the given key
+	// descriptor.
+	SignMailboxMsg(ctx context.Context, keyDesc keychain.KeyDescriptor,
+		msg []byte) (*schnorr.Signature, error)
+}
+
+// mailboxAuthType is the type of a mailbox authentication message.
+type mailboxAuthType uint8
+
+const (
+	// mailboxAuthClaim is a claim the receiver writes to its ACK mailbox
+	// when creating it, to show that it is waiting for the proof.
+	mailboxAuthClaim mailboxAuthType = 1
+
+	// mailboxAuthReceipt is a receipt the receiver writes to its ACK
+	// mailbox once it received a valid proof. It commits to the hash of
+	// the proof that was received.
+	mailboxAuthReceipt mailboxAuthType = 2
+)
+
+// String returns a human-readable name of the authentication message type.
+func (t mailboxAuthType) String() string {
+	switch t {
+	case mailboxAuthClaim:
+		return "claim"
+
+	case mailboxAuthReceipt:
+		return "receipt"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// mailboxAuth is a message signed by the receiver of a proof with the key its
+// script key was derived from. As only the receiver knows that key, the sender
+// can tell its messages apart from those of third parties that have access to
+// the same mailbox.
+type mailboxAuth struct {
+	// authType is the type of the message.
+	authType mailboxAuthType
+
+	// streamID is the ID of the mailbox the message was written to.
+	streamID streamID
+
+	// rawKey is the raw key the recipient's script key was derived from.
+	rawKey *btcec.PublicKey
+
+	// tweak is the tapscript tweak that was applied to the raw key. If it
+	// is empty, a BIP-0086 tweak was applied.
+	tweak []byte
+
+	// proofHash is the SHA-256 hash of the received proof. This is only
+	// set for receipts.
+	proofHash [sha256.Size]byte
+
+	// sig is the signature over the message, created with the raw key.
+	sig *schnorr.Signature
+}
+
+// sigMessage returns the message that is signed by the receiver. It commits to
+// the mailbox and to the script key, so a message can't be replayed for another
+// mailbox or recipient.
+func (m *mailboxAuth) sigMessage(scriptKey *btcec.PublicKey) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(mailboxAuthSigPrefix)
+	buf.WriteByte(byte(m.authType))
+	buf.Write(m.streamID[:])
+	buf.Write(schnorr.SerializePubKey(scriptKey))
+	buf.Write(m.proofHash[:])
+
+	return buf.Bytes()
+}
+
+// verify checks that the message was signed with the key the given script key
+// was derived from.
+func (m *mailboxAuth) verify(scriptKey *btcec.PublicKey) error {
+	if m.rawKey == nil || m.sig == nil {
+		return fmt.Errorf("mailbox auth %v is incomplete", m.authType)
+	}
+
+	derivedKey := txscript.ComputeTaprootOutputKey(m.rawKey, m.tweak)
+	derivedKeyBytes := schnorr.SerializePubKey(derivedKey)
+	if !bytes.Equal(derivedKeyBytes, schnorr.SerializePubKey(scriptKey)) {
+		return ErrMailboxAuthKeyMismatch
+	}
+
+	digest := sha256.Sum256(m.sigMessage(scriptKey))
+	if !m.sig.Verify(digest[:], m.rawKey) {
+		return ErrMailboxAuthInvalidSig
+	}
+
+	return nil
+}
+
+// encode serializes the message, including the magic prefix.
+func (m *mailboxAuth) encode() ([]byte, error) {
+	if m.rawKey == nil || m.sig == nil {
+		return nil, fmt.Errorf("mailbox auth %v is incomplete",
+			m.authType)
+	}
+	if len(m.tweak) > 0xff {
+		return nil, fmt.Errorf("tweak too long: %d bytes", len(m.tweak))
+	}
+
+	var buf bytes.Buffer
+	buf.Write(mailboxAuthMagic)
+	buf.WriteByte(byte(m.authType))
+	buf.Write(m.streamID[:])
+	buf.Write(m.rawKey.SerializeCompressed())
+	buf.WriteByte(byte(len(m.tweak)))
+	buf.Write(m.tweak)
+	buf.Write(m.proofHash[:])
+	buf.Write(m.sig.Serialize())
+
+	return buf.Bytes(), nil
+}
+
+// isMailboxAuthMsg returns true if the given mailbox message is an
+// authentication message.
+func isMailboxAuthMsg(msg []byte) bool {
+	return bytes.HasPrefix(msg, mailboxAuthMagic)
+}
+
+// decodeMailboxAuth parses an authentication message that was read from a
+// mailbox.
+func decodeMailboxAuth(msg []byte) (*mailboxAuth, error) {
+	if !isMailboxAuthMsg(msg) {
+		return nil, fmt.Errorf("not a mailbox auth message")
+	}
+
+	r := bytes.NewReader(msg[len(mailboxAuthMagic):])
+
+	var (
+		m        mailboxAuth
+		rawKey   [btcec.PubKeyBytesLenCompressed]byte
+		sig      [schnorr.SignatureSize]byte
+		authType uint8
+		tweakLen uint8
+	)
+	if err := binary.Read(r, binary.BigEndian, &authType); err != nil {
+		return nil, err
+	}
+	m.authType = mailboxAuthType(authType)
+
+	if _, err := io.ReadFull(r, m.streamID[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, rawKey[:]); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &tweakLen); err != nil {
+		return nil, err
+	}
+	if tweakLen > 0 {
+		m.tweak = make([]byte, tweakLen)
+		if _, err := io.ReadFull(r, m.tweak); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := io.ReadFull(r, m.proofHash[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, sig[:]); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes in mailbox auth "+
+			"message", r.Len())
+	}
+
+	var err error
+	m.rawKey, err = btcec.ParsePubKey(rawKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mailbox auth key: %w", err)
+	}
+
+	m.sig, err = schnorr.ParseSignature(sig[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mailbox auth signature: %w",
+			err)
+	}
+
+	return &m, nil
+}
+
+// newMailboxAuth creates a new authentication message for the given mailbox
+// and signs it with the key the recipient's script key was derived from.
+func newMailboxAuth(ctx context.Context, signer MailboxSigner,
+	authType mailboxAuthType, sid streamID, recipient Recipient,
+	proofHash [sha256.Size]byte) (*mailboxAuth, error) {
+
+	tweakedKey := recipient.ScriptKeyTweak
+	if tweakedKey == nil || tweakedKey.RawKey.PubKey == nil {
+		return nil, fmt.Errorf("raw script key of recipient unknown")
+	}
+
+	m := &mailboxAuth{
+		authType:  authType,
+		streamID:  sid,
+		rawKey:    tweakedKey.RawKey.PubKey,
+		tweak:     tweakedKey.Tweak,
+		proofHash: proofHash,
+	}
+
+	sig, err := signer.SignMailboxMsg(
+		ctx, tweakedKey.RawKey, m.sigMessage(recipient.ScriptKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign mailbox %v: %w",
+			authType, err)
+	}
+	m.sig = sig
+
+	// As a sanity check, we make sure the sender will be able to verify
+	// the message.
+	if err := m.verify(recipient.ScriptKey); err != nil {
+		return nil, fmt.Errorf("unable to verify mailbox %v: %w",
+			authType, err)
+	}
+
+	return m, nil
+}
+
+// isProofForRecipient returns true if the given proof blob is a proof file
+// for an asset that was sent to the recipient's script key. The receiver uses
+// this to discard messages of third parties in its proof mailbox.
+func isProofForRecipient(blob Blob, recipient Recipient) bool {
+	if !blob.IsFile() {
+		return false
+	}
+
+	file, err := blob.AsFile()
+	if err != nil {
+		return false
+	}
+
+	lastProof, err := file.LastProof()
+	if err != nil {
+		return false
+	}
+
+	scriptKey := lastProof.Asset.ScriptKey.PubKey
+	if scriptKey == nil || recipient.ScriptKey == nil {
+		return false
+	}
+
+	return bytes.Equal(
+		schnorr.SerializePubKey(scriptKey),
+		schnorr.SerializePubKey(recipient.ScriptKey),
+	)
+}
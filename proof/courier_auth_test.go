@@ -0,0 +1,275 @@
+package proof
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMailboxSigner is a MailboxSigner that signs with a set of known private
+// keys.
+type mockMailboxSigner struct {
+	keys map[[33]byte]*btcec.PrivateKey
+}
+
+// SignMailboxMsg returns a Schnorr signature over the SHA-256 hash of the
+// given message.
+func (m *mockMailboxSigner) SignMailboxMsg(_ context.Context,
+	keyDesc keychain.KeyDescriptor, msg []byte) (*schnorr.Signature,
+	error) {
+
+	var pubKey [33]byte
+	copy(pubKey[:], keyDesc.PubKey.SerializeCompressed())
+
+	privKey, ok := m.keys[pubKey]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %x", pubKey[:])
+	}
+
+	digest := sha256.Sum256(msg)
+	return schnorr.Sign(privKey, digest[:])
+}
+
+// newAuthRecipient creates a recipient with a script key derived from a new
+// private key, which is added to the signer.
+func newAuthRecipient(t *testing.T, signer *mockMailboxSigner,
+	tweak []byte) Recipient {
+
+	t.Helper()
+
+	privKey := test.RandPrivKey()
+	rawKey := privKey.PubKey()
+
+	var pubKey [33]byte
+	copy(pubKey[:], rawKey.SerializeCompressed())
+	signer.keys[pubKey] = privKey
+
+	return Recipient{
+		ScriptKey: txscript.ComputeTaprootOutputKey(rawKey, tweak),
+		ScriptKeyTweak: &asset.TweakedScriptKey{
+			RawKey: keychain.KeyDescriptor{
+				PubKey: rawKey,
+			},
+			Tweak: tweak,
+		},
+	}
+}
+
+// TestMailboxAuth tests that mailbox claims and delivery receipts can be
+// encoded, decoded and verified, and that they're bound to the recipient and
+// mailbox they were created for.
+func TestMailboxAuth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	signer := &mockMailboxSigner{
+		keys: make(map[[33]byte]*btcec.PrivateKey),
+	}
+
+	testCases := []struct {
+		name  string
+		tweak []byte
+	}{{
+		name: "bip86 script key",
+	}, {
+		name:  "tweaked script key",
+		tweak: test.RandBytes(32),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipient := newAuthRecipient(t, signer, tc.tweak)
+			other := newAuthRecipient(t, signer, tc.tweak)
+
+			var sid streamID
+			copy(sid[:], test.RandBytes(len(sid)))
+			proofHash := sha256.Sum256(test.RandBytes(100))
+
+			auth, err := newMailboxAuth(
+				ctx, signer, mailboxAuthReceipt, sid,
+				recipient, proofHash,
+			)
+			require.NoError(t, err)
+
+			msg, err := auth.encode()
+			require.NoError(t, err)
+			require.True(t, isMailboxAuthMsg(msg))
+			require.False(t, isMailboxAuthMsg(ackMsg))
+
+			decoded, err := decodeMailboxAuth(msg)
+			require.NoError(t, err)
+			require.Equal(t, mailboxAuthReceipt, decoded.authType)
+			require.Equal(t, sid, decoded.streamID)
+			require.Equal(t, proofHash, decoded.proofHash)
+			require.NoError(t, decoded.verify(recipient.ScriptKey))
+
+			// The message must not be accepted for another
+			// recipient.
+			err = decoded.verify(other.ScriptKey)
+			require.ErrorIs(t, err, ErrMailboxAuthKeyMismatch)
+
+			// Changing any of the signed fields must invalidate
+			// the signature.
+			tampered := *decoded
+			tampered.proofHash[0] ^= 1
+			err = tampered.verify(recipient.ScriptKey)
+			require.ErrorIs(t, err, ErrMailboxAuthInvalidSig)
+
+			tampered = *decoded
+			tampered.streamID[0] ^= 1
+			err = tampered.verify(recipient.ScriptKey)
+			require.ErrorIs(t, err, ErrMailboxAuthInvalidSig)
+
+			tampered = *decoded
+			tampered.authType = mailboxAuthClaim
+			err = tampered.verify(recipient.ScriptKey)
+			require.ErrorIs(t, err, ErrMailboxAuthInvalidSig)
+
+			// Truncated or extended messages must be rejected.
+			_, err = decodeMailboxAuth(msg[:len(msg)-1])
+			require.Error(t, err)
+			_, err = decodeMailboxAuth(append(msg, 0x00))
+			require.Error(t, err)
+		})
+	}
+}
+
+// ackOnlyMailbox is a ProofMailbox that only supports receiving messages
+// from the receiver's ACK mailbox.
+type ackOnlyMailbox struct {
+	ProofMailbox
+
+	msgs [][]byte
+}
+
+// RecvAck returns the next queued message, or an error if there are no more
+// messages.
+func (m *ackOnlyMailbox) RecvAck(context.Context, streamID) ([]byte, error) {
+	if len(m.msgs) == 0 {
+		return nil, errors.New("mailbox closed")
+	}
+
+	msg := m.msgs[0]
+	m.msgs = m.msgs[1:]
+
+	return msg, nil
+}
+
+// TestHashMailCourierWaitForReceipt tests that the sender only accepts valid
+// delivery receipts for the proof it delivered.
+func TestHashMailCourierWaitForReceipt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	signer := &mockMailboxSigner{
+		keys: make(map[[33]byte]*btcec.PrivateKey),
+	}
+	recipient := newAuthRecipient(t, signer, nil)
+	other := newAuthRecipient(t, signer, nil)
+
+	var sid streamID
+	copy(sid[:], test.RandBytes(len(sid)))
+	proof := Blob(test.RandBytes(100))
+	proofHash := sha256.Sum256(proof)
+
+	newMsg := func(authType mailboxAuthType, sid streamID,
+		recipient Recipient, proofHash [32]byte) []byte {
+
+		auth, err := newMailboxAuth(
+			ctx, signer, authType, sid, recipient, proofHash,
+		)
+		require.NoError(t, err)
+
+		msg, err := auth.encode()
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	var otherSID streamID
+	copy(otherSID[:], test.RandBytes(len(otherSID)))
+
+	claim := newMsg(mailboxAuthClaim, sid, recipient, [32]byte{})
+	receipt := newMsg(mailboxAuthReceipt, sid, recipient, proofHash)
+	staleReceipt := newMsg(
+		mailboxAuthReceipt, sid, recipient, sha256.Sum256(nil),
+	)
+	foreignReceipt := newMsg(mailboxAuthReceipt, sid, other, proofHash)
+	replayedReceipt := newMsg(
+		mailboxAuthReceipt, otherSID, recipient, proofHash,
+	)
+
+	testCases := []struct {
+		name           string
+		requireSigned  bool
+		msgs           [][]byte
+		expectErr      bool
+		expectClaimErr bool
+	}{{
+		name: "plain ack",
+		msgs: [][]byte{ackMsg},
+	}, {
+		name:          "plain ack with signed receipts required",
+		requireSigned: true,
+		msgs:          [][]byte{ackMsg},
+		expectErr:     true,
+	}, {
+		name:          "claim and receipt",
+		requireSigned: true,
+		msgs:          [][]byte{claim, ackMsg, receipt},
+	}, {
+		name:          "invalid receipts",
+		requireSigned: true,
+		msgs: [][]byte{
+			[]byte("garbage"), staleReceipt, foreignReceipt,
+			replayedReceipt,
+		},
+		expectErr: true,
+	}, {
+		name:           "claim without receipt",
+		requireSigned:  true,
+		msgs:           [][]byte{claim, ackMsg},
+		expectErr:      true,
+		expectClaimErr: true,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			courier := &HashMailCourier{
+				cfg: &HashMailCourierCfg{
+					RequireSignedReceipts: tc.requireSigned,
+				},
+				mailbox: &ackOnlyMailbox{msgs: tc.msgs},
+			}
+
+			err := courier.waitForReceipt(
+				ctx, sid, recipient, proof,
+			)
+			if !tc.expectErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			if tc.expectClaimErr {
+				require.ErrorContains(
+					t, err, "receiver claimed",
+				)
+			} else {
+				require.NotContains(
+					t, err.Error(), "receiver claimed",
+				)
+			}
+		})
+	}
+}
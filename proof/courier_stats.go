@@ -0,0 +1,167 @@
+package proof
+
+import (
+	"context"
+	"encoding/hex"
+	"sync/atomic"
+
+	"github.com/lightninglabs/taproot-assets/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CourierStats is a snapshot of the statistics of the proof transfers of all
+// couriers created by a courier dispatch.
+type CourierStats struct {
+	// DeliveryAttempts is the number of attempts to deliver a proof,
+	// including the retries of a single delivery.
+	DeliveryAttempts uint64
+
+	// DeliverySuccesses is the number of proofs that were delivered.
+	DeliverySuccesses uint64
+
+	// DeliveryFailures is the number of proofs that couldn't be delivered
+	// after all attempts.
+	DeliveryFailures uint64
+
+	// ReceiveAttempts is the number of attempts to receive a proof,
+	// including the retries of a single retrieval.
+	ReceiveAttempts uint64
+
+	// ReceiveSuccesses is the number of proofs that were received.
+	ReceiveSuccesses uint64
+
+	// ReceiveFailures is the number of proofs that couldn't be received
+	// after all attempts.
+	ReceiveFailures uint64
+}
+
+// courierStats holds the counters of the proof transfers.
+type courierStats struct {
+	deliveryAttempts  atomic.Uint64
+	deliverySuccesses atomic.Uint64
+	deliveryFailures  atomic.Uint64
+	receiveAttempts   atomic.Uint64
+	receiveSuccesses  atomic.Uint64
+	receiveFailures   atomic.Uint64
+}
+
+// snapshot returns the current values of the counters.
+func (c *courierStats) snapshot() CourierStats {
+	return CourierStats{
+		DeliveryAttempts:  c.deliveryAttempts.Load(),
+		DeliverySuccesses: c.deliverySuccesses.Load(),
+		DeliveryFailures:  c.deliveryFailures.Load(),
+		ReceiveAttempts:   c.receiveAttempts.Load(),
+		ReceiveSuccesses:  c.receiveSuccesses.Load(),
+		ReceiveFailures:   c.receiveFailures.Load(),
+	}
+}
+
+// statsTransferLog is a transfer log that counts the logged proof transfer
+// attempts before passing them on to the wrapped log.
+type statsTransferLog struct {
+	TransferLog
+
+	stats *courierStats
+}
+
+// LogProofTransferAttempt logs a new proof transfer attempt.
+//
+// NOTE: This is part of the TransferLog interface.
+func (s *statsTransferLog) LogProofTransferAttempt(ctx context.Context,
+	loc Locator, transferType TransferType) error {
+
+	switch transferType {
+	case SendTransferType:
+		s.stats.deliveryAttempts.Add(1)
+
+	case ReceiveTransferType:
+		s.stats.receiveAttempts.Add(1)
+	}
+
+	return s.TransferLog.LogProofTransferAttempt(ctx, loc, transferType)
+}
+
+// A compile-time assertion to ensure statsTransferLog meets the TransferLog
+// interface.
+var _ TransferLog = (*statsTransferLog)(nil)
+
+// instrumentedCourier is a courier that counts the outcomes of the proof
+// transfers of the wrapped courier and traces them.
+type instrumentedCourier struct {
+	Courier
+
+	stats *courierStats
+}
+
+// DeliverProof attempts to delivery a proof to the receiver, using the
+// information in the Addr type.
+//
+// NOTE: This is part of the Courier interface.
+func (i *instrumentedCourier) DeliverProof(ctx context.Context,
+	recipient Recipient, proof *AnnotatedProof) error {
+
+	ctx, span := tracer.Start(
+		ctx, "Courier.DeliverProof",
+		trace.WithAttributes(locatorAttributes(proof.Locator)...),
+	)
+
+	err := i.Courier.DeliverProof(ctx, recipient, proof)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		i.stats.deliveryFailures.Add(1)
+		return err
+	}
+
+	i.stats.deliverySuccesses.Add(1)
+
+	return nil
+}
+
+// ReceiveProof attempts to obtain a proof as identified by the passed locator
+// from the source encapsulated within the specified address.
+//
+// NOTE: This is part of the Courier interface.
+func (i *instrumentedCourier) ReceiveProof(ctx context.Context,
+	recipient Recipient, loc Locator) (*AnnotatedProof, error) {
+
+	ctx, span := tracer.Start(
+		ctx, "Courier.ReceiveProof",
+		trace.WithAttributes(locatorAttributes(loc)...),
+	)
+
+	proof, err := i.Courier.ReceiveProof(ctx, recipient, loc)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		i.stats.receiveFailures.Add(1)
+		return nil, err
+	}
+
+	i.stats.receiveSuccesses.Add(1)
+
+	return proof, nil
+}
+
+// A compile-time assertion to ensure instrumentedCourier meets the Courier
+// interface.
+var _ Courier = (*instrumentedCourier)(nil)
+
+// locatorAttributes returns the trace attributes of the given proof locator.
+func locatorAttributes(loc Locator) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		tracing.ScriptKeyKey.String(hex.EncodeToString(
+			loc.ScriptKey.SerializeCompressed(),
+		)),
+	}
+	if loc.AssetID != nil {
+		attrs = append(attrs, tracing.AssetIDKey.String(
+			loc.AssetID.String(),
+		))
+	}
+	if loc.OutPoint != nil {
+		attrs = append(attrs, tracing.AnchorOutPoint(*loc.OutPoint)...)
+	}
+
+	return attrs
+}
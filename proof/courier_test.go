@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -72,3 +73,74 @@ func TestUniverseRpcCourierLocalArchiveShortCut(t *testing.T) {
 	})
 	require.ErrorContains(t, err, "is missing outpoint")
 }
+
+// noopTransferLog is a transfer log that doesn't record anything.
+type noopTransferLog struct{}
+
+// LogProofTransferAttempt logs a new proof transfer attempt.
+func (n *noopTransferLog) LogProofTransferAttempt(context.Context, Locator,
+	TransferType) error {
+
+	return nil
+}
+
+// QueryProofTransferLog returns timestamps which correspond to logged proof
+// delivery attempts.
+func (n *noopTransferLog) QueryProofTransferLog(context.Context, Locator,
+	TransferType) ([]time.Time, error) {
+
+	return nil, nil
+}
+
+// TestCourierStats tests that the proof transfer attempts and their outcomes
+// are counted.
+func TestCourierStats(t *testing.T) {
+	t.Parallel()
+
+	stats := &courierStats{}
+	transferLog := &statsTransferLog{
+		TransferLog: &noopTransferLog{},
+		stats:       stats,
+	}
+	courier := &instrumentedCourier{
+		Courier: NewMockProofCourier(),
+		stats:   stats,
+	}
+
+	ctx := context.Background()
+	loc := Locator{
+		ScriptKey: *test.RandPubKey(t),
+	}
+
+	// Two delivery attempts are logged before the proof is delivered.
+	for range 2 {
+		err := transferLog.LogProofTransferAttempt(
+			ctx, loc, SendTransferType,
+		)
+		require.NoError(t, err)
+	}
+	err := courier.DeliverProof(ctx, Recipient{}, &AnnotatedProof{
+		Locator: loc,
+		AssetSnapshot: &AssetSnapshot{
+			Asset: &asset.Asset{},
+		},
+	})
+	require.NoError(t, err)
+
+	// Receiving a proof the mock courier doesn't know fails.
+	err = transferLog.LogProofTransferAttempt(
+		ctx, loc, ReceiveTransferType,
+	)
+	require.NoError(t, err)
+	_, err = courier.ReceiveProof(ctx, Recipient{}, Locator{
+		ScriptKey: *test.RandPubKey(t),
+	})
+	require.ErrorIs(t, err, ErrProofNotFound)
+
+	require.Equal(t, CourierStats{
+		DeliveryAttempts:  2,
+		DeliverySuccesses: 1,
+		ReceiveAttempts:   1,
+		ReceiveFailures:   1,
+	}, stats.snapshot())
+}
@@ -0,0 +1,94 @@
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalidDecimalAmount is returned when an amount string can't be
+	// parsed as a decimal number.
+	ErrInvalidDecimalAmount = errors.New("invalid decimal amount")
+
+	// ErrExcessPrecision is returned when an amount in decimal display
+	// units has more decimal places than the decimal display of the asset
+	// allows, so it can't be converted to base units without rounding.
+	ErrExcessPrecision = errors.New("amount has more decimal places " +
+		"than the asset's decimal display")
+)
+
+// FormatDecimalDisplayAmount renders an amount of asset base units in decimal
+// display units, with exactly as many decimal places as the given decimal
+// display value. For example, an amount of 150 with a decimal display of 2 is
+// rendered as "1.50".
+func FormatDecimalDisplayAmount(amount uint64, decDisplay uint32) string {
+	digits := fmt.Sprintf("%d", amount)
+	if decDisplay == 0 {
+		return digits
+	}
+
+	// Pad the digits with leading zeros so there is at least one digit
+	// before the decimal point.
+	width := int(decDisplay) + 1
+	if len(digits) < width {
+		digits = strings.Repeat("0", width-len(digits)) + digits
+	}
+
+	split := len(digits) - int(decDisplay)
+	return digits[:split] + "." + digits[split:]
+}
+
+// ParseDecimalDisplayAmount converts an amount given in decimal display units
+// to asset base units. Amounts that have more significant decimal places than
+// the given decimal display value are rejected instead of being rounded.
+func ParseDecimalDisplayAmount(amount string,
+	decDisplay uint32) (uint64, error) {
+
+	if err := IsValidDecDisplay(decDisplay); err != nil {
+		return 0, err
+	}
+
+	whole, fraction, hasPoint := strings.Cut(amount, ".")
+	if whole == "" || (hasPoint && fraction == "") ||
+		!isDecimalDigits(whole) || !isDecimalDigits(fraction) {
+
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimalAmount, amount)
+	}
+
+	// Trailing zeros don't add any precision, so we only reject the
+	// amount if a non-zero digit can't be represented in base units.
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decDisplay) {
+		return 0, fmt.Errorf("%w: %q has %d decimal places, asset "+
+			"supports %d", ErrExcessPrecision, amount,
+			len(fraction), decDisplay)
+	}
+
+	fraction += strings.Repeat("0", int(decDisplay)-len(fraction))
+
+	baseUnits, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimalAmount, amount)
+	}
+
+	if !baseUnits.IsUint64() {
+		return 0, fmt.Errorf("%w: %q exceeds the maximum amount",
+			ErrInvalidDecimalAmount, amount)
+	}
+
+	return baseUnits.Uint64(), nil
+}
+
+// isDecimalDigits returns true if the given string only consists of the
+// digits 0 to 9.
+func isDecimalDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,138 @@
+package proof
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// TestDecimalDisplayAmount tests the conversion between asset base units and
+// decimal display units.
+func TestDecimalDisplayAmount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		amount     string
+		decDisplay uint32
+		baseUnits  uint64
+		formatted  string
+		err        error
+	}{{
+		name:       "integer without decimal display",
+		amount:     "150",
+		decDisplay: 0,
+		baseUnits:  150,
+		formatted:  "150",
+	}, {
+		name:       "fraction",
+		amount:     "1.5",
+		decDisplay: 2,
+		baseUnits:  150,
+		formatted:  "1.50",
+	}, {
+		name:       "fraction below one",
+		amount:     "0.07",
+		decDisplay: 3,
+		baseUnits:  70,
+		formatted:  "0.070",
+	}, {
+		name:       "whole units",
+		amount:     "42",
+		decDisplay: 6,
+		baseUnits:  42_000_000,
+		formatted:  "42.000000",
+	}, {
+		name:       "trailing zeros beyond decimal display",
+		amount:     "1.2300",
+		decDisplay: 2,
+		baseUnits:  123,
+		formatted:  "1.23",
+	}, {
+		name:       "max amount",
+		amount:     "18446744073709.551615",
+		decDisplay: 6,
+		baseUnits:  math.MaxUint64,
+		formatted:  "18446744073709.551615",
+	}, {
+		name:       "excess precision",
+		amount:     "1.234",
+		decDisplay: 2,
+		err:        ErrExcessPrecision,
+	}, {
+		name:       "fraction without decimal display",
+		amount:     "1.5",
+		decDisplay: 0,
+		err:        ErrExcessPrecision,
+	}, {
+		name:       "overflow",
+		amount:     "18446744073709.551616",
+		decDisplay: 6,
+		err:        ErrInvalidDecimalAmount,
+	}, {
+		name:       "negative",
+		amount:     "-1",
+		decDisplay: 2,
+		err:        ErrInvalidDecimalAmount,
+	}, {
+		name:       "missing whole part",
+		amount:     ".5",
+		decDisplay: 2,
+		err:        ErrInvalidDecimalAmount,
+	}, {
+		name:       "missing fraction",
+		amount:     "1.",
+		decDisplay: 2,
+		err:        ErrInvalidDecimalAmount,
+	}, {
+		name:       "exponent",
+		amount:     "1e3",
+		decDisplay: 2,
+		err:        ErrInvalidDecimalAmount,
+	}, {
+		name:       "decimal display too large",
+		amount:     "1",
+		decDisplay: MaxDecDisplay + 1,
+		err:        ErrDecDisplayTooLarge,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			baseUnits, err := ParseDecimalDisplayAmount(
+				tc.amount, tc.decDisplay,
+			)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.baseUnits, baseUnits)
+			require.Equal(
+				t, tc.formatted, FormatDecimalDisplayAmount(
+					baseUnits, tc.decDisplay,
+				),
+			)
+		})
+	}
+}
+
+// TestDecimalDisplayAmountRoundTrip tests that any amount survives a round
+// trip through its decimal display representation.
+func TestDecimalDisplayAmountRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		amount := rapid.Uint64().Draw(t, "amount")
+		decDisplay := rapid.Uint32Range(0, MaxDecDisplay).Draw(
+			t, "decDisplay",
+		)
+
+		formatted := FormatDecimalDisplayAmount(amount, decDisplay)
+		parsed, err := ParseDecimalDisplayAmount(formatted, decDisplay)
+		require.NoError(t, err)
+		require.Equal(t, amount, parsed)
+	})
+}
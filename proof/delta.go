@@ -0,0 +1,203 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+)
+
+var (
+	// ErrNotDeltaFile is returned when a proof file is treated as a delta
+	// but actually starts at the genesis of the asset.
+	ErrNotDeltaFile = errors.New("proof file is not a delta")
+
+	// ErrDeltaDiscontinuity is returned when the prefix of a delta proof
+	// file doesn't end with the asset output that is spent by the first
+	// proof of the delta.
+	ErrDeltaDiscontinuity = errors.New("proof file prefix doesn't end " +
+		"with the asset spent by the delta")
+)
+
+// IsDelta returns true if the proof file doesn't start at the genesis of the
+// asset but with a transfer that spends a previous asset output. Such a delta
+// file only contains a suffix of the provenance of the asset and must be
+// combined with the prefix the receiver already has before it can be verified.
+func (f *File) IsDelta() (bool, error) {
+	if f.IsEmpty() {
+		return false, nil
+	}
+
+	firstProof, err := f.ProofAt(0)
+	if err != nil {
+		return false, err
+	}
+
+	return !firstProof.Asset.IsGenesisAsset(), nil
+}
+
+// DeltaPrevID returns the previous asset output that is spent by the first
+// proof of the delta proof file. This is the asset the prefix of the delta
+// must end with.
+func (f *File) DeltaPrevID() (*asset.PrevID, error) {
+	isDelta, err := f.IsDelta()
+	if err != nil {
+		return nil, err
+	}
+	if !isDelta {
+		return nil, ErrNotDeltaFile
+	}
+
+	firstProof, err := f.ProofAt(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// The input of a split output is only referenced in the witness of
+	// the split root asset.
+	newAsset := &firstProof.Asset
+	if newAsset.HasSplitCommitmentWitness() {
+		witness := newAsset.PrevWitnesses[0]
+		newAsset = &witness.SplitCommitment.RootAsset
+	}
+
+	// An asset can spend multiple inputs, but only the one anchored at the
+	// previous outpoint of the proof is part of this provenance chain. All
+	// other inputs are carried as additional inputs within the proof.
+	for _, witness := range newAsset.PrevWitnesses {
+		if witness.PrevID == nil {
+			continue
+		}
+
+		if witness.PrevID.OutPoint == firstProof.PrevOut {
+			prevID := *witness.PrevID
+			return &prevID, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no input of the first proof spends the "+
+		"previous outpoint %v", firstProof.PrevOut)
+}
+
+// AppendDelta combines the given prefix proof file with the given delta proof
+// file. The prefix must end with the asset output that is spent by the first
+// proof of the delta. The proofs themselves aren't verified, so the resulting
+// file must still be verified before it can be trusted.
+func AppendDelta(prefix, delta *File) (*File, error) {
+	prevID, err := delta.DeltaPrevID()
+	if err != nil {
+		return nil, err
+	}
+
+	lastProof, err := prefix.LastProof()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch last proof of prefix: "+
+			"%w", err)
+	}
+
+	lastAsset := &lastProof.Asset
+	switch {
+	case lastProof.OutPoint() != prevID.OutPoint:
+		return nil, fmt.Errorf("%w: prefix ends at outpoint %v, delta "+
+			"spends %v", ErrDeltaDiscontinuity,
+			lastProof.OutPoint(), prevID.OutPoint)
+
+	case lastAsset.ID() != prevID.ID:
+		return nil, fmt.Errorf("%w: prefix ends with asset ID %v, "+
+			"delta spends %v", ErrDeltaDiscontinuity,
+			lastAsset.ID(), prevID.ID)
+
+	case asset.ToSerialized(lastAsset.ScriptKey.PubKey) != prevID.ScriptKey:
+		return nil, fmt.Errorf("%w: prefix ends with script key %x, "+
+			"delta spends %x", ErrDeltaDiscontinuity,
+			lastAsset.ScriptKey.PubKey.SerializeCompressed(),
+			prevID.ScriptKey[:])
+	}
+
+	combined := NewEmptyFile(prefix.Version)
+	for idx := 0; idx < prefix.NumProofs(); idx++ {
+		rawProof, err := prefix.RawProofAt(uint32(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := combined.AppendProofRaw(rawProof); err != nil {
+			return nil, err
+		}
+	}
+	for idx := 0; idx < delta.NumProofs(); idx++ {
+		rawProof, err := delta.RawProofAt(uint32(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := combined.AppendProofRaw(rawProof); err != nil {
+			return nil, err
+		}
+	}
+
+	return combined, nil
+}
+
+// ResolveDelta checks whether the given blob is a delta proof file and, if
+// so, looks up the prefix the delta continues from in the given archive and
+// returns the encoded full proof file. Any other blob is returned unchanged.
+// If the prefix isn't known to the archive, ErrProofNotFound is returned.
+func ResolveDelta(ctx context.Context, archive Archiver,
+	blob Blob) (Blob, error) {
+
+	if !IsProofFile(blob) {
+		return blob, nil
+	}
+
+	delta, err := blob.AsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	isDelta, err := delta.IsDelta()
+	if err != nil {
+		return nil, err
+	}
+	if !isDelta {
+		return blob, nil
+	}
+
+	prevID, err := delta.DeltaPrevID()
+	if err != nil {
+		return nil, err
+	}
+
+	scriptKey, err := prevID.ScriptKey.ToPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid script key of delta input: %w",
+			err)
+	}
+
+	prefixBlob, err := archive.FetchProof(ctx, Locator{
+		AssetID:   &prevID.ID,
+		ScriptKey: *scriptKey,
+		OutPoint:  &prevID.OutPoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch prefix of delta proof "+
+			"spending %v: %w", prevID.OutPoint, err)
+	}
+
+	prefix, err := prefixBlob.AsFile()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode prefix: %w", err)
+	}
+
+	combined, err := AppendDelta(prefix, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Resolved delta proof file of %d proofs with known prefix "+
+		"of %d proofs spending %v", delta.NumProofs(),
+		prefix.NumProofs(), prevID.OutPoint)
+
+	return EncodeFile(combined)
+}
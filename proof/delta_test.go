@@ -0,0 +1,112 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/stretchr/testify/require"
+)
+
+// splitFile splits the given proof file into a prefix with the given number of
+// proofs and a delta with the remaining proofs.
+func splitFile(t *testing.T, f *File, numPrefix int) (*File, *File) {
+	prefix := NewEmptyFile(f.Version)
+	delta := NewEmptyFile(f.Version)
+	for idx := 0; idx < f.NumProofs(); idx++ {
+		rawProof, err := f.RawProofAt(uint32(idx))
+		require.NoError(t, err)
+
+		if idx < numPrefix {
+			require.NoError(t, prefix.AppendProofRaw(rawProof))
+		} else {
+			require.NoError(t, delta.AppendProofRaw(rawProof))
+		}
+	}
+
+	return prefix, delta
+}
+
+// TestProofDelta tests that a delta proof file can be combined with the
+// prefix it continues from, but not with any other prefix.
+func TestProofDelta(t *testing.T) {
+	t.Parallel()
+
+	proofHex, err := os.ReadFile(proofFileHexFileName)
+	require.NoError(t, err)
+
+	fileBytes, err := hex.DecodeString(
+		strings.Trim(string(proofHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	f := &File{}
+	require.NoError(t, f.Decode(bytes.NewReader(fileBytes)))
+	require.Greater(t, f.NumProofs(), 2)
+
+	// A full proof file starts at the genesis and isn't a delta.
+	isDelta, err := f.IsDelta()
+	require.NoError(t, err)
+	require.False(t, isDelta)
+
+	_, err = f.DeltaPrevID()
+	require.ErrorIs(t, err, ErrNotDeltaFile)
+
+	prefix, delta := splitFile(t, f, 2)
+	isDelta, err = delta.IsDelta()
+	require.NoError(t, err)
+	require.True(t, isDelta)
+
+	// Combining the delta with its prefix results in the original file.
+	combined, err := AppendDelta(prefix, delta)
+	require.NoError(t, err)
+	require.Equal(t, fileBytes, encodeFile(t, combined))
+
+	_, err = combined.Verify(
+		context.Background(), MockHeaderVerifier, MockMerkleVerifier,
+		MockGroupVerifier, MockChainLookup,
+	)
+	require.NoError(t, err)
+
+	// A prefix that doesn't end with the asset spent by the delta is
+	// rejected.
+	shortPrefix, _ := splitFile(t, f, 1)
+	_, err = AppendDelta(shortPrefix, delta)
+	require.ErrorIs(t, err, ErrDeltaDiscontinuity)
+
+	// The delta can only be resolved once the archive knows the prefix.
+	lastPrefixProof, err := prefix.LastProof()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	archive := NewMockProofArchive()
+	deltaBlob := Blob(encodeFile(t, delta))
+	_, err = ResolveDelta(ctx, archive, deltaBlob)
+	require.ErrorIs(t, err, ErrProofNotFound)
+
+	err = archive.ImportProofs(
+		ctx, nil, nil, nil, nil, false, &AnnotatedProof{
+			Locator: Locator{
+				AssetID: fn.Ptr(lastPrefixProof.Asset.ID()),
+				ScriptKey: *lastPrefixProof.Asset.ScriptKey.
+					PubKey,
+				OutPoint: fn.Ptr(lastPrefixProof.OutPoint()),
+			},
+			Blob: encodeFile(t, prefix),
+		},
+	)
+	require.NoError(t, err)
+
+	fullBlob, err := ResolveDelta(ctx, archive, deltaBlob)
+	require.NoError(t, err)
+	require.Equal(t, Blob(fileBytes), fullBlob)
+
+	// A full proof file is returned unchanged.
+	fullBlob, err = ResolveDelta(ctx, archive, fileBytes)
+	require.NoError(t, err)
+	require.Equal(t, Blob(fileBytes), fullBlob)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
@@ -62,6 +63,25 @@ func VersionDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
 	return tlv.NewTypeForDecodingErr(val, "TransitionVersion", l, 1)
 }
 
+func VMVersionEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*vm.Version); ok {
+		return tlv.EUint32T(w, uint32(*t), buf)
+	}
+	return tlv.NewTypeForEncodingErr(val, "vm.Version")
+}
+
+func VMVersionDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
+	if typ, ok := val.(*vm.Version); ok {
+		var t uint32
+		if err := tlv.DUint32(r, &t, buf, l); err != nil {
+			return err
+		}
+		*typ = vm.Version(t)
+		return nil
+	}
+	return tlv.NewTypeForDecodingErr(val, "vm.Version", l, 4)
+}
+
 func BlockHeaderEncoder(w io.Writer, val any, buf *[8]byte) error {
 	if t, ok := val.(*wire.BlockHeader); ok {
 		return t.Serialize(w)
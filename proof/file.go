@@ -13,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
@@ -41,6 +42,12 @@ const (
 	// V0 is the first version of the proof file.
 	V0 Version = 0
 
+	// V1 is the second version of the proof file. The proofs are the same
+	// as in V0, but each of them is compressed with zstd, and an index of
+	// the compressed proofs allows reading individual proofs without
+	// decoding the entire file.
+	V1 Version = 1
+
 	// FileMaxNumProofs is the maximum number of proofs we expect/allow to
 	// be encoded within a single proof file. Given that there can only be
 	// one transfer per block, this value would be enough to transfer an
@@ -139,6 +146,10 @@ func (f *File) Encode(w io.Writer) error {
 		return err
 	}
 
+	if f.Version == V1 {
+		return f.encodeCompressed(w)
+	}
+
 	var tlvBuf [8]byte
 	if err := tlv.WriteVarInt(w, uint64(len(f.proofs)), &tlvBuf); err != nil {
 		return err
@@ -195,6 +206,10 @@ func (f *File) Decode(r io.Reader) error {
 	}
 	f.Version = Version(version)
 
+	if f.Version == V1 {
+		return f.decodeCompressed(r)
+	}
+
 	var tlvBuf [8]byte
 	numProofs, err := tlv.ReadVarInt(r, &tlvBuf)
 	if err != nil {
@@ -262,7 +277,7 @@ func (f *File) Decode(r io.Reader) error {
 // recognized by this implementation of tap.
 func (f *File) IsUnknownVersion() bool {
 	switch f.Version {
-	case V0:
+	case V0, V1:
 		return false
 	default:
 		return true
@@ -314,6 +329,53 @@ func (f *File) ProofAt(index uint32) (*Proof, error) {
 	return proof, nil
 }
 
+// VMCompatibility describes whether the state transitions of a proof file can
+// be validated under the consensus rules this implementation knows of.
+type VMCompatibility struct {
+	// ProofVersions is the VM version declared by each proof of the file,
+	// in the order of the proofs.
+	ProofVersions []vm.Version
+
+	// MinVersion is the minimum VM version required by the meta reveal of
+	// the asset's genesis.
+	MinVersion vm.Version
+
+	// Compatible is true if all VM versions the file declares or requires
+	// are supported by this implementation.
+	Compatible bool
+}
+
+// VMCompatibility reports which VM versions the proofs of the file declare and
+// whether this implementation knows how to validate all of them. This doesn't
+// verify the proofs themselves.
+func (f *File) VMCompatibility() (*VMCompatibility, error) {
+	report := &VMCompatibility{
+		ProofVersions: make([]vm.Version, 0, len(f.proofs)),
+		Compatible:    true,
+	}
+	for idx := range f.proofs {
+		p, err := f.ProofAt(uint32(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		if idx == 0 && p.MetaReveal != nil {
+			report.MinVersion = p.MetaReveal.MinVMVersion
+		}
+
+		report.ProofVersions = append(report.ProofVersions, p.VMVersion)
+		if !p.VMVersion.IsSupported() {
+			report.Compatible = false
+		}
+	}
+
+	if !report.MinVersion.IsSupported() {
+		report.Compatible = false
+	}
+
+	return report, nil
+}
+
 // LocateProof calls the given predicate for each proof in the file and returns
 // the first proof (and the index) where the predicate returns true, starting
 // from the end of the file. If no proof is found, this returns
@@ -527,4 +589,9 @@ type AssetSnapshot struct {
 	// asset. This is only populated if the asset is a genesis asset, and
 	// the proof had a valid meta reveal.
 	MetaReveal *MetaReveal
+
+	// MinVMVersion is the minimum VM version that all further state
+	// transitions of the asset must declare, as required by the meta
+	// reveal of the asset's genesis.
+	MinVMVersion vm.Version
 }
@@ -2,10 +2,30 @@ package proof
 
 import (
 	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
 )
 
+// addHexFileSeed adds the hex encoded content of the given file to the seed
+// corpus of the fuzz test, with the given magic bytes prefix removed.
+func addHexFileSeed(f *testing.F, fileName string, prefix []byte) {
+	fileHex, err := os.ReadFile(fileName)
+	require.NoError(f, err)
+
+	fileBytes, err := hex.DecodeString(strings.Trim(string(fileHex), "\n"))
+	require.NoError(f, err)
+
+	f.Add(bytes.TrimPrefix(fileBytes, prefix))
+}
+
 func FuzzFile(f *testing.F) {
+	addHexFileSeed(f, proofFileHexFileName, FilePrefixMagicBytes[:])
+
 	f.Fuzz(func(t *testing.T, data []byte) {
 		fileData := make([]byte, 0)
 		fileData = append(fileData, FilePrefixMagicBytes[:]...)
@@ -18,6 +38,19 @@ func FuzzFile(f *testing.F) {
 }
 
 func FuzzProof(f *testing.F) {
+	addHexFileSeed(f, proofHexFileName, PrefixMagicBytes[:])
+	addHexFileSeed(f, ownershipProofHexFileName, PrefixMagicBytes[:])
+
+	// The valid test vectors cover all the optional fields of a proof.
+	testVectors := &TestVectors{}
+	test.ParseTestVectors(f, generatedTestVectorName, &testVectors)
+	for _, validCase := range testVectors.ValidTestCases {
+		proofBytes, err := hex.DecodeString(validCase.Expected)
+		require.NoError(f, err)
+
+		f.Add(bytes.TrimPrefix(proofBytes, PrefixMagicBytes[:]))
+	}
+
 	f.Fuzz(func(t *testing.T, data []byte) {
 		proof := &Proof{}
 
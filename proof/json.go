@@ -0,0 +1,584 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// The JSON types in this file define a canonical JSON encoding of proofs and
+// proof files that can be used alongside the binary TLV encoding, for example
+// by implementations or auditors that don't want to implement a TLV parser.
+// The field names follow the ones used in the BIP test vectors. Transactions
+// are encoded as hex of their wire serialization, hashes in their usual
+// byte-reversed hex notation. Converting a proof to JSON and back results in
+// the exact same TLV encoding.
+
+// JSONProof is the JSON representation of a single state transition proof.
+type JSONProof struct {
+	Version          uint32                    `json:"version"`
+	PrevOut          string                    `json:"prev_out"`
+	BlockHeader      *JSONBlockHeader          `json:"block_header"`
+	BlockHeight      uint32                    `json:"block_height"`
+	AnchorTx         string                    `json:"anchor_tx"`
+	TxMerkleProof    *JSONTxMerkleProof        `json:"tx_merkle_proof"`
+	Asset            *asset.JSONAsset          `json:"asset"`
+	InclusionProof   *JSONTaprootProof         `json:"inclusion_proof"`
+	ExclusionProofs  []*JSONTaprootProof       `json:"exclusion_proofs"`
+	SplitRootProof   *JSONTaprootProof         `json:"split_root_proof"`
+	MetaReveal       *JSONMetaReveal           `json:"meta_reveal"`
+	AdditionalInputs []*JSONFile               `json:"additional_inputs"`
+	ChallengeWitness []string                  `json:"challenge_witness"`
+	GenesisReveal    *asset.JSONGenesis        `json:"genesis_reveal"`
+	GroupKeyReveal   *asset.JSONGroupKeyReveal `json:"group_key_reveal"`
+	UnknownOddTypes  tlv.TypeMap               `json:"unknown_odd_types"`
+}
+
+// NewJSONProof creates the JSON representation of the given proof.
+func NewJSONProof(p *Proof) (*JSONProof, error) {
+	var anchorTx bytes.Buffer
+	if err := p.AnchorTx.Serialize(&anchorTx); err != nil {
+		return nil, fmt.Errorf("unable to serialize anchor tx: %w", err)
+	}
+
+	jsonAsset, err := asset.NewJSONAsset(&p.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode asset: %w", err)
+	}
+
+	inclusionProof, err := NewJSONTaprootProof(&p.InclusionProof)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode inclusion proof: %w",
+			err)
+	}
+
+	jp := &JSONProof{
+		Version:         uint32(p.Version),
+		PrevOut:         p.PrevOut.String(),
+		BlockHeader:     NewJSONBlockHeader(&p.BlockHeader),
+		BlockHeight:     p.BlockHeight,
+		AnchorTx:        hex.EncodeToString(anchorTx.Bytes()),
+		TxMerkleProof:   NewJSONTxMerkleProof(&p.TxMerkleProof),
+		Asset:           jsonAsset,
+		InclusionProof:  inclusionProof,
+		UnknownOddTypes: p.UnknownOddTypes,
+	}
+
+	for idx := range p.ExclusionProofs {
+		exclusionProof, err := NewJSONTaprootProof(
+			&p.ExclusionProofs[idx],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode exclusion "+
+				"proof %d: %w", idx, err)
+		}
+
+		jp.ExclusionProofs = append(jp.ExclusionProofs, exclusionProof)
+	}
+
+	if p.SplitRootProof != nil {
+		jp.SplitRootProof, err = NewJSONTaprootProof(p.SplitRootProof)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode split root "+
+				"proof: %w", err)
+		}
+	}
+
+	if p.MetaReveal != nil {
+		jp.MetaReveal = &JSONMetaReveal{
+			Type:            uint8(p.MetaReveal.Type),
+			Data:            hex.EncodeToString(p.MetaReveal.Data),
+			UnknownOddTypes: p.MetaReveal.UnknownOddTypes,
+		}
+	}
+
+	for idx := range p.AdditionalInputs {
+		inputFile, err := NewJSONFile(&p.AdditionalInputs[idx])
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode additional "+
+				"input %d: %w", idx, err)
+		}
+
+		jp.AdditionalInputs = append(jp.AdditionalInputs, inputFile)
+	}
+
+	for _, witness := range p.ChallengeWitness {
+		jp.ChallengeWitness = append(
+			jp.ChallengeWitness, hex.EncodeToString(witness),
+		)
+	}
+
+	if p.GenesisReveal != nil {
+		jp.GenesisReveal = asset.NewJSONGenesis(p.GenesisReveal)
+	}
+
+	if p.GroupKeyReveal != nil {
+		jp.GroupKeyReveal = asset.NewJSONGroupKeyReveal(
+			p.GroupKeyReveal,
+		)
+	}
+
+	return jp, nil
+}
+
+// ToProof converts the JSON representation back into a proof.
+func (jp *JSONProof) ToProof() (*Proof, error) {
+	if jp.BlockHeader == nil || jp.TxMerkleProof == nil ||
+		jp.Asset == nil || jp.InclusionProof == nil {
+
+		return nil, fmt.Errorf("%w: missing mandatory proof fields",
+			asset.ErrInvalidJSON)
+	}
+
+	prevOut, err := wire.NewOutPointFromString(jp.PrevOut)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prev out: %w", err)
+	}
+
+	blockHeader, err := jp.BlockHeader.ToBlockHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	anchorTxBytes, err := hex.DecodeString(jp.AnchorTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor tx: %w", err)
+	}
+	var anchorTx wire.MsgTx
+	err = anchorTx.Deserialize(bytes.NewReader(anchorTxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to deserialize anchor tx: %w",
+			err)
+	}
+
+	txMerkleProof, err := jp.TxMerkleProof.ToTxMerkleProof()
+	if err != nil {
+		return nil, err
+	}
+
+	proofAsset, err := jp.Asset.ToAsset()
+	if err != nil {
+		return nil, fmt.Errorf("invalid asset: %w", err)
+	}
+
+	inclusionProof, err := jp.InclusionProof.ToTaprootProof()
+	if err != nil {
+		return nil, fmt.Errorf("invalid inclusion proof: %w", err)
+	}
+
+	p := &Proof{
+		Version:         TransitionVersion(jp.Version),
+		PrevOut:         *prevOut,
+		BlockHeader:     *blockHeader,
+		BlockHeight:     jp.BlockHeight,
+		AnchorTx:        anchorTx,
+		TxMerkleProof:   *txMerkleProof,
+		Asset:           *proofAsset,
+		InclusionProof:  *inclusionProof,
+		UnknownOddTypes: jp.UnknownOddTypes,
+	}
+
+	for idx, jsonProof := range jp.ExclusionProofs {
+		exclusionProof, err := jsonProof.ToTaprootProof()
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusion proof %d: %w",
+				idx, err)
+		}
+
+		p.ExclusionProofs = append(p.ExclusionProofs, *exclusionProof)
+	}
+
+	if jp.SplitRootProof != nil {
+		p.SplitRootProof, err = jp.SplitRootProof.ToTaprootProof()
+		if err != nil {
+			return nil, fmt.Errorf("invalid split root proof: %w",
+				err)
+		}
+	}
+
+	if jp.MetaReveal != nil {
+		data, err := hex.DecodeString(jp.MetaReveal.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid meta reveal data: %w",
+				err)
+		}
+
+		p.MetaReveal = &MetaReveal{
+			Type:            MetaType(jp.MetaReveal.Type),
+			Data:            data,
+			UnknownOddTypes: jp.MetaReveal.UnknownOddTypes,
+		}
+	}
+
+	for idx, jsonFile := range jp.AdditionalInputs {
+		inputFile, err := jsonFile.ToFile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid additional input %d: "+
+				"%w", idx, err)
+		}
+
+		p.AdditionalInputs = append(p.AdditionalInputs, *inputFile)
+	}
+
+	for _, witness := range jp.ChallengeWitness {
+		witnessBytes, err := hex.DecodeString(witness)
+		if err != nil {
+			return nil, fmt.Errorf("invalid challenge witness: %w",
+				err)
+		}
+
+		p.ChallengeWitness = append(p.ChallengeWitness, witnessBytes)
+	}
+
+	if jp.GenesisReveal != nil {
+		p.GenesisReveal, err = jp.GenesisReveal.ToGenesis()
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis reveal: %w",
+				err)
+		}
+	}
+
+	if jp.GroupKeyReveal != nil {
+		p.GroupKeyReveal, err = jp.GroupKeyReveal.ToGroupKeyReveal()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// JSONBlockHeader is the JSON representation of a block header.
+type JSONBlockHeader struct {
+	Version    int32  `json:"version"`
+	PrevBlock  string `json:"prev_block"`
+	MerkleRoot string `json:"merkle_root"`
+	Timestamp  uint32 `json:"timestamp"`
+	Bits       uint32 `json:"bits"`
+	Nonce      uint32 `json:"nonce"`
+}
+
+// NewJSONBlockHeader creates the JSON representation of the given block
+// header.
+func NewJSONBlockHeader(h *wire.BlockHeader) *JSONBlockHeader {
+	return &JSONBlockHeader{
+		Version:    h.Version,
+		PrevBlock:  h.PrevBlock.String(),
+		MerkleRoot: h.MerkleRoot.String(),
+		Timestamp:  uint32(h.Timestamp.Unix()),
+		Bits:       h.Bits,
+		Nonce:      h.Nonce,
+	}
+}
+
+// ToBlockHeader converts the JSON representation back into a block header.
+func (jh *JSONBlockHeader) ToBlockHeader() (*wire.BlockHeader, error) {
+	prevBlock, err := chainhash.NewHashFromStr(jh.PrevBlock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prev block hash: %w", err)
+	}
+
+	merkleRoot, err := chainhash.NewHashFromStr(jh.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	return &wire.BlockHeader{
+		Version:    jh.Version,
+		PrevBlock:  *prevBlock,
+		MerkleRoot: *merkleRoot,
+		Timestamp:  time.Unix(int64(jh.Timestamp), 0),
+		Bits:       jh.Bits,
+		Nonce:      jh.Nonce,
+	}, nil
+}
+
+// JSONTxMerkleProof is the JSON representation of a transaction merkle proof.
+type JSONTxMerkleProof struct {
+	Nodes []string `json:"nodes"`
+	Bits  []bool   `json:"bits"`
+}
+
+// NewJSONTxMerkleProof creates the JSON representation of the given
+// transaction merkle proof.
+func NewJSONTxMerkleProof(p *TxMerkleProof) *JSONTxMerkleProof {
+	nodes := make([]string, len(p.Nodes))
+	for idx := range p.Nodes {
+		nodes[idx] = p.Nodes[idx].String()
+	}
+
+	return &JSONTxMerkleProof{
+		Nodes: nodes,
+		Bits:  p.Bits,
+	}
+}
+
+// ToTxMerkleProof converts the JSON representation back into a transaction
+// merkle proof.
+func (jp *JSONTxMerkleProof) ToTxMerkleProof() (*TxMerkleProof, error) {
+	nodes := make([]chainhash.Hash, len(jp.Nodes))
+	for idx := range jp.Nodes {
+		node, err := chainhash.NewHashFromStr(jp.Nodes[idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid merkle proof node: %w",
+				err)
+		}
+
+		nodes[idx] = *node
+	}
+
+	return &TxMerkleProof{
+		Nodes: nodes,
+		Bits:  jp.Bits,
+	}, nil
+}
+
+// JSONTaprootProof is the JSON representation of a Taproot output inclusion
+// or exclusion proof.
+type JSONTaprootProof struct {
+	OutputIndex     uint32               `json:"output_index"`
+	InternalKey     string               `json:"internal_key"`
+	CommitmentProof *JSONCommitmentProof `json:"commitment_proof"`
+	TapscriptProof  *JSONTapscriptProof  `json:"tapscript_proof"`
+	UnknownOddTypes tlv.TypeMap          `json:"unknown_odd_types"`
+}
+
+// NewJSONTaprootProof creates the JSON representation of the given Taproot
+// proof.
+func NewJSONTaprootProof(p *TaprootProof) (*JSONTaprootProof, error) {
+	jp := &JSONTaprootProof{
+		OutputIndex:     p.OutputIndex,
+		InternalKey:     asset.HexPubKey(p.InternalKey),
+		UnknownOddTypes: p.UnknownOddTypes,
+	}
+
+	if p.CommitmentProof != nil {
+		commitmentProof, err := commitment.NewJSONProof(
+			&p.CommitmentProof.Proof,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sibling, err := commitment.EncodeHexTapscriptPreimage(
+			p.CommitmentProof.TapSiblingPreimage,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		jp.CommitmentProof = &JSONCommitmentProof{
+			Proof:            commitmentProof,
+			TapscriptSibling: sibling,
+			UnknownOddTypes:  p.CommitmentProof.UnknownOddTypes,
+		}
+	}
+
+	if p.TapscriptProof != nil {
+		preimage1, err := commitment.EncodeHexTapscriptPreimage(
+			p.TapscriptProof.TapPreimage1,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		preimage2, err := commitment.EncodeHexTapscriptPreimage(
+			p.TapscriptProof.TapPreimage2,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		jp.TapscriptProof = &JSONTapscriptProof{
+			TapPreimage1:    preimage1,
+			TapPreimage2:    preimage2,
+			Bip86:           p.TapscriptProof.Bip86,
+			UnknownOddTypes: p.TapscriptProof.UnknownOddTypes,
+		}
+	}
+
+	return jp, nil
+}
+
+// ToTaprootProof converts the JSON representation back into a Taproot proof.
+func (jp *JSONTaprootProof) ToTaprootProof() (*TaprootProof, error) {
+	internalKey, err := asset.ParseHexPubKey(jp.InternalKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid internal key: %w", err)
+	}
+
+	p := &TaprootProof{
+		OutputIndex:     jp.OutputIndex,
+		InternalKey:     internalKey,
+		UnknownOddTypes: jp.UnknownOddTypes,
+	}
+
+	if jp.CommitmentProof != nil {
+		if jp.CommitmentProof.Proof == nil {
+			return nil, fmt.Errorf("%w: missing commitment proof",
+				asset.ErrInvalidJSON)
+		}
+
+		commitmentProof, err := jp.CommitmentProof.Proof.ToProof()
+		if err != nil {
+			return nil, err
+		}
+
+		sibling, err := commitment.DecodeHexTapscriptPreimage(
+			jp.CommitmentProof.TapscriptSibling,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		p.CommitmentProof = &CommitmentProof{
+			Proof:              *commitmentProof,
+			TapSiblingPreimage: sibling,
+			UnknownOddTypes:    jp.CommitmentProof.UnknownOddTypes,
+		}
+	}
+
+	if jp.TapscriptProof != nil {
+		preimage1, err := commitment.DecodeHexTapscriptPreimage(
+			jp.TapscriptProof.TapPreimage1,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		preimage2, err := commitment.DecodeHexTapscriptPreimage(
+			jp.TapscriptProof.TapPreimage2,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		p.TapscriptProof = &TapscriptProof{
+			TapPreimage1:    preimage1,
+			TapPreimage2:    preimage2,
+			Bip86:           jp.TapscriptProof.Bip86,
+			UnknownOddTypes: jp.TapscriptProof.UnknownOddTypes,
+		}
+	}
+
+	return p, nil
+}
+
+// JSONCommitmentProof is the JSON representation of a Taproot Asset
+// commitment proof along with its tapscript sibling.
+type JSONCommitmentProof struct {
+	Proof            *commitment.JSONProof `json:"proof"`
+	TapscriptSibling string                `json:"tapscript_sibling"`
+	UnknownOddTypes  tlv.TypeMap           `json:"unknown_odd_types"`
+}
+
+// JSONTapscriptProof is the JSON representation of a proof that an output
+// doesn't contain a Taproot Asset commitment.
+type JSONTapscriptProof struct {
+	TapPreimage1    string      `json:"tap_preimage_1"`
+	TapPreimage2    string      `json:"tap_preimage_2"`
+	Bip86           bool        `json:"bip86"`
+	UnknownOddTypes tlv.TypeMap `json:"unknown_odd_types"`
+}
+
+// JSONMetaReveal is the JSON representation of an asset meta reveal.
+type JSONMetaReveal struct {
+	Type            uint8       `json:"type"`
+	Data            string      `json:"data"`
+	UnknownOddTypes tlv.TypeMap `json:"unknown_odd_types"`
+}
+
+// JSONFile is the JSON representation of a proof file.
+type JSONFile struct {
+	Version uint32       `json:"version"`
+	Proofs  []*JSONProof `json:"proofs"`
+}
+
+// NewJSONFile creates the JSON representation of the given proof file.
+func NewJSONFile(f *File) (*JSONFile, error) {
+	jf := &JSONFile{
+		Version: uint32(f.Version),
+		Proofs:  make([]*JSONProof, 0, f.NumProofs()),
+	}
+
+	for idx := 0; idx < f.NumProofs(); idx++ {
+		p, err := f.ProofAt(uint32(idx))
+		if err != nil {
+			return nil, err
+		}
+
+		jsonProof, err := NewJSONProof(p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode proof %d: %w",
+				idx, err)
+		}
+
+		jf.Proofs = append(jf.Proofs, jsonProof)
+	}
+
+	return jf, nil
+}
+
+// ToFile converts the JSON representation back into a proof file.
+func (jf *JSONFile) ToFile() (*File, error) {
+	proofs := make([]Proof, 0, len(jf.Proofs))
+	for idx, jsonProof := range jf.Proofs {
+		p, err := jsonProof.ToProof()
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof %d: %w", idx, err)
+		}
+
+		proofs = append(proofs, *p)
+	}
+
+	return NewFile(Version(jf.Version), proofs...)
+}
+
+// EncodeJSON encodes the given proof in its canonical JSON representation.
+func EncodeJSON(p *Proof) ([]byte, error) {
+	jsonProof, err := NewJSONProof(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonProof)
+}
+
+// DecodeJSON decodes a proof from its canonical JSON representation.
+func DecodeJSON(jsonBytes []byte) (*Proof, error) {
+	var jsonProof JSONProof
+	if err := json.Unmarshal(jsonBytes, &jsonProof); err != nil {
+		return nil, fmt.Errorf("%w: %w", asset.ErrInvalidJSON, err)
+	}
+
+	return jsonProof.ToProof()
+}
+
+// EncodeFileJSON encodes the given proof file in its canonical JSON
+// representation.
+func EncodeFileJSON(f *File) ([]byte, error) {
+	jsonFile, err := NewJSONFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonFile)
+}
+
+// DecodeFileJSON decodes a proof file from its canonical JSON representation.
+func DecodeFileJSON(jsonBytes []byte) (*File, error) {
+	var jsonFile JSONFile
+	if err := json.Unmarshal(jsonBytes, &jsonFile); err != nil {
+		return nil, fmt.Errorf("%w: %w", asset.ErrInvalidJSON, err)
+	}
+
+	return jsonFile.ToFile()
+}
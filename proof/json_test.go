@@ -0,0 +1,110 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonTestVectors is a minimal version of the BIP test vector format that
+// keeps the proofs in their raw JSON form.
+type jsonTestVectors struct {
+	ValidTestCases []struct {
+		Proof    json.RawMessage `json:"proof"`
+		Expected string          `json:"expected"`
+		Comment  string          `json:"comment"`
+	} `json:"valid_test_cases"`
+}
+
+// TestJSONTestVectors tests that the proofs in the BIP test vectors can be
+// decoded with the canonical JSON encoding and result in the expected TLV
+// encoding.
+func TestJSONTestVectors(t *testing.T) {
+	t.Parallel()
+
+	fileNames := []string{generatedTestVectorName, RegtestTestVectorName}
+	for _, fileName := range fileNames {
+		var testVectors jsonTestVectors
+		test.ParseTestVectors(t, fileName, &testVectors)
+
+		for _, validCase := range testVectors.ValidTestCases {
+			p, err := DecodeJSON(validCase.Proof)
+			require.NoError(t, err, validCase.Comment)
+
+			var buf bytes.Buffer
+			require.NoError(t, p.Encode(&buf))
+			require.Equal(
+				t, validCase.Expected,
+				hex.EncodeToString(buf.Bytes()),
+				validCase.Comment,
+			)
+
+			assertJSONProofRoundTrip(t, p)
+		}
+	}
+}
+
+// TestJSONProofFile tests that a proof file, including a proof with an
+// additional input, survives a round trip through the canonical JSON encoding.
+func TestJSONProofFile(t *testing.T) {
+	t.Parallel()
+
+	proofHex, err := os.ReadFile(proofFileHexFileName)
+	require.NoError(t, err)
+
+	proofBytes, err := hex.DecodeString(
+		strings.Trim(string(proofHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	f := &File{}
+	require.NoError(t, f.Decode(bytes.NewReader(proofBytes)))
+
+	jsonBytes, err := EncodeFileJSON(f)
+	require.NoError(t, err)
+
+	decodedFile, err := DecodeFileJSON(jsonBytes)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, decodedFile.Encode(&buf))
+	require.Equal(t, proofBytes, buf.Bytes())
+
+	// Encoding the decoded file again must result in the exact same JSON.
+	jsonBytes2, err := EncodeFileJSON(decodedFile)
+	require.NoError(t, err)
+	require.Equal(t, jsonBytes, jsonBytes2)
+
+	// Additional inputs are nested proof files, which are represented as
+	// JSON as well.
+	lastProof, err := f.LastProof()
+	require.NoError(t, err)
+
+	lastProof.AdditionalInputs = []File{*f}
+	assertJSONProofRoundTrip(t, lastProof)
+}
+
+// assertJSONProofRoundTrip asserts that the given proof results in the same
+// TLV encoding after a round trip through the canonical JSON encoding.
+func assertJSONProofRoundTrip(t *testing.T, p *Proof) {
+	t.Helper()
+
+	var expected bytes.Buffer
+	require.NoError(t, p.Encode(&expected))
+
+	jsonBytes, err := EncodeJSON(p)
+	require.NoError(t, err)
+
+	decoded, err := DecodeJSON(jsonBytes)
+	require.NoError(t, err)
+
+	var actual bytes.Buffer
+	require.NoError(t, decoded.Encode(&actual))
+	require.Equal(t, expected.Bytes(), actual.Bytes())
+}
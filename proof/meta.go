@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/tlv"
 	"golang.org/x/exp/constraints"
 )
@@ -25,6 +26,11 @@ const (
 
 	// MetaJson signals that the meta data is a JSON object.
 	MetaJson MetaType = 1
+
+	// MetaBlobRef signals that the meta data is stored as a blob in a
+	// content-addressed store, and only a reference to the blob is
+	// committed to. See BlobRef for the encoding of the reference.
+	MetaBlobRef MetaType = 2
 )
 
 const (
@@ -100,6 +106,13 @@ type MetaReveal struct {
 	// Data is the committed data being revealed.
 	Data []byte
 
+	// MinVMVersion is the minimum version of the consensus rules that
+	// all state transitions of the asset must be validated under. This
+	// allows an issuer to opt an asset into new rules from its genesis
+	// onward. This is an optional field that is only encoded if it isn't
+	// the initial version.
+	MinVMVersion vm.Version
+
 	// UnknownOddTypes is a map of unknown odd types that were encountered
 	// during decoding. This map is used to preserve unknown types that we
 	// don't know of yet, so we can still encode them back when serializing.
@@ -134,11 +147,24 @@ func (m *MetaReveal) Validate() error {
 		return err
 	}
 
+	switch m.Type {
 	// If the type is JSON, then it should be parseable as a JSON string.
-	if m.Type == MetaJson {
+	case MetaJson:
 		if !json.Valid(m.Data) {
 			return ErrInvalidJSON
 		}
+
+	// If the type is a blob reference, then it must be a valid reference.
+	case MetaBlobRef:
+		if _, err := DecodeBlobRef(m.Data); err != nil {
+			return err
+		}
+	}
+
+	// An asset can't require rules we don't know how to enforce.
+	if !m.MinVMVersion.IsSupported() {
+		return fmt.Errorf("%w: minimum version %v",
+			vm.ErrUnsupportedVersion, m.MinVMVersion)
 	}
 
 	return nil
@@ -349,6 +375,11 @@ func (m *MetaReveal) EncodeRecords() []tlv.Record {
 		MetaRevealTypeRecord(&m.Type),
 		MetaRevealDataRecord(&m.Data),
 	}
+	if m.MinVMVersion != vm.V0 {
+		records = append(
+			records, MetaRevealMinVMVersionRecord(&m.MinVMVersion),
+		)
+	}
 
 	// Add any unknown odd types that were encountered during decoding.
 	return asset.CombineRecords(records, m.UnknownOddTypes)
@@ -359,6 +390,7 @@ func (m *MetaReveal) DecodeRecords() []tlv.Record {
 	return []tlv.Record{
 		MetaRevealTypeRecord(&m.Type),
 		MetaRevealDataRecord(&m.Data),
+		MetaRevealMinVMVersionRecord(&m.MinVMVersion),
 	}
 }
 
@@ -0,0 +1,242 @@
+package proof
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+)
+
+const (
+	// MetaBlobRefSize is the size of an encoded blob reference: the
+	// SHA-256 hash of the blob followed by its size as a big-endian
+	// uint64.
+	MetaBlobRefSize = sha256.Size + 8
+)
+
+var (
+	// ErrInvalidBlobRef is returned when the data of a blob reference meta
+	// can't be decoded.
+	ErrInvalidBlobRef = errors.New("invalid meta blob reference")
+
+	// ErrBlobNotFound is returned when a meta blob isn't found in a blob
+	// store.
+	ErrBlobNotFound = errors.New("meta blob not found")
+
+	// ErrBlobMismatch is returned when a meta blob doesn't match the
+	// reference it was expected to match.
+	ErrBlobMismatch = errors.New("meta blob does not match reference")
+)
+
+// BlobRef is a reference to a meta blob in a content-addressed store. A meta
+// reveal of type MetaBlobRef commits to the reference only, which allows the
+// metadata of an asset to exceed the size limit of metadata that is part of
+// the genesis proof.
+type BlobRef struct {
+	// Hash is the SHA-256 hash of the blob.
+	Hash [sha256.Size]byte
+
+	// Size is the size of the blob in bytes.
+	Size uint64
+}
+
+// NewBlobRef creates the reference of the given blob.
+func NewBlobRef(blob []byte) BlobRef {
+	return BlobRef{
+		Hash: sha256.Sum256(blob),
+		Size: uint64(len(blob)),
+	}
+}
+
+// DecodeBlobRef decodes a blob reference from the data of a meta reveal.
+func DecodeBlobRef(data []byte) (BlobRef, error) {
+	if len(data) != MetaBlobRefSize {
+		return BlobRef{}, fmt.Errorf("%w: expected %d bytes, got %d",
+			ErrInvalidBlobRef, MetaBlobRefSize, len(data))
+	}
+
+	var ref BlobRef
+	copy(ref.Hash[:], data[:sha256.Size])
+	ref.Size = binary.BigEndian.Uint64(data[sha256.Size:])
+
+	if ref.Size == 0 {
+		return BlobRef{}, fmt.Errorf("%w: blob must not be empty",
+			ErrInvalidBlobRef)
+	}
+
+	return ref, nil
+}
+
+// Encode encodes the blob reference as the data of a meta reveal.
+func (r BlobRef) Encode() []byte {
+	data := make([]byte, MetaBlobRefSize)
+	copy(data, r.Hash[:])
+	binary.BigEndian.PutUint64(data[sha256.Size:], r.Size)
+
+	return data
+}
+
+// MetaReveal returns the meta reveal that commits to the blob reference.
+func (r BlobRef) MetaReveal() *MetaReveal {
+	return &MetaReveal{
+		Type: MetaBlobRef,
+		Data: r.Encode(),
+	}
+}
+
+// String returns the hex encoded hash of the blob.
+func (r BlobRef) String() string {
+	return hex.EncodeToString(r.Hash[:])
+}
+
+// BlobRef returns the blob reference of the meta reveal, if it is of type
+// MetaBlobRef.
+func (m *MetaReveal) BlobRef() fn.Option[BlobRef] {
+	if m == nil || m.Type != MetaBlobRef {
+		return fn.None[BlobRef]()
+	}
+
+	ref, err := DecodeBlobRef(m.Data)
+	if err != nil {
+		return fn.None[BlobRef]()
+	}
+
+	return fn.Some(ref)
+}
+
+// MetaBlobStore is a content-addressed store for meta blobs.
+type MetaBlobStore interface {
+	// PutBlob stores the blob read from the given reader and returns its
+	// reference. If an expected reference is given, the blob is only
+	// stored if it matches the reference.
+	PutBlob(ctx context.Context, r io.Reader,
+		expected fn.Option[BlobRef]) (BlobRef, error)
+
+	// GetBlob returns a reader for the blob with the given reference. The
+	// reader returns ErrBlobMismatch instead of io.EOF if the stored blob
+	// doesn't match the reference. ErrBlobNotFound is returned if the blob
+	// isn't stored.
+	GetBlob(ctx context.Context, ref BlobRef) (io.ReadCloser, error)
+
+	// HasBlob returns true if the blob with the given reference is stored.
+	HasBlob(ctx context.Context, ref BlobRef) (bool, error)
+}
+
+// blobVerifier is a reader that verifies that the data read from the
+// underlying reader matches a blob reference.
+type blobVerifier struct {
+	r    io.ReadCloser
+	ref  BlobRef
+	hash hash.Hash
+	read uint64
+}
+
+// NewBlobVerifier wraps the given reader of a blob, such that reading returns
+// ErrBlobMismatch instead of io.EOF if the data read doesn't match the given
+// reference.
+func NewBlobVerifier(r io.ReadCloser, ref BlobRef) io.ReadCloser {
+	return &blobVerifier{
+		r:    r,
+		ref:  ref,
+		hash: sha256.New(),
+	}
+}
+
+// Read reads from the underlying reader and verifies the blob once the end of
+// the data is reached.
+func (v *blobVerifier) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.hash.Write(p[:n])
+	v.read += uint64(n)
+
+	if v.read > v.ref.Size {
+		return n, fmt.Errorf("%w: blob %v exceeds %d bytes",
+			ErrBlobMismatch, v.ref, v.ref.Size)
+	}
+
+	if errors.Is(err, io.EOF) {
+		var sum [sha256.Size]byte
+		copy(sum[:], v.hash.Sum(nil))
+
+		if v.read != v.ref.Size || sum != v.ref.Hash {
+			return n, fmt.Errorf("%w: blob %v", ErrBlobMismatch,
+				v.ref)
+		}
+	}
+
+	return n, err
+}
+
+// Close closes the underlying reader.
+func (v *blobVerifier) Close() error {
+	return v.r.Close()
+}
+
+const (
+	// BlobChunkSize is the size of the chunks meta blobs are streamed in
+	// over RPC.
+	BlobChunkSize = 1024 * 1024
+)
+
+// blobChunkReader is a reader that reads a blob from a stream of chunks.
+type blobChunkReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+}
+
+// NewBlobChunkReader returns a reader that reads a blob from the chunks
+// returned by the given function. The function must return io.EOF once all
+// chunks were returned.
+func NewBlobChunkReader(recv func() ([]byte, error)) io.Reader {
+	return &blobChunkReader{
+		recv: recv,
+	}
+}
+
+// Read reads from the current chunk, receiving the next chunk once the
+// current one was fully read.
+func (c *blobChunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		chunk, err := c.recv()
+		if err != nil {
+			return 0, err
+		}
+		c.buf = chunk
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+
+	return n, nil
+}
+
+// SendBlobChunks reads the blob from the given reader and passes it to the
+// given function in chunks of at most BlobChunkSize bytes.
+func SendBlobChunks(r io.Reader, send func([]byte) error) error {
+	buf := make([]byte, BlobChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		switch {
+		// The blob was fully read, and the last chunk was sent before.
+		case errors.Is(err, io.EOF):
+			return nil
+
+		// This is the last chunk of the blob.
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return send(buf[:n])
+
+		case err != nil:
+			return err
+		}
+
+		if err := send(buf[:n]); err != nil {
+			return err
+		}
+	}
+}
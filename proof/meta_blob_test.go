@@ -0,0 +1,87 @@
+package proof
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlobChunks tests that a blob survives being split into chunks and read
+// back from them.
+func TestBlobChunks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		size int
+	}{{
+		name: "single partial chunk",
+		size: 100,
+	}, {
+		name: "exact chunk",
+		size: BlobChunkSize,
+	}, {
+		name: "multiple chunks",
+		size: 2*BlobChunkSize + 7,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blob := bytes.Repeat([]byte{0xab}, tc.size)
+			ref := NewBlobRef(blob)
+
+			var chunks [][]byte
+			blobReader := io.NopCloser(bytes.NewReader(blob))
+			err := SendBlobChunks(
+				NewBlobVerifier(blobReader, ref),
+				func(chunk []byte) error {
+					chunks = append(
+						chunks, bytes.Clone(chunk),
+					)
+					return nil
+				},
+			)
+			require.NoError(t, err)
+
+			numChunks := (tc.size + BlobChunkSize - 1) /
+				BlobChunkSize
+			require.Len(t, chunks, numChunks)
+
+			r := NewBlobChunkReader(func() ([]byte, error) {
+				if len(chunks) == 0 {
+					return nil, io.EOF
+				}
+
+				chunk := chunks[0]
+				chunks = chunks[1:]
+
+				return chunk, nil
+			})
+			received, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, blob, received)
+		})
+	}
+
+	// A blob that doesn't match its reference is detected while it is
+	// being sent.
+	blob := []byte("blob")
+	ref := NewBlobRef([]byte("other"))
+	err := SendBlobChunks(
+		NewBlobVerifier(io.NopCloser(bytes.NewReader(blob)), ref),
+		func([]byte) error {
+			return nil
+		},
+	)
+	require.ErrorIs(t, err, ErrBlobMismatch)
+
+	// Blob references survive being encoded as meta data.
+	ref = NewBlobRef(blob)
+	decoded, err := DecodeBlobRef(ref.Encode())
+	require.NoError(t, err)
+	require.Equal(t, ref, decoded)
+	require.Equal(t, fn.Some(ref), ref.MetaReveal().BlobRef())
+}
@@ -2,6 +2,7 @@ package proof
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"os"
 	"path/filepath"
@@ -76,6 +77,26 @@ func TestValidateMetaReveal(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:        "valid blob reference",
+			reveal:      NewBlobRef([]byte("blob")).MetaReveal(),
+			expectedErr: nil,
+		},
+		{
+			name: "invalid blob reference",
+			reveal: &MetaReveal{
+				Type: MetaBlobRef,
+				Data: []byte("blob"),
+			},
+			expectedErr: ErrInvalidBlobRef,
+		},
+		{
+			name: "empty blob reference",
+			reveal: BlobRef{
+				Hash: sha256.Sum256(nil),
+			}.MetaReveal(),
+			expectedErr: ErrInvalidBlobRef,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,139 @@
+package proof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/asset"
+)
+
+const (
+	// metaUpdateMsgPrefix is the domain separation prefix of the message
+	// that is signed for a metadata update.
+	metaUpdateMsgPrefix = "taproot-assets:meta-update:v1"
+)
+
+var (
+	// ErrMetaUpdateInvalidSig is returned if the signature of a metadata
+	// update is invalid.
+	ErrMetaUpdateInvalidSig = errors.New("invalid meta update signature")
+
+	// ErrMetaUpdateSignerMismatch is returned if a metadata update wasn't
+	// signed with the raw key of the asset group it updates.
+	ErrMetaUpdateSignerMismatch = errors.New("meta update signer key " +
+		"doesn't match raw group key")
+)
+
+// MetaUpdate is an update of the metadata of an asset group. Since the
+// metadata of an asset is committed to in its genesis and can't be changed,
+// this allows the issuer of a group to publish updated information (for
+// example a new JSON document or icon URI) for all assets of the group. The
+// update is signed with the raw key the group key is derived from, so anyone
+// that knows the group can verify it was created by the issuer.
+type MetaUpdate struct {
+	// GroupKey is the tweaked group key of the asset group the update is
+	// for.
+	GroupKey *btcec.PublicKey
+
+	// Version is the version of the update. Each update of a group must
+	// have a higher version than the previous one.
+	Version uint64
+
+	// Meta is the updated metadata.
+	Meta *MetaReveal
+
+	// SignerKey is the raw group key the update was signed with.
+	SignerKey *btcec.PublicKey
+
+	// Signature is the Schnorr signature of the update by the signer key.
+	Signature *schnorr.Signature
+}
+
+// SigMessage returns the message that is signed by the issuer of the asset
+// group. The message commits to the group key, the version and the hash of the
+// updated metadata.
+func (u *MetaUpdate) SigMessage() ([]byte, error) {
+	if u.GroupKey == nil {
+		return nil, fmt.Errorf("group key must be set")
+	}
+	if u.Meta == nil {
+		return nil, ErrMetaDataMissing
+	}
+
+	var (
+		buf     bytes.Buffer
+		version [8]byte
+	)
+	metaHash := u.Meta.MetaHash()
+	binary.BigEndian.PutUint64(version[:], u.Version)
+
+	buf.WriteString(metaUpdateMsgPrefix)
+	buf.Write(u.GroupKey.SerializeCompressed())
+	buf.Write(version[:])
+	buf.Write(metaHash[:])
+
+	return buf.Bytes(), nil
+}
+
+// SigDigest returns the digest of the signed message. This is the single
+// SHA-256 hash of the message, which matches what lnd's SignMessage RPC signs
+// for Schnorr signatures.
+func (u *MetaUpdate) SigDigest() ([sha256.Size]byte, error) {
+	msg, err := u.SigMessage()
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(msg), nil
+}
+
+// Verify checks that the metadata update is valid and was signed by the
+// issuer of the given asset group.
+func (u *MetaUpdate) Verify(groupKey *asset.GroupKey) error {
+	switch {
+	case groupKey == nil:
+		return fmt.Errorf("group key must be set")
+
+	case groupKey.RawKey.PubKey == nil:
+		return fmt.Errorf("raw group key unknown")
+
+	case u.GroupKey == nil || u.Meta == nil || u.SignerKey == nil ||
+		u.Signature == nil:
+
+		return fmt.Errorf("meta update is incomplete")
+	}
+
+	if !u.GroupKey.IsEqual(&groupKey.GroupPubKey) {
+		return fmt.Errorf("meta update is for group %x, expected %x",
+			u.GroupKey.SerializeCompressed(),
+			groupKey.GroupPubKey.SerializeCompressed())
+	}
+
+	// The signer key must be the raw key that the group key was derived
+	// from, which is only known to the issuer's wallet.
+	signerKey := schnorr.SerializePubKey(u.SignerKey)
+	rawKey := schnorr.SerializePubKey(groupKey.RawKey.PubKey)
+	if !bytes.Equal(signerKey, rawKey) {
+		return ErrMetaUpdateSignerMismatch
+	}
+
+	if err := u.Meta.Validate(); err != nil {
+		return fmt.Errorf("invalid meta update data: %w", err)
+	}
+
+	digest, err := u.SigDigest()
+	if err != nil {
+		return err
+	}
+
+	if !u.Signature.Verify(digest[:], u.SignerKey) {
+		return ErrMetaUpdateInvalidSig
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package proof
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMetaUpdateVerify tests that a metadata update is only valid if it was
+// signed by the raw key of the group it updates.
+func TestMetaUpdateVerify(t *testing.T) {
+	t.Parallel()
+
+	rawPriv := test.RandPrivKey()
+	groupKey := &asset.GroupKey{
+		RawKey: keychain.KeyDescriptor{
+			PubKey: rawPriv.PubKey(),
+		},
+		GroupPubKey: *test.RandPubKey(t),
+	}
+
+	sign := func(u *MetaUpdate) {
+		digest, err := u.SigDigest()
+		require.NoError(t, err)
+
+		u.Signature, err = schnorr.Sign(rawPriv, digest[:])
+		require.NoError(t, err)
+	}
+
+	update := &MetaUpdate{
+		GroupKey: &groupKey.GroupPubKey,
+		Version:  1,
+		Meta: &MetaReveal{
+			Type: MetaJson,
+			Data: []byte(`{"icon":"https://example.com/icon.png"}`),
+		},
+		SignerKey: rawPriv.PubKey(),
+	}
+	sign(update)
+	require.NoError(t, update.Verify(groupKey))
+
+	// A signature over a different version is invalid.
+	tampered := *update
+	tampered.Version = 2
+	require.ErrorIs(t, tampered.Verify(groupKey), ErrMetaUpdateInvalidSig)
+
+	// A signature over different metadata is invalid.
+	tampered = *update
+	tampered.Meta = &MetaReveal{
+		Type: MetaJson,
+		Data: []byte(`{"icon":"https://example.com/other.png"}`),
+	}
+	require.ErrorIs(t, tampered.Verify(groupKey), ErrMetaUpdateInvalidSig)
+
+	// An update signed by any key other than the raw group key is
+	// rejected, even if the signature itself is valid.
+	otherPriv := test.RandPrivKey()
+	tampered = *update
+	tampered.SignerKey = otherPriv.PubKey()
+	digest, err := tampered.SigDigest()
+	require.NoError(t, err)
+	tampered.Signature, err = schnorr.Sign(otherPriv, digest[:])
+	require.NoError(t, err)
+	require.ErrorIs(
+		t, tampered.Verify(groupKey), ErrMetaUpdateSignerMismatch,
+	)
+
+	// An update for a different group is rejected.
+	otherGroup := *groupKey
+	otherGroup.GroupPubKey = *test.RandPubKey(t)
+	require.Error(t, update.Verify(&otherGroup))
+}
@@ -0,0 +1,330 @@
+package proof
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+)
+
+const (
+	// medianTimeBlocks is the number of previous blocks which should be
+	// used to calculate the median time used to validate block
+	// timestamps.
+	medianTimeBlocks = 11
+)
+
+// HeaderSet is a set of block headers of the main chain that proof files can
+// be verified against without access to a chain backend. The highest header
+// of the set is treated as the tip of the main chain.
+type HeaderSet struct {
+	byHeight map[uint32]wire.BlockHeader
+	byHash   map[chainhash.Hash]uint32
+
+	tipHeight uint32
+}
+
+// NewHeaderSet creates a new, empty header set.
+func NewHeaderSet() *HeaderSet {
+	return &HeaderSet{
+		byHeight: make(map[uint32]wire.BlockHeader),
+		byHash:   make(map[chainhash.Hash]uint32),
+	}
+}
+
+// Add adds the given block header at the given height to the set. An error is
+// returned if a different header is already known at the same height.
+func (h *HeaderSet) Add(height uint32, header wire.BlockHeader) error {
+	hash := header.BlockHash()
+	if known, ok := h.byHeight[height]; ok {
+		if known.BlockHash() != hash {
+			return fmt.Errorf("conflicting headers at height %d",
+				height)
+		}
+
+		return nil
+	}
+
+	h.byHeight[height] = header
+	h.byHash[hash] = height
+
+	if height > h.tipHeight {
+		h.tipHeight = height
+	}
+
+	return nil
+}
+
+// TipHeight returns the height of the highest header of the set.
+func (h *HeaderSet) TipHeight() uint32 {
+	return h.tipHeight
+}
+
+// ParseHeaderSet parses a header set from the given reader. Each line must
+// contain a block height and the hex encoded 80 byte block header, separated
+// by whitespace. Empty lines and lines starting with # are ignored.
+func ParseHeaderSet(r io.Reader) (*HeaderSet, error) {
+	headers := NewHeaderSet()
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected height and "+
+				"header", lineNum)
+		}
+
+		height, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid height: %w",
+				lineNum, err)
+		}
+
+		headerBytes, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid header: %w",
+				lineNum, err)
+		}
+
+		if len(headerBytes) != wire.MaxBlockHeaderPayload {
+			return nil, fmt.Errorf("line %d: invalid header "+
+				"length %d", lineNum, len(headerBytes))
+		}
+
+		var header wire.BlockHeader
+		err = header.Deserialize(bytes.NewReader(headerBytes))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: unable to decode "+
+				"header: %w", lineNum, err)
+		}
+
+		if err := headers.Add(uint32(height), header); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// HeaderVerifier returns a header verifier that only accepts block headers
+// that are part of the set.
+func (h *HeaderSet) HeaderVerifier() HeaderVerifier {
+	return func(header wire.BlockHeader, height uint32) error {
+		hash := header.BlockHash()
+		knownHeight, ok := h.byHash[hash]
+		if !ok {
+			return fmt.Errorf("block %s not in header set", hash)
+		}
+
+		// Proofs without an assigned height are only checked for the
+		// existence of the block.
+		if height != 0 && height != knownHeight {
+			return fmt.Errorf("block hash and block height "+
+				"mismatch; (height: %d, knownHeight: %d, "+
+				"hash: %s)", height, knownHeight, hash)
+		}
+
+		return nil
+	}
+}
+
+// offlineChainLookup is an implementation of the asset.ChainLookup interface
+// that only uses the proof file and the header set.
+type offlineChainLookup struct {
+	headers *HeaderSet
+	file    *File
+}
+
+// A compile-time assertion to ensure offlineChainLookup meets the
+// asset.ChainLookup interface.
+var _ asset.ChainLookup = (*offlineChainLookup)(nil)
+
+// TxBlockHeight returns the block height that the given transaction was
+// included in.
+func (l *offlineChainLookup) TxBlockHeight(_ context.Context,
+	txid chainhash.Hash) (uint32, error) {
+
+	height, found, err := txHeightInFile(l.file, txid)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, fmt.Errorf("transaction %v not found in proof file",
+			txid)
+	}
+
+	return height, nil
+}
+
+// txHeightInFile searches the given proof file and the files of its
+// additional inputs for the block height of the given transaction.
+func txHeightInFile(f *File, txid chainhash.Hash) (uint32, bool, error) {
+	for i := f.NumProofs() - 1; i >= 0; i-- {
+		p, err := f.ProofAt(uint32(i))
+		if err != nil {
+			return 0, false, err
+		}
+
+		if p.AnchorTx.TxHash() == txid {
+			return p.BlockHeight, true, nil
+		}
+
+		for idx := range p.AdditionalInputs {
+			height, found, err := txHeightInFile(
+				&p.AdditionalInputs[idx], txid,
+			)
+			if err != nil || found {
+				return height, found, err
+			}
+		}
+	}
+
+	return 0, false, nil
+}
+
+// MeanBlockTimestamp returns the timestamp of the block at the given height as
+// a Unix timestamp in seconds, taking into account the mean time elapsed over
+// the previous 11 blocks. All of these blocks must be part of the header set.
+func (l *offlineChainLookup) MeanBlockTimestamp(_ context.Context,
+	blockHeight uint32) (time.Time, error) {
+
+	timestamps := make([]int64, 0, medianTimeBlocks)
+	for i := uint32(0); i < medianTimeBlocks && i <= blockHeight; i++ {
+		header, ok := l.headers.byHeight[blockHeight-i]
+		if !ok {
+			return time.Time{}, fmt.Errorf("header at height %d "+
+				"not in header set", blockHeight-i)
+		}
+
+		timestamps = append(timestamps, header.Timestamp.Unix())
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	return time.Unix(timestamps[len(timestamps)/2], 0), nil
+}
+
+// CurrentHeight returns the height of the tip of the header set.
+func (l *offlineChainLookup) CurrentHeight(context.Context) (uint32, error) {
+	return l.headers.TipHeight(), nil
+}
+
+// offlineVerifyParams is the set of parameters used when verifying a proof
+// file offline.
+type offlineVerifyParams struct {
+	trustedGroupKeys map[asset.SerializedKey]struct{}
+}
+
+// OfflineVerifyOption is an option that may be applied on
+// *offlineVerifyParams.
+type OfflineVerifyOption func(p *offlineVerifyParams)
+
+// WithTrustedGroupKeys adds group keys that are accepted even though the
+// proof file doesn't reveal them. This is required to verify the proof of a
+// re-issuance into an existing group, as the group anchor is part of a
+// different proof file.
+func WithTrustedGroupKeys(keys ...*btcec.PublicKey) OfflineVerifyOption {
+	return func(p *offlineVerifyParams) {
+		for _, key := range keys {
+			p.trustedGroupKeys[asset.ToSerialized(key)] = struct{}{}
+		}
+	}
+}
+
+// VerifyOffline fully verifies the given proof file without access to a chain
+// backend or a tapd database. The anchor blocks of all proofs of the file must
+// be part of the given header set. Group keys are accepted if they're revealed
+// by a genesis proof within the file or explicitly trusted by the caller.
+func VerifyOffline(ctx context.Context, f *File, headers *HeaderSet,
+	opts ...OfflineVerifyOption) (*AssetSnapshot, error) {
+
+	params := offlineVerifyParams{
+		trustedGroupKeys: make(map[asset.SerializedKey]struct{}),
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if err := collectRevealedGroupKeys(
+		f, params.trustedGroupKeys,
+	); err != nil {
+		return nil, err
+	}
+
+	groupVerifier := func(groupKey *btcec.PublicKey) error {
+		_, ok := params.trustedGroupKeys[asset.ToSerialized(groupKey)]
+		if !ok {
+			return fmt.Errorf("group key %x is neither revealed "+
+				"in the proof file nor trusted",
+				groupKey.SerializeCompressed())
+		}
+
+		return nil
+	}
+
+	chainLookup := &offlineChainLookup{
+		headers: headers,
+		file:    f,
+	}
+
+	return f.Verify(
+		ctx, headers.HeaderVerifier(), DefaultMerkleVerifier,
+		groupVerifier, chainLookup,
+	)
+}
+
+// collectRevealedGroupKeys adds the group keys that are revealed by genesis
+// proofs of the given file and the files of its additional inputs to the
+// given set. The reveals themselves are checked during the verification of
+// the file.
+func collectRevealedGroupKeys(f *File,
+	keys map[asset.SerializedKey]struct{}) error {
+
+	for i := 0; i < f.NumProofs(); i++ {
+		p, err := f.ProofAt(uint32(i))
+		if err != nil {
+			return err
+		}
+
+		if p.GroupKeyReveal != nil && p.Asset.GroupKey != nil {
+			groupKey := &p.Asset.GroupKey.GroupPubKey
+			keys[asset.ToSerialized(groupKey)] = struct{}{}
+		}
+
+		for idx := range p.AdditionalInputs {
+			err := collectRevealedGroupKeys(
+				&p.AdditionalInputs[idx], keys,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
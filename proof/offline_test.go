@@ -0,0 +1,79 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyOffline tests that a proof file can be verified against a set of
+// block headers that is parsed from its text representation.
+func TestVerifyOffline(t *testing.T) {
+	t.Parallel()
+
+	proofHex, err := os.ReadFile(proofFileHexFileName)
+	require.NoError(t, err)
+
+	fileBytes, err := hex.DecodeString(
+		strings.Trim(string(proofHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	f := &File{}
+	require.NoError(t, f.Decode(bytes.NewReader(fileBytes)))
+
+	// Write the anchor block headers of all proofs in the text format of
+	// a header set.
+	var headerText strings.Builder
+	headerText.WriteString("# height header\n\n")
+	for i := 0; i < f.NumProofs(); i++ {
+		p, err := f.ProofAt(uint32(i))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, p.BlockHeader.Serialize(&buf))
+
+		_, err = fmt.Fprintf(
+			&headerText, "%d %x\n", p.BlockHeight, buf.Bytes(),
+		)
+		require.NoError(t, err)
+	}
+
+	headers, err := ParseHeaderSet(strings.NewReader(headerText.String()))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	snapshot, err := VerifyOffline(ctx, f, headers)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+
+	lastProof, err := f.LastProof()
+	require.NoError(t, err)
+	require.Equal(t, lastProof.Asset.ID(), snapshot.Asset.ID())
+
+	// Without the block headers, the file can't be verified.
+	_, err = VerifyOffline(ctx, f, NewHeaderSet())
+	require.ErrorContains(t, err, "not in header set")
+
+	// A different header at a known height is rejected.
+	firstProof, err := f.ProofAt(0)
+	require.NoError(t, err)
+
+	otherHeader := firstProof.BlockHeader
+	otherHeader.Nonce++
+	err = headers.Add(firstProof.BlockHeight, otherHeader)
+	require.ErrorContains(t, err, "conflicting headers")
+
+	// Malformed lines are rejected.
+	_, err = ParseHeaderSet(strings.NewReader("1 00"))
+	require.ErrorContains(t, err, "invalid header length")
+
+	_, err = ParseHeaderSet(strings.NewReader("abc"))
+	require.ErrorContains(t, err, "expected height and header")
+}
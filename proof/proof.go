@@ -9,6 +9,7 @@ import (
 	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/tlv"
 )
@@ -40,6 +41,12 @@ var (
 	// genesis asset is missing a genesis reveal.
 	ErrGenesisRevealRequired = errors.New("genesis reveal required")
 
+	// ErrVMVersionTooLow is an error returned if an asset proof declares a
+	// lower VM version than the minimum version required by the meta
+	// reveal of the asset's genesis.
+	ErrVMVersionTooLow = errors.New("VM version below minimum required " +
+		"by asset")
+
 	// ErrGenesisRevealAssetIDMismatch is an error returned if an asset
 	// proof for a genesis asset has a genesis reveal that is inconsistent
 	// with the asset ID.
@@ -307,6 +314,12 @@ type Proof struct {
 	// grouped assets.
 	GroupKeyReveal *asset.GroupKeyReveal
 
+	// VMVersion is the version of the consensus rules the state
+	// transition of this proof must be validated under. This is an
+	// optional field that is only encoded if it isn't the initial
+	// version.
+	VMVersion vm.Version
+
 	// UnknownOddTypes is a map of unknown odd types that were encountered
 	// during decoding. This map is used to preserve unknown types that we
 	// don't know of yet, so we can still encode them back when serializing.
@@ -327,7 +340,7 @@ func (p *Proof) OutPoint() wire.OutPoint {
 
 // EncodeRecords returns the set of known TLV records to encode a Proof.
 func (p *Proof) EncodeRecords() []tlv.Record {
-	records := make([]tlv.Record, 0, 15)
+	records := make([]tlv.Record, 0, 16)
 	records = append(records, VersionRecord(&p.Version))
 	records = append(records, PrevOutRecord(&p.PrevOut))
 	records = append(records, BlockHeaderRecord(&p.BlockHeader))
@@ -367,6 +380,9 @@ func (p *Proof) EncodeRecords() []tlv.Record {
 			&p.GroupKeyReveal,
 		))
 	}
+	if p.VMVersion != vm.V0 {
+		records = append(records, VMVersionRecord(&p.VMVersion))
+	}
 
 	// Add any unknown odd types that were encountered during decoding.
 	return asset.CombineRecords(records, p.UnknownOddTypes)
@@ -390,6 +406,7 @@ func (p *Proof) DecodeRecords() []tlv.Record {
 		BlockHeightRecord(&p.BlockHeight),
 		GenesisRevealRecord(&p.GenesisReveal),
 		GroupKeyRevealRecord(&p.GroupKeyReveal),
+		VMVersionRecord(&p.VMVersion),
 	}
 }
 
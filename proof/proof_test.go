@@ -25,6 +25,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/tlv"
 	"github.com/stretchr/testify/require"
@@ -136,6 +137,7 @@ func assertEqualProof(t *testing.T, expected, actual *Proof) {
 	}
 
 	require.Equal(t, expected.ChallengeWitness, actual.ChallengeWitness)
+	require.Equal(t, expected.VMVersion, actual.VMVersion)
 }
 
 func TestProofEncoding(t *testing.T) {
@@ -461,6 +463,15 @@ func TestGenesisProofVerification(t *testing.T) {
 				Data: []byte("shall be lifted nevermore"),
 			},
 		},
+		{
+			name:      "meta reveal requires unknown VM version",
+			assetType: asset.Collectible,
+			metaReveal: &MetaReveal{
+				Data:         []byte("lifted nevermore"),
+				MinVMVersion: vm.LatestVersion + 1,
+			},
+			expectedErr: ErrVMVersionTooLow,
+		},
 		{
 			name:      "collectible invalid meta reveal",
 			assetType: asset.Collectible,
@@ -782,6 +793,71 @@ func TestProofVerification(t *testing.T) {
 	)
 	require.Nil(t, lastAsset)
 	require.ErrorIs(t, err, ErrUnknownVersion)
+	// The same goes for a proof that declares a VM version we don't know
+	// how to enforce.
+	p.Version = TransitionV0
+	p.VMVersion = vm.LatestVersion + 1
+
+	lastAsset, err = p.Verify(
+		context.Background(), nil, MockHeaderVerifier,
+		MockMerkleVerifier, MockGroupVerifier, MockChainLookup,
+	)
+	require.Nil(t, lastAsset)
+	require.ErrorIs(t, err, vm.ErrUnsupportedVersion)
+}
+
+// TestProofVMVersion tests that the VM version of a proof and the minimum VM
+// version of a meta reveal are encoded and reported correctly.
+func TestProofVMVersion(t *testing.T) {
+	t.Parallel()
+
+	metaReveal := &MetaReveal{
+		Data:         []byte("meant in croking nevermore"),
+		MinVMVersion: vm.LatestVersion + 2,
+	}
+	genesisProof, _ := genRandomGenesisWithProof(
+		t, asset.Collectible, nil, nil, false, metaReveal, nil, nil,
+		nil, asset.V0,
+	)
+
+	// A proof with the initial VM version doesn't encode the version at
+	// all, so its encoding stays compatible with older implementations.
+	var buf bytes.Buffer
+	require.NoError(t, genesisProof.Encode(&buf))
+	v0Bytes := fn.CopySlice(buf.Bytes())
+
+	genesisProof.VMVersion = vm.LatestVersion + 1
+	buf.Reset()
+	require.NoError(t, genesisProof.Encode(&buf))
+	require.Greater(t, buf.Len(), len(v0Bytes))
+
+	var decodedProof Proof
+	require.NoError(t, decodedProof.Decode(bytes.NewReader(buf.Bytes())))
+	require.Equal(t, genesisProof.VMVersion, decodedProof.VMVersion)
+	require.Equal(t, genesisProof.MetaReveal, decodedProof.MetaReveal)
+
+	// The compatibility report of a file should show both versions and
+	// flag them as unsupported.
+	f, err := NewFile(V0, genesisProof)
+	require.NoError(t, err)
+
+	report, err := f.VMCompatibility()
+	require.NoError(t, err)
+	require.Equal(
+		t, []vm.Version{vm.LatestVersion + 1}, report.ProofVersions,
+	)
+	require.Equal(t, vm.LatestVersion+2, report.MinVersion)
+	require.False(t, report.Compatible)
+
+	// Without the unsupported versions, the file is compatible.
+	genesisProof.VMVersion = vm.LatestVersion
+	genesisProof.MetaReveal.MinVMVersion = vm.V0
+	f, err = NewFile(V0, genesisProof)
+	require.NoError(t, err)
+
+	report, err = f.VMCompatibility()
+	require.NoError(t, err)
+	require.True(t, report.Compatible)
 }
 
 // TestOwnershipProofVerification ensures that the ownership proof encoding and
@@ -926,6 +1002,35 @@ func BenchmarkProofEncoding(b *testing.B) {
 	}
 }
 
+func BenchmarkProofVerification(b *testing.B) {
+	amt := uint64(5000)
+
+	// Start with a minted genesis asset that also reveals its meta data,
+	// so the meta hash is verified as well.
+	metaReveal := &MetaReveal{
+		Data: []byte("meant in croking nevermore"),
+	}
+	genesisProof, _ := genRandomGenesisWithProof(
+		b, asset.Normal, &amt, nil, false, metaReveal, nil, nil, nil,
+		asset.V0,
+	)
+
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	// Only the verification itself is measured, the chain related checks
+	// are mocked out.
+	for i := 0; i < b.N; i++ {
+		_, err := genesisProof.Verify(
+			ctx, nil, MockHeaderVerifier, MockMerkleVerifier,
+			MockGroupVerifier, MockChainLookup,
+		)
+		require.NoError(b, err)
+	}
+}
+
 // TestBIPTestVectors tests that the BIP test vectors are passing.
 func TestBIPTestVectors(t *testing.T) {
 	t.Parallel()
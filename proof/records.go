@@ -8,6 +8,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/vm"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
@@ -27,6 +28,7 @@ const (
 	BlockHeightType      tlv.Type = 22
 	GenesisRevealType    tlv.Type = 23
 	GroupKeyRevealType   tlv.Type = 25
+	VMVersionType        tlv.Type = 27
 
 	TaprootProofOutputIndexType     tlv.Type = 0
 	TaprootProofInternalKeyType     tlv.Type = 2
@@ -42,8 +44,9 @@ const (
 	TapscriptProofTapPreimage2 tlv.Type = 3
 	TapscriptProofBip86        tlv.Type = 4
 
-	MetaRevealEncodingType tlv.Type = 0
-	MetaRevealDataType     tlv.Type = 2
+	MetaRevealEncodingType     tlv.Type = 0
+	MetaRevealDataType         tlv.Type = 2
+	MetaRevealMinVMVersionType tlv.Type = 3
 )
 
 // KnownProofTypes is a set of all known proof TLV types. This set is asserted
@@ -53,7 +56,7 @@ var KnownProofTypes = fn.NewSet(
 	TxMerkleProofType, AssetLeafType, InclusionProofType,
 	ExclusionProofsType, SplitRootProofType, MetaRevealType,
 	AdditionalInputsType, ChallengeWitnessType, BlockHeightType,
-	GenesisRevealType, GroupKeyRevealType,
+	GenesisRevealType, GroupKeyRevealType, VMVersionType,
 )
 
 // KnownTaprootProofTypes is a set of all known Taproot proof TLV types. This
@@ -82,7 +85,7 @@ var KnownTapscriptProofTypes = fn.NewSet(
 // KnownMetaRevealTypes is a set of all known meta reveal TLV types. This set is
 // asserted to be complete by a check in the BIP test vector unit tests.
 var KnownMetaRevealTypes = fn.NewSet(
-	MetaRevealEncodingType, MetaRevealDataType,
+	MetaRevealEncodingType, MetaRevealDataType, MetaRevealMinVMVersionType,
 )
 
 func VersionRecord(version *TransitionVersion) tlv.Record {
@@ -91,6 +94,12 @@ func VersionRecord(version *TransitionVersion) tlv.Record {
 	)
 }
 
+func VMVersionRecord(version *vm.Version) tlv.Record {
+	return tlv.MakeStaticRecord(
+		VMVersionType, version, 4, VMVersionEncoder, VMVersionDecoder,
+	)
+}
+
 func PrevOutRecord(prevOut *wire.OutPoint) tlv.Record {
 	return tlv.MakeStaticRecord(
 		PrevOutType, prevOut, 32+4, asset.OutPointEncoder,
@@ -348,6 +357,13 @@ func MetaRevealTypeRecord(metaType *MetaType) tlv.Record {
 	)
 }
 
+func MetaRevealMinVMVersionRecord(version *vm.Version) tlv.Record {
+	return tlv.MakeStaticRecord(
+		MetaRevealMinVMVersionType, version, 4, VMVersionEncoder,
+		VMVersionDecoder,
+	)
+}
+
 func MetaRevealDataRecord(data *[]byte) tlv.Record {
 	sizeFunc := func() uint64 {
 		if data == nil {
@@ -0,0 +1,235 @@
+package proof
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+	"sync"
+
+	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"google.golang.org/grpc"
+)
+
+const (
+	// MaxUploadPoWBits is the maximum proof-of-work difficulty, in leading
+	// zero bits, that a universe server can require for proof uploads.
+	MaxUploadPoWBits = 32
+)
+
+var (
+	// ErrInsufficientUploadPoW is returned if the proof-of-work nonce of a
+	// proof upload doesn't meet the required difficulty.
+	ErrInsufficientUploadPoW = errors.New("insufficient proof upload " +
+		"proof-of-work")
+)
+
+// uploadPoWHash returns the hashcash style hash of the given proof upload
+// challenge and nonce.
+func uploadPoWHash(challenge [32]byte, nonce uint64) [32]byte {
+	var buf [sha256.Size + 8]byte
+	copy(buf[:], challenge[:])
+	binary.BigEndian.PutUint64(buf[sha256.Size:], nonce)
+
+	return sha256.Sum256(buf[:])
+}
+
+// leadingZeroBits returns the number of leading zero bits of the given hash.
+func leadingZeroBits(hash [32]byte) uint32 {
+	var zeros uint32
+	for _, b := range hash {
+		if b != 0 {
+			return zeros + uint32(bits.LeadingZeros8(b))
+		}
+
+		zeros += 8
+	}
+
+	return zeros
+}
+
+// VerifyUploadPoW checks that the given nonce is a valid proof-of-work for
+// uploading the given proof blob at the given difficulty. The work is bound to
+// the exact proof being uploaded, so it can't be re-used for other proofs.
+func VerifyUploadPoW(proofBlob []byte, nonce uint64, difficulty uint32) error {
+	if difficulty == 0 {
+		return nil
+	}
+
+	challenge := sha256.Sum256(proofBlob)
+	if leadingZeroBits(uploadPoWHash(challenge, nonce)) < difficulty {
+		return fmt.Errorf("%w: %d leading zero bits required",
+			ErrInsufficientUploadPoW, difficulty)
+	}
+
+	return nil
+}
+
+// SolveUploadPoW searches for a nonce that is a valid proof-of-work for
+// uploading the given proof blob at the given difficulty.
+func SolveUploadPoW(ctx context.Context, proofBlob []byte,
+	difficulty uint32) (uint64, error) {
+
+	if difficulty == 0 {
+		return 0, nil
+	}
+
+	if difficulty > MaxUploadPoWBits {
+		return 0, fmt.Errorf("proof upload proof-of-work difficulty "+
+			"%d exceeds maximum of %d", difficulty,
+			MaxUploadPoWBits)
+	}
+
+	challenge := sha256.Sum256(proofBlob)
+	for nonce := uint64(0); nonce < math.MaxUint64; nonce++ {
+		// Check for cancellation every once in a while, as the search
+		// can take a considerable amount of time for high
+		// difficulties.
+		if nonce%(1<<16) == 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			default:
+			}
+		}
+
+		if leadingZeroBits(uploadPoWHash(challenge, nonce)) >=
+			difficulty {
+
+			return nonce, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unable to solve proof upload proof-of-work")
+}
+
+// isInsufficientUploadPoW returns true if the given error was caused by a proof
+// upload being rejected because of insufficient proof-of-work. Errors returned
+// by a remote universe server only carry the error message.
+func isInsufficientUploadPoW(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, ErrInsufficientUploadPoW) ||
+		strings.Contains(err.Error(), ErrInsufficientUploadPoW.Error())
+}
+
+// UploadPoWClient is a universe client that adds the proof-of-work the
+// universe server requires to each proof upload. The required difficulty is
+// queried once and then cached. It is only queried again if the server rejects
+// an upload because of insufficient proof-of-work, for example because the
+// server operator raised the difficulty.
+type UploadPoWClient struct {
+	unirpc.UniverseClient
+
+	// difficulty is the cached proof-of-work difficulty of the server, or
+	// nil if it wasn't queried yet.
+	difficulty *uint32
+
+	// difficultyMtx guards the cached difficulty.
+	difficultyMtx sync.Mutex
+}
+
+// NewUploadPoWClient creates a new universe client that adds the required
+// proof-of-work to the proof uploads sent through the given client.
+func NewUploadPoWClient(client unirpc.UniverseClient) *UploadPoWClient {
+	return &UploadPoWClient{
+		UniverseClient: client,
+	}
+}
+
+// A compile-time assertion to ensure UploadPoWClient satisfies the
+// unirpc.UniverseClient interface.
+var _ unirpc.UniverseClient = (*UploadPoWClient)(nil)
+
+// InsertProof solves the proof-of-work the universe server requires for the
+// given proof upload, if any, and then inserts the proof. If the server rejects
+// the proof-of-work, the difficulty is queried again and the upload is retried
+// once.
+func (c *UploadPoWClient) InsertProof(ctx context.Context,
+	req *unirpc.AssetProof,
+	opts ...grpc.CallOption) (*unirpc.AssetProofResponse, error) {
+
+	difficulty, err := c.uploadDifficulty(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addUploadPoW(ctx, req, difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.UniverseClient.InsertProof(ctx, req, opts...)
+	if !isInsufficientUploadPoW(err) {
+		return resp, err
+	}
+
+	// Our cached difficulty seems to be outdated, so we refresh it and try
+	// again.
+	newDifficulty, err := c.uploadDifficulty(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addUploadPoW(ctx, req, newDifficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UniverseClient.InsertProof(ctx, req, opts...)
+}
+
+// uploadDifficulty returns the proof-of-work difficulty the universe server
+// requires for proof uploads. The server is only queried if the difficulty
+// isn't cached yet or a refresh is requested.
+func (c *UploadPoWClient) uploadDifficulty(ctx context.Context,
+	refresh bool) (uint32, error) {
+
+	c.difficultyMtx.Lock()
+	defer c.difficultyMtx.Unlock()
+
+	if c.difficulty != nil && !refresh {
+		return *c.difficulty, nil
+	}
+
+	info, err := c.UniverseClient.Info(ctx, &unirpc.InfoRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("unable to query universe server info: %w",
+			err)
+	}
+
+	c.difficulty = &info.ProofUploadPowBits
+
+	return info.ProofUploadPowBits, nil
+}
+
+// addUploadPoW solves the proof-of-work of the given difficulty for the given
+// proof insertion request.
+func addUploadPoW(ctx context.Context, req *unirpc.AssetProof,
+	difficulty uint32) error {
+
+	if difficulty == 0 {
+		req.PowNonce = 0
+		return nil
+	}
+
+	if req.AssetLeaf == nil {
+		return fmt.Errorf("asset leaf must be set")
+	}
+
+	nonce, err := SolveUploadPoW(ctx, req.AssetLeaf.Proof, difficulty)
+	if err != nil {
+		return fmt.Errorf("unable to solve proof upload "+
+			"proof-of-work: %w", err)
+	}
+
+	req.PowNonce = nonce
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package proof
+
+import (
+	"context"
+	"testing"
+
+	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// mockPoWUniverseClient is a universe client that requires the proof-of-work
+// of proof uploads to meet a difficulty that can be changed at any time.
+type mockPoWUniverseClient struct {
+	unirpc.UniverseClient
+
+	difficulty uint32
+
+	infoCalls   int
+	insertCalls int
+}
+
+// Info returns the current proof upload difficulty.
+func (m *mockPoWUniverseClient) Info(context.Context, *unirpc.InfoRequest,
+	...grpc.CallOption) (*unirpc.InfoResponse, error) {
+
+	m.infoCalls++
+
+	return &unirpc.InfoResponse{
+		ProofUploadPowBits: m.difficulty,
+	}, nil
+}
+
+// InsertProof rejects proof uploads without sufficient proof-of-work.
+func (m *mockPoWUniverseClient) InsertProof(_ context.Context,
+	req *unirpc.AssetProof,
+	_ ...grpc.CallOption) (*unirpc.AssetProofResponse, error) {
+
+	m.insertCalls++
+
+	err := VerifyUploadPoW(req.AssetLeaf.Proof, req.PowNonce, m.difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.AssetProofResponse{}, nil
+}
+
+// TestUploadPoWClient tests that the proof upload difficulty is only queried
+// once and then refreshed if the server rejects the proof-of-work.
+func TestUploadPoWClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := &mockPoWUniverseClient{
+		difficulty: 4,
+	}
+	client := NewUploadPoWClient(server)
+
+	newReq := func(proofBlob []byte) *unirpc.AssetProof {
+		return &unirpc.AssetProof{
+			AssetLeaf: &unirpc.AssetLeaf{
+				Proof: proofBlob,
+			},
+		}
+	}
+
+	// The difficulty is queried on the first upload and then re-used for
+	// the following ones.
+	for i := byte(0); i < 3; i++ {
+		req := newReq([]byte{i})
+		_, err := client.InsertProof(ctx, req)
+		require.NoError(t, err)
+		require.NoError(t, VerifyUploadPoW(
+			req.AssetLeaf.Proof, req.PowNonce, server.difficulty,
+		))
+	}
+	require.Equal(t, 1, server.infoCalls)
+	require.Equal(t, 3, server.insertCalls)
+
+	// If the server raises the difficulty, the first rejected upload
+	// causes the difficulty to be refreshed and the upload to be retried.
+	server.difficulty = 12
+	req := newReq([]byte("raised difficulty"))
+	_, err := client.InsertProof(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, VerifyUploadPoW(
+		req.AssetLeaf.Proof, req.PowNonce, server.difficulty,
+	))
+	require.Equal(t, 2, server.infoCalls)
+	require.Equal(t, 5, server.insertCalls)
+
+	// The refreshed difficulty is cached as well.
+	_, err = client.InsertProof(ctx, newReq([]byte("cached")))
+	require.NoError(t, err)
+	require.Equal(t, 2, server.infoCalls)
+	require.Equal(t, 6, server.insertCalls)
+}
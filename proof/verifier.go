@@ -308,6 +308,7 @@ func (p *Proof) verifyAssetStateTransition(ctx context.Context,
 	verifyOpts := []vm.NewEngineOpt{
 		vm.WithChainLookup(chainLookup),
 		vm.WithBlockHeight(p.BlockHeight),
+		vm.WithVersion(p.VMVersion),
 	}
 	engine, err := vm.New(newAsset, splitAssets, prevAssets, verifyOpts...)
 	if err != nil {
@@ -341,8 +342,11 @@ func (p *Proof) verifyChallengeWitness(ctx context.Context,
 		prevId: ownedAsset,
 	}
 
-	verifyOpts := vm.WithChainLookup(chainLookup)
-	engine, err := vm.New(proofAsset, nil, prevAssets, verifyOpts)
+	verifyOpts := []vm.NewEngineOpt{
+		vm.WithChainLookup(chainLookup),
+		vm.WithVersion(p.VMVersion),
+	}
+	engine, err := vm.New(proofAsset, nil, prevAssets, verifyOpts...)
 	if err != nil {
 		return false, err
 	}
@@ -553,6 +557,13 @@ func (p *Proof) Verify(ctx context.Context, prev *AssetSnapshot,
 		return nil, ErrUnknownVersion
 	}
 
+	// We also can't verify the state transition if it's meant to be
+	// validated under consensus rules we don't know of.
+	if !p.VMVersion.IsSupported() {
+		return nil, fmt.Errorf("%w: %v", vm.ErrUnsupportedVersion,
+			p.VMVersion)
+	}
+
 	// Ensure proof asset is valid.
 	if err := p.Asset.Validate(); err != nil {
 		return nil, fmt.Errorf("failed to validate proof asset: "+
@@ -654,7 +665,23 @@ func (p *Proof) Verify(ctx context.Context, prev *AssetSnapshot,
 		}
 	}
 
-	// 8. Either a set of asset inputs with valid witnesses is included that
+	// 8. The state transition must declare at least the VM version that
+	// the meta reveal of the asset's genesis requires. We only know the
+	// requirement if we verify the full provenance of the asset.
+	minVMVersion := vm.V0
+	switch {
+	case prev != nil:
+		minVMVersion = prev.MinVMVersion
+
+	case p.MetaReveal != nil:
+		minVMVersion = p.MetaReveal.MinVMVersion
+	}
+	if p.VMVersion < minVMVersion {
+		return nil, fmt.Errorf("%w: proof declares %v, asset requires "+
+			"%v", ErrVMVersionTooLow, p.VMVersion, minVMVersion)
+	}
+
+	// 9. Either a set of asset inputs with valid witnesses is included that
 	// satisfy the resulting state transition or a challenge witness is
 	// provided as part of an ownership proof.
 	var splitAsset bool
@@ -674,7 +701,7 @@ func (p *Proof) Verify(ctx context.Context, prev *AssetSnapshot,
 		return nil, err
 	}
 
-	// 8. At this point we know there is an inclusion proof, which must be
+	// 10. At this point we know there is an inclusion proof, which must be
 	// a commitment proof. So we can extract the tapscript preimage directly
 	// from there.
 	tapscriptPreimage := p.InclusionProof.CommitmentProof.TapSiblingPreimage
@@ -696,6 +723,7 @@ func (p *Proof) Verify(ctx context.Context, prev *AssetSnapshot,
 		TapscriptSibling:  tapscriptPreimage,
 		SplitAsset:        splitAsset,
 		MetaReveal:        p.MetaReveal,
+		MinVMVersion:      minVMVersion,
 	}, nil
 }
 
@@ -111,6 +111,16 @@ func (l *LndPbstChannelFunder) OpenChannel(ctx context.Context,
 		lndclient.WithRemoteReserve(CustomChannelRemoteReserve),
 	}
 
+	// A zero-conf channel uses the scid-alias channel type, so the
+	// channel's base SCID stays the same once the funding transaction
+	// confirms.
+	if req.ZeroConf {
+		channelOpenOptions = append(
+			channelOpenOptions, lndclient.WithZeroConf(),
+			lndclient.WithScid(),
+		)
+	}
+
 	// Limit the number of HTLCs that can be added to the channel by the
 	// remote party.
 	if req.RemoteMaxHtlc > 0 {
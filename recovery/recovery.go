@@ -0,0 +1,687 @@
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// DefaultGapLimit is the default number of consecutive keys after the
+	// last used key that are scanned before the recovery concludes that
+	// no further keys were used.
+	DefaultGapLimit = 1000
+
+	// MaxGapLimit is the maximum gap limit that can be used for a
+	// recovery.
+	MaxGapLimit = 100_000
+
+	// scanProgress is the share of the total progress, in percent, that
+	// is attributed to scanning the universe for leaves paying our keys.
+	scanProgress = 40
+)
+
+var (
+	// ErrInvalidParams is returned if the parameters of a recovery are
+	// invalid.
+	ErrInvalidParams = errors.New("invalid recovery parameters")
+)
+
+// Params are the parameters of a recovery. They are stored as the parameters
+// of the recovery job, so an interrupted recovery can be restarted.
+type Params struct {
+	// UniverseHost is the host:port of the universe server that is
+	// queried for proofs paying our script keys.
+	UniverseHost string `json:"universe_host"`
+
+	// GapLimit is the number of consecutive keys after the last used key
+	// that are scanned.
+	GapLimit uint32 `json:"gap_limit"`
+}
+
+// Validate makes sure the parameters describe a valid recovery.
+func (p *Params) Validate() error {
+	if p.UniverseHost == "" {
+		return fmt.Errorf("%w: universe host must be set",
+			ErrInvalidParams)
+	}
+
+	if p.GapLimit == 0 || p.GapLimit > MaxGapLimit {
+		return fmt.Errorf("%w: gap limit must be between 1 and %d",
+			ErrInvalidParams, MaxGapLimit)
+	}
+
+	return nil
+}
+
+// Encode encodes the parameters, so they can be stored with a job.
+func (p *Params) Encode() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// DecodeParams decodes and validates the encoded parameters of a recovery.
+func DecodeParams(encoded []byte) (*Params, error) {
+	var params Params
+	if err := json.Unmarshal(encoded, &params); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParams, err)
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+// Result summarizes a completed recovery.
+type Result struct {
+	// NumKeysScanned is the number of keys of the Taproot Assets key
+	// family that were derived and scanned for.
+	NumKeysScanned uint32
+
+	// NumLeavesFound is the number of universe leaves that pay one of our
+	// script keys.
+	NumLeavesFound int
+
+	// NumSpent is the number of found leaves that were already spent.
+	NumSpent int
+
+	// NumProofsImported is the number of proofs of unspent assets that
+	// were imported.
+	NumProofsImported int
+
+	// NumProofsSkipped is the number of proofs of unspent assets that were
+	// already known.
+	NumProofsSkipped int
+
+	// LastUsedIndex is the highest index of a key that was found to be
+	// used, either as a script key or as the internal key of an anchor
+	// output.
+	LastUsedIndex fn.Option[uint32]
+}
+
+// KeyRing is used to derive the keys of the Taproot Assets key family.
+type KeyRing interface {
+	// DeriveKey derives the key with the given key locator.
+	DeriveKey(ctx context.Context,
+		keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error)
+
+	// DeriveNextTaprootAssetKey derives the next unused key of the
+	// Taproot Assets key family.
+	DeriveNextTaprootAssetKey(
+		ctx context.Context) (keychain.KeyDescriptor, error)
+}
+
+// Importer imports recovered keys and proofs into the local database.
+type Importer interface {
+	// ImportInternalKey imports the given internal key, so the anchor
+	// outputs it is used for can be spent.
+	ImportInternalKey(ctx context.Context,
+		keyDesc keychain.KeyDescriptor) error
+
+	// ImportScriptKey imports the given script key, so the assets it is
+	// used for can be spent.
+	ImportScriptKey(ctx context.Context, scriptKey asset.ScriptKey) error
+
+	// ImportProof imports the given proof file. False is returned if the
+	// proof was already known.
+	ImportProof(ctx context.Context, blob proof.Blob) (bool, error)
+}
+
+// Config is the configuration of a Recoverer.
+type Config struct {
+	// KeyRing is used to derive the keys that are scanned for.
+	KeyRing KeyRing
+
+	// Importer imports the recovered keys and proofs.
+	Importer Importer
+
+	// NewUniverse connects to the universe server with the given address.
+	NewUniverse func(addr universe.ServerAddr) (universe.DiffEngine,
+		error)
+
+	// NewCourier creates a proof courier that fetches full proof files
+	// from the universe server with the given courier address.
+	NewCourier func(ctx context.Context,
+		addr *url.URL) (proof.Courier, error)
+}
+
+// Recoverer rebuilds the local asset state from the keys of the lnd wallet
+// and the proofs stored by a universe server. Since all script keys and
+// anchor internal keys are derived from the lnd seed, the assets of a wallet
+// that was restored from its seed can be found by deriving the keys of the
+// Taproot Assets key family and looking for universe leaves that pay them.
+type Recoverer struct {
+	cfg *Config
+}
+
+// NewRecoverer creates a new Recoverer with the given config.
+func NewRecoverer(cfg *Config) *Recoverer {
+	return &Recoverer{
+		cfg: cfg,
+	}
+}
+
+// derivedKeys holds the keys of the Taproot Assets key family that were
+// derived so far.
+type derivedKeys struct {
+	// descs holds the derived keys, indexed by their key index.
+	descs []keychain.KeyDescriptor
+
+	// scriptKeys maps the x-only BIP-0086 script key of each derived key
+	// to the index of the key.
+	scriptKeys map[[32]byte]uint32
+
+	// internalKeys maps each derived key in its x-only form to the index
+	// of the key.
+	internalKeys map[[32]byte]uint32
+}
+
+// leafMatch is a universe leaf that pays one of our script keys.
+type leafMatch struct {
+	// id is the identifier of the universe the leaf was found in.
+	id universe.Identifier
+
+	// key is the key of the leaf.
+	key universe.LeafKey
+
+	// keyIndex is the index of the key the script key was derived from.
+	keyIndex uint32
+}
+
+// spentKey identifies an asset output independent of the parity of its
+// script key.
+type spentKey struct {
+	outPoint  wire.OutPoint
+	assetID   asset.ID
+	scriptKey [32]byte
+}
+
+// Recover scans the universe server given in the parameters for proofs paying
+// the script keys derived from the lnd wallet, and imports all unspent assets
+// it finds. The scan covers all keys up to the gap limit after the last used
+// key. Since already known proofs are skipped, a recovery can be safely
+// repeated.
+func (r *Recoverer) Recover(ctx context.Context, params Params,
+	report func(percent uint8)) (*Result, error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Since the universe might be scanned multiple times, we make sure we
+	// never report a lower progress than before.
+	var lastProgress uint8
+	reportProgress := func(percent uint8) {
+		if percent > lastProgress {
+			lastProgress = percent
+			report(percent)
+		}
+	}
+
+	serverAddr := universe.NewServerAddrFromStr(params.UniverseHost)
+	diff, err := r.cfg.NewUniverse(serverAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to universe: %w", err)
+	}
+	defer diff.Close()
+
+	roots, err := fetchAllRoots(ctx, diff)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch universe roots: %w",
+			err)
+	}
+
+	log.Infof("Scanning %d universe roots of %v for assets paying our "+
+		"keys (gap_limit=%d)", len(roots), params.UniverseHost,
+		params.GapLimit)
+
+	keys := &derivedKeys{
+		scriptKeys:   make(map[[32]byte]uint32),
+		internalKeys: make(map[[32]byte]uint32),
+	}
+	if err := r.deriveKeys(ctx, keys, params.GapLimit); err != nil {
+		return nil, err
+	}
+
+	// We scan the universe for leaves paying the derived keys until the
+	// last used key is followed by at least gap limit unused keys. Each
+	// time a used key is found close to the end of the derived keys, we
+	// derive more keys and scan again.
+	var matches []leafMatch
+	for {
+		matches, err = scanUniverse(
+			ctx, diff, roots, keys, reportProgress,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var lastUsed uint32
+		for _, match := range matches {
+			lastUsed = max(lastUsed, match.keyIndex)
+		}
+
+		numKeys := uint32(len(keys.descs))
+		if len(matches) == 0 || lastUsed+params.GapLimit < numKeys {
+			break
+		}
+
+		err := r.deriveKeys(ctx, keys, lastUsed+params.GapLimit+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{
+		NumKeysScanned: uint32(len(keys.descs)),
+		NumLeavesFound: len(matches),
+	}
+	if len(matches) == 0 {
+		reportProgress(100)
+		return result, nil
+	}
+
+	courierAddr := &url.URL{
+		Scheme: proof.UniverseRpcCourierType,
+		Host:   params.UniverseHost,
+	}
+	courier, err := r.cfg.NewCourier(ctx, courierAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create proof courier: %w",
+			err)
+	}
+	defer courier.Close()
+
+	spent := make(map[[32]byte]map[spentKey]struct{})
+	for idx, match := range matches {
+		used, err := r.recoverLeaf(
+			ctx, diff, courier, keys, match, spent, result,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, keyIndex := range used {
+			lastUsed := result.LastUsedIndex.UnwrapOr(0)
+			result.LastUsedIndex = fn.Some(max(lastUsed, keyIndex))
+		}
+
+		reportProgress(progress(
+			scanProgress, 100, idx+1, len(matches),
+		))
+	}
+
+	// The lnd wallet of a restored node doesn't know which keys of our
+	// key family were already used. So we derive keys until we're past
+	// the last used one, to make sure no key is ever used twice.
+	err = fn.MapOptionZ(result.LastUsedIndex, func(lastUsed uint32) error {
+		return r.advanceKeyIndex(ctx, lastUsed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Recovery complete: keys_scanned=%d, leaves_found=%d, "+
+		"spent=%d, proofs_imported=%d, proofs_skipped=%d",
+		result.NumKeysScanned, result.NumLeavesFound, result.NumSpent,
+		result.NumProofsImported, result.NumProofsSkipped)
+
+	return result, nil
+}
+
+// deriveKeys derives the keys of the Taproot Assets key family until the
+// given number of keys is known.
+func (r *Recoverer) deriveKeys(ctx context.Context, keys *derivedKeys,
+	numKeys uint32) error {
+
+	for idx := uint32(len(keys.descs)); idx < numKeys; idx++ {
+		keyLoc := keychain.KeyLocator{
+			Family: asset.TaprootAssetsKeyFamily,
+			Index:  idx,
+		}
+		keyDesc, err := r.cfg.KeyRing.DeriveKey(ctx, keyLoc)
+		if err != nil {
+			return fmt.Errorf("unable to derive key %d: %w", idx,
+				err)
+		}
+
+		scriptKey := asset.NewScriptKeyBip86(keyDesc)
+		keys.scriptKeys[xOnly(scriptKey.PubKey)] = idx
+		keys.internalKeys[xOnly(keyDesc.PubKey)] = idx
+		keys.descs = append(keys.descs, keyDesc)
+	}
+
+	return nil
+}
+
+// advanceKeyIndex derives new keys of the Taproot Assets key family until the
+// index of the next key is past the given last used index.
+func (r *Recoverer) advanceKeyIndex(ctx context.Context,
+	lastUsed uint32) error {
+
+	for {
+		keyDesc, err := r.cfg.KeyRing.DeriveNextTaprootAssetKey(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to derive next key: %w", err)
+		}
+
+		if keyDesc.Index >= lastUsed {
+			return nil
+		}
+	}
+}
+
+// recoverLeaf imports the asset of the given leaf, unless it was already
+// spent. The indexes of the keys the asset uses are returned.
+func (r *Recoverer) recoverLeaf(ctx context.Context,
+	diff universe.DiffEngine, courier proof.Courier, keys *derivedKeys,
+	match leafMatch, spent map[[32]byte]map[spentKey]struct{},
+	result *Result) ([]uint32, error) {
+
+	uniProofs, err := diff.FetchProofLeaf(ctx, match.id, match.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch proof leaf: %w", err)
+	}
+	if len(uniProofs) == 0 || uniProofs[0].Leaf == nil ||
+		uniProofs[0].Leaf.Asset == nil {
+
+		return nil, fmt.Errorf("universe returned no proof for leaf "+
+			"%v", match.key.OutPoint)
+	}
+	leaf := uniProofs[0].Leaf
+	leafAsset := leaf.Asset
+
+	var transitionProof proof.Proof
+	err = transitionProof.Decode(bytes.NewReader(leaf.RawProof))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode proof: %w", err)
+	}
+
+	used := []uint32{match.keyIndex}
+	internalKey := transitionProof.InclusionProof.InternalKey
+	internalKeyIdx, haveInternalKey := keys.internalKeys[xOnly(internalKey)]
+	if haveInternalKey {
+		used = append(used, internalKeyIdx)
+	}
+
+	// The leaf might have been spent in a later transfer. We find out by
+	// looking for a transfer of the same universe that spends it.
+	uniID := universe.Identifier{
+		AssetID:   match.id.AssetID,
+		GroupKey:  match.id.GroupKey,
+		ProofType: universe.ProofTypeTransfer,
+	}
+	spentOutputs, ok := spent[uniID.Bytes()]
+	if !ok {
+		spentOutputs, err = fetchSpentOutputs(ctx, diff, uniID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch spent outputs "+
+				"of universe %v: %w", uniID.String(), err)
+		}
+		spent[uniID.Bytes()] = spentOutputs
+	}
+
+	assetID := leafAsset.ID()
+	_, isSpent := spentOutputs[spentKey{
+		outPoint:  match.key.OutPoint,
+		assetID:   assetID,
+		scriptKey: xOnly(leafAsset.ScriptKey.PubKey),
+	}]
+	if isSpent {
+		log.Debugf("Skipping spent asset %v at %v", assetID,
+			match.key.OutPoint)
+
+		result.NumSpent++
+		return used, nil
+	}
+
+	// The keys need to be known before the proof is imported, so the
+	// asset is recognized as ours.
+	if haveInternalKey {
+		err := r.cfg.Importer.ImportInternalKey(
+			ctx, keys.descs[internalKeyIdx],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import internal "+
+				"key: %w", err)
+		}
+	} else {
+		log.Warnf("Internal key of anchor output %v of asset %v isn't "+
+			"derived from our wallet, the asset might not be "+
+			"spendable", match.key.OutPoint, assetID)
+	}
+
+	scriptKey := asset.NewScriptKeyBip86(keys.descs[match.keyIndex])
+	scriptKey.TweakedScriptKey.DeclaredKnown = true
+	if err := r.cfg.Importer.ImportScriptKey(ctx, scriptKey); err != nil {
+		return nil, fmt.Errorf("unable to import script key: %w", err)
+	}
+
+	locator := proof.Locator{
+		AssetID:   &assetID,
+		ScriptKey: *leafAsset.ScriptKey.PubKey,
+		OutPoint:  &match.key.OutPoint,
+	}
+	if leafAsset.GroupKey != nil {
+		locator.GroupKey = &leafAsset.GroupKey.GroupPubKey
+	}
+	recipient := proof.Recipient{
+		ScriptKey: leafAsset.ScriptKey.PubKey,
+		AssetID:   assetID,
+		Amount:    leafAsset.Amount,
+	}
+	annotatedProof, err := courier.ReceiveProof(ctx, recipient, locator)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch proof file of asset "+
+			"%v at %v: %w", assetID, match.key.OutPoint, err)
+	}
+
+	imported, err := r.cfg.Importer.ImportProof(ctx, annotatedProof.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to import proof of asset %v at "+
+			"%v: %w", assetID, match.key.OutPoint, err)
+	}
+
+	if imported {
+		log.Infof("Recovered %d units of asset %v at %v",
+			leafAsset.Amount, assetID, match.key.OutPoint)
+
+		result.NumProofsImported++
+	} else {
+		result.NumProofsSkipped++
+	}
+
+	return used, nil
+}
+
+// scanUniverse returns all leaves of the given universe roots that pay one of
+// the derived script keys.
+func scanUniverse(ctx context.Context, diff universe.DiffEngine,
+	roots []universe.Root, keys *derivedKeys,
+	report func(percent uint8)) ([]leafMatch, error) {
+
+	var matches []leafMatch
+	for idx, root := range roots {
+		leafKeys, err := fetchAllLeafKeys(ctx, diff, root.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch leaf keys of "+
+				"universe %v: %w", root.ID.String(), err)
+		}
+
+		for _, leafKey := range leafKeys {
+			if leafKey.ScriptKey == nil ||
+				leafKey.ScriptKey.PubKey == nil {
+
+				continue
+			}
+
+			scriptKey := xOnly(leafKey.ScriptKey.PubKey)
+			keyIndex, ok := keys.scriptKeys[scriptKey]
+			if !ok {
+				continue
+			}
+
+			matches = append(matches, leafMatch{
+				id:       root.ID,
+				key:      leafKey,
+				keyIndex: keyIndex,
+			})
+		}
+
+		report(progress(0, scanProgress, idx+1, len(roots)))
+	}
+
+	return matches, nil
+}
+
+// fetchSpentOutputs returns all asset outputs that are spent by the transfers
+// of the given transfer universe.
+func fetchSpentOutputs(ctx context.Context, diff universe.DiffEngine,
+	id universe.Identifier) (map[spentKey]struct{}, error) {
+
+	leafKeys, err := fetchAllLeafKeys(ctx, diff, id)
+	if err != nil {
+		return nil, err
+	}
+
+	spent := make(map[spentKey]struct{})
+	for _, leafKey := range leafKeys {
+		uniProofs, err := diff.FetchProofLeaf(ctx, id, leafKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, uniProof := range uniProofs {
+			if uniProof.Leaf == nil || uniProof.Leaf.Asset == nil {
+				continue
+			}
+
+			// The inputs of a split output are only referenced by
+			// the root asset of the split.
+			leafAsset := uniProof.Leaf.Asset
+			witnesses := leafAsset.PrevWitnesses
+			if leafAsset.HasSplitCommitmentWitness() {
+				split := witnesses[0].SplitCommitment
+				witnesses = split.RootAsset.PrevWitnesses
+			}
+
+			for _, witness := range witnesses {
+				if witness.PrevID == nil ||
+					*witness.PrevID == asset.ZeroPrevID {
+
+					continue
+				}
+
+				prevID := witness.PrevID
+				scriptKey, err := prevID.ScriptKey.ToPubKey()
+				if err != nil {
+					return nil, err
+				}
+
+				spent[spentKey{
+					outPoint:  prevID.OutPoint,
+					assetID:   prevID.ID,
+					scriptKey: xOnly(scriptKey),
+				}] = struct{}{}
+			}
+		}
+	}
+
+	return spent, nil
+}
+
+// fetchAllRoots fetches all universe roots from the given universe, page by
+// page.
+func fetchAllRoots(ctx context.Context,
+	diff universe.DiffEngine) ([]universe.Root, error) {
+
+	var (
+		roots    []universe.Root
+		offset   int32
+		pageSize = int32(universe.MaxPageSize)
+	)
+	for {
+		page, err := diff.RootNodes(ctx, universe.RootNodesQuery{
+			SortDirection: universe.SortAscending,
+			Offset:        offset,
+			Limit:         pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		roots = append(roots, page...)
+
+		// If we're getting a partial page, then we know we're done.
+		if len(page) < int(pageSize) {
+			return roots, nil
+		}
+
+		offset += pageSize
+	}
+}
+
+// fetchAllLeafKeys fetches all leaf keys of the given universe, page by page.
+func fetchAllLeafKeys(ctx context.Context, diff universe.DiffEngine,
+	id universe.Identifier) ([]universe.LeafKey, error) {
+
+	var (
+		leafKeys []universe.LeafKey
+		offset   int32
+		pageSize = int32(universe.MaxPageSize)
+	)
+	for {
+		page, err := diff.UniverseLeafKeys(
+			ctx, universe.UniverseLeafKeysQuery{
+				Id:            id,
+				SortDirection: universe.SortAscending,
+				Offset:        offset,
+				Limit:         pageSize,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		leafKeys = append(leafKeys, page...)
+
+		// If we're getting a partial page, then we know we're done.
+		if len(page) < int(pageSize) {
+			return leafKeys, nil
+		}
+
+		offset += pageSize
+	}
+}
+
+// progress maps the given number of completed steps to a progress in percent
+// between the given lower and upper bound.
+func progress(from, to uint8, done, total int) uint8 {
+	if total == 0 {
+		return to
+	}
+
+	return from + uint8(int(to-from)*done/total)
+}
+
+// xOnly returns the x-only serialization of the given key, which makes keys
+// comparable independent of their parity.
+func xOnly(key *btcec.PublicKey) [32]byte {
+	var xOnlyKey [32]byte
+	copy(xOnlyKey[:], schnorr.SerializePubKey(key))
+
+	return xOnlyKey
+}
@@ -0,0 +1,384 @@
+package recovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKeyRing is a key ring that deterministically derives a key for each
+// key index, like a wallet that was restored from its seed.
+type mockKeyRing struct {
+	nextIndex uint32
+}
+
+// keyDesc returns the key descriptor of the key with the given index.
+func (m *mockKeyRing) keyDesc(idx uint32) keychain.KeyDescriptor {
+	var seed [4]byte
+	binary.BigEndian.PutUint32(seed[:], idx)
+	privKeyBytes := sha256.Sum256(seed[:])
+	_, pubKey := btcec.PrivKeyFromBytes(privKeyBytes[:])
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: asset.TaprootAssetsKeyFamily,
+			Index:  idx,
+		},
+		PubKey: pubKey,
+	}
+}
+
+// DeriveKey derives the key with the given key locator.
+func (m *mockKeyRing) DeriveKey(_ context.Context,
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	return m.keyDesc(keyLoc.Index), nil
+}
+
+// DeriveNextTaprootAssetKey derives the next unused key of the Taproot Assets
+// key family.
+func (m *mockKeyRing) DeriveNextTaprootAssetKey(
+	context.Context) (keychain.KeyDescriptor, error) {
+
+	keyDesc := m.keyDesc(m.nextIndex)
+	m.nextIndex++
+
+	return keyDesc, nil
+}
+
+// mockImporter records all imported keys and proofs.
+type mockImporter struct {
+	internalKeys []keychain.KeyDescriptor
+	scriptKeys   []asset.ScriptKey
+	proofs       map[[32]byte]proof.Blob
+}
+
+// ImportInternalKey records the given internal key.
+func (m *mockImporter) ImportInternalKey(_ context.Context,
+	keyDesc keychain.KeyDescriptor) error {
+
+	m.internalKeys = append(m.internalKeys, keyDesc)
+
+	return nil
+}
+
+// ImportScriptKey records the given script key.
+func (m *mockImporter) ImportScriptKey(_ context.Context,
+	scriptKey asset.ScriptKey) error {
+
+	m.scriptKeys = append(m.scriptKeys, scriptKey)
+
+	return nil
+}
+
+// ImportProof records the given proof, unless it was already imported.
+func (m *mockImporter) ImportProof(_ context.Context,
+	blob proof.Blob) (bool, error) {
+
+	hash := sha256.Sum256(blob)
+	if _, ok := m.proofs[hash]; ok {
+		return false, nil
+	}
+	m.proofs[hash] = blob
+
+	return true, nil
+}
+
+// mockUniverse is an in-memory universe that serves the issuance and transfer
+// leaves of a single asset.
+type mockUniverse struct {
+	assetID asset.ID
+	leaves  map[universe.ProofType][]*universe.Leaf
+	keys    map[universe.ProofType][]universe.LeafKey
+}
+
+// addLeaf adds a leaf with the given key to the universe of the given type.
+func (m *mockUniverse) addLeaf(proofType universe.ProofType,
+	key universe.LeafKey, leaf *universe.Leaf) {
+
+	m.keys[proofType] = append(m.keys[proofType], key)
+	m.leaves[proofType] = append(m.leaves[proofType], leaf)
+}
+
+// RootNode returns the root of the universe with the given ID.
+func (m *mockUniverse) RootNode(_ context.Context,
+	id universe.Identifier) (universe.Root, error) {
+
+	if len(m.keys[id.ProofType]) == 0 {
+		return universe.Root{}, universe.ErrNoUniverseRoot
+	}
+
+	return universe.Root{
+		ID: id,
+		Node: mssmt.NewLeafNode(
+			nil, uint64(len(m.keys[id.ProofType])),
+		),
+	}, nil
+}
+
+// RootNodes returns the roots of all non-empty universes.
+func (m *mockUniverse) RootNodes(ctx context.Context,
+	q universe.RootNodesQuery) ([]universe.Root, error) {
+
+	var roots []universe.Root
+	for _, proofType := range []universe.ProofType{
+		universe.ProofTypeIssuance, universe.ProofTypeTransfer,
+	} {
+
+		root, err := m.RootNode(ctx, universe.Identifier{
+			AssetID:   m.assetID,
+			ProofType: proofType,
+		})
+		if err != nil {
+			continue
+		}
+		roots = append(roots, root)
+	}
+
+	start := min(int(q.Offset), len(roots))
+	end := min(start+int(q.Limit), len(roots))
+
+	return roots[start:end], nil
+}
+
+// UniverseLeafKeys returns a page of the leaf keys of the universe.
+func (m *mockUniverse) UniverseLeafKeys(_ context.Context,
+	q universe.UniverseLeafKeysQuery) ([]universe.LeafKey, error) {
+
+	keys := m.keys[q.Id.ProofType]
+	start := min(int(q.Offset), len(keys))
+	end := min(start+int(q.Limit), len(keys))
+
+	return keys[start:end], nil
+}
+
+// FetchProofLeaf returns the leaf with the given key.
+func (m *mockUniverse) FetchProofLeaf(_ context.Context, id universe.Identifier,
+	key universe.LeafKey) ([]*universe.Proof, error) {
+
+	for idx, k := range m.keys[id.ProofType] {
+		if k.UniverseKey() != key.UniverseKey() {
+			continue
+		}
+
+		return []*universe.Proof{{
+			Leaf: m.leaves[id.ProofType][idx],
+		}}, nil
+	}
+
+	return nil, universe.ErrNoUniverseProofFound
+}
+
+// Close closes the universe.
+func (m *mockUniverse) Close() error {
+	return nil
+}
+
+// testBlock returns a block with a single transaction with two outputs.
+func testBlock(t *testing.T) wire.MsgBlock {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: test.RandOp(t),
+	})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+
+	return wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Timestamp: time.Unix(1_700_000_000, 0),
+		},
+		Transactions: []*wire.MsgTx{tx},
+	}
+}
+
+// TestRecover tests that unspent assets paying keys of the wallet are found
+// and imported, while spent assets and keys past the gap limit are ignored.
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keyRing := &mockKeyRing{}
+	genesis := asset.RandGenesis(t, asset.Normal)
+	assetID := genesis.ID()
+	block := testBlock(t)
+
+	uni := &mockUniverse{
+		assetID: assetID,
+		leaves:  make(map[universe.ProofType][]*universe.Leaf),
+		keys:    make(map[universe.ProofType][]universe.LeafKey),
+	}
+	courier := proof.NewMockProofCourier()
+
+	// addLeaf adds a leaf paying the script key derived from the key with
+	// the given index, anchored in an output with the given internal key.
+	addLeaf := func(proofType universe.ProofType, scriptKeyIdx,
+		internalKeyIdx uint32, prevIDs ...*asset.PrevID) wire.OutPoint {
+
+		scriptKey := asset.NewScriptKeyBip86(
+			keyRing.keyDesc(scriptKeyIdx),
+		)
+		p := proof.RandProof(t, genesis, scriptKey.PubKey, block, 0, 1)
+		p.InclusionProof.InternalKey = keyRing.keyDesc(
+			internalKeyIdx,
+		).PubKey
+		rawProof, err := proof.Encode(&p)
+		require.NoError(t, err)
+
+		leafAsset := asset.NewAssetNoErr(
+			t, genesis, 50, 0, 0, scriptKey, nil,
+		)
+		if len(prevIDs) > 0 {
+			leafAsset.PrevWitnesses = nil
+		}
+		for _, prevID := range prevIDs {
+			leafAsset.PrevWitnesses = append(
+				leafAsset.PrevWitnesses, asset.Witness{
+					PrevID: prevID,
+				},
+			)
+		}
+
+		outPoint := test.RandOp(t)
+		uni.addLeaf(proofType, universe.LeafKey{
+			OutPoint:  outPoint,
+			ScriptKey: &scriptKey,
+		}, &universe.Leaf{
+			RawProof: rawProof,
+			Asset:    leafAsset,
+			Amt:      50,
+		})
+
+		err = courier.DeliverProof(ctx, proof.Recipient{
+			ScriptKey: scriptKey.PubKey,
+		}, &proof.AnnotatedProof{
+			Locator: proof.Locator{
+				AssetID:   &assetID,
+				ScriptKey: *scriptKey.PubKey,
+				OutPoint:  &outPoint,
+			},
+			Blob: rawProof,
+			AssetSnapshot: &proof.AssetSnapshot{
+				Asset: leafAsset,
+			},
+		})
+		require.NoError(t, err)
+
+		return outPoint
+	}
+
+	// The asset was issued to key 2, which then sent it to key 6. Key 6 is
+	// only found after the scan was extended, since it's past the initial
+	// gap. Key 20 is past the gap after the last used key and is never
+	// found.
+	issuanceOutPoint := addLeaf(universe.ProofTypeIssuance, 2, 3)
+	spentScriptKey := asset.NewScriptKeyBip86(keyRing.keyDesc(2))
+	addLeaf(universe.ProofTypeTransfer, 6, 7, &asset.PrevID{
+		OutPoint:  issuanceOutPoint,
+		ID:        assetID,
+		ScriptKey: asset.ToSerialized(spentScriptKey.PubKey),
+	})
+	addLeaf(universe.ProofTypeIssuance, 20, 21)
+
+	importer := &mockImporter{
+		proofs: make(map[[32]byte]proof.Blob),
+	}
+	recoverer := NewRecoverer(&Config{
+		KeyRing:  keyRing,
+		Importer: importer,
+		NewUniverse: func(universe.ServerAddr) (universe.DiffEngine,
+			error) {
+
+			return uni, nil
+		},
+		NewCourier: func(context.Context, *url.URL) (proof.Courier,
+			error) {
+
+			return courier, nil
+		},
+	})
+
+	var reports []uint8
+	report := func(percent uint8) {
+		reports = append(reports, percent)
+	}
+	params := Params{
+		UniverseHost: "localhost:10029",
+		GapLimit:     5,
+	}
+	result, err := recoverer.Recover(ctx, params, report)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 12, result.NumKeysScanned)
+	require.Equal(t, 2, result.NumLeavesFound)
+	require.Equal(t, 1, result.NumSpent)
+	require.Equal(t, 1, result.NumProofsImported)
+	require.Equal(t, 0, result.NumProofsSkipped)
+	require.Equal(t, uint32(7), result.LastUsedIndex.UnwrapOr(0))
+
+	// Only the keys of the unspent asset were imported.
+	require.Len(t, importer.internalKeys, 1)
+	require.EqualValues(t, 7, importer.internalKeys[0].Index)
+	require.Len(t, importer.scriptKeys, 1)
+	require.True(t, importer.scriptKeys[0].PubKey.IsEqual(
+		asset.NewScriptKeyBip86(keyRing.keyDesc(6)).PubKey,
+	))
+	require.True(t, importer.scriptKeys[0].TweakedScriptKey.DeclaredKnown)
+	require.Len(t, importer.proofs, 1)
+
+	// The key index of the wallet was advanced past the last used key.
+	require.EqualValues(t, 8, keyRing.nextIndex)
+
+	// The progress is reported in order and ends at 100 percent.
+	require.NotEmpty(t, reports)
+	require.IsNonDecreasing(t, reports)
+	require.EqualValues(t, 100, reports[len(reports)-1])
+
+	// A repeated recovery skips the already imported proof.
+	result, err = recoverer.Recover(ctx, params, report)
+	require.NoError(t, err)
+	require.Equal(t, 0, result.NumProofsImported)
+	require.Equal(t, 1, result.NumProofsSkipped)
+
+	// The wallet was already past the last used key, so only a single
+	// key was derived to find out.
+	require.EqualValues(t, 9, keyRing.nextIndex)
+}
+
+// TestDecodeParams tests that recovery parameters survive an encoding round
+// trip and are validated when decoded.
+func TestDecodeParams(t *testing.T) {
+	t.Parallel()
+
+	params := Params{
+		UniverseHost: "universe.example.com:10029",
+		GapLimit:     DefaultGapLimit,
+	}
+	encoded, err := params.Encode()
+	require.NoError(t, err)
+
+	decoded, err := DecodeParams(encoded)
+	require.NoError(t, err)
+	require.Equal(t, params, *decoded)
+
+	_, err = DecodeParams([]byte(`{"gap_limit": 10}`))
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = DecodeParams([]byte(`{"universe_host": "a", "gap_limit": 0}`))
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = DecodeParams([]byte(`not json`))
+	require.ErrorIs(t, err, ErrInvalidParams)
+}
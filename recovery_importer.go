@@ -0,0 +1,83 @@
+package taprootassets
+
+import (
+	"context"
+
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/recovery"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// RecoveryImporterConfig houses all the items the recovery importer needs to
+// import recovered keys and proofs into the local state.
+type RecoveryImporterConfig struct {
+	// KeyStore is the store the recovered internal and script keys are
+	// inserted into.
+	KeyStore address.Storage
+
+	// ProofArchive is the archive the recovered proofs are imported into.
+	ProofArchive proof.Archiver
+
+	// ChainBridge is used to verify the recovered proofs.
+	ChainBridge tapgarden.ChainBridge
+
+	// MintingStore is used to verify the group keys of the recovered
+	// proofs.
+	MintingStore tapgarden.MintingStore
+}
+
+// RecoveryImporter is an implementation of the recovery.Importer interface
+// that imports recovered keys and proofs into the local stores of the node.
+type RecoveryImporter struct {
+	cfg *RecoveryImporterConfig
+}
+
+// A compile-time assertion to ensure RecoveryImporter meets the
+// recovery.Importer interface.
+var _ recovery.Importer = (*RecoveryImporter)(nil)
+
+// NewRecoveryImporter creates a new recovery importer.
+func NewRecoveryImporter(cfg *RecoveryImporterConfig) *RecoveryImporter {
+	return &RecoveryImporter{
+		cfg: cfg,
+	}
+}
+
+// ImportInternalKey inserts the given internal key, so the anchor outputs it
+// is used for are recognized as ours.
+//
+// NOTE: This is part of the recovery.Importer interface.
+func (r *RecoveryImporter) ImportInternalKey(ctx context.Context,
+	keyDesc keychain.KeyDescriptor) error {
+
+	return r.cfg.KeyStore.InsertInternalKey(ctx, keyDesc)
+}
+
+// ImportScriptKey inserts the given script key, so the assets it is used for
+// are recognized as ours.
+//
+// NOTE: This is part of the recovery.Importer interface.
+func (r *RecoveryImporter) ImportScriptKey(ctx context.Context,
+	scriptKey asset.ScriptKey) error {
+
+	declaredKnown := scriptKey.TweakedScriptKey != nil &&
+		scriptKey.TweakedScriptKey.DeclaredKnown
+
+	return r.cfg.KeyStore.InsertScriptKey(ctx, scriptKey, declaredKnown)
+}
+
+// ImportProof verifies and imports the given proof file, unless we already
+// have it.
+//
+// NOTE: This is part of the recovery.Importer interface.
+func (r *RecoveryImporter) ImportProof(ctx context.Context,
+	blob proof.Blob) (bool, error) {
+
+	return importProofFile(
+		ctx, r.cfg.ProofArchive, r.cfg.ChainBridge, r.cfg.MintingStore,
+		blob,
+	)
+}
@@ -0,0 +1,284 @@
+package replication
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// ChangeType is the type of state change that is replicated.
+type ChangeType uint8
+
+const (
+	// ChangeTypeProof is a new asset proof that was stored by the leader.
+	// The payload is the full proof file.
+	ChangeTypeProof ChangeType = 1
+
+	// ChangeTypeAddr is a new address that was created by the leader. The
+	// payload is a JSON encoded AddrChange.
+	ChangeTypeAddr ChangeType = 2
+
+	// ChangeTypeTransfer is an outbound transfer that was completed by the
+	// leader. The payload is a JSON encoded TransferChange.
+	ChangeTypeTransfer ChangeType = 3
+)
+
+// String returns a human-readable representation of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeTypeProof:
+		return "proof"
+
+	case ChangeTypeAddr:
+		return "addr"
+
+	case ChangeTypeTransfer:
+		return "transfer"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// Change is a single state change in the replication log of a leader.
+type Change struct {
+	// Seq is the sequence number of the change in the log of the leader.
+	// Sequence numbers are strictly increasing.
+	Seq uint64
+
+	// Type is the type of the change.
+	Type ChangeType
+
+	// Payload is the serialized change, depending on its type.
+	Payload []byte
+
+	// Timestamp is the time the change was recorded at.
+	Timestamp time.Time
+}
+
+// keyDesc is the JSON representation of a key descriptor.
+type keyDesc struct {
+	PubKey string `json:"pub_key"`
+	Family uint32 `json:"family"`
+	Index  uint32 `json:"index"`
+}
+
+// newKeyDesc creates the JSON representation of the given key descriptor.
+func newKeyDesc(desc keychain.KeyDescriptor) keyDesc {
+	return keyDesc{
+		PubKey: hex.EncodeToString(desc.PubKey.SerializeCompressed()),
+		Family: uint32(desc.Family),
+		Index:  desc.Index,
+	}
+}
+
+// toKeyDescriptor parses the JSON representation of a key descriptor.
+func (k keyDesc) toKeyDescriptor() (keychain.KeyDescriptor, error) {
+	pubKeyBytes, err := hex.DecodeString(k.PubKey)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamily(k.Family),
+			Index:  k.Index,
+		},
+		PubKey: pubKey,
+	}, nil
+}
+
+// AddrChange is a new address that was created by the leader, together with
+// the key information the follower needs to receive assets on it.
+type AddrChange struct {
+	// Addr is the bech32m encoded address.
+	Addr string `json:"addr"`
+
+	// ScriptKey is the raw key the script key of the address is derived
+	// from.
+	ScriptKey keyDesc `json:"script_key"`
+
+	// ScriptKeyTweak is the hex encoded tweak that is applied to the raw
+	// script key, if any.
+	ScriptKeyTweak string `json:"script_key_tweak"`
+
+	// InternalKey is the internal key of the address.
+	InternalKey keyDesc `json:"internal_key"`
+}
+
+// NewAddrChange creates the payload of a ChangeTypeAddr change for the given
+// address.
+func NewAddrChange(addr *address.AddrWithKeyInfo) ([]byte, error) {
+	addrStr, err := addr.EncodeAddress()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode address: %w", err)
+	}
+
+	return json.Marshal(&AddrChange{
+		Addr:      addrStr,
+		ScriptKey: newKeyDesc(addr.ScriptKeyTweak.RawKey),
+		ScriptKeyTweak: hex.EncodeToString(
+			addr.ScriptKeyTweak.Tweak,
+		),
+		InternalKey: newKeyDesc(addr.InternalKeyDesc),
+	})
+}
+
+// DecodeAddrChange decodes the payload of a ChangeTypeAddr change.
+func DecodeAddrChange(payload []byte) (*AddrChange, error) {
+	var change AddrChange
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return nil, fmt.Errorf("unable to decode address change: %w",
+			err)
+	}
+
+	return &change, nil
+}
+
+// Decode decodes the address and returns it together with its script key and
+// internal key descriptor.
+func (a *AddrChange) Decode(params *address.ChainParams) (*address.Tap,
+	asset.ScriptKey, keychain.KeyDescriptor, error) {
+
+	var (
+		scriptKey   asset.ScriptKey
+		internalKey keychain.KeyDescriptor
+	)
+
+	addr, err := address.DecodeAddress(a.Addr, params)
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("unable to "+
+			"decode address: %w", err)
+	}
+
+	rawScriptKey, err := a.ScriptKey.toKeyDescriptor()
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("invalid "+
+			"script key: %w", err)
+	}
+
+	tweak, err := hex.DecodeString(a.ScriptKeyTweak)
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("invalid "+
+			"script key tweak: %w", err)
+	}
+	if len(tweak) == 0 {
+		tweak = nil
+	}
+
+	internalKey, err = a.InternalKey.toKeyDescriptor()
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("invalid "+
+			"internal key: %w", err)
+	}
+
+	if !internalKey.PubKey.IsEqual(&addr.InternalKey) {
+		return nil, scriptKey, internalKey, fmt.Errorf("internal " +
+			"key doesn't match address")
+	}
+
+	scriptKey = asset.ScriptKey{
+		PubKey: &addr.ScriptKey,
+		TweakedScriptKey: &asset.TweakedScriptKey{
+			RawKey: rawScriptKey,
+			Tweak:  tweak,
+		},
+	}
+
+	return addr, scriptKey, internalKey, nil
+}
+
+// SpentAsset identifies an asset that was spent by a transfer.
+type SpentAsset struct {
+	// AssetID is the hex encoded ID of the asset.
+	AssetID string `json:"asset_id"`
+
+	// ScriptKey is the hex encoded script key of the asset.
+	ScriptKey string `json:"script_key"`
+
+	// AnchorPoint is the outpoint the asset was anchored at.
+	AnchorPoint string `json:"anchor_point"`
+}
+
+// PrevID returns the previous asset ID of the spent asset.
+func (s *SpentAsset) PrevID() (asset.PrevID, error) {
+	var prevID asset.PrevID
+
+	assetIDBytes, err := hex.DecodeString(s.AssetID)
+	if err != nil || len(assetIDBytes) != len(prevID.ID) {
+		return prevID, fmt.Errorf("invalid asset ID %q", s.AssetID)
+	}
+	copy(prevID.ID[:], assetIDBytes)
+
+	scriptKeyBytes, err := hex.DecodeString(s.ScriptKey)
+	if err != nil || len(scriptKeyBytes) != len(prevID.ScriptKey) {
+		return prevID, fmt.Errorf("invalid script key %q", s.ScriptKey)
+	}
+	copy(prevID.ScriptKey[:], scriptKeyBytes)
+
+	outPoint, err := wire.NewOutPointFromString(s.AnchorPoint)
+	if err != nil {
+		return prevID, fmt.Errorf("invalid anchor point %q: %w",
+			s.AnchorPoint, err)
+	}
+	prevID.OutPoint = *outPoint
+
+	return prevID, nil
+}
+
+// TransferChange is an outbound transfer that was completed by the leader.
+// The proofs of the new assets created by the transfer are replicated as
+// separate proof changes, so the transfer only records the assets it spent.
+type TransferChange struct {
+	// AnchorTxid is the transaction ID of the anchor transaction of the
+	// transfer.
+	AnchorTxid string `json:"anchor_txid"`
+
+	// SpentAssets are the active and passive assets that were spent by the
+	// transfer.
+	SpentAssets []SpentAsset `json:"spent_assets"`
+}
+
+// NewTransferChange creates the payload of a ChangeTypeTransfer change for a
+// transfer with the given anchor transaction that spent the given assets.
+func NewTransferChange(anchorTxid chainhash.Hash,
+	spent []asset.PrevID) ([]byte, error) {
+
+	change := TransferChange{
+		AnchorTxid:  anchorTxid.String(),
+		SpentAssets: make([]SpentAsset, len(spent)),
+	}
+	for idx, prevID := range spent {
+		change.SpentAssets[idx] = SpentAsset{
+			AssetID:     prevID.ID.String(),
+			ScriptKey:   hex.EncodeToString(prevID.ScriptKey[:]),
+			AnchorPoint: prevID.OutPoint.String(),
+		}
+	}
+
+	return json.Marshal(&change)
+}
+
+// DecodeTransferChange decodes the payload of a ChangeTypeTransfer change.
+func DecodeTransferChange(payload []byte) (*TransferChange, error) {
+	var change TransferChange
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return nil, fmt.Errorf("unable to decode transfer change: %w",
+			err)
+	}
+
+	return &change, nil
+}
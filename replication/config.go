@@ -0,0 +1,188 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// RoleLeader is the replication role of a node that records its state
+	// changes and serves them to followers.
+	RoleLeader = "leader"
+
+	// RoleFollower is the replication role of a node that replicates the
+	// state changes of a leader.
+	RoleFollower = "follower"
+
+	// DefaultRetryInterval is the default time a follower waits before it
+	// reconnects to the leader after the replication stream failed.
+	DefaultRetryInterval = 10 * time.Second
+
+	// fetchBatchSize is the maximum number of changes that are fetched
+	// from the log at once.
+	fetchBatchSize = 100
+
+	// defaultTimeout is the timeout used for database operations.
+	defaultTimeout = 30 * time.Second
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// state replication between two tapd nodes.
+//
+// nolint: lll
+type CliConfig struct {
+	Role string `long:"role" description:"The replication role of this node; a leader records its asset, address and transfer state changes and serves them to followers, a follower replicates the state changes of a leader; replication is disabled if not set"`
+
+	LeaderHost string `long:"leaderhost" description:"The host:port of the RPC server of the leader to replicate from; only used by a follower"`
+
+	LeaderTLSCertPath string `long:"leadertlscertpath" description:"The path to the TLS certificate of the leader's RPC server; only used by a follower"`
+
+	LeaderMacaroonPath string `long:"leadermacaroonpath" description:"The path to a macaroon of the leader that grants read access to assets, addresses and proofs; only used by a follower"`
+
+	RetryInterval time.Duration `long:"retryinterval" description:"The time a follower waits before it reconnects to the leader after the replication stream failed"`
+}
+
+// DefaultCliConfig returns the default replication configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		RetryInterval: DefaultRetryInterval,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	switch c.Role {
+	case "", RoleLeader:
+		return nil
+
+	case RoleFollower:
+
+	default:
+		return fmt.Errorf("unknown replication role %q", c.Role)
+	}
+
+	if c.LeaderHost == "" {
+		return fmt.Errorf("replication leader host must be set")
+	}
+
+	if c.LeaderTLSCertPath == "" {
+		return fmt.Errorf("replication leader TLS certificate path " +
+			"must be set")
+	}
+
+	if c.LeaderMacaroonPath == "" {
+		return fmt.Errorf("replication leader macaroon path must be " +
+			"set")
+	}
+
+	if c.RetryInterval <= 0 {
+		return fmt.Errorf("replication retry interval must be " +
+			"positive")
+	}
+
+	return nil
+}
+
+// Store is the persistent, append-only log of the state changes of a leader.
+type Store interface {
+	// AppendChange appends a change of the given type to the log and
+	// returns its sequence number.
+	AppendChange(ctx context.Context, changeType ChangeType,
+		payload []byte, timestamp time.Time) (uint64, error)
+
+	// FetchChanges returns up to limit changes with a sequence number
+	// greater than afterSeq, in the order they were appended.
+	FetchChanges(ctx context.Context, afterSeq uint64,
+		limit int32) ([]Change, error)
+}
+
+// CursorStore persists the sequence number of the last change a follower
+// applied.
+type CursorStore interface {
+	// FetchCursor returns the sequence number of the last change that was
+	// applied from the given leader, or zero if no change was applied
+	// yet.
+	FetchCursor(ctx context.Context, leaderID string) (uint64, error)
+
+	// UpdateCursor sets the sequence number of the last change that was
+	// applied from the given leader.
+	UpdateCursor(ctx context.Context, leaderID string, seq uint64) error
+}
+
+// Client is the connection of a follower to its leader.
+type Client interface {
+	// SubscribeChanges streams the changes of the leader with a sequence
+	// number greater than afterSeq to the given callback, in order. It
+	// blocks until the stream fails, the callback returns an error or
+	// the context is canceled.
+	SubscribeChanges(ctx context.Context, afterSeq uint64,
+		cb func(Change) error) error
+}
+
+// Applier applies the replicated state changes to the local state of a
+// follower. All methods must be idempotent, as changes might be applied more
+// than once.
+type Applier interface {
+	// ApplyProof imports the given proof file.
+	ApplyProof(ctx context.Context, blob proof.Blob) error
+
+	// ApplyAddr imports the given address.
+	ApplyAddr(ctx context.Context, addr *AddrChange) error
+
+	// ApplySpentAssets marks the given assets as spent.
+	ApplySpentAssets(ctx context.Context, spent []asset.PrevID) error
+}
+
+// LeaderConfig houses all the items the leader needs to carry out its
+// duties.
+type LeaderConfig struct {
+	// Store is the log the state changes are recorded in.
+	Store Store
+
+	// ProofPublisher notifies the leader about new proofs.
+	ProofPublisher fn.EventPublisher[proof.Blob, []*proof.Locator]
+
+	// AddrPublisher notifies the leader about new addresses.
+	AddrPublisher fn.EventPublisher[
+		*address.AddrWithKeyInfo, address.QueryParams,
+	]
+
+	// TransferPublisher notifies the leader about the progress of
+	// outbound transfers.
+	TransferPublisher fn.EventPublisher[fn.Event, bool]
+
+	// ExistingProofs returns the proof files of all assets we currently
+	// own. They are recorded when the log is still empty, so a follower
+	// can bootstrap from the current state of the leader.
+	ExistingProofs func(ctx context.Context) ([]proof.Blob, error)
+
+	// Clock is used to timestamp the changes.
+	Clock clock.Clock
+}
+
+// FollowerConfig houses all the items the follower needs to carry out its
+// duties.
+type FollowerConfig struct {
+	// LeaderID identifies the leader the follower replicates from.
+	LeaderID string
+
+	// Client is the connection to the leader.
+	Client Client
+
+	// Applier applies the replicated changes.
+	Applier Applier
+
+	// CursorStore persists the replication progress.
+	CursorStore CursorStore
+
+	// RetryInterval is the time the follower waits before it reconnects
+	// after the replication stream failed.
+	RetryInterval time.Duration
+}
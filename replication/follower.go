@@ -0,0 +1,168 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+// Follower replicates the state changes of a leader by applying them to the
+// local state of the node, in the order they were recorded by the leader. The
+// sequence number of the last applied change is persisted, so the replication
+// resumes where it left off after a restart or a lost connection.
+type Follower struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *FollowerConfig
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewFollower creates a new replication follower.
+func NewFollower(cfg *FollowerConfig) *Follower {
+	return &Follower{
+		cfg: cfg,
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: defaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start starts replicating the state changes of the leader.
+func (f *Follower) Start() error {
+	f.startOnce.Do(func() {
+		log.Infof("Starting replication follower of leader %v",
+			f.cfg.LeaderID)
+
+		f.Wg.Add(1)
+		go f.replicate()
+	})
+
+	return nil
+}
+
+// Stop stops the replication.
+func (f *Follower) Stop() error {
+	f.stopOnce.Do(func() {
+		log.Info("Stopping replication follower")
+
+		close(f.Quit)
+		f.Wg.Wait()
+	})
+
+	return nil
+}
+
+// replicate is the main loop of the follower. It (re-)connects to the leader
+// and applies the streamed changes until the follower is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *Follower) replicate() {
+	defer f.Wg.Done()
+
+	for {
+		err := f.syncChanges()
+		if err != nil {
+			log.Errorf("Replication from leader %v failed, "+
+				"retrying in %v: %v", f.cfg.LeaderID,
+				f.cfg.RetryInterval, err)
+		}
+
+		select {
+		case <-time.After(f.cfg.RetryInterval):
+		case <-f.Quit:
+			return
+		}
+	}
+}
+
+// syncChanges subscribes to the changes of the leader after the last applied
+// change and applies them until the stream fails.
+func (f *Follower) syncChanges() error {
+	ctx, cancel := f.WithCtxQuitNoTimeout()
+	defer cancel()
+
+	cursor, err := f.cfg.CursorStore.FetchCursor(ctx, f.cfg.LeaderID)
+	if err != nil {
+		return fmt.Errorf("unable to fetch replication cursor: %w", err)
+	}
+
+	log.Infof("Replicating changes of leader %v after sequence number "+
+		"%d", f.cfg.LeaderID, cursor)
+
+	return f.cfg.Client.SubscribeChanges(
+		ctx, cursor, func(change Change) error {
+			// Changes we already applied are skipped, in case the
+			// leader sends them again.
+			if change.Seq <= cursor {
+				return nil
+			}
+
+			err := f.applyChange(ctx, change)
+			if err != nil {
+				return fmt.Errorf("unable to apply %v change "+
+					"%d: %w", change.Type, change.Seq, err)
+			}
+
+			err = f.cfg.CursorStore.UpdateCursor(
+				ctx, f.cfg.LeaderID, change.Seq,
+			)
+			if err != nil {
+				return fmt.Errorf("unable to update "+
+					"replication cursor: %w", err)
+			}
+			cursor = change.Seq
+
+			return nil
+		},
+	)
+}
+
+// applyChange applies a single change to the local state.
+func (f *Follower) applyChange(ctx context.Context, change Change) error {
+	log.Debugf("Applying %v change %d", change.Type, change.Seq)
+
+	switch change.Type {
+	case ChangeTypeProof:
+		return f.cfg.Applier.ApplyProof(ctx, proof.Blob(change.Payload))
+
+	case ChangeTypeAddr:
+		addrChange, err := DecodeAddrChange(change.Payload)
+		if err != nil {
+			return err
+		}
+
+		return f.cfg.Applier.ApplyAddr(ctx, addrChange)
+
+	case ChangeTypeTransfer:
+		transferChange, err := DecodeTransferChange(change.Payload)
+		if err != nil {
+			return err
+		}
+
+		spent := make([]asset.PrevID, len(transferChange.SpentAssets))
+		for idx := range transferChange.SpentAssets {
+			spentAsset := transferChange.SpentAssets[idx]
+			spent[idx], err = spentAsset.PrevID()
+			if err != nil {
+				return err
+			}
+		}
+
+		return f.cfg.Applier.ApplySpentAssets(ctx, spent)
+
+	// We can't skip changes we don't know, as the state of the follower
+	// would silently diverge from the leader.
+	default:
+		return fmt.Errorf("unknown change type %v", change.Type)
+	}
+}
@@ -0,0 +1,324 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+)
+
+// Leader records the asset, address and transfer state changes of the node in
+// the replication log and streams them to followers.
+type Leader struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *LeaderConfig
+
+	proofSub    *fn.EventReceiver[proof.Blob]
+	addrSub     *fn.EventReceiver[*address.AddrWithKeyInfo]
+	transferSub *fn.EventReceiver[fn.Event]
+
+	// signalMtx guards newChange.
+	signalMtx sync.Mutex
+
+	// newChange is closed and replaced whenever a new change was appended
+	// to the log, to wake up the streams waiting for new changes.
+	newChange chan struct{}
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewLeader creates a new replication leader.
+func NewLeader(cfg *LeaderConfig) *Leader {
+	return &Leader{
+		cfg: cfg,
+		proofSub: fn.NewEventReceiver[proof.Blob](
+			fn.DefaultQueueSize,
+		),
+		addrSub: fn.NewEventReceiver[*address.AddrWithKeyInfo](
+			fn.DefaultQueueSize,
+		),
+		transferSub: fn.NewEventReceiver[fn.Event](
+			fn.DefaultQueueSize,
+		),
+		newChange: make(chan struct{}),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: defaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start subscribes to the state changes of the node and starts recording
+// them. If the log is still empty, the current state of the node is recorded
+// first.
+func (l *Leader) Start() error {
+	var startErr error
+	l.startOnce.Do(func() {
+		log.Info("Starting replication leader")
+
+		startErr = l.start()
+	})
+
+	return startErr
+}
+
+// start subscribes to the state changes of the node.
+func (l *Leader) start() error {
+	ctx, cancel := l.WithCtxQuit()
+	defer cancel()
+
+	existing, err := l.cfg.Store.FetchChanges(ctx, 0, 1)
+	if err != nil {
+		return fmt.Errorf("unable to fetch replication log: %w", err)
+	}
+	logEmpty := len(existing) == 0
+
+	// We subscribe to new proofs before we record the existing ones, so
+	// we don't miss any proof in between. Recording a proof twice is fine,
+	// as followers skip proofs they already have.
+	err = l.cfg.ProofPublisher.RegisterSubscriber(l.proofSub, false, nil)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to proofs: %w", err)
+	}
+
+	if logEmpty {
+		proofs, err := l.cfg.ExistingProofs(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to fetch existing proofs: %w",
+				err)
+		}
+
+		log.Infof("Recording %d existing proofs in empty "+
+			"replication log", len(proofs))
+
+		for _, blob := range proofs {
+			err := l.appendChange(ctx, ChangeTypeProof, blob)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The existing addresses are delivered through the subscription if
+	// the log is still empty, after the proofs of the assets they are for.
+	err = l.cfg.AddrPublisher.RegisterSubscriber(
+		l.addrSub, logEmpty, address.QueryParams{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to addresses: %w", err)
+	}
+
+	err = l.cfg.TransferPublisher.RegisterSubscriber(
+		l.transferSub, false, false,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to transfers: %w", err)
+	}
+
+	l.Wg.Add(1)
+	go l.recordChanges()
+
+	return nil
+}
+
+// Stop stops recording state changes.
+func (l *Leader) Stop() error {
+	var stopErr error
+	l.stopOnce.Do(func() {
+		log.Info("Stopping replication leader")
+
+		close(l.Quit)
+		l.Wg.Wait()
+
+		stopErr = l.removeSubscribers()
+	})
+
+	return stopErr
+}
+
+// removeSubscribers removes the subscriptions to the state changes of the
+// node.
+func (l *Leader) removeSubscribers() error {
+	err := l.cfg.ProofPublisher.RemoveSubscriber(l.proofSub)
+	if err != nil {
+		return err
+	}
+
+	err = l.cfg.AddrPublisher.RemoveSubscriber(l.addrSub)
+	if err != nil {
+		return err
+	}
+
+	return l.cfg.TransferPublisher.RemoveSubscriber(l.transferSub)
+}
+
+// recordChanges is the main loop of the leader. It records the state changes
+// of the node in the replication log.
+//
+// NOTE: This MUST be run as a goroutine.
+func (l *Leader) recordChanges() {
+	defer l.Wg.Done()
+
+	for {
+		var err error
+		select {
+		case blob := <-l.proofSub.NewItemCreated.ChanOut():
+			err = l.recordChange(ChangeTypeProof, blob, nil)
+
+		case addr := <-l.addrSub.NewItemCreated.ChanOut():
+			payload, encodeErr := NewAddrChange(addr)
+			err = l.recordChange(ChangeTypeAddr, payload, encodeErr)
+
+		case event := <-l.transferSub.NewItemCreated.ChanOut():
+			sendEvent, ok := event.(*tapfreighter.AssetSendEvent)
+			if !ok || sendEvent.Error != nil ||
+				sendEvent.SendState !=
+					tapfreighter.SendStateComplete ||
+				sendEvent.Transfer == nil {
+
+				continue
+			}
+
+			payload, encodeErr := newTransferChange(
+				sendEvent.Transfer,
+			)
+			err = l.recordChange(
+				ChangeTypeTransfer, payload, encodeErr,
+			)
+
+		case <-l.Quit:
+			return
+		}
+
+		// There is no way to recover a change we failed to record, so
+		// we can only log the error. Followers will be missing the
+		// change until it's replicated again, for example as part of
+		// a later proof.
+		if err != nil {
+			log.Errorf("Unable to record replication change: %v",
+				err)
+		}
+	}
+}
+
+// recordChange appends the given change to the log, unless its payload
+// couldn't be encoded.
+func (l *Leader) recordChange(changeType ChangeType, payload []byte,
+	encodeErr error) error {
+
+	if encodeErr != nil {
+		return fmt.Errorf("unable to encode %v change: %w", changeType,
+			encodeErr)
+	}
+
+	ctx, cancel := l.WithCtxQuit()
+	defer cancel()
+
+	return l.appendChange(ctx, changeType, payload)
+}
+
+// appendChange appends the given change to the log and wakes up the streams
+// waiting for new changes.
+func (l *Leader) appendChange(ctx context.Context, changeType ChangeType,
+	payload []byte) error {
+
+	seq, err := l.cfg.Store.AppendChange(
+		ctx, changeType, payload, l.cfg.Clock.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to append %v change: %w", changeType,
+			err)
+	}
+
+	log.Debugf("Recorded %v change with sequence number %d", changeType,
+		seq)
+
+	l.signalMtx.Lock()
+	close(l.newChange)
+	l.newChange = make(chan struct{})
+	l.signalMtx.Unlock()
+
+	return nil
+}
+
+// changeSignal returns a channel that is closed once the next change was
+// appended to the log.
+func (l *Leader) changeSignal() <-chan struct{} {
+	l.signalMtx.Lock()
+	defer l.signalMtx.Unlock()
+
+	return l.newChange
+}
+
+// StreamChanges sends all changes with a sequence number greater than afterSeq
+// to the given callback, in order. Once all recorded changes were sent, it
+// waits for new changes until the context is canceled, the leader is stopped
+// or the callback returns an error.
+func (l *Leader) StreamChanges(ctx context.Context, afterSeq uint64,
+	cb func(Change) error) error {
+
+	for {
+		// We get the signal before we fetch the changes, so we don't
+		// miss a change that is appended in between.
+		signal := l.changeSignal()
+
+		changes, err := l.cfg.Store.FetchChanges(
+			ctx, afterSeq, fetchBatchSize,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to fetch changes: %w", err)
+		}
+
+		for _, change := range changes {
+			if err := cb(change); err != nil {
+				return err
+			}
+
+			afterSeq = change.Seq
+		}
+
+		// If we got a full batch, there might be more changes to
+		// send right away.
+		if len(changes) == fetchBatchSize {
+			continue
+		}
+
+		select {
+		case <-signal:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.Quit:
+			return fmt.Errorf("replication leader shutting down")
+		}
+	}
+}
+
+// newTransferChange creates the payload of a transfer change for the given
+// outbound transfer. Both the active inputs and the passive assets that were
+// re-anchored by the transfer are recorded as spent.
+func newTransferChange(parcel *tapfreighter.OutboundParcel) ([]byte, error) {
+	if parcel.AnchorTx == nil {
+		return nil, fmt.Errorf("transfer without anchor transaction")
+	}
+
+	var spent []asset.PrevID
+	for _, input := range parcel.Inputs {
+		spent = append(spent, input.PrevID)
+	}
+	for _, vPkt := range parcel.PassiveAssets {
+		for _, input := range vPkt.Inputs {
+			spent = append(spent, input.PrevID)
+		}
+	}
+
+	return NewTransferChange(parcel.AnchorTx.TxHash(), spent)
+}
@@ -0,0 +1,402 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is an in-memory implementation of the Store and CursorStore
+// interfaces.
+type mockStore struct {
+	sync.Mutex
+
+	changes []Change
+	cursors map[string]uint64
+}
+
+// newMockStore creates a new empty mock store.
+func newMockStore() *mockStore {
+	return &mockStore{
+		cursors: make(map[string]uint64),
+	}
+}
+
+// AppendChange appends a change to the log.
+func (m *mockStore) AppendChange(_ context.Context, changeType ChangeType,
+	payload []byte, timestamp time.Time) (uint64, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	seq := uint64(len(m.changes) + 1)
+	m.changes = append(m.changes, Change{
+		Seq:       seq,
+		Type:      changeType,
+		Payload:   payload,
+		Timestamp: timestamp,
+	})
+
+	return seq, nil
+}
+
+// FetchChanges returns up to limit changes after the given sequence number.
+func (m *mockStore) FetchChanges(_ context.Context, afterSeq uint64,
+	limit int32) ([]Change, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	var changes []Change
+	for _, change := range m.changes {
+		if change.Seq <= afterSeq {
+			continue
+		}
+		if len(changes) == int(limit) {
+			break
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// FetchCursor returns the cursor of the given leader.
+func (m *mockStore) FetchCursor(_ context.Context,
+	leaderID string) (uint64, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	return m.cursors[leaderID], nil
+}
+
+// UpdateCursor sets the cursor of the given leader.
+func (m *mockStore) UpdateCursor(_ context.Context, leaderID string,
+	seq uint64) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.cursors[leaderID] = seq
+
+	return nil
+}
+
+// mockPublisher is a simple event publisher that delivers the items it is
+// given to all its subscribers.
+type mockPublisher[T any, Q any] struct {
+	sync.Mutex
+
+	existing    []T
+	subscribers map[uint64]*fn.EventReceiver[T]
+}
+
+// newMockPublisher creates a new mock publisher with the given existing
+// items.
+func newMockPublisher[T any, Q any](existing ...T) *mockPublisher[T, Q] {
+	return &mockPublisher[T, Q]{
+		existing:    existing,
+		subscribers: make(map[uint64]*fn.EventReceiver[T]),
+	}
+}
+
+// RegisterSubscriber adds a new subscriber.
+func (m *mockPublisher[T, Q]) RegisterSubscriber(
+	receiver *fn.EventReceiver[T], deliverExisting bool, _ Q) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.subscribers[receiver.ID()] = receiver
+
+	if deliverExisting {
+		for _, item := range m.existing {
+			receiver.NewItemCreated.ChanIn() <- item
+		}
+	}
+
+	return nil
+}
+
+// RemoveSubscriber removes the given subscriber.
+func (m *mockPublisher[T, Q]) RemoveSubscriber(
+	subscriber *fn.EventReceiver[T]) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.subscribers, subscriber.ID())
+
+	return nil
+}
+
+// publish delivers the given item to all subscribers.
+func (m *mockPublisher[T, Q]) publish(item T) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, subscriber := range m.subscribers {
+		subscriber.NewItemCreated.ChanIn() <- item
+	}
+}
+
+// mockApplier records the changes it applies.
+type mockApplier struct {
+	sync.Mutex
+
+	proofs []proof.Blob
+	addrs  []*AddrChange
+	spent  []asset.PrevID
+}
+
+// ApplyProof records the given proof.
+func (m *mockApplier) ApplyProof(_ context.Context, blob proof.Blob) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.proofs = append(m.proofs, blob)
+
+	return nil
+}
+
+// ApplyAddr records the given address.
+func (m *mockApplier) ApplyAddr(_ context.Context, addr *AddrChange) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.addrs = append(m.addrs, addr)
+
+	return nil
+}
+
+// ApplySpentAssets records the given spent assets.
+func (m *mockApplier) ApplySpentAssets(_ context.Context,
+	spent []asset.PrevID) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.spent = append(m.spent, spent...)
+
+	return nil
+}
+
+// numApplied returns the number of proofs, addresses and spent assets that
+// were applied.
+func (m *mockApplier) numApplied() (int, int, int) {
+	m.Lock()
+	defer m.Unlock()
+
+	return len(m.proofs), len(m.addrs), len(m.spent)
+}
+
+// leaderClient is a Client that streams the changes directly from a leader.
+// It can be made to fail the stream once a number of changes were received.
+type leaderClient struct {
+	leader *Leader
+
+	// failAfter is the number of changes after which the stream fails,
+	// zero means the stream never fails.
+	failAfter int
+}
+
+// SubscribeChanges streams the changes of the leader.
+func (c *leaderClient) SubscribeChanges(ctx context.Context, afterSeq uint64,
+	cb func(Change) error) error {
+
+	var numReceived int
+	return c.leader.StreamChanges(ctx, afterSeq, func(change Change) error {
+		if c.failAfter > 0 && numReceived == c.failAfter {
+			return fmt.Errorf("connection lost")
+		}
+		numReceived++
+
+		return cb(change)
+	})
+}
+
+// randPrevID returns a random previous asset ID.
+func randPrevID(t *testing.T) asset.PrevID {
+	return asset.PrevID{
+		OutPoint: wire.OutPoint{
+			Hash:  test.RandHash(),
+			Index: test.RandInt[uint32](),
+		},
+		ID:        asset.RandID(t),
+		ScriptKey: asset.RandSerializedKey(t),
+	}
+}
+
+// TestChangePayloads tests that the address and transfer change payloads can
+// be decoded again.
+func TestChangePayloads(t *testing.T) {
+	t.Parallel()
+
+	params := &address.TestNet3Tap
+	addr, _, _ := address.RandAddr(
+		t, params, address.RandProofCourierAddr(t),
+	)
+
+	payload, err := NewAddrChange(addr)
+	require.NoError(t, err)
+
+	addrChange, err := DecodeAddrChange(payload)
+	require.NoError(t, err)
+
+	tapAddr, scriptKey, internalKey, err := addrChange.Decode(params)
+	require.NoError(t, err)
+
+	addrStr, err := addr.EncodeAddress()
+	require.NoError(t, err)
+	decodedStr, err := tapAddr.EncodeAddress()
+	require.NoError(t, err)
+	require.Equal(t, addrStr, decodedStr)
+
+	require.True(t, scriptKey.PubKey.IsEqual(&addr.ScriptKey))
+	require.Equal(t, addr.ScriptKeyTweak, *scriptKey.TweakedScriptKey)
+	require.Equal(t, addr.InternalKeyDesc, internalKey)
+
+	// An address with a different internal key is rejected.
+	addrChange.InternalKey = newKeyDesc(addr.ScriptKeyTweak.RawKey)
+	_, _, _, err = addrChange.Decode(params)
+	require.ErrorContains(t, err, "internal key doesn't match")
+
+	spent := []asset.PrevID{randPrevID(t), randPrevID(t)}
+	anchorTxid := test.RandHash()
+	payload, err = NewTransferChange(anchorTxid, spent)
+	require.NoError(t, err)
+
+	transferChange, err := DecodeTransferChange(payload)
+	require.NoError(t, err)
+	require.Equal(t, anchorTxid.String(), transferChange.AnchorTxid)
+	require.Len(t, transferChange.SpentAssets, len(spent))
+
+	for idx := range transferChange.SpentAssets {
+		prevID, err := transferChange.SpentAssets[idx].PrevID()
+		require.NoError(t, err)
+		require.Equal(t, spent[idx], prevID)
+	}
+}
+
+// TestLeaderFollower tests that the state changes of a leader are replicated
+// to a follower, including the state the leader had before it started
+// recording, and that a follower resumes from its cursor after the stream
+// failed.
+func TestLeaderFollower(t *testing.T) {
+	t.Parallel()
+
+	addr, _, _ := address.RandAddr(
+		t, &address.TestNet3Tap, address.RandProofCourierAddr(t),
+	)
+
+	existingProof := proof.Blob("existing proof")
+	proofPublisher := newMockPublisher[proof.Blob, []*proof.Locator]()
+	addrPublisher := newMockPublisher[
+		*address.AddrWithKeyInfo, address.QueryParams,
+	](addr)
+	transferPublisher := newMockPublisher[fn.Event, bool]()
+
+	leaderStore := newMockStore()
+	leader := NewLeader(&LeaderConfig{
+		Store:             leaderStore,
+		ProofPublisher:    proofPublisher,
+		AddrPublisher:     addrPublisher,
+		TransferPublisher: transferPublisher,
+		ExistingProofs: func(context.Context) ([]proof.Blob, error) {
+			return []proof.Blob{existingProof}, nil
+		},
+		Clock: clock.NewTestClock(time.Unix(1_700_000_000, 0)),
+	})
+	require.NoError(t, leader.Start())
+	t.Cleanup(func() {
+		require.NoError(t, leader.Stop())
+	})
+
+	// The follower's stream fails after every two changes, so it has to
+	// resume from its cursor a couple of times.
+	applier := &mockApplier{}
+	followerStore := newMockStore()
+	follower := NewFollower(&FollowerConfig{
+		LeaderID: "leader",
+		Client: &leaderClient{
+			leader:    leader,
+			failAfter: 2,
+		},
+		Applier:       applier,
+		CursorStore:   followerStore,
+		RetryInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, follower.Start())
+	t.Cleanup(func() {
+		require.NoError(t, follower.Stop())
+	})
+
+	// The existing proof and address are replicated first.
+	require.Eventually(t, func() bool {
+		numProofs, numAddrs, _ := applier.numApplied()
+		return numProofs == 1 && numAddrs == 1
+	}, defaultTimeout, 10*time.Millisecond)
+
+	// New proofs and completed transfers are replicated as they happen.
+	// Transfers that didn't complete are ignored.
+	newProof := proof.Blob("new proof")
+	proofPublisher.publish(newProof)
+
+	spentInput := randPrevID(t)
+	passiveInput := randPrevID(t)
+	parcel := &tapfreighter.OutboundParcel{
+		AnchorTx: wire.NewMsgTx(2),
+		Inputs: []tapfreighter.TransferInput{{
+			PrevID: spentInput,
+		}},
+		PassiveAssets: []*tappsbt.VPacket{{
+			Inputs: []*tappsbt.VInput{{
+				PrevID: passiveInput,
+			}},
+		}},
+	}
+	transferPublisher.publish(&tapfreighter.AssetSendEvent{
+		SendState: tapfreighter.SendStateBroadcast,
+		Transfer:  parcel,
+	})
+	transferPublisher.publish(&tapfreighter.AssetSendEvent{
+		SendState: tapfreighter.SendStateComplete,
+		Transfer:  parcel,
+	})
+
+	require.Eventually(t, func() bool {
+		numProofs, _, numSpent := applier.numApplied()
+		return numProofs == 2 && numSpent == 2
+	}, defaultTimeout, 10*time.Millisecond)
+
+	applier.Lock()
+	require.Equal(t, []proof.Blob{existingProof, newProof}, applier.proofs)
+	require.Equal(
+		t, []asset.PrevID{spentInput, passiveInput}, applier.spent,
+	)
+	applier.Unlock()
+
+	// Every change was applied exactly once, and the cursor points to the
+	// last change.
+	changes, err := leaderStore.FetchChanges(context.Background(), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	cursor, err := followerStore.FetchCursor(context.Background(), "leader")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, cursor)
+}
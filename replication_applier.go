@@ -0,0 +1,204 @@
+package taprootassets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// SpentAssetMarker is used to mark assets as spent.
+type SpentAssetMarker interface {
+	// MarkAssetsSpent marks the assets identified by the given previous
+	// IDs as spent. Unknown assets are skipped.
+	MarkAssetsSpent(ctx context.Context, spent []asset.PrevID) error
+}
+
+// ReplicationApplierConfig houses all the items the replication applier needs
+// to apply the replicated state changes to the local state.
+type ReplicationApplierConfig struct {
+	// ProofArchive is the archive the replicated proofs are imported
+	// into.
+	ProofArchive proof.Archiver
+
+	// ChainBridge is used to verify the replicated proofs.
+	ChainBridge tapgarden.ChainBridge
+
+	// MintingStore is used to verify the group keys of the replicated
+	// proofs.
+	MintingStore tapgarden.MintingStore
+
+	// AddrBook is the address book the replicated addresses are imported
+	// into.
+	AddrBook *address.Book
+
+	// ChainParams are the chain parameters of the node, used to decode
+	// the replicated addresses.
+	ChainParams *address.ChainParams
+
+	// AssetStore is used to mark assets spent by replicated transfers.
+	AssetStore SpentAssetMarker
+}
+
+// ReplicationApplier is an implementation of the replication.Applier
+// interface that applies the replicated state changes to the local stores of
+// the node.
+type ReplicationApplier struct {
+	cfg *ReplicationApplierConfig
+}
+
+// A compile-time assertion to ensure ReplicationApplier meets the
+// replication.Applier interface.
+var _ replication.Applier = (*ReplicationApplier)(nil)
+
+// NewReplicationApplier creates a new replication applier.
+func NewReplicationApplier(
+	cfg *ReplicationApplierConfig) *ReplicationApplier {
+
+	return &ReplicationApplier{
+		cfg: cfg,
+	}
+}
+
+// ApplyProof imports the given proof file, unless we already have it.
+//
+// NOTE: This is part of the replication.Applier interface.
+func (r *ReplicationApplier) ApplyProof(ctx context.Context,
+	blob proof.Blob) error {
+
+	_, err := importProofFile(
+		ctx, r.cfg.ProofArchive, r.cfg.ChainBridge, r.cfg.MintingStore,
+		blob,
+	)
+
+	return err
+}
+
+// ApplyAddr imports the given address with its keys, unless we already have
+// it.
+//
+// NOTE: This is part of the replication.Applier interface.
+func (r *ReplicationApplier) ApplyAddr(ctx context.Context,
+	addrChange *replication.AddrChange) error {
+
+	addr, scriptKey, internalKey, err := addrChange.Decode(
+		r.cfg.ChainParams,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = importAddrWithKeys(
+		ctx, r.cfg.AddrBook, addr, scriptKey, internalKey,
+	)
+
+	return err
+}
+
+// importProofFile verifies and imports the given proof file into the given
+// archive, unless the archive already has it. True is returned if the proof
+// file was imported.
+func importProofFile(ctx context.Context, archive proof.Archiver,
+	chainBridge tapgarden.ChainBridge, mintingStore tapgarden.MintingStore,
+	blob proof.Blob) (bool, error) {
+
+	proofFile, err := proof.DecodeFile(blob)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode proof file: %w", err)
+	}
+
+	lastProof, err := proofFile.LastProof()
+	if err != nil {
+		return false, fmt.Errorf("error extracting last proof: %w", err)
+	}
+
+	locator := proof.Locator{
+		AssetID:   fn.Ptr(lastProof.Asset.ID()),
+		ScriptKey: *lastProof.Asset.ScriptKey.PubKey,
+		OutPoint:  fn.Ptr(lastProof.OutPoint()),
+	}
+	haveProof, err := archive.HasProof(ctx, locator)
+	if err != nil {
+		return false, fmt.Errorf("unable to check for proof: %w", err)
+	}
+	if haveProof {
+		return false, nil
+	}
+
+	headerVerifier := tapgarden.GenHeaderVerifier(ctx, chainBridge)
+	groupVerifier := tapgarden.GenGroupVerifier(ctx, mintingStore)
+
+	err = archive.ImportProofs(
+		ctx, headerVerifier, proof.DefaultMerkleVerifier, groupVerifier,
+		chainBridge, false, &proof.AnnotatedProof{
+			Locator: locator,
+			Blob:    blob,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// importAddrWithKeys imports the given address with its keys into the given
+// address book, unless the address book already has it. True is returned if
+// the address was imported.
+func importAddrWithKeys(ctx context.Context, addrBook *address.Book,
+	addr *address.Tap, scriptKey asset.ScriptKey,
+	internalKey keychain.KeyDescriptor) (bool, error) {
+
+	taprootOutputKey, err := addr.TaprootOutputKey()
+	if err != nil {
+		return false, fmt.Errorf("unable to derive Taproot output "+
+			"key: %w", err)
+	}
+
+	_, err = addrBook.AddrByTaprootOutput(ctx, taprootOutputKey)
+	switch {
+	case err == nil:
+		return false, nil
+
+	case !errors.Is(err, address.ErrNoAddr):
+		return false, fmt.Errorf("unable to look up address: %w", err)
+	}
+
+	addrOpts := []address.NewAddrOpt{
+		address.WithAssetVersion(addr.AssetVersion),
+		address.WithFallbackProofCourierAddrs(
+			addr.FallbackProofCourierAddrs...,
+		),
+		address.WithExpiry(addr.Expiry),
+	}
+	if addr.Reusable {
+		addrOpts = append(addrOpts, address.WithReusable())
+	}
+
+	_, err = addrBook.NewAddressWithKeys(
+		ctx, addr.Version, addr.AssetID, addr.Amount, scriptKey,
+		internalKey, addr.TapscriptSibling, addr.ProofCourierAddr,
+		addrOpts...,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unable to import address: %w", err)
+	}
+
+	return true, nil
+}
+
+// ApplySpentAssets marks the given assets as spent.
+//
+// NOTE: This is part of the replication.Applier interface.
+func (r *ReplicationApplier) ApplySpentAssets(ctx context.Context,
+	spent []asset.PrevID) error {
+
+	return r.cfg.AssetStore.MarkAssetsSpent(ctx, spent)
+}
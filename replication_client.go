@@ -0,0 +1,122 @@
+package taprootassets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon.v2"
+)
+
+// RpcReplicationClient is an implementation of the replication.Client
+// interface that streams the state changes from the RPC server of the leader.
+type RpcReplicationClient struct {
+	cfg *replication.CliConfig
+}
+
+// A compile-time assertion to ensure RpcReplicationClient meets the
+// replication.Client interface.
+var _ replication.Client = (*RpcReplicationClient)(nil)
+
+// NewRpcReplicationClient creates a new replication client that connects to
+// the leader configured in the given replication config.
+func NewRpcReplicationClient(
+	cfg *replication.CliConfig) *RpcReplicationClient {
+
+	return &RpcReplicationClient{
+		cfg: cfg,
+	}
+}
+
+// dial creates a new connection to the RPC server of the leader, using the
+// configured TLS certificate and macaroon.
+func (c *RpcReplicationClient) dial() (*grpc.ClientConn, error) {
+	creds, err := credentials.NewClientTLSFromFile(
+		c.cfg.LeaderTLSCertPath, "",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load leader TLS "+
+			"certificate: %w", err)
+	}
+
+	macBytes, err := os.ReadFile(c.cfg.LeaderMacaroonPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read leader macaroon: %w",
+			err)
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, fmt.Errorf("unable to decode leader macaroon: %w",
+			err)
+	}
+
+	macCred, err := macaroons.NewMacaroonCredential(mac)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create macaroon credential: "+
+			"%w", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(macCred),
+		grpc.WithDefaultCallOptions(MaxMsgReceiveSize),
+	}
+
+	conn, err := grpc.Dial(c.cfg.LeaderHost, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to leader: %w", err)
+	}
+
+	return conn, nil
+}
+
+// SubscribeChanges streams the changes of the leader with a sequence number
+// greater than afterSeq to the given callback, in order. A new connection is
+// established for every subscription, so a follower can simply subscribe
+// again after the stream failed.
+//
+// NOTE: This is part of the replication.Client interface.
+func (c *RpcReplicationClient) SubscribeChanges(ctx context.Context,
+	afterSeq uint64, cb func(replication.Change) error) error {
+
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := taprpc.NewTaprootAssetsClient(conn)
+	stream, err := client.SubscribeReplicationChanges(
+		ctx, &taprpc.SubscribeReplicationChangesRequest{
+			AfterSeq: afterSeq,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to replication "+
+			"changes: %w", err)
+	}
+
+	for {
+		rpcChange, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("replication stream failed: %w", err)
+		}
+
+		err = cb(replication.Change{
+			Seq:       rpcChange.Seq,
+			Type:      replication.ChangeType(rpcChange.ChangeType),
+			Payload:   rpcChange.Payload,
+			Timestamp: time.Unix(rpcChange.Timestamp, 0),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
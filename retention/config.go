@@ -0,0 +1,80 @@
+package retention
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+)
+
+const (
+	// DefaultInterval is the default interval at which data is pruned.
+	DefaultInterval = 24 * time.Hour
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// data retention policy. Each category of data is kept forever if its
+// retention age is zero.
+//
+// nolint: lll
+type CliConfig struct {
+	SpentProofAge time.Duration `long:"spent-proof-age" description:"The age after which the proofs of assets spent in a transfer are pruned; 0 keeps them forever"`
+
+	DeliveredProofAge time.Duration `long:"delivered-proof-age" description:"The age after which the proofs of confirmed transfer outputs that were delivered to their recipient, and the proof courier delivery log, are pruned; 0 keeps them forever"`
+
+	UniverseEventAge time.Duration `long:"universe-event-age" description:"The age after which universe sync and proof events are pruned; pruned events are no longer included in universe statistics; 0 keeps them forever"`
+
+	Interval time.Duration `long:"interval" description:"The interval at which data is pruned according to the retention policy"`
+}
+
+// DefaultCliConfig returns the default retention configuration, which keeps
+// all data forever.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		Interval: DefaultInterval,
+	}
+}
+
+// Enabled returns true if at least one category of data is pruned.
+func (c *CliConfig) Enabled() bool {
+	return c.SpentProofAge > 0 || c.DeliveredProofAge > 0 ||
+		c.UniverseEventAge > 0
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	switch {
+	case c.SpentProofAge < 0:
+		return fmt.Errorf("spent proof age must not be negative")
+
+	case c.DeliveredProofAge < 0:
+		return fmt.Errorf("delivered proof age must not be negative")
+
+	case c.UniverseEventAge < 0:
+		return fmt.Errorf("universe event age must not be negative")
+
+	case c.Enabled() && c.Interval <= 0:
+		return fmt.Errorf("retention interval must be positive")
+	}
+
+	return nil
+}
+
+// Policy returns the retention policy for the given current time.
+func (c *CliConfig) Policy(now time.Time) Policy {
+	return Policy{
+		SpentProofsBefore:     cutoff(now, c.SpentProofAge),
+		DeliveredProofsBefore: cutoff(now, c.DeliveredProofAge),
+		UniverseEventsBefore:  cutoff(now, c.UniverseEventAge),
+	}
+}
+
+// cutoff returns the cutoff time for data of the given retention age, or none
+// if the data is kept forever.
+func cutoff(now time.Time, age time.Duration) fn.Option[time.Time] {
+	if age <= 0 {
+		return fn.None[time.Time]()
+	}
+
+	return fn.Some(now.Add(-age))
+}
@@ -0,0 +1,156 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+// PrunerConfig is the configuration of the pruner.
+type PrunerConfig struct {
+	// Store is the store data is pruned from.
+	Store Store
+
+	// Retention is the configured retention policy.
+	Retention *CliConfig
+
+	// Clock is the clock used to compute the cutoff times of the policy.
+	Clock clock.Clock
+}
+
+// Pruner periodically prunes old data from the database according to the
+// configured retention policy. Pruning can also be triggered on demand.
+type Pruner struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg PrunerConfig
+
+	// pruneMtx ensures that only a single prune runs at a time.
+	pruneMtx sync.Mutex
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewPruner creates a new pruner.
+func NewPruner(cfg PrunerConfig) *Pruner {
+	return &Pruner{
+		cfg: cfg,
+		ContextGuard: &fn.ContextGuard{
+			Quit: make(chan struct{}),
+		},
+	}
+}
+
+// Start starts the periodic pruning, if any data is configured to be pruned.
+func (p *Pruner) Start() error {
+	p.startOnce.Do(func() {
+		if !p.cfg.Retention.Enabled() {
+			log.Infof("No retention policy configured, not " +
+				"pruning data")
+			return
+		}
+
+		log.Infof("Starting pruner (interval=%v)",
+			p.cfg.Retention.Interval)
+
+		p.Wg.Add(1)
+		go p.pruneLoop()
+	})
+
+	return nil
+}
+
+// Stop stops the periodic pruning.
+func (p *Pruner) Stop() error {
+	p.stopOnce.Do(func() {
+		log.Info("Stopping pruner")
+
+		close(p.Quit)
+		p.Wg.Wait()
+	})
+
+	return nil
+}
+
+// Compact prunes all data according to the configured retention policy. The
+// given ages override the configured retention age of their category if they
+// are set, which allows data to be pruned on demand even if it is otherwise
+// kept forever.
+func (p *Pruner) Compact(ctx context.Context, spentProofAge,
+	deliveredProofAge, universeEventAge fn.Option[time.Duration]) (*Result,
+	error) {
+
+	ages := *p.cfg.Retention
+	ages.SpentProofAge = spentProofAge.UnwrapOr(ages.SpentProofAge)
+	ages.DeliveredProofAge = deliveredProofAge.UnwrapOr(
+		ages.DeliveredProofAge,
+	)
+	ages.UniverseEventAge = universeEventAge.UnwrapOr(
+		ages.UniverseEventAge,
+	)
+	if err := ages.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p.prune(ctx, ages.Policy(p.cfg.Clock.Now()))
+}
+
+// prune prunes all data selected by the given policy.
+func (p *Pruner) prune(ctx context.Context, policy Policy) (*Result, error) {
+	p.pruneMtx.Lock()
+	defer p.pruneMtx.Unlock()
+
+	if policy.IsEmpty() {
+		return &Result{}, nil
+	}
+
+	result, err := p.cfg.Store.Prune(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prune data: %w", err)
+	}
+
+	log.Infof("Pruned %d spent proofs, %d delivered proofs, %d proof "+
+		"courier log entries and %d universe events, reclaiming %d "+
+		"bytes", result.SpentProofs.Rows, result.DeliveredProofs.Rows,
+		result.TransferLogEntries.Rows, result.UniverseEvents.Rows,
+		result.ReclaimedBytes())
+
+	return result, nil
+}
+
+// pruneLoop prunes data at the configured interval until the pruner is
+// stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *Pruner) pruneLoop() {
+	defer p.Wg.Done()
+
+	ticker := time.NewTicker(p.cfg.Retention.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := p.WithCtxQuitNoTimeout()
+			_, err := p.prune(
+				ctx, p.cfg.Retention.Policy(p.cfg.Clock.Now()),
+			)
+			cancel()
+
+			if err != nil {
+				log.Errorf("Unable to apply retention "+
+					"policy: %v", err)
+			}
+
+		case <-p.Quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is a store that records the policies it is asked to prune.
+type mockStore struct {
+	policies []Policy
+}
+
+// Prune records the given policy.
+func (m *mockStore) Prune(_ context.Context, policy Policy) (*Result,
+	error) {
+
+	m.policies = append(m.policies, policy)
+
+	return &Result{
+		SpentProofs: Pruned{
+			Rows:  1,
+			Bytes: 10,
+		},
+		DeliveredProofs: Pruned{
+			Rows:  2,
+			Bytes: 20,
+		},
+	}, nil
+}
+
+// TestPrunerCompact tests that compacting uses the configured retention ages
+// unless they are overridden.
+func TestPrunerCompact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	store := &mockStore{}
+	pruner := NewPruner(PrunerConfig{
+		Store: store,
+		Retention: &CliConfig{
+			SpentProofAge: time.Hour,
+			Interval:      DefaultInterval,
+		},
+		Clock: clock.NewTestClock(now),
+	})
+
+	none := fn.None[time.Duration]()
+	result, err := pruner.Compact(ctx, none, none, none)
+	require.NoError(t, err)
+	require.EqualValues(t, 30, result.ReclaimedBytes())
+	require.Equal(t, []Policy{{
+		SpentProofsBefore: fn.Some(now.Add(-time.Hour)),
+	}}, store.policies)
+
+	// Overriding an age also prunes data that is otherwise kept forever.
+	result, err = pruner.Compact(
+		ctx, fn.Some(2*time.Hour), none, fn.Some(time.Minute),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, Policy{
+		SpentProofsBefore:    fn.Some(now.Add(-2 * time.Hour)),
+		UniverseEventsBefore: fn.Some(now.Add(-time.Minute)),
+	}, store.policies[1])
+
+	// Negative ages are rejected.
+	_, err = pruner.Compact(ctx, none, fn.Some(-time.Minute), none)
+	require.Error(t, err)
+
+	// A policy that doesn't prune anything doesn't hit the store.
+	pruner.cfg.Retention.SpentProofAge = 0
+	result, err = pruner.Compact(ctx, none, none, none)
+	require.NoError(t, err)
+	require.Equal(t, &Result{}, result)
+	require.Len(t, store.policies, 2)
+}
@@ -0,0 +1,76 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+)
+
+// Policy describes which data is pruned from the database. Each category of
+// data is only pruned if a cutoff time is set for it, in which case all data
+// of that category older than the cutoff is pruned.
+type Policy struct {
+	// SpentProofsBefore is the cutoff for the proofs of assets that were
+	// spent in a transfer. Once an asset is spent, its proof is part of
+	// the proof file of every output of the spending transfer, so the
+	// stand-alone copy is no longer needed.
+	SpentProofsBefore fn.Option[time.Time]
+
+	// DeliveredProofsBefore is the cutoff for the proof suffixes of
+	// confirmed transfer outputs that were delivered to their recipient
+	// by the proof courier, and for the proof courier delivery log.
+	DeliveredProofsBefore fn.Option[time.Time]
+
+	// UniverseEventsBefore is the cutoff for the universe sync and proof
+	// insertion events that universe statistics are derived from.
+	UniverseEventsBefore fn.Option[time.Time]
+}
+
+// IsEmpty returns true if the policy doesn't prune any data.
+func (p Policy) IsEmpty() bool {
+	return p.SpentProofsBefore.IsNone() &&
+		p.DeliveredProofsBefore.IsNone() &&
+		p.UniverseEventsBefore.IsNone()
+}
+
+// Pruned is the amount of data that was pruned from a single category.
+type Pruned struct {
+	// Rows is the number of pruned database rows.
+	Rows int64
+
+	// Bytes is the number of bytes of proof data that were pruned. The
+	// space is reclaimed by the database, but the size of the database
+	// file on disk might only shrink once the database is vacuumed.
+	Bytes int64
+}
+
+// Result is the amount of data that was pruned from the database.
+type Result struct {
+	// SpentProofs is the amount of pruned proofs of spent assets.
+	SpentProofs Pruned
+
+	// DeliveredProofs is the amount of pruned proof suffixes of delivered
+	// transfer outputs.
+	DeliveredProofs Pruned
+
+	// TransferLogEntries is the amount of pruned proof courier delivery
+	// log entries.
+	TransferLogEntries Pruned
+
+	// UniverseEvents is the amount of pruned universe events.
+	UniverseEvents Pruned
+}
+
+// ReclaimedBytes returns the total number of bytes of pruned proof data.
+func (r *Result) ReclaimedBytes() int64 {
+	return r.SpentProofs.Bytes + r.DeliveredProofs.Bytes +
+		r.TransferLogEntries.Bytes + r.UniverseEvents.Bytes
+}
+
+// Store is the interface of the persistent storage that data is pruned from.
+type Store interface {
+	// Prune prunes all data selected by the given policy in a single
+	// database transaction and returns the amount of pruned data.
+	Prune(ctx context.Context, policy Policy) (*Result, error)
+}
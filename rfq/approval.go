@@ -0,0 +1,194 @@
+package rfq
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// DefaultApprovalHookTimeout is the default maximum time the external
+	// quote approval hook can take to answer. Quotes the hook doesn't
+	// answer in time are rejected.
+	DefaultApprovalHookTimeout = 10 * time.Second
+
+	// maxApprovalResponseSize is the maximum size of the response body of
+	// the approval hook we read.
+	maxApprovalResponseSize = 64 * 1024
+)
+
+// QuoteApprovalRequest describes an incoming quote request our node is about
+// to accept, with the rate our price oracle proposed.
+type QuoteApprovalRequest struct {
+	// Peer is the peer that requested the quote.
+	Peer route.Vertex
+
+	// ID is the ID of the quote request.
+	ID rfqmsg.ID
+
+	// IsBuy is true if the peer wants to buy the asset from us, and false
+	// if it wants to sell the asset to us.
+	IsBuy bool
+
+	// AssetID is the ID of the requested asset, if it was specified by
+	// its ID.
+	AssetID []byte
+
+	// AssetGroupKey is the group key of the requested asset, if it was
+	// specified by its group.
+	AssetGroupKey []byte
+
+	// AssetMaxAmt is the maximum number of asset units the peer wants to
+	// buy. It is only set for buy requests.
+	AssetMaxAmt uint64
+
+	// PaymentMaxAmt is the maximum amount the peer wants to be paid for
+	// the asset units it sells. It is only set for sell requests.
+	PaymentMaxAmt lnwire.MilliSatoshi
+
+	// AssetRate is the rate our price oracle proposed for the quote.
+	AssetRate rfqmsg.AssetRate
+}
+
+// QuoteApproval is the decision of a quote approver.
+type QuoteApproval struct {
+	// Approved is true if the quote may be accepted.
+	Approved bool
+
+	// Reason is an optional human-readable reason for the decision. It is
+	// only logged and never sent to the peer.
+	Reason string
+}
+
+// QuoteApprover is an external approval hook that is consulted before an
+// incoming quote request is accepted. This lets market makers plug in their
+// own pricing or risk engine.
+type QuoteApprover interface {
+	// ApproveQuote decides whether the described quote may be accepted.
+	ApproveQuote(ctx context.Context,
+		req QuoteApprovalRequest) (*QuoteApproval, error)
+}
+
+// approvalPayload is the JSON representation of a quote approval request
+// that is sent to the approval webhook.
+type approvalPayload struct {
+	Peer           string `json:"peer"`
+	QuoteID        string `json:"quote_id"`
+	Direction      string `json:"direction"`
+	AssetID        string `json:"asset_id,omitempty"`
+	AssetGroupKey  string `json:"asset_group_key,omitempty"`
+	AssetMaxAmt    uint64 `json:"asset_max_amount,omitempty"`
+	PaymentMaxMsat uint64 `json:"payment_max_msat,omitempty"`
+	Coefficient    string `json:"rate_coefficient"`
+	Scale          uint8  `json:"rate_scale"`
+	Expiry         int64  `json:"rate_expiry"`
+}
+
+// approvalResponse is the JSON response the approval webhook answers with.
+type approvalResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// WebhookQuoteApprover is a quote approver that sends the quote as a JSON
+// encoded HTTP POST request to a webhook URL, which answers with a JSON object
+// containing the decision.
+type WebhookQuoteApprover struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookQuoteApprover creates a new quote approver that posts quotes to the
+// given URL. Requests that aren't answered within the given timeout fail.
+func NewWebhookQuoteApprover(url string,
+	timeout time.Duration) *WebhookQuoteApprover {
+
+	return &WebhookQuoteApprover{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// ApproveQuote decides whether the described quote may be accepted.
+//
+// NOTE: This is part of the QuoteApprover interface.
+func (w *WebhookQuoteApprover) ApproveQuote(ctx context.Context,
+	req QuoteApprovalRequest) (*QuoteApproval, error) {
+
+	direction := "sell"
+	if req.IsBuy {
+		direction = "buy"
+	}
+
+	payload, err := json.Marshal(approvalPayload{
+		Peer:           req.Peer.String(),
+		QuoteID:        req.ID.String(),
+		Direction:      direction,
+		AssetID:        hex.EncodeToString(req.AssetID),
+		AssetGroupKey:  hex.EncodeToString(req.AssetGroupKey),
+		AssetMaxAmt:    req.AssetMaxAmt,
+		PaymentMaxMsat: uint64(req.PaymentMaxAmt),
+		Coefficient:    req.AssetRate.Rate.Coefficient.String(),
+		Scale:          req.AssetRate.Rate.Scale,
+		Expiry:         req.AssetRate.Expiry.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode approval request: %w",
+			err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, w.url, bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create approval request: %w",
+			err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send approval request: %w",
+			err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(
+		resp.Body, maxApprovalResponseSize,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read approval response: %w",
+			err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("approval hook returned status %s",
+			resp.Status)
+	}
+
+	var decision approvalResponse
+	if err := json.Unmarshal(body, &decision); err != nil {
+		return nil, fmt.Errorf("unable to decode approval response: "+
+			"%w", err)
+	}
+
+	return &QuoteApproval{
+		Approved: decision.Approved,
+		Reason:   decision.Reason,
+	}, nil
+}
+
+// A compile-time assertion to ensure WebhookQuoteApprover meets the
+// QuoteApprover interface.
+var _ QuoteApprover = (*WebhookQuoteApprover)(nil)
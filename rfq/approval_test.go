@@ -0,0 +1,191 @@
+package rfq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhookQuoteApprover tests that the webhook quote approver sends the
+// quote to the webhook and returns its decision.
+func TestWebhookQuoteApprover(t *testing.T) {
+	t.Parallel()
+
+	var received approvalPayload
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			err := json.NewDecoder(r.Body).Decode(&received)
+			require.NoError(t, err)
+
+			switch received.AssetMaxAmt {
+			case 1:
+				_, _ = fmt.Fprint(w, `{"approved":true}`)
+
+			case 2:
+				_, _ = fmt.Fprint(
+					w, `{"approved":false,"reason":"risk"}`,
+				)
+
+			case 3:
+				w.WriteHeader(http.StatusInternalServerError)
+
+			case 4:
+				_, _ = fmt.Fprint(w, `not json`)
+
+			case 5:
+				time.Sleep(200 * time.Millisecond)
+			}
+		},
+	))
+	defer server.Close()
+
+	approver := NewWebhookQuoteApprover(
+		server.URL, 100*time.Millisecond,
+	)
+	req := QuoteApprovalRequest{
+		Peer:    route.Vertex{1, 2, 3},
+		IsBuy:   true,
+		AssetID: []byte{4, 5, 6},
+		AssetRate: rfqmsg.NewAssetRate(
+			rfqmath.NewBigIntFixedPoint(42_000, 3),
+			time.Unix(1_700_000_000, 0),
+		),
+	}
+	approve := func(assetMaxAmt uint64) (*QuoteApproval, error) {
+		req.AssetMaxAmt = assetMaxAmt
+		return approver.ApproveQuote(context.Background(), req)
+	}
+
+	approval, err := approve(1)
+	require.NoError(t, err)
+	require.True(t, approval.Approved)
+	require.Equal(t, req.Peer.String(), received.Peer)
+	require.Equal(t, "buy", received.Direction)
+	require.Equal(t, "040506", received.AssetID)
+	require.Empty(t, received.AssetGroupKey)
+	require.Equal(t, "42000", received.Coefficient)
+	require.Equal(t, uint8(3), received.Scale)
+	require.Equal(t, int64(1_700_000_000), received.Expiry)
+
+	approval, err = approve(2)
+	require.NoError(t, err)
+	require.False(t, approval.Approved)
+	require.Equal(t, "risk", approval.Reason)
+
+	_, err = approve(3)
+	require.ErrorContains(t, err, "500")
+
+	_, err = approve(4)
+	require.ErrorContains(t, err, "unable to decode approval response")
+
+	_, err = approve(5)
+	require.ErrorContains(t, err, "unable to send approval request")
+}
+
+// mockQuoteApprover is a quote approver that returns a fixed decision.
+type mockQuoteApprover struct {
+	approval *QuoteApproval
+	err      error
+	requests chan QuoteApprovalRequest
+}
+
+// ApproveQuote decides whether the described quote may be accepted.
+func (m *mockQuoteApprover) ApproveQuote(_ context.Context,
+	req QuoteApprovalRequest) (*QuoteApproval, error) {
+
+	m.requests <- req
+
+	return m.approval, m.err
+}
+
+// TestNegotiatorQuoteApproval tests that the negotiator only accepts incoming
+// quote requests the quote approver approves.
+func TestNegotiatorQuoteApproval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		approval *QuoteApproval
+		err      error
+		accepted bool
+	}{{
+		name:     "approved",
+		approval: &QuoteApproval{Approved: true},
+		accepted: true,
+	}, {
+		name:     "rejected",
+		approval: &QuoteApproval{Approved: false},
+	}, {
+		name: "hook failure",
+		err:  fmt.Errorf("hook unavailable"),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			approver := &mockQuoteApprover{
+				approval: tc.approval,
+				err:      tc.err,
+				requests: make(chan QuoteApprovalRequest, 2),
+			}
+			oracle := NewMockPriceOracle(3600, 100_000)
+			outgoing := make(chan rfqmsg.OutgoingMsg, 2)
+			negotiator, err := NewNegotiator(NegotiatorCfg{
+				PriceOracle:      oracle,
+				OutgoingMessages: outgoing,
+				QuoteApprover:    approver,
+				ErrChan:          make(chan error, 2),
+			})
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, negotiator.Stop())
+			}()
+
+			specifier := asset.NewSpecifierFromId(asset.ID{1})
+			peer := route.Vertex{1}
+
+			buyReq, err := rfqmsg.NewBuyRequest(
+				peer, specifier, 1_000,
+				fn.None[rfqmsg.AssetRate](),
+			)
+			require.NoError(t, err)
+			err = negotiator.HandleIncomingBuyRequest(*buyReq)
+			require.NoError(t, err)
+
+			approvalReq := <-approver.requests
+			require.True(t, approvalReq.IsBuy)
+			require.Equal(t, buyReq.ID, approvalReq.ID)
+			require.Equal(t, uint64(1_000), approvalReq.AssetMaxAmt)
+
+			msg := <-outgoing
+			_, isAccept := msg.(*rfqmsg.BuyAccept)
+			require.Equal(t, tc.accepted, isAccept)
+
+			sellReq, err := rfqmsg.NewSellRequest(
+				peer, specifier, 5_000,
+				fn.None[rfqmsg.AssetRate](),
+			)
+			require.NoError(t, err)
+			err = negotiator.HandleIncomingSellRequest(*sellReq)
+			require.NoError(t, err)
+
+			approvalReq = <-approver.requests
+			require.False(t, approvalReq.IsBuy)
+			require.EqualValues(t, 5_000, approvalReq.PaymentMaxAmt)
+
+			msg = <-outgoing
+			_, isAccept = msg.(*rfqmsg.SellAccept)
+			require.Equal(t, tc.accepted, isAccept)
+		})
+	}
+}
@@ -2,6 +2,10 @@ package rfq
 
 import (
 	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
 )
 
 const (
@@ -26,6 +30,20 @@ type CliConfig struct {
 
 	SkipAcceptQuotePriceCheck bool `long:"skipacceptquotepricecheck" description:"Accept any price quote returned by RFQ peer, skipping price validation"`
 
+	PriceCacheMaxStaleness time.Duration `long:"pricecachemaxstaleness" description:"The maximum age of a cached price oracle rate that can still be used to quote small amounts; set to 0 to always query the price oracle"`
+
+	PriceCacheMaxAmtMsat uint64 `long:"pricecachemaxamtmsat" description:"The maximum value in milli-satoshi of a quote that can use a cached price oracle rate; larger quotes always query the price oracle for a fresh rate"`
+
+	MinPeerReputation uint8 `long:"minpeerreputation" description:"The minimum reputation score (0-100) a peer needs to be selected automatically for quotes; peers below are only used if specified explicitly. Set to 0 to disable"`
+
+	PreferReputablePeers bool `long:"preferreputablepeers" description:"If multiple peers can provide a quote and none is specified, select the peer with the highest reputation score instead of requiring the peer to be specified"`
+
+	ApprovalHookURL string `long:"approvalhookurl" description:"The HTTP(S) URL of an external approval hook that is consulted before an incoming quote request is accepted; the quote is sent as a JSON encoded POST request and the hook must answer with a JSON object containing an 'approved' field. Quotes are rejected if the hook fails"`
+
+	ApprovalHookTimeout time.Duration `long:"approvalhooktimeout" description:"The maximum time the approval hook can take to answer, after which the quote is rejected"`
+
+	InventoryTargets []string `long:"inventorytarget" description:"The number of units of an asset the node aims to hold in its asset channels, in the format <asset_id>:<units>; the projected balance of the asset is compared against it in the inventory report. Can be specified multiple times"`
+
 	MockOracleAssetsPerBTC uint64 `long:"mockoracleassetsperbtc" description:"Mock price oracle static asset units per BTC rate (for example number of USD cents per BTC if one asset unit represents a USD cent); whole numbers only, use either this or mockoraclesatsperasset depending on required precision"`
 
 	// TODO(ffranr): Remove in favour of MockOracleAssetsPerBTC.
@@ -80,6 +98,36 @@ func (c *CliConfig) Validate() error {
 			MinAssetsPerBTC)
 	}
 
+	if c.MinPeerReputation > MaxReputationScore {
+		return fmt.Errorf("minpeerreputation must not exceed %d",
+			MaxReputationScore)
+	}
+
+	if _, err := c.ParseInventoryTargets(); err != nil {
+		return err
+	}
+
+	if c.ApprovalHookURL != "" {
+		hookURL, err := url.Parse(c.ApprovalHookURL)
+		if err != nil {
+			return fmt.Errorf("invalid approval hook URL: %w", err)
+		}
+
+		if hookURL.Scheme != "http" && hookURL.Scheme != "https" {
+			return fmt.Errorf("approval hook URL must use http or " +
+				"https")
+		}
+
+		if c.ApprovalHookTimeout <= 0 {
+			return fmt.Errorf("approvalhooktimeout must be positive")
+		}
+	}
+
+	if c.PriceCacheMaxStaleness < 0 {
+		return fmt.Errorf("pricecachemaxstaleness must not be " +
+			"negative")
+	}
+
 	// Ensure that if the price oracle address not the mock price oracle
 	// service address then it must be a valid gRPC address.
 	if c.PriceOracleAddress != "" &&
@@ -94,3 +142,25 @@ func (c *CliConfig) Validate() error {
 
 	return nil
 }
+
+// ParseInventoryTargets parses the configured inventory targets.
+func (c *CliConfig) ParseInventoryTargets() ([]InventoryTarget, error) {
+	targets := make([]InventoryTarget, 0, len(c.InventoryTargets))
+	seen := make(map[asset.ID]struct{}, len(c.InventoryTargets))
+	for _, targetStr := range c.InventoryTargets {
+		target, err := ParseInventoryTarget(targetStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[target.AssetID]; ok {
+			return nil, fmt.Errorf("duplicate inventory target "+
+				"for asset %v", target.AssetID)
+		}
+		seen[target.AssetID] = struct{}{}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,307 @@
+package rfq
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+)
+
+// InventoryTarget is the number of asset units an edge node aims to hold in
+// its asset channels. The inventory report compares the projected balance of
+// the asset against it.
+type InventoryTarget struct {
+	// AssetID is the asset the target applies to.
+	AssetID asset.ID
+
+	// Units is the targeted number of asset units.
+	Units uint64
+}
+
+// ParseInventoryTarget parses an inventory target in the format
+// <asset_id>:<units>.
+func ParseInventoryTarget(target string) (InventoryTarget, error) {
+	parts := strings.Split(target, ":")
+	if len(parts) != 2 {
+		return InventoryTarget{}, fmt.Errorf("invalid inventory "+
+			"target %q, expected format <asset_id>:<units>", target)
+	}
+
+	assetIDBytes, err := hex.DecodeString(parts[0])
+	if err != nil || len(assetIDBytes) != len(asset.ID{}) {
+		return InventoryTarget{}, fmt.Errorf("invalid asset ID in "+
+			"inventory target %q", target)
+	}
+
+	units, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return InventoryTarget{}, fmt.Errorf("invalid units in "+
+			"inventory target %q: %w", target, err)
+	}
+
+	var assetID asset.ID
+	copy(assetID[:], assetIDBytes)
+
+	return InventoryTarget{
+		AssetID: assetID,
+		Units:   units,
+	}, nil
+}
+
+// InFlightAssets is the asset amount of an HTLC that was accepted under an RFQ
+// policy and is still waiting for its final outcome.
+type InFlightAssets struct {
+	// AssetSpecifier is the asset the HTLC carries, as specified by the
+	// quote it was accepted under.
+	AssetSpecifier asset.Specifier
+
+	// Units is the number of asset units of the HTLC.
+	Units uint64
+
+	// Outgoing is true if we sell the assets, so they leave our node once
+	// the HTLC settles. Otherwise, we buy them.
+	Outgoing bool
+}
+
+// AssetInventory is the current exposure of our node in a single asset. All
+// amounts are in asset units.
+type AssetInventory struct {
+	// AssetSpecifier is the asset the exposure is reported for. Assets of
+	// quotes that only specify a group key are reported under that group
+	// key.
+	AssetSpecifier asset.Specifier
+
+	// LocalBalance is the sum of our local balances in the active asset
+	// channels.
+	LocalBalance uint64
+
+	// RemoteBalance is the sum of the remote balances in the active asset
+	// channels.
+	RemoteBalance uint64
+
+	// OpenSellUnits is the maximum number of units we committed to sell
+	// under the quotes we accepted and that didn't expire yet.
+	OpenSellUnits uint64
+
+	// OpenBuyUnits is the maximum number of units we committed to buy
+	// under the quotes we accepted and that didn't expire yet.
+	OpenBuyUnits uint64
+
+	// InFlightOutgoingUnits is the number of units we sell in HTLCs that
+	// are still in flight. These units are no longer part of the local
+	// balance.
+	InFlightOutgoingUnits uint64
+
+	// InFlightIncomingUnits is the number of units we buy in HTLCs that
+	// are still in flight. These units aren't part of the local balance
+	// yet.
+	InFlightIncomingUnits uint64
+
+	// Target is the configured inventory target of the asset, if any.
+	Target fn.Option[uint64]
+}
+
+// ProjectedBalance returns the local balance we end up with if all in-flight
+// HTLCs settle and all open quotes are used up to their maximum amount.
+func (i *AssetInventory) ProjectedBalance() int64 {
+	projected := saturatingInt64(i.LocalBalance)
+	projected = saturatingAdd(
+		projected, saturatingInt64(i.InFlightIncomingUnits),
+	)
+	projected = saturatingAdd(projected, saturatingInt64(i.OpenBuyUnits))
+
+	return saturatingAdd(projected, -saturatingInt64(i.OpenSellUnits))
+}
+
+// TargetDeviation returns by how many units the projected balance exceeds (if
+// positive) or falls short of (if negative) the inventory target. None is
+// returned if there is no target for the asset.
+func (i *AssetInventory) TargetDeviation() fn.Option[int64] {
+	return fn.MapOption(func(target uint64) int64 {
+		return saturatingAdd(
+			i.ProjectedBalance(), -saturatingInt64(target),
+		)
+	})(i.Target)
+}
+
+// saturatingInt64 converts the given value to an int64, capping it at the
+// maximum int64 value.
+func saturatingInt64(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return int64(v)
+}
+
+// saturatingAdd adds the given values, capping the result at the int64 range.
+func saturatingAdd(a, b int64) int64 {
+	switch {
+	case b > 0 && a > math.MaxInt64-b:
+		return math.MaxInt64
+
+	case b < 0 && a < math.MinInt64-b:
+		return math.MinInt64
+
+	default:
+		return a + b
+	}
+}
+
+// saturatingSum adds the given values, capping the result at the maximum
+// uint64 value.
+func saturatingSum(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+
+	return a + b
+}
+
+// inventoryKey returns the key the exposure of the given asset is collected
+// under. Assets are identified by their ID if it is known, otherwise by their
+// group key.
+func inventoryKey(specifier asset.Specifier) string {
+	assetID, groupKey := specifier.AsBytes()
+	if len(assetID) > 0 {
+		return hex.EncodeToString(assetID)
+	}
+
+	return hex.EncodeToString(groupKey)
+}
+
+// inventoryBuilder collects the exposure of our node per asset.
+type inventoryBuilder struct {
+	inventory map[string]*AssetInventory
+}
+
+// entry returns the exposure of the given asset, creating it if it doesn't
+// exist yet.
+func (b *inventoryBuilder) entry(specifier asset.Specifier) *AssetInventory {
+	key := inventoryKey(specifier)
+	if entry, ok := b.inventory[key]; ok {
+		return entry
+	}
+
+	// If both the ID and the group key are known, we only keep the ID,
+	// so the entry matches the one of the channel balances.
+	if id := specifier.UnwrapIdToPtr(); id != nil {
+		specifier = asset.NewSpecifierFromId(*id)
+	}
+
+	entry := &AssetInventory{
+		AssetSpecifier: specifier,
+	}
+	b.inventory[key] = entry
+
+	return entry
+}
+
+// buildInventoryReport summarizes the exposure of our node per asset from the
+// given channels, locally accepted quotes and in-flight HTLCs, and compares it
+// against the given inventory targets. The result is ordered by asset.
+func buildInventoryReport(channels []lndclient.ChannelInfo,
+	buyAccepts BuyAcceptMap, sellAccepts SellAcceptMap,
+	inFlight []InFlightAssets,
+	targets []InventoryTarget) ([]AssetInventory, error) {
+
+	b := &inventoryBuilder{
+		inventory: make(map[string]*AssetInventory),
+	}
+
+	for _, target := range targets {
+		entry := b.entry(asset.NewSpecifierFromId(target.AssetID))
+		entry.Target = fn.Some(target.Units)
+	}
+
+	for _, channel := range channels {
+		if len(channel.CustomChannelData) == 0 {
+			continue
+		}
+
+		var assetData rfqmsg.JsonAssetChannel
+		err := json.Unmarshal(channel.CustomChannelData, &assetData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal asset "+
+				"data of channel %d: %w", channel.ChannelID,
+				err)
+		}
+
+		for _, chanAsset := range assetData.Assets {
+			assetIDStr := chanAsset.AssetInfo.AssetGenesis.AssetID
+			assetIDBytes, err := hex.DecodeString(assetIDStr)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding asset "+
+					"ID: %w", err)
+			}
+
+			var assetID asset.ID
+			copy(assetID[:], assetIDBytes)
+
+			entry := b.entry(asset.NewSpecifierFromId(assetID))
+			entry.LocalBalance = saturatingSum(
+				entry.LocalBalance, chanAsset.LocalBalance,
+			)
+			entry.RemoteBalance = saturatingSum(
+				entry.RemoteBalance, chanAsset.RemoteBalance,
+			)
+		}
+	}
+
+	// A locally accepted buy quote means that the peer buys assets from
+	// us, so we committed to sell up to the maximum asset amount.
+	for _, accept := range buyAccepts {
+		entry := b.entry(accept.Request.AssetSpecifier)
+		entry.OpenSellUnits = saturatingSum(
+			entry.OpenSellUnits, accept.Request.AssetMaxAmt,
+		)
+	}
+
+	// A locally accepted sell quote means that the peer sells assets to
+	// us, up to the maximum payment amount at the quoted rate.
+	for _, accept := range sellAccepts {
+		units := rfqmath.MilliSatoshiToUnits(
+			accept.Request.PaymentMaxAmt, accept.AssetRate.Rate,
+		)
+
+		entry := b.entry(accept.Request.AssetSpecifier)
+		entry.OpenBuyUnits = saturatingSum(
+			entry.OpenBuyUnits, units.ScaleTo(0).ToUint64(),
+		)
+	}
+
+	for _, htlc := range inFlight {
+		entry := b.entry(htlc.AssetSpecifier)
+		if htlc.Outgoing {
+			entry.InFlightOutgoingUnits = saturatingSum(
+				entry.InFlightOutgoingUnits, htlc.Units,
+			)
+		} else {
+			entry.InFlightIncomingUnits = saturatingSum(
+				entry.InFlightIncomingUnits, htlc.Units,
+			)
+		}
+	}
+
+	keys := make([]string, 0, len(b.inventory))
+	for key := range b.inventory {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := make([]AssetInventory, 0, len(keys))
+	for _, key := range keys {
+		report = append(report, *b.inventory[key])
+	}
+
+	return report, nil
+}
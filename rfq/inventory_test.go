@@ -0,0 +1,161 @@
+package rfq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseInventoryTarget tests the parsing of inventory targets.
+func TestParseInventoryTarget(t *testing.T) {
+	t.Parallel()
+
+	assetID := asset.ID{1, 2, 3}
+	target, err := ParseInventoryTarget(assetID.String() + ":5000")
+	require.NoError(t, err)
+	require.Equal(t, InventoryTarget{
+		AssetID: assetID,
+		Units:   5000,
+	}, target)
+
+	invalidTargets := []string{
+		"", assetID.String(), "0102:5000", assetID.String() + ":-1",
+		assetID.String() + ":1:2",
+	}
+	for _, invalid := range invalidTargets {
+		_, err := ParseInventoryTarget(invalid)
+		require.Error(t, err, invalid)
+	}
+
+	// The same asset can't have multiple targets.
+	cfg := &CliConfig{
+		InventoryTargets: []string{
+			assetID.String() + ":1", assetID.String() + ":2",
+		},
+	}
+	_, err = cfg.ParseInventoryTargets()
+	require.ErrorContains(t, err, "duplicate inventory target")
+}
+
+// TestBuildInventoryReport tests that the exposure per asset is collected
+// from the channels, quotes and in-flight HTLCs.
+func TestBuildInventoryReport(t *testing.T) {
+	t.Parallel()
+
+	idA := asset.ID{0xaa}
+	idB := asset.ID{0xbb}
+	groupKey := test.RandPubKey(t)
+
+	// Our node has two channels with asset A and no channel with asset B.
+	channelData := func(localBalance, remoteBalance uint64) []byte {
+		var chanAsset rfqmsg.JsonAssetChanInfo
+		chanAsset.AssetInfo.AssetGenesis.AssetID = idA.String()
+		chanAsset.LocalBalance = localBalance
+		chanAsset.RemoteBalance = remoteBalance
+
+		data, err := json.Marshal(rfqmsg.JsonAssetChannel{
+			Assets: []rfqmsg.JsonAssetChanInfo{chanAsset},
+		})
+		require.NoError(t, err)
+
+		return data
+	}
+	channels := []lndclient.ChannelInfo{{
+		ChannelID:         1,
+		CustomChannelData: channelData(1_000, 200),
+	}, {
+		ChannelID:         2,
+		CustomChannelData: channelData(500, 300),
+	}, {
+		// A plain BTC channel is ignored.
+		ChannelID: 3,
+	}}
+
+	// A peer may buy up to 300 units of asset A from us.
+	expiry := time.Now().Add(time.Hour)
+	buyAccepts := BuyAcceptMap{
+		SerialisedScid(1): {
+			Request: rfqmsg.BuyRequest{
+				AssetSpecifier: asset.NewSpecifierFromId(idA),
+				AssetMaxAmt:    300,
+			},
+		},
+	}
+
+	// A peer may sell us assets of a group for up to 0.001 BTC at a rate
+	// of 100k units per BTC, so up to 100 units.
+	sellAccepts := SellAcceptMap{
+		SerialisedScid(2): {
+			Request: rfqmsg.SellRequest{
+				AssetSpecifier: asset.NewSpecifierFromGroupKey(
+					*groupKey,
+				),
+				PaymentMaxAmt: 100_000_000,
+			},
+			AssetRate: rfqmsg.NewAssetRate(
+				rfqmath.NewBigIntFixedPoint(100_000, 0), expiry,
+			),
+		},
+	}
+
+	inFlight := []InFlightAssets{{
+		AssetSpecifier: asset.NewSpecifierFromId(idA),
+		Units:          50,
+		Outgoing:       true,
+	}, {
+		AssetSpecifier: asset.NewSpecifierFromId(idA),
+		Units:          20,
+	}}
+
+	targets := []InventoryTarget{{
+		AssetID: idA,
+		Units:   1_000,
+	}, {
+		AssetID: idB,
+		Units:   10,
+	}}
+
+	report, err := buildInventoryReport(
+		channels, buyAccepts, sellAccepts, inFlight, targets,
+	)
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+
+	// The group key entry comes first, as it starts with 0x02 or 0x03.
+	group := report[0]
+	require.Equal(
+		t, asset.NewSpecifierFromGroupKey(*groupKey),
+		group.AssetSpecifier,
+	)
+	require.EqualValues(t, 100, group.OpenBuyUnits)
+	require.EqualValues(t, 100, group.ProjectedBalance())
+	require.True(t, group.TargetDeviation().IsNone())
+
+	// Asset A has its channel balances, quotes and in-flight HTLCs.
+	// The projected balance is 1500 + 20 - 300 = 1220.
+	assetA := report[1]
+	require.Equal(t, AssetInventory{
+		AssetSpecifier:        asset.NewSpecifierFromId(idA),
+		LocalBalance:          1_500,
+		RemoteBalance:         500,
+		OpenSellUnits:         300,
+		InFlightOutgoingUnits: 50,
+		InFlightIncomingUnits: 20,
+		Target:                fn.Some[uint64](1_000),
+	}, assetA)
+	require.EqualValues(t, 1_220, assetA.ProjectedBalance())
+	require.Equal(t, fn.Some[int64](220), assetA.TargetDeviation())
+
+	// Asset B only has a target, so we fall short of it.
+	assetB := report[2]
+	require.Equal(t, asset.NewSpecifierFromId(idB), assetB.AssetSpecifier)
+	require.Equal(t, fn.Some[int64](-10), assetB.TargetDeviation())
+}
@@ -0,0 +1,183 @@
+package rfq
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// AssetPaymentLimits are the maximum amounts of an asset our node can
+// currently receive through an invoice and pay out through its active asset
+// channels.
+type AssetPaymentLimits struct {
+	// MaxReceivableUnits is the sum of the remote balances in the active
+	// asset channels, which is the maximum number of asset units we can
+	// receive.
+	MaxReceivableUnits uint64
+
+	// MaxPayableUnits is the sum of the local balances in the active asset
+	// channels, which is the maximum number of asset units we can pay.
+	MaxPayableUnits uint64
+
+	// MaxReceivableMsat is the largest invoice amount that can be paid to
+	// us under one of the buy quotes our peers accepted. It is limited by
+	// both the maximum asset amount of the quote and the remote balance of
+	// the channels with the peer. Zero if there is no such quote.
+	MaxReceivableMsat lnwire.MilliSatoshi
+
+	// MaxPayableMsat is the largest invoice amount we can pay under one of
+	// the sell quotes our peers accepted. It is limited by both the
+	// maximum payment amount of the quote and the local balance of the
+	// channels with the peer. Zero if there is no such quote.
+	MaxPayableMsat lnwire.MilliSatoshi
+}
+
+// channelBalances is the local and remote balance of an asset in the channels
+// with a single peer.
+type channelBalances struct {
+	local  uint64
+	remote uint64
+}
+
+// PaymentLimits returns the maximum amounts of the given asset our node can
+// currently receive and pay, based on the balances of the active asset
+// channels and the quotes our peers accepted. If a peer is specified, only the
+// channels with and quotes of that peer are considered.
+func (m *Manager) PaymentLimits(ctx context.Context, assetID asset.ID,
+	peer *route.Vertex) (*AssetPaymentLimits, error) {
+
+	channels, err := m.cfg.ChannelLister.ListChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list channels: %w", err)
+	}
+
+	return buildPaymentLimits(
+		assetID, peer, channels, m.PeerAcceptedBuyQuotes(),
+		m.PeerAcceptedSellQuotes(),
+	)
+}
+
+// buildPaymentLimits computes the maximum amounts of the given asset that can
+// be received and paid from the given channels and the quotes accepted by our
+// peers.
+func buildPaymentLimits(assetID asset.ID, peer *route.Vertex,
+	channels []lndclient.ChannelInfo, buyAccepts BuyAcceptMap,
+	sellAccepts SellAcceptMap) (*AssetPaymentLimits, error) {
+
+	var (
+		limits       AssetPaymentLimits
+		peerBalances = make(map[route.Vertex]*channelBalances)
+	)
+	for _, channel := range channels {
+		if len(channel.CustomChannelData) == 0 {
+			continue
+		}
+
+		if peer != nil && channel.PubKeyBytes != *peer {
+			continue
+		}
+
+		var assetData rfqmsg.JsonAssetChannel
+		err := json.Unmarshal(channel.CustomChannelData, &assetData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal asset "+
+				"data of channel %d: %w", channel.ChannelID,
+				err)
+		}
+
+		for _, chanAsset := range assetData.Assets {
+			assetIDStr := chanAsset.AssetInfo.AssetGenesis.AssetID
+			if assetIDStr != hex.EncodeToString(assetID[:]) {
+				continue
+			}
+
+			balances, ok := peerBalances[channel.PubKeyBytes]
+			if !ok {
+				balances = &channelBalances{}
+				peerBalances[channel.PubKeyBytes] = balances
+			}
+
+			balances.local = saturatingSum(
+				balances.local, chanAsset.LocalBalance,
+			)
+			balances.remote = saturatingSum(
+				balances.remote, chanAsset.RemoteBalance,
+			)
+			limits.MaxPayableUnits = saturatingSum(
+				limits.MaxPayableUnits, chanAsset.LocalBalance,
+			)
+			limits.MaxReceivableUnits = saturatingSum(
+				limits.MaxReceivableUnits,
+				chanAsset.RemoteBalance,
+			)
+		}
+	}
+
+	// An invoice is paid to us under a single buy quote, so the peer that
+	// accepted it sells us at most the quote's maximum asset amount over
+	// the channels we have with it.
+	for _, accept := range buyAccepts {
+		balances, ok := quoteBalances(
+			accept.Peer, accept.Request.AssetSpecifier, assetID,
+			peerBalances,
+		)
+		if !ok {
+			continue
+		}
+
+		units := min(balances.remote, accept.Request.AssetMaxAmt)
+		msat := rfqmath.UnitsToMilliSatoshi(
+			rfqmath.NewBigIntFixedPoint(units, 0),
+			accept.AssetRate.Rate,
+		)
+		limits.MaxReceivableMsat = max(limits.MaxReceivableMsat, msat)
+	}
+
+	// Similarly, we pay an invoice under a single sell quote, so we can
+	// sell at most our local balance with the peer that accepted it, up to
+	// the quote's maximum payment amount.
+	for _, accept := range sellAccepts {
+		balances, ok := quoteBalances(
+			accept.Peer, accept.Request.AssetSpecifier, assetID,
+			peerBalances,
+		)
+		if !ok {
+			continue
+		}
+
+		msat := rfqmath.UnitsToMilliSatoshi(
+			rfqmath.NewBigIntFixedPoint(balances.local, 0),
+			accept.AssetRate.Rate,
+		)
+		msat = min(msat, accept.Request.PaymentMaxAmt)
+		limits.MaxPayableMsat = max(limits.MaxPayableMsat, msat)
+	}
+
+	return &limits, nil
+}
+
+// quoteBalances returns the channel balances with the peer of a quote, if the
+// quote is for the given asset. Quotes that only specify a group key are
+// ignored, as we can't tell which asset of the group they are for.
+func quoteBalances(peer route.Vertex, specifier asset.Specifier,
+	assetID asset.ID,
+	peerBalances map[route.Vertex]*channelBalances) (*channelBalances,
+	bool) {
+
+	quoteID := specifier.UnwrapIdToPtr()
+	if quoteID == nil || *quoteID != assetID {
+		return nil, false
+	}
+
+	balances, ok := peerBalances[peer]
+
+	return balances, ok
+}
@@ -0,0 +1,159 @@
+package rfq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildPaymentLimits tests that the maximum receivable and payable amounts
+// of an asset are derived from the channel balances and the quotes accepted by
+// our peers.
+func TestBuildPaymentLimits(t *testing.T) {
+	t.Parallel()
+
+	idA := asset.ID{0xaa}
+	idB := asset.ID{0xbb}
+	groupKey := test.RandPubKey(t)
+	peer1 := route.Vertex{1}
+	peer2 := route.Vertex{2}
+	peer3 := route.Vertex{3}
+
+	channelData := func(id asset.ID, localBalance,
+		remoteBalance uint64) []byte {
+
+		var chanAsset rfqmsg.JsonAssetChanInfo
+		chanAsset.AssetInfo.AssetGenesis.AssetID = id.String()
+		chanAsset.LocalBalance = localBalance
+		chanAsset.RemoteBalance = remoteBalance
+
+		data, err := json.Marshal(rfqmsg.JsonAssetChannel{
+			Assets: []rfqmsg.JsonAssetChanInfo{chanAsset},
+		})
+		require.NoError(t, err)
+
+		return data
+	}
+	channels := []lndclient.ChannelInfo{{
+		ChannelID:         1,
+		PubKeyBytes:       peer1,
+		CustomChannelData: channelData(idA, 1_000, 200),
+	}, {
+		ChannelID:         2,
+		PubKeyBytes:       peer2,
+		CustomChannelData: channelData(idA, 600, 300),
+	}, {
+		// A channel with another asset is ignored.
+		ChannelID:         3,
+		PubKeyBytes:       peer3,
+		CustomChannelData: channelData(idB, 5_000, 5_000),
+	}, {
+		// A plain BTC channel is ignored.
+		ChannelID:   4,
+		PubKeyBytes: peer1,
+	}}
+
+	// All quotes use a rate of 100k units per BTC, so a single asset unit
+	// is worth 1k satoshis.
+	rate := rfqmsg.NewAssetRate(
+		rfqmath.NewBigIntFixedPoint(100_000, 0),
+		time.Now().Add(time.Hour),
+	)
+	buyAccept := func(peer route.Vertex, specifier asset.Specifier,
+		maxAmt uint64) rfqmsg.BuyAccept {
+
+		return rfqmsg.BuyAccept{
+			Peer: peer,
+			Request: rfqmsg.BuyRequest{
+				AssetSpecifier: specifier,
+				AssetMaxAmt:    maxAmt,
+			},
+			AssetRate: rate,
+		}
+	}
+	buyAccepts := BuyAcceptMap{
+		// Limited by the quote's maximum asset amount.
+		SerialisedScid(1): buyAccept(
+			peer1, asset.NewSpecifierFromId(idA), 150,
+		),
+
+		// Limited by the remote balance of the channel with the peer.
+		SerialisedScid(2): buyAccept(
+			peer2, asset.NewSpecifierFromId(idA), 1_000,
+		),
+
+		// We don't have a channel with the asset with this peer.
+		SerialisedScid(3): buyAccept(
+			peer3, asset.NewSpecifierFromId(idA), 10_000,
+		),
+
+		// Quotes for a group key or another asset are ignored.
+		SerialisedScid(4): buyAccept(
+			peer1, asset.NewSpecifierFromGroupKey(*groupKey),
+			10_000,
+		),
+		SerialisedScid(5): buyAccept(
+			peer3, asset.NewSpecifierFromId(idB), 10_000,
+		),
+	}
+
+	sellAccept := func(peer route.Vertex,
+		maxAmtMsat uint64) rfqmsg.SellAccept {
+
+		return rfqmsg.SellAccept{
+			Peer: peer,
+			Request: rfqmsg.SellRequest{
+				AssetSpecifier: asset.NewSpecifierFromId(idA),
+				PaymentMaxAmt:  lnwire.MilliSatoshi(maxAmtMsat),
+			},
+			AssetRate: rate,
+		}
+	}
+	sellAccepts := SellAcceptMap{
+		// Limited by the quote's maximum payment amount.
+		SerialisedScid(6): sellAccept(peer1, 500_000_000),
+
+		// Limited by the local balance of the channel with the peer.
+		SerialisedScid(7): sellAccept(peer2, 1_000_000_000_000),
+	}
+
+	limits, err := buildPaymentLimits(
+		idA, nil, channels, buyAccepts, sellAccepts,
+	)
+	require.NoError(t, err)
+	require.Equal(t, &AssetPaymentLimits{
+		MaxReceivableUnits: 500,
+		MaxPayableUnits:    1_600,
+		MaxReceivableMsat:  300_000_000,
+		MaxPayableMsat:     600_000_000,
+	}, limits)
+
+	// If we only look at the first peer, the limits are lower.
+	limits, err = buildPaymentLimits(
+		idA, &peer1, channels, buyAccepts, sellAccepts,
+	)
+	require.NoError(t, err)
+	require.Equal(t, &AssetPaymentLimits{
+		MaxReceivableUnits: 200,
+		MaxPayableUnits:    1_000,
+		MaxReceivableMsat:  150_000_000,
+		MaxPayableMsat:     500_000_000,
+	}, limits)
+
+	// Without any quotes, we only know the asset unit limits.
+	limits, err = buildPaymentLimits(idB, nil, channels, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, &AssetPaymentLimits{
+		MaxReceivableUnits: 5_000,
+		MaxPayableUnits:    5_000,
+	}, limits)
+}
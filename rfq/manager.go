@@ -9,6 +9,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/alert"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/rfqmsg"
@@ -95,6 +96,46 @@ type ManagerCfg struct {
 	// messages (this means that the price oracle will not be queried).
 	SkipAcceptQuotePriceCheck bool
 
+	// PriceCache is the configuration of the cache that holds recent
+	// price oracle rates, which are used to quote small amounts.
+	PriceCache PriceCacheCfg
+
+	// AlertSender is used to raise alerts about suspicious peer behavior.
+	// This is optional.
+	AlertSender alert.Sender
+
+	// PeerStatsStore is the store the metrics of the peers we request
+	// quotes from are persisted in. If this is nil, no metrics are
+	// collected.
+	PeerStatsStore PeerStatsStore
+
+	// ReputationPolicy determines how the reputation of peers is used when
+	// selecting the peer to request a quote from.
+	ReputationPolicy ReputationPolicy
+
+	// SettlementStatsStore is the store the settled asset HTLC volume is
+	// persisted in. If this or HtlcEvents is nil, no settlement stats are
+	// collected.
+	SettlementStatsStore SettlementStatsStore
+
+	// HtlcEvents is used to learn about the final outcome of the HTLCs
+	// that were accepted under an RFQ policy.
+	HtlcEvents HtlcEventSubscriber
+
+	// QuoteStore is the store all accepted quotes and the volume realized
+	// under them are recorded in. If this is nil, no quote history is
+	// recorded. The realized volume is only recorded if settlement stats
+	// are collected as well.
+	QuoteStore QuoteStore
+
+	// InventoryTargets are the inventory targets the exposure of our node
+	// is compared against in the inventory report.
+	InventoryTargets []InventoryTarget
+
+	// QuoteApprover is an optional external approval hook that is
+	// consulted before an incoming quote request is accepted.
+	QuoteApprover QuoteApprover
+
 	// ErrChan is the main error channel which will be used to report back
 	// critical errors to the main server.
 	ErrChan chan<- error
@@ -121,6 +162,15 @@ type Manager struct {
 	// whether a quote is accepted or rejected.
 	negotiator *Negotiator
 
+	// reputation tracks the reputation of the peers we request quotes
+	// from.
+	reputation *PeerReputation
+
+	// settlements tracks the HTLCs accepted under an RFQ policy and
+	// collects the settlement stats. This is nil if no stats are
+	// collected.
+	settlements *SettlementTracker
+
 	// incomingMessages is a channel which is populated with incoming
 	// messages.
 	incomingMessages chan rfqmsg.IncomingMsg
@@ -165,6 +215,9 @@ type Manager struct {
 	// events, keyed by their subscription ID.
 	subscribers lnutils.SyncMap[uint64, *fn.EventReceiver[fn.Event]]
 
+	// quoteMetrics collects the metrics of the quote negotiations.
+	quoteMetrics *quoteMetricsTracker
+
 	// subsystemErrChan is the error channel populated by subsystems.
 	subsystemErrChan chan error
 
@@ -190,8 +243,13 @@ func NewManager(cfg ManagerCfg) (*Manager, error) {
 		subscribers: lnutils.SyncMap[
 			uint64, *fn.EventReceiver[fn.Event]]{},
 
+		quoteMetrics:     newQuoteMetricsTracker(),
 		subsystemErrChan: make(chan error, 10),
 
+		reputation: NewPeerReputation(
+			cfg.PeerStatsStore, cfg.ReputationPolicy,
+		),
+
 		ContextGuard: &fn.ContextGuard{
 			DefaultTimeout: DefaultTimeout,
 			Quit:           make(chan struct{}),
@@ -243,6 +301,10 @@ func (m *Manager) startSubsystems(ctx context.Context) error {
 			OutgoingMessages:          m.outgoingMessages,
 			AcceptPriceDeviationPpm:   m.cfg.AcceptPriceDeviationPpm,
 			SkipAcceptQuotePriceCheck: m.cfg.SkipAcceptQuotePriceCheck,
+			PriceCache:                m.cfg.PriceCache,
+			AlertSender:               m.cfg.AlertSender,
+			Reputation:                m.reputation,
+			QuoteApprover:             m.cfg.QuoteApprover,
 			ErrChan:                   m.subsystemErrChan,
 		},
 	)
@@ -255,6 +317,22 @@ func (m *Manager) startSubsystems(ctx context.Context) error {
 		return fmt.Errorf("unable to start RFQ negotiator: %w", err)
 	}
 
+	// Initialise and start the settlement tracker, if settlement stats
+	// should be collected.
+	if m.cfg.SettlementStatsStore != nil && m.cfg.HtlcEvents != nil {
+		m.settlements = NewSettlementTracker(SettlementTrackerCfg{
+			Store:      m.cfg.SettlementStatsStore,
+			QuoteStore: m.cfg.QuoteStore,
+			HtlcEvents: m.cfg.HtlcEvents,
+			ErrChan:    m.subsystemErrChan,
+		})
+
+		if err := m.settlements.Start(); err != nil {
+			return fmt.Errorf("unable to start RFQ settlement "+
+				"tracker: %w", err)
+		}
+	}
+
 	return err
 }
 
@@ -345,6 +423,15 @@ func (m *Manager) stopSubsystems() error {
 			err)
 	}
 
+	// Stop the RFQ settlement tracker.
+	if m.settlements != nil {
+		err = m.settlements.Stop()
+		if err != nil {
+			return fmt.Errorf("error stopping RFQ settlement "+
+				"tracker: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -354,6 +441,10 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 	// Perform type specific handling of the incoming message.
 	switch msg := incomingMsg.(type) {
 	case *rfqmsg.BuyRequest:
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.RequestsReceived++
+		})
+
 		err := m.negotiator.HandleIncomingBuyRequest(*msg)
 		if err != nil {
 			return fmt.Errorf("error handling incoming buy "+
@@ -363,10 +454,13 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 	case *rfqmsg.BuyAccept:
 		// TODO(ffranr): The stream handler should ensure that the
 		//  accept message corresponds to a request.
+		m.quoteMetrics.responseReceived(msg.ID)
 
 		finaliseCallback := func(msg rfqmsg.BuyAccept,
 			invalidQuoteEvent fn.Option[InvalidQuoteRespEvent]) {
 
+			m.recordQuoteResponse(msg.Peer, invalidQuoteEvent)
+
 			// If the quote is invalid, notify subscribers of the
 			// invalid quote event and return.
 			invalidQuoteEvent.WhenSome(
@@ -384,6 +478,7 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 			// lightning node.
 			scid := msg.ShortChannelId()
 			m.peerAcceptedBuyQuotes.Store(scid, msg)
+			m.recordQuote(newBuyQuoteRecord(msg, true, time.Now()))
 
 			// Since we're going to buy assets from our peer, we
 			// need to make sure we can identify the incoming asset
@@ -410,6 +505,10 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 		m.negotiator.HandleIncomingBuyAccept(*msg, finaliseCallback)
 
 	case *rfqmsg.SellRequest:
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.RequestsReceived++
+		})
+
 		err := m.negotiator.HandleIncomingSellRequest(*msg)
 		if err != nil {
 			return fmt.Errorf("error handling incoming sell "+
@@ -419,10 +518,13 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 	case *rfqmsg.SellAccept:
 		// TODO(ffranr): The stream handler should ensure that the
 		//  accept message corresponds to a request.
+		m.quoteMetrics.responseReceived(msg.ID)
 
 		finaliseCallback := func(msg rfqmsg.SellAccept,
 			invalidQuoteEvent fn.Option[InvalidQuoteRespEvent]) {
 
+			m.recordQuoteResponse(msg.Peer, invalidQuoteEvent)
+
 			// If the quote is invalid, notify subscribers of the
 			// invalid quote event and return.
 			invalidQuoteEvent.WhenSome(
@@ -440,6 +542,9 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 			// lightning node.
 			scid := msg.ShortChannelId()
 			m.peerAcceptedSellQuotes.Store(scid, msg)
+			m.recordQuote(
+				newSellQuoteRecord(msg, true, time.Now()),
+			)
 
 			// Notify subscribers of the incoming peer accepted
 			// asset sell quote.
@@ -450,6 +555,15 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 		m.negotiator.HandleIncomingSellAccept(*msg, finaliseCallback)
 
 	case *rfqmsg.Reject:
+		m.quoteMetrics.responseReceived(msg.ID.Val)
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.PeerRejected++
+		})
+
+		ctx, cancel := m.WithCtxQuit()
+		m.reputation.RecordQuoteRejected(ctx, msg.Peer)
+		cancel()
+
 		// The quote request has been rejected. Notify subscribers of
 		// the rejection.
 		event := NewIncomingRejectQuoteEvent(msg)
@@ -462,12 +576,57 @@ func (m *Manager) handleIncomingMessage(incomingMsg rfqmsg.IncomingMsg) error {
 	return nil
 }
 
+// recordQuoteResponse records the validation result of a quote accepted by the
+// given peer in the peer's reputation. Quotes that couldn't be validated
+// because of an error of our price oracle are not held against the peer.
+func (m *Manager) recordQuoteResponse(peer route.Vertex,
+	invalidQuoteEvent fn.Option[InvalidQuoteRespEvent]) {
+
+	m.quoteMetrics.update(func(q *QuoteMetrics) {
+		if invalidQuoteEvent.IsNone() {
+			q.PeerAccepted++
+		} else {
+			q.PeerInvalid++
+		}
+	})
+
+	ctx, cancel := m.WithCtxQuit()
+	defer cancel()
+
+	switch {
+	case invalidQuoteEvent.IsNone():
+		m.reputation.RecordQuoteAccepted(ctx, peer)
+
+	case fn.MapOptionZ(
+		invalidQuoteEvent, func(e InvalidQuoteRespEvent) bool {
+			return e.Status != PriceOracleQueryErrQuoteRespStatus
+		},
+	):
+		m.reputation.RecordQuoteRejected(ctx, peer)
+	}
+}
+
 // handleOutgoingMessage handles an outgoing message. Outgoing messages are
 // messages that will be sent to a peer.
 func (m *Manager) handleOutgoingMessage(outgoingMsg rfqmsg.OutgoingMsg) error {
 	// Perform type specific handling of the outgoing message.
 	switch msg := outgoingMsg.(type) {
+	case *rfqmsg.BuyRequest:
+		m.quoteMetrics.requestSent(msg.ID)
+
+	case *rfqmsg.SellRequest:
+		m.quoteMetrics.requestSent(msg.ID)
+
+	case *rfqmsg.Reject:
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.LocalRejected++
+		})
+
 	case *rfqmsg.BuyAccept:
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.LocalAccepted++
+		})
+
 		// A peer sent us an asset buy quote request in an attempt to
 		// buy an asset from us. Having accepted the request, but before
 		// we inform our peer of our decision, we inform the order
@@ -478,6 +637,7 @@ func (m *Manager) handleOutgoingMessage(outgoingMsg rfqmsg.OutgoingMsg) error {
 		// We want to store that we accepted the buy quote, in case we
 		// need to look it up for a direct peer payment.
 		m.localAcceptedBuyQuotes.Store(msg.ShortChannelId(), *msg)
+		m.recordQuote(newBuyQuoteRecord(*msg, false, time.Now()))
 
 		// Since our peer is going to buy assets from us, we need to
 		// make sure we can identify the forwarded asset payment by the
@@ -491,6 +651,10 @@ func (m *Manager) handleOutgoingMessage(outgoingMsg rfqmsg.OutgoingMsg) error {
 		}
 
 	case *rfqmsg.SellAccept:
+		m.quoteMetrics.update(func(q *QuoteMetrics) {
+			q.LocalAccepted++
+		})
+
 		// A peer sent us an asset sell quote request in an attempt to
 		// sell an asset to us. Having accepted the request, but before
 		// we inform our peer of our decision, we inform the order
@@ -501,6 +665,7 @@ func (m *Manager) handleOutgoingMessage(outgoingMsg rfqmsg.OutgoingMsg) error {
 		// We want to store that we accepted the sell quote, in case we
 		// need to look it up for a direct peer payment.
 		m.localAcceptedSellQuotes.Store(msg.ShortChannelId(), *msg)
+		m.recordQuote(newSellQuoteRecord(*msg, false, time.Now()))
 	}
 
 	// Send the outgoing message to the peer.
@@ -513,6 +678,22 @@ func (m *Manager) handleOutgoingMessage(outgoingMsg rfqmsg.OutgoingMsg) error {
 	return nil
 }
 
+// recordQuote records an accepted quote in the quote history, if one is kept.
+// The history is best effort and must not interfere with the quote
+// processing, so errors are only logged.
+func (m *Manager) recordQuote(quote QuoteRecord) {
+	if m.cfg.QuoteStore == nil {
+		return
+	}
+
+	ctx, cancel := m.WithCtxQuit()
+	defer cancel()
+
+	if err := m.cfg.QuoteStore.AddQuote(ctx, quote); err != nil {
+		log.Warnf("Unable to record quote %v: %v", quote.ID, err)
+	}
+}
+
 // addScidAlias adds a SCID alias to the alias manager.
 func (m *Manager) addScidAlias(scidAlias uint64, assetSpecifier asset.Specifier,
 	peer route.Vertex) error {
@@ -535,7 +716,13 @@ func (m *Manager) addScidAlias(scidAlias uint64, assetSpecifier asset.Specifier,
 	}, localChans)
 
 	// Identify the correct channel to use as the base SCID for the alias
-	// by inspecting the asset data in the custom channel data.
+	// by inspecting the asset data in the custom channel data. Note that
+	// for zero-conf channels, the channel ID reported by lnd is the alias
+	// assigned during funding. Because asset channels are always private
+	// and use the scid-alias channel type, that alias remains the base
+	// SCID once the funding transaction confirms. The real SCID is only
+	// tracked separately, so quote aliases added before confirmation stay
+	// valid.
 	assetID, err := assetSpecifier.UnwrapIdOrErr()
 	if err != nil {
 		return fmt.Errorf("asset ID must be specified when adding "+
@@ -631,7 +818,11 @@ func (m *Manager) mainEventLoop() {
 			}
 
 		case acceptHtlcEvent := <-m.acceptHtlcEvents:
-			// Handle a HTLC accept event. Notify any subscribers.
+			// Handle a HTLC accept event. Track the HTLC until it
+			// settles and notify any subscribers.
+			if m.settlements != nil {
+				m.settlements.TrackHtlc(acceptHtlcEvent)
+			}
 			m.publishSubscriberEvent(acceptHtlcEvent)
 
 		// Handle subsystem errors.
@@ -795,6 +986,83 @@ func (m *Manager) UpsertAssetSellOrder(order SellOrder) error {
 	return nil
 }
 
+// QuoteMetrics returns a snapshot of the metrics of the quote negotiations.
+func (m *Manager) QuoteMetrics() QuoteMetrics {
+	return m.quoteMetrics.snapshot()
+}
+
+// SettlementStats returns the settled asset HTLC volume matching the given
+// query. ErrSettlementStatsDisabled is returned if no settlement stats are
+// collected.
+func (m *Manager) SettlementStats(ctx context.Context,
+	q SettlementStatsQuery) ([]SettlementStats, error) {
+
+	if m.settlements == nil {
+		return nil, ErrSettlementStatsDisabled
+	}
+
+	return m.settlements.QuerySettlementStats(ctx, q)
+}
+
+// QueryQuotes returns the recorded quotes matching the given query.
+// ErrQuoteHistoryDisabled is returned if no quote history is recorded.
+func (m *Manager) QueryQuotes(ctx context.Context,
+	q QuoteQuery) ([]QuoteRecord, error) {
+
+	if m.cfg.QuoteStore == nil {
+		return nil, ErrQuoteHistoryDisabled
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultQuoteQueryLimit
+	}
+
+	return m.cfg.QuoteStore.QueryQuotes(ctx, q, time.Now())
+}
+
+// QuoteVolume returns the realized volume of the recorded quotes matching the
+// given query, aggregated per asset. ErrQuoteHistoryDisabled is returned if no
+// quote history is recorded.
+func (m *Manager) QuoteVolume(ctx context.Context,
+	q QuoteQuery) ([]QuoteVolume, error) {
+
+	if m.cfg.QuoteStore == nil {
+		return nil, ErrQuoteHistoryDisabled
+	}
+
+	return m.cfg.QuoteStore.QueryQuoteVolume(ctx, q, time.Now())
+}
+
+// InventoryReport summarizes the current exposure of our node per asset from
+// the open quotes we accepted, the in-flight HTLCs and the balances of the
+// active asset channels, compared against the configured inventory targets.
+// In-flight HTLCs are only known if settlement stats are collected.
+func (m *Manager) InventoryReport(
+	ctx context.Context) ([]AssetInventory, error) {
+
+	channels, err := m.cfg.ChannelLister.ListChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list channels: %w", err)
+	}
+
+	var inFlight []InFlightAssets
+	if m.settlements != nil {
+		inFlight = m.settlements.InFlightHtlcs()
+	}
+
+	return buildInventoryReport(
+		channels, m.LocalAcceptedBuyQuotes(),
+		m.LocalAcceptedSellQuotes(), inFlight,
+		m.cfg.InventoryTargets,
+	)
+}
+
+// Reputation returns the tracker of the reputation of the peers we request
+// quotes from.
+func (m *Manager) Reputation() *PeerReputation {
+	return m.reputation
+}
+
 // PeerAcceptedBuyQuotes returns buy quotes that were requested by our node and
 // have been accepted by our peers. These quotes are exclusively available to
 // our node for the acquisition of assets.
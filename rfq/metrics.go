@@ -0,0 +1,181 @@
+package rfq
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+)
+
+var (
+	// QuoteLatencyBuckets are the upper bounds of the buckets of the
+	// histogram of the time it takes our peers to respond to our quote
+	// requests.
+	QuoteLatencyBuckets = []time.Duration{
+		100 * time.Millisecond,
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		2500 * time.Millisecond,
+		5 * time.Second,
+		10 * time.Second,
+		30 * time.Second,
+	}
+)
+
+const (
+	// maxPendingQuoteRequestAge is the maximum time we wait for the
+	// response to a quote request before we stop tracking its latency.
+	maxPendingQuoteRequestAge = 10 * time.Minute
+)
+
+// LatencyHistogram is a histogram of latencies.
+type LatencyHistogram struct {
+	// Buckets are the upper bounds of the buckets of the histogram.
+	Buckets []time.Duration
+
+	// Counts are the cumulative numbers of observations that are less
+	// than or equal to the upper bound of the bucket with the same index.
+	Counts []uint64
+
+	// Count is the total number of observations.
+	Count uint64
+
+	// Sum is the sum of all observed latencies.
+	Sum time.Duration
+}
+
+// newLatencyHistogram creates an empty histogram with the given buckets.
+func newLatencyHistogram(buckets []time.Duration) LatencyHistogram {
+	return LatencyHistogram{
+		Buckets: buckets,
+		Counts:  make([]uint64, len(buckets)),
+	}
+}
+
+// observe adds the given latency to the histogram.
+func (h *LatencyHistogram) observe(latency time.Duration) {
+	for idx, bucket := range h.Buckets {
+		if latency <= bucket {
+			h.Counts[idx]++
+		}
+	}
+
+	h.Count++
+	h.Sum += latency
+}
+
+// copy returns a deep copy of the histogram.
+func (h *LatencyHistogram) copy() LatencyHistogram {
+	histogram := *h
+	histogram.Counts = append([]uint64(nil), h.Counts...)
+
+	return histogram
+}
+
+// QuoteMetrics is a snapshot of the metrics of the quote negotiations of the
+// RFQ manager.
+type QuoteMetrics struct {
+	// RequestsSent is the number of quote requests our node sent.
+	RequestsSent uint64
+
+	// PeerAccepted is the number of our quote requests our peers accepted
+	// with a quote we considered valid.
+	PeerAccepted uint64
+
+	// PeerInvalid is the number of our quote requests our peers accepted
+	// with a quote we considered invalid.
+	PeerInvalid uint64
+
+	// PeerRejected is the number of our quote requests our peers
+	// rejected.
+	PeerRejected uint64
+
+	// RequestsReceived is the number of quote requests our peers sent us.
+	RequestsReceived uint64
+
+	// LocalAccepted is the number of quote requests our node accepted.
+	LocalAccepted uint64
+
+	// LocalRejected is the number of quote requests our node rejected.
+	LocalRejected uint64
+
+	// ResponseLatency is the histogram of the time it took our peers to
+	// respond to our quote requests.
+	ResponseLatency LatencyHistogram
+}
+
+// quoteMetricsTracker collects the metrics of the quote negotiations.
+type quoteMetricsTracker struct {
+	mu sync.Mutex
+
+	metrics QuoteMetrics
+
+	// pending holds the time our quote requests that are still waiting
+	// for a response were sent.
+	pending map[rfqmsg.ID]time.Time
+}
+
+// newQuoteMetricsTracker creates a new quote metrics tracker.
+func newQuoteMetricsTracker() *quoteMetricsTracker {
+	return &quoteMetricsTracker{
+		metrics: QuoteMetrics{
+			ResponseLatency: newLatencyHistogram(
+				QuoteLatencyBuckets,
+			),
+		},
+		pending: make(map[rfqmsg.ID]time.Time),
+	}
+}
+
+// requestSent records that our node sent the quote request with the given ID.
+func (q *quoteMetricsTracker) requestSent(id rfqmsg.ID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// We stop tracking requests that were never answered, so they don't
+	// accumulate forever.
+	now := time.Now()
+	for pendingID, sentAt := range q.pending {
+		if now.Sub(sentAt) > maxPendingQuoteRequestAge {
+			delete(q.pending, pendingID)
+		}
+	}
+
+	q.metrics.RequestsSent++
+	q.pending[id] = now
+}
+
+// responseReceived records the latency of the response to the quote request
+// with the given ID, if we sent it.
+func (q *quoteMetricsTracker) responseReceived(id rfqmsg.ID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sentAt, ok := q.pending[id]
+	if !ok {
+		return
+	}
+	delete(q.pending, id)
+
+	q.metrics.ResponseLatency.observe(time.Since(sentAt))
+}
+
+// update applies the given update to the metrics.
+func (q *quoteMetricsTracker) update(f func(*QuoteMetrics)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	f(&q.metrics)
+}
+
+// snapshot returns a copy of the current metrics.
+func (q *quoteMetricsTracker) snapshot() QuoteMetrics {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	metrics := q.metrics
+	metrics.ResponseLatency = q.metrics.ResponseLatency.copy()
+
+	return metrics
+}
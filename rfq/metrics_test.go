@@ -0,0 +1,72 @@
+package rfq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLatencyHistogram tests that observations are counted in all buckets with
+// an upper bound of at least the observed latency.
+func TestLatencyHistogram(t *testing.T) {
+	t.Parallel()
+
+	h := newLatencyHistogram([]time.Duration{
+		time.Second, 5 * time.Second, 10 * time.Second,
+	})
+
+	h.observe(500 * time.Millisecond)
+	h.observe(time.Second)
+	h.observe(7 * time.Second)
+	h.observe(time.Minute)
+
+	require.Equal(t, []uint64{2, 2, 3}, h.Counts)
+	require.Equal(t, uint64(4), h.Count)
+	require.Equal(t, 68500*time.Millisecond, h.Sum)
+
+	// The copy must not share the counts with the original.
+	c := h.copy()
+	h.observe(time.Millisecond)
+	require.Equal(t, []uint64{2, 2, 3}, c.Counts)
+	require.Equal(t, []uint64{3, 3, 4}, h.Counts)
+}
+
+// TestQuoteMetricsTracker tests that the latency of responses is only tracked
+// for the quote requests our node sent.
+func TestQuoteMetricsTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newQuoteMetricsTracker()
+
+	sentID := rfqmsg.ID{1}
+	tracker.requestSent(sentID)
+	tracker.requestSent(rfqmsg.ID{2})
+
+	// A response to a request we didn't send isn't tracked.
+	tracker.responseReceived(rfqmsg.ID{3})
+	require.Zero(t, tracker.snapshot().ResponseLatency.Count)
+
+	// Only the first response to a request is tracked.
+	tracker.responseReceived(sentID)
+	tracker.responseReceived(sentID)
+
+	tracker.update(func(m *QuoteMetrics) {
+		m.PeerAccepted++
+	})
+
+	metrics := tracker.snapshot()
+	require.Equal(t, uint64(2), metrics.RequestsSent)
+	require.Equal(t, uint64(1), metrics.PeerAccepted)
+	require.Equal(t, uint64(1), metrics.ResponseLatency.Count)
+	require.Len(t, tracker.pending, 1)
+
+	// Requests that are never answered are eventually dropped.
+	tracker.pending[rfqmsg.ID{2}] = time.Now().Add(
+		-2 * maxPendingQuoteRequestAge,
+	)
+	tracker.requestSent(rfqmsg.ID{4})
+	require.Len(t, tracker.pending, 1)
+	require.Contains(t, tracker.pending, rfqmsg.ID{4})
+}
@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/alert"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/rfqmath"
 	"github.com/lightninglabs/taproot-assets/rfqmsg"
 	"github.com/lightningnetwork/lnd/lnutils"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 )
 
 const (
@@ -24,6 +26,12 @@ const (
 	//
 	// NOTE: This value is set to 5% (50,000 ppm).
 	DefaultAcceptPriceDeviationPpm = 50_000
+
+	// priceDeviationAlertThreshold is the number of consecutive quote
+	// accept messages from the same peer with a price outside the accepted
+	// deviation from the price oracle's rate after which an alert is
+	// raised.
+	priceDeviationAlertThreshold = 3
 )
 
 // NegotiatorCfg holds the configuration for the negotiator.
@@ -48,6 +56,23 @@ type NegotiatorCfg struct {
 	// useful for testing purposes.
 	SkipAcceptQuotePriceCheck bool
 
+	// PriceCache is the configuration of the cache that holds recent
+	// price oracle rates, which are used to quote small amounts.
+	PriceCache PriceCacheCfg
+
+	// AlertSender is used to raise an alert if a peer repeatedly quotes
+	// prices that deviate too far from the price oracle's rate. This is
+	// optional.
+	AlertSender alert.Sender
+
+	// Reputation is used to record how competitive the rates of the peers
+	// are compared to the price oracle's rate.
+	Reputation *PeerReputation
+
+	// QuoteApprover is an optional external approval hook that is
+	// consulted before an incoming quote request is accepted.
+	QuoteApprover QuoteApprover
+
 	// ErrChan is a channel that is populated with errors by this subsystem.
 	ErrChan chan<- error
 }
@@ -77,6 +102,16 @@ type Negotiator struct {
 	// asset buy offers.
 	assetGroupBuyOffers lnutils.SyncMap[asset.SerializedKey, BuyOffer]
 
+	// rateCache holds the most recent rates returned by the price oracle.
+	rateCache *assetRateCache
+
+	// priceDeviations counts the number of consecutive quote accept
+	// messages per peer with a price outside the accepted deviation.
+	priceDeviations map[route.Vertex]uint32
+
+	// priceDeviationsMtx guards priceDeviations.
+	priceDeviationsMtx sync.Mutex
+
 	// ContextGuard provides a wait group and main quit channel that can be
 	// used to create guarded contexts.
 	*fn.ContextGuard
@@ -95,6 +130,10 @@ func NewNegotiator(cfg NegotiatorCfg) (*Negotiator, error) {
 		assetGroupBuyOffers: lnutils.SyncMap[
 			asset.SerializedKey, BuyOffer]{},
 
+		rateCache: newAssetRateCache(cfg.PriceCache),
+
+		priceDeviations: make(map[route.Vertex]uint32),
+
 		ContextGuard: &fn.ContextGuard{
 			DefaultTimeout: DefaultTimeout,
 			Quit:           make(chan struct{}),
@@ -116,6 +155,15 @@ func (n *Negotiator) queryBidFromPriceOracle(assetSpecifier asset.Specifier,
 	//  be optional because at some call sites we are initiating a request
 	//  and do not have a peer's proposed ask price.
 
+	// Quotes for small amounts can use a recent cached rate.
+	cachedRate := n.rateCache.fetch(
+		assetSpecifier, false, assetMaxAmt, paymentMaxAmt,
+		assetRateHint,
+	).UnwrapToPtr()
+	if cachedRate != nil {
+		return cachedRate, nil
+	}
+
 	ctx, cancel := n.WithCtxQuitNoTimeout()
 	defer cancel()
 
@@ -144,6 +192,10 @@ func (n *Negotiator) queryBidFromPriceOracle(assetSpecifier asset.Specifier,
 	// TODO(ffranr): Check that the bid price is reasonable.
 	// TODO(ffranr): Ensure that the expiry time is valid and sufficient.
 
+	n.rateCache.store(
+		assetSpecifier, false, oracleResponse.AssetRate, assetRateHint,
+	)
+
 	return &oracleResponse.AssetRate, nil
 }
 
@@ -234,6 +286,15 @@ func (n *Negotiator) queryAskFromPriceOracle(assetSpecifier asset.Specifier,
 	paymentMaxAmt fn.Option[lnwire.MilliSatoshi],
 	assetRateHint fn.Option[rfqmsg.AssetRate]) (*rfqmsg.AssetRate, error) {
 
+	// Quotes for small amounts can use a recent cached rate.
+	cachedRate := n.rateCache.fetch(
+		assetSpecifier, true, assetMaxAmt, paymentMaxAmt,
+		assetRateHint,
+	).UnwrapToPtr()
+	if cachedRate != nil {
+		return cachedRate, nil
+	}
+
 	// Query the price oracle for an asking price.
 	ctx, cancel := n.WithCtxQuitNoTimeout()
 	defer cancel()
@@ -264,6 +325,10 @@ func (n *Negotiator) queryAskFromPriceOracle(assetSpecifier asset.Specifier,
 	// TODO(ffranr): Check that the asking price is reasonable.
 	// TODO(ffranr): Ensure that the expiry time is valid and sufficient.
 
+	n.rateCache.store(
+		assetSpecifier, true, oracleResponse.AssetRate, assetRateHint,
+	)
+
 	return &oracleResponse.AssetRate, nil
 }
 
@@ -348,6 +413,25 @@ func (n *Negotiator) HandleIncomingBuyRequest(
 			return
 		}
 
+		assetID, groupKey := request.AssetSpecifier.AsBytes()
+		approved := n.approveQuote(QuoteApprovalRequest{
+			Peer:          request.Peer,
+			ID:            request.ID,
+			IsBuy:         true,
+			AssetID:       assetID,
+			AssetGroupKey: groupKey,
+			AssetMaxAmt:   request.AssetMaxAmt,
+			AssetRate:     *assetRate,
+		})
+		if !approved {
+			msg := rfqmsg.NewReject(
+				request.Peer, request.ID,
+				rfqmsg.ErrUnknownReject,
+			)
+			sendOutgoingMsg(msg)
+			return
+		}
+
 		// Construct and send a buy accept message.
 		msg := rfqmsg.NewBuyAcceptFromRequest(request, *assetRate)
 		sendOutgoingMsg(msg)
@@ -443,6 +527,24 @@ func (n *Negotiator) HandleIncomingSellRequest(
 			return
 		}
 
+		assetID, groupKey := request.AssetSpecifier.AsBytes()
+		approved := n.approveQuote(QuoteApprovalRequest{
+			Peer:          request.Peer,
+			ID:            request.ID,
+			AssetID:       assetID,
+			AssetGroupKey: groupKey,
+			PaymentMaxAmt: request.PaymentMaxAmt,
+			AssetRate:     *assetRate,
+		})
+		if !approved {
+			msg := rfqmsg.NewReject(
+				request.Peer, request.ID,
+				rfqmsg.ErrUnknownReject,
+			)
+			sendOutgoingMsg(msg)
+			return
+		}
+
 		// Construct and send a sell accept message.
 		msg := rfqmsg.NewSellAcceptFromRequest(request, *assetRate)
 		sendOutgoingMsg(msg)
@@ -451,6 +553,35 @@ func (n *Negotiator) HandleIncomingSellRequest(
 	return nil
 }
 
+// approveQuote consults the external quote approver, if one is configured,
+// and returns true if the described quote may be accepted. Quotes are
+// rejected if the approver fails to answer, since accepting a quote the
+// approver would have rejected could be costly. The reason of a rejection is
+// only logged and not sent to the peer.
+func (n *Negotiator) approveQuote(req QuoteApprovalRequest) bool {
+	if n.cfg.QuoteApprover == nil {
+		return true
+	}
+
+	ctx, cancel := n.WithCtxQuitNoTimeout()
+	defer cancel()
+
+	approval, err := n.cfg.QuoteApprover.ApproveQuote(ctx, req)
+	if err != nil {
+		log.Warnf("Rejecting quote request %v from peer %v, approval "+
+			"hook failed: %v", req.ID, req.Peer, err)
+		return false
+	}
+
+	if !approval.Approved {
+		log.Infof("Quote request %v from peer %v not approved: %v",
+			req.ID, req.Peer, approval.Reason)
+		return false
+	}
+
+	return true
+}
+
 // HandleOutgoingSellOrder handles an outgoing sell order by constructing sell
 // requests and passing them to the outgoing messages channel. These requests
 // are sent to peers.
@@ -640,6 +771,9 @@ func (n *Negotiator) HandleIncomingBuyAccept(msg rfqmsg.BuyAccept,
 				"acceptable bounds (peer_asset_rate=%s, "+
 				"oracle_asset_rate=%s)", msg.AssetRate.String(),
 				assetRate.String())
+			n.trackPriceDeviation(
+				msg.Peer, true, msg.AssetRate, *assetRate,
+			)
 
 			// Construct an invalid quote response event so that we
 			// can inform the peer that the quote response has not
@@ -656,6 +790,8 @@ func (n *Negotiator) HandleIncomingBuyAccept(msg rfqmsg.BuyAccept,
 			return
 		}
 
+		n.resetPriceDeviations(msg.Peer)
+		n.recordRateDeviation(msg.Peer, true, msg.AssetRate, *assetRate)
 		finalise(msg, fn.None[InvalidQuoteRespEvent]())
 	}()
 }
@@ -768,6 +904,9 @@ func (n *Negotiator) HandleIncomingSellAccept(msg rfqmsg.SellAccept,
 				"acceptable bounds (asset_rate=%v, "+
 				"oracle_asset_rate=%v)", msg.AssetRate,
 				assetRate)
+			n.trackPriceDeviation(
+				msg.Peer, false, msg.AssetRate, *assetRate,
+			)
 
 			// Construct an invalid quote response event so that we
 			// can inform the peer that the quote response has not
@@ -784,10 +923,71 @@ func (n *Negotiator) HandleIncomingSellAccept(msg rfqmsg.SellAccept,
 			return
 		}
 
+		n.resetPriceDeviations(msg.Peer)
+		n.recordRateDeviation(
+			msg.Peer, false, msg.AssetRate, *assetRate,
+		)
 		finalise(msg, fn.None[InvalidQuoteRespEvent]())
 	}()
 }
 
+// recordRateDeviation records the deviation of an acceptable peer rate from
+// the price oracle's rate in the peer's reputation.
+func (n *Negotiator) recordRateDeviation(peer route.Vertex, isBuy bool,
+	peerRate, oracleRate rfqmsg.AssetRate) {
+
+	if n.cfg.Reputation == nil {
+		return
+	}
+
+	ctx, cancel := n.WithCtxQuit()
+	defer cancel()
+
+	n.cfg.Reputation.RecordRateDeviation(
+		ctx, peer, isBuy, peerRate, oracleRate,
+	)
+}
+
+// trackPriceDeviation records that the given peer quoted a price outside the
+// accepted deviation from the price oracle's rate and raises an alert if this
+// happened repeatedly.
+func (n *Negotiator) trackPriceDeviation(peer route.Vertex, isBuy bool,
+	peerRate, oracleRate rfqmsg.AssetRate) {
+
+	n.priceDeviationsMtx.Lock()
+	n.priceDeviations[peer]++
+	count := n.priceDeviations[peer]
+	n.priceDeviationsMtx.Unlock()
+
+	if count < priceDeviationAlertThreshold {
+		return
+	}
+
+	quoteType := "sell"
+	if isBuy {
+		quoteType = "buy"
+	}
+
+	alert.SendIfSet(n.cfg.AlertSender, alert.Alert{
+		Type:     alert.TypeOracleDeviation,
+		Severity: alert.SeverityWarning,
+		Message: fmt.Sprintf("Peer %v quoted %d consecutive %s "+
+			"prices outside the accepted deviation from the "+
+			"price oracle (peer_asset_rate=%v, "+
+			"oracle_asset_rate=%v)", peer, count, quoteType,
+			peerRate, oracleRate),
+		DedupKey: peer.String(),
+	})
+}
+
+// resetPriceDeviations resets the number of consecutive price deviations of
+// the given peer after it quoted an acceptable price.
+func (n *Negotiator) resetPriceDeviations(peer route.Vertex) {
+	n.priceDeviationsMtx.Lock()
+	delete(n.priceDeviations, peer)
+	n.priceDeviationsMtx.Unlock()
+}
+
 // SellOffer is a struct that represents an asset sell offer. This
 // data structure describes the maximum amount of an asset that is available
 // for sale.
@@ -17,6 +17,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnutils"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
@@ -97,6 +98,9 @@ type AssetSalePolicy struct {
 	// AskAssetRate is the quote's asking asset unit to BTC conversion rate.
 	AskAssetRate rfqmath.BigIntFixedPoint
 
+	// Peer is the peer that requested the quote the policy is based on.
+	Peer route.Vertex
+
 	// expiry is the policy's expiry unix timestamp after which the policy
 	// is no longer valid.
 	expiry uint64
@@ -109,6 +113,7 @@ func NewAssetSalePolicy(quote rfqmsg.BuyAccept) *AssetSalePolicy {
 		AcceptedQuoteId:        quote.ID,
 		MaxOutboundAssetAmount: quote.Request.AssetMaxAmt,
 		AskAssetRate:           quote.AssetRate.Rate,
+		Peer:                   quote.Peer,
 		expiry:                 uint64(quote.AssetRate.Expiry.Unix()),
 	}
 }
@@ -251,6 +256,9 @@ type AssetPurchasePolicy struct {
 	// PaymentMaxAmt is the maximum agreed BTC payment.
 	PaymentMaxAmt lnwire.MilliSatoshi
 
+	// Peer is the peer that requested the quote the policy is based on.
+	Peer route.Vertex
+
 	// expiry is the policy's expiry unix timestamp in seconds after which
 	// the policy is no longer valid.
 	expiry uint64
@@ -264,6 +272,7 @@ func NewAssetPurchasePolicy(quote rfqmsg.SellAccept) *AssetPurchasePolicy {
 		AcceptedQuoteId: quote.ID,
 		BidAssetRate:    quote.AssetRate.Rate,
 		PaymentMaxAmt:   quote.Request.PaymentMaxAmt,
+		Peer:            quote.Peer,
 		expiry:          uint64(quote.AssetRate.Expiry.Unix()),
 	}
 }
@@ -0,0 +1,192 @@
+package rfq
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultPriceCacheMaxAmtMsat is the default maximum value of a quote
+	// in milli-satoshi for which a cached price oracle rate can be used.
+	DefaultPriceCacheMaxAmtMsat = 10_000_000
+
+	// minCachedRateLifetime is the minimum remaining lifetime a cached
+	// asset rate must have to be used for a new quote.
+	minCachedRateLifetime = minAssetRatesExpiryLifetime * time.Second
+)
+
+// PriceCacheCfg is the configuration of the price oracle rate cache.
+type PriceCacheCfg struct {
+	// MaxStaleness is the maximum age of a cached rate that can still be
+	// used for a quote. A value of zero disables the cache.
+	MaxStaleness time.Duration
+
+	// MaxAmt is the maximum value of a quote for which a cached rate can be
+	// used. Quotes above this value, or quotes without any amount, always
+	// query the price oracle for a fresh rate.
+	MaxAmt lnwire.MilliSatoshi
+
+	// Clock is used to determine the age of the cached rates.
+	Clock clock.Clock
+}
+
+// PriceCacheStats holds the accumulated statistics of the price oracle rate
+// cache.
+type PriceCacheStats struct {
+	// Hits is the number of price oracle queries that were answered from
+	// the cache.
+	Hits uint64
+
+	// Misses is the number of price oracle queries that could have been
+	// answered from the cache, but required a fresh rate.
+	Misses uint64
+}
+
+// priceCacheKey identifies a cached asset rate.
+type priceCacheKey struct {
+	// assetSpecifier is the string representation of the asset specifier
+	// the rate was queried for.
+	assetSpecifier string
+
+	// isAsk is true if the rate is an ask price, false if it is a bid
+	// price.
+	isAsk bool
+}
+
+// cachedAssetRate is an asset rate returned by the price oracle, together with
+// the time it was queried at.
+type cachedAssetRate struct {
+	rate rfqmsg.AssetRate
+
+	queriedAt time.Time
+}
+
+// assetRateCache caches the most recent asset rates returned by the price
+// oracle. The cached rates are only used for quotes of small amounts, so
+// frequent small quotes don't each require a price oracle query, while large
+// quotes always get a fresh rate.
+type assetRateCache struct {
+	cfg PriceCacheCfg
+
+	mu sync.Mutex
+
+	// rates holds the most recent rate for each asset and quote side.
+	rates map[priceCacheKey]cachedAssetRate
+
+	stats PriceCacheStats
+}
+
+// newAssetRateCache creates a new asset rate cache.
+func newAssetRateCache(cfg PriceCacheCfg) *assetRateCache {
+	if cfg.Clock == nil {
+		cfg.Clock = clock.NewDefaultClock()
+	}
+
+	return &assetRateCache{
+		cfg:   cfg,
+		rates: make(map[priceCacheKey]cachedAssetRate),
+	}
+}
+
+// enabled returns true if the cache is enabled.
+func (c *assetRateCache) enabled() bool {
+	return c.cfg.MaxStaleness > 0 && c.cfg.MaxAmt > 0
+}
+
+// fetch returns a cached rate for the given asset and quote side, if there is
+// one that is recent enough and the quote amount is small enough for it to be
+// used. Queries with an asset rate hint always get a fresh rate, as the price
+// oracle may take the hint into account.
+func (c *assetRateCache) fetch(assetSpecifier asset.Specifier, isAsk bool,
+	assetAmt fn.Option[uint64], paymentAmt fn.Option[lnwire.MilliSatoshi],
+	assetRateHint fn.Option[rfqmsg.AssetRate]) fn.Option[rfqmsg.AssetRate] {
+
+	none := fn.None[rfqmsg.AssetRate]()
+	if !c.enabled() || assetRateHint.IsSome() {
+		return none
+	}
+
+	// Quotes without any amount might be used for any amount, so they
+	// always get a fresh rate.
+	if assetAmt.IsNone() && paymentAmt.IsNone() {
+		return none
+	}
+
+	key := priceCacheKey{
+		assetSpecifier: assetSpecifier.String(),
+		isAsk:          isAsk,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.rates[key]
+	if !ok {
+		c.stats.Misses++
+		return none
+	}
+
+	// The rate must neither be too old, nor about to expire.
+	now := c.cfg.Clock.Now()
+	if now.Sub(cached.queriedAt) > c.cfg.MaxStaleness ||
+		cached.rate.Expiry.Before(now.Add(minCachedRateLifetime)) {
+
+		delete(c.rates, key)
+		c.stats.Misses++
+
+		return none
+	}
+
+	// We only use the cached rate if the quote is small. If the amount is
+	// given in asset units, we use the cached rate to determine its value.
+	quoteAmt := paymentAmt.UnwrapOrFunc(func() lnwire.MilliSatoshi {
+		units := rfqmath.NewBigIntFixedPoint(assetAmt.UnwrapOr(0), 0)
+		return rfqmath.UnitsToMilliSatoshi(units, cached.rate.Rate)
+	})
+	if quoteAmt > c.cfg.MaxAmt {
+		return none
+	}
+
+	c.stats.Hits++
+
+	return fn.Some(cached.rate)
+}
+
+// store adds a rate that was just returned by the price oracle to the cache.
+// Rates queried with an asset rate hint aren't cached, as they might not apply
+// to queries without the same hint.
+func (c *assetRateCache) store(assetSpecifier asset.Specifier, isAsk bool,
+	rate rfqmsg.AssetRate, assetRateHint fn.Option[rfqmsg.AssetRate]) {
+
+	if !c.enabled() || assetRateHint.IsSome() {
+		return
+	}
+
+	key := priceCacheKey{
+		assetSpecifier: assetSpecifier.String(),
+		isAsk:          isAsk,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rates[key] = cachedAssetRate{
+		rate:      rate,
+		queriedAt: c.cfg.Clock.Now(),
+	}
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (c *assetRateCache) Stats() PriceCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
@@ -0,0 +1,146 @@
+package rfq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// countingPriceOracle is a mock price oracle that counts the number of
+// queries it receives.
+type countingPriceOracle struct {
+	*MockPriceOracle
+
+	numQueries int
+}
+
+// QueryAskPrice returns the ask price for the given asset amount.
+func (c *countingPriceOracle) QueryAskPrice(ctx context.Context,
+	assetSpecifier asset.Specifier, assetMaxAmt fn.Option[uint64],
+	paymentMaxAmt fn.Option[lnwire.MilliSatoshi],
+	assetRateHint fn.Option[rfqmsg.AssetRate]) (*OracleResponse, error) {
+
+	c.numQueries++
+
+	return c.MockPriceOracle.QueryAskPrice(
+		ctx, assetSpecifier, assetMaxAmt, paymentMaxAmt, assetRateHint,
+	)
+}
+
+// QueryBidPrice returns a bid price for the given asset amount.
+func (c *countingPriceOracle) QueryBidPrice(ctx context.Context,
+	assetSpecifier asset.Specifier, assetMaxAmt fn.Option[uint64],
+	paymentMaxAmt fn.Option[lnwire.MilliSatoshi],
+	assetRateHint fn.Option[rfqmsg.AssetRate]) (*OracleResponse, error) {
+
+	c.numQueries++
+
+	return c.MockPriceOracle.QueryBidPrice(
+		ctx, assetSpecifier, assetMaxAmt, paymentMaxAmt, assetRateHint,
+	)
+}
+
+// TestNegotiatorPriceCache tests that the negotiator only uses cached price
+// oracle rates for small, recent quotes.
+func TestNegotiatorPriceCache(t *testing.T) {
+	t.Parallel()
+
+	// With 100M asset units per BTC, one asset unit is worth one satoshi.
+	oracle := &countingPriceOracle{
+		MockPriceOracle: NewMockPriceOracle(3600, 100_000_000),
+	}
+	testClock := clock.NewTestClock(time.Now())
+
+	negotiator, err := NewNegotiator(NegotiatorCfg{
+		PriceOracle: oracle,
+		PriceCache: PriceCacheCfg{
+			MaxStaleness: time.Minute,
+			MaxAmt:       100_000,
+			Clock:        testClock,
+		},
+	})
+	require.NoError(t, err)
+
+	var (
+		specifier = asset.NewSpecifierFromId(asset.ID{1, 2, 3})
+		noUnits   = fn.None[uint64]()
+		noMsat    = fn.None[lnwire.MilliSatoshi]()
+		noHint    = fn.None[rfqmsg.AssetRate]()
+	)
+	queryAsk := func(units fn.Option[uint64],
+		msat fn.Option[lnwire.MilliSatoshi]) {
+
+		_, err := negotiator.queryAskFromPriceOracle(
+			specifier, units, msat, noHint,
+		)
+		require.NoError(t, err)
+	}
+
+	// The first small quote needs to query the oracle, the second one
+	// can use the cached rate.
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](50_000))
+	require.Equal(t, 1, oracle.numQueries)
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](50_000))
+	require.Equal(t, 1, oracle.numQueries)
+
+	// Bid prices are cached separately.
+	_, err = negotiator.queryBidFromPriceOracle(
+		specifier, noUnits, fn.Some[lnwire.MilliSatoshi](50_000),
+		noHint,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, oracle.numQueries)
+
+	// Large quotes and quotes without an amount always get a fresh rate.
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](1_000_000))
+	require.Equal(t, 3, oracle.numQueries)
+	queryAsk(noUnits, noMsat)
+	require.Equal(t, 4, oracle.numQueries)
+
+	// Asset amounts are valued using the cached rate.
+	queryAsk(fn.Some[uint64](10), noMsat)
+	require.Equal(t, 4, oracle.numQueries)
+	queryAsk(fn.Some[uint64](1_000), noMsat)
+	require.Equal(t, 5, oracle.numQueries)
+
+	// Once the cached rate is too old, a fresh rate is queried again.
+	testClock.SetTime(testClock.Now().Add(2 * time.Minute))
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](50_000))
+	require.Equal(t, 6, oracle.numQueries)
+
+	stats := negotiator.rateCache.Stats()
+	require.EqualValues(t, 2, stats.Hits)
+	require.EqualValues(t, 3, stats.Misses)
+
+	// A quote with an asset rate hint always gets a fresh rate, even if a
+	// cached rate is available.
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](50_000))
+	require.Equal(t, 6, oracle.numQueries)
+
+	hint := fn.Some(rfqmsg.NewAssetRate(
+		rfqmath.NewBigIntFixedPoint(50_000_000, 0),
+		testClock.Now().Add(time.Hour),
+	))
+	_, err = negotiator.queryAskFromPriceOracle(
+		specifier, noUnits, fn.Some[lnwire.MilliSatoshi](50_000), hint,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 7, oracle.numQueries)
+
+	// The rate returned for the hinted quote isn't cached either, so the
+	// next quote without a hint still uses the previously cached rate.
+	queryAsk(noUnits, fn.Some[lnwire.MilliSatoshi](50_000))
+	require.Equal(t, 7, oracle.numQueries)
+
+	stats = negotiator.rateCache.Stats()
+	require.EqualValues(t, 4, stats.Hits)
+	require.EqualValues(t, 3, stats.Misses)
+}
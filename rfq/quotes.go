@@ -0,0 +1,218 @@
+package rfq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// ErrQuoteHistoryDisabled is returned if the quote history is queried
+	// but accepted quotes aren't recorded.
+	ErrQuoteHistoryDisabled = errors.New("quote history is not recorded")
+)
+
+const (
+	// DefaultQuoteQueryLimit is the maximum number of quotes that are
+	// returned by a quote query that doesn't specify a limit.
+	DefaultQuoteQueryLimit = 1000
+)
+
+// QuoteState is the state of a recorded quote.
+type QuoteState uint8
+
+const (
+	// QuoteStateActive is the state of a quote that hasn't expired yet.
+	QuoteStateActive QuoteState = 0
+
+	// QuoteStateExpired is the state of a quote that has expired.
+	QuoteStateExpired QuoteState = 1
+)
+
+// String returns the human-readable name of the quote state.
+func (s QuoteState) String() string {
+	switch s {
+	case QuoteStateActive:
+		return "active"
+
+	case QuoteStateExpired:
+		return "expired"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// QuoteRecord is a quote that was accepted, either by our node or by one of
+// our peers, together with the volume that was realized under it.
+type QuoteRecord struct {
+	// ID is the ID of the quote request the quote was accepted for.
+	ID rfqmsg.ID
+
+	// Peer is the peer the quote was negotiated with.
+	Peer route.Vertex
+
+	// IsBuy is true for a buy quote, where the requesting node buys the
+	// asset, and false for a sell quote, where the requesting node sells
+	// the asset.
+	IsBuy bool
+
+	// AcceptedByPeer is true if the peer accepted a quote our node
+	// requested, and false if our node accepted a quote the peer
+	// requested.
+	AcceptedByPeer bool
+
+	// AssetSpecifier is the asset of the quote.
+	AssetSpecifier asset.Specifier
+
+	// AssetMaxAmt is the maximum asset amount of a buy quote.
+	AssetMaxAmt uint64
+
+	// PaymentMaxAmt is the maximum payment amount of a sell quote.
+	PaymentMaxAmt lnwire.MilliSatoshi
+
+	// AssetRate is the accepted rate and its expiry.
+	AssetRate rfqmsg.AssetRate
+
+	// AcceptedAt is the time the quote was accepted.
+	AcceptedAt time.Time
+
+	// NumHtlcs is the number of settled HTLCs that were accepted under
+	// the quote. Only the HTLCs of quotes our node accepted are tracked.
+	NumHtlcs uint64
+
+	// AssetAmount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64
+
+	// AmountMsat is the sum of the BTC amounts of the settled HTLCs.
+	AmountMsat lnwire.MilliSatoshi
+}
+
+// State returns the state of the quote at the given time.
+func (q *QuoteRecord) State(now time.Time) QuoteState {
+	if now.Before(q.AssetRate.Expiry) {
+		return QuoteStateActive
+	}
+
+	return QuoteStateExpired
+}
+
+// newBuyQuoteRecord creates a quote record from an accepted buy quote.
+func newBuyQuoteRecord(quote rfqmsg.BuyAccept, acceptedByPeer bool,
+	acceptedAt time.Time) QuoteRecord {
+
+	return QuoteRecord{
+		ID:             quote.ID,
+		Peer:           quote.Peer,
+		IsBuy:          true,
+		AcceptedByPeer: acceptedByPeer,
+		AssetSpecifier: quote.Request.AssetSpecifier,
+		AssetMaxAmt:    quote.Request.AssetMaxAmt,
+		AssetRate:      quote.AssetRate,
+		AcceptedAt:     acceptedAt,
+	}
+}
+
+// newSellQuoteRecord creates a quote record from an accepted sell quote.
+func newSellQuoteRecord(quote rfqmsg.SellAccept, acceptedByPeer bool,
+	acceptedAt time.Time) QuoteRecord {
+
+	return QuoteRecord{
+		ID:             quote.ID,
+		Peer:           quote.Peer,
+		AcceptedByPeer: acceptedByPeer,
+		AssetSpecifier: quote.Request.AssetSpecifier,
+		PaymentMaxAmt:  quote.Request.PaymentMaxAmt,
+		AssetRate:      quote.AssetRate,
+		AcceptedAt:     acceptedAt,
+	}
+}
+
+// QuoteVolume is the realized volume of all quotes of a single asset that
+// match a quote query.
+type QuoteVolume struct {
+	// AssetSpecifier is the asset of the quotes.
+	AssetSpecifier asset.Specifier
+
+	// NumQuotes is the number of quotes.
+	NumQuotes uint64
+
+	// NumHtlcs is the number of settled HTLCs that were accepted under
+	// the quotes.
+	NumHtlcs uint64
+
+	// AssetAmount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64
+
+	// AmountMsat is the sum of the BTC amounts of the settled HTLCs.
+	AmountMsat lnwire.MilliSatoshi
+}
+
+// AvgRate returns the average realized rate of the settled HTLCs in asset units
+// per BTC. None is returned if there was no BTC volume.
+func (v *QuoteVolume) AvgRate() fn.Option[rfqmath.BigIntFixedPoint] {
+	return avgRate(v.AssetAmount, v.AmountMsat)
+}
+
+// QuoteQuery is a query for recorded quotes.
+type QuoteQuery struct {
+	// Peer, if set, restricts the result to the given peer.
+	Peer fn.Option[route.Vertex]
+
+	// AssetSpecifier, if set, restricts the result to the given asset.
+	AssetSpecifier fn.Option[asset.Specifier]
+
+	// IsBuy, if set, restricts the result to buy or sell quotes.
+	IsBuy fn.Option[bool]
+
+	// AcceptedByPeer, if set, restricts the result to quotes accepted by
+	// our peers or to quotes accepted by our node.
+	AcceptedByPeer fn.Option[bool]
+
+	// State, if set, restricts the result to quotes in the given state.
+	State fn.Option[QuoteState]
+
+	// StartTime and EndTime restrict the result to quotes accepted in the
+	// given range, both inclusive.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Offset is the number of matching quotes to skip.
+	Offset uint32
+
+	// Limit is the maximum number of quotes to return. This is ignored
+	// when querying the volume.
+	Limit uint32
+}
+
+// QuoteStore is an interface for a persistent store of accepted quotes.
+type QuoteStore interface {
+	// AddQuote records an accepted quote. Recording the same quote twice
+	// has no effect.
+	AddQuote(ctx context.Context, quote QuoteRecord) error
+
+	// AddQuoteVolume adds the volume of the given delta to the realized
+	// volume of the quote with the given ID.
+	AddQuoteVolume(ctx context.Context, id rfqmsg.ID,
+		delta SettlementStats) error
+
+	// QueryQuotes returns the quotes matching the given query, ordered by
+	// the time they were accepted. The state of the quotes is evaluated
+	// at the given time.
+	QueryQuotes(ctx context.Context, q QuoteQuery,
+		now time.Time) ([]QuoteRecord, error)
+
+	// QueryQuoteVolume returns the realized volume of the quotes matching
+	// the given query, aggregated per asset. The state of the quotes is
+	// evaluated at the given time.
+	QueryQuoteVolume(ctx context.Context, q QuoteQuery,
+		now time.Time) ([]QuoteVolume, error)
+}
@@ -0,0 +1,310 @@
+package rfq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// MaxReputationScore is the highest reputation score a peer can have.
+	MaxReputationScore = 100
+
+	// acceptanceWeight is the weight of the quote acceptance rate in the
+	// reputation score.
+	acceptanceWeight = 0.4
+
+	// htlcSuccessWeight is the weight of the HTLC success rate in the
+	// reputation score.
+	htlcSuccessWeight = 0.4
+
+	// rateWeight is the weight of the rate competitiveness in the
+	// reputation score.
+	rateWeight = 0.2
+
+	// rateCompetitivenessRangePpm is the average deviation from our price
+	// oracle's rate at which a peer's rates are considered maximally
+	// competitive (or uncompetitive, if the deviation is to our
+	// disadvantage).
+	rateCompetitivenessRangePpm = 50_000
+)
+
+var (
+	// ErrNoReputablePeer is returned if all candidate peers are excluded
+	// because their reputation score is below the configured minimum.
+	ErrNoReputablePeer = errors.New("no candidate peer has the minimum " +
+		"reputation score")
+)
+
+// PeerStats holds the metrics collected about a peer we request quotes from.
+// The same struct is used as a delta when updating the stored stats.
+type PeerStats struct {
+	// Peer is the public key of the peer.
+	Peer route.Vertex
+
+	// QuotesAccepted is the number of quotes the peer accepted with terms
+	// that passed our validation.
+	QuotesAccepted uint64
+
+	// QuotesRejected is the number of quotes the peer rejected or accepted
+	// with terms that failed our validation.
+	QuotesRejected uint64
+
+	// HtlcsSettled is the number of payments that settled using a quote of
+	// the peer.
+	HtlcsSettled uint64
+
+	// HtlcsFailed is the number of HTLCs the peer failed after quoting.
+	HtlcsFailed uint64
+
+	// RateDeviationPpmSum is the sum of the deviations of the peer's
+	// accepted quote rates from our price oracle's rate, in parts per
+	// million. A positive deviation means the rate was to our advantage.
+	RateDeviationPpmSum int64
+
+	// RateSamples is the number of deviations that were summed up in
+	// RateDeviationPpmSum.
+	RateSamples uint64
+
+	// UpdatedAt is the time the stats were last updated.
+	UpdatedAt time.Time
+}
+
+// Score returns the reputation score of the peer, between 0 and
+// MaxReputationScore. The quote acceptance and HTLC success rates are
+// smoothed, so a peer without any history starts with a neutral score that
+// moves with every observation.
+func (s *PeerStats) Score() uint8 {
+	acceptance := smoothedRatio(s.QuotesAccepted, s.QuotesRejected)
+	htlcSuccess := smoothedRatio(s.HtlcsSettled, s.HtlcsFailed)
+
+	competitiveness := 0.5
+	if s.RateSamples > 0 {
+		avgPpm := float64(s.RateDeviationPpmSum) /
+			float64(s.RateSamples)
+		competitiveness = 0.5 + avgPpm/(2*rateCompetitivenessRangePpm)
+		competitiveness = math.Max(0, math.Min(1, competitiveness))
+	}
+
+	score := acceptanceWeight*acceptance + htlcSuccessWeight*htlcSuccess +
+		rateWeight*competitiveness
+
+	return uint8(math.Round(score * MaxReputationScore))
+}
+
+// smoothedRatio returns the ratio of good observations using Laplace
+// smoothing, which yields 0.5 if there are no observations.
+func smoothedRatio(good, bad uint64) float64 {
+	return (float64(good) + 1) / (float64(good) + float64(bad) + 2)
+}
+
+// PeerStatsStore is an interface for a persistent store of peer stats.
+type PeerStatsStore interface {
+	// AddPeerStats adds the counters of the given delta to the stored
+	// stats of the peer, creating them if they don't exist yet.
+	AddPeerStats(ctx context.Context, delta PeerStats) error
+
+	// FetchPeerStats returns the stats of the given peer. Empty stats are
+	// returned if there are none stored for the peer.
+	FetchPeerStats(ctx context.Context, peer route.Vertex) (*PeerStats,
+		error)
+
+	// QueryPeerStats returns the stats of all peers.
+	QueryPeerStats(ctx context.Context) ([]PeerStats, error)
+}
+
+// ReputationPolicy determines how the reputation of peers is used when
+// selecting the peer to request a quote from.
+type ReputationPolicy struct {
+	// MinScore is the minimum reputation score a peer needs to be selected.
+	// Peers below are excluded. A value of zero disables the exclusion.
+	MinScore uint8
+
+	// PreferHighScore, if set, selects the peer with the highest score if
+	// there are multiple candidates. Otherwise, no peer is selected in
+	// that case and the caller needs to specify the peer explicitly.
+	PreferHighScore bool
+}
+
+// PeerReputation tracks the reputation of the peers we request quotes from
+// and selects peers according to a reputation policy.
+type PeerReputation struct {
+	store  PeerStatsStore
+	policy ReputationPolicy
+}
+
+// NewPeerReputation creates a new peer reputation tracker. If the store is
+// nil, no stats are recorded and all peers have a neutral score.
+func NewPeerReputation(store PeerStatsStore,
+	policy ReputationPolicy) *PeerReputation {
+
+	return &PeerReputation{
+		store:  store,
+		policy: policy,
+	}
+}
+
+// record adds the given delta to the stats of the peer. Errors are only
+// logged, as the reputation is best effort and must not interfere with the
+// quote negotiation.
+func (r *PeerReputation) record(ctx context.Context, delta PeerStats) {
+	if r.store == nil {
+		return
+	}
+
+	delta.UpdatedAt = time.Now().UTC()
+	if err := r.store.AddPeerStats(ctx, delta); err != nil {
+		log.Warnf("Unable to update stats of peer %v: %v", delta.Peer,
+			err)
+	}
+}
+
+// RecordQuoteAccepted records that the peer accepted a quote with valid
+// terms.
+func (r *PeerReputation) RecordQuoteAccepted(ctx context.Context,
+	peer route.Vertex) {
+
+	r.record(ctx, PeerStats{
+		Peer:           peer,
+		QuotesAccepted: 1,
+	})
+}
+
+// RecordQuoteRejected records that the peer rejected a quote or accepted it
+// with invalid terms.
+func (r *PeerReputation) RecordQuoteRejected(ctx context.Context,
+	peer route.Vertex) {
+
+	r.record(ctx, PeerStats{
+		Peer:           peer,
+		QuotesRejected: 1,
+	})
+}
+
+// RecordRateDeviation records the deviation of a peer's quoted rate from our
+// price oracle's rate. For buy quotes a higher number of asset units per BTC is
+// to our advantage, for sell quotes a lower one.
+func (r *PeerReputation) RecordRateDeviation(ctx context.Context,
+	peer route.Vertex, isBuy bool, peerRate, oracleRate rfqmsg.AssetRate) {
+
+	oracleFloat := oracleRate.Rate.ToFloat64()
+	if oracleFloat == 0 {
+		return
+	}
+
+	deviation := (peerRate.Rate.ToFloat64() - oracleFloat) / oracleFloat
+	if !isBuy {
+		deviation = -deviation
+	}
+
+	r.record(ctx, PeerStats{
+		Peer:                peer,
+		RateDeviationPpmSum: int64(math.Round(deviation * 1_000_000)),
+		RateSamples:         1,
+	})
+}
+
+// RecordPaymentResult records the outcome of a payment that used a quote of
+// the peer. The number of failed HTLCs only includes failures that were caused
+// by the peer.
+func (r *PeerReputation) RecordPaymentResult(ctx context.Context,
+	peer route.Vertex, settled bool, failedHtlcs uint64) {
+
+	delta := PeerStats{
+		Peer:        peer,
+		HtlcsFailed: failedHtlcs,
+	}
+	if settled {
+		delta.HtlcsSettled = 1
+	}
+
+	if delta.HtlcsSettled == 0 && delta.HtlcsFailed == 0 {
+		return
+	}
+
+	r.record(ctx, delta)
+}
+
+// PeerStats returns the stats of the given peer.
+func (r *PeerReputation) PeerStats(ctx context.Context,
+	peer route.Vertex) (*PeerStats, error) {
+
+	if r.store == nil {
+		return &PeerStats{Peer: peer}, nil
+	}
+
+	return r.store.FetchPeerStats(ctx, peer)
+}
+
+// AllPeerStats returns the stats of all peers we have a history with.
+func (r *PeerReputation) AllPeerStats(ctx context.Context) ([]PeerStats,
+	error) {
+
+	if r.store == nil {
+		return nil, nil
+	}
+
+	return r.store.QueryPeerStats(ctx)
+}
+
+// SelectPeer selects the peer to request a quote from out of the given
+// candidates according to the reputation policy. Candidates below the minimum
+// score are excluded, and ErrNoReputablePeer is returned if no candidate
+// remains. If multiple candidates remain, the one with the highest score is
+// returned if the policy prefers high scores. Otherwise, fn.None is returned
+// to signal that the choice is ambiguous.
+func (r *PeerReputation) SelectPeer(ctx context.Context,
+	candidates []route.Vertex) (fn.Option[route.Vertex], error) {
+
+	type scoredPeer struct {
+		peer  route.Vertex
+		score uint8
+	}
+
+	scored := make([]scoredPeer, 0, len(candidates))
+	for _, peer := range candidates {
+		stats, err := r.PeerStats(ctx, peer)
+		if err != nil {
+			return fn.None[route.Vertex](), fmt.Errorf("unable to "+
+				"fetch stats of peer %v: %w", peer, err)
+		}
+
+		score := stats.Score()
+		if score < r.policy.MinScore {
+			log.Debugf("Excluding peer %v with reputation score "+
+				"%d from quote requests", peer, score)
+			continue
+		}
+
+		scored = append(scored, scoredPeer{
+			peer:  peer,
+			score: score,
+		})
+	}
+
+	switch {
+	case len(scored) == 0:
+		return fn.None[route.Vertex](), ErrNoReputablePeer
+
+	case len(scored) == 1:
+		return fn.Some(scored[0].peer), nil
+
+	case !r.policy.PreferHighScore:
+		return fn.None[route.Vertex](), nil
+	}
+
+	// We sort stably, so candidates with the same score keep the order
+	// they were given in.
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	return fn.Some(scored[0].peer), nil
+}
@@ -0,0 +1,222 @@
+package rfq
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPeerStatsStore is an in-memory implementation of the PeerStatsStore
+// interface.
+type mockPeerStatsStore struct {
+	mtx   sync.Mutex
+	stats map[route.Vertex]PeerStats
+}
+
+// newMockPeerStatsStore creates a new in-memory peer stats store.
+func newMockPeerStatsStore() *mockPeerStatsStore {
+	return &mockPeerStatsStore{
+		stats: make(map[route.Vertex]PeerStats),
+	}
+}
+
+// AddPeerStats adds the counters of the given delta to the stats of the peer.
+func (m *mockPeerStatsStore) AddPeerStats(_ context.Context,
+	delta PeerStats) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	stats := m.stats[delta.Peer]
+	stats.Peer = delta.Peer
+	stats.QuotesAccepted += delta.QuotesAccepted
+	stats.QuotesRejected += delta.QuotesRejected
+	stats.HtlcsSettled += delta.HtlcsSettled
+	stats.HtlcsFailed += delta.HtlcsFailed
+	stats.RateDeviationPpmSum += delta.RateDeviationPpmSum
+	stats.RateSamples += delta.RateSamples
+	stats.UpdatedAt = delta.UpdatedAt
+	m.stats[delta.Peer] = stats
+
+	return nil
+}
+
+// FetchPeerStats returns the stats of the given peer.
+func (m *mockPeerStatsStore) FetchPeerStats(_ context.Context,
+	peer route.Vertex) (*PeerStats, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	stats := m.stats[peer]
+	stats.Peer = peer
+
+	return &stats, nil
+}
+
+// QueryPeerStats returns the stats of all peers.
+func (m *mockPeerStatsStore) QueryPeerStats(
+	_ context.Context) ([]PeerStats, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	result := make([]PeerStats, 0, len(m.stats))
+	for _, stats := range m.stats {
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+// TestPeerStatsScore tests the computation of the reputation score.
+func TestPeerStatsScore(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		stats PeerStats
+		score uint8
+	}{{
+		name:  "no history",
+		stats: PeerStats{},
+		score: 50,
+	}, {
+		name: "perfect peer",
+		stats: PeerStats{
+			QuotesAccepted:      98,
+			HtlcsSettled:        98,
+			RateDeviationPpmSum: 10 * rateCompetitivenessRangePpm,
+			RateSamples:         10,
+		},
+		score: 99,
+	}, {
+		name: "unreliable peer",
+		stats: PeerStats{
+			QuotesRejected:      98,
+			HtlcsFailed:         98,
+			RateDeviationPpmSum: -10 * rateCompetitivenessRangePpm,
+			RateSamples:         10,
+		},
+		score: 1,
+	}, {
+		name: "accepts quotes but fails HTLCs",
+		stats: PeerStats{
+			QuotesAccepted: 8,
+			HtlcsFailed:    8,
+		},
+		score: 50,
+	}, {
+		name: "slightly better rates",
+		stats: PeerStats{
+			RateDeviationPpmSum: rateCompetitivenessRangePpm / 2,
+			RateSamples:         1,
+		},
+		score: 55,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.score, tc.stats.Score())
+		})
+	}
+}
+
+// TestPeerReputation tests that the reputation tracker records the metrics of
+// peers and selects peers according to the policy.
+func TestPeerReputation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	goodPeer := route.Vertex{1}
+	badPeer := route.Vertex{2}
+	newPeer := route.Vertex{3}
+
+	store := newMockPeerStatsStore()
+	reputation := NewPeerReputation(store, ReputationPolicy{
+		MinScore: 40,
+	})
+
+	for i := 0; i < 5; i++ {
+		reputation.RecordQuoteAccepted(ctx, goodPeer)
+		reputation.RecordPaymentResult(ctx, goodPeer, true, 0)
+
+		reputation.RecordQuoteRejected(ctx, badPeer)
+		reputation.RecordPaymentResult(ctx, badPeer, false, 2)
+	}
+
+	// Payments without a result that can be attributed to the peer aren't
+	// recorded.
+	reputation.RecordPaymentResult(ctx, newPeer, false, 0)
+
+	// A buy quote with more asset units per BTC than the oracle's rate is
+	// to our advantage, a sell quote is not.
+	oracleRate := rfqmsg.NewAssetRate(
+		rfqmath.NewBigIntFixedPoint(100_000, 0), time.Now(),
+	)
+	peerRate := rfqmsg.NewAssetRate(
+		rfqmath.NewBigIntFixedPoint(101_000, 0), time.Now(),
+	)
+	reputation.RecordRateDeviation(
+		ctx, goodPeer, true, peerRate, oracleRate,
+	)
+	reputation.RecordRateDeviation(
+		ctx, badPeer, false, peerRate, oracleRate,
+	)
+
+	goodStats, err := reputation.PeerStats(ctx, goodPeer)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, goodStats.QuotesAccepted)
+	require.EqualValues(t, 5, goodStats.HtlcsSettled)
+	require.EqualValues(t, 10_000, goodStats.RateDeviationPpmSum)
+	require.EqualValues(t, 1, goodStats.RateSamples)
+
+	badStats, err := reputation.PeerStats(ctx, badPeer)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, badStats.QuotesRejected)
+	require.EqualValues(t, 10, badStats.HtlcsFailed)
+	require.EqualValues(t, -10_000, badStats.RateDeviationPpmSum)
+
+	allStats, err := reputation.AllPeerStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, allStats, 2)
+
+	// The bad peer is excluded, which leaves only the good peer.
+	selected, err := reputation.SelectPeer(
+		ctx, []route.Vertex{badPeer, goodPeer},
+	)
+	require.NoError(t, err)
+	require.Equal(t, fn.Some(goodPeer), selected)
+
+	_, err = reputation.SelectPeer(ctx, []route.Vertex{badPeer})
+	require.ErrorIs(t, err, ErrNoReputablePeer)
+
+	// With multiple acceptable peers, the choice is ambiguous unless the
+	// policy prefers reputable peers.
+	selected, err = reputation.SelectPeer(
+		ctx, []route.Vertex{newPeer, goodPeer},
+	)
+	require.NoError(t, err)
+	require.True(t, selected.IsNone())
+
+	reputation.policy.PreferHighScore = true
+	selected, err = reputation.SelectPeer(
+		ctx, []route.Vertex{newPeer, badPeer, goodPeer},
+	)
+	require.NoError(t, err)
+	require.Equal(t, fn.Some(goodPeer), selected)
+
+	// Without a store, all peers are neutral and nothing is recorded.
+	noStore := NewPeerReputation(nil, ReputationPolicy{})
+	noStore.RecordQuoteRejected(ctx, badPeer)
+	stats, err := noStore.PeerStats(ctx, badPeer)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, stats.Score())
+}
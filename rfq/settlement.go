@@ -0,0 +1,481 @@
+package rfq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// ErrSettlementStatsDisabled is returned if settlement stats are
+	// queried but not collected.
+	ErrSettlementStatsDisabled = errors.New("settlement stats are not " +
+		"collected")
+)
+
+const (
+	// maxPendingSettlementAge is the maximum time an accepted HTLC is
+	// tracked while waiting for its final outcome. HTLCs can't stay
+	// pending for longer than their CLTV expiry, so this only cleans up
+	// HTLCs whose final event we missed, for example because lnd was
+	// restarted in the meantime.
+	maxPendingSettlementAge = 14 * 24 * time.Hour
+)
+
+// SettlementStats is the settled asset HTLC volume of a single asset and peer
+// on a single day. The same struct is used as a delta when updating the stored
+// stats.
+type SettlementStats struct {
+	// AssetSpecifier is the asset the HTLCs carried, as specified by the
+	// quotes they were accepted under.
+	AssetSpecifier asset.Specifier
+
+	// Peer is the peer the quotes were negotiated with.
+	Peer route.Vertex
+
+	// Day is the start of the UTC day the HTLCs settled on.
+	Day time.Time
+
+	// NumHtlcs is the number of settled HTLCs.
+	NumHtlcs uint64
+
+	// AssetAmount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64
+
+	// AmountMsat is the sum of the BTC amounts of the settled HTLCs.
+	AmountMsat lnwire.MilliSatoshi
+
+	// QuoteID is the ID of the quote the HTLCs were accepted under. It is
+	// only set on the deltas of single HTLCs and isn't part of the
+	// aggregated stats.
+	QuoteID rfqmsg.ID
+}
+
+// AvgRate returns the average realized rate of the settled HTLCs in asset units
+// per BTC. None is returned if there was no BTC volume.
+func (s *SettlementStats) AvgRate() fn.Option[rfqmath.BigIntFixedPoint] {
+	return avgRate(s.AssetAmount, s.AmountMsat)
+}
+
+// avgRate returns the average rate in asset units per BTC of the given asset
+// and BTC amounts. None is returned if the BTC amount is zero.
+func avgRate(assetAmount uint64,
+	amountMsat lnwire.MilliSatoshi) fn.Option[rfqmath.BigIntFixedPoint] {
+
+	if amountMsat == 0 {
+		return fn.None[rfqmath.BigIntFixedPoint]()
+	}
+
+	// We scale everything up to have enough precision for the division,
+	// then scale the result back down to whole asset units.
+	const arithmeticScale = 11
+	units := rfqmath.FixedPointFromUint64[rfqmath.BigInt](
+		assetAmount, arithmeticScale,
+	)
+	oneBtcInMilliSat := rfqmath.FixedPointFromUint64[rfqmath.BigInt](
+		uint64(btcutil.SatoshiPerBitcoin*1_000), arithmeticScale,
+	)
+	amtMsat := rfqmath.FixedPointFromUint64[rfqmath.BigInt](
+		uint64(amountMsat), arithmeticScale,
+	)
+
+	rate := units.Mul(oneBtcInMilliSat).Div(amtMsat)
+
+	return fn.Some(rate.ScaleTo(0))
+}
+
+// SettlementStatsQuery is a query for settlement stats.
+type SettlementStatsQuery struct {
+	// AssetSpecifier, if set, restricts the result to the given asset.
+	AssetSpecifier fn.Option[asset.Specifier]
+
+	// Peer, if set, restricts the result to the given peer.
+	Peer fn.Option[route.Vertex]
+
+	// StartDay and EndDay restrict the result to the given range of days,
+	// both inclusive. The stats of the whole day a time falls on are
+	// included.
+	StartDay time.Time
+	EndDay   time.Time
+}
+
+// SettlementStatsStore is an interface for a persistent store of settlement
+// stats.
+type SettlementStatsStore interface {
+	// AddSettlementStats adds the counters of the given delta to the
+	// stored stats of the delta's asset, peer and day, creating them if
+	// they don't exist yet.
+	AddSettlementStats(ctx context.Context, delta SettlementStats) error
+
+	// QuerySettlementStats returns the stats matching the given query,
+	// ordered by day.
+	QuerySettlementStats(ctx context.Context,
+		q SettlementStatsQuery) ([]SettlementStats, error)
+}
+
+// HtlcEventSubscriber is an interface that allows subscribing to the HTLC
+// events of lnd's switch.
+type HtlcEventSubscriber interface {
+	// SubscribeHtlcEvents subscribes to a stream of HTLC events from the
+	// router.
+	SubscribeHtlcEvents(ctx context.Context) (<-chan *routerrpc.HtlcEvent,
+		<-chan error, error)
+}
+
+// settlementDay returns the start of the UTC day of the given time.
+func settlementDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// htlcSettlements returns the asset volume an HTLC that was accepted under the
+// given policy contributes to the settlement stats once it settles. Forwards
+// contribute to the stats of both the incoming and the outgoing quote.
+func htlcSettlements(htlc lndclient.InterceptedHtlc,
+	policy Policy) ([]SettlementStats, error) {
+
+	switch p := policy.(type) {
+	// We sell assets over an outgoing asset channel, so the asset amount
+	// is derived from the outgoing BTC amount.
+	case *AssetSalePolicy:
+		assetAmt := rfqmath.MilliSatoshiToUnits(
+			htlc.AmountOutMsat, p.AskAssetRate,
+		)
+
+		return []SettlementStats{{
+			AssetSpecifier: p.AssetSpecifier,
+			Peer:           p.Peer,
+			NumHtlcs:       1,
+			AssetAmount:    assetAmt.ScaleTo(0).ToUint64(),
+			AmountMsat:     htlc.AmountOutMsat,
+			QuoteID:        p.AcceptedQuoteId,
+		}}, nil
+
+	// We buy the assets of an incoming asset HTLC, so the asset amount is
+	// taken from the HTLC's custom records.
+	case *AssetPurchasePolicy:
+		htlcRecord, err := parseHtlcCustomRecords(
+			htlc.InWireCustomRecords,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parsing HTLC custom records "+
+				"failed: %w", err)
+		}
+
+		return []SettlementStats{{
+			AssetSpecifier: p.AssetSpecifier,
+			Peer:           p.Peer,
+			NumHtlcs:       1,
+			AssetAmount:    htlcRecord.Amounts.Val.Sum(),
+			AmountMsat:     htlc.AmountOutMsat,
+			QuoteID:        p.AcceptedQuoteId,
+		}}, nil
+
+	case *AssetForwardPolicy:
+		incoming, err := htlcSettlements(htlc, p.incomingPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		outgoing, err := htlcSettlements(htlc, p.outgoingPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(incoming, outgoing...), nil
+
+	default:
+		return nil, fmt.Errorf("unknown policy type %T", policy)
+	}
+}
+
+// pendingSettlement is an HTLC that was accepted under an RFQ policy and is
+// waiting for its final outcome.
+type pendingSettlement struct {
+	// settlements is the volume the HTLC contributes to the stats once it
+	// settles.
+	settlements []SettlementStats
+
+	// acceptedAt is the time the HTLC was accepted.
+	acceptedAt time.Time
+
+	// inFlight is the asset amount the HTLC carries while it is waiting
+	// for its final outcome.
+	inFlight []InFlightAssets
+}
+
+// htlcAssetDirections returns for each of the settlements htlcSettlements
+// returns for the given policy whether its assets leave our node.
+func htlcAssetDirections(policy Policy) []bool {
+	switch p := policy.(type) {
+	case *AssetSalePolicy:
+		return []bool{true}
+
+	case *AssetPurchasePolicy:
+		return []bool{false}
+
+	case *AssetForwardPolicy:
+		return append(
+			htlcAssetDirections(p.incomingPolicy),
+			htlcAssetDirections(p.outgoingPolicy)...,
+		)
+
+	default:
+		return nil
+	}
+}
+
+// SettlementTrackerCfg is the configuration of the settlement tracker.
+type SettlementTrackerCfg struct {
+	// Store is the store the settlement stats are persisted in.
+	Store SettlementStatsStore
+
+	// QuoteStore is the store the realized volume of the quotes the HTLCs
+	// were accepted under is added to. This is optional.
+	QuoteStore QuoteStore
+
+	// HtlcEvents is used to learn about the final outcome of the HTLCs
+	// that were accepted under an RFQ policy.
+	HtlcEvents HtlcEventSubscriber
+
+	// ErrChan is the error channel critical errors are reported on.
+	ErrChan chan<- error
+}
+
+// SettlementTracker keeps track of the HTLCs that were accepted under an RFQ
+// policy and adds their volume to the persisted settlement stats once they
+// settle. The stats are thus aggregated incrementally instead of being
+// computed from the raw HTLC data on demand.
+type SettlementTracker struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg SettlementTrackerCfg
+
+	mu sync.Mutex
+
+	// pending holds the accepted HTLCs that are waiting for their final
+	// outcome, keyed by their incoming circuit key.
+	pending map[models.CircuitKey]*pendingSettlement
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewSettlementTracker creates a new settlement tracker.
+func NewSettlementTracker(cfg SettlementTrackerCfg) *SettlementTracker {
+	return &SettlementTracker{
+		cfg:     cfg,
+		pending: make(map[models.CircuitKey]*pendingSettlement),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: DefaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start subscribes to the HTLC events of lnd and starts processing them.
+func (t *SettlementTracker) Start() error {
+	var startErr error
+	t.startOnce.Do(func() {
+		log.Info("Starting RFQ settlement tracker")
+
+		ctx, cancel := t.WithCtxQuitNoTimeout()
+		events, errChan, err := t.cfg.HtlcEvents.SubscribeHtlcEvents(
+			ctx,
+		)
+		if err != nil {
+			cancel()
+			startErr = fmt.Errorf("unable to subscribe to HTLC "+
+				"events: %w", err)
+			return
+		}
+
+		t.Wg.Add(1)
+		go func() {
+			defer t.Wg.Done()
+			defer cancel()
+
+			t.eventLoop(ctx, events, errChan)
+		}()
+	})
+
+	return startErr
+}
+
+// Stop stops the settlement tracker.
+func (t *SettlementTracker) Stop() error {
+	t.stopOnce.Do(func() {
+		log.Info("Stopping RFQ settlement tracker")
+
+		close(t.Quit)
+		t.Wg.Wait()
+	})
+
+	return nil
+}
+
+// eventLoop processes the HTLC events until the tracker is stopped or the
+// subscription fails.
+func (t *SettlementTracker) eventLoop(ctx context.Context,
+	events <-chan *routerrpc.HtlcEvent, errChan <-chan error) {
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			t.handleHtlcEvent(ctx, event)
+
+		case err, ok := <-errChan:
+			if !ok || ctx.Err() != nil {
+				return
+			}
+
+			// Without the HTLC events, the settlement stats can't
+			// be collected anymore, so this is a critical error.
+			t.cfg.ErrChan <- fn.NewCriticalError(fmt.Errorf(
+				"HTLC event subscription failed: %w", err,
+			))
+
+			return
+
+		case <-t.Quit:
+			return
+		}
+	}
+}
+
+// TrackHtlc starts tracking an HTLC that was accepted under an RFQ policy, so
+// its volume can be added to the settlement stats once it settles.
+func (t *SettlementTracker) TrackHtlc(event *AcceptHtlcEvent) {
+	settlements, err := htlcSettlements(event.Htlc, event.Policy)
+	if err != nil {
+		log.Warnf("Unable to determine settlement volume of HTLC %v: "+
+			"%v", event.Htlc.IncomingCircuitKey, err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// We clean up HTLCs whose final outcome we missed, so they don't
+	// accumulate forever.
+	now := time.Now()
+	for key, pending := range t.pending {
+		if now.Sub(pending.acceptedAt) > maxPendingSettlementAge {
+			delete(t.pending, key)
+		}
+	}
+
+	directions := htlcAssetDirections(event.Policy)
+	inFlight := make([]InFlightAssets, 0, len(settlements))
+	for idx, settlement := range settlements {
+		if idx >= len(directions) {
+			break
+		}
+
+		inFlight = append(inFlight, InFlightAssets{
+			AssetSpecifier: settlement.AssetSpecifier,
+			Units:          settlement.AssetAmount,
+			Outgoing:       directions[idx],
+		})
+	}
+
+	t.pending[event.Htlc.IncomingCircuitKey] = &pendingSettlement{
+		settlements: settlements,
+		acceptedAt:  now,
+		inFlight:    inFlight,
+	}
+}
+
+// InFlightHtlcs returns the asset amounts of the tracked HTLCs that are still
+// waiting for their final outcome.
+func (t *SettlementTracker) InFlightHtlcs() []InFlightAssets {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var inFlight []InFlightAssets
+	for _, pending := range t.pending {
+		inFlight = append(inFlight, pending.inFlight...)
+	}
+
+	return inFlight
+}
+
+// handleHtlcEvent adds the volume of a tracked HTLC to the settlement stats
+// once it was settled irrevocably, or stops tracking it if it failed.
+func (t *SettlementTracker) handleHtlcEvent(ctx context.Context,
+	event *routerrpc.HtlcEvent) {
+
+	finalEvent := event.GetFinalHtlcEvent()
+	if finalEvent == nil {
+		return
+	}
+
+	key := models.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(event.IncomingChannelId),
+		HtlcID: event.IncomingHtlcId,
+	}
+
+	t.mu.Lock()
+	pending, ok := t.pending[key]
+	delete(t.pending, key)
+	t.mu.Unlock()
+
+	if !ok || !finalEvent.Settled {
+		return
+	}
+
+	// The event timestamp is the time the outcome was final, which is the
+	// time we attribute the settlement to.
+	day := settlementDay(time.Unix(0, int64(event.TimestampNs)))
+	for _, delta := range pending.settlements {
+		delta.Day = day
+
+		// The stats are best effort and must not interfere with the
+		// HTLC processing, so we only log errors.
+		err := t.cfg.Store.AddSettlementStats(ctx, delta)
+		if err != nil {
+			log.Warnf("Unable to update settlement stats of peer "+
+				"%v: %v", delta.Peer, err)
+		}
+
+		if t.cfg.QuoteStore == nil {
+			continue
+		}
+
+		err = t.cfg.QuoteStore.AddQuoteVolume(ctx, delta.QuoteID, delta)
+		if err != nil {
+			log.Warnf("Unable to update volume of quote %v: %v",
+				delta.QuoteID, err)
+		}
+	}
+}
+
+// QuerySettlementStats returns the settlement stats matching the given query.
+// The start and end of the query are extended to cover the whole days they
+// fall on.
+func (t *SettlementTracker) QuerySettlementStats(ctx context.Context,
+	q SettlementStatsQuery) ([]SettlementStats, error) {
+
+	q.StartDay = settlementDay(q.StartDay)
+	q.EndDay = settlementDay(q.EndDay)
+
+	return t.cfg.Store.QuerySettlementStats(ctx, q)
+}
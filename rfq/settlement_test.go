@@ -0,0 +1,274 @@
+package rfq
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSettlementStatsStore is an in-memory implementation of the
+// SettlementStatsStore interface that records all deltas.
+type mockSettlementStatsStore struct {
+	mtx    sync.Mutex
+	deltas []SettlementStats
+}
+
+// AddSettlementStats records the given delta.
+func (m *mockSettlementStatsStore) AddSettlementStats(_ context.Context,
+	delta SettlementStats) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.deltas = append(m.deltas, delta)
+
+	return nil
+}
+
+// QuerySettlementStats returns all recorded deltas within the queried days.
+func (m *mockSettlementStatsStore) QuerySettlementStats(_ context.Context,
+	q SettlementStatsQuery) ([]SettlementStats, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var result []SettlementStats
+	for _, delta := range m.deltas {
+		if delta.Day.Before(q.StartDay) || delta.Day.After(q.EndDay) {
+			continue
+		}
+
+		result = append(result, delta)
+	}
+
+	return result, nil
+}
+
+// numDeltas returns the number of recorded deltas.
+func (m *mockSettlementStatsStore) numDeltas() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.deltas)
+}
+
+// mockQuoteStore is an in-memory implementation of the QuoteStore interface
+// that only records the realized volume per quote.
+type mockQuoteStore struct {
+	mtx     sync.Mutex
+	volumes map[rfqmsg.ID]SettlementStats
+}
+
+// AddQuote does nothing.
+func (m *mockQuoteStore) AddQuote(context.Context, QuoteRecord) error {
+	return nil
+}
+
+// AddQuoteVolume adds the volume of the given delta to the recorded volume of
+// the quote.
+func (m *mockQuoteStore) AddQuoteVolume(_ context.Context, id rfqmsg.ID,
+	delta SettlementStats) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	volume := m.volumes[id]
+	volume.NumHtlcs += delta.NumHtlcs
+	volume.AssetAmount += delta.AssetAmount
+	volume.AmountMsat += delta.AmountMsat
+	m.volumes[id] = volume
+
+	return nil
+}
+
+// QueryQuotes returns no quotes.
+func (m *mockQuoteStore) QueryQuotes(context.Context, QuoteQuery,
+	time.Time) ([]QuoteRecord, error) {
+
+	return nil, nil
+}
+
+// QueryQuoteVolume returns no volume.
+func (m *mockQuoteStore) QueryQuoteVolume(context.Context, QuoteQuery,
+	time.Time) ([]QuoteVolume, error) {
+
+	return nil, nil
+}
+
+// volume returns the recorded volume of the given quote.
+func (m *mockQuoteStore) volume(id rfqmsg.ID) SettlementStats {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.volumes[id]
+}
+
+// mockHtlcEventSubscriber is a mock implementation of the
+// HtlcEventSubscriber interface that forwards the events sent on its channel.
+type mockHtlcEventSubscriber struct {
+	events chan *routerrpc.HtlcEvent
+	errs   chan error
+}
+
+// SubscribeHtlcEvents returns the event and error channels of the mock.
+func (m *mockHtlcEventSubscriber) SubscribeHtlcEvents(
+	context.Context) (<-chan *routerrpc.HtlcEvent, <-chan error, error) {
+
+	return m.events, m.errs, nil
+}
+
+// finalHtlcEvent creates a final HTLC event for the given circuit key.
+func finalHtlcEvent(key models.CircuitKey, settled bool,
+	timestamp time.Time) *routerrpc.HtlcEvent {
+
+	return &routerrpc.HtlcEvent{
+		IncomingChannelId: key.ChanID.ToUint64(),
+		IncomingHtlcId:    key.HtlcID,
+		TimestampNs:       uint64(timestamp.UnixNano()),
+		Event: &routerrpc.HtlcEvent_FinalHtlcEvent{
+			FinalHtlcEvent: &routerrpc.FinalHtlcEvent{
+				Settled: settled,
+			},
+		},
+	}
+}
+
+// TestSettlementTracker tests that the volume of accepted HTLCs is only added
+// to the settlement stats once they settle.
+func TestSettlementTracker(t *testing.T) {
+	t.Parallel()
+
+	store := &mockSettlementStatsStore{}
+	quoteStore := &mockQuoteStore{
+		volumes: make(map[rfqmsg.ID]SettlementStats),
+	}
+	subscriber := &mockHtlcEventSubscriber{
+		events: make(chan *routerrpc.HtlcEvent),
+		errs:   make(chan error),
+	}
+	errChan := make(chan error, 1)
+
+	tracker := NewSettlementTracker(SettlementTrackerCfg{
+		Store:      store,
+		QuoteStore: quoteStore,
+		HtlcEvents: subscriber,
+		ErrChan:    errChan,
+	})
+	require.NoError(t, tracker.Start())
+	t.Cleanup(func() {
+		require.NoError(t, tracker.Stop())
+	})
+
+	// We sell 100 units over a quote with a rate of 100k units per BTC,
+	// which corresponds to 0.001 BTC.
+	specifier := asset.NewSpecifierFromId(asset.ID{1, 2, 3})
+	peer := route.Vertex{2, 1}
+	quoteID := rfqmsg.ID{7}
+	policy := &AssetSalePolicy{
+		AssetSpecifier:  specifier,
+		AskAssetRate:    rfqmath.NewBigIntFixedPoint(100_000, 0),
+		Peer:            peer,
+		AcceptedQuoteId: quoteID,
+	}
+
+	settledKey := models.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(1),
+		HtlcID: 1,
+	}
+	failedKey := models.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(1),
+		HtlcID: 2,
+	}
+	for _, key := range []models.CircuitKey{settledKey, failedKey} {
+		tracker.TrackHtlc(NewAcceptHtlcEvent(lndclient.InterceptedHtlc{
+			IncomingCircuitKey: key,
+			AmountOutMsat:      100_000_000,
+		}, policy))
+	}
+
+	// Both HTLCs are in flight until their final outcome is known.
+	require.Equal(t, []InFlightAssets{{
+		AssetSpecifier: specifier,
+		Units:          100,
+		Outgoing:       true,
+	}, {
+		AssetSpecifier: specifier,
+		Units:          100,
+		Outgoing:       true,
+	}}, tracker.InFlightHtlcs())
+
+	// Events that aren't final, failed HTLCs and HTLCs that aren't
+	// tracked don't change the stats.
+	settleTime := time.Date(2024, 5, 1, 13, 37, 0, 0, time.UTC)
+	subscriber.events <- &routerrpc.HtlcEvent{
+		IncomingChannelId: settledKey.ChanID.ToUint64(),
+		IncomingHtlcId:    settledKey.HtlcID,
+		Event:             &routerrpc.HtlcEvent_SettleEvent{},
+	}
+	subscriber.events <- finalHtlcEvent(failedKey, false, settleTime)
+	subscriber.events <- finalHtlcEvent(
+		models.CircuitKey{HtlcID: 3}, true, settleTime,
+	)
+
+	// The settled HTLC is attributed to the day it settled on.
+	subscriber.events <- finalHtlcEvent(settledKey, true, settleTime)
+	require.Eventually(t, func() bool {
+		return store.numDeltas() == 1
+	}, DefaultTimeout, 10*time.Millisecond)
+
+	stats, err := tracker.QuerySettlementStats(
+		context.Background(), SettlementStatsQuery{
+			StartDay: settleTime,
+			EndDay:   settleTime,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []SettlementStats{{
+		AssetSpecifier: specifier,
+		Peer:           peer,
+		Day:            time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		NumHtlcs:       1,
+		AssetAmount:    100,
+		AmountMsat:     100_000_000,
+		QuoteID:        quoteID,
+	}}, stats)
+
+	// The volume is also added to the quote it was accepted under.
+	require.Eventually(t, func() bool {
+		return quoteStore.volume(quoteID) == SettlementStats{
+			NumHtlcs:    1,
+			AssetAmount: 100,
+			AmountMsat:  100_000_000,
+		}
+	}, DefaultTimeout, 10*time.Millisecond)
+
+	// The average rate is derived from the settled amounts.
+	avgRate := stats[0].AvgRate()
+	require.True(t, avgRate.IsSome())
+	require.Equal(
+		t, "100000", fn.MapOptionZ(
+			avgRate, func(r rfqmath.BigIntFixedPoint) string {
+				return r.Coefficient.String()
+			},
+		),
+	)
+
+	// The HTLC isn't tracked anymore, so a repeated event is ignored.
+	subscriber.events <- finalHtlcEvent(settledKey, true, settleTime)
+	require.NoError(t, tracker.Stop())
+	require.Equal(t, 1, store.numDeltas())
+	require.Empty(t, tracker.InFlightHtlcs())
+	require.Empty(t, errChan)
+}
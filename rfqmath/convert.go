@@ -1,6 +1,7 @@
 package rfqmath
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/btcsuite/btcd/btcutil"
@@ -97,3 +98,74 @@ func UnitsToMilliSatoshi[N Int[N]](assetUnits,
 	// along the way.
 	return lnwire.MilliSatoshi(amtMsat.ScaleTo(0).ToUint64())
 }
+
+// MilliSatoshiToUnitsRounded converts the given milli-satoshi amount to a
+// number of whole asset units, using the given price in units per bitcoin as
+// a fixed point in the asset's desired resolution. In contrast to
+// MilliSatoshiToUnits, the result is computed exactly and only rounded once
+// using the given rounding mode. An OverflowError is returned if the number of
+// units doesn't fit into a uint64.
+//
+// Given the amount of mSat (X), the number of units per BTC (Y) and its scale
+// (S), we compute the total amount of units (U) as follows:
+//   - U = (X * Y) / (M * 10^S)
+//   - where M is the number of mSAT in a BTC (100,000,000,000).
+func MilliSatoshiToUnitsRounded[N Int[N]](milliSat lnwire.MilliSatoshi,
+	unitsPerBtc FixedPoint[N], mode RoundingMode) (uint64, error) {
+
+	numerator := NewInt[N]().FromUint64(uint64(milliSat)).Mul(
+		unitsPerBtc.Coefficient,
+	)
+	denominator := oneBtcInMilliSat[N]().Mul(pow10[N](unitsPerBtc.Scale))
+
+	units, err := divRound(numerator, denominator, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	return toUint64(units)
+}
+
+// UnitsToMilliSatoshiRounded converts the given number of asset units to a
+// milli-satoshi amount, using the given price in units per bitcoin as a fixed
+// point in the asset's desired resolution. In contrast to UnitsToMilliSatoshi,
+// the result is computed exactly and only rounded once using the given
+// rounding mode. An OverflowError is returned if the amount doesn't fit into a
+// uint64.
+//
+// Given the amount of asset units (U) with scale (A), and the number of units
+// per BTC (Y) with scale (S), we compute the total amount of mSAT (X) as
+// follows:
+//   - X = (U * M * 10^S) / (Y * 10^A)
+//   - where M is the number of mSAT in a BTC (100,000,000,000).
+func UnitsToMilliSatoshiRounded[N Int[N]](assetUnits,
+	unitsPerBtc FixedPoint[N], mode RoundingMode) (lnwire.MilliSatoshi,
+	error) {
+
+	zero := NewInt[N]().FromUint64(0)
+	if unitsPerBtc.Coefficient.Equals(zero) {
+		return 0, fmt.Errorf("units per BTC must be positive")
+	}
+
+	numerator := assetUnits.Coefficient.Mul(oneBtcInMilliSat[N]()).Mul(
+		pow10[N](unitsPerBtc.Scale),
+	)
+	denominator := unitsPerBtc.Coefficient.Mul(pow10[N](assetUnits.Scale))
+
+	milliSat, err := divRound(numerator, denominator, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	amt, err := toUint64(milliSat)
+	if err != nil {
+		return 0, err
+	}
+
+	return lnwire.MilliSatoshi(amt), nil
+}
+
+// oneBtcInMilliSat returns the number of mSAT in a BTC as an integer.
+func oneBtcInMilliSat[N Int[N]]() N {
+	return NewInt[N]().FromUint64(uint64(btcutil.SatoshiPerBitcoin * 1_000))
+}
@@ -67,6 +67,43 @@ func (f FixedPoint[T]) ScaleTo(newScale uint8) FixedPoint[T] {
 	}
 }
 
+// ScaleToRounded returns a new FixedPoint that is scaled up or down to the
+// given scale. Unlike ScaleTo, the digits that are dropped when scaling down
+// are rounded using the given rounding mode. A PrecisionLossError is returned
+// if the RoundExact mode is used and any of the dropped digits is non-zero.
+func (f FixedPoint[T]) ScaleToRounded(newScale uint8,
+	mode RoundingMode) (FixedPoint[T], error) {
+
+	// Scaling up never loses precision, so there's nothing to round.
+	if newScale >= f.Scale {
+		return f.ScaleTo(newScale), nil
+	}
+
+	coefficient, err := divRound(
+		f.Coefficient, pow10[T](f.Scale-newScale), mode,
+	)
+	if err != nil {
+		return FixedPoint[T]{}, err
+	}
+
+	return FixedPoint[T]{
+		Coefficient: coefficient,
+		Scale:       newScale,
+	}, nil
+}
+
+// ToUint64Rounded returns the number of whole units the FixedPoint represents,
+// rounded using the given rounding mode. An OverflowError is returned if the
+// number of units doesn't fit into a uint64.
+func (f FixedPoint[T]) ToUint64Rounded(mode RoundingMode) (uint64, error) {
+	units, err := f.ScaleToRounded(0, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	return toUint64(units.Coefficient)
+}
+
 // ToUint64 returns a new FixedPoint that is scaled down from the existing scale
 // and mapped to a uint64 representing the amount of units. This should be used
 // to go from FixedPoint to an amount of "units".
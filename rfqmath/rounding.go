@@ -0,0 +1,170 @@
+package rfqmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// RoundingMode determines how the digits that are dropped when a fixed point
+// value is scaled down or converted to an integer amount are rounded.
+type RoundingMode uint8
+
+const (
+	// RoundFloor rounds towards zero by dropping the fractional digits.
+	// This is how ScaleTo and the plain conversion functions round.
+	RoundFloor RoundingMode = 0
+
+	// RoundCeil rounds up to the next integer if any of the dropped
+	// digits is non-zero.
+	RoundCeil RoundingMode = 1
+
+	// RoundHalfEven rounds to the nearest integer, and to the nearest even
+	// integer if the dropped digits are exactly half way between two
+	// integers. This is also known as banker's rounding, which doesn't
+	// introduce a bias when many amounts are rounded.
+	RoundHalfEven RoundingMode = 2
+
+	// RoundExact doesn't round at all and instead fails with a
+	// PrecisionLossError if any of the dropped digits is non-zero.
+	RoundExact RoundingMode = 3
+)
+
+// String returns the human-readable name of the rounding mode.
+func (r RoundingMode) String() string {
+	switch r {
+	case RoundFloor:
+		return "floor"
+
+	case RoundCeil:
+		return "ceil"
+
+	case RoundHalfEven:
+		return "half-even"
+
+	case RoundExact:
+		return "exact"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// OverflowError is returned if the result of a conversion doesn't fit into the
+// integer type it is returned as.
+type OverflowError struct {
+	// Value is the decimal representation of the result that overflowed.
+	Value string
+
+	// Target is the name of the type the result didn't fit into.
+	Target string
+}
+
+// Error returns the error message of the overflow error.
+//
+// NOTE: This is part of the error interface.
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %s overflows %s", e.Value, e.Target)
+}
+
+// PrecisionLossError is returned if a value would need to be rounded while
+// the RoundExact rounding mode is used.
+type PrecisionLossError struct {
+	// Numerator is the decimal representation of the numerator of the
+	// division that didn't have an integer result.
+	Numerator string
+
+	// Denominator is the decimal representation of the denominator of the
+	// division that didn't have an integer result.
+	Denominator string
+}
+
+// Error returns the error message of the precision loss error.
+//
+// NOTE: This is part of the error interface.
+func (e *PrecisionLossError) Error() string {
+	return fmt.Sprintf("%s / %s is not an integer, rounding would lose "+
+		"precision", e.Numerator, e.Denominator)
+}
+
+// divRound divides the numerator by the denominator and rounds the quotient
+// using the given rounding mode. Both values must be positive.
+func divRound[N Int[N]](numerator, denominator N,
+	mode RoundingMode) (N, error) {
+
+	var (
+		zero = NewInt[N]().FromUint64(0)
+		one  = NewInt[N]().FromUint64(1)
+		two  = NewInt[N]().FromUint64(2)
+	)
+
+	if mode > RoundExact {
+		return zero, fmt.Errorf("unknown rounding mode: %v", mode)
+	}
+
+	quotient := numerator.Div(denominator)
+	remainder := numerator.Sub(quotient.Mul(denominator))
+	if remainder.Equals(zero) {
+		return quotient, nil
+	}
+
+	switch mode {
+	case RoundCeil:
+		return quotient.Add(one), nil
+
+	case RoundHalfEven:
+		// We compare twice the remainder to the denominator, to find
+		// out whether the dropped fraction is more or less than half.
+		twiceRemainder := remainder.Add(remainder)
+		switch {
+		case twiceRemainder.Gt(denominator):
+			return quotient.Add(one), nil
+
+		case twiceRemainder.Equals(denominator):
+			// On a tie we round to the even neighbour.
+			parity := quotient.Sub(quotient.Div(two).Mul(two))
+			if !parity.Equals(zero) {
+				return quotient.Add(one), nil
+			}
+		}
+
+		return quotient, nil
+
+	case RoundExact:
+		return zero, &PrecisionLossError{
+			Numerator:   fmt.Sprintf("%v", numerator),
+			Denominator: fmt.Sprintf("%v", denominator),
+		}
+
+	// RoundFloor simply drops the remainder.
+	default:
+		return quotient, nil
+	}
+}
+
+// pow10 returns 10 to the power of the given exponent. Unlike converting the
+// result of math.Pow10, this doesn't lose precision for large exponents.
+func pow10[N Int[N]](exponent uint8) N {
+	var (
+		result = NewInt[N]().FromUint64(1)
+		ten    = NewInt[N]().FromUint64(10)
+	)
+	for i := uint8(0); i < exponent; i++ {
+		result = result.Mul(ten)
+	}
+
+	return result
+}
+
+// toUint64 returns the given value as a uint64, or an OverflowError if it
+// doesn't fit.
+func toUint64[N Int[N]](value N) (uint64, error) {
+	maxUint64 := NewInt[N]().FromUint64(math.MaxUint64)
+	if value.Gt(maxUint64) {
+		return 0, &OverflowError{
+			Value:  fmt.Sprintf("%v", value),
+			Target: "uint64",
+		}
+	}
+
+	return value.ToUint64(), nil
+}
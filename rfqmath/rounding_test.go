@@ -0,0 +1,204 @@
+package rfqmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// TestScaleToRounded tests that scaling a fixed point value down rounds the
+// dropped digits according to the rounding mode.
+func TestScaleToRounded(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		coefficient uint64
+		mode        RoundingMode
+		expected    uint64
+		expectedErr string
+	}{
+		// 1.24 is rounded to a single decimal.
+		{124, RoundFloor, 12, ""},
+		{124, RoundCeil, 13, ""},
+		{124, RoundHalfEven, 12, ""},
+		{124, RoundExact, 0, "rounding would lose precision"},
+
+		// 1.26 is rounded to a single decimal.
+		{126, RoundFloor, 12, ""},
+		{126, RoundCeil, 13, ""},
+		{126, RoundHalfEven, 13, ""},
+
+		// Ties are rounded to the even neighbour.
+		{125, RoundHalfEven, 12, ""},
+		{135, RoundHalfEven, 14, ""},
+		{5, RoundHalfEven, 0, ""},
+		{15, RoundHalfEven, 2, ""},
+
+		// Values without dropped digits are never rounded.
+		{120, RoundFloor, 12, ""},
+		{120, RoundCeil, 12, ""},
+		{120, RoundHalfEven, 12, ""},
+		{120, RoundExact, 12, ""},
+		{0, RoundCeil, 0, ""},
+
+		// Unknown rounding modes are rejected.
+		{124, RoundingMode(100), 0, "unknown rounding mode"},
+	}
+
+	for _, tc := range testCases {
+		fp := NewBigIntFixedPoint(tc.coefficient, 2)
+		scaled, err := fp.ScaleToRounded(1, tc.mode)
+		if tc.expectedErr != "" {
+			require.ErrorContains(t, err, tc.expectedErr)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, uint8(1), scaled.Scale)
+		require.Equal(
+			t, tc.expected, scaled.Coefficient.ToUint64(),
+			"%d with mode %v", tc.coefficient, tc.mode,
+		)
+	}
+
+	// Scaling up is never rounded.
+	fp := NewBigIntFixedPoint(125, 2)
+	scaled, err := fp.ScaleToRounded(4, RoundExact)
+	require.NoError(t, err)
+	require.True(t, scaled.Equals(NewBigIntFixedPoint(12_500, 4)))
+}
+
+// TestToUint64RoundedOverflow tests that an overflow of the uint64 result is
+// reported.
+func TestToUint64RoundedOverflow(t *testing.T) {
+	t.Parallel()
+
+	fp := FixedPointFromUint64[BigInt](math.MaxUint64, 2)
+	units, err := fp.ToUint64Rounded(RoundFloor)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), units)
+
+	// Adding half a unit overflows when rounding up, but not when rounding
+	// down.
+	fp.Coefficient = fp.Coefficient.Add(NewBigIntFromUint64(50))
+	units, err = fp.ToUint64Rounded(RoundFloor)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), units)
+
+	_, err = fp.ToUint64Rounded(RoundCeil)
+	var overflowErr *OverflowError
+	require.ErrorAs(t, err, &overflowErr)
+	require.Equal(t, "18446744073709551616", overflowErr.Value)
+}
+
+// TestConversionRounded tests the rounding of the conversions between asset
+// units and milli-satoshis with concrete values.
+func TestConversionRounded(t *testing.T) {
+	t.Parallel()
+
+	// At a price of 3 units per BTC, a third of a BTC is one unit.
+	unitsPerBtc := NewBigIntFixedPoint(3, 0)
+	thirdBtc := lnwire.MilliSatoshi(100_000_000_000 / 3)
+
+	units, err := MilliSatoshiToUnitsRounded(
+		thirdBtc, unitsPerBtc, RoundFloor,
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), units)
+
+	units, err = MilliSatoshiToUnitsRounded(
+		thirdBtc, unitsPerBtc, RoundHalfEven,
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), units)
+
+	_, err = MilliSatoshiToUnitsRounded(thirdBtc, unitsPerBtc, RoundExact)
+	var precisionErr *PrecisionLossError
+	require.ErrorAs(t, err, &precisionErr)
+
+	// One unit is worth a third of a BTC, which isn't a whole number of
+	// milli-satoshis.
+	oneUnit := NewBigIntFixedPoint(1, 0)
+	mSat, err := UnitsToMilliSatoshiRounded(
+		oneUnit, unitsPerBtc, RoundFloor,
+	)
+	require.NoError(t, err)
+	require.Equal(t, thirdBtc, mSat)
+
+	mSat, err = UnitsToMilliSatoshiRounded(oneUnit, unitsPerBtc, RoundCeil)
+	require.NoError(t, err)
+	require.Equal(t, thirdBtc+1, mSat)
+
+	_, err = UnitsToMilliSatoshiRounded(oneUnit, unitsPerBtc, RoundExact)
+	require.ErrorAs(t, err, &precisionErr)
+
+	// Three units are exactly one BTC.
+	mSat, err = UnitsToMilliSatoshiRounded(
+		NewBigIntFixedPoint(3, 0), unitsPerBtc, RoundExact,
+	)
+	require.NoError(t, err)
+	require.Equal(t, lnwire.MilliSatoshi(100_000_000_000), mSat)
+
+	// A zero price can't be converted.
+	_, err = UnitsToMilliSatoshiRounded(
+		oneUnit, NewBigIntFixedPoint(0, 0), RoundFloor,
+	)
+	require.ErrorContains(t, err, "must be positive")
+
+	// A huge price overflows the number of units.
+	hugePrice := FixedPointFromUint64[BigInt](math.MaxUint64, 0)
+	_, err = MilliSatoshiToUnitsRounded(
+		lnwire.MilliSatoshi(200_000_000_000), hugePrice, RoundFloor,
+	)
+	var overflowErr *OverflowError
+	require.ErrorAs(t, err, &overflowErr)
+}
+
+// testConversionRoundingModes tests that the rounding modes of a conversion
+// only ever differ by one and are consistent with the plain conversion.
+func testConversionRoundingModes(t *rapid.T) {
+	decDisplay := rapid.Uint8Range(0, 8).Draw(t, "decDisplay")
+	btcPrice := rapid.Uint64Range(1, 1_000_000_000).Draw(t, "btcPrice")
+	mSat := lnwire.MilliSatoshi(
+		rapid.Uint64Range(1, 1_000_000_000_000).Draw(t, "mSat"),
+	)
+	unitsPerBtc := FixedPointFromUint64[BigInt](btcPrice, decDisplay)
+
+	convert := func(mode RoundingMode) uint64 {
+		units, err := MilliSatoshiToUnitsRounded(
+			mSat, unitsPerBtc, mode,
+		)
+		require.NoError(t, err)
+
+		return units
+	}
+
+	floor := convert(RoundFloor)
+	ceil := convert(RoundCeil)
+	halfEven := convert(RoundHalfEven)
+
+	require.LessOrEqual(t, floor, halfEven)
+	require.LessOrEqual(t, halfEven, ceil)
+	require.LessOrEqual(t, ceil-floor, uint64(1))
+
+	// The plain conversion truncates intermediate results, so it can only
+	// ever be below the exact result.
+	plain := MilliSatoshiToUnits(mSat, unitsPerBtc).ScaleTo(0).ToUint64()
+	require.LessOrEqual(t, plain, floor)
+
+	// If the result is exact, all modes agree.
+	_, err := MilliSatoshiToUnitsRounded(mSat, unitsPerBtc, RoundExact)
+	if err == nil {
+		require.Equal(t, floor, ceil)
+	}
+}
+
+// TestConversionRoundingModes runs the property based rounding mode test.
+func TestConversionRoundingModes(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, testConversionRoundingModes)
+}
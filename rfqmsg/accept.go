@@ -163,8 +163,9 @@ func (m *acceptWireMsgData) Decode(r io.Reader) error {
 		return err
 	}
 
-	// Decode the reader's contents into the tlv stream.
-	_, err = tlvStream.DecodeWithParsedTypes(r)
+	// Decode the reader's contents into the tlv stream. The message is
+	// received from a peer, so we limit the record size.
+	_, err = tlvStream.DecodeWithParsedTypesP2P(r)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,77 @@
+package rfqmsg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzIncomingMsgFromWire(f *testing.F) {
+	var peer route.Vertex
+	assetSpecifier := asset.NewSpecifierFromId(asset.ID{1})
+	assetRate := NewAssetRate(
+		rfqmath.NewBigIntFixedPoint(100, 2), time.Now().Add(time.Hour),
+	)
+
+	buyRequest, err := NewBuyRequest(
+		peer, assetSpecifier, 1000, fn.Some(assetRate),
+	)
+	require.NoError(f, err)
+
+	sellRequest, err := NewSellRequest(
+		peer, assetSpecifier, 1000, fn.Some(assetRate),
+	)
+	require.NoError(f, err)
+
+	// The seed corpus contains one message of each type.
+	outgoingMsgs := []OutgoingMsg{
+		buyRequest,
+		sellRequest,
+		NewBuyAcceptFromRequest(*buyRequest, assetRate),
+		NewReject(peer, buyRequest.ID, ErrUnknownReject),
+	}
+	for _, msg := range outgoingMsgs {
+		wireMsg, err := msg.ToWire()
+		require.NoError(f, err)
+
+		f.Add(uint16(wireMsg.MsgType), wireMsg.Data)
+	}
+
+	// Accept messages can only be decoded if there is a matching request,
+	// so we pretend to have sent a request for every ID.
+	sessionLookup := func(id ID) (OutgoingMsg, bool) {
+		if id[0]%2 == 0 {
+			return buyRequest, true
+		}
+
+		return sellRequest, true
+	}
+
+	f.Fuzz(func(t *testing.T, msgType uint16, data []byte) {
+		wireMsg := WireMessage{
+			Peer:    peer,
+			MsgType: lnwire.MessageType(msgType),
+			Data:    data,
+		}
+
+		_, _ = NewIncomingMsgFromWire(wireMsg, sessionLookup)
+	})
+}
+
+func FuzzDecodeHtlc(f *testing.F) {
+	f.Add(NewHtlc(nil, fn.None[ID]()).Bytes())
+	f.Add(NewHtlc(
+		[]*AssetBalance{NewAssetBalance(asset.ID{1}, 1000)},
+		fn.Some(ID{2}),
+	).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeHtlc(data)
+	})
+}
@@ -130,3 +130,24 @@ func TestHtlc(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkHtlcFromCustomRecords measures decoding the HTLC custom records,
+// which happens for every HTLC that is forwarded or settled by an asset
+// channel.
+func BenchmarkHtlcFromCustomRecords(b *testing.B) {
+	htlc := NewHtlc([]*AssetBalance{
+		NewAssetBalance([32]byte{1}, 1000),
+		NewAssetBalance([32]byte{2}, 2000),
+	}, fn.Some(ID{0, 1, 2, 3, 4, 5, 6, 7}))
+
+	customRecords, err := htlc.ToCustomRecords()
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := HtlcFromCustomRecords(customRecords)
+		require.NoError(b, err)
+	}
+}
@@ -436,8 +436,9 @@ func (m *requestWireMsgData) Decode(r io.Reader) error {
 		return err
 	}
 
-	// Decode the reader's contents into the tlv stream.
-	tlvMap, err := tlvStream.DecodeWithParsedTypes(r)
+	// Decode the reader's contents into the tlv stream. The message is
+	// received from a peer, so we limit the record size.
+	tlvMap, err := tlvStream.DecodeWithParsedTypesP2P(r)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,385 @@
+package rpcjournal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// DefaultQueueSize is the default number of entries that can be
+	// queued for writing before new calls block.
+	DefaultQueueSize = 1000
+
+	// DefaultQueryLimit is the default maximum number of entries that are
+	// returned by a single query.
+	DefaultQueryLimit = 1000
+
+	// maxBatchSize is the maximum number of entries that are written to
+	// the store at once.
+	maxBatchSize = 100
+
+	// defaultTimeout is the timeout used when writing entries to the
+	// store.
+	defaultTimeout = 30 * time.Second
+)
+
+// Entry is a single entry of the RPC journal.
+type Entry struct {
+	// Method is the full gRPC method name of the call.
+	Method string
+
+	// Caller is the identity of the caller, derived from the ID of the
+	// macaroon the call was authenticated with. It is empty if the call
+	// didn't use a macaroon.
+	Caller string
+
+	// PeerAddr is the network address of the caller.
+	PeerAddr string
+
+	// Params are the JSON encoded request parameters, with secrets
+	// redacted.
+	Params string
+
+	// StatusCode is the gRPC status code the call finished with.
+	StatusCode string
+
+	// ErrorMsg is the error message of a failed call.
+	ErrorMsg string
+
+	// StartTime is the time the call was received at.
+	StartTime time.Time
+
+	// EndTime is the time the call finished at.
+	EndTime time.Time
+}
+
+// Query is a query for journal entries.
+type Query struct {
+	// StartAfter is the earliest start time of the returned entries.
+	StartAfter time.Time
+
+	// StartBefore is the latest start time of the returned entries.
+	StartBefore time.Time
+
+	// Method optionally restricts the returned entries to a single
+	// method.
+	Method fn.Option[string]
+
+	// Offset is the number of entries to skip.
+	Offset int32
+
+	// Limit is the maximum number of entries to return.
+	Limit int32
+}
+
+// Store is a persistent, append-only store of journal entries.
+type Store interface {
+	// AppendEntries appends the given entries to the journal.
+	AppendEntries(ctx context.Context, entries []Entry) error
+
+	// QueryEntries returns the journal entries matching the given query,
+	// in the order they were appended.
+	QueryEntries(ctx context.Context, query Query) ([]Entry, error)
+}
+
+// Config houses all the items that the journal needs to carry out its
+// duties.
+type Config struct {
+	// Store is the store the entries are written to.
+	Store Store
+
+	// IsMutating returns true if the call of the given full gRPC method
+	// changes the state of the daemon and should be journaled.
+	IsMutating func(fullMethod string) bool
+
+	// Clock is used to timestamp the entries.
+	Clock clock.Clock
+
+	// QueueSize is the number of entries that can be queued for writing
+	// before new calls block.
+	QueueSize int
+}
+
+// Journal records all mutating RPC calls in an append-only journal. The
+// entries are written to the store asynchronously, so the calls themselves
+// aren't slowed down by the database.
+type Journal struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *Config
+
+	entries chan Entry
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// New creates a new RPC journal.
+func New(cfg *Config) *Journal {
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+
+	return &Journal{
+		cfg:     cfg,
+		entries: make(chan Entry, queueSize),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: defaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start starts the journal writer.
+func (j *Journal) Start() error {
+	j.startOnce.Do(func() {
+		log.Info("Starting RPC journal")
+
+		j.Wg.Add(1)
+		go j.writeEntries()
+	})
+
+	return nil
+}
+
+// Stop stops the journal writer after all queued entries were written.
+func (j *Journal) Stop() error {
+	j.stopOnce.Do(func() {
+		log.Info("Stopping RPC journal")
+
+		close(j.Quit)
+		j.Wg.Wait()
+	})
+
+	return nil
+}
+
+// writeEntries is the main loop of the journal writer. It writes the queued
+// entries to the store in batches.
+//
+// NOTE: This MUST be run as a goroutine.
+func (j *Journal) writeEntries() {
+	defer j.Wg.Done()
+
+	for {
+		select {
+		case entry := <-j.entries:
+			j.writeBatch(j.collectBatch(entry))
+
+		case <-j.Quit:
+			// Write out everything that is still queued before we
+			// exit.
+			for {
+				select {
+				case entry := <-j.entries:
+					j.writeBatch(j.collectBatch(entry))
+
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// collectBatch collects the given entry and any further queued entries into a
+// batch.
+func (j *Journal) collectBatch(first Entry) []Entry {
+	batch := []Entry{first}
+	for len(batch) < maxBatchSize {
+		select {
+		case entry := <-j.entries:
+			batch = append(batch, entry)
+
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// writeBatch writes the given batch of entries to the store.
+func (j *Journal) writeBatch(batch []Entry) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), defaultTimeout,
+	)
+	defer cancel()
+
+	if err := j.cfg.Store.AppendEntries(ctx, batch); err != nil {
+		log.Errorf("Unable to write %d RPC journal entries: %v",
+			len(batch), err)
+	}
+}
+
+// record queues the given entry for writing. If the queue is full, this
+// blocks until there is space again, so no entry is lost.
+func (j *Journal) record(entry Entry) {
+	select {
+	case j.entries <- entry:
+	case <-j.Quit:
+		log.Warnf("RPC journal stopped, dropping entry for %v",
+			entry.Method)
+	}
+}
+
+// newEntry creates the journal entry of a call to the given method.
+func (j *Journal) newEntry(ctx context.Context, fullMethod string, req any,
+	startTime time.Time, err error) Entry {
+
+	params, redactErr := RedactParams(req)
+	if redactErr != nil {
+		log.Warnf("Unable to encode params of %v: %v", fullMethod,
+			redactErr)
+		params = "{}"
+	}
+
+	entry := Entry{
+		Method:     fullMethod,
+		Caller:     callerFromContext(ctx),
+		Params:     params,
+		StatusCode: status.Code(err).String(),
+		StartTime:  startTime.UTC(),
+		EndTime:    j.cfg.Clock.Now().UTC(),
+	}
+	if err != nil {
+		entry.ErrorMsg = err.Error()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		entry.PeerAddr = p.Addr.String()
+	}
+
+	return entry
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that records all mutating
+// unary calls.
+func (j *Journal) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		if !j.cfg.IsMutating(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		startTime := j.cfg.Clock.Now()
+		resp, err := handler(ctx, req)
+
+		j.record(j.newEntry(ctx, info.FullMethod, req, startTime, err))
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that records all
+// mutating streaming calls. The messages of a stream aren't recorded.
+func (j *Journal) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if !j.cfg.IsMutating(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		startTime := j.cfg.Clock.Now()
+		err := handler(srv, ss)
+
+		j.record(j.newEntry(
+			ss.Context(), info.FullMethod, nil, startTime, err,
+		))
+
+		return err
+	}
+}
+
+// callerFromContext derives the identity of the caller from the macaroon of
+// the call. The identity consists of the root key ID and the nonce of the
+// macaroon, so all macaroons baked from the same root key can be told apart.
+func callerFromContext(ctx context.Context) string {
+	macHex, err := macaroons.RawMacaroonFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return ""
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return ""
+	}
+
+	rootKeyID, nonce, err := DecodeMacaroonID(mac.Id())
+	if err != nil {
+		// Fall back to the raw ID if it isn't in the format we expect.
+		return hex.EncodeToString(mac.Id())
+	}
+
+	return fmt.Sprintf("%s:%x", rootKeyID, nonce)
+}
+
+// DecodeMacaroonID decodes the root key ID and nonce of a version 3 bakery
+// macaroon ID, which consists of a version byte followed by a protobuf
+// encoded message with the nonce as field 1 and the root key ID as field 2.
+func DecodeMacaroonID(id []byte) (string, []byte, error) {
+	const bakeryVersion3 = 3
+	if len(id) == 0 || id[0] != bakeryVersion3 {
+		return "", nil, fmt.Errorf("unknown macaroon ID version")
+	}
+
+	var (
+		rootKeyID []byte
+		nonce     []byte
+	)
+	b := id[1:]
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return "", nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return "", nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch num {
+		case 1:
+			nonce = value
+
+		case 2:
+			rootKeyID = value
+		}
+	}
+
+	return string(rootKeyID), nonce, nil
+}
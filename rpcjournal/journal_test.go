@@ -0,0 +1,150 @@
+package rpcjournal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"gopkg.in/macaroon.v2"
+)
+
+// mockStore is an in-memory implementation of the Store interface.
+type mockStore struct {
+	sync.Mutex
+
+	entries []Entry
+}
+
+// AppendEntries appends the given entries to the journal.
+func (m *mockStore) AppendEntries(_ context.Context, entries []Entry) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.entries = append(m.entries, entries...)
+
+	return nil
+}
+
+// QueryEntries returns all entries of the journal.
+func (m *mockStore) QueryEntries(context.Context, Query) ([]Entry, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	return append([]Entry(nil), m.entries...), nil
+}
+
+// macaroonContext returns an incoming call context that carries a macaroon
+// with the given root key ID and nonce.
+func macaroonContext(t *testing.T, rootKeyID string,
+	nonce []byte) context.Context {
+
+	id := []byte{3}
+	id = protowire.AppendTag(id, 1, protowire.BytesType)
+	id = protowire.AppendBytes(id, nonce)
+	id = protowire.AppendTag(id, 2, protowire.BytesType)
+	id = protowire.AppendBytes(id, []byte(rootKeyID))
+
+	mac, err := macaroon.New([]byte("root"), id, "tapd", macaroon.V2)
+	require.NoError(t, err)
+
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("macaroon", hex.EncodeToString(macBytes)),
+	)
+
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 10029},
+	})
+}
+
+// TestJournalUnaryInterceptor tests that mutating unary calls are recorded
+// with their caller, params and result, while other calls are ignored.
+func TestJournalUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sendMethod = "/taprpc.TaprootAssets/SendAsset"
+		listMethod = "/taprpc.TaprootAssets/ListAssets"
+	)
+
+	store := &mockStore{}
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	journal := New(&Config{
+		Store: store,
+		IsMutating: func(fullMethod string) bool {
+			return fullMethod == sendMethod
+		},
+		Clock: testClock,
+	})
+	require.NoError(t, journal.Start())
+
+	interceptor := journal.UnaryServerInterceptor()
+	ctx := macaroonContext(t, "0", []byte{0x01, 0x02})
+
+	sendErr := status.Error(codes.Unknown, "insufficient balance")
+	sendHandler := func(context.Context, any) (any, error) {
+		return nil, sendErr
+	}
+	listHandler := func(context.Context, any) (any, error) {
+		return &taprpc.ListAssetResponse{}, nil
+	}
+
+	sendReq := &taprpc.SendAssetRequest{
+		TapAddrs: []string{"taptb1abc"},
+	}
+	_, err := interceptor(
+		ctx, sendReq, &grpc.UnaryServerInfo{FullMethod: sendMethod},
+		sendHandler,
+	)
+	require.True(t, errors.Is(err, sendErr))
+
+	_, err = interceptor(
+		ctx, &taprpc.ListAssetRequest{},
+		&grpc.UnaryServerInfo{FullMethod: listMethod}, listHandler,
+	)
+	require.NoError(t, err)
+
+	// Stopping the journal writes out all queued entries.
+	require.NoError(t, journal.Stop())
+
+	entries, err := store.QueryEntries(context.Background(), Query{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entry := entries[0]
+	require.Equal(t, sendMethod, entry.Method)
+	require.Equal(t, "0:0102", entry.Caller)
+	require.Equal(t, "127.0.0.1:10029", entry.PeerAddr)
+	require.Equal(t, `{"tap_addrs":["taptb1abc"]}`, entry.Params)
+	require.Equal(t, codes.Unknown.String(), entry.StatusCode)
+	require.Contains(t, entry.ErrorMsg, "insufficient balance")
+	require.Equal(t, testClock.Now().UTC(), entry.StartTime)
+	require.Equal(t, testClock.Now().UTC(), entry.EndTime)
+}
+
+// TestCallerFromContext tests that calls without a macaroon have an empty
+// caller identity.
+func TestCallerFromContext(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, callerFromContext(context.Background()))
+
+	ctx := macaroonContext(t, "custom", []byte{0xaa})
+	require.Equal(t, "custom:aa", callerFromContext(ctx))
+}
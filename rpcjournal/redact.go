@@ -0,0 +1,118 @@
+package rpcjournal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// redactedValue is the value that replaces the value of a secret
+	// field.
+	redactedValue = "<redacted>"
+
+	// maxValueLen is the maximum length of a single string value that is
+	// stored in the journal. Longer values, such as raw proof files, are
+	// replaced by their length and hash.
+	maxValueLen = 1024
+)
+
+// secretFieldParts is the set of name parts that mark a request field as
+// secret. The value of a field whose snake case name contains any of them as
+// a separate part is never stored in the journal.
+var secretFieldParts = map[string]struct{}{
+	"macaroon":   {},
+	"password":   {},
+	"passphrase": {},
+	"secret":     {},
+	"seed":       {},
+	"mnemonic":   {},
+	"preimage":   {},
+	"private":    {},
+	"priv":       {},
+	"privkey":    {},
+	"token":      {},
+	"master":     {},
+	"xprv":       {},
+}
+
+// isSecretField returns true if the field with the given name holds a secret.
+func isSecretField(name string) bool {
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		if _, ok := secretFieldParts[part]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedactParams encodes the given request message as JSON, with the values of
+// all secret fields redacted and overly long values replaced by their hash.
+func RedactParams(req any) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "{}", nil
+	}
+
+	jsonBytes, err := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode request: %w", err)
+	}
+
+	var params any
+	if err := json.Unmarshal(jsonBytes, &params); err != nil {
+		return "", fmt.Errorf("unable to decode request: %w", err)
+	}
+
+	redacted, err := json.Marshal(redactValue(params))
+	if err != nil {
+		return "", fmt.Errorf("unable to encode params: %w", err)
+	}
+
+	return string(redacted), nil
+}
+
+// redactValue recursively redacts the secret fields of the given decoded JSON
+// value.
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, fieldValue := range v {
+			if isSecretField(key) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = redactValue(fieldValue)
+		}
+
+		return v
+
+	case []any:
+		for idx := range v {
+			v[idx] = redactValue(v[idx])
+		}
+
+		return v
+
+	case string:
+		if len(v) <= maxValueLen {
+			return v
+		}
+
+		hash := sha256.Sum256([]byte(v))
+		return fmt.Sprintf("<%d chars, sha256:%s>", len(v),
+			hex.EncodeToString(hash[:]))
+
+	default:
+		return v
+	}
+}
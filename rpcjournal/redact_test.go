@@ -0,0 +1,63 @@
+package rpcjournal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRedactParams tests that the values of secret fields are redacted and
+// that overly long values are replaced by their hash.
+func TestRedactParams(t *testing.T) {
+	t.Parallel()
+
+	longValue := strings.Repeat("a", maxValueLen+1)
+	req := &lnrpc.InitWalletRequest{
+		WalletPassword:     []byte("hunter2"),
+		CipherSeedMnemonic: []string{"abandon", "ability"},
+		AezeedPassphrase:   []byte("passphrase"),
+		RecoveryWindow:     2500,
+		ExtendedMasterKey:  "tprv8ZgxMBicQKsPd",
+		MacaroonRootKey:    []byte("root key"),
+		ChannelBackups: &lnrpc.ChanBackupSnapshot{
+			MultiChanBackup: &lnrpc.MultiChanBackup{
+				MultiChanBackup: []byte(longValue),
+			},
+		},
+	}
+
+	params, err := RedactParams(req)
+	require.NoError(t, err)
+
+	// None of the secrets must show up in the encoded params.
+	for _, secret := range []string{
+		"hunter2", "abandon", "tprv8ZgxMBicQKsPd",
+	} {
+		require.NotContains(t, params, secret)
+	}
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(params), &decoded))
+
+	require.Equal(t, redactedValue, decoded["wallet_password"])
+	require.Equal(t, redactedValue, decoded["cipher_seed_mnemonic"])
+	require.Equal(t, redactedValue, decoded["aezeed_passphrase"])
+	require.Equal(t, redactedValue, decoded["extended_master_key"])
+	require.Equal(t, redactedValue, decoded["macaroon_root_key"])
+	require.EqualValues(t, 2500, decoded["recovery_window"])
+
+	// The long backup is replaced by its length and hash.
+	backups := decoded["channel_backups"].(map[string]any)
+	multi := backups["multi_chan_backup"].(map[string]any)
+	require.Contains(
+		t, multi["multi_chan_backup"].(string), " chars, sha256:",
+	)
+
+	// Anything that isn't a proto message results in empty params.
+	params, err = RedactParams(nil)
+	require.NoError(t, err)
+	require.Equal(t, "{}", params)
+}
@@ -0,0 +1,704 @@
+package rpcperms
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// CondAssetID is the name of the first-party caveat condition that
+	// restricts a macaroon to the assets with the given, comma separated
+	// and hex encoded asset IDs.
+	CondAssetID = "tapd-asset-id"
+
+	// CondGroupKey is the name of the first-party caveat condition that
+	// restricts a macaroon to the assets of the groups with the given,
+	// comma separated and hex encoded group keys.
+	CondGroupKey = "tapd-group-key"
+
+	// CondUniverseReadOnly is the name of the first-party caveat condition
+	// that restricts a macaroon to the calls that only read from the
+	// universe.
+	CondUniverseReadOnly = "tapd-universe-read-only"
+
+	// universeEntity is the permission entity of the universe calls.
+	universeEntity = "universe"
+
+	// readAction is the permission action of calls that don't modify any
+	// state.
+	readAction = "read"
+)
+
+var (
+	// ErrMacaroonScope is returned if a call isn't allowed by the asset
+	// or universe caveats of the macaroon it was made with.
+	ErrMacaroonScope = errors.New("call not permitted by macaroon " +
+		"caveats")
+
+	// AssetScopeCheckers are the macaroon checkers that need to be
+	// registered with the macaroon service to accept macaroons with asset
+	// or universe caveats. The checkers only validate the format of the
+	// caveats, as they are enforced by the asset scope interceptor of the
+	// InterceptorChain, which has access to the request.
+	AssetScopeCheckers = []macaroons.Checker{
+		AssetIDChecker, GroupKeyChecker, UniverseReadOnlyChecker,
+	}
+
+	// assetScopeExemptMethods are the methods that can be called with an
+	// asset scoped macaroon even though they don't reference an asset, as
+	// they don't reveal any information about assets.
+	assetScopeExemptMethods = fn.NewSet(
+		"/taprpc.TaprootAssets/GetInfo",
+	)
+
+	// TenantMethods are the only methods that can be called with the
+	// macaroon of a tenant. Each of them restricts the assets it operates
+	// on to the account of the tenant the call is scoped to.
+	TenantMethods = fn.NewSet(
+		"/taprpc.TaprootAssets/GetInfo",
+		"/taprpc.TaprootAssets/ListAssets",
+		"/taprpc.TaprootAssets/ListBalances",
+		"/taprpc.TaprootAssets/ListTransfers",
+		"/taprpc.TaprootAssets/NewAddr",
+		"/taprpc.TaprootAssets/SendAsset",
+		"/taprpc.TaprootAssets/ConsolidateAssets",
+	)
+)
+
+// AssetGroupQuerier is used to look up the group of an asset, so an asset
+// that is referenced by its ID can be matched against a group key caveat.
+type AssetGroupQuerier interface {
+	// QueryAssetGroup attempts to fetch the asset group of the asset with
+	// the given ID.
+	QueryAssetGroup(ctx context.Context,
+		assetID asset.ID) (*asset.AssetGroup, error)
+}
+
+// TenantQuerier is used to look up the account of the tenant a macaroon was
+// baked for.
+type TenantQuerier interface {
+	// FetchAccount returns the account with the given name.
+	FetchAccount(ctx context.Context, name string) (*account.Account,
+		error)
+}
+
+// AssetIDConstraint returns a macaroon constraint that restricts the macaroon
+// to calls that only reference the given assets.
+func AssetIDConstraint(ids ...asset.ID) macaroons.Constraint {
+	return func(mac *macaroon.Macaroon) error {
+		if len(ids) == 0 {
+			return fmt.Errorf("at least one asset ID is required")
+		}
+
+		encoded := fn.Map(ids, func(id asset.ID) string {
+			return id.String()
+		})
+		caveat := checkers.Condition(
+			CondAssetID, strings.Join(encoded, ","),
+		)
+
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// GroupKeyConstraint returns a macaroon constraint that restricts the macaroon
+// to calls that only reference assets of the given asset groups.
+func GroupKeyConstraint(keys ...*btcec.PublicKey) macaroons.Constraint {
+	return func(mac *macaroon.Macaroon) error {
+		if len(keys) == 0 {
+			return fmt.Errorf("at least one group key is required")
+		}
+
+		encoded := fn.Map(keys, func(key *btcec.PublicKey) string {
+			return hex.EncodeToString(key.SerializeCompressed())
+		})
+		caveat := checkers.Condition(
+			CondGroupKey, strings.Join(encoded, ","),
+		)
+
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// UniverseReadOnlyConstraint returns a macaroon constraint that restricts the
+// macaroon to the calls that only read from the universe.
+func UniverseReadOnlyConstraint() macaroons.Constraint {
+	return func(mac *macaroon.Macaroon) error {
+		caveat := checkers.Condition(CondUniverseReadOnly, "")
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// AssetIDChecker accepts well-formed asset ID caveats. It is of the
+// macaroons.Checker type.
+func AssetIDChecker() (string, checkers.Func) {
+	return CondAssetID, func(_ context.Context, _, arg string) error {
+		_, err := parseAssetIDs(arg)
+		return err
+	}
+}
+
+// GroupKeyChecker accepts well-formed group key caveats. It is of the
+// macaroons.Checker type.
+func GroupKeyChecker() (string, checkers.Func) {
+	return CondGroupKey, func(_ context.Context, _, arg string) error {
+		_, err := parseGroupKeys(arg)
+		return err
+	}
+}
+
+// UniverseReadOnlyChecker accepts well-formed universe read-only caveats. It
+// is of the macaroons.Checker type.
+func UniverseReadOnlyChecker() (string, checkers.Func) {
+	return CondUniverseReadOnly, func(_ context.Context, _,
+		arg string) error {
+
+		if arg != "" {
+			return fmt.Errorf("unexpected argument for %s caveat",
+				CondUniverseReadOnly)
+		}
+
+		return nil
+	}
+}
+
+// groupKeyID is the x-only serialization of a group key. Group keys are
+// compared in their x-only form, as the universe RPCs identify groups by it.
+type groupKeyID [schnorr.PubKeyBytesLen]byte
+
+// parseAssetIDs parses the comma separated, hex encoded asset IDs of an asset
+// ID caveat.
+func parseAssetIDs(arg string) (fn.Set[asset.ID], error) {
+	if arg == "" {
+		return nil, fmt.Errorf("asset ID caveat without asset IDs")
+	}
+
+	ids := fn.NewSet[asset.ID]()
+	for _, encoded := range strings.Split(arg, ",") {
+		id, err := parseAssetID(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		ids.Add(id)
+	}
+
+	return ids, nil
+}
+
+// parseGroupKeys parses the comma separated, hex encoded group keys of a group
+// key caveat.
+func parseGroupKeys(arg string) (fn.Set[groupKeyID], error) {
+	if arg == "" {
+		return nil, fmt.Errorf("group key caveat without group keys")
+	}
+
+	keys := fn.NewSet[groupKeyID]()
+	for _, encoded := range strings.Split(arg, ",") {
+		key, err := parseGroupKeyHex(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		keys.Add(key)
+	}
+
+	return keys, nil
+}
+
+// parseAssetID parses a hex encoded asset ID.
+func parseAssetID(encoded string) (asset.ID, error) {
+	idBytes, err := hex.DecodeString(encoded)
+	if err != nil {
+		return asset.ID{}, fmt.Errorf("invalid asset ID %q: %w",
+			encoded, err)
+	}
+
+	return assetIDFromBytes(idBytes)
+}
+
+// assetIDFromBytes parses a raw asset ID.
+func assetIDFromBytes(idBytes []byte) (asset.ID, error) {
+	var id asset.ID
+	if len(idBytes) != len(id) {
+		return id, fmt.Errorf("invalid asset ID length %d",
+			len(idBytes))
+	}
+	copy(id[:], idBytes)
+
+	return id, nil
+}
+
+// parseGroupKeyHex parses a hex encoded group key.
+func parseGroupKeyHex(encoded string) (groupKeyID, error) {
+	keyBytes, err := hex.DecodeString(encoded)
+	if err != nil {
+		return groupKeyID{}, fmt.Errorf("invalid group key %q: %w",
+			encoded, err)
+	}
+
+	return groupKeyFromBytes(keyBytes)
+}
+
+// groupKeyFromBytes parses a raw group key, either in its compressed or in its
+// x-only form.
+func groupKeyFromBytes(keyBytes []byte) (groupKeyID, error) {
+	var id groupKeyID
+
+	switch len(keyBytes) {
+	case schnorr.PubKeyBytesLen:
+	case btcec.PubKeyBytesLenCompressed:
+		keyBytes = keyBytes[1:]
+
+	default:
+		return id, fmt.Errorf("invalid group key length %d",
+			len(keyBytes))
+	}
+
+	if _, err := schnorr.ParsePubKey(keyBytes); err != nil {
+		return id, fmt.Errorf("invalid group key: %w", err)
+	}
+	copy(id[:], keyBytes)
+
+	return id, nil
+}
+
+// toGroupKeyID returns the x-only serialization of a group key.
+func toGroupKeyID(key *btcec.PublicKey) groupKeyID {
+	var id groupKeyID
+	copy(id[:], schnorr.SerializePubKey(key))
+
+	return id
+}
+
+// assetRef is a reference to an asset in a request, either by its asset ID,
+// by its group key or both.
+type assetRef struct {
+	// assetID is the ID of the referenced asset, if known.
+	assetID fn.Option[asset.ID]
+
+	// groupKey is the group key of the referenced asset, if known.
+	groupKey fn.Option[groupKeyID]
+}
+
+// refParser parses the asset references from the value of a request field.
+type refParser struct {
+	// kind is the kind of field the parser can be applied to.
+	kind protoreflect.Kind
+
+	// parse parses the asset reference from the field value.
+	parse func(protoreflect.Value) (assetRef, error)
+}
+
+// assetIDRef returns an asset reference parsed from a raw asset ID.
+func assetIDRef(v protoreflect.Value) (assetRef, error) {
+	id, err := assetIDFromBytes(v.Bytes())
+	if err != nil {
+		return assetRef{}, err
+	}
+
+	return assetRef{assetID: fn.Some(id)}, nil
+}
+
+// assetIDStrRef returns an asset reference parsed from a hex encoded asset ID.
+func assetIDStrRef(v protoreflect.Value) (assetRef, error) {
+	id, err := parseAssetID(v.String())
+	if err != nil {
+		return assetRef{}, err
+	}
+
+	return assetRef{assetID: fn.Some(id)}, nil
+}
+
+// groupKeyRef returns an asset reference parsed from a raw group key.
+func groupKeyRef(v protoreflect.Value) (assetRef, error) {
+	key, err := groupKeyFromBytes(v.Bytes())
+	if err != nil {
+		return assetRef{}, err
+	}
+
+	return assetRef{groupKey: fn.Some(key)}, nil
+}
+
+// groupKeyStrRef returns an asset reference parsed from a hex encoded group
+// key.
+func groupKeyStrRef(v protoreflect.Value) (assetRef, error) {
+	key, err := parseGroupKeyHex(v.String())
+	if err != nil {
+		return assetRef{}, err
+	}
+
+	return assetRef{groupKey: fn.Some(key)}, nil
+}
+
+// tapAddrRef returns an asset reference parsed from an encoded Taproot Asset
+// address.
+func tapAddrRef(v protoreflect.Value) (assetRef, error) {
+	encoded := v.String()
+
+	oneIndex := strings.LastIndexByte(encoded, '1')
+	if oneIndex <= 0 {
+		return assetRef{}, address.ErrInvalidBech32m
+	}
+
+	net, err := address.Net(strings.ToLower(encoded[:oneIndex]))
+	if err != nil {
+		return assetRef{}, err
+	}
+
+	addr, err := address.DecodeAddress(encoded, net)
+	if err != nil {
+		return assetRef{}, fmt.Errorf("unable to decode address: %w",
+			err)
+	}
+
+	ref := assetRef{assetID: fn.Some(addr.AssetID)}
+	if addr.GroupKey != nil {
+		ref.groupKey = fn.Some(toGroupKeyID(addr.GroupKey))
+	}
+
+	return ref, nil
+}
+
+// assetRefFields maps the names of the request fields that reference assets
+// to the parser of their value.
+var assetRefFields = map[protoreflect.Name]refParser{
+	"asset_id":          {protoreflect.BytesKind, assetIDRef},
+	"asset_ids":         {protoreflect.BytesKind, assetIDRef},
+	"asset_id_filter":   {protoreflect.BytesKind, assetIDRef},
+	"asset_filter":      {protoreflect.BytesKind, assetIDRef},
+	"price_asset_id":    {protoreflect.BytesKind, assetIDRef},
+	"asset_id_str":      {protoreflect.StringKind, assetIDStrRef},
+	"group_key":         {protoreflect.BytesKind, groupKeyRef},
+	"group_key_filter":  {protoreflect.BytesKind, groupKeyRef},
+	"tweaked_group_key": {protoreflect.BytesKind, groupKeyRef},
+	"group_key_str":     {protoreflect.StringKind, groupKeyStrRef},
+	"addr":              {protoreflect.StringKind, tapAddrRef},
+	"tap_addrs":         {protoreflect.StringKind, tapAddrRef},
+}
+
+// collectAssetRefs returns all asset references of the given request message,
+// including the ones of nested messages.
+func collectAssetRefs(msg protoreflect.Message) ([]assetRef, error) {
+	var (
+		refs []assetRef
+		err  error
+	)
+	msg.Range(func(fd protoreflect.FieldDescriptor,
+		v protoreflect.Value) bool {
+
+		var fieldRefs []assetRef
+		fieldRefs, err = collectFieldRefs(fd, v)
+		refs = append(refs, fieldRefs...)
+
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// collectFieldRefs returns the asset references of a single request field.
+func collectFieldRefs(fd protoreflect.FieldDescriptor,
+	v protoreflect.Value) ([]assetRef, error) {
+
+	switch {
+	case fd.IsList():
+		var refs []assetRef
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			elemRefs, err := collectValueRefs(fd, list.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, elemRefs...)
+		}
+
+		return refs, nil
+
+	case fd.IsMap():
+		var (
+			refs []assetRef
+			err  error
+		)
+		v.Map().Range(func(_ protoreflect.MapKey,
+			mv protoreflect.Value) bool {
+
+			var valueRefs []assetRef
+			valueRefs, err = collectValueRefs(fd.MapValue(), mv)
+			refs = append(refs, valueRefs...)
+
+			return err == nil
+		})
+
+		return refs, err
+
+	default:
+		return collectValueRefs(fd, v)
+	}
+}
+
+// collectValueRefs returns the asset references of a single, non-repeated
+// value of a request field.
+func collectValueRefs(fd protoreflect.FieldDescriptor,
+	v protoreflect.Value) ([]assetRef, error) {
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return collectAssetRefs(v.Message())
+	}
+
+	parser, ok := assetRefFields[fd.Name()]
+	if !ok || parser.kind != fd.Kind() {
+		return nil, nil
+	}
+
+	ref, err := parser.parse(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fd.Name(), err)
+	}
+
+	return []assetRef{ref}, nil
+}
+
+// macaroonScope is the scope a macaroon is restricted to by its asset and
+// universe caveats. As caveats can only ever restrict a macaroon further, a
+// call needs to satisfy each of the caveats.
+type macaroonScope struct {
+	// assetIDs holds the asset IDs of each asset ID caveat.
+	assetIDs []fn.Set[asset.ID]
+
+	// groupKeys holds the group keys of each group key caveat.
+	groupKeys []fn.Set[groupKeyID]
+
+	// universeReadOnly is true if the macaroon may only be used to read
+	// from the universe.
+	universeReadOnly bool
+
+	// tenant is the name of the tenant the macaroon was baked for, if any.
+	tenant string
+}
+
+// scopeFromMacaroon extracts the scope of a macaroon from its root key ID and
+// its first-party caveats.
+func scopeFromMacaroon(mac *macaroon.Macaroon) (*macaroonScope, error) {
+	var scope macaroonScope
+
+	// The macaroons of a tenant are baked with the root key of the tenant,
+	// so the tenant is known from the root key ID. Macaroons with an ID of
+	// an unknown format can't have been baked for a tenant.
+	rootKeyID, _, err := rpcjournal.DecodeMacaroonID(mac.Id())
+	if err == nil {
+		scope.tenant, _ = account.TenantFromRootKeyID(
+			[]byte(rootKeyID),
+		)
+	}
+
+	for _, caveat := range mac.Caveats() {
+		// Third-party caveats are never scope caveats.
+		if len(caveat.VerificationId) != 0 {
+			continue
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil {
+			return nil, err
+		}
+
+		switch cond {
+		case CondAssetID:
+			ids, err := parseAssetIDs(arg)
+			if err != nil {
+				return nil, err
+			}
+			scope.assetIDs = append(scope.assetIDs, ids)
+
+		case CondGroupKey:
+			keys, err := parseGroupKeys(arg)
+			if err != nil {
+				return nil, err
+			}
+			scope.groupKeys = append(scope.groupKeys, keys)
+
+		case CondUniverseReadOnly:
+			scope.universeReadOnly = true
+		}
+	}
+
+	return &scope, nil
+}
+
+// scopeFromContext extracts the scope of the macaroon the call of the given
+// context was made with.
+func scopeFromContext(ctx context.Context) (*macaroonScope, error) {
+	macHex, err := macaroons.RawMacaroonFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, err
+	}
+
+	return scopeFromMacaroon(mac)
+}
+
+// assetScoped returns true if the macaroon is restricted to a set of assets.
+func (s *macaroonScope) assetScoped() bool {
+	return len(s.assetIDs) != 0 || len(s.groupKeys) != 0
+}
+
+// checkMethod checks whether the method with the given required permissions
+// may be called at all within the scope.
+func (s *macaroonScope) checkMethod(fullMethod string,
+	requiredPerms []bakery.Op) error {
+
+	if s.tenant != "" && !TenantMethods.Contains(fullMethod) {
+		return fmt.Errorf("%w: %s can't be called by tenant %s",
+			ErrMacaroonScope, fullMethod, s.tenant)
+	}
+
+	if s.universeReadOnly {
+		for _, op := range requiredPerms {
+			if op.Entity != universeEntity ||
+				op.Action != readAction {
+
+				return fmt.Errorf("%w: %s is not a read-only "+
+					"universe call", ErrMacaroonScope,
+					fullMethod)
+			}
+		}
+	}
+
+	return nil
+}
+
+// requestExempt returns true if the request of the given method doesn't need
+// to be checked against the asset scope of the macaroon.
+func (s *macaroonScope) requestExempt(fullMethod string) bool {
+	return !s.assetScoped() || assetScopeExemptMethods.Contains(fullMethod)
+}
+
+// checkRequest checks that the given request only references assets within
+// the scope. As the responses of calls aren't filtered, requests that don't
+// reference any asset aren't permitted for an asset scoped macaroon.
+func (s *macaroonScope) checkRequest(ctx context.Context, fullMethod string,
+	req proto.Message, groups AssetGroupQuerier) error {
+
+	if s.requestExempt(fullMethod) {
+		return nil
+	}
+
+	refs, err := collectAssetRefs(req.ProtoReflect())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMacaroonScope, err)
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("%w: %s request doesn't reference an asset",
+			ErrMacaroonScope, fullMethod)
+	}
+
+	for _, ref := range refs {
+		if err := s.checkAssetRef(ctx, ref, groups); err != nil {
+			return fmt.Errorf("%w: %w", ErrMacaroonScope, err)
+		}
+	}
+
+	return nil
+}
+
+// checkAssetRef checks that a single asset reference satisfies all asset ID
+// and group key caveats.
+func (s *macaroonScope) checkAssetRef(ctx context.Context, ref assetRef,
+	groups AssetGroupQuerier) error {
+
+	for _, ids := range s.assetIDs {
+		id, err := ref.assetID.UnwrapOrErr(
+			fmt.Errorf("asset not referenced by its asset ID"),
+		)
+		if err != nil {
+			return err
+		}
+
+		if !ids.Contains(id) {
+			return fmt.Errorf("asset %v not permitted", id)
+		}
+	}
+
+	if len(s.groupKeys) == 0 {
+		return nil
+	}
+
+	groupKey, err := ref.resolveGroupKey(ctx, groups)
+	if err != nil {
+		return err
+	}
+
+	for _, keys := range s.groupKeys {
+		if !keys.Contains(groupKey) {
+			return fmt.Errorf("asset group %x not permitted",
+				groupKey[:])
+		}
+	}
+
+	return nil
+}
+
+// resolveGroupKey returns the group key of the referenced asset, looking it up
+// by the asset ID if the asset wasn't referenced by its group key.
+func (r *assetRef) resolveGroupKey(ctx context.Context,
+	groups AssetGroupQuerier) (groupKeyID, error) {
+
+	if r.groupKey.IsSome() {
+		return r.groupKey.UnwrapOr(groupKeyID{}), nil
+	}
+
+	id, err := r.assetID.UnwrapOrErr(
+		fmt.Errorf("asset not referenced by its ID or group key"),
+	)
+	if err != nil {
+		return groupKeyID{}, err
+	}
+
+	if groups == nil {
+		return groupKeyID{}, fmt.Errorf("unable to look up group of "+
+			"asset %v", id)
+	}
+
+	group, err := groups.QueryAssetGroup(ctx, id)
+	if err != nil {
+		return groupKeyID{}, fmt.Errorf("unable to look up group of "+
+			"asset %v: %w", id, err)
+	}
+
+	if group == nil || group.GroupKey == nil {
+		return groupKeyID{}, fmt.Errorf("asset %v is not grouped", id)
+	}
+
+	return toGroupKeyID(&group.GroupKey.GroupPubKey), nil
+}
@@ -0,0 +1,271 @@
+package rpcperms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/perms"
+	"github.com/lightninglabs/taproot-assets/taprpc"
+	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// mockAssetGroups is a mock AssetGroupQuerier that knows the groups of a fixed
+// set of assets.
+type mockAssetGroups struct {
+	groups map[asset.ID]*btcec.PublicKey
+}
+
+// QueryAssetGroup returns the group of the asset with the given ID.
+func (m *mockAssetGroups) QueryAssetGroup(_ context.Context,
+	id asset.ID) (*asset.AssetGroup, error) {
+
+	groupKey, ok := m.groups[id]
+	if !ok {
+		return nil, address.ErrAssetGroupUnknown
+	}
+
+	return &asset.AssetGroup{
+		GroupKey: &asset.GroupKey{
+			GroupPubKey: *groupKey,
+		},
+	}, nil
+}
+
+// constrainedScope returns the scope of a new macaroon with the given
+// constraints.
+func constrainedScope(t *testing.T,
+	constraints ...macaroons.Constraint) *macaroonScope {
+
+	mac, err := macaroon.New(
+		test.RandBytes(32), []byte{byte(bakery.Version3)}, "tapd",
+		macaroon.V2,
+	)
+	require.NoError(t, err)
+
+	mac, err = macaroons.AddConstraints(mac, constraints...)
+	require.NoError(t, err)
+
+	scope, err := scopeFromMacaroon(mac)
+	require.NoError(t, err)
+
+	return scope
+}
+
+// TestAssetScope tests that requests are only permitted if all the assets they
+// reference satisfy all asset ID and group key caveats.
+func TestAssetScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	groupedID, otherID, ungroupedID := asset.RandID(t), asset.RandID(t),
+		asset.RandID(t)
+	groupKey := test.RandPubKey(t)
+	groups := &mockAssetGroups{
+		groups: map[asset.ID]*btcec.PublicKey{
+			groupedID: groupKey,
+			otherID:   test.RandPubKey(t),
+		},
+	}
+
+	const (
+		listBalances = "/taprpc.TaprootAssets/ListBalances"
+		listAssets   = "/taprpc.TaprootAssets/ListAssets"
+		getInfo      = "/taprpc.TaprootAssets/GetInfo"
+	)
+	balanceOf := func(id asset.ID) *taprpc.ListBalancesRequest {
+		return &taprpc.ListBalancesRequest{
+			GroupBy: &taprpc.ListBalancesRequest_AssetId{
+				AssetId: true,
+			},
+			AssetFilter: id[:],
+		}
+	}
+
+	// A macaroon without any caveats isn't restricted.
+	scope := constrainedScope(t)
+	require.False(t, scope.assetScoped())
+	require.NoError(t, scope.checkRequest(
+		ctx, listAssets, &taprpc.ListAssetRequest{}, groups,
+	))
+
+	// An asset ID caveat only permits requests for the given assets.
+	scope = constrainedScope(t, AssetIDConstraint(groupedID, ungroupedID))
+	require.NoError(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(ungroupedID), groups,
+	))
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(otherID), groups,
+	), ErrMacaroonScope)
+
+	// Requests that don't reference any asset aren't permitted, unless the
+	// method is exempt.
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listAssets, &taprpc.ListAssetRequest{}, groups,
+	), ErrMacaroonScope)
+	require.NoError(t, scope.checkRequest(
+		ctx, getInfo, &taprpc.GetInfoRequest{}, groups,
+	))
+
+	// A group key caveat permits assets referenced by their group key in
+	// either form, or by the ID of an asset of the group.
+	scope = constrainedScope(t, GroupKeyConstraint(groupKey))
+	require.NoError(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(groupedID), groups,
+	))
+	require.NoError(t, scope.checkRequest(
+		ctx, listBalances, &taprpc.ListBalancesRequest{
+			GroupBy: &taprpc.ListBalancesRequest_GroupKey{
+				GroupKey: true,
+			},
+			GroupKeyFilter: groupKey.SerializeCompressed(),
+		}, groups,
+	))
+	require.NoError(t, scope.checkRequest(
+		ctx, "/universerpc.Universe/QueryAssetRoots",
+		&universerpc.AssetRootQuery{
+			Id: &universerpc.ID{
+				Id: &universerpc.ID_GroupKeyStr{
+					GroupKeyStr: test.HexPubKey(groupKey),
+				},
+			},
+		}, groups,
+	))
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(otherID), groups,
+	), ErrMacaroonScope)
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(ungroupedID), groups,
+	), ErrMacaroonScope)
+
+	// Without a querier, the group of an asset can't be looked up.
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(groupedID), nil,
+	), ErrMacaroonScope)
+
+	// Multiple caveats must all be satisfied, so a group key alone doesn't
+	// satisfy an additional asset ID caveat.
+	scope = constrainedScope(
+		t, GroupKeyConstraint(groupKey), AssetIDConstraint(groupedID),
+	)
+	require.NoError(t, scope.checkRequest(
+		ctx, listBalances, balanceOf(groupedID), groups,
+	))
+	require.ErrorIs(t, scope.checkRequest(
+		ctx, listBalances, &taprpc.ListBalancesRequest{
+			GroupBy: &taprpc.ListBalancesRequest_GroupKey{
+				GroupKey: true,
+			},
+			GroupKeyFilter: groupKey.SerializeCompressed(),
+		}, groups,
+	), ErrMacaroonScope)
+}
+
+// TestUniverseReadOnlyScope tests that a universe read-only caveat only
+// permits the methods that exclusively require universe read permissions.
+func TestUniverseReadOnlyScope(t *testing.T) {
+	t.Parallel()
+
+	scope := constrainedScope(t, UniverseReadOnlyConstraint())
+
+	for _, method := range []string{
+		"/universerpc.Universe/AssetRoots",
+		"/universerpc.Universe/QueryProof",
+	} {
+		require.NoError(t, scope.checkMethod(
+			method, perms.RequiredPermissions[method],
+		))
+	}
+
+	for _, method := range []string{
+		"/universerpc.Universe/InsertProof",
+		"/universerpc.Universe/SyncUniverse",
+		"/taprpc.TaprootAssets/ListAssets",
+	} {
+		require.ErrorIs(t, scope.checkMethod(
+			method, perms.RequiredPermissions[method],
+		), ErrMacaroonScope)
+	}
+}
+
+// tenantScope returns the scope of a new macaroon that is baked with the root
+// key of the given tenant.
+func tenantScope(t *testing.T, tenant string) *macaroonScope {
+	// A version 3 bakery macaroon ID holds the root key ID as field 2 of
+	// the protobuf encoded message that follows the version byte.
+	id := []byte{byte(bakery.Version3)}
+	id = protowire.AppendTag(id, 2, protowire.BytesType)
+	id = protowire.AppendBytes(id, account.TenantRootKeyID(tenant))
+
+	mac, err := macaroon.New(test.RandBytes(32), id, "tapd", macaroon.V2)
+	require.NoError(t, err)
+
+	scope, err := scopeFromMacaroon(mac)
+	require.NoError(t, err)
+
+	return scope
+}
+
+// TestTenantScope tests that the macaroon of a tenant only permits the methods
+// that are scoped to the account of the tenant.
+func TestTenantScope(t *testing.T) {
+	t.Parallel()
+
+	scope := tenantScope(t, "customer")
+	require.Equal(t, "customer", scope.tenant)
+
+	for method := range TenantMethods {
+		require.NoError(t, scope.checkMethod(
+			method, perms.RequiredPermissions[method],
+		))
+	}
+
+	for _, method := range []string{
+		"/taprpc.TaprootAssets/QueryAddrs",
+		"/taprpc.TaprootAssets/BurnAsset",
+		"/mintrpc.Mint/MintAsset",
+		"/assetwalletrpc.AssetWallet/NewAccount",
+		"/assetwalletrpc.AssetWallet/FundVirtualPsbt",
+	} {
+		require.ErrorIs(t, scope.checkMethod(
+			method, perms.RequiredPermissions[method],
+		), ErrMacaroonScope)
+	}
+
+	// Macaroons of the default root key aren't scoped to a tenant.
+	require.Empty(t, constrainedScope(t).tenant)
+}
+
+// TestAssetScopeCheckers tests that the macaroon checkers only accept well
+// formed caveats.
+func TestAssetScopeCheckers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	id := asset.RandID(t)
+
+	_, checkAssetID := AssetIDChecker()
+	require.NoError(t, checkAssetID(ctx, CondAssetID, id.String()))
+	require.Error(t, checkAssetID(ctx, CondAssetID, ""))
+	require.Error(t, checkAssetID(ctx, CondAssetID, id.String()+",00"))
+
+	_, checkGroupKey := GroupKeyChecker()
+	require.NoError(t, checkGroupKey(
+		ctx, CondGroupKey, test.HexPubKey(test.RandPubKey(t)),
+	))
+	require.Error(t, checkGroupKey(ctx, CondGroupKey, id.String()+"zz"))
+
+	_, checkReadOnly := UniverseReadOnlyChecker()
+	require.NoError(t, checkReadOnly(ctx, CondUniverseReadOnly, ""))
+	require.Error(t, checkReadOnly(ctx, CondUniverseReadOnly, "yes"))
+}
@@ -7,10 +7,13 @@ import (
 
 	"github.com/btcsuite/btclog"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/monitoring"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
@@ -26,11 +29,20 @@ const (
 	// this instance has been elected as leader.
 	waitingToStart rpcState = iota
 
+	// databaseLocked means that the database is encrypted with a key
+	// derived from a passphrase, and only the call to unlock it is
+	// accepted.
+	databaseLocked
+
 	// rpcActive means that the RPC server is ready to accept calls.
 	rpcActive
 
 	// serverActive means that the tapd server is ready to accept calls.
 	serverActive
+
+	// shuttingDown means that the tapd server is shutting down and waits
+	// for in-flight operations to complete, so no new calls are accepted.
+	shuttingDown
 )
 
 var (
@@ -43,8 +55,32 @@ var (
 	// RPC server is not yet ready to accept calls.
 	ErrRPCStarting = fmt.Errorf("the RPC server is in the process of " +
 		"starting up, but not yet ready to accept calls")
+
+	// ErrShuttingDown is returned if the server is shutting down and no
+	// longer accepts new calls.
+	ErrShuttingDown = fmt.Errorf("the server is in the process of " +
+		"shutting down, no new calls are accepted")
+
+	// ErrDatabaseLocked is returned if the database needs to be unlocked
+	// before any calls other than the one to unlock it are accepted.
+	ErrDatabaseLocked = fmt.Errorf("the database is locked, unlock it " +
+		"with the UnlockDatabase RPC first")
+
+	// ErrUniverseMirror is returned if a call would write to the local
+	// universe while it runs as a read-only mirror of a remote universe.
+	ErrUniverseMirror = fmt.Errorf("the universe is a read-only mirror, " +
+		"calls that write to it are not accepted")
 )
 
+// UnlockDatabaseMethod is the full method name of the RPC that unlocks the
+// database. It is the only call accepted while the database is locked.
+const UnlockDatabaseMethod = "/taprpc.TaprootAssets/UnlockDatabase"
+
+// SyncFederationMethod is the full method name of the RPC that syncs the local
+// universe with the federation. It is accepted even if the local universe is a
+// read-only mirror.
+const SyncFederationMethod = "/universerpc.Universe/SyncFederation"
+
 // InterceptorChain is a struct that can be added to the running GRPC server,
 // intercepting API calls. This is useful for logging, enforcing permissions,
 // supporting middleware etc. The following diagram shows the order of each
@@ -66,6 +102,10 @@ var (
 //	  +----------------------------------+
 //	  | Macaroon Interceptor             |
 //	  +----------------------------------+
+//	  | Asset Scope Interceptor          |
+//	  +----------------------------------+
+//	  | Universe Mirror Interceptor      |
+//	  +----------------------------------+
 //	  | Prometheus Interceptor           |
 //	  +-+--------------------------------+
 //	    | validated gRPC request from client
@@ -92,6 +132,14 @@ type InterceptorChain struct {
 	// permissionMap is the permissions to enforce if macaroons are used.
 	permissionMap map[string][]bakery.Op
 
+	// assetGroups is used to look up the group of assets referenced by
+	// their ID when enforcing group key caveats.
+	assetGroups AssetGroupQuerier
+
+	// tenants is used to look up the account of the tenant a macaroon was
+	// baked for.
+	tenants TenantQuerier
+
 	// rpcsLog is the logger used to log calls to the RPCs intercepted.
 	rpcsLog btclog.Logger
 
@@ -139,6 +187,15 @@ func (r *InterceptorChain) Stop() error {
 	return err
 }
 
+// SetDatabaseLocked moves the RPC state to databaseLocked, in which only the
+// call to unlock the database is accepted.
+func (r *InterceptorChain) SetDatabaseLocked() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.state = databaseLocked
+}
+
 // SetRPCActive moves the RPC state from walletUnlocked to rpcActive.
 func (r *InterceptorChain) SetRPCActive() {
 	r.Lock()
@@ -155,6 +212,15 @@ func (r *InterceptorChain) SetServerActive() {
 	r.state = serverActive
 }
 
+// SetShuttingDown moves the RPC state to shuttingDown, after which no new
+// calls are accepted. Calls that are already in progress aren't affected.
+func (r *InterceptorChain) SetShuttingDown() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.state = shuttingDown
+}
+
 // AddMacaroonService adds a macaroon service to the interceptor. After this is
 // done every RPC call made will have to pass a valid macaroon to be accepted.
 func (r *InterceptorChain) AddMacaroonService(svc *macaroons.Service) {
@@ -173,6 +239,25 @@ func (r *InterceptorChain) MacaroonService() *macaroons.Service {
 	return r.svc
 }
 
+// AddAssetGroupQuerier adds the querier used to look up the group of assets
+// that are referenced by their ID in calls made with a macaroon that has a
+// group key caveat.
+func (r *InterceptorChain) AddAssetGroupQuerier(groups AssetGroupQuerier) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.assetGroups = groups
+}
+
+// AddTenantQuerier adds the querier used to look up the account of the tenant
+// that calls made with the macaroon of a tenant are scoped to.
+func (r *InterceptorChain) AddTenantQuerier(tenants TenantQuerier) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.tenants = tenants
+}
+
 // AddPermission adds a new macaroon rule for the given method.
 func (r *InterceptorChain) AddPermission(method string, ops []bakery.Op) error {
 	r.Lock()
@@ -207,6 +292,15 @@ func (r *InterceptorChain) Permissions() map[string][]bakery.Op {
 // interceptors.
 type InterceptorsOpts struct {
 	Prometheus *monitoring.PrometheusConfig
+
+	// RPCJournal is the optional journal that records all mutating RPC
+	// calls.
+	RPCJournal *rpcjournal.Journal
+
+	// UniverseMirror indicates that the local universe is a read-only
+	// mirror of a remote universe server, so all calls that require write
+	// access to the universe are rejected.
+	UniverseMirror bool
 }
 
 // CreateServerOpts creates the GRPC server options that can be added to a GRPC
@@ -245,6 +339,42 @@ func (r *InterceptorChain) CreateServerOpts(
 		strmInterceptors, r.MacaroonStreamServerInterceptor(),
 	)
 
+	// Once the macaroon is known to be valid, we'll make sure the call is
+	// within the scope its asset and universe caveats restrict it to.
+	unaryInterceptors = append(
+		unaryInterceptors, r.assetScopeUnaryServerInterceptor(),
+	)
+	strmInterceptors = append(
+		strmInterceptors, r.assetScopeStreamServerInterceptor(),
+	)
+
+	// If the local universe is a mirror, we'll reject all calls that would
+	// write to it.
+	if opts.UniverseMirror {
+		unaryInterceptors = append(
+			unaryInterceptors,
+			r.universeMirrorUnaryServerInterceptor(),
+		)
+		strmInterceptors = append(
+			strmInterceptors,
+			r.universeMirrorStreamServerInterceptor(),
+		)
+	}
+
+	// If the RPC journal is enabled, we'll record all mutating calls. As
+	// this happens after the macaroon check, only authenticated calls are
+	// recorded.
+	if opts.RPCJournal != nil {
+		unaryInterceptors = append(
+			unaryInterceptors,
+			opts.RPCJournal.UnaryServerInterceptor(),
+		)
+		strmInterceptors = append(
+			strmInterceptors,
+			opts.RPCJournal.StreamServerInterceptor(),
+		)
+	}
+
 	// Get interceptors for Prometheus to gather gRPC performance metrics.
 	// If monitoring is disabled, GetPromInterceptors() will return empty
 	// slices.
@@ -377,9 +507,230 @@ func (r *InterceptorChain) MacaroonStreamServerInterceptor() grpc.StreamServerIn
 	}
 }
 
-// checkRPCState checks whether a call to the given server is allowed in the
-// current RPC state.
-func (r *InterceptorChain) checkRPCState(srv interface{}) error {
+// callScope returns the scope of the macaroon the call to the given method was
+// made with and the permissions the method requires. If the call isn't subject
+// to any macaroon checks, a nil scope is returned.
+func (r *InterceptorChain) callScope(ctx context.Context,
+	fullMethod string) (*macaroonScope, error) {
+
+	// Calls that don't require a macaroon aren't restricted by one either.
+	if r.noMacaroons {
+		return nil, nil
+	}
+	if _, ok := r.macaroonWhitelist[fullMethod]; ok {
+		return nil, nil
+	}
+
+	scope, err := scopeFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse macaroon caveats: %w",
+			err)
+	}
+
+	r.RLock()
+	uriPermissions := r.permissionMap[fullMethod]
+	r.RUnlock()
+
+	if err := scope.checkMethod(fullMethod, uriPermissions); err != nil {
+		return nil, err
+	}
+
+	return scope, nil
+}
+
+// checkAssetScope checks that a request to the given method only references
+// the assets the macaroon of the call is restricted to.
+func (r *InterceptorChain) checkAssetScope(ctx context.Context,
+	scope *macaroonScope, fullMethod string, req interface{}) error {
+
+	if scope == nil || scope.requestExempt(fullMethod) {
+		return nil
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: unable to inspect request of %s",
+			ErrMacaroonScope, fullMethod)
+	}
+
+	r.RLock()
+	groups := r.assetGroups
+	r.RUnlock()
+
+	return scope.checkRequest(ctx, fullMethod, msg, groups)
+}
+
+// tenantContext returns the context a call within the given scope is handled
+// with. If the macaroon of the call was baked for a tenant, the call is scoped
+// to the account of the tenant, which restricts it to the assets of the
+// tenant.
+func (r *InterceptorChain) tenantContext(ctx context.Context,
+	scope *macaroonScope) (context.Context, error) {
+
+	if scope == nil || scope.tenant == "" {
+		return ctx, nil
+	}
+
+	r.RLock()
+	tenants := r.tenants
+	r.RUnlock()
+
+	if tenants == nil {
+		return nil, fmt.Errorf("%w: unable to look up tenant %s",
+			ErrMacaroonScope, scope.tenant)
+	}
+
+	tenant, err := tenants.FetchAccount(ctx, scope.tenant)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to look up tenant %s: %w",
+			ErrMacaroonScope, scope.tenant, err)
+	}
+
+	if !tenant.Tenant {
+		return nil, fmt.Errorf("%w: account %s is not a tenant",
+			ErrMacaroonScope, scope.tenant)
+	}
+
+	return account.WithTenant(ctx, *tenant), nil
+}
+
+// assetScopeUnaryServerInterceptor is a GRPC interceptor that checks whether
+// the request is within the scope of the asset and universe caveats of the
+// included macaroon.
+func (r *InterceptorChain) assetScopeUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		scope, err := r.callScope(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.checkAssetScope(ctx, scope, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, err = r.tenantContext(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// assetScopeStreamServerInterceptor is a GRPC interceptor that checks whether
+// all requests received on a stream are within the scope of the asset and
+// universe caveats of the included macaroon.
+func (r *InterceptorChain) assetScopeStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		scope, err := r.callScope(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// Only unary calls are scoped to the account of a tenant, so
+		// streams can't be opened with the macaroon of a tenant.
+		if scope != nil && scope.tenant != "" {
+			return fmt.Errorf("%w: %s can't be called by tenant %s",
+				ErrMacaroonScope, info.FullMethod, scope.tenant)
+		}
+
+		if scope == nil || scope.requestExempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &assetScopeServerStream{
+			ServerStream: ss,
+			chain:        r,
+			scope:        scope,
+			fullMethod:   info.FullMethod,
+		})
+	}
+}
+
+// assetScopeServerStream is a server stream that checks every received request
+// against the scope of the macaroon of the call.
+type assetScopeServerStream struct {
+	grpc.ServerStream
+
+	chain      *InterceptorChain
+	scope      *macaroonScope
+	fullMethod string
+}
+
+// RecvMsg receives the next request of the stream and checks it against the
+// scope of the macaroon.
+func (s *assetScopeServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.chain.checkAssetScope(
+		s.Context(), s.scope, s.fullMethod, m,
+	)
+}
+
+// checkUniverseMirror returns ErrUniverseMirror if the given method requires
+// write access to the universe, which isn't allowed for a read-only mirror.
+// Syncing with the federation is still allowed, as a mirror only syncs with
+// the universe server it replicates.
+func (r *InterceptorChain) checkUniverseMirror(fullMethod string) error {
+	if fullMethod == SyncFederationMethod {
+		return nil
+	}
+
+	r.RLock()
+	uriPermissions := r.permissionMap[fullMethod]
+	r.RUnlock()
+
+	for _, op := range uriPermissions {
+		if op.Entity == "universe" && op.Action == "write" {
+			return ErrUniverseMirror
+		}
+	}
+
+	return nil
+}
+
+// universeMirrorUnaryServerInterceptor is a GRPC interceptor that rejects
+// calls that would write to the local universe while it runs as a mirror.
+func (r *InterceptorChain) universeMirrorUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if err := r.checkUniverseMirror(info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// universeMirrorStreamServerInterceptor is a GRPC interceptor that rejects
+// calls that would write to the local universe while it runs as a mirror.
+func (r *InterceptorChain) universeMirrorStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if err := r.checkUniverseMirror(info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// checkRPCState checks whether a call to the given method of the given server
+// is allowed in the current RPC state.
+func (r *InterceptorChain) checkRPCState(srv interface{},
+	fullMethod string) error {
+
 	// The StateService is being accessed, we allow the call regardless of
 	// the current state.
 	_, ok := srv.(lnrpc.StateServer)
@@ -397,9 +748,19 @@ func (r *InterceptorChain) checkRPCState(srv interface{}) error {
 	case waitingToStart:
 		return ErrWaitingToStart
 
+	// While the database is locked, we only accept the call to unlock it.
+	case databaseLocked:
+		if fullMethod != UnlockDatabaseMethod {
+			return ErrDatabaseLocked
+		}
+
 	// If the RPC server or tapd server is active, we allow all calls.
 	case rpcActive, serverActive:
 
+	// Once we're shutting down, we don't accept any new calls.
+	case shuttingDown:
+		return ErrShuttingDown
+
 	default:
 		return fmt.Errorf("unknown RPC state: %v", state)
 	}
@@ -418,7 +779,8 @@ func (r *InterceptorChain) rpcStateUnaryServerInterceptor() grpc.UnaryServerInte
 				"not ready")
 		}
 
-		if err := r.checkRPCState(info.Server); err != nil {
+		err := r.checkRPCState(info.Server, info.FullMethod)
+		if err != nil {
 			return nil, err
 		}
 
@@ -436,7 +798,7 @@ func (r *InterceptorChain) rpcStateStreamServerInterceptor() grpc.StreamServerIn
 			return fmt.Errorf("srv is nil, can't check RPC state")
 		}
 
-		if err := r.checkRPCState(srv); err != nil {
+		if err := r.checkRPCState(srv, info.FullMethod); err != nil {
 			return err
 		}
 
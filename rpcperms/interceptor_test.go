@@ -0,0 +1,110 @@
+package rpcperms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btclog"
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/perms"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTenants is a mock TenantQuerier that knows a fixed set of accounts.
+type mockTenants struct {
+	accounts map[string]account.Account
+}
+
+// FetchAccount returns the account with the given name.
+func (m *mockTenants) FetchAccount(_ context.Context,
+	name string) (*account.Account, error) {
+
+	acct, ok := m.accounts[name]
+	if !ok {
+		return nil, account.ErrNotFound
+	}
+
+	return &acct, nil
+}
+
+// TestUniverseMirror tests that a universe mirror rejects all calls that
+// require universe write permissions, except for syncing with the federation.
+func TestUniverseMirror(t *testing.T) {
+	t.Parallel()
+
+	chain := NewInterceptorChain(btclog.Disabled, false, nil, nil)
+	for method, ops := range perms.RequiredPermissions {
+		require.NoError(t, chain.AddPermission(method, ops))
+	}
+
+	for _, method := range []string{
+		"/universerpc.Universe/AssetRoots",
+		"/universerpc.Universe/QueryProof",
+		"/taprpc.TaprootAssets/ListAssets",
+		"/mintrpc.Mint/MintAsset",
+		SyncFederationMethod,
+	} {
+		require.NoError(t, chain.checkUniverseMirror(method))
+	}
+
+	for _, method := range []string{
+		"/universerpc.Universe/InsertProof",
+		"/universerpc.Universe/SyncUniverse",
+		"/universerpc.Universe/DeleteAssetRoot",
+		"/universerpc.Universe/AddFederationServer",
+		"/universerpc.Universe/SetFederationSyncConfig",
+	} {
+		require.ErrorIs(
+			t, chain.checkUniverseMirror(method), ErrUniverseMirror,
+		)
+	}
+}
+
+// TestTenantContext tests that the calls made with the macaroon of a tenant are
+// scoped to the account of the tenant.
+func TestTenantContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewInterceptorChain(btclog.Disabled, false, nil, nil)
+
+	// Calls that aren't made with the macaroon of a tenant aren't scoped.
+	scopedCtx, err := chain.tenantContext(ctx, constrainedScope(t))
+	require.NoError(t, err)
+	require.True(t, account.TenantFromContext(scopedCtx).IsNone())
+
+	// Without a way to look up the tenant, the call is rejected.
+	_, err = chain.tenantContext(ctx, tenantScope(t, "customer"))
+	require.ErrorIs(t, err, ErrMacaroonScope)
+
+	customer := account.Account{
+		Name:      "customer",
+		KeyFamily: account.FirstKeyFamily,
+		Tenant:    true,
+	}
+	chain.AddTenantQuerier(&mockTenants{
+		accounts: map[string]account.Account{
+			customer.Name: customer,
+			"treasury": {
+				Name:      "treasury",
+				KeyFamily: account.FirstKeyFamily + 1,
+			},
+		},
+	})
+
+	scopedCtx, err = chain.tenantContext(ctx, tenantScope(t, "customer"))
+	require.NoError(t, err)
+	require.Equal(
+		t, customer, account.TenantFromContext(scopedCtx).UnwrapOr(
+			account.Account{},
+		),
+	)
+
+	// Accounts that weren't created for a tenant can't be accessed with a
+	// tenant macaroon, and neither can unknown accounts.
+	_, err = chain.tenantContext(ctx, tenantScope(t, "treasury"))
+	require.ErrorIs(t, err, ErrMacaroonScope)
+
+	_, err = chain.tenantContext(ctx, tenantScope(t, "unknown"))
+	require.ErrorIs(t, err, ErrMacaroonScope)
+}
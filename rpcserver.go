@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,25 +21,37 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/davecgh/go-spew/spew"
 	proxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/freeze"
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/lightninglabs/taproot-assets/ledger"
+	"github.com/lightninglabs/taproot-assets/metaschema"
 	"github.com/lightninglabs/taproot-assets/mssmt"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/recovery"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/retention"
 	"github.com/lightninglabs/taproot-assets/rfq"
 	"github.com/lightninglabs/taproot-assets/rfqmath"
 	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
 	"github.com/lightninglabs/taproot-assets/rpcperms"
 	"github.com/lightninglabs/taproot-assets/tapchannel"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
 	"github.com/lightninglabs/taproot-assets/tapdb"
 	"github.com/lightninglabs/taproot-assets/tapfreighter"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
@@ -50,15 +65,24 @@ import (
 	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/tapsession"
+	"github.com/lightninglabs/taproot-assets/tapswap"
 	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/lightninglabs/taproot-assets/vm"
+	"github.com/lightninglabs/taproot-assets/walletarchive"
 	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/signal"
@@ -67,6 +91,9 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
 var (
@@ -98,6 +125,27 @@ const (
 	// proofTypeReceive is an alias for the proof type used for receiving
 	// assets.
 	proofTypeReceive = tapdevrpc.ProofTransferType_PROOF_TRANSFER_TYPE_RECEIVE
+
+	// defaultPayInvoiceTimeout is the default number of seconds that is
+	// spent on payment attempts when paying an invoice with assets.
+	defaultPayInvoiceTimeout = 60
+
+	// defaultPayInvoiceFeeLimitPercent is the default maximum routing fee
+	// in percent of the payment amount when paying an invoice with assets.
+	defaultPayInvoiceFeeLimitPercent = 5
+
+	// defaultSwapQuoteExpiry is the default time after which a swap quote
+	// expires. It matches the duration our coins are leased for when the
+	// seller funds its proposal.
+	defaultSwapQuoteExpiry = 10 * time.Minute
+
+	// defaultTopTransferUniverses is the default number of transfer
+	// universes returned when querying the ones with the most transfers.
+	defaultTopTransferUniverses = 10
+
+	// maxTopTransferUniverses is the maximum number of transfer universes
+	// returned when querying the ones with the most transfers.
+	maxTopTransferUniverses = 1000
 )
 
 type (
@@ -167,6 +215,13 @@ type rpcServer struct {
 
 	proofQueryRateLimiter *rate.Limiter
 
+	proofUploadLimiter *universe.UploadLimiter
+
+	// restProxyHost is the host the REST proxy dials to reach the gRPC
+	// server. Requests from this host are forwarded on behalf of REST
+	// clients.
+	restProxyHost string
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -176,6 +231,13 @@ func newRPCServer(interceptor signal.Interceptor,
 	interceptorChain *rpcperms.InterceptorChain,
 	cfg *Config) (*rpcServer, error) {
 
+	var restProxyHost string
+	if cfg.RPCConfig != nil && len(cfg.RPCListeners) > 0 {
+		restProxyHost, _, _ = net.SplitHostPort(
+			restProxyDestination(cfg.RPCListeners),
+		)
+	}
+
 	return &rpcServer{
 		interceptor:      interceptor,
 		interceptorChain: interceptorChain,
@@ -183,7 +245,11 @@ func newRPCServer(interceptor signal.Interceptor,
 		proofQueryRateLimiter: rate.NewLimiter(
 			cfg.UniverseQueriesPerSecond, cfg.UniverseQueriesBurst,
 		),
-		cfg: cfg,
+		proofUploadLimiter: universe.NewUploadLimiter(
+			cfg.UniverseUploadLimits,
+		),
+		restProxyHost: restProxyHost,
+		cfg:           cfg,
 	}, nil
 }
 
@@ -553,6 +619,21 @@ func (r *rpcServer) MintAsset(ctx context.Context,
 		}
 	}
 
+	// If a meta schema was declared, the final metadata, including the
+	// decimal display, must conform to it.
+	if req.Asset.MetaSchemaId != "" {
+		err = r.cfg.MetaSchemas.ValidateMeta(
+			ctx, req.Asset.MetaSchemaId, seedlingMeta,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.checkMetaBlob(ctx, seedlingMeta); err != nil {
+		return nil, err
+	}
+
 	// Parse the optional script key and group internal key. The group
 	// tapscript root was length-checked above.
 	var (
@@ -663,6 +744,120 @@ func (r *rpcServer) MintAsset(ctx context.Context,
 	}
 }
 
+// MintCollection adds a collection of collectibles that are linked under a
+// single asset group to the pending batch, and returns the IDs of the
+// editions' assets.
+func (r *rpcServer) MintCollection(ctx context.Context,
+	req *mintrpc.MintCollectionRequest) (*mintrpc.MintCollectionResponse,
+	error) {
+
+	assetVersion, err := taprpc.UnmarshalAssetVersion(req.AssetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	feeRate, err := checkFeeRateSanity(
+		ctx, chainfee.SatPerKWeight(req.FeeRate), r.cfg.Lnd.WalletKit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	params := tapgarden.CollectionParams{
+		AssetVersion: assetVersion,
+		FeeRate:      fn.MaybeSome(feeRate),
+	}
+
+	if len(req.GroupKey) != 0 {
+		groupKey, err := btcec.ParsePubKey(req.GroupKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group key: %w", err)
+		}
+
+		params.GroupKey = fn.Some(*groupKey)
+	}
+
+	if req.GroupInternalKey != nil {
+		groupInternalKey, err := taprpc.UnmarshalKeyDescriptor(
+			req.GroupInternalKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		params.GroupInternalKey = fn.Some(groupInternalKey)
+	}
+
+	for _, rpcEdition := range req.Editions {
+		err := asset.ValidateAssetName(rpcEdition.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edition name: %w", err)
+		}
+
+		edition := tapgarden.CollectionEdition{
+			Name: rpcEdition.Name,
+		}
+
+		if rpcEdition.AssetMeta != nil {
+			metaType, err := proof.IsValidMetaType(
+				rpcEdition.AssetMeta.Type,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			edition.Meta = &proof.MetaReveal{
+				Data: rpcEdition.AssetMeta.Data,
+				Type: metaType,
+			}
+		}
+
+		if req.MetaSchemaId != "" {
+			err = r.cfg.MetaSchemas.ValidateMeta(
+				ctx, req.MetaSchemaId, edition.Meta,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("invalid metadata for "+
+					"edition %v: %w", edition.Name, err)
+			}
+		}
+
+		if err := r.checkMetaBlob(ctx, edition.Meta); err != nil {
+			return nil, fmt.Errorf("invalid metadata for edition "+
+				"%v: %w", edition.Name, err)
+		}
+
+		params.Editions = append(params.Editions, edition)
+	}
+
+	rpcsLog.Infof("[MintCollection]: version=%v, editions=%v, "+
+		"existing_group=%v", params.AssetVersion, len(params.Editions),
+		params.GroupKey.IsSome())
+
+	result, err := r.cfg.AssetMinter.MintCollection(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to mint collection: %w", err)
+	}
+
+	rpcBatch, err := marshalMintingBatch(result.Batch, req.ShortResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	editions := make([]*mintrpc.MintedEdition, 0, len(result.Editions))
+	for _, edition := range result.Editions {
+		editions = append(editions, &mintrpc.MintedEdition{
+			Name:    edition.Name,
+			AssetId: fn.ByteSlice(edition.AssetID),
+		})
+	}
+
+	return &mintrpc.MintCollectionResponse{
+		PendingBatch: rpcBatch,
+		Editions:     editions,
+	}, nil
+}
+
 // checkFeeRateSanity ensures that the provided fee rate, in sat/kw, is above
 // the same minimum fee used as a floor in the fee estimator.
 func checkFeeRateSanity(ctx context.Context, rpcFeeRate chainfee.SatPerKWeight,
@@ -718,6 +913,9 @@ func (r *rpcServer) FundBatch(ctx context.Context,
 		tapgarden.FundParams{
 			FeeRate:        feeRateOpt,
 			SiblingTapTree: tapTreeOpt,
+			VanityID: unmarshalVanityAssetID(
+				req.VanityAssetId,
+			),
 		},
 	)
 	if err != nil {
@@ -739,6 +937,22 @@ func (r *rpcServer) FundBatch(ctx context.Context,
 	}, nil
 }
 
+// unmarshalVanityAssetID converts the RPC vanity asset ID request into the
+// planter parameters. The parameters are validated by the planter.
+func unmarshalVanityAssetID(
+	req *mintrpc.VanityAssetId) fn.Option[tapgarden.VanityIDParams] {
+
+	if req == nil {
+		return fn.None[tapgarden.VanityIDParams]()
+	}
+
+	return fn.Some(tapgarden.VanityIDParams{
+		AssetName: req.AssetName,
+		Prefix:    req.Prefix,
+		Timeout:   time.Duration(req.TimeoutSeconds) * time.Second,
+	})
+}
+
 // SealBatch attempts to seal the current pending batch, validating provided
 // asset group witnesses and generating asset group witnesses as needed.
 func (r *rpcServer) SealBatch(ctx context.Context,
@@ -773,6 +987,96 @@ func (r *rpcServer) SealBatch(ctx context.Context,
 	}, nil
 }
 
+// ExportGroupPsbts exports the asset group witnesses of the current pending
+// batch that must be produced by an external signer as PSBTs.
+func (r *rpcServer) ExportGroupPsbts(ctx context.Context,
+	_ *mintrpc.ExportGroupPsbtsRequest) (*mintrpc.ExportGroupPsbtsResponse,
+	error) {
+
+	signingReqs, err := r.cfg.AssetMinter.GroupSigningRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcReqs := make([]*mintrpc.GroupSigningRequest, 0, len(signingReqs))
+	for _, signingReq := range signingReqs {
+		pkt, err := signingReq.Psbt(r.cfg.ChainParams.HDCoinType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create group PSBT "+
+				"for asset %v: %w", signingReq.AssetName, err)
+		}
+
+		var b bytes.Buffer
+		if err := pkt.Serialize(&b); err != nil {
+			return nil, fmt.Errorf("unable to serialize group "+
+				"PSBT: %w", err)
+		}
+
+		rpcReqs = append(rpcReqs, &mintrpc.GroupSigningRequest{
+			AssetName: signingReq.AssetName,
+			AssetId:   fn.ByteSlice(signingReq.GenID),
+			GroupInternalKey: taprpc.MarshalKeyDescriptor(
+				signingReq.RawKey,
+			),
+			TweakedGroupKey: signingReq.TweakedKey.
+				SerializeCompressed(),
+			GroupPsbt: b.Bytes(),
+		})
+	}
+
+	return &mintrpc.ExportGroupPsbtsResponse{
+		SigningRequests: rpcReqs,
+	}, nil
+}
+
+// SubmitGroupSignatures seals the current pending batch with the asset group
+// witnesses of the given externally signed group PSBTs.
+func (r *rpcServer) SubmitGroupSignatures(ctx context.Context,
+	req *mintrpc.SubmitGroupSignaturesRequest) (
+	*mintrpc.SubmitGroupSignaturesResponse, error) {
+
+	if len(req.SignedGroupPsbts) == 0 {
+		return nil, fmt.Errorf("no signed group PSBTs provided")
+	}
+
+	groupWitnesses := make(
+		[]asset.PendingGroupWitness, 0, len(req.SignedGroupPsbts),
+	)
+	for idx, rawPsbt := range req.SignedGroupPsbts {
+		pkt, err := psbt.NewFromRawBytes(
+			bytes.NewReader(rawPsbt), false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse group PSBT "+
+				"%d: %w", idx, err)
+		}
+
+		wit, err := tapgarden.GroupWitnessFromPsbt(pkt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group PSBT %d: %w", idx,
+				err)
+		}
+
+		groupWitnesses = append(groupWitnesses, wit)
+	}
+
+	batch, err := r.cfg.AssetMinter.SealBatch(tapgarden.SealParams{
+		GroupWitnesses: groupWitnesses,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rpcBatch, err := marshalMintingBatch(batch, req.ShortResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mintrpc.SubmitGroupSignaturesResponse{
+		Batch: rpcBatch,
+	}, nil
+}
+
 // FinalizeBatch attempts to finalize the current pending batch.
 func (r *rpcServer) FinalizeBatch(ctx context.Context,
 	req *mintrpc.FinalizeBatchRequest) (*mintrpc.FinalizeBatchResponse,
@@ -793,12 +1097,27 @@ func (r *rpcServer) FinalizeBatch(ctx context.Context,
 		return nil, err
 	}
 
-	batch, err := r.cfg.AssetMinter.FinalizeBatch(
-		tapgarden.FinalizeParams{
-			FeeRate:        feeRateOpt,
-			SiblingTapTree: tapTreeOpt,
-		},
-	)
+	finalizeParams := tapgarden.FinalizeParams{
+		FeeRate:        feeRateOpt,
+		SiblingTapTree: tapTreeOpt,
+		VanityID:       unmarshalVanityAssetID(req.VanityAssetId),
+	}
+
+	// For a dry run, we only estimate the genesis transaction of the batch
+	// without finalizing it.
+	if req.DryRun {
+		estimate, err := r.cfg.AssetMinter.EstimateBatch(finalizeParams)
+		if err != nil {
+			return nil, fmt.Errorf("unable to estimate batch: %w",
+				err)
+		}
+
+		return &mintrpc.FinalizeBatchResponse{
+			Estimate: marshalBatchEstimate(estimate),
+		}, nil
+	}
+
+	batch, err := r.cfg.AssetMinter.FinalizeBatch(finalizeParams)
 	if err != nil {
 		return nil, fmt.Errorf("unable to finalize batch: %w", err)
 	}
@@ -818,6 +1137,37 @@ func (r *rpcServer) FinalizeBatch(ctx context.Context,
 	}, nil
 }
 
+// marshalBatchEstimate marshals the estimate of a minting batch into its RPC
+// counterpart.
+func marshalBatchEstimate(
+	estimate *tapgarden.BatchEstimate) *mintrpc.BatchEstimate {
+
+	seedlings := make(
+		[]*mintrpc.SeedlingEstimate, 0, len(estimate.Seedlings),
+	)
+	for _, seedling := range estimate.Seedlings {
+		seedlings = append(seedlings, &mintrpc.SeedlingEstimate{
+			AssetName: seedling.AssetName,
+			AssetType: taprpc.AssetType(seedling.AssetType),
+			Amount:    seedling.Amount,
+			AssetId:   fn.ByteSlice(seedling.AssetID),
+		})
+	}
+
+	return &mintrpc.BatchEstimate{
+		BatchKey:          estimate.BatchKey[:],
+		Funded:            estimate.Funded,
+		Weight:            uint64(estimate.Weight),
+		Vsize:             uint64(estimate.VSize),
+		FeeRate:           uint32(estimate.FeeRate),
+		FeeSats:           int64(estimate.Fee),
+		GenesisOutpoint:   estimate.GenesisOutPoint.String(),
+		AnchorOutputIndex: estimate.AnchorOutputIndex,
+		ChangeOutputIndex: estimate.ChangeOutputIndex,
+		Seedlings:         seedlings,
+	}
+}
+
 // CancelBatch attempts to cancel the current pending batch.
 func (r *rpcServer) CancelBatch(_ context.Context,
 	_ *mintrpc.CancelBatchRequest) (*mintrpc.CancelBatchResponse,
@@ -840,7 +1190,7 @@ func (r *rpcServer) CancelBatch(_ context.Context,
 
 // ListBatches lists the set of batches submitted for minting, including pending
 // and cancelled batches.
-func (r *rpcServer) ListBatches(_ context.Context,
+func (r *rpcServer) ListBatches(ctx context.Context,
 	req *mintrpc.ListBatchRequest) (*mintrpc.ListBatchResponse, error) {
 
 	var (
@@ -886,7 +1236,21 @@ func (r *rpcServer) ListBatches(_ context.Context,
 		batches, func(b *tapgarden.VerboseBatch) (*mintrpc.VerboseBatch,
 			error) {
 
-			return marshalVerboseBatch(b, req.Verbose, false)
+			rpcBatch, err := marshalVerboseBatch(
+				b, req.Verbose, false,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			err = r.addBatchConfDepth(
+				ctx, b.MintingBatch, rpcBatch.Batch,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return rpcBatch, nil
 		},
 	)
 	if err != nil {
@@ -898,6 +1262,101 @@ func (r *rpcServer) ListBatches(_ context.Context,
 	}, nil
 }
 
+// RegisterMetaSchema registers a custom JSON schema that asset metadata can be
+// validated against when minting.
+func (r *rpcServer) RegisterMetaSchema(ctx context.Context,
+	req *mintrpc.RegisterMetaSchemaRequest) (
+	*mintrpc.RegisterMetaSchemaResponse, error) {
+
+	schema, err := r.cfg.MetaSchemas.Register(
+		ctx, req.SchemaId, req.Definition,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to register meta schema: %w",
+			err)
+	}
+
+	return &mintrpc.RegisterMetaSchemaResponse{
+		Schema: marshalMetaSchema(*schema),
+	}, nil
+}
+
+// ListMetaSchemas lists the built-in and custom JSON schemas asset metadata
+// can be validated against.
+func (r *rpcServer) ListMetaSchemas(ctx context.Context,
+	_ *mintrpc.ListMetaSchemasRequest) (*mintrpc.ListMetaSchemasResponse,
+	error) {
+
+	schemas, err := r.cfg.MetaSchemas.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing meta schemas: %w", err)
+	}
+
+	return &mintrpc.ListMetaSchemasResponse{
+		Schemas: fn.Map(schemas, marshalMetaSchema),
+	}, nil
+}
+
+// marshalMetaSchema converts a meta schema into its RPC counterpart.
+func marshalMetaSchema(schema metaschema.Schema) *mintrpc.MetaSchema {
+	var registeredAt int64
+	if !schema.Builtin {
+		registeredAt = schema.RegisteredAt.Unix()
+	}
+
+	return &mintrpc.MetaSchema{
+		SchemaId:     schema.ID,
+		Definition:   schema.Definition,
+		Builtin:      schema.Builtin,
+		RegisteredAt: registeredAt,
+	}
+}
+
+// addBatchConfDepth adds the block height and current confirmation depth of
+// the batch transaction to the RPC batch, if the batch was confirmed.
+func (r *rpcServer) addBatchConfDepth(ctx context.Context,
+	batch *tapgarden.MintingBatch, rpcBatch *mintrpc.MintingBatch) error {
+
+	state := batch.State()
+	if state != tapgarden.BatchStateConfirmed &&
+		state != tapgarden.BatchStateFinalized {
+
+		return nil
+	}
+
+	if batch.GenesisPacket == nil {
+		return nil
+	}
+
+	batchTx, err := psbt.Extract(batch.GenesisPacket.Pkt)
+	if err != nil {
+		return fmt.Errorf("unable to extract batch tx: %w", err)
+	}
+
+	blockHash, blockHeight, err := r.cfg.AssetStore.TxBlock(
+		ctx, batchTx.TxHash(),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to fetch batch tx block: %w", err)
+	}
+
+	var maybeHash fn.Option[chainhash.Hash]
+	if blockHeight != 0 {
+		maybeHash = fn.Some(blockHash)
+	}
+
+	numConfs, err := r.confDepth(ctx, maybeHash, blockHeight)
+	if err != nil {
+		return fmt.Errorf("unable to determine confirmation depth: "+
+			"%w", err)
+	}
+
+	rpcBatch.BlockHeight = blockHeight
+	rpcBatch.NumConfirmations = numConfs
+
+	return nil
+}
+
 // checkBalanceOverflow ensures that the new asset amount will not overflow
 // the max allowed asset (or asset group) balance.
 func (r *rpcServer) checkBalanceOverflow(ctx context.Context,
@@ -920,7 +1379,7 @@ func (r *rpcServer) checkBalanceOverflow(ctx context.Context,
 	case assetID != nil:
 		// Retrieve the current asset balance.
 		balances, err := r.cfg.AssetStore.QueryBalancesByAsset(
-			ctx, assetID, true,
+			ctx, assetID, true, fn.None[account.Account](),
 		)
 		if err != nil {
 			return fmt.Errorf("unable to query asset balance: %w",
@@ -936,7 +1395,7 @@ func (r *rpcServer) checkBalanceOverflow(ctx context.Context,
 	case groupPubKey != nil:
 		// Retrieve the current balance of the group.
 		balances, err := r.cfg.AssetStore.QueryAssetBalancesByGroup(
-			ctx, groupPubKey, true,
+			ctx, groupPubKey, true, fn.None[account.Account](),
 		)
 		if err != nil {
 			return fmt.Errorf("unable to query group balance: %w",
@@ -964,47 +1423,64 @@ func (r *rpcServer) checkBalanceOverflow(ctx context.Context,
 func (r *rpcServer) ListAssets(ctx context.Context,
 	req *taprpc.ListAssetRequest) (*taprpc.ListAssetResponse, error) {
 
-	if req.IncludeSpent && req.IncludeLeased {
+	switch {
+	case req.IncludeSpent && req.IncludeLeased:
 		return nil, fmt.Errorf("cannot specify both include_spent " +
 			"and include_leased")
+
+	case req.OnlySpent && (req.IncludeLeased || req.OnlyLeased):
+		return nil, fmt.Errorf("cannot specify only_spent together " +
+			"with include_leased or only_leased")
+
+	case req.OnlyLeased && req.IncludeSpent:
+		return nil, fmt.Errorf("cannot specify both include_spent " +
+			"and only_leased")
 	}
 
-	rpcAssets, err := r.fetchRpcAssets(
-		ctx, req.WithWitness, req.IncludeSpent, req.IncludeLeased,
-	)
+	// Only list the assets of a single account if one was specified.
+	acct, err := r.accountFilter(ctx, req.Account)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		filteredAssets   []*taprpc.Asset
-		unconfirmedMints uint64
+	query, err := unmarshalListAssetsQuery(req, acct)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unless requested, we don't list the assets of mints that haven't
+	// been confirmed yet, which have an anchor height of zero. We still
+	// count them below.
+	if !req.IncludeUnconfirmedMints {
+		query.MinAnchorHeight = 1
+	}
+
+	assets, nextCursor, err := r.cfg.AssetStore.FetchAssetPage(
+		ctx, req.IncludeSpent, req.IncludeLeased, *query,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chain assets: %w", err)
+	}
 
-	// We now count and filter the assets according to the
-	// IncludeUnconfirmedMints flag.
-	//
-	// TODO(guggero): Do this on the SQL level once we add pagination to the
-	// asset list query, as this will no longer work with pagination.
-	for idx := range rpcAssets {
-		switch {
-		// If the asset isn't confirmed yet, we count it but only
-		// include it in the output list if the client requested it.
-		case rpcAssets[idx].ChainAnchor.BlockHeight == 0:
-			unconfirmedMints++
-
-			if req.IncludeUnconfirmedMints {
-				filteredAssets = append(
-					filteredAssets, rpcAssets[idx],
-				)
-			}
+	rpcAssets, err := r.marshalChainAssets(ctx, assets, req.WithWitness)
+	if err != nil {
+		return nil, err
+	}
 
-		// Don't filter out confirmed assets.
-		default:
-			filteredAssets = append(
-				filteredAssets, rpcAssets[idx],
-			)
-		}
+	// The number of unconfirmed mints is reported independently of the
+	// requested page, so we count them with a separate query.
+	countQuery := &tapdb.AssetQueryFilters{
+		CommitmentConstraints: query.CommitmentConstraints,
+		MaxAnchorHeight:       fn.Some[int32](0),
+		OnlySpent:             query.OnlySpent,
+		OnlyLeased:            query.OnlyLeased,
+	}
+	unconfirmedMints, err := r.cfg.AssetStore.FetchAllAssets(
+		ctx, req.IncludeSpent, req.IncludeLeased, countQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to count unconfirmed mints: %w",
+			err)
 	}
 
 	// We will also report the number of unconfirmed transfers. This is
@@ -1016,25 +1492,134 @@ func (r *rpcServer) ListAssets(ctx context.Context,
 			"outgoing parcels: %w", err)
 	}
 
-	return &taprpc.ListAssetResponse{
-		Assets:               filteredAssets,
+	resp := &taprpc.ListAssetResponse{
+		Assets:               rpcAssets,
 		UnconfirmedTransfers: uint64(len(outboundParcels)),
-		UnconfirmedMints:     unconfirmedMints,
-	}, nil
+		UnconfirmedMints:     uint64(len(unconfirmedMints)),
+	}
+	if nextCursor != nil {
+		resp.NextCursor = nextCursor.Encode()
+	}
+
+	return resp, nil
 }
 
-func (r *rpcServer) fetchRpcAssets(ctx context.Context, withWitness,
-	includeSpent, includeLeased bool) ([]*taprpc.Asset, error) {
+// unmarshalListAssetsQuery maps the filters, sort order and pagination
+// parameters of the given list assets request to an asset query.
+func unmarshalListAssetsQuery(req *taprpc.ListAssetRequest,
+	acct fn.Option[account.Account]) (*tapdb.AssetQueryFilters, error) {
 
-	assets, err := r.cfg.AssetStore.FetchAllAssets(
-		ctx, includeSpent, includeLeased, nil,
+	var (
+		assetID  *asset.ID
+		groupKey *btcec.PublicKey
+	)
+	if len(req.AssetId) != 0 {
+		if len(req.AssetId) != sha256.Size {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		}
+
+		var id asset.ID
+		copy(id[:], req.AssetId)
+		assetID = &id
+	}
+	if len(req.GroupKey) != 0 {
+		var err error
+		groupKey, err = btcec.ParsePubKey(req.GroupKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing group key: %w",
+				err)
+		}
+	}
+
+	specifier, err := asset.NewSpecifier(assetID, groupKey, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var sortBy tapdb.AssetSortKey
+	switch req.SortBy {
+	case taprpc.AssetSortKey_ASSET_SORT_KEY_NONE:
+		sortBy = tapdb.AssetSortByPrimaryKey
+
+	case taprpc.AssetSortKey_ASSET_SORT_KEY_AMOUNT:
+		sortBy = tapdb.AssetSortByAmount
+
+	case taprpc.AssetSortKey_ASSET_SORT_KEY_ANCHOR_HEIGHT:
+		sortBy = tapdb.AssetSortByAnchorHeight
+
+	case taprpc.AssetSortKey_ASSET_SORT_KEY_ASSET_ID:
+		sortBy = tapdb.AssetSortByAssetID
+
+	default:
+		return nil, fmt.Errorf("unknown sort key: %v", req.SortBy)
+	}
+
+	if req.Limit > math.MaxInt32 {
+		return nil, fmt.Errorf("limit must not exceed %d",
+			math.MaxInt32)
+	}
+
+	// Any script key type is allowed, as we don't select coins here.
+	constraints := tapfreighter.CommitmentConstraints{
+		AssetSpecifier: specifier,
+		MinAmt:         req.MinAmount,
+		CoinSelectType: tapsend.ScriptTreesAllowed,
+		Account:        acct,
+	}
+	query := &tapdb.AssetQueryFilters{
+		CommitmentConstraints: constraints,
+		OnlySpent:             req.OnlySpent,
+		OnlyLeased:            req.OnlyLeased,
+		SortBy:                sortBy,
+		SortDescending:        req.SortDescending,
+		Limit:                 int32(req.Limit),
+	}
+
+	if req.Cursor != "" {
+		query.Cursor, err = tapdb.DecodeAssetCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return query, nil
+}
+
+func (r *rpcServer) fetchRpcAssets(ctx context.Context, withWitness,
+	includeSpent, includeLeased bool,
+	acct fn.Option[account.Account]) ([]*taprpc.Asset, error) {
+
+	// If an account is given, we restrict the query to the assets of that
+	// account. Any script key type is allowed, as we don't select coins
+	// here.
+	var query *tapdb.AssetQueryFilters
+	if acct.IsSome() {
+		constraints := tapfreighter.CommitmentConstraints{
+			CoinSelectType: tapsend.ScriptTreesAllowed,
+			Account:        acct,
+		}
+		query = &tapdb.AssetQueryFilters{
+			CommitmentConstraints: constraints,
+		}
+	}
+
+	assets, err := r.cfg.AssetStore.FetchAllAssets(
+		ctx, includeSpent, includeLeased, query,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read chain assets: %w", err)
 	}
 
+	return r.marshalChainAssets(ctx, assets, withWitness)
+}
+
+// marshalChainAssets marshals the given chain assets into RPC assets.
+func (r *rpcServer) marshalChainAssets(ctx context.Context,
+	assets []*asset.ChainAsset, withWitness bool) ([]*taprpc.Asset, error) {
+
 	rpcAssets := make([]*taprpc.Asset, len(assets))
 	for i, a := range assets {
+		var err error
 		rpcAssets[i], err = r.MarshalChainAsset(
 			ctx, a, nil, withWitness, r.cfg.AddrBook,
 		)
@@ -1075,6 +1660,7 @@ func (r *rpcServer) MarshalChainAsset(ctx context.Context, a *asset.ChainAsset,
 	if err != nil {
 		return nil, err
 	}
+	rpcAsset.DisplayAmount = r.displayAmount(a.Amount, decDisplay)
 
 	var anchorTxBytes []byte
 	if a.AnchorTx != nil {
@@ -1104,11 +1690,12 @@ func (r *rpcServer) MarshalChainAsset(ctx context.Context, a *asset.ChainAsset,
 }
 
 func (r *rpcServer) listBalancesByAsset(ctx context.Context,
-	assetID *asset.ID, includeLeased bool) (*taprpc.ListBalancesResponse,
+	assetID *asset.ID, includeLeased bool,
+	acct fn.Option[account.Account]) (*taprpc.ListBalancesResponse,
 	error) {
 
 	balances, err := r.cfg.AssetStore.QueryBalancesByAsset(
-		ctx, assetID, includeLeased,
+		ctx, assetID, includeLeased, acct,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list balances: %w", err)
@@ -1123,6 +1710,20 @@ func (r *rpcServer) listBalancesByAsset(ctx context.Context,
 
 		assetIDStr := hex.EncodeToString(balance.ID[:])
 
+		var displayBalance string
+		if r.cfg.DecimalDisplayAmounts {
+			decDisplay, err := r.DecDisplayForAssetID(
+				ctx, balance.ID,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			displayBalance = r.displayAmount(
+				balance.Balance, decDisplay,
+			)
+		}
+
 		resp.AssetBalances[assetIDStr] = &taprpc.AssetBalance{
 			AssetGenesis: &taprpc.GenesisInfo{
 				GenesisPoint: balance.GenesisPoint.String(),
@@ -1131,7 +1732,8 @@ func (r *rpcServer) listBalancesByAsset(ctx context.Context,
 				MetaHash:     balance.MetaHash[:],
 				AssetId:      balance.ID[:],
 			},
-			Balance: balance.Balance,
+			Balance:        balance.Balance,
+			DisplayBalance: displayBalance,
 		}
 	}
 
@@ -1139,11 +1741,12 @@ func (r *rpcServer) listBalancesByAsset(ctx context.Context,
 }
 
 func (r *rpcServer) listBalancesByGroupKey(ctx context.Context,
-	groupKey *btcec.PublicKey,
-	includeLeased bool) (*taprpc.ListBalancesResponse, error) {
+	groupKey *btcec.PublicKey, includeLeased bool,
+	acct fn.Option[account.Account]) (*taprpc.ListBalancesResponse,
+	error) {
 
 	balances, err := r.cfg.AssetStore.QueryAssetBalancesByGroup(
-		ctx, groupKey, includeLeased,
+		ctx, groupKey, includeLeased, acct,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list balances: %w", err)
@@ -1178,7 +1781,10 @@ func (r *rpcServer) listBalancesByGroupKey(ctx context.Context,
 func (r *rpcServer) ListUtxos(ctx context.Context,
 	req *taprpc.ListUtxosRequest) (*taprpc.ListUtxosResponse, error) {
 
-	rpcAssets, err := r.fetchRpcAssets(ctx, false, false, req.IncludeLeased)
+	rpcAssets, err := r.fetchRpcAssets(
+		ctx, false, false, req.IncludeLeased,
+		fn.None[account.Account](),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1227,6 +1833,90 @@ func (r *rpcServer) ListUtxos(ctx context.Context,
 	}, nil
 }
 
+// ExportAnchorDescriptors exports output descriptors for all anchor outputs
+// that hold assets owned by the daemon.
+func (r *rpcServer) ExportAnchorDescriptors(ctx context.Context,
+	req *taprpc.ExportAnchorDescriptorsRequest) (
+	*taprpc.ExportAnchorDescriptorsResponse, error) {
+
+	assets, err := r.cfg.AssetStore.FetchAllAssets(
+		ctx, false, req.IncludeLeased, nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chain assets: %w", err)
+	}
+
+	// We first collect the IDs of the assets held at each anchor output.
+	assetIDs := make(map[wire.OutPoint]map[asset.ID]struct{})
+	for _, a := range assets {
+		ids, ok := assetIDs[a.AnchorOutpoint]
+		if !ok {
+			ids = make(map[asset.ID]struct{})
+			assetIDs[a.AnchorOutpoint] = ids
+		}
+
+		ids[a.ID()] = struct{}{}
+	}
+
+	managedUtxos, err := r.cfg.AssetStore.FetchManagedUTXOs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := make([]*taprpc.AnchorOutputDescriptor, 0, len(assetIDs))
+	for _, u := range managedUtxos {
+		// UTXOs that don't hold any of our assets may be in the DB just
+		// for record keeping, so we skip them.
+		ids, ok := assetIDs[u.OutPoint]
+		if !ok {
+			continue
+		}
+
+		internalKey := u.InternalKey.PubKey
+		outputKey := txscript.ComputeTaprootOutputKey(
+			internalKey, u.MerkleRoot,
+		)
+		pkScript, err := txscript.PayToTaprootScript(outputKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create pk script "+
+				"for anchor output %v: %w", u.OutPoint, err)
+		}
+
+		addr, err := btcutil.NewAddressTaproot(
+			schnorr.SerializePubKey(outputKey),
+			r.cfg.ChainParams.Params,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create address for "+
+				"anchor output %v: %w", u.OutPoint, err)
+		}
+
+		rpcAssetIDs := make([][]byte, 0, len(ids))
+		for id := range ids {
+			rpcAssetIDs = append(rpcAssetIDs, fn.CopySlice(id[:]))
+		}
+		sort.Slice(rpcAssetIDs, func(i, j int) bool {
+			return bytes.Compare(rpcAssetIDs[i], rpcAssetIDs[j]) < 0
+		})
+
+		desc := &taprpc.AnchorOutputDescriptor{
+			OutPoint:         u.OutPoint.String(),
+			OutputDescriptor: tapscript.RawTrDescriptor(outputKey),
+			PkScript:         pkScript,
+			Address:          addr.EncodeAddress(),
+			AmtSat:           int64(u.OutputValue),
+			InternalKey:      internalKey.SerializeCompressed(),
+			MerkleRoot:       u.MerkleRoot,
+			AssetIds:         rpcAssetIDs,
+		}
+		descriptors = append(descriptors, desc)
+	}
+
+	return &taprpc.ExportAnchorDescriptorsResponse{
+		Descriptors: descriptors,
+	}, nil
+}
+
 // ListGroups lists known groups and the assets held in each group.
 func (r *rpcServer) ListGroups(ctx context.Context,
 	_ *taprpc.ListGroupsRequest) (*taprpc.ListGroupsResponse, error) {
@@ -1279,6 +1969,12 @@ func (r *rpcServer) ListGroups(ctx context.Context,
 func (r *rpcServer) ListBalances(ctx context.Context,
 	req *taprpc.ListBalancesRequest) (*taprpc.ListBalancesResponse, error) {
 
+	// Only query the balances of a single account if one was specified.
+	acct, err := r.accountFilter(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	switch groupBy := req.GroupBy.(type) {
 	case *taprpc.ListBalancesRequest_AssetId:
 		if !groupBy.AssetId {
@@ -1295,7 +1991,9 @@ func (r *rpcServer) ListBalances(ctx context.Context,
 			copy(assetID[:], req.AssetFilter)
 		}
 
-		return r.listBalancesByAsset(ctx, assetID, req.IncludeLeased)
+		return r.listBalancesByAsset(
+			ctx, assetID, req.IncludeLeased, acct,
+		)
 
 	case *taprpc.ListBalancesRequest_GroupKey:
 		if !groupBy.GroupKey {
@@ -1313,7 +2011,7 @@ func (r *rpcServer) ListBalances(ctx context.Context,
 		}
 
 		return r.listBalancesByGroupKey(
-			ctx, groupKey, req.IncludeLeased,
+			ctx, groupKey, req.IncludeLeased, acct,
 		)
 
 	default:
@@ -1341,7 +2039,15 @@ func (r *rpcServer) ListTransfers(ctx context.Context,
 		}
 	}
 
-	parcels, err := r.cfg.AssetStore.QueryParcels(ctx, anchorTxHash, false)
+	// Only list the transfers of a single account if one was specified.
+	acct, err := r.accountFilter(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	parcels, err := r.cfg.AssetStore.QueryAccountParcels(
+		ctx, anchorTxHash, false, acct,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query parcels: %w", err)
 	}
@@ -1351,16 +2057,152 @@ func (r *rpcServer) ListTransfers(ctx context.Context,
 	}
 
 	for idx := range parcels {
-		resp.Transfers[idx], err = marshalOutboundParcel(parcels[idx])
+		parcel := parcels[idx]
+		resp.Transfers[idx], err = marshalOutboundParcel(parcel)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal parcel: %w",
 				err)
 		}
+
+		numConfs, err := r.confDepth(
+			ctx, parcel.AnchorTxBlockHash,
+			parcel.AnchorTxBlockHeight,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine "+
+				"confirmation depth: %w", err)
+		}
+		resp.Transfers[idx].AnchorTxNumConfirmations = numConfs
 	}
 
 	return resp, nil
 }
 
+// ExportLedger exports a time-ordered ledger of all asset movements of the
+// daemon.
+func (r *rpcServer) ExportLedger(ctx context.Context,
+	req *taprpc.ExportLedgerRequest) (*taprpc.ExportLedgerResponse, error) {
+
+	var format ledger.Format
+	switch req.Format {
+	case taprpc.LedgerFormat_LEDGER_FORMAT_CSV:
+		format = ledger.FormatCSV
+
+	case taprpc.LedgerFormat_LEDGER_FORMAT_JSON:
+		format = ledger.FormatJSON
+
+	default:
+		return nil, fmt.Errorf("unknown ledger format: %v", req.Format)
+	}
+
+	if req.IncludeValuation && r.cfg.PriceOracle == nil {
+		return nil, fmt.Errorf("valuation requires a price oracle to " +
+			"be configured")
+	}
+
+	entries, err := r.ledgerEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger.Sort(entries)
+	page, nextCursor, err := ledger.Page(
+		entries, req.Cursor, int(req.Limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// We only value the entries of the requested page, to not query the
+	// oracle for assets that aren't exported.
+	if req.IncludeValuation {
+		valuer := ledger.NewValuer(
+			r.cfg.PriceOracle, clock.NewDefaultClock(),
+		)
+		valuer.Value(ctx, page)
+	}
+
+	var buf bytes.Buffer
+	if err := ledger.Encode(&buf, page, format); err != nil {
+		return nil, fmt.Errorf("unable to encode ledger: %w", err)
+	}
+
+	return &taprpc.ExportLedgerResponse{
+		Ledger:     buf.Bytes(),
+		NumEntries: uint32(len(page)),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ledgerEntries collects the ledger entries of all minting batches, completed
+// address receives and outbound transfers of the daemon.
+func (r *rpcServer) ledgerEntries(ctx context.Context) ([]*ledger.Entry,
+	error) {
+
+	var entries []*ledger.Entry
+
+	batches, err := r.cfg.AssetMinter.ListBatches(
+		tapgarden.ListBatchesParams{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list batches: %w", err)
+	}
+	for _, batch := range batches {
+		entries = append(entries, ledger.MintEntries(
+			batch.MintingBatch,
+		)...)
+	}
+
+	completed := address.StatusCompleted
+	events, err := r.cfg.AddrBook.QueryEvents(
+		ctx, address.EventQueryParams{
+			StatusFrom: &completed,
+			StatusTo:   &completed,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query address events: %w",
+			err)
+	}
+	for _, event := range events {
+		ledger.ReceiveEntry(event).WhenSome(func(e *ledger.Entry) {
+			entries = append(entries, e)
+		})
+	}
+
+	parcels, err := r.cfg.AssetStore.QueryParcels(ctx, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query parcels: %w", err)
+	}
+	for _, parcel := range parcels {
+		transferEntries, err := ledger.TransferEntries(parcel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create ledger "+
+				"entries for transfer %v: %w",
+				parcel.AnchorTx.TxHash(), err)
+		}
+
+		entries = append(entries, transferEntries...)
+	}
+
+	return entries, nil
+}
+
+// confDepth returns the current confirmation depth of a transaction that was
+// confirmed in the given block. Zero is returned for unconfirmed transactions.
+func (r *rpcServer) confDepth(ctx context.Context,
+	blockHash fn.Option[chainhash.Hash], blockHeight uint32) (uint32,
+	error) {
+
+	if blockHash.IsNone() {
+		return 0, nil
+	}
+
+	return r.cfg.ConfDepthTracker.ConfDepth(
+		ctx, blockHash.UnwrapOr(chainhash.Hash{}), blockHeight,
+	)
+}
+
 // QueryAddrs queries the set of Taproot Asset addresses stored in the database.
 func (r *rpcServer) QueryAddrs(ctx context.Context,
 	req *taprpc.QueryAddrRequest) (*taprpc.QueryAddrResponse, error) {
@@ -1439,10 +2281,15 @@ func (r *rpcServer) NewAddr(ctx context.Context,
 	var assetID asset.ID
 	copy(assetID[:], req.AssetId)
 
+	amt, err := r.resolveAssetAmount(ctx, assetID, req.Amt, req.DisplayAmt)
+	if err != nil {
+		return nil, err
+	}
+
 	rpcsLog.Infof("[NewAddr]: making new addr: asset_id=%x, amt=%v",
-		assetID[:], req.Amt)
+		assetID[:], amt)
 
-	err := r.checkBalanceOverflow(ctx, &assetID, nil, req.Amt)
+	err = r.checkBalanceOverflow(ctx, &assetID, nil, amt)
 	if err != nil {
 		return nil, err
 	}
@@ -1466,6 +2313,66 @@ func (r *rpcServer) NewAddr(ctx context.Context,
 		return nil, err
 	}
 
+	addrOpts := []address.NewAddrOpt{
+		address.WithAssetVersion(assetVersion),
+	}
+
+	// The fallback proof couriers and the expiry are only supported by V2
+	// addresses, which the address itself makes sure of.
+	var fallbackCourierAddrs []url.URL
+	for _, addrStr := range req.FallbackProofCourierAddrs {
+		fallbackAddr, err := proof.ParseCourierAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback proof "+
+				"courier address: %w", err)
+		}
+
+		fallbackCourierAddrs = append(
+			fallbackCourierAddrs, *fallbackAddr,
+		)
+	}
+	if len(fallbackCourierAddrs) > 0 {
+		addrOpts = append(
+			addrOpts, address.WithFallbackProofCourierAddrs(
+				fallbackCourierAddrs...,
+			),
+		)
+	}
+
+	switch {
+	case req.ExpiryTimestamp < 0:
+		return nil, fmt.Errorf("invalid expiry timestamp: %d",
+			req.ExpiryTimestamp)
+
+	case req.ExpiryTimestamp > 0:
+		expiry := time.Unix(req.ExpiryTimestamp, 0)
+		if !expiry.After(time.Now()) {
+			return nil, fmt.Errorf("expiry timestamp %d is not in "+
+				"the future", req.ExpiryTimestamp)
+		}
+
+		addrOpts = append(addrOpts, address.WithExpiry(expiry))
+	}
+
+	// The base script key of a reusable address must be a raw key the
+	// deposit tweaks can be applied to, so we always derive it ourselves.
+	if req.Reusable {
+		if req.ScriptKey != nil {
+			return nil, fmt.Errorf("custom script key cannot be " +
+				"used for a reusable address")
+		}
+
+		addrOpts = append(addrOpts, address.WithReusable())
+	}
+
+	// The keys of the address are derived within the key scope of the
+	// account the address belongs to, which is what attributes the
+	// received assets to that account.
+	acct, err := account.Resolve(ctx, r.cfg.Accounts, req.Account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %w", err)
+	}
+
 	var addr *address.AddrWithKeyInfo
 	switch {
 	// No key was specified, we'll let the address book derive them.
@@ -1473,8 +2380,10 @@ func (r *rpcServer) NewAddr(ctx context.Context,
 		// Now that we have all the params, we'll try to add a new
 		// address to the addr book.
 		addr, err = r.cfg.AddrBook.NewAddress(
-			ctx, addrVersion, assetID, req.Amt, tapscriptSibling,
-			*courierAddr, address.WithAssetVersion(assetVersion),
+			ctx, addrVersion, assetID, amt, tapscriptSibling,
+			*courierAddr, append(
+				addrOpts, address.WithKeyFamily(acct.KeyFamily),
+			)...,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("unable to make new addr: %w",
@@ -1491,6 +2400,11 @@ func (r *rpcServer) NewAddr(ctx context.Context,
 		return nil, fmt.Errorf("script key must also be specified " +
 			"if internal key is specified")
 
+	// Custom keys can't be attributed to a named account.
+	case !acct.IsDefault():
+		return nil, fmt.Errorf("account cannot be specified together " +
+			"with custom script and internal keys")
+
 	// Both the script and internal keys were specified.
 	default:
 		scriptKey, err := taprpc.UnmarshalScriptKey(req.ScriptKey)
@@ -1522,9 +2436,9 @@ func (r *rpcServer) NewAddr(ctx context.Context,
 		// Now that we have all the params, we'll try to add a new
 		// address to the addr book.
 		addr, err = r.cfg.AddrBook.NewAddressWithKeys(
-			ctx, addrVersion, assetID, req.Amt, *scriptKey,
+			ctx, addrVersion, assetID, amt, *scriptKey,
 			internalKey, tapscriptSibling, *courierAddr,
-			address.WithAssetVersion(assetVersion),
+			addrOpts...,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("unable to make new addr: %w",
@@ -1564,6 +2478,59 @@ func (r *rpcServer) DecodeAddr(_ context.Context,
 	return rpcAddr, nil
 }
 
+// InspectAddr fully decodes a Taproot Asset address, even if it is for a
+// different network, and reports any problems found with it as warnings.
+func (r *rpcServer) InspectAddr(ctx context.Context,
+	req *taprpc.InspectAddrRequest) (*taprpc.InspectAddrResponse, error) {
+
+	if len(req.Addr) == 0 {
+		return nil, fmt.Errorf("must specify an addr")
+	}
+
+	inspection, err := r.cfg.AddrBook.InspectAddr(ctx, req.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode addr: %w", err)
+	}
+	addr := inspection.Addr
+
+	// We validate the proof courier addresses here, as the address book
+	// doesn't know which courier protocols are supported.
+	courierAddrs := append(
+		[]url.URL{addr.ProofCourierAddr},
+		addr.FallbackProofCourierAddrs...,
+	)
+	for idx := range courierAddrs {
+		courierAddr := &courierAddrs[idx]
+		err := proof.ValidateCourierAddress(courierAddr)
+		if err != nil {
+			inspection.Warnings = append(
+				inspection.Warnings, fmt.Sprintf("invalid "+
+					"proof courier address %v: %v",
+					courierAddr, err),
+			)
+		}
+	}
+
+	rpcAddr, err := marshalAddr(addr, r.cfg.TapAddrBook)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal addr: %w", err)
+	}
+
+	resp := &taprpc.InspectAddrResponse{
+		Addr:     rpcAddr,
+		Network:  inspection.Net.Name,
+		Warnings: inspection.Warnings,
+	}
+	if inspection.AssetGroup != nil {
+		genesis := inspection.AssetGroup.Genesis
+		resp.AssetGenesis = taprpc.MarshalGenesisInfo(
+			genesis, genesis.Type,
+		)
+	}
+
+	return resp, nil
+}
+
 // VerifyProof attempts to verify a given proof file that claims to be anchored
 // at the specified genesis point.
 func (r *rpcServer) VerifyProof(ctx context.Context,
@@ -1615,33 +2582,84 @@ func (r *rpcServer) VerifyProof(ctx context.Context,
 	}, nil
 }
 
-// DecodeProof attempts to decode a given proof file that claims to be anchored
-// at the specified genesis point.
-func (r *rpcServer) DecodeProof(ctx context.Context,
-	req *taprpc.DecodeProofRequest) (*taprpc.DecodeProofResponse, error) {
-
-	var rpcProof *taprpc.DecodedProof
-	switch {
-	case proof.IsSingleProof(req.RawProof):
-		p, err := proof.Decode(req.RawProof)
-		if err != nil {
-			return nil, fmt.Errorf("unable to decode proof: %w",
-				err)
-		}
+// CompatibilityReport returns the VM versions this node knows how to enforce
+// and, if a proof file is given, whether this node is able to validate all of
+// its state transitions.
+func (r *rpcServer) CompatibilityReport(_ context.Context,
+	req *taprpc.CompatibilityReportRequest) (
+	*taprpc.CompatibilityReportResponse, error) {
 
-		rpcProof, err = r.marshalProof(
-			ctx, p, req.WithPrevWitnesses, req.WithMetaReveal,
+	resp := &taprpc.CompatibilityReportResponse{
+		LatestVmVersion: uint32(vm.LatestVersion),
+		Compatible:      true,
+	}
+	for _, version := range vm.SupportedVersions() {
+		resp.SupportedVmVersions = append(
+			resp.SupportedVmVersions, uint32(version),
 		)
-		if err != nil {
-			return nil, fmt.Errorf("unable to marshal proof: %w",
-				err)
-		}
+	}
 
-		rpcProof.NumberOfProofs = 1
+	if len(req.RawProofFile) == 0 {
+		return resp, nil
+	}
 
-	case proof.IsProofFile(req.RawProof):
-		if err := proof.CheckMaxFileSize(req.RawProof); err != nil {
-			return nil, fmt.Errorf("invalid proof file: %w", err)
+	if !proof.IsProofFile(req.RawProofFile) {
+		return nil, fmt.Errorf("invalid raw proof, expect file, not " +
+			"single encoded mint or transition proof")
+	}
+
+	if err := proof.CheckMaxFileSize(req.RawProofFile); err != nil {
+		return nil, fmt.Errorf("invalid proof file: %w", err)
+	}
+
+	proofFile, err := proof.DecodeFile(req.RawProofFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode proof file: %w", err)
+	}
+
+	report, err := proofFile.VMCompatibility()
+	if err != nil {
+		return nil, fmt.Errorf("unable to check proof file: %w", err)
+	}
+
+	for _, version := range report.ProofVersions {
+		resp.ProofVmVersions = append(
+			resp.ProofVmVersions, uint32(version),
+		)
+	}
+	resp.MinVmVersion = uint32(report.MinVersion)
+	resp.Compatible = report.Compatible
+
+	return resp, nil
+}
+
+// DecodeProof attempts to decode a given proof file that claims to be anchored
+// at the specified genesis point.
+func (r *rpcServer) DecodeProof(ctx context.Context,
+	req *taprpc.DecodeProofRequest) (*taprpc.DecodeProofResponse, error) {
+
+	var rpcProof *taprpc.DecodedProof
+	switch {
+	case proof.IsSingleProof(req.RawProof):
+		p, err := proof.Decode(req.RawProof)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode proof: %w",
+				err)
+		}
+
+		rpcProof, err = r.marshalProof(
+			ctx, p, req.WithPrevWitnesses, req.WithMetaReveal,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal proof: %w",
+				err)
+		}
+
+		rpcProof.NumberOfProofs = 1
+
+	case proof.IsProofFile(req.RawProof):
+		if err := proof.CheckMaxFileSize(req.RawProof); err != nil {
+			return nil, fmt.Errorf("invalid proof file: %w", err)
 		}
 
 		proofFile, err := proof.DecodeFile(req.RawProof)
@@ -1893,6 +2911,250 @@ func (r *rpcServer) ExportProof(ctx context.Context,
 	}, nil
 }
 
+// ExportAllProofs streams an archive that contains the proof files of all
+// unspent assets owned by the wallet, the address book and the descriptors of
+// the script and internal keys of the assets.
+func (r *rpcServer) ExportAllProofs(_ *taprpc.ExportAllProofsRequest,
+	stream taprpc.TaprootAssets_ExportAllProofsServer) error {
+
+	ctx := stream.Context()
+	archive, err := r.newWalletArchive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := archive.Encode(&buf); err != nil {
+		return fmt.Errorf("unable to encode wallet archive: %w", err)
+	}
+
+	rpcsLog.Infof("[ExportAllProofs]: exporting %d proofs and %d "+
+		"addresses (%d bytes)", len(archive.Proofs), len(archive.Addrs),
+		buf.Len())
+
+	return proof.SendBlobChunks(&buf, func(chunk []byte) error {
+		return stream.Send(&taprpc.ProofArchiveChunk{
+			Chunk: chunk,
+		})
+	})
+}
+
+// newWalletArchive creates a wallet archive with the proof files of all
+// confirmed and unspent assets owned by the wallet, including leased ones,
+// and the full address book.
+func (r *rpcServer) newWalletArchive(
+	ctx context.Context) (*walletarchive.Archive, error) {
+
+	// Any script key type is allowed, as we don't select coins here. The
+	// proofs of unconfirmed mints aren't final yet, so we skip them.
+	query := &tapdb.AssetQueryFilters{
+		CommitmentConstraints: tapfreighter.CommitmentConstraints{
+			CoinSelectType: tapsend.ScriptTreesAllowed,
+		},
+		MinAnchorHeight: 1,
+	}
+	assets, err := r.cfg.AssetStore.FetchAllAssets(ctx, false, true, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chain assets: %w", err)
+	}
+
+	archive := &walletarchive.Archive{
+		Manifest: walletarchive.Manifest{
+			Network:   r.cfg.ChainParams.Name,
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+
+	var (
+		scriptKeys   = make(map[asset.SerializedKey]struct{})
+		internalKeys = make(map[asset.SerializedKey]struct{})
+	)
+	for _, a := range assets {
+		blob, err := r.cfg.ProofArchive.FetchProof(ctx, proof.Locator{
+			AssetID:   fn.Ptr(a.ID()),
+			ScriptKey: *a.ScriptKey.PubKey,
+			OutPoint:  &a.AnchorOutpoint,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch proof of "+
+				"asset %v at %v: %w", a.ID(), a.AnchorOutpoint,
+				err)
+		}
+		archive.Proofs = append(archive.Proofs, blob)
+
+		scriptKey := asset.ToSerialized(a.ScriptKey.PubKey)
+		if _, ok := scriptKeys[scriptKey]; !ok {
+			scriptKeys[scriptKey] = struct{}{}
+
+			archivedKey, err := walletarchive.NewScriptKey(
+				a.ScriptKey,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("unable to archive "+
+					"script key %x: %w", scriptKey[:], err)
+			}
+			archive.ScriptKeys = append(
+				archive.ScriptKeys, archivedKey,
+			)
+		}
+
+		internalKey := asset.ToSerialized(a.AnchorInternalKey)
+		if _, ok := internalKeys[internalKey]; ok {
+			continue
+		}
+		internalKeys[internalKey] = struct{}{}
+
+		keyLoc, err := r.cfg.TapAddrBook.FetchInternalKeyLocator(
+			ctx, a.AnchorInternalKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch internal key "+
+				"%x: %w", internalKey[:], err)
+		}
+		archive.InternalKeys = append(
+			archive.InternalKeys, walletarchive.NewKeyDescriptor(
+				keychain.KeyDescriptor{
+					KeyLocator: keyLoc,
+					PubKey:     a.AnchorInternalKey,
+				},
+			),
+		)
+	}
+
+	addrs, err := r.cfg.AddrBook.ListAddrs(ctx, address.QueryParams{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list addresses: %w", err)
+	}
+	for idx := range addrs {
+		archivedAddr, err := walletarchive.NewAddr(&addrs[idx])
+		if err != nil {
+			return nil, err
+		}
+		archive.Addrs = append(archive.Addrs, archivedAddr)
+	}
+
+	return archive, nil
+}
+
+// ImportProofArchive imports an archive that was created by ExportAllProofs.
+func (r *rpcServer) ImportProofArchive(
+	stream taprpc.TaprootAssets_ImportProofArchiveServer) error {
+
+	ctx := stream.Context()
+	archiveReader := proof.NewBlobChunkReader(func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		return req.Chunk, nil
+	})
+
+	archive, err := walletarchive.Decode(archiveReader)
+	if err != nil {
+		return fmt.Errorf("unable to decode wallet archive: %w", err)
+	}
+
+	if archive.Manifest.Network != r.cfg.ChainParams.Name {
+		return fmt.Errorf("wallet archive was created on %s, but the "+
+			"daemon runs on %s", archive.Manifest.Network,
+			r.cfg.ChainParams.Name)
+	}
+
+	resp, err := r.importWalletArchive(ctx, archive)
+	if err != nil {
+		return err
+	}
+
+	rpcsLog.Infof("[ImportProofArchive]: imported %d proofs (%d "+
+		"skipped) and %d addresses (%d skipped)",
+		resp.NumProofsImported, resp.NumProofsSkipped,
+		resp.NumAddrsImported, resp.NumAddrsSkipped)
+
+	return stream.SendAndClose(resp)
+}
+
+// importWalletArchive imports the content of the given wallet archive. The key
+// descriptors are imported first, so the imported assets are recognized as
+// owned by the wallet. The proofs are imported before the addresses, so the
+// assets of the addresses are known without syncing them from a universe.
+func (r *rpcServer) importWalletArchive(ctx context.Context,
+	archive *walletarchive.Archive) (*taprpc.ImportProofArchiveResponse,
+	error) {
+
+	var resp taprpc.ImportProofArchiveResponse
+
+	for _, archivedKey := range archive.InternalKeys {
+		keyDesc, err := archivedKey.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid internal key: %w", err)
+		}
+
+		err = r.cfg.TapAddrBook.InsertInternalKey(ctx, keyDesc)
+		if err != nil {
+			return nil, err
+		}
+		resp.NumInternalKeys++
+	}
+
+	for _, archivedKey := range archive.ScriptKeys {
+		scriptKey, err := archivedKey.Parse()
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.cfg.AddrBook.InsertScriptKey(
+			ctx, scriptKey, scriptKey.DeclaredKnown,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to insert script key: "+
+				"%w", err)
+		}
+		resp.NumScriptKeys++
+	}
+
+	for idx, blob := range archive.Proofs {
+		imported, err := importProofFile(
+			ctx, r.cfg.ProofArchive, r.cfg.ChainBridge,
+			r.cfg.MintingStore, blob,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import proof %d: %w",
+				idx, err)
+		}
+
+		if imported {
+			resp.NumProofsImported++
+		} else {
+			resp.NumProofsSkipped++
+		}
+	}
+
+	for _, archivedAddr := range archive.Addrs {
+		addr, scriptKey, internalKey, err := archivedAddr.Parse(
+			&r.cfg.ChainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		imported, err := importAddrWithKeys(
+			ctx, r.cfg.AddrBook, addr, scriptKey, internalKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if imported {
+			resp.NumAddrsImported++
+		} else {
+			resp.NumAddrsSkipped++
+		}
+	}
+
+	return &resp, nil
+}
+
 // ImportProof attempts to import a proof file into the daemon. If successful, a
 // new asset will be inserted on disk, spendable using the specified target
 // script key, and internal key.
@@ -1940,6 +3202,150 @@ func (r *rpcServer) ImportProof(ctx context.Context,
 	return &tapdevrpc.ImportProofResponse{}, nil
 }
 
+// marshalChannelAssetOutputs converts the given channel asset outputs to their
+// RPC counterparts.
+func marshalChannelAssetOutputs(
+	outputs []*cmsg.AssetOutput) []*tapdevrpc.ChannelAssetOutput {
+
+	rpcOutputs := make([]*tapdevrpc.ChannelAssetOutput, len(outputs))
+	for idx, o := range outputs {
+		assetID := o.AssetID.Val
+		rpcOutputs[idx] = &tapdevrpc.ChannelAssetOutput{
+			AssetId:        assetID[:],
+			Amount:         o.Amount.Val,
+			AnchorOutpoint: o.Proof.Val.OutPoint().String(),
+		}
+	}
+
+	return rpcOutputs
+}
+
+// marshalHtlcAssetAllocations converts the asset outputs of the given HTLCs and
+// their auxiliary leaves to their RPC counterparts, ordered by HTLC index.
+func marshalHtlcAssetAllocations(htlcs cmsg.HtlcAssetOutput,
+	leaves cmsg.HtlcAuxLeafMapRecord) []*tapdevrpc.HtlcAssetAllocation {
+
+	allocations := make(
+		[]*tapdevrpc.HtlcAssetAllocation, 0, len(htlcs.HtlcOutputs),
+	)
+	for htlcIndex, outputs := range htlcs.HtlcOutputs {
+		allocation := &tapdevrpc.HtlcAssetAllocation{
+			HtlcIndex: htlcIndex,
+			Assets:    marshalChannelAssetOutputs(outputs.Outputs),
+		}
+
+		leaf, ok := leaves.HtlcAuxLeaves[htlcIndex]
+		if ok {
+			leaf.AuxLeaf.WhenSomeV(func(l cmsg.TapLeafRecord) {
+				allocation.AuxLeafScript = l.Leaf.Script
+			})
+			leaf.SecondLevelLeaf.WhenSomeV(
+				func(l cmsg.TapLeafRecord) {
+					allocation.SecondLevelAuxLeafScript =
+						l.Leaf.Script
+				},
+			)
+		}
+
+		allocations = append(allocations, allocation)
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].HtlcIndex < allocations[j].HtlcIndex
+	})
+
+	return allocations
+}
+
+// marshalCommitmentAllocations converts an asset commitment to its RPC
+// counterpart. A nil commitment is converted to nil.
+func marshalCommitmentAllocations(
+	c *cmsg.Commitment) *tapdevrpc.CommitmentAllocations {
+
+	if c == nil {
+		return nil
+	}
+
+	leaves := c.AuxLeaves.Val
+	rpcCommit := &tapdevrpc.CommitmentAllocations{
+		LocalAssets:  marshalChannelAssetOutputs(c.LocalOutputs()),
+		RemoteAssets: marshalChannelAssetOutputs(c.RemoteOutputs()),
+		OutgoingHtlcs: marshalHtlcAssetAllocations(
+			c.OutgoingHtlcAssets.Val, leaves.OutgoingHtlcLeaves.Val,
+		),
+		IncomingHtlcs: marshalHtlcAssetAllocations(
+			c.IncomingHtlcAssets.Val, leaves.IncomingHtlcLeaves.Val,
+		),
+	}
+
+	leaves.LocalAuxLeaf.WhenSomeV(func(l cmsg.TapLeafRecord) {
+		rpcCommit.LocalAuxLeafScript = l.Leaf.Script
+	})
+	leaves.RemoteAuxLeaf.WhenSomeV(func(l cmsg.TapLeafRecord) {
+		rpcCommit.RemoteAuxLeafScript = l.Leaf.Script
+	})
+
+	return rpcCommit
+}
+
+// marshalCommitmentChainState converts the recorded commitments of one side of
+// a channel to their RPC counterpart.
+func marshalCommitmentChainState(
+	c tapchannel.RecordedCommitments) *tapdevrpc.CommitmentChainState {
+
+	return &tapdevrpc.CommitmentChainState{
+		Current:   marshalCommitmentAllocations(c.Current),
+		Previous:  marshalCommitmentAllocations(c.Previous),
+		UpdatedAt: c.UpdatedAt.Unix(),
+	}
+}
+
+// DumpChannelCommitments returns the current and previous asset commitment
+// allocations of both sides of an asset channel.
+func (r *rpcServer) DumpChannelCommitments(_ context.Context,
+	req *tapdevrpc.DumpChannelCommitmentsRequest) (
+	*tapdevrpc.DumpChannelCommitmentsResponse, error) {
+
+	recorder := r.cfg.AuxCommitmentRecorder
+	if recorder == nil {
+		return nil, fmt.Errorf("channel commitment recording is not " +
+			"enabled")
+	}
+
+	var channels []tapchannel.ChannelCommitments
+	switch {
+	case req.ChanPoint != "":
+		chanPoint, err := wire.NewOutPointFromString(req.ChanPoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel point: %w",
+				err)
+		}
+
+		channel, ok := recorder.FetchChannel(*chanPoint)
+		if !ok {
+			return nil, fmt.Errorf("no commitments known for "+
+				"channel %v", chanPoint)
+		}
+		channels = append(channels, channel)
+
+	default:
+		channels = recorder.ListChannels()
+	}
+
+	rpcChannels := make([]*tapdevrpc.ChannelCommitments, len(channels))
+	for idx, c := range channels {
+		rpcChannels[idx] = &tapdevrpc.ChannelCommitments{
+			ChanPoint:        c.ChannelPoint.String(),
+			LocalCommitment:  marshalCommitmentChainState(c.Local),
+			RemoteCommitment: marshalCommitmentChainState(c.Remote),
+		}
+	}
+
+	return &tapdevrpc.DumpChannelCommitmentsResponse{
+		Channels: rpcChannels,
+	}, nil
+}
+
 // AddrReceives lists all receives for incoming asset transfers for addresses
 // that were created previously.
 func (r *rpcServer) AddrReceives(ctx context.Context,
@@ -2029,6 +3435,12 @@ func (r *rpcServer) FundVirtualPsbt(ctx context.Context,
 			err)
 	}
 
+	// Only select inputs of a single account if one was specified.
+	acct, err := r.accountFilter(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var fundedVPkt *tapfreighter.FundedVPacket
 	switch {
 	case req.GetPsbt() != nil:
@@ -2051,6 +3463,8 @@ func (r *rpcServer) FundVirtualPsbt(ctx context.Context,
 		}
 
 		desc.CoinSelectType = coinSelectType
+		desc.ExactAmount = req.ExactAmount
+		desc.Account = acct
 		fundedVPkt, err = r.cfg.AssetWallet.FundPacket(ctx, desc, vPkt)
 		if err != nil {
 			return nil, fmt.Errorf("error funding packet: %w", err)
@@ -2065,6 +3479,10 @@ func (r *rpcServer) FundVirtualPsbt(ctx context.Context,
 		if len(raw.Recipients) > 1 {
 			return nil, fmt.Errorf("only one recipient supported")
 		}
+		if req.ExactAmount {
+			return nil, fmt.Errorf("exact amount selection is " +
+				"only supported for PSBT templates")
+		}
 
 		var (
 			addr *address.Tap
@@ -2083,7 +3501,7 @@ func (r *rpcServer) FundVirtualPsbt(ctx context.Context,
 		}
 
 		fundedVPkt, err = r.cfg.AssetWallet.FundAddressSend(
-			ctx, coinSelectType, addr,
+			ctx, coinSelectType, acct, addr,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error funding address send: "+
@@ -2163,6 +3581,39 @@ func (r *rpcServer) SignVirtualPsbt(ctx context.Context,
 		return nil, fmt.Errorf("error decoding packet: %w", err)
 	}
 
+	// When partially signing, we only sign the inputs of our own wallet.
+	// Since the derivation information of the other inputs was added by
+	// other parties, we can't rely on it to identify our inputs.
+	if req.Partial {
+		signInputs, err := r.localVirtualInputs(ctx, vPkt)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(signInputs) == 0 {
+			return nil, fmt.Errorf("packet has no inputs owned " +
+				"by this wallet")
+		}
+
+		signedInputs, err := r.cfg.AssetWallet.SignVirtualPacket(
+			vPkt, tapfreighter.WithSignInputs(signInputs...),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error signing packet: %w", err)
+		}
+
+		signedPsbtBytes, err := serialize(vPkt)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing packet: %w",
+				err)
+		}
+
+		return &wrpc.SignVirtualPsbtResponse{
+			SignedPsbt:   signedPsbtBytes,
+			SignedInputs: signedInputs,
+		}, nil
+	}
+
 	// Make sure the input keys are known.
 	for _, input := range vPkt.Inputs {
 		// If we have all the derivation information, we don't need to
@@ -2216,9 +3667,47 @@ func (r *rpcServer) SignVirtualPsbt(ctx context.Context,
 	}, nil
 }
 
-// AnchorVirtualPsbts merges and then commits multiple virtual transactions in
-// a single BTC level anchor transaction.
-func (r *rpcServer) AnchorVirtualPsbts(ctx context.Context,
+// localVirtualInputs returns the indexes of the inputs of the packet that
+// spend assets owned by our wallet and sets their derivation information.
+func (r *rpcServer) localVirtualInputs(ctx context.Context,
+	vPkt *tappsbt.VPacket) ([]uint32, error) {
+
+	var localInputs []uint32
+	for idx, input := range vPkt.Inputs {
+		scriptKey := input.Asset().ScriptKey
+		tweakedScriptKey, err := r.cfg.AssetWallet.FetchScriptKey(
+			ctx, scriptKey.PubKey,
+		)
+		switch {
+		case errors.Is(err, address.ErrScriptKeyNotFound):
+			continue
+
+		case err != nil:
+			return nil, fmt.Errorf("error fetching script key: %w",
+				err)
+		}
+
+		if !r.cfg.AddrBook.IsLocalKey(ctx, tweakedScriptKey.RawKey) {
+			continue
+		}
+
+		derivation, trDerivation := tappsbt.Bip32DerivationFromKeyDesc(
+			tweakedScriptKey.RawKey, r.cfg.ChainParams.HDCoinType,
+		)
+		input.Bip32Derivation = []*psbt.Bip32Derivation{derivation}
+		input.TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{
+			trDerivation,
+		}
+
+		localInputs = append(localInputs, uint32(idx))
+	}
+
+	return localInputs, nil
+}
+
+// AnchorVirtualPsbts merges and then commits multiple virtual transactions in
+// a single BTC level anchor transaction.
+func (r *rpcServer) AnchorVirtualPsbts(ctx context.Context,
 	req *wrpc.AnchorVirtualPsbtsRequest) (*taprpc.SendAssetResponse,
 	error) {
 
@@ -2466,7 +3955,19 @@ func (r *rpcServer) CommitVirtualPsbts(ctx context.Context,
 		ChangeOutputIndex: changeIndex,
 	}
 
-	response.AnchorPsbt, err = serialize(fundedPacket)
+	// If the anchor PSBT is going to be signed by an external signer, we
+	// encode the anchor output fields as proprietary fields, so they aren't
+	// dropped along the way.
+	anchorPacket := fundedPacket
+	if req.ProprietaryAnchorFields {
+		anchorPacket, err = tappsbt.ExportAnchorPsbt(fundedPacket)
+		if err != nil {
+			return nil, fmt.Errorf("error exporting anchor "+
+				"packet: %w", err)
+		}
+	}
+
+	response.AnchorPsbt, err = serialize(anchorPacket)
 	if err != nil {
 		return nil, fmt.Errorf("error serializing packet: %w", err)
 	}
@@ -2680,6 +4181,10 @@ func (r *rpcServer) PublishAndLogTransfer(ctx context.Context,
 		return nil, fmt.Errorf("error decoding packet: %w", err)
 	}
 
+	// The packet might have been signed by an external signer, in which
+	// case the anchor output fields are encoded as proprietary fields.
+	tappsbt.ImportAnchorPsbt(pkt)
+
 	activePackets, err := decodeVirtualPackets(req.VirtualPsbts)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding active packets: %w", err)
@@ -2713,6 +4218,15 @@ func (r *rpcServer) PublishAndLogTransfer(ctx context.Context,
 			err)
 	}
 
+	// External signers usually only add the signatures without finalizing
+	// the inputs, so we attempt to do that here.
+	if !pkt.IsComplete() {
+		if err := psbt.MaybeFinalizeAll(pkt); err != nil {
+			return nil, fmt.Errorf("error finalizing anchor "+
+				"transaction: %w", err)
+		}
+	}
+
 	// The BTC level transaction must be fully complete, and we must be able
 	// to extract the final transaction from it.
 	finalTx, err := psbt.Extract(pkt)
@@ -3051,6 +4565,18 @@ func marshalAddr(addr *address.Tap,
 		rpcAddr.GroupKey = addr.GroupKey.SerializeCompressed()
 	}
 
+	for _, courierAddr := range addr.FallbackProofCourierAddrs {
+		rpcAddr.FallbackProofCourierAddrs = append(
+			rpcAddr.FallbackProofCourierAddrs, courierAddr.String(),
+		)
+	}
+
+	if !addr.Expiry.IsZero() {
+		rpcAddr.ExpiryTimestamp = addr.Expiry.Unix()
+	}
+
+	rpcAddr.Reusable = addr.Reusable
+
 	return rpcAddr, nil
 }
 
@@ -3188,8 +4714,47 @@ func (r *rpcServer) SendAsset(ctx context.Context,
 		return nil, err
 	}
 
+	// Only the assets of the given account are spent, so the funds of
+	// the different accounts stay segregated.
+	acct, err := account.Resolve(ctx, r.cfg.Accounts, req.Account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %w", err)
+	}
+
+	proofDelivery := tapfreighter.ProofDelivery{
+		Skip: req.SkipProofDelivery,
+	}
+	if req.ProofCourierAddr != "" {
+		proofDelivery.CourierAddr, err = proof.ParseCourierAddress(
+			req.ProofCourierAddr,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof courier "+
+				"address: %w", err)
+		}
+	}
+	for _, addrStr := range req.AdditionalProofCourierAddrs {
+		courierAddr, err := proof.ParseCourierAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid additional proof "+
+				"courier address: %w", err)
+		}
+
+		proofDelivery.AdditionalCourierAddrs = append(
+			proofDelivery.AdditionalCourierAddrs, courierAddr,
+		)
+	}
+	proofDelivery.Mode, err = unmarshalProofCourierMode(
+		req.ProofCourierMode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := r.cfg.ChainPorter.RequestShipment(
-		tapfreighter.NewAddressParcel(feeRate, tapAddrs...),
+		tapfreighter.NewAddressParcel(
+			feeRate, fn.Some(acct), proofDelivery, tapAddrs...,
+		),
 	)
 	if err != nil {
 		return nil, err
@@ -3234,7 +4799,14 @@ func (r *rpcServer) BurnAsset(ctx context.Context,
 		return nil, fmt.Errorf("asset ID must be specified")
 	}
 
-	if in.AmountToBurn == 0 {
+	amountToBurn, err := r.resolveAssetAmount(
+		ctx, assetID, in.AmountToBurn, in.DisplayAmountToBurn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if amountToBurn == 0 {
 		return nil, fmt.Errorf("amount to burn must be specified")
 	}
 	if in.ConfirmationText != AssetBurnConfirmationText {
@@ -3266,7 +4838,7 @@ func (r *rpcServer) BurnAsset(ctx context.Context,
 
 	rpcsLog.Infof("Burning asset (asset_id=%x, group_key=%x, "+
 		"burn_amount=%d)", assetID[:], serializedGroupKey,
-		in.AmountToBurn)
+		amountToBurn)
 
 	assetSpecifier := asset.NewSpecifierOptionalGroupPubKey(
 		assetID, groupKey,
@@ -3275,7 +4847,7 @@ func (r *rpcServer) BurnAsset(ctx context.Context,
 	fundResp, err := r.cfg.AssetWallet.FundBurn(
 		ctx, &tapsend.FundingDescriptor{
 			AssetSpecifier: assetSpecifier,
-			Amount:         in.AmountToBurn,
+			Amount:         amountToBurn,
 		},
 	)
 	if err != nil {
@@ -3421,6 +4993,13 @@ func marshalOutboundParcel(
 		// Marshall the proof delivery status.
 		proofDeliveryStatus := marshalOutputProofDeliveryStatus(out)
 
+		courierMode, err := marshalProofCourierMode(
+			out.ProofCourierMode,
+		)
+		if err != nil {
+			return nil, err
+		}
+
 		rpcOutputs[idx] = &taprpc.TransferOutput{
 			Anchor:              rpcAnchor,
 			ScriptKey:           scriptPubKey.SerializeCompressed(),
@@ -3433,6 +5012,10 @@ func marshalOutboundParcel(
 			OutputType:          rpcOutType,
 			AssetVersion:        assetVersion,
 			ProofDeliveryStatus: proofDeliveryStatus,
+			ProofCourierMode:    courierMode,
+			ProofCourierDeliveries: marshalProofCourierDeliveries(
+				out.ProofCourierDeliveries,
+			),
 		}
 	}
 
@@ -3448,13 +5031,14 @@ func marshalOutboundParcel(
 	})
 
 	return &taprpc.AssetTransfer{
-		TransferTimestamp:  parcel.TransferTime.Unix(),
-		AnchorTxHash:       anchorTxHash[:],
-		AnchorTxHeightHint: parcel.AnchorTxHeightHint,
-		AnchorTxChainFees:  parcel.ChainFees,
-		AnchorTxBlockHash:  anchorTxBlockHash,
-		Inputs:             rpcInputs,
-		Outputs:            rpcOutputs,
+		TransferTimestamp:   parcel.TransferTime.Unix(),
+		AnchorTxHash:        anchorTxHash[:],
+		AnchorTxHeightHint:  parcel.AnchorTxHeightHint,
+		AnchorTxChainFees:   parcel.ChainFees,
+		AnchorTxBlockHash:   anchorTxBlockHash,
+		AnchorTxBlockHeight: parcel.AnchorTxBlockHeight,
+		Inputs:              rpcInputs,
+		Outputs:             rpcOutputs,
 	}, nil
 }
 
@@ -3463,6 +5047,10 @@ func marshalOutboundParcel(
 func marshalOutputProofDeliveryStatus(
 	out tapfreighter.TransferOutput) taprpc.ProofDeliveryStatus {
 
+	if out.ProofDeliverySkipped {
+		return taprpc.ProofDeliveryStatusSkipped
+	}
+
 	proofDeliveryStatus := taprpc.ProofDeliveryStatusNotApplicable
 	out.ProofDeliveryComplete.WhenSome(func(complete bool) {
 		if complete {
@@ -3475,6 +5063,66 @@ func marshalOutputProofDeliveryStatus(
 	return proofDeliveryStatus
 }
 
+// marshalProofCourierMode turns the proof courier mode into the RPC
+// counterpart.
+func marshalProofCourierMode(
+	mode tapfreighter.ProofCourierMode) (taprpc.ProofCourierMode, error) {
+
+	switch mode {
+	case tapfreighter.ProofCourierModeFailover:
+		return taprpc.ProofCourierModeFailover, nil
+
+	case tapfreighter.ProofCourierModeAll:
+		return taprpc.ProofCourierModeAll, nil
+
+	default:
+		return 0, fmt.Errorf("unknown proof courier mode: %v", mode)
+	}
+}
+
+// unmarshalProofCourierMode parses the RPC proof courier mode.
+func unmarshalProofCourierMode(
+	mode taprpc.ProofCourierMode) (tapfreighter.ProofCourierMode, error) {
+
+	switch mode {
+	case taprpc.ProofCourierModeFailover:
+		return tapfreighter.ProofCourierModeFailover, nil
+
+	case taprpc.ProofCourierModeAll:
+		return tapfreighter.ProofCourierModeAll, nil
+
+	default:
+		return 0, fmt.Errorf("unknown proof courier mode: %v", mode)
+	}
+}
+
+// marshalProofCourierDeliveries turns the per-courier proof delivery states of
+// a transfer output into their RPC counterpart.
+func marshalProofCourierDeliveries(
+	deliveries []tapfreighter.ProofCourierDelivery,
+) []*taprpc.ProofCourierDelivery {
+
+	rpcDeliveries := make(
+		[]*taprpc.ProofCourierDelivery, 0, len(deliveries),
+	)
+	for _, delivery := range deliveries {
+		var lastAttempt int64
+		if !delivery.LastAttempt.IsZero() {
+			lastAttempt = delivery.LastAttempt.Unix()
+		}
+
+		rpcDelivery := &taprpc.ProofCourierDelivery{
+			CourierAddr:          string(delivery.CourierAddr),
+			Delivered:            delivery.Delivered,
+			LastError:            delivery.LastError,
+			LastAttemptTimestamp: lastAttempt,
+		}
+		rpcDeliveries = append(rpcDeliveries, rpcDelivery)
+	}
+
+	return rpcDeliveries
+}
+
 // marshalOutputType turns the transfer output type into the RPC counterpart.
 func marshalOutputType(outputType tappsbt.VOutputType) (taprpc.OutputType,
 	error) {
@@ -3592,13 +5240,17 @@ func (r *rpcServer) SubscribeReceiveEvents(
 			errString = e.Error.Error()
 		}
 
+		attempt := uint32(e.ProofRetrievalAttempt)
+		backoff := e.ProofRetrievalBackoff
 		return &taprpc.ReceiveEvent{
-			Timestamp:          e.Timestamp().UnixMicro(),
-			Address:            rpcAddr,
-			Outpoint:           e.OutPoint.String(),
-			Status:             rpcStatus,
-			ConfirmationHeight: e.ConfirmationHeight,
-			Error:              errString,
+			Timestamp:               e.Timestamp().UnixMicro(),
+			Address:                 rpcAddr,
+			Outpoint:                e.OutPoint.String(),
+			Status:                  rpcStatus,
+			ConfirmationHeight:      e.ConfirmationHeight,
+			Error:                   errString,
+			ProofRetrievalAttempt:   attempt,
+			ProofRetrievalBackoffUs: backoff.Microseconds(),
 		}, nil
 	}
 
@@ -3610,6 +5262,14 @@ func (r *rpcServer) SubscribeReceiveEvents(
 
 		switch e := event.(type) {
 		case *tapgarden.AssetReceiveEvent:
+			// Failed proof retrieval attempts are only reported
+			// if explicitly requested.
+			if e.IsProofRetrievalAttempt() &&
+				!req.IncludeProofRetrievalAttempts {
+
+				return false, nil
+			}
+
 			eventAddrString, err = e.Address.EncodeAddress()
 			if err != nil {
 				return false, fmt.Errorf("error encoding "+
@@ -3849,3628 +5509,8860 @@ func handleEvents[T any, Q any](eventSource fn.EventPublisher[fn.Event, T],
 	}
 }
 
-// marshallReceiveAssetEvent maps an asset receive event to its RPC counterpart.
-func marshallReceiveAssetEvent(event fn.Event,
-	db address.Storage) (*tapdevrpc.ReceiveAssetEvent, error) {
+// ListJobs lists the long-running jobs tracked by the daemon, including their
+// state and progress.
+func (r *rpcServer) ListJobs(ctx context.Context,
+	req *taprpc.ListJobsRequest) (*taprpc.ListJobsResponse, error) {
 
-	switch e := event.(type) {
-	case *proof.BackoffWaitEvent:
-		// Map the transfer type to the RPC counterpart. We only
-		// support the "receive" transfer type for asset receive events.
-		var transferTypeRpc tapdevrpc.ProofTransferType
-		switch e.TransferType {
-		case proof.ReceiveTransferType:
-			transferTypeRpc = proofTypeReceive
-		default:
-			return nil, fmt.Errorf("unexpected transfer type: %v",
-				e.TransferType)
-		}
+	dbJobs, err := r.cfg.JobManager.ListJobs(ctx, req.ActiveOnly)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list jobs: %w", err)
+	}
 
-		evt := &tapdevrpc.ProofTransferBackoffWaitEvent{
-			Timestamp:    e.Timestamp().UnixMicro(),
-			Backoff:      e.Backoff.Microseconds(),
-			TriesCounter: e.TriesCounter,
-			TransferType: transferTypeRpc,
+	rpcJobs := make([]*taprpc.Job, 0, len(dbJobs))
+	for _, job := range dbJobs {
+		rpcJobs = append(rpcJobs, marshalJob(job))
+	}
+
+	return &taprpc.ListJobsResponse{
+		Jobs: rpcJobs,
+	}, nil
+}
+
+// CancelJob cancels a running job.
+func (r *rpcServer) CancelJob(ctx context.Context,
+	req *taprpc.CancelJobRequest) (*taprpc.CancelJobResponse, error) {
+
+	if req.JobId == 0 {
+		return nil, fmt.Errorf("job ID must be specified")
+	}
+
+	err := r.cfg.JobManager.Cancel(ctx, jobs.ID(req.JobId))
+	if err != nil {
+		return nil, fmt.Errorf("unable to cancel job: %w", err)
+	}
+
+	return &taprpc.CancelJobResponse{}, nil
+}
+
+// SubscribeJobUpdates allows a caller to subscribe to the state and progress
+// updates of jobs. If a specific job is watched, the stream ends once that job
+// has finished.
+func (r *rpcServer) SubscribeJobUpdates(
+	req *taprpc.SubscribeJobUpdatesRequest,
+	stream taprpc.TaprootAssets_SubscribeJobUpdatesServer) error {
+
+	ctx := stream.Context()
+	jobManager := r.cfg.JobManager
+	watchAll := req.JobId == 0
+
+	// We register the subscriber before fetching the current state of a
+	// watched job, so we can't miss any update in between.
+	subscriber := fn.NewEventReceiver[jobs.Job](fn.DefaultQueueSize)
+	defer subscriber.Stop()
+
+	err := jobManager.RegisterSubscriber(subscriber, watchAll, false)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to job updates: %w", err)
+	}
+	defer func() {
+		err := jobManager.RemoveSubscriber(subscriber)
+		if err != nil {
+			rpcsLog.Errorf("Error unsubscribing subscriber: %v",
+				err)
 		}
-		return &tapdevrpc.ReceiveAssetEvent{
-			Event: &receiveBackoff{
-				ProofTransferBackoffWaitEvent: evt,
-			},
-		}, nil
+	}()
 
-	case *tapgarden.AssetReceiveEvent:
-		rpcAddr, err := marshalAddr(&e.Address, db)
+	if !watchAll {
+		job, err := jobManager.FetchJob(ctx, jobs.ID(req.JobId))
 		if err != nil {
-			return nil, fmt.Errorf("error marshaling addr: %w", err)
+			return fmt.Errorf("unable to fetch job: %w", err)
 		}
 
-		evt := &tapdevrpc.AssetReceiveCompleteEvent{
-			Timestamp: e.Timestamp().UnixMicro(),
-			Address:   rpcAddr,
-			Outpoint:  e.OutPoint.String(),
+		if err := stream.Send(marshalJob(job)); err != nil {
+			return err
 		}
-		return &tapdevrpc.ReceiveAssetEvent{
-			Event: &receiveComplete{
-				AssetReceiveCompleteEvent: evt,
-			},
-		}, nil
 
-	default:
-		return nil, fmt.Errorf("unknown event type: %T", e)
+		if job.State.IsFinal() {
+			return nil
+		}
 	}
-}
 
-// marshallSendAssetEvent maps an asset send event to its RPC counterpart.
-func marshallSendAssetEvent(event fn.Event) (*tapdevrpc.SendAssetEvent, error) {
-	switch e := event.(type) {
-	case *tapfreighter.AssetSendEvent:
-		evt := &tapdevrpc.ExecuteSendStateEvent{
-			Timestamp: e.Timestamp().UnixMicro(),
-			SendState: e.SendState.String(),
-		}
-		return &tapdevrpc.SendAssetEvent{
-			Event: &sendExecute{
-				ExecuteSendStateEvent: evt,
-			},
-		}, nil
+	for {
+		select {
+		case job := <-subscriber.NewItemCreated.ChanOut():
+			if !watchAll && job.ID != jobs.ID(req.JobId) {
+				continue
+			}
 
-	case *proof.BackoffWaitEvent:
-		// Map the transfer type to the RPC counterpart. We only
-		// support the send transfer type for asset send events.
-		var transferTypeRpc tapdevrpc.ProofTransferType
-		switch e.TransferType {
-		case proof.SendTransferType:
-			transferTypeRpc = proofTypeSend
-		default:
-			return nil, fmt.Errorf("unexpected transfer type: %v",
-				e.TransferType)
-		}
+			if err := stream.Send(marshalJob(&job)); err != nil {
+				return err
+			}
 
-		evt := &tapdevrpc.ProofTransferBackoffWaitEvent{
-			Timestamp:    e.Timestamp().UnixMicro(),
-			Backoff:      e.Backoff.Microseconds(),
-			TriesCounter: e.TriesCounter,
-			TransferType: transferTypeRpc,
+			if !watchAll && job.State.IsFinal() {
+				return nil
+			}
+
+		case <-ctx.Done():
+			// Don't return an error if a normal context
+			// cancellation has occurred.
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+
+			return ctx.Err()
+
+		case <-r.quit:
+			return nil
 		}
-		return &tapdevrpc.SendAssetEvent{
-			Event: &sendBackoff{
-				ProofTransferBackoffWaitEvent: evt,
-			},
-		}, nil
+	}
+}
 
-	default:
-		return nil, fmt.Errorf("unknown event type: %T", e)
+// marshalJob maps a job to its RPC counterpart.
+func marshalJob(job *jobs.Job) *taprpc.Job {
+	return &taprpc.Job{
+		JobId:           uint64(job.ID),
+		Kind:            string(job.Kind),
+		State:           taprpc.JobState(job.State),
+		ProgressPercent: uint32(job.Progress),
+		ErrorMessage:    job.ErrorMsg,
+		CreatedAt:       job.CreatedAt.Unix(),
+		UpdatedAt:       job.UpdatedAt.Unix(),
 	}
 }
 
-// marshalSendEvent marshals an asset send event into the RPC counterpart.
-func marshalSendEvent(event fn.Event) (*taprpc.SendEvent, error) {
-	e, ok := event.(*tapfreighter.AssetSendEvent)
-	if !ok {
-		return nil, fmt.Errorf("invalid event type: %T", event)
+// ExportRpcJournal exports the entries of the RPC journal.
+func (r *rpcServer) ExportRpcJournal(ctx context.Context,
+	req *taprpc.ExportRpcJournalRequest) (*taprpc.ExportRpcJournalResponse,
+	error) {
+
+	if req.StartTimestamp < 0 || req.EndTimestamp < 0 {
+		return nil, fmt.Errorf("timestamps must not be negative")
+	}
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit must not be negative")
 	}
 
-	result := &taprpc.SendEvent{
-		Timestamp:             e.Timestamp().UnixMicro(),
-		SendState:             e.SendState.String(),
-		VirtualPackets:        make([][]byte, len(e.VirtualPackets)),
-		PassiveVirtualPackets: make([][]byte, len(e.PassivePackets)),
+	query := rpcjournal.Query{
+		StartAfter:  time.Unix(req.StartTimestamp, 0),
+		StartBefore: time.Now(),
+		Offset:      req.Offset,
+		Limit:       req.Limit,
+	}
+	if req.EndTimestamp != 0 {
+		query.StartBefore = time.Unix(req.EndTimestamp, 0)
+	}
+	if req.Method != "" {
+		query.Method = fn.Some(req.Method)
+	}
+	if query.Limit == 0 {
+		query.Limit = rpcjournal.DefaultQueryLimit
 	}
 
-	if e.Error != nil {
-		result.Error = e.Error.Error()
+	entries, err := r.cfg.RPCJournalStore.QueryEntries(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query RPC journal: %w", err)
 	}
 
-	var err error
-	for idx, vPkt := range e.VirtualPackets {
-		result.VirtualPackets[idx], err = tappsbt.Encode(vPkt)
-		if err != nil {
-			return nil, fmt.Errorf("error encoding virtual "+
-				"packet: %w", err)
-		}
-	}
-	for idx, vPkt := range e.PassivePackets {
-		result.PassiveVirtualPackets[idx], err = tappsbt.Encode(vPkt)
-		if err != nil {
-			return nil, fmt.Errorf("error encoding virtual "+
-				"packet: %w", err)
+	rpcEntries := make([]*taprpc.RpcJournalEntry, len(entries))
+	for idx, entry := range entries {
+		rpcEntries[idx] = &taprpc.RpcJournalEntry{
+			Method:           entry.Method,
+			Caller:           entry.Caller,
+			PeerAddr:         entry.PeerAddr,
+			Params:           entry.Params,
+			StatusCode:       entry.StatusCode,
+			ErrorMsg:         entry.ErrorMsg,
+			StartTimestampUs: entry.StartTime.UnixMicro(),
+			EndTimestampUs:   entry.EndTime.UnixMicro(),
 		}
 	}
 
-	switch e.Parcel.(type) {
-	case *tapfreighter.AddressParcel:
-		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_ADDRESS
-
-	case *tapfreighter.PreSignedParcel:
-		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PRE_SIGNED
-
-	case *tapfreighter.PendingParcel:
-		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PENDING
+	return &taprpc.ExportRpcJournalResponse{
+		Entries: rpcEntries,
+	}, nil
+}
 
-	case *tapfreighter.PreAnchoredParcel:
-		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PRE_ANCHORED
+// SubscribeReplicationChanges streams the state changes recorded by the
+// replication leader to a follower.
+func (r *rpcServer) SubscribeReplicationChanges(
+	req *taprpc.SubscribeReplicationChangesRequest,
+	stream taprpc.TaprootAssets_SubscribeReplicationChangesServer) error {
 
-	default:
-		return nil, fmt.Errorf("unknown parcel type %T", e.Parcel)
+	if r.cfg.ReplicationLeader == nil {
+		return fmt.Errorf("node is not configured as a replication " +
+			"leader")
 	}
 
-	if e.AnchorTx != nil {
-		var (
-			psbtBuf, finalTxBuf bytes.Buffer
-			changeOutputIndex   int32
-			lockedOutpoints     []*taprpc.OutPoint
-		)
-		if e.AnchorTx.FundedPsbt != nil {
-			fundedPsbt := e.AnchorTx.FundedPsbt
-			changeOutputIndex = fundedPsbt.ChangeOutputIndex
-
-			if fundedPsbt.Pkt == nil {
-				return nil, fmt.Errorf("funded PSBT is missing")
-			}
+	return r.cfg.ReplicationLeader.StreamChanges(
+		stream.Context(), req.AfterSeq,
+		func(change replication.Change) error {
+			changeType := taprpc.ReplicationChangeType(change.Type)
+			return stream.Send(&taprpc.ReplicationChange{
+				Seq:        change.Seq,
+				ChangeType: changeType,
+				Payload:    change.Payload,
+				Timestamp:  change.Timestamp.Unix(),
+			})
+		},
+	)
+}
 
-			err = fundedPsbt.Pkt.Serialize(&psbtBuf)
-			if err != nil {
-				return nil, fmt.Errorf("error serializing "+
-					"funded PSBT: %w", err)
-			}
+// Compact prunes old data from the database according to the retention
+// policy, optionally overriding the configured retention ages.
+func (r *rpcServer) Compact(ctx context.Context,
+	req *taprpc.CompactRequest) (*taprpc.CompactResponse, error) {
 
-			for idx := range fundedPsbt.LockedUTXOs {
-				utxo := fundedPsbt.LockedUTXOs[idx]
-				lockedOutpoints = append(
-					lockedOutpoints, &taprpc.OutPoint{
-						Txid:        utxo.Hash[:],
-						OutputIndex: utxo.Index,
-					},
-				)
-			}
-		}
+	// A retention age of zero in the request means the configured age is
+	// used.
+	none := fn.None[time.Duration]()
+	ageOverride := func(seconds int64) (fn.Option[time.Duration], error) {
+		switch {
+		case seconds < 0:
+			return none, fmt.Errorf("retention age must not be "+
+				"negative: %d", seconds)
 
-		if e.AnchorTx.FinalTx != nil {
-			err = e.AnchorTx.FinalTx.Serialize(&finalTxBuf)
-			if err != nil {
-				return nil, fmt.Errorf("error serializing "+
-					"final tx: %w", err)
-			}
-		}
+		case seconds == 0:
+			return none, nil
 
-		result.AnchorTransaction = &taprpc.AnchorTransaction{
-			AnchorPsbt:         psbtBuf.Bytes(),
-			ChangeOutputIndex:  changeOutputIndex,
-			ChainFeesSats:      e.AnchorTx.ChainFees,
-			TargetFeeRateSatKw: int32(e.AnchorTx.TargetFeeRate),
-			LndLockedUtxos:     lockedOutpoints,
-			FinalTx:            finalTxBuf.Bytes(),
+		default:
+			return fn.Some(time.Duration(seconds) * time.Second),
+				nil
 		}
 	}
 
-	if e.Transfer != nil {
-		result.Transfer, err = marshalOutboundParcel(e.Transfer)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling transfer: %w",
-				err)
-		}
+	spentProofAge, err := ageOverride(req.SpentProofAgeSeconds)
+	if err != nil {
+		return nil, err
+	}
+	deliveredProofAge, err := ageOverride(req.DeliveredProofAgeSeconds)
+	if err != nil {
+		return nil, err
+	}
+	universeEventAge, err := ageOverride(req.UniverseEventAgeSeconds)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
-}
-
-// marshalVerboseBatch marshals a minting batch into the RPC counterpart.
-func marshalVerboseBatch(batch *tapgarden.VerboseBatch, verbose bool,
-	skipSeedlings bool) (*mintrpc.VerboseBatch, error) {
-
-	rpcMintingBatch, err := marshalMintingBatch(
-		batch.MintingBatch, skipSeedlings,
+	result, err := r.cfg.Pruner.Compact(
+		ctx, spentProofAge, deliveredProofAge, universeEventAge,
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to compact database: %w", err)
 	}
 
-	rpcBatch := &mintrpc.VerboseBatch{
-		Batch: rpcMintingBatch,
+	marshalPruned := func(pruned retention.Pruned) *taprpc.PrunedData {
+		return &taprpc.PrunedData{
+			Rows:  pruned.Rows,
+			Bytes: pruned.Bytes,
+		}
 	}
 
-	// If we don't need to include the seedlings, we can return here.
-	if skipSeedlings {
-		return rpcBatch, nil
+	return &taprpc.CompactResponse{
+		SpentProofs:        marshalPruned(result.SpentProofs),
+		DeliveredProofs:    marshalPruned(result.DeliveredProofs),
+		TransferLogEntries: marshalPruned(result.TransferLogEntries),
+		UniverseEvents:     marshalPruned(result.UniverseEvents),
+		ReclaimedBytes:     result.ReclaimedBytes(),
+	}, nil
+}
+
+// UnlockDatabase unlocks a database whose sensitive fields are encrypted with a
+// key derived from a passphrase.
+func (r *rpcServer) UnlockDatabase(ctx context.Context,
+	req *taprpc.UnlockDatabaseRequest) (*taprpc.UnlockDatabaseResponse,
+	error) {
+
+	fieldCipher := r.cfg.DatabaseConfig.FieldCipher
+	if fieldCipher.KeySource() != tapdb.FieldEncryptionPassphrase {
+		return nil, fmt.Errorf("database isn't encrypted with a key " +
+			"derived from a passphrase")
 	}
 
-	// No sprouts, so we marshal the seedlings.
-	// We only need to convert the seedlings to unsealed seedlings.
-	if len(batch.UnsealedSeedlings) > 0 {
-		rpcBatch.UnsealedAssets, err = marshalUnsealedSeedlings(
-			verbose, batch.UnsealedSeedlings,
-		)
-		if err != nil {
-			return nil, err
-		}
+	if len(req.Passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase must be set")
+	}
 
-		rpcBatch.Batch.Assets = nil
+	if err := fieldCipher.Unlock(ctx, req.Passphrase); err != nil {
+		return nil, fmt.Errorf("unable to unlock database: %w", err)
 	}
 
-	return rpcBatch, nil
+	return &taprpc.UnlockDatabaseResponse{}, nil
 }
 
-// marshalMintingBatch marshals a minting batch into the RPC counterpart.
-func marshalMintingBatch(batch *tapgarden.MintingBatch,
-	skipSeedlings bool) (*mintrpc.MintingBatch, error) {
+// RecoverAssets starts a background job that recovers the assets of a wallet
+// whose lnd node was restored from its seed, by scanning the given universe
+// for proofs paying the keys of the wallet.
+func (r *rpcServer) RecoverAssets(ctx context.Context,
+	req *taprpc.RecoverAssetsRequest) (*taprpc.RecoverAssetsResponse,
+	error) {
 
-	rpcBatchState, err := marshalBatchState(batch.State())
-	if err != nil {
+	params := recovery.Params{
+		UniverseHost: req.UniverseHost,
+		GapLimit:     req.GapLimit,
+	}
+	if params.GapLimit == 0 {
+		params.GapLimit = recovery.DefaultGapLimit
+	}
+	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 
-	rpcBatch := &mintrpc.MintingBatch{
-		BatchKey:   batch.BatchKey.PubKey.SerializeCompressed(),
-		State:      rpcBatchState,
-		CreatedAt:  batch.CreationTime.UTC().Unix(),
-		HeightHint: batch.HeightHint,
+	encodedParams, err := params.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode recovery parameters: "+
+			"%w", err)
 	}
 
-	// If we have the genesis packet available (funded+signed), then we'll
-	// display the txid as well.
-	if batch.GenesisPacket != nil {
-		rpcBatch.BatchPsbt, err = serialize(batch.GenesisPacket.Pkt)
-		if err != nil {
-			return nil, fmt.Errorf("error serializing batch PSBT: "+
-				"%w", err)
-		}
-
-		if batch.State() > tapgarden.BatchStateFrozen {
-			batchTx, err := psbt.Extract(batch.GenesisPacket.Pkt)
-			if err == nil {
-				rpcBatch.BatchTxid = batchTx.TxHash().String()
-			} else {
-				rpcsLog.Errorf("unable to extract batch tx: %v",
-					err)
-			}
-		}
+	job, err := r.cfg.JobManager.Submit(
+		ctx, jobs.KindAssetRecovery, encodedParams,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start asset recovery: %w",
+			err)
 	}
 
-	// If we don't need to include the seedlings, we can return here.
-	if skipSeedlings {
-		return rpcBatch, nil
-	}
+	rpcsLog.Infof("[RecoverAssets]: started asset recovery job %d "+
+		"(universe=%v, gap_limit=%d)", job.ID, params.UniverseHost,
+		params.GapLimit)
 
-	// When we have sprouts, then they represent the same assets as the
-	// seedlings but in a more "grown up" state. So in that case we only
-	// marshal the sprouts.
-	switch {
-	// We have sprouts, ignore seedlings.
-	case batch.RootAssetCommitment != nil &&
-		len(batch.RootAssetCommitment.CommittedAssets()) > 0:
+	return &taprpc.RecoverAssetsResponse{
+		JobId: uint64(job.ID),
+	}, nil
+}
 
-		rpcBatch.Assets = marshalSprouts(
-			batch.RootAssetCommitment.CommittedAssets(),
-			batch.AssetMetas,
-		)
+// ConsolidateAssets merges many small UTXOs of an asset or asset group into
+// as few outputs as possible by sending them to the wallet itself in a single
+// anchor transaction.
+func (r *rpcServer) ConsolidateAssets(ctx context.Context,
+	req *taprpc.ConsolidateAssetsRequest) (
+	*taprpc.ConsolidateAssetsResponse, error) {
 
-	// No sprouts, so we marshal the seedlings.
-	case len(batch.Seedlings) > 0:
-		rpcBatch.Assets, err = marshalSeedlings(batch.Seedlings)
+	var (
+		assetID  *asset.ID
+		groupKey *btcec.PublicKey
+	)
+	if len(req.AssetId) != 0 {
+		if len(req.AssetId) != sha256.Size {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		}
+
+		var id asset.ID
+		copy(id[:], req.AssetId)
+		assetID = &id
+	}
+	if len(req.GroupKey) != 0 {
+		var err error
+		groupKey, err = btcec.ParsePubKey(req.GroupKey)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing group key: %w",
+				err)
 		}
 	}
+	specifier, err := asset.NewSpecifier(assetID, groupKey, nil, true)
+	if err != nil {
+		return nil, err
+	}
 
-	return rpcBatch, nil
-}
+	// Only the assets of the given account are merged, so the funds of
+	// the different accounts stay segregated.
+	acct, err := account.Resolve(ctx, r.cfg.Accounts, req.Account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %w", err)
+	}
 
-// marshalSeedling marshals a seedling into the RPC counterpart.
-func marshalSeedling(seedling *tapgarden.Seedling) (*mintrpc.PendingAsset,
-	error) {
+	params := tapfreighter.ConsolidationParams{
+		AssetSpecifier: specifier,
+		Account:        fn.Some(acct),
+		MaxInputs:      int(req.MaxInputs),
+		MaxProofSize:   int64(req.MaxProofSize),
+	}
+	if params.MaxInputs == 0 {
+		params.MaxInputs = tapfreighter.DefaultConsolidationMaxInputs
+	}
+	if params.MaxProofSize == 0 {
+		params.MaxProofSize =
+			tapfreighter.DefaultConsolidationMaxProofSize
+	}
 
-	var (
-		scriptKey        *taprpc.ScriptKey
-		groupKeyBytes    []byte
-		groupInternalKey *taprpc.KeyDescriptor
-		groupAnchor      string
-		seedlingMeta     *taprpc.AssetMeta
-		newGroupedAsset  bool
+	manualFeeRate, err := checkFeeRateSanity(
+		ctx, chainfee.SatPerKWeight(req.FeeRate), r.cfg.Lnd.WalletKit,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	if seedling.ScriptKey.PubKey != nil {
-		scriptKey = taprpc.MarshalScriptKey(seedling.ScriptKey)
+	// Without a manual fee rate, the chain porter uses the estimated fee
+	// rate for the default confirmation target, so we use the same for
+	// the estimate.
+	var feeRate chainfee.SatPerKWeight
+	if manualFeeRate != nil {
+		feeRate = *manualFeeRate
+	} else {
+		feeRate, err = r.cfg.ChainBridge.EstimateFee(
+			ctx, tapsend.SendConfTarget,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to estimate fee rate: "+
+				"%w", err)
+		}
 	}
 
-	if seedling.HasGroupKey() {
-		groupKey := seedling.GroupInfo.GroupKey
-		groupKeyBytes = groupKey.GroupPubKey.SerializeCompressed()
+	plan, err := r.cfg.AssetWallet.PlanConsolidation(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to plan consolidation: %w", err)
 	}
 
-	if seedling.GroupInternalKey != nil {
-		groupInternalKey = taprpc.MarshalKeyDescriptor(
-			*seedling.GroupInternalKey,
-		)
+	resp := &taprpc.ConsolidateAssetsResponse{
+		NumInputs:          uint32(len(plan.Coins)),
+		NumAnchorInputs:    uint32(len(plan.AnchorPoints)),
+		TotalAmount:        plan.Amount,
+		EstimatedProofSize: uint64(plan.ProofSize),
+		EstimatedVsize:     uint64(plan.EstimateWeight().ToVB()),
+		EstimatedFeeSat:    uint64(plan.EstimateFee(feeRate)),
+		FeeRate:            uint32(feeRate),
+		NumRemaining:       uint32(plan.NumRemaining),
+	}
+	if req.DryRun {
+		return resp, nil
 	}
 
-	if seedling.GroupAnchor != nil {
-		groupAnchor = *seedling.GroupAnchor
+	fundedPkts, err := r.cfg.AssetWallet.FundConsolidation(ctx, plan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fund consolidation: %w", err)
 	}
 
-	if seedling.EnableEmission {
-		newGroupedAsset = true
+	var (
+		vPackets         = make([]*tappsbt.VPacket, len(fundedPkts))
+		inputCommitments = make(tappsbt.InputCommitments)
+	)
+	for idx, fundedPkt := range fundedPkts {
+		_, err = r.cfg.AssetWallet.SignVirtualPacket(fundedPkt.VPacket)
+		if err != nil {
+			return nil, fmt.Errorf("error signing packet: %w", err)
+		}
+
+		vPackets[idx] = fundedPkt.VPacket
+		maps.Copy(inputCommitments, fundedPkt.InputCommitments)
 	}
 
-	if seedling.Meta != nil {
-		seedlingMeta = &taprpc.AssetMeta{
-			MetaHash: fn.ByteSlice(
-				seedling.Meta.MetaHash(),
+	var parcelOpts []tapfreighter.PreSignedParcelOption
+	if manualFeeRate != nil {
+		parcelOpts = append(
+			parcelOpts, tapfreighter.WithPreSignedFeeRate(
+				*manualFeeRate,
 			),
-			Data: seedling.Meta.Data,
-			Type: taprpc.AssetMetaType(seedling.Meta.Type),
-		}
+		)
 	}
 
-	assetVersion, err := taprpc.MarshalAssetVersion(
-		seedling.AssetVersion,
+	rpcsLog.Infof("[ConsolidateAssets]: merging %d coins with total "+
+		"amount %d into %d outputs", len(plan.Coins), plan.Amount,
+		len(vPackets))
+
+	outboundParcel, err := r.cfg.ChainPorter.RequestShipment(
+		tapfreighter.NewPreSignedParcel(
+			vPackets, inputCommitments, req.Note, parcelOpts...,
+		),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &mintrpc.PendingAsset{
-		AssetType:          taprpc.AssetType(seedling.AssetType),
-		AssetVersion:       assetVersion,
-		Name:               seedling.AssetName,
-		AssetMeta:          seedlingMeta,
-		Amount:             seedling.Amount,
-		ScriptKey:          scriptKey,
-		GroupKey:           groupKeyBytes,
-		GroupAnchor:        groupAnchor,
+	resp.Transfer, err = marshalOutboundParcel(outboundParcel)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling outbound parcel: %w",
+			err)
+	}
+
+	return resp, nil
+}
+
+// marshallReceiveAssetEvent maps an asset receive event to its RPC counterpart.
+func marshallReceiveAssetEvent(event fn.Event,
+	db address.Storage) (*tapdevrpc.ReceiveAssetEvent, error) {
+
+	switch e := event.(type) {
+	case *proof.BackoffWaitEvent:
+		// Map the transfer type to the RPC counterpart. We only
+		// support the "receive" transfer type for asset receive events.
+		var transferTypeRpc tapdevrpc.ProofTransferType
+		switch e.TransferType {
+		case proof.ReceiveTransferType:
+			transferTypeRpc = proofTypeReceive
+		default:
+			return nil, fmt.Errorf("unexpected transfer type: %v",
+				e.TransferType)
+		}
+
+		evt := &tapdevrpc.ProofTransferBackoffWaitEvent{
+			Timestamp:    e.Timestamp().UnixMicro(),
+			Backoff:      e.Backoff.Microseconds(),
+			TriesCounter: e.TriesCounter,
+			TransferType: transferTypeRpc,
+		}
+		return &tapdevrpc.ReceiveAssetEvent{
+			Event: &receiveBackoff{
+				ProofTransferBackoffWaitEvent: evt,
+			},
+		}, nil
+
+	case *tapgarden.AssetReceiveEvent:
+		rpcAddr, err := marshalAddr(&e.Address, db)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling addr: %w", err)
+		}
+
+		evt := &tapdevrpc.AssetReceiveCompleteEvent{
+			Timestamp: e.Timestamp().UnixMicro(),
+			Address:   rpcAddr,
+			Outpoint:  e.OutPoint.String(),
+		}
+		return &tapdevrpc.ReceiveAssetEvent{
+			Event: &receiveComplete{
+				AssetReceiveCompleteEvent: evt,
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event type: %T", e)
+	}
+}
+
+// marshallSendAssetEvent maps an asset send event to its RPC counterpart.
+func marshallSendAssetEvent(event fn.Event) (*tapdevrpc.SendAssetEvent, error) {
+	switch e := event.(type) {
+	case *tapfreighter.AssetSendEvent:
+		evt := &tapdevrpc.ExecuteSendStateEvent{
+			Timestamp: e.Timestamp().UnixMicro(),
+			SendState: e.SendState.String(),
+		}
+		return &tapdevrpc.SendAssetEvent{
+			Event: &sendExecute{
+				ExecuteSendStateEvent: evt,
+			},
+		}, nil
+
+	case *proof.BackoffWaitEvent:
+		// Map the transfer type to the RPC counterpart. We only
+		// support the send transfer type for asset send events.
+		var transferTypeRpc tapdevrpc.ProofTransferType
+		switch e.TransferType {
+		case proof.SendTransferType:
+			transferTypeRpc = proofTypeSend
+		default:
+			return nil, fmt.Errorf("unexpected transfer type: %v",
+				e.TransferType)
+		}
+
+		evt := &tapdevrpc.ProofTransferBackoffWaitEvent{
+			Timestamp:    e.Timestamp().UnixMicro(),
+			Backoff:      e.Backoff.Microseconds(),
+			TriesCounter: e.TriesCounter,
+			TransferType: transferTypeRpc,
+		}
+		return &tapdevrpc.SendAssetEvent{
+			Event: &sendBackoff{
+				ProofTransferBackoffWaitEvent: evt,
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event type: %T", e)
+	}
+}
+
+// marshalSendEvent marshals an asset send event into the RPC counterpart.
+func marshalSendEvent(event fn.Event) (*taprpc.SendEvent, error) {
+	e, ok := event.(*tapfreighter.AssetSendEvent)
+	if !ok {
+		return nil, fmt.Errorf("invalid event type: %T", event)
+	}
+
+	result := &taprpc.SendEvent{
+		Timestamp:             e.Timestamp().UnixMicro(),
+		SendState:             e.SendState.String(),
+		VirtualPackets:        make([][]byte, len(e.VirtualPackets)),
+		PassiveVirtualPackets: make([][]byte, len(e.PassivePackets)),
+	}
+
+	if e.Error != nil {
+		result.Error = e.Error.Error()
+	}
+
+	var err error
+	for idx, vPkt := range e.VirtualPackets {
+		result.VirtualPackets[idx], err = tappsbt.Encode(vPkt)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding virtual "+
+				"packet: %w", err)
+		}
+	}
+	for idx, vPkt := range e.PassivePackets {
+		result.PassiveVirtualPackets[idx], err = tappsbt.Encode(vPkt)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding virtual "+
+				"packet: %w", err)
+		}
+	}
+
+	switch e.Parcel.(type) {
+	case *tapfreighter.AddressParcel:
+		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_ADDRESS
+
+	case *tapfreighter.PreSignedParcel:
+		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PRE_SIGNED
+
+	case *tapfreighter.PendingParcel:
+		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PENDING
+
+	case *tapfreighter.PreAnchoredParcel:
+		result.ParcelType = taprpc.ParcelType_PARCEL_TYPE_PRE_ANCHORED
+
+	default:
+		return nil, fmt.Errorf("unknown parcel type %T", e.Parcel)
+	}
+
+	if e.AnchorTx != nil {
+		var (
+			psbtBuf, finalTxBuf bytes.Buffer
+			changeOutputIndex   int32
+			lockedOutpoints     []*taprpc.OutPoint
+		)
+		if e.AnchorTx.FundedPsbt != nil {
+			fundedPsbt := e.AnchorTx.FundedPsbt
+			changeOutputIndex = fundedPsbt.ChangeOutputIndex
+
+			if fundedPsbt.Pkt == nil {
+				return nil, fmt.Errorf("funded PSBT is missing")
+			}
+
+			err = fundedPsbt.Pkt.Serialize(&psbtBuf)
+			if err != nil {
+				return nil, fmt.Errorf("error serializing "+
+					"funded PSBT: %w", err)
+			}
+
+			for idx := range fundedPsbt.LockedUTXOs {
+				utxo := fundedPsbt.LockedUTXOs[idx]
+				lockedOutpoints = append(
+					lockedOutpoints, &taprpc.OutPoint{
+						Txid:        utxo.Hash[:],
+						OutputIndex: utxo.Index,
+					},
+				)
+			}
+		}
+
+		if e.AnchorTx.FinalTx != nil {
+			err = e.AnchorTx.FinalTx.Serialize(&finalTxBuf)
+			if err != nil {
+				return nil, fmt.Errorf("error serializing "+
+					"final tx: %w", err)
+			}
+		}
+
+		result.AnchorTransaction = &taprpc.AnchorTransaction{
+			AnchorPsbt:         psbtBuf.Bytes(),
+			ChangeOutputIndex:  changeOutputIndex,
+			ChainFeesSats:      e.AnchorTx.ChainFees,
+			TargetFeeRateSatKw: int32(e.AnchorTx.TargetFeeRate),
+			LndLockedUtxos:     lockedOutpoints,
+			FinalTx:            finalTxBuf.Bytes(),
+		}
+	}
+
+	if e.Transfer != nil {
+		result.Transfer, err = marshalOutboundParcel(e.Transfer)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling transfer: %w",
+				err)
+		}
+	}
+
+	return result, nil
+}
+
+// marshalVerboseBatch marshals a minting batch into the RPC counterpart.
+func marshalVerboseBatch(batch *tapgarden.VerboseBatch, verbose bool,
+	skipSeedlings bool) (*mintrpc.VerboseBatch, error) {
+
+	rpcMintingBatch, err := marshalMintingBatch(
+		batch.MintingBatch, skipSeedlings,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcBatch := &mintrpc.VerboseBatch{
+		Batch: rpcMintingBatch,
+	}
+
+	// If we don't need to include the seedlings, we can return here.
+	if skipSeedlings {
+		return rpcBatch, nil
+	}
+
+	// No sprouts, so we marshal the seedlings.
+	// We only need to convert the seedlings to unsealed seedlings.
+	if len(batch.UnsealedSeedlings) > 0 {
+		rpcBatch.UnsealedAssets, err = marshalUnsealedSeedlings(
+			verbose, batch.UnsealedSeedlings,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcBatch.Batch.Assets = nil
+	}
+
+	return rpcBatch, nil
+}
+
+// marshalMintingBatch marshals a minting batch into the RPC counterpart.
+func marshalMintingBatch(batch *tapgarden.MintingBatch,
+	skipSeedlings bool) (*mintrpc.MintingBatch, error) {
+
+	rpcBatchState, err := marshalBatchState(batch.State())
+	if err != nil {
+		return nil, err
+	}
+
+	rpcBatch := &mintrpc.MintingBatch{
+		BatchKey:   batch.BatchKey.PubKey.SerializeCompressed(),
+		State:      rpcBatchState,
+		CreatedAt:  batch.CreationTime.UTC().Unix(),
+		HeightHint: batch.HeightHint,
+	}
+
+	// If we have the genesis packet available (funded+signed), then we'll
+	// display the txid as well.
+	if batch.GenesisPacket != nil {
+		rpcBatch.BatchPsbt, err = serialize(batch.GenesisPacket.Pkt)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing batch PSBT: "+
+				"%w", err)
+		}
+
+		if batch.State() > tapgarden.BatchStateFrozen {
+			batchTx, err := psbt.Extract(batch.GenesisPacket.Pkt)
+			if err == nil {
+				rpcBatch.BatchTxid = batchTx.TxHash().String()
+			} else {
+				rpcsLog.Errorf("unable to extract batch tx: %v",
+					err)
+			}
+		}
+	}
+
+	// If we don't need to include the seedlings, we can return here.
+	if skipSeedlings {
+		return rpcBatch, nil
+	}
+
+	// When we have sprouts, then they represent the same assets as the
+	// seedlings but in a more "grown up" state. So in that case we only
+	// marshal the sprouts.
+	switch {
+	// We have sprouts, ignore seedlings.
+	case batch.RootAssetCommitment != nil &&
+		len(batch.RootAssetCommitment.CommittedAssets()) > 0:
+
+		rpcBatch.Assets = marshalSprouts(
+			batch.RootAssetCommitment.CommittedAssets(),
+			batch.AssetMetas,
+		)
+
+	// No sprouts, so we marshal the seedlings.
+	case len(batch.Seedlings) > 0:
+		rpcBatch.Assets, err = marshalSeedlings(batch.Seedlings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rpcBatch, nil
+}
+
+// marshalSeedling marshals a seedling into the RPC counterpart.
+func marshalSeedling(seedling *tapgarden.Seedling) (*mintrpc.PendingAsset,
+	error) {
+
+	var (
+		scriptKey        *taprpc.ScriptKey
+		groupKeyBytes    []byte
+		groupInternalKey *taprpc.KeyDescriptor
+		groupAnchor      string
+		seedlingMeta     *taprpc.AssetMeta
+		newGroupedAsset  bool
+	)
+
+	if seedling.ScriptKey.PubKey != nil {
+		scriptKey = taprpc.MarshalScriptKey(seedling.ScriptKey)
+	}
+
+	if seedling.HasGroupKey() {
+		groupKey := seedling.GroupInfo.GroupKey
+		groupKeyBytes = groupKey.GroupPubKey.SerializeCompressed()
+	}
+
+	if seedling.GroupInternalKey != nil {
+		groupInternalKey = taprpc.MarshalKeyDescriptor(
+			*seedling.GroupInternalKey,
+		)
+	}
+
+	if seedling.GroupAnchor != nil {
+		groupAnchor = *seedling.GroupAnchor
+	}
+
+	if seedling.EnableEmission {
+		newGroupedAsset = true
+	}
+
+	if seedling.Meta != nil {
+		seedlingMeta = &taprpc.AssetMeta{
+			MetaHash: fn.ByteSlice(
+				seedling.Meta.MetaHash(),
+			),
+			Data: seedling.Meta.Data,
+			Type: taprpc.AssetMetaType(seedling.Meta.Type),
+		}
+	}
+
+	assetVersion, err := taprpc.MarshalAssetVersion(
+		seedling.AssetVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mintrpc.PendingAsset{
+		AssetType:          taprpc.AssetType(seedling.AssetType),
+		AssetVersion:       assetVersion,
+		Name:               seedling.AssetName,
+		AssetMeta:          seedlingMeta,
+		Amount:             seedling.Amount,
+		ScriptKey:          scriptKey,
+		GroupKey:           groupKeyBytes,
+		GroupAnchor:        groupAnchor,
 		GroupInternalKey:   groupInternalKey,
 		GroupTapscriptRoot: seedling.GroupTapscriptRoot,
 		NewGroupedAsset:    newGroupedAsset,
 	}, nil
 }
 
-// marshalUnsealedSeedling marshals an unsealed seedling into the RPC
-// counterpart.
-func marshalUnsealedSeedling(verbose bool,
-	seedling *tapgarden.UnsealedSeedling) (*mintrpc.UnsealedAsset, error) {
+// marshalUnsealedSeedling marshals an unsealed seedling into the RPC
+// counterpart.
+func marshalUnsealedSeedling(verbose bool,
+	seedling *tapgarden.UnsealedSeedling) (*mintrpc.UnsealedAsset, error) {
+
+	var (
+		groupVirtualTx *taprpc.GroupVirtualTx
+		groupReq       *taprpc.GroupKeyRequest
+		err            error
+	)
+
+	rpcSeedling, err := marshalSeedling(seedling.Seedling)
+	if err != nil {
+		return nil, err
+	}
+
+	if verbose && seedling.PendingAssetGroup != nil {
+		groupVirtualTx, err = taprpc.MarshalGroupVirtualTx(
+			&seedling.PendingAssetGroup.GroupVirtualTx,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		groupReq, err = taprpc.MarshalGroupKeyRequest(
+			&seedling.PendingAssetGroup.GroupKeyRequest,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &mintrpc.UnsealedAsset{
+		Asset:           rpcSeedling,
+		GroupVirtualTx:  groupVirtualTx,
+		GroupKeyRequest: groupReq,
+	}, nil
+}
+
+// marshalSeedlings marshals the seedlings into the RPC counterpart.
+func marshalSeedlings(
+	seedlings map[string]*tapgarden.Seedling) ([]*mintrpc.PendingAsset,
+	error) {
+
+	return fn.MapErr(maps.Values(seedlings), marshalSeedling)
+}
+
+// marshalUnsealedSeedlings marshals the unsealed seedlings into the RPC
+// counterpart.
+func marshalUnsealedSeedlings(verbose bool,
+	seedlings map[string]*tapgarden.UnsealedSeedling) (
+	[]*mintrpc.UnsealedAsset, error) {
+
+	rpcAssets := make([]*mintrpc.UnsealedAsset, 0, len(seedlings))
+	for _, seedling := range seedlings {
+		nextSeedling, err := marshalUnsealedSeedling(verbose, seedling)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcAssets = append(rpcAssets, nextSeedling)
+	}
+
+	return rpcAssets, nil
+}
+
+// marshalSprouts marshals the sprouts into the RPC counterpart.
+func marshalSprouts(sprouts []*asset.Asset,
+	metas tapgarden.AssetMetas) []*mintrpc.PendingAsset {
+
+	rpcAssets := make([]*mintrpc.PendingAsset, 0, len(sprouts))
+	for _, sprout := range sprouts {
+		var (
+			groupKeyBytes      []byte
+			groupTapscriptRoot []byte
+			groupInternalKey   *taprpc.KeyDescriptor
+			assetMeta          *taprpc.AssetMeta
+		)
+
+		if metas != nil {
+			serializedScriptKey := asset.ToSerialized(
+				sprout.ScriptKey.PubKey,
+			)
+			if m, ok := metas[serializedScriptKey]; ok && m != nil {
+				assetMeta = &taprpc.AssetMeta{
+					MetaHash: fn.ByteSlice(m.MetaHash()),
+					Data:     m.Data,
+					Type:     taprpc.AssetMetaType(m.Type),
+				}
+			}
+		}
+
+		if sprout.GroupKey != nil {
+			grp := sprout.GroupKey
+			groupKeyBytes = grp.GroupPubKey.SerializeCompressed()
+			groupTapscriptRoot = grp.TapscriptRoot
+			groupInternalKey = taprpc.MarshalKeyDescriptor(
+				grp.RawKey,
+			)
+		}
+
+		rpcAssets = append(rpcAssets, &mintrpc.PendingAsset{
+			AssetType:          taprpc.AssetType(sprout.Type),
+			Name:               sprout.Tag,
+			AssetMeta:          assetMeta,
+			Amount:             sprout.Amount,
+			GroupKey:           groupKeyBytes,
+			GroupInternalKey:   groupInternalKey,
+			GroupTapscriptRoot: groupTapscriptRoot,
+			ScriptKey: taprpc.MarshalScriptKey(
+				sprout.ScriptKey,
+			),
+		})
+	}
+
+	return rpcAssets
+}
+
+// marshalBatchState converts the batch state field into its RPC counterpart.
+func marshalBatchState(state tapgarden.BatchState) (mintrpc.BatchState, error) {
+	switch state {
+	case tapgarden.BatchStatePending:
+		return mintrpc.BatchState_BATCH_STATE_PENDING, nil
+
+	case tapgarden.BatchStateFrozen:
+		return mintrpc.BatchState_BATCH_STATE_FROZEN, nil
+
+	case tapgarden.BatchStateCommitted:
+		return mintrpc.BatchState_BATCH_STATE_COMMITTED, nil
+
+	case tapgarden.BatchStateBroadcast:
+		return mintrpc.BatchState_BATCH_STATE_BROADCAST, nil
+
+	case tapgarden.BatchStateConfirmed:
+		return mintrpc.BatchState_BATCH_STATE_CONFIRMED, nil
+
+	case tapgarden.BatchStateFinalized:
+		return mintrpc.BatchState_BATCH_STATE_FINALIZED, nil
+
+	case tapgarden.BatchStateSeedlingCancelled:
+		return mintrpc.BatchState_BATCH_STATE_SEEDLING_CANCELLED, nil
+
+	case tapgarden.BatchStateSproutCancelled:
+		return mintrpc.BatchState_BATCH_STATE_SPROUT_CANCELLED, nil
+
+	default:
+		return 0, fmt.Errorf("unknown batch state: %v", state)
+	}
+}
+
+// parseUserKey parses a user-provided script or group key, which can be in
+// either the Schnorr or Compressed format.
+func parseUserKey(scriptKey []byte) (*btcec.PublicKey, error) {
+	switch len(scriptKey) {
+	case schnorr.PubKeyBytesLen:
+		return schnorr.ParsePubKey(scriptKey)
+
+	// Truncate the key and then parse as a Schnorr key.
+	case btcec.PubKeyBytesLenCompressed:
+		return schnorr.ParsePubKey(scriptKey[1:])
+
+	default:
+		return nil, fmt.Errorf("unknown script key length: %v",
+			len(scriptKey))
+	}
+}
+
+func (r *rpcServer) FetchAssetMeta(ctx context.Context,
+	req *taprpc.FetchAssetMetaRequest) (*taprpc.AssetMeta, error) {
+
+	var (
+		assetMeta *proof.MetaReveal
+		err       error
+	)
+
+	switch {
+	case req.GetAssetId() != nil:
+		if len(req.GetAssetId()) != sha256.Size {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		}
+
+		var assetID asset.ID
+		copy(assetID[:], req.GetAssetId())
+
+		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaForAsset(
+			ctx, assetID,
+		)
+
+	case req.GetAssetIdStr() != "":
+		if len(req.GetAssetIdStr()) != hex.EncodedLen(sha256.Size) {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		}
+
+		var assetIDBytes []byte
+		assetIDBytes, err = hex.DecodeString(req.GetAssetIdStr())
+		if err != nil {
+			return nil, fmt.Errorf("error hex decoding asset ID: "+
+				"%w", err)
+		}
+
+		var assetID asset.ID
+		copy(assetID[:], assetIDBytes)
+
+		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaForAsset(
+			ctx, assetID,
+		)
+
+	case req.GetMetaHash() != nil:
+		if len(req.GetMetaHash()) != sha256.Size {
+			return nil, fmt.Errorf("meta hash must be 32 bytes")
+		}
+
+		var metaHash [asset.MetaHashLen]byte
+		copy(metaHash[:], req.GetMetaHash())
+
+		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaByHash(
+			ctx, metaHash,
+		)
+
+	case req.GetMetaHashStr() != "":
+		if len(req.GetMetaHashStr()) != hex.EncodedLen(sha256.Size) {
+			return nil, fmt.Errorf("meta hash must be 32 bytes")
+		}
+
+		var metaHashBytes []byte
+		metaHashBytes, err = hex.DecodeString(req.GetMetaHashStr())
+		if err != nil {
+			return nil, fmt.Errorf("error hex decoding meta hash: "+
+				"%w", err)
+		}
+
+		var metaHash [asset.MetaHashLen]byte
+		copy(metaHash[:], metaHashBytes)
+
+		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaByHash(
+			ctx, metaHash,
+		)
+
+	default:
+		return nil, fmt.Errorf("either asset ID or meta hash must " +
+			"be set")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch asset "+
+			"meta: %w", err)
+	}
+
+	metaHash := assetMeta.MetaHash()
+	return &taprpc.AssetMeta{
+		Data:     assetMeta.Data,
+		Type:     taprpc.AssetMetaType(assetMeta.Type),
+		MetaHash: metaHash[:],
+	}, nil
+}
+
+// checkMetaBlob returns an error if the given asset meta references a meta
+// blob that isn't stored locally. Without the blob, the asset's metadata
+// couldn't be delivered to anyone.
+func (r *rpcServer) checkMetaBlob(ctx context.Context,
+	meta *proof.MetaReveal) error {
+
+	return fn.MapOptionZ(meta.BlobRef(), func(ref proof.BlobRef) error {
+		has, err := r.cfg.MetaBlobs.HasBlob(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("unable to look up meta blob: %w",
+				err)
+		}
+
+		if !has {
+			return fmt.Errorf("meta blob %v must be uploaded "+
+				"before minting: %w", ref,
+				proof.ErrBlobNotFound)
+		}
+
+		return nil
+	})
+}
+
+// unmarshalBlobRef parses the reference of a meta blob from its hash and
+// size.
+func unmarshalBlobRef(blobHash []byte, size uint64) (proof.BlobRef, error) {
+	if len(blobHash) != sha256.Size {
+		return proof.BlobRef{}, fmt.Errorf("blob hash must be %d bytes",
+			sha256.Size)
+	}
+
+	if size == 0 {
+		return proof.BlobRef{}, fmt.Errorf("blob size must be set")
+	}
+
+	ref := proof.BlobRef{
+		Size: size,
+	}
+	copy(ref.Hash[:], blobHash)
+
+	return ref, nil
+}
+
+// UploadMetaBlob streams a metadata blob that is too large to be committed to
+// directly into the content-addressed meta blob store.
+func (r *rpcServer) UploadMetaBlob(
+	stream taprpc.TaprootAssets_UploadMetaBlobServer) error {
+
+	ctx := stream.Context()
+	blob := proof.NewBlobChunkReader(func() ([]byte, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		return req.Chunk, nil
+	})
+
+	ref, err := r.cfg.MetaBlobs.PutBlob(ctx, blob, fn.None[proof.BlobRef]())
+	if err != nil {
+		return fmt.Errorf("unable to store meta blob: %w", err)
+	}
+
+	rpcsLog.Infof("[UploadMetaBlob]: stored meta blob %v (%d bytes)", ref,
+		ref.Size)
+
+	meta := ref.MetaReveal()
+	metaHash := meta.MetaHash()
+
+	return stream.SendAndClose(&taprpc.UploadMetaBlobResponse{
+		BlobHash: ref.Hash[:],
+		Size:     ref.Size,
+		AssetMeta: &taprpc.AssetMeta{
+			Data:     meta.Data,
+			Type:     taprpc.AssetMetaType(meta.Type),
+			MetaHash: metaHash[:],
+		},
+	})
+}
+
+// FetchMetaBlob streams a metadata blob from the content-addressed meta blob
+// store.
+func (r *rpcServer) FetchMetaBlob(req *taprpc.FetchMetaBlobRequest,
+	stream taprpc.TaprootAssets_FetchMetaBlobServer) error {
+
+	ref, err := unmarshalBlobRef(req.BlobHash, req.Size)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	return r.streamMetaBlob(ctx, ref, func(chunk []byte) error {
+		return stream.Send(&taprpc.MetaBlobChunk{
+			Chunk: chunk,
+		})
+	})
+}
+
+// streamMetaBlob reads the meta blob with the given reference from the store
+// and passes it to the given function in chunks.
+func (r *rpcServer) streamMetaBlob(ctx context.Context, ref proof.BlobRef,
+	send func([]byte) error) error {
+
+	blob, err := r.cfg.MetaBlobs.GetBlob(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("unable to fetch meta blob: %w", err)
+	}
+	defer blob.Close()
+
+	return proof.SendBlobChunks(blob, send)
+}
+
+// MarshalUniProofType marshals the universe proof type into the RPC
+// counterpart.
+func MarshalUniProofType(
+	proofType universe.ProofType) (unirpc.ProofType, error) {
+
+	switch proofType {
+	case universe.ProofTypeUnspecified:
+		return unirpc.ProofType_PROOF_TYPE_UNSPECIFIED, nil
+	case universe.ProofTypeIssuance:
+		return unirpc.ProofType_PROOF_TYPE_ISSUANCE, nil
+	case universe.ProofTypeTransfer:
+		return unirpc.ProofType_PROOF_TYPE_TRANSFER, nil
+
+	default:
+		return 0, fmt.Errorf("unknown universe proof type: %v",
+			proofType)
+	}
+}
+
+// MarshalUniID marshals the universe ID into the RPC counterpart.
+func MarshalUniID(id universe.Identifier) (*unirpc.ID, error) {
+	var uniID unirpc.ID
+
+	if id.GroupKey != nil {
+		uniID.Id = &unirpc.ID_GroupKey{
+			GroupKey: schnorr.SerializePubKey(id.GroupKey),
+		}
+	} else {
+		uniID.Id = &unirpc.ID_AssetId{
+			AssetId: id.AssetID[:],
+		}
+	}
+
+	proofTypeRpc, err := MarshalUniProofType(id.ProofType)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal proof type: %w", err)
+	}
+	uniID.ProofType = proofTypeRpc
+
+	return &uniID, nil
+}
+
+// marshalMssmtNode marshals a MS-SMT node into the RPC counterpart.
+func marshalMssmtNode(node mssmt.Node) *unirpc.MerkleSumNode {
+	nodeHash := node.NodeHash()
+
+	return &unirpc.MerkleSumNode{
+		RootHash: nodeHash[:],
+		RootSum:  int64(node.NodeSum()),
+	}
+}
+
+// marshalUniverseRoot marshals the universe root into the RPC counterpart.
+func marshalUniverseRoot(node universe.Root) (*unirpc.UniverseRoot, error) {
+	// There was no old base root, so we'll just return a blank root.
+	if node.Node == nil {
+		return &unirpc.UniverseRoot{}, nil
+	}
+	mssmtRoot := marshalMssmtNode(node.Node)
+
+	rpcGroupedAssets := make(map[string]uint64, len(node.GroupedAssets))
+	for assetID, amount := range node.GroupedAssets {
+		rpcGroupedAssets[assetID.String()] = amount
+	}
+
+	uniID, err := MarshalUniID(node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.UniverseRoot{
+		Id:               uniID,
+		MssmtRoot:        mssmtRoot,
+		AssetName:        node.AssetName,
+		AmountsByAssetId: rpcGroupedAssets,
+	}, nil
+}
+
+// marshalSignedUniverseRoot marshals the given universe root into its RPC
+// counterpart and signs it with the operator key of the local universe
+// server, so clients can verify the root was published by this server.
+func (r *rpcServer) marshalSignedUniverseRoot(ctx context.Context,
+	root universe.Root) (*unirpc.UniverseRoot, error) {
+
+	rpcRoot, err := marshalUniverseRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// There's nothing to sign for an empty root.
+	if root.Node == nil || r.cfg.UniverseRootSigner == nil {
+		return rpcRoot, nil
+	}
+
+	sig, err := r.cfg.UniverseRootSigner.SignRoot(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	rpcRoot.OperatorSig = sig.Serialize()
+
+	return rpcRoot, nil
+}
+
+// MultiverseRoot returns the root of the multiverse tree. This is useful to
+// determine the equality of two multiverse trees, since the root can directly
+// be compared to another multiverse root to find out if a sync is required.
+func (r *rpcServer) MultiverseRoot(ctx context.Context,
+	req *unirpc.MultiverseRootRequest) (*unirpc.MultiverseRootResponse,
+	error) {
+
+	proofType, err := UnmarshalUniProofType(req.ProofType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof type: %w", err)
+	}
+
+	if proofType == universe.ProofTypeUnspecified {
+		return nil, fmt.Errorf("proof type must be specified")
+	}
+
+	filterByIDs := make([]universe.Identifier, len(req.SpecificIds))
+	for idx, rpcID := range req.SpecificIds {
+		filterByIDs[idx], err = UnmarshalUniID(rpcID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse universe id: "+
+				"%w", err)
+		}
+
+		// Allow the RPC user to not specify the proof type for each ID
+		// individually since the outer one is mandatory.
+		if filterByIDs[idx].ProofType == universe.ProofTypeUnspecified {
+			filterByIDs[idx].ProofType = proofType
+		}
+
+		if filterByIDs[idx].ProofType != proofType {
+			return nil, fmt.Errorf("proof type mismatch in ID "+
+				"%d: %v != %v", idx, filterByIDs[idx].ProofType,
+				proofType)
+		}
+	}
+
+	rootNode, err := r.cfg.UniverseArchive.MultiverseRoot(
+		ctx, proofType, filterByIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch multiverse root: %w",
+			err)
+	}
+
+	var resp unirpc.MultiverseRootResponse
+	rootNode.WhenSome(func(node universe.MultiverseRoot) {
+		resp.MultiverseRoot = marshalMssmtNode(node)
+	})
+
+	return &resp, nil
+}
+
+// AssetRoots queries for the known Universe roots associated with each known
+// asset. These roots represent the supply/audit state for each known asset.
+func (r *rpcServer) AssetRoots(ctx context.Context,
+	req *unirpc.AssetRootRequest) (*unirpc.AssetRootResponse, error) {
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err := r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// First, we'll retrieve the full set of known asset Universe roots.
+	assetRoots, err := r.cfg.UniverseArchive.RootNodes(
+		ctx, universe.RootNodesQuery{
+			WithAmountsById: req.WithAmountsById,
+			SortDirection:   universe.SortDirection(req.Direction),
+			Offset:          req.Offset,
+			Limit:           req.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &unirpc.AssetRootResponse{
+		UniverseRoots: make(
+			map[string]*unirpc.UniverseRoot, len(assetRoots),
+		),
+	}
+
+	// Retrieve config for use in filtering asset roots based on sync export
+	// settings.
+	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// For each universe root, marshal it into the RPC form, taking care to
+	// specify the proper universe ID.
+	for _, assetRoot := range assetRoots {
+		idStr := assetRoot.ID.String()
+
+		// Skip this asset if it's not configured for sync export.
+		if !syncConfigs.IsSyncExportEnabled(assetRoot.ID) {
+			continue
+		}
+
+		resp.UniverseRoots[idStr], err = r.marshalSignedUniverseRoot(
+			ctx, assetRoot,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// UnmarshalUniProofType parses the RPC universe proof type into the native
+// counterpart.
+func UnmarshalUniProofType(rpcType unirpc.ProofType) (universe.ProofType,
+	error) {
+
+	switch rpcType {
+	case unirpc.ProofType_PROOF_TYPE_UNSPECIFIED:
+		return universe.ProofTypeUnspecified, nil
+
+	case unirpc.ProofType_PROOF_TYPE_ISSUANCE:
+		return universe.ProofTypeIssuance, nil
+
+	case unirpc.ProofType_PROOF_TYPE_TRANSFER:
+		return universe.ProofTypeTransfer, nil
+
+	default:
+		return 0, fmt.Errorf("unknown universe proof type: %v", rpcType)
+	}
+}
+
+// unmarshalAssetSyncConfig parses the RPC asset sync config into the native
+// counterpart.
+func unmarshalAssetSyncConfig(
+	config *unirpc.AssetFederationSyncConfig) (*universe.FedUniSyncConfig,
+	error) {
+
+	if config == nil {
+		return nil, fmt.Errorf("empty universe sync config")
+	}
+
+	// Parse the universe ID from the RPC form.
+	uniID, err := UnmarshalUniID(config.Id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse universe id: %w",
+			err)
+	}
+
+	return &universe.FedUniSyncConfig{
+		UniverseID:      uniID,
+		AllowSyncInsert: config.AllowSyncInsert,
+		AllowSyncExport: config.AllowSyncExport,
+	}, nil
+}
+
+// UnmarshalUniID parses the RPC universe ID into the native counterpart.
+func UnmarshalUniID(rpcID *unirpc.ID) (universe.Identifier, error) {
+	if rpcID == nil {
+		return universe.Identifier{}, fmt.Errorf("missing universe id")
+	}
+
+	// Unmarshal the proof type.
+	proofType, err := UnmarshalUniProofType(rpcID.ProofType)
+	if err != nil {
+		return universe.Identifier{}, fmt.Errorf("unable to unmarshal "+
+			"proof type: %w", err)
+	}
+	switch {
+	case rpcID.GetAssetId() != nil:
+		var assetID asset.ID
+		copy(assetID[:], rpcID.GetAssetId())
+
+		return universe.Identifier{
+			AssetID:   assetID,
+			ProofType: proofType,
+		}, nil
+
+	case rpcID.GetAssetIdStr() != "":
+		assetIDBytes, err := hex.DecodeString(rpcID.GetAssetIdStr())
+		if err != nil {
+			return universe.Identifier{}, err
+		}
+
+		// TODO(roasbeef): reuse with above
+
+		var assetID asset.ID
+		copy(assetID[:], assetIDBytes)
+
+		return universe.Identifier{
+			AssetID:   assetID,
+			ProofType: proofType,
+		}, nil
+
+	case rpcID.GetGroupKey() != nil:
+		groupKey, err := parseUserKey(rpcID.GetGroupKey())
+		if err != nil {
+			return universe.Identifier{}, err
+		}
+
+		return universe.Identifier{
+			GroupKey:  groupKey,
+			ProofType: proofType,
+		}, nil
+
+	case rpcID.GetGroupKeyStr() != "":
+		groupKeyBytes, err := hex.DecodeString(rpcID.GetGroupKeyStr())
+		if err != nil {
+			return universe.Identifier{}, err
+		}
+
+		// TODO(roasbeef): reuse with above
+
+		groupKey, err := parseUserKey(groupKeyBytes)
+		if err != nil {
+			return universe.Identifier{}, err
+		}
+
+		return universe.Identifier{
+			GroupKey:  groupKey,
+			ProofType: proofType,
+		}, nil
+
+	default:
+		return universe.Identifier{}, fmt.Errorf("no id set")
+	}
+}
+
+// QueryAssetRoots attempts to locate the current Universe root for a specific
+// asset. This asset can be identified by its asset ID or group key.
+func (r *rpcServer) QueryAssetRoots(ctx context.Context,
+	req *unirpc.AssetRootQuery) (*unirpc.QueryRootResponse, error) {
+
+	universeID, err := UnmarshalUniID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attempt to retrieve the issuance universe root.
+	rpcsLog.Debugf("Querying for asset (group) issuance universe root "+
+		"for %v", spew.Sdump(universeID))
+
+	universeID.ProofType = universe.ProofTypeIssuance
+
+	// Ensure proof export is enabled for the given universe.
+	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !syncConfigs.IsSyncExportEnabled(universeID) {
+		return nil, fmt.Errorf("proof export is disabled for the " +
+			"given universe")
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// Query for both an issuance and transfer universe root.
+	assetRoots, err := r.queryAssetProofRoots(ctx, universeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no roots were found and the universe ID had no group key, the
+	// asset may be a grouped asset.
+	mayBeGrouped := assetRoots.IssuanceRoot.Id == nil &&
+		assetRoots.TransferRoot.Id == nil && universeID.GroupKey == nil
+
+	// We already found some universe roots, or the original
+	// request was for an asset group. Return the roots we have.
+	if !mayBeGrouped {
+		return assetRoots, nil
+	}
+
+	// Query for a matching asset group, and fetch the matching universe
+	// roots if we find a group.
+	groupedAssetID := universeID.AssetID
+	rpcsLog.Debugf("No roots found for asset %v, checking if this "+
+		"asset is part of a group", groupedAssetID.String())
+
+	assetGroup, err := r.cfg.TapAddrBook.QueryAssetGroup(
+		ctx, groupedAssetID,
+	)
+
+	switch {
+	// No asset info was found; we will return empty universe roots.
+	case errors.Is(err, address.ErrAssetGroupUnknown):
+		return assetRoots, nil
+
+	case err != nil:
+		return nil, fmt.Errorf("asset group lookup failed: %w",
+			err)
+
+	// We found the correct group for this asset; fetch the universe
+	// roots for the group.
+	case assetGroup.GroupKey != nil:
+		foundGroupKey := &assetGroup.GroupPubKey
+		groupUniID := universe.Identifier{
+			GroupKey:  foundGroupKey,
+			ProofType: universe.ProofTypeIssuance,
+		}
+
+		rpcsLog.Debugf("Found group %x for asset %v",
+			foundGroupKey.SerializeCompressed(),
+			groupedAssetID.String())
+
+		assetRoots, err = r.queryAssetProofRoots(ctx, groupUniID)
+		if err != nil {
+			return nil, err
+		}
+
+		return assetRoots, nil
+
+	// The asset has no group; we will return empty universe roots.
+	default:
+		return assetRoots, nil
+	}
+}
+
+// queryAssetProofRoots attempts to locate the current Universe root for a
+// specific asset, for both proof types. The asset can be identified by its
+// asset ID or group key.
+func (r *rpcServer) queryAssetProofRoots(ctx context.Context,
+	id universe.Identifier) (*unirpc.QueryRootResponse, error) {
+
+	var issuanceRootRPC, transferRootRPC *unirpc.UniverseRoot
+	uniID := id
+
+	issuanceRoot, issuanceErr := r.cfg.UniverseArchive.RootNode(ctx, uniID)
+	if issuanceErr != nil {
+		// Do not return at this point if the error only indicates that
+		// the root wasn't found. We'll try to find the transfer root
+		// below.
+		if !errors.Is(issuanceErr, universe.ErrNoUniverseRoot) {
+			return nil, issuanceErr
+		}
+	}
+
+	issuanceRootRPC, err := r.marshalSignedUniverseRoot(ctx, issuanceRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attempt to retrieve the transfer universe root.
+	rpcsLog.Debugf("Querying for asset (group) transfer universe root "+
+		"for %v", spew.Sdump(uniID))
+
+	uniID.ProofType = universe.ProofTypeTransfer
+
+	transferRoot, transferErr := r.cfg.UniverseArchive.RootNode(ctx, uniID)
+	if transferErr != nil {
+		// Do not return at this point if the error only indicates that
+		// the root wasn't found. We may have found the issuance root
+		// above.
+		if !errors.Is(transferErr, universe.ErrNoUniverseRoot) {
+			return nil, transferErr
+		}
+	}
+
+	transferRootRPC, err = r.marshalSignedUniverseRoot(ctx, transferRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.QueryRootResponse{
+		IssuanceRoot: issuanceRootRPC,
+		TransferRoot: transferRootRPC,
+	}, nil
+}
+
+// DeleteAssetRoot attempts to locate the current Universe root for a specific
+// asset, and deletes the associated Universe tree if found.
+func (r *rpcServer) DeleteAssetRoot(ctx context.Context,
+	req *unirpc.DeleteRootQuery) (*unirpc.DeleteRootResponse, error) {
+
+	universeID, err := UnmarshalUniID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("Deleting asset root for %v", spew.Sdump(universeID))
+
+	// If the universe proof type is unspecified, we'll delete both the
+	// issuance and transfer roots.
+	if universeID.ProofType == universe.ProofTypeUnspecified {
+		universeID.ProofType = universe.ProofTypeIssuance
+		_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
+		if err != nil {
+			return nil, err
+		}
+
+		universeID.ProofType = universe.ProofTypeTransfer
+		_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &unirpc.DeleteRootResponse{}, nil
+	}
+
+	// At this point the universe proof type was specified, so we'll only
+	// delete the root for that proof type.
+	_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.DeleteRootResponse{}, nil
+}
+
+func marshalLeafKey(leafKey universe.LeafKey) *unirpc.AssetKey {
+	return &unirpc.AssetKey{
+		Outpoint: &unirpc.AssetKey_OpStr{
+			OpStr: leafKey.OutPoint.String(),
+		},
+		ScriptKey: &unirpc.AssetKey_ScriptKeyBytes{
+			ScriptKeyBytes: schnorr.SerializePubKey(
+				leafKey.ScriptKey.PubKey,
+			),
+		},
+	}
+}
+
+// AssetLeafKeys queries for the set of Universe keys associated with a given
+// asset_id or group_key. Each key takes the form: (outpoint, script_key),
+// where outpoint is an outpoint in the Bitcoin blockchain that anchors a valid
+// Taproot Asset commitment, and script_key is the script_key of the asset
+// within the Taproot Asset commitment for the given asset_id or group_key.
+func (r *rpcServer) AssetLeafKeys(ctx context.Context,
+	req *unirpc.AssetLeafKeysRequest) (*unirpc.AssetLeafKeyResponse, error) {
+
+	if req == nil {
+		return nil, fmt.Errorf("request must be set")
+	}
+
+	universeID, err := UnmarshalUniID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the proof type wasn't specified, then we'll return an error as we
+	// don't know which keys to actually fetch.
+	if universeID.ProofType == universe.ProofTypeUnspecified {
+		return nil, fmt.Errorf("proof type must be specified")
+	}
+
+	if req.Limit > universe.MaxPageSize || req.Limit < 0 {
+		return nil, fmt.Errorf("invalid request limit: %d", req.Limit)
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	leafKeys, err := r.cfg.UniverseArchive.UniverseLeafKeys(
+		ctx, universe.UniverseLeafKeysQuery{
+			Id:            universeID,
+			SortDirection: universe.SortDirection(req.Direction),
+			Offset:        req.Offset,
+			Limit:         req.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &unirpc.AssetLeafKeyResponse{
+		AssetKeys: make([]*unirpc.AssetKey, len(leafKeys)),
+	}
+
+	for i, leafKey := range leafKeys {
+		resp.AssetKeys[i] = marshalLeafKey(leafKey)
+	}
+
+	return resp, nil
+}
+
+// marshalSubtreePath marshals a MS-SMT node path into the RPC counterpart.
+func marshalSubtreePath(path mssmt.NodePath) *unirpc.SubtreePath {
+	return &unirpc.SubtreePath{
+		Key:   fn.CopySlice(path.Key[:]),
+		Depth: uint32(path.Depth),
+	}
+}
+
+// unmarshalSubtreePath parses a MS-SMT node path from the RPC counterpart.
+func unmarshalSubtreePath(path *unirpc.SubtreePath) (mssmt.NodePath, error) {
+	if path == nil {
+		return mssmt.NodePath{}, fmt.Errorf("subtree path must be set")
+	}
+
+	var nodePath mssmt.NodePath
+	if len(path.Key) != len(nodePath.Key) {
+		return nodePath, fmt.Errorf("invalid subtree path key length "+
+			"%d", len(path.Key))
+	}
+	copy(nodePath.Key[:], path.Key)
+	nodePath.Depth = int(path.Depth)
+
+	if err := nodePath.Validate(); err != nil {
+		return nodePath, err
+	}
+
+	return nodePath, nil
+}
+
+// SubtreeNodes queries for the inner nodes of the Universe MS-SMT tree for a
+// given asset_id or group_key. For each of the given paths, the nodes that are
+// found the given number of levels below the node at that path are returned.
+func (r *rpcServer) SubtreeNodes(ctx context.Context,
+	req *unirpc.SubtreeNodesRequest) (*unirpc.SubtreeNodesResponse, error) {
+
+	if req == nil {
+		return nil, fmt.Errorf("request must be set")
+	}
+
+	universeID, err := UnmarshalUniID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the proof type wasn't specified, then we'll return an error as we
+	// don't know which tree to query.
+	if universeID.ProofType == universe.ProofTypeUnspecified {
+		return nil, fmt.Errorf("proof type must be specified")
+	}
+
+	if req.Levels > mssmt.MaxSubtreeLevels {
+		return nil, fmt.Errorf("invalid number of levels: %d",
+			req.Levels)
+	}
+
+	if len(req.Paths)<<req.Levels > universe.MaxSubtreeNodes {
+		return nil, fmt.Errorf("too many subtree nodes requested, max "+
+			"is %d", universe.MaxSubtreeNodes)
+	}
+
+	paths, err := fn.MapErr(req.Paths, unmarshalSubtreePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.cfg.UniverseArchive.SubtreeNodes(
+		ctx, universeID, paths, int(req.Levels),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &unirpc.SubtreeNodesResponse{
+		Nodes: make([]*unirpc.SubtreeNode, len(nodes)),
+	}
+	for i, node := range nodes {
+		resp.Nodes[i] = &unirpc.SubtreeNode{
+			Path: marshalSubtreePath(node.Path),
+			Node: marshalMssmtNode(node.Node),
+		}
+
+		node.LeafKey.WhenSome(func(leafKey universe.LeafKey) {
+			resp.Nodes[i].LeafKey = marshalLeafKey(leafKey)
+		})
+	}
+
+	return resp, nil
+}
+
+func marshalAssetLeaf(ctx context.Context, keys taprpc.KeyLookup,
+	assetLeaf *universe.Leaf,
+	decDisplay fn.Option[uint32]) (*unirpc.AssetLeaf, error) {
+
+	rpcAsset, err := taprpc.MarshalAsset(
+		ctx, assetLeaf.Asset, false, true, keys, decDisplay,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.AssetLeaf{
+		Asset: rpcAsset,
+		Proof: assetLeaf.RawProof,
+	}, nil
+}
+
+// marshalAssetLeaf marshals an asset leaf into the RPC form.
+func (r *rpcServer) marshalAssetLeaf(ctx context.Context,
+	assetLeaf *universe.Leaf,
+	decDisplay fn.Option[uint32]) (*unirpc.AssetLeaf, error) {
+
+	return marshalAssetLeaf(ctx, r.cfg.AddrBook, assetLeaf, decDisplay)
+}
+
+// AssetLeaves queries for the set of asset leaves (the values in the Universe
+// MS-SMT tree) for a given asset_id or group_key. These represents either
+// asset issuance events (they have a genesis witness) or asset transfers that
+// took place on chain. The leaves contain a normal Taproot asset proof, as well
+// as details for the asset.
+func (r *rpcServer) AssetLeaves(ctx context.Context,
+	req *unirpc.ID) (*unirpc.AssetLeafResponse, error) {
+
+	universeID, err := UnmarshalUniID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	assetLeaves, err := r.cfg.UniverseArchive.MintingLeaves(ctx, universeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &unirpc.AssetLeafResponse{
+		Leaves: make([]*unirpc.AssetLeaf, len(assetLeaves)),
+	}
+	for i, assetLeaf := range assetLeaves {
+		assetLeaf := assetLeaf
+
+		decDisplay, err := r.DecDisplayForAssetID(ctx, assetLeaf.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Leaves[i], err = r.marshalAssetLeaf(
+			ctx, &assetLeaf, decDisplay,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// unmarshalLeafKey un-marshals a leaf key from the RPC form.
+func unmarshalLeafKey(key *unirpc.AssetKey) (universe.LeafKey, error) {
+	var leafKey universe.LeafKey
+
+	switch {
+	case key.GetScriptKeyBytes() != nil:
+		scriptKey, err := parseUserKey(key.GetScriptKeyBytes())
+		if err != nil {
+			return leafKey, err
+		}
+
+		leafKey.ScriptKey = &asset.ScriptKey{
+			PubKey: scriptKey,
+		}
+
+	case key.GetScriptKeyStr() != "":
+		scriptKeyBytes, err := hex.DecodeString(key.GetScriptKeyStr())
+		if err != nil {
+			return leafKey, err
+		}
+
+		scriptKey, err := parseUserKey(scriptKeyBytes)
+		if err != nil {
+			return leafKey, err
+		}
+
+		leafKey.ScriptKey = &asset.ScriptKey{
+			PubKey: scriptKey,
+		}
+	default:
+		// TODO(roasbeef): can actually allow not to be, then would
+		// fetch all for the given outpoint
+		return leafKey, fmt.Errorf("script key must be set")
+	}
+
+	switch {
+	case key.GetOpStr() != "":
+		// Parse a bitcoin outpoint in the form txid:index into a
+		// wire.OutPoint struct.
+		outpoint, err := wire.NewOutPointFromString(key.GetOpStr())
+		if err != nil {
+			return leafKey, err
+		}
+
+		leafKey.OutPoint = *outpoint
+
+	case key.GetOutpoint() != nil:
+		op := key.GetOp()
+
+		hash, err := chainhash.NewHashFromStr(op.HashStr)
+		if err != nil {
+			return leafKey, err
+		}
+
+		leafKey.OutPoint = wire.OutPoint{
+			Hash:  *hash,
+			Index: uint32(op.Index),
+		}
+
+	default:
+		return leafKey, fmt.Errorf("outpoint not set")
+	}
+
+	return leafKey, nil
+}
+
+// marshalMssmtProof marshals a MS-SMT proof into the RPC form.
+func marshalMssmtProof(proof *mssmt.Proof) ([]byte, error) {
+	compressedProof := proof.Compress()
+
+	var b bytes.Buffer
+	if err := compressedProof.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// marshalUniverseProofLeaf marshals a universe proof leaf into the RPC form.
+func (r *rpcServer) marshalUniverseProofLeaf(ctx context.Context,
+	req *unirpc.UniverseKey,
+	proof *universe.Proof) (*unirpc.AssetProofResponse, error) {
+
+	uniProof, err := marshalMssmtProof(proof.UniverseInclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	decDisplay, err := r.DecDisplayForAssetID(ctx, proof.Leaf.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	assetLeaf, err := r.marshalAssetLeaf(ctx, proof.Leaf, decDisplay)
+	if err != nil {
+		return nil, err
+	}
+
+	uniRoot, err := marshalUniverseRoot(universe.Root{
+		Node: proof.UniverseRoot,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uniRoot.AssetName = assetLeaf.Asset.AssetGenesis.Name
+	uniRoot.Id = req.Id
+
+	// Marshal multiverse specific fields.
+	multiverseRoot := marshalMssmtNode(proof.MultiverseRoot)
+
+	multiverseProof, err := marshalMssmtProof(
+		proof.MultiverseInclusionProof,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.AssetProofResponse{
+		Req:                      req,
+		UniverseRoot:             uniRoot,
+		UniverseInclusionProof:   uniProof,
+		AssetLeaf:                assetLeaf,
+		MultiverseRoot:           multiverseRoot,
+		MultiverseInclusionProof: multiverseProof,
+	}, nil
+}
+
+// QueryProof attempts to query for an issuance or transfer proof for a given
+// asset based on its UniverseKey. A UniverseKey is composed of the Universe ID
+// (asset_id/group_key) and also a leaf key (outpoint || script_key). If found,
+// the target universe proof leaf is returned in addition to inclusion proofs
+// for the Universe and Multiverse MS-SMTs. This allows a caller to verify the
+// known Universe root, Multiverse root, and transition or issuance proof for
+// the target asset.
+func (r *rpcServer) QueryProof(ctx context.Context,
+	req *unirpc.UniverseKey) (*unirpc.AssetProofResponse, error) {
+
+	universeID, leafKey, err := unmarshalUniverseKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	firstProof, err := r.queryProof(ctx, universeID, leafKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.marshalUniverseProofLeaf(ctx, req, firstProof)
+}
+
+// queryProof attempts to query for an issuance or transfer proof for a given
+// asset based on its UniverseKey. A UniverseKey is composed of the Universe ID
+// (asset_id/group_key) and also a leaf key (outpoint || script_key).
+func (r *rpcServer) queryProof(ctx context.Context, uniID universe.Identifier,
+	leafKey universe.LeafKey) (*universe.Proof, error) {
+
+	rpcsLog.Tracef("[QueryProof]: fetching proof at (uniID=%v, "+
+		"leafKey=%x)", uniID.StringForLog(), leafKey.UniverseKey())
+
+	// Retrieve proof export config for the given universe.
+	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidateIDs []universe.Identifier
+
+	if uniID.ProofType == universe.ProofTypeUnspecified {
+		// If the proof type is unspecified, then we'll attempt to
+		// retrieve both the issuance and transfer proofs. We gather the
+		// corresponding universe IDs into a candidate set.
+		uniID.ProofType = universe.ProofTypeIssuance
+		if syncConfigs.IsSyncExportEnabled(uniID) {
+			candidateIDs = append(candidateIDs, uniID)
+		}
+
+		uniID.ProofType = universe.ProofTypeTransfer
+		if syncConfigs.IsSyncExportEnabled(uniID) {
+			candidateIDs = append(candidateIDs, uniID)
+		}
+	} else {
+		// Otherwise, we'll only attempt to retrieve the proof for the
+		// specified proof type. But first we'll check that proof export
+		// is enabled for the given universe.
+		if !syncConfigs.IsSyncExportEnabled(uniID) {
+			return nil, fmt.Errorf("proof export is disabled for " +
+				"the given universe")
+		}
+
+		candidateIDs = append(candidateIDs, uniID)
+	}
+
+	// If no candidate IDs were applicable then our config must have
+	// disabled proof export for the given universe.
+	if len(candidateIDs) == 0 {
+		return nil, fmt.Errorf("proof export is disabled for the " +
+			"given universe")
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// Attempt to retrieve the proof given the candidate set of universe
+	// IDs.
+	var proofs []*universe.Proof
+	for i := range candidateIDs {
+		candidateID := candidateIDs[i]
+
+		proofs, err = r.cfg.UniverseArchive.FetchProofLeaf(
+			ctx, candidateID, leafKey,
+		)
+		if err != nil {
+			if errors.Is(err, universe.ErrNoUniverseProofFound) {
+				continue
+			}
+
+			rpcsLog.Debugf("[QueryProof]: error querying for "+
+				"proof at (uniID=%v, leafKey=%x)",
+				uniID.StringForLog(), leafKey.UniverseKey())
+			return nil, err
+		}
+
+		// At this point we've found a proof, so we'll break out of the
+		// loop. We don't need to attempt to retrieve a proof for any
+		// other candidate IDs.
+		break
+	}
+
+	if len(proofs) == 0 {
+		return nil, universe.ErrNoUniverseProofFound
+	}
+
+	// TODO(roasbeef): query may return multiple proofs, if allow key to
+	// not be fully specified
+	firstProof := proofs[0]
+
+	rpcsLog.Tracef("[QueryProof]: found proof at (uniID=%v, "+
+		"leafKey=%x)", uniID.StringForLog(), leafKey.UniverseKey())
+
+	return firstProof, nil
+}
+
+// unmarshalUniverseKey unmarshals a universe key from the RPC form.
+func unmarshalUniverseKey(key *unirpc.UniverseKey) (universe.Identifier,
+	universe.LeafKey, error) {
+
+	var (
+		uniID  = universe.Identifier{}
+		uniKey = universe.LeafKey{}
+		err    error
+	)
+
+	if key == nil {
+		return uniID, uniKey, fmt.Errorf("universe key cannot be nil")
+	}
+
+	uniID, err = UnmarshalUniID(key.Id)
+	if err != nil {
+		return uniID, uniKey, err
+	}
+
+	uniKey, err = unmarshalLeafKey(key.LeafKey)
+	if err != nil {
+		return uniID, uniKey, err
+	}
+
+	return uniID, uniKey, nil
+}
+
+// unmarshalAssetLeaf unmarshals an asset leaf from the RPC form.
+func unmarshalAssetLeaf(leaf *unirpc.AssetLeaf) (*universe.Leaf, error) {
+	// We'll just pull the asset details from the serialized issuance proof
+	// itself.
+	var proofAsset asset.Asset
+	assetRecord := proof.AssetLeafRecord(&proofAsset)
+	err := proof.SparseDecode(bytes.NewReader(leaf.Proof), assetRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO(roasbeef): double check posted file format everywhere
+	//  * raw proof, or within file?
+
+	return &universe.Leaf{
+		GenesisWithGroup: universe.GenesisWithGroup{
+			Genesis:  proofAsset.Genesis,
+			GroupKey: proofAsset.GroupKey,
+		},
+		RawProof: leaf.Proof,
+		Asset:    &proofAsset,
+		Amt:      proofAsset.Amount,
+	}, nil
+}
+
+// InsertProof attempts to insert a new issuance or transfer proof into the
+// Universe tree specified by the UniverseKey. If valid, then the proof is
+// inserted into the database, with a new Universe root returned for the updated
+// asset_id/group_key.
+func (r *rpcServer) InsertProof(ctx context.Context,
+	req *unirpc.AssetProof) (*unirpc.AssetProofResponse, error) {
+
+	// Before doing any work, we make sure the caller hasn't exceeded their
+	// proof upload rate.
+	err := r.proofUploadLimiter.AllowPeer(
+		clientIP(ctx, r.restProxyHost),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	universeID, leafKey, err := unmarshalUniverseKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	assetLeaf, err := unmarshalAssetLeaf(req.AssetLeaf)
+	if err != nil {
+		return nil, err
+	}
+
+	// If universe proof type unspecified, set based on the provided asset
+	// proof.
+	if universeID.ProofType == universe.ProofTypeUnspecified {
+		universeID.ProofType, err = universe.NewProofTypeFromAsset(
+			assetLeaf.Asset,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Ensure that the new proof is of the correct type for the target
+	// universe.
+	err = universe.ValidateProofUniverseType(assetLeaf.Asset, universeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure proof insert is enabled for the given universe.
+	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !syncConfigs.IsSyncInsertEnabled(universeID) {
+		return nil, fmt.Errorf("proof insert is disabled for the " +
+			"given universe")
+	}
+
+	// Make sure the upload carries the required proof-of-work and doesn't
+	// exceed the upload rate of the target universe.
+	err = r.proofUploadLimiter.AllowUpload(
+		universeID, req.AssetLeaf.Proof, req.PowNonce,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the rate limiter to see if we need to wait at all. If not then
+	// this'll be a noop.
+	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[InsertProof]: inserting proof at "+
+		"(universeID=%v, leafKey=%x)", universeID.StringForLog(),
+		leafKey.UniverseKey())
+
+	newUniverseState, err := r.cfg.UniverseArchive.UpsertProofLeaf(
+		ctx, universeID, leafKey, assetLeaf,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	universeRootHash := newUniverseState.UniverseRoot.NodeHash()
+	rpcsLog.Debugf("[InsertProof]: proof inserted, new universe root: %x",
+		universeRootHash[:])
+
+	return r.marshalUniverseProofLeaf(ctx, req.Key, newUniverseState)
+}
+
+// PushProof attempts to query the local universe for a proof specified by a
+// UniverseKey. If found, a connection is made to a remote Universe server to
+// attempt to upload the asset leaf.
+func (r *rpcServer) PushProof(ctx context.Context,
+	req *unirpc.PushProofRequest) (*unirpc.PushProofResponse, error) {
+
+	switch {
+	case req.Server == nil:
+		return nil, fmt.Errorf("remote Universe must be specified")
+
+	case req.Key == nil:
+		return nil, fmt.Errorf("universe key must be specified")
+
+	case req.Server.Host == "" && req.Server.Id == 0:
+		return nil, fmt.Errorf("remote Universe must be specified")
+
+	case req.Server.Host != "" && req.Server.Id != 0:
+		return nil, fmt.Errorf("cannot specify both universe host " +
+			"and id")
+	}
+
+	remoteUniAddr := unmarshalUniverseServer(req.Server)
+	universeID, leafKey, err := unmarshalUniverseKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to fetch the requested proof from the local universe.
+	localProof, err := r.queryProof(ctx, universeID, leafKey)
+	if err != nil {
+		return nil, err
+	}
+	if localProof.Leaf == nil {
+		return nil, fmt.Errorf("proof not found in local universe")
+	}
+
+	// Make sure that we aren't trying to push the proof to ourself, and
+	// then attempt to push the proof.
+	err = CheckFederationServer(
+		r.cfg.RuntimeID, universe.DefaultTimeout, remoteUniAddr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteUni, err := NewRpcUniverseRegistrar(remoteUniAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[PushProof]: pushing proof to universe "+
+		"(universeID=%v, server=%v", universeID.StringForLog(),
+		remoteUniAddr)
+
+	_, err = remoteUni.UpsertProofLeaf(
+		ctx, universeID, leafKey, localProof.Leaf,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[PushProof]: proof pushed to universe "+
+		"(universeID=%v, server=%v", universeID.StringForLog(),
+		remoteUniAddr)
+
+	return &unirpc.PushProofResponse{
+		Key: req.Key,
+	}, nil
+}
+
+// knownMetaBlob returns an error if no known asset commits to the meta blob
+// with the given reference. The universe server only stores and serves meta
+// blobs of known assets.
+func (r *rpcServer) knownMetaBlob(ctx context.Context,
+	ref proof.BlobRef) error {
+
+	_, err := r.cfg.AssetStore.FetchAssetMetaByHash(
+		ctx, ref.MetaReveal().MetaHash(),
+	)
+	switch {
+	case errors.Is(err, tapdb.ErrAssetMetaNotFound):
+		return fmt.Errorf("no known asset commits to meta blob %v", ref)
+
+	case err != nil:
+		return fmt.Errorf("unable to fetch asset meta: %w", err)
+	}
+
+	return nil
+}
+
+// PushMetaBlob uploads the metadata blob referenced by the asset meta of an
+// issued asset.
+func (r *rpcServer) PushMetaBlob(
+	stream unirpc.Universe_PushMetaBlobServer) error {
+
+	ctx := stream.Context()
+	firstReq, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	ref, err := unmarshalBlobRef(firstReq.BlobHash, firstReq.Size)
+	if err != nil {
+		return err
+	}
+
+	if err := r.knownMetaBlob(ctx, ref); err != nil {
+		return err
+	}
+
+	has, err := r.cfg.MetaBlobs.HasBlob(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("unable to look up meta blob: %w", err)
+	}
+	if has {
+		return stream.SendAndClose(&unirpc.PushMetaBlobResponse{
+			AlreadyStored: true,
+		})
+	}
+
+	// The first message may already carry the first chunk of the blob.
+	pendingChunk := firstReq.Chunk
+	blob := proof.NewBlobChunkReader(func() ([]byte, error) {
+		if pendingChunk != nil {
+			chunk := pendingChunk
+			pendingChunk = nil
+
+			return chunk, nil
+		}
+
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		return req.Chunk, nil
+	})
+
+	_, err = r.cfg.MetaBlobs.PutBlob(ctx, blob, fn.Some(ref))
+	if err != nil {
+		return fmt.Errorf("unable to store meta blob: %w", err)
+	}
+
+	rpcsLog.Debugf("[PushMetaBlob]: stored meta blob %v (%d bytes)", ref,
+		ref.Size)
+
+	return stream.SendAndClose(&unirpc.PushMetaBlobResponse{})
+}
+
+// PullMetaBlob downloads the metadata blob referenced by the asset meta of an
+// issued asset.
+func (r *rpcServer) PullMetaBlob(req *unirpc.PullMetaBlobRequest,
+	stream unirpc.Universe_PullMetaBlobServer) error {
+
+	ref, err := unmarshalBlobRef(req.BlobHash, req.Size)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	if err := r.knownMetaBlob(ctx, ref); err != nil {
+		return err
+	}
+
+	return r.streamMetaBlob(ctx, ref, func(chunk []byte) error {
+		return stream.Send(&unirpc.PullMetaBlobChunk{
+			Chunk: chunk,
+		})
+	})
+}
+
+// Info returns a set of information about the current state of the Universe.
+func (r *rpcServer) Info(ctx context.Context,
+	_ *unirpc.InfoRequest) (*unirpc.InfoResponse, error) {
+
+	resp := &unirpc.InfoResponse{
+		RuntimeId:          r.cfg.RuntimeID,
+		ProofUploadPowBits: r.proofUploadLimiter.PoWBits(),
+	}
+	if r.cfg.UniverseRootSigner != nil {
+		operatorKey := r.cfg.UniverseRootSigner.OperatorKey()
+		resp.OperatorKey = operatorKey.SerializeCompressed()
+	}
+
+	return resp, nil
+}
+
+// peerIP returns the IP address of the remote peer of the given RPC call, or
+// an empty string if it can't be determined.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// clientIP returns the IP address of the client of the given RPC call. Calls
+// made through the REST proxy arrive from the proxy's local address, so for
+// calls from the given REST proxy host or a loopback address, the client IP the
+// gRPC gateway forwarded in the x-forwarded-for metadata is used instead, if
+// present.
+func clientIP(ctx context.Context, restProxyHost string) string {
+	ip := peerIP(ctx)
+
+	parsedIP := net.ParseIP(ip)
+	fromProxy := ip != "" && ip == restProxyHost
+	if !fromProxy && (parsedIP == nil || !parsedIP.IsLoopback()) {
+		return ip
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ip
+	}
+
+	forwarded := md.Get("x-forwarded-for")
+	if len(forwarded) == 0 {
+		return ip
+	}
+
+	// The gateway appends the address it saw the request coming from to
+	// any existing header value, so the last entry is the one we can
+	// trust.
+	entries := strings.Split(forwarded[len(forwarded)-1], ",")
+	forwardedIP := strings.TrimSpace(entries[len(entries)-1])
+	if forwardedIP == "" {
+		return ip
+	}
+
+	return forwardedIP
+}
+
+// unmarshalUniverseSyncType maps an RPC universe sync type into a concrete
+// type.
+func unmarshalUniverseSyncType(
+	req unirpc.UniverseSyncMode) (universe.SyncType, error) {
+
+	switch req {
+	case unirpc.UniverseSyncMode_SYNC_FULL:
+		return universe.SyncFull, nil
+
+	case unirpc.UniverseSyncMode_SYNC_ISSUANCE_ONLY:
+		return universe.SyncIssuance, nil
+
+	default:
+		return 0, fmt.Errorf("unknown sync type: %v", req)
+	}
+}
+
+// unmarshalSyncTargets maps an RPC sync target into a concrete type.
+func unmarshalSyncTargets(
+	targets []*unirpc.SyncTarget) ([]universe.Identifier, error) {
+
+	uniIDs := make([]universe.Identifier, 0, len(targets))
+	for _, target := range targets {
+		uniID, err := UnmarshalUniID(target.Id)
+		if err != nil {
+			return nil, err
+		}
+		uniIDs = append(uniIDs, uniID)
+	}
+
+	return uniIDs, nil
+}
+
+// marshalUniverseDiff marshals a universe diff into the RPC form.
+func (r *rpcServer) marshalUniverseDiff(ctx context.Context,
+	uniDiff []universe.AssetSyncDiff) (*unirpc.SyncResponse, error) {
+
+	resp := &unirpc.SyncResponse{
+		SyncedUniverses: make([]*unirpc.SyncedUniverse, 0, len(uniDiff)),
+	}
+
+	err := fn.ForEachErr(uniDiff, func(diff universe.AssetSyncDiff) error {
+		oldUniRoot, err := marshalUniverseRoot(diff.OldUniverseRoot)
+		if err != nil {
+			return fmt.Errorf("unable to marshal old uni "+
+				"root: %w", err)
+		}
+		newUniRoot, err := marshalUniverseRoot(diff.NewUniverseRoot)
+		if err != nil {
+			return fmt.Errorf("unable to marshal new unit "+
+				"root: %w", err)
+		}
+
+		leaves := make([]*unirpc.AssetLeaf, len(diff.NewLeafProofs))
+		for i, leaf := range diff.NewLeafProofs {
+			decDisplay, err := r.DecDisplayForAssetID(
+				ctx, leaf.ID(),
+			)
+			if err != nil {
+				return err
+			}
+
+			leaves[i], err = r.marshalAssetLeaf(
+				ctx, leaf, decDisplay,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp.SyncedUniverses = append(
+			resp.SyncedUniverses, &unirpc.SyncedUniverse{
+				OldAssetRoot:   oldUniRoot,
+				NewAssetRoot:   newUniRoot,
+				NewAssetLeaves: leaves,
+			},
+		)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SyncUniverse takes host information for a remote Universe server, then
+// attempts to synchronize either only the set of specified asset_ids, or all
+// assets if none are specified. The sync process will attempt to query for the
+// latest known root for each asset, performing tree based reconciliation to
+// arrive at a new shared root.
+func (r *rpcServer) SyncUniverse(ctx context.Context,
+	req *unirpc.SyncRequest) (*unirpc.SyncResponse, error) {
+
+	// TODO(roasbeef): have another layer, only allow single outstanding
+	// sync request per host?
+
+	syncMode, err := unmarshalUniverseSyncType(req.SyncMode)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse sync type: %w", err)
+	}
+	syncTargets, err := unmarshalSyncTargets(req.SyncTargets)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse sync targets: %w", err)
+	}
+
+	uniAddr := universe.NewServerAddrFromStr(req.UniverseHost)
+
+	// Obtain the general and universe specific federation sync configs.
+	queryFedSyncConfigs := r.cfg.FederationDB.QueryFederationSyncConfigs
+	globalConfigs, uniSyncConfigs, err := queryFedSyncConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query federation sync "+
+			"config(s): %w", err)
+	}
+
+	syncConfigs := universe.SyncConfigs{
+		GlobalSyncConfigs: globalConfigs,
+		UniSyncConfigs:    uniSyncConfigs,
+	}
+
+	// TODO(roasbeef): add layer of indirection in front of?
+	//  * just interface interaction
+	// TODO(ffranr): Sync via the FederationEnvoy rather than syncer.
+	universeDiff, err := r.cfg.UniverseSyncer.SyncUniverse(
+		ctx, uniAddr, syncMode, syncConfigs, syncTargets...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sync universe: %w", err)
+	}
+
+	return r.marshalUniverseDiff(ctx, universeDiff)
+}
+
+// SyncFederation starts a background job that fully syncs the local Universe
+// with all servers of the Universe federation.
+func (r *rpcServer) SyncFederation(ctx context.Context,
+	_ *unirpc.SyncFederationRequest) (*unirpc.SyncFederationResponse,
+	error) {
+
+	job, err := r.cfg.JobManager.Submit(
+		ctx, jobs.KindFederationSync, nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start federation sync: %w",
+			err)
+	}
+
+	return &unirpc.SyncFederationResponse{
+		JobId: uint64(job.ID),
+	}, nil
+}
+
+func marshalUniverseServer(
+	server universe.ServerAddr) *unirpc.UniverseFederationServer {
+
+	return &unirpc.UniverseFederationServer{
+		Host: server.HostStr(),
+		Id:   int32(server.ID),
+	}
+}
+
+// ListFederationServers lists the set of servers that make up the federation
+// of the local Universe server. These servers are used to push out new proofs,
+// and also periodically call sync new proofs from the remote server.
+func (r *rpcServer) ListFederationServers(ctx context.Context,
+	_ *unirpc.ListFederationServersRequest) (
+	*unirpc.ListFederationServersResponse, error) {
+
+	uniServers, err := r.cfg.FederationDB.UniverseServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := r.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query federation server "+
+			"scopes: %w", err)
+	}
+
+	rpcServers := make(
+		[]*unirpc.UniverseFederationServer, 0, len(uniServers),
+	)
+	for _, server := range uniServers {
+		rpcServer := marshalUniverseServer(server)
+
+		scope := scopes[server.HostStr()]
+		for idx := range scope.AssetIDs {
+			rpcServer.ScopeAssetIds = append(
+				rpcServer.ScopeAssetIds, scope.AssetIDs[idx][:],
+			)
+		}
+		for _, groupKey := range scope.GroupKeys {
+			rpcServer.ScopeGroupKeys = append(
+				rpcServer.ScopeGroupKeys,
+				schnorr.SerializePubKey(groupKey),
+			)
+		}
+
+		rpcServers = append(rpcServers, rpcServer)
+	}
+
+	return &unirpc.ListFederationServersResponse{
+		Servers: rpcServers,
+	}, nil
+}
+
+func unmarshalUniverseServer(
+	server *unirpc.UniverseFederationServer) universe.ServerAddr {
+
+	return universe.NewServerAddr(int64(server.Id), server.Host)
+}
+
+// AddFederationServer adds a new server to the federation of the local
+// Universe server. Once a server is added, this call can also optionally be
+// used to trigger a sync of the remote server.
+func (r *rpcServer) AddFederationServer(ctx context.Context,
+	req *unirpc.AddFederationServerRequest,
+) (*unirpc.AddFederationServerResponse, error) {
+
+	serversToAdd := fn.Map(req.Servers, unmarshalUniverseServer)
+
+	for idx := range serversToAdd {
+		server := serversToAdd[idx]
+
+		// Before we add the server as a federation member, we check
+		// that we can actually connect to it and that it isn't
+		// ourselves.
+		err := CheckFederationServer(
+			r.cfg.RuntimeID, universe.DefaultTimeout, server,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := r.cfg.UniverseFederation.AddServer(serversToAdd...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.AddFederationServerResponse{}, nil
+}
+
+// DeleteFederationServer removes a server from the federation of the local
+// Universe server.
+func (r *rpcServer) DeleteFederationServer(ctx context.Context,
+	req *unirpc.DeleteFederationServerRequest,
+) (*unirpc.DeleteFederationServerResponse, error) {
+
+	serversToDel := fn.Map(req.Servers, unmarshalUniverseServer)
+
+	// Remove the servers from the proofs sync log. This is necessary before
+	// we can remove the servers from the database because of a foreign
+	// key constraint.
+	err := r.cfg.FederationDB.DeleteProofsSyncLogEntries(
+		ctx, serversToDel...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.cfg.FederationDB.RemoveServers(ctx, serversToDel...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.DeleteFederationServerResponse{}, nil
+}
+
+// SetFederationServerScope restricts the sync with a server of the federation
+// of the local Universe server to the universes of a set of assets. An empty
+// scope removes any restriction.
+func (r *rpcServer) SetFederationServerScope(ctx context.Context,
+	req *unirpc.SetFederationServerScopeRequest,
+) (*unirpc.SetFederationServerScopeResponse, error) {
+
+	if req.Host == "" {
+		return nil, fmt.Errorf("federation server host must be set")
+	}
+
+	var scope universe.FedServerScope
+	for _, rpcAssetID := range req.AssetIds {
+		if len(rpcAssetID) != sha256.Size {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		}
+
+		var assetID asset.ID
+		copy(assetID[:], rpcAssetID)
+
+		scope.AssetIDs = append(scope.AssetIDs, assetID)
+	}
+
+	for _, rpcGroupKey := range req.GroupKeys {
+		groupKey, err := parseUserKey(rpcGroupKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse group key: %w",
+				err)
+		}
+
+		scope.GroupKeys = append(scope.GroupKeys, groupKey)
+	}
+
+	err := r.cfg.FederationDB.SetFederationServerScope(
+		ctx, universe.NewServerAddrFromStr(req.Host), scope,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set federation server "+
+			"scope: %w", err)
+	}
+
+	return &unirpc.SetFederationServerScopeResponse{}, nil
+}
+
+// SetFederationSyncConfig sets the configuration of the universe federation
+// sync.
+func (r *rpcServer) SetFederationSyncConfig(ctx context.Context,
+	req *unirpc.SetFederationSyncConfigRequest) (
+	*unirpc.SetFederationSyncConfigResponse, error) {
+
+	// Unmarshal global sync configs.
+	globalSyncConfig := make(
+		[]*universe.FedGlobalSyncConfig, len(req.GlobalSyncConfigs),
+	)
+	for i := range req.GlobalSyncConfigs {
+		config := req.GlobalSyncConfigs[i]
+
+		proofType, err := UnmarshalUniProofType(config.ProofType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal "+
+				"proof type: %w", err)
+		}
+
+		globalSyncConfig[i] = &universe.FedGlobalSyncConfig{
+			ProofType:       proofType,
+			AllowSyncInsert: config.AllowSyncInsert,
+			AllowSyncExport: config.AllowSyncExport,
+		}
+	}
+
+	// Unmarshal asset (asset/asset group) specific sync configs.
+	assetSyncConfigs := make(
+		[]*universe.FedUniSyncConfig, len(req.AssetSyncConfigs),
+	)
+	for i := range req.AssetSyncConfigs {
+		assetSyncConfig := req.AssetSyncConfigs[i]
+		config, err := unmarshalAssetSyncConfig(assetSyncConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse asset sync "+
+				"config: %w", err)
+		}
+
+		assetSyncConfigs[i] = config
+	}
+
+	// Update asset (asset/asset group) specific sync configs.
+	err := r.cfg.FederationDB.UpsertFederationSyncConfig(
+		ctx, globalSyncConfig, assetSyncConfigs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set federation sync "+
+			"config: %w", err)
+	}
+
+	return &unirpc.SetFederationSyncConfigResponse{}, nil
+}
+
+// QueryFederationSyncConfig queries the universe federation sync configuration
+// settings.
+func (r *rpcServer) QueryFederationSyncConfig(ctx context.Context,
+	_ *unirpc.QueryFederationSyncConfigRequest,
+) (*unirpc.QueryFederationSyncConfigResponse, error) {
+
+	// Obtain the general and universe specific federation sync configs.
+	queryFedSyncConfigs := r.cfg.FederationDB.QueryFederationSyncConfigs
+	globalConfigs, uniSyncConfigs, err := queryFedSyncConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query federation sync "+
+			"config(s): %w", err)
+	}
+
+	// Marshal the general sync config into the RPC form.
+	globalConfigRPC := make(
+		[]*unirpc.GlobalFederationSyncConfig, len(globalConfigs),
+	)
+	for i := range globalConfigs {
+		globalConfig := globalConfigs[i]
+
+		proofTypeRpc, err := MarshalUniProofType(globalConfig.ProofType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal "+
+				"proof type: %w", err)
+		}
+
+		globalConfigRPC[i] = &unirpc.GlobalFederationSyncConfig{
+			ProofType:       proofTypeRpc,
+			AllowSyncInsert: globalConfig.AllowSyncInsert,
+			AllowSyncExport: globalConfig.AllowSyncExport,
+		}
+	}
+
+	// Marshal universe specific sync configs into the RPC form.
+	uniConfigRPCs := make(
+		[]*unirpc.AssetFederationSyncConfig, len(uniSyncConfigs),
+	)
+	for i := range uniSyncConfigs {
+		uniSyncConfig := uniSyncConfigs[i]
+		uniConfigRPC, err := MarshalAssetFedSyncCfg(*uniSyncConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal universe "+
+				"specific federation sync config: %w", err)
+		}
+		uniConfigRPCs[i] = uniConfigRPC
+	}
+
+	return &unirpc.QueryFederationSyncConfigResponse{
+		GlobalSyncConfigs: globalConfigRPC,
+		AssetSyncConfigs:  uniConfigRPCs,
+	}, nil
+}
+
+// marshalMetaUpdate marshals a signed metadata update into its RPC
+// counterpart.
+func marshalMetaUpdate(update *proof.MetaUpdate) *unirpc.MetaUpdate {
+	metaHash := update.Meta.MetaHash()
+
+	return &unirpc.MetaUpdate{
+		GroupKey: update.GroupKey.SerializeCompressed(),
+		Version:  update.Version,
+		Meta: &taprpc.AssetMeta{
+			Data:     update.Meta.Data,
+			Type:     taprpc.AssetMetaType(update.Meta.Type),
+			MetaHash: metaHash[:],
+		},
+		SignerKey: update.SignerKey.SerializeCompressed(),
+		Signature: update.Signature.Serialize(),
+	}
+}
+
+// unmarshalMetaReveal parses the RPC asset meta into a meta reveal.
+func unmarshalMetaReveal(rpcMeta *taprpc.AssetMeta) (*proof.MetaReveal,
+	error) {
+
+	if rpcMeta == nil {
+		return nil, fmt.Errorf("asset meta must be set")
+	}
+
+	metaType, err := proof.IsValidMetaType(rpcMeta.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &proof.MetaReveal{
+		Type: metaType,
+		Data: rpcMeta.Data,
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// unmarshalMetaUpdate parses a signed metadata update from its RPC
+// counterpart. The signature is not verified.
+func unmarshalMetaUpdate(rpcUpdate *unirpc.MetaUpdate) (*proof.MetaUpdate,
+	error) {
+
+	if rpcUpdate == nil {
+		return nil, fmt.Errorf("meta update must be set")
+	}
+
+	groupKey, err := btcec.ParsePubKey(rpcUpdate.GroupKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key: %w", err)
+	}
+
+	meta, err := unmarshalMetaReveal(rpcUpdate.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	signerKey, err := btcec.ParsePubKey(rpcUpdate.SignerKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer key: %w", err)
+	}
+
+	sig, err := schnorr.ParseSignature(rpcUpdate.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return &proof.MetaUpdate{
+		GroupKey:  groupKey,
+		Version:   rpcUpdate.Version,
+		Meta:      meta,
+		SignerKey: signerKey,
+		Signature: sig,
+	}, nil
+}
+
+// PublishMetaUpdate creates a new metadata update for an asset group issued
+// by this node. The update is signed with the raw group key held by the
+// node's wallet and stored in the local universe, from where it is served to
+// clients.
+func (r *rpcServer) PublishMetaUpdate(ctx context.Context,
+	req *unirpc.PublishMetaUpdateRequest) (
+	*unirpc.PublishMetaUpdateResponse, error) {
+
+	groupKey, err := btcec.ParsePubKey(req.GroupKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key: %w", err)
+	}
+
+	meta, err := unmarshalMetaReveal(req.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("invalid asset meta: %w", err)
+	}
+
+	assetGroup, err := r.cfg.MintingStore.FetchGroupByGroupKey(
+		ctx, groupKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch asset group: %w", err)
+	}
+
+	// Only the issuer of the group holds the raw group key, so we can't
+	// publish updates for groups issued by someone else.
+	rawKey := assetGroup.GroupKey.RawKey
+	if !r.cfg.AddrBook.IsLocalKey(ctx, rawKey) {
+		return nil, fmt.Errorf("asset group %x was not issued by this "+
+			"node", groupKey.SerializeCompressed())
+	}
+
+	// Each update must have a higher version than the previous one.
+	latestVersion, found, err := r.cfg.MetaUpdates.LatestVersion(
+		ctx, groupKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch latest meta update: "+
+			"%w", err)
+	}
+
+	update := &proof.MetaUpdate{
+		GroupKey:  groupKey,
+		Version:   1,
+		Meta:      meta,
+		SignerKey: rawKey.PubKey,
+	}
+	if found {
+		update.Version = latestVersion + 1
+	}
+
+	sigMsg, err := update.SigMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytes, err := r.cfg.SignerLnd.Signer.SignMessage(
+		ctx, sigMsg, rawKey.KeyLocator, lndclient.SignSchnorr(nil),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign meta update: %w", err)
+	}
+
+	update.Signature, err = schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse meta update "+
+			"signature: %w", err)
+	}
+
+	// As a sanity check, we make sure clients will be able to verify the
+	// update before we publish it.
+	if err := update.Verify(assetGroup.GroupKey); err != nil {
+		return nil, fmt.Errorf("unable to verify meta update: %w", err)
+	}
+
+	if err := r.cfg.MetaUpdates.InsertMetaUpdate(ctx, update); err != nil {
+		return nil, fmt.Errorf("unable to store meta update: %w", err)
+	}
+
+	return &unirpc.PublishMetaUpdateResponse{
+		Update: marshalMetaUpdate(update),
+	}, nil
+}
+
+// verifyAndStoreMetaUpdate verifies that the given metadata update was signed
+// by the issuer of its asset group and stores it.
+func (r *rpcServer) verifyAndStoreMetaUpdate(ctx context.Context,
+	update *proof.MetaUpdate) error {
+
+	assetGroup, err := r.cfg.MintingStore.FetchGroupByGroupKey(
+		ctx, update.GroupKey,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to fetch asset group: %w", err)
+	}
+
+	if err := update.Verify(assetGroup.GroupKey); err != nil {
+		return fmt.Errorf("invalid meta update: %w", err)
+	}
+
+	return r.cfg.MetaUpdates.InsertMetaUpdate(ctx, update)
+}
+
+// InsertMetaUpdate inserts a metadata update that was signed by the issuer of
+// an asset group into the local universe. The signature is verified against
+// the known group key, and only updates with a version higher than the latest
+// known version are accepted.
+func (r *rpcServer) InsertMetaUpdate(ctx context.Context,
+	req *unirpc.InsertMetaUpdateRequest) (*unirpc.InsertMetaUpdateResponse,
+	error) {
+
+	update, err := unmarshalMetaUpdate(req.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.verifyAndStoreMetaUpdate(ctx, update); err != nil {
+		return nil, err
+	}
+
+	return &unirpc.InsertMetaUpdateResponse{}, nil
+}
+
+// SyncMetaUpdates fetches the metadata updates of an asset group from a
+// remote universe server. Each update is verified against the locally known
+// group key, and the valid ones that are newer than the latest locally known
+// update are stored.
+func (r *rpcServer) SyncMetaUpdates(ctx context.Context,
+	req *unirpc.SyncMetaUpdatesRequest) (*unirpc.SyncMetaUpdatesResponse,
+	error) {
+
+	groupKey, err := btcec.ParsePubKey(req.GroupKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key: %w", err)
+	}
+
+	if req.UniverseHost == "" {
+		return nil, fmt.Errorf("universe host must be set")
+	}
+
+	conn, err := ConnectUniverse(universe.NewServerAddrFromStr(
+		req.UniverseHost,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to universe %v: %w",
+			req.UniverseHost, err)
+	}
+	defer conn.Close()
+
+	resp, err := conn.QueryMetaUpdates(ctx, &unirpc.QueryMetaUpdatesRequest{
+		Group: &unirpc.QueryMetaUpdatesRequest_GroupKey{
+			GroupKey: req.GroupKey,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query meta updates from "+
+			"universe %v: %w", req.UniverseHost, err)
+	}
+
+	latestVersion, found, err := r.cfg.MetaUpdates.LatestVersion(
+		ctx, groupKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch latest meta update: "+
+			"%w", err)
+	}
+
+	// The updates are ordered from the latest to the oldest version, but
+	// we need to insert them in ascending order.
+	var newUpdates []*unirpc.MetaUpdate
+	for i := len(resp.Updates) - 1; i >= 0; i-- {
+		update, err := unmarshalMetaUpdate(resp.Updates[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid meta update from "+
+				"universe %v: %w", req.UniverseHost, err)
+		}
+
+		if !update.GroupKey.IsEqual(groupKey) {
+			return nil, fmt.Errorf("universe %v returned meta "+
+				"update for wrong group", req.UniverseHost)
+		}
+
+		if found && update.Version <= latestVersion {
+			continue
+		}
+
+		if err := r.verifyAndStoreMetaUpdate(ctx, update); err != nil {
+			return nil, fmt.Errorf("meta update from universe %v "+
+				"rejected: %w", req.UniverseHost, err)
+		}
+
+		latestVersion, found = update.Version, true
+		newUpdates = append(newUpdates, marshalMetaUpdate(update))
+	}
+
+	return &unirpc.SyncMetaUpdatesResponse{
+		NewUpdates: newUpdates,
+	}, nil
+}
+
+// QueryMetaUpdates returns the signed metadata updates known for an asset
+// group, ordered from the latest to the oldest version.
+func (r *rpcServer) QueryMetaUpdates(ctx context.Context,
+	req *unirpc.QueryMetaUpdatesRequest) (*unirpc.QueryMetaUpdatesResponse,
+	error) {
+
+	var groupKeyBytes []byte
+	switch {
+	case len(req.GetGroupKey()) > 0:
+		groupKeyBytes = req.GetGroupKey()
+
+	case len(req.GetGroupKeyStr()) > 0:
+		var err error
+		groupKeyBytes, err = hex.DecodeString(req.GetGroupKeyStr())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode group key: "+
+				"%w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("group key must be set")
+	}
+
+	groupKey, err := btcec.ParsePubKey(groupKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group key: %w", err)
+	}
+
+	updates, err := r.cfg.MetaUpdates.FetchMetaUpdates(
+		ctx, groupKey, req.LatestOnly,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch meta updates: %w", err)
+	}
+
+	return &unirpc.QueryMetaUpdatesResponse{
+		Updates: fn.Map(updates, marshalMetaUpdate),
+	}, nil
+}
+
+// ProveAssetOwnership creates an ownership proof embedded in an asset
+// transition proof. That ownership proof is a signed virtual transaction
+// spending the asset with a valid witness to prove the prover owns the keys
+// that can spend the asset.
+func (r *rpcServer) ProveAssetOwnership(ctx context.Context,
+	req *wrpc.ProveAssetOwnershipRequest) (*wrpc.ProveAssetOwnershipResponse,
+	error) {
+
+	if len(req.ScriptKey) == 0 {
+		return nil, fmt.Errorf("a valid script key must be specified")
+	}
+
+	scriptKey, err := parseUserKey(req.ScriptKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script key: %w", err)
+	}
+
+	if len(req.AssetId) != 32 {
+		return nil, fmt.Errorf("asset ID must be 32 bytes")
+	}
+
+	if len(req.Challenge) != 0 && len(req.Challenge) != 32 {
+		return nil, fmt.Errorf("challenge must be 32 bytes")
+	}
+
+	var (
+		assetID  = fn.ToArray[asset.ID](req.AssetId)
+		outPoint *wire.OutPoint
+	)
+
+	// The outpoint is optional when querying for a proof file. But if
+	// multiple proofs exist for the same assetID and script key, then an
+	// error will be returned and the outpoint needs to be specified to
+	// disambiguate.
+	if req.Outpoint != nil {
+		txid, err := chainhash.NewHash(req.Outpoint.Txid)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing outpoint: %w",
+				err)
+		}
+		outPoint = &wire.OutPoint{
+			Hash:  *txid,
+			Index: req.Outpoint.OutputIndex,
+		}
+	}
+
+	proofBlob, err := r.cfg.ProofArchive.FetchProof(ctx, proof.Locator{
+		AssetID:   &assetID,
+		ScriptKey: *scriptKey,
+		OutPoint:  outPoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch proof: %w", err)
+	}
+
+	proofFile, err := proof.DecodeFile(proofBlob)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode proof: %w", err)
+	}
+
+	headerVerifier := tapgarden.GenHeaderVerifier(ctx, r.cfg.ChainBridge)
+	groupVerifier := tapgarden.GenGroupVerifier(ctx, r.cfg.MintingStore)
+	lastSnapshot, err := proofFile.Verify(
+		ctx, headerVerifier, proof.DefaultMerkleVerifier, groupVerifier,
+		r.cfg.ChainBridge.GenFileChainLookup(proofFile),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify proof: %w", err)
+	}
+
+	inputAsset := lastSnapshot.Asset
+	inputCommitment, err := r.cfg.AssetStore.FetchCommitment(
+		ctx, inputAsset.ID(), lastSnapshot.OutPoint,
+		inputAsset.GroupKey, &inputAsset.ScriptKey, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching commitment: %w", err)
+	}
+
+	var challengeOpt fn.Option[[32]byte]
+
+	if len(req.Challenge) == 32 {
+		var bCopy [32]byte
+		copy(bCopy[:], req.Challenge[:32])
+		challengeOpt = fn.Some[[32]byte](bCopy)
+	}
+
+	challengeWitness, err := r.cfg.AssetWallet.SignOwnershipProof(
+		inputCommitment.Asset.Copy(), challengeOpt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error signing ownership proof: %w", err)
+	}
+
+	lastProof, err := proofFile.LastProof()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching last proof: %w", err)
+	}
+
+	lastProof.ChallengeWitness = challengeWitness
+
+	var buf bytes.Buffer
+	if err := lastProof.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("error encoding proof file: %w", err)
+	}
+
+	return &wrpc.ProveAssetOwnershipResponse{
+		ProofWithWitness: buf.Bytes(),
+	}, nil
+}
+
+// VerifyAssetOwnership verifies the asset ownership proof embedded in the
+// given transition proof of an asset and returns true if the proof is valid.
+func (r *rpcServer) VerifyAssetOwnership(ctx context.Context,
+	req *wrpc.VerifyAssetOwnershipRequest) (*wrpc.VerifyAssetOwnershipResponse,
+	error) {
+
+	if len(req.ProofWithWitness) == 0 {
+		return nil, fmt.Errorf("a valid proof must be specified")
+	}
+
+	if len(req.Challenge) != 0 && len(req.Challenge) != 32 {
+		return nil, fmt.Errorf("challenge must be 32 bytes")
+	}
+
+	p, err := proof.Decode(req.ProofWithWitness)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode proof file: %w", err)
+	}
+
+	lookup, err := r.cfg.ChainBridge.GenProofChainLookup(p)
+	if err != nil {
+		return nil, fmt.Errorf("error generating proof chain lookup: "+
+			"%w", err)
+	}
+
+	headerVerifier := tapgarden.GenHeaderVerifier(ctx, r.cfg.ChainBridge)
+	groupVerifier := tapgarden.GenGroupVerifier(ctx, r.cfg.MintingStore)
+
+	var (
+		challengeBytes [32]byte
+		opts           []proof.ProofVerificationOption
+	)
+
+	if len(req.Challenge) == 32 {
+		copy(challengeBytes[:], req.Challenge[:32])
+		opts = append(opts, proof.WithChallengeBytes(challengeBytes))
+	}
+
+	snapShot, err := p.Verify(
+		ctx, nil, headerVerifier, proof.DefaultMerkleVerifier,
+		groupVerifier, lookup, opts...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying proof: %w", err)
+	}
+
+	return &wrpc.VerifyAssetOwnershipResponse{
+		ValidProof: true,
+		Outpoint: &taprpc.OutPoint{
+			Txid:        snapShot.OutPoint.Hash[:],
+			OutputIndex: snapShot.OutPoint.Index,
+		},
+		OutpointStr:  snapShot.OutPoint.String(),
+		BlockHash:    snapShot.AnchorBlockHash[:],
+		BlockHashStr: snapShot.AnchorBlockHash.String(),
+		BlockHeight:  snapShot.AnchorBlockHeight,
+	}, nil
+}
+
+// UniverseStats returns a set of aggregate statistics for the current state
+// of the Universe.
+func (r *rpcServer) UniverseStats(ctx context.Context,
+	_ *unirpc.StatsRequest) (*unirpc.StatsResponse, error) {
+
+	universeStats, err := r.cfg.UniverseStats.AggregateSyncStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unirpc.StatsResponse{
+		NumTotalAssets: int64(universeStats.NumTotalAssets),
+		NumTotalGroups: int64(universeStats.NumTotalGroups),
+		NumTotalSyncs:  int64(universeStats.NumTotalSyncs),
+		NumTotalProofs: int64(universeStats.NumTotalProofs),
+	}, nil
+}
+
+// marshalAssetSyncSnapshot maps a universe asset sync stat snapshot to the RPC
+// counterpart.
+func (r *rpcServer) marshalAssetSyncSnapshot(ctx context.Context,
+	a universe.AssetSyncSnapshot) *unirpc.AssetStatsSnapshot {
+
+	resp := &unirpc.AssetStatsSnapshot{
+		TotalSyncs:  int64(a.TotalSyncs),
+		TotalProofs: int64(a.TotalProofs),
+		GroupSupply: int64(a.GroupSupply),
+	}
+	rpcAsset := &unirpc.AssetStatsAsset{
+		AssetId:       a.AssetID[:],
+		GenesisPoint:  a.GenesisPoint.String(),
+		AssetName:     a.AssetName,
+		AssetType:     taprpc.AssetType(a.AssetType),
+		TotalSupply:   int64(a.TotalSupply),
+		GenesisHeight: int32(a.GenesisHeight),
+		GenesisTimestamp: r.cfg.ChainBridge.GetBlockTimestamp(
+			ctx, a.GenesisHeight,
+		),
+		AnchorPoint: a.AnchorPoint.String(),
+	}
+
+	if a.GroupKey != nil {
+		resp.GroupKey = a.GroupKey.SerializeCompressed()
+		resp.GroupAnchor = rpcAsset
+	} else {
+		resp.Asset = rpcAsset
+	}
+
+	return resp
+}
+
+// QueryAssetStats returns a set of statistics for a given set of assets.
+// Stats can be queried for all assets, or based on the: asset ID, name, or
+// asset type. Pagination is supported via the offset and limit params.
+// Results can also be sorted based on any of the main query params.
+func (r *rpcServer) QueryAssetStats(ctx context.Context,
+	req *unirpc.AssetStatsQuery) (*unirpc.UniverseAssetStats, error) {
+
+	assetStats, err := r.cfg.UniverseStats.QuerySyncStats(
+		ctx, universe.SyncStatsQuery{
+			AssetNameFilter: req.AssetNameFilter,
+			AssetTypeFilter: func() *asset.Type {
+				switch req.AssetTypeFilter {
+				case unirpc.AssetTypeFilter_FILTER_ASSET_NORMAL:
+					return fn.Ptr(asset.Normal)
+
+				case unirpc.AssetTypeFilter_FILTER_ASSET_COLLECTIBLE:
+					return fn.Ptr(asset.Collectible)
+
+				default:
+					return nil
+				}
+			}(),
+			AssetIDFilter: fn.ToArray[asset.ID](
+				req.AssetIdFilter,
+			),
+			SortBy:        universe.SyncStatsSort(req.SortBy),
+			SortDirection: universe.SortDirection(req.Direction),
+			Offset:        int(req.Offset),
+			Limit:         int(req.Limit),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &unirpc.UniverseAssetStats{
+		AssetStats: make(
+			[]*unirpc.AssetStatsSnapshot, len(assetStats.SyncStats),
+		),
+	}
+	for idx, snapshot := range assetStats.SyncStats {
+		resp.AssetStats[idx] = r.marshalAssetSyncSnapshot(ctx, snapshot)
+	}
+
+	return resp, nil
+}
+
+// QueryEvents returns the number of sync and proof events for a given time
+// period, grouped by day.
+func (r *rpcServer) QueryEvents(ctx context.Context,
+	req *unirpc.QueryEventsRequest) (*unirpc.QueryEventsResponse, error) {
+
+	statsQuery, err := parseStatsTimeRange(
+		req.StartTimestamp, req.EndTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.cfg.UniverseStats.QueryAssetStatsPerDay(
+		ctx, statsQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stats: %w", err)
+	}
+
+	rpcStats := &unirpc.QueryEventsResponse{
+		Events: make([]*unirpc.GroupedUniverseEvents, len(stats)),
+	}
+	for day, s := range stats {
+		rpcStats.Events[day] = &unirpc.GroupedUniverseEvents{
+			Date:           s.Date,
+			SyncEvents:     s.NumTotalSyncs,
+			NewProofEvents: s.NumTotalProofs,
+		}
+	}
+
+	return rpcStats, nil
+}
+
+// parseStatsTimeRange parses the time range of a stats query. If no start or
+// end time is specified, the range defaults to the last 30 days.
+func parseStatsTimeRange(startTimestamp,
+	endTimestamp int64) (universe.GroupedStatsQuery, error) {
+
+	var (
+		startTime = time.Now().AddDate(0, 0, -30)
+		endTime   = time.Now()
+	)
+	if startTimestamp > 0 {
+		startTime = time.Unix(startTimestamp, 0)
+	}
+	if endTimestamp > 0 {
+		endTime = time.Unix(endTimestamp, 0)
+	}
+
+	if endTime.Before(startTime) {
+		return universe.GroupedStatsQuery{}, fmt.Errorf("end time " +
+			"cannot be before start time")
+	}
+
+	return universe.GroupedStatsQuery{
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, nil
+}
+
+// QueryDailyStats returns the number of new assets and ingested proofs of the
+// local Universe for a given time period, grouped by day.
+func (r *rpcServer) QueryDailyStats(ctx context.Context,
+	req *unirpc.QueryDailyStatsRequest) (*unirpc.QueryDailyStatsResponse,
+	error) {
+
+	statsQuery, err := parseStatsTimeRange(
+		req.StartTimestamp, req.EndTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.cfg.UniverseStats.QueryDailyStats(ctx, statsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("error querying daily stats: %w", err)
+	}
+
+	resp := &unirpc.QueryDailyStatsResponse{
+		Days: make([]*unirpc.DailyUniverseStats, len(stats)),
+	}
+	for idx, s := range stats {
+		resp.Days[idx] = &unirpc.DailyUniverseStats{
+			Date:      s.Date,
+			NewAssets: s.NumNewAssets,
+			NewProofs: s.NumNewProofs,
+		}
+	}
+
+	return resp, nil
+}
+
+// QueryServerSyncStats returns the sync volume with each server of the
+// federation of the local Universe for a given time period, grouped by day.
+func (r *rpcServer) QueryServerSyncStats(ctx context.Context,
+	req *unirpc.QueryServerSyncStatsRequest) (
+	*unirpc.QueryServerSyncStatsResponse, error) {
+
+	statsQuery, err := parseStatsTimeRange(
+		req.StartTimestamp, req.EndTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	query := universe.ServerSyncStatsQuery{
+		GroupedStatsQuery: statsQuery,
+	}
+	if req.ServerHost != "" {
+		query.ServerHost = fn.Some(req.ServerHost)
+	}
+
+	stats, err := r.cfg.FederationDB.QueryServerSyncStats(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying server sync stats: %w",
+			err)
+	}
+
+	resp := &unirpc.QueryServerSyncStatsResponse{
+		Stats: make([]*unirpc.ServerSyncStats, len(stats)),
+	}
+	for idx, s := range stats {
+		resp.Stats[idx] = &unirpc.ServerSyncStats{
+			ServerHost:      s.ServerHost,
+			Date:            s.Date,
+			NumSyncs:        s.NumSyncs,
+			NumSyncedLeaves: s.NumSyncedLeaves,
+		}
+	}
+
+	return resp, nil
+}
+
+// QueryTopTransferUniverses returns the transfer universes with the most
+// transfer proofs in the local Universe, most transfers first.
+func (r *rpcServer) QueryTopTransferUniverses(ctx context.Context,
+	req *unirpc.QueryTopTransferUniversesRequest) (
+	*unirpc.QueryTopTransferUniversesResponse, error) {
+
+	limit := int(req.Limit)
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("limit must be non-negative")
+
+	case limit == 0:
+		limit = defaultTopTransferUniverses
+
+	case limit > maxTopTransferUniverses:
+		limit = maxTopTransferUniverses
+	}
+
+	stats, err := r.cfg.UniverseStats.QueryTopTransferUniverses(
+		ctx, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying transfer stats: %w", err)
+	}
+
+	resp := &unirpc.QueryTopTransferUniversesResponse{
+		Universes: make([]*unirpc.TransferUniverseStats, len(stats)),
+	}
+	for idx, s := range stats {
+		uniID, err := MarshalUniID(s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Universes[idx] = &unirpc.TransferUniverseStats{
+			Id:           uniID,
+			NumTransfers: s.NumTransfers,
+		}
+	}
+
+	return resp, nil
+}
+
+// RemoveUTXOLease removes the lease/lock/reservation of the given managed
+// UTXO.
+func (r *rpcServer) RemoveUTXOLease(ctx context.Context,
+	req *wrpc.RemoveUTXOLeaseRequest) (*wrpc.RemoveUTXOLeaseResponse,
+	error) {
+
+	if req.Outpoint == nil {
+		return nil, fmt.Errorf("outpoint must be specified")
+	}
+
+	hash, err := chainhash.NewHash(req.Outpoint.Txid)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing txid: %w", err)
+	}
+
+	outPoint := wire.OutPoint{
+		Hash:  *hash,
+		Index: req.Outpoint.OutputIndex,
+	}
+
+	err = r.cfg.CoinSelect.ReleaseCoins(ctx, outPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrpc.RemoveUTXOLeaseResponse{}, nil
+}
+
+// MarshalAssetFedSyncCfg returns an RPC ready asset specific federation sync
+// config.
+func MarshalAssetFedSyncCfg(
+	config universe.FedUniSyncConfig) (*unirpc.AssetFederationSyncConfig,
+	error) {
+
+	// Marshal universe ID into the RPC form.
+	uniID := config.UniverseID
+	assetIDBytes := uniID.AssetID[:]
+
+	var groupKeyBytes []byte
+	if uniID.GroupKey != nil {
+		groupKeyBytes = uniID.GroupKey.SerializeCompressed()
+	}
+	uniIdRPC := unirpc.MarshalUniverseID(assetIDBytes, groupKeyBytes)
+
+	// Marshal proof type.
+	proofTypeRpc, err := MarshalUniProofType(uniID.ProofType)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal proof type: %w", err)
+	}
+	uniIdRPC.ProofType = proofTypeRpc
+
+	return &unirpc.AssetFederationSyncConfig{
+		Id:              uniIdRPC,
+		AllowSyncInsert: config.AllowSyncInsert,
+		AllowSyncExport: config.AllowSyncExport,
+	}, nil
+}
+
+// unmarshalAssetSpecifier unmarshals an asset specifier from the RPC form.
+func unmarshalAssetSpecifier(req *rfqrpc.AssetSpecifier) (*asset.ID,
+	*btcec.PublicKey, error) {
+
+	// Attempt to decode the asset specifier from the RPC request. In cases
+	// where both the asset ID and asset group key are provided, we will
+	// give precedence to the asset ID due to its higher level of
+	// specificity.
+	var (
+		assetID *asset.ID
+
+		groupKeyBytes []byte
+		groupKey      *btcec.PublicKey
+
+		err error
+	)
+
+	switch {
+	// Parse the asset ID if it's set.
+	case len(req.GetAssetId()) > 0:
+		var assetIdBytes [32]byte
+		copy(assetIdBytes[:], req.GetAssetId())
+		id := asset.ID(assetIdBytes)
+		assetID = &id
+
+	case len(req.GetAssetIdStr()) > 0:
+		assetIDBytes, err := hex.DecodeString(req.GetAssetIdStr())
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding asset "+
+				"ID: %w", err)
+		}
+
+		var id asset.ID
+		copy(id[:], assetIDBytes)
+		assetID = &id
+
+	// Parse the group key if it's set.
+	case len(req.GetGroupKey()) > 0:
+		groupKeyBytes = req.GetGroupKey()
+		groupKey, err = btcec.ParsePubKey(groupKeyBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing group "+
+				"key: %w", err)
+		}
+
+	case len(req.GetGroupKeyStr()) > 0:
+		groupKeyBytes, err := hex.DecodeString(
+			req.GetGroupKeyStr(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding group "+
+				"key: %w", err)
+		}
+
+		groupKey, err = btcec.ParsePubKey(groupKeyBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing group "+
+				"key: %w", err)
+		}
+
+	default:
+		// At this point, we know that neither the asset ID nor the
+		// group key are specified. Return an error.
+		return nil, nil, fmt.Errorf("either asset ID or asset group " +
+			"key must be specified")
+	}
+
+	return assetID, groupKey, nil
+}
+
+// unmarshalAssetBuyOrder unmarshals an asset buy order from the RPC form.
+func unmarshalAssetBuyOrder(
+	req *rfqrpc.AddAssetBuyOrderRequest) (*rfq.BuyOrder, error) {
+
+	assetId, assetGroupKey, err := unmarshalAssetSpecifier(
+		req.AssetSpecifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
+			"%w", err)
+	}
+
+	// Unmarshal the peer if specified.
+	var peer *route.Vertex
+	if len(req.PeerPubKey) > 0 {
+		pv, err := route.NewVertexFromBytes(req.PeerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling peer "+
+				"route vertex: %w", err)
+		}
+
+		peer = &pv
+	}
+
+	// Construct an asset specifier from the asset ID and/or group key.
+	assetSpecifier, err := asset.NewSpecifier(
+		assetId, assetGroupKey, nil, true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating asset specifier: %w",
+			err)
+	}
+
+	// Convert expiry unix timestamp in seconds to time.Time.
+	if req.Expiry > math.MaxInt64 {
+		return nil, fmt.Errorf("expiry must be less than or equal to "+
+			"math.MaxInt64 (expiry=%d)", req.Expiry)
+	}
+	expiry := time.Unix(int64(req.Expiry), 0).UTC()
+
+	return &rfq.BuyOrder{
+		AssetSpecifier: assetSpecifier,
+		AssetMaxAmt:    req.AssetMaxAmt,
+		Expiry:         expiry,
+		Peer:           fn.MaybeSome(peer),
+	}, nil
+}
+
+// AddAssetBuyOrder upserts a new buy order for the given asset into the RFQ
+// manager. If the order already exists for the given asset, it will be updated.
+func (r *rpcServer) AddAssetBuyOrder(ctx context.Context,
+	req *rfqrpc.AddAssetBuyOrderRequest) (*rfqrpc.AddAssetBuyOrderResponse,
+	error) {
+
+	if req.TimeoutSeconds == 0 {
+		return nil, fmt.Errorf("timeout must be greater than 0")
+	}
+
+	// Unmarshal the buy order from the RPC form.
+	buyOrder, err := unmarshalAssetBuyOrder(req)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling buy order: %w", err)
+	}
+
+	// Currently, we require the peer to be specified in the buy order.
+	peer, err := buyOrder.Peer.UnwrapOrErr(
+		fmt.Errorf("buy order peer must be specified"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if we have a channel with the peer.
+	err = r.checkPeerChannel(
+		ctx, peer, buyOrder.AssetSpecifier, req.SkipAssetChannelCheck,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error checking peer channel: %w", err)
+	}
+
+	rpcsLog.Debugf("[AddAssetBuyOrder]: upserting buy order "+
+		"(dest_peer=%s)", peer.String())
+
+	// Register an event listener before actually inserting the order, so we
+	// definitely don't miss any responses.
+	eventSubscriber := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
+	defer eventSubscriber.Stop()
+
+	err = r.cfg.RfqManager.RegisterSubscriber(eventSubscriber, false, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register event listener: %w",
+			err)
+	}
+
+	defer func() {
+		_ = r.cfg.RfqManager.RemoveSubscriber(eventSubscriber)
+	}()
+
+	// Upsert the buy order into the RFQ manager.
+	err = r.cfg.RfqManager.UpsertAssetBuyOrder(*buyOrder)
+	if err != nil {
+		return nil, fmt.Errorf("error upserting buy order into RFQ "+
+			"manager: %w", err)
+	}
+
+	timeout := time.After(time.Second * time.Duration(req.TimeoutSeconds))
+
+	for {
+		select {
+		case event := <-eventSubscriber.NewItemCreated.ChanOut():
+			resp, err := taprpc.NewAddAssetBuyOrderResponse(event)
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling "+
+					"buy order response: %w", err)
+			}
+
+			return resp, nil
+
+		case <-r.quit:
+			return nil, fmt.Errorf("server shutting down")
+
+		case <-timeout:
+			return nil, fmt.Errorf("timeout waiting for response "+
+				"(peer=%s)", peer.String())
+		}
+	}
+}
+
+// checkPeerChannel checks if there is a channel with the given peer. If the
+// asset channel check is enabled, it will also check if there is a channel with
+// the given asset with the peer.
+func (r *rpcServer) checkPeerChannel(ctx context.Context, peer route.Vertex,
+	specifier asset.Specifier, skipAssetChannelCheck bool) error {
+
+	// We want to make sure there is at least a channel between us and the
+	// peer, otherwise RFQ negotiation doesn't make sense.
+	switch {
+	// For integration tests, we can't create asset channels, so we allow
+	// the asset channel check to be skipped. In this case we simply check
+	// that we have any channel with the peer.
+	case skipAssetChannelCheck:
+		activeChannels, err := r.cfg.Lnd.Client.ListChannels(
+			ctx, true, false,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to fetch channels: %w", err)
+		}
+		peerChannels := fn.Filter(
+			activeChannels, func(c lndclient.ChannelInfo) bool {
+				return c.PubKeyBytes == peer
+			},
+		)
+		if len(peerChannels) == 0 {
+			return fmt.Errorf("no active channel found with peer "+
+				"%x", peer[:])
+		}
+
+	// For any other case, we'll want to make sure there is a channel with
+	// a non-zero balance of the given asset to carry the order.
+	default:
+		assetID, err := specifier.UnwrapIdOrErr()
+		if err != nil {
+			return fmt.Errorf("cannot check asset channel, " +
+				"missing asset ID")
+		}
+
+		// If we don't get an error here, it means we do have an asset
+		// channel with the peer.
+		_, err = r.rfqChannel(ctx, assetID, &peer)
+		if err != nil {
+			return fmt.Errorf("error checking asset channel: %w",
+				err)
+		}
+	}
+
+	return nil
+}
+
+// unmarshalAssetSellOrder unmarshals an asset sell order from the RPC form.
+func unmarshalAssetSellOrder(
+	req *rfqrpc.AddAssetSellOrderRequest) (*rfq.SellOrder, error) {
+
+	assetId, assetGroupKey, err := unmarshalAssetSpecifier(
+		req.AssetSpecifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
+			"%w", err)
+	}
+
+	// Unmarshal the peer if specified.
+	var peer fn.Option[route.Vertex]
+	if len(req.PeerPubKey) > 0 {
+		pv, err := route.NewVertexFromBytes(req.PeerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling peer "+
+				"route vertex: %w", err)
+		}
+
+		peer = fn.Some(pv)
+	}
+
+	// Construct an asset specifier from the asset ID and/or group key.
+	assetSpecifier, err := asset.NewSpecifier(
+		assetId, assetGroupKey, nil, true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating asset specifier: %w",
+			err)
+	}
+
+	// Convert expiry unix timestamp in seconds to time.Time.
+	if req.Expiry > math.MaxInt64 {
+		return nil, fmt.Errorf("expiry must be less than or equal to "+
+			"math.MaxInt64 (expiry=%d)", req.Expiry)
+	}
+	expiry := time.Unix(int64(req.Expiry), 0).UTC()
+
+	return &rfq.SellOrder{
+		AssetSpecifier: assetSpecifier,
+		PaymentMaxAmt:  lnwire.MilliSatoshi(req.PaymentMaxAmt),
+		Expiry:         expiry,
+		Peer:           peer,
+	}, nil
+}
+
+// AddAssetSellOrder upserts a new sell order for the given asset into the RFQ
+// manager. If the order already exists for the given asset, it will be updated.
+func (r *rpcServer) AddAssetSellOrder(ctx context.Context,
+	req *rfqrpc.AddAssetSellOrderRequest) (*rfqrpc.AddAssetSellOrderResponse,
+	error) {
+
+	if req.TimeoutSeconds == 0 {
+		return nil, fmt.Errorf("timeout must be greater than 0")
+	}
+
+	// Unmarshal the order from the RPC form.
+	sellOrder, err := unmarshalAssetSellOrder(req)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling sell order: %w",
+			err)
+	}
+
+	// Currently, we require the peer to be specified in the buy order.
+	peer, err := sellOrder.Peer.UnwrapOrErr(
+		fmt.Errorf("sell order peer must be specified"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if we have a channel with the peer.
+	err = r.checkPeerChannel(
+		ctx, peer, sellOrder.AssetSpecifier, req.SkipAssetChannelCheck,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error checking peer channel: %w", err)
+	}
+
+	rpcsLog.Debugf("[AddAssetSellOrder]: upserting sell order "+
+		"(dest_peer=%s)", peer.String())
+
+	// Register an event listener before actually inserting the order, so we
+	// definitely don't miss any responses.
+	eventSubscriber := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
+	defer eventSubscriber.Stop()
+
+	err = r.cfg.RfqManager.RegisterSubscriber(eventSubscriber, false, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register event listener: %w",
+			err)
+	}
+
+	defer func() {
+		_ = r.cfg.RfqManager.RemoveSubscriber(eventSubscriber)
+	}()
+
+	// Upsert the order into the RFQ manager.
+	err = r.cfg.RfqManager.UpsertAssetSellOrder(*sellOrder)
+	if err != nil {
+		return nil, fmt.Errorf("error upserting sell order into RFQ "+
+			"manager: %w", err)
+	}
+
+	timeout := time.After(time.Second * time.Duration(req.TimeoutSeconds))
+
+	for {
+		select {
+		case event := <-eventSubscriber.NewItemCreated.ChanOut():
+			resp, err := taprpc.NewAddAssetSellOrderResponse(event)
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling "+
+					"sell order response: %w", err)
+			}
+
+			return resp, nil
+
+		case <-r.quit:
+			return nil, fmt.Errorf("server shutting down")
+
+		case <-timeout:
+			return nil, fmt.Errorf("timeout waiting for response "+
+				"from peer %s", peer.String())
+		}
+	}
+}
+
+// AddAssetSellOffer upserts a new sell offer for the given asset into the
+// RFQ manager. If the offer already exists for the given asset, it will be
+// updated.
+func (r *rpcServer) AddAssetSellOffer(_ context.Context,
+	req *rfqrpc.AddAssetSellOfferRequest) (*rfqrpc.AddAssetSellOfferResponse,
+	error) {
+
+	// Unmarshal the sell offer from the RPC form.
+	assetID, assetGroupKey, err := unmarshalAssetSpecifier(
+		req.AssetSpecifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
+			"%w", err)
+	}
+
+	sellOffer := &rfq.SellOffer{
+		AssetID:       assetID,
+		AssetGroupKey: assetGroupKey,
+		MaxUnits:      req.MaxUnits,
+	}
+
+	rpcsLog.Debugf("[AddAssetSellOffer]: upserting sell offer "+
+		"(sell_offer=%v)", sellOffer)
+
+	// Upsert the sell offer into the RFQ manager.
+	err = r.cfg.RfqManager.UpsertAssetSellOffer(*sellOffer)
+	if err != nil {
+		return nil, fmt.Errorf("error upserting sell offer into RFQ "+
+			"manager: %w", err)
+	}
+
+	return &rfqrpc.AddAssetSellOfferResponse{}, nil
+}
+
+// AddAssetBuyOffer upserts a new buy offer for the given asset into the RFQ
+// manager. If the offer already exists for the given asset, it will be updated.
+//
+// A buy offer is used by the node to selectively accept or reject incoming
+// asset sell quote requests before price is considered.
+func (r *rpcServer) AddAssetBuyOffer(_ context.Context,
+	req *rfqrpc.AddAssetBuyOfferRequest) (*rfqrpc.AddAssetBuyOfferResponse,
+	error) {
 
-	var (
-		groupVirtualTx *taprpc.GroupVirtualTx
-		groupReq       *taprpc.GroupKeyRequest
-		err            error
+	// Unmarshal the asset specifier from the RPC form.
+	assetID, assetGroupKey, err := unmarshalAssetSpecifier(
+		req.AssetSpecifier,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
+			"%w", err)
+	}
 
-	rpcSeedling, err := marshalSeedling(seedling.Seedling)
+	// Upsert the offer into the RFQ manager.
+	buyOffer := rfq.BuyOffer{
+		AssetID:       assetID,
+		AssetGroupKey: assetGroupKey,
+		MaxUnits:      req.MaxUnits,
+	}
+	rpcsLog.Debugf("[AddAssetBuyOffer]: upserting buy offer (buy_offer=%v)",
+		buyOffer)
+	err = r.cfg.RfqManager.UpsertAssetBuyOffer(buyOffer)
+	if err != nil {
+		return nil, fmt.Errorf("error upserting buy offer into RFQ "+
+			"manager: %w", err)
+	}
+
+	return &rfqrpc.AddAssetBuyOfferResponse{}, nil
+}
+
+// marshalPeerAcceptedBuyQuotes marshals a map of peer accepted asset buy quotes
+// into the RPC form. These are quotes that were requested by our node and have
+// been accepted by our peers.
+func marshalPeerAcceptedBuyQuotes(
+	quotes map[rfq.SerialisedScid]rfqmsg.BuyAccept) (
+	[]*rfqrpc.PeerAcceptedBuyQuote, error) {
+
+	// Marshal the accepted quotes into the RPC form.
+	rpcQuotes := make(
+		[]*rfqrpc.PeerAcceptedBuyQuote, 0, len(quotes),
+	)
+	for scid, quote := range quotes {
+		coefficient := quote.AssetRate.Rate.Coefficient.String()
+		rpcAskAssetRate := &rfqrpc.FixedPoint{
+			Coefficient: coefficient,
+			Scale:       uint32(quote.AssetRate.Rate.Scale),
+		}
+
+		rpcQuote := &rfqrpc.PeerAcceptedBuyQuote{
+			Peer:         quote.Peer.String(),
+			Id:           quote.ID[:],
+			Scid:         uint64(scid),
+			AssetAmount:  quote.Request.AssetMaxAmt,
+			AskAssetRate: rpcAskAssetRate,
+			Expiry:       uint64(quote.AssetRate.Expiry.Unix()),
+		}
+		rpcQuotes = append(rpcQuotes, rpcQuote)
+	}
+
+	return rpcQuotes, nil
+}
+
+// marshalPeerAcceptedSellQuotes marshals a map of peer accepted asset sell
+// quotes into the RPC form. These are quotes that were requested by our node
+// and have been accepted by our peers.
+//
+// nolint: lll
+func marshalPeerAcceptedSellQuotes(quotes map[rfq.SerialisedScid]rfqmsg.SellAccept) (
+	[]*rfqrpc.PeerAcceptedSellQuote, error) {
+
+	// Marshal the accepted quotes into the RPC form.
+	rpcQuotes := make([]*rfqrpc.PeerAcceptedSellQuote, 0, len(quotes))
+	for scid, quote := range quotes {
+		rpcAssetRate := &rfqrpc.FixedPoint{
+			Coefficient: quote.AssetRate.Rate.Coefficient.String(),
+			Scale:       uint32(quote.AssetRate.Rate.Scale),
+		}
+
+		// TODO(ffranr): Add SellRequest payment max amount to
+		//  PeerAcceptedSellQuote.
+		rpcQuote := &rfqrpc.PeerAcceptedSellQuote{
+			Peer:         quote.Peer.String(),
+			Id:           quote.ID[:],
+			Scid:         uint64(scid),
+			BidAssetRate: rpcAssetRate,
+			Expiry:       uint64(quote.AssetRate.Expiry.Unix()),
+		}
+		rpcQuotes = append(rpcQuotes, rpcQuote)
+	}
+
+	return rpcQuotes, nil
+}
+
+// QueryPeerAcceptedQuotes is used to query for quotes that were requested by
+// our node and have been accepted our peers.
+func (r *rpcServer) QueryPeerAcceptedQuotes(_ context.Context,
+	_ *rfqrpc.QueryPeerAcceptedQuotesRequest) (
+	*rfqrpc.QueryPeerAcceptedQuotesResponse, error) {
+
+	// Query the RFQ manager for quotes that were requested by our node and
+	// have been accepted by our peers.
+	peerAcceptedBuyQuotes := r.cfg.RfqManager.PeerAcceptedBuyQuotes()
+	peerAcceptedSellQuotes := r.cfg.RfqManager.PeerAcceptedSellQuotes()
+
+	rpcBuyQuotes, err := marshalPeerAcceptedBuyQuotes(peerAcceptedBuyQuotes)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling peer accepted buy "+
+			"quotes: %w", err)
+	}
+
+	rpcSellQuotes, err := marshalPeerAcceptedSellQuotes(
+		peerAcceptedSellQuotes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling peer accepted sell "+
+			"quotes: %w", err)
+	}
+
+	return &rfqrpc.QueryPeerAcceptedQuotesResponse{
+		BuyQuotes:  rpcBuyQuotes,
+		SellQuotes: rpcSellQuotes,
+	}, nil
+}
+
+// QueryPeerReputations queries the reputation scores and the underlying
+// metrics of the peers our node requested quotes from.
+func (r *rpcServer) QueryPeerReputations(ctx context.Context,
+	_ *rfqrpc.QueryPeerReputationsRequest) (
+	*rfqrpc.QueryPeerReputationsResponse, error) {
+
+	allStats, err := r.cfg.RfqManager.Reputation().AllPeerStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error querying peer stats: %w", err)
+	}
+
+	resp := &rfqrpc.QueryPeerReputationsResponse{
+		Peers: make([]*rfqrpc.PeerReputation, 0, len(allStats)),
+	}
+	for _, stats := range allStats {
+		var avgDeviation int64
+		if stats.RateSamples > 0 {
+			avgDeviation = stats.RateDeviationPpmSum /
+				int64(stats.RateSamples)
+		}
+
+		resp.Peers = append(resp.Peers, &rfqrpc.PeerReputation{
+			Peer:                stats.Peer.String(),
+			Score:               uint32(stats.Score()),
+			QuotesAccepted:      stats.QuotesAccepted,
+			QuotesRejected:      stats.QuotesRejected,
+			HtlcsSettled:        stats.HtlcsSettled,
+			HtlcsFailed:         stats.HtlcsFailed,
+			AvgRateDeviationPpm: avgDeviation,
+			UpdatedAt:           stats.UpdatedAt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+// QuerySettlementStats queries the settled asset HTLC volume of the RFQ order
+// handler, aggregated per asset, peer and day.
+func (r *rpcServer) QuerySettlementStats(ctx context.Context,
+	req *rfqrpc.QuerySettlementStatsRequest) (
+	*rfqrpc.QuerySettlementStatsResponse, error) {
+
+	query := rfq.SettlementStatsQuery{
+		StartDay: time.Unix(req.StartTime, 0),
+		EndDay:   time.Unix(req.EndTime, 0),
+	}
+
+	// Default to the last 30 days if no range is given.
+	if req.EndTime == 0 {
+		query.EndDay = time.Now()
+	}
+	if req.StartTime == 0 {
+		query.StartDay = query.EndDay.AddDate(0, 0, -30)
+	}
+	if query.StartDay.After(query.EndDay) {
+		return nil, fmt.Errorf("start time must not be after end time")
+	}
+
+	if req.AssetSpecifier != nil {
+		assetID, groupKey, err := unmarshalAssetSpecifier(
+			req.AssetSpecifier,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		specifier, err := asset.NewSpecifier(
+			assetID, groupKey, nil, true,
+		)
+		if err != nil {
+			return nil, err
+		}
+		query.AssetSpecifier = fn.Some(specifier)
+	}
+
+	if len(req.PeerPubKey) > 0 {
+		peer, err := route.NewVertexFromBytes(req.PeerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer public key: %w",
+				err)
+		}
+		query.Peer = fn.Some(peer)
+	}
+
+	allStats, err := r.cfg.RfqManager.SettlementStats(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying settlement stats: %w",
+			err)
+	}
+
+	resp := &rfqrpc.QuerySettlementStatsResponse{
+		Stats: make([]*rfqrpc.SettlementStats, 0, len(allStats)),
+	}
+	for _, stats := range allStats {
+		rpcStats := &rfqrpc.SettlementStats{
+			AssetSpecifier: marshalRfqAssetSpecifier(
+				stats.AssetSpecifier,
+			),
+			Peer:        stats.Peer.String(),
+			Day:         stats.Day.Unix(),
+			NumHtlcs:    stats.NumHtlcs,
+			AssetAmount: stats.AssetAmount,
+			AmountMsat:  uint64(stats.AmountMsat),
+		}
+		stats.AvgRate().WhenSome(func(rate rfqmath.BigIntFixedPoint) {
+			rpcStats.AvgRate = &rfqrpc.FixedPoint{
+				Coefficient: rate.Coefficient.String(),
+				Scale:       uint32(rate.Scale),
+			}
+		})
+
+		resp.Stats = append(resp.Stats, rpcStats)
+	}
+
+	return resp, nil
+}
+
+// QueryQuotes queries the active and historical quotes that were accepted by
+// our node or by our peers, together with the volume realized under them.
+func (r *rpcServer) QueryQuotes(ctx context.Context,
+	req *rfqrpc.QueryQuotesRequest) (*rfqrpc.QueryQuotesResponse, error) {
+
+	query, err := unmarshalQuoteFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset = req.Offset
+	query.Limit = req.Limit
+
+	quotes, err := r.cfg.RfqManager.QueryQuotes(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying quotes: %w", err)
+	}
+
+	now := time.Now()
+	resp := &rfqrpc.QueryQuotesResponse{
+		Quotes: make([]*rfqrpc.RecordedQuote, 0, len(quotes)),
+	}
+	for _, quote := range quotes {
+		direction := rfqrpc.QuoteDirection_QUOTE_DIRECTION_SELL
+		if quote.IsBuy {
+			direction = rfqrpc.QuoteDirection_QUOTE_DIRECTION_BUY
+		}
+
+		acceptor := rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_LOCAL
+		if quote.AcceptedByPeer {
+			acceptor = rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_PEER
+		}
+
+		state := rfqrpc.QuoteState_QUOTE_STATE_EXPIRED
+		if quote.State(now) == rfq.QuoteStateActive {
+			state = rfqrpc.QuoteState_QUOTE_STATE_ACTIVE
+		}
+
+		rate := quote.AssetRate.Rate
+		resp.Quotes = append(resp.Quotes, &rfqrpc.RecordedQuote{
+			Id:        quote.ID[:],
+			Peer:      quote.Peer.String(),
+			Direction: direction,
+			Acceptor:  acceptor,
+			AssetSpecifier: marshalRfqAssetSpecifier(
+				quote.AssetSpecifier,
+			),
+			AssetMaxAmount:    quote.AssetMaxAmt,
+			PaymentMaxAmtMsat: uint64(quote.PaymentMaxAmt),
+			Rate: &rfqrpc.FixedPoint{
+				Coefficient: rate.Coefficient.String(),
+				Scale:       uint32(rate.Scale),
+			},
+			Expiry:      quote.AssetRate.Expiry.Unix(),
+			AcceptedAt:  quote.AcceptedAt.Unix(),
+			State:       state,
+			NumHtlcs:    quote.NumHtlcs,
+			AssetAmount: quote.AssetAmount,
+			AmountMsat:  uint64(quote.AmountMsat),
+		})
+	}
+
+	return resp, nil
+}
+
+// QueryQuoteVolume queries the volume realized under the quotes matching a
+// filter, aggregated per asset.
+func (r *rpcServer) QueryQuoteVolume(ctx context.Context,
+	req *rfqrpc.QueryQuoteVolumeRequest) (*rfqrpc.QueryQuoteVolumeResponse,
+	error) {
+
+	query, err := unmarshalQuoteFilter(req.Filter)
 	if err != nil {
 		return nil, err
 	}
 
-	if verbose && seedling.PendingAssetGroup != nil {
-		groupVirtualTx, err = taprpc.MarshalGroupVirtualTx(
-			&seedling.PendingAssetGroup.GroupVirtualTx,
+	volumes, err := r.cfg.RfqManager.QuoteVolume(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying quote volume: %w", err)
+	}
+
+	resp := &rfqrpc.QueryQuoteVolumeResponse{
+		Volumes: make([]*rfqrpc.QuoteVolume, 0, len(volumes)),
+	}
+	for _, volume := range volumes {
+		rpcVolume := &rfqrpc.QuoteVolume{
+			AssetSpecifier: marshalRfqAssetSpecifier(
+				volume.AssetSpecifier,
+			),
+			NumQuotes:   volume.NumQuotes,
+			NumHtlcs:    volume.NumHtlcs,
+			AssetAmount: volume.AssetAmount,
+			AmountMsat:  uint64(volume.AmountMsat),
+		}
+		volume.AvgRate().WhenSome(func(rate rfqmath.BigIntFixedPoint) {
+			rpcVolume.AvgRate = &rfqrpc.FixedPoint{
+				Coefficient: rate.Coefficient.String(),
+				Scale:       uint32(rate.Scale),
+			}
+		})
+
+		resp.Volumes = append(resp.Volumes, rpcVolume)
+	}
+
+	return resp, nil
+}
+
+// unmarshalQuoteFilter unmarshals the RPC filter of a quote query. A nil
+// filter matches the quotes accepted in the last 30 days.
+func unmarshalQuoteFilter(filter *rfqrpc.QuoteFilter) (rfq.QuoteQuery, error) {
+	if filter == nil {
+		filter = &rfqrpc.QuoteFilter{}
+	}
+
+	query := rfq.QuoteQuery{
+		StartTime: time.Unix(filter.StartTime, 0),
+		EndTime:   time.Unix(filter.EndTime, 0),
+	}
+
+	// Default to the last 30 days if no range is given.
+	if filter.EndTime == 0 {
+		query.EndTime = time.Now()
+	}
+	if filter.StartTime == 0 {
+		query.StartTime = query.EndTime.AddDate(0, 0, -30)
+	}
+	if query.StartTime.After(query.EndTime) {
+		return query, fmt.Errorf("start time must not be after end " +
+			"time")
+	}
+
+	if filter.AssetSpecifier != nil {
+		assetID, groupKey, err := unmarshalAssetSpecifier(
+			filter.AssetSpecifier,
 		)
 		if err != nil {
-			return nil, err
+			return query, err
 		}
 
-		groupReq, err = taprpc.MarshalGroupKeyRequest(
-			&seedling.PendingAssetGroup.GroupKeyRequest,
+		specifier, err := asset.NewSpecifier(
+			assetID, groupKey, nil, true,
 		)
 		if err != nil {
-			return nil, err
+			return query, err
 		}
+		query.AssetSpecifier = fn.Some(specifier)
 	}
 
-	return &mintrpc.UnsealedAsset{
-		Asset:           rpcSeedling,
-		GroupVirtualTx:  groupVirtualTx,
-		GroupKeyRequest: groupReq,
-	}, nil
-}
-
-// marshalSeedlings marshals the seedlings into the RPC counterpart.
-func marshalSeedlings(
-	seedlings map[string]*tapgarden.Seedling) ([]*mintrpc.PendingAsset,
-	error) {
-
-	return fn.MapErr(maps.Values(seedlings), marshalSeedling)
-}
-
-// marshalUnsealedSeedlings marshals the unsealed seedlings into the RPC
-// counterpart.
-func marshalUnsealedSeedlings(verbose bool,
-	seedlings map[string]*tapgarden.UnsealedSeedling) (
-	[]*mintrpc.UnsealedAsset, error) {
-
-	rpcAssets := make([]*mintrpc.UnsealedAsset, 0, len(seedlings))
-	for _, seedling := range seedlings {
-		nextSeedling, err := marshalUnsealedSeedling(verbose, seedling)
+	if len(filter.PeerPubKey) > 0 {
+		peer, err := route.NewVertexFromBytes(filter.PeerPubKey)
 		if err != nil {
-			return nil, err
+			return query, fmt.Errorf("invalid peer public key: %w",
+				err)
 		}
-
-		rpcAssets = append(rpcAssets, nextSeedling)
+		query.Peer = fn.Some(peer)
 	}
 
-	return rpcAssets, nil
-}
+	switch filter.Direction {
+	case rfqrpc.QuoteDirection_QUOTE_DIRECTION_ANY:
+		// Quotes of both directions match.
 
-// marshalSprouts marshals the sprouts into the RPC counterpart.
-func marshalSprouts(sprouts []*asset.Asset,
-	metas tapgarden.AssetMetas) []*mintrpc.PendingAsset {
+	case rfqrpc.QuoteDirection_QUOTE_DIRECTION_BUY:
+		query.IsBuy = fn.Some(true)
 
-	rpcAssets := make([]*mintrpc.PendingAsset, 0, len(sprouts))
-	for _, sprout := range sprouts {
-		var (
-			groupKeyBytes      []byte
-			groupTapscriptRoot []byte
-			groupInternalKey   *taprpc.KeyDescriptor
-			assetMeta          *taprpc.AssetMeta
-		)
+	case rfqrpc.QuoteDirection_QUOTE_DIRECTION_SELL:
+		query.IsBuy = fn.Some(false)
 
-		if metas != nil {
-			serializedScriptKey := asset.ToSerialized(
-				sprout.ScriptKey.PubKey,
-			)
-			if m, ok := metas[serializedScriptKey]; ok && m != nil {
-				assetMeta = &taprpc.AssetMeta{
-					MetaHash: fn.ByteSlice(m.MetaHash()),
-					Data:     m.Data,
-					Type:     taprpc.AssetMetaType(m.Type),
-				}
-			}
-		}
+	default:
+		return query, fmt.Errorf("unknown quote direction: %v",
+			filter.Direction)
+	}
 
-		if sprout.GroupKey != nil {
-			grp := sprout.GroupKey
-			groupKeyBytes = grp.GroupPubKey.SerializeCompressed()
-			groupTapscriptRoot = grp.TapscriptRoot
-			groupInternalKey = taprpc.MarshalKeyDescriptor(
-				grp.RawKey,
-			)
-		}
+	switch filter.Acceptor {
+	case rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_ANY:
+		// Quotes accepted by any node match.
 
-		rpcAssets = append(rpcAssets, &mintrpc.PendingAsset{
-			AssetType:          taprpc.AssetType(sprout.Type),
-			Name:               sprout.Tag,
-			AssetMeta:          assetMeta,
-			Amount:             sprout.Amount,
-			GroupKey:           groupKeyBytes,
-			GroupInternalKey:   groupInternalKey,
-			GroupTapscriptRoot: groupTapscriptRoot,
-			ScriptKey: taprpc.MarshalScriptKey(
-				sprout.ScriptKey,
-			),
-		})
-	}
+	case rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_LOCAL:
+		query.AcceptedByPeer = fn.Some(false)
 
-	return rpcAssets
-}
+	case rfqrpc.QuoteAcceptor_QUOTE_ACCEPTOR_PEER:
+		query.AcceptedByPeer = fn.Some(true)
 
-// marshalBatchState converts the batch state field into its RPC counterpart.
-func marshalBatchState(state tapgarden.BatchState) (mintrpc.BatchState, error) {
-	switch state {
-	case tapgarden.BatchStatePending:
-		return mintrpc.BatchState_BATCH_STATE_PENDING, nil
+	default:
+		return query, fmt.Errorf("unknown quote acceptor: %v",
+			filter.Acceptor)
+	}
 
-	case tapgarden.BatchStateFrozen:
-		return mintrpc.BatchState_BATCH_STATE_FROZEN, nil
+	switch filter.State {
+	case rfqrpc.QuoteState_QUOTE_STATE_ANY:
+		// Quotes in any state match.
 
-	case tapgarden.BatchStateCommitted:
-		return mintrpc.BatchState_BATCH_STATE_COMMITTED, nil
+	case rfqrpc.QuoteState_QUOTE_STATE_ACTIVE:
+		query.State = fn.Some(rfq.QuoteStateActive)
 
-	case tapgarden.BatchStateBroadcast:
-		return mintrpc.BatchState_BATCH_STATE_BROADCAST, nil
+	case rfqrpc.QuoteState_QUOTE_STATE_EXPIRED:
+		query.State = fn.Some(rfq.QuoteStateExpired)
 
-	case tapgarden.BatchStateConfirmed:
-		return mintrpc.BatchState_BATCH_STATE_CONFIRMED, nil
+	default:
+		return query, fmt.Errorf("unknown quote state: %v",
+			filter.State)
+	}
 
-	case tapgarden.BatchStateFinalized:
-		return mintrpc.BatchState_BATCH_STATE_FINALIZED, nil
+	return query, nil
+}
 
-	case tapgarden.BatchStateSeedlingCancelled:
-		return mintrpc.BatchState_BATCH_STATE_SEEDLING_CANCELLED, nil
+// QueryInventoryRisk queries the current exposure of the node per asset from
+// the open quotes it accepted, the in-flight HTLCs and the asset channel
+// balances, compared against the configured inventory targets.
+func (r *rpcServer) QueryInventoryRisk(ctx context.Context,
+	_ *rfqrpc.QueryInventoryRiskRequest) (
+	*rfqrpc.QueryInventoryRiskResponse, error) {
 
-	case tapgarden.BatchStateSproutCancelled:
-		return mintrpc.BatchState_BATCH_STATE_SPROUT_CANCELLED, nil
+	report, err := r.cfg.RfqManager.InventoryReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating inventory report: %w",
+			err)
+	}
 
-	default:
-		return 0, fmt.Errorf("unknown batch state: %v", state)
+	resp := &rfqrpc.QueryInventoryRiskResponse{
+		Inventory: make([]*rfqrpc.AssetInventory, 0, len(report)),
+	}
+	for _, inventory := range report {
+		rpcInventory := &rfqrpc.AssetInventory{
+			AssetSpecifier: marshalRfqAssetSpecifier(
+				inventory.AssetSpecifier,
+			),
+			LocalBalance:          inventory.LocalBalance,
+			RemoteBalance:         inventory.RemoteBalance,
+			OpenSellUnits:         inventory.OpenSellUnits,
+			OpenBuyUnits:          inventory.OpenBuyUnits,
+			InFlightOutgoingUnits: inventory.InFlightOutgoingUnits,
+			InFlightIncomingUnits: inventory.InFlightIncomingUnits,
+			ProjectedBalance:      inventory.ProjectedBalance(),
+		}
+		inventory.Target.WhenSome(func(target uint64) {
+			rpcInventory.HasTarget = true
+			rpcInventory.TargetUnits = target
+		})
+		inventory.TargetDeviation().WhenSome(func(deviation int64) {
+			rpcInventory.TargetDeviation = deviation
+		})
+
+		resp.Inventory = append(resp.Inventory, rpcInventory)
 	}
+
+	return resp, nil
 }
 
-// parseUserKey parses a user-provided script or group key, which can be in
-// either the Schnorr or Compressed format.
-func parseUserKey(scriptKey []byte) (*btcec.PublicKey, error) {
-	switch len(scriptKey) {
-	case schnorr.PubKeyBytesLen:
-		return schnorr.ParsePubKey(scriptKey)
+// marshalRfqAssetSpecifier marshals an asset specifier into the RFQ RPC form.
+// The group key takes precedence over the asset ID if both are set.
+func marshalRfqAssetSpecifier(
+	specifier asset.Specifier) *rfqrpc.AssetSpecifier {
 
-	// Truncate the key and then parse as a Schnorr key.
-	case btcec.PubKeyBytesLenCompressed:
-		return schnorr.ParsePubKey(scriptKey[1:])
+	if groupKey := specifier.UnwrapGroupKeyToPtr(); groupKey != nil {
+		return &rfqrpc.AssetSpecifier{
+			Id: &rfqrpc.AssetSpecifier_GroupKey{
+				GroupKey: groupKey.SerializeCompressed(),
+			},
+		}
+	}
 
-	default:
-		return nil, fmt.Errorf("unknown script key length: %v",
-			len(scriptKey))
+	var assetID []byte
+	specifier.WhenId(func(id asset.ID) {
+		assetID = id[:]
+	})
+
+	return &rfqrpc.AssetSpecifier{
+		Id: &rfqrpc.AssetSpecifier_AssetId{
+			AssetId: assetID,
+		},
 	}
 }
 
-func (r *rpcServer) FetchAssetMeta(ctx context.Context,
-	req *taprpc.FetchAssetMetaRequest) (*taprpc.AssetMeta, error) {
-
-	var (
-		assetMeta *proof.MetaReveal
-		err       error
-	)
+// marshallRfqEvent marshals an RFQ event into the RPC form.
+func marshallRfqEvent(eventInterface fn.Event) (*rfqrpc.RfqEvent, error) {
+	timestamp := eventInterface.Timestamp().UTC().UnixMicro()
 
-	switch {
-	case req.GetAssetId() != nil:
-		if len(req.GetAssetId()) != sha256.Size {
-			return nil, fmt.Errorf("asset ID must be 32 bytes")
+	switch event := eventInterface.(type) {
+	case *rfq.PeerAcceptedBuyQuoteEvent:
+		acceptedQuote, err := taprpc.MarshalAcceptedBuyQuoteEvent(event)
+		if err != nil {
+			return nil, err
 		}
 
-		var assetID asset.ID
-		copy(assetID[:], req.GetAssetId())
+		eventRpc := &rfqrpc.RfqEvent_PeerAcceptedBuyQuote{
+			PeerAcceptedBuyQuote: &rfqrpc.PeerAcceptedBuyQuoteEvent{
+				Timestamp:            uint64(timestamp),
+				PeerAcceptedBuyQuote: acceptedQuote,
+			},
+		}
+		return &rfqrpc.RfqEvent{
+			Event: eventRpc,
+		}, nil
 
-		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaForAsset(
-			ctx, assetID,
+	case *rfq.PeerAcceptedSellQuoteEvent:
+		rpcAcceptedQuote, err := taprpc.MarshalAcceptedSellQuoteEvent(
+			event,
 		)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling accepted "+
+				"sell quote event: %w", err)
+		}
 
-	case req.GetAssetIdStr() != "":
-		if len(req.GetAssetIdStr()) != hex.EncodedLen(sha256.Size) {
-			return nil, fmt.Errorf("asset ID must be 32 bytes")
+		eventRpc := &rfqrpc.RfqEvent_PeerAcceptedSellQuote{
+			PeerAcceptedSellQuote: &rfqrpc.PeerAcceptedSellQuoteEvent{
+				Timestamp:             uint64(timestamp),
+				PeerAcceptedSellQuote: rpcAcceptedQuote,
+			},
 		}
+		return &rfqrpc.RfqEvent{
+			Event: eventRpc,
+		}, nil
 
-		var assetIDBytes []byte
-		assetIDBytes, err = hex.DecodeString(req.GetAssetIdStr())
-		if err != nil {
-			return nil, fmt.Errorf("error hex decoding asset ID: "+
-				"%w", err)
+	case *rfq.AcceptHtlcEvent:
+		eventRpc := &rfqrpc.RfqEvent_AcceptHtlc{
+			AcceptHtlc: &rfqrpc.AcceptHtlcEvent{
+				Timestamp: uint64(timestamp),
+				Scid:      event.Policy.Scid(),
+			},
 		}
+		return &rfqrpc.RfqEvent{
+			Event: eventRpc,
+		}, nil
 
-		var assetID asset.ID
-		copy(assetID[:], assetIDBytes)
+	default:
+		return nil, fmt.Errorf("unknown RFQ event type: %T",
+			eventInterface)
+	}
+}
 
-		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaForAsset(
-			ctx, assetID,
-		)
+// SubscribeRfqEventNtfns subscribes to RFQ event notifications.
+func (r *rpcServer) SubscribeRfqEventNtfns(
+	_ *rfqrpc.SubscribeRfqEventNtfnsRequest,
+	ntfnStream rfqrpc.Rfq_SubscribeRfqEventNtfnsServer) error {
 
-	case req.GetMetaHash() != nil:
-		if len(req.GetMetaHash()) != sha256.Size {
-			return nil, fmt.Errorf("meta hash must be 32 bytes")
-		}
+	filter := func(event fn.Event) (bool, error) {
+		return true, nil
+	}
 
-		var metaHash [asset.MetaHashLen]byte
-		copy(metaHash[:], req.GetMetaHash())
+	return handleEvents[uint64, *rfqrpc.RfqEvent](
+		r.cfg.RfqManager, ntfnStream, marshallRfqEvent, filter, r.quit,
+		0,
+	)
+}
 
-		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaByHash(
-			ctx, metaHash,
-		)
+// FundChannel initiates the channel funding negotiation with a peer for the
+// creation of a channel that contains a specified amount of a given asset.
+func (r *rpcServer) FundChannel(ctx context.Context,
+	req *tchrpc.FundChannelRequest) (*tchrpc.FundChannelResponse,
+	error) {
 
-	case req.GetMetaHashStr() != "":
-		if len(req.GetMetaHashStr()) != hex.EncodedLen(sha256.Size) {
-			return nil, fmt.Errorf("meta hash must be 32 bytes")
+	// If we're not running inside litd, we cannot offer this functionality.
+	if !r.cfg.EnableChannelFeatures {
+		return nil, fmt.Errorf("the Taproot Asset channel " +
+			"functionality is only available when running inside " +
+			"Lightning Terminal daemon (litd), with lnd and tapd " +
+			"both running in 'integrated' mode")
+	}
+
+	peerPub, err := btcec.ParsePubKey(req.PeerPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing peer pubkey: %w", err)
+	}
+
+	var assetSpecifier asset.Specifier
+	switch {
+	case len(req.AssetId) != 0 && len(req.GroupKey) != 0:
+		return nil, fmt.Errorf("cannot specify both asset ID and " +
+			"group key")
+
+	case len(req.AssetId) != 0:
+		if len(req.AssetId) != sha256.Size {
+			return nil, fmt.Errorf("asset ID must be 32 bytes")
 		}
 
-		var metaHashBytes []byte
-		metaHashBytes, err = hex.DecodeString(req.GetMetaHashStr())
+		var assetID asset.ID
+		copy(assetID[:], req.AssetId)
+		assetSpecifier = asset.NewSpecifierFromId(assetID)
+
+	case len(req.GroupKey) != 0:
+		groupKey, err := btcec.ParsePubKey(req.GroupKey)
 		if err != nil {
-			return nil, fmt.Errorf("error hex decoding meta hash: "+
-				"%w", err)
+			return nil, fmt.Errorf("error parsing group key: %w",
+				err)
 		}
+		assetSpecifier = asset.NewSpecifierFromGroupKey(*groupKey)
 
-		var metaHash [asset.MetaHashLen]byte
-		copy(metaHash[:], metaHashBytes)
+	default:
+		return nil, fmt.Errorf("asset ID or group key must be " +
+			"specified")
+	}
 
-		assetMeta, err = r.cfg.AssetStore.FetchAssetMetaByHash(
-			ctx, metaHash,
-		)
+	if req.AssetAmount == 0 {
+		return nil, fmt.Errorf("asset amount must be specified")
+	}
+	if req.FeeRateSatPerVbyte == 0 {
+		return nil, fmt.Errorf("fee rate must be specified")
+	}
 
-	default:
-		return nil, fmt.Errorf("either asset ID or meta hash must " +
-			"be set")
+	fundReq := tapchannel.FundReq{
+		PeerPub:        *peerPub,
+		AssetSpecifier: assetSpecifier,
+		AssetAmount:    req.AssetAmount,
+		FeeRate:        chainfee.SatPerVByte(req.FeeRateSatPerVbyte),
+		PushAmount:     btcutil.Amount(req.PushSat),
+		ZeroConf:       req.ZeroConf,
 	}
+
+	chanPoint, err := r.cfg.AuxFundingController.FundChannel(ctx, fundReq)
 	if err != nil {
-		return nil, fmt.Errorf("unable to fetch asset "+
-			"meta: %w", err)
+		return nil, fmt.Errorf("error funding channel: %w", err)
 	}
 
-	metaHash := assetMeta.MetaHash()
-	return &taprpc.AssetMeta{
-		Data:     assetMeta.Data,
-		Type:     taprpc.AssetMetaType(assetMeta.Type),
-		MetaHash: metaHash[:],
+	return &tchrpc.FundChannelResponse{
+		Txid:        chanPoint.Hash.String(),
+		OutputIndex: int32(chanPoint.Index),
 	}, nil
 }
 
-// MarshalUniProofType marshals the universe proof type into the RPC
-// counterpart.
-func MarshalUniProofType(
-	proofType universe.ProofType) (unirpc.ProofType, error) {
+// EncodeCustomRecords allows RPC users to encode Taproot Asset channel related
+// data into the TLV format that is used in the custom records of the lnd
+// payment or other channel related RPCs. This RPC is completely stateless and
+// does not perform any checks on the data provided, other than pure format
+// validation.
+func (r *rpcServer) EncodeCustomRecords(_ context.Context,
+	in *tchrpc.EncodeCustomRecordsRequest) (
+	*tchrpc.EncodeCustomRecordsResponse, error) {
 
-	switch proofType {
-	case universe.ProofTypeUnspecified:
-		return unirpc.ProofType_PROOF_TYPE_UNSPECIFIED, nil
-	case universe.ProofTypeIssuance:
-		return unirpc.ProofType_PROOF_TYPE_ISSUANCE, nil
-	case universe.ProofTypeTransfer:
-		return unirpc.ProofType_PROOF_TYPE_TRANSFER, nil
+	switch i := in.Input.(type) {
+	case *tchrpc.EncodeCustomRecordsRequest_RouterSendPayment:
+		req := i.RouterSendPayment
 
-	default:
-		return 0, fmt.Errorf("unknown universe proof type: %v",
-			proofType)
-	}
-}
+		assetAmounts := make(
+			[]*rfqmsg.AssetBalance, 0, len(req.AssetAmounts),
+		)
+		for idStr, amount := range req.AssetAmounts {
+			idBytes, err := hex.DecodeString(idStr)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding asset "+
+					"ID: %w", err)
+			}
 
-// MarshalUniID marshals the universe ID into the RPC counterpart.
-func MarshalUniID(id universe.Identifier) (*unirpc.ID, error) {
-	var uniID unirpc.ID
+			if len(idBytes) != sha256.Size {
+				return nil, fmt.Errorf("asset ID must be 32 " +
+					"bytes")
+			}
 
-	if id.GroupKey != nil {
-		uniID.Id = &unirpc.ID_GroupKey{
-			GroupKey: schnorr.SerializePubKey(id.GroupKey),
+			if amount == 0 {
+				return nil, fmt.Errorf("asset amount must be " +
+					"specified")
+			}
+
+			var assetID asset.ID
+			copy(assetID[:], idBytes)
+
+			assetAmounts = append(
+				assetAmounts, rfqmsg.NewAssetBalance(
+					assetID, amount,
+				),
+			)
 		}
-	} else {
-		uniID.Id = &unirpc.ID_AssetId{
-			AssetId: id.AssetID[:],
+
+		rfqID := fn.None[rfqmsg.ID]()
+		if len(req.RfqId) > 0 {
+			if len(req.RfqId) != sha256.Size {
+				return nil, fmt.Errorf("RFQ ID must be empty " +
+					"or exactly 32 bytes")
+			}
+
+			var id rfqmsg.ID
+			copy(id[:], req.RfqId)
+
+			rfqID = fn.Some[rfqmsg.ID](id)
 		}
-	}
 
-	proofTypeRpc, err := MarshalUniProofType(id.ProofType)
-	if err != nil {
-		return nil, fmt.Errorf("unable to marshal proof type: %w", err)
-	}
-	uniID.ProofType = proofTypeRpc
+		htlc := rfqmsg.NewHtlc(assetAmounts, rfqID)
 
-	return &uniID, nil
-}
+		// We'll now map the HTLC struct into a set of TLV records,
+		// which we can then encode into the map format expected.
+		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode records as "+
+				"map: %w", err)
+		}
 
-// marshalMssmtNode marshals a MS-SMT node into the RPC counterpart.
-func marshalMssmtNode(node mssmt.Node) *unirpc.MerkleSumNode {
-	nodeHash := node.NodeHash()
+		return &tchrpc.EncodeCustomRecordsResponse{
+			CustomRecords: htlcMapRecords,
+		}, nil
 
-	return &unirpc.MerkleSumNode{
-		RootHash: nodeHash[:],
-		RootSum:  int64(node.NodeSum()),
+	default:
+		return nil, fmt.Errorf("unknown input type: %T", i)
 	}
 }
 
-// marshalUniverseRoot marshals the universe root into the RPC counterpart.
-func marshalUniverseRoot(node universe.Root) (*unirpc.UniverseRoot, error) {
-	// There was no old base root, so we'll just return a blank root.
-	if node.Node == nil {
-		return &unirpc.UniverseRoot{}, nil
+// SendPayment is a wrapper around lnd's routerrpc.SendPaymentV2 RPC method
+// with asset specific parameters. It allows RPC users to send asset keysend
+// payments (direct payments) or payments to an invoice with a specified asset
+// amount.
+func (r *rpcServer) SendPayment(req *tchrpc.SendPaymentRequest,
+	stream tchrpc.TaprootAssetChannels_SendPaymentServer) error {
+
+	if req.PaymentRequest == nil {
+		return fmt.Errorf("payment request must be specified")
 	}
-	mssmtRoot := marshalMssmtNode(node.Node)
+	pReq := req.PaymentRequest
+	ctx := stream.Context()
 
-	rpcGroupedAssets := make(map[string]uint64, len(node.GroupedAssets))
-	for assetID, amount := range node.GroupedAssets {
-		rpcGroupedAssets[assetID.String()] = amount
+	// Do some preliminary checks on the asset ID and make sure we have any
+	// balance for that asset.
+	if len(req.AssetId) != sha256.Size {
+		return fmt.Errorf("asset ID must be 32 bytes")
 	}
+	var assetID asset.ID
+	copy(assetID[:], req.AssetId)
 
-	uniID, err := MarshalUniID(node.ID)
+	assetAmount, err := r.resolveAssetAmount(
+		ctx, assetID, req.AssetAmount, req.AssetDisplayAmount,
+	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &unirpc.UniverseRoot{
-		Id:               uniID,
-		MssmtRoot:        mssmtRoot,
-		AssetName:        node.AssetName,
-		AmountsByAssetId: rpcGroupedAssets,
-	}, nil
-}
+	// Now that we know we have at least _some_ asset balance, we'll figure
+	// out what kind of payment this is, so we can determine _how many_
+	// asset units we need.
+	destRecords := pReq.DestCustomRecords
+	_, isKeysend := destRecords[record.KeySendType]
+	firstHopRecords := pReq.FirstHopCustomRecords
 
-// MultiverseRoot returns the root of the multiverse tree. This is useful to
-// determine the equality of two multiverse trees, since the root can directly
-// be compared to another multiverse root to find out if a sync is required.
-func (r *rpcServer) MultiverseRoot(ctx context.Context,
-	req *unirpc.MultiverseRootRequest) (*unirpc.MultiverseRootResponse,
-	error) {
+	// quotePeer is the peer we negotiated a quote with for this payment,
+	// if any. The outcome of the payment is recorded in its reputation.
+	var quotePeer fn.Option[route.Vertex]
 
-	proofType, err := UnmarshalUniProofType(req.ProofType)
-	if err != nil {
-		return nil, fmt.Errorf("invalid proof type: %w", err)
-	}
+	switch {
+	// Both payment request and keysend is set, which isn't a supported
+	// combination.
+	case pReq.PaymentRequest != "" && isKeysend:
+		return fmt.Errorf("payment request and keysend custom " +
+			"records cannot be set at the same time")
 
-	if proofType == universe.ProofTypeUnspecified {
-		return nil, fmt.Errorf("proof type must be specified")
-	}
+	// There are custom records for the first hop set, which means RFQ
+	// negotiation has already happened (or this is a keysend payment and
+	// the correct asset amount is already encoded). So we don't need to do
+	// anything special and can just forward the payment to lnd.
+	case len(firstHopRecords) > 0:
+		// Continue below.
 
-	filterByIDs := make([]universe.Identifier, len(req.SpecificIds))
-	for idx, rpcID := range req.SpecificIds {
-		filterByIDs[idx], err = UnmarshalUniID(rpcID)
+	// The request wants to pay a specific invoice.
+	case pReq.PaymentRequest != "":
+		invoice, err := zpay32.Decode(
+			pReq.PaymentRequest, r.cfg.Lnd.ChainParams,
+		)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse universe id: "+
-				"%w", err)
+			return fmt.Errorf("error decoding payment request: %w",
+				err)
+		}
+
+		// The peer public key is optional if there is only a single
+		// asset channel.
+		var peerPubKey *route.Vertex
+		if len(req.PeerPubkey) > 0 {
+			parsedKey, err := route.NewVertexFromBytes(
+				req.PeerPubkey,
+			)
+			if err != nil {
+				return fmt.Errorf("error parsing peer pubkey: "+
+					"%w", err)
+			}
+
+			peerPubKey = &parsedKey
+		}
+
+		// We can now query the asset channels we have.
+		assetChan, err := r.rfqChannel(ctx, assetID, peerPubKey)
+		if err != nil {
+			return fmt.Errorf("error finding asset channel to "+
+				"use: %w", err)
 		}
 
-		// Allow the RPC user to not specify the proof type for each ID
-		// individually since the outer one is mandatory.
-		if filterByIDs[idx].ProofType == universe.ProofTypeUnspecified {
-			filterByIDs[idx].ProofType = proofType
+		// Even if the user didn't specify the peer public key before,
+		// we definitely know it now. So let's make sure it's always
+		// set.
+		peerPubKey = &assetChan.channelInfo.PubKeyBytes
+
+		// paymentMaxAmt is the maximum amount that the counterparty is
+		// expected to pay. This is the amount that the invoice is
+		// asking for plus the fee limit in milli-satoshis.
+		var invoiceAmt lnwire.MilliSatoshi
+		if invoice.MilliSat == nil {
+			amt, err := lnrpc.UnmarshallAmt(pReq.Amt, pReq.AmtMsat)
+			if err != nil {
+				return fmt.Errorf("error unmarshalling "+
+					"amount: %w", err)
+			}
+			if amt == 0 {
+				return errors.New("amount must be specified " +
+					"when paying a zero amount invoice")
+			}
+
+			invoiceAmt = amt
+		} else {
+			invoiceAmt = *invoice.MilliSat
 		}
 
-		if filterByIDs[idx].ProofType != proofType {
-			return nil, fmt.Errorf("proof type mismatch in ID "+
-				"%d: %v != %v", idx, filterByIDs[idx].ProofType,
-				proofType)
+		// Calculate the fee limit that should be used for this payment.
+		feeLimit, err := lnrpc.UnmarshallAmt(
+			pReq.FeeLimitSat, pReq.FeeLimitMsat,
+		)
+		if err != nil {
+			return fmt.Errorf("error unmarshalling fee limit: %w",
+				err)
 		}
-	}
-
-	rootNode, err := r.cfg.UniverseArchive.MultiverseRoot(
-		ctx, proofType, filterByIDs,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch multiverse root: %w",
-			err)
-	}
-
-	var resp unirpc.MultiverseRootResponse
-	rootNode.WhenSome(func(node universe.MultiverseRoot) {
-		resp.MultiverseRoot = marshalMssmtNode(node)
-	})
-
-	return &resp, nil
-}
 
-// AssetRoots queries for the known Universe roots associated with each known
-// asset. These roots represent the supply/audit state for each known asset.
-func (r *rpcServer) AssetRoots(ctx context.Context,
-	req *unirpc.AssetRootRequest) (*unirpc.AssetRootResponse, error) {
+		paymentMaxAmt := invoiceAmt + feeLimit
 
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err := r.proofQueryRateLimiter.Wait(ctx); err != nil {
-		return nil, err
-	}
+		expiryTimestamp := invoice.Timestamp.Add(invoice.Expiry())
+		resp, err := r.AddAssetSellOrder(
+			ctx, &rfqrpc.AddAssetSellOrderRequest{
+				AssetSpecifier: &rfqrpc.AssetSpecifier{
+					Id: &rfqrpc.AssetSpecifier_AssetId{
+						AssetId: assetID[:],
+					},
+				},
+				PaymentMaxAmt: uint64(paymentMaxAmt),
+				Expiry:        uint64(expiryTimestamp.Unix()),
+				PeerPubKey:    peerPubKey[:],
+				TimeoutSeconds: uint32(
+					rfq.DefaultTimeout.Seconds(),
+				),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error adding sell order: %w", err)
+		}
 
-	// First, we'll retrieve the full set of known asset Universe roots.
-	assetRoots, err := r.cfg.UniverseArchive.RootNodes(
-		ctx, universe.RootNodesQuery{
-			WithAmountsById: req.WithAmountsById,
-			SortDirection:   universe.SortDirection(req.Direction),
-			Offset:          req.Offset,
-			Limit:           req.Limit,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
+		var acceptedQuote *rfqrpc.PeerAcceptedSellQuote
+		switch r := resp.Response.(type) {
+		case *rfqrpc.AddAssetSellOrderResponse_AcceptedQuote:
+			acceptedQuote = r.AcceptedQuote
 
-	resp := &unirpc.AssetRootResponse{
-		UniverseRoots: make(
-			map[string]*unirpc.UniverseRoot, len(assetRoots),
-		),
-	}
+		case *rfqrpc.AddAssetSellOrderResponse_InvalidQuote:
+			return fmt.Errorf("peer %v sent back an invalid "+
+				"quote, status: %v", r.InvalidQuote.Peer,
+				r.InvalidQuote.Status.String())
 
-	// Retrieve config for use in filtering asset roots based on sync export
-	// settings.
-	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
-	if err != nil {
-		return nil, err
-	}
+		case *rfqrpc.AddAssetSellOrderResponse_RejectedQuote:
+			return fmt.Errorf("peer %v rejected the quote, code: "+
+				"%v, error message: %v", r.RejectedQuote.Peer,
+				r.RejectedQuote.ErrorCode,
+				r.RejectedQuote.ErrorMessage)
 
-	// For each universe root, marshal it into the RPC form, taking care to
-	// specify the proper universe ID.
-	for _, assetRoot := range assetRoots {
-		idStr := assetRoot.ID.String()
+		default:
+			return fmt.Errorf("unexpected response type: %T", r)
+		}
 
-		// Skip this asset if it's not configured for sync export.
-		if !syncConfigs.IsSyncExportEnabled(assetRoot.ID) {
-			continue
+		// Send out the information about the quote on the stream.
+		err = stream.Send(&tchrpc.SendPaymentResponse{
+			Result: &tchrpc.SendPaymentResponse_AcceptedSellOrder{
+				AcceptedSellOrder: acceptedQuote,
+			},
+		})
+		if err != nil {
+			return err
 		}
 
-		resp.UniverseRoots[idStr], err = marshalUniverseRoot(assetRoot)
+		// Unmarshall the accepted quote's asset rate.
+		assetRate, err := rfqrpc.UnmarshalFixedPoint(
+			acceptedQuote.BidAssetRate,
+		)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("error unmarshalling asset rate: %w",
+				err)
 		}
-	}
 
-	return resp, nil
-}
+		// Calculate the equivalent asset units for the given invoice
+		// amount based on the asset-to-BTC conversion rate.
+		numAssetUnits := rfqmath.MilliSatoshiToUnits(
+			invoiceAmt, *assetRate,
+		)
 
-// UnmarshalUniProofType parses the RPC universe proof type into the native
-// counterpart.
-func UnmarshalUniProofType(rpcType unirpc.ProofType) (universe.ProofType,
-	error) {
+		rpcsLog.Infof("Got quote for %v asset units at %v asset/BTC "+
+			"from peer %x with SCID %d", numAssetUnits, assetRate,
+			peerPubKey, acceptedQuote.Scid)
 
-	switch rpcType {
-	case unirpc.ProofType_PROOF_TYPE_UNSPECIFIED:
-		return universe.ProofTypeUnspecified, nil
+		quotePeer = fn.Some(*peerPubKey)
 
-	case unirpc.ProofType_PROOF_TYPE_ISSUANCE:
-		return universe.ProofTypeIssuance, nil
+		var rfqID rfqmsg.ID
+		copy(rfqID[:], acceptedQuote.Id)
 
-	case unirpc.ProofType_PROOF_TYPE_TRANSFER:
-		return universe.ProofTypeTransfer, nil
+		htlc := rfqmsg.NewHtlc(nil, fn.Some(rfqID))
 
-	default:
-		return 0, fmt.Errorf("unknown universe proof type: %v", rpcType)
-	}
-}
+		// We'll now map the HTLC struct into a set of TLV records,
+		// which we can then encode into the expected map format.
+		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
+		if err != nil {
+			return fmt.Errorf("unable to encode records as map: %w",
+				err)
+		}
 
-// unmarshalAssetSyncConfig parses the RPC asset sync config into the native
-// counterpart.
-func unmarshalAssetSyncConfig(
-	config *unirpc.AssetFederationSyncConfig) (*universe.FedUniSyncConfig,
-	error) {
+		pReq.FirstHopCustomRecords = htlcMapRecords
 
-	if config == nil {
-		return nil, fmt.Errorf("empty universe sync config")
-	}
+	// The payment request is a keysend payment.
+	case isKeysend:
+		if assetAmount == 0 {
+			return fmt.Errorf("asset amount must be specified " +
+				"for keysend payment")
+		}
 
-	// Parse the universe ID from the RPC form.
-	uniID, err := UnmarshalUniID(config.Id)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse universe id: %w",
-			err)
-	}
+		balances := []*rfqmsg.AssetBalance{
+			rfqmsg.NewAssetBalance(assetID, assetAmount),
+		}
+		htlc := rfqmsg.NewHtlc(balances, fn.None[rfqmsg.ID]())
 
-	return &universe.FedUniSyncConfig{
-		UniverseID:      uniID,
-		AllowSyncInsert: config.AllowSyncInsert,
-		AllowSyncExport: config.AllowSyncExport,
-	}, nil
-}
+		// We'll now map the HTLC struct into a set of TLV records,
+		// which we can then encode into the map format expected.
+		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
+		if err != nil {
+			return fmt.Errorf("unable to encode records as map: %w",
+				err)
+		}
 
-// UnmarshalUniID parses the RPC universe ID into the native counterpart.
-func UnmarshalUniID(rpcID *unirpc.ID) (universe.Identifier, error) {
-	if rpcID == nil {
-		return universe.Identifier{}, fmt.Errorf("missing universe id")
+		pReq.FirstHopCustomRecords = htlcMapRecords
 	}
 
-	// Unmarshal the proof type.
-	proofType, err := UnmarshalUniProofType(rpcID.ProofType)
+	rpcCtx, _, routerClient := r.cfg.Lnd.Router.RawClientWithMacAuth(ctx)
+	updateStream, err := routerClient.SendPaymentV2(rpcCtx, pReq)
 	if err != nil {
-		return universe.Identifier{}, fmt.Errorf("unable to unmarshal "+
-			"proof type: %w", err)
+		return err
 	}
-	switch {
-	case rpcID.GetAssetId() != nil:
-		var assetID asset.ID
-		copy(assetID[:], rpcID.GetAssetId())
-
-		return universe.Identifier{
-			AssetID:   assetID,
-			ProofType: proofType,
-		}, nil
-
-	case rpcID.GetAssetIdStr() != "":
-		assetIDBytes, err := hex.DecodeString(rpcID.GetAssetIdStr())
-		if err != nil {
-			return universe.Identifier{}, err
-		}
 
-		// TODO(roasbeef): reuse with above
-
-		var assetID asset.ID
-		copy(assetID[:], assetIDBytes)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
 
-		return universe.Identifier{
-			AssetID:   assetID,
-			ProofType: proofType,
-		}, nil
+		case <-r.quit:
+			return fmt.Errorf("server shutting down")
 
-	case rpcID.GetGroupKey() != nil:
-		groupKey, err := parseUserKey(rpcID.GetGroupKey())
-		if err != nil {
-			return universe.Identifier{}, err
+		default:
 		}
 
-		return universe.Identifier{
-			GroupKey:  groupKey,
-			ProofType: proofType,
-		}, nil
-
-	case rpcID.GetGroupKeyStr() != "":
-		groupKeyBytes, err := hex.DecodeString(rpcID.GetGroupKeyStr())
+		update, err := updateStream.Recv()
 		if err != nil {
-			return universe.Identifier{}, err
+			return err
 		}
 
-		// TODO(roasbeef): reuse with above
+		quotePeer.WhenSome(func(peer route.Vertex) {
+			r.recordPaymentResult(ctx, peer, update)
+		})
 
-		groupKey, err := parseUserKey(groupKeyBytes)
+		err = stream.Send(&tchrpc.SendPaymentResponse{
+			Result: &tchrpc.SendPaymentResponse_PaymentResult{
+				PaymentResult: update,
+			},
+		})
 		if err != nil {
-			return universe.Identifier{}, err
+			return err
 		}
+	}
+}
 
-		return universe.Identifier{
-			GroupKey:  groupKey,
-			ProofType: proofType,
-		}, nil
+// recordPaymentResult records the outcome of a payment that used a quote of
+// the given peer in the peer's reputation, once the payment reached a final
+// state. Only HTLCs that failed at the peer itself count against it.
+func (r *rpcServer) recordPaymentResult(ctx context.Context, peer route.Vertex,
+	payment *lnrpc.Payment) {
 
-	default:
-		return universe.Identifier{}, fmt.Errorf("no id set")
+	if payment.Status != lnrpc.Payment_SUCCEEDED &&
+		payment.Status != lnrpc.Payment_FAILED {
+
+		return
 	}
-}
 
-// QueryAssetRoots attempts to locate the current Universe root for a specific
-// asset. This asset can be identified by its asset ID or group key.
-func (r *rpcServer) QueryAssetRoots(ctx context.Context,
-	req *unirpc.AssetRootQuery) (*unirpc.QueryRootResponse, error) {
+	// The peer is the first hop of the route, which has the failure
+	// source index 1 (index 0 is our own node).
+	var failedHtlcs uint64
+	for _, htlc := range payment.Htlcs {
+		if htlc.Status == lnrpc.HTLCAttempt_FAILED &&
+			htlc.Failure != nil &&
+			htlc.Failure.FailureSourceIndex == 1 {
 
-	universeID, err := UnmarshalUniID(req.Id)
-	if err != nil {
-		return nil, err
+			failedHtlcs++
+		}
 	}
 
-	// Attempt to retrieve the issuance universe root.
-	rpcsLog.Debugf("Querying for asset (group) issuance universe root "+
-		"for %v", spew.Sdump(universeID))
+	r.cfg.RfqManager.Reputation().RecordPaymentResult(
+		ctx, peer, payment.Status == lnrpc.Payment_SUCCEEDED,
+		failedHtlcs,
+	)
+}
 
-	universeID.ProofType = universe.ProofTypeIssuance
+// PayAssetInvoice pays a BOLT11 invoice with assets from an asset channel. A
+// sell quote for the invoice amount is negotiated with a channel peer, the
+// accepted quote is added to the first hop custom records of the payment and
+// the payment is dispatched through lnd. This is a simplified version of
+// SendPayment that doesn't require a full lnd payment request.
+func (r *rpcServer) PayAssetInvoice(req *tchrpc.PayAssetInvoiceRequest,
+	stream tchrpc.TaprootAssetChannels_PayAssetInvoiceServer) error {
 
-	// Ensure proof export is enabled for the given universe.
-	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	if req.PaymentRequest == "" {
+		return fmt.Errorf("payment request must be specified")
+	}
+
+	invoice, err := zpay32.Decode(
+		req.PaymentRequest, r.cfg.Lnd.ChainParams,
+	)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error decoding payment request: %w", err)
 	}
 
-	if !syncConfigs.IsSyncExportEnabled(universeID) {
-		return nil, fmt.Errorf("proof export is disabled for the " +
-			"given universe")
+	// The amount must only be specified for zero amount invoices, as
+	// otherwise it would be ambiguous which amount should be paid.
+	paymentAmt := lnwire.MilliSatoshi(req.AmtMsat)
+	switch {
+	case req.AmtMsat < 0:
+		return fmt.Errorf("amount cannot be negative")
+
+	case invoice.MilliSat != nil && req.AmtMsat != 0:
+		return fmt.Errorf("amount must not be specified when paying " +
+			"a non-zero amount invoice")
+
+	case invoice.MilliSat != nil:
+		paymentAmt = *invoice.MilliSat
 	}
 
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
-		return nil, err
+	feeLimitMsat := req.FeeLimitMsat
+	switch {
+	case feeLimitMsat < 0:
+		return fmt.Errorf("fee limit cannot be negative")
+
+	case feeLimitMsat == 0:
+		feeLimitMsat = int64(paymentAmt) *
+			defaultPayInvoiceFeeLimitPercent / 100
 	}
 
-	// Query for both an issuance and transfer universe root.
-	assetRoots, err := r.queryAssetProofRoots(ctx, universeID)
-	if err != nil {
-		return nil, err
+	timeoutSeconds := req.TimeoutSeconds
+	if timeoutSeconds == 0 {
+		timeoutSeconds = defaultPayInvoiceTimeout
 	}
 
-	// If no roots were found and the universe ID had no group key, the
-	// asset may be a grouped asset.
-	mayBeGrouped := assetRoots.IssuanceRoot.Id == nil &&
-		assetRoots.TransferRoot.Id == nil && universeID.GroupKey == nil
+	// The RFQ negotiation, the construction of the first hop custom
+	// records and the payment itself are all handled by SendPayment.
+	return r.SendPayment(&tchrpc.SendPaymentRequest{
+		AssetId:    req.AssetId,
+		PeerPubkey: req.PeerPubkey,
+		PaymentRequest: &routerrpc.SendPaymentRequest{
+			PaymentRequest: req.PaymentRequest,
+			AmtMsat:        req.AmtMsat,
+			FeeLimitMsat:   feeLimitMsat,
+			TimeoutSeconds: timeoutSeconds,
+		},
+	}, stream)
+}
 
-	// We already found some universe roots, or the original
-	// request was for an asset group. Return the roots we have.
-	if !mayBeGrouped {
-		return assetRoots, nil
-	}
+// AddInvoice is a wrapper around lnd's lnrpc.AddInvoice method with asset
+// specific parameters. It allows RPC users to create invoices that correspond
+// to the specified asset amount.
+func (r *rpcServer) AddInvoice(ctx context.Context,
+	req *tchrpc.AddInvoiceRequest) (*tchrpc.AddInvoiceResponse, error) {
 
-	// Query for a matching asset group, and fetch the matching universe
-	// roots if we find a group.
-	groupedAssetID := universeID.AssetID
-	rpcsLog.Debugf("No roots found for asset %v, checking if this "+
-		"asset is part of a group", groupedAssetID.String())
+	params, err := r.parseAddInvoiceRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	assetID, assetUnits := params.assetID, params.assetUnits
+	peerPubKey, iReq := params.peerPubKey, params.invoice
 
-	assetGroup, err := r.cfg.TapAddrBook.QueryAssetGroup(
-		ctx, groupedAssetID,
+	return r.addAssetInvoice(
+		ctx, assetID, assetUnits, peerPubKey, iReq, req.HodlInvoice,
+		fn.None[lnwire.MilliSatoshi](),
 	)
+}
 
-	switch {
-	// No asset info was found; we will return empty universe roots.
-	case errors.Is(err, address.ErrAssetGroupUnknown):
-		return assetRoots, nil
+// ExportAssetChannelBackup exports the asset level state of one or all asset
+// channels that is needed to sweep the assets of a channel after it was force
+// closed.
+func (r *rpcServer) ExportAssetChannelBackup(_ context.Context,
+	req *tchrpc.ExportAssetChannelBackupRequest) (
+	*tchrpc.ExportAssetChannelBackupResponse, error) {
 
-	case err != nil:
-		return nil, fmt.Errorf("asset group lookup failed: %w",
-			err)
+	recorder := r.cfg.AuxCommitmentRecorder
+	if recorder == nil {
+		return nil, fmt.Errorf("asset channels are not enabled")
+	}
 
-	// We found the correct group for this asset; fetch the universe
-	// roots for the group.
-	case assetGroup.GroupKey != nil:
-		foundGroupKey := &assetGroup.GroupPubKey
-		groupUniID := universe.Identifier{
-			GroupKey:  foundGroupKey,
-			ProofType: universe.ProofTypeIssuance,
+	backups := cmsg.ChannelBackups{
+		Version: cmsg.ChannelBackupsV0,
+	}
+	switch {
+	case req.ChanPoint != "":
+		chanPoint, err := wire.NewOutPointFromString(req.ChanPoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel point: %w",
+				err)
 		}
 
-		rpcsLog.Debugf("Found group %x for asset %v",
-			foundGroupKey.SerializeCompressed(),
-			groupedAssetID.String())
+		channel, ok := recorder.FetchChannel(*chanPoint)
+		if !ok {
+			return nil, fmt.Errorf("no state known for channel %v",
+				chanPoint)
+		}
 
-		assetRoots, err = r.queryAssetProofRoots(ctx, groupUniID)
+		backup, err := tapchannel.NewChannelBackup(channel)
 		if err != nil {
 			return nil, err
 		}
+		backups.Backups = append(backups.Backups, backup)
 
-		return assetRoots, nil
-
-	// The asset has no group; we will return empty universe roots.
 	default:
-		return assetRoots, nil
+		// Channels we haven't seen the full state of yet are skipped,
+		// as they can't be swept from a partial backup anyway.
+		for _, channel := range recorder.ListChannels() {
+			backup, err := tapchannel.NewChannelBackup(channel)
+			if err != nil {
+				rpcsLog.Warnf("Skipping backup of channel %v: "+
+					"%v", channel.ChannelPoint, err)
+				continue
+			}
+			backups.Backups = append(backups.Backups, backup)
+		}
 	}
-}
-
-// queryAssetProofRoots attempts to locate the current Universe root for a
-// specific asset, for both proof types. The asset can be identified by its
-// asset ID or group key.
-func (r *rpcServer) queryAssetProofRoots(ctx context.Context,
-	id universe.Identifier) (*unirpc.QueryRootResponse, error) {
-
-	var issuanceRootRPC, transferRootRPC *unirpc.UniverseRoot
-	uniID := id
 
-	issuanceRoot, issuanceErr := r.cfg.UniverseArchive.RootNode(ctx, uniID)
-	if issuanceErr != nil {
-		// Do not return at this point if the error only indicates that
-		// the root wasn't found. We'll try to find the transfer root
-		// below.
-		if !errors.Is(issuanceErr, universe.ErrNoUniverseRoot) {
-			return nil, issuanceErr
-		}
+	var buf bytes.Buffer
+	if err := backups.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("unable to encode channel backup: %w",
+			err)
 	}
 
-	issuanceRootRPC, err := marshalUniverseRoot(issuanceRoot)
-	if err != nil {
-		return nil, err
+	chanPoints := make([]string, len(backups.Backups))
+	for idx, backup := range backups.Backups {
+		chanPoints[idx] = backup.ChanPoint.String()
 	}
 
-	// Attempt to retrieve the transfer universe root.
-	rpcsLog.Debugf("Querying for asset (group) transfer universe root "+
-		"for %v", spew.Sdump(uniID))
+	return &tchrpc.ExportAssetChannelBackupResponse{
+		Backup:     buf.Bytes(),
+		ChanPoints: chanPoints,
+	}, nil
+}
 
-	uniID.ProofType = universe.ProofTypeTransfer
+// RestoreAssetChannelBackup restores an asset channel backup that was
+// previously exported with ExportAssetChannelBackup.
+func (r *rpcServer) RestoreAssetChannelBackup(ctx context.Context,
+	req *tchrpc.RestoreAssetChannelBackupRequest) (
+	*tchrpc.RestoreAssetChannelBackupResponse, error) {
 
-	transferRoot, transferErr := r.cfg.UniverseArchive.RootNode(ctx, uniID)
-	if transferErr != nil {
-		// Do not return at this point if the error only indicates that
-		// the root wasn't found. We may have found the issuance root
-		// above.
-		if !errors.Is(transferErr, universe.ErrNoUniverseRoot) {
-			return nil, transferErr
-		}
+	if len(req.Backup) == 0 {
+		return nil, fmt.Errorf("backup must be specified")
 	}
 
-	transferRootRPC, err = marshalUniverseRoot(transferRoot)
+	backups, err := cmsg.DecodeChannelBackups(req.Backup)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to decode channel backup: %w",
+			err)
 	}
 
-	return &unirpc.QueryRootResponse{
-		IssuanceRoot: issuanceRootRPC,
-		TransferRoot: transferRootRPC,
+	err = r.cfg.ChannelBackups.StoreChannelBackups(ctx, backups.Backups)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store channel backup: %w",
+			err)
+	}
+
+	chanPoints := make([]string, len(backups.Backups))
+	for idx, backup := range backups.Backups {
+		chanPoints[idx] = backup.ChanPoint.String()
+	}
+
+	return &tchrpc.RestoreAssetChannelBackupResponse{
+		ChanPoints: chanPoints,
 	}, nil
 }
 
-// DeleteAssetRoot attempts to locate the current Universe root for a specific
-// asset, and deletes the associated Universe tree if found.
-func (r *rpcServer) DeleteAssetRoot(ctx context.Context,
-	req *unirpc.DeleteRootQuery) (*unirpc.DeleteRootResponse, error) {
+// QueryAssetPaymentLimits returns the maximum amounts of an asset that can
+// currently be received through an asset invoice and paid out through the
+// active asset channels.
+func (r *rpcServer) QueryAssetPaymentLimits(ctx context.Context,
+	req *tchrpc.QueryAssetPaymentLimitsRequest) (
+	*tchrpc.QueryAssetPaymentLimitsResponse, error) {
 
-	universeID, err := UnmarshalUniID(req.Id)
-	if err != nil {
-		return nil, err
+	if len(req.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be 32 bytes")
 	}
+	var assetID asset.ID
+	copy(assetID[:], req.AssetId)
 
-	rpcsLog.Debugf("Deleting asset root for %v", spew.Sdump(universeID))
-
-	// If the universe proof type is unspecified, we'll delete both the
-	// issuance and transfer roots.
-	if universeID.ProofType == universe.ProofTypeUnspecified {
-		universeID.ProofType = universe.ProofTypeIssuance
-		_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
+	var peerPubKey *route.Vertex
+	if len(req.PeerPubkey) > 0 {
+		parsedKey, err := route.NewVertexFromBytes(req.PeerPubkey)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing peer pubkey: %w",
+				err)
 		}
 
-		universeID.ProofType = universe.ProofTypeTransfer
-		_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
-		if err != nil {
-			return nil, err
-		}
+		peerPubKey = &parsedKey
+	}
 
-		return &unirpc.DeleteRootResponse{}, nil
+	limits, err := r.cfg.RfqManager.PaymentLimits(
+		ctx, assetID, peerPubKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error computing payment limits: %w",
+			err)
 	}
 
-	// At this point the universe proof type was specified, so we'll only
-	// delete the root for that proof type.
-	_, err = r.cfg.UniverseArchive.DeleteRoot(ctx, universeID)
+	return &tchrpc.QueryAssetPaymentLimitsResponse{
+		MaxReceivableUnits: limits.MaxReceivableUnits,
+		MaxPayableUnits:    limits.MaxPayableUnits,
+		MaxReceivableMsat:  uint64(limits.MaxReceivableMsat),
+		MaxPayableMsat:     uint64(limits.MaxPayableMsat),
+	}, nil
+}
+
+// AddAssetInvoice negotiates a quote with the given peer (or the single asset
+// channel peer if nil) for receiving up to maxUnits of the given asset and
+// creates an invoice over the exact amount that commits to the given
+// description hash. The payment request of the invoice is returned.
+//
+// NOTE: This is part of the lnurl.InvoiceCreator interface.
+func (r *rpcServer) AddAssetInvoice(ctx context.Context, assetID asset.ID,
+	peer *route.Vertex, maxUnits uint64, amt lnwire.MilliSatoshi,
+	descHash []byte) (string, error) {
+
+	resp, err := r.addAssetInvoice(
+		ctx, assetID, maxUnits, peer, &lnrpc.Invoice{
+			DescriptionHash: descHash,
+		}, nil, fn.Some(amt),
+	)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return &unirpc.DeleteRootResponse{}, nil
+	return resp.InvoiceResult.PaymentRequest, nil
 }
 
-func marshalLeafKey(leafKey universe.LeafKey) *unirpc.AssetKey {
-	return &unirpc.AssetKey{
-		Outpoint: &unirpc.AssetKey_OpStr{
-			OpStr: leafKey.OutPoint.String(),
-		},
-		ScriptKey: &unirpc.AssetKey_ScriptKeyBytes{
-			ScriptKeyBytes: schnorr.SerializePubKey(
-				leafKey.ScriptKey.PubKey,
-			),
+// addAssetInvoice negotiates a buy quote for the given number of asset units
+// and creates an invoice that routes the payment through the quote's SCID. If
+// no invoice amount is given, the invoice is created over the value of the
+// asset units at the quoted rate. Otherwise, the invoice is created over the
+// given amount, which must be covered by the quote.
+func (r *rpcServer) addAssetInvoice(ctx context.Context, assetID asset.ID,
+	assetUnits uint64, peerPubKey *route.Vertex, iReq *lnrpc.Invoice,
+	hodlInvoice *tchrpc.HodlInvoice,
+	invoiceAmt fn.Option[lnwire.MilliSatoshi]) (*tchrpc.AddInvoiceResponse,
+	error) {
+
+	// We can now query the asset channels we have.
+	assetChan, err := r.rfqChannel(ctx, assetID, peerPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("error finding asset channel to use: %w",
+			err)
+	}
+
+	// Even if the user didn't specify the peer public key before, we
+	// definitely know it now. So let's make sure it's always set.
+	peerPubKey = &assetChan.channelInfo.PubKeyBytes
+
+	expirySeconds := iReq.Expiry
+	if expirySeconds == 0 {
+		expirySeconds = int64(rfq.DefaultInvoiceExpiry.Seconds())
+	}
+	expiryTimestamp := time.Now().Add(
+		time.Duration(expirySeconds) * time.Second,
+	)
+
+	resp, err := r.AddAssetBuyOrder(ctx, &rfqrpc.AddAssetBuyOrderRequest{
+		AssetSpecifier: &rfqrpc.AssetSpecifier{
+			Id: &rfqrpc.AssetSpecifier_AssetId{
+				AssetId: assetID[:],
+			},
 		},
+		AssetMaxAmt: assetUnits,
+		Expiry:      uint64(expiryTimestamp.Unix()),
+		PeerPubKey:  peerPubKey[:],
+		TimeoutSeconds: uint32(
+			rfq.DefaultTimeout.Seconds(),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding buy order: %w", err)
 	}
-}
 
-// AssetLeafKeys queries for the set of Universe keys associated with a given
-// asset_id or group_key. Each key takes the form: (outpoint, script_key),
-// where outpoint is an outpoint in the Bitcoin blockchain that anchors a valid
-// Taproot Asset commitment, and script_key is the script_key of the asset
-// within the Taproot Asset commitment for the given asset_id or group_key.
-func (r *rpcServer) AssetLeafKeys(ctx context.Context,
-	req *unirpc.AssetLeafKeysRequest) (*unirpc.AssetLeafKeyResponse, error) {
+	var acceptedQuote *rfqrpc.PeerAcceptedBuyQuote
+	switch r := resp.Response.(type) {
+	case *rfqrpc.AddAssetBuyOrderResponse_AcceptedQuote:
+		acceptedQuote = r.AcceptedQuote
+
+	case *rfqrpc.AddAssetBuyOrderResponse_InvalidQuote:
+		return nil, fmt.Errorf("peer %v sent back an invalid quote, "+
+			"status: %v", r.InvalidQuote.Peer,
+			r.InvalidQuote.Status.String())
 
-	if req == nil {
-		return nil, fmt.Errorf("request must be set")
+	case *rfqrpc.AddAssetBuyOrderResponse_RejectedQuote:
+		return nil, fmt.Errorf("peer %v rejected the quote, code: %v, "+
+			"error message: %v", r.RejectedQuote.Peer,
+			r.RejectedQuote.ErrorCode, r.RejectedQuote.ErrorMessage)
+
+	default:
+		return nil, fmt.Errorf("unexpected response type: %T", r)
 	}
 
-	universeID, err := UnmarshalUniID(req.Id)
+	// Now that we have the accepted quote, we know the amount in Satoshi
+	// that we need to pay. We can now update the invoice with this amount.
+	//
+	// First, un-marshall the ask asset rate from the accepted quote.
+	askAssetRate, err := rfqrpc.UnmarshalFixedPoint(
+		acceptedQuote.AskAssetRate,
+	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error unmarshalling ask asset rate: %w",
+			err)
 	}
 
-	// If the proof type wasn't specified, then we'll return an error as we
-	// don't know which keys to actually fetch.
-	if universeID.ProofType == universe.ProofTypeUnspecified {
-		return nil, fmt.Errorf("proof type must be specified")
-	}
+	// Convert the asset amount into a fixed-point.
+	assetAmount := rfqmath.NewBigIntFixedPoint(assetUnits, 0)
 
-	if req.Limit > universe.MaxPageSize || req.Limit < 0 {
-		return nil, fmt.Errorf("invalid request limit: %d", req.Limit)
-	}
+	// Calculate the invoice amount in msat.
+	valMsat := rfqmath.UnitsToMilliSatoshi(assetAmount, *askAssetRate)
 
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
-		return nil, err
+	// If a fixed invoice amount was requested, the quote must be large
+	// enough to cover it.
+	if invoiceAmt.UnwrapOr(valMsat) > valMsat {
+		return nil, fmt.Errorf("quote only covers %v, which is less "+
+			"than the invoice amount %v", valMsat,
+			invoiceAmt.UnwrapOr(valMsat))
 	}
+	iReq.ValueMsat = int64(invoiceAmt.UnwrapOr(valMsat))
 
-	leafKeys, err := r.cfg.UniverseArchive.UniverseLeafKeys(
-		ctx, universe.UniverseLeafKeysQuery{
-			Id:            universeID,
-			SortDirection: universe.SortDirection(req.Direction),
-			Offset:        req.Offset,
-			Limit:         req.Limit,
-		},
+	// The last step is to create a hop hint that includes the fake SCID of
+	// the quote, alongside the channel's routing policy. We need to choose
+	// the policy that points towards us, as the payment will be flowing in.
+	// So we get the policy that's being set by the remote peer.
+	channelID := assetChan.channelInfo.ChannelID
+	inboundPolicy, err := r.getInboundPolicy(
+		ctx, channelID, peerPubKey.String(),
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to get inbound channel policy "+
+			"for channel with ID %d: %w", channelID, err)
 	}
 
-	resp := &unirpc.AssetLeafKeyResponse{
-		AssetKeys: make([]*unirpc.AssetKey, len(leafKeys)),
-	}
+	// If this is a hodl invoice, then we'll copy over the relevant fields,
+	// then route this through the invoicerpc instead.
+	if hodlInvoice != nil {
+		payHash, err := lntypes.MakeHash(hodlInvoice.PaymentHash)
+		if err != nil {
+			return nil, fmt.Errorf("error creating payment "+
+				"hash: %w", err)
+		}
 
-	for i, leafKey := range leafKeys {
-		resp.AssetKeys[i] = marshalLeafKey(leafKey)
-	}
+		peerPub, err := btcec.ParsePubKey(peerPubKey[:])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing peer "+
+				"pubkey: %w", err)
+		}
 
-	return resp, nil
-}
+		hopHint := []zpay32.HopHint{
+			{
+				NodeID:      peerPub,
+				ChannelID:   acceptedQuote.Scid,
+				FeeBaseMSat: uint32(inboundPolicy.FeeBaseMsat),
+				FeeProportionalMillionths: uint32(
+					inboundPolicy.FeeRateMilliMsat,
+				),
+				CLTVExpiryDelta: uint16(
+					inboundPolicy.TimeLockDelta,
+				),
+			},
+		}
 
-func marshalAssetLeaf(ctx context.Context, keys taprpc.KeyLookup,
-	assetLeaf *universe.Leaf,
-	decDisplay fn.Option[uint32]) (*unirpc.AssetLeaf, error) {
+		payReq, err := r.cfg.Lnd.Invoices.AddHoldInvoice(
+			ctx, &invoicesrpc.AddInvoiceData{
+				Memo: iReq.Memo,
+				Value: lnwire.MilliSatoshi(
+					iReq.ValueMsat,
+				),
+				Hash:            &payHash,
+				DescriptionHash: iReq.DescriptionHash,
+				Expiry:          iReq.Expiry,
+				// We set private to false as we don't want to
+				// add any hop hints other than this one.
+				Private:     false,
+				HodlInvoice: true,
+				RouteHints:  [][]zpay32.HopHint{hopHint},
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating hodl invoice: "+
+				"%w", err)
+		}
 
-	rpcAsset, err := taprpc.MarshalAsset(
-		ctx, assetLeaf.Asset, false, true, keys, decDisplay,
-	)
+		return &tchrpc.AddInvoiceResponse{
+			AcceptedBuyQuote: acceptedQuote,
+			InvoiceResult: &lnrpc.AddInvoiceResponse{
+				PaymentRequest: payReq,
+			},
+		}, nil
+	}
+
+	// Otherwise, we'll make this into a normal invoice.
+	hopHint := &lnrpc.HopHint{
+		NodeId:      peerPubKey.String(),
+		ChanId:      acceptedQuote.Scid,
+		FeeBaseMsat: uint32(inboundPolicy.FeeBaseMsat),
+		FeeProportionalMillionths: uint32(
+			inboundPolicy.FeeRateMilliMsat,
+		),
+		CltvExpiryDelta: inboundPolicy.TimeLockDelta,
+	}
+	iReq.RouteHints = []*lnrpc.RouteHint{
+		{
+			HopHints: []*lnrpc.HopHint{
+				hopHint,
+			},
+		},
+	}
+
+	rpcCtx, _, rawClient := r.cfg.Lnd.Client.RawClientWithMacAuth(ctx)
+	invoiceResp, err := rawClient.AddInvoice(rpcCtx, iReq)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error creating invoice: %w", err)
 	}
 
-	return &unirpc.AssetLeaf{
-		Asset: rpcAsset,
-		Proof: assetLeaf.RawProof,
+	return &tchrpc.AddInvoiceResponse{
+		AcceptedBuyQuote: acceptedQuote,
+		InvoiceResult:    invoiceResp,
 	}, nil
 }
 
-// marshalAssetLeaf marshals an asset leaf into the RPC form.
-func (r *rpcServer) marshalAssetLeaf(ctx context.Context,
-	assetLeaf *universe.Leaf,
-	decDisplay fn.Option[uint32]) (*unirpc.AssetLeaf, error) {
+// addInvoiceParams holds the validated parameters of an AddInvoice request.
+type addInvoiceParams struct {
+	// assetID is the ID of the asset to receive.
+	assetID asset.ID
 
-	return marshalAssetLeaf(ctx, r.cfg.AddrBook, assetLeaf, decDisplay)
+	// assetUnits is the number of asset base units to receive.
+	assetUnits uint64
+
+	// peerPubKey is the optional peer to receive the assets from.
+	peerPubKey *route.Vertex
+
+	// invoice is the lnd invoice request, which is never nil.
+	invoice *lnrpc.Invoice
 }
 
-// AssetLeaves queries for the set of asset leaves (the values in the Universe
-// MS-SMT tree) for a given asset_id or group_key. These represents either
-// asset issuance events (they have a genesis witness) or asset transfers that
-// took place on chain. The leaves contain a normal Taproot asset proof, as well
-// as details for the asset.
-func (r *rpcServer) AssetLeaves(ctx context.Context,
-	req *unirpc.ID) (*unirpc.AssetLeafResponse, error) {
+// parseAddInvoiceRequest validates the given AddInvoice request and returns
+// its parameters.
+func (r *rpcServer) parseAddInvoiceRequest(ctx context.Context,
+	req *tchrpc.AddInvoiceRequest) (*addInvoiceParams, error) {
 
-	universeID, err := UnmarshalUniID(req)
-	if err != nil {
-		return nil, err
+	// The invoice request is optional, an invoice for the given asset
+	// amount can be created from the asset ID and amount alone.
+	iReq := req.InvoiceRequest
+	if iReq == nil {
+		iReq = &lnrpc.Invoice{}
 	}
 
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
-		return nil, err
+	// Do some preliminary checks on the asset ID and make sure we have any
+	// balance for that asset.
+	if len(req.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be 32 bytes")
 	}
+	var assetID asset.ID
+	copy(assetID[:], req.AssetId)
 
-	assetLeaves, err := r.cfg.UniverseArchive.MintingLeaves(ctx, universeID)
+	assetUnits, err := r.resolveAssetAmount(
+		ctx, assetID, req.AssetAmount, req.AssetDisplayAmount,
+	)
 	if err != nil {
 		return nil, err
 	}
-
-	resp := &unirpc.AssetLeafResponse{
-		Leaves: make([]*unirpc.AssetLeaf, len(assetLeaves)),
+	if assetUnits == 0 {
+		return nil, fmt.Errorf("asset amount must be specified")
 	}
-	for i, assetLeaf := range assetLeaves {
-		assetLeaf := assetLeaf
 
-		decDisplay, err := r.DecDisplayForAssetID(ctx, assetLeaf.ID())
+	// The peer public key is optional if there is only a single asset
+	// channel.
+	var peerPubKey *route.Vertex
+	if len(req.PeerPubkey) > 0 {
+		parsedKey, err := route.NewVertexFromBytes(req.PeerPubkey)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing peer pubkey: %w",
+				err)
 		}
 
-		resp.Leaves[i], err = r.marshalAssetLeaf(
-			ctx, &assetLeaf, decDisplay,
-		)
-		if err != nil {
-			return nil, err
-		}
+		peerPubKey = &parsedKey
 	}
 
-	return resp, nil
+	return &addInvoiceParams{
+		assetID:    assetID,
+		assetUnits: assetUnits,
+		peerPubKey: peerPubKey,
+		invoice:    iReq,
+	}, nil
 }
 
-// unmarshalLeafKey un-marshals a leaf key from the RPC form.
-func unmarshalLeafKey(key *unirpc.AssetKey) (universe.LeafKey, error) {
-	var leafKey universe.LeafKey
+// DeclareScriptKey declares a new script key to the wallet. This is useful
+// when the script key contains scripts, which would mean it wouldn't be
+// recognized by the wallet automatically. Declaring a script key will make any
+// assets sent to the script key be recognized as being local assets.
+func (r *rpcServer) DeclareScriptKey(ctx context.Context,
+	in *wrpc.DeclareScriptKeyRequest) (*wrpc.DeclareScriptKeyResponse,
+	error) {
 
-	switch {
-	case key.GetScriptKeyBytes() != nil:
-		scriptKey, err := parseUserKey(key.GetScriptKeyBytes())
-		if err != nil {
-			return leafKey, err
-		}
+	scriptKey, err := taprpc.UnmarshalScriptKey(in.ScriptKey)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling script key: %w",
+			err)
+	}
 
-		leafKey.ScriptKey = &asset.ScriptKey{
-			PubKey: scriptKey,
-		}
+	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, *scriptKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting script key: %w", err)
+	}
 
-	case key.GetScriptKeyStr() != "":
-		scriptKeyBytes, err := hex.DecodeString(key.GetScriptKeyStr())
-		if err != nil {
-			return leafKey, err
-		}
+	return &wrpc.DeclareScriptKeyResponse{
+		ScriptKey: taprpc.MarshalScriptKey(*scriptKey),
+	}, nil
+}
 
-		scriptKey, err := parseUserKey(scriptKeyBytes)
-		if err != nil {
-			return leafKey, err
-		}
+// accountFilter resolves the account with the given name to be used as a
+// filter. An empty name means that no filtering by account should be applied.
+func (r *rpcServer) accountFilter(ctx context.Context,
+	name string) (fn.Option[account.Account], error) {
+
+	// A call that is scoped to a tenant is always restricted to the
+	// account of the tenant.
+	name, err := account.ScopeName(ctx, name)
+	if err != nil {
+		return fn.None[account.Account](), err
+	}
 
-		leafKey.ScriptKey = &asset.ScriptKey{
-			PubKey: scriptKey,
-		}
-	default:
-		// TODO(roasbeef): can actually allow not to be, then would
-		// fetch all for the given outpoint
-		return leafKey, fmt.Errorf("script key must be set")
+	if name == "" {
+		return fn.None[account.Account](), nil
 	}
 
-	switch {
-	case key.GetOpStr() != "":
-		// Parse a bitcoin outpoint in the form txid:index into a
-		// wire.OutPoint struct.
-		outpoint, err := wire.NewOutPointFromString(key.GetOpStr())
-		if err != nil {
-			return leafKey, err
-		}
+	acct, err := account.Resolve(ctx, r.cfg.Accounts, name)
+	if err != nil {
+		return fn.None[account.Account](), fmt.Errorf("invalid "+
+			"account: %w", err)
+	}
 
-		leafKey.OutPoint = *outpoint
+	return fn.Some(acct), nil
+}
 
-	case key.GetOutpoint() != nil:
-		op := key.GetOp()
+// NewAccount creates a new named asset account.
+func (r *rpcServer) NewAccount(ctx context.Context,
+	in *wrpc.NewAccountRequest) (*wrpc.NewAccountResponse, error) {
 
-		hash, err := chainhash.NewHashFromStr(op.HashStr)
+	if !in.Tenant {
+		acct, err := r.cfg.Accounts.NewAccount(ctx, in.Name)
 		if err != nil {
-			return leafKey, err
-		}
-
-		leafKey.OutPoint = wire.OutPoint{
-			Hash:  *hash,
-			Index: uint32(op.Index),
+			return nil, fmt.Errorf("error creating account: %w",
+				err)
 		}
 
-	default:
-		return leafKey, fmt.Errorf("outpoint not set")
+		return &wrpc.NewAccountResponse{
+			Account: marshalAccount(*acct),
+		}, nil
 	}
 
-	return leafKey, nil
-}
+	// A tenant can only access its account with its own macaroons, so we
+	// can't create a tenant if macaroons are disabled.
+	macSvc := r.interceptorChain.MacaroonService()
+	if macSvc == nil {
+		return nil, fmt.Errorf("tenants require macaroons to be " +
+			"enabled")
+	}
 
-// marshalMssmtProof marshals a MS-SMT proof into the RPC form.
-func marshalMssmtProof(proof *mssmt.Proof) ([]byte, error) {
-	compressedProof := proof.Compress()
+	tenant, err := r.cfg.Accounts.NewTenant(ctx, in.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tenant: %w", err)
+	}
 
-	var b bytes.Buffer
-	if err := compressedProof.Encode(&b); err != nil {
+	mac, err := r.bakeTenantMacaroon(ctx, macSvc, *tenant)
+	if err != nil {
 		return nil, err
 	}
 
-	return b.Bytes(), nil
+	return &wrpc.NewAccountResponse{
+		Account:        marshalAccount(*tenant),
+		TenantMacaroon: mac,
+	}, nil
 }
 
-// marshalUniverseProofLeaf marshals a universe proof leaf into the RPC form.
-func (r *rpcServer) marshalUniverseProofLeaf(ctx context.Context,
-	req *unirpc.UniverseKey,
-	proof *universe.Proof) (*unirpc.AssetProofResponse, error) {
+// bakeTenantMacaroon bakes a macaroon for the given tenant with the root key of
+// the tenant. The macaroon grants the permissions of all methods a tenant can
+// call, which are restricted to the account of the tenant by the interceptor
+// chain.
+func (r *rpcServer) bakeTenantMacaroon(ctx context.Context,
+	macSvc *macaroons.Service, tenant account.Account) (string, error) {
 
-	uniProof, err := marshalMssmtProof(proof.UniverseInclusionProof)
-	if err != nil {
-		return nil, err
+	permissions := r.interceptorChain.Permissions()
+	tenantOps := fn.NewSet[bakery.Op]()
+	for method := range rpcperms.TenantMethods {
+		for _, op := range permissions[method] {
+			tenantOps.Add(op)
+		}
 	}
 
-	decDisplay, err := r.DecDisplayForAssetID(ctx, proof.Leaf.ID())
-	if err != nil {
-		return nil, err
-	}
+	ops := tenantOps.ToSlice()
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Entity != ops[j].Entity {
+			return ops[i].Entity < ops[j].Entity
+		}
 
-	assetLeaf, err := r.marshalAssetLeaf(ctx, proof.Leaf, decDisplay)
+		return ops[i].Action < ops[j].Action
+	})
+
+	mac, err := macSvc.NewMacaroon(
+		ctx, account.TenantRootKeyID(tenant.Name), ops...,
+	)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("unable to bake tenant macaroon: %w", err)
 	}
 
-	uniRoot, err := marshalUniverseRoot(universe.Root{
-		Node: proof.UniverseRoot,
-	})
+	macBytes, err := mac.M().MarshalBinary()
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("unable to encode tenant macaroon: %w",
+			err)
 	}
 
-	uniRoot.AssetName = assetLeaf.Asset.AssetGenesis.Name
-	uniRoot.Id = req.Id
+	return hex.EncodeToString(macBytes), nil
+}
 
-	// Marshal multiverse specific fields.
-	multiverseRoot := marshalMssmtNode(proof.MultiverseRoot)
+// ListAccounts lists all named asset accounts.
+func (r *rpcServer) ListAccounts(ctx context.Context,
+	_ *wrpc.ListAccountsRequest) (*wrpc.ListAccountsResponse, error) {
 
-	multiverseProof, err := marshalMssmtProof(
-		proof.MultiverseInclusionProof,
-	)
+	accounts, err := r.cfg.Accounts.ListAccounts(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error listing accounts: %w", err)
 	}
 
-	return &unirpc.AssetProofResponse{
-		Req:                      req,
-		UniverseRoot:             uniRoot,
-		UniverseInclusionProof:   uniProof,
-		AssetLeaf:                assetLeaf,
-		MultiverseRoot:           multiverseRoot,
-		MultiverseInclusionProof: multiverseProof,
+	return &wrpc.ListAccountsResponse{
+		Accounts: fn.Map(accounts, marshalAccount),
 	}, nil
 }
 
-// QueryProof attempts to query for an issuance or transfer proof for a given
-// asset based on its UniverseKey. A UniverseKey is composed of the Universe ID
-// (asset_id/group_key) and also a leaf key (outpoint || script_key). If found,
-// the target universe proof leaf is returned in addition to inclusion proofs
-// for the Universe and Multiverse MS-SMTs. This allows a caller to verify the
-// known Universe root, Multiverse root, and transition or issuance proof for
-// the target asset.
-func (r *rpcServer) QueryProof(ctx context.Context,
-	req *unirpc.UniverseKey) (*unirpc.AssetProofResponse, error) {
+// marshalAccount converts an asset account into its RPC counterpart.
+func marshalAccount(acct account.Account) *wrpc.Account {
+	return &wrpc.Account{
+		Name:      acct.Name,
+		KeyFamily: uint32(acct.KeyFamily),
+		CreatedAt: acct.CreatedAt.Unix(),
+		Tenant:    acct.Tenant,
+	}
+}
 
-	universeID, leafKey, err := unmarshalUniverseKey(req)
+// parseIssuerPolicy creates the issuer policy script tree of the given holder
+// and issuer keys.
+func parseIssuerPolicy(holderKeyBytes,
+	issuerKeyBytes []byte) (*tapscript.IssuerPolicyScriptTree, error) {
+
+	holderKey, err := btcec.ParsePubKey(holderKeyBytes)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid holder key: %w", err)
 	}
 
-	firstProof, err := r.queryProof(ctx, universeID, leafKey)
+	issuerKey, err := btcec.ParsePubKey(issuerKeyBytes)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid issuer key: %w", err)
 	}
 
-	return r.marshalUniverseProofLeaf(ctx, req, firstProof)
+	return tapscript.NewIssuerPolicyScriptTree(holderKey, issuerKey)
 }
 
-// queryProof attempts to query for an issuance or transfer proof for a given
-// asset based on its UniverseKey. A UniverseKey is composed of the Universe ID
-// (asset_id/group_key) and also a leaf key (outpoint || script_key).
-func (r *rpcServer) queryProof(ctx context.Context, uniID universe.Identifier,
-	leafKey universe.LeafKey) (*universe.Proof, error) {
-
-	rpcsLog.Tracef("[QueryProof]: fetching proof at (uniID=%v, "+
-		"leafKey=%x)", uniID.StringForLog(), leafKey.UniverseKey())
+// NewIssuerPolicyScriptKey creates the script key of a regulated asset that is
+// controlled by both its holder and its issuer, and declares it to the wallet.
+func (r *rpcServer) NewIssuerPolicyScriptKey(ctx context.Context,
+	in *wrpc.NewIssuerPolicyScriptKeyRequest) (
+	*wrpc.NewIssuerPolicyScriptKeyResponse, error) {
 
-	// Retrieve proof export config for the given universe.
-	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	tree, err := parseIssuerPolicy(in.HolderKey, in.IssuerKey)
 	if err != nil {
 		return nil, err
 	}
 
-	var candidateIDs []universe.Identifier
-
-	if uniID.ProofType == universe.ProofTypeUnspecified {
-		// If the proof type is unspecified, then we'll attempt to
-		// retrieve both the issuance and transfer proofs. We gather the
-		// corresponding universe IDs into a candidate set.
-		uniID.ProofType = universe.ProofTypeIssuance
-		if syncConfigs.IsSyncExportEnabled(uniID) {
-			candidateIDs = append(candidateIDs, uniID)
-		}
-
-		uniID.ProofType = universe.ProofTypeTransfer
-		if syncConfigs.IsSyncExportEnabled(uniID) {
-			candidateIDs = append(candidateIDs, uniID)
-		}
-	} else {
-		// Otherwise, we'll only attempt to retrieve the proof for the
-		// specified proof type. But first we'll check that proof export
-		// is enabled for the given universe.
-		if !syncConfigs.IsSyncExportEnabled(uniID) {
-			return nil, fmt.Errorf("proof export is disabled for " +
-				"the given universe")
-		}
-
-		candidateIDs = append(candidateIDs, uniID)
+	// The script key contains scripts, so we need to declare it for the
+	// wallet to recognize assets sent to it.
+	scriptKey := tree.ScriptKey()
+	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, scriptKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting script key: %w", err)
 	}
 
-	// If no candidate IDs were applicable then our config must have
-	// disabled proof export for the given universe.
-	if len(candidateIDs) == 0 {
-		return nil, fmt.Errorf("proof export is disabled for the " +
-			"given universe")
-	}
+	return &wrpc.NewIssuerPolicyScriptKeyResponse{
+		ScriptKey: taprpc.MarshalScriptKey(scriptKey),
+	}, nil
+}
 
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
+// SignIssuerPolicyPsbt signs a virtual PSBT that spends assets locked to an
+// issuer policy script key with all keys of the policy the wallet controls.
+func (r *rpcServer) SignIssuerPolicyPsbt(ctx context.Context,
+	in *wrpc.SignIssuerPolicyPsbtRequest) (
+	*wrpc.SignIssuerPolicyPsbtResponse, error) {
+
+	tree, err := parseIssuerPolicy(in.HolderKey, in.IssuerKey)
+	if err != nil {
 		return nil, err
 	}
 
-	// Attempt to retrieve the proof given the candidate set of universe
-	// IDs.
-	var proofs []*universe.Proof
-	for i := range candidateIDs {
-		candidateID := candidateIDs[i]
+	var path tapscript.IssuerPolicyPath
+	switch in.Path {
+	case wrpc.IssuerPolicyPath_ISSUER_POLICY_PATH_CO_SIGN:
+		path = tapscript.IssuerPolicyCoSign
 
-		proofs, err = r.cfg.UniverseArchive.FetchProofLeaf(
-			ctx, candidateID, leafKey,
-		)
-		if err != nil {
-			if errors.Is(err, universe.ErrNoUniverseProofFound) {
-				continue
-			}
+	case wrpc.IssuerPolicyPath_ISSUER_POLICY_PATH_CLAWBACK:
+		path = tapscript.IssuerPolicyClawback
 
-			rpcsLog.Debugf("[QueryProof]: error querying for "+
-				"proof at (uniID=%v, leafKey=%x)",
-				uniID.StringForLog(), leafKey.UniverseKey())
-			return nil, err
-		}
+	default:
+		return nil, fmt.Errorf("unknown issuer policy path: %v",
+			in.Path)
+	}
 
-		// At this point we've found a proof, so we'll break out of the
-		// loop. We don't need to attempt to retrieve a proof for any
-		// other candidate IDs.
-		break
+	vPkt, err := tappsbt.Decode(in.Psbt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding packet: %w", err)
 	}
 
-	if len(proofs) == 0 {
-		return nil, universe.ErrNoUniverseProofFound
+	requiredKeys, err := tree.RequiredKeys(path)
+	if err != nil {
+		return nil, err
 	}
 
-	// TODO(roasbeef): query may return multiple proofs, if allow key to
-	// not be fully specified
-	firstProof := proofs[0]
+	// We sign with all keys of the path that belong to our wallet.
+	var signingKeys []keychain.KeyDescriptor
+	for _, key := range requiredKeys {
+		keyLoc, err := r.cfg.AssetWallet.FetchInternalKeyLocator(
+			ctx, key,
+		)
+		switch {
+		case errors.Is(err, address.ErrInternalKeyNotFound):
+			continue
 
-	rpcsLog.Tracef("[QueryProof]: found proof at (uniID=%v, "+
-		"leafKey=%x)", uniID.StringForLog(), leafKey.UniverseKey())
+		case err != nil:
+			return nil, fmt.Errorf("error fetching key locator: %w",
+				err)
+		}
 
-	return firstProof, nil
-}
+		// The issuer refuses to co-sign the transfer of assets locked
+		// to a frozen script key. A clawback is still possible.
+		if key.IsEqual(tree.IssuerKey) &&
+			path == tapscript.IssuerPolicyCoSign {
 
-// unmarshalUniverseKey unmarshals a universe key from the RPC form.
-func unmarshalUniverseKey(key *unirpc.UniverseKey) (universe.Identifier,
-	universe.LeafKey, error) {
+			err := r.checkNotFrozen(ctx, tree.ScriptKey().PubKey)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	var (
-		uniID  = universe.Identifier{}
-		uniKey = universe.LeafKey{}
-		err    error
-	)
+		signingKeys = append(signingKeys, keychain.KeyDescriptor{
+			KeyLocator: keyLoc,
+			PubKey:     key,
+		})
+	}
 
-	if key == nil {
-		return uniID, uniKey, fmt.Errorf("universe key cannot be nil")
+	if len(signingKeys) == 0 {
+		return nil, fmt.Errorf("wallet doesn't control any key of the "+
+			"issuer policy's %v path", path)
 	}
 
-	uniID, err = UnmarshalUniID(key.Id)
+	fullySigned, err := tapsend.SignIssuerPolicyPacket(
+		vPkt, tree, path, signingKeys, r.cfg.VirtualTxSigner,
+		&WitnessValidatorV0{},
+	)
 	if err != nil {
-		return uniID, uniKey, err
+		return nil, fmt.Errorf("error signing packet: %w", err)
 	}
 
-	uniKey, err = unmarshalLeafKey(key.LeafKey)
+	signedPsbtBytes, err := serialize(vPkt)
 	if err != nil {
-		return uniID, uniKey, err
+		return nil, fmt.Errorf("error serializing packet: %w", err)
 	}
 
-	return uniID, uniKey, nil
+	return &wrpc.SignIssuerPolicyPsbtResponse{
+		SignedPsbt:  signedPsbtBytes,
+		FullySigned: fullySigned,
+	}, nil
 }
 
-// unmarshalAssetLeaf unmarshals an asset leaf from the RPC form.
-func unmarshalAssetLeaf(leaf *unirpc.AssetLeaf) (*universe.Leaf, error) {
-	// We'll just pull the asset details from the serialized issuance proof
-	// itself.
-	var proofAsset asset.Asset
-	assetRecord := proof.AssetLeafRecord(&proofAsset)
-	err := proof.SparseDecode(bytes.NewReader(leaf.Proof), assetRecord)
-	if err != nil {
-		return nil, err
+// checkNotFrozen returns freeze.ErrFrozen if the given script key is frozen.
+func (r *rpcServer) checkNotFrozen(ctx context.Context,
+	scriptKey *btcec.PublicKey) error {
+
+	frozen, err := r.cfg.Freezes.FetchFreeze(ctx, scriptKey)
+	switch {
+	case errors.Is(err, freeze.ErrNotFrozen):
+		return nil
+
+	case err != nil:
+		return fmt.Errorf("error checking freeze: %w", err)
 	}
 
-	// TODO(roasbeef): double check posted file format everywhere
-	//  * raw proof, or within file?
+	if frozen.Reason == "" {
+		return fmt.Errorf("%w: refusing to co-sign transfer",
+			freeze.ErrFrozen)
+	}
 
-	return &universe.Leaf{
-		GenesisWithGroup: universe.GenesisWithGroup{
-			Genesis:  proofAsset.Genesis,
-			GroupKey: proofAsset.GroupKey,
-		},
-		RawProof: leaf.Proof,
-		Asset:    &proofAsset,
-		Amt:      proofAsset.Amount,
-	}, nil
+	return fmt.Errorf("%w (%s): refusing to co-sign transfer",
+		freeze.ErrFrozen, frozen.Reason)
 }
 
-// InsertProof attempts to insert a new issuance or transfer proof into the
-// Universe tree specified by the UniverseKey. If valid, then the proof is
-// inserted into the database, with a new Universe root returned for the updated
-// asset_id/group_key.
-func (r *rpcServer) InsertProof(ctx context.Context,
-	req *unirpc.AssetProof) (*unirpc.AssetProofResponse, error) {
+// FreezeScriptKey freezes an issuer policy script key, which makes the issuer
+// refuse to co-sign regular transfers of the assets locked to it.
+func (r *rpcServer) FreezeScriptKey(ctx context.Context,
+	in *wrpc.FreezeScriptKeyRequest) (*wrpc.FreezeScriptKeyResponse,
+	error) {
 
-	universeID, leafKey, err := unmarshalUniverseKey(req.Key)
+	scriptKey, err := parseUserKey(in.ScriptKey)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid script key: %w", err)
 	}
 
-	assetLeaf, err := unmarshalAssetLeaf(req.AssetLeaf)
+	frozen, err := r.cfg.Freezes.FreezeScriptKey(
+		ctx, scriptKey, in.Reason,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// If universe proof type unspecified, set based on the provided asset
-	// proof.
-	if universeID.ProofType == universe.ProofTypeUnspecified {
-		universeID.ProofType, err = universe.NewProofTypeFromAsset(
-			assetLeaf.Asset,
-		)
-		if err != nil {
-			return nil, err
-		}
-	}
+	return &wrpc.FreezeScriptKeyResponse{
+		Freeze: marshalScriptKeyFreeze(*frozen),
+	}, nil
+}
 
-	// Ensure that the new proof is of the correct type for the target
-	// universe.
-	err = universe.ValidateProofUniverseType(assetLeaf.Asset, universeID)
+// UnfreezeScriptKey unfreezes a frozen issuer policy script key.
+func (r *rpcServer) UnfreezeScriptKey(ctx context.Context,
+	in *wrpc.UnfreezeScriptKeyRequest) (*wrpc.UnfreezeScriptKeyResponse,
+	error) {
+
+	scriptKey, err := parseUserKey(in.ScriptKey)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid script key: %w", err)
 	}
 
-	// Ensure proof insert is enabled for the given universe.
-	syncConfigs, err := r.cfg.UniverseFederation.QuerySyncConfigs(ctx)
+	err = r.cfg.Freezes.UnfreezeScriptKey(ctx, scriptKey)
 	if err != nil {
 		return nil, err
 	}
 
-	if !syncConfigs.IsSyncInsertEnabled(universeID) {
-		return nil, fmt.Errorf("proof insert is disabled for the " +
-			"given universe")
-	}
-
-	// Check the rate limiter to see if we need to wait at all. If not then
-	// this'll be a noop.
-	if err = r.proofQueryRateLimiter.Wait(ctx); err != nil {
-		return nil, err
-	}
+	return &wrpc.UnfreezeScriptKeyResponse{}, nil
+}
 
-	rpcsLog.Debugf("[InsertProof]: inserting proof at "+
-		"(universeID=%v, leafKey=%x)", universeID.StringForLog(),
-		leafKey.UniverseKey())
+// ListFrozenScriptKeys lists all frozen issuer policy script keys.
+func (r *rpcServer) ListFrozenScriptKeys(ctx context.Context,
+	_ *wrpc.ListFrozenScriptKeysRequest) (
+	*wrpc.ListFrozenScriptKeysResponse, error) {
 
-	newUniverseState, err := r.cfg.UniverseArchive.UpsertProofLeaf(
-		ctx, universeID, leafKey, assetLeaf,
-	)
+	freezes, err := r.cfg.Freezes.ListFreezes(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error listing freezes: %w", err)
 	}
 
-	universeRootHash := newUniverseState.UniverseRoot.NodeHash()
-	rpcsLog.Debugf("[InsertProof]: proof inserted, new universe root: %x",
-		universeRootHash[:])
-
-	return r.marshalUniverseProofLeaf(ctx, req.Key, newUniverseState)
+	return &wrpc.ListFrozenScriptKeysResponse{
+		Freezes: fn.Map(freezes, marshalScriptKeyFreeze),
+	}, nil
 }
 
-// PushProof attempts to query the local universe for a proof specified by a
-// UniverseKey. If found, a connection is made to a remote Universe server to
-// attempt to upload the asset leaf.
-func (r *rpcServer) PushProof(ctx context.Context,
-	req *unirpc.PushProofRequest) (*unirpc.PushProofResponse, error) {
-
-	switch {
-	case req.Server == nil:
-		return nil, fmt.Errorf("remote Universe must be specified")
-
-	case req.Key == nil:
-		return nil, fmt.Errorf("universe key must be specified")
+// marshalScriptKeyFreeze converts a script key freeze into its RPC
+// counterpart.
+func marshalScriptKeyFreeze(frozen freeze.Freeze) *wrpc.ScriptKeyFreeze {
+	return &wrpc.ScriptKeyFreeze{
+		ScriptKey: frozen.ScriptKey.SerializeCompressed(),
+		Reason:    frozen.Reason,
+		FrozenAt:  frozen.FrozenAt.Unix(),
+	}
+}
 
-	case req.Server.Host == "" && req.Server.Id == 0:
-		return nil, fmt.Errorf("remote Universe must be specified")
+// parseMuSig2Participants parses the given participant keys and tapscript
+// root of a MuSig2 script key.
+func parseMuSig2Participants(rawKeys [][]byte,
+	tapscriptRoot []byte) ([]*btcec.PublicKey, error) {
 
-	case req.Server.Host != "" && req.Server.Id != 0:
-		return nil, fmt.Errorf("cannot specify both universe host " +
-			"and id")
+	keys := make([]*btcec.PublicKey, len(rawKeys))
+	for idx, rawKey := range rawKeys {
+		var err error
+		keys[idx], err = btcec.ParsePubKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant key %d: %w",
+				idx, err)
+		}
 	}
 
-	remoteUniAddr := unmarshalUniverseServer(req.Server)
-	universeID, leafKey, err := unmarshalUniverseKey(req.Key)
-	if err != nil {
-		return nil, err
+	if len(tapscriptRoot) != 0 && len(tapscriptRoot) != sha256.Size {
+		return nil, fmt.Errorf("tapscript root must be %d bytes",
+			sha256.Size)
 	}
 
-	// Try to fetch the requested proof from the local universe.
-	localProof, err := r.queryProof(ctx, universeID, leafKey)
-	if err != nil {
-		return nil, err
-	}
-	if localProof.Leaf == nil {
-		return nil, fmt.Errorf("proof not found in local universe")
-	}
+	return keys, nil
+}
 
-	// Make sure that we aren't trying to push the proof to ourself, and
-	// then attempt to push the proof.
-	err = CheckFederationServer(
-		r.cfg.RuntimeID, universe.DefaultTimeout, remoteUniAddr,
+// NewMuSig2ScriptKey creates the script key that is the MuSig2 aggregate of
+// the given participant keys and declares it to the wallet.
+func (r *rpcServer) NewMuSig2ScriptKey(ctx context.Context,
+	in *wrpc.NewMuSig2ScriptKeyRequest) (*wrpc.NewMuSig2ScriptKeyResponse,
+	error) {
+
+	keys, err := parseMuSig2Participants(
+		in.ParticipantKeys, in.TapscriptRoot,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	remoteUni, err := NewRpcUniverseRegistrar(remoteUniAddr)
+	scriptKey, err := tapscript.MuSig2ScriptKey(keys, in.TapscriptRoot)
 	if err != nil {
 		return nil, err
 	}
 
-	rpcsLog.Debugf("[PushProof]: pushing proof to universe "+
-		"(universeID=%v, server=%v", universeID.StringForLog(),
-		remoteUniAddr)
-
-	_, err = remoteUni.UpsertProofLeaf(
-		ctx, universeID, leafKey, localProof.Leaf,
-	)
+	// None of our keys can sign for the aggregate key alone, so we need to
+	// declare it for the wallet to recognize assets sent to it.
+	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, scriptKey, true)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error inserting script key: %w", err)
 	}
 
-	rpcsLog.Debugf("[PushProof]: proof pushed to universe "+
-		"(universeID=%v, server=%v", universeID.StringForLog(),
-		remoteUniAddr)
-
-	return &unirpc.PushProofResponse{
-		Key: req.Key,
-	}, nil
-}
-
-// Info returns a set of information about the current state of the Universe.
-func (r *rpcServer) Info(ctx context.Context,
-	_ *unirpc.InfoRequest) (*unirpc.InfoResponse, error) {
-
-	return &unirpc.InfoResponse{
-		RuntimeId: r.cfg.RuntimeID,
+	return &wrpc.NewMuSig2ScriptKeyResponse{
+		ScriptKey: taprpc.MarshalScriptKey(scriptKey),
 	}, nil
 }
 
-// unmarshalUniverseSyncType maps an RPC universe sync type into a concrete
-// type.
-func unmarshalUniverseSyncType(
-	req unirpc.UniverseSyncMode) (universe.SyncType, error) {
-
-	switch req {
-	case unirpc.UniverseSyncMode_SYNC_FULL:
-		return universe.SyncFull, nil
+// muSig2Input returns the input with the given index of the virtual packet,
+// which must already contain the MuSig2 participant information.
+func muSig2Input(vPkt *tappsbt.VPacket, idx uint32) (*tappsbt.VInput,
+	error) {
 
-	case unirpc.UniverseSyncMode_SYNC_ISSUANCE_ONLY:
-		return universe.SyncIssuance, nil
+	if int(idx) >= len(vPkt.Inputs) {
+		return nil, fmt.Errorf("input index %d out of range", idx)
+	}
 
-	default:
-		return 0, fmt.Errorf("unknown sync type: %v", req)
+	vIn := vPkt.Inputs[idx]
+	if vIn.MuSig2 == nil {
+		return nil, fmt.Errorf("input %d has no MuSig2 participants",
+			idx)
 	}
+
+	return vIn, nil
 }
 
-// unmarshalSyncTargets maps an RPC sync target into a concrete type.
-func unmarshalSyncTargets(
-	targets []*unirpc.SyncTarget) ([]universe.Identifier, error) {
+// localMuSig2Key returns the participant key of a MuSig2 input that belongs to
+// our wallet.
+func (r *rpcServer) localMuSig2Key(ctx context.Context,
+	muSig2 *tappsbt.MuSig2Info) (keychain.KeyDescriptor, error) {
 
-	uniIDs := make([]universe.Identifier, 0, len(targets))
-	for _, target := range targets {
-		uniID, err := UnmarshalUniID(target.Id)
-		if err != nil {
-			return nil, err
+	for _, key := range muSig2.ParticipantKeys {
+		keyLoc, err := r.cfg.AssetWallet.FetchInternalKeyLocator(
+			ctx, key,
+		)
+		switch {
+		case errors.Is(err, address.ErrInternalKeyNotFound):
+			continue
+
+		case err != nil:
+			return keychain.KeyDescriptor{}, fmt.Errorf("error "+
+				"fetching key locator: %w", err)
 		}
-		uniIDs = append(uniIDs, uniID)
+
+		return keychain.KeyDescriptor{
+			KeyLocator: keyLoc,
+			PubKey:     key,
+		}, nil
 	}
 
-	return uniIDs, nil
+	return keychain.KeyDescriptor{}, fmt.Errorf("wallet doesn't control " +
+		"any participant key of the MuSig2 input")
 }
 
-// marshalUniverseDiff marshals a universe diff into the RPC form.
-func (r *rpcServer) marshalUniverseDiff(ctx context.Context,
-	uniDiff []universe.AssetSyncDiff) (*unirpc.SyncResponse, error) {
+// CreateMuSig2Nonce starts a MuSig2 signing session for an input of a funded
+// virtual PSBT that spends an asset locked to a MuSig2 script key.
+func (r *rpcServer) CreateMuSig2Nonce(ctx context.Context,
+	in *wrpc.CreateMuSig2NonceRequest) (*wrpc.CreateMuSig2NonceResponse,
+	error) {
 
-	resp := &unirpc.SyncResponse{
-		SyncedUniverses: make([]*unirpc.SyncedUniverse, 0, len(uniDiff)),
+	vPkt, err := tappsbt.Decode(in.VirtualPsbt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding packet: %w", err)
 	}
 
-	err := fn.ForEachErr(uniDiff, func(diff universe.AssetSyncDiff) error {
-		oldUniRoot, err := marshalUniverseRoot(diff.OldUniverseRoot)
-		if err != nil {
-			return fmt.Errorf("unable to marshal old uni "+
-				"root: %w", err)
-		}
-		newUniRoot, err := marshalUniverseRoot(diff.NewUniverseRoot)
+	if int(in.InputIndex) >= len(vPkt.Inputs) {
+		return nil, fmt.Errorf("input index %d out of range",
+			in.InputIndex)
+	}
+	vIn := vPkt.Inputs[in.InputIndex]
+
+	// The first participant adds the participant keys to the input, all
+	// others can rely on the keys being present.
+	if len(in.ParticipantKeys) > 0 {
+		keys, err := parseMuSig2Participants(
+			in.ParticipantKeys, in.TapscriptRoot,
+		)
 		if err != nil {
-			return fmt.Errorf("unable to marshal new unit "+
-				"root: %w", err)
+			return nil, err
 		}
 
-		leaves := make([]*unirpc.AssetLeaf, len(diff.NewLeafProofs))
-		for i, leaf := range diff.NewLeafProofs {
-			decDisplay, err := r.DecDisplayForAssetID(
-				ctx, leaf.ID(),
-			)
-			if err != nil {
-				return err
+		if vIn.MuSig2 == nil {
+			vIn.MuSig2 = &tappsbt.MuSig2Info{
+				ParticipantKeys: keys,
+				TapscriptRoot:   in.TapscriptRoot,
 			}
+		}
 
-			leaves[i], err = r.marshalAssetLeaf(
-				ctx, leaf, decDisplay,
-			)
-			if err != nil {
-				return err
-			}
+		sameKeys := len(keys) == len(vIn.MuSig2.ParticipantKeys) &&
+			fn.All(keys, vIn.MuSig2.HasParticipant)
+		if !sameKeys || !bytes.Equal(
+			in.TapscriptRoot, vIn.MuSig2.TapscriptRoot,
+		) {
+
+			return nil, fmt.Errorf("participants don't match the " +
+				"participants of the input")
 		}
+	}
 
-		resp.SyncedUniverses = append(
-			resp.SyncedUniverses, &unirpc.SyncedUniverse{
-				OldAssetRoot:   oldUniRoot,
-				NewAssetRoot:   newUniRoot,
-				NewAssetLeaves: leaves,
-			},
+	vIn, err = muSig2Input(vPkt, in.InputIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	muSig2 := vIn.MuSig2
+	if !tapscript.IsMuSig2ScriptKey(
+		vIn.Asset().ScriptKey.PubKey, muSig2.ParticipantKeys,
+		muSig2.TapscriptRoot,
+	) {
+
+		return nil, fmt.Errorf("participant keys don't aggregate to " +
+			"the script key of the input")
+	}
+
+	localKey, err := r.localMuSig2Key(ctx, muSig2)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := fn.Map(
+		muSig2.ParticipantKeys, func(key *btcec.PublicKey) []byte {
+			return key.SerializeCompressed()
+		},
+	)
+	tweakOpt := lndclient.MuSig2TaprootTweakOpt(nil, true)
+	if len(muSig2.TapscriptRoot) > 0 {
+		tweakOpt = lndclient.MuSig2TaprootTweakOpt(
+			muSig2.TapscriptRoot, false,
 		)
-		return nil
-	})
+	}
+
+	session, err := r.cfg.SignerLnd.Signer.MuSig2CreateSession(
+		ctx, input.MuSig2Version100RC2, &localKey.KeyLocator, signers,
+		tweakOpt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating MuSig2 session: %w", err)
+	}
+
+	err = muSig2.SetPubNonce(localKey.PubKey, session.PublicNonce)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	vPktBytes, err := serialize(vPkt)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing packet: %w", err)
+	}
+
+	return &wrpc.CreateMuSig2NonceResponse{
+		VirtualPsbt: vPktBytes,
+		SessionId:   session.SessionID[:],
+		PubNonce:    session.PublicNonce[:],
+	}, nil
 }
 
-// SyncUniverse takes host information for a remote Universe server, then
-// attempts to synchronize either only the set of specified asset_ids, or all
-// assets if none are specified. The sync process will attempt to query for the
-// latest known root for each asset, performing tree based reconciliation to
-// arrive at a new shared root.
-func (r *rpcServer) SyncUniverse(ctx context.Context,
-	req *unirpc.SyncRequest) (*unirpc.SyncResponse, error) {
+// SignMuSig2Input creates the MuSig2 partial signature of the wallet's
+// participant key for an input of a virtual PSBT.
+func (r *rpcServer) SignMuSig2Input(ctx context.Context,
+	in *wrpc.SignMuSig2InputRequest) (*wrpc.SignMuSig2InputResponse,
+	error) {
 
-	// TODO(roasbeef): have another layer, only allow single outstanding
-	// sync request per host?
+	vPkt, err := tappsbt.Decode(in.VirtualPsbt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding packet: %w", err)
+	}
 
-	syncMode, err := unmarshalUniverseSyncType(req.SyncMode)
+	vIn, err := muSig2Input(vPkt, in.InputIndex)
+	if err != nil {
+		return nil, err
+	}
+	muSig2 := vIn.MuSig2
+
+	var sessionID [32]byte
+	if len(in.SessionId) != len(sessionID) {
+		return nil, fmt.Errorf("session ID must be %d bytes",
+			len(sessionID))
+	}
+	copy(sessionID[:], in.SessionId)
+
+	localKey, err := r.localMuSig2Key(ctx, muSig2)
+	if err != nil {
+		return nil, err
+	}
+
+	localNonce, ok := muSig2.PubNonce(localKey.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("input has no nonce of the wallet's " +
+			"participant key")
+	}
+
+	combinedNonce, ok, err := muSig2.CombinedNonce()
+	if err != nil {
+		return nil, fmt.Errorf("error combining nonces: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("not all participants submitted their " +
+			"nonce")
+	}
+
+	var otherNonces [][musig2.PubNonceSize]byte
+	for _, nonce := range muSig2.PubNonces {
+		if !nonce.ParticipantKey.IsEqual(localKey.PubKey) {
+			otherNonces = append(otherNonces, nonce.Nonce)
+		}
+	}
+
+	signer := r.cfg.SignerLnd.Signer
+	_, err = signer.MuSig2RegisterNonces(ctx, sessionID, otherNonces)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse sync type: %w", err)
+		return nil, fmt.Errorf("error registering nonces: %w", err)
 	}
-	syncTargets, err := unmarshalSyncTargets(req.SyncTargets)
+
+	sigHash, err := tapsend.VirtualInputSigHash(vPkt, in.InputIndex)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse sync targets: %w", err)
+		return nil, fmt.Errorf("error computing sighash: %w", err)
 	}
 
-	uniAddr := universe.NewServerAddrFromStr(req.UniverseHost)
+	// We combine the signatures ourselves, so the session can be removed
+	// right away, which also makes sure its nonce is never reused.
+	rawSig, err := signer.MuSig2Sign(ctx, sessionID, sigHash, true)
+	if err != nil {
+		return nil, fmt.Errorf("error signing: %w", err)
+	}
 
-	// Obtain the general and universe specific federation sync configs.
-	queryFedSyncConfigs := r.cfg.FederationDB.QueryFederationSyncConfigs
-	globalConfigs, uniSyncConfigs, err := queryFedSyncConfigs(ctx)
+	partialSig := &musig2.PartialSignature{}
+	err = partialSig.Decode(bytes.NewReader(rawSig))
 	if err != nil {
-		return nil, fmt.Errorf("unable to query federation sync "+
-			"config(s): %w", err)
+		return nil, fmt.Errorf("invalid partial signature: %w", err)
 	}
 
-	syncConfigs := universe.SyncConfigs{
-		GlobalSyncConfigs: globalConfigs,
-		UniSyncConfigs:    uniSyncConfigs,
+	if !tapscript.VerifyMuSig2PartialSig(
+		partialSig, localNonce, combinedNonce, muSig2.ParticipantKeys,
+		localKey.PubKey, muSig2.TapscriptRoot, sigHash,
+	) {
+
+		return nil, fmt.Errorf("created partial signature is invalid")
 	}
 
-	// TODO(roasbeef): add layer of indirection in front of?
-	//  * just interface interaction
-	// TODO(ffranr): Sync via the FederationEnvoy rather than syncer.
-	universeDiff, err := r.cfg.UniverseSyncer.SyncUniverse(
-		ctx, uniAddr, syncMode, syncConfigs, syncTargets...,
-	)
+	err = muSig2.SetPartialSig(localKey.PubKey, partialSig)
 	if err != nil {
-		return nil, fmt.Errorf("unable to sync universe: %w", err)
+		return nil, err
 	}
 
-	return r.marshalUniverseDiff(ctx, universeDiff)
+	vPktBytes, err := serialize(vPkt)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing packet: %w", err)
+	}
+
+	return &wrpc.SignMuSig2InputResponse{
+		VirtualPsbt: vPktBytes,
+		PartialSig:  rawSig,
+	}, nil
 }
 
-func marshalUniverseServer(
-	server universe.ServerAddr) *unirpc.UniverseFederationServer {
+// CombineMuSig2Sigs combines the MuSig2 partial signatures of all participants
+// of an input of a virtual PSBT into its final witness.
+func (r *rpcServer) CombineMuSig2Sigs(_ context.Context,
+	in *wrpc.CombineMuSig2SigsRequest) (*wrpc.CombineMuSig2SigsResponse,
+	error) {
 
-	return &unirpc.UniverseFederationServer{
-		Host: server.HostStr(),
-		Id:   int32(server.ID),
+	vPkt, err := tappsbt.Decode(in.VirtualPsbt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding packet: %w", err)
 	}
-}
-
-// ListFederationServers lists the set of servers that make up the federation
-// of the local Universe server. These servers are used to push out new proofs,
-// and also periodically call sync new proofs from the remote server.
-func (r *rpcServer) ListFederationServers(ctx context.Context,
-	_ *unirpc.ListFederationServersRequest) (
-	*unirpc.ListFederationServersResponse, error) {
 
-	uniServers, err := r.cfg.FederationDB.UniverseServers(ctx)
+	vIn, err := muSig2Input(vPkt, in.InputIndex)
 	if err != nil {
 		return nil, err
 	}
+	muSig2 := vIn.MuSig2
 
-	return &unirpc.ListFederationServersResponse{
-		Servers: fn.Map(uniServers, marshalUniverseServer),
-	}, nil
-}
+	combinedNonce, ok, err := muSig2.CombinedNonce()
+	if err != nil {
+		return nil, fmt.Errorf("error combining nonces: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("not all participants submitted their " +
+			"nonce")
+	}
 
-func unmarshalUniverseServer(
-	server *unirpc.UniverseFederationServer) universe.ServerAddr {
+	partialSigs := make(
+		[]*musig2.PartialSignature, 0, len(muSig2.ParticipantKeys),
+	)
+	for _, key := range muSig2.ParticipantKeys {
+		partialSig, ok := muSig2.PartialSig(key)
+		if !ok {
+			return nil, fmt.Errorf("participant %x didn't "+
+				"submit a partial signature",
+				key.SerializeCompressed())
+		}
 
-	return universe.NewServerAddr(int64(server.Id), server.Host)
-}
+		partialSigs = append(partialSigs, partialSig)
+	}
 
-// AddFederationServer adds a new server to the federation of the local
-// Universe server. Once a server is added, this call can also optionally be
-// used to trigger a sync of the remote server.
-func (r *rpcServer) AddFederationServer(ctx context.Context,
-	req *unirpc.AddFederationServerRequest,
-) (*unirpc.AddFederationServerResponse, error) {
+	sigHash, err := tapsend.VirtualInputSigHash(vPkt, in.InputIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error computing sighash: %w", err)
+	}
 
-	serversToAdd := fn.Map(req.Servers, unmarshalUniverseServer)
+	sig, err := tapscript.CombineMuSig2Sigs(
+		muSig2.ParticipantKeys, muSig2.TapscriptRoot, sigHash,
+		combinedNonce, partialSigs,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	for idx := range serversToAdd {
-		server := serversToAdd[idx]
+	err = tapsend.SetVirtualWitness(
+		vPkt, in.InputIndex, wire.TxWitness{sig.Serialize()},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error setting witness: %w", err)
+	}
 
-		// Before we add the server as a federation member, we check
-		// that we can actually connect to it and that it isn't
-		// ourselves.
-		err := CheckFederationServer(
-			r.cfg.RuntimeID, universe.DefaultTimeout, server,
+	// Once every input has a witness, we make sure the virtual transaction
+	// is valid as a whole.
+	_, witnesses, err := tapsend.VirtualWitnesses(vPkt)
+	if err != nil {
+		return nil, err
+	}
+
+	fullySigned := fn.All(witnesses, func(w wire.TxWitness) bool {
+		return len(w) > 0
+	})
+	if fullySigned {
+		err := tapsend.ValidateVirtualWitnesses(
+			vPkt, &WitnessValidatorV0{},
 		)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error validating packet: %w",
+				err)
 		}
 	}
 
-	err := r.cfg.UniverseFederation.AddServer(serversToAdd...)
+	vPktBytes, err := serialize(vPkt)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error serializing packet: %w", err)
 	}
 
-	return &unirpc.AddFederationServerResponse{}, nil
+	return &wrpc.CombineMuSig2SigsResponse{
+		VirtualPsbt: vPktBytes,
+		FullySigned: fullySigned,
+	}, nil
 }
 
-// DeleteFederationServer removes a server from the federation of the local
-// Universe server.
-func (r *rpcServer) DeleteFederationServer(ctx context.Context,
-	req *unirpc.DeleteFederationServerRequest,
-) (*unirpc.DeleteFederationServerResponse, error) {
+// unmarshalHashLock creates the hash lock script tree of the given RPC hash
+// lock parameters.
+func unmarshalHashLock(
+	hashLock *wrpc.HashLock) (*tapscript.HashLockScriptTree, error) {
 
-	serversToDel := fn.Map(req.Servers, unmarshalUniverseServer)
+	if hashLock == nil {
+		return nil, fmt.Errorf("hash lock must be set")
+	}
 
-	// Remove the servers from the proofs sync log. This is necessary before
-	// we can remove the servers from the database because of a foreign
-	// key constraint.
-	err := r.cfg.FederationDB.DeleteProofsSyncLogEntries(
-		ctx, serversToDel...,
+	if len(hashLock.PaymentHash) != sha256.Size {
+		return nil, fmt.Errorf("payment hash must be %d bytes",
+			sha256.Size)
+	}
+
+	claimKey, err := btcec.ParsePubKey(hashLock.ClaimKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim key: %w", err)
+	}
+
+	refundKey, err := btcec.ParsePubKey(hashLock.RefundKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refund key: %w", err)
+	}
+
+	return tapscript.NewHashLockScriptTree(
+		[sha256.Size]byte(hashLock.PaymentHash), claimKey, refundKey,
+		hashLock.CsvDelay,
 	)
+}
+
+// NewHashLockScriptKey creates an HTLC-style script key and declares it to the
+// wallet.
+func (r *rpcServer) NewHashLockScriptKey(ctx context.Context,
+	in *wrpc.NewHashLockScriptKeyRequest) (
+	*wrpc.NewHashLockScriptKeyResponse, error) {
+
+	tree, err := unmarshalHashLock(in.HashLock)
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.cfg.FederationDB.RemoveServers(ctx, serversToDel...)
+	// The script key contains scripts, so we need to declare it for the
+	// wallet to recognize assets sent to it.
+	scriptKey := tree.ScriptKey()
+	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, scriptKey, true)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error inserting script key: %w", err)
 	}
 
-	return &unirpc.DeleteFederationServerResponse{}, nil
+	return &wrpc.NewHashLockScriptKeyResponse{
+		ScriptKey: taprpc.MarshalScriptKey(scriptKey),
+	}, nil
 }
 
-// SetFederationSyncConfig sets the configuration of the universe federation
-// sync.
-func (r *rpcServer) SetFederationSyncConfig(ctx context.Context,
-	req *unirpc.SetFederationSyncConfigRequest) (
-	*unirpc.SetFederationSyncConfigResponse, error) {
+// SweepHashLock sweeps all assets of the given asset ID that are locked to a
+// hash lock script key to a new script key of the wallet.
+func (r *rpcServer) SweepHashLock(ctx context.Context,
+	in *wrpc.SweepHashLockRequest) (*taprpc.SendAssetResponse, error) {
 
-	// Unmarshal global sync configs.
-	globalSyncConfig := make(
-		[]*universe.FedGlobalSyncConfig, len(req.GlobalSyncConfigs),
-	)
-	for i := range req.GlobalSyncConfigs {
-		config := req.GlobalSyncConfigs[i]
+	tree, err := unmarshalHashLock(in.HashLock)
+	if err != nil {
+		return nil, err
+	}
 
-		proofType, err := UnmarshalUniProofType(config.ProofType)
-		if err != nil {
-			return nil, fmt.Errorf("unable to unmarshal "+
-				"proof type: %w", err)
-		}
+	var path tapscript.HashLockPath
+	switch in.Path {
+	case wrpc.HashLockPath_HASH_LOCK_PATH_SUCCESS:
+		path = tapscript.HashLockSuccess
 
-		globalSyncConfig[i] = &universe.FedGlobalSyncConfig{
-			ProofType:       proofType,
-			AllowSyncInsert: config.AllowSyncInsert,
-			AllowSyncExport: config.AllowSyncExport,
+		if err := tree.CheckPreimage(in.Preimage); err != nil {
+			return nil, err
 		}
-	}
 
-	// Unmarshal asset (asset/asset group) specific sync configs.
-	assetSyncConfigs := make(
-		[]*universe.FedUniSyncConfig, len(req.AssetSyncConfigs),
-	)
-	for i := range req.AssetSyncConfigs {
-		assetSyncConfig := req.AssetSyncConfigs[i]
-		config, err := unmarshalAssetSyncConfig(assetSyncConfig)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse asset sync "+
-				"config: %w", err)
-		}
+	case wrpc.HashLockPath_HASH_LOCK_PATH_TIMEOUT:
+		path = tapscript.HashLockTimeout
 
-		assetSyncConfigs[i] = config
+	default:
+		return nil, fmt.Errorf("unknown hash lock path: %v", in.Path)
 	}
 
-	// Update asset (asset/asset group) specific sync configs.
-	err := r.cfg.FederationDB.UpsertFederationSyncConfig(
-		ctx, globalSyncConfig, assetSyncConfigs,
-	)
+	if len(in.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be %d bytes",
+			sha256.Size)
+	}
+	assetID := asset.ID(in.AssetId)
+
+	signingKey, err := tree.SigningKey(path)
 	if err != nil {
-		return nil, fmt.Errorf("unable to set federation sync "+
-			"config: %w", err)
+		return nil, err
 	}
 
-	return &unirpc.SetFederationSyncConfigResponse{}, nil
-}
+	keyLoc, err := r.cfg.AssetWallet.FetchInternalKeyLocator(
+		ctx, signingKey,
+	)
+	switch {
+	case errors.Is(err, address.ErrInternalKeyNotFound):
+		return nil, fmt.Errorf("wallet doesn't control the key of the "+
+			"hash lock's %v path", path)
 
-// QueryFederationSyncConfig queries the universe federation sync configuration
-// settings.
-func (r *rpcServer) QueryFederationSyncConfig(ctx context.Context,
-	_ *unirpc.QueryFederationSyncConfigRequest,
-) (*unirpc.QueryFederationSyncConfigResponse, error) {
+	case err != nil:
+		return nil, fmt.Errorf("error fetching key locator: %w", err)
+	}
 
-	// Obtain the general and universe specific federation sync configs.
-	queryFedSyncConfigs := r.cfg.FederationDB.QueryFederationSyncConfigs
-	globalConfigs, uniSyncConfigs, err := queryFedSyncConfigs(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("unable to query federation sync "+
-			"config(s): %w", err)
+	// The timeout path can only be used once the new asset is locked for
+	// the CSV delay.
+	var relativeLockTime uint64
+	if path == tapscript.HashLockTimeout {
+		relativeLockTime = uint64(tree.CsvDelay)
 	}
 
-	// Marshal the general sync config into the RPC form.
-	globalConfigRPC := make(
-		[]*unirpc.GlobalFederationSyncConfig, len(globalConfigs),
+	fundedVPkt, err := r.fundScriptKeySweep(
+		ctx, assetID, tree.ScriptKey().PubKey, 0, relativeLockTime,
 	)
-	for i := range globalConfigs {
-		globalConfig := globalConfigs[i]
+	if err != nil {
+		return nil, err
+	}
+	vPkt := fundedVPkt.VPacket
 
-		proofTypeRpc, err := MarshalUniProofType(globalConfig.ProofType)
-		if err != nil {
-			return nil, fmt.Errorf("unable to unmarshal "+
-				"proof type: %w", err)
+	// If we can't sign the sweep, we release the inputs again, so they can
+	// be swept later.
+	success := false
+	defer func() {
+		if !success {
+			r.releasePacketInputs(ctx, vPkt)
 		}
+	}()
 
-		globalConfigRPC[i] = &unirpc.GlobalFederationSyncConfig{
-			ProofType:       proofTypeRpc,
-			AllowSyncInsert: globalConfig.AllowSyncInsert,
-			AllowSyncExport: globalConfig.AllowSyncExport,
+	if path == tapscript.HashLockTimeout {
+		err := r.checkCsvExpired(ctx, vPkt, tree.CsvDelay)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// Marshal universe specific sync configs into the RPC form.
-	uniConfigRPCs := make(
-		[]*unirpc.AssetFederationSyncConfig, len(uniSyncConfigs),
+	keyDesc := keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     signingKey,
+	}
+	err = tapsend.SignHashLockPacket(
+		vPkt, tree, path, in.Preimage, keyDesc, r.cfg.VirtualTxSigner,
+		&WitnessValidatorV0{},
 	)
-	for i := range uniSyncConfigs {
-		uniSyncConfig := uniSyncConfigs[i]
-		uniConfigRPC, err := MarshalAssetFedSyncCfg(*uniSyncConfig)
-		if err != nil {
-			return nil, fmt.Errorf("unable to marshal universe "+
-				"specific federation sync config: %w", err)
-		}
-		uniConfigRPCs[i] = uniConfigRPC
+	if err != nil {
+		return nil, fmt.Errorf("error signing sweep: %w", err)
 	}
 
-	return &unirpc.QueryFederationSyncConfigResponse{
-		GlobalSyncConfigs: globalConfigRPC,
-		AssetSyncConfigs:  uniConfigRPCs,
+	resp, err := r.cfg.ChainPorter.RequestShipment(
+		tapfreighter.NewPreSignedParcel(
+			[]*tappsbt.VPacket{vPkt}, fundedVPkt.InputCommitments,
+			"",
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting delivery: %w", err)
+	}
+	success = true
+
+	parcel, err := marshalOutboundParcel(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling outbound parcel: %w",
+			err)
+	}
+
+	return &taprpc.SendAssetResponse{
+		Transfer: parcel,
 	}, nil
 }
 
-// ProveAssetOwnership creates an ownership proof embedded in an asset
-// transition proof. That ownership proof is a signed virtual transaction
-// spending the asset with a valid witness to prove the prover owns the keys
-// that can spend the asset.
-func (r *rpcServer) ProveAssetOwnership(ctx context.Context,
-	req *wrpc.ProveAssetOwnershipRequest) (*wrpc.ProveAssetOwnershipResponse,
-	error) {
-
-	if len(req.ScriptKey) == 0 {
-		return nil, fmt.Errorf("a valid script key must be specified")
-	}
+// checkCsvExpired returns an error if the sweep of any input of the given
+// packet couldn't be confirmed in the next block because the input hasn't been
+// confirmed for the given CSV delay yet.
+func (r *rpcServer) checkCsvExpired(ctx context.Context, vPkt *tappsbt.VPacket,
+	csvDelay uint32) error {
 
-	scriptKey, err := parseUserKey(req.ScriptKey)
+	height, err := r.cfg.ChainBridge.CurrentHeight(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("invalid script key: %w", err)
+		return fmt.Errorf("error fetching current height: %w", err)
 	}
 
-	if len(req.AssetId) != 32 {
-		return nil, fmt.Errorf("asset ID must be 32 bytes")
+	for idx, vIn := range vPkt.Inputs {
+		if vIn.Proof == nil {
+			return fmt.Errorf("input %d has no proof", idx)
+		}
+
+		spendableHeight := vIn.Proof.BlockHeight + csvDelay
+		if height+1 < spendableHeight {
+			return fmt.Errorf("input %d can only be swept through "+
+				"the timeout path from block %d", idx,
+				spendableHeight)
+		}
 	}
 
-	if len(req.Challenge) != 0 && len(req.Challenge) != 32 {
-		return nil, fmt.Errorf("challenge must be 32 bytes")
+	return nil
+}
+
+// fundScriptKeySweep funds a packet that sweeps all assets of the given asset
+// ID that are locked to the given script key to a new script key of the
+// wallet. The new asset is given the lock times that are required by the
+// script that is used to sweep the assets.
+func (r *rpcServer) fundScriptKeySweep(ctx context.Context, assetID asset.ID,
+	scriptKey *btcec.PublicKey, lockTime,
+	relativeLockTime uint64) (*tapfreighter.FundedVPacket, error) {
+
+	// We sweep all assets locked to the script key, so we first find out
+	// how many there are.
+	coins, err := r.cfg.AssetStore.ListEligibleCoins(
+		ctx, tapfreighter.CommitmentConstraints{
+			AssetSpecifier: asset.NewSpecifierFromId(assetID),
+			MinAmt:         1,
+			CoinSelectType: tapsend.ScriptTreesAllowed,
+			ScriptKey:      scriptKey,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing locked assets: %w", err)
 	}
 
 	var (
-		assetID  = fn.ToArray[asset.ID](req.AssetId)
-		outPoint *wire.OutPoint
+		amount       uint64
+		assetVersion = asset.V0
 	)
-
-	// The outpoint is optional when querying for a proof file. But if
-	// multiple proofs exist for the same assetID and script key, then an
-	// error will be returned and the outpoint needs to be specified to
-	// disambiguate.
-	if req.Outpoint != nil {
-		txid, err := chainhash.NewHash(req.Outpoint.Txid)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing outpoint: %w",
-				err)
-		}
-		outPoint = &wire.OutPoint{
-			Hash:  *txid,
-			Index: req.Outpoint.OutputIndex,
+	for _, coin := range coins {
+		amount += coin.Asset.Amount
+		if coin.Asset.Version > assetVersion {
+			assetVersion = coin.Asset.Version
 		}
 	}
 
-	proofBlob, err := r.cfg.ProofArchive.FetchProof(ctx, proof.Locator{
-		AssetID:   &assetID,
-		ScriptKey: *scriptKey,
-		OutPoint:  outPoint,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("cannot fetch proof: %w", err)
+	if amount == 0 {
+		return nil, fmt.Errorf("no assets locked to script key %x",
+			scriptKey.SerializeCompressed())
 	}
 
-	proofFile, err := proof.DecodeFile(proofBlob)
+	sweepScriptKey, err := r.cfg.AddrBook.NextScriptKey(
+		ctx, asset.TaprootAssetsKeyFamily,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("cannot decode proof: %w", err)
+		return nil, fmt.Errorf("error deriving script key: %w", err)
 	}
 
-	headerVerifier := tapgarden.GenHeaderVerifier(ctx, r.cfg.ChainBridge)
-	groupVerifier := tapgarden.GenGroupVerifier(ctx, r.cfg.MintingStore)
-	lastSnapshot, err := proofFile.Verify(
-		ctx, headerVerifier, proof.DefaultMerkleVerifier, groupVerifier,
-		r.cfg.ChainBridge.GenFileChainLookup(proofFile),
+	internalKey, err := r.cfg.AddrBook.NextInternalKey(
+		ctx, asset.TaprootAssetsKeyFamily,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("cannot verify proof: %w", err)
+		return nil, fmt.Errorf("error deriving internal key: %w", err)
 	}
 
-	inputAsset := lastSnapshot.Asset
-	inputCommitment, err := r.cfg.AssetStore.FetchCommitment(
-		ctx, inputAsset.ID(), lastSnapshot.OutPoint,
-		inputAsset.GroupKey, &inputAsset.ScriptKey, false,
+	vPkt := tappsbt.ForInteractiveSend(
+		assetID, amount, sweepScriptKey, lockTime, relativeLockTime, 0,
+		internalKey, assetVersion, &r.cfg.ChainParams,
+	)
+	fundedVPkt, err := r.cfg.AssetWallet.FundPacket(
+		ctx, &tapsend.FundingDescriptor{
+			AssetSpecifier: asset.NewSpecifierFromId(assetID),
+			Amount:         amount,
+			CoinSelectType: tapsend.ScriptTreesAllowed,
+			ScriptKey:      scriptKey,
+		}, vPkt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching commitment: %w", err)
+		return nil, fmt.Errorf("error funding sweep: %w", err)
 	}
 
-	var challengeOpt fn.Option[[32]byte]
+	return fundedVPkt, nil
+}
 
-	if len(req.Challenge) == 32 {
-		var bCopy [32]byte
-		copy(bCopy[:], req.Challenge[:32])
-		challengeOpt = fn.Some[[32]byte](bCopy)
-	}
+// releasePacketInputs releases the inputs of the given funded packet, so they
+// can be selected again.
+func (r *rpcServer) releasePacketInputs(ctx context.Context,
+	vPkt *tappsbt.VPacket) {
 
-	challengeWitness, err := r.cfg.AssetWallet.SignOwnershipProof(
-		inputCommitment.Asset.Copy(), challengeOpt,
+	outpoints := fn.Map(
+		vPkt.Inputs, func(vIn *tappsbt.VInput) wire.OutPoint {
+			return vIn.PrevID.OutPoint
+		},
 	)
+	err := r.cfg.AssetWallet.ReleaseCoins(ctx, outpoints...)
 	if err != nil {
-		return nil, fmt.Errorf("error signing ownership proof: %w", err)
+		rpcsLog.Errorf("Unable to release coins: %v", err)
 	}
+}
 
-	lastProof, err := proofFile.LastProof()
-	if err != nil {
-		return nil, fmt.Errorf("error fetching last proof: %w", err)
-	}
+// unmarshalTimeLock creates the time lock script tree of the given RPC time
+// lock parameters.
+func unmarshalTimeLock(
+	timeLock *wrpc.TimeLock) (*tapscript.TimeLockScriptTree, error) {
 
-	lastProof.ChallengeWitness = challengeWitness
+	if timeLock == nil {
+		return nil, fmt.Errorf("time lock must be set")
+	}
 
-	var buf bytes.Buffer
-	if err := lastProof.Encode(&buf); err != nil {
-		return nil, fmt.Errorf("error encoding proof file: %w", err)
+	key, err := btcec.ParsePubKey(timeLock.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time lock key: %w", err)
 	}
 
-	return &wrpc.ProveAssetOwnershipResponse{
-		ProofWithWitness: buf.Bytes(),
-	}, nil
+	return tapscript.NewTimeLockScriptTree(
+		key, timeLock.LockTime, timeLock.CsvDelay,
+	)
 }
 
-// VerifyAssetOwnership verifies the asset ownership proof embedded in the
-// given transition proof of an asset and returns true if the proof is valid.
-func (r *rpcServer) VerifyAssetOwnership(ctx context.Context,
-	req *wrpc.VerifyAssetOwnershipRequest) (*wrpc.VerifyAssetOwnershipResponse,
-	error) {
+// declareTimeLockScriptKey declares the script key of the given time lock to
+// the wallet, if the wallet controls the key of the time lock. Script keys of
+// other wallets aren't declared, as assets sent to them would otherwise be
+// shown as owned. False is returned if the script key wasn't declared.
+func (r *rpcServer) declareTimeLockScriptKey(ctx context.Context,
+	tree *tapscript.TimeLockScriptTree) (bool, error) {
 
-	if len(req.ProofWithWitness) == 0 {
-		return nil, fmt.Errorf("a valid proof must be specified")
-	}
+	_, err := r.cfg.AssetWallet.FetchInternalKeyLocator(ctx, tree.Key)
+	switch {
+	case errors.Is(err, address.ErrInternalKeyNotFound):
+		return false, nil
 
-	if len(req.Challenge) != 0 && len(req.Challenge) != 32 {
-		return nil, fmt.Errorf("challenge must be 32 bytes")
+	case err != nil:
+		return false, fmt.Errorf("error fetching key locator: %w", err)
 	}
 
-	p, err := proof.Decode(req.ProofWithWitness)
+	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, tree.ScriptKey(), true)
 	if err != nil {
-		return nil, fmt.Errorf("cannot decode proof file: %w", err)
+		return false, fmt.Errorf("error inserting script key: %w", err)
 	}
 
-	lookup, err := r.cfg.ChainBridge.GenProofChainLookup(p)
-	if err != nil {
-		return nil, fmt.Errorf("error generating proof chain lookup: "+
-			"%w", err)
+	return true, nil
+}
+
+// NewTimeLockScriptKey creates a script key that locks assets to a key until
+// the given lock times have passed.
+func (r *rpcServer) NewTimeLockScriptKey(ctx context.Context,
+	in *wrpc.NewTimeLockScriptKeyRequest) (
+	*wrpc.NewTimeLockScriptKeyResponse, error) {
+
+	if in.TimeLock == nil {
+		return nil, fmt.Errorf("time lock must be set")
 	}
 
-	headerVerifier := tapgarden.GenHeaderVerifier(ctx, r.cfg.ChainBridge)
-	groupVerifier := tapgarden.GenGroupVerifier(ctx, r.cfg.MintingStore)
+	timeLock := &wrpc.TimeLock{
+		Key:      in.TimeLock.Key,
+		LockTime: in.TimeLock.LockTime,
+		CsvDelay: in.TimeLock.CsvDelay,
+	}
+	if len(timeLock.Key) == 0 {
+		keyDesc, err := r.cfg.AddrBook.NextInternalKey(
+			ctx, asset.TaprootAssetsKeyFamily,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key: %w", err)
+		}
 
-	var (
-		challengeBytes [32]byte
-		opts           []proof.ProofVerificationOption
-	)
+		timeLock.Key = keyDesc.PubKey.SerializeCompressed()
+	}
 
-	if len(req.Challenge) == 32 {
-		copy(challengeBytes[:], req.Challenge[:32])
-		opts = append(opts, proof.WithChallengeBytes(challengeBytes))
+	tree, err := unmarshalTimeLock(timeLock)
+	if err != nil {
+		return nil, err
 	}
 
-	snapShot, err := p.Verify(
-		ctx, nil, headerVerifier, proof.DefaultMerkleVerifier,
-		groupVerifier, lookup, opts...,
-	)
+	declared, err := r.declareTimeLockScriptKey(ctx, tree)
 	if err != nil {
-		return nil, fmt.Errorf("error verifying proof: %w", err)
+		return nil, err
 	}
 
-	return &wrpc.VerifyAssetOwnershipResponse{
-		ValidProof: true,
-		Outpoint: &taprpc.OutPoint{
-			Txid:        snapShot.OutPoint.Hash[:],
-			OutputIndex: snapShot.OutPoint.Index,
-		},
-		OutpointStr:  snapShot.OutPoint.String(),
-		BlockHash:    snapShot.AnchorBlockHash[:],
-		BlockHashStr: snapShot.AnchorBlockHash.String(),
-		BlockHeight:  snapShot.AnchorBlockHeight,
+	return &wrpc.NewTimeLockScriptKeyResponse{
+		ScriptKey: taprpc.MarshalScriptKey(tree.ScriptKey()),
+		TimeLock:  timeLock,
+		Declared:  declared,
 	}, nil
 }
 
-// UniverseStats returns a set of aggregate statistics for the current state
-// of the Universe.
-func (r *rpcServer) UniverseStats(ctx context.Context,
-	_ *unirpc.StatsRequest) (*unirpc.StatsResponse, error) {
+// SendTimeLocked sends an asset to multiple recipients, each of which receives
+// its amount locked to its own time lock script key.
+func (r *rpcServer) SendTimeLocked(ctx context.Context,
+	in *wrpc.SendTimeLockedRequest) (*taprpc.SendAssetResponse, error) {
 
-	universeStats, err := r.cfg.UniverseStats.AggregateSyncStats(ctx)
+	if len(in.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be %d bytes",
+			sha256.Size)
+	}
+	assetID := asset.ID(in.AssetId)
+
+	assetVersion, err := taprpc.UnmarshalAssetVersion(in.AssetVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	return &unirpc.StatsResponse{
-		NumTotalAssets: int64(universeStats.NumTotalAssets),
-		NumTotalGroups: int64(universeStats.NumTotalGroups),
-		NumTotalSyncs:  int64(universeStats.NumTotalSyncs),
-		NumTotalProofs: int64(universeStats.NumTotalProofs),
-	}, nil
-}
+	if len(in.Recipients) == 0 {
+		return nil, fmt.Errorf("at least one recipient must be " +
+			"specified")
+	}
 
-// marshalAssetSyncSnapshot maps a universe asset sync stat snapshot to the RPC
-// counterpart.
-func (r *rpcServer) marshalAssetSyncSnapshot(ctx context.Context,
-	a universe.AssetSyncSnapshot) *unirpc.AssetStatsSnapshot {
+	var (
+		amount  uint64
+		outputs = make([]*tapsend.TimeLockedOutput, len(in.Recipients))
+	)
+	for idx, recipient := range in.Recipients {
+		tree, err := unmarshalTimeLock(recipient.TimeLock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time lock of "+
+				"recipient %d: %w", idx, err)
+		}
 
-	resp := &unirpc.AssetStatsSnapshot{
-		TotalSyncs:  int64(a.TotalSyncs),
-		TotalProofs: int64(a.TotalProofs),
-		GroupSupply: int64(a.GroupSupply),
+		outputs[idx] = &tapsend.TimeLockedOutput{
+			Amount: recipient.Amount,
+			Tree:   tree,
+		}
+		amount += recipient.Amount
+
+		if len(recipient.AnchorInternalKey) == 0 {
+			continue
+		}
+
+		internalKey, err := btcec.ParsePubKey(
+			recipient.AnchorInternalKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchor internal key "+
+				"of recipient %d: %w", idx, err)
+		}
+		outputs[idx].AnchorInternalKey = &keychain.KeyDescriptor{
+			PubKey: internalKey,
+		}
 	}
-	rpcAsset := &unirpc.AssetStatsAsset{
-		AssetId:       a.AssetID[:],
-		GenesisPoint:  a.GenesisPoint.String(),
-		AssetName:     a.AssetName,
-		AssetType:     taprpc.AssetType(a.AssetType),
-		TotalSupply:   int64(a.TotalSupply),
-		GenesisHeight: int32(a.GenesisHeight),
-		GenesisTimestamp: r.cfg.ChainBridge.GetBlockTimestamp(
-			ctx, a.GenesisHeight,
-		),
-		AnchorPoint: a.AnchorPoint.String(),
+
+	manualFeeRate, err := checkFeeRateSanity(
+		ctx, chainfee.SatPerKWeight(in.FeeRate), r.cfg.Lnd.WalletKit,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	if a.GroupKey != nil {
-		resp.GroupKey = a.GroupKey.SerializeCompressed()
-		resp.GroupAnchor = rpcAsset
-	} else {
-		resp.Asset = rpcAsset
+	vPkt, err := tapsend.CreateTimeLockedPacket(
+		assetID, outputs, assetVersion, &r.cfg.ChainParams,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return resp
-}
+	// The script keys of time locks the wallet controls need to be
+	// declared, so the funding logic recognizes the outputs as local and
+	// the received assets are shown as owned.
+	for _, out := range outputs {
+		_, err := r.declareTimeLockScriptKey(ctx, out.Tree)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-// QueryAssetStats returns a set of statistics for a given set of assets.
-// Stats can be queried for all assets, or based on the: asset ID, name, or
-// asset type. Pagination is supported via the offset and limit params.
-// Results can also be sorted based on any of the main query params.
-func (r *rpcServer) QueryAssetStats(ctx context.Context,
-	req *unirpc.AssetStatsQuery) (*unirpc.UniverseAssetStats, error) {
+	fundedVPkt, err := r.cfg.AssetWallet.FundPacket(
+		ctx, &tapsend.FundingDescriptor{
+			AssetSpecifier: asset.NewSpecifierFromId(assetID),
+			Amount:         amount,
+		}, vPkt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error funding packet: %w", err)
+	}
+	vPkt = fundedVPkt.VPacket
 
-	assetStats, err := r.cfg.UniverseStats.QuerySyncStats(
-		ctx, universe.SyncStatsQuery{
-			AssetNameFilter: req.AssetNameFilter,
-			AssetTypeFilter: func() *asset.Type {
-				switch req.AssetTypeFilter {
-				case unirpc.AssetTypeFilter_FILTER_ASSET_NORMAL:
-					return fn.Ptr(asset.Normal)
+	success := false
+	defer func() {
+		if !success {
+			r.releasePacketInputs(ctx, vPkt)
+		}
+	}()
 
-				case unirpc.AssetTypeFilter_FILTER_ASSET_COLLECTIBLE:
-					return fn.Ptr(asset.Collectible)
+	_, err = r.cfg.AssetWallet.SignVirtualPacket(vPkt)
+	if err != nil {
+		return nil, fmt.Errorf("error signing packet: %w", err)
+	}
 
-				default:
-					return nil
-				}
-			}(),
-			AssetIDFilter: fn.ToArray[asset.ID](
-				req.AssetIdFilter,
+	var parcelOpts []tapfreighter.PreSignedParcelOption
+	if manualFeeRate != nil {
+		parcelOpts = append(
+			parcelOpts, tapfreighter.WithPreSignedFeeRate(
+				*manualFeeRate,
 			),
-			SortBy:        universe.SyncStatsSort(req.SortBy),
-			SortDirection: universe.SortDirection(req.Direction),
-			Offset:        int(req.Offset),
-			Limit:         int(req.Limit),
-		},
+		)
+	}
+
+	resp, err := r.cfg.ChainPorter.RequestShipment(
+		tapfreighter.NewPreSignedParcel(
+			[]*tappsbt.VPacket{vPkt}, fundedVPkt.InputCommitments,
+			in.Note, parcelOpts...,
+		),
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error requesting delivery: %w", err)
+	}
+	success = true
+
+	parcel, err := marshalOutboundParcel(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling outbound parcel: %w",
+			err)
 	}
 
-	resp := &unirpc.UniverseAssetStats{
-		AssetStats: make(
-			[]*unirpc.AssetStatsSnapshot, len(assetStats.SyncStats),
-		),
+	return &taprpc.SendAssetResponse{
+		Transfer: parcel,
+	}, nil
+}
+
+// SweepTimeLock sweeps all assets of the given asset ID that are locked to a
+// time lock script key to a new script key of the wallet.
+func (r *rpcServer) SweepTimeLock(ctx context.Context,
+	in *wrpc.SweepTimeLockRequest) (*taprpc.SendAssetResponse, error) {
+
+	tree, err := unmarshalTimeLock(in.TimeLock)
+	if err != nil {
+		return nil, err
 	}
-	for idx, snapshot := range assetStats.SyncStats {
-		resp.AssetStats[idx] = r.marshalAssetSyncSnapshot(ctx, snapshot)
+
+	if len(in.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be %d bytes",
+			sha256.Size)
 	}
+	assetID := asset.ID(in.AssetId)
 
-	return resp, nil
-}
+	keyLoc, err := r.cfg.AssetWallet.FetchInternalKeyLocator(
+		ctx, tree.Key,
+	)
+	switch {
+	case errors.Is(err, address.ErrInternalKeyNotFound):
+		return nil, fmt.Errorf("wallet doesn't control the key of " +
+			"the time lock")
 
-// QueryEvents returns the number of sync and proof events for a given time
-// period, grouped by day.
-func (r *rpcServer) QueryEvents(ctx context.Context,
-	req *unirpc.QueryEventsRequest) (*unirpc.QueryEventsResponse, error) {
+	case err != nil:
+		return nil, fmt.Errorf("error fetching key locator: %w", err)
+	}
 
-	// If no start or end time is specified, default to the last 30 days.
-	var (
-		startTime = time.Now().AddDate(0, 0, -30)
-		endTime   = time.Now()
+	// The lock time checks of the leaf are executed against the lock
+	// times of the new asset, so it needs to carry those of the tree.
+	fundedVPkt, err := r.fundScriptKeySweep(
+		ctx, assetID, tree.ScriptKey().PubKey, uint64(tree.LockTime),
+		uint64(tree.CsvDelay),
 	)
-	if req.StartTimestamp > 0 {
-		startTime = time.Unix(req.StartTimestamp, 0)
-	}
-	if req.EndTimestamp > 0 {
-		endTime = time.Unix(req.EndTimestamp, 0)
+	if err != nil {
+		return nil, err
 	}
+	vPkt := fundedVPkt.VPacket
 
-	if endTime.Before(startTime) {
-		return nil, fmt.Errorf("end time cannot be before start time")
+	// If we can't sign the sweep, we release the inputs again, so they can
+	// be swept later.
+	success := false
+	defer func() {
+		if !success {
+			r.releasePacketInputs(ctx, vPkt)
+		}
+	}()
+
+	if err := r.checkTimeLockMature(ctx, vPkt, tree); err != nil {
+		return nil, err
 	}
 
-	stats, err := r.cfg.UniverseStats.QueryAssetStatsPerDay(
-		ctx, universe.GroupedStatsQuery{
-			StartTime: startTime,
-			EndTime:   endTime,
-		},
+	keyDesc := keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     tree.Key,
+	}
+	err = tapsend.SignTimeLockPacket(
+		vPkt, tree, keyDesc, r.cfg.VirtualTxSigner,
+		&WitnessValidatorV0{},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error querying stats: %w", err)
+		return nil, fmt.Errorf("error signing sweep: %w", err)
 	}
 
-	rpcStats := &unirpc.QueryEventsResponse{
-		Events: make([]*unirpc.GroupedUniverseEvents, len(stats)),
+	resp, err := r.cfg.ChainPorter.RequestShipment(
+		tapfreighter.NewPreSignedParcel(
+			[]*tappsbt.VPacket{vPkt}, fundedVPkt.InputCommitments,
+			"",
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting delivery: %w", err)
 	}
-	for day, s := range stats {
-		rpcStats.Events[day] = &unirpc.GroupedUniverseEvents{
-			Date:           s.Date,
-			SyncEvents:     s.NumTotalSyncs,
-			NewProofEvents: s.NumTotalProofs,
-		}
+	success = true
+
+	parcel, err := marshalOutboundParcel(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling outbound parcel: %w",
+			err)
 	}
 
-	return rpcStats, nil
+	return &taprpc.SendAssetResponse{
+		Transfer: parcel,
+	}, nil
 }
 
-// RemoveUTXOLease removes the lease/lock/reservation of the given managed
-// UTXO.
-func (r *rpcServer) RemoveUTXOLease(ctx context.Context,
-	req *wrpc.RemoveUTXOLeaseRequest) (*wrpc.RemoveUTXOLeaseResponse,
-	error) {
-
-	if req.Outpoint == nil {
-		return nil, fmt.Errorf("outpoint must be specified")
-	}
+// checkTimeLockMature returns an error if the sweep of any input of the given
+// packet couldn't be confirmed in the next block because the lock times of the
+// given time lock haven't passed for the input yet.
+func (r *rpcServer) checkTimeLockMature(ctx context.Context,
+	vPkt *tappsbt.VPacket, tree *tapscript.TimeLockScriptTree) error {
 
-	hash, err := chainhash.NewHash(req.Outpoint.Txid)
+	height, err := r.cfg.ChainBridge.CurrentHeight(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing txid: %w", err)
+		return fmt.Errorf("error fetching current height: %w", err)
 	}
 
-	outPoint := wire.OutPoint{
-		Hash:  *hash,
-		Index: req.Outpoint.OutputIndex,
-	}
+	for idx, vIn := range vPkt.Inputs {
+		if vIn.Proof == nil {
+			return fmt.Errorf("input %d has no proof", idx)
+		}
 
-	err = r.cfg.CoinSelect.ReleaseCoins(ctx, outPoint)
-	if err != nil {
-		return nil, err
+		confirmHeight := vIn.Proof.BlockHeight
+		if tree.IsMature(confirmHeight, height+1) {
+			continue
+		}
+
+		spendableHeight := max(
+			tree.LockTime, confirmHeight+tree.CsvDelay,
+		)
+		return fmt.Errorf("input %d can only be swept from block %d",
+			idx, spendableHeight)
 	}
 
-	return &wrpc.RemoveUTXOLeaseResponse{}, nil
+	return nil
 }
 
-// MarshalAssetFedSyncCfg returns an RPC ready asset specific federation sync
-// config.
-func MarshalAssetFedSyncCfg(
-	config universe.FedUniSyncConfig) (*unirpc.AssetFederationSyncConfig,
-	error) {
+// CreateSigningSession creates a multi-party signing session with the funded
+// virtual PSBTs of the creating party.
+func (r *rpcServer) CreateSigningSession(_ context.Context,
+	req *wrpc.CreateSigningSessionRequest) (
+	*wrpc.CreateSigningSessionResponse, error) {
 
-	// Marshal universe ID into the RPC form.
-	uniID := config.UniverseID
-	assetIDBytes := uniID.AssetID[:]
+	packets, err := decodeVirtualPackets(req.VirtualPsbts)
+	if err != nil {
+		return nil, err
+	}
 
-	var groupKeyBytes []byte
-	if uniID.GroupKey != nil {
-		groupKeyBytes = uniID.GroupKey.SerializeCompressed()
+	session, err := r.cfg.SessionManager.CreateSession(packets)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
 	}
-	uniIdRPC := unirpc.MarshalUniverseID(assetIDBytes, groupKeyBytes)
 
-	// Marshal proof type.
-	proofTypeRpc, err := MarshalUniProofType(uniID.ProofType)
+	rpcSession, err := marshalSigningSession(session)
 	if err != nil {
-		return nil, fmt.Errorf("unable to marshal proof type: %w", err)
+		return nil, err
 	}
-	uniIdRPC.ProofType = proofTypeRpc
 
-	return &unirpc.AssetFederationSyncConfig{
-		Id:              uniIdRPC,
-		AllowSyncInsert: config.AllowSyncInsert,
-		AllowSyncExport: config.AllowSyncExport,
+	return &wrpc.CreateSigningSessionResponse{
+		Session: rpcSession,
 	}, nil
 }
 
-// unmarshalAssetSpecifier unmarshals an asset specifier from the RPC form.
-func unmarshalAssetSpecifier(req *rfqrpc.AssetSpecifier) (*asset.ID,
-	*btcec.PublicKey, error) {
+// ContributeToSession adds the inputs and outputs of the funded virtual PSBTs
+// of another party to an open signing session.
+func (r *rpcServer) ContributeToSession(_ context.Context,
+	req *wrpc.ContributeToSessionRequest) (
+	*wrpc.ContributeToSessionResponse, error) {
 
-	// Attempt to decode the asset specifier from the RPC request. In cases
-	// where both the asset ID and asset group key are provided, we will
-	// give precedence to the asset ID due to its higher level of
-	// specificity.
-	var (
-		assetID *asset.ID
+	id, err := parseSessionID(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
 
-		groupKeyBytes []byte
-		groupKey      *btcec.PublicKey
+	packets, err := decodeVirtualPackets(req.VirtualPsbts)
+	if err != nil {
+		return nil, err
+	}
 
-		err error
-	)
+	session, err := r.cfg.SessionManager.Contribute(id, packets)
+	if err != nil {
+		return nil, fmt.Errorf("error contributing to session: %w",
+			err)
+	}
 
-	switch {
-	// Parse the asset ID if it's set.
-	case len(req.GetAssetId()) > 0:
-		var assetIdBytes [32]byte
-		copy(assetIdBytes[:], req.GetAssetId())
-		id := asset.ID(assetIdBytes)
-		assetID = &id
+	rpcSession, err := marshalSigningSession(session)
+	if err != nil {
+		return nil, err
+	}
 
-	case len(req.GetAssetIdStr()) > 0:
-		assetIDBytes, err := hex.DecodeString(req.GetAssetIdStr())
-		if err != nil {
-			return nil, nil, fmt.Errorf("error decoding asset "+
-				"ID: %w", err)
-		}
+	return &wrpc.ContributeToSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
 
-		var id asset.ID
-		copy(id[:], assetIDBytes)
-		assetID = &id
+// StartSessionSigning closes a signing session for contributions and prepares
+// its virtual transactions for signing.
+func (r *rpcServer) StartSessionSigning(ctx context.Context,
+	req *wrpc.StartSessionSigningRequest) (
+	*wrpc.StartSessionSigningResponse, error) {
 
-	// Parse the group key if it's set.
-	case len(req.GetGroupKey()) > 0:
-		groupKeyBytes = req.GetGroupKey()
-		groupKey, err = btcec.ParsePubKey(groupKeyBytes)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error parsing group "+
-				"key: %w", err)
-		}
+	id, err := parseSessionID(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
 
-	case len(req.GetGroupKeyStr()) > 0:
-		groupKeyBytes, err := hex.DecodeString(
-			req.GetGroupKeyStr(),
-		)
+	specs := make([]tapsession.MuSig2InputSpec, len(req.Musig2Inputs))
+	for idx, rpcSpec := range req.Musig2Inputs {
+		specs[idx], err = unmarshalMuSig2InputSpec(rpcSpec)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error decoding group "+
-				"key: %w", err)
+			return nil, fmt.Errorf("invalid MuSig2 input %d: %w",
+				idx, err)
 		}
+	}
 
-		groupKey, err = btcec.ParsePubKey(groupKeyBytes)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error parsing group "+
-				"key: %w", err)
-		}
+	session, err := r.cfg.SessionManager.StartSigning(ctx, id, specs)
+	if err != nil {
+		return nil, fmt.Errorf("error starting session signing: %w",
+			err)
+	}
 
-	default:
-		// At this point, we know that neither the asset ID nor the
-		// group key are specified. Return an error.
-		return nil, nil, fmt.Errorf("either asset ID or asset group " +
-			"key must be specified")
+	rpcSession, err := marshalSigningSession(session)
+	if err != nil {
+		return nil, err
 	}
 
-	return assetID, groupKey, nil
+	return &wrpc.StartSessionSigningResponse{
+		Session: rpcSession,
+	}, nil
 }
 
-// unmarshalAssetBuyOrder unmarshals an asset buy order from the RPC form.
-func unmarshalAssetBuyOrder(
-	req *rfqrpc.AddAssetBuyOrderRequest) (*rfq.BuyOrder, error) {
+// SubmitSessionSignatures adds the witnesses, MuSig2 nonces and MuSig2 partial
+// signatures of a party to a signing session.
+func (r *rpcServer) SubmitSessionSignatures(_ context.Context,
+	req *wrpc.SubmitSessionSignaturesRequest) (
+	*wrpc.SubmitSessionSignaturesResponse, error) {
 
-	assetId, assetGroupKey, err := unmarshalAssetSpecifier(
-		req.AssetSpecifier,
-	)
+	id, err := parseSessionID(req.SessionId)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
-			"%w", err)
+		return nil, err
 	}
 
-	// Unmarshal the peer if specified.
-	var peer *route.Vertex
-	if len(req.PeerPubKey) > 0 {
-		pv, err := route.NewVertexFromBytes(req.PeerPubKey)
+	var sigs tapsession.Signatures
+	sigs.Packets, err = decodeVirtualPackets(req.SignedVirtualPsbts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rpcNonce := range req.Nonces {
+		ref, key, err := unmarshalSessionParticipant(
+			rpcNonce.Input, rpcNonce.ParticipantKey,
+		)
 		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling peer "+
-				"route vertex: %w", err)
+			return nil, err
 		}
 
-		peer = &pv
-	}
-
-	// Construct an asset specifier from the asset ID and/or group key.
-	assetSpecifier, err := asset.NewSpecifier(
-		assetId, assetGroupKey, nil, true,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error creating asset specifier: %w",
-			err)
-	}
+		nonce := tapsession.Nonce{
+			InputRef:       ref,
+			ParticipantKey: key,
+		}
+		if len(rpcNonce.PubNonce) != musig2.PubNonceSize {
+			return nil, fmt.Errorf("public nonce must be %d bytes",
+				musig2.PubNonceSize)
+		}
+		copy(nonce.PubNonce[:], rpcNonce.PubNonce)
 
-	// Convert expiry unix timestamp in seconds to time.Time.
-	if req.Expiry > math.MaxInt64 {
-		return nil, fmt.Errorf("expiry must be less than or equal to "+
-			"math.MaxInt64 (expiry=%d)", req.Expiry)
+		sigs.Nonces = append(sigs.Nonces, nonce)
 	}
-	expiry := time.Unix(int64(req.Expiry), 0).UTC()
 
-	return &rfq.BuyOrder{
-		AssetSpecifier: assetSpecifier,
-		AssetMaxAmt:    req.AssetMaxAmt,
-		Expiry:         expiry,
-		Peer:           fn.MaybeSome(peer),
-	}, nil
-}
-
-// AddAssetBuyOrder upserts a new buy order for the given asset into the RFQ
-// manager. If the order already exists for the given asset, it will be updated.
-func (r *rpcServer) AddAssetBuyOrder(ctx context.Context,
-	req *rfqrpc.AddAssetBuyOrderRequest) (*rfqrpc.AddAssetBuyOrderResponse,
-	error) {
+	for _, rpcSig := range req.PartialSigs {
+		ref, key, err := unmarshalSessionParticipant(
+			rpcSig.Input, rpcSig.ParticipantKey,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	if req.TimeoutSeconds == 0 {
-		return nil, fmt.Errorf("timeout must be greater than 0")
-	}
+		partialSig := &musig2.PartialSignature{}
+		err = partialSig.Decode(bytes.NewReader(rpcSig.PartialSig))
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signature: %w",
+				err)
+		}
 
-	// Unmarshal the buy order from the RPC form.
-	buyOrder, err := unmarshalAssetBuyOrder(req)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling buy order: %w", err)
+		sigs.PartialSigs = append(
+			sigs.PartialSigs, tapsession.PartialSig{
+				InputRef:       ref,
+				ParticipantKey: key,
+				Sig:            partialSig,
+			},
+		)
 	}
 
-	// Currently, we require the peer to be specified in the buy order.
-	peer, err := buyOrder.Peer.UnwrapOrErr(
-		fmt.Errorf("buy order peer must be specified"),
-	)
+	session, err := r.cfg.SessionManager.SubmitSignatures(id, sigs)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error submitting signatures: %w", err)
 	}
 
-	// Check if we have a channel with the peer.
-	err = r.checkPeerChannel(
-		ctx, peer, buyOrder.AssetSpecifier, req.SkipAssetChannelCheck,
-	)
+	rpcSession, err := marshalSigningSession(session)
 	if err != nil {
-		return nil, fmt.Errorf("error checking peer channel: %w", err)
+		return nil, err
 	}
 
-	rpcsLog.Debugf("[AddAssetBuyOrder]: upserting buy order "+
-		"(dest_peer=%s)", peer.String())
+	return &wrpc.SubmitSessionSignaturesResponse{
+		Session: rpcSession,
+	}, nil
+}
 
-	// Register an event listener before actually inserting the order, so we
-	// definitely don't miss any responses.
-	eventSubscriber := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
-	defer eventSubscriber.Stop()
+// FinalizeSession combines the MuSig2 partial signatures of a signing session
+// and validates the witnesses of all its virtual transactions.
+func (r *rpcServer) FinalizeSession(_ context.Context,
+	req *wrpc.FinalizeSessionRequest) (*wrpc.FinalizeSessionResponse,
+	error) {
 
-	err = r.cfg.RfqManager.RegisterSubscriber(eventSubscriber, false, 0)
+	id, err := parseSessionID(req.SessionId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register event listener: %w",
-			err)
+		return nil, err
 	}
 
-	defer func() {
-		_ = r.cfg.RfqManager.RemoveSubscriber(eventSubscriber)
-	}()
+	session, err := r.cfg.SessionManager.Finalize(id)
+	if err != nil {
+		return nil, fmt.Errorf("error finalizing session: %w", err)
+	}
 
-	// Upsert the buy order into the RFQ manager.
-	err = r.cfg.RfqManager.UpsertAssetBuyOrder(*buyOrder)
+	rpcSession, err := marshalSigningSession(session)
 	if err != nil {
-		return nil, fmt.Errorf("error upserting buy order into RFQ "+
-			"manager: %w", err)
+		return nil, err
 	}
 
-	timeout := time.After(time.Second * time.Duration(req.TimeoutSeconds))
+	return &wrpc.FinalizeSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
 
-	for {
-		select {
-		case event := <-eventSubscriber.NewItemCreated.ChanOut():
-			resp, err := taprpc.NewAddAssetBuyOrderResponse(event)
-			if err != nil {
-				return nil, fmt.Errorf("error marshalling "+
-					"buy order response: %w", err)
-			}
+// AnchorSession commits the finalized virtual transactions of a signing session
+// to a BTC level anchor transaction that is funded by our wallet.
+func (r *rpcServer) AnchorSession(ctx context.Context,
+	req *wrpc.AnchorSessionRequest) (*wrpc.AnchorSessionResponse, error) {
 
-			return resp, nil
+	id, err := parseSessionID(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
 
-		case <-r.quit:
-			return nil, fmt.Errorf("server shutting down")
+	commitReq := &wrpc.CommitVirtualPsbtsRequest{
+		AnchorChangeOutput: &wrpc.CommitVirtualPsbtsRequest_Add{
+			Add: true,
+		},
+	}
+	switch fees := req.Fees.(type) {
+	case *wrpc.AnchorSessionRequest_TargetConf:
+		commitReq.Fees = &wrpc.CommitVirtualPsbtsRequest_TargetConf{
+			TargetConf: fees.TargetConf,
+		}
 
-		case <-timeout:
-			return nil, fmt.Errorf("timeout waiting for response "+
-				"(peer=%s)", peer.String())
+	case *wrpc.AnchorSessionRequest_SatPerVbyte:
+		commitReq.Fees = &wrpc.CommitVirtualPsbtsRequest_SatPerVbyte{
+			SatPerVbyte: fees.SatPerVbyte,
 		}
+
+	default:
+		return nil, fmt.Errorf("either target conf or fee rate must " +
+			"be specified")
 	}
-}
 
-// checkPeerChannel checks if there is a channel with the given peer. If the
-// asset channel check is enabled, it will also check if there is a channel with
-// the given asset with the peer.
-func (r *rpcServer) checkPeerChannel(ctx context.Context, peer route.Vertex,
-	specifier asset.Specifier, skipAssetChannelCheck bool) error {
+	// We commit the packets through the same code path as a user calling
+	// CommitVirtualPsbts with a template that spends all anchor inputs of
+	// the session.
+	commit := func(ctx context.Context, packets []*tappsbt.VPacket) (
+		*tapsession.AnchorCommitment, error) {
 
-	// We want to make sure there is at least a channel between us and the
-	// peer, otherwise RFQ negotiation doesn't make sense.
-	switch {
-	// For integration tests, we can't create asset channels, so we allow
-	// the asset channel check to be skipped. In this case we simply check
-	// that we have any channel with the peer.
-	case skipAssetChannelCheck:
-		activeChannels, err := r.cfg.Lnd.Client.ListChannels(
-			ctx, true, false,
-		)
+		anchorPsbt, err := tapsend.PrepareAnchoringTemplate(packets)
 		if err != nil {
-			return fmt.Errorf("unable to fetch channels: %w", err)
+			return nil, fmt.Errorf("error creating anchor "+
+				"template: %w", err)
 		}
-		peerChannels := fn.Filter(
-			activeChannels, func(c lndclient.ChannelInfo) bool {
-				return c.PubKeyBytes == peer
-			},
-		)
-		if len(peerChannels) == 0 {
-			return fmt.Errorf("no active channel found with peer "+
-				"%x", peer[:])
+
+		commitReq.AnchorPsbt, err = serialize(anchorPsbt)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing packet: %w",
+				err)
 		}
 
-	// For any other case, we'll want to make sure there is a channel with
-	// a non-zero balance of the given asset to carry the order.
-	default:
-		assetID, err := specifier.UnwrapIdOrErr()
+		commitReq.VirtualPsbts, err = encodeVirtualPackets(packets)
 		if err != nil {
-			return fmt.Errorf("cannot check asset channel, " +
-				"missing asset ID")
+			return nil, err
 		}
 
-		// If we don't get an error here, it means we do have an asset
-		// channel with the peer.
-		_, err = r.rfqChannel(ctx, assetID, &peer)
+		resp, err := r.CommitVirtualPsbts(ctx, commitReq)
 		if err != nil {
-			return fmt.Errorf("error checking asset channel: %w",
-				err)
+			return nil, err
 		}
-	}
 
-	return nil
-}
+		return unmarshalAnchorCommitment(resp)
+	}
 
-// unmarshalAssetSellOrder unmarshals an asset sell order from the RPC form.
-func unmarshalAssetSellOrder(
-	req *rfqrpc.AddAssetSellOrderRequest) (*rfq.SellOrder, error) {
+	session, err := r.cfg.SessionManager.Commit(ctx, id, commit)
+	if err != nil {
+		return nil, fmt.Errorf("error anchoring session: %w", err)
+	}
 
-	assetId, assetGroupKey, err := unmarshalAssetSpecifier(
-		req.AssetSpecifier,
-	)
+	rpcSession, err := marshalSigningSession(session)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
-			"%w", err)
+		return nil, err
 	}
 
-	// Unmarshal the peer if specified.
-	var peer fn.Option[route.Vertex]
-	if len(req.PeerPubKey) > 0 {
-		pv, err := route.NewVertexFromBytes(req.PeerPubKey)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling peer "+
-				"route vertex: %w", err)
-		}
+	return &wrpc.AnchorSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
 
-		peer = fn.Some(pv)
-	}
+// PublishSession adds the signatures of the given signed anchor transactions
+// to the anchor transaction of a signing session and, once it is fully signed,
+// publishes it and logs the transfer.
+func (r *rpcServer) PublishSession(ctx context.Context,
+	req *wrpc.PublishSessionRequest) (*taprpc.SendAssetResponse, error) {
 
-	// Construct an asset specifier from the asset ID and/or group key.
-	assetSpecifier, err := asset.NewSpecifier(
-		assetId, assetGroupKey, nil, true,
-	)
+	id, err := parseSessionID(req.SessionId)
 	if err != nil {
-		return nil, fmt.Errorf("error creating asset specifier: %w",
-			err)
+		return nil, err
 	}
 
-	// Convert expiry unix timestamp in seconds to time.Time.
-	if req.Expiry > math.MaxInt64 {
-		return nil, fmt.Errorf("expiry must be less than or equal to "+
-			"math.MaxInt64 (expiry=%d)", req.Expiry)
+	signedPsbts := make([]*psbt.Packet, len(req.SignedAnchorPsbts))
+	for idx, rawPsbt := range req.SignedAnchorPsbts {
+		signedPsbts[idx], err = psbt.NewFromRawBytes(
+			bytes.NewReader(rawPsbt), false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding anchor packet "+
+				"%d: %w", idx, err)
+		}
 	}
-	expiry := time.Unix(int64(req.Expiry), 0).UTC()
 
-	return &rfq.SellOrder{
-		AssetSpecifier: assetSpecifier,
-		PaymentMaxAmt:  lnwire.MilliSatoshi(req.PaymentMaxAmt),
-		Expiry:         expiry,
-		Peer:           peer,
-	}, nil
-}
+	// The fully signed anchor transaction is published through the same
+	// code path as a user calling PublishAndLogTransfer.
+	var resp *taprpc.SendAssetResponse
+	publish := func(ctx context.Context,
+		commitment *tapsession.AnchorCommitment) error {
 
-// AddAssetSellOrder upserts a new sell order for the given asset into the RFQ
-// manager. If the order already exists for the given asset, it will be updated.
-func (r *rpcServer) AddAssetSellOrder(ctx context.Context,
-	req *rfqrpc.AddAssetSellOrderRequest) (*rfqrpc.AddAssetSellOrderResponse,
-	error) {
+		publishReq, err := marshalPublishRequest(commitment)
+		if err != nil {
+			return err
+		}
 
-	if req.TimeoutSeconds == 0 {
-		return nil, fmt.Errorf("timeout must be greater than 0")
+		resp, err = r.PublishAndLogTransfer(ctx, publishReq)
+
+		return err
 	}
 
-	// Unmarshal the order from the RPC form.
-	sellOrder, err := unmarshalAssetSellOrder(req)
+	_, err = r.cfg.SessionManager.Publish(ctx, id, signedPsbts, publish)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling sell order: %w",
-			err)
+		return nil, fmt.Errorf("error publishing session: %w", err)
 	}
 
-	// Currently, we require the peer to be specified in the buy order.
-	peer, err := sellOrder.Peer.UnwrapOrErr(
-		fmt.Errorf("sell order peer must be specified"),
-	)
+	return resp, nil
+}
+
+// GetSigningSession returns the current state of a signing session.
+func (r *rpcServer) GetSigningSession(_ context.Context,
+	req *wrpc.GetSigningSessionRequest) (*wrpc.GetSigningSessionResponse,
+	error) {
+
+	id, err := parseSessionID(req.SessionId)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if we have a channel with the peer.
-	err = r.checkPeerChannel(
-		ctx, peer, sellOrder.AssetSpecifier, req.SkipAssetChannelCheck,
-	)
+	session, err := r.cfg.SessionManager.FetchSession(id)
 	if err != nil {
-		return nil, fmt.Errorf("error checking peer channel: %w", err)
+		return nil, err
 	}
 
-	rpcsLog.Debugf("[AddAssetSellOrder]: upserting sell order "+
-		"(dest_peer=%s)", peer.String())
-
-	// Register an event listener before actually inserting the order, so we
-	// definitely don't miss any responses.
-	eventSubscriber := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
-	defer eventSubscriber.Stop()
-
-	err = r.cfg.RfqManager.RegisterSubscriber(eventSubscriber, false, 0)
+	rpcSession, err := marshalSigningSession(session)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register event listener: %w",
-			err)
+		return nil, err
 	}
 
-	defer func() {
-		_ = r.cfg.RfqManager.RemoveSubscriber(eventSubscriber)
-	}()
+	return &wrpc.GetSigningSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
 
-	// Upsert the order into the RFQ manager.
-	err = r.cfg.RfqManager.UpsertAssetSellOrder(*sellOrder)
-	if err != nil {
-		return nil, fmt.Errorf("error upserting sell order into RFQ "+
-			"manager: %w", err)
+// parseSessionID parses the given signing session ID.
+func parseSessionID(rawID []byte) (tapsession.ID, error) {
+	var id tapsession.ID
+	if len(rawID) != len(id) {
+		return id, fmt.Errorf("session ID must be %d bytes", len(id))
 	}
 
-	timeout := time.After(time.Second * time.Duration(req.TimeoutSeconds))
+	copy(id[:], rawID)
 
-	for {
-		select {
-		case event := <-eventSubscriber.NewItemCreated.ChanOut():
-			resp, err := taprpc.NewAddAssetSellOrderResponse(event)
-			if err != nil {
-				return nil, fmt.Errorf("error marshalling "+
-					"sell order response: %w", err)
-			}
+	return id, nil
+}
 
-			return resp, nil
+// unmarshalSessionParticipant parses the input reference and participant key
+// of a MuSig2 nonce or partial signature.
+func unmarshalSessionParticipant(rpcRef *wrpc.SessionInputRef,
+	rawKey []byte) (tapsession.InputRef, *btcec.PublicKey, error) {
 
-		case <-r.quit:
-			return nil, fmt.Errorf("server shutting down")
+	if rpcRef == nil {
+		return tapsession.InputRef{}, nil, fmt.Errorf("input " +
+			"reference missing")
+	}
 
-		case <-timeout:
-			return nil, fmt.Errorf("timeout waiting for response "+
-				"from peer %s", peer.String())
-		}
+	key, err := btcec.ParsePubKey(rawKey)
+	if err != nil {
+		return tapsession.InputRef{}, nil, fmt.Errorf("invalid "+
+			"participant key: %w", err)
 	}
+
+	return tapsession.InputRef{
+		PacketIndex: rpcRef.PacketIndex,
+		InputIndex:  rpcRef.InputIndex,
+	}, key, nil
 }
 
-// AddAssetSellOffer upserts a new sell offer for the given asset into the
-// RFQ manager. If the offer already exists for the given asset, it will be
-// updated.
-func (r *rpcServer) AddAssetSellOffer(_ context.Context,
-	req *rfqrpc.AddAssetSellOfferRequest) (*rfqrpc.AddAssetSellOfferResponse,
-	error) {
+// unmarshalMuSig2InputSpec parses the given RPC MuSig2 input specification.
+func unmarshalMuSig2InputSpec(
+	rpcSpec *wrpc.MuSig2InputSpec) (tapsession.MuSig2InputSpec, error) {
 
-	// Unmarshal the sell offer from the RPC form.
-	assetID, assetGroupKey, err := unmarshalAssetSpecifier(
-		req.AssetSpecifier,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
-			"%w", err)
+	var spec tapsession.MuSig2InputSpec
+	if rpcSpec.Input == nil {
+		return spec, fmt.Errorf("input reference missing")
 	}
 
-	sellOffer := &rfq.SellOffer{
-		AssetID:       assetID,
-		AssetGroupKey: assetGroupKey,
-		MaxUnits:      req.MaxUnits,
+	spec.InputRef = tapsession.InputRef{
+		PacketIndex: rpcSpec.Input.PacketIndex,
+		InputIndex:  rpcSpec.Input.InputIndex,
+	}
+	for _, rawKey := range rpcSpec.ParticipantKeys {
+		key, err := btcec.ParsePubKey(rawKey)
+		if err != nil {
+			return spec, fmt.Errorf("invalid participant key: %w",
+				err)
+		}
+
+		spec.ParticipantKeys = append(spec.ParticipantKeys, key)
 	}
 
-	rpcsLog.Debugf("[AddAssetSellOffer]: upserting sell offer "+
-		"(sell_offer=%v)", sellOffer)
+	if len(rpcSpec.TapscriptRoot) != 0 &&
+		len(rpcSpec.TapscriptRoot) != sha256.Size {
 
-	// Upsert the sell offer into the RFQ manager.
-	err = r.cfg.RfqManager.UpsertAssetSellOffer(*sellOffer)
-	if err != nil {
-		return nil, fmt.Errorf("error upserting sell offer into RFQ "+
-			"manager: %w", err)
+		return spec, fmt.Errorf("tapscript root must be %d bytes",
+			sha256.Size)
 	}
+	spec.TapscriptRoot = rpcSpec.TapscriptRoot
 
-	return &rfqrpc.AddAssetSellOfferResponse{}, nil
+	return spec, nil
 }
 
-// AddAssetBuyOffer upserts a new buy offer for the given asset into the RFQ
-// manager. If the offer already exists for the given asset, it will be updated.
-//
-// A buy offer is used by the node to selectively accept or reject incoming
-// asset sell quote requests before price is considered.
-func (r *rpcServer) AddAssetBuyOffer(_ context.Context,
-	req *rfqrpc.AddAssetBuyOfferRequest) (*rfqrpc.AddAssetBuyOfferResponse,
+// unmarshalAnchorCommitment parses the response of CommitVirtualPsbts into the
+// anchor commitment of a signing session.
+func unmarshalAnchorCommitment(
+	resp *wrpc.CommitVirtualPsbtsResponse) (*tapsession.AnchorCommitment,
 	error) {
 
-	// Unmarshal the asset specifier from the RPC form.
-	assetID, assetGroupKey, err := unmarshalAssetSpecifier(
-		req.AssetSpecifier,
+	anchorPsbt, err := psbt.NewFromRawBytes(
+		bytes.NewReader(resp.AnchorPsbt), false,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling asset specifier: "+
-			"%w", err)
+		return nil, fmt.Errorf("error decoding anchor packet: %w", err)
 	}
 
-	// Upsert the offer into the RFQ manager.
-	buyOffer := rfq.BuyOffer{
-		AssetID:       assetID,
-		AssetGroupKey: assetGroupKey,
-		MaxUnits:      req.MaxUnits,
-	}
-	rpcsLog.Debugf("[AddAssetBuyOffer]: upserting buy offer (buy_offer=%v)",
-		buyOffer)
-	err = r.cfg.RfqManager.UpsertAssetBuyOffer(buyOffer)
+	activePackets, err := decodeVirtualPackets(resp.VirtualPsbts)
 	if err != nil {
-		return nil, fmt.Errorf("error upserting buy offer into RFQ "+
-			"manager: %w", err)
+		return nil, err
 	}
 
-	return &rfqrpc.AddAssetBuyOfferResponse{}, nil
-}
-
-// marshalPeerAcceptedBuyQuotes marshals a map of peer accepted asset buy quotes
-// into the RPC form. These are quotes that were requested by our node and have
-// been accepted by our peers.
-func marshalPeerAcceptedBuyQuotes(
-	quotes map[rfq.SerialisedScid]rfqmsg.BuyAccept) (
-	[]*rfqrpc.PeerAcceptedBuyQuote, error) {
+	passivePackets, err := decodeVirtualPackets(resp.PassiveAssetPsbts)
+	if err != nil {
+		return nil, err
+	}
 
-	// Marshal the accepted quotes into the RPC form.
-	rpcQuotes := make(
-		[]*rfqrpc.PeerAcceptedBuyQuote, 0, len(quotes),
-	)
-	for scid, quote := range quotes {
-		coefficient := quote.AssetRate.Rate.Coefficient.String()
-		rpcAskAssetRate := &rfqrpc.FixedPoint{
-			Coefficient: coefficient,
-			Scale:       uint32(quote.AssetRate.Rate.Scale),
+	lockedUTXOs := make([]wire.OutPoint, len(resp.LndLockedUtxos))
+	for idx, lockedUTXO := range resp.LndLockedUtxos {
+		hash, err := chainhash.NewHash(lockedUTXO.Txid)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing txid: %w", err)
 		}
 
-		rpcQuote := &rfqrpc.PeerAcceptedBuyQuote{
-			Peer:         quote.Peer.String(),
-			Id:           quote.ID[:],
-			Scid:         uint64(scid),
-			AssetAmount:  quote.Request.AssetMaxAmt,
-			AskAssetRate: rpcAskAssetRate,
-			Expiry:       uint64(quote.AssetRate.Expiry.Unix()),
+		lockedUTXOs[idx] = wire.OutPoint{
+			Hash:  *hash,
+			Index: lockedUTXO.OutputIndex,
 		}
-		rpcQuotes = append(rpcQuotes, rpcQuote)
 	}
 
-	return rpcQuotes, nil
+	return &tapsession.AnchorCommitment{
+		AnchorPsbt:        anchorPsbt,
+		ActivePackets:     activePackets,
+		PassivePackets:    passivePackets,
+		ChangeOutputIndex: resp.ChangeOutputIndex,
+		LockedUTXOs:       lockedUTXOs,
+	}, nil
 }
 
-// marshalPeerAcceptedSellQuotes marshals a map of peer accepted asset sell
-// quotes into the RPC form. These are quotes that were requested by our node
-// and have been accepted by our peers.
-//
-// nolint: lll
-func marshalPeerAcceptedSellQuotes(quotes map[rfq.SerialisedScid]rfqmsg.SellAccept) (
-	[]*rfqrpc.PeerAcceptedSellQuote, error) {
+// marshalPublishRequest creates the PublishAndLogTransfer request for the
+// given anchor commitment of a signing session.
+func marshalPublishRequest(
+	commitment *tapsession.AnchorCommitment) (*wrpc.PublishAndLogRequest,
+	error) {
 
-	// Marshal the accepted quotes into the RPC form.
-	rpcQuotes := make([]*rfqrpc.PeerAcceptedSellQuote, 0, len(quotes))
-	for scid, quote := range quotes {
-		rpcAssetRate := &rfqrpc.FixedPoint{
-			Coefficient: quote.AssetRate.Rate.Coefficient.String(),
-			Scale:       uint32(quote.AssetRate.Rate.Scale),
-		}
+	anchorPsbt, err := serialize(commitment.AnchorPsbt)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing packet: %w", err)
+	}
 
-		// TODO(ffranr): Add SellRequest payment max amount to
-		//  PeerAcceptedSellQuote.
-		rpcQuote := &rfqrpc.PeerAcceptedSellQuote{
-			Peer:         quote.Peer.String(),
-			Id:           quote.ID[:],
-			Scid:         uint64(scid),
-			BidAssetRate: rpcAssetRate,
-			Expiry:       uint64(quote.AssetRate.Expiry.Unix()),
-		}
-		rpcQuotes = append(rpcQuotes, rpcQuote)
+	activePackets, err := encodeVirtualPackets(commitment.ActivePackets)
+	if err != nil {
+		return nil, err
 	}
 
-	return rpcQuotes, nil
-}
+	passivePackets, err := encodeVirtualPackets(commitment.PassivePackets)
+	if err != nil {
+		return nil, err
+	}
 
-// QueryPeerAcceptedQuotes is used to query for quotes that were requested by
-// our node and have been accepted our peers.
-func (r *rpcServer) QueryPeerAcceptedQuotes(_ context.Context,
-	_ *rfqrpc.QueryPeerAcceptedQuotesRequest) (
-	*rfqrpc.QueryPeerAcceptedQuotesResponse, error) {
+	req := &wrpc.PublishAndLogRequest{
+		AnchorPsbt:        anchorPsbt,
+		VirtualPsbts:      activePackets,
+		PassiveAssetPsbts: passivePackets,
+		ChangeOutputIndex: commitment.ChangeOutputIndex,
+	}
+	for _, lockedUTXO := range commitment.LockedUTXOs {
+		req.LndLockedUtxos = append(
+			req.LndLockedUtxos, &taprpc.OutPoint{
+				Txid:        lockedUTXO.Hash[:],
+				OutputIndex: lockedUTXO.Index,
+			},
+		)
+	}
 
-	// Query the RFQ manager for quotes that were requested by our node and
-	// have been accepted by our peers.
-	peerAcceptedBuyQuotes := r.cfg.RfqManager.PeerAcceptedBuyQuotes()
-	peerAcceptedSellQuotes := r.cfg.RfqManager.PeerAcceptedSellQuotes()
+	return req, nil
+}
 
-	rpcBuyQuotes, err := marshalPeerAcceptedBuyQuotes(peerAcceptedBuyQuotes)
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling peer accepted buy "+
-			"quotes: %w", err)
+// marshalSigningSession converts a signing session into its RPC counterpart.
+func marshalSigningSession(
+	session *tapsession.Session) (*wrpc.SigningSession, error) {
+
+	packets := session.Packets
+	if session.Commitment != nil {
+		packets = session.Commitment.ActivePackets
 	}
 
-	rpcSellQuotes, err := marshalPeerAcceptedSellQuotes(
-		peerAcceptedSellQuotes,
-	)
+	virtualPsbts, err := encodeVirtualPackets(packets)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling peer accepted sell "+
-			"quotes: %w", err)
+		return nil, err
 	}
 
-	return &rfqrpc.QueryPeerAcceptedQuotesResponse{
-		BuyQuotes:  rpcBuyQuotes,
-		SellQuotes: rpcSellQuotes,
-	}, nil
-}
-
-// marshallRfqEvent marshals an RFQ event into the RPC form.
-func marshallRfqEvent(eventInterface fn.Event) (*rfqrpc.RfqEvent, error) {
-	timestamp := eventInterface.Timestamp().UTC().UnixMicro()
+	rpcSession := &wrpc.SigningSession{
+		SessionId:       session.ID[:],
+		State:           wrpc.SigningSessionState(session.State),
+		VirtualPsbts:    virtualPsbts,
+		ExpiryTimestamp: session.ExpiresAt.Unix(),
+	}
 
-	switch event := eventInterface.(type) {
-	case *rfq.PeerAcceptedBuyQuoteEvent:
-		acceptedQuote, err := taprpc.MarshalAcceptedBuyQuoteEvent(event)
+	if session.Commitment != nil {
+		rpcSession.AnchorPsbt, err = serialize(
+			session.Commitment.AnchorPsbt,
+		)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error serializing packet: %w",
+				err)
 		}
+	}
 
-		eventRpc := &rfqrpc.RfqEvent_PeerAcceptedBuyQuote{
-			PeerAcceptedBuyQuote: &rfqrpc.PeerAcceptedBuyQuoteEvent{
-				Timestamp:            uint64(timestamp),
-				PeerAcceptedBuyQuote: acceptedQuote,
-			},
+	if session.AnchorTxHash != nil {
+		rpcSession.AnchorTxid = session.AnchorTxHash.String()
+	}
+
+	// The MuSig2 inputs are returned in the order of their reference, so
+	// the output is stable.
+	refs := maps.Keys(session.MuSig2Inputs)
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].PacketIndex != refs[j].PacketIndex {
+			return refs[i].PacketIndex < refs[j].PacketIndex
 		}
-		return &rfqrpc.RfqEvent{
-			Event: eventRpc,
-		}, nil
 
-	case *rfq.PeerAcceptedSellQuoteEvent:
-		rpcAcceptedQuote, err := taprpc.MarshalAcceptedSellQuoteEvent(
-			event,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error marshalling accepted "+
-				"sell quote event: %w", err)
+		return refs[i].InputIndex < refs[j].InputIndex
+	})
+
+	for _, ref := range refs {
+		in := session.MuSig2Inputs[ref]
+		rpcRef := &wrpc.SessionInputRef{
+			PacketIndex: ref.PacketIndex,
+			InputIndex:  ref.InputIndex,
 		}
 
-		eventRpc := &rfqrpc.RfqEvent_PeerAcceptedSellQuote{
-			PeerAcceptedSellQuote: &rfqrpc.PeerAcceptedSellQuoteEvent{
-				Timestamp:             uint64(timestamp),
-				PeerAcceptedSellQuote: rpcAcceptedQuote,
+		rpcIn := &wrpc.SessionMuSig2Input{
+			Spec: &wrpc.MuSig2InputSpec{
+				Input:         rpcRef,
+				TapscriptRoot: in.TapscriptRoot,
 			},
+			SigHash: in.SigHash[:],
 		}
-		return &rfqrpc.RfqEvent{
-			Event: eventRpc,
-		}, nil
+		for _, key := range in.ParticipantKeys {
+			rawKey := key.SerializeCompressed()
+			rpcIn.Spec.ParticipantKeys = append(
+				rpcIn.Spec.ParticipantKeys, rawKey,
+			)
 
-	case *rfq.AcceptHtlcEvent:
-		eventRpc := &rfqrpc.RfqEvent_AcceptHtlc{
-			AcceptHtlc: &rfqrpc.AcceptHtlcEvent{
-				Timestamp: uint64(timestamp),
-				Scid:      event.Policy.Scid(),
-			},
+			var mapKey [33]byte
+			copy(mapKey[:], rawKey)
+			if nonce, ok := in.Nonces[mapKey]; ok {
+				rpcIn.Nonces = append(
+					rpcIn.Nonces, &wrpc.MuSig2Nonce{
+						Input:          rpcRef,
+						ParticipantKey: rawKey,
+						PubNonce:       nonce[:],
+					},
+				)
+			}
+
+			if _, ok := in.PartialSigs[mapKey]; ok {
+				rpcIn.SignedParticipants = append(
+					rpcIn.SignedParticipants, rawKey,
+				)
+			}
 		}
-		return &rfqrpc.RfqEvent{
-			Event: eventRpc,
-		}, nil
 
-	default:
-		return nil, fmt.Errorf("unknown RFQ event type: %T",
-			eventInterface)
+		combinedNonce, ok, err := in.CombinedNonce()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rpcIn.CombinedNonce = combinedNonce[:]
+		}
+
+		rpcSession.Musig2Inputs = append(
+			rpcSession.Musig2Inputs, rpcIn,
+		)
 	}
+
+	return rpcSession, nil
 }
 
-// SubscribeRfqEventNtfns subscribes to RFQ event notifications.
-func (r *rpcServer) SubscribeRfqEventNtfns(
-	_ *rfqrpc.SubscribeRfqEventNtfnsRequest,
-	ntfnStream rfqrpc.Rfq_SubscribeRfqEventNtfnsServer) error {
+// CreateSwapOffer creates an offer to sell an asset for BTC or another asset
+// to another daemon in an atomic swap.
+func (r *rpcServer) CreateSwapOffer(_ context.Context,
+	req *wrpc.CreateSwapOfferRequest) (*wrpc.CreateSwapOfferResponse,
+	error) {
 
-	filter := func(event fn.Event) (bool, error) {
-		return true, nil
+	if len(req.AssetId) != sha256.Size {
+		return nil, fmt.Errorf("asset ID must be %d bytes",
+			sha256.Size)
 	}
 
-	return handleEvents[uint64, *rfqrpc.RfqEvent](
-		r.cfg.RfqManager, ntfnStream, marshallRfqEvent, filter, r.quit,
-		0,
+	assetPrice, err := unmarshalSwapAssetPrice(
+		req.PriceAssetId, req.PriceAssetAmount,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := defaultSwapQuoteExpiry
+	if req.ExpirySeconds != 0 {
+		expiry = time.Duration(req.ExpirySeconds) * time.Second
+	}
+
+	swap, err := r.cfg.SwapManager.CreateOffer(tapswap.Quote{
+		AssetID:     asset.ID(req.AssetId),
+		AssetAmount: req.AssetAmount,
+		Price:       btcutil.Amount(req.PriceSat),
+		AssetPrice:  assetPrice,
+		Expiry:      time.Now().Add(expiry),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating swap offer: %w", err)
+	}
+
+	rpcSwap, err := marshalSwap(swap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrpc.CreateSwapOfferResponse{
+		Swap: rpcSwap,
+	}, nil
 }
 
-// FundChannel initiates the channel funding negotiation with a peer for the
-// creation of a channel that contains a specified amount of a given asset.
-func (r *rpcServer) FundChannel(ctx context.Context,
-	req *tchrpc.FundChannelRequest) (*tchrpc.FundChannelResponse,
+// AcceptSwapOffer accepts the quote of a seller and derives the keys the asset
+// is sent to.
+func (r *rpcServer) AcceptSwapOffer(ctx context.Context,
+	req *wrpc.AcceptSwapOfferRequest) (*wrpc.AcceptSwapOfferResponse,
 	error) {
 
-	// If we're not running inside litd, we cannot offer this functionality.
-	if !r.cfg.EnableChannelFeatures {
-		return nil, fmt.Errorf("the Taproot Asset channel " +
-			"functionality is only available when running inside " +
-			"Lightning Terminal daemon (litd), with lnd and tapd " +
-			"both running in 'integrated' mode")
+	id, quote, err := unmarshalSwapQuote(req.Quote)
+	if err != nil {
+		return nil, err
+	}
+
+	accept := func(ctx context.Context,
+		_ tapswap.Quote) (*tapswap.Acceptance, error) {
+
+		return r.deriveSwapKeys(ctx)
+	}
+
+	swap, err := r.cfg.SwapManager.AcceptOffer(ctx, id, quote, accept)
+	if err != nil {
+		return nil, fmt.Errorf("error accepting swap offer: %w", err)
+	}
+
+	rpcSwap, err := marshalSwap(swap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrpc.AcceptSwapOfferResponse{
+		Swap: rpcSwap,
+	}, nil
+}
+
+// ProposeSwap funds and signs the virtual transaction that sends the asset of
+// an offered swap to the buyer.
+func (r *rpcServer) ProposeSwap(ctx context.Context,
+	req *wrpc.ProposeSwapRequest) (*wrpc.ProposeSwapResponse, error) {
+
+	if req.Acceptance == nil {
+		return nil, fmt.Errorf("acceptance must be specified")
+	}
+
+	id, err := parseSwapID(req.Acceptance.SwapId)
+	if err != nil {
+		return nil, err
 	}
 
-	peerPub, err := btcec.ParsePubKey(req.PeerPubkey)
+	scriptKey, err := btcec.ParsePubKey(req.Acceptance.ScriptKey)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing peer pubkey: %w", err)
+		return nil, fmt.Errorf("invalid script key: %w", err)
 	}
 
-	if len(req.AssetId) != sha256.Size {
-		return nil, fmt.Errorf("asset ID must be 32 bytes")
+	internalKey, err := btcec.ParsePubKey(req.Acceptance.InternalKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid internal key: %w", err)
 	}
 
-	if req.AssetAmount == 0 {
-		return nil, fmt.Errorf("asset amount must be specified")
+	swap, err := r.cfg.SwapManager.Propose(
+		ctx, id, tapswap.Acceptance{
+			ScriptKey:   scriptKey,
+			InternalKey: internalKey,
+		}, r.proposeSwap,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error proposing swap: %w", err)
 	}
-	if req.FeeRateSatPerVbyte == 0 {
-		return nil, fmt.Errorf("fee rate must be specified")
+
+	rpcSwap, err := marshalSwap(swap)
+	if err != nil {
+		return nil, err
 	}
 
-	fundReq := tapchannel.FundReq{
-		PeerPub:     *peerPub,
-		AssetAmount: req.AssetAmount,
-		FeeRate:     chainfee.SatPerVByte(req.FeeRateSatPerVbyte),
-		PushAmount:  btcutil.Amount(req.PushSat),
+	return &wrpc.ProposeSwapResponse{
+		Swap: rpcSwap,
+	}, nil
+}
+
+// deriveSwapKeys derives the keys we receive the asset of a swap with. Both
+// keys are inserted into our database when derived, so the asset is recognized
+// as ours once we import its proof.
+func (r *rpcServer) deriveSwapKeys(
+	ctx context.Context) (*tapswap.Acceptance, error) {
+
+	scriptKey, err := r.cfg.AddrBook.NextScriptKey(
+		ctx, asset.TaprootAssetsKeyFamily,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving script key: %w", err)
 	}
-	copy(fundReq.AssetID[:], req.AssetId)
 
-	chanPoint, err := r.cfg.AuxFundingController.FundChannel(ctx, fundReq)
+	internalKey, err := r.cfg.AddrBook.NextInternalKey(
+		ctx, asset.TaprootAssetsKeyFamily,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("error funding channel: %w", err)
+		return nil, fmt.Errorf("error deriving internal key: %w", err)
 	}
 
-	return &tchrpc.FundChannelResponse{
-		Txid:        chanPoint.Hash.String(),
-		OutputIndex: int32(chanPoint.Index),
+	return &tapswap.Acceptance{
+		ScriptKey:   scriptKey.PubKey,
+		InternalKey: internalKey.PubKey,
 	}, nil
 }
 
-// EncodeCustomRecords allows RPC users to encode Taproot Asset channel related
-// data into the TLV format that is used in the custom records of the lnd
-// payment or other channel related RPCs. This RPC is completely stateless and
-// does not perform any checks on the data provided, other than pure format
-// validation.
-func (r *rpcServer) EncodeCustomRecords(_ context.Context,
-	in *tchrpc.EncodeCustomRecordsRequest) (
-	*tchrpc.EncodeCustomRecordsResponse, error) {
+// swapTransfer is one leg of an atomic swap, the signed virtual transactions
+// that send an asset to the other party.
+type swapTransfer struct {
+	// activePacket sends the asset to the other party.
+	activePacket *tappsbt.VPacket
 
-	switch i := in.Input.(type) {
-	case *tchrpc.EncodeCustomRecordsRequest_RouterSendPayment:
-		req := i.RouterSendPayment
+	// passivePackets carry along the other assets of the anchor inputs.
+	passivePackets []*tappsbt.VPacket
 
-		assetAmounts := make(
-			[]*rfqmsg.AssetBalance, 0, len(req.AssetAmounts),
-		)
-		for idStr, amount := range req.AssetAmounts {
-			idBytes, err := hex.DecodeString(idStr)
-			if err != nil {
-				return nil, fmt.Errorf("error decoding asset "+
-					"ID: %w", err)
-			}
+	// inputProofs are the proof files of the inputs of the active packet.
+	inputProofs []proof.Blob
+}
 
-			if len(idBytes) != sha256.Size {
-				return nil, fmt.Errorf("asset ID must be 32 " +
-					"bytes")
-			}
+// fundSwapTransfer funds and signs a virtual transaction that sends the given
+// amount of the given asset to the keys of the other party of a swap, anchored
+// at the given output index. The funded assets stay leased until they are
+// spent by the anchor transaction or the lease expires.
+func (r *rpcServer) fundSwapTransfer(ctx context.Context, assetID asset.ID,
+	amount uint64, keys tapswap.Acceptance,
+	anchorOutputIndex uint32) (*swapTransfer, error) {
 
-			if amount == 0 {
-				return nil, fmt.Errorf("asset amount must be " +
-					"specified")
-			}
+	vPkt := tappsbt.ForInteractiveSend(
+		assetID, amount, asset.NewScriptKey(keys.ScriptKey), 0, 0,
+		anchorOutputIndex, keychain.KeyDescriptor{
+			PubKey: keys.InternalKey,
+		}, asset.V0, &r.cfg.ChainParams,
+	)
 
-			var assetID asset.ID
-			copy(assetID[:], idBytes)
+	// We can only sign for inputs with a BIP-086 script key without any
+	// further information, so we don't select any other coins.
+	fundedVPkt, err := r.cfg.AssetWallet.FundPacket(
+		ctx, &tapsend.FundingDescriptor{
+			AssetSpecifier: asset.NewSpecifierFromId(assetID),
+			Amount:         amount,
+			CoinSelectType: tapsend.Bip86Only,
+		}, vPkt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error funding packet: %w", err)
+	}
+	vPkt = fundedVPkt.VPacket
 
-			assetAmounts = append(
-				assetAmounts, rfqmsg.NewAssetBalance(
-					assetID, amount,
-				),
-			)
+	success := false
+	defer func() {
+		if !success {
+			r.releaseSwapInputs(ctx, vPkt)
 		}
+	}()
 
-		rfqID := fn.None[rfqmsg.ID]()
-		if len(req.RfqId) > 0 {
-			if len(req.RfqId) != sha256.Size {
-				return nil, fmt.Errorf("RFQ ID must be empty " +
-					"or exactly 32 bytes")
-			}
+	// Our assets are signed right away. Their witnesses only commit to the
+	// virtual transaction, so they can't be used without the anchor
+	// transaction that pays us.
+	_, err = r.cfg.AssetWallet.SignVirtualPacket(vPkt)
+	if err != nil {
+		return nil, fmt.Errorf("error signing packet: %w", err)
+	}
 
-			var id rfqmsg.ID
-			copy(id[:], req.RfqId)
+	passivePackets, err := r.cfg.AssetWallet.CreatePassiveAssets(
+		ctx, []*tappsbt.VPacket{vPkt}, fundedVPkt.InputCommitments,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating passive assets: %w", err)
+	}
 
-			rfqID = fn.Some[rfqmsg.ID](id)
+	err = r.cfg.AssetWallet.SignPassiveAssets(passivePackets)
+	if err != nil {
+		return nil, fmt.Errorf("error signing passive assets: %w", err)
+	}
+
+	// The other party needs the full provenance of the assets we spend.
+	inputProofs := make([]proof.Blob, len(vPkt.Inputs))
+	for idx, vIn := range vPkt.Inputs {
+		inputProofs[idx], err = r.cfg.ProofArchive.FetchProof(
+			ctx, proof.Locator{
+				AssetID:   &vIn.PrevID.ID,
+				ScriptKey: *vIn.Asset().ScriptKey.PubKey,
+				OutPoint:  &vIn.PrevID.OutPoint,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching proof of "+
+				"input %d: %w", idx, err)
 		}
+	}
 
-		htlc := rfqmsg.NewHtlc(assetAmounts, rfqID)
+	success = true
 
-		// We'll now map the HTLC struct into a set of TLV records,
-		// which we can then encode into the map format expected.
-		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
+	return &swapTransfer{
+		activePacket:   vPkt,
+		passivePackets: passivePackets,
+		inputProofs:    inputProofs,
+	}, nil
+}
+
+// releaseSwapInputs releases the leases of the asset inputs of the given
+// packet.
+func (r *rpcServer) releaseSwapInputs(ctx context.Context,
+	vPkt *tappsbt.VPacket) {
+
+	outpoints := fn.Map(
+		vPkt.Inputs, func(vIn *tappsbt.VInput) wire.OutPoint {
+			return vIn.PrevID.OutPoint
+		},
+	)
+	err := r.cfg.AssetWallet.ReleaseCoins(ctx, outpoints...)
+	if err != nil {
+		rpcsLog.Errorf("Unable to release coins: %v", err)
+	}
+}
+
+// proposeSwap funds and signs the virtual transactions that send the asset of
+// the quote to the keys of the buyer. We're either paid to a new address of
+// our wallet or, in an asset-for-asset swap, to new keys of our asset wallet.
+func (r *rpcServer) proposeSwap(ctx context.Context, quote tapswap.Quote,
+	acceptance tapswap.Acceptance) (*tapswap.Proposal, error) {
+
+	transfer, err := r.fundSwapTransfer(
+		ctx, quote.AssetID, quote.AssetAmount, acceptance, 0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	success := false
+	defer func() {
+		if !success {
+			r.releaseSwapInputs(ctx, transfer.activePacket)
+		}
+	}()
+
+	proposal := &tapswap.Proposal{
+		ActivePackets:  []*tappsbt.VPacket{transfer.activePacket},
+		PassivePackets: transfer.passivePackets,
+		InputProofs:    transfer.inputProofs,
+	}
+
+	if quote.IsAssetSwap() {
+		proposal.PriceKeys, err = r.deriveSwapKeys(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("unable to encode records as "+
-				"map: %w", err)
+			return nil, err
 		}
 
-		return &tchrpc.EncodeCustomRecordsResponse{
-			CustomRecords: htlcMapRecords,
-		}, nil
+		success = true
 
-	default:
-		return nil, fmt.Errorf("unknown input type: %T", i)
+		return proposal, nil
+	}
+
+	// We're paid to a P2WKH address, since the buyer can't create the
+	// exclusion proof of a P2TR output it doesn't know the internal key
+	// of.
+	paymentAddr, err := r.cfg.Lnd.WalletKit.NextAddr(
+		ctx, "", walletrpc.AddressType_WITNESS_PUBKEY_HASH, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving payment address: %w",
+			err)
 	}
+
+	proposal.PaymentPkScript, err = txscript.PayToAddrScript(paymentAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error creating payment script: %w",
+			err)
+	}
+
+	success = true
+
+	return proposal, nil
 }
 
-// SendPayment is a wrapper around lnd's routerrpc.SendPaymentV2 RPC method
-// with asset specific parameters. It allows RPC users to send asset keysend
-// payments (direct payments) or payments to an invoice with a specified asset
-// amount.
-func (r *rpcServer) SendPayment(req *tchrpc.SendPaymentRequest,
-	stream tchrpc.TaprootAssetChannels_SendPaymentServer) error {
+// FundSwap validates the seller's proposal of a swap and commits its virtual
+// transactions to an anchor transaction that also pays the seller and is
+// funded and signed by our wallet.
+func (r *rpcServer) FundSwap(ctx context.Context,
+	req *wrpc.FundSwapRequest) (*wrpc.FundSwapResponse, error) {
 
-	if req.PaymentRequest == nil {
-		return fmt.Errorf("payment request must be specified")
+	id, proposal, err := unmarshalSwapProposal(req.Proposal)
+	if err != nil {
+		return nil, err
 	}
-	pReq := req.PaymentRequest
-	ctx := stream.Context()
 
-	// Do some preliminary checks on the asset ID and make sure we have any
-	// balance for that asset.
-	if len(req.AssetId) != sha256.Size {
-		return fmt.Errorf("asset ID must be 32 bytes")
+	commitReq := &wrpc.CommitVirtualPsbtsRequest{
+		AnchorChangeOutput: &wrpc.CommitVirtualPsbtsRequest_Add{
+			Add: true,
+		},
 	}
-	var assetID asset.ID
-	copy(assetID[:], req.AssetId)
+	switch fees := req.Fees.(type) {
+	case *wrpc.FundSwapRequest_TargetConf:
+		commitReq.Fees = &wrpc.CommitVirtualPsbtsRequest_TargetConf{
+			TargetConf: fees.TargetConf,
+		}
 
-	// Now that we know we have at least _some_ asset balance, we'll figure
-	// out what kind of payment this is, so we can determine _how many_
-	// asset units we need.
-	destRecords := pReq.DestCustomRecords
-	_, isKeysend := destRecords[record.KeySendType]
-	firstHopRecords := pReq.FirstHopCustomRecords
+	case *wrpc.FundSwapRequest_SatPerVbyte:
+		commitReq.Fees = &wrpc.CommitVirtualPsbtsRequest_SatPerVbyte{
+			SatPerVbyte: fees.SatPerVbyte,
+		}
 
-	switch {
-	// Both payment request and keysend is set, which isn't a supported
-	// combination.
-	case pReq.PaymentRequest != "" && isKeysend:
-		return fmt.Errorf("payment request and keysend custom " +
-			"records cannot be set at the same time")
+	default:
+		return nil, fmt.Errorf("either target conf or fee rate must " +
+			"be specified")
+	}
 
-	// There are custom records for the first hop set, which means RFQ
-	// negotiation has already happened (or this is a keysend payment and
-	// the correct asset amount is already encoded). So we don't need to do
-	// anything special and can just forward the payment to lnd.
-	case len(firstHopRecords) > 0:
-		// Continue below.
+	// We commit the packets through the same code path as a user calling
+	// CommitVirtualPsbts with a template that spends the seller's anchor
+	// inputs and pays the seller.
+	fund := func(ctx context.Context,
+		swap *tapswap.Swap) (*tapswap.Funding, error) {
 
-	// The request wants to pay a specific invoice.
-	case pReq.PaymentRequest != "":
-		invoice, err := zpay32.Decode(
-			pReq.PaymentRequest, r.cfg.Lnd.ChainParams,
+		numSellerActive := len(swap.Proposal.ActivePackets)
+		numSellerPassive := len(swap.Proposal.PassivePackets)
+		activePackets := append(
+			[]*tappsbt.VPacket{}, swap.Proposal.ActivePackets...,
+		)
+		passivePackets := append(
+			[]*tappsbt.VPacket{}, swap.Proposal.PassivePackets...,
 		)
-		if err != nil {
-			return fmt.Errorf("error decoding payment request: %w",
-				err)
-		}
 
-		// The peer public key is optional if there is only a single
-		// asset channel.
-		var peerPubKey *route.Vertex
-		if len(req.PeerPubkey) > 0 {
-			parsedKey, err := route.NewVertexFromBytes(
-				req.PeerPubkey,
+		// In an asset-for-asset swap, we pay the seller with our own
+		// transfer of the price asset. It is anchored in the outputs
+		// after the seller's, since each party only logs the transfer
+		// of its own packets.
+		var (
+			priceTransfer *swapTransfer
+			success       bool
+		)
+		if swap.Quote.IsAssetSwap() {
+			price := swap.Quote.AssetPrice.UnwrapOr(
+				tapswap.AssetPrice{},
+			)
+
+			var maxOutputIndex uint32
+			sellerPackets := append(
+				[]*tappsbt.VPacket{}, activePackets...,
+			)
+			sellerPackets = append(sellerPackets, passivePackets...)
+			for _, vPkt := range sellerPackets {
+				for _, vOut := range vPkt.Outputs {
+					maxOutputIndex = max(
+						maxOutputIndex,
+						vOut.AnchorOutputIndex,
+					)
+				}
+			}
+
+			var err error
+			priceTransfer, err = r.fundSwapTransfer(
+				ctx, price.AssetID, price.Amount,
+				*swap.Proposal.PriceKeys, maxOutputIndex+1,
 			)
 			if err != nil {
-				return fmt.Errorf("error parsing peer pubkey: "+
-					"%w", err)
+				return nil, err
 			}
 
-			peerPubKey = &parsedKey
+			defer func() {
+				if !success {
+					r.releaseSwapInputs(
+						ctx, priceTransfer.activePacket,
+					)
+				}
+			}()
+
+			activePackets = append(
+				activePackets, priceTransfer.activePacket,
+			)
+			passivePackets = append(
+				passivePackets, priceTransfer.passivePackets...,
+			)
 		}
 
-		// We can now query the asset channels we have.
-		assetChan, err := r.rfqChannel(ctx, assetID, peerPubKey)
+		allPackets := append([]*tappsbt.VPacket{}, activePackets...)
+		allPackets = append(allPackets, passivePackets...)
+
+		anchorPsbt, err := tapsend.PrepareAnchoringTemplate(allPackets)
 		if err != nil {
-			return fmt.Errorf("error finding asset channel to "+
-				"use: %w", err)
+			return nil, fmt.Errorf("error creating anchor "+
+				"template: %w", err)
 		}
 
-		// Even if the user didn't specify the peer public key before,
-		// we definitely know it now. So let's make sure it's always
-		// set.
-		peerPubKey = &assetChan.channelInfo.PubKeyBytes
+		if !swap.Quote.IsAssetSwap() {
+			anchorPsbt.UnsignedTx.AddTxOut(&wire.TxOut{
+				Value:    int64(swap.Quote.Price),
+				PkScript: swap.Proposal.PaymentPkScript,
+			})
+			anchorPsbt.Outputs = append(
+				anchorPsbt.Outputs, psbt.POutput{},
+			)
+		}
 
-		// paymentMaxAmt is the maximum amount that the counterparty is
-		// expected to pay. This is the amount that the invoice is
-		// asking for plus the fee limit in milli-satoshis.
-		var paymentMaxAmt lnwire.MilliSatoshi
-		if invoice.MilliSat == nil {
-			amt, err := lnrpc.UnmarshallAmt(pReq.Amt, pReq.AmtMsat)
-			if err != nil {
-				return fmt.Errorf("error unmarshalling "+
-					"amount: %w", err)
-			}
-			if amt == 0 {
-				return errors.New("amount must be specified " +
-					"when paying a zero amount invoice")
-			}
+		commitReq.AnchorPsbt, err = serialize(anchorPsbt)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing packet: %w",
+				err)
+		}
 
-			paymentMaxAmt = amt
-		} else {
-			paymentMaxAmt = *invoice.MilliSat
+		commitReq.VirtualPsbts, err = encodeVirtualPackets(
+			activePackets,
+		)
+		if err != nil {
+			return nil, err
 		}
 
-		// Calculate the fee limit that should be used for this payment.
-		feeLimit, err := lnrpc.UnmarshallAmt(
-			pReq.FeeLimitSat, pReq.FeeLimitMsat,
+		commitReq.PassiveAssetPsbts, err = encodeVirtualPackets(
+			passivePackets,
 		)
 		if err != nil {
-			return fmt.Errorf("error unmarshalling fee limit: %w",
-				err)
+			return nil, err
 		}
 
-		paymentMaxAmt += feeLimit
+		resp, err := r.CommitVirtualPsbts(ctx, commitReq)
+		if err != nil {
+			return nil, err
+		}
 
-		expiryTimestamp := invoice.Timestamp.Add(invoice.Expiry())
-		resp, err := r.AddAssetSellOrder(
-			ctx, &rfqrpc.AddAssetSellOrderRequest{
-				AssetSpecifier: &rfqrpc.AssetSpecifier{
-					Id: &rfqrpc.AssetSpecifier_AssetId{
-						AssetId: assetID[:],
-					},
-				},
-				PaymentMaxAmt: uint64(paymentMaxAmt),
-				Expiry:        uint64(expiryTimestamp.Unix()),
-				PeerPubKey:    peerPubKey[:],
-				TimeoutSeconds: uint32(
-					rfq.DefaultTimeout.Seconds(),
-				),
-			},
+		commitment, err := unmarshalAnchorCommitment(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Our wallet only signs the inputs it funded the anchor
+		// transaction with, including the anchor inputs of our price
+		// asset. The seller signs its anchor inputs.
+		signedPsbt, err := r.cfg.Lnd.WalletKit.SignPsbt(
+			ctx, commitment.AnchorPsbt,
 		)
 		if err != nil {
-			return fmt.Errorf("error adding sell order: %w", err)
+			return nil, fmt.Errorf("error signing anchor "+
+				"transaction: %w", err)
 		}
 
-		var acceptedQuote *rfqrpc.PeerAcceptedSellQuote
-		switch r := resp.Response.(type) {
-		case *rfqrpc.AddAssetSellOrderResponse_AcceptedQuote:
-			acceptedQuote = r.AcceptedQuote
+		// The committed packets are returned in the order we passed
+		// them in, so ours follow the seller's.
+		active := commitment.ActivePackets
+		passive := commitment.PassivePackets
+		funding := &tapswap.Funding{
+			AnchorPsbt:     signedPsbt,
+			ActivePackets:  active[:numSellerActive],
+			PassivePackets: passive[:numSellerPassive],
+		}
+		if priceTransfer != nil {
+			funding.PricePackets = active[numSellerActive:]
+			funding.PricePassivePackets = passive[numSellerPassive:]
+			funding.PriceInputProofs = priceTransfer.inputProofs
+		}
 
-		case *rfqrpc.AddAssetSellOrderResponse_InvalidQuote:
-			return fmt.Errorf("peer %v sent back an invalid "+
-				"quote, status: %v", r.InvalidQuote.Peer,
-				r.InvalidQuote.Status.String())
+		success = true
 
-		case *rfqrpc.AddAssetSellOrderResponse_RejectedQuote:
-			return fmt.Errorf("peer %v rejected the quote, code: "+
-				"%v, error message: %v", r.RejectedQuote.Peer,
-				r.RejectedQuote.ErrorCode,
-				r.RejectedQuote.ErrorMessage)
+		return funding, nil
+	}
 
-		default:
-			return fmt.Errorf("unexpected response type: %T", r)
+	swap, err := r.cfg.SwapManager.Fund(ctx, id, proposal, fund)
+	if err != nil {
+		return nil, fmt.Errorf("error funding swap: %w", err)
+	}
+
+	rpcSwap, err := marshalSwap(swap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrpc.FundSwapResponse{
+		Swap: rpcSwap,
+	}, nil
+}
+
+// PublishSwap validates the buyer's funding of a swap and, if the anchor
+// transaction pays the agreed price, signs our inputs, publishes the anchor
+// transaction and logs the transfer.
+func (r *rpcServer) PublishSwap(ctx context.Context,
+	req *wrpc.PublishSwapRequest) (*taprpc.SendAssetResponse, error) {
+
+	id, funding, err := unmarshalSwapFunding(req.Funding)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fully signed anchor transaction is published through the same
+	// code path as a user calling PublishAndLogTransfer.
+	var resp *taprpc.SendAssetResponse
+	publish := func(ctx context.Context, _ *tapswap.Swap,
+		funding *tapswap.Funding) error {
+
+		allPackets := append(
+			[]*tappsbt.VPacket{}, funding.ActivePackets...,
+		)
+		allPackets = append(allPackets, funding.PassivePackets...)
+
+		// The derivation information of our anchor inputs is added
+		// while validating them, so our wallet can sign them.
+		anchorPsbt := funding.AnchorPsbt
+		err := r.validateInputAssets(ctx, anchorPsbt, allPackets)
+		if err != nil {
+			return fmt.Errorf("error validating input assets: %w",
+				err)
 		}
 
-		// Send out the information about the quote on the stream.
-		err = stream.Send(&tchrpc.SendPaymentResponse{
-			Result: &tchrpc.SendPaymentResponse_AcceptedSellOrder{
-				AcceptedSellOrder: acceptedQuote,
-			},
-		})
+		signedPsbt, err := r.cfg.Lnd.WalletKit.SignPsbt(ctx, anchorPsbt)
 		if err != nil {
-			return err
+			return fmt.Errorf("error signing anchor transaction: "+
+				"%w", err)
 		}
 
-		// Unmarshall the accepted quote's asset rate.
-		assetRate, err := rfqrpc.UnmarshalFixedPoint(
-			acceptedQuote.BidAssetRate,
+		if err := psbt.MaybeFinalizeAll(signedPsbt); err != nil {
+			return fmt.Errorf("anchor transaction isn't fully "+
+				"signed: %w", err)
+		}
+
+		publishReq, err := marshalPublishRequest(
+			&tapsession.AnchorCommitment{
+				AnchorPsbt:        signedPsbt,
+				ActivePackets:     funding.ActivePackets,
+				PassivePackets:    funding.PassivePackets,
+				ChangeOutputIndex: -1,
+			},
 		)
 		if err != nil {
-			return fmt.Errorf("error unmarshalling asset rate: %w",
-				err)
+			return err
 		}
 
-		// Calculate the equivalent asset units for the given invoice
-		// amount based on the asset-to-BTC conversion rate.
-		numAssetUnits := rfqmath.MilliSatoshiToUnits(
-			*invoice.MilliSat, *assetRate,
-		)
+		resp, err = r.PublishAndLogTransfer(ctx, publishReq)
 
-		rpcsLog.Infof("Got quote for %v asset units at %v asset/BTC "+
-			"from peer %x with SCID %d", numAssetUnits, assetRate,
-			peerPubKey, acceptedQuote.Scid)
+		return err
+	}
 
-		var rfqID rfqmsg.ID
-		copy(rfqID[:], acceptedQuote.Id)
+	_, err = r.cfg.SwapManager.Publish(ctx, id, funding, publish)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing swap: %w", err)
+	}
 
-		htlc := rfqmsg.NewHtlc(nil, fn.Some(rfqID))
+	return resp, nil
+}
 
-		// We'll now map the HTLC struct into a set of TLV records,
-		// which we can then encode into the expected map format.
-		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
-		if err != nil {
-			return fmt.Errorf("unable to encode records as map: %w",
-				err)
-		}
+// CompleteSwap waits for the anchor transaction of a swap to confirm and
+// imports the proof of the asset that was received. The buyer completes a
+// funded swap, the seller only completes a published asset-for-asset swap.
+func (r *rpcServer) CompleteSwap(ctx context.Context,
+	req *wrpc.CompleteSwapRequest) (*wrpc.CompleteSwapResponse, error) {
 
-		pReq.FirstHopCustomRecords = htlcMapRecords
+	id, err := parseSwapID(req.SwapId)
+	if err != nil {
+		return nil, err
+	}
 
-	// The payment request is a keysend payment.
-	case isKeysend:
-		if req.AssetAmount == 0 {
-			return fmt.Errorf("asset amount must be specified " +
-				"for keysend payment")
-		}
+	swap, err := r.cfg.SwapManager.Complete(ctx, id, r.completeSwap)
+	if err != nil {
+		return nil, fmt.Errorf("error completing swap: %w", err)
+	}
 
-		balances := []*rfqmsg.AssetBalance{
-			rfqmsg.NewAssetBalance(assetID, req.AssetAmount),
+	rpcSwap, err := marshalSwap(swap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrpc.CompleteSwapResponse{
+		Swap: rpcSwap,
+	}, nil
+}
+
+// completeSwap waits for the anchor transaction of the given swap to confirm,
+// then appends the transition proof of our output to the proofs of the other
+// party's inputs and imports the resulting proof file. In an asset-for-asset
+// swap, the buyer also logs its transfer of the price asset.
+func (r *rpcServer) completeSwap(ctx context.Context,
+	swap *tapswap.Swap) error {
+
+	// As the buyer we receive the sold asset, as the seller we receive
+	// the price asset.
+	var (
+		vPkt        = swap.Funding.ActivePackets[0]
+		assetID     = swap.Quote.AssetID
+		scriptKey   = swap.Acceptance.ScriptKey
+		inputProofs = swap.Proposal.InputProofs
+	)
+	if swap.Role == tapswap.RoleSeller {
+		vPkt = swap.Funding.PricePackets[0]
+		assetID = swap.Quote.AssetPrice.UnwrapOr(
+			tapswap.AssetPrice{},
+		).AssetID
+		scriptKey = swap.Proposal.PriceKeys.ScriptKey
+		inputProofs = swap.Funding.PriceInputProofs
+	}
+
+	var vOut *tappsbt.VOutput
+	for idx := range vPkt.Outputs {
+		if vPkt.Outputs[idx].ScriptKey.PubKey.IsEqual(scriptKey) {
+			vOut = vPkt.Outputs[idx]
 		}
-		htlc := rfqmsg.NewHtlc(balances, fn.None[rfqmsg.ID]())
+	}
+	if vOut == nil || vOut.ProofSuffix == nil {
+		return fmt.Errorf("swap has no proof of our output")
+	}
 
-		// We'll now map the HTLC struct into a set of TLV records,
-		// which we can then encode into the map format expected.
-		htlcMapRecords, err := tlv.RecordsToMap(htlc.Records())
-		if err != nil {
-			return fmt.Errorf("unable to encode records as map: %w",
-				err)
+	// The anchor transaction can't have confirmed before the other
+	// party's inputs, so their height is a safe height hint.
+	var heightHint uint32
+	for _, vIn := range vPkt.Inputs {
+		if vIn.Proof != nil && vIn.Proof.BlockHeight > heightHint {
+			heightHint = vIn.Proof.BlockHeight
 		}
+	}
 
-		pReq.FirstHopCustomRecords = htlcMapRecords
+	anchorTx := swap.Funding.AnchorPsbt.UnsignedTx
+	pkScript := anchorTx.TxOut[vOut.AnchorOutputIndex].PkScript
+	confNtfn, errChan, err := r.cfg.ChainBridge.RegisterConfirmationsNtfn(
+		ctx, swap.AnchorTxHash, pkScript, 1, heightHint, true, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error registering for confirmation: %w",
+			err)
 	}
 
-	rpcCtx, _, routerClient := r.cfg.Lnd.Router.RawClientWithMacAuth(ctx)
-	updateStream, err := routerClient.SendPaymentV2(rpcCtx, pReq)
+	var confEvent *chainntnfs.TxConfirmation
+	select {
+	case confEvent = <-confNtfn.Confirmed:
+
+	case err := <-errChan:
+		return fmt.Errorf("error waiting for confirmation: %w", err)
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	proofSuffix := vOut.ProofSuffix
+	err = proofSuffix.UpdateTransitionProof(&proof.BaseProofParams{
+		Block:       confEvent.Block,
+		BlockHeight: confEvent.BlockHeight,
+		Tx:          confEvent.Tx,
+		TxIndex:     int(confEvent.TxIndex),
+	})
+	if err != nil {
+		return fmt.Errorf("error updating transition proof: %w", err)
+	}
+
+	proofFile, err := tapsend.CreateOutputProofFile(
+		proofSuffix, inputProofs,
+	)
 	if err != nil {
 		return err
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
+	headerVerifier := tapgarden.GenHeaderVerifier(ctx, r.cfg.ChainBridge)
+	groupVerifier := tapgarden.GenGroupVerifier(ctx, r.cfg.MintingStore)
 
-		case <-r.quit:
-			return fmt.Errorf("server shutting down")
+	err = r.cfg.ProofArchive.ImportProofs(
+		ctx, headerVerifier, proof.DefaultMerkleVerifier, groupVerifier,
+		r.cfg.ChainBridge, false, &proof.AnnotatedProof{
+			Locator: proof.Locator{
+				AssetID:   &assetID,
+				ScriptKey: *scriptKey,
+				OutPoint:  fn.Ptr(proofSuffix.OutPoint()),
+			},
+			Blob: proofFile,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error importing proof: %w", err)
+	}
 
-		default:
+	// The buyer never saw the seller's signatures of the anchor
+	// transaction, so it logs its transfer of the price asset only now
+	// that the final transaction confirmed.
+	if swap.Role == tapswap.RoleBuyer && swap.Quote.IsAssetSwap() {
+		return r.logSwapPriceTransfer(ctx, swap, confEvent.Tx)
+	}
+
+	return nil
+}
+
+// logSwapPriceTransfer logs the buyer's transfer of the price asset of an
+// asset-for-asset swap, using the witnesses of the confirmed anchor
+// transaction. The transaction is already confirmed, so publishing it again
+// is a no-op.
+func (r *rpcServer) logSwapPriceTransfer(ctx context.Context,
+	swap *tapswap.Swap, finalTx *wire.MsgTx) error {
+
+	anchorPsbt := *swap.Funding.AnchorPsbt
+	anchorPsbt.Inputs = append([]psbt.PInput{}, anchorPsbt.Inputs...)
+	for idx, txIn := range anchorPsbt.UnsignedTx.TxIn {
+		finalIn := finalTx.TxIn[idx]
+		if finalIn.PreviousOutPoint != txIn.PreviousOutPoint {
+			return fmt.Errorf("anchor input %d doesn't match "+
+				"confirmed transaction", idx)
 		}
 
-		update, err := updateStream.Recv()
+		var witness bytes.Buffer
+		err := psbt.WriteTxWitness(&witness, finalIn.Witness)
 		if err != nil {
-			return err
+			return fmt.Errorf("error serializing witness: %w", err)
 		}
 
-		err = stream.Send(&tchrpc.SendPaymentResponse{
-			Result: &tchrpc.SendPaymentResponse_PaymentResult{
-				PaymentResult: update,
-			},
-		})
+		anchorPsbt.Inputs[idx].FinalScriptSig = finalIn.SignatureScript
+		anchorPsbt.Inputs[idx].FinalScriptWitness = witness.Bytes()
+	}
+
+	publishReq, err := marshalPublishRequest(
+		&tapsession.AnchorCommitment{
+			AnchorPsbt:        &anchorPsbt,
+			ActivePackets:     swap.Funding.PricePackets,
+			PassivePackets:    swap.Funding.PricePassivePackets,
+			ChangeOutputIndex: -1,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.PublishAndLogTransfer(ctx, publishReq)
+	if err != nil {
+		return fmt.Errorf("error logging price transfer: %w", err)
+	}
+
+	return nil
+}
+
+// ListSwaps lists all swaps this daemon takes part in.
+func (r *rpcServer) ListSwaps(_ context.Context,
+	_ *wrpc.ListSwapsRequest) (*wrpc.ListSwapsResponse, error) {
+
+	swaps := r.cfg.SwapManager.ListSwaps()
+
+	resp := &wrpc.ListSwapsResponse{
+		Swaps: make([]*wrpc.Swap, len(swaps)),
+	}
+	for idx, swap := range swaps {
+		var err error
+		resp.Swaps[idx], err = marshalSwap(swap)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
+
+	return resp, nil
 }
 
-// AddInvoice is a wrapper around lnd's lnrpc.AddInvoice method with asset
-// specific parameters. It allows RPC users to create invoices that correspond
-// to the specified asset amount.
-func (r *rpcServer) AddInvoice(ctx context.Context,
-	req *tchrpc.AddInvoiceRequest) (*tchrpc.AddInvoiceResponse, error) {
+// parseSwapID parses the given swap ID.
+func parseSwapID(rawID []byte) (tapswap.ID, error) {
+	var id tapswap.ID
+	if len(rawID) != len(id) {
+		return id, fmt.Errorf("swap ID must be %d bytes", len(id))
+	}
+
+	copy(id[:], rawID)
+
+	return id, nil
+}
+
+// unmarshalSwapQuote parses the given RPC swap quote.
+func unmarshalSwapQuote(rpcQuote *wrpc.SwapQuote) (tapswap.ID, tapswap.Quote,
+	error) {
 
-	if req.InvoiceRequest == nil {
-		return nil, fmt.Errorf("invoice request must be specified")
+	if rpcQuote == nil {
+		return tapswap.ID{}, tapswap.Quote{}, fmt.Errorf("quote must " +
+			"be specified")
 	}
-	iReq := req.InvoiceRequest
 
-	// Do some preliminary checks on the asset ID and make sure we have any
-	// balance for that asset.
-	if len(req.AssetId) != sha256.Size {
-		return nil, fmt.Errorf("asset ID must be 32 bytes")
+	id, err := parseSwapID(rpcQuote.SwapId)
+	if err != nil {
+		return id, tapswap.Quote{}, err
 	}
-	var assetID asset.ID
-	copy(assetID[:], req.AssetId)
 
-	// The peer public key is optional if there is only a single asset
-	// channel.
-	var peerPubKey *route.Vertex
-	if len(req.PeerPubkey) > 0 {
-		parsedKey, err := route.NewVertexFromBytes(req.PeerPubkey)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing peer pubkey: %w",
-				err)
-		}
+	if len(rpcQuote.AssetId) != sha256.Size {
+		return id, tapswap.Quote{}, fmt.Errorf("asset ID must be %d "+
+			"bytes", sha256.Size)
+	}
+
+	assetPrice, err := unmarshalSwapAssetPrice(
+		rpcQuote.PriceAssetId, rpcQuote.PriceAssetAmount,
+	)
+	if err != nil {
+		return id, tapswap.Quote{}, err
+	}
+
+	return id, tapswap.Quote{
+		AssetID:     asset.ID(rpcQuote.AssetId),
+		AssetAmount: rpcQuote.AssetAmount,
+		Price:       btcutil.Amount(rpcQuote.PriceSat),
+		AssetPrice:  assetPrice,
+		Expiry:      time.Unix(rpcQuote.ExpiryTimestamp, 0),
+	}, nil
+}
+
+// unmarshalSwapAssetPrice parses the price asset of an asset-for-asset swap.
+// An empty asset ID means the price is paid in BTC.
+func unmarshalSwapAssetPrice(assetID []byte,
+	amount uint64) (fn.Option[tapswap.AssetPrice], error) {
+
+	if len(assetID) == 0 {
+		return fn.None[tapswap.AssetPrice](), nil
+	}
+
+	if len(assetID) != sha256.Size {
+		return fn.None[tapswap.AssetPrice](), fmt.Errorf("price asset "+
+			"ID must be %d bytes", sha256.Size)
+	}
+
+	return fn.Some(tapswap.AssetPrice{
+		AssetID: asset.ID(assetID),
+		Amount:  amount,
+	}), nil
+}
 
-		peerPubKey = &parsedKey
+// unmarshalSwapProposal parses the given RPC swap proposal.
+func unmarshalSwapProposal(
+	rpcProposal *wrpc.SwapProposal) (tapswap.ID, *tapswap.Proposal, error) {
+
+	if rpcProposal == nil {
+		return tapswap.ID{}, nil, fmt.Errorf("proposal must be " +
+			"specified")
 	}
 
-	// We can now query the asset channels we have.
-	assetChan, err := r.rfqChannel(ctx, assetID, peerPubKey)
+	id, err := parseSwapID(rpcProposal.SwapId)
 	if err != nil {
-		return nil, fmt.Errorf("error finding asset channel to use: %w",
-			err)
+		return id, nil, err
 	}
 
-	// Even if the user didn't specify the peer public key before, we
-	// definitely know it now. So let's make sure it's always set.
-	peerPubKey = &assetChan.channelInfo.PubKeyBytes
-
-	expirySeconds := iReq.Expiry
-	if expirySeconds == 0 {
-		expirySeconds = int64(rfq.DefaultInvoiceExpiry.Seconds())
+	activePackets, err := decodeVirtualPackets(rpcProposal.VirtualPsbts)
+	if err != nil {
+		return id, nil, err
 	}
-	expiryTimestamp := time.Now().Add(
-		time.Duration(expirySeconds) * time.Second,
+
+	passivePackets, err := decodeVirtualPackets(
+		rpcProposal.PassiveAssetPsbts,
 	)
+	if err != nil {
+		return id, nil, err
+	}
 
-	resp, err := r.AddAssetBuyOrder(ctx, &rfqrpc.AddAssetBuyOrderRequest{
-		AssetSpecifier: &rfqrpc.AssetSpecifier{
-			Id: &rfqrpc.AssetSpecifier_AssetId{
-				AssetId: assetID[:],
-			},
-		},
-		AssetMaxAmt: req.AssetAmount,
-		Expiry:      uint64(expiryTimestamp.Unix()),
-		PeerPubKey:  peerPubKey[:],
-		TimeoutSeconds: uint32(
-			rfq.DefaultTimeout.Seconds(),
-		),
-	})
+	proposal := &tapswap.Proposal{
+		ActivePackets:   activePackets,
+		PassivePackets:  passivePackets,
+		InputProofs:     unmarshalProofBlobs(rpcProposal.InputProofs),
+		PaymentPkScript: rpcProposal.PaymentPkScript,
+	}
+
+	// The price keys are only set in asset-for-asset swaps.
+	if len(rpcProposal.PriceScriptKey) == 0 &&
+		len(rpcProposal.PriceInternalKey) == 0 {
+
+		return id, proposal, nil
+	}
+
+	scriptKey, err := btcec.ParsePubKey(rpcProposal.PriceScriptKey)
 	if err != nil {
-		return nil, fmt.Errorf("error adding buy order: %w", err)
+		return id, nil, fmt.Errorf("error parsing price script key: "+
+			"%w", err)
 	}
 
-	var acceptedQuote *rfqrpc.PeerAcceptedBuyQuote
-	switch r := resp.Response.(type) {
-	case *rfqrpc.AddAssetBuyOrderResponse_AcceptedQuote:
-		acceptedQuote = r.AcceptedQuote
+	internalKey, err := btcec.ParsePubKey(rpcProposal.PriceInternalKey)
+	if err != nil {
+		return id, nil, fmt.Errorf("error parsing price internal "+
+			"key: %w", err)
+	}
 
-	case *rfqrpc.AddAssetBuyOrderResponse_InvalidQuote:
-		return nil, fmt.Errorf("peer %v sent back an invalid quote, "+
-			"status: %v", r.InvalidQuote.Peer,
-			r.InvalidQuote.Status.String())
+	proposal.PriceKeys = &tapswap.Acceptance{
+		ScriptKey:   scriptKey,
+		InternalKey: internalKey,
+	}
 
-	case *rfqrpc.AddAssetBuyOrderResponse_RejectedQuote:
-		return nil, fmt.Errorf("peer %v rejected the quote, code: %v, "+
-			"error message: %v", r.RejectedQuote.Peer,
-			r.RejectedQuote.ErrorCode, r.RejectedQuote.ErrorMessage)
+	return id, proposal, nil
+}
 
-	default:
-		return nil, fmt.Errorf("unexpected response type: %T", r)
+// unmarshalSwapFunding parses the given RPC swap funding.
+func unmarshalSwapFunding(
+	rpcFunding *wrpc.SwapFunding) (tapswap.ID, *tapswap.Funding, error) {
+
+	if rpcFunding == nil {
+		return tapswap.ID{}, nil, fmt.Errorf("funding must be " +
+			"specified")
 	}
 
-	// Now that we have the accepted quote, we know the amount in Satoshi
-	// that we need to pay. We can now update the invoice with this amount.
-	//
-	// First, un-marshall the ask asset rate from the accepted quote.
-	askAssetRate, err := rfqrpc.UnmarshalFixedPoint(
-		acceptedQuote.AskAssetRate,
+	id, err := parseSwapID(rpcFunding.SwapId)
+	if err != nil {
+		return id, nil, err
+	}
+
+	anchorPsbt, err := psbt.NewFromRawBytes(
+		bytes.NewReader(rpcFunding.AnchorPsbt), false,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling ask asset rate: %w",
+		return id, nil, fmt.Errorf("error decoding anchor packet: %w",
 			err)
 	}
 
-	// Convert the asset amount into a fixed-point.
-	assetAmount := rfqmath.NewBigIntFixedPoint(req.AssetAmount, 0)
+	activePackets, err := decodeVirtualPackets(rpcFunding.VirtualPsbts)
+	if err != nil {
+		return id, nil, err
+	}
 
-	// Calculate the invoice amount in msat.
-	valMsat := rfqmath.UnitsToMilliSatoshi(assetAmount, *askAssetRate)
-	iReq.ValueMsat = int64(valMsat)
+	passivePackets, err := decodeVirtualPackets(
+		rpcFunding.PassiveAssetPsbts,
+	)
+	if err != nil {
+		return id, nil, err
+	}
 
-	// The last step is to create a hop hint that includes the fake SCID of
-	// the quote, alongside the channel's routing policy. We need to choose
-	// the policy that points towards us, as the payment will be flowing in.
-	// So we get the policy that's being set by the remote peer.
-	channelID := assetChan.channelInfo.ChannelID
-	inboundPolicy, err := r.getInboundPolicy(
-		ctx, channelID, peerPubKey.String(),
+	pricePackets, err := decodeVirtualPackets(
+		rpcFunding.PriceVirtualPsbts,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get inbound channel policy "+
-			"for channel with ID %d: %w", channelID, err)
+		return id, nil, err
 	}
 
-	// If this is a hodl invoice, then we'll copy over the relevant fields,
-	// then route this through the invoicerpc instead.
-	if req.HodlInvoice != nil {
-		payHash, err := lntypes.MakeHash(req.HodlInvoice.PaymentHash)
-		if err != nil {
-			return nil, fmt.Errorf("error creating payment "+
-				"hash: %w", err)
-		}
+	pricePassivePackets, err := decodeVirtualPackets(
+		rpcFunding.PricePassiveAssetPsbts,
+	)
+	if err != nil {
+		return id, nil, err
+	}
 
-		peerPub, err := btcec.ParsePubKey(peerPubKey[:])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing peer "+
-				"pubkey: %w", err)
+	return id, &tapswap.Funding{
+		AnchorPsbt:          anchorPsbt,
+		ActivePackets:       activePackets,
+		PassivePackets:      passivePackets,
+		PricePackets:        pricePackets,
+		PricePassivePackets: pricePassivePackets,
+		PriceInputProofs: unmarshalProofBlobs(
+			rpcFunding.PriceInputProofs,
+		),
+	}, nil
+}
+
+// unmarshalProofBlobs converts the given raw proof files into proof blobs.
+func unmarshalProofBlobs(rawProofs [][]byte) []proof.Blob {
+	proofs := make([]proof.Blob, len(rawProofs))
+	for idx, rawProof := range rawProofs {
+		proofs[idx] = rawProof
+	}
+
+	return proofs
+}
+
+// marshalSwap converts a swap into its RPC counterpart.
+func marshalSwap(swap *tapswap.Swap) (*wrpc.Swap, error) {
+	rpcSwap := &wrpc.Swap{
+		SwapId: swap.ID[:],
+		Role:   wrpc.SwapRole(swap.Role),
+		State:  wrpc.SwapState(swap.State),
+		Quote: &wrpc.SwapQuote{
+			SwapId:          swap.ID[:],
+			AssetId:         swap.Quote.AssetID[:],
+			AssetAmount:     swap.Quote.AssetAmount,
+			PriceSat:        int64(swap.Quote.Price),
+			ExpiryTimestamp: swap.Quote.Expiry.Unix(),
+		},
+		CreatedTimestamp: swap.CreatedAt.Unix(),
+	}
+	swap.Quote.AssetPrice.WhenSome(func(price tapswap.AssetPrice) {
+		rpcSwap.Quote.PriceAssetId = fn.CopySlice(price.AssetID[:])
+		rpcSwap.Quote.PriceAssetAmount = price.Amount
+	})
+
+	if swap.Acceptance != nil {
+		rpcSwap.Acceptance = &wrpc.SwapAcceptance{
+			SwapId: swap.ID[:],
+			ScriptKey: swap.Acceptance.ScriptKey.
+				SerializeCompressed(),
+			InternalKey: swap.Acceptance.InternalKey.
+				SerializeCompressed(),
 		}
+	}
 
-		hopHint := []zpay32.HopHint{
-			{
-				NodeID:      peerPub,
-				ChannelID:   acceptedQuote.Scid,
-				FeeBaseMSat: uint32(inboundPolicy.FeeBaseMsat),
-				FeeProportionalMillionths: uint32(
-					inboundPolicy.FeeRateMilliMsat,
-				),
-				CLTVExpiryDelta: uint16(
-					inboundPolicy.TimeLockDelta,
-				),
-			},
+	if swap.Proposal != nil {
+		activePackets, err := encodeVirtualPackets(
+			swap.Proposal.ActivePackets,
+		)
+		if err != nil {
+			return nil, err
 		}
 
-		payReq, err := r.cfg.Lnd.Invoices.AddHoldInvoice(
-			ctx, &invoicesrpc.AddInvoiceData{
-				Memo: iReq.Memo,
-				Value: lnwire.MilliSatoshi(
-					iReq.ValueMsat,
-				),
-				Hash:            &payHash,
-				DescriptionHash: iReq.DescriptionHash,
-				Expiry:          iReq.Expiry,
-				// We set private to false as we don't want to
-				// add any hop hints other than this one.
-				Private:     false,
-				HodlInvoice: true,
-				RouteHints:  [][]zpay32.HopHint{hopHint},
-			},
+		passivePackets, err := encodeVirtualPackets(
+			swap.Proposal.PassivePackets,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("error creating hodl invoice: "+
-				"%w", err)
+			return nil, err
 		}
 
-		return &tchrpc.AddInvoiceResponse{
-			AcceptedBuyQuote: acceptedQuote,
-			InvoiceResult: &lnrpc.AddInvoiceResponse{
-				PaymentRequest: payReq,
-			},
-		}, nil
-	}
+		rpcSwap.Proposal = &wrpc.SwapProposal{
+			SwapId:            swap.ID[:],
+			VirtualPsbts:      activePackets,
+			PassiveAssetPsbts: passivePackets,
+			PaymentPkScript:   swap.Proposal.PaymentPkScript,
+		}
+		for _, inputProof := range swap.Proposal.InputProofs {
+			rpcSwap.Proposal.InputProofs = append(
+				rpcSwap.Proposal.InputProofs, inputProof,
+			)
+		}
 
-	// Otherwise, we'll make this into a normal invoice.
-	hopHint := &lnrpc.HopHint{
-		NodeId:      peerPubKey.String(),
-		ChanId:      acceptedQuote.Scid,
-		FeeBaseMsat: uint32(inboundPolicy.FeeBaseMsat),
-		FeeProportionalMillionths: uint32(
-			inboundPolicy.FeeRateMilliMsat,
-		),
-		CltvExpiryDelta: inboundPolicy.TimeLockDelta,
-	}
-	iReq.RouteHints = []*lnrpc.RouteHint{
-		{
-			HopHints: []*lnrpc.HopHint{
-				hopHint,
-			},
-		},
+		if swap.Proposal.PriceKeys != nil {
+			priceKeys := swap.Proposal.PriceKeys
+			rpcSwap.Proposal.PriceScriptKey = priceKeys.ScriptKey.
+				SerializeCompressed()
+			rpcSwap.Proposal.PriceInternalKey = priceKeys.
+				InternalKey.SerializeCompressed()
+		}
 	}
 
-	rpcCtx, _, rawClient := r.cfg.Lnd.Client.RawClientWithMacAuth(ctx)
-	invoiceResp, err := rawClient.AddInvoice(rpcCtx, iReq)
-	if err != nil {
-		return nil, fmt.Errorf("error creating invoice: %w", err)
-	}
+	if swap.Funding != nil {
+		anchorPsbt, err := serialize(swap.Funding.AnchorPsbt)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing packet: %w",
+				err)
+		}
 
-	return &tchrpc.AddInvoiceResponse{
-		AcceptedBuyQuote: acceptedQuote,
-		InvoiceResult:    invoiceResp,
-	}, nil
-}
+		activePackets, err := encodeVirtualPackets(
+			swap.Funding.ActivePackets,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-// DeclareScriptKey declares a new script key to the wallet. This is useful
-// when the script key contains scripts, which would mean it wouldn't be
-// recognized by the wallet automatically. Declaring a script key will make any
-// assets sent to the script key be recognized as being local assets.
-func (r *rpcServer) DeclareScriptKey(ctx context.Context,
-	in *wrpc.DeclareScriptKeyRequest) (*wrpc.DeclareScriptKeyResponse,
-	error) {
+		passivePackets, err := encodeVirtualPackets(
+			swap.Funding.PassivePackets,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	scriptKey, err := taprpc.UnmarshalScriptKey(in.ScriptKey)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling script key: %w",
-			err)
+		pricePackets, err := encodeVirtualPackets(
+			swap.Funding.PricePackets,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pricePassivePackets, err := encodeVirtualPackets(
+			swap.Funding.PricePassivePackets,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcSwap.Funding = &wrpc.SwapFunding{
+			SwapId:                 swap.ID[:],
+			AnchorPsbt:             anchorPsbt,
+			VirtualPsbts:           activePackets,
+			PassiveAssetPsbts:      passivePackets,
+			PriceVirtualPsbts:      pricePackets,
+			PricePassiveAssetPsbts: pricePassivePackets,
+		}
+		for _, inputProof := range swap.Funding.PriceInputProofs {
+			rpcSwap.Funding.PriceInputProofs = append(
+				rpcSwap.Funding.PriceInputProofs, inputProof,
+			)
+		}
 	}
 
-	err = r.cfg.TapAddrBook.InsertScriptKey(ctx, *scriptKey, true)
-	if err != nil {
-		return nil, fmt.Errorf("error inserting script key: %w", err)
+	if swap.AnchorTxHash != nil {
+		rpcSwap.AnchorTxid = swap.AnchorTxHash.String()
 	}
 
-	return &wrpc.DeclareScriptKeyResponse{
-		ScriptKey: taprpc.MarshalScriptKey(*scriptKey),
-	}, nil
+	return rpcSwap, nil
 }
 
 // serialize is a helper function that serializes a serializable object into a
@@ -7561,10 +14453,54 @@ func getDecimalDisplayNonStrict(
 	return fn.Some(decDisplay), nil
 }
 
+// resolveAssetAmount returns the amount of asset base units requested by an
+// RPC call that accepts the amount either in base units or, if enabled, as a
+// string in decimal display units of the given asset.
+func (r *rpcServer) resolveAssetAmount(ctx context.Context, id asset.ID,
+	baseUnits uint64, displayAmount string) (uint64, error) {
+
+	if displayAmount == "" {
+		return baseUnits, nil
+	}
+
+	if !r.cfg.DecimalDisplayAmounts {
+		return 0, fmt.Errorf("decimal display amounts are not " +
+			"enabled, amount must be specified in base units")
+	}
+
+	if baseUnits != 0 {
+		return 0, fmt.Errorf("amount can only be specified either in " +
+			"base units or in decimal display units")
+	}
+
+	decDisplay, err := r.DecDisplayForAssetID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return proof.ParseDecimalDisplayAmount(
+		displayAmount, decDisplay.UnwrapOr(0),
+	)
+}
+
+// displayAmount renders the given amount of asset base units in decimal
+// display units, if the daemon is configured to do so. Otherwise, an empty
+// string is returned.
+func (r *rpcServer) displayAmount(amount uint64,
+	decDisplay fn.Option[uint32]) string {
+
+	if !r.cfg.DecimalDisplayAmounts {
+		return ""
+	}
+
+	return proof.FormatDecimalDisplayAmount(amount, decDisplay.UnwrapOr(0))
+}
+
 // rfqChannel returns the channel to use for RFQ operations. If a peer public
-// key is specified, the channels are filtered by that peer. If there are
-// multiple channels for the same asset, the user must specify the peer public
-// key.
+// key is specified, the channels are filtered by that peer. Otherwise, the
+// peer is selected based on the reputation policy of the RFQ manager. If there
+// are multiple channels for the same asset and the policy doesn't select a
+// peer, the user must specify the peer public key.
 func (r *rpcServer) rfqChannel(ctx context.Context, id asset.ID,
 	peerPubKey *route.Vertex) (*channelWithAsset, error) {
 
@@ -7580,8 +14516,39 @@ func (r *rpcServer) rfqChannel(ctx context.Context, id asset.ID,
 			"asset %s", id.String())
 	}
 
-	// If a peer public key was specified, we always want to use that to
-	// filter the asset channels.
+	// If no peer public key was specified, we let the reputation policy
+	// choose between the peers we have a channel with. Peers with a bad
+	// reputation are excluded, and if the policy prefers reputable peers,
+	// the one with the best reputation is selected.
+	if peerPubKey == nil {
+		var (
+			candidates []route.Vertex
+			seen       = make(map[route.Vertex]struct{})
+		)
+		for _, c := range assetBalances {
+			peer := c.channelInfo.PubKeyBytes
+			if _, ok := seen[peer]; ok {
+				continue
+			}
+
+			seen[peer] = struct{}{}
+			candidates = append(candidates, peer)
+		}
+
+		reputation := r.cfg.RfqManager.Reputation()
+		selectedPeer, err := reputation.SelectPeer(ctx, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("unable to select peer for "+
+				"asset %s: %w", id.String(), err)
+		}
+
+		selectedPeer.WhenSome(func(peer route.Vertex) {
+			peerPubKey = &peer
+		})
+	}
+
+	// If a peer public key was specified or selected, we always want to use
+	// that to filter the asset channels.
 	if peerPubKey != nil {
 		assetBalances = fn.Filter(
 			assetBalances, func(c channelWithAsset) bool {
@@ -0,0 +1,164 @@
+package taprootassets
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	tchrpc "github.com/lightninglabs/taproot-assets/taprpc/tapchannelrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TestClientIP tests that the client IP of proof uploads made through the REST
+// proxy is taken from the metadata forwarded by the gRPC gateway, while the
+// metadata of any other caller is ignored.
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	const proxyHost = "10.0.0.1"
+
+	testCases := []struct {
+		name      string
+		peerAddr  net.Addr
+		forwarded string
+		expected  string
+	}{{
+		name:     "no peer",
+		expected: "",
+	}, {
+		name: "direct gRPC call",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("1.2.3.4"), Port: 1234,
+		},
+		expected: "1.2.3.4",
+	}, {
+		name: "direct gRPC call with spoofed header",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("1.2.3.4"), Port: 1234,
+		},
+		forwarded: "5.6.7.8",
+		expected:  "1.2.3.4",
+	}, {
+		name: "local call without header",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("127.0.0.1"), Port: 1234,
+		},
+		expected: "127.0.0.1",
+	}, {
+		name: "REST call via loopback",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("127.0.0.1"), Port: 1234,
+		},
+		forwarded: "5.6.7.8",
+		expected:  "5.6.7.8",
+	}, {
+		name: "REST call via IPv6 loopback",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("::1"), Port: 1234,
+		},
+		forwarded: "2001:db8::1",
+		expected:  "2001:db8::1",
+	}, {
+		name: "REST call via proxy host",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP(proxyHost), Port: 1234,
+		},
+		forwarded: "5.6.7.8",
+		expected:  "5.6.7.8",
+	}, {
+		name: "REST call with client supplied header",
+		peerAddr: &net.TCPAddr{
+			IP: net.ParseIP("127.0.0.1"), Port: 1234,
+		},
+		forwarded: "9.9.9.9, 5.6.7.8",
+		expected:  "5.6.7.8",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.peerAddr != nil {
+				ctx = peer.NewContext(ctx, &peer.Peer{
+					Addr: tc.peerAddr,
+				})
+			}
+			if tc.forwarded != "" {
+				ctx = metadata.NewIncomingContext(
+					ctx, metadata.Pairs(
+						"x-forwarded-for", tc.forwarded,
+					),
+				)
+			}
+
+			require.Equal(t, tc.expected, clientIP(ctx, proxyHost))
+		})
+	}
+}
+
+// TestParseAddInvoiceRequest tests the validation of AddInvoice requests.
+func TestParseAddInvoiceRequest(t *testing.T) {
+	t.Parallel()
+
+	r := &rpcServer{
+		cfg: &Config{},
+	}
+	assetID := test.RandBytes(32)
+
+	testCases := []struct {
+		name        string
+		req         *tchrpc.AddInvoiceRequest
+		expectedErr string
+		expected    *lnrpc.Invoice
+	}{{
+		name: "nil invoice request",
+		req: &tchrpc.AddInvoiceRequest{
+			AssetId:     assetID,
+			AssetAmount: 100,
+		},
+		expected: &lnrpc.Invoice{},
+	}, {
+		name: "zero asset amount",
+		req: &tchrpc.AddInvoiceRequest{
+			AssetId: assetID,
+			InvoiceRequest: &lnrpc.Invoice{
+				Memo: "zero",
+			},
+		},
+		expectedErr: "asset amount must be specified",
+	}, {
+		name: "non-zero asset amount",
+		req: &tchrpc.AddInvoiceRequest{
+			AssetId:     assetID,
+			AssetAmount: 100,
+			InvoiceRequest: &lnrpc.Invoice{
+				Memo: "non-zero",
+			},
+		},
+		expected: &lnrpc.Invoice{
+			Memo: "non-zero",
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params, err := r.parseAddInvoiceRequest(
+				context.Background(), tc.req,
+			)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, assetID, params.assetID[:])
+			require.Equal(t, tc.req.AssetAmount, params.assetUnits)
+			require.Nil(t, params.peerPubKey)
+			require.NotNil(t, params.invoice)
+			require.Equal(t, tc.expected.Memo, params.invoice.Memo)
+		})
+	}
+}
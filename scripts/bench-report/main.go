@@ -0,0 +1,356 @@
+// bench-report converts the output of `go test -bench` into a JSON report that
+// can be stored and compared against a later run to catch performance
+// regressions.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is the aggregated result of a single benchmark that was run one or
+// more times.
+type Result struct {
+	// Package is the full import path of the benchmark's package.
+	Package string `json:"package"`
+
+	// Name is the name of the benchmark, without the GOMAXPROCS suffix.
+	Name string `json:"name"`
+
+	// Runs is the number of times the benchmark was run (-count).
+	Runs int `json:"runs"`
+
+	// Iterations is the median number of iterations of all runs.
+	Iterations int64 `json:"iterations"`
+
+	// NsPerOp is the median time per operation in nanoseconds.
+	NsPerOp float64 `json:"ns_per_op"`
+
+	// BytesPerOp is the median number of bytes allocated per operation.
+	BytesPerOp float64 `json:"bytes_per_op"`
+
+	// AllocsPerOp is the median number of allocations per operation.
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+// Report is the full benchmark report, including the information required to
+// judge whether two reports are comparable.
+type Report struct {
+	// Commit is the git commit the benchmarks were run against.
+	Commit string `json:"commit"`
+
+	// GoVersion is the version of the Go toolchain.
+	GoVersion string `json:"go_version"`
+
+	// GoOS is the operating system the benchmarks were run on.
+	GoOS string `json:"goos"`
+
+	// GoArch is the architecture the benchmarks were run on.
+	GoArch string `json:"goarch"`
+
+	// CPU is the CPU model as reported by the benchmark output.
+	CPU string `json:"cpu"`
+
+	// CreatedAt is the time the report was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Benchmarks is the list of benchmark results, sorted by package and
+	// name.
+	Benchmarks []Result `json:"benchmarks"`
+}
+
+// sample is a single result line of a benchmark run.
+type sample struct {
+	iterations  int64
+	nsPerOp     float64
+	bytesPerOp  float64
+	allocsPerOp float64
+}
+
+// parseOutput parses the raw output of one or more `go test -bench` runs.
+func parseOutput(r io.Reader) (*Report, error) {
+	var (
+		report = &Report{
+			GoVersion: runtime.Version(),
+			GoOS:      runtime.GOOS,
+			GoArch:    runtime.GOARCH,
+		}
+		pkg     string
+		samples = make(map[string][]sample)
+		pkgs    = make(map[string]string)
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "pkg: "):
+			pkg = strings.TrimPrefix(line, "pkg: ")
+
+		case strings.HasPrefix(line, "goos: "):
+			report.GoOS = strings.TrimPrefix(line, "goos: ")
+
+		case strings.HasPrefix(line, "goarch: "):
+			report.GoArch = strings.TrimPrefix(line, "goarch: ")
+
+		case strings.HasPrefix(line, "cpu: "):
+			report.CPU = strings.TrimPrefix(line, "cpu: ")
+
+		case strings.HasPrefix(line, "Benchmark"):
+			name, s, ok, err := parseResultLine(line)
+			if err != nil {
+				return nil, err
+			}
+
+			// Lines starting with "Benchmark" that aren't result
+			// lines (e.g. log output) are skipped.
+			if !ok {
+				continue
+			}
+
+			key := pkg + "." + name
+			samples[key] = append(samples[key], s)
+			pkgs[key] = pkg
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read benchmark output: %w",
+			err)
+	}
+
+	for key, runs := range samples {
+		pkg := pkgs[key]
+		report.Benchmarks = append(report.Benchmarks, Result{
+			Package: pkg,
+			Name:    strings.TrimPrefix(key, pkg+"."),
+			Runs:    len(runs),
+			Iterations: int64(median(runs, func(s sample) float64 {
+				return float64(s.iterations)
+			})),
+			NsPerOp: median(runs, func(s sample) float64 {
+				return s.nsPerOp
+			}),
+			BytesPerOp: median(runs, func(s sample) float64 {
+				return s.bytesPerOp
+			}),
+			AllocsPerOp: median(runs, func(s sample) float64 {
+				return s.allocsPerOp
+			}),
+		})
+	}
+
+	sort.Slice(report.Benchmarks, func(i, j int) bool {
+		a, b := report.Benchmarks[i], report.Benchmarks[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		return a.Name < b.Name
+	})
+
+	return report, nil
+}
+
+// parseResultLine parses a single benchmark result line of the form:
+//
+//	BenchmarkName-8   100   1234 ns/op   56 B/op   7 allocs/op
+//
+// The boolean return value is false if the line isn't a result line.
+func parseResultLine(line string) (string, sample, bool, error) {
+	var s sample
+
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[3] != "ns/op" {
+		return "", s, false, nil
+	}
+
+	iterations, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return "", s, false, nil
+	}
+	s.iterations = iterations
+
+	// The value/unit pairs follow the iteration count.
+	for i := 2; i+1 < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return "", s, false, fmt.Errorf("invalid value %q in "+
+				"line %q: %w", fields[i], line, err)
+		}
+
+		switch fields[i+1] {
+		case "ns/op":
+			s.nsPerOp = value
+
+		case "B/op":
+			s.bytesPerOp = value
+
+		case "allocs/op":
+			s.allocsPerOp = value
+		}
+	}
+
+	// Strip the GOMAXPROCS suffix so results from machines with a
+	// different number of cores can still be matched.
+	name := fields[0]
+	if idx := strings.LastIndex(name, "-"); idx > 0 {
+		if _, err := strconv.Atoi(name[idx+1:]); err == nil {
+			name = name[:idx]
+		}
+	}
+
+	return name, s, true, nil
+}
+
+// median returns the median of the values extracted from the given samples.
+func median(samples []sample, value func(sample) float64) float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = value(s)
+	}
+	sort.Float64s(values)
+
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+
+	return values[mid]
+}
+
+// compareReports compares the time per operation of all benchmarks present in
+// both reports and returns a description of each benchmark that got slower by
+// more than the given threshold (in percent).
+func compareReports(baseline, current *Report, threshold float64) []string {
+	baseResults := make(map[string]Result, len(baseline.Benchmarks))
+	for _, result := range baseline.Benchmarks {
+		baseResults[result.Package+"."+result.Name] = result
+	}
+
+	var regressions []string
+	for _, result := range current.Benchmarks {
+		base, ok := baseResults[result.Package+"."+result.Name]
+		if !ok || base.NsPerOp == 0 {
+			continue
+		}
+
+		delta := (result.NsPerOp - base.NsPerOp) / base.NsPerOp * 100
+		if delta <= threshold {
+			continue
+		}
+
+		regressions = append(regressions, fmt.Sprintf("%s.%s: %.0f "+
+			"ns/op -> %.0f ns/op (+%.2f%%)", result.Package,
+			result.Name, base.NsPerOp, result.NsPerOp, delta))
+	}
+
+	return regressions
+}
+
+// readReport reads a JSON report from the given file.
+func readReport(fileName string) (*Report, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read report: %w", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("unable to decode report %s: %w",
+			fileName, err)
+	}
+
+	return &report, nil
+}
+
+func run() error {
+	var (
+		input = flag.String("input", "", "file containing the raw "+
+			"benchmark output, reads from stdin if empty")
+		output = flag.String("output", "bench_report.json", "file "+
+			"to write the JSON report to")
+		commit = flag.String("commit", "", "the git commit the "+
+			"benchmarks were run against")
+		baseline = flag.String("baseline", "", "optional JSON report "+
+			"to compare the results against")
+		threshold = flag.Float64("threshold", 10, "maximum allowed "+
+			"slowdown in percent when comparing against a baseline")
+	)
+	flag.Parse()
+
+	in := os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("unable to open input: %w", err)
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	report, err := parseOutput(in)
+	if err != nil {
+		return err
+	}
+	report.Commit = *commit
+	report.CreatedAt = time.Now().UTC()
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode report: %w", err)
+	}
+	if err := os.WriteFile(*output, content, 0644); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+
+	fmt.Printf("Wrote results of %d benchmarks to %s\n",
+		len(report.Benchmarks), *output)
+
+	if *baseline == "" {
+		return nil
+	}
+
+	baseReport, err := readReport(*baseline)
+	if err != nil {
+		return err
+	}
+
+	if baseReport.CPU != report.CPU || baseReport.GoArch != report.GoArch {
+		fmt.Printf("Warning: baseline was created on a different "+
+			"machine (%s/%s), results might not be comparable\n",
+			baseReport.GoArch, baseReport.CPU)
+	}
+
+	regressions := compareReports(baseReport, report, *threshold)
+	if len(regressions) == 0 {
+		fmt.Printf("No regressions over %.2f%% compared to %s\n",
+			*threshold, *baseline)
+
+		return nil
+	}
+
+	for _, regression := range regressions {
+		fmt.Println(regression)
+	}
+
+	return fmt.Errorf("%d benchmarks regressed by more than %.2f%%",
+		len(regressions), *threshold)
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "bench-report: %v\n", err)
+		os.Exit(1)
+	}
+}
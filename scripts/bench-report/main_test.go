@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testOutput is the raw benchmark output of two packages.
+//
+//nolint:lll
+const testOutput = `goos: linux
+goarch: amd64
+pkg: github.com/lightninglabs/taproot-assets/rfqmsg
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkHtlcFromCustomRecords-8 	  100000	     10000 ns/op	    1992 B/op	      35 allocs/op
+BenchmarkHtlcFromCustomRecords-8 	  120000	     12000 ns/op	    1992 B/op	      35 allocs/op
+BenchmarkHtlcFromCustomRecords-8 	  110000	     11000 ns/op	    1992 B/op	      35 allocs/op
+PASS
+ok  	github.com/lightninglabs/taproot-assets/rfqmsg	3.043s
+goos: linux
+goarch: amd64
+pkg: github.com/lightninglabs/taproot-assets/mssmt
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkTree/insert-1000-8         	   50000	     25000 ns/op
+--- BENCH: BenchmarkTree
+PASS
+ok  	github.com/lightninglabs/taproot-assets/mssmt	5.001s
+`
+
+// TestParseOutput tests that raw benchmark output is aggregated into the
+// expected report.
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	report, err := parseOutput(strings.NewReader(testOutput))
+	require.NoError(t, err)
+
+	require.Equal(t, "linux", report.GoOS)
+	require.Equal(t, "amd64", report.GoArch)
+	require.Equal(t, "Intel(R) Xeon(R) Processor", report.CPU)
+	require.Equal(t, []Result{{
+		Package:    "github.com/lightninglabs/taproot-assets/mssmt",
+		Name:       "BenchmarkTree/insert-1000",
+		Runs:       1,
+		Iterations: 50000,
+		NsPerOp:    25000,
+	}, {
+		Package:     "github.com/lightninglabs/taproot-assets/rfqmsg",
+		Name:        "BenchmarkHtlcFromCustomRecords",
+		Runs:        3,
+		Iterations:  110000,
+		NsPerOp:     11000,
+		BytesPerOp:  1992,
+		AllocsPerOp: 35,
+	}}, report.Benchmarks)
+}
+
+// TestCompareReports tests that only benchmarks that got slower by more than
+// the threshold are reported as regressions.
+func TestCompareReports(t *testing.T) {
+	t.Parallel()
+
+	baseline := &Report{
+		Benchmarks: []Result{{
+			Package: "pkg",
+			Name:    "BenchmarkA",
+			NsPerOp: 1000,
+		}, {
+			Package: "pkg",
+			Name:    "BenchmarkB",
+			NsPerOp: 1000,
+		}},
+	}
+	current := &Report{
+		Benchmarks: []Result{{
+			Package: "pkg",
+			Name:    "BenchmarkA",
+			NsPerOp: 1050,
+		}, {
+			Package: "pkg",
+			Name:    "BenchmarkB",
+			NsPerOp: 1200,
+		}, {
+			Package: "pkg",
+			Name:    "BenchmarkNew",
+			NsPerOp: 5000,
+		}},
+	}
+
+	regressions := compareReports(baseline, current, 10)
+	require.Len(t, regressions, 1)
+	require.Contains(t, regressions[0], "pkg.BenchmarkB")
+}
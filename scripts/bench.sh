@@ -0,0 +1,51 @@
+#!/bin/bash
+
+set -e -o pipefail
+
+function run_bench() {
+  PACKAGES=$1
+  COUNT=$2
+  BENCH_TIME=$3
+  TIMEOUT=$4
+  RAW_OUTPUT=$5
+  REPORT=$6
+  BASELINE=$7
+  THRESHOLD=$8
+
+  # Start with an empty raw output file, each package appends to it.
+  : > "$RAW_OUTPUT"
+
+  for pkg in $PACKAGES; do
+    echo "----- Benchmarking $pkg with count=$COUNT and benchtime=$BENCH_TIME -----"
+    go test -run='^$' -bench=. -benchmem -count="$COUNT" \
+      -benchtime="$BENCH_TIME" -timeout="$TIMEOUT" "./$pkg" | tee -a "$RAW_OUTPUT"
+  done
+
+  go run ./scripts/bench-report -input="$RAW_OUTPUT" -output="$REPORT" \
+    -commit="$(git rev-parse HEAD)" -baseline="$BASELINE" \
+    -threshold="$THRESHOLD"
+}
+
+# usage prints the usage of the whole script.
+function usage() {
+  echo "Usage: "
+  echo "bench.sh run <packages> <count> <bench_time> <timeout> <raw_output> <report> [baseline] [threshold]"
+}
+
+# Extract the sub command and remove it from the list of parameters by shifting
+# them to the left.
+SUBCOMMAND=$1
+shift
+
+# Call the function corresponding to the specified sub command or print the
+# usage if the sub command was not found.
+case $SUBCOMMAND in
+run)
+  echo "Running benchmarks"
+  run_bench "$@"
+  ;;
+*)
+  usage
+  exit 1
+  ;;
+esac
@@ -2,7 +2,9 @@ package taprootassets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -16,7 +18,9 @@ import (
 	proxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/explorer"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/lnurl"
 	"github.com/lightninglabs/taproot-assets/monitoring"
 	"github.com/lightninglabs/taproot-assets/perms"
 	"github.com/lightninglabs/taproot-assets/rpcperms"
@@ -64,6 +68,12 @@ type Server struct {
 	*rpcServer
 	macaroonService *lndclient.MacaroonService
 
+	// lnurlServer is the optional LNURL-pay server.
+	lnurlServer *lnurl.Server
+
+	// explorerServer is the optional read-only explorer API server.
+	explorerServer *explorer.Server
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -128,9 +138,9 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 				RootKeyStore:     s.cfg.DatabaseConfig.RootKeyStore,
 				MacaroonLocation: tapdMacaroonLocation,
 				MacaroonPath:     s.cfg.MacaroonPath,
-				Checkers: []macaroons.Checker{
+				Checkers: append([]macaroons.Checker{
 					macaroons.IPLockChecker,
-				},
+				}, rpcperms.AssetScopeCheckers...),
 				RequiredPerms: perms.RequiredPermissions,
 			},
 		)
@@ -154,6 +164,16 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 				s.macaroonService.Service,
 			)
 
+			// The group of assets referenced by their ID needs to
+			// be looked up to enforce group key caveats.
+			interceptorChain.AddAssetGroupQuerier(
+				s.cfg.TapAddrBook,
+			)
+
+			// The calls made with the macaroon of a tenant are
+			// scoped to the account of the tenant.
+			interceptorChain.AddTenantQuerier(s.cfg.Accounts)
+
 			// Register all our known permission with the macaroon
 			// service.
 			for method, ops := range perms.RequiredPermissions {
@@ -168,16 +188,52 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 	}
 
 	// Initialize, and register our implementation of the gRPC interface
-	// exported by the rpcServer.
+	// exported by the rpcServer. When running standalone, the rpc server
+	// was already created to accept the call that unlocks the database.
 	var err error
-	s.rpcServer, err = newRPCServer(
-		s.cfg.SignalInterceptor, interceptorChain, s.cfg,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to create rpc server: %w", err)
+	if s.rpcServer == nil {
+		s.rpcServer, err = newRPCServer(
+			s.cfg.SignalInterceptor, interceptorChain, s.cfg,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create rpc server: %w",
+				err)
+		}
+	}
+
+	// We start the trace export before any of the subsystems that create
+	// spans, so their traces are complete.
+	if s.cfg.TraceProvider != nil {
+		if err := s.cfg.TraceProvider.Start(); err != nil {
+			return fmt.Errorf("unable to start trace export: %w",
+				err)
+		}
+	}
+
+	// We start the alert manager before any of the subsystems that might
+	// raise alerts.
+	if s.cfg.AlertManager != nil {
+		if err := s.cfg.AlertManager.Start(); err != nil {
+			return fmt.Errorf("unable to start alert manager: %w",
+				err)
+		}
+	}
+
+	// If enabled, we'll start the DB event bus first, so it's ready
+	// before any of the subsystems write to the database.
+	if s.cfg.DBEventBus != nil {
+		if err := s.cfg.DBEventBus.Start(); err != nil {
+			return fmt.Errorf("unable to start DB event bus: %w",
+				err)
+		}
+
+		if err := s.cfg.DBProofNotifier.Start(); err != nil {
+			return fmt.Errorf("unable to start DB proof "+
+				"notifier: %w", err)
+		}
 	}
 
-	// First, we'll start the main batched asset minter.
+	// Next, we'll start the main batched asset minter.
 	if err := s.cfg.AssetMinter.Start(); err != nil {
 		return fmt.Errorf("unable to start asset minter: %w", err)
 	}
@@ -191,6 +247,34 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 		return fmt.Errorf("unable to start re-org watcher: %w", err)
 	}
 
+	if s.cfg.AnchorSpendWatcher != nil {
+		if err := s.cfg.AnchorSpendWatcher.Start(); err != nil {
+			return fmt.Errorf("unable to start anchor spend "+
+				"watcher: %w", err)
+		}
+	}
+
+	if err := s.cfg.ConfDepthTracker.Start(); err != nil {
+		return fmt.Errorf("unable to start confirmation depth "+
+			"tracker: %w", err)
+	}
+
+	if s.cfg.RPCJournal != nil {
+		if err := s.cfg.RPCJournal.Start(); err != nil {
+			return fmt.Errorf("unable to start RPC journal: %w",
+				err)
+		}
+	}
+
+	// The replication leader needs to subscribe to the chain porter
+	// before it starts, so it doesn't miss any completed transfers.
+	if s.cfg.ReplicationLeader != nil {
+		if err := s.cfg.ReplicationLeader.Start(); err != nil {
+			return fmt.Errorf("unable to start replication "+
+				"leader: %w", err)
+		}
+	}
+
 	if err := s.cfg.ChainPorter.Start(); err != nil {
 		return fmt.Errorf("unable to start chain porter: %w", err)
 	}
@@ -200,6 +284,16 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 			"federation: %w", err)
 	}
 
+	// The job manager resumes interrupted jobs on startup, so it needs to
+	// be started after the subsystems the jobs use.
+	if err := s.cfg.JobManager.Start(); err != nil {
+		return fmt.Errorf("unable to start job manager: %w", err)
+	}
+
+	if err := s.cfg.Pruner.Start(); err != nil {
+		return fmt.Errorf("unable to start pruner: %w", err)
+	}
+
 	// Start the request for quote (RFQ) manager.
 	if err := s.cfg.RfqManager.Start(); err != nil {
 		return fmt.Errorf("unable to start RFQ manager: %w", err)
@@ -239,14 +333,39 @@ func (s *Server) initialize(interceptorChain *rpcperms.InterceptorChain) error {
 		return fmt.Errorf("unable to start RPC server: %w", err)
 	}
 
-	// This does have no effect if starting the rpc server is the last step
-	// in this function, but its better to have it here in case we add more
-	// steps in the future.
-	//
-	// NOTE: if this is not the last step in the function, feel free to
-	// delete this comment.
 	shutdownFuncs["rpcServer"] = s.rpcServer.Stop
 
+	if s.cfg.ReplicationFollower != nil {
+		if err := s.cfg.ReplicationFollower.Start(); err != nil {
+			return fmt.Errorf("unable to start replication "+
+				"follower: %w", err)
+		}
+	}
+
+	// The LNURL-pay server creates its invoices through the RPC server,
+	// so it can only be started now.
+	if s.cfg.Lnurl != nil {
+		s.cfg.Lnurl.InvoiceCreator = s.rpcServer
+		s.lnurlServer, err = lnurl.NewServer(s.cfg.Lnurl)
+		if err != nil {
+			return fmt.Errorf("unable to create LNURL-pay server: "+
+				"%w", err)
+		}
+
+		if err := s.lnurlServer.Start(); err != nil {
+			return fmt.Errorf("unable to start LNURL-pay server: "+
+				"%w", err)
+		}
+	}
+
+	if s.cfg.Explorer != nil {
+		s.explorerServer = explorer.NewServer(s.cfg.Explorer)
+		if err := s.explorerServer.Start(); err != nil {
+			return fmt.Errorf("unable to start explorer API "+
+				"server: %w", err)
+		}
+	}
+
 	shutdownFuncs = nil
 
 	close(s.ready)
@@ -332,14 +451,21 @@ func (s *Server) RunUntilShutdown(mainErrChan <-chan error) error {
 		}
 	}()
 
-	err := s.initialize(interceptorChain)
+	// We create the rpc server before initializing the daemon, so we can
+	// already serve the call to unlock the database.
+	var err error
+	s.rpcServer, err = newRPCServer(
+		s.cfg.SignalInterceptor, interceptorChain, s.cfg,
+	)
 	if err != nil {
-		return mkErr("unable to initialize RPC server: %v", err)
+		return mkErr("unable to create rpc server: %v", err)
 	}
 
 	rpcServerOpts := interceptorChain.CreateServerOpts(
 		&rpcperms.InterceptorsOpts{
-			Prometheus: &s.cfg.Prometheus,
+			Prometheus:     &s.cfg.Prometheus,
+			RPCJournal:     s.cfg.RPCJournal,
+			UniverseMirror: s.cfg.UniverseMirror,
 		},
 	)
 	serverOpts = append(serverOpts, rpcServerOpts...)
@@ -376,6 +502,34 @@ func (s *Server) RunUntilShutdown(mainErrChan <-chan error) error {
 	}
 	defer stopProxy()
 
+	// If the database is encrypted with a key derived from a passphrase,
+	// we can only initialize the daemon once it was unlocked. Until then,
+	// we only accept the call to unlock the database.
+	fieldCipher := s.cfg.DatabaseConfig.FieldCipher
+	if fieldCipher.IsLocked() {
+		interceptorChain.SetDatabaseLocked()
+
+		srvrLog.Infof("Database is locked, waiting for it to be " +
+			"unlocked with the UnlockDatabase RPC")
+
+		select {
+		case <-fieldCipher.Unlocked():
+
+		case <-s.cfg.SignalInterceptor.ShutdownChannel():
+			srvrLog.Infof("Received SIGINT (Ctrl+C). Shutting " +
+				"down...")
+			return nil
+
+		case <-s.quit:
+			return nil
+		}
+	}
+
+	err = s.initialize(interceptorChain)
+	if err != nil {
+		return mkErr("unable to initialize RPC server: %v", err)
+	}
+
 	// TODO(roasbeef): make macaroons service, needs the lnd APIs present
 	// an abstracted
 
@@ -389,6 +543,11 @@ func (s *Server) RunUntilShutdown(mainErrChan <-chan error) error {
 	// We transition the server state to Active, as the server is up.
 	interceptorChain.SetServerActive()
 
+	// On shutdown, we first stop accepting new calls and let the in-flight
+	// operations reach a safe checkpoint, before the deferred calls above
+	// stop the RPC and gRPC servers.
+	defer s.drain(interceptorChain, grpcServer)
+
 	// If Prometheus monitoring is enabled, start the Prometheus exporter.
 	if s.cfg.Prometheus.Active {
 		// Set the gRPC server instance in the Prometheus exporter
@@ -405,6 +564,23 @@ func (s *Server) RunUntilShutdown(mainErrChan <-chan error) error {
 		// minter.
 		s.cfg.Prometheus.AssetMinter = s.cfg.AssetMinter
 
+		// Provide Prometheus collectors with access to the aux invoice
+		// manager.
+		s.cfg.Prometheus.AuxInvoiceManager = s.cfg.AuxInvoiceManager
+
+		// Provide Prometheus collectors with access to the multiverse
+		// caches.
+		s.cfg.Prometheus.Multiverse = s.cfg.DatabaseConfig.Multiverse
+
+		// Provide Prometheus collectors with access to the RFQ
+		// manager.
+		s.cfg.Prometheus.RfqManager = s.cfg.RfqManager
+
+		// Provide Prometheus collectors with access to the proof
+		// courier dispatcher.
+		s.cfg.Prometheus.ProofCourierDispatch =
+			s.cfg.ProofCourierDispatcher
+
 		promExporter, err := monitoring.NewPrometheusExporter(
 			&s.cfg.Prometheus,
 		)
@@ -450,11 +626,100 @@ func (s *Server) RunUntilShutdown(mainErrChan <-chan error) error {
 	return nil
 }
 
+// drain stops accepting new RPC calls and waits for the in-flight operations
+// to reach a checkpoint they can be resumed from after a restart, up to the
+// configured drain timeout. Once the subsystems are drained, the RPC server is
+// stopped and the calls that are still in progress are given the remaining
+// time to complete.
+func (s *Server) drain(interceptorChain *rpcperms.InterceptorChain,
+	grpcServer *grpc.Server) {
+
+	if s.cfg.ShutdownDrainTimeout == 0 {
+		return
+	}
+
+	srvrLog.Infof("Draining in-flight operations, waiting at most %v",
+		s.cfg.ShutdownDrainTimeout)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), s.cfg.ShutdownDrainTimeout,
+	)
+	defer cancel()
+
+	drainStart := time.Now()
+
+	interceptorChain.SetShuttingDown()
+
+	s.drainSubsystems(ctx)
+
+	// Stopping the RPC server ends the streaming calls, so the gRPC server
+	// only has to wait for the unary calls that are still in progress.
+	if err := s.rpcServer.Stop(); err != nil {
+		srvrLog.Warnf("Unable to stop RPC server: %v", err)
+	}
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	select {
+	case <-grpcStopped:
+		srvrLog.Infof("Drained in-flight operations in %v",
+			time.Since(drainStart))
+
+	case <-ctx.Done():
+		srvrLog.Warnf("Drain timeout reached with RPC calls still in " +
+			"progress, stopping anyway")
+
+		// Stopping the gRPC server also releases the graceful stop.
+		grpcServer.Stop()
+	}
+}
+
+// drainSubsystems waits for the in-flight operations of the subsystems that
+// can't be interrupted safely to reach a checkpoint, or until the given context
+// is done. The HTLC modifications, asset transfers that are being broadcast
+// and proof deliveries are drained concurrently.
+func (s *Server) drainSubsystems(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		err := s.cfg.ChainPorter.Drain(ctx)
+		if err != nil {
+			srvrLog.Warnf("Unable to drain chain porter: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		err := s.cfg.AuxInvoiceManager.Drain(ctx)
+		if err != nil {
+			srvrLog.Warnf("Unable to drain aux invoice manager: %v",
+				err)
+		}
+	}()
+
+	wg.Wait()
+}
+
 // StartAsSubserver is an alternative to Start where the RPC server does not
 // create its own gRPC server but registers to an existing one. The same goes
 // for REST (if enabled), instead of creating an own mux and HTTP server, we
 // register to an existing one.
 func (s *Server) StartAsSubserver(lndGrpc *lndclient.GrpcLndServices) error {
+	// As a subserver, there is no RPC server that could accept the call to
+	// unlock the database before the daemon is initialized.
+	if s.cfg.DatabaseConfig.FieldCipher.IsLocked() {
+		return fmt.Errorf("database encryption with a passphrase is " +
+			"not supported when running as a subserver")
+	}
+
 	if err := s.initialize(nil); err != nil {
 		return fmt.Errorf("unable to initialize RPC server: %w", err)
 	}
@@ -508,13 +773,12 @@ func startGrpcListen(cfg *Config, grpcServer *grpc.Server,
 	return nil
 }
 
-// startRestProxy starts the given REST proxy on the listeners found in the
-// config.
-func startRestProxy(cfg *Config, rpcServer *rpcServer) (func(), error) {
-	// We use the first RPC listener as the destination for our REST proxy.
-	// If the listener is set to listen on all interfaces, we replace it
-	// with localhost, as we cannot dial it directly.
-	restProxyDest := cfg.RPCListeners[0].String()
+// restProxyDestination returns the address the REST proxy dials to reach the
+// gRPC server. We use the first RPC listener as the destination for our REST
+// proxy. If the listener is set to listen on all interfaces, we replace it
+// with localhost, as we cannot dial it directly.
+func restProxyDestination(rpcListeners []net.Addr) string {
+	restProxyDest := rpcListeners[0].String()
 	switch {
 	case strings.Contains(restProxyDest, "0.0.0.0"):
 		restProxyDest = strings.Replace(
@@ -527,6 +791,14 @@ func startRestProxy(cfg *Config, rpcServer *rpcServer) (func(), error) {
 		)
 	}
 
+	return restProxyDest
+}
+
+// startRestProxy starts the given REST proxy on the listeners found in the
+// config.
+func startRestProxy(cfg *Config, rpcServer *rpcServer) (func(), error) {
+	restProxyDest := restProxyDestination(cfg.RPCListeners)
+
 	var shutdownFuncs []func()
 	shutdown := func() {
 		for _, shutdownFn := range shutdownFuncs {
@@ -581,7 +853,7 @@ func startRestProxy(cfg *Config, rpcServer *rpcServer) (func(), error) {
 	// Wrap the default grpc-gateway handler with the WebSocket handler.
 	restHandler := lnrpc.NewWebSocketProxy(
 		mux, rpcsLog, cfg.WSPingInterval, cfg.WSPongWait,
-		nil,
+		taprpc.ClientStreamingURIs,
 	)
 
 	// Use a WaitGroup so we can be sure the instructions on how to input the
@@ -638,6 +910,40 @@ func (s *Server) Stop() error {
 
 	srvrLog.Infof("Stopping Main Server")
 
+	// As a subserver, the RPC calls are served by litd, so we can only
+	// drain the subsystems before stopping them.
+	if s.cfg.ShutdownDrainTimeout > 0 {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), s.cfg.ShutdownDrainTimeout,
+		)
+		s.drainSubsystems(ctx)
+		cancel()
+	}
+
+	if s.explorerServer != nil {
+		if err := s.explorerServer.Stop(); err != nil {
+			return err
+		}
+	}
+	if s.lnurlServer != nil {
+		if err := s.lnurlServer.Stop(); err != nil {
+			return err
+		}
+	}
+
+	// We stop the replication before the RPC server, so the replication
+	// streams of followers are ended.
+	if s.cfg.ReplicationFollower != nil {
+		if err := s.cfg.ReplicationFollower.Stop(); err != nil {
+			return err
+		}
+	}
+	if s.cfg.ReplicationLeader != nil {
+		if err := s.cfg.ReplicationLeader.Stop(); err != nil {
+			return err
+		}
+	}
+
 	if err := s.rpcServer.Stop(); err != nil {
 		return err
 	}
@@ -652,10 +958,34 @@ func (s *Server) Stop() error {
 		return err
 	}
 
+	if s.cfg.AnchorSpendWatcher != nil {
+		if err := s.cfg.AnchorSpendWatcher.Stop(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.cfg.ConfDepthTracker.Stop(); err != nil {
+		return err
+	}
+
+	if s.cfg.RPCJournal != nil {
+		if err := s.cfg.RPCJournal.Stop(); err != nil {
+			return err
+		}
+	}
+
 	if err := s.cfg.ChainPorter.Stop(); err != nil {
 		return err
 	}
 
+	if err := s.cfg.JobManager.Stop(); err != nil {
+		return err
+	}
+
+	if err := s.cfg.Pruner.Stop(); err != nil {
+		return err
+	}
+
 	if err := s.cfg.UniverseFederation.Start(); err != nil {
 		return err
 	}
@@ -680,6 +1010,30 @@ func (s *Server) Stop() error {
 		return err
 	}
 
+	if s.cfg.DBEventBus != nil {
+		if err := s.cfg.DBProofNotifier.Stop(); err != nil {
+			return err
+		}
+
+		if err := s.cfg.DBEventBus.Stop(); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.AlertManager != nil {
+		if err := s.cfg.AlertManager.Stop(); err != nil {
+			return err
+		}
+	}
+
+	// The trace export is stopped after the subsystems, so their
+	// remaining spans are still exported.
+	if s.cfg.TraceProvider != nil {
+		if err := s.cfg.TraceProvider.Stop(); err != nil {
+			return err
+		}
+	}
+
 	if s.macaroonService != nil {
 		err := s.macaroonService.Stop()
 		if err != nil {
@@ -767,6 +1121,34 @@ func (s *Server) FetchLeavesFromCommit(chanState lnwl.AuxChanState,
 		"theirBalance=%v, numHtlcs=%d", com.LocalBalance,
 		com.RemoteBalance, len(com.Htlcs))
 
+	// Taproot channels that were restored from a static channel backup
+	// have lost their custom channel data, so we fill it in from the
+	// restored asset channel backup, if there is one.
+	if com.CustomBlob.IsNone() && chanState.CustomBlob.IsNone() &&
+		chanState.ChanType.IsTaproot() {
+
+		chanState, com = s.auxStateFromBackup(
+			chanState, com, whoseCommit,
+		)
+	}
+
+	// Channels that were loaded before any new commitment was created are
+	// only ever seen here, so we use this to learn about their state.
+	if s.cfg.AuxCommitmentRecorder != nil {
+		s.recordFunding(chanState)
+
+		com.CustomBlob.WhenSome(func(blob tlv.Blob) {
+			err := s.cfg.AuxCommitmentRecorder.RecordCommitment(
+				chanState.FundingOutpoint, whoseCommit, blob,
+			)
+			if err != nil {
+				srvrLog.Warnf("Unable to record commitment of "+
+					"channel %v: %v",
+					chanState.FundingOutpoint, err)
+			}
+		})
+	}
+
 	// The aux leaf creator is fully stateless, and we don't need to wait
 	// for the server to be started before being able to use it.
 	return tapchannel.FetchLeavesFromCommit(
@@ -774,6 +1156,59 @@ func (s *Server) FetchLeavesFromCommit(chanState lnwl.AuxChanState,
 	)
 }
 
+// recordFunding records the funding blob of the given channel, if it has one,
+// so it can be included in asset channel backups.
+func (s *Server) recordFunding(chanState lnwl.AuxChanState) {
+	chanState.CustomBlob.WhenSome(func(blob tlv.Blob) {
+		err := s.cfg.AuxCommitmentRecorder.RecordFunding(
+			chanState.FundingOutpoint, blob,
+		)
+		if err != nil {
+			srvrLog.Warnf("Unable to record funding of channel "+
+				"%v: %v", chanState.FundingOutpoint, err)
+		}
+	})
+}
+
+// auxStateFromBackup fills in the funding and commitment blobs of the given
+// channel from its restored asset channel backup. The channel state and
+// commitment are returned unchanged if no backup exists for the channel.
+func (s *Server) auxStateFromBackup(chanState lnwl.AuxChanState,
+	com channeldb.ChannelCommitment,
+	whoseCommit lntypes.ChannelParty) (lnwl.AuxChanState,
+	channeldb.ChannelCommitment) {
+
+	if s.cfg.ChannelBackups == nil {
+		return chanState, com
+	}
+
+	backup, err := s.cfg.ChannelBackups.FetchChannelBackup(
+		context.Background(), chanState.FundingOutpoint,
+	)
+	switch {
+	case errors.Is(err, cmsg.ErrChannelBackupNotFound):
+		return chanState, com
+
+	case err != nil:
+		srvrLog.Errorf("Unable to fetch channel backup of %v: %v",
+			chanState.FundingOutpoint, err)
+		return chanState, com
+	}
+
+	srvrLog.Debugf("Using restored channel backup of %v",
+		chanState.FundingOutpoint)
+
+	commitment := backup.LocalCommitment
+	if whoseCommit.IsRemote() {
+		commitment = backup.RemoteCommitment
+	}
+
+	chanState.CustomBlob = lfn.Some[tlv.Blob](backup.Funding.Bytes())
+	com.CustomBlob = lfn.Some[tlv.Blob](commitment.Bytes())
+
+	return chanState, com
+}
+
 // FetchLeavesFromRevocation attempts to fetch the auxiliary leaves
 // from a channel revocation that stores balance + blob information.
 //
@@ -806,7 +1241,30 @@ func (s *Server) ApplyHtlcView(
 
 	// The aux leaf creator is fully stateless, and we don't need to wait
 	// for the server to be started before being able to use it.
-	return tapchannel.ApplyHtlcView(s.chainParams, in)
+	result := tapchannel.ApplyHtlcView(s.chainParams, in)
+	if s.cfg.AuxCommitmentRecorder == nil {
+		return result
+	}
+
+	// lnd only calls this when a new commitment is signed or received, so
+	// we record the resulting commitment for later inspection.
+	newBlob, err := result.Unpack()
+	if err != nil {
+		return result
+	}
+	s.recordFunding(in.ChannelState)
+	newBlob.WhenSome(func(blob tlv.Blob) {
+		err := s.cfg.AuxCommitmentRecorder.RecordTransition(
+			in.ChannelState.FundingOutpoint, in.WhoseCommit,
+			in.PrevBlob, blob,
+		)
+		if err != nil {
+			srvrLog.Warnf("Unable to record commitment of channel "+
+				"%v: %v", in.ChannelState.FundingOutpoint, err)
+		}
+	})
+
+	return result
 }
 
 // InlineParseCustomData replaces any custom data binary blob in the given RPC
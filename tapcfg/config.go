@@ -21,16 +21,29 @@ import (
 	"github.com/jessevdk/go-flags"
 	"github.com/lightninglabs/lndclient"
 	tap "github.com/lightninglabs/taproot-assets"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/anchorwallet"
+	"github.com/lightninglabs/taproot-assets/chainsource"
+	"github.com/lightninglabs/taproot-assets/explorer"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/lnurl"
+	"github.com/lightninglabs/taproot-assets/metablob"
 	"github.com/lightninglabs/taproot-assets/monitoring"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/retention"
 	"github.com/lightninglabs/taproot-assets/rfq"
 	"github.com/lightninglabs/taproot-assets/tapdb"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightninglabs/taproot-assets/tracing"
+	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/cert"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/tor"
 	"golang.org/x/net/http2"
@@ -41,6 +54,7 @@ import (
 
 const (
 	defaultDataDirname        = "data"
+	defaultMetaBlobDirname    = "metablobs"
 	defaultTLSCertFilename    = "tls.cert"
 	defaultTLSKeyFilename     = "tls.key"
 	defaultAdminMacFilename   = "admin.macaroon"
@@ -133,9 +147,17 @@ const (
 	// retrieving the corresponding proof via the proof courier service.
 	defaultProofRetrievalDelay = 5 * time.Second
 
+	// defaultShutdownDrainTimeout is the default maximum time we wait for
+	// in-flight operations to reach a safe checkpoint on shutdown.
+	defaultShutdownDrainTimeout = 30 * time.Second
+
 	// defaultLndRPCTimeout is the default timeout we'll use for RPC
 	// requests to lnd.
 	defaultLndRPCTimeout = 1 * time.Minute
+
+	// defaultRemoteSignerTimeout is the default timeout we'll use for RPC
+	// requests to the remote signer.
+	defaultRemoteSignerTimeout = 5 * time.Second
 )
 
 var (
@@ -204,6 +226,20 @@ var (
 			"signrpc", "walletrpc", "chainrpc", "invoicesrpc",
 		},
 	}
+
+	// minimalSignerVersion is the minimum version and build tags required
+	// in the lnd node that is used as a remote signer.
+	minimalSignerVersion = &verrpc.Version{
+		AppMajor: 0,
+		AppMinor: 18,
+		AppPatch: 4,
+
+		// See above, the invoicesrpc build tag is only required
+		// because of a bug in lndclient.
+		BuildTags: []string{
+			"signrpc", "walletrpc", "invoicesrpc",
+		},
+	}
 )
 
 // ChainConfig houses the configuration options that govern which chain/network
@@ -239,6 +275,10 @@ type RpcConfig struct {
 	AllowPublicUniProofCourier bool `long:"allow-public-uni-proof-courier" description:"Disable macaroon authentication for universe proof courier RPC endpoints."`
 	AllowPublicStats           bool `long:"allow-public-stats" description:"Disable macaroon authentication for stats RPC endpoints."`
 
+	RPCJournal bool `long:"rpcjournal" description:"Record all mutating RPC calls (caller identity, parameters with secrets redacted, result and timestamps) in an append-only journal in the database. The journal can be exported with tapcli rpcjournal export."`
+
+	DecimalDisplayAmounts bool `long:"decimal-display-amounts" description:"Render asset amounts in decimal display units (according to the decimal display value of each asset) in addition to base units in RPC responses, and accept amounts in decimal display units in RPC requests."`
+
 	RestCORS []string `long:"restcors" description:"Add an ip:port/hostname to allow cross origin access from. To allow all origins, set as \"*\"."`
 
 	LetsEncryptDir    string `long:"letsencryptdir" description:"The directory to store Let's Encrypt certificates within"`
@@ -269,6 +309,57 @@ type LndConfig struct {
 	RPCTimeout time.Duration `long:"rpctimeout" description:"The timeout to use for RPC requests to lnd; a sufficiently long duration should be chosen to avoid issues with slow responses. Valid time units are {s, m, h}."`
 }
 
+// RemoteSignerConfig is the config for the connection to the lnd node that
+// holds the private keys of the daemon when running in remote signer mode.
+//
+// nolint: lll
+type RemoteSignerConfig struct {
+	Enable bool `long:"enable" description:"Use a remote signer for all script key and internal key derivation and signing, so this daemon holds no private keys. The connected lnd node must be a watch-only node of the same remote signer."`
+
+	RPCHost string `long:"rpchost" description:"The remote signer's lnd RPC host:port."`
+
+	MacaroonPath string `long:"macaroonpath" description:"The macaroon to use for authenticating with the remote signer; it must allow deriving keys and signing."`
+
+	TLSCertPath string `long:"tlscertpath" description:"The TLS certificate to use for establishing the remote signer's identity."`
+
+	Timeout time.Duration `long:"timeout" description:"The timeout for RPC requests to the remote signer. Valid time units are {s, m, h}."`
+}
+
+// Validate checks that the remote signer config is complete if it's enabled.
+// Connection settings without the remote signer being enabled are rejected, as
+// the daemon would otherwise silently hold its own private keys.
+func (c *RemoteSignerConfig) Validate() error {
+	if !c.Enable {
+		if c.RPCHost != "" || c.MacaroonPath != "" ||
+			c.TLSCertPath != "" {
+
+			return fmt.Errorf("remotesigner connection settings " +
+				"are set but remotesigner.enable is false")
+		}
+
+		return nil
+	}
+
+	switch {
+	case c.RPCHost == "":
+		return fmt.Errorf("remotesigner.rpchost must be set")
+
+	case c.MacaroonPath == "":
+		return fmt.Errorf("remotesigner.macaroonpath must be set")
+
+	case c.TLSCertPath == "":
+		return fmt.Errorf("remotesigner.tlscertpath must be set")
+
+	case c.Timeout <= 0:
+		return fmt.Errorf("remotesigner.timeout must be positive")
+	}
+
+	c.MacaroonPath = lncfg.CleanAndExpandPath(c.MacaroonPath)
+	c.TLSCertPath = lncfg.CleanAndExpandPath(c.TLSCertPath)
+
+	return nil
+}
+
 // UniverseConfig is the config that houses any Universe related config
 // values.
 type UniverseConfig struct {
@@ -280,6 +371,10 @@ type UniverseConfig struct {
 
 	SyncAllAssets bool `long:"sync-all-assets" description:"If set, the federation syncer will default to syncing all assets."`
 
+	Mirror string `long:"mirror" description:"The host:port of a remote universe server to mirror. If set, the local universe continuously replicates all roots and leaves of that server and serves them read-only. A mirror doesn't sync with any other universe server and rejects all RPC calls that would write to its universe."`
+
+	NoReceivedProofPush bool `long:"no-received-proof-push" description:"If set, proofs of assets received to our addresses will not be pushed to the universe servers of our federation."`
+
 	PublicAccess string `long:"public-access" description:"The public access mode for the universe server, controlling whether remote parties can read from and/or write to this universe server over RPC if exposed to a public network interface. This can be unset, 'r', 'w', or 'rw'. If unset, public access is not enabled for the universe server. If 'r' is included, public access is allowed for read-only endpoints. If 'w' is included, public access is allowed for write endpoints."`
 
 	StatsCacheDuration time.Duration `long:"stats-cache-duration" description:"The amount of time to cache stats for before refreshing them. Valid time units are {s, m, h}."`
@@ -289,6 +384,10 @@ type UniverseConfig struct {
 	UniverseQueriesBurst int `long:"req-burst-budget" description:"The burst budget for the universe query rate limiting."`
 
 	MultiverseCaches *tapdb.MultiverseCacheConfig `group:"multiverse-caches" namespace:"multiverse-caches"`
+
+	UploadLimits *universe.UploadLimiterConfig `group:"upload-limits" namespace:"upload-limits"`
+
+	ProofArchive *metablob.S3Config `group:"proof-archive" namespace:"proof-archive"`
 }
 
 // AddrBookConfig is the config that houses any address Book related config
@@ -297,6 +396,34 @@ type AddrBookConfig struct {
 	DisableSyncer bool `long:"disable-syncer" description:"If true, tapd will not try to sync issuance proofs for unknown assets when creating an address."`
 }
 
+// ChannelConfig is the config that houses any asset channel related config
+// values.
+type ChannelConfig struct {
+	ZeroConfPeers []string `long:"zeroconfpeer" description:"The hex encoded public key of a peer that is trusted to open zero-conf asset channels to this node. Can be specified multiple times."`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *ChannelConfig) Validate() error {
+	_, err := c.zeroConfPeers()
+	return err
+}
+
+// zeroConfPeers parses the public keys of the trusted zero-conf peers.
+func (c *ChannelConfig) zeroConfPeers() (fn.Set[route.Vertex], error) {
+	peers := fn.NewSet[route.Vertex]()
+	for _, peerStr := range c.ZeroConfPeers {
+		peer, err := route.NewVertexFromStr(peerStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zero-conf peer %v: %w",
+				peerStr, err)
+		}
+
+		peers.Add(peer)
+	}
+
+	return peers, nil
+}
+
 // ExperimentalConfig houses experimental tapd cli configuration options.
 type ExperimentalConfig struct {
 	Rfq rfq.CliConfig `group:"rfq" namespace:"rfq"`
@@ -333,11 +460,15 @@ type Config struct {
 
 	CustodianProofRetrievalDelay time.Duration `long:"custodianproofretrievaldelay" description:"The number of seconds the custodian waits after identifying an asset transfer on-chain and before retrieving the corresponding proof. Valid time units are {s, m, h}."`
 
+	ShutdownDrainTimeout time.Duration `long:"shutdowndraintimeout" description:"The maximum time to wait on shutdown for in-flight operations (HTLC modifications, asset transfers that are being broadcast and proof deliveries) to reach a safe checkpoint, after new RPC calls are no longer accepted. Set to 0 to shut down immediately. Valid time units are {s, m, h}."`
+
 	ChainConf *ChainConfig
 	RpcConf   *RpcConfig
 
 	Lnd *LndConfig `group:"lnd" namespace:"lnd"`
 
+	RemoteSigner *RemoteSignerConfig `group:"remotesigner" namespace:"remotesigner"`
+
 	DatabaseBackend string                `long:"databasebackend" description:"The database backend to use for storing all asset related data." choice:"sqlite" choice:"postgres"`
 	Sqlite          *tapdb.SqliteConfig   `group:"sqlite" namespace:"sqlite"`
 	Postgres        *tapdb.PostgresConfig `group:"postgres" namespace:"postgres"`
@@ -346,8 +477,32 @@ type Config struct {
 
 	AddrBook *AddrBookConfig `group:"address" namespace:"address"`
 
+	Channel *ChannelConfig `group:"channel" namespace:"channel"`
+
 	Prometheus monitoring.PrometheusConfig `group:"prometheus" namespace:"prometheus"`
 
+	Alerts *alert.CliConfig `group:"alerts" namespace:"alerts"`
+
+	Tracing *tracing.CliConfig `group:"tracing" namespace:"tracing"`
+
+	Lnurl *lnurl.CliConfig `group:"lnurl" namespace:"lnurl"`
+
+	Explorer *explorer.CliConfig `group:"explorer" namespace:"explorer"`
+
+	Replication *replication.CliConfig `group:"replication" namespace:"replication"`
+
+	ChainSource *chainsource.CliConfig `group:"chainsource" namespace:"chainsource"`
+
+	AutoFinalize *tapgarden.AutoFinalizeConfig `group:"autofinalize" namespace:"autofinalize"`
+
+	Anchor *tapfreighter.AnchorConfig `group:"anchor" namespace:"anchor"`
+
+	AnchorWallet *anchorwallet.CliConfig `group:"anchorwallet" namespace:"anchorwallet"`
+
+	MetaBlobs *metablob.CliConfig `group:"metablobs" namespace:"metablobs"`
+
+	Retention *retention.CliConfig `group:"retention" namespace:"retention"`
+
 	Experimental *ExperimentalConfig `group:"experimental" namespace:"experimental"`
 
 	// LogWriter is the root logger that all of the daemon's subloggers are
@@ -396,9 +551,13 @@ func DefaultConfig() Config {
 			MacaroonPath: defaultLndMacaroonPath,
 			RPCTimeout:   defaultLndRPCTimeout,
 		},
+		RemoteSigner: &RemoteSignerConfig{
+			Timeout: defaultRemoteSignerTimeout,
+		},
 		DatabaseBackend: DatabaseBackendSqlite,
 		Sqlite: &tapdb.SqliteConfig{
 			DatabaseFileName: defaultSqliteDatabasePath,
+			Encryption:       tapdb.FieldEncryptionNone,
 		},
 		Postgres: &tapdb.PostgresConfig{
 			Host:               "localhost",
@@ -428,6 +587,7 @@ func DefaultConfig() Config {
 			},
 		},
 		CustodianProofRetrievalDelay: defaultProofRetrievalDelay,
+		ShutdownDrainTimeout:         defaultShutdownDrainTimeout,
 		Universe: &UniverseConfig{
 			SyncInterval: defaultUniverseSyncInterval,
 			UniverseQueriesPerSecond: rate.Limit(
@@ -437,13 +597,37 @@ func DefaultConfig() Config {
 			MultiverseCaches: fn.Ptr(
 				tapdb.DefaultMultiverseCacheConfig(),
 			),
+			UploadLimits: fn.Ptr(
+				universe.DefaultUploadLimiterConfig(),
+			),
+			ProofArchive: &metablob.S3Config{
+				Timeout: metablob.DefaultS3Timeout,
+			},
 		},
 		AddrBook: &AddrBookConfig{
 			DisableSyncer: false,
 		},
+		Channel:     &ChannelConfig{},
+		Alerts:      alert.DefaultCliConfig(),
+		Tracing:     tracing.DefaultCliConfig(),
+		Lnurl:       lnurl.DefaultCliConfig(),
+		Explorer:    explorer.DefaultCliConfig(),
+		Replication: replication.DefaultCliConfig(),
+		ChainSource: chainsource.DefaultCliConfig(),
+		AutoFinalize: fn.Ptr(
+			tapgarden.DefaultAutoFinalizeConfig(),
+		),
+		Anchor: fn.Ptr(
+			tapfreighter.DefaultAnchorConfig(),
+		),
+		AnchorWallet: anchorwallet.DefaultCliConfig(),
+		MetaBlobs:    metablob.DefaultCliConfig(),
+		Retention:    retention.DefaultCliConfig(),
 		Experimental: &ExperimentalConfig{
 			Rfq: rfq.CliConfig{
 				AcceptPriceDeviationPpm: rfq.DefaultAcceptPriceDeviationPpm,
+				PriceCacheMaxAmtMsat:    rfq.DefaultPriceCacheMaxAmtMsat,
+				ApprovalHookTimeout:     rfq.DefaultApprovalHookTimeout,
 			},
 		},
 	}
@@ -710,6 +894,15 @@ func ValidateConfig(cfg Config, cfgLogger btclog.Logger) (*Config, error) {
 		)
 	}
 
+	// Meta blobs are stored in the network directory as well, unless a
+	// custom directory was set.
+	if cfg.MetaBlobs.Dir == "" {
+		cfg.MetaBlobs.Dir = filepath.Join(
+			cfg.networkDir, defaultMetaBlobDirname,
+		)
+	}
+	cfg.MetaBlobs.Dir = CleanAndExpandPath(cfg.MetaBlobs.Dir)
+
 	// If a custom macaroon directory wasn't specified and the data
 	// directory has changed from the default path, then we'll also update
 	// the path for the macaroons to be generated.
@@ -745,6 +938,12 @@ func ValidateConfig(cfg Config, cfgLogger btclog.Logger) (*Config, error) {
 		return nil, fmt.Errorf("must specify --lnd.macaroonpath")
 	}
 
+	// Make sure the remote signer connection is fully specified if it's
+	// enabled.
+	if err := cfg.RemoteSigner.Validate(); err != nil {
+		return nil, mkErr("error in remote signer config: %v", err)
+	}
+
 	// Adjust the default lnd macaroon path if only the network is
 	// specified.
 	if cfg.ChainConf.Network != defaultNetwork &&
@@ -855,6 +1054,104 @@ func ValidateConfig(cfg Config, cfgLogger btclog.Logger) (*Config, error) {
 		}
 	}
 
+	// Validate the universe proof upload limits.
+	err = cfg.Universe.UploadLimits.Validate()
+	if err != nil {
+		return nil, mkErr("error in universe upload limits config: "+
+			"%v", err)
+	}
+
+	// A universe mirror is read-only, so it can't allow public write
+	// access.
+	if cfg.Universe.Mirror != "" &&
+		strings.Contains(cfg.Universe.PublicAccess, "w") {
+
+		return nil, mkErr("universe public write access can't be " +
+			"enabled for a universe mirror")
+	}
+
+	// Validate the universe proof archive, if proofs are offloaded.
+	if cfg.Universe.ProofArchive.Endpoint != "" {
+		err = cfg.Universe.ProofArchive.Validate()
+		if err != nil {
+			return nil, mkErr("error in universe proof archive "+
+				"config: %v", err)
+		}
+	}
+
+	// Validate the asset channel config.
+	err = cfg.Channel.Validate()
+	if err != nil {
+		return nil, mkErr("error in channel config: %v", err)
+	}
+
+	// Validate the alerting config.
+	err = cfg.Alerts.Validate()
+	if err != nil {
+		return nil, mkErr("error in alerts config: %v", err)
+	}
+
+	// Validate the tracing config.
+	err = cfg.Tracing.Validate()
+	if err != nil {
+		return nil, mkErr("error in tracing config: %v", err)
+	}
+
+	// Validate the LNURL-pay server config.
+	err = cfg.Lnurl.Validate()
+	if err != nil {
+		return nil, mkErr("error in LNURL-pay config: %v", err)
+	}
+
+	// Validate the explorer API config.
+	err = cfg.Explorer.Validate()
+	if err != nil {
+		return nil, mkErr("error in explorer API config: %v", err)
+	}
+
+	// Validate the state replication config.
+	err = cfg.Replication.Validate()
+	if err != nil {
+		return nil, mkErr("error in replication config: %v", err)
+	}
+
+	// Validate the chain backend config.
+	err = cfg.ChainSource.Validate()
+	if err != nil {
+		return nil, mkErr("error in chain source config: %v", err)
+	}
+
+	// Validate the automatic batch finalization config.
+	err = cfg.AutoFinalize.Validate()
+	if err != nil {
+		return nil, mkErr("error in automatic batch finalization "+
+			"config: %v", err)
+	}
+
+	// Validate the anchor output config.
+	err = cfg.Anchor.Validate()
+	if err != nil {
+		return nil, mkErr("error in anchor output config: %v", err)
+	}
+
+	// Validate the anchor wallet config.
+	err = cfg.AnchorWallet.Validate()
+	if err != nil {
+		return nil, mkErr("error in anchor wallet config: %v", err)
+	}
+
+	// Validate the meta blob store config.
+	err = cfg.MetaBlobs.Validate()
+	if err != nil {
+		return nil, mkErr("error in meta blob store config: %v", err)
+	}
+
+	// Validate the data retention config.
+	err = cfg.Retention.Validate()
+	if err != nil {
+		return nil, mkErr("error in retention config: %v", err)
+	}
+
 	// Validate the experimental command line config.
 	err = cfg.Experimental.Validate()
 	if err != nil {
@@ -862,6 +1159,11 @@ func ValidateConfig(cfg Config, cfgLogger btclog.Logger) (*Config, error) {
 			"config: %w", err)
 	}
 
+	if cfg.ShutdownDrainTimeout < 0 {
+		return nil, mkErr("shutdown drain timeout must not be " +
+			"negative")
+	}
+
 	// Use a way higher re-org safe depth value for testnet (if the user
 	// didn't specify a custom value).
 	if cfg.ActiveNetParams.Net == chaincfg.TestNet3Params.Net &&
@@ -1165,3 +1467,35 @@ func getLnd(network string, cfg *LndConfig,
 		RPCTimeout:            cfg.RPCTimeout,
 	})
 }
+
+// getRemoteSigner returns an instance of the lnd services proxy connected to
+// the remote signer.
+func getRemoteSigner(network string, cfg *RemoteSignerConfig,
+	interceptor signal.Interceptor) (*lndclient.GrpcLndServices, error) {
+
+	ctxc, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Make sure the context is canceled if the user requests shutdown.
+	go func() {
+		select {
+		case <-interceptor.ShutdownChannel():
+			cancel()
+
+		case <-ctxc.Done():
+		}
+	}()
+
+	// The remote signer has no chain backend, so we can't wait for it to
+	// be synced.
+	return lndclient.NewLndServices(&lndclient.LndServicesConfig{
+		LndAddress:         cfg.RPCHost,
+		Network:            lndclient.Network(network),
+		CustomMacaroonPath: cfg.MacaroonPath,
+		TLSPath:            cfg.TLSCertPath,
+		CheckVersion:       minimalSignerVersion,
+		BlockUntilUnlocked: true,
+		CallerCtx:          ctxc,
+		RPCTimeout:         cfg.Timeout,
+	})
+}
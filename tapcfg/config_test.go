@@ -0,0 +1,109 @@
+package tapcfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemoteSignerConfigValidate tests the validation of the remote signer
+// config.
+func TestRemoteSignerConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	validConfig := func() *RemoteSignerConfig {
+		return &RemoteSignerConfig{
+			Enable:       true,
+			RPCHost:      "localhost:10019",
+			MacaroonPath: "/signer/signer.macaroon",
+			TLSCertPath:  "/signer/tls.cert",
+			Timeout:      defaultRemoteSignerTimeout,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		modify      func(cfg *RemoteSignerConfig)
+		expectedErr string
+	}{{
+		name:   "valid config",
+		modify: func(*RemoteSignerConfig) {},
+	}, {
+		name: "disabled without settings",
+		modify: func(cfg *RemoteSignerConfig) {
+			*cfg = RemoteSignerConfig{
+				Timeout: defaultRemoteSignerTimeout,
+			}
+		},
+	}, {
+		name: "missing host",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.RPCHost = ""
+		},
+		expectedErr: "remotesigner.rpchost must be set",
+	}, {
+		name: "missing macaroon path",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.MacaroonPath = ""
+		},
+		expectedErr: "remotesigner.macaroonpath must be set",
+	}, {
+		name: "missing TLS cert path",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.TLSCertPath = ""
+		},
+		expectedErr: "remotesigner.tlscertpath must be set",
+	}, {
+		name: "zero timeout",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.Timeout = 0
+		},
+		expectedErr: "remotesigner.timeout must be positive",
+	}, {
+		name: "negative timeout",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.Timeout = -time.Second
+		},
+		expectedErr: "remotesigner.timeout must be positive",
+	}, {
+		name: "host set but disabled",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.Enable = false
+			cfg.MacaroonPath = ""
+			cfg.TLSCertPath = ""
+		},
+		expectedErr: "remotesigner.enable is false",
+	}, {
+		name: "macaroon path set but disabled",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.Enable = false
+			cfg.RPCHost = ""
+			cfg.TLSCertPath = ""
+		},
+		expectedErr: "remotesigner.enable is false",
+	}, {
+		name: "TLS cert path set but disabled",
+		modify: func(cfg *RemoteSignerConfig) {
+			cfg.Enable = false
+			cfg.RPCHost = ""
+			cfg.MacaroonPath = ""
+		},
+		expectedErr: "remotesigner.enable is false",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -6,23 +6,47 @@ import (
 	"database/sql"
 	"encoding/binary"
 	"fmt"
+	"net/url"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btclog"
 	"github.com/lightninglabs/lndclient"
 	tap "github.com/lightninglabs/taproot-assets"
 	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/anchorwallet"
 	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/chainsource"
+	"github.com/lightninglabs/taproot-assets/explorer"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/lightninglabs/taproot-assets/lnurl"
+	"github.com/lightninglabs/taproot-assets/metablob"
+	"github.com/lightninglabs/taproot-assets/metaschema"
+	"github.com/lightninglabs/taproot-assets/perms"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/recovery"
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/retention"
 	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
 	"github.com/lightninglabs/taproot-assets/tapchannel"
 	"github.com/lightninglabs/taproot-assets/tapdb"
 	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
 	"github.com/lightninglabs/taproot-assets/tapfreighter"
 	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsession"
+	"github.com/lightninglabs/taproot-assets/tapswap"
+	"github.com/lightninglabs/taproot-assets/tracing"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd"
 	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/signal"
 )
 
@@ -33,19 +57,29 @@ type databaseBackend interface {
 	WithTx(tx *sql.Tx) *sqlc.Queries
 }
 
-// genServerConfig generates a server config from the given tapd config.
+// genServerConfig generates a server config from the given tapd config. All
+// script and internal keys are derived and signed for by the given signer,
+// which is the same as the given lnd node unless running in remote signer mode.
 //
 // NOTE: The RPCConfig and SignalInterceptor fields must be set by the caller
 // after generating the server config.
 func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
-	lndServices *lndclient.LndServices, enableChannelFeatures bool,
-	mainErrChan chan<- error) (*tap.Config, error) {
+	lndServices, signerServices *lndclient.LndServices,
+	enableChannelFeatures bool, mainErrChan chan<- error) (*tap.Config,
+	error) {
 
 	var (
 		err    error
 		db     databaseBackend
 		dbType sqlc.BackendType
+
+		// dbEventBus is only set if the Postgres event bus is
+		// enabled, in which case it's also used as the event notifier.
+		dbEventBus *tapdb.PostgresEventBus
+
+		dbEventNotifier tapdb.DBEventNotifier
 	)
+	dbEventNotifier = tapdb.NoOpDBEventNotifier{}
 
 	// Now that we know where the database will live, we'll go ahead and
 	// open up the default implementation of it.
@@ -62,7 +96,16 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 
 		cfgLogger.Infof("Opening postgres database at: %v",
 			cfg.Postgres.DSN(true))
-		db, err = tapdb.NewPostgresStore(cfg.Postgres)
+
+		var pgStore *tapdb.PostgresStore
+		pgStore, err = tapdb.NewPostgresStore(cfg.Postgres)
+		if err == nil && cfg.Postgres.EnableEventBus {
+			cfgLogger.Infof("Enabling postgres DB event bus")
+
+			dbEventBus = tapdb.NewPostgresEventBus(pgStore)
+			dbEventNotifier = dbEventBus
+		}
+		db = pgStore
 
 	default:
 		return nil, fmt.Errorf("unknown database backend: %s",
@@ -73,6 +116,50 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 	}
 
 	defaultClock := clock.NewDefaultClock()
+
+	// Field encryption is only supported for the sqlite backend, but we
+	// still check that a postgres database isn't encrypted.
+	fieldKeySource := tapdb.FieldEncryptionNone
+	if cfg.DatabaseBackend == DatabaseBackendSqlite {
+		fieldKeySource = cfg.Sqlite.Encryption
+	}
+	fieldEncryptionDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.FieldEncryptionStore {
+			return db.WithTx(tx)
+		},
+	)
+	fieldCipher, err := tapdb.NewFieldCipher(
+		context.Background(), fieldEncryptionDB, fieldKeySource,
+		defaultClock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create field cipher: %w", err)
+	}
+
+	// If the key is derived from the lnd wallet, we can unlock the
+	// database right away. The secret is the ECDH shared key of our
+	// Taproot Assets key and the NUMS key, which only the lnd wallet can
+	// compute.
+	if fieldCipher.KeySource() == tapdb.FieldEncryptionLnd {
+		cfgLogger.Infof("Unlocking database with lnd derived key")
+
+		secret, err := signerServices.Signer.DeriveSharedKey(
+			context.Background(), asset.NUMSPubKey,
+			&keychain.KeyLocator{
+				Family: asset.TaprootAssetsKeyFamily,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive database "+
+				"encryption key: %w", err)
+		}
+
+		err = fieldCipher.Unlock(context.Background(), secret[:])
+		if err != nil {
+			return nil, fmt.Errorf("unable to unlock database: %w",
+				err)
+		}
+	}
 	rksDB := tapdb.NewTransactionExecutor(
 		db, func(tx *sql.Tx) tapdb.KeyStore {
 			return db.WithTx(tx)
@@ -83,7 +170,10 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 			return db.WithTx(tx)
 		},
 	)
-	assetMintingStore := tapdb.NewAssetMintingStore(mintingStore)
+	rootKeyStore := tapdb.NewRootKeyStore(rksDB, fieldCipher)
+	assetMintingStore := tapdb.NewAssetMintingStore(
+		mintingStore, fieldCipher,
+	)
 
 	assetDB := tapdb.NewTransactionExecutor(
 		db, func(tx *sql.Tx) tapdb.ActiveAssetsStore {
@@ -104,18 +194,68 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 	)
 	tapChainParams := address.ParamsForChain(cfg.ActiveNetParams.Name)
 	tapdbAddrBook := tapdb.NewTapAddressBook(
-		addrBookDB, &tapChainParams, defaultClock,
+		addrBookDB, &tapChainParams, defaultClock, dbEventNotifier,
 	)
-	assetStore := tapdb.NewAssetStore(assetDB, metaDB, defaultClock, dbType)
+	var assetStoreOpts []tapdb.AssetStoreOption
+	if cfg.DatabaseBackend == DatabaseBackendPostgres &&
+		cfg.Postgres.ReadReplica.Host != "" &&
+		cfg.Postgres.ReadReplica.Balances {
 
-	keyRing := tap.NewLndRpcKeyRing(lndServices)
+		cfgLogger.Warnf("Executing asset balance queries on the read " +
+			"replica, balances might be stale")
+
+		assetStoreOpts = append(
+			assetStoreOpts, tapdb.WithReplicaBalances(),
+		)
+	}
+	assetStore := tapdb.NewAssetStore(
+		assetDB, metaDB, defaultClock, dbType, dbEventNotifier,
+		fieldCipher, assetStoreOpts...,
+	)
+
+	keyRing := tap.NewLndRpcKeyRing(signerServices)
 	walletAnchor := tap.NewLndRpcWalletAnchor(lndServices)
+	anchorWallet, err := genAnchorWallet(
+		cfg, walletAnchor, signerServices, tapChainParams.Params,
+	)
+	if err != nil {
+		return nil, err
+	}
 	chainBridge := tap.NewLndRpcChainBridge(lndServices, assetStore)
 	msgTransportClient := tap.NewLndMsgTransportClient(lndServices)
 	lndRouterClient := tap.NewLndRouterClient(lndServices)
 	lndInvoicesClient := tap.NewLndInvoicesClient(lndServices)
 	lndFeatureBitsVerifier := tap.NewLndFeatureBitVerifier(lndServices)
 
+	alertManager, err := alert.NewManagerFromConfig(cfg.Alerts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create alert manager: %w",
+			err)
+	}
+
+	traceProvider, err := tracing.NewProviderFromConfig(cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create trace provider: %w",
+			err)
+	}
+
+	// The anchor spend watcher needs to keep a spend notification
+	// registered for every anchor output, so we only run it if the alerts
+	// it raises are actually delivered somewhere.
+	var anchorSpendWatcher *tapgarden.AnchorSpendWatcher
+	if cfg.Alerts.Enabled() {
+		anchorSpendWatcher = tapgarden.NewAnchorSpendWatcher(
+			&tapgarden.AnchorSpendWatcherConfig{
+				ChainBridge:   chainBridge,
+				SpendNotifier: chainBridge,
+				AnchorLister:  assetStore,
+				IsKnownSpend:  knownAnchorSpend(assetStore),
+				AlertSender:   alertManager,
+				ErrChan:       mainErrChan,
+			},
+		)
+	}
+
 	uniDB := tapdb.NewTransactionExecutor(
 		db, func(tx *sql.Tx) tapdb.BaseUniverseStore {
 			return db.WithTx(tx)
@@ -126,8 +266,21 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 			return db.WithTx(tx)
 		},
 	)
+	// If a proof archive is configured, the raw proofs of universe leaves
+	// are offloaded to it and only fetched once they're queried.
+	var uniProofStore *tapdb.UniverseProofStore
+	if cfg.Universe.ProofArchive.Endpoint != "" {
+		uniProofStore = tapdb.NewUniverseProofStore(metablob.NewS3Store(
+			cfg.Universe.ProofArchive, proof.FileMaxProofSizeBytes,
+		))
+	}
+	uniProofOpt := tapdb.WithUniverseProofStore(uniProofStore)
+
 	multiverse := tapdb.NewMultiverseStore(
-		multiverseDB, tapdb.DefaultMultiverseStoreConfig(),
+		multiverseDB, &tapdb.MultiverseStoreConfig{
+			Caches:     *cfg.Universe.MultiverseCaches,
+			ProofStore: uniProofStore,
+		},
 	)
 
 	uniStatsDB := tapdb.NewTransactionExecutor(
@@ -148,16 +301,17 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		uniStatsDB, defaultClock, statsOpts...,
 	)
 
-	headerVerifier := tapgarden.GenHeaderVerifier(
-		context.Background(), chainBridge,
-	)
+	headerVerifier, err := genHeaderVerifier(cfg, chainBridge)
+	if err != nil {
+		return nil, err
+	}
 	groupVerifier := tapgarden.GenGroupVerifier(
 		context.Background(), assetMintingStore,
 	)
 	uniCfg := universe.ArchiveConfig{
 		NewBaseTree: func(id universe.Identifier) universe.BaseBackend {
 			return tapdb.NewBaseUniverseTree(
-				uniDB, id,
+				uniDB, id, uniProofOpt,
 			)
 		},
 		HeaderVerifier:       headerVerifier,
@@ -166,6 +320,7 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		ChainLookupGenerator: chainBridge,
 		Multiverse:           multiverse,
 		UniverseStats:        universeStats,
+		AlertSender:          alertManager,
 	}
 
 	federationStore := tapdb.NewTransactionExecutor(db,
@@ -174,8 +329,94 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		},
 	)
 	federationDB := tapdb.NewUniverseFederationDB(
-		federationStore, defaultClock,
+		federationStore, defaultClock, uniProofOpt,
+	)
+
+	metaUpdatesDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.MetaUpdateStore {
+			return db.WithTx(tx)
+		},
+	)
+	metaUpdates := tapdb.NewAssetMetaUpdates(metaUpdatesDB, defaultClock)
+
+	jobsDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.JobStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	rfqDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.RfqStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	accountsDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.AccountStore {
+			return db.WithTx(tx)
+		},
+	)
+	accounts := tapdb.NewAssetAccounts(accountsDB, defaultClock)
+
+	freezesDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.FreezeStore {
+			return db.WithTx(tx)
+		},
+	)
+	scriptKeyFreezes := tapdb.NewScriptKeyFreezes(freezesDB, defaultClock)
+
+	metaSchemasDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.MetaSchemaStore {
+			return db.WithTx(tx)
+		},
+	)
+	metaSchemas := metaschema.NewRegistry(
+		tapdb.NewMetaSchemas(metaSchemasDB, defaultClock),
+	)
+
+	retentionDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.RetentionStore {
+			return db.WithTx(tx)
+		},
 	)
+	pruner := retention.NewPruner(retention.PrunerConfig{
+		Store:     tapdb.NewRetentionDB(retentionDB),
+		Retention: cfg.Retention,
+		Clock:     defaultClock,
+	})
+
+	metaBlobs, err := cfg.MetaBlobs.NewStore()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create meta blob store: %w",
+			err)
+	}
+
+	channelBackupsDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.ChannelBackupStore {
+			return db.WithTx(tx)
+		},
+	)
+	channelBackups := tapdb.NewChannelBackups(
+		channelBackupsDB, defaultClock,
+	)
+
+	rpcJournalDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.RpcJournalStore {
+			return db.WithTx(tx)
+		},
+	)
+	rpcJournalStore := tapdb.NewRpcJournal(rpcJournalDB)
+
+	// The journal itself is opt-in, but previously recorded entries can
+	// always be exported.
+	var rpcJournal *rpcjournal.Journal
+	if cfg.RpcConf.RPCJournal {
+		rpcJournal = rpcjournal.New(&rpcjournal.Config{
+			Store:      rpcJournalStore,
+			IsMutating: isMutatingRPC,
+			Clock:      defaultClock,
+		})
+	}
 
 	proofFileStore, err := proof.NewFileArchiver(cfg.networkDir)
 	if err != nil {
@@ -186,13 +427,29 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		assetStore, proofFileStore,
 	)
 
+	// A universe mirror only syncs with the universe server it replicates,
+	// so neither the configured nor the default federation servers are
+	// added in that case.
 	federationMembers := cfg.Universe.FederationServers
+	addDefaultFederation := !cfg.Universe.NoDefaultFederation
+	var mirrorServer fn.Option[universe.ServerAddr]
+	if cfg.Universe.Mirror != "" {
+		cfgLogger.Infof("Configuring Universe as mirror of %v",
+			cfg.Universe.Mirror)
+
+		federationMembers = []string{cfg.Universe.Mirror}
+		addDefaultFederation = false
+		mirrorServer = fn.Some(
+			universe.NewServerAddrFromStr(cfg.Universe.Mirror),
+		)
+	}
+
 	switch cfg.ChainConf.Network {
 	case "mainnet":
 		// Add our default mainnet federation server to the list of
 		// federation servers if not disabled by the user for privacy
 		// reasons.
-		if !cfg.Universe.NoDefaultFederation {
+		if addDefaultFederation {
 			cfgLogger.Infof("Configuring %v as initial Universe "+
 				"federation server",
 				defaultMainnetFederationServer)
@@ -216,7 +473,7 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		// Add our default testnet federation server to the list of
 		// federation servers if not disabled by the user for privacy
 		// reasons.
-		if !cfg.Universe.NoDefaultFederation {
+		if addDefaultFederation {
 			cfgLogger.Infof("Configuring %v as initial Universe "+
 				"federation server",
 				defaultTestnetFederationServer)
@@ -265,34 +522,50 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		}
 	}
 
+	reOrgDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.ReOrgStore {
+			return db.WithTx(tx)
+		},
+	)
+	reOrgLog := tapdb.NewReOrgLog(reOrgDB, defaultClock)
 	reOrgWatcher := tapgarden.NewReOrgWatcher(&tapgarden.ReOrgWatcherConfig{
 		ChainBridge: chainBridge,
 		GroupVerifier: tapgarden.GenGroupVerifier(
 			context.Background(), assetMintingStore,
 		),
-		ProofArchive: proofArchive,
-		NonBuriedAssetFetcher: func(ctx context.Context,
-			minHeight int32) ([]*asset.ChainAsset, error) {
-
-			return assetStore.FetchAllAssets(
-				ctx, false, true, &tapdb.AssetQueryFilters{
-					MinAnchorHeight: minHeight,
-				},
-			)
-		},
-		SafeDepth: cfg.ReOrgSafeDepth,
-		ErrChan:   mainErrChan,
+		ProofArchive:          proofArchive,
+		NonBuriedAssetFetcher: nonBuriedAssets(assetStore, reOrgLog),
+		ReOrgLog:              reOrgLog,
+		SafeDepth:             cfg.ReOrgSafeDepth,
+		ErrChan:               mainErrChan,
 	})
 
 	baseUni := universe.NewArchive(uniCfg)
 
+	// The roots synced from remote universe servers must be signed by
+	// the operator key that is pinned for the server.
 	universeSyncer := universe.NewSimpleSyncer(universe.SimpleSyncCfg{
-		LocalDiffEngine:     baseUni,
-		NewRemoteDiffEngine: tap.NewRpcUniverseDiff,
-		LocalRegistrar:      baseUni,
-		SyncBatchSize:       defaultUniverseSyncBatchSize,
+		LocalDiffEngine: baseUni,
+		NewRemoteDiffEngine: tap.NewPinnedRpcUniverseDiff(
+			federationDB,
+		),
+		LocalRegistrar: baseUni,
+		SyncBatchSize:  defaultUniverseSyncBatchSize,
 	})
 
+	// The local universe server signs the roots it publishes with its
+	// operator key, which is held by the signer.
+	rootSignerCtx, cancelRootSigner := context.WithTimeout(
+		context.Background(), defaultLndRPCTimeout,
+	)
+	universeRootSigner, err := tap.NewLndRootSigner(
+		rootSignerCtx, signerServices,
+	)
+	cancelRootSigner()
+	if err != nil {
+		return nil, err
+	}
+
 	var runtimeIDBytes [8]byte
 	_, err = rand.Read(runtimeIDBytes[:])
 	if err != nil {
@@ -314,10 +587,67 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 					addr,
 				)
 			},
-			ErrChan: mainErrChan,
+			ErrChan:      mainErrChan,
+			MirrorServer: mirrorServer,
+		},
+	)
+
+	// The job manager runs long-running operations in the background. Each
+	// kind of job needs its handler registered before the manager starts,
+	// so interrupted jobs can be resumed.
+	jobManager := jobs.NewManager(jobs.ManagerConfig{
+		Store: tapdb.NewJobs(jobsDB),
+		Clock: defaultClock,
+	})
+	jobManager.RegisterHandler(
+		jobs.KindFederationSync, func(ctx context.Context, _ []byte,
+			report jobs.ProgressFunc) error {
+
+			return universeFederation.SyncAllServers(ctx, report)
 		},
 	)
 
+	// Multi-party signing sessions are only kept in memory, they expire
+	// long before they would be worth persisting.
+	sessionManager := tapsession.NewManager(tapsession.ManagerConfig{
+		Clock:            defaultClock,
+		WitnessValidator: &tap.WitnessValidatorV0{},
+	})
+
+	// Swaps are short-lived as well, so they're also only kept in memory.
+	// The proofs of the assets offered to us are verified just like the
+	// proofs we receive.
+	swapManager := tapswap.NewManager(tapswap.ManagerConfig{
+		Clock:            defaultClock,
+		WitnessValidator: &tap.WitnessValidatorV0{},
+		VerifyProof: func(ctx context.Context,
+			blob proof.Blob) (*proof.AssetSnapshot, error) {
+
+			if err := proof.CheckMaxFileSize(blob); err != nil {
+				return nil, err
+			}
+
+			proofFile, err := proof.DecodeFile(blob)
+			if err != nil {
+				return nil, err
+			}
+
+			return proofFile.Verify(
+				ctx, headerVerifier,
+				proof.DefaultMerkleVerifier, groupVerifier,
+				chainBridge.GenFileChainLookup(proofFile),
+			)
+		},
+	})
+
+	// Unless disabled, we push the proofs of assets we receive to our own
+	// federation, so we don't depend on the sender's universe servers when
+	// spending the assets later on.
+	var receivedProofPusher tapgarden.ReceivedProofPusher
+	if !cfg.Universe.NoReceivedProofPush {
+		receivedProofPusher = universeFederation
+	}
+
 	addrBookConfig := address.BookConfig{
 		Store:        tapdbAddrBook,
 		Syncer:       universeFederation,
@@ -330,8 +660,9 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 	}
 	addrBook := address.NewBook(addrBookConfig)
 
-	virtualTxSigner := tap.NewLndRpcVirtualTxSigner(lndServices)
-	coinSelect := tapfreighter.NewCoinSelect(assetStore)
+	virtualTxSigner := tap.NewLndRpcVirtualTxSigner(signerServices)
+	inputReservations := tapfreighter.NewInputReservations(defaultClock)
+	coinSelect := tapfreighter.NewCoinSelect(assetStore, inputReservations)
 	assetWallet := tapfreighter.NewAssetWallet(&tapfreighter.WalletConfig{
 		CoinSelector:     coinSelect,
 		AssetProofs:      proofArchive,
@@ -340,8 +671,9 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		Signer:           virtualTxSigner,
 		TxValidator:      &tap.ValidatorV0{},
 		WitnessValidator: &tap.WitnessValidatorV0{},
-		Wallet:           walletAnchor,
+		Wallet:           anchorWallet,
 		ChainParams:      &tapChainParams,
+		Anchor:           *cfg.Anchor,
 	})
 
 	// Addresses can have different proof couriers configured, but both
@@ -352,9 +684,21 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		UniverseRpcCfg: cfg.UniverseRpcCourier,
 		TransferLog:    assetStore,
 		LocalArchive:   proofArchive,
+		MailboxSigner:  keyRing,
+		MetaBlobs:      metaBlobs,
 	})
 
-	multiNotifier := proof.NewMultiArchiveNotifier(assetStore, multiverse)
+	// If the DB event bus is enabled, we're also notified about the proofs
+	// stored by other tapd instances using the same database.
+	proofNotifiers := []proof.NotifyArchiver{assetStore, multiverse}
+	var dbProofNotifier *tapdb.DBEventProofNotifier
+	if dbEventBus != nil {
+		dbProofNotifier = tapdb.NewDBEventProofNotifier(
+			dbEventBus, assetStore,
+		)
+		proofNotifiers = append(proofNotifiers, dbProofNotifier)
+	}
+	multiNotifier := proof.NewMultiArchiveNotifier(proofNotifiers...)
 
 	// Determine whether we should use the mock price oracle service or a
 	// real price oracle service.
@@ -390,6 +734,18 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		}
 	}
 
+	inventoryTargets, err := rfqCfg.ParseInventoryTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	var quoteApprover rfq.QuoteApprover
+	if rfqCfg.ApprovalHookURL != "" {
+		quoteApprover = rfq.NewWebhookQuoteApprover(
+			rfqCfg.ApprovalHookURL, rfqCfg.ApprovalHookTimeout,
+		)
+	}
+
 	// Construct the RFQ manager.
 	rfqManager, err := rfq.NewManager(
 		rfq.ManagerCfg{
@@ -402,13 +758,58 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 			AcceptPriceDeviationPpm: rfqCfg.AcceptPriceDeviationPpm,
 			// nolint: lll
 			SkipAcceptQuotePriceCheck: rfqCfg.SkipAcceptQuotePriceCheck,
-			ErrChan:                   mainErrChan,
+			PriceCache: rfq.PriceCacheCfg{
+				MaxStaleness: rfqCfg.PriceCacheMaxStaleness,
+				MaxAmt: lnwire.MilliSatoshi(
+					rfqCfg.PriceCacheMaxAmtMsat,
+				),
+			},
+			AlertSender:    alertManager,
+			PeerStatsStore: tapdb.NewRfqPeerStats(rfqDB),
+			ReputationPolicy: rfq.ReputationPolicy{
+				MinScore:        rfqCfg.MinPeerReputation,
+				PreferHighScore: rfqCfg.PreferReputablePeers,
+			},
+			// nolint: lll
+			SettlementStatsStore: tapdb.NewRfqSettlementStats(rfqDB),
+			HtlcEvents:           lndRouterClient,
+			QuoteStore:           tapdb.NewRfqQuotes(rfqDB),
+			InventoryTargets:     inventoryTargets,
+			QuoteApprover:        quoteApprover,
+			ErrChan:              mainErrChan,
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// The LNURL-pay server uses the same price oracle as the RFQ manager
+	// to estimate the size of the quotes it needs. The invoice creator is
+	// set once the RPC server is created.
+	var lnurlCfg *lnurl.Config
+	if cfg.Lnurl.Active {
+		if priceOracle == nil {
+			return nil, fmt.Errorf("LNURL-pay server requires a " +
+				"price oracle")
+		}
+
+		lnurlCfg = &lnurl.Config{
+			CliConfig:   cfg.Lnurl,
+			PriceOracle: priceOracle,
+		}
+	}
+
+	// The explorer API only reads from the local universe, so it can use
+	// the base universe archive directly.
+	var explorerCfg *explorer.Config
+	if cfg.Explorer.Active {
+		explorerCfg = &explorer.Config{
+			CliConfig: cfg.Explorer,
+			Universe:  baseUni,
+			Stats:     universeStats,
+		}
+	}
+
 	// For the porter, we'll make a multi-notifier comprised of all the
 	// possible proof file sources to ensure it can always fetch input
 	// proofs.
@@ -417,14 +818,15 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 	)
 	chainPorter := tapfreighter.NewChainPorter(
 		&tapfreighter.ChainPorterConfig{
-			Signer:      virtualTxSigner,
-			TxValidator: &tap.ValidatorV0{},
-			ExportLog:   assetStore,
-			ChainBridge: chainBridge,
+			Signer:        virtualTxSigner,
+			TxValidator:   &tap.ValidatorV0{},
+			ExportLog:     assetStore,
+			ChainBridge:   chainBridge,
+			SpendNotifier: chainBridge,
 			GroupVerifier: tapgarden.GenGroupVerifier(
 				context.Background(), assetMintingStore,
 			),
-			Wallet:                 walletAnchor,
+			Wallet:                 anchorWallet,
 			KeyRing:                keyRing,
 			AssetWallet:            assetWallet,
 			ProofReader:            porterProofReader,
@@ -435,6 +837,90 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		},
 	)
 
+	// A replication leader records the state changes of this node in the
+	// replication log, a follower applies the changes of its leader.
+	replicationDB := tapdb.NewTransactionExecutor(
+		db, func(tx *sql.Tx) tapdb.ReplicationStore {
+			return db.WithTx(tx)
+		},
+	)
+	replicationLog := tapdb.NewReplicationLog(replicationDB)
+
+	var (
+		replicationLeader   *replication.Leader
+		replicationFollower *replication.Follower
+	)
+	switch cfg.Replication.Role {
+	case replication.RoleLeader:
+		replicationLeader = replication.NewLeader(
+			&replication.LeaderConfig{
+				Store:             replicationLog,
+				ProofPublisher:    assetStore,
+				AddrPublisher:     addrBook,
+				TransferPublisher: chainPorter,
+				ExistingProofs: ownedAssetProofs(
+					assetStore, proofArchive,
+				),
+				Clock: defaultClock,
+			},
+		)
+
+	case replication.RoleFollower:
+		replicationFollower = replication.NewFollower(
+			&replication.FollowerConfig{
+				LeaderID: cfg.Replication.LeaderHost,
+				Client: tap.NewRpcReplicationClient(
+					cfg.Replication,
+				),
+				Applier: tap.NewReplicationApplier(
+					&tap.ReplicationApplierConfig{
+						ProofArchive: proofArchive,
+						ChainBridge:  chainBridge,
+						MintingStore: assetMintingStore,
+						AddrBook:     addrBook,
+						ChainParams:  &tapChainParams,
+						AssetStore:   assetStore,
+					},
+				),
+				CursorStore:   replicationLog,
+				RetryInterval: cfg.Replication.RetryInterval,
+			},
+		)
+	}
+
+	// Assets of a wallet that was restored from its seed are recovered by
+	// scanning a universe for proofs paying the keys of the wallet.
+	assetRecoverer := recovery.NewRecoverer(&recovery.Config{
+		KeyRing: keyRing,
+		Importer: tap.NewRecoveryImporter(&tap.RecoveryImporterConfig{
+			KeyStore:     tapdbAddrBook,
+			ProofArchive: proofArchive,
+			ChainBridge:  chainBridge,
+			MintingStore: assetMintingStore,
+		}),
+		NewUniverse: tap.NewRpcUniverseDiff,
+		NewCourier: func(ctx context.Context,
+			addr *url.URL) (proof.Courier, error) {
+
+			return proofCourierDispatcher.NewCourier(
+				ctx, addr, false,
+			)
+		},
+	})
+	jobManager.RegisterHandler(
+		jobs.KindAssetRecovery, func(ctx context.Context,
+			encodedParams []byte, report jobs.ProgressFunc) error {
+
+			params, err := recovery.DecodeParams(encodedParams)
+			if err != nil {
+				return err
+			}
+
+			_, err = assetRecoverer.Recover(ctx, *params, report)
+			return err
+		},
+	)
+
 	auxLeafSigner := tapchannel.NewAuxLeafSigner(
 		&tapchannel.LeafSignerConfig{
 			ChainParams: &tapChainParams,
@@ -442,6 +928,10 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		},
 	)
 	channelFunder := tap.NewLndPbstChannelFunder(lndServices)
+	zeroConfPeers, err := cfg.Channel.zeroConfPeers()
+	if err != nil {
+		return nil, err
+	}
 	auxFundingController := tapchannel.NewFundingController(
 		tapchannel.FundingControllerCfg{
 			HeaderVerifier: headerVerifier,
@@ -464,6 +954,7 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 			DefaultCourierAddr: proofCourierAddr,
 			AssetSyncer:        addrBook,
 			FeatureBits:        lndFeatureBitsVerifier,
+			ZeroConfPeers:      zeroConfPeers,
 			ErrChan:            mainErrChan,
 		},
 	)
@@ -508,10 +999,15 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 			GroupVerifier: tapgarden.GenGroupVerifier(
 				context.Background(), assetMintingStore,
 			),
-			ChainBridge: chainBridge,
+			ChainBridge:    chainBridge,
+			ChannelBackups: channelBackups,
 		},
 	)
 
+	auxCommitmentRecorder := tapchannel.NewCommitmentRecorder(
+		clock.NewDefaultClock(),
+	)
+
 	// Parse the universe public access status.
 	universePublicAccess, err := tap.ParseUniversePublicAccessStatus(
 		cfg.Universe.PublicAccess,
@@ -525,14 +1021,36 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 		DebugLevel:            cfg.DebugLevel,
 		RuntimeID:             runtimeID,
 		EnableChannelFeatures: enableChannelFeatures,
+		DecimalDisplayAmounts: cfg.RpcConf.DecimalDisplayAmounts,
+		ShutdownDrainTimeout:  cfg.ShutdownDrainTimeout,
 		Lnd:                   lndServices,
+		SignerLnd:             signerServices,
 		ChainParams: address.ParamsForChain(
 			cfg.ActiveNetParams.Name,
 		),
-		ReOrgWatcher: reOrgWatcher,
+		ReOrgWatcher:        reOrgWatcher,
+		AlertManager:        alertManager,
+		TraceProvider:       traceProvider,
+		JobManager:          jobManager,
+		SessionManager:      sessionManager,
+		SwapManager:         swapManager,
+		Pruner:              pruner,
+		Lnurl:               lnurlCfg,
+		Explorer:            explorerCfg,
+		AnchorSpendWatcher:  anchorSpendWatcher,
+		RPCJournal:          rpcJournal,
+		RPCJournalStore:     rpcJournalStore,
+		ReplicationLeader:   replicationLeader,
+		ReplicationFollower: replicationFollower,
+		ConfDepthTracker: tapgarden.NewConfDepthTracker(
+			&tapgarden.ConfDepthTrackerConfig{
+				ChainBridge: chainBridge,
+				ErrChan:     mainErrChan,
+			},
+		),
 		AssetMinter: tapgarden.NewChainPlanter(tapgarden.PlanterConfig{
 			GardenKit: tapgarden.GardenKit{
-				Wallet:                walletAnchor,
+				Wallet:                anchorWallet,
 				ChainBridge:           chainBridge,
 				Log:                   assetMintingStore,
 				TreeStore:             assetMintingStore,
@@ -544,9 +1062,15 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 				Universe:              universeFederation,
 				ProofWatcher:          reOrgWatcher,
 				UniversePushBatchSize: defaultUniverseSyncBatchSize,
+				ScriptKeyReserver:     tapdbAddrBook,
 			},
 			ProofUpdates: proofArchive,
 			ErrChan:      mainErrChan,
+			AutoFinalize: *cfg.AutoFinalize,
+			Clock:        defaultClock,
+			AnchorOutputValue: btcutil.Amount(
+				cfg.Anchor.OutputValue,
+			),
 		}),
 		AssetCustodian: tapgarden.NewCustodian(
 			&tapgarden.CustodianConfig{
@@ -562,44 +1086,228 @@ func genServerConfig(cfg *Config, cfgLogger btclog.Logger,
 				ErrChan:                mainErrChan,
 				ProofCourierDispatcher: proofCourierDispatcher,
 				ProofRetrievalDelay:    cfg.CustodianProofRetrievalDelay, ProofWatcher: reOrgWatcher,
+				AlertSender: alertManager,
+				ProofPusher: receivedProofPusher,
 			},
 		),
 		ChainBridge:              chainBridge,
 		AddrBook:                 addrBook,
 		AddrBookDisableSyncer:    cfg.AddrBook.DisableSyncer,
 		DefaultProofCourierAddr:  proofCourierAddr,
+		ProofCourierDispatcher:   proofCourierDispatcher,
 		ProofArchive:             proofArchive,
 		AssetWallet:              assetWallet,
+		VirtualTxSigner:          virtualTxSigner,
 		CoinSelect:               coinSelect,
 		ChainPorter:              chainPorter,
 		UniverseArchive:          baseUni,
 		UniverseSyncer:           universeSyncer,
 		UniverseFederation:       universeFederation,
+		UniverseRootSigner:       universeRootSigner,
 		UniFedSyncAllAssets:      cfg.Universe.SyncAllAssets,
+		UniverseMirror:           cfg.Universe.Mirror != "",
 		UniverseStats:            universeStats,
 		UniversePublicAccess:     universePublicAccess,
 		UniverseQueriesPerSecond: cfg.Universe.UniverseQueriesPerSecond,
 		UniverseQueriesBurst:     cfg.Universe.UniverseQueriesBurst,
+		UniverseUploadLimits:     *cfg.Universe.UploadLimits,
 		RfqManager:               rfqManager,
+		PriceOracle:              priceOracle,
 		AuxLeafSigner:            auxLeafSigner,
 		AuxFundingController:     auxFundingController,
 		AuxChanCloser:            auxChanCloser,
 		AuxTrafficShaper:         auxTrafficShaper,
 		AuxInvoiceManager:        auxInvoiceManager,
 		AuxSweeper:               auxSweeper,
+		AuxCommitmentRecorder:    auxCommitmentRecorder,
 		LogWriter:                cfg.LogWriter,
 		DatabaseConfig: &tap.DatabaseConfig{
-			RootKeyStore: tapdb.NewRootKeyStore(rksDB),
-			MintingStore: assetMintingStore,
-			AssetStore:   assetStore,
-			TapAddrBook:  tapdbAddrBook,
-			Multiverse:   multiverse,
-			FederationDB: federationDB,
+			RootKeyStore:    rootKeyStore,
+			FieldCipher:     fieldCipher,
+			MintingStore:    assetMintingStore,
+			AssetStore:      assetStore,
+			TapAddrBook:     tapdbAddrBook,
+			Multiverse:      multiverse,
+			FederationDB:    federationDB,
+			MetaUpdates:     metaUpdates,
+			Accounts:        accounts,
+			ChannelBackups:  channelBackups,
+			Freezes:         scriptKeyFreezes,
+			MetaSchemas:     metaSchemas,
+			MetaBlobs:       metaBlobs,
+			DBEventBus:      dbEventBus,
+			DBProofNotifier: dbProofNotifier,
 		},
 		Prometheus: cfg.Prometheus,
 	}, nil
 }
 
+// genHeaderVerifier creates the header verifier for the configured chain
+// backend. By default, block headers are verified through the connected lnd
+// node. Merkle proofs are always verified locally and don't need a chain
+// backend.
+func genHeaderVerifier(cfg *Config,
+	chainBridge tapgarden.ChainBridge) (proof.HeaderVerifier, error) {
+
+	if cfg.ChainSource.Backend == chainsource.BackendLnd {
+		return tapgarden.GenHeaderVerifier(
+			context.Background(), chainBridge,
+		), nil
+	}
+
+	headerSource, err := cfg.ChainSource.NewHeaderSource()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create chain source: %w", err)
+	}
+
+	return chainsource.NewHeaderVerifier(
+		context.Background(), headerSource, cfg.ChainSource.Timeout,
+	), nil
+}
+
+// genAnchorWallet creates the wallet that funds and signs the anchor
+// transactions of mints and transfers. By default, the wallet of the connected
+// lnd node is used. With the bitcoind backend, the asset anchor inputs are
+// still signed by the signer lnd node, as it derives their keys.
+func genAnchorWallet(cfg *Config, lndAnchor *tap.LndRpcWalletAnchor,
+	signerServices *lndclient.LndServices,
+	chainParams *chaincfg.Params) (tapfreighter.WalletAnchor, error) {
+
+	if cfg.AnchorWallet.Backend == anchorwallet.BackendLnd {
+		return lndAnchor, nil
+	}
+
+	anchorWallet, err := anchorwallet.NewBitcoindWalletAnchor(
+		cfg.AnchorWallet.Bitcoind, signerServices.WalletKit,
+		chainParams,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create anchor wallet: %w",
+			err)
+	}
+
+	return anchorWallet, nil
+}
+
+// ownedAssetProofs returns a function that fetches the proof files of all
+// unspent assets we own. It's used to record the current state of the node
+// when the replication log of a leader is still empty.
+func ownedAssetProofs(assetStore *tapdb.AssetStore,
+	proofArchive proof.Archiver) func(context.Context) ([]proof.Blob,
+	error) {
+
+	return func(ctx context.Context) ([]proof.Blob, error) {
+		assets, err := assetStore.FetchAllAssets(ctx, false, true, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch assets: %w",
+				err)
+		}
+
+		proofs := make([]proof.Blob, 0, len(assets))
+		for _, chainAsset := range assets {
+			blob, err := proofArchive.FetchProof(ctx, proof.Locator{
+				AssetID:   fn.Ptr(chainAsset.ID()),
+				ScriptKey: *chainAsset.ScriptKey.PubKey,
+				OutPoint:  &chainAsset.AnchorOutpoint,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch proof "+
+					"of asset %v: %w", chainAsset.ID(), err)
+			}
+
+			proofs = append(proofs, blob)
+		}
+
+		return proofs, nil
+	}
+}
+
+// knownAnchorSpend returns a function that checks whether a spend of one of our
+// anchor outputs was made by one of our own transfers. A spend by a
+// replacement of a pending transfer, for example one that was fee bumped
+// through RBF, is also known, as the chain porter picks up the replacement.
+func knownAnchorSpend(assetStore *tapdb.AssetStore) func(context.Context,
+	wire.OutPoint, chainhash.Hash) (bool, error) {
+
+	return func(ctx context.Context, spent wire.OutPoint,
+		txHash chainhash.Hash) (bool, error) {
+
+		parcels, err := assetStore.QueryParcels(ctx, &txHash, false)
+		if err != nil {
+			return false, err
+		}
+		if len(parcels) > 0 {
+			return true, nil
+		}
+
+		pendingParcels, err := assetStore.PendingParcels(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, parcel := range pendingParcels {
+			for _, input := range parcel.Inputs {
+				if input.OutPoint == spent {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// nonBuriedAssets returns a function that fetches all assets that aren't yet
+// buried deeper than the given height. Assets of which the anchor transaction
+// was re-organized out of the chain and wasn't mined again are also returned,
+// independent of the height they were previously confirmed at.
+func nonBuriedAssets(assetStore *tapdb.AssetStore,
+	reOrgLog *tapdb.ReOrgLog) func(context.Context, int32) (
+	[]*asset.ChainAsset, error) {
+
+	return func(ctx context.Context,
+		minHeight int32) ([]*asset.ChainAsset, error) {
+
+		assets, err := assetStore.FetchAllAssets(
+			ctx, false, true, &tapdb.AssetQueryFilters{
+				MinAnchorHeight: minHeight,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		orphanedTxs, err := reOrgLog.OrphanedAnchorTxs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		orphaned := make(map[chainhash.Hash]struct{}, len(orphanedTxs))
+		for _, orphanedTx := range orphanedTxs {
+			if int32(orphanedTx.BlockHeight) < minHeight {
+				orphaned[orphanedTx.Txid] = struct{}{}
+			}
+		}
+		if len(orphaned) == 0 {
+			return assets, nil
+		}
+
+		allAssets, err := assetStore.FetchAllAssets(
+			ctx, false, true, nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range allAssets {
+			if _, ok := orphaned[a.AnchorOutpoint.Hash]; ok {
+				assets = append(assets, a)
+			}
+		}
+
+		return assets, nil
+	}
+}
+
 // CreateServerFromConfig creates a new Taproot Asset server from the given CLI
 // config.
 func CreateServerFromConfig(cfg *Config, cfgLogger btclog.Logger,
@@ -628,9 +1336,39 @@ func CreateServerFromConfig(cfg *Config, cfgLogger btclog.Logger,
 
 	cfgLogger.Infof("lnd connection initialized")
 
+	// In remote signer mode, all script and internal keys are derived and
+	// signed for by the remote signer, so this daemon holds no private
+	// keys. The connected lnd node must be a watch-only node of the same
+	// signer, so it can sign for the anchor outputs.
+	signerServices := &lndConn.LndServices
+	if cfg.RemoteSigner.Enable {
+		cfgLogger.Infof("Attempting to establish connection to "+
+			"remote signer at %v...", cfg.RemoteSigner.RPCHost)
+
+		signerConn, err := getRemoteSigner(
+			cfg.ChainConf.Network, cfg.RemoteSigner,
+			shutdownInterceptor,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to remote "+
+				"signer: %w", err)
+		}
+
+		err = checkRemoteSigner(
+			&lndConn.LndServices, &signerConn.LndServices,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		signerServices = &signerConn.LndServices
+
+		cfgLogger.Infof("Remote signer connection initialized")
+	}
+
 	serverCfg, err := genServerConfig(
-		cfg, cfgLogger, &lndConn.LndServices, enableChannelFeatures,
-		mainErrChan,
+		cfg, cfgLogger, &lndConn.LndServices, signerServices,
+		enableChannelFeatures, mainErrChan,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate server config: %w",
@@ -662,13 +1400,56 @@ func CreateServerFromConfig(cfg *Config, cfgLogger btclog.Logger,
 	return tap.NewServer(&serverCfg.ChainParams, serverCfg), nil
 }
 
+// checkRemoteSigner makes sure the remote signer holds the keys of the
+// connected lnd wallet, by deriving the first Taproot Assets key on both of
+// them. Otherwise, the lnd wallet couldn't sign for the anchor outputs whose
+// internal keys were derived by the remote signer.
+func checkRemoteSigner(lndServices,
+	signerServices *lndclient.LndServices) error {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), defaultLndRPCTimeout,
+	)
+	defer cancel()
+
+	keyLoc := keychain.KeyLocator{
+		Family: asset.TaprootAssetsKeyFamily,
+	}
+	lndKey, err := lndServices.WalletKit.DeriveKey(ctx, &keyLoc)
+	if err != nil {
+		return fmt.Errorf("unable to derive key with lnd: %w", err)
+	}
+	signerKey, err := signerServices.WalletKit.DeriveKey(ctx, &keyLoc)
+	if err != nil {
+		return fmt.Errorf("unable to derive key with remote signer: "+
+			"%w", err)
+	}
+
+	if !lndKey.PubKey.IsEqual(signerKey.PubKey) {
+		return fmt.Errorf("remote signer doesn't hold the keys of the "+
+			"lnd wallet, derived %x instead of %x",
+			signerKey.PubKey.SerializeCompressed(),
+			lndKey.PubKey.SerializeCompressed())
+	}
+
+	return nil
+}
+
 // ConfigureSubServer updates a Taproot Asset server with the given CLI config.
 func ConfigureSubServer(srv *tap.Server, cfg *Config, cfgLogger btclog.Logger,
 	lndServices *lndclient.LndServices, litdIntegrated bool,
 	mainErrChan chan<- error) error {
 
+	// As a subserver, the keys are managed by the lnd node we're
+	// integrated with, which can itself use a remote signer.
+	if cfg.RemoteSigner.Enable {
+		return fmt.Errorf("remote signer mode is not supported when " +
+			"running as a subserver")
+	}
+
 	serverCfg, err := genServerConfig(
-		cfg, cfgLogger, lndServices, litdIntegrated, mainErrChan,
+		cfg, cfgLogger, lndServices, lndServices, litdIntegrated,
+		mainErrChan,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to generate server config: %w", err)
@@ -683,3 +1464,15 @@ func ConfigureSubServer(srv *tap.Server, cfg *Config, cfgLogger btclog.Logger,
 
 	return nil
 }
+
+// isMutatingRPC returns true if the given tapd RPC method requires a write
+// permission, which means it may change the state of the daemon.
+func isMutatingRPC(fullMethod string) bool {
+	for _, op := range perms.RequiredPermissions[fullMethod] {
+		if op.Action == "write" {
+			return true
+		}
+	}
+
+	return false
+}
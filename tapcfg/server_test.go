@@ -0,0 +1,92 @@
+package tapcfg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// stubWalletKit is a wallet kit that derives a fixed key or fails.
+type stubWalletKit struct {
+	lndclient.WalletKitClient
+
+	key *btcec.PublicKey
+	err error
+}
+
+// DeriveKey returns the stub's key or error.
+func (s *stubWalletKit) DeriveKey(_ context.Context,
+	loc *keychain.KeyLocator) (*keychain.KeyDescriptor, error) {
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &keychain.KeyDescriptor{
+		KeyLocator: *loc,
+		PubKey:     s.key,
+	}, nil
+}
+
+// TestCheckRemoteSigner tests that the startup check of the remote signer only
+// passes if the remote signer holds the keys of the lnd wallet.
+func TestCheckRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	lndKey := test.RandPubKey(t)
+	services := func(walletKit *stubWalletKit) *lndclient.LndServices {
+		return &lndclient.LndServices{
+			WalletKit: walletKit,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		lnd         *stubWalletKit
+		signer      *stubWalletKit
+		expectedErr string
+	}{{
+		name:   "same keys",
+		lnd:    &stubWalletKit{key: lndKey},
+		signer: &stubWalletKit{key: lndKey},
+	}, {
+		name:        "different keys",
+		lnd:         &stubWalletKit{key: lndKey},
+		signer:      &stubWalletKit{key: test.RandPubKey(t)},
+		expectedErr: "remote signer doesn't hold the keys",
+	}, {
+		name: "signer unreachable",
+		lnd:  &stubWalletKit{key: lndKey},
+		signer: &stubWalletKit{
+			err: errors.New("connection refused"),
+		},
+		expectedErr: "unable to derive key with remote signer",
+	}, {
+		name: "lnd unreachable",
+		lnd: &stubWalletKit{
+			err: errors.New("connection refused"),
+		},
+		signer:      &stubWalletKit{key: lndKey},
+		expectedErr: "unable to derive key with lnd",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkRemoteSigner(
+				services(tc.lnd), services(tc.signer),
+			)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
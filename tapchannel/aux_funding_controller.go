@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -42,6 +43,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/msgmux"
+	"github.com/lightningnetwork/lnd/routing/route"
 )
 
 const (
@@ -125,6 +127,11 @@ type OpenChanReq struct {
 	// open the channel with.
 	PeerPub btcec.PublicKey
 
+	// ZeroConf indicates that the channel should be usable before the
+	// funding transaction confirms. The remote peer must trust us to not
+	// double spend the funding transaction.
+	ZeroConf bool
+
 	// TempPID is the temporary channel ID to use for this channel.
 	TempPID funding.PendingChanID
 
@@ -250,6 +257,11 @@ type FundingControllerCfg struct {
 	// to fund asset channels.
 	FeatureBits FeatureBitVerifer
 
+	// ZeroConfPeers is the set of peers we trust to open zero-conf asset
+	// channels to us. Zero-conf asset channel requests from any other peer
+	// are rejected.
+	ZeroConfPeers fn.Set[route.Vertex]
+
 	// ErrChan is used to report errors back to the main server.
 	ErrChan chan<- error
 }
@@ -400,6 +412,8 @@ type pendingAssetFunding struct {
 
 	pushAmt btcutil.Amount
 
+	zeroConf bool
+
 	inputProofs []*proof.Proof
 
 	feeRate chainfee.SatPerVByte
@@ -701,13 +715,17 @@ func (f *fundingFlowIndex) fromMsg(chainParams *address.ChainParams,
 	return assetProof, assetFunding, nil
 }
 
-// fundVirtualPacket attempts to fund a new vPacket using the asset wallet to
-// find the asset inputs required to satisfy a funding request.
-func (f *FundingController) fundVirtualPacket(ctx context.Context,
-	assetID asset.ID, amt uint64) (*tapfreighter.FundedVPacket, error) {
+// fundVirtualPackets attempts to fund new vPackets using the asset wallet to
+// find the asset inputs required to satisfy a funding request. If the asset is
+// specified by its ID, a single vPacket is funded. If it is specified by its
+// group key, the asset pieces of the group are merged into the funding output,
+// which requires one vPacket per asset ID that is spent.
+func (f *FundingController) fundVirtualPackets(ctx context.Context,
+	specifier asset.Specifier,
+	amt uint64) ([]*tapfreighter.FundedVPacket, error) {
 
-	log.Infof("Funding new vPacket channel, asset_id=%v, amt=%v",
-		assetID, amt)
+	log.Infof("Funding new vPackets for channel, asset=%s, amt=%v",
+		specifier.String(), amt)
 
 	// Our funding script key will be the OP_TRUE addr that we'll use as
 	// the funding script on the asset level.
@@ -725,10 +743,21 @@ func (f *FundingController) fundVirtualPacket(ctx context.Context,
 		},
 	}
 
+	// The funding script key is shared by all asset channels, but it must
+	// never be used by any other subsystem, as we'd otherwise count
+	// channel funds as regular wallet funds or vice versa.
+	err := f.cfg.AddrBook.ReserveScriptKey(
+		ctx, fundingScriptKey.PubKey,
+		address.ScriptKeyOwnerChannelFunding,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reserve script key: %w", err)
+	}
+
 	// We'll also need to import the funding script key into the wallet so
 	// the asset will be materialized in the asset table and show up in the
 	// balance correctly.
-	err := f.cfg.AddrBook.InsertScriptKey(ctx, fundingScriptKey, true)
+	err = f.cfg.AddrBook.InsertScriptKey(ctx, fundingScriptKey, true)
 	if err != nil {
 		return nil, fmt.Errorf("unable to insert script key: %w", err)
 	}
@@ -738,11 +767,6 @@ func (f *FundingController) fundVirtualPacket(ctx context.Context,
 	// case our destination will just be the OP_TRUE tapscript that we use
 	// for the funding output.
 	pktTemplate := &tappsbt.VPacket{
-		Inputs: []*tappsbt.VInput{{
-			PrevID: asset.PrevID{
-				ID: assetID,
-			},
-		}},
 		Outputs: []*tappsbt.VOutput{{
 			Amount:            amt,
 			AssetVersion:      asset.V1,
@@ -753,6 +777,33 @@ func (f *FundingController) fundVirtualPacket(ctx context.Context,
 		ChainParams: &f.cfg.ChainParams,
 		Version:     tappsbt.V1,
 	}
+
+	// If we're funding with a group key, the asset wallet selects the
+	// asset pieces across all asset IDs of the group and creates a vPacket
+	// for each of them. This will derive anchor internal keys for us, but
+	// we'll overwrite the one of the funding output later on.
+	if !specifier.HasId() {
+		fundDesc := &tapsend.FundingDescriptor{
+			AssetSpecifier: specifier,
+			Amount:         amt,
+			CoinSelectType: tapsend.Bip86Only,
+			Reserver:       string(tapfreighter.ReserverChannel),
+		}
+		return f.cfg.AssetWallet.FundGroupPackets(
+			ctx, fundDesc, pktTemplate,
+		)
+	}
+
+	assetID, err := specifier.UnwrapIdOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	pktTemplate.Inputs = []*tappsbt.VInput{{
+		PrevID: asset.PrevID{
+			ID: assetID,
+		},
+	}}
 	fundDesc, err := tapsend.DescribeRecipients(
 		ctx, pktTemplate, f.cfg.GroupKeyIndex,
 	)
@@ -763,25 +814,39 @@ func (f *FundingController) fundVirtualPacket(ctx context.Context,
 	// Fund the packet. This will derive an anchor internal key for us, but
 	// we'll overwrite that later on.
 	fundDesc.CoinSelectType = tapsend.Bip86Only
-	return f.cfg.AssetWallet.FundPacket(ctx, fundDesc, pktTemplate)
+	fundDesc.Reserver = string(tapfreighter.ReserverChannel)
+	fundedPkt, err := f.cfg.AssetWallet.FundPacket(
+		ctx, fundDesc, pktTemplate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*tapfreighter.FundedVPacket{fundedPkt}, nil
 }
 
 // sendInputOwnershipProofs sends the input ownership proofs to the remote
 // party during the validation phase of the funding process.
 func (f *FundingController) sendInputOwnershipProofs(peerPub btcec.PublicKey,
-	vPkt *tappsbt.VPacket, fundingState *pendingAssetFunding) error {
+	vPkts []*tappsbt.VPacket, fundingState *pendingAssetFunding) error {
 
 	ctx, done := f.WithCtxQuit()
 	defer done()
 
+	vInputs := fn.FlatMap(
+		vPkts, func(vPkt *tappsbt.VPacket) []*tappsbt.VInput {
+			return vPkt.Inputs
+		},
+	)
+
 	log.Infof("Generating input ownership proofs for %v inputs",
-		len(vPkt.Inputs))
+		len(vInputs))
 
 	// For each of the inputs we selected, we'll create a new ownership
 	// proof for each of them. We'll send this to the peer, so they can
 	// verify that we actually own the inputs we're using to fund
 	// the channel.
-	for _, assetInput := range vPkt.Inputs {
+	for _, assetInput := range vInputs {
 		// First, we'll grab the proof for the asset input, then
 		// generate the challenge witness to place in the proof so it
 		challengeWitness, err := f.cfg.AssetWallet.SignOwnershipProof(
@@ -835,31 +900,37 @@ func (f *FundingController) sendInputOwnershipProofs(peerPub btcec.PublicKey,
 	}
 
 	// Now that we've sent the proofs for the input assets, we'll send them
-	// a fully signed asset funding output. We can send this safely as they
-	// can't actually broadcast this without our signed Bitcoin inputs.
-	signedInputs, err := f.cfg.AssetWallet.SignVirtualPacket(vPkt)
-	if err != nil {
-		return fmt.Errorf("unable to sign funding inputs: %w", err)
-	}
-	if len(signedInputs) != len(vPkt.Inputs) {
-		return fmt.Errorf("expected %v signed inputs, got %v",
-			len(vPkt.Inputs), len(signedInputs))
-	}
+	// a fully signed asset funding output for each of the vPackets. We can
+	// send this safely as they can't actually broadcast this without our
+	// signed Bitcoin inputs.
+	for idx, vPkt := range vPkts {
+		signedInputs, err := f.cfg.AssetWallet.SignVirtualPacket(vPkt)
+		if err != nil {
+			return fmt.Errorf("unable to sign funding inputs: %w",
+				err)
+		}
+		if len(signedInputs) != len(vPkt.Inputs) {
+			return fmt.Errorf("expected %v signed inputs, got %v",
+				len(vPkt.Inputs), len(signedInputs))
+		}
 
-	// We'll now send the signed inputs to the remote party.
-	//
-	// TODO(roasbeef): generalize for multi-asset
-	fundingAsset := vPkt.Outputs[0].Asset.Copy()
-	assetOutputMsg := cmsg.NewTxAssetOutputProof(
-		fundingState.pid, *fundingAsset, true,
-	)
+		// We'll now send the signed funding output to the remote
+		// party, letting them know whether more are coming.
+		fundingAsset := vPkt.Outputs[0].Asset.Copy()
+		assetOutputMsg := cmsg.NewTxAssetOutputProof(
+			fundingState.pid, *fundingAsset, idx == len(vPkts)-1,
+		)
 
-	log.Debugf("Sending TLV for funding asset output to remote party: %v",
-		limitSpewer.Sdump(fundingAsset))
+		log.Debugf("Sending TLV for funding asset output to remote "+
+			"party: %v", limitSpewer.Sdump(fundingAsset))
 
-	err = f.cfg.PeerMessenger.SendMessage(ctx, peerPub, assetOutputMsg)
-	if err != nil {
-		return fmt.Errorf("unable to send proof to peer: %w", err)
+		err = f.cfg.PeerMessenger.SendMessage(
+			ctx, peerPub, assetOutputMsg,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to send proof to peer: %w",
+				err)
+		}
 	}
 
 	return nil
@@ -879,35 +950,43 @@ func (f *FundingController) fundPsbt(
 	return f.cfg.ChainWallet.FundPsbt(ctx, psbtPkt, 1, feeRate, changeIndex)
 }
 
-// signAllVPackets takes the funding vPSBT, signs all the explicit transfer,
+// signAllVPackets takes the funding vPSBTs, signs all the explicit transfers,
 // and then derives all the passive transfers that also needs to be signed, and
 // then signs those. A single slice of all the passive and active assets signed
 // is returned.
 func (f *FundingController) signAllVPackets(ctx context.Context,
-	fundingVpkt *tapfreighter.FundedVPacket) ([]*tappsbt.VPacket,
+	fundingVpkts []*tapfreighter.FundedVPacket) ([]*tappsbt.VPacket,
 	[]*tappsbt.VPacket, []*tappsbt.VPacket, error) {
 
 	log.Infof("Signing all funding vPackets")
 
-	activePkt := fundingVpkt.VPacket
+	var (
+		activePkts       []*tappsbt.VPacket
+		inputCommitments = make(tappsbt.InputCommitments)
+	)
+	for _, fundingVpkt := range fundingVpkts {
+		activePkt := fundingVpkt.VPacket
 
-	encoded, err := tappsbt.Encode(activePkt)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to encode active "+
-			"packet: %w", err)
-	}
+		encoded, err := tappsbt.Encode(activePkt)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to encode "+
+				"active packet: %w", err)
+		}
 
-	log.Debugf("Active packet: %x", encoded)
+		log.Debugf("Active packet: %x", encoded)
 
-	_, err = f.cfg.AssetWallet.SignVirtualPacket(activePkt)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("unable to sign and commit "+
-			"virtual packet: %w", err)
+		_, err = f.cfg.AssetWallet.SignVirtualPacket(activePkt)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to sign and "+
+				"commit virtual packet: %w", err)
+		}
+
+		activePkts = append(activePkts, activePkt)
+		maps.Copy(inputCommitments, fundingVpkt.InputCommitments)
 	}
 
 	passivePkts, err := f.cfg.AssetWallet.CreatePassiveAssets(
-		ctx, []*tappsbt.VPacket{activePkt},
-		fundingVpkt.InputCommitments,
+		ctx, activePkts, inputCommitments,
 	)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to create passive "+
@@ -919,7 +998,7 @@ func (f *FundingController) signAllVPackets(ctx context.Context,
 			"assets: %w", err)
 	}
 
-	allPackets := append([]*tappsbt.VPacket{}, activePkt)
+	allPackets := append([]*tappsbt.VPacket{}, activePkts...)
 	allPackets = append(allPackets, passivePkts...)
 
 	err = tapsend.ValidateVPacketVersions(allPackets)
@@ -927,7 +1006,7 @@ func (f *FundingController) signAllVPackets(ctx context.Context,
 		return nil, nil, nil, fmt.Errorf("signed packets: %w", err)
 	}
 
-	return allPackets, []*tappsbt.VPacket{activePkt}, passivePkts, nil
+	return allPackets, activePkts, passivePkts, nil
 }
 
 // anchorVPackets anchors the vPackets to the funding PSBT, creating a
@@ -1062,7 +1141,7 @@ func (f *FundingController) sendAssetFundingCreated(ctx context.Context,
 // ultimately broadcasting the funding transaction.
 func (f *FundingController) completeChannelFunding(ctx context.Context,
 	fundingState *pendingAssetFunding,
-	fundedVpkt *tapfreighter.FundedVPacket) (*wire.OutPoint, error) {
+	fundedVpkts []*tapfreighter.FundedVPacket) (*wire.OutPoint, error) {
 
 	log.Debugf("Finalizing funding vPackets and PSBT...")
 
@@ -1072,6 +1151,7 @@ func (f *FundingController) completeChannelFunding(ctx context.Context,
 		ChanAmt:       100_000,
 		PushAmt:       fundingState.pushAmt,
 		PeerPub:       fundingState.peerPub,
+		ZeroConf:      fundingState.zeroConf,
 		TempPID:       fundingState.pid,
 		RemoteMaxHtlc: maxNumHTLCsPerParty,
 	}
@@ -1097,19 +1177,27 @@ func (f *FundingController) completeChannelFunding(ctx context.Context,
 		return nil, fmt.Errorf("unable to parse internal key: %w", err)
 	}
 
-	fundedVpkt.VPacket.Outputs[0].AnchorOutputBip32Derivation = nil
-	fundedVpkt.VPacket.Outputs[0].AnchorOutputTaprootBip32Derivation = nil
 	fundingInternalKeyDesc := keychain.KeyDescriptor{
 		PubKey: fundingInternalKey,
 	}
-	fundedVpkt.VPacket.Outputs[0].SetAnchorInternalKey(
-		fundingInternalKeyDesc, f.cfg.ChainParams.HDCoinType,
+	fundingVPkts := fn.Map(
+		fundedVpkts,
+		func(fundedVpkt *tapfreighter.FundedVPacket) *tappsbt.VPacket {
+			return fundedVpkt.VPacket
+		},
 	)
+	for _, vPkt := range fundingVPkts {
+		fundingOut := vPkt.Outputs[0]
+		fundingOut.AnchorOutputBip32Derivation = nil
+		fundingOut.AnchorOutputTaprootBip32Derivation = nil
+		fundingOut.SetAnchorInternalKey(
+			fundingInternalKeyDesc, f.cfg.ChainParams.HDCoinType,
+		)
+	}
 
 	// Given the asset inputs selected in the prior step, we'll now
 	// construct a template packet that maps our asset inputs to actual
 	// inputs in the PSBT packet.
-	fundingVPkts := []*tappsbt.VPacket{fundedVpkt.VPacket}
 	fundingPsbt, err := tapsend.PrepareAnchoringTemplate(fundingVPkts)
 	if err != nil {
 		return nil, err
@@ -1148,7 +1236,7 @@ func (f *FundingController) completeChannelFunding(ctx context.Context,
 	// With the PSBT fully funded, we'll now sign all the vPackets before
 	// we finalize anchor them concretely into our PSBt.
 	signedPkts, activePkts, passivePkts, err := f.signAllVPackets(
-		ctx, fundedVpkt,
+		ctx, fundedVpkts,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to sign vPackets: %w", err)
@@ -1449,6 +1537,7 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 		initiator:              true,
 		amt:                    fundReq.AssetAmount,
 		pushAmt:                fundReq.PushAmount,
+		zeroConf:               fundReq.ZeroConf,
 		feeRate:                fundReq.FeeRate,
 		fundingAckChan:         make(chan bool, 1),
 		fundingFinalizedSignal: make(chan struct{}),
@@ -1457,21 +1546,30 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 	fundingFlows[tempPID] = fundingState
 
 	// With our initial state created, we'll now attempt to fund the
-	// channel on the TAP level with a vPacket.
-	fundingVpkt, err := f.fundVirtualPacket(
-		fundReq.ctx, fundReq.AssetID,
-		fundReq.AssetAmount,
+	// channel on the TAP level with one or more vPackets.
+	fundingVpkts, err := f.fundVirtualPackets(
+		fundReq.ctx, fundReq.AssetSpecifier, fundReq.AssetAmount,
 	)
 	if err != nil {
-		return fmt.Errorf("unable to fund vPacket: %w", err)
+		return fmt.Errorf("unable to fund vPackets: %w", err)
 	}
+	fundingVPkts := fn.Map(
+		fundingVpkts,
+		func(fundingVpkt *tapfreighter.FundedVPacket) *tappsbt.VPacket {
+			return fundingVpkt.VPacket
+		},
+	)
 
-	// Now that we've funded the vPk, keep track of the set of inputs we
+	// Now that we've funded the vPkts, keep track of the set of inputs we
 	// locked to ensure we unlock them later.
-	fundingState.lockedAssetInputs = fn.Map(
-		fundingVpkt.VPacket.Inputs,
-		func(in *tappsbt.VInput) wire.OutPoint {
-			return in.PrevID.OutPoint
+	fundingState.lockedAssetInputs = fn.FlatMap(
+		fundingVPkts, func(vPkt *tappsbt.VPacket) []wire.OutPoint {
+			return fn.Map(
+				vPkt.Inputs,
+				func(in *tappsbt.VInput) wire.OutPoint {
+					return in.PrevID.OutPoint
+				},
+			)
 		},
 	)
 
@@ -1503,15 +1601,9 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 	// we allow to be commited to a single channel. This is to make sure we
 	// have a decent number of HTLCs available. See Godoc of maxNumAssetIDs
 	// for more information.
-	//
-	// TODO(guggero): This following code is obviously wrong and needs to be
-	// changed when we support committing fungible assets into a channel. To
-	// avoid this TODO from being overlooked, we add a dummy implementation
-	// with a condition that currently will never be true (since there's
-	// only a single vPacket being selected currently anyway).
 	assetIDSet := lfn.NewSet[asset.ID]()
-	for _, out := range fundingVpkt.VPacket.Outputs {
-		assetIDSet.Add(out.Asset.ID())
+	for _, vPkt := range fundingVPkts {
+		assetIDSet.Add(vPkt.Outputs[0].Asset.ID())
 	}
 	if assetIDSet.Size() > maxNumAssetIDs {
 		return fmt.Errorf("too many different asset IDs in channel "+
@@ -1522,18 +1614,22 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 	// Now that we know the final funding asset root along with the splits,
 	// we can derive the tapscript root that'll be used alongside the
 	// internal key (which we'll only learn from lnd later as we finalize
-	// the funding PSBT).
-	fundingOutput := fundingVpkt.VPacket.Outputs[0]
+	// the funding PSBT). If we're merging multiple asset pieces, the
+	// funding outputs of all vPackets are committed to the same root.
 	fundingCommitVersion, err := tappsbt.CommitmentVersion(
-		fundingVpkt.VPacket.Version,
+		fundingVPkts[0].Version,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create commitment: %w", err)
 	}
 
+	fundingAssets := fn.Map(
+		fundingVPkts, func(vPkt *tappsbt.VPacket) *asset.Asset {
+			return vPkt.Outputs[0].Asset.Copy()
+		},
+	)
 	fundingCommitment, err := commitment.FromAssets(
-		fundingCommitVersion,
-		fundingOutput.Asset.Copy(),
+		fundingCommitVersion, fundingAssets...,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create commitment: %w", err)
@@ -1550,7 +1646,7 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 	// need to derive then send a series of ownership
 	// proofs to the remote party.
 	err = f.sendInputOwnershipProofs(
-		fundReq.PeerPub, fundingVpkt.VPacket, fundingState,
+		fundReq.PeerPub, fundingVPkts, fundingState,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to send input ownership "+
@@ -1597,7 +1693,7 @@ func (f *FundingController) processFundingReq(fundingFlows fundingFlowIndex,
 		}
 
 		chanPoint, err := f.completeChannelFunding(
-			fundReq.ctx, fundingState, fundingVpkt,
+			fundReq.ctx, fundingState, fundingVpkts,
 		)
 		if err != nil {
 			// If anything went wrong during the funding process,
@@ -1795,11 +1891,36 @@ func (f *FundingController) channelAcceptor(_ context.Context,
 		}, nil
 	}
 
+	// A zero-conf channel can be used before its funding transaction
+	// confirms, so we need to trust the initiator to not double spend it.
+	// We also require the scid-alias channel type, which makes sure the
+	// channel keeps its alias as the base SCID after confirmation. Any
+	// RFQ quote SCID aliases that were mapped to that base SCID therefore
+	// stay valid.
+	if req.WantsZeroConf {
+		switch {
+		case !f.cfg.ZeroConfPeers.Contains(req.NodePubkey):
+			return &lndclient.AcceptorResponse{
+				Accept: false,
+				Error: "zero-conf asset channels are not " +
+					"accepted from this peer",
+			}, nil
+
+		case !req.WantsSCIDAlias:
+			return &lndclient.AcceptorResponse{
+				Accept: false,
+				Error: "zero-conf asset channels require the " +
+					"scid-alias channel type",
+			}, nil
+		}
+	}
+
 	// Everything looks good, we can now set our own max HTLC limit we'll
 	// observe for this channel.
 	return &lndclient.AcceptorResponse{
 		Accept:       true,
 		MaxHtlcCount: maxNumHTLCsPerParty,
+		ZeroConf:     req.WantsZeroConf,
 	}, nil
 }
 
@@ -1877,8 +1998,10 @@ type FundReq struct {
 	// TODO(roasbeef): also need p2p address?
 	PeerPub btcec.PublicKey
 
-	// AssetID is the asset that we're funding the channel with.
-	AssetID asset.ID
+	// AssetSpecifier is the asset that we're funding the channel with. If
+	// it only specifies a group key, asset pieces of different asset IDs
+	// within the group are merged into the funding output.
+	AssetSpecifier asset.Specifier
 
 	// AssetAmount is the amount of the asset that we're funding the channel
 	// with.
@@ -1891,6 +2014,11 @@ type FundReq struct {
 	// party.
 	PushAmount btcutil.Amount
 
+	// ZeroConf indicates that the channel should be usable before the
+	// funding transaction confirms. The remote peer must trust us to not
+	// double spend the funding transaction.
+	ZeroConf bool
+
 	ctx      context.Context
 	respChan chan *wire.OutPoint
 	errChan  chan error
@@ -0,0 +1,83 @@
+package tapchannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChannelAcceptorZeroConf tests that zero-conf asset channels are only
+// accepted from trusted peers.
+func TestChannelAcceptorZeroConf(t *testing.T) {
+	t.Parallel()
+
+	trustedPeer := route.Vertex{2, 1}
+	otherPeer := route.Vertex{3, 2}
+
+	f := NewFundingController(FundingControllerCfg{
+		ZeroConfPeers: fn.NewSet(trustedPeer),
+	})
+
+	assetChanType := lnwallet.CommitmentTypeSimpleTaprootOverlay
+
+	testCases := []struct {
+		name         string
+		req          lndclient.AcceptorRequest
+		accept       bool
+		wantZeroConf bool
+	}{{
+		name: "regular asset channel",
+		req: lndclient.AcceptorRequest{
+			NodePubkey:     otherPeer,
+			CommitmentType: &assetChanType,
+		},
+		accept: true,
+	}, {
+		name: "zero-conf asset channel from trusted peer",
+		req: lndclient.AcceptorRequest{
+			NodePubkey:     trustedPeer,
+			CommitmentType: &assetChanType,
+			WantsZeroConf:  true,
+			WantsSCIDAlias: true,
+		},
+		accept:       true,
+		wantZeroConf: true,
+	}, {
+		name: "zero-conf asset channel from untrusted peer",
+		req: lndclient.AcceptorRequest{
+			NodePubkey:     otherPeer,
+			CommitmentType: &assetChanType,
+			WantsZeroConf:  true,
+			WantsSCIDAlias: true,
+		},
+		accept: false,
+	}, {
+		name: "zero-conf asset channel without scid alias",
+		req: lndclient.AcceptorRequest{
+			NodePubkey:     trustedPeer,
+			CommitmentType: &assetChanType,
+			WantsZeroConf:  true,
+		},
+		accept: false,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := f.channelAcceptor(
+				context.Background(), &tc.req,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.accept, resp.Accept)
+			require.Equal(t, tc.wantZeroConf, resp.ZeroConf)
+
+			if !tc.accept {
+				require.NotEmpty(t, resp.Error)
+			}
+		})
+	}
+}
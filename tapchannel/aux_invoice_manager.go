@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/address"
@@ -47,6 +48,11 @@ type RfqManager interface {
 // tapchannel.RfqManager interface.
 var _ RfqManager = (*rfq.Manager)(nil)
 
+// invoiceRoundingMode is the rounding mode used when converting the asset
+// amounts of HTLCs to milli-satoshis. Rounding down makes sure we never
+// consider an invoice paid with less than its value.
+const invoiceRoundingMode = rfqmath.RoundFloor
+
 // RfqLookup is an interface that abstracts away the process of performing
 // a lookup to the current set of existing RFQs.
 type RfqLookup interface {
@@ -80,6 +86,18 @@ type AuxInvoiceManager struct {
 
 	cfg *InvoiceManagerConfig
 
+	// htlcTracker keeps track of the HTLC modification requests that are
+	// being processed.
+	htlcTracker htlcModifierTracker
+
+	// numModified, numCancelled, totalAssetUnits and totalConvertedMsat
+	// count the outcomes of the processed HTLC modification requests. They
+	// are reported as part of the HTLC modifier stats.
+	numModified        atomic.Uint64
+	numCancelled       atomic.Uint64
+	totalAssetUnits    atomic.Uint64
+	totalConvertedMsat atomic.Uint64
+
 	// ContextGuard provides a wait group and main quit channel that can be
 	// used to create guarded contexts.
 	*fn.ContextGuard
@@ -127,10 +145,39 @@ func (s *AuxInvoiceManager) Start() error {
 // handleInvoiceAccept is the handler that will be called for each invoice that
 // is accepted. It will intercept the HTLCs that attempt to settle the invoice
 // and modify them if necessary.
+//
+// NOTE: lnd holds its invoice registry lock while waiting for our response, so
+// requests are never processed concurrently and lnd holds off on any further
+// HTLCs until we've responded.
 func (s *AuxInvoiceManager) handleInvoiceAccept(_ context.Context,
 	req lndclient.InvoiceHtlcModifyRequest) (
 	*lndclient.InvoiceHtlcModifyResponse, error) {
 
+	processingStart := s.htlcTracker.start()
+	defer s.htlcTracker.done(processingStart)
+
+	return s.modifyHtlc(req)
+}
+
+// HtlcModifierStats returns a snapshot of the state and statistics of the HTLC
+// modifier.
+func (s *AuxInvoiceManager) HtlcModifierStats() HtlcModifierStats {
+	stats := s.htlcTracker.Stats()
+	stats.NumModified = s.numModified.Load()
+	stats.NumCancelled = s.numCancelled.Load()
+	stats.TotalAssetUnits = s.totalAssetUnits.Load()
+	stats.TotalConvertedMsat = lnwire.MilliSatoshi(
+		s.totalConvertedMsat.Load(),
+	)
+
+	return stats
+}
+
+// modifyHtlc intercepts an HTLC that attempts to settle an invoice and modifies
+// it if necessary.
+func (s *AuxInvoiceManager) modifyHtlc(req lndclient.InvoiceHtlcModifyRequest) (
+	*lndclient.InvoiceHtlcModifyResponse, error) {
+
 	// By default, we'll return the same amount that was requested.
 	resp := &lndclient.InvoiceHtlcModifyResponse{
 		CircuitKey: req.CircuitKey,
@@ -162,6 +209,7 @@ func (s *AuxInvoiceManager) handleInvoiceAccept(_ context.Context,
 		// TODO(george): Strict-forwarding could be configurable?
 		if isAssetInvoice(req.Invoice, s) {
 			resp.CancelSet = true
+			s.numCancelled.Add(1)
 		}
 
 		return resp, nil
@@ -198,7 +246,13 @@ func (s *AuxInvoiceManager) handleInvoiceAccept(_ context.Context,
 
 	htlcAssetAmount := htlc.Amounts.Val.Sum()
 	totalAssetAmt := rfqmath.NewBigIntFixedPoint(htlcAssetAmount, 0)
-	resp.AmtPaid = rfqmath.UnitsToMilliSatoshi(totalAssetAmt, *assetRate)
+	resp.AmtPaid, err = rfqmath.UnitsToMilliSatoshiRounded(
+		totalAssetAmt, *assetRate, invoiceRoundingMode,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert HTLC asset "+
+			"amount: %w", err)
+	}
 
 	// If all previously accepted HTLC amounts plus the intercepted HTLC
 	// amount together add up to just about the asset invoice amount, then
@@ -215,9 +269,13 @@ func (s *AuxInvoiceManager) handleInvoiceAccept(_ context.Context,
 	marginAssetUnits := rfqmath.NewBigIntFixedPoint(
 		allowedMarginAssetUnits, 0,
 	)
-	allowedMarginMSat := rfqmath.UnitsToMilliSatoshi(
-		marginAssetUnits, *assetRate,
+	allowedMarginMSat, err := rfqmath.UnitsToMilliSatoshiRounded(
+		marginAssetUnits, *assetRate, invoiceRoundingMode,
 	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert error margin: %w",
+			err)
+	}
 
 	// If the sum of the accepted HTLCs plus the current HTLC amount plus
 	// the error margin is greater than the invoice amount, we'll accept it.
@@ -237,6 +295,10 @@ func (s *AuxInvoiceManager) handleInvoiceAccept(_ context.Context,
 		resp.AmtPaid = invoiceValue - acceptedHtlcSum
 	}
 
+	s.numModified.Add(1)
+	s.totalAssetUnits.Add(htlcAssetAmount)
+	s.totalConvertedMsat.Add(uint64(resp.AmtPaid))
+
 	return resp, nil
 }
 
@@ -329,6 +391,20 @@ func isAssetInvoice(invoice *lnrpc.Invoice, rfqLookup RfqLookup) bool {
 	return false
 }
 
+// Drain waits for the HTLC modification requests that are being processed to
+// complete, or until the context is done. Requests that lnd sends in the
+// meantime are still processed, as rejecting them would fail the HTLCs.
+func (s *AuxInvoiceManager) Drain(ctx context.Context) error {
+	log.Info("Draining aux invoice manager")
+
+	if err := s.htlcTracker.waitIdle(ctx); err != nil {
+		return fmt.Errorf("HTLC modifications still in flight: %w",
+			err)
+	}
+
+	return nil
+}
+
 // Stop signals for an aux invoice manager to gracefully exit.
 func (s *AuxInvoiceManager) Stop() error {
 	var stopErr error
@@ -430,6 +430,16 @@ func TestAuxInvoiceManager(t *testing.T) {
 		case <-time.After(testTimeout):
 			t.Fail()
 		}
+
+		// Every response that cancelled the HTLC set must be counted.
+		var numCancelled uint64
+		for _, resp := range testCase.responses {
+			if resp.CancelSet {
+				numCancelled++
+			}
+		}
+		stats := manager.HtlcModifierStats()
+		require.Equal(t, numCancelled, stats.NumCancelled)
 	}
 }
 
@@ -0,0 +1,129 @@
+package tapchannel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// HtlcModifierStats is a snapshot of the state and the accumulated statistics
+// of the invoice HTLC modifier.
+type HtlcModifierStats struct {
+	// InFlight is the number of requests that are currently being
+	// processed. lnd holds its invoice registry lock while waiting for our
+	// response, so this is never more than one with the lnd versions we
+	// currently support.
+	InFlight int
+
+	// NumProcessed is the total number of requests that were processed.
+	NumProcessed uint64
+
+	// TotalProcessingTime is the accumulated time it took to process the
+	// processed requests.
+	TotalProcessingTime time.Duration
+
+	// MaxProcessingTime is the longest time it took to process a single
+	// request.
+	MaxProcessingTime time.Duration
+
+	// NumModified is the total number of asset HTLCs whose amount was
+	// converted from asset units to milli-satoshis.
+	NumModified uint64
+
+	// NumCancelled is the total number of HTLCs whose invoice HTLC set was
+	// cancelled because they didn't carry the assets the invoice asked
+	// for.
+	NumCancelled uint64
+
+	// TotalAssetUnits is the sum of the asset units of the converted
+	// asset HTLCs.
+	TotalAssetUnits uint64
+
+	// TotalConvertedMsat is the sum of the milli-satoshi amounts the asset
+	// HTLCs were converted to.
+	TotalConvertedMsat lnwire.MilliSatoshi
+}
+
+// htlcModifierTracker keeps track of the invoice HTLC modification requests
+// that are being processed and of the time it took to process them.
+type htlcModifierTracker struct {
+	mu sync.Mutex
+
+	// inFlight is the number of requests that are currently being
+	// processed.
+	inFlight int
+
+	// stats holds the accumulated statistics of the processed requests.
+	stats HtlcModifierStats
+
+	// idleWaiters are closed once no more requests are being processed.
+	idleWaiters []chan struct{}
+}
+
+// start marks the beginning of processing a request and returns the time it
+// started at.
+func (t *htlcModifierTracker) start() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight++
+
+	return time.Now()
+}
+
+// done marks a request that was started at the given time as processed.
+func (t *htlcModifierTracker) done(processingStart time.Time) {
+	processingTime := time.Since(processingStart)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.NumProcessed++
+	t.stats.TotalProcessingTime += processingTime
+	if processingTime > t.stats.MaxProcessingTime {
+		t.stats.MaxProcessingTime = processingTime
+	}
+
+	t.inFlight--
+	if t.inFlight == 0 {
+		for _, waiter := range t.idleWaiters {
+			close(waiter)
+		}
+		t.idleWaiters = nil
+	}
+}
+
+// waitIdle blocks until all requests that are being processed were processed,
+// or until the given context is done.
+func (t *htlcModifierTracker) waitIdle(ctx context.Context) error {
+	t.mu.Lock()
+	if t.inFlight == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+
+	idle := make(chan struct{})
+	t.idleWaiters = append(t.idleWaiters, idle)
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the current state and statistics.
+func (t *htlcModifierTracker) Stats() HtlcModifierStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.InFlight = t.inFlight
+
+	return stats
+}
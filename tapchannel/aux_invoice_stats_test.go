@@ -0,0 +1,64 @@
+package tapchannel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestHtlcModifierTrackerWaitIdle tests that waiting for the HTLC modifier to
+// become idle only returns once all requests in flight were processed.
+func TestHtlcModifierTrackerWaitIdle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tracker := &htlcModifierTracker{}
+
+	// An idle tracker returns right away.
+	require.NoError(t, tracker.waitIdle(ctx))
+
+	first := tracker.start()
+	second := tracker.start()
+	require.Equal(t, 2, tracker.Stats().InFlight)
+
+	// With requests in flight, waiting times out.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(
+		t, tracker.waitIdle(timeoutCtx), context.DeadlineExceeded,
+	)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- tracker.waitIdle(ctx)
+	}()
+
+	// Processing only one of the requests doesn't release the waiter.
+	tracker.done(first)
+
+	select {
+	case <-errChan:
+		t.Fatalf("waiter released with request in flight")
+
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	tracker.done(second)
+
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+
+	case <-time.After(DefaultTimeout):
+		t.Fatalf("waiter wasn't released")
+	}
+
+	stats := tracker.Stats()
+	require.Zero(t, stats.InFlight)
+	require.EqualValues(t, 2, stats.NumProcessed)
+	require.GreaterOrEqual(
+		t, stats.TotalProcessingTime, stats.MaxProcessingTime,
+	)
+}
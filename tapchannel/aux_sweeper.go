@@ -3,6 +3,7 @@ package tapchannel
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
@@ -129,6 +130,12 @@ type AuxSweeperCfg struct {
 
 	// ChainBridge is used to fetch blocks from the main chain.
 	ChainBridge tapgarden.ChainBridge
+
+	// ChannelBackups is an optional store of restored asset channel
+	// backups. It is used to sweep the assets of channels that lnd lost
+	// the custom channel data of, for example because they were restored
+	// from a static channel backup.
+	ChannelBackups ChannelBackupStore
 }
 
 // AuxSweeper is used to sweep funds from a commitment transaction that has
@@ -1624,6 +1631,56 @@ func (a *AuxSweeper) importCommitTx(req lnwallet.ResolutionReq,
 // errNoPayHash is an error returned when no payment hash is provided.
 var errNoPayHash = fmt.Errorf("no payment hash provided")
 
+// resolutionReqFromBackup fills in the commit and funding blobs of the given
+// resolution request from the restored asset channel backup of the channel, if
+// one exists. The request is returned unchanged otherwise.
+func (a *AuxSweeper) resolutionReqFromBackup(
+	req lnwallet.ResolutionReq) lnwallet.ResolutionReq {
+
+	if a.cfg.ChannelBackups == nil {
+		return req
+	}
+
+	ctx := context.Background()
+	backup, err := a.cfg.ChannelBackups.FetchChannelBackup(
+		ctx, req.ChanPoint,
+	)
+	switch {
+	case errors.Is(err, cmsg.ErrChannelBackupNotFound):
+		return req
+
+	case err != nil:
+		log.Errorf("Unable to fetch channel backup of %v: %v",
+			req.ChanPoint, err)
+		return req
+	}
+
+	var commitment *cmsg.Commitment
+	switch req.Type {
+	// The backup only contains the latest state of each side, so we can't
+	// sweep revoked commitments with it.
+	case input.TaprootCommitmentRevoke:
+		return req
+
+	case input.TaprootRemoteCommitSpend,
+		input.TaprootHtlcOfferedRemoteTimeout,
+		input.TaprootHtlcAcceptedRemoteSuccess:
+
+		commitment = backup.RemoteCommitment
+
+	default:
+		commitment = backup.LocalCommitment
+	}
+
+	log.Infof("Using restored channel backup to resolve contract_type=%v, "+
+		"chan_point=%v", req.Type, req.ChanPoint)
+
+	req.CommitBlob = lfn.Some[tlv.Blob](commitment.Bytes())
+	req.FundingBlob = lfn.Some[tlv.Blob](backup.Funding.Bytes())
+
+	return req
+}
+
 // resolveContract takes in a resolution request and resolves it by creating a
 // serialized resolution blob that contains the virtual packets needed to sweep
 // the funds from the contract.
@@ -1632,6 +1689,12 @@ func (a *AuxSweeper) resolveContract(
 
 	type returnType = tlv.Blob
 
+	// If lnd doesn't know the commit blob, the channel may have been
+	// restored from a backup, in which case we use the restored state.
+	if req.CommitBlob.IsNone() {
+		req = a.resolutionReqFromBackup(req)
+	}
+
 	// If there's no commit blob, then there's nothing to resolve.
 	if req.CommitBlob.IsNone() {
 		return lfn.Err[tlv.Blob](nil)
@@ -28,6 +28,12 @@ var (
 	)
 )
 
+// shaperRoundingMode is the rounding mode used when converting between asset
+// units and milli-satoshis. Rounding down makes sure we never report more
+// bandwidth than the local asset balance covers, and never send more asset
+// units than the HTLC amount is worth.
+const shaperRoundingMode = rfqmath.RoundFloor
+
 // TrafficShaperConfig defines the configuration for the auxiliary traffic
 // shaper.
 type TrafficShaperConfig struct {
@@ -223,9 +229,13 @@ func (s *AuxTrafficShaper) PaymentBandwidth(htlcBlob,
 	// Calculate the local available balance in the local asset unit,
 	// expressed in milli-satoshis.
 	localBalanceFp := rfqmath.NewBigIntFixedPoint(localBalance, 0)
-	availableBalanceMsat := rfqmath.UnitsToMilliSatoshi(
-		localBalanceFp, quote.AssetRate.Rate,
+	availableBalanceMsat, err := rfqmath.UnitsToMilliSatoshiRounded(
+		localBalanceFp, quote.AssetRate.Rate, shaperRoundingMode,
 	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert local balance: %w",
+			err)
+	}
 
 	// At this point we have acquired what we need to express the asset
 	// bandwidth expressed in satoshis. Before we return the result, we need
@@ -282,10 +292,13 @@ func (s *AuxTrafficShaper) ProduceHtlcExtraData(totalAmount lnwire.MilliSatoshi,
 	// convert the BTC amount originally intended to be sent out into the
 	// corresponding number of assets, then reduce the number of satoshis of
 	// the HTLC to the bare minimum that can be materialized on chain.
-	numAssetUnitsFp := rfqmath.MilliSatoshiToUnits(
-		totalAmount, quote.AssetRate.Rate,
+	numAssetUnits, err := rfqmath.MilliSatoshiToUnitsRounded(
+		totalAmount, quote.AssetRate.Rate, shaperRoundingMode,
 	)
-	numAssetUnits := numAssetUnitsFp.ScaleTo(0).ToUint64()
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to convert HTLC amount: %w",
+			err)
+	}
 
 	// We now know how many units we need. We take the asset ID from the
 	// RFQ so the recipient can match it back to the quote.
@@ -0,0 +1,50 @@
+package tapchannel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
+)
+
+// ChannelBackupStore is used to persist and look up the asset channel backups
+// that were restored after a data loss.
+type ChannelBackupStore interface {
+	// StoreChannelBackups persists the given channel backups, replacing any
+	// existing backup of the same channel.
+	StoreChannelBackups(ctx context.Context,
+		backups []*cmsg.ChannelBackup) error
+
+	// FetchChannelBackup returns the backup of the channel with the given
+	// funding outpoint. cmsg.ErrChannelBackupNotFound is returned if no
+	// backup exists for the channel.
+	FetchChannelBackup(ctx context.Context,
+		chanPoint wire.OutPoint) (*cmsg.ChannelBackup, error)
+}
+
+// NewChannelBackup creates an asset channel backup from the recorded state of
+// a channel. An error is returned if the funding blob or the current
+// commitment of either side of the channel hasn't been recorded yet.
+func NewChannelBackup(c ChannelCommitments) (*cmsg.ChannelBackup, error) {
+	switch {
+	case c.Funding == nil:
+		return nil, fmt.Errorf("funding state of channel %v not known",
+			c.ChannelPoint)
+
+	case c.Local.Current == nil:
+		return nil, fmt.Errorf("local commitment of channel %v not "+
+			"known", c.ChannelPoint)
+
+	case c.Remote.Current == nil:
+		return nil, fmt.Errorf("remote commitment of channel %v not "+
+			"known", c.ChannelPoint)
+	}
+
+	return &cmsg.ChannelBackup{
+		ChanPoint:        c.ChannelPoint,
+		Funding:          c.Funding,
+		LocalCommitment:  c.Local.Current,
+		RemoteCommitment: c.Remote.Current,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package tapchannel
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// RecordedCommitments holds the two most recent asset commitments of one side
+// of a channel's commitment chain.
+type RecordedCommitments struct {
+	// Current is the latest asset commitment that was signed or received
+	// for this side of the channel.
+	Current *cmsg.Commitment
+
+	// Previous is the asset commitment that preceded the current one. This
+	// is nil if the previous state isn't known, for example because the
+	// channel was only loaded after a restart.
+	Previous *cmsg.Commitment
+
+	// UpdatedAt is the time the current commitment was recorded.
+	UpdatedAt time.Time
+}
+
+// ChannelCommitments holds the recorded asset commitments of both sides of a
+// single asset channel.
+type ChannelCommitments struct {
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// Funding is the funding blob of the channel. This is nil if lnd
+	// hasn't handed it to us yet.
+	Funding *cmsg.OpenChannel
+
+	// Local is the state of our own commitment chain.
+	Local RecordedCommitments
+
+	// Remote is the state of the remote party's commitment chain.
+	Remote RecordedCommitments
+}
+
+// CommitmentRecorder keeps an in-memory record of the most recent asset
+// commitment allocations of each asset channel. The commitments are recorded
+// as lnd hands them to the aux leaf store, which only happens whenever a new
+// commitment is signed or received. The record is meant for inspecting the
+// channel state when investigating disputes and for exporting asset channel
+// backups. It is not persisted.
+type CommitmentRecorder struct {
+	clock clock.Clock
+
+	mu       sync.RWMutex
+	channels map[wire.OutPoint]*ChannelCommitments
+}
+
+// NewCommitmentRecorder creates a new, empty commitment recorder.
+func NewCommitmentRecorder(clock clock.Clock) *CommitmentRecorder {
+	return &CommitmentRecorder{
+		clock:    clock,
+		channels: make(map[wire.OutPoint]*ChannelCommitments),
+	}
+}
+
+// channelFor returns the record of the given channel, creating an empty record
+// if none exists yet.
+//
+// NOTE: The caller must hold the write lock.
+func (r *CommitmentRecorder) channelFor(
+	chanPoint wire.OutPoint) *ChannelCommitments {
+
+	channel, ok := r.channels[chanPoint]
+	if !ok {
+		channel = &ChannelCommitments{
+			ChannelPoint: chanPoint,
+		}
+		r.channels[chanPoint] = channel
+	}
+
+	return channel
+}
+
+// commitmentsFor returns the recorded commitments of the given side of the
+// given channel, creating an empty record if none exists yet.
+//
+// NOTE: The caller must hold the write lock.
+func (r *CommitmentRecorder) commitmentsFor(chanPoint wire.OutPoint,
+	whoseCommit lntypes.ChannelParty) *RecordedCommitments {
+
+	channel := r.channelFor(chanPoint)
+	if whoseCommit.IsLocal() {
+		return &channel.Local
+	}
+
+	return &channel.Remote
+}
+
+// RecordTransition records a new asset commitment of the given side of a
+// channel, along with the commitment it was derived from.
+func (r *CommitmentRecorder) RecordTransition(chanPoint wire.OutPoint,
+	whoseCommit lntypes.ChannelParty, prevBlob, newBlob tlv.Blob) error {
+
+	prevCommit, err := cmsg.DecodeCommitment(prevBlob)
+	if err != nil {
+		return fmt.Errorf("unable to decode previous commitment: %w",
+			err)
+	}
+
+	newCommit, err := cmsg.DecodeCommitment(newBlob)
+	if err != nil {
+		return fmt.Errorf("unable to decode new commitment: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commitments := r.commitmentsFor(chanPoint, whoseCommit)
+	commitments.Previous = prevCommit
+	commitments.Current = newCommit
+	commitments.UpdatedAt = r.clock.Now()
+
+	return nil
+}
+
+// RecordCommitment records an existing asset commitment of the given side of a
+// channel. This is used to learn about the state of channels that were loaded
+// by lnd before any new commitment was created, so it never overwrites a
+// commitment that was already recorded.
+func (r *CommitmentRecorder) RecordCommitment(chanPoint wire.OutPoint,
+	whoseCommit lntypes.ChannelParty, blob tlv.Blob) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commitments := r.commitmentsFor(chanPoint, whoseCommit)
+	if commitments.Current != nil {
+		return nil
+	}
+
+	commit, err := cmsg.DecodeCommitment(blob)
+	if err != nil {
+		return fmt.Errorf("unable to decode commitment: %w", err)
+	}
+
+	commitments.Current = commit
+	commitments.UpdatedAt = r.clock.Now()
+
+	return nil
+}
+
+// RecordFunding records the funding blob of a channel. The funding blob never
+// changes over the lifetime of a channel, so it is only decoded and recorded
+// the first time it is seen.
+func (r *CommitmentRecorder) RecordFunding(chanPoint wire.OutPoint,
+	blob tlv.Blob) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	channel := r.channelFor(chanPoint)
+	if channel.Funding != nil {
+		return nil
+	}
+
+	funding, err := cmsg.DecodeOpenChannel(blob)
+	if err != nil {
+		return fmt.Errorf("unable to decode funding blob: %w", err)
+	}
+
+	channel.Funding = funding
+
+	return nil
+}
+
+// FetchChannel returns the recorded commitments of the given channel.
+func (r *CommitmentRecorder) FetchChannel(
+	chanPoint wire.OutPoint) (ChannelCommitments, bool) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	channel, ok := r.channels[chanPoint]
+	if !ok {
+		return ChannelCommitments{}, false
+	}
+
+	return *channel, true
+}
+
+// ListChannels returns the recorded commitments of all channels, ordered by
+// their channel point.
+func (r *CommitmentRecorder) ListChannels() []ChannelCommitments {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	channels := make([]ChannelCommitments, 0, len(r.channels))
+	for _, channel := range r.channels {
+		channels = append(channels, *channel)
+	}
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ChannelPoint.String() <
+			channels[j].ChannelPoint.String()
+	})
+
+	return channels
+}
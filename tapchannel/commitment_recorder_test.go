@@ -0,0 +1,155 @@
+package tapchannel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	lfn "github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCommitmentRecorder tests that the commitment recorder keeps track of the
+// current and previous commitment of both sides of a channel.
+func TestCommitmentRecorder(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1_000_000, 0))
+	recorder := NewCommitmentRecorder(testClock)
+
+	// Each commitment gets a distinct local aux leaf, so we can tell them
+	// apart after decoding.
+	newCommitment := func() *cmsg.Commitment {
+		return cmsg.NewCommitment(
+			nil, nil, nil, nil, lnwallet.CommitAuxLeaves{
+				LocalAuxLeaf: lfn.Some(test.RandTapLeaf(nil)),
+			},
+		)
+	}
+	leafScript := func(c *cmsg.Commitment) []byte {
+		var script []byte
+		c.AuxLeaves.Val.LocalAuxLeaf.WhenSomeV(
+			func(l cmsg.TapLeafRecord) {
+				script = l.Leaf.Script
+			},
+		)
+		return script
+	}
+
+	chanPoint1 := wire.OutPoint{Hash: test.RandHash(), Index: 1}
+	chanPoint2 := wire.OutPoint{Hash: test.RandHash(), Index: 0}
+
+	_, ok := recorder.FetchChannel(chanPoint1)
+	require.False(t, ok)
+
+	// An existing commitment is recorded without a previous state.
+	commit0 := newCommitment()
+	err := recorder.RecordCommitment(
+		chanPoint1, lntypes.Local, commit0.Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, ok := recorder.FetchChannel(chanPoint1)
+	require.True(t, ok)
+	require.Equal(t, chanPoint1, channel.ChannelPoint)
+	require.Equal(t, leafScript(commit0), leafScript(channel.Local.Current))
+	require.Nil(t, channel.Local.Previous)
+	require.Nil(t, channel.Remote.Current)
+
+	// A transition moves the current commitment to the previous one.
+	testClock.SetTime(time.Unix(1_000_100, 0))
+	commit1 := newCommitment()
+	err = recorder.RecordTransition(
+		chanPoint1, lntypes.Local, commit0.Bytes(), commit1.Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, ok = recorder.FetchChannel(chanPoint1)
+	require.True(t, ok)
+	require.Equal(t, leafScript(commit1), leafScript(channel.Local.Current))
+	require.Equal(
+		t, leafScript(commit0), leafScript(channel.Local.Previous),
+	)
+	require.Equal(t, testClock.Now(), channel.Local.UpdatedAt)
+
+	// Recording an existing commitment must not overwrite the state we
+	// learned from a transition.
+	err = recorder.RecordCommitment(
+		chanPoint1, lntypes.Local, commit0.Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, _ = recorder.FetchChannel(chanPoint1)
+	require.Equal(t, leafScript(commit1), leafScript(channel.Local.Current))
+
+	// The remote side of the channel is tracked separately.
+	commit2 := newCommitment()
+	err = recorder.RecordTransition(
+		chanPoint1, lntypes.Remote, commit1.Bytes(), commit2.Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, _ = recorder.FetchChannel(chanPoint1)
+	require.Equal(t, leafScript(commit1), leafScript(channel.Local.Current))
+	require.Equal(
+		t, leafScript(commit2), leafScript(channel.Remote.Current),
+	)
+
+	// Invalid blobs are rejected without modifying the state.
+	err = recorder.RecordTransition(
+		chanPoint1, lntypes.Local, commit1.Bytes(), []byte{0xff},
+	)
+	require.Error(t, err)
+
+	channel, _ = recorder.FetchChannel(chanPoint1)
+	require.Equal(t, leafScript(commit1), leafScript(channel.Local.Current))
+
+	// All channels are listed, ordered by channel point.
+	err = recorder.RecordCommitment(
+		chanPoint2, lntypes.Remote, commit2.Bytes(),
+	)
+	require.NoError(t, err)
+
+	channels := recorder.ListChannels()
+	require.Len(t, channels, 2)
+	require.Less(
+		t, channels[0].ChannelPoint.String(),
+		channels[1].ChannelPoint.String(),
+	)
+
+	// A backup can only be created once the funding state is known.
+	channel, _ = recorder.FetchChannel(chanPoint1)
+	_, err = NewChannelBackup(channel)
+	require.ErrorContains(t, err, "funding state")
+
+	err = recorder.RecordFunding(
+		chanPoint1, cmsg.NewOpenChannel(nil).Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, _ = recorder.FetchChannel(chanPoint1)
+	backup, err := NewChannelBackup(channel)
+	require.NoError(t, err)
+	require.Equal(t, chanPoint1, backup.ChanPoint)
+	require.Equal(
+		t, leafScript(commit1), leafScript(backup.LocalCommitment),
+	)
+	require.Equal(
+		t, leafScript(commit2), leafScript(backup.RemoteCommitment),
+	)
+
+	// The second channel doesn't know its local commitment yet.
+	err = recorder.RecordFunding(
+		chanPoint2, cmsg.NewOpenChannel(nil).Bytes(),
+	)
+	require.NoError(t, err)
+
+	channel, _ = recorder.FetchChannel(chanPoint2)
+	_, err = NewChannelBackup(channel)
+	require.ErrorContains(t, err, "local commitment")
+}
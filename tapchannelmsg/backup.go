@@ -0,0 +1,297 @@
+package tapchannelmsg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// ChannelBackupsV0 is the first version of the asset channel backup
+	// encoding.
+	ChannelBackupsV0 uint8 = 0
+
+	// MaxNumChannelBackups is the maximum number of channel backups that
+	// are allowed in a single multi-channel backup.
+	MaxNumChannelBackups = 4096
+
+	// ChannelBackupMaxSize is the maximum size of a single encoded channel
+	// backup. A backup contains the full proofs of all funding and
+	// commitment outputs of a channel, so we only guard against lengths
+	// that can't be represented.
+	ChannelBackupMaxSize = math.MaxUint32
+)
+
+var (
+	// ErrChannelBackupNotFound is returned when no asset channel backup
+	// exists for a channel.
+	ErrChannelBackupNotFound = errors.New("channel backup not found")
+)
+
+// ChannelBackup contains the asset level state of a single asset channel that
+// is needed to sweep the assets of the channel after it was force closed. This
+// complements lnd's static channel backup, which doesn't cover the custom
+// channel data of asset channels.
+type ChannelBackup struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Funding is the funding blob of the channel, which contains the
+	// funding output proofs of all assets committed to the channel.
+	Funding *OpenChannel
+
+	// LocalCommitment is the latest asset commitment of our own
+	// commitment transaction.
+	LocalCommitment *Commitment
+
+	// RemoteCommitment is the latest asset commitment of the remote
+	// party's commitment transaction.
+	RemoteCommitment *Commitment
+}
+
+// Encode serializes the ChannelBackup to the given io.Writer.
+func (c *ChannelBackup) Encode(w io.Writer) error {
+	if c.Funding == nil || c.LocalCommitment == nil ||
+		c.RemoteCommitment == nil {
+
+		return fmt.Errorf("channel backup of %v is incomplete",
+			c.ChanPoint)
+	}
+
+	var (
+		funding = c.Funding.Bytes()
+		local   = c.LocalCommitment.Bytes()
+		remote  = c.RemoteCommitment.Bytes()
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakeStaticRecord(
+			0, &c.ChanPoint, 36, asset.OutPointEncoder,
+			asset.OutPointDecoder,
+		),
+		tlv.MakePrimitiveRecord(1, &funding),
+		tlv.MakePrimitiveRecord(2, &local),
+		tlv.MakePrimitiveRecord(3, &remote),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+// Decode deserializes the ChannelBackup from the given io.Reader.
+func (c *ChannelBackup) Decode(r io.Reader) error {
+	var funding, local, remote []byte
+	tlvStream, err := tlv.NewStream(
+		tlv.MakeStaticRecord(
+			0, &c.ChanPoint, 36, asset.OutPointEncoder,
+			asset.OutPointDecoder,
+		),
+		tlv.MakePrimitiveRecord(1, &funding),
+		tlv.MakePrimitiveRecord(2, &local),
+		tlv.MakePrimitiveRecord(3, &remote),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return err
+	}
+
+	c.Funding, err = DecodeOpenChannel(funding)
+	if err != nil {
+		return fmt.Errorf("unable to decode funding blob: %w", err)
+	}
+
+	c.LocalCommitment, err = DecodeCommitment(local)
+	if err != nil {
+		return fmt.Errorf("unable to decode local commitment: %w", err)
+	}
+
+	c.RemoteCommitment, err = DecodeCommitment(remote)
+	if err != nil {
+		return fmt.Errorf("unable to decode remote commitment: %w",
+			err)
+	}
+
+	return nil
+}
+
+// Bytes returns the serialized ChannelBackup record.
+func (c *ChannelBackup) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.Encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeChannelBackup deserializes a ChannelBackup from the given blob.
+func DecodeChannelBackup(blob tlv.Blob) (*ChannelBackup, error) {
+	var c ChannelBackup
+	err := c.Decode(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ChannelBackups is a versioned list of asset channel backups, which is the
+// format backups are exported in.
+type ChannelBackups struct {
+	// Version is the version of the backup encoding.
+	Version uint8
+
+	// Backups are the backups of the individual channels.
+	Backups []*ChannelBackup
+}
+
+// records returns the records that make up the ChannelBackups.
+func (c *ChannelBackups) records() []tlv.Record {
+	size := func() uint64 {
+		var (
+			buf     bytes.Buffer
+			scratch [8]byte
+		)
+		err := eChannelBackupList(&buf, &c.Backups, &scratch)
+		if err != nil {
+			panic(err)
+		}
+
+		return uint64(buf.Len())
+	}
+
+	return []tlv.Record{
+		tlv.MakePrimitiveRecord(0, &c.Version),
+		tlv.MakeDynamicRecord(
+			1, &c.Backups, size, eChannelBackupList,
+			dChannelBackupList,
+		),
+	}
+}
+
+// Encode serializes the ChannelBackups to the given io.Writer.
+func (c *ChannelBackups) Encode(w io.Writer) error {
+	// The size function of the list record panics on encoding errors, so
+	// we make sure all backups can be encoded first.
+	for _, backup := range c.Backups {
+		if _, err := backup.Bytes(); err != nil {
+			return err
+		}
+	}
+
+	tlvStream, err := tlv.NewStream(c.records()...)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+// Decode deserializes the ChannelBackups from the given io.Reader.
+func (c *ChannelBackups) Decode(r io.Reader) error {
+	tlvStream, err := tlv.NewStream(c.records()...)
+	if err != nil {
+		return err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return err
+	}
+
+	if c.Version != ChannelBackupsV0 {
+		return fmt.Errorf("unknown channel backup version %d",
+			c.Version)
+	}
+
+	return nil
+}
+
+// DecodeChannelBackups deserializes a multi-channel backup from the given
+// blob.
+func DecodeChannelBackups(blob []byte) (*ChannelBackups, error) {
+	var c ChannelBackups
+	err := c.Decode(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// eChannelBackupList is an encoder for a list of channel backups.
+func eChannelBackupList(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*[]*ChannelBackup); ok {
+		numBackups := uint64(len(*v))
+		if err := tlv.WriteVarInt(w, numBackups, buf); err != nil {
+			return err
+		}
+
+		for _, backup := range *v {
+			backupBytes, err := backup.Bytes()
+			if err != nil {
+				return err
+			}
+
+			err = asset.InlineVarBytesEncoder(w, &backupBytes, buf)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "*[]*ChannelBackup")
+}
+
+// dChannelBackupList is a decoder for a list of channel backups.
+func dChannelBackupList(r io.Reader, val interface{}, buf *[8]byte,
+	_ uint64) error {
+
+	if typ, ok := val.(*[]*ChannelBackup); ok {
+		numBackups, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		// Avoid OOM by limiting the number of backups we accept.
+		if numBackups > MaxNumChannelBackups {
+			return fmt.Errorf("%w: too many channel backups",
+				ErrListInvalid)
+		}
+
+		backups := make([]*ChannelBackup, 0, numBackups)
+		for i := uint64(0); i < numBackups; i++ {
+			var backupBytes []byte
+			err := asset.InlineVarBytesDecoder(
+				r, &backupBytes, buf, ChannelBackupMaxSize,
+			)
+			if err != nil {
+				return err
+			}
+
+			backup, err := DecodeChannelBackup(backupBytes)
+			if err != nil {
+				return err
+			}
+
+			backups = append(backups, backup)
+		}
+
+		*typ = backups
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "*[]*ChannelBackup", 0, 0)
+}
@@ -0,0 +1,106 @@
+package tapchannelmsg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzAssetOutput creates an asset output with a random proof that is used to
+// seed the corpus of the fuzz tests.
+func fuzzAssetOutput(f *testing.F) *AssetOutput {
+	oddTxBlockHex, err := os.ReadFile(oddTxBlockHexFileName)
+	require.NoError(f, err)
+
+	oddTxBlockBytes, err := hex.DecodeString(
+		strings.Trim(string(oddTxBlockHex), "\n"),
+	)
+	require.NoError(f, err)
+
+	var oddTxBlock wire.MsgBlock
+	err = oddTxBlock.Deserialize(bytes.NewReader(oddTxBlockBytes))
+	require.NoError(f, err)
+
+	randGen := asset.RandGenesis(f, asset.Normal)
+	randProof := proof.RandProof(
+		f, randGen, test.RandPubKey(f), oddTxBlock, 0, 1,
+	)
+
+	return NewAssetOutput([32]byte{1}, 1000, randProof)
+}
+
+func FuzzOpenChannel(f *testing.F) {
+	f.Add((&OpenChannel{}).Bytes())
+	f.Add(NewOpenChannel([]*AssetOutput{fuzzAssetOutput(f)}).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeOpenChannel(data)
+	})
+}
+
+func FuzzCommitment(f *testing.F) {
+	output := fuzzAssetOutput(f)
+	commitment := NewCommitment(
+		[]*AssetOutput{output}, []*AssetOutput{output},
+		map[input.HtlcIndex][]*AssetOutput{
+			1: {output},
+		}, map[input.HtlcIndex][]*AssetOutput{
+			2: {output},
+		}, lnwallet.CommitAuxLeaves{},
+	)
+	f.Add(commitment.Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeCommitment(data)
+	})
+}
+
+func FuzzAssetOutput(f *testing.F) {
+	f.Add(fuzzAssetOutput(f).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeAssetOutput(data)
+	})
+}
+
+func FuzzAuxLeaves(f *testing.F) {
+	f.Add((&AuxLeaves{}).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeAuxLeaves(data)
+	})
+}
+
+func FuzzCommitSig(f *testing.F) {
+	f.Add(NewCommitSig(nil).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeCommitSig(data)
+	})
+}
+
+func FuzzHtlcAuxLeaf(f *testing.F) {
+	f.Add((&HtlcAuxLeaf{}).Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeHtlcAuxLeaf(data)
+	})
+}
+
+func FuzzAuxShutdownMsg(f *testing.F) {
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeAuxShutdownMsg(data)
+	})
+}
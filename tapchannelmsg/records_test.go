@@ -346,6 +346,120 @@ func TestCommitment(t *testing.T) {
 	}
 }
 
+// TestChannelBackups tests encoding and decoding of the ChannelBackups struct.
+func TestChannelBackups(t *testing.T) {
+	t.Parallel()
+
+	oddTxBlockHex, err := os.ReadFile(oddTxBlockHexFileName)
+	require.NoError(t, err)
+
+	oddTxBlockBytes, err := hex.DecodeString(
+		strings.Trim(string(oddTxBlockHex), "\n"),
+	)
+	require.NoError(t, err)
+
+	var oddTxBlock wire.MsgBlock
+	err = oddTxBlock.Deserialize(bytes.NewReader(oddTxBlockBytes))
+	require.NoError(t, err)
+
+	randGen := asset.RandGenesis(t, asset.Normal)
+	scriptKey1 := test.RandPubKey(t)
+	originalRandProof := proof.RandProof(
+		t, randGen, scriptKey1, oddTxBlock, 0, 1,
+	)
+
+	// Proofs don't Encode everything, so we need to do a quick Encode/
+	// Decode cycle to make sure we can compare it afterward.
+	proofBytes, err := proof.Encode(&originalRandProof)
+	require.NoError(t, err)
+	randProof, err := proof.Decode(proofBytes)
+	require.NoError(t, err)
+
+	newBackup := func(index uint32) *ChannelBackup {
+		output := NewAssetOutput([32]byte{1}, 1000, *randProof)
+		return &ChannelBackup{
+			ChanPoint: wire.OutPoint{
+				Hash:  test.RandHash(),
+				Index: index,
+			},
+			Funding: NewOpenChannel([]*AssetOutput{output}),
+			LocalCommitment: NewCommitment(
+				[]*AssetOutput{output}, nil, nil, nil,
+				lnwallet.CommitAuxLeaves{
+					LocalAuxLeaf: lfn.Some(
+						test.RandTapLeaf(nil),
+					),
+				},
+			),
+			RemoteCommitment: NewCommitment(
+				nil, []*AssetOutput{output}, nil, nil,
+				lnwallet.CommitAuxLeaves{
+					RemoteAuxLeaf: lfn.Some(
+						test.RandTapLeaf(nil),
+					),
+				},
+			),
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		backups *ChannelBackups
+	}{
+		{
+			name: "no backups",
+			backups: &ChannelBackups{
+				Backups: []*ChannelBackup{},
+			},
+		},
+		{
+			name: "single backup",
+			backups: &ChannelBackups{
+				Backups: []*ChannelBackup{newBackup(0)},
+			},
+		},
+		{
+			name: "multiple backups",
+			backups: &ChannelBackups{
+				Backups: []*ChannelBackup{
+					newBackup(0), newBackup(1),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Serialize the backups and then deserialize them
+			// again.
+			var b bytes.Buffer
+			err := tc.backups.Encode(&b)
+			require.NoError(t, err)
+
+			deserializedBackups, err := DecodeChannelBackups(
+				b.Bytes(),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.backups, deserializedBackups)
+		})
+	}
+
+	// A backup that is missing any part of the channel state can't be
+	// encoded.
+	incomplete := newBackup(0)
+	incomplete.RemoteCommitment = nil
+	_, err = incomplete.Bytes()
+	require.ErrorContains(t, err, "incomplete")
+
+	// Backups of unknown versions are rejected.
+	var b bytes.Buffer
+	unknownVersion := &ChannelBackups{Version: ChannelBackupsV0 + 1}
+	require.NoError(t, unknownVersion.Encode(&b))
+	_, err = DecodeChannelBackups(b.Bytes())
+	require.ErrorContains(t, err, "unknown channel backup version")
+}
+
 // TestCommitSig tests encoding and decoding of the CommitSig struct.
 func TestCommitSig(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,204 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+type (
+	// NewAssetAccount is used to insert a new named asset account.
+	NewAssetAccount = sqlc.InsertAssetAccountParams
+
+	// AssetAccountRow is a named asset account stored in the DB.
+	AssetAccountRow = sqlc.AssetAccount
+)
+
+// AccountStore is the main storage interface for named asset accounts.
+type AccountStore interface {
+	// InsertAssetAccount inserts a new named asset account.
+	InsertAssetAccount(ctx context.Context, arg NewAssetAccount) (int64,
+		error)
+
+	// FetchAssetAccount fetches the asset account with the given name.
+	FetchAssetAccount(ctx context.Context, name string) (AssetAccountRow,
+		error)
+
+	// QueryAssetAccounts returns all asset accounts, ordered by their key
+	// family.
+	QueryAssetAccounts(ctx context.Context) ([]AssetAccountRow, error)
+}
+
+// BatchedAccountStore allows for batched DB transactions for the account
+// store.
+type BatchedAccountStore interface {
+	AccountStore
+
+	BatchedTx[AccountStore]
+}
+
+// AssetAccounts is a persistent store for named asset accounts.
+type AssetAccounts struct {
+	db BatchedAccountStore
+
+	clock clock.Clock
+}
+
+// NewAssetAccounts creates a new asset account store.
+func NewAssetAccounts(db BatchedAccountStore,
+	clock clock.Clock) *AssetAccounts {
+
+	return &AssetAccounts{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// NewAccount creates a new account with the given name and assigns it the
+// next unused key family.
+//
+// NOTE: This is part of the account.Store interface.
+func (a *AssetAccounts) NewAccount(ctx context.Context,
+	name string) (*account.Account, error) {
+
+	return a.newAccount(ctx, name, false)
+}
+
+// NewTenant creates a new tenant account with the given name and assigns it
+// the next unused key family.
+//
+// NOTE: This is part of the account.Store interface.
+func (a *AssetAccounts) NewTenant(ctx context.Context,
+	name string) (*account.Account, error) {
+
+	return a.newAccount(ctx, name, true)
+}
+
+// newAccount creates a new account with the given name and assigns it the next
+// unused key family.
+func (a *AssetAccounts) newAccount(ctx context.Context, name string,
+	tenant bool) (*account.Account, error) {
+
+	if err := account.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	acct := &account.Account{
+		Name:      name,
+		KeyFamily: account.FirstKeyFamily,
+		CreatedAt: a.clock.Now().UTC(),
+		Tenant:    tenant,
+	}
+
+	var writeTx AssetStoreTxOptions
+	dbErr := a.db.ExecTx(ctx, &writeTx, func(q AccountStore) error {
+		// The accounts are ordered by their key family, so the new
+		// account gets the family after the one of the last account.
+		rows, err := q.QueryAssetAccounts(ctx)
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			lastFamily := rows[len(rows)-1].KeyFamily
+			acct.KeyFamily = keychain.KeyFamily(lastFamily + 1)
+		}
+
+		_, err = q.InsertAssetAccount(ctx, NewAssetAccount{
+			Name:      acct.Name,
+			KeyFamily: int32(acct.KeyFamily),
+			CreatedAt: acct.CreatedAt,
+			Tenant:    acct.Tenant,
+		})
+
+		return err
+	})
+	if dbErr != nil {
+		var uniqueConstraintErr *ErrSqlUniqueConstraintViolation
+		if errors.As(dbErr, &uniqueConstraintErr) {
+			return nil, fmt.Errorf("%w: %s", account.ErrExists,
+				name)
+		}
+
+		return nil, fmt.Errorf("unable to create account: %w", dbErr)
+	}
+
+	return acct, nil
+}
+
+// FetchAccount returns the account with the given name.
+//
+// NOTE: This is part of the account.Store interface.
+func (a *AssetAccounts) FetchAccount(ctx context.Context,
+	name string) (*account.Account, error) {
+
+	var acct *account.Account
+	readTx := NewAssetStoreReadTx()
+	dbErr := a.db.ExecTx(ctx, &readTx, func(q AccountStore) error {
+		row, err := q.FetchAssetAccount(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		parsed := parseAssetAccount(row)
+		acct = &parsed
+
+		return nil
+	})
+	switch {
+	case errors.Is(dbErr, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %s", account.ErrNotFound, name)
+
+	case dbErr != nil:
+		return nil, fmt.Errorf("unable to fetch account: %w", dbErr)
+	}
+
+	return acct, nil
+}
+
+// ListAccounts returns all named accounts, ordered by their key family.
+//
+// NOTE: This is part of the account.Store interface.
+func (a *AssetAccounts) ListAccounts(
+	ctx context.Context) ([]account.Account, error) {
+
+	var accounts []account.Account
+	readTx := NewAssetStoreReadTx()
+	dbErr := a.db.ExecTx(ctx, &readTx, func(q AccountStore) error {
+		rows, err := q.QueryAssetAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		accounts = make([]account.Account, 0, len(rows))
+		for _, row := range rows {
+			accounts = append(accounts, parseAssetAccount(row))
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to list accounts: %w", dbErr)
+	}
+
+	return accounts, nil
+}
+
+// parseAssetAccount converts an account DB row into an account.
+func parseAssetAccount(row AssetAccountRow) account.Account {
+	return account.Account{
+		Name:      row.Name,
+		KeyFamily: keychain.KeyFamily(row.KeyFamily),
+		CreatedAt: row.CreatedAt.UTC(),
+		Tenant:    row.Tenant,
+	}
+}
+
+// A compile-time assertion to ensure AssetAccounts meets the account.Store
+// interface.
+var _ account.Store = (*AssetAccounts)(nil)
@@ -0,0 +1,345 @@
+package tapdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// newAssetAccountsFromDB makes a new account store backed by the passed
+// database.
+func newAssetAccountsFromDB(db *BaseDB) *AssetAccounts {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) AccountStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewAssetAccounts(dbTxer, clock.NewDefaultClock())
+}
+
+// TestAssetAccounts tests that named accounts can be created and are assigned
+// distinct key families.
+func TestAssetAccounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	store := newAssetAccountsFromDB(db.BaseDB)
+
+	treasury, err := store.NewAccount(ctx, "treasury")
+	require.NoError(t, err)
+	require.Equal(t, account.FirstKeyFamily, treasury.KeyFamily)
+
+	ops, err := store.NewAccount(ctx, "ops")
+	require.NoError(t, err)
+	require.Equal(t, account.FirstKeyFamily+1, ops.KeyFamily)
+
+	tenant, err := store.NewTenant(ctx, "customer")
+	require.NoError(t, err)
+	require.Equal(t, account.FirstKeyFamily+2, tenant.KeyFamily)
+	require.True(t, tenant.Tenant)
+
+	// Names must be unique and valid.
+	_, err = store.NewAccount(ctx, "treasury")
+	require.ErrorIs(t, err, account.ErrExists)
+	_, err = store.NewTenant(ctx, "ops")
+	require.ErrorIs(t, err, account.ErrExists)
+	_, err = store.NewAccount(ctx, account.DefaultName)
+	require.Error(t, err)
+	_, err = store.NewAccount(ctx, "no spaces")
+	require.Error(t, err)
+
+	dbTreasury, err := store.FetchAccount(ctx, "treasury")
+	require.NoError(t, err)
+	require.Equal(t, treasury.KeyFamily, dbTreasury.KeyFamily)
+	require.Equal(t, treasury.CreatedAt.Unix(), dbTreasury.CreatedAt.Unix())
+	require.False(t, dbTreasury.Tenant)
+
+	dbTenant, err := store.FetchAccount(ctx, "customer")
+	require.NoError(t, err)
+	require.True(t, dbTenant.Tenant)
+
+	_, err = store.FetchAccount(ctx, "unknown")
+	require.ErrorIs(t, err, account.ErrNotFound)
+
+	accounts, err := store.ListAccounts(ctx)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	require.Equal(t, "treasury", accounts[0].Name)
+	require.Equal(t, "ops", accounts[1].Name)
+	require.Equal(t, "customer", accounts[2].Name)
+
+	// The default account resolves without being stored.
+	defaultAcct, err := account.Resolve(ctx, store, "")
+	require.NoError(t, err)
+	require.Equal(t, account.Default, defaultAcct)
+
+	// A call that is scoped to a tenant resolves to the account of the
+	// tenant and can't resolve any other account.
+	tenantCtx := account.WithTenant(ctx, *dbTenant)
+	tenantAcct, err := account.Resolve(tenantCtx, store, "")
+	require.NoError(t, err)
+	require.Equal(t, *dbTenant, tenantAcct)
+
+	_, err = account.Resolve(tenantCtx, store, "treasury")
+	require.ErrorIs(t, err, account.ErrTenantIsolation)
+	_, err = account.Resolve(tenantCtx, store, account.DefaultName)
+	require.ErrorIs(t, err, account.ErrTenantIsolation)
+}
+
+// TestQueryAssetBalancesByAccount tests that the balances, assets, eligible
+// coins and transfers of an account only include the assets whose script keys
+// were derived from the key family of the account.
+func TestQueryAssetBalancesByAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	_, assetsStore := newAssetStoreFromDB(db.BaseDB)
+	accounts := newAssetAccountsFromDB(db.BaseDB)
+
+	treasury, err := accounts.NewAccount(ctx, "treasury")
+	require.NoError(t, err)
+
+	scriptKeyForFamily := func(fam keychain.KeyFamily) *asset.ScriptKey {
+		scriptKey := asset.NewScriptKeyBip86(keychain.KeyDescriptor{
+			PubKey: test.RandPubKey(t),
+			KeyLocator: keychain.KeyLocator{
+				Family: fam,
+				Index:  1,
+			},
+		})
+
+		return &scriptKey
+	}
+
+	// We create one asset owned by the treasury account and one owned by
+	// the default account.
+	assetGen := newAssetGenerator(t, 2, 0)
+	assetGen.genAssets(t, assetsStore, []assetDesc{
+		{
+			assetGen:    assetGen.assetGens[0],
+			anchorPoint: assetGen.anchorPoints[0],
+			noGroupKey:  true,
+			scriptKey:   scriptKeyForFamily(treasury.KeyFamily),
+			amt:         100,
+		},
+		{
+			assetGen:    assetGen.assetGens[1],
+			anchorPoint: assetGen.anchorPoints[1],
+			noGroupKey:  true,
+			scriptKey: scriptKeyForFamily(
+				asset.TaprootAssetsKeyFamily,
+			),
+			amt: 5,
+		},
+	})
+	testCases := []struct {
+		name              string
+		acct              fn.Option[account.Account]
+		expectedFamilies  []keychain.KeyFamily
+		expectedTransfers int
+	}{
+		{
+			name: "all accounts",
+			acct: fn.None[account.Account](),
+			expectedFamilies: []keychain.KeyFamily{
+				treasury.KeyFamily,
+				asset.TaprootAssetsKeyFamily,
+			},
+			expectedTransfers: 1,
+		},
+		{
+			name: "default account",
+			acct: fn.Some(account.Default),
+			expectedFamilies: []keychain.KeyFamily{
+				asset.TaprootAssetsKeyFamily,
+			},
+			expectedTransfers: 0,
+		},
+		{
+			name: "named account",
+			acct: fn.Some(*treasury),
+			expectedFamilies: []keychain.KeyFamily{
+				treasury.KeyFamily,
+			},
+			expectedTransfers: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			constraints := tapfreighter.CommitmentConstraints{
+				MinAmt:         1,
+				CoinSelectType: tapsend.ScriptTreesAllowed,
+				Account:        tc.acct,
+			}
+			coins, err := assetsStore.ListEligibleCoins(
+				ctx, constraints,
+			)
+			require.NoError(t, err)
+			require.Len(t, coins, len(tc.expectedFamilies))
+
+			balances, err := assetsStore.QueryBalancesByAsset(
+				ctx, nil, false, tc.acct,
+			)
+			require.NoError(t, err)
+			require.Len(t, balances, len(tc.expectedFamilies))
+
+			for _, coin := range coins {
+				scriptKey := coin.Asset.ScriptKey
+				require.Contains(
+					t, tc.expectedFamilies,
+					scriptKey.RawKey.Family,
+				)
+				require.Contains(t, balances, coin.Asset.ID())
+			}
+
+			assets, err := assetsStore.FetchAllAssets(
+				ctx, false, false, &AssetQueryFilters{
+					CommitmentConstraints: constraints,
+				},
+			)
+			require.NoError(t, err)
+			require.Len(t, assets, len(tc.expectedFamilies))
+		})
+	}
+
+	// A call that is scoped to a tenant only sees the assets of the
+	// tenant, even without filtering by its account, and can't query the
+	// assets of any other account.
+	tenantCtx := account.WithTenant(ctx, *treasury)
+	coins, err := assetsStore.ListEligibleCoins(
+		tenantCtx, tapfreighter.CommitmentConstraints{
+			MinAmt:         1,
+			CoinSelectType: tapsend.ScriptTreesAllowed,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(
+		t, treasury.KeyFamily, coins[0].Asset.ScriptKey.RawKey.Family,
+	)
+
+	tenantAssets, err := assetsStore.FetchAllAssets(
+		tenantCtx, false, false, nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, tenantAssets, 1)
+
+	tenantBalances, err := assetsStore.QueryBalancesByAsset(
+		tenantCtx, nil, false, fn.None[account.Account](),
+	)
+	require.NoError(t, err)
+	require.Len(t, tenantBalances, 1)
+
+	_, err = assetsStore.QueryBalancesByAsset(
+		tenantCtx, nil, false, fn.Some(account.Default),
+	)
+	require.ErrorIs(t, err, account.ErrTenantIsolation)
+
+	defaultConstraints := tapfreighter.CommitmentConstraints{
+		Account: fn.Some(account.Default),
+	}
+	_, err = assetsStore.FetchAllAssets(
+		tenantCtx, false, false, &AssetQueryFilters{
+			CommitmentConstraints: defaultConstraints,
+		},
+	)
+	require.ErrorIs(t, err, account.ErrTenantIsolation)
+
+	// We now log a transfer that spends the asset of the treasury account,
+	// which should only be attributed to that account.
+	treasuryConstraints := tapfreighter.CommitmentConstraints{
+		CoinSelectType: tapsend.ScriptTreesAllowed,
+		Account:        fn.Some(*treasury),
+	}
+	treasuryAssets, err := assetsStore.FetchAllAssets(
+		ctx, false, false, &AssetQueryFilters{
+			CommitmentConstraints: treasuryConstraints,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, treasuryAssets, 1)
+
+	inputAsset := treasuryAssets[0]
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{})
+	anchorTx.AddTxOut(&wire.TxOut{
+		PkScript: bytes.Repeat([]byte{0x01}, 34),
+		Value:    1000,
+	})
+	parcel := &tapfreighter.OutboundParcel{
+		AnchorTx:           anchorTx,
+		AnchorTxHeightHint: 1450,
+		Inputs: []tapfreighter.TransferInput{{
+			PrevID: asset.PrevID{
+				OutPoint: inputAsset.AnchorOutpoint,
+				ID:       inputAsset.ID(),
+				ScriptKey: asset.ToSerialized(
+					inputAsset.ScriptKey.PubKey,
+				),
+			},
+			Amount: inputAsset.Amount,
+		}},
+		Outputs: []tapfreighter.TransferOutput{{
+			Anchor: tapfreighter.Anchor{
+				Value: 1000,
+				OutPoint: wire.OutPoint{
+					Hash: anchorTx.TxHash(),
+				},
+				InternalKey: keychain.KeyDescriptor{
+					PubKey: test.RandPubKey(t),
+				},
+				TaprootAssetRoot: test.RandBytes(32),
+				MerkleRoot:       test.RandBytes(32),
+			},
+			ScriptKey:    *scriptKeyForFamily(treasury.KeyFamily),
+			Amount:       inputAsset.Amount,
+			AssetVersion: asset.V0,
+			ProofSuffix:  test.RandBytes(100),
+		}},
+	}
+	require.NoError(t, assetsStore.LogPendingParcel(
+		ctx, parcel, fn.ToArray[[32]byte](test.RandBytes(32)),
+		time.Now().Add(time.Hour),
+	))
+
+	for _, tc := range testCases {
+		parcels, err := assetsStore.QueryAccountParcels(
+			ctx, nil, false, tc.acct,
+		)
+		require.NoError(t, err, tc.name)
+		require.Len(t, parcels, tc.expectedTransfers, tc.name)
+	}
+
+	// The transfers of a tenant scoped call are restricted to the ones of
+	// the tenant.
+	parcels, err := assetsStore.QueryParcels(tenantCtx, nil, false)
+	require.NoError(t, err)
+	require.Len(t, parcels, 1)
+
+	otherCtx := account.WithTenant(ctx, account.Account{
+		Name:      "other",
+		KeyFamily: treasury.KeyFamily + 1,
+		Tenant:    true,
+	})
+	parcels, err = assetsStore.QueryParcels(otherCtx, nil, false)
+	require.NoError(t, err)
+	require.Empty(t, parcels)
+}
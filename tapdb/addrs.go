@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -77,6 +78,14 @@ type (
 	// KeyLocator is a type alias for fetching the key locator information
 	// for an internal key.
 	KeyLocator = sqlc.FetchInternalKeyLocatorRow
+
+	// NewScriptKeyReservation is a type alias for the params to reserve a
+	// script key for a subsystem.
+	NewScriptKeyReservation = sqlc.InsertScriptKeyReservationParams
+
+	// ScriptKeyReservation is a type alias for a script key reservation
+	// row.
+	ScriptKeyReservation = sqlc.ScriptKeyReservation
 )
 
 // AddrBook is an interface that represents the storage backed needed to create
@@ -160,6 +169,16 @@ type AddrBook interface {
 	// FetchInternalKeyLocator fetches the key locator for an internal key.
 	FetchInternalKeyLocator(ctx context.Context, rawKey []byte) (KeyLocator,
 		error)
+
+	// InsertScriptKeyReservation reserves a script key for a subsystem. If
+	// the script key is already reserved, this is a no-op.
+	InsertScriptKeyReservation(ctx context.Context,
+		arg NewScriptKeyReservation) error
+
+	// FetchScriptKeyReservation fetches the reservation of a script key or
+	// returns sql.ErrNoRows if the key isn't reserved.
+	FetchScriptKeyReservation(ctx context.Context,
+		tweakedScriptKey []byte) (ScriptKeyReservation, error)
 }
 
 // AddrBookTxOptions defines the set of db txn options the AddrBook
@@ -194,20 +213,22 @@ type BatchedAddrBook interface {
 // TapAddressBook represents a storage backend for all the Taproot Asset
 // addresses a daemon has created.
 type TapAddressBook struct {
-	db     BatchedAddrBook
-	params *address.ChainParams
-	clock  clock.Clock
+	db            BatchedAddrBook
+	params        *address.ChainParams
+	clock         clock.Clock
+	eventNotifier DBEventNotifier
 }
 
 // NewTapAddressBook creates a new TapAddressBook instance given a open
 // BatchedAddrBook storage backend.
 func NewTapAddressBook(db BatchedAddrBook, params *address.ChainParams,
-	clock clock.Clock) *TapAddressBook {
+	clock clock.Clock, eventNotifier DBEventNotifier) *TapAddressBook {
 
 	return &TapAddressBook{
-		db:     db,
-		params: params,
-		clock:  clock,
+		db:            db,
+		params:        params,
+		clock:         clock,
+		eventNotifier: eventNotifier,
 	}
 }
 
@@ -228,7 +249,7 @@ func (t *TapAddressBook) InsertAddrs(ctx context.Context,
 	addrs ...address.AddrWithKeyInfo) error {
 
 	var writeTxOpts AddrBookTxOptions
-	return t.db.ExecTx(ctx, &writeTxOpts, func(db AddrBook) error {
+	err := t.db.ExecTx(ctx, &writeTxOpts, func(db AddrBook) error {
 		// For each of the addresses listed, we'll insert the two new
 		// internal keys, then use those returned primary key IDs to
 		// returned to insert the address itself.
@@ -284,9 +305,25 @@ func (t *TapAddressBook) InsertAddrs(ctx context.Context,
 				groupKeyBytes = addr.GroupKey.SerializeCompressed()
 			}
 
-			proofCourierAddrBytes := []byte(
+			courierAddrBytes := []byte(
 				addr.Tap.ProofCourierAddr.String(),
 			)
+			creationTime := addr.CreationTime.UTC()
+
+			fallbackAddrBytes, err := encodeFallbackCourierAddrs(
+				addr.FallbackProofCourierAddrs,
+			)
+			if err != nil {
+				return err
+			}
+
+			var expiry sql.NullTime
+			if !addr.Expiry.IsZero() {
+				expiry = sql.NullTime{
+					Time:  addr.Expiry.UTC(),
+					Valid: true,
+				}
+			}
 
 			_, err = db.InsertAddr(ctx, NewAddr{
 				Version:          int16(addr.Version),
@@ -299,10 +336,13 @@ func (t *TapAddressBook) InsertAddrs(ctx context.Context,
 				TaprootOutputKey: schnorr.SerializePubKey(
 					&addr.TaprootOutputKey,
 				),
-				Amount:           int64(addr.Amount),
-				AssetType:        assetGen.AssetType,
-				CreationTime:     addr.CreationTime.UTC(),
-				ProofCourierAddr: proofCourierAddrBytes,
+				Amount:                    int64(addr.Amount),
+				AssetType:                 assetGen.AssetType,
+				CreationTime:              creationTime,
+				ProofCourierAddr:          courierAddrBytes,
+				FallbackProofCourierAddrs: fallbackAddrBytes,
+				Expiry:                    expiry,
+				Reusable:                  addr.Reusable,
 			})
 			if err != nil {
 				return fmt.Errorf("unable to insert addr: %w",
@@ -312,6 +352,23 @@ func (t *TapAddressBook) InsertAddrs(ctx context.Context,
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Now that the addresses are committed, we let other components know
+	// about them.
+	for idx := range addrs {
+		addr := addrs[idx]
+		outputKey := schnorr.SerializePubKey(&addr.TaprootOutputKey)
+		notifyDBEvent(ctx, t.eventNotifier, DBEventChannelNewAddr,
+			NewAddrPayload{
+				TaprootOutputKey: hex.EncodeToString(outputKey),
+			},
+		)
+	}
+
+	return nil
 }
 
 // QueryAddrs attempts to query for the set of addresses on disk given the
@@ -454,14 +511,23 @@ func (t *TapAddressBook) QueryAddrs(ctx context.Context,
 					"courier address: %w", err)
 			}
 
+			addrOpts, err := addrV2Opts(
+				addr.FallbackProofCourierAddrs, addr.Expiry,
+				addr.Reusable,
+			)
+			if err != nil {
+				return err
+			}
+			addrOpts = append(addrOpts, address.WithAssetVersion(
+				asset.Version(addr.AssetVersion),
+			))
+
 			tapAddr, err := address.New(
 				address.Version(addr.Version), assetGenesis,
 				groupKey, groupWitness,
 				*scriptKey, *internalKey, uint64(addr.Amount),
 				tapscriptSibling, t.params, *proofCourierAddr,
-				address.WithAssetVersion(
-					asset.Version(addr.AssetVersion),
-				),
+				addrOpts...,
 			)
 			if err != nil {
 				return fmt.Errorf("unable to make addr: %w", err)
@@ -609,11 +675,21 @@ func fetchAddr(ctx context.Context, db AddrBook, params *address.ChainParams,
 			"address: %w", err)
 	}
 
+	addrOpts, err := addrV2Opts(
+		dbAddr.FallbackProofCourierAddrs, dbAddr.Expiry,
+		dbAddr.Reusable,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addrOpts = append(addrOpts, address.WithAssetVersion(
+		asset.Version(dbAddr.AssetVersion),
+	))
+
 	tapAddr, err := address.New(
 		address.Version(dbAddr.Version), genesis, groupKey,
 		groupWitness, *scriptKey, *internalKey, uint64(dbAddr.Amount),
-		tapscriptSibling, params, *proofCourierAddr,
-		address.WithAssetVersion(asset.Version(dbAddr.AssetVersion)),
+		tapscriptSibling, params, *proofCourierAddr, addrOpts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to make addr: %w", err)
@@ -634,6 +710,63 @@ func fetchAddr(ctx context.Context, db AddrBook, params *address.ChainParams,
 	}, nil
 }
 
+// encodeFallbackCourierAddrs encodes the fallback proof courier addresses of an
+// address for storage. If there are no fallback proof couriers, nil is
+// returned.
+func encodeFallbackCourierAddrs(addrs []url.URL) ([]byte, error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+
+	var (
+		b   bytes.Buffer
+		buf [8]byte
+	)
+	if err := address.UrlSliceEncoder(&b, &addrs, &buf); err != nil {
+		return nil, fmt.Errorf("unable to encode fallback proof "+
+			"courier addresses: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// addrV2Opts returns the address options for the fallback proof courier
+// addresses, the expiry and the reusability of an address, as they were stored
+// on disk.
+func addrV2Opts(fallbackAddrBytes []byte, expiry sql.NullTime,
+	reusable bool) ([]address.NewAddrOpt, error) {
+
+	var opts []address.NewAddrOpt
+	if len(fallbackAddrBytes) > 0 {
+		var (
+			addrs []url.URL
+			buf   [8]byte
+		)
+		err := address.UrlSliceDecoder(
+			bytes.NewReader(fallbackAddrBytes), &addrs, &buf,
+			uint64(len(fallbackAddrBytes)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode fallback "+
+				"proof courier addresses: %w", err)
+		}
+
+		opts = append(
+			opts, address.WithFallbackProofCourierAddrs(addrs...),
+		)
+	}
+
+	if expiry.Valid {
+		opts = append(opts, address.WithExpiry(expiry.Time))
+	}
+
+	if reusable {
+		opts = append(opts, address.WithReusable())
+	}
+
+	return opts, nil
+}
+
 // SetAddrManaged sets an address as being managed by the internal
 // wallet.
 func (t *TapAddressBook) SetAddrManaged(ctx context.Context,
@@ -700,6 +833,50 @@ func (t *TapAddressBook) InsertScriptKey(ctx context.Context,
 	})
 }
 
+// ReserveScriptKey reserves the given tweaked script key for the given owner.
+// Reserving a key that is already reserved by the same owner is a no-op. If the
+// key is already reserved by a different owner, address.ErrScriptKeyReserved is
+// returned.
+func (t *TapAddressBook) ReserveScriptKey(ctx context.Context,
+	scriptKey *btcec.PublicKey, owner address.ScriptKeyOwner) error {
+
+	scriptKeyBytes := scriptKey.SerializeCompressed()
+
+	var writeTxOpts AddrBookTxOptions
+	return t.db.ExecTx(ctx, &writeTxOpts, func(q AddrBook) error {
+		// We first attempt to claim the key. If it's already claimed,
+		// the insert is ignored and we'll find out who the actual owner
+		// is below.
+		err := q.InsertScriptKeyReservation(
+			ctx, NewScriptKeyReservation{
+				TweakedScriptKey: scriptKeyBytes,
+				Owner:            int16(owner),
+				ReservedAt:       t.clock.Now().UTC(),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error reserving script key: %w", err)
+		}
+
+		reservation, err := q.FetchScriptKeyReservation(
+			ctx, scriptKeyBytes,
+		)
+		if err != nil {
+			return fmt.Errorf("error fetching script key "+
+				"reservation: %w", err)
+		}
+
+		existingOwner := address.ScriptKeyOwner(reservation.Owner)
+		if existingOwner != owner {
+			return fmt.Errorf("%w: script_key=%x, owner=%v, "+
+				"requested_by=%v", address.ErrScriptKeyReserved,
+				scriptKeyBytes, existingOwner, owner)
+		}
+
+		return nil
+	})
+}
+
 // GetOrCreateEvent creates a new address event for the given status, address
 // and transaction. If an event for that address and transaction already exists,
 // then the status and transaction information is updated instead.
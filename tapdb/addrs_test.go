@@ -3,8 +3,11 @@ package tapdb
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -36,7 +39,9 @@ func newAddrBook(t *testing.T,
 	}
 
 	addrTx := NewTransactionExecutor(db, txCreator)
-	return NewTapAddressBook(addrTx, chainParams, clock), db
+	return NewTapAddressBook(
+		addrTx, chainParams, clock, NoOpDBEventNotifier{},
+	), db
 }
 
 func confirmTx(tx *lndclient.Transaction) {
@@ -757,3 +762,119 @@ func TestScriptKeyKnownUpsert(t *testing.T) {
 		assertKeyKnowledge(t, ctx, addrBook, scriptKey, known)
 	})
 }
+
+// TestReserveScriptKey tests that a script key can only be reserved by a
+// single subsystem.
+func TestReserveScriptKey(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Now())
+	addrBook, _ := newAddrBook(t, testClock)
+
+	ctx := context.Background()
+	scriptKey := randScriptKey(t)
+
+	// Reserving the key for the first time should succeed, and doing so
+	// again for the same owner should be a no-op.
+	err := addrBook.ReserveScriptKey(
+		ctx, scriptKey.PubKey, address.ScriptKeyOwnerAddress,
+	)
+	require.NoError(t, err)
+	err = addrBook.ReserveScriptKey(
+		ctx, scriptKey.PubKey, address.ScriptKeyOwnerAddress,
+	)
+	require.NoError(t, err)
+
+	// Any other subsystem should be rejected.
+	err = addrBook.ReserveScriptKey(
+		ctx, scriptKey.PubKey, address.ScriptKeyOwnerChannelFunding,
+	)
+	require.ErrorIs(t, err, address.ErrScriptKeyReserved)
+
+	err = addrBook.ReserveScriptKey(
+		ctx, scriptKey.PubKey, address.ScriptKeyOwnerMint,
+	)
+	require.ErrorIs(t, err, address.ErrScriptKeyReserved)
+
+	// A different key can be reserved by a different subsystem.
+	otherKey := randScriptKey(t)
+	err = addrBook.ReserveScriptKey(
+		ctx, otherKey.PubKey, address.ScriptKeyOwnerMint,
+	)
+	require.NoError(t, err)
+}
+
+// mockDBEventNotifier is a DBEventNotifier that records all published events.
+type mockDBEventNotifier struct {
+	sync.Mutex
+
+	events []*DBEvent
+}
+
+// NotifyDBEvent records the given payload as a JSON encoded event.
+func (m *mockDBEventNotifier) NotifyDBEvent(_ context.Context,
+	channel DBEventChannel, payload any) error {
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.events = append(m.events, &DBEvent{
+		Channel: channel,
+		Payload: payloadBytes,
+	})
+
+	return nil
+}
+
+// TestAddressInsertionDBEvent tests that a DB event is published for each new
+// address once it was inserted.
+func TestAddressInsertionDBEvent(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Now())
+	addrBook, _ := newAddrBook(t, testClock)
+	notifier := &mockDBEventNotifier{}
+	addrBook.eventNotifier = notifier
+	ctx := context.Background()
+
+	var writeTxOpts AddrBookTxOptions
+
+	const numAddrs = 3
+	proofCourierAddr := address.RandProofCourierAddr(t)
+	addrs := make([]address.AddrWithKeyInfo, numAddrs)
+	for i := 0; i < numAddrs; i++ {
+		addr, assetGen, assetGroup := address.RandAddr(
+			t, chainParams, proofCourierAddr,
+		)
+
+		addrs[i] = *addr
+
+		err := addrBook.db.ExecTx(
+			ctx, &writeTxOpts,
+			insertFullAssetGen(ctx, assetGen, assetGroup),
+		)
+		require.NoError(t, err)
+	}
+	require.NoError(t, addrBook.InsertAddrs(ctx, addrs...))
+
+	require.Len(t, notifier.events, numAddrs)
+	for idx, event := range notifier.events {
+		require.Equal(t, DBEventChannelNewAddr, event.Channel)
+
+		var payload NewAddrPayload
+		require.NoError(t, event.DecodePayload(&payload))
+
+		outputKey := schnorr.SerializePubKey(
+			&addrs[idx].TaprootOutputKey,
+		)
+		require.Equal(
+			t, hex.EncodeToString(outputKey),
+			payload.TaprootOutputKey,
+		)
+	}
+}
@@ -262,6 +262,10 @@ var (
 type AssetStoreTxOptions struct {
 	// readOnly governs if a read only transaction is needed or not.
 	readOnly bool
+
+	// allowReplica governs if the transaction may be executed on a read
+	// replica of the database.
+	allowReplica bool
 }
 
 // ReadOnly returns true if the transaction should be read only.
@@ -278,6 +282,24 @@ func NewAssetStoreReadTx() AssetStoreTxOptions {
 	}
 }
 
+// AllowReplica returns true if the transaction may be executed on a read
+// replica.
+//
+// NOTE: This implements the ReplicaTxOptions interface.
+func (r *AssetStoreTxOptions) AllowReplica() bool {
+	return r.allowReplica
+}
+
+// NewAssetStoreReplicaReadTx creates a new read transaction option set that
+// may be executed on a read replica. This should only be used for queries that
+// can tolerate slightly stale data.
+func NewAssetStoreReplicaReadTx() AssetStoreTxOptions {
+	return AssetStoreTxOptions{
+		readOnly:     true,
+		allowReplica: true,
+	}
+}
+
 // BatchedPendingAssetStore combines the PendingAssetStore interface with the
 // BatchedTx interface, allowing for multiple queries to be executed in a
 // single SQL transaction.
@@ -293,13 +315,20 @@ type BatchedPendingAssetStore interface {
 // logic for any backend that can implement the specified interface.
 type AssetMintingStore struct {
 	db BatchedPendingAssetStore
+
+	// cipher is used to encrypt the proofs at rest. If nil, the proofs are
+	// stored in plaintext.
+	cipher *FieldCipher
 }
 
 // NewAssetMintingStore creates a new AssetMintingStore from the specified
-// BatchedPendingAssetStore interface.
-func NewAssetMintingStore(db BatchedPendingAssetStore) *AssetMintingStore {
+// BatchedPendingAssetStore interface and the optional field cipher.
+func NewAssetMintingStore(db BatchedPendingAssetStore,
+	cipher *FieldCipher) *AssetMintingStore {
+
 	return &AssetMintingStore{
-		db: db,
+		db:     db,
+		cipher: cipher,
 	}
 }
 
@@ -1642,12 +1671,17 @@ func (a *AssetMintingStore) MarkBatchConfirmed(ctx context.Context,
 					len(dbAssetIds), dbAssetIds)
 			}
 
+			proofFile, err := a.cipher.Encrypt(proofBlob)
+			if err != nil {
+				return err
+			}
+
 			// Upload proof by the dbAssetId, which is the _primary
 			// key_ of the asset in table assets, not the BIPS
 			// concept of `asset_id`.
 			err = q.UpsertAssetProofByID(ctx, ProofUpdateByID{
 				AssetID:   dbAssetIds[0],
-				ProofFile: proofBlob,
+				ProofFile: proofFile,
 			})
 			if err != nil {
 				return fmt.Errorf("unable to insert proof "+
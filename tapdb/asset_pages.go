@@ -0,0 +1,168 @@
+package tapdb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+)
+
+// AssetSortKey is the key the assets of a query are sorted by.
+type AssetSortKey uint8
+
+const (
+	// AssetSortByPrimaryKey sorts the assets in the order they were
+	// stored.
+	AssetSortByPrimaryKey AssetSortKey = 0
+
+	// AssetSortByAmount sorts the assets by their amount.
+	AssetSortByAmount AssetSortKey = 1
+
+	// AssetSortByAnchorHeight sorts the assets by the block height of
+	// their anchor transaction. Unconfirmed anchor transactions have a
+	// height of zero.
+	AssetSortByAnchorHeight AssetSortKey = 2
+
+	// AssetSortByAssetID sorts the assets by their asset ID.
+	AssetSortByAssetID AssetSortKey = 3
+)
+
+// assetCursorLen is the length of an encoded asset cursor.
+const assetCursorLen = 1 + 1 + 8 + 8 + 4 + 32
+
+// AssetCursor is the position of an asset in the sort order of an asset query,
+// which is used to continue a paginated query after that asset.
+type AssetCursor struct {
+	// SortBy is the sort key of the query the cursor was created for.
+	SortBy AssetSortKey
+
+	// SortDescending is true if the query the cursor was created for
+	// sorted the assets in descending order.
+	SortDescending bool
+
+	// PrimaryKey is the primary key of the asset, which is the tie
+	// breaker between assets with the same sort value.
+	PrimaryKey int64
+
+	// Amount is the amount of the asset.
+	Amount uint64
+
+	// AnchorHeight is the block height of the anchor transaction of the
+	// asset.
+	AnchorHeight uint32
+
+	// AssetID is the asset ID of the asset.
+	AssetID asset.ID
+}
+
+// newAssetCursor creates the cursor that points at the given asset.
+func newAssetCursor(dbAsset ConfirmedAsset, sortBy AssetSortKey,
+	sortDescending bool) *AssetCursor {
+
+	cursor := &AssetCursor{
+		SortBy:         sortBy,
+		SortDescending: sortDescending,
+		PrimaryKey:     dbAsset.AssetPrimaryKey,
+		Amount:         uint64(dbAsset.Amount),
+		AnchorHeight:   uint32(dbAsset.AnchorBlockHeight.Int32),
+	}
+	copy(cursor.AssetID[:], dbAsset.AssetID)
+
+	return cursor
+}
+
+// apply sets the cursor fields of the given database query.
+func (c *AssetCursor) apply(filter *QueryAssetFilters) {
+	filter.CursorPrimaryKey = sqlInt64(c.PrimaryKey)
+	filter.CursorAmount = sqlInt64(c.Amount)
+	filter.CursorAnchorHeight = sqlInt32(c.AnchorHeight)
+	filter.CursorAssetID = fn.CopySlice(c.AssetID[:])
+}
+
+// Encode encodes the cursor as an opaque string.
+func (c *AssetCursor) Encode() string {
+	var b [assetCursorLen]byte
+	b[0] = byte(c.SortBy)
+	if c.SortDescending {
+		b[1] = 1
+	}
+	binary.BigEndian.PutUint64(b[2:10], uint64(c.PrimaryKey))
+	binary.BigEndian.PutUint64(b[10:18], c.Amount)
+	binary.BigEndian.PutUint32(b[18:22], c.AnchorHeight)
+	copy(b[22:], c.AssetID[:])
+
+	return hex.EncodeToString(b[:])
+}
+
+// DecodeAssetCursor decodes a cursor that was encoded with Encode.
+func DecodeAssetCursor(encoded string) (*AssetCursor, error) {
+	b, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if len(b) != assetCursorLen {
+		return nil, fmt.Errorf("invalid cursor length %d", len(b))
+	}
+	if b[1] > 1 {
+		return nil, fmt.Errorf("invalid cursor sort direction %d", b[1])
+	}
+
+	cursor := &AssetCursor{
+		SortBy:         AssetSortKey(b[0]),
+		SortDescending: b[1] == 1,
+		PrimaryKey:     int64(binary.BigEndian.Uint64(b[2:10])),
+		Amount:         binary.BigEndian.Uint64(b[10:18]),
+		AnchorHeight:   binary.BigEndian.Uint32(b[18:22]),
+	}
+	copy(cursor.AssetID[:], b[22:])
+
+	return cursor, nil
+}
+
+// FetchAssetPage fetches a page of the confirmed assets stored on disk that
+// match the given filters, sorted by the sort key of the filters. Besides the
+// assets, the cursor to continue the query after the last returned asset is
+// returned, which is nil if there are no more matching assets.
+func (a *AssetStore) FetchAssetPage(ctx context.Context, includeSpent,
+	includeLeased bool, query AssetQueryFilters) ([]*asset.ChainAsset,
+	*AssetCursor, error) {
+
+	if query.Cursor != nil && (query.Cursor.SortBy != query.SortBy ||
+		query.Cursor.SortDescending != query.SortDescending) {
+
+		return nil, nil, fmt.Errorf("cursor was created for a " +
+			"different sort order")
+	}
+
+	// We fetch one more asset than requested, so we know whether there
+	// are more assets after the page.
+	limit := query.Limit
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+
+	dbAssets, witnesses, err := a.queryAssets(
+		ctx, includeSpent, includeLeased, &query,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *AssetCursor
+	if limit > 0 && len(dbAssets) > int(limit) {
+		dbAssets = dbAssets[:limit]
+		nextCursor = newAssetCursor(
+			dbAssets[limit-1], query.SortBy, query.SortDescending,
+		)
+	}
+
+	chainAssets, err := a.dbAssetsToChainAssets(dbAssets, witnesses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return chainAssets, nextCursor, nil
+}
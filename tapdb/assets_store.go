@@ -15,6 +15,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightninglabs/neutrino/cache/lru"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -22,6 +23,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
 	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
 	"github.com/lightninglabs/taproot-assets/tappsbt"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
@@ -108,6 +110,14 @@ type (
 	// AnchorTxConf identifies an unconfirmed anchor tx to confirm.
 	AnchorTxConf = sqlc.ConfirmChainAnchorTxParams
 
+	// AnchorTxReplacement identifies an unconfirmed anchor tx to replace
+	// with a new version of the transaction.
+	AnchorTxReplacement = sqlc.ReplaceChainAnchorTxParams
+
+	// ManagedUTXOReplacement wraps the params needed to move a managed
+	// UTXO to the outpoint of a replacement anchor tx.
+	ManagedUTXOReplacement = sqlc.UpdateManagedUTXOOutpointParams
+
 	// NewAssetTransfer wraps the params needed to insert a new asset
 	// transfer.
 	NewAssetTransfer = sqlc.InsertAssetTransferParams
@@ -148,6 +158,20 @@ type (
 	// nolint: lll
 	OutputProofDeliveryStatus = sqlc.SetTransferOutputProofDeliveryStatusParams
 
+	// NewOutputProofCourier wraps the params needed to insert a new proof
+	// courier of a transfer output.
+	NewOutputProofCourier = sqlc.InsertTransferOutputProofCourierParams
+
+	// OutputProofCourierStatus wraps the params needed to set the delivery
+	// status of a transfer output proof to a single proof courier.
+	//
+	// nolint: lll
+	OutputProofCourierStatus = sqlc.SetTransferOutputProofCourierStatusParams
+
+	// OutputProofCourier tracks the delivery of a transfer output proof to
+	// a single proof courier.
+	OutputProofCourier = sqlc.AssetTransferOutputProofCourier
+
 	// NewPassiveAsset wraps the params needed to insert a new passive
 	// asset.
 	NewPassiveAsset = sqlc.InsertPassiveAssetParams
@@ -295,6 +319,16 @@ type ActiveAssetsStore interface {
 	// previously unconfirmed as confirmed.
 	ConfirmChainAnchorTx(ctx context.Context, arg AnchorTxConf) error
 
+	// ReplaceChainAnchorTx replaces an unconfirmed anchor transaction with
+	// a new version of the transaction, for example one that was fee
+	// bumped.
+	ReplaceChainAnchorTx(ctx context.Context,
+		arg AnchorTxReplacement) error
+
+	// UpdateManagedUTXOOutpoint moves a managed UTXO to a new outpoint.
+	UpdateManagedUTXOOutpoint(ctx context.Context,
+		arg ManagedUTXOReplacement) error
+
 	// InsertAssetTransfer inserts a new asset transfer into the DB.
 	InsertAssetTransfer(ctx context.Context,
 		arg NewAssetTransfer) (int64, error)
@@ -314,6 +348,21 @@ type ActiveAssetsStore interface {
 	SetTransferOutputProofDeliveryStatus(ctx context.Context,
 		arg OutputProofDeliveryStatus) error
 
+	// InsertTransferOutputProofCourier inserts a new proof courier of a
+	// transfer output into the DB.
+	InsertTransferOutputProofCourier(ctx context.Context,
+		arg NewOutputProofCourier) error
+
+	// SetTransferOutputProofCourierStatus sets the delivery status of a
+	// transfer output proof to a single proof courier.
+	SetTransferOutputProofCourierStatus(ctx context.Context,
+		arg OutputProofCourierStatus) error
+
+	// FetchTransferOutputProofCouriers fetches the proof couriers of a
+	// given transfer output, in order of their priority.
+	FetchTransferOutputProofCouriers(ctx context.Context,
+		outputID int64) ([]OutputProofCourier, error)
+
 	// FetchTransferInputs fetches the inputs to a given asset transfer.
 	FetchTransferInputs(ctx context.Context,
 		transferID int64) ([]TransferInputRow, error)
@@ -448,12 +497,50 @@ type AssetStore struct {
 	txHeights *lru.Cache[chainhash.Hash, cacheableBlockHeight]
 
 	dbType sqlc.BackendType
+
+	// eventNotifier is used to announce new proofs and transfers to other
+	// components sharing the same database.
+	eventNotifier DBEventNotifier
+
+	// cipher is used to encrypt the proofs at rest. If nil, the proofs are
+	// stored in plaintext.
+	cipher *FieldCipher
+
+	opts assetStoreOpts
+}
+
+// assetStoreOpts holds the optional settings of the asset store.
+type assetStoreOpts struct {
+	// replicaBalances governs if the asset balance queries may be
+	// executed on a read replica of the database.
+	replicaBalances bool
+}
+
+// AssetStoreOption is a functional option that can be used to modify the way
+// that the AssetStore is created.
+type AssetStoreOption func(*assetStoreOpts)
+
+// WithReplicaBalances is a functional option that allows the asset balance
+// queries to be executed on a read replica of the database, if one is
+// configured. A replica can lag behind the primary database, so the balances
+// might not reflect a transfer that was made just before.
+func WithReplicaBalances() AssetStoreOption {
+	return func(o *assetStoreOpts) {
+		o.replicaBalances = true
+	}
 }
 
 // NewAssetStore creates a new AssetStore from the specified BatchedAssetStore
-// interface.
+// interface and the optional field cipher.
 func NewAssetStore(db BatchedAssetStore, metaDB BatchedMetaStore,
-	clock clock.Clock, dbType sqlc.BackendType) *AssetStore {
+	clock clock.Clock, dbType sqlc.BackendType,
+	eventNotifier DBEventNotifier, cipher *FieldCipher,
+	options ...AssetStoreOption) *AssetStore {
+
+	var opts assetStoreOpts
+	for _, o := range options {
+		o(&opts)
+	}
 
 	return &AssetStore{
 		db:               db,
@@ -463,8 +550,21 @@ func NewAssetStore(db BatchedAssetStore, metaDB BatchedMetaStore,
 		txHeights: lru.NewCache[chainhash.Hash, cacheableBlockHeight](
 			10_000,
 		),
-		dbType: dbType,
+		dbType:        dbType,
+		eventNotifier: eventNotifier,
+		cipher:        cipher,
+		opts:          opts,
+	}
+}
+
+// balancesReadTx returns the transaction options of the asset balance
+// queries. They only run on a read replica if explicitly allowed.
+func (a *AssetStore) balancesReadTx() AssetStoreTxOptions {
+	if a.opts.replicaBalances {
+		return NewAssetStoreReplicaReadTx()
 	}
+
+	return NewAssetStoreReadTx()
 }
 
 // ManagedUTXO holds information about a given UTXO we manage.
@@ -866,9 +966,10 @@ func (a *AssetStore) dbAssetsToChainAssets(dbAssets []ConfirmedAsset,
 }
 
 // constraintsToDbFilter maps application level constraints to the set of
-// filters we use in the SQL queries.
-func (a *AssetStore) constraintsToDbFilter(
-	query *AssetQueryFilters) QueryAssetFilters {
+// filters we use in the SQL queries. If the call of the given context is scoped
+// to a tenant, the filters are restricted to the assets of the tenant.
+func (a *AssetStore) constraintsToDbFilter(ctx context.Context,
+	query *AssetQueryFilters) (QueryAssetFilters, error) {
 
 	assetFilter := QueryAssetFilters{
 		Now: sql.NullTime{
@@ -876,6 +977,20 @@ func (a *AssetStore) constraintsToDbFilter(
 			Valid: true,
 		},
 	}
+
+	var (
+		acct fn.Option[account.Account]
+		err  error
+	)
+	if query != nil {
+		acct = query.Account
+	}
+	assetFilter.ScriptKeyFamily, assetFilter.ExcludeAccountKeys, err =
+		accountKeyFilters(ctx, acct)
+	if err != nil {
+		return assetFilter, err
+	}
+
 	if query != nil {
 		if query.MinAmt != 0 {
 			assetFilter.MinAmt = sql.NullInt64{
@@ -888,6 +1003,9 @@ func (a *AssetStore) constraintsToDbFilter(
 				query.MinAnchorHeight,
 			)
 		}
+		query.MaxAnchorHeight.WhenSome(func(height int32) {
+			assetFilter.MaxAnchorHeight = sqlInt32(height)
+		})
 
 		// Add asset ID bytes and group key bytes to the filter. These
 		// byte arrays are empty if the asset ID or group key is not
@@ -906,9 +1024,55 @@ func (a *AssetStore) constraintsToDbFilter(
 		default:
 			assetFilter.Bip86ScriptKeysOnly = true
 		}
+
+		if query.ScriptKey != nil {
+			assetFilter.TweakedScriptKey =
+				query.ScriptKey.SerializeCompressed()
+		}
+
+		assetFilter.SortBy = int32(query.SortBy)
+		assetFilter.SortDescending = query.SortDescending
+		if query.Cursor != nil {
+			query.Cursor.apply(&assetFilter)
+		}
+		if query.Limit != 0 {
+			assetFilter.NumLimit = sqlInt32(query.Limit)
+		}
 	}
 
-	return assetFilter
+	return assetFilter, nil
+}
+
+// accountKeyFilters maps the given account to the script key family filters we
+// use in the SQL queries. The assets of a named account are identified by the
+// key family of their script key, while the default account owns all assets
+// whose script key wasn't derived from the key family of a named account. If
+// no account is given, then no filtering is applied. A call that is scoped to
+// a tenant is always restricted to the account of the tenant, which enforces
+// the isolation of tenants independent of the filters the caller chose.
+func accountKeyFilters(ctx context.Context,
+	acct fn.Option[account.Account]) (sql.NullInt32, sql.NullBool, error) {
+
+	var (
+		scriptKeyFamily    sql.NullInt32
+		excludeAccountKeys sql.NullBool
+	)
+
+	acct, err := account.ScopeFilter(ctx, acct)
+	if err != nil {
+		return scriptKeyFamily, excludeAccountKeys, err
+	}
+
+	acct.WhenSome(func(a account.Account) {
+		if a.IsDefault() {
+			excludeAccountKeys = sqlBool(true)
+			return
+		}
+
+		scriptKeyFamily = sqlInt32(a.KeyFamily)
+	})
+
+	return scriptKeyFamily, excludeAccountKeys, nil
 }
 
 // specificAssetFilter maps the given asset parameters to the set of filters
@@ -980,13 +1144,40 @@ type AssetQueryFilters struct {
 	// MinAnchorHeight is the minimum block height the asset's anchor tx
 	// must have been confirmed at.
 	MinAnchorHeight int32
+
+	// MaxAnchorHeight is the optional maximum block height the asset's
+	// anchor tx must have been confirmed at. Unconfirmed anchor txns have
+	// a height of zero.
+	MaxAnchorHeight fn.Option[int32]
+
+	// OnlySpent restricts the query to spent assets.
+	OnlySpent bool
+
+	// OnlyLeased restricts the query to assets with a leased anchor
+	// output.
+	OnlyLeased bool
+
+	// SortBy is the key the assets are sorted by.
+	SortBy AssetSortKey
+
+	// SortDescending reverses the sort order of the assets.
+	SortDescending bool
+
+	// Cursor is the optional position after which the query continues in
+	// the sort order.
+	Cursor *AssetCursor
+
+	// Limit is the maximum number of assets to return. If zero, all
+	// matching assets are returned.
+	Limit int32
 }
 
 // QueryBalancesByAsset queries the balances for assets or alternatively
-// for a selected one that matches the passed asset ID filter.
+// for a selected one that matches the passed asset ID filter. If an account is
+// given, only the balances of that account are returned.
 func (a *AssetStore) QueryBalancesByAsset(ctx context.Context,
-	assetID *asset.ID,
-	includeLeased bool) (map[asset.ID]AssetBalance, error) {
+	assetID *asset.ID, includeLeased bool,
+	acct fn.Option[account.Account]) (map[asset.ID]AssetBalance, error) {
 
 	// We'll now map the application level filtering to the type of
 	// filtering our database query understands.
@@ -1016,9 +1207,16 @@ func (a *AssetStore) QueryBalancesByAsset(ctx context.Context,
 		assetBalancesFilter.AssetIDFilter = assetID[:]
 	}
 
+	keyFamily, excludeAccountKeys, err := accountKeyFilters(ctx, acct)
+	if err != nil {
+		return nil, err
+	}
+	assetBalancesFilter.ScriptKeyFamily = keyFamily
+	assetBalancesFilter.ExcludeAccountKeys = excludeAccountKeys
+
 	balances := make(map[asset.ID]AssetBalance)
 
-	readOpts := NewAssetStoreReadTx()
+	readOpts := a.balancesReadTx()
 	dbErr := a.db.ExecTx(ctx, &readOpts, func(q ActiveAssetsStore) error {
 		dbBalances, err := q.QueryAssetBalancesByAsset(
 			ctx, assetBalancesFilter,
@@ -1063,11 +1261,12 @@ func (a *AssetStore) QueryBalancesByAsset(ctx context.Context,
 }
 
 // QueryAssetBalancesByGroup queries the asset balances for asset groups or
-// alternatively for a selected one that matches the passed filter.
+// alternatively for a selected one that matches the passed filter. If an
+// account is given, only the balances of that account are returned.
 func (a *AssetStore) QueryAssetBalancesByGroup(ctx context.Context,
-	groupKey *btcec.PublicKey,
-	includeLeased bool) (map[asset.SerializedKey]AssetGroupBalance,
-	error) {
+	groupKey *btcec.PublicKey, includeLeased bool,
+	acct fn.Option[account.Account]) (
+	map[asset.SerializedKey]AssetGroupBalance, error) {
 
 	// We'll now map the application level filtering to the type of
 	// filtering our database query understands.
@@ -1098,9 +1297,16 @@ func (a *AssetStore) QueryAssetBalancesByGroup(ctx context.Context,
 		assetBalancesFilter.KeyGroupFilter = groupKeySerialized[:]
 	}
 
+	keyFamily, excludeAccountKeys, err := accountKeyFilters(ctx, acct)
+	if err != nil {
+		return nil, err
+	}
+	assetBalancesFilter.ScriptKeyFamily = keyFamily
+	assetBalancesFilter.ExcludeAccountKeys = excludeAccountKeys
+
 	balances := make(map[asset.SerializedKey]AssetGroupBalance)
 
-	readOpts := NewAssetStoreReadTx()
+	readOpts := a.balancesReadTx()
 	dbErr := a.db.ExecTx(ctx, &readOpts, func(q ActiveAssetsStore) error {
 		dbBalances, err := q.QueryAssetBalancesByGroup(
 			ctx, assetBalancesFilter,
@@ -1191,15 +1397,34 @@ func (a *AssetStore) FetchAllAssets(ctx context.Context, includeSpent,
 	includeLeased bool, query *AssetQueryFilters) ([]*asset.ChainAsset,
 	error) {
 
+	dbAssets, assetWitnesses, err := a.queryAssets(
+		ctx, includeSpent, includeLeased, query,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.dbAssetsToChainAssets(dbAssets, assetWitnesses)
+}
+
+// queryAssets fetches the assets that match the given filters along with
+// their witnesses.
+func (a *AssetStore) queryAssets(ctx context.Context, includeSpent,
+	includeLeased bool, query *AssetQueryFilters) ([]ConfirmedAsset,
+	assetWitnesses, error) {
+
 	var (
-		dbAssets       []ConfirmedAsset
-		assetWitnesses map[int64][]AssetWitness
-		err            error
+		dbAssets  []ConfirmedAsset
+		witnesses assetWitnesses
+		err       error
 	)
 
 	// We'll now map the application level filtering to the type of
 	// filtering our database query understands.
-	assetFilter := a.constraintsToDbFilter(query)
+	assetFilter, err := a.constraintsToDbFilter(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// By default, the spent boolean is null, which means we'll fetch all
 	// assets. Only if we should exclude spent assets, we'll set the spent
@@ -1213,21 +1438,83 @@ func (a *AssetStore) FetchAllAssets(ctx context.Context, includeSpent,
 		assetFilter.Leased = sqlBool(false)
 	}
 
+	// The query can also be restricted to only spent or only leased
+	// assets.
+	if query != nil && query.OnlySpent {
+		assetFilter.Spent = sqlBool(true)
+	}
+	if query != nil && query.OnlyLeased {
+		assetFilter.Leased = sqlBool(true)
+	}
+
 	// With the query constructed, we can now fetch the assets along w/
 	// their witness information.
 	readOpts := NewAssetStoreReadTx()
 	dbErr := a.db.ExecTx(ctx, &readOpts, func(q ActiveAssetsStore) error {
-		dbAssets, assetWitnesses, err = fetchAssetsWithWitness(
+		dbAssets, witnesses, err = fetchAssetsWithWitness(
 			ctx, q, assetFilter,
 		)
 
 		return err
 	})
 	if dbErr != nil {
-		return nil, dbErr
+		return nil, nil, dbErr
 	}
 
-	return a.dbAssetsToChainAssets(dbAssets, assetWitnesses)
+	return dbAssets, witnesses, nil
+}
+
+// ListOwnedAnchors returns all confirmed anchor outputs that hold unspent
+// assets owned by the daemon. Outputs that only hold assets used for funding
+// custom channels are excluded, as spending those is handled by lnd.
+func (a *AssetStore) ListOwnedAnchors(
+	ctx context.Context) ([]tapgarden.OwnedAnchor, error) {
+
+	assets, err := a.FetchAllAssets(ctx, false, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingKey := asset.NewScriptKey(
+		tapscript.NewChannelFundingScriptTree().TaprootKey,
+	).PubKey
+
+	var (
+		anchors []tapgarden.OwnedAnchor
+		seen    = make(map[wire.OutPoint]struct{})
+	)
+	for _, chainAsset := range assets {
+		// Unconfirmed anchors will be picked up once they confirm.
+		if chainAsset.AnchorBlockHeight == 0 ||
+			chainAsset.AnchorTx == nil {
+
+			continue
+		}
+
+		if chainAsset.ScriptKey.PubKey.IsEqual(fundingKey) {
+			continue
+		}
+
+		op := chainAsset.AnchorOutpoint
+		if _, ok := seen[op]; ok {
+			continue
+		}
+		seen[op] = struct{}{}
+
+		if int(op.Index) >= len(chainAsset.AnchorTx.TxOut) {
+			return nil, fmt.Errorf("invalid anchor output index "+
+				"for %v", op)
+		}
+
+		anchorOut := chainAsset.AnchorTx.TxOut[op.Index]
+		anchors = append(anchors, tapgarden.OwnedAnchor{
+			OutPoint:   op,
+			PkScript:   anchorOut.PkScript,
+			HeightHint: chainAsset.AnchorBlockHeight,
+		})
+	}
+
+	return anchors, nil
 }
 
 // FetchManagedUTXOs fetches all UTXOs we manage.
@@ -1348,8 +1635,13 @@ func (a *AssetStore) FetchAssetProofs(ctx context.Context,
 					return err
 				}
 
+				proofFile, err := a.cipher.Decrypt(p.ProofFile)
+				if err != nil {
+					return err
+				}
+
 				serializedKey := asset.ToSerialized(scriptKey)
-				proofs[serializedKey] = p.ProofFile
+				proofs[serializedKey] = proofFile
 			}
 
 			return nil
@@ -1386,8 +1678,15 @@ func (a *AssetStore) FetchAssetProofs(ctx context.Context,
 				return proof.ErrMultipleProofs
 			}
 
+			proofFile, err := a.cipher.Decrypt(
+				assetProofs[0].ProofFile,
+			)
+			if err != nil {
+				return err
+			}
+
 			serializedKey := asset.ToSerialized(&locator.ScriptKey)
-			proofs[serializedKey] = assetProofs[0].ProofFile
+			proofs[serializedKey] = proofFile
 		}
 		return nil
 	})
@@ -1434,9 +1733,11 @@ func (a *AssetStore) FetchProof(ctx context.Context,
 		// then we're fine. If there actually are multiple proofs, we
 		// require the user to specify the outpoint as well.
 		case len(assetProofs) == 1:
-			diskProof = assetProofs[0].ProofFile
+			diskProof, err = a.cipher.Decrypt(
+				assetProofs[0].ProofFile,
+			)
 
-			return nil
+			return err
 
 		// User needs to specify the outpoint as well, since we have
 		// multiple proofs for this script key.
@@ -1552,8 +1853,15 @@ func (a *AssetStore) FetchProofs(ctx context.Context,
 						"script key: %w", err)
 				}
 
+				proofFile, err := a.cipher.Decrypt(
+					dbRow.ProofFile,
+				)
+				if err != nil {
+					return nil, err
+				}
+
 				f := proof.File{}
-				err = f.Decode(bytes.NewReader(dbRow.ProofFile))
+				err = f.Decode(bytes.NewReader(proofFile))
 				if err != nil {
 					return nil, fmt.Errorf("error "+
 						"decoding proof file: %w", err)
@@ -1573,7 +1881,7 @@ func (a *AssetStore) FetchProofs(ctx context.Context,
 							lastProof.OutPoint(),
 						),
 					},
-					Blob: dbRow.ProofFile,
+					Blob: proofFile,
 				}, nil
 			},
 		)
@@ -1759,11 +2067,16 @@ func (a *AssetStore) importAssetFromProof(ctx context.Context,
 		return fmt.Errorf("unable to insert asset witness: %w", err)
 	}
 
+	proofFile, err := a.cipher.Encrypt(proof.Blob)
+	if err != nil {
+		return err
+	}
+
 	// Upload proof by the dbAssetId, which is the _primary key_ of the
 	// asset in table assets, not the BIPS concept of `asset_id`.
 	return db.UpsertAssetProofByID(ctx, ProofUpdateByID{
 		AssetID:   assetIDs[0],
-		ProofFile: proof.Blob,
+		ProofFile: proofFile,
 	})
 }
 
@@ -1825,11 +2138,16 @@ func (a *AssetStore) upsertAssetProof(ctx context.Context,
 			"ids %v", len(dbAssetIds), dbAssetIds)
 	}
 
+	proofFile, err := a.cipher.Encrypt(proof.Blob)
+	if err != nil {
+		return err
+	}
+
 	// Upload proof by the dbAssetId, which is the _primary key_ of the
 	// asset in table assets, not the BIPS concept of `asset_id`.
 	return db.UpsertAssetProofByID(ctx, ProofUpdateByID{
 		AssetID:   dbAssetIds[0],
-		ProofFile: proof.Blob,
+		ProofFile: proofFile,
 	})
 }
 
@@ -1876,6 +2194,13 @@ func (a *AssetStore) ImportProofs(ctx context.Context, _ proof.HeaderVerifier,
 	})
 	a.eventDistributor.NotifySubscribers(proofBlobs...)
 
+	for _, p := range proofs {
+		notifyDBEvent(
+			ctx, a.eventNotifier, DBEventChannelNewProof,
+			newProofPayload(p.Locator),
+		)
+	}
+
 	return nil
 }
 
@@ -1991,9 +2316,12 @@ func (a *AssetStore) ListEligibleCoins(ctx context.Context,
 
 	// First, we'll map the commitment constraints to our database query
 	// filters.
-	assetFilter := a.constraintsToDbFilter(&AssetQueryFilters{
+	assetFilter, err := a.constraintsToDbFilter(ctx, &AssetQueryFilters{
 		CommitmentConstraints: constraints,
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	// We only want to select unspent and non-leased commitments.
 	assetFilter.Spent = sqlBool(false)
@@ -2297,7 +2625,7 @@ func (a *AssetStore) LogPendingParcel(ctx context.Context,
 	anchorTxBytes := txBuf.Bytes()
 
 	var writeTxOpts AssetStoreTxOptions
-	return a.db.ExecTx(ctx, &writeTxOpts, func(q ActiveAssetsStore) error {
+	err := a.db.ExecTx(ctx, &writeTxOpts, func(q ActiveAssetsStore) error {
 		// First, we'll insert the new transaction that anchors the new
 		// anchor point (commits to the set of new outputs).
 		txnID, err := q.UpsertChainTx(ctx, ChainTxParams{
@@ -2342,7 +2670,7 @@ func (a *AssetStore) LogPendingParcel(ctx context.Context,
 					"required")
 			}
 
-			err = insertPassiveAssets(
+			err = a.insertPassiveAssets(
 				ctx, q, transferID, txnID,
 				spend.PassiveAssetsAnchor, spend.PassiveAssets,
 			)
@@ -2354,7 +2682,7 @@ func (a *AssetStore) LogPendingParcel(ctx context.Context,
 
 		// And then finally the outputs.
 		for idx := range spend.Outputs {
-			err = insertAssetTransferOutput(
+			err = a.insertAssetTransferOutput(
 				ctx, q, transferID, txnID, spend.Outputs[idx],
 			)
 			if err != nil {
@@ -2365,6 +2693,18 @@ func (a *AssetStore) LogPendingParcel(ctx context.Context,
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	notifyDBEvent(ctx, a.eventNotifier, DBEventChannelNewTransfer,
+		NewTransferPayload{
+			AnchorTxid: newAnchorTXID.String(),
+			Status:     TransferStatusPending,
+		},
+	)
+
+	return nil
 }
 
 // insertAssetTransferInput inserts a new asset transfer input into the DB.
@@ -2444,8 +2784,9 @@ func fetchAssetTransferInputs(ctx context.Context, q ActiveAssetsStore,
 // main difference between an active and passive asset on the database level is
 // that we do not create a new asset entry for the passive assets. Instead, we
 // simply re-anchor the existing asset entry to the new anchor point.
-func insertPassiveAssets(ctx context.Context, q ActiveAssetsStore,
-	transferID, txnID int64, anchor *tapfreighter.Anchor,
+func (a *AssetStore) insertPassiveAssets(ctx context.Context,
+	q ActiveAssetsStore, transferID, txnID int64,
+	anchor *tapfreighter.Anchor,
 	passiveAssets []*tappsbt.VPacket) error {
 
 	anchorPointBytes, err := encodeOutpoint(anchor.OutPoint)
@@ -2490,7 +2831,7 @@ func insertPassiveAssets(ctx context.Context, q ActiveAssetsStore,
 
 	// And now that we know the ID of that new anchor TX, we can
 	// store the passive assets, referencing that new UTXO.
-	err = logPendingPassiveAssets(
+	err = a.logPendingPassiveAssets(
 		ctx, q, transferID, newUtxoID, passiveAssets,
 	)
 	if err != nil {
@@ -2503,8 +2844,9 @@ func insertPassiveAssets(ctx context.Context, q ActiveAssetsStore,
 
 // insertAssetTransferOutput inserts a new asset transfer output into the DB
 // and returns its ID.
-func insertAssetTransferOutput(ctx context.Context, q ActiveAssetsStore,
-	transferID, txnID int64, output tapfreighter.TransferOutput) error {
+func (a *AssetStore) insertAssetTransferOutput(ctx context.Context,
+	q ActiveAssetsStore, transferID, txnID int64,
+	output tapfreighter.TransferOutput) error {
 
 	anchor := output.Anchor
 	anchorPointBytes, err := encodeOutpoint(anchor.OutPoint)
@@ -2602,6 +2944,11 @@ func insertAssetTransferOutput(ctx context.Context, q ActiveAssetsStore,
 	}
 	position := int32(output.Position)
 
+	proofSuffix, err := a.cipher.Encrypt(output.ProofSuffix)
+	if err != nil {
+		return err
+	}
+
 	dbOutput := NewTransferOutput{
 		TransferID:            transferID,
 		AnchorUtxo:            newUtxoID,
@@ -2612,11 +2959,13 @@ func insertAssetTransferOutput(ctx context.Context, q ActiveAssetsStore,
 		RelativeLockTime:      sqlInt32(output.RelativeLockTime),
 		AssetVersion:          int32(output.AssetVersion),
 		SerializedWitnesses:   witnessBuf.Bytes(),
-		ProofSuffix:           output.ProofSuffix,
+		ProofSuffix:           proofSuffix,
 		NumPassiveAssets:      int32(output.Anchor.NumPassiveAssets),
 		OutputType:            int16(output.Type),
 		ProofCourierAddr:      output.ProofCourierAddr,
 		ProofDeliveryComplete: proofDeliveryComplete,
+		ProofDeliverySkipped:  output.ProofDeliverySkipped,
+		ProofCourierMode:      int16(output.ProofCourierMode),
 		Position:              position,
 	}
 
@@ -2636,12 +2985,28 @@ func insertAssetTransferOutput(ctx context.Context, q ActiveAssetsStore,
 		return fmt.Errorf("unable to insert transfer output: %w", err)
 	}
 
+	for priority, delivery := range output.ProofCourierDeliveries {
+		err = q.InsertTransferOutputProofCourier(
+			ctx, NewOutputProofCourier{
+				TransferID:  transferID,
+				Position:    position,
+				Priority:    int32(priority),
+				CourierAddr: delivery.CourierAddr,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to insert transfer output "+
+				"proof courier: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // fetchAssetTransferOutputs fetches all the outputs for a given transfer ID.
-func fetchAssetTransferOutputs(ctx context.Context, q ActiveAssetsStore,
-	transferID int64) ([]tapfreighter.TransferOutput, error) {
+func (a *AssetStore) fetchAssetTransferOutputs(ctx context.Context,
+	q ActiveAssetsStore, transferID int64) ([]tapfreighter.TransferOutput,
+	error) {
 
 	dbOutputs, err := q.FetchTransferOutputs(ctx, transferID)
 	if err != nil {
@@ -2738,6 +3103,9 @@ func fetchAssetTransferOutputs(ctx context.Context, q ActiveAssetsStore,
 		}
 
 		vOutputType := tappsbt.VOutputType(dbOut.OutputType)
+		courierMode := tapfreighter.ProofCourierMode(
+			dbOut.ProofCourierMode,
+		)
 
 		// Ensure the position value is valid.
 		if dbOut.Position < 0 {
@@ -2745,6 +3113,11 @@ func fetchAssetTransferOutputs(ctx context.Context, q ActiveAssetsStore,
 				"db: %d", dbOut.Position)
 		}
 
+		proofSuffix, err := a.cipher.Decrypt(dbOut.ProofSuffix)
+		if err != nil {
+			return nil, err
+		}
+
 		outputs[idx] = tapfreighter.TransferOutput{
 			Anchor:           outputAnchor,
 			Amount:           uint64(dbOut.Amount),
@@ -2768,13 +3141,38 @@ func fetchAssetTransferOutputs(ctx context.Context, q ActiveAssetsStore,
 				splitRootHash,
 				uint64(dbOut.SplitCommitmentRootValue.Int64),
 			),
-			ProofSuffix:           dbOut.ProofSuffix,
+			ProofSuffix:           proofSuffix,
 			Type:                  vOutputType,
 			ProofCourierAddr:      dbOut.ProofCourierAddr,
 			ProofDeliveryComplete: proofDeliveryComplete,
+			ProofDeliverySkipped:  dbOut.ProofDeliverySkipped,
+			ProofCourierMode:      courierMode,
 			Position:              uint64(dbOut.Position),
 		}
 
+		dbCouriers, err := q.FetchTransferOutputProofCouriers(
+			ctx, dbOut.OutputID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch transfer "+
+				"output proof couriers: %w", err)
+		}
+		for _, dbCourier := range dbCouriers {
+			delivery := tapfreighter.ProofCourierDelivery{
+				CourierAddr: dbCourier.CourierAddr,
+				Delivered:   dbCourier.Delivered,
+				LastError:   dbCourier.LastError,
+			}
+			if dbCourier.LastAttemptTime.Valid {
+				delivery.LastAttempt =
+					dbCourier.LastAttemptTime.Time.UTC()
+			}
+
+			outputs[idx].ProofCourierDeliveries = append(
+				outputs[idx].ProofCourierDeliveries, delivery,
+			)
+		}
+
 		err = readOutPoint(
 			bytes.NewReader(dbOut.AnchorOutpoint), 0, 0,
 			&outputs[idx].Anchor.OutPoint,
@@ -2789,7 +3187,7 @@ func fetchAssetTransferOutputs(ctx context.Context, q ActiveAssetsStore,
 }
 
 // logPendingPassiveAssets logs passive assets re-anchoring data to disk.
-func logPendingPassiveAssets(ctx context.Context,
+func (a *AssetStore) logPendingPassiveAssets(ctx context.Context,
 	q ActiveAssetsStore, transferID, newUtxoID int64,
 	passiveAssets []*tappsbt.VPacket) error {
 
@@ -2824,6 +3222,11 @@ func logPendingPassiveAssets(ctx context.Context,
 				"asset proof: %w", err)
 		}
 
+		newProof, err := a.cipher.Encrypt(newProofBuf.Bytes())
+		if err != nil {
+			return err
+		}
+
 		// Encode previous anchor outpoint.
 		prevOutpointBytes, err := encodeOutpoint(
 			passiveIn.PrevID.OutPoint,
@@ -2842,7 +3245,7 @@ func logPendingPassiveAssets(ctx context.Context,
 				TransferID:      transferID,
 				NewAnchorUtxo:   newUtxoID,
 				NewWitnessStack: newWitnessBuf.Bytes(),
-				NewProof:        newProofBuf.Bytes(),
+				NewProof:        newProof,
 				PrevOutpoint:    prevOutpointBytes,
 				ScriptKey:       scriptKeyBytes,
 				AssetGenesisID:  passiveIn.PrevID.ID[:],
@@ -2960,6 +3363,55 @@ func (a *AssetStore) ConfirmProofDelivery(ctx context.Context,
 	return nil
 }
 
+// LogProofCourierDelivery records the outcome of an attempt to deliver a
+// transfer output proof to the proof courier with the given priority. A nil
+// error marks the proof as delivered to that courier.
+func (a *AssetStore) LogProofCourierDelivery(ctx context.Context,
+	anchorOutpoint wire.OutPoint, outputPosition uint64, priority uint32,
+	deliveryErr error, attemptTime time.Time) error {
+
+	anchorOutpointBytes, err := encodeOutpoint(anchorOutpoint)
+	if err != nil {
+		return fmt.Errorf("unable to encode anchor outpoint: %w", err)
+	}
+
+	// Ensure that the position and priority values can be stored in a
+	// 32-bit integer.
+	if outputPosition > math.MaxInt32 {
+		return fmt.Errorf("position value is too large for db: %d",
+			outputPosition)
+	}
+	if priority > math.MaxInt32 {
+		return fmt.Errorf("priority value is too large for db: %d",
+			priority)
+	}
+
+	params := OutputProofCourierStatus{
+		Delivered: deliveryErr == nil,
+		LastAttemptTime: sql.NullTime{
+			Time:  attemptTime.UTC(),
+			Valid: true,
+		},
+		SerializedAnchorOutpoint: anchorOutpointBytes,
+		Position:                 int32(outputPosition),
+		Priority:                 int32(priority),
+	}
+	if deliveryErr != nil {
+		params.LastError = deliveryErr.Error()
+	}
+
+	var writeTxOpts AssetStoreTxOptions
+	err = a.db.ExecTx(ctx, &writeTxOpts, func(q ActiveAssetsStore) error {
+		return q.SetTransferOutputProofCourierStatus(ctx, params)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log transfer output proof "+
+			"courier delivery in db: %w", err)
+	}
+
+	return nil
+}
+
 // LogAnchorTxConfirm updates the send package state on disk to reflect the
 // confirmation of the anchor transaction, ensuring the on-chain reference
 // information is up to date.
@@ -3125,12 +3577,17 @@ func (a *AssetStore) LogAnchorTxConfirm(ctx context.Context,
 			}
 			localProofKeys = append(localProofKeys, scriptKey)
 
+			proofFile, err := a.cipher.Encrypt(receiverProof.Blob)
+			if err != nil {
+				return err
+			}
+
 			// Upload proof by the dbAssetId, which is the _primary
 			// key_ of the asset in table assets, not the BIPS
 			// concept of `asset_id`.
 			err = q.UpsertAssetProofByID(ctx, ProofUpdateByID{
 				AssetID:   newAssetID,
-				ProofFile: receiverProof.Blob,
+				ProofFile: proofFile,
 			})
 
 			if err != nil {
@@ -3209,9 +3666,146 @@ func (a *AssetStore) LogAnchorTxConfirm(ctx context.Context,
 		}
 	}
 
+	// Let other components sharing the database know about the confirmed
+	// transfer and the new proofs.
+	notifyDBEvent(ctx, a.eventNotifier, DBEventChannelNewTransfer,
+		NewTransferPayload{
+			AnchorTxid: conf.AnchorTXID.String(),
+			Status:     TransferStatusConfirmed,
+		},
+	)
+	for idx := range localProofKeys {
+		finalProof := conf.FinalProofs[localProofKeys[idx]]
+		notifyDBEvent(
+			ctx, a.eventNotifier, DBEventChannelNewProof,
+			newProofPayload(finalProof.Locator),
+		)
+	}
+	for assetID := range conf.PassiveAssetProofFiles {
+		passiveProofs := conf.PassiveAssetProofFiles[assetID]
+		for _, passiveProof := range passiveProofs {
+			notifyDBEvent(
+				ctx, a.eventNotifier, DBEventChannelNewProof,
+				newProofPayload(passiveProof.Locator),
+			)
+		}
+	}
+
+	return nil
+}
+
+// LogAnchorTxReplacement replaces the unconfirmed anchor transaction of a
+// pending transfer with the given replacement transaction, for example one
+// that was fee bumped through RBF. The managed UTXOs created by the original
+// transaction are moved to the output with the same index of the replacement.
+func (a *AssetStore) LogAnchorTxReplacement(ctx context.Context,
+	oldTxid chainhash.Hash, newTx *wire.MsgTx) error {
+
+	var txBuf bytes.Buffer
+	if err := newTx.Serialize(&txBuf); err != nil {
+		return err
+	}
+	newTxid := newTx.TxHash()
+
+	var writeTxOpts AssetStoreTxOptions
+	err := a.db.ExecTx(ctx, &writeTxOpts, func(q ActiveAssetsStore) error {
+		chainTx, err := q.FetchChainTx(ctx, oldTxid[:])
+		if err != nil {
+			return fmt.Errorf("unable to fetch anchor tx: %w", err)
+		}
+
+		// A confirmed transaction can't be replaced anymore.
+		if chainTx.BlockHeight.Valid {
+			return fmt.Errorf("anchor tx %v is already confirmed",
+				oldTxid)
+		}
+
+		err = q.ReplaceChainAnchorTx(ctx, AnchorTxReplacement{
+			NewTxid: newTxid[:],
+			RawTx:   txBuf.Bytes(),
+			OldTxid: oldTxid[:],
+		})
+		if err != nil {
+			return fmt.Errorf("unable to replace anchor tx: %w",
+				err)
+		}
+
+		for idx, txOut := range newTx.TxOut {
+			oldOutpoint, err := encodeOutpoint(wire.OutPoint{
+				Hash:  oldTxid,
+				Index: uint32(idx),
+			})
+			if err != nil {
+				return err
+			}
+			newOutpoint, err := encodeOutpoint(wire.OutPoint{
+				Hash:  newTxid,
+				Index: uint32(idx),
+			})
+			if err != nil {
+				return err
+			}
+
+			// Outputs that aren't managed by us simply don't
+			// match any row.
+			err = q.UpdateManagedUTXOOutpoint(
+				ctx, ManagedUTXOReplacement{
+					NewOutpoint: newOutpoint,
+					AmtSats:     txOut.Value,
+					OldOutpoint: oldOutpoint,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("unable to update managed "+
+					"UTXO: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace anchor tx: %w", err)
+	}
+
 	return nil
 }
 
+// MarkAssetsSpent marks the assets identified by the given previous IDs as
+// spent. Assets that are unknown to the store are skipped, so marking assets
+// as spent is idempotent.
+func (a *AssetStore) MarkAssetsSpent(ctx context.Context,
+	spent []asset.PrevID) error {
+
+	var writeTxOpts AssetStoreTxOptions
+	return a.db.ExecTx(ctx, &writeTxOpts, func(q ActiveAssetsStore) error {
+		for idx := range spent {
+			prevID := spent[idx]
+			anchorPoint, err := encodeOutpoint(prevID.OutPoint)
+			if err != nil {
+				return fmt.Errorf("unable to encode outpoint: "+
+					"%w", err)
+			}
+
+			_, err = q.SetAssetSpent(ctx, SetAssetSpentParams{
+				ScriptKey:   prevID.ScriptKey[:],
+				GenAssetID:  prevID.ID[:],
+				AnchorPoint: anchorPoint,
+			})
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				log.Debugf("Asset %v to mark as spent not "+
+					"found", prevID.ID)
+
+			case err != nil:
+				return fmt.Errorf("unable to set asset spent: "+
+					"%w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
 // reAnchorPassiveAssets re-anchors all passive assets that were anchored by
 // the given transfer output.
 func (a *AssetStore) reAnchorPassiveAssets(ctx context.Context,
@@ -3279,9 +3873,13 @@ func (a *AssetStore) reAnchorPassiveAssets(ctx context.Context,
 		}
 
 		// Update the asset proof.
+		encryptedProof, err := a.cipher.Encrypt(proofFile)
+		if err != nil {
+			return err
+		}
 		err = q.UpsertAssetProofByID(ctx, ProofUpdateByID{
 			AssetID:   passiveAsset.AssetID,
-			ProofFile: proofFile,
+			ProofFile: encryptedProof,
 		})
 		if err != nil {
 			return fmt.Errorf("unable to update passive asset "+
@@ -3320,6 +3918,26 @@ func (a *AssetStore) QueryParcels(ctx context.Context,
 	anchorTxHash *chainhash.Hash,
 	pendingTransfersOnly bool) ([]*tapfreighter.OutboundParcel, error) {
 
+	return a.QueryAccountParcels(
+		ctx, anchorTxHash, pendingTransfersOnly,
+		fn.None[account.Account](),
+	)
+}
+
+// QueryAccountParcels returns the set of confirmed or unconfirmed parcels. If
+// an account is given, only the parcels that spent assets of that account are
+// returned.
+func (a *AssetStore) QueryAccountParcels(ctx context.Context,
+	anchorTxHash *chainhash.Hash, pendingTransfersOnly bool,
+	acct fn.Option[account.Account]) ([]*tapfreighter.OutboundParcel,
+	error) {
+
+	// Restrict the transfers to the given account, if any.
+	keyFamily, excludeAccountKeys, err := accountKeyFilters(ctx, acct)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		outboundParcels []*tapfreighter.OutboundParcel
 		readOpts        = NewAssetStoreReadTx()
@@ -3337,6 +3955,8 @@ func (a *AssetStore) QueryParcels(ctx context.Context,
 		transferQuery := TransferQuery{
 			AnchorTxHash:         anchorTxHashBytes,
 			PendingTransfersOnly: sqlBool(pendingTransfersOnly),
+			ScriptKeyFamily:      keyFamily,
+			ExcludeAccountKeys:   excludeAccountKeys,
 		}
 
 		// Query for asset transfers.
@@ -3355,7 +3975,7 @@ func (a *AssetStore) QueryParcels(ctx context.Context,
 					"inputs: %w", err)
 			}
 
-			outputs, err := fetchAssetTransferOutputs(
+			outputs, err := a.fetchAssetTransferOutputs(
 				ctx, q, dbT.ID,
 			)
 			if err != nil {
@@ -3407,6 +4027,10 @@ func (a *AssetStore) QueryParcels(ctx context.Context,
 				Inputs:             inputs,
 				Outputs:            outputs,
 			}
+			parcel.AnchorTxBlockHeight = uint32(
+				dbAnchorTx.BlockHeight.Int32,
+			)
+
 			outboundParcels = append(outboundParcels, parcel)
 		}
 
@@ -3561,6 +4185,35 @@ func (a *AssetStore) TxHeight(ctx context.Context, txid chainhash.Hash) (uint32,
 	return uint32(dbBlockHeight), nil
 }
 
+// TxBlock returns the hash and height of the block that contains the given
+// transaction. If the transaction is known to the store but not yet
+// confirmed, the zero hash and height are returned.
+func (a *AssetStore) TxBlock(ctx context.Context,
+	txid chainhash.Hash) (chainhash.Hash, uint32, error) {
+
+	var (
+		blockHash   chainhash.Hash
+		blockHeight uint32
+	)
+	readOpts := NewAssetStoreReadTx()
+	dbErr := a.db.ExecTx(ctx, &readOpts, func(q ActiveAssetsStore) error {
+		dbTx, err := q.FetchChainTx(ctx, txid[:])
+		if err != nil {
+			return err
+		}
+
+		copy(blockHash[:], dbTx.BlockHash)
+		blockHeight = uint32(dbTx.BlockHeight.Int32)
+
+		return nil
+	})
+	if dbErr != nil {
+		return chainhash.Hash{}, 0, dbErr
+	}
+
+	return blockHash, blockHeight, nil
+}
+
 // QueryBurns queries burnt assets based on the passed filters.
 func (a *AssetStore) QueryBurns(ctx context.Context,
 	filters QueryBurnsFilters) ([]*tapfreighter.AssetBurn, error) {
@@ -3615,3 +4268,7 @@ var _ tapfreighter.CoinLister = (*AssetStore)(nil)
 // A compile-time constraint to ensure that AssetStore meets the
 // tapfreighter.ExportLog interface.
 var _ tapfreighter.ExportLog = (*AssetStore)(nil)
+
+// A compile-time constraint to ensure that AssetStore meets the
+// tapgarden.AnchorLister interface.
+var _ tapgarden.AnchorLister = (*AssetStore)(nil)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"errors"
 	"math/rand"
 	"sort"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -856,6 +858,153 @@ func TestFetchAllAssets(t *testing.T) {
 	}
 }
 
+// TestFetchAssetPage tests that assets can be fetched sorted and page by page,
+// with the filters applied by the database.
+func TestFetchAssetPage(t *testing.T) {
+	t.Parallel()
+
+	const numAssets = 6
+
+	ctx := context.Background()
+	assetGen := newAssetGenerator(t, numAssets, 2)
+
+	// Some assets have the same amount, to make sure the primary key is
+	// used as the tie breaker.
+	amounts := []uint64{50, 10, 30, 10, 90, 30}
+	assetDescs := make([]assetDesc, numAssets)
+	for i, amt := range amounts {
+		assetDescs[i] = assetDesc{
+			assetGen:    assetGen.assetGens[i],
+			anchorPoint: assetGen.anchorPoints[i],
+			amt:         amt,
+			spent:       i == 4,
+		}
+	}
+
+	_, assetsStore, _ := newAssetStore(t)
+	assetGen.genAssets(t, assetsStore, assetDescs)
+
+	// fetchPages fetches all assets matching the filters, page by page.
+	fetchPages := func(query AssetQueryFilters,
+		includeSpent bool) []*asset.ChainAsset {
+
+		var (
+			all   []*asset.ChainAsset
+			pages int
+		)
+		for {
+			page, cursor, err := assetsStore.FetchAssetPage(
+				ctx, includeSpent, false, query,
+			)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), int(query.Limit))
+
+			all = append(all, page...)
+			pages++
+			require.LessOrEqual(t, pages, numAssets)
+
+			if cursor == nil {
+				return all
+			}
+
+			// The cursor must survive the round trip through its
+			// encoding.
+			query.Cursor, err = DecodeAssetCursor(cursor.Encode())
+			require.NoError(t, err)
+			require.Equal(t, cursor, query.Cursor)
+		}
+	}
+
+	amountsOf := func(assets []*asset.ChainAsset) []uint64 {
+		return fn.Map(assets, func(a *asset.ChainAsset) uint64 {
+			return a.Amount
+		})
+	}
+
+	// Sorted by amount, all unspent assets are returned across the pages.
+	byAmount := fetchPages(AssetQueryFilters{
+		SortBy: AssetSortByAmount,
+		Limit:  2,
+	}, false)
+	require.Equal(t, []uint64{10, 10, 30, 30, 50}, amountsOf(byAmount))
+
+	byAmountDesc := fetchPages(AssetQueryFilters{
+		SortBy:         AssetSortByAmount,
+		SortDescending: true,
+		Limit:          4,
+	}, true)
+	require.Equal(
+		t, []uint64{90, 50, 30, 30, 10, 10}, amountsOf(byAmountDesc),
+	)
+
+	// Assets with the same amount are returned in the reverse order when
+	// sorting in descending order.
+	require.Equal(t, byAmount[0].ID(), byAmountDesc[5].ID())
+	require.Equal(t, byAmount[1].ID(), byAmountDesc[4].ID())
+
+	// Sorted by asset ID, the IDs are strictly increasing.
+	byID := fetchPages(AssetQueryFilters{
+		SortBy: AssetSortByAssetID,
+		Limit:  1,
+	}, true)
+	require.Len(t, byID, numAssets)
+	for i := 1; i < len(byID); i++ {
+		prevID, id := byID[i-1].ID(), byID[i].ID()
+		require.Negative(t, bytes.Compare(prevID[:], id[:]))
+	}
+
+	// Sorted by anchor height, the heights are increasing.
+	byHeight := fetchPages(AssetQueryFilters{
+		SortBy: AssetSortByAnchorHeight,
+		Limit:  3,
+	}, true)
+	require.Len(t, byHeight, numAssets)
+	for i := 1; i < len(byHeight); i++ {
+		require.LessOrEqual(
+			t, byHeight[i-1].AnchorBlockHeight,
+			byHeight[i].AnchorBlockHeight,
+		)
+	}
+
+	// Without a limit, all matching assets are returned in one page.
+	all, cursor, err := assetsStore.FetchAssetPage(
+		ctx, false, false, AssetQueryFilters{
+			CommitmentConstraints: tapfreighter.CommitmentConstraints{
+				MinAmt:         30,
+				CoinSelectType: tapsend.ScriptTreesAllowed,
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+	require.Len(t, all, 3)
+
+	// The spent filter is applied by the database.
+	spent := fetchPages(AssetQueryFilters{
+		OnlySpent: true,
+		Limit:     2,
+	}, false)
+	require.Equal(t, []uint64{90}, amountsOf(spent))
+
+	// None of the assets is unconfirmed.
+	unconfirmed, _, err := assetsStore.FetchAssetPage(
+		ctx, true, true, AssetQueryFilters{
+			MaxAnchorHeight: fn.Some[int32](0),
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, unconfirmed)
+
+	// A cursor can't be used with a different sort order.
+	_, _, err = assetsStore.FetchAssetPage(
+		ctx, false, false, AssetQueryFilters{
+			SortBy: AssetSortByAssetID,
+			Cursor: &AssetCursor{SortBy: AssetSortByAmount},
+		},
+	)
+	require.ErrorContains(t, err, "different sort order")
+}
+
 // TestUTXOLeases tests that we're able to properly lease UTXOs in the DB,
 // update and then remove them again.
 func TestUTXOLeases(t *testing.T) {
@@ -979,6 +1128,9 @@ func TestSelectCommitment(t *testing.T) {
 
 	assetGen := newAssetGenerator(t, numAssetIDs, numGroupKeys)
 	inOneHour := time.Now().Add(time.Hour)
+	filterScriptKey := asset.NewScriptKeyBip86(keychain.KeyDescriptor{
+		PubKey: test.RandPubKey(t),
+	})
 
 	testCases := []struct {
 		name string
@@ -1159,6 +1311,37 @@ func TestSelectCommitment(t *testing.T) {
 			numAssets: 2,
 			sum:       30,
 		},
+
+		// Create two assets of the same asset ID, but only one of them
+		// is locked to the script key of the constraints. We should
+		// only get that one back.
+		{
+			name: "script key filter",
+			assets: []assetDesc{
+				{
+					assetGen: assetGen.assetGens[0],
+					amt:      8,
+
+					anchorPoint: assetGen.anchorPoints[0],
+					scriptKey:   &filterScriptKey,
+				},
+				{
+					assetGen: assetGen.assetGens[0],
+					amt:      10,
+
+					anchorPoint: assetGen.anchorPoints[1],
+				},
+			},
+			constraints: tapfreighter.CommitmentConstraints{
+				AssetSpecifier: assetGen.assetSpecifierAssetID(
+					0, assetGen.anchorPoints[0],
+				),
+				MinAmt:    1,
+				ScriptKey: filterScriptKey.PubKey,
+			},
+			numAssets: 1,
+			sum:       8,
+		},
 	}
 
 	ctx := context.Background()
@@ -1634,6 +1817,145 @@ func TestAssetExportLog(t *testing.T) {
 	require.Len(t, parcels, 0)
 }
 
+// TestLogAnchorTxReplacement tests that the unconfirmed anchor transaction of
+// a pending transfer can be replaced by a new version of the transaction,
+// which moves the anchor outputs of the transfer to the replacement.
+func TestLogAnchorTxReplacement(t *testing.T) {
+	t.Parallel()
+
+	_, assetsStore, db := newAssetStore(t)
+	ctx := context.Background()
+
+	assetGen := newAssetGenerator(t, 1, 1)
+	assetGen.genAssets(t, assetsStore, []assetDesc{{
+		assetGen:    assetGen.assetGens[0],
+		anchorPoint: assetGen.anchorPoints[0],
+		amt:         16,
+	}})
+
+	allAssets, err := assetsStore.FetchAllAssets(ctx, true, false, nil)
+	require.NoError(t, err)
+	require.Len(t, allAssets, 1)
+	inputAsset := allAssets[0]
+
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: assetGen.anchorPoints[0],
+		SignatureScript:  []byte{},
+	})
+	anchorTx.AddTxOut(&wire.TxOut{
+		PkScript: bytes.Repeat([]byte{0x01}, 34),
+		Value:    1000,
+	})
+	anchorTx.AddTxOut(&wire.TxOut{
+		PkScript: bytes.Repeat([]byte{0x02}, 22),
+		Value:    5000,
+	})
+	anchorTxHash := anchorTx.TxHash()
+
+	newScriptKey := asset.NewScriptKeyBip86(keychain.KeyDescriptor{
+		PubKey: test.RandPubKey(t),
+		KeyLocator: keychain.KeyLocator{
+			Index:  uint32(rand.Int31()),
+			Family: keychain.KeyFamily(rand.Int31()),
+		},
+	})
+
+	parcel := &tapfreighter.OutboundParcel{
+		AnchorTx:           anchorTx,
+		AnchorTxHeightHint: 1450,
+		ChainFees:          100,
+		Inputs: []tapfreighter.TransferInput{{
+			PrevID: asset.PrevID{
+				OutPoint: assetGen.anchorPoints[0],
+				ID:       inputAsset.ID(),
+				ScriptKey: asset.ToSerialized(
+					inputAsset.ScriptKey.PubKey,
+				),
+			},
+			Amount: inputAsset.Amount,
+		}},
+		Outputs: []tapfreighter.TransferOutput{{
+			Anchor: tapfreighter.Anchor{
+				Value: 1000,
+				OutPoint: wire.OutPoint{
+					Hash:  anchorTxHash,
+					Index: 0,
+				},
+				InternalKey: keychain.KeyDescriptor{
+					PubKey: test.RandPubKey(t),
+				},
+				TaprootAssetRoot: bytes.Repeat([]byte{0x1}, 32),
+				MerkleRoot:       bytes.Repeat([]byte{0x1}, 32),
+			},
+			ScriptKey:      newScriptKey,
+			ScriptKeyLocal: true,
+			Amount:         inputAsset.Amount,
+			WitnessData: []asset.Witness{{
+				PrevID:    &asset.PrevID{},
+				TxWitness: [][]byte{{0x01}, {0x02}},
+			}},
+			SplitCommitmentRoot: mssmt.NewComputedNode(
+				sha256.Sum256([]byte("kek")), 100,
+			),
+			ProofSuffix: bytes.Repeat([]byte{0x01}, 100),
+		}},
+	}
+	require.NoError(t, assetsStore.LogPendingParcel(
+		ctx, parcel, fn.ToArray[[32]byte](test.RandBytes(32)),
+		time.Now().Add(time.Hour),
+	))
+
+	// The replacement pays a higher fee from the BTC change output.
+	replacement := anchorTx.Copy()
+	replacement.TxIn[0].SignatureScript = []byte{}
+	replacement.TxOut[1].Value = 4000
+	replacementHash := replacement.TxHash()
+
+	err = assetsStore.LogAnchorTxReplacement(ctx, anchorTxHash, replacement)
+	require.NoError(t, err)
+
+	// The pending parcel should now reference the replacement.
+	parcel.ReplaceAnchorTx(replacement)
+	parcels, err := assetsStore.PendingParcels(ctx)
+	require.NoError(t, err)
+	require.Len(t, parcels, 1)
+	require.Equal(t, parcel, parcels[0])
+	require.Equal(t, replacementHash, parcels[0].AnchorTx.TxHash())
+
+	parcels, err = assetsStore.QueryParcels(ctx, &anchorTxHash, true)
+	require.NoError(t, err)
+	require.Empty(t, parcels)
+
+	// The managed UTXO of the transfer output was moved to the
+	// replacement.
+	utxos, err := assetsStore.FetchManagedUTXOs(ctx)
+	require.NoError(t, err)
+	newOutPoint := wire.OutPoint{Hash: replacementHash, Index: 0}
+	require.True(t, fn.Any(utxos, func(u *ManagedUTXO) bool {
+		return u.OutPoint == newOutPoint
+	}))
+
+	// The original transaction no longer exists, so it can't be replaced
+	// again.
+	err = assetsStore.LogAnchorTxReplacement(ctx, anchorTxHash, replacement)
+	require.Error(t, err)
+
+	// A confirmed transaction can't be replaced.
+	err = db.ConfirmChainAnchorTx(ctx, AnchorTxConf{
+		Txid:        replacementHash[:],
+		BlockHash:   test.RandBytes(32),
+		BlockHeight: sqlInt32(100),
+		TxIndex:     sqlInt32(1),
+	})
+	require.NoError(t, err)
+
+	err = assetsStore.LogAnchorTxReplacement(
+		ctx, replacementHash, anchorTx,
+	)
+	require.ErrorContains(t, err, "already confirmed")
+}
+
 // TestAssetGroupWitnessUpsert tests that if you try to insert another asset
 // group witness with the same asset_gen_id, then only one is actually created.
 func TestAssetGroupWitnessUpsert(t *testing.T) {
@@ -1994,8 +2316,15 @@ func TestTransferOutputProofDeliveryStatus(t *testing.T) {
 		SplitCommitment: nil,
 	}
 
-	// Mock proof courier address.
+	// Mock proof courier addresses.
 	proofCourierAddrBytes := []byte("universerpc://localhost:10009")
+	backupCourierAddrBytes := []byte("universerpc://localhost:10010")
+	courierModeAll := tapfreighter.ProofCourierModeAll
+	courierDeliveries := []tapfreighter.ProofCourierDelivery{{
+		CourierAddr: proofCourierAddrBytes,
+	}, {
+		CourierAddr: backupCourierAddrBytes,
+	}}
 
 	// Fetch the asset that was previously generated.
 	allAssets, err := assetsStore.FetchAllAssets(ctx, true, false, nil)
@@ -2043,18 +2372,20 @@ func TestTransferOutputProofDeliveryStatus(t *testing.T) {
 				TaprootAssetRoot: bytes.Repeat([]byte{0x1}, 32),
 				MerkleRoot:       bytes.Repeat([]byte{0x1}, 32),
 			},
-			ScriptKey:             newScriptKey,
-			ScriptKeyLocal:        false,
-			Amount:                uint64(newAmt),
-			LockTime:              1337,
-			RelativeLockTime:      31337,
-			WitnessData:           []asset.Witness{newWitness},
-			SplitCommitmentRoot:   nil,
-			AssetVersion:          asset.V0,
-			ProofSuffix:           receiverBlob,
-			ProofCourierAddr:      proofCourierAddrBytes,
-			ProofDeliveryComplete: fn.Some[bool](false),
-			Position:              0,
+			ScriptKey:              newScriptKey,
+			ScriptKeyLocal:         false,
+			Amount:                 uint64(newAmt),
+			LockTime:               1337,
+			RelativeLockTime:       31337,
+			WitnessData:            []asset.Witness{newWitness},
+			SplitCommitmentRoot:    nil,
+			AssetVersion:           asset.V0,
+			ProofSuffix:            receiverBlob,
+			ProofCourierAddr:       proofCourierAddrBytes,
+			ProofDeliveryComplete:  fn.Some[bool](false),
+			ProofCourierMode:       courierModeAll,
+			ProofCourierDeliveries: courierDeliveries,
+			Position:               0,
 		}, {
 			Anchor: tapfreighter.Anchor{
 				Value: 1000,
@@ -2084,6 +2415,10 @@ func TestTransferOutputProofDeliveryStatus(t *testing.T) {
 			AssetVersion:        asset.V1,
 			ProofSuffix:         senderBlob,
 			Position:            1,
+
+			// The sender delivers the proof of this output out of
+			// band.
+			ProofDeliverySkipped: true,
 		}},
 	}
 
@@ -2143,6 +2478,50 @@ func TestTransferOutputProofDeliveryStatus(t *testing.T) {
 		t, sql.NullBool{}, transferOutputs[1].ProofDeliveryComplete,
 	)
 
+	// Only the delivery of the second output's proof was skipped.
+	require.False(t, transferOutputs[0].ProofDeliverySkipped)
+	require.True(t, transferOutputs[1].ProofDeliverySkipped)
+
+	// The proof of the first output is delivered to both of its proof
+	// couriers, while the second output doesn't have any.
+	require.EqualValues(
+		t, tapfreighter.ProofCourierModeAll,
+		transferOutputs[0].ProofCourierMode,
+	)
+	couriers, err := db.FetchTransferOutputProofCouriers(
+		ctx, transferOutputs[1].OutputID,
+	)
+	require.NoError(t, err)
+	require.Empty(t, couriers)
+
+	// We now record a failed delivery to the backup courier and a
+	// successful one to the primary courier.
+	anchorPoint := spendDelta.Outputs[0].Anchor.OutPoint
+	attemptTime := time.Unix(1700000000, 0)
+	require.NoError(t, assetsStore.LogProofCourierDelivery(
+		ctx, anchorPoint, 0, 1, errors.New("courier offline"),
+		attemptTime,
+	))
+	require.NoError(t, assetsStore.LogProofCourierDelivery(
+		ctx, anchorPoint, 0, 0, nil, attemptTime,
+	))
+
+	pendingParcels, err := assetsStore.PendingParcels(ctx)
+	require.NoError(t, err)
+	require.Len(t, pendingParcels, 1)
+	require.Equal(
+		t, []tapfreighter.ProofCourierDelivery{{
+			CourierAddr: proofCourierAddrBytes,
+			Delivered:   true,
+			LastAttempt: attemptTime.UTC(),
+		}, {
+			CourierAddr: backupCourierAddrBytes,
+			LastError:   "courier offline",
+			LastAttempt: attemptTime.UTC(),
+		}}, pendingParcels[0].Outputs[0].ProofCourierDeliveries,
+	)
+	require.Empty(t, pendingParcels[0].Outputs[1].ProofCourierDeliveries)
+
 	// We will now set the status of the transfer output proof to
 	// "delivered".
 	//
@@ -2205,6 +2584,12 @@ func TestTransferOutputProofDeliveryStatus(t *testing.T) {
 	require.Equal(
 		t, randBlockHash[:], assetTransfers[0].AnchorTxBlockHash,
 	)
+
+	// The parcel should also carry the height of the confirming block.
+	parcels, err := assetsStore.QueryParcels(ctx, nil, false)
+	require.NoError(t, err)
+	require.Len(t, parcels, 1)
+	require.EqualValues(t, 441, parcels[0].AnchorTxBlockHeight)
 }
 
 func TestQueryAssetBurns(t *testing.T) {
@@ -2377,6 +2762,10 @@ func TestQueryAssetBurns(t *testing.T) {
 			AssetVersion:        asset.V1,
 			ProofSuffix:         senderBlob,
 			Position:            1,
+
+			// The sender delivers the proof of this output out of
+			// band.
+			ProofDeliverySkipped: true,
 		}},
 	}
 
@@ -2531,11 +2920,11 @@ func TestQueryAssetBalances(t *testing.T) {
 	// At first, none of the assets should be leased.
 	includeLeased := false
 	balances, err := assetsStore.QueryBalancesByAsset(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	balancesByGroup, err := assetsStore.QueryAssetBalancesByGroup(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, balances, numAssets)
@@ -2571,14 +2960,14 @@ func TestQueryAssetBalances(t *testing.T) {
 
 	// Only two assets should be returned that is not leased.
 	unleasedBalances, err := assetsStore.QueryBalancesByAsset(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, unleasedBalances, numAssets-2)
 
 	// Only one group should be returned that is not leased.
 	unleasedBalancesByGroup, err := assetsStore.QueryAssetBalancesByGroup(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, unleasedBalancesByGroup, numGroups-1)
@@ -2601,12 +2990,12 @@ func TestQueryAssetBalances(t *testing.T) {
 	// the same results as when the assets where unleased.
 	includeLeased = true
 	includeLeasedBalances, err := assetsStore.QueryBalancesByAsset(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, includeLeasedBalances, numAssets)
 	includeLeasedBalByGroup, err := assetsStore.QueryAssetBalancesByGroup(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, includeLeasedBalByGroup, len(assetGen.groupKeys))
@@ -2660,11 +3049,11 @@ func TestQueryAssetBalancesCustomChannelFunding(t *testing.T) {
 	// Hit both balance queries, they should return the same result.
 	includeLeased := false
 	balances, err := assetsStore.QueryBalancesByAsset(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	balancesByGroup, err := assetsStore.QueryAssetBalancesByGroup(
-		ctx, nil, includeLeased,
+		ctx, nil, includeLeased, fn.None[account.Account](),
 	)
 	require.NoError(t, err)
 	require.Len(t, balances, numAssets-1)
@@ -49,3 +49,24 @@ func (c *cacheLogger) log() {
 	log.Infof("db cache %s: %d hits, %d misses, %.2f%% hit ratio",
 		c.name, hit, miss, ratio)
 }
+
+// CacheStats is a snapshot of the hit/miss counters of a single cache.
+type CacheStats struct {
+	// Name is the name of the cache.
+	Name string
+
+	// Hits is the number of lookups that were served by the cache.
+	Hits int64
+
+	// Misses is the number of lookups that weren't served by the cache.
+	Misses int64
+}
+
+// stats returns a snapshot of the hit/miss counters of the cacheLogger.
+func (c *cacheLogger) stats() CacheStats {
+	return CacheStats{
+		Name:   c.name,
+		Hits:   c.hit.Load(),
+		Misses: c.miss.Load(),
+	}
+}
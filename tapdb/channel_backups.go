@@ -0,0 +1,134 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+type (
+	// NewAssetChannelBackup is used to insert or replace an asset channel
+	// backup.
+	NewAssetChannelBackup = sqlc.UpsertAssetChannelBackupParams
+)
+
+// ChannelBackupStore is the main storage interface for restored asset channel
+// backups.
+type ChannelBackupStore interface {
+	// UpsertAssetChannelBackup inserts a new asset channel backup or
+	// replaces the existing backup of the same channel.
+	UpsertAssetChannelBackup(ctx context.Context,
+		arg NewAssetChannelBackup) error
+
+	// FetchAssetChannelBackup fetches the asset channel backup of the
+	// channel with the given serialized funding outpoint.
+	FetchAssetChannelBackup(ctx context.Context,
+		chanPoint []byte) (sqlc.AssetChannelBackup, error)
+}
+
+// BatchedChannelBackupStore allows for batched DB transactions for the
+// channel backup store.
+type BatchedChannelBackupStore interface {
+	ChannelBackupStore
+
+	BatchedTx[ChannelBackupStore]
+}
+
+// ChannelBackups is a persistent store for restored asset channel backups.
+type ChannelBackups struct {
+	db BatchedChannelBackupStore
+
+	clock clock.Clock
+}
+
+// NewChannelBackups creates a new asset channel backup store.
+func NewChannelBackups(db BatchedChannelBackupStore,
+	clock clock.Clock) *ChannelBackups {
+
+	return &ChannelBackups{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// StoreChannelBackups persists the given channel backups, replacing any
+// existing backup of the same channel.
+//
+// NOTE: This is part of the tapchannel.ChannelBackupStore interface.
+func (c *ChannelBackups) StoreChannelBackups(ctx context.Context,
+	backups []*cmsg.ChannelBackup) error {
+
+	now := c.clock.Now().UTC()
+
+	var writeTx AssetStoreTxOptions
+	return c.db.ExecTx(ctx, &writeTx, func(q ChannelBackupStore) error {
+		for _, backup := range backups {
+			chanPoint, err := encodeOutpoint(backup.ChanPoint)
+			if err != nil {
+				return err
+			}
+
+			backupBytes, err := backup.Bytes()
+			if err != nil {
+				return err
+			}
+
+			err = q.UpsertAssetChannelBackup(
+				ctx, NewAssetChannelBackup{
+					ChanPoint:  chanPoint,
+					Backup:     backupBytes,
+					RestoredAt: now,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("unable to store backup of "+
+					"channel %v: %w", backup.ChanPoint, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchChannelBackup returns the backup of the channel with the given funding
+// outpoint.
+//
+// NOTE: This is part of the tapchannel.ChannelBackupStore interface.
+func (c *ChannelBackups) FetchChannelBackup(ctx context.Context,
+	chanPoint wire.OutPoint) (*cmsg.ChannelBackup, error) {
+
+	chanPointBytes, err := encodeOutpoint(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var backup *cmsg.ChannelBackup
+	readTx := NewAssetStoreReadTx()
+	dbErr := c.db.ExecTx(ctx, &readTx, func(q ChannelBackupStore) error {
+		row, err := q.FetchAssetChannelBackup(ctx, chanPointBytes)
+		if err != nil {
+			return err
+		}
+
+		backup, err = cmsg.DecodeChannelBackup(row.Backup)
+
+		return err
+	})
+	switch {
+	case errors.Is(dbErr, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %v",
+			cmsg.ErrChannelBackupNotFound, chanPoint)
+
+	case dbErr != nil:
+		return nil, fmt.Errorf("unable to fetch channel backup: %w",
+			dbErr)
+	}
+
+	return backup, nil
+}
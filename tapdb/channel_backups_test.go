@@ -0,0 +1,95 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	cmsg "github.com/lightninglabs/taproot-assets/tapchannelmsg"
+	"github.com/lightningnetwork/lnd/clock"
+	lfn "github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// newChannelBackupsFromDB makes a new channel backup store backed by the
+// passed database.
+func newChannelBackupsFromDB(db *BaseDB) *ChannelBackups {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) ChannelBackupStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewChannelBackups(dbTxer, clock.NewDefaultClock())
+}
+
+// randChannelBackup creates a channel backup with random aux leaves, which
+// allows backups to be told apart after they were stored.
+func randChannelBackup(chanPoint wire.OutPoint) *cmsg.ChannelBackup {
+	newCommitment := func() *cmsg.Commitment {
+		return cmsg.NewCommitment(
+			nil, nil, nil, nil, lnwallet.CommitAuxLeaves{
+				LocalAuxLeaf: lfn.Some(test.RandTapLeaf(nil)),
+			},
+		)
+	}
+
+	return &cmsg.ChannelBackup{
+		ChanPoint:        chanPoint,
+		Funding:          cmsg.NewOpenChannel(nil),
+		LocalCommitment:  newCommitment(),
+		RemoteCommitment: newCommitment(),
+	}
+}
+
+// TestChannelBackups tests that restored asset channel backups can be stored
+// and fetched, and that a newer backup replaces an older one.
+func TestChannelBackups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	store := newChannelBackupsFromDB(db.BaseDB)
+
+	chanPoint1 := wire.OutPoint{Hash: test.RandHash(), Index: 1}
+	chanPoint2 := wire.OutPoint{Hash: test.RandHash(), Index: 0}
+
+	_, err := store.FetchChannelBackup(ctx, chanPoint1)
+	require.ErrorIs(t, err, cmsg.ErrChannelBackupNotFound)
+
+	backup1 := randChannelBackup(chanPoint1)
+	backup2 := randChannelBackup(chanPoint2)
+	err = store.StoreChannelBackups(
+		ctx, []*cmsg.ChannelBackup{backup1, backup2},
+	)
+	require.NoError(t, err)
+
+	backupBytes := func(b *cmsg.ChannelBackup) []byte {
+		raw, err := b.Bytes()
+		require.NoError(t, err)
+		return raw
+	}
+
+	dbBackup1, err := store.FetchChannelBackup(ctx, chanPoint1)
+	require.NoError(t, err)
+	require.Equal(t, backupBytes(backup1), backupBytes(dbBackup1))
+
+	dbBackup2, err := store.FetchChannelBackup(ctx, chanPoint2)
+	require.NoError(t, err)
+	require.Equal(t, backupBytes(backup2), backupBytes(dbBackup2))
+
+	// Restoring a newer backup of the same channel replaces the old one.
+	newBackup1 := randChannelBackup(chanPoint1)
+	err = store.StoreChannelBackups(
+		ctx, []*cmsg.ChannelBackup{newBackup1},
+	)
+	require.NoError(t, err)
+
+	dbBackup1, err = store.FetchChannelBackup(ctx, chanPoint1)
+	require.NoError(t, err)
+	require.Equal(t, backupBytes(newBackup1), backupBytes(dbBackup1))
+	require.NotEqual(t, backupBytes(backup1), backupBytes(dbBackup1))
+}
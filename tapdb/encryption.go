@@ -0,0 +1,609 @@
+package tapdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// FieldEncryptionNone disables the encryption of sensitive fields.
+	FieldEncryptionNone = "none"
+
+	// FieldEncryptionLnd derives the field encryption key from a secret
+	// only the connected lnd wallet can compute, which itself is derived
+	// from the wallet seed.
+	FieldEncryptionLnd = "lnd"
+
+	// FieldEncryptionPassphrase derives the field encryption key from a
+	// passphrase that is supplied at startup with the UnlockDatabase RPC.
+	FieldEncryptionPassphrase = "passphrase"
+
+	// fieldEncryptionSaltSize is the size of the random salt the field
+	// encryption key is derived with.
+	fieldEncryptionSaltSize = 32
+
+	// fieldKeySize is the size of the AES-256 field encryption key.
+	fieldKeySize = 32
+
+	// The scrypt parameters used to derive the field encryption key. These
+	// match the parameters lnd uses to encrypt its wallet seed.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+
+	// encryptionPageSize is the number of rows that are encrypted per
+	// query when encryption is enabled for an existing database.
+	encryptionPageSize = 1000
+)
+
+var (
+	// encryptedFieldHeader is the header that is prepended to every
+	// encrypted field. It consists of the magic bytes "TAPE" followed by
+	// the version of the encryption scheme. Fields without this header are
+	// plaintext values written before encryption was enabled.
+	encryptedFieldHeader = []byte{'T', 'A', 'P', 'E', 0}
+
+	// keyCheckValue is the known value that is encrypted and stored to
+	// check that the correct secret was supplied to unlock the database.
+	keyCheckValue = []byte("tapd field encryption key check")
+
+	// ErrDatabaseLocked is returned if an encrypted field is read or
+	// written before the database was unlocked.
+	ErrDatabaseLocked = errors.New("database is locked")
+
+	// ErrDatabaseUnlocked is returned if the database is unlocked again.
+	ErrDatabaseUnlocked = errors.New("database is already unlocked")
+
+	// ErrInvalidFieldKey is returned if the secret supplied to unlock the
+	// database doesn't match the one the fields were encrypted with.
+	ErrInvalidFieldKey = errors.New("invalid database encryption secret")
+)
+
+type (
+	// FieldEncryptionParams holds the parameters of the field encryption
+	// stored in the DB.
+	FieldEncryptionParams = sqlc.FieldEncryption
+
+	// NewFieldEncryption is used to store the field encryption parameters.
+	NewFieldEncryption = sqlc.InsertFieldEncryptionParams
+
+	// AssetProofBlobQuery is used to page through the stored proof files.
+	AssetProofBlobQuery = sqlc.QueryAssetProofBlobsParams
+
+	// AssetProofBlob is a stored proof file.
+	AssetProofBlob = sqlc.QueryAssetProofBlobsRow
+
+	// AssetProofBlobUpdate is used to replace a stored proof file.
+	AssetProofBlobUpdate = sqlc.UpdateAssetProofBlobParams
+
+	// ProofSuffixQuery is used to page through the stored proof suffixes of
+	// transfer outputs.
+	ProofSuffixQuery = sqlc.QueryTransferOutputProofSuffixesParams
+
+	// ProofSuffixBlob is a stored proof suffix of a transfer output.
+	ProofSuffixBlob = sqlc.QueryTransferOutputProofSuffixesRow
+
+	// ProofSuffixUpdate is used to replace the proof suffix of a transfer
+	// output.
+	ProofSuffixUpdate = sqlc.UpdateTransferOutputProofSuffixParams
+
+	// PassiveProofQuery is used to page through the stored proofs of
+	// passive assets.
+	PassiveProofQuery = sqlc.QueryPassiveAssetProofsParams
+
+	// PassiveProofBlob is a stored proof of a passive asset.
+	PassiveProofBlob = sqlc.QueryPassiveAssetProofsRow
+
+	// PassiveProofUpdate is used to replace the proof of a passive asset.
+	PassiveProofUpdate = sqlc.UpdatePassiveAssetProofParams
+
+	// MacaroonRootKeyUpdate is used to replace a macaroon root key.
+	MacaroonRootKeyUpdate = sqlc.UpdateMacaroonRootKeyParams
+)
+
+// FieldEncryptionStore is the storage interface for the parameters of the
+// field encryption and the sensitive fields that are encrypted.
+type FieldEncryptionStore interface {
+	// FetchFieldEncryption fetches the stored field encryption parameters.
+	FetchFieldEncryption(ctx context.Context) (FieldEncryptionParams,
+		error)
+
+	// InsertFieldEncryption stores the field encryption parameters.
+	InsertFieldEncryption(ctx context.Context,
+		arg NewFieldEncryption) error
+
+	// QueryAssetProofBlobs returns a page of stored proof files.
+	QueryAssetProofBlobs(ctx context.Context,
+		arg AssetProofBlobQuery) ([]AssetProofBlob, error)
+
+	// UpdateAssetProofBlob replaces a stored proof file.
+	UpdateAssetProofBlob(ctx context.Context,
+		arg AssetProofBlobUpdate) error
+
+	// QueryTransferOutputProofSuffixes returns a page of stored proof
+	// suffixes of transfer outputs.
+	QueryTransferOutputProofSuffixes(ctx context.Context,
+		arg ProofSuffixQuery) ([]ProofSuffixBlob, error)
+
+	// UpdateTransferOutputProofSuffix replaces the proof suffix of a
+	// transfer output.
+	UpdateTransferOutputProofSuffix(ctx context.Context,
+		arg ProofSuffixUpdate) error
+
+	// QueryPassiveAssetProofs returns a page of stored proofs of passive
+	// assets.
+	QueryPassiveAssetProofs(ctx context.Context,
+		arg PassiveProofQuery) ([]PassiveProofBlob, error)
+
+	// UpdatePassiveAssetProof replaces the proof of a passive asset.
+	UpdatePassiveAssetProof(ctx context.Context,
+		arg PassiveProofUpdate) error
+
+	// QueryMacaroonRootKeys returns all macaroon root keys.
+	QueryMacaroonRootKeys(ctx context.Context) ([]MacaroonRootKey, error)
+
+	// UpdateMacaroonRootKey replaces a macaroon root key.
+	UpdateMacaroonRootKey(ctx context.Context,
+		arg MacaroonRootKeyUpdate) error
+}
+
+// BatchedFieldEncryptionStore allows for batched DB transactions for the field
+// encryption store.
+type BatchedFieldEncryptionStore interface {
+	FieldEncryptionStore
+
+	BatchedTx[FieldEncryptionStore]
+}
+
+// FieldCipher encrypts and decrypts the sensitive fields of the database,
+// which are macaroon root keys and proofs, with AES-256-GCM. The key is derived
+// from a secret that is either computed by the lnd wallet or supplied as a
+// passphrase, so the fields can only be read once the cipher is unlocked with
+// that secret.
+//
+// A nil FieldCipher leaves all fields in plaintext.
+type FieldCipher struct {
+	db BatchedFieldEncryptionStore
+
+	keySource string
+
+	clock clock.Clock
+
+	// mu protects aead, which is nil while the cipher is locked.
+	mu   sync.RWMutex
+	aead cipher.AEAD
+
+	// unlocked is closed once the cipher is unlocked.
+	unlocked chan struct{}
+}
+
+// NewFieldCipher creates a new locked field cipher for the given key source.
+// If the key source is FieldEncryptionNone, nil is returned, after checking
+// that the database fields aren't encrypted already.
+func NewFieldCipher(ctx context.Context, db BatchedFieldEncryptionStore,
+	keySource string, clock clock.Clock) (*FieldCipher, error) {
+
+	switch keySource {
+	case FieldEncryptionLnd, FieldEncryptionPassphrase:
+		return &FieldCipher{
+			db:        db,
+			keySource: keySource,
+			clock:     clock,
+			unlocked:  make(chan struct{}),
+		}, nil
+
+	// An empty key source is treated like an explicitly disabled
+	// encryption.
+	case FieldEncryptionNone, "":
+	default:
+		return nil, fmt.Errorf("unknown field encryption key source: "+
+			"%v", keySource)
+	}
+
+	// Once enabled, encryption can't be disabled again, as the encrypted
+	// fields couldn't be read anymore.
+	readOpts := NewAssetStoreReadTx()
+	dbErr := db.ExecTx(ctx, &readOpts, func(q FieldEncryptionStore) error {
+		params, err := q.FetchFieldEncryption(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+
+		case err != nil:
+			return fmt.Errorf("unable to fetch field encryption: "+
+				"%w", err)
+		}
+
+		return fmt.Errorf("database fields are encrypted with a key "+
+			"from %v, encryption can't be disabled",
+			params.KeySource)
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return nil, nil
+}
+
+// KeySource returns the source of the secret the key is derived from.
+func (c *FieldCipher) KeySource() string {
+	if c == nil {
+		return FieldEncryptionNone
+	}
+
+	return c.keySource
+}
+
+// IsLocked returns true if the cipher still needs to be unlocked.
+func (c *FieldCipher) IsLocked() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.aead == nil
+}
+
+// Unlocked returns a channel that is closed once the cipher is unlocked.
+func (c *FieldCipher) Unlocked() <-chan struct{} {
+	return c.unlocked
+}
+
+// Unlock derives the field encryption key from the given secret and unlocks
+// the cipher. If the database is unlocked for the first time, encryption is
+// enabled and all sensitive fields that are stored in plaintext are encrypted.
+// ErrInvalidFieldKey is returned if the secret doesn't match the secret the
+// fields were encrypted with.
+func (c *FieldCipher) Unlock(ctx context.Context, secret []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.aead != nil {
+		return ErrDatabaseUnlocked
+	}
+
+	var (
+		aead    cipher.AEAD
+		writeTx AssetStoreTxOptions
+	)
+	dbErr := c.db.ExecTx(ctx, &writeTx, func(q FieldEncryptionStore) error {
+		params, err := q.FetchFieldEncryption(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			aead, err = c.enable(ctx, q, secret)
+			return err
+
+		case err != nil:
+			return fmt.Errorf("unable to fetch field encryption: "+
+				"%w", err)
+		}
+
+		if params.KeySource != c.keySource {
+			return fmt.Errorf("database fields are encrypted with "+
+				"a key from %v, not %v", params.KeySource,
+				c.keySource)
+		}
+
+		aead, err = newFieldAEAD(secret, params.Salt)
+		if err != nil {
+			return err
+		}
+
+		check, err := openField(aead, params.KeyCheck)
+		if err != nil || !bytes.Equal(check, keyCheckValue) {
+			return ErrInvalidFieldKey
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return dbErr
+	}
+
+	c.aead = aead
+	close(c.unlocked)
+
+	log.Infof("Database unlocked, sensitive fields are encrypted with a "+
+		"key from %v", c.keySource)
+
+	return nil
+}
+
+// enable enables field encryption with a key derived from the given secret
+// and a new random salt, and encrypts all sensitive fields that are stored in
+// plaintext.
+func (c *FieldCipher) enable(ctx context.Context, q FieldEncryptionStore,
+	secret []byte) (cipher.AEAD, error) {
+
+	var salt [fieldEncryptionSaltSize]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := newFieldAEAD(secret, salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	keyCheck, err := sealField(aead, keyCheckValue)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.InsertFieldEncryption(ctx, NewFieldEncryption{
+		KeySource: c.keySource,
+		Salt:      salt[:],
+		KeyCheck:  keyCheck,
+		CreatedAt: c.clock.Now().UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to store field encryption: %w",
+			err)
+	}
+
+	numFields, err := encryptStoredFields(ctx, q, aead)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Enabled database field encryption, encrypted %d existing "+
+		"fields", numFields)
+
+	return aead, nil
+}
+
+// Encrypt encrypts the given sensitive field. A nil field stays nil, so
+// optional fields remain NULL in the database.
+func (c *FieldCipher) Encrypt(field []byte) ([]byte, error) {
+	if c == nil || field == nil {
+		return field, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.aead == nil {
+		return nil, ErrDatabaseLocked
+	}
+
+	return sealField(c.aead, field)
+}
+
+// Decrypt decrypts the given sensitive field. Fields that were stored in
+// plaintext before encryption was enabled are returned as is.
+func (c *FieldCipher) Decrypt(field []byte) ([]byte, error) {
+	if !IsEncryptedField(field) {
+		return field, nil
+	}
+
+	if c == nil {
+		return nil, fmt.Errorf("%w: field is encrypted, but "+
+			"encryption isn't enabled", ErrDatabaseLocked)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.aead == nil {
+		return nil, ErrDatabaseLocked
+	}
+
+	return openField(c.aead, field)
+}
+
+// IsEncryptedField returns true if the given field is encrypted.
+func IsEncryptedField(field []byte) bool {
+	return bytes.HasPrefix(field, encryptedFieldHeader)
+}
+
+// newFieldAEAD derives the field encryption key from the given secret and
+// salt and returns the AES-256-GCM cipher for it.
+func newFieldAEAD(secret, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(secret, salt, scryptN, scryptR, scryptP,
+		fieldKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive field encryption "+
+			"key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealField encrypts the given plaintext field. The result consists of the
+// header, the random nonce and the sealed plaintext.
+func sealField(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	headerSize := len(encryptedFieldHeader) + len(nonce)
+	field := make([]byte, 0, headerSize+len(plaintext)+aead.Overhead())
+	field = append(field, encryptedFieldHeader...)
+	field = append(field, nonce...)
+
+	return aead.Seal(field, nonce, plaintext, nil), nil
+}
+
+// openField decrypts the given encrypted field.
+func openField(aead cipher.AEAD, field []byte) ([]byte, error) {
+	headerSize := len(encryptedFieldHeader) + aead.NonceSize()
+	if len(field) < headerSize+aead.Overhead() {
+		return nil, fmt.Errorf("encrypted field too short")
+	}
+
+	nonce := field[len(encryptedFieldHeader):headerSize]
+	plaintext, err := aead.Open(nil, nonce, field[headerSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt field: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// encryptStoredFields encrypts all sensitive fields that are stored in
+// plaintext and returns the number of encrypted fields.
+func encryptStoredFields(ctx context.Context, q FieldEncryptionStore,
+	aead cipher.AEAD) (int, error) {
+
+	numProofs, err := encryptPages(
+		func(afterID int64) ([]AssetProofBlob, error) {
+			return q.QueryAssetProofBlobs(ctx, AssetProofBlobQuery{
+				AfterID:  afterID,
+				NumLimit: encryptionPageSize,
+			})
+		},
+		func(row AssetProofBlob) (int64, []byte) {
+			return row.ProofID, row.ProofFile
+		},
+		func(id int64, field []byte) error {
+			return q.UpdateAssetProofBlob(ctx, AssetProofBlobUpdate{
+				ProofID:   id,
+				ProofFile: field,
+			})
+		}, aead,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to encrypt proofs: %w", err)
+	}
+
+	numSuffixes, err := encryptPages(
+		func(afterID int64) ([]ProofSuffixBlob, error) {
+			return q.QueryTransferOutputProofSuffixes(
+				ctx, ProofSuffixQuery{
+					AfterID:  afterID,
+					NumLimit: encryptionPageSize,
+				},
+			)
+		},
+		func(row ProofSuffixBlob) (int64, []byte) {
+			return row.OutputID, row.ProofSuffix
+		},
+		func(id int64, field []byte) error {
+			return q.UpdateTransferOutputProofSuffix(
+				ctx, ProofSuffixUpdate{
+					OutputID:    id,
+					ProofSuffix: field,
+				},
+			)
+		}, aead,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to encrypt proof suffixes: %w",
+			err)
+	}
+
+	numPassive, err := encryptPages(
+		func(afterID int64) ([]PassiveProofBlob, error) {
+			return q.QueryPassiveAssetProofs(ctx, PassiveProofQuery{
+				AfterID:  afterID,
+				NumLimit: encryptionPageSize,
+			})
+		},
+		func(row PassiveProofBlob) (int64, []byte) {
+			return row.PassiveID, row.NewProof
+		},
+		func(id int64, field []byte) error {
+			return q.UpdatePassiveAssetProof(
+				ctx, PassiveProofUpdate{
+					PassiveID: id,
+					NewProof:  field,
+				},
+			)
+		}, aead,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to encrypt passive asset "+
+			"proofs: %w", err)
+	}
+
+	rootKeys, err := q.QueryMacaroonRootKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query macaroon root keys: %w",
+			err)
+	}
+
+	var numRootKeys int
+	for _, rootKey := range rootKeys {
+		if IsEncryptedField(rootKey.RootKey) {
+			continue
+		}
+
+		sealed, err := sealField(aead, rootKey.RootKey)
+		if err != nil {
+			return 0, err
+		}
+
+		err = q.UpdateMacaroonRootKey(ctx, MacaroonRootKeyUpdate{
+			ID:      rootKey.ID,
+			RootKey: sealed,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to encrypt macaroon root "+
+				"key: %w", err)
+		}
+		numRootKeys++
+	}
+
+	return numProofs + numSuffixes + numPassive + numRootKeys, nil
+}
+
+// encryptPages encrypts the plaintext fields of all rows returned page by page
+// by the given query function, using the given update function to store the
+// encrypted fields. The number of encrypted fields is returned.
+func encryptPages[T any](query func(afterID int64) ([]T, error),
+	unpack func(T) (int64, []byte), update func(int64, []byte) error,
+	aead cipher.AEAD) (int, error) {
+
+	var (
+		afterID      int64
+		numEncrypted int
+	)
+	for {
+		rows, err := query(afterID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, row := range rows {
+			id, field := unpack(row)
+			afterID = id
+
+			if IsEncryptedField(field) {
+				continue
+			}
+
+			sealed, err := sealField(aead, field)
+			if err != nil {
+				return 0, err
+			}
+
+			if err := update(id, sealed); err != nil {
+				return 0, err
+			}
+			numEncrypted++
+		}
+
+		if len(rows) < encryptionPageSize {
+			return numEncrypted, nil
+		}
+	}
+}
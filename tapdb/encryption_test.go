@@ -0,0 +1,218 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/stretchr/testify/require"
+)
+
+// newFieldCipherFromDB makes a new field cipher for the given key source backed
+// by the passed database.
+func newFieldCipherFromDB(db *BaseDB, keySource string) (*FieldCipher,
+	error) {
+
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) FieldEncryptionStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewFieldCipher(
+		context.Background(), dbTxer, keySource,
+		clock.NewTestClock(time.Now()),
+	)
+}
+
+// TestFieldCipherEncryptDecrypt tests that fields are encrypted and decrypted
+// once the cipher is unlocked, and that plaintext fields are passed through.
+func TestFieldCipherEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+
+	// With encryption disabled, no cipher is created.
+	fieldCipher, err := newFieldCipherFromDB(
+		db.BaseDB, FieldEncryptionNone,
+	)
+	require.NoError(t, err)
+	require.Nil(t, fieldCipher)
+	require.False(t, fieldCipher.IsLocked())
+
+	fieldCipher, err = newFieldCipherFromDB(
+		db.BaseDB, FieldEncryptionPassphrase,
+	)
+	require.NoError(t, err)
+	require.True(t, fieldCipher.IsLocked())
+
+	// Fields can't be encrypted while the cipher is locked.
+	field := []byte("sensitive field")
+	_, err = fieldCipher.Encrypt(field)
+	require.ErrorIs(t, err, ErrDatabaseLocked)
+
+	// Plaintext fields are passed through even if the cipher is locked.
+	plaintext, err := fieldCipher.Decrypt(field)
+	require.NoError(t, err)
+	require.Equal(t, field, plaintext)
+
+	passphrase := []byte("passphrase")
+	require.NoError(t, fieldCipher.Unlock(ctx, passphrase))
+	require.False(t, fieldCipher.IsLocked())
+	require.ErrorIs(
+		t, fieldCipher.Unlock(ctx, passphrase), ErrDatabaseUnlocked,
+	)
+
+	select {
+	case <-fieldCipher.Unlocked():
+	default:
+		t.Fatalf("unlocked channel not closed")
+	}
+
+	encrypted, err := fieldCipher.Encrypt(field)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedField(encrypted))
+	require.NotContains(t, string(encrypted), string(field))
+
+	plaintext, err = fieldCipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, field, plaintext)
+
+	// Encrypting the same field twice results in different ciphertexts.
+	encrypted2, err := fieldCipher.Encrypt(field)
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, encrypted2)
+
+	// Optional fields stay NULL.
+	encrypted, err = fieldCipher.Encrypt(nil)
+	require.NoError(t, err)
+	require.Nil(t, encrypted)
+
+	// A tampered field can't be decrypted.
+	tampered, err := fieldCipher.Encrypt(field)
+	require.NoError(t, err)
+	tampered[len(tampered)-1] ^= 0x01
+	_, err = fieldCipher.Decrypt(tampered)
+	require.ErrorContains(t, err, "unable to decrypt field")
+
+	// A cipher that is unlocked with the wrong passphrase is rejected.
+	wrongCipher, err := newFieldCipherFromDB(
+		db.BaseDB, FieldEncryptionPassphrase,
+	)
+	require.NoError(t, err)
+	err = wrongCipher.Unlock(ctx, []byte("wrong passphrase"))
+	require.ErrorIs(t, err, ErrInvalidFieldKey)
+	require.True(t, wrongCipher.IsLocked())
+
+	// The key source can't be changed once encryption is enabled.
+	lndCipher, err := newFieldCipherFromDB(
+		db.BaseDB, FieldEncryptionLnd,
+	)
+	require.NoError(t, err)
+	err = lndCipher.Unlock(ctx, passphrase)
+	require.ErrorContains(t, err, "not lnd")
+
+	// And encryption can't be disabled again.
+	_, err = newFieldCipherFromDB(db.BaseDB, FieldEncryptionNone)
+	require.ErrorContains(t, err, "encryption can't be disabled")
+
+	// Without a cipher, encrypted fields can't be read.
+	var nilCipher *FieldCipher
+	_, err = nilCipher.Decrypt(encrypted2)
+	require.ErrorIs(t, err, ErrDatabaseLocked)
+}
+
+// TestFieldCipherEncryptExistingData tests that the macaroon root keys and
+// proofs that were stored in plaintext are encrypted once encryption is
+// enabled, and that they can still be read through the stores.
+func TestFieldCipherEncryptExistingData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+
+	// We first store a macaroon root key and two asset proofs without
+	// encryption.
+	rksDB := NewTransactionExecutor(db, func(tx *sql.Tx) KeyStore {
+		return db.WithTx(tx)
+	})
+	rootKeyID := []byte("root key id")
+	rootKeyCtx := macaroons.ContextWithRootKeyID(ctx, rootKeyID)
+	rootKey, _, err := NewRootKeyStore(rksDB, nil).RootKey(rootKeyCtx)
+	require.NoError(t, err)
+
+	_, assetsStore := newAssetStoreFromDB(db.BaseDB)
+	assetGen := newAssetGenerator(t, 2, 0)
+	assetGen.genAssets(t, assetsStore, []assetDesc{{
+		assetGen:    assetGen.assetGens[0],
+		anchorPoint: assetGen.anchorPoints[0],
+		amt:         10,
+		noGroupKey:  true,
+	}, {
+		assetGen:    assetGen.assetGens[1],
+		anchorPoint: assetGen.anchorPoints[1],
+		amt:         20,
+		noGroupKey:  true,
+	}})
+
+	plainProofs, err := assetsStore.FetchAssetProofs(ctx)
+	require.NoError(t, err)
+	require.Len(t, plainProofs, 2)
+
+	// Now we enable encryption, which encrypts the existing data on the
+	// first unlock.
+	fieldCipher, err := newFieldCipherFromDB(
+		db.BaseDB, FieldEncryptionPassphrase,
+	)
+	require.NoError(t, err)
+
+	// While the cipher is locked, no new root keys can be created.
+	rks := NewRootKeyStore(rksDB, fieldCipher)
+	newKeyCtx := macaroons.ContextWithRootKeyID(ctx, []byte("new key"))
+	_, _, err = rks.RootKey(newKeyCtx)
+	require.ErrorIs(t, err, ErrDatabaseLocked)
+
+	require.NoError(t, fieldCipher.Unlock(ctx, []byte("passphrase")))
+
+	// The stored fields are now encrypted.
+	dbRootKey, err := db.GetRootKey(ctx, rootKeyID)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedField(dbRootKey.RootKey))
+
+	dbProofs, err := db.FetchAssetProofs(ctx)
+	require.NoError(t, err)
+	require.Len(t, dbProofs, 2)
+	for _, dbProof := range dbProofs {
+		require.True(t, IsEncryptedField(dbProof.ProofFile))
+	}
+
+	// But they can still be read through the stores.
+	storedRootKey, err := rks.Get(ctx, rootKeyID)
+	require.NoError(t, err)
+	require.Equal(t, rootKey, storedRootKey)
+
+	encryptedStore := NewAssetStore(
+		assetsStore.db, assetsStore.metaDb, assetsStore.clock,
+		db.Backend(), NoOpDBEventNotifier{}, fieldCipher,
+	)
+	proofs, err := encryptedStore.FetchAssetProofs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, plainProofs, proofs)
+
+	// A store without the cipher can't read the proofs anymore.
+	_, err = assetsStore.FetchAssetProofs(ctx)
+	require.ErrorIs(t, err, ErrDatabaseLocked)
+
+	// New root keys are encrypted as well.
+	newRootKey, _, err := rks.RootKey(newKeyCtx)
+	require.NoError(t, err)
+
+	dbRootKey, err = db.GetRootKey(ctx, []byte("new key"))
+	require.NoError(t, err)
+	require.True(t, IsEncryptedField(dbRootKey.RootKey))
+	require.NotEqual(t, newRootKey, dbRootKey.RootKey)
+}
@@ -0,0 +1,210 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/freeze"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+type (
+	// NewScriptKeyFreeze is used to insert or update a script key freeze.
+	NewScriptKeyFreeze = sqlc.UpsertScriptKeyFreezeParams
+
+	// ScriptKeyFreezeRow is a script key freeze stored in the DB.
+	ScriptKeyFreezeRow = sqlc.ScriptKeyFreeze
+)
+
+// FreezeStore is the main storage interface for frozen script keys.
+type FreezeStore interface {
+	// UpsertScriptKeyFreeze inserts a new script key freeze or updates the
+	// reason of an existing one.
+	UpsertScriptKeyFreeze(ctx context.Context,
+		arg NewScriptKeyFreeze) (ScriptKeyFreezeRow, error)
+
+	// DeleteScriptKeyFreeze deletes the freeze of the given script key
+	// and returns the number of deleted rows.
+	DeleteScriptKeyFreeze(ctx context.Context,
+		tweakedScriptKey []byte) (int64, error)
+
+	// FetchScriptKeyFreeze fetches the freeze of the given script key.
+	FetchScriptKeyFreeze(ctx context.Context,
+		tweakedScriptKey []byte) (ScriptKeyFreezeRow, error)
+
+	// QueryScriptKeyFreezes returns all script key freezes, ordered by the
+	// time they were created at.
+	QueryScriptKeyFreezes(ctx context.Context) ([]ScriptKeyFreezeRow,
+		error)
+}
+
+// BatchedFreezeStore allows for batched DB transactions for the freeze store.
+type BatchedFreezeStore interface {
+	FreezeStore
+
+	BatchedTx[FreezeStore]
+}
+
+// ScriptKeyFreezes is a persistent store for frozen issuer policy script keys.
+type ScriptKeyFreezes struct {
+	db BatchedFreezeStore
+
+	clock clock.Clock
+}
+
+// NewScriptKeyFreezes creates a new script key freeze store.
+func NewScriptKeyFreezes(db BatchedFreezeStore,
+	clock clock.Clock) *ScriptKeyFreezes {
+
+	return &ScriptKeyFreezes{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// FreezeScriptKey freezes the given script key. Freezing a script key that is
+// already frozen updates the reason of the freeze.
+//
+// NOTE: This is part of the freeze.Store interface.
+func (f *ScriptKeyFreezes) FreezeScriptKey(ctx context.Context,
+	scriptKey *btcec.PublicKey, reason string) (*freeze.Freeze, error) {
+
+	var frozen freeze.Freeze
+	var writeTx AssetStoreTxOptions
+	dbErr := f.db.ExecTx(ctx, &writeTx, func(q FreezeStore) error {
+		row, err := q.UpsertScriptKeyFreeze(ctx, NewScriptKeyFreeze{
+			TweakedScriptKey: scriptKey.SerializeCompressed(),
+			Reason:           reason,
+			FrozenAt:         f.clock.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		frozen, err = parseScriptKeyFreeze(row)
+
+		return err
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to freeze script key: %w", dbErr)
+	}
+
+	return &frozen, nil
+}
+
+// UnfreezeScriptKey unfreezes the given script key.
+//
+// NOTE: This is part of the freeze.Store interface.
+func (f *ScriptKeyFreezes) UnfreezeScriptKey(ctx context.Context,
+	scriptKey *btcec.PublicKey) error {
+
+	var numDeleted int64
+	var writeTx AssetStoreTxOptions
+	dbErr := f.db.ExecTx(ctx, &writeTx, func(q FreezeStore) error {
+		var err error
+		numDeleted, err = q.DeleteScriptKeyFreeze(
+			ctx, scriptKey.SerializeCompressed(),
+		)
+
+		return err
+	})
+	switch {
+	case dbErr != nil:
+		return fmt.Errorf("unable to unfreeze script key: %w", dbErr)
+
+	case numDeleted == 0:
+		return fmt.Errorf("%w: %x", freeze.ErrNotFrozen,
+			scriptKey.SerializeCompressed())
+	}
+
+	return nil
+}
+
+// FetchFreeze returns the freeze of the given script key.
+//
+// NOTE: This is part of the freeze.Store interface.
+func (f *ScriptKeyFreezes) FetchFreeze(ctx context.Context,
+	scriptKey *btcec.PublicKey) (*freeze.Freeze, error) {
+
+	var frozen freeze.Freeze
+	readTx := NewAssetStoreReadTx()
+	dbErr := f.db.ExecTx(ctx, &readTx, func(q FreezeStore) error {
+		row, err := q.FetchScriptKeyFreeze(
+			ctx, scriptKey.SerializeCompressed(),
+		)
+		if err != nil {
+			return err
+		}
+
+		frozen, err = parseScriptKeyFreeze(row)
+
+		return err
+	})
+	switch {
+	case errors.Is(dbErr, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %x", freeze.ErrNotFrozen,
+			scriptKey.SerializeCompressed())
+
+	case dbErr != nil:
+		return nil, fmt.Errorf("unable to fetch freeze: %w", dbErr)
+	}
+
+	return &frozen, nil
+}
+
+// ListFreezes returns all frozen script keys, ordered by the time they were
+// frozen at.
+//
+// NOTE: This is part of the freeze.Store interface.
+func (f *ScriptKeyFreezes) ListFreezes(
+	ctx context.Context) ([]freeze.Freeze, error) {
+
+	var freezes []freeze.Freeze
+	readTx := NewAssetStoreReadTx()
+	dbErr := f.db.ExecTx(ctx, &readTx, func(q FreezeStore) error {
+		rows, err := q.QueryScriptKeyFreezes(ctx)
+		if err != nil {
+			return err
+		}
+
+		freezes = make([]freeze.Freeze, 0, len(rows))
+		for _, row := range rows {
+			frozen, err := parseScriptKeyFreeze(row)
+			if err != nil {
+				return err
+			}
+
+			freezes = append(freezes, frozen)
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to list freezes: %w", dbErr)
+	}
+
+	return freezes, nil
+}
+
+// parseScriptKeyFreeze converts a script key freeze DB row into a freeze.
+func parseScriptKeyFreeze(row ScriptKeyFreezeRow) (freeze.Freeze, error) {
+	scriptKey, err := btcec.ParsePubKey(row.TweakedScriptKey)
+	if err != nil {
+		return freeze.Freeze{}, fmt.Errorf("unable to parse script "+
+			"key: %w", err)
+	}
+
+	return freeze.Freeze{
+		ScriptKey: scriptKey,
+		Reason:    row.Reason,
+		FrozenAt:  row.FrozenAt.UTC(),
+	}, nil
+}
+
+// A compile-time assertion to ensure ScriptKeyFreezes meets the freeze.Store
+// interface.
+var _ freeze.Store = (*ScriptKeyFreezes)(nil)
@@ -0,0 +1,78 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/freeze"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// newScriptKeyFreezesFromDB makes a new script key freeze store backed by the
+// passed database.
+func newScriptKeyFreezesFromDB(db *BaseDB,
+	clock clock.Clock) *ScriptKeyFreezes {
+
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) FreezeStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewScriptKeyFreezes(dbTxer, clock)
+}
+
+// TestScriptKeyFreezes tests that script keys can be frozen and unfrozen, and
+// that freezing a frozen script key again keeps the original freeze time.
+func TestScriptKeyFreezes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	testClock := clock.NewTestClock(time.Unix(1700000000, 0))
+	store := newScriptKeyFreezesFromDB(db.BaseDB, testClock)
+
+	scriptKey1 := test.RandPubKey(t)
+	scriptKey2 := test.RandPubKey(t)
+
+	_, err := store.FetchFreeze(ctx, scriptKey1)
+	require.ErrorIs(t, err, freeze.ErrNotFrozen)
+
+	frozen1, err := store.FreezeScriptKey(ctx, scriptKey1, "sanctioned")
+	require.NoError(t, err)
+	require.Equal(t, scriptKey1, frozen1.ScriptKey)
+	require.Equal(t, testClock.Now().UTC(), frozen1.FrozenAt)
+
+	testClock.SetTime(testClock.Now().Add(time.Hour))
+	_, err = store.FreezeScriptKey(ctx, scriptKey2, "")
+	require.NoError(t, err)
+
+	// Freezing the first key again updates the reason only.
+	testClock.SetTime(testClock.Now().Add(time.Hour))
+	_, err = store.FreezeScriptKey(ctx, scriptKey1, "court order")
+	require.NoError(t, err)
+
+	dbFrozen1, err := store.FetchFreeze(ctx, scriptKey1)
+	require.NoError(t, err)
+	require.Equal(t, "court order", dbFrozen1.Reason)
+	require.Equal(t, frozen1.FrozenAt, dbFrozen1.FrozenAt)
+
+	freezes, err := store.ListFreezes(ctx)
+	require.NoError(t, err)
+	require.Len(t, freezes, 2)
+	require.Equal(t, scriptKey1, freezes[0].ScriptKey)
+	require.Equal(t, scriptKey2, freezes[1].ScriptKey)
+
+	require.NoError(t, store.UnfreezeScriptKey(ctx, scriptKey1))
+	err = store.UnfreezeScriptKey(ctx, scriptKey1)
+	require.ErrorIs(t, err, freeze.ErrNotFrozen)
+
+	freezes, err = store.ListFreezes(ctx)
+	require.NoError(t, err)
+	require.Len(t, freezes, 1)
+	require.Equal(t, scriptKey2, freezes[0].ScriptKey)
+}
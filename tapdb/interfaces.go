@@ -41,6 +41,30 @@ type TxOptions interface {
 	ReadOnly() bool
 }
 
+// ReplicaTxOptions is an optional extension of the TxOptions interface. Read
+// transactions can implement it to signal that they may be served by a read
+// replica of the database instead of the primary. Only queries that can
+// tolerate slightly stale data should opt in, as a replica may lag behind the
+// primary database.
+type ReplicaTxOptions interface {
+	TxOptions
+
+	// AllowReplica returns true if the transaction may be executed on a
+	// read replica.
+	AllowReplica() bool
+}
+
+// useReplica returns true if a transaction with the given options may be
+// executed on a read replica.
+func useReplica(opts TxOptions) bool {
+	replicaOpts, ok := opts.(ReplicaTxOptions)
+	if !ok {
+		return false
+	}
+
+	return replicaOpts.ReadOnly() && replicaOpts.AllowReplica()
+}
+
 // BatchedTx is a generic interface that represents the ability to execute
 // several operations to a given storage interface in a single atomic
 // transaction. Typically, Q here will be some subset of the main sqlc.Querier
@@ -286,12 +310,27 @@ type BaseDB struct {
 	*sql.DB
 
 	*sqlc.Queries
+
+	// readReplica is an optional connection to a read-only replica of the
+	// database. If set, read transactions that allow it are executed on
+	// the replica instead of the primary database.
+	readReplica *sql.DB
 }
 
 // BeginTx wraps the normal sql specific BeginTx method with the TxOptions
 // interface. This interface is then mapped to the concrete sql tx options
 // struct.
 func (s *BaseDB) BeginTx(ctx context.Context, opts TxOptions) (*sql.Tx, error) {
+	if s.readReplica != nil && useReplica(opts) {
+		// Serializable transactions aren't supported on hot standby
+		// servers, so we fall back to repeatable read, which still
+		// gives us a consistent snapshot for read-only transactions.
+		return s.readReplica.BeginTx(ctx, &sql.TxOptions{
+			ReadOnly:  true,
+			Isolation: sql.LevelRepeatableRead,
+		})
+	}
+
 	sqlOptions := sql.TxOptions{
 		ReadOnly:  opts.ReadOnly(),
 		Isolation: sql.LevelSerializable,
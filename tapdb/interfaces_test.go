@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -69,3 +70,45 @@ func TestInt64PrimaryKey(t *testing.T) {
 	require.Equal(t, testID, storedID)
 	require.Equal(t, testHash, storedHash)
 }
+
+// TestUseReplica tests that only read transactions that explicitly allow it are
+// executed on a read replica.
+func TestUseReplica(t *testing.T) {
+	t.Parallel()
+
+	readTx := NewBaseUniverseReadTx()
+	replicaReadTx := NewBaseUniverseReplicaReadTx()
+	writeTx := BaseUniverseStoreOptions{}
+	invalidTx := BaseUniverseStoreOptions{
+		allowReplica: true,
+	}
+
+	require.False(t, useReplica(&readTx))
+	require.True(t, useReplica(&replicaReadTx))
+	require.False(t, useReplica(&writeTx))
+
+	// A write transaction must never be executed on a replica, even if
+	// the replica is allowed.
+	require.False(t, useReplica(&invalidTx))
+
+	// Options that don't implement the ReplicaTxOptions interface are
+	// always executed on the primary database.
+	treeStoreTx := NewTreeStoreReadTx()
+	require.False(t, useReplica(&treeStoreTx))
+
+	// The asset balance queries only use the replica if the asset store
+	// was explicitly configured to allow it, as they must otherwise see
+	// the effect of a transfer right after it was made.
+	primaryStore := NewAssetStore(
+		nil, nil, nil, sqlc.BackendTypePostgres, nil, nil,
+	)
+	primaryBalancesTx := primaryStore.balancesReadTx()
+	require.False(t, useReplica(&primaryBalancesTx))
+
+	replicaStore := NewAssetStore(
+		nil, nil, nil, sqlc.BackendTypePostgres, nil, nil,
+		WithReplicaBalances(),
+	)
+	replicaBalancesTx := replicaStore.balancesReadTx()
+	require.True(t, useReplica(&replicaBalancesTx))
+}
@@ -0,0 +1,185 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+)
+
+type (
+	// NewJob is used to insert a new job.
+	NewJob = sqlc.InsertJobParams
+
+	// JobUpdate is used to update the state and progress of a job.
+	JobUpdate = sqlc.UpdateJobParams
+
+	// JobRow is a single job stored in the DB.
+	JobRow = sqlc.Job
+)
+
+// JobStore is the main storage interface for long-running jobs.
+type JobStore interface {
+	// InsertJob inserts a new job and returns its ID.
+	InsertJob(ctx context.Context, arg NewJob) (int64, error)
+
+	// UpdateJob updates the state, progress and error message of a job.
+	UpdateJob(ctx context.Context, arg JobUpdate) error
+
+	// FetchJob returns the job with the given ID.
+	FetchJob(ctx context.Context, id int64) (JobRow, error)
+
+	// QueryJobs returns all jobs with a state up to the given maximum
+	// state, or all jobs if no maximum state is set.
+	QueryJobs(ctx context.Context, maxState sql.NullInt16) ([]JobRow,
+		error)
+}
+
+// BatchedJobStore allows for batched DB transactions for the job store.
+type BatchedJobStore interface {
+	JobStore
+
+	BatchedTx[JobStore]
+}
+
+// Jobs is a persistent store for the jobs of the job manager.
+type Jobs struct {
+	db BatchedJobStore
+}
+
+// NewJobs creates a new job store.
+func NewJobs(db BatchedJobStore) *Jobs {
+	return &Jobs{
+		db: db,
+	}
+}
+
+// InsertJob stores a new job and returns its ID.
+//
+// NOTE: This is part of the jobs.Store interface.
+func (j *Jobs) InsertJob(ctx context.Context, job *jobs.Job) (jobs.ID,
+	error) {
+
+	var (
+		writeTx AssetStoreTxOptions
+		id      int64
+	)
+	err := j.db.ExecTx(ctx, &writeTx, func(q JobStore) error {
+		var err error
+		id, err = q.InsertJob(ctx, NewJob{
+			Kind:      string(job.Kind),
+			Params:    job.Params,
+			State:     int16(job.State),
+			Progress:  int16(job.Progress),
+			ErrorMsg:  job.ErrorMsg,
+			CreatedAt: job.CreatedAt.UTC(),
+			UpdatedAt: job.UpdatedAt.UTC(),
+		})
+
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to insert job: %w", err)
+	}
+
+	return jobs.ID(id), nil
+}
+
+// UpdateJob updates the state, progress, error message and update time of an
+// existing job.
+//
+// NOTE: This is part of the jobs.Store interface.
+func (j *Jobs) UpdateJob(ctx context.Context, job *jobs.Job) error {
+	var writeTx AssetStoreTxOptions
+	return j.db.ExecTx(ctx, &writeTx, func(q JobStore) error {
+		return q.UpdateJob(ctx, JobUpdate{
+			ID:        int64(job.ID),
+			State:     int16(job.State),
+			Progress:  int16(job.Progress),
+			ErrorMsg:  job.ErrorMsg,
+			UpdatedAt: job.UpdatedAt.UTC(),
+		})
+	})
+}
+
+// FetchJob returns the job with the given ID.
+//
+// NOTE: This is part of the jobs.Store interface.
+func (j *Jobs) FetchJob(ctx context.Context, id jobs.ID) (*jobs.Job, error) {
+	var job *jobs.Job
+	readTx := NewAssetStoreReadTx()
+	dbErr := j.db.ExecTx(ctx, &readTx, func(q JobStore) error {
+		row, err := q.FetchJob(ctx, int64(id))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("%w: %d", jobs.ErrJobNotFound, id)
+
+		case err != nil:
+			return err
+		}
+
+		job = parseJob(row)
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return job, nil
+}
+
+// QueryJobs returns all jobs ordered by their ID. If activeOnly is set, only
+// jobs that aren't in a final state are returned.
+//
+// NOTE: This is part of the jobs.Store interface.
+func (j *Jobs) QueryJobs(ctx context.Context, activeOnly bool) ([]*jobs.Job,
+	error) {
+
+	var maxState sql.NullInt16
+	if activeOnly {
+		maxState = sqlInt16(jobs.StateRunning)
+	}
+
+	var result []*jobs.Job
+	readTx := NewAssetStoreReadTx()
+	dbErr := j.db.ExecTx(ctx, &readTx, func(q JobStore) error {
+		result = nil
+
+		rows, err := q.QueryJobs(ctx, maxState)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			result = append(result, parseJob(row))
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return result, nil
+}
+
+// parseJob parses a job from its database representation.
+func parseJob(row JobRow) *jobs.Job {
+	return &jobs.Job{
+		ID:        jobs.ID(row.ID),
+		Kind:      jobs.Kind(row.Kind),
+		Params:    row.Params,
+		State:     jobs.State(row.State),
+		Progress:  uint8(row.Progress),
+		ErrorMsg:  row.ErrorMsg,
+		CreatedAt: row.CreatedAt.UTC(),
+		UpdatedAt: row.UpdatedAt.UTC(),
+	}
+}
+
+// A compile-time assertion to ensure Jobs meets the jobs.Store interface.
+var _ jobs.Store = (*Jobs)(nil)
@@ -0,0 +1,66 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/jobs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJobStore tests that jobs can be stored, updated and queried.
+func TestJobStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) JobStore {
+			return db.WithTx(tx)
+		},
+	)
+	store := NewJobs(dbTxer)
+
+	now := time.Unix(1_000_000, 0).UTC()
+	job := &jobs.Job{
+		Kind:      jobs.KindFederationSync,
+		Params:    []byte{1, 2, 3},
+		State:     jobs.StatePending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	id1, err := store.InsertJob(ctx, job)
+	require.NoError(t, err)
+	id2, err := store.InsertJob(ctx, job)
+	require.NoError(t, err)
+	require.Greater(t, id2, id1)
+
+	// Finish the first job.
+	job.ID = id1
+	job.State = jobs.StateFailed
+	job.Progress = 42
+	job.ErrorMsg = "boom"
+	job.UpdatedAt = now.Add(time.Minute)
+	require.NoError(t, store.UpdateJob(ctx, job))
+
+	fetched, err := store.FetchJob(ctx, id1)
+	require.NoError(t, err)
+	require.Equal(t, job, fetched)
+
+	allJobs, err := store.QueryJobs(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, allJobs, 2)
+	require.Equal(t, id1, allJobs[0].ID)
+	require.Equal(t, id2, allJobs[1].ID)
+
+	activeJobs, err := store.QueryJobs(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, activeJobs, 1)
+	require.Equal(t, id2, activeJobs[0].ID)
+
+	_, err = store.FetchJob(ctx, id2+1)
+	require.ErrorIs(t, err, jobs.ErrJobNotFound)
+}
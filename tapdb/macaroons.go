@@ -72,12 +72,18 @@ type BatchedKeyStore interface {
 // to implement the interface.
 type RootKeyStore struct {
 	db BatchedKeyStore
+
+	// cipher is used to encrypt the root keys at rest. If nil, the root
+	// keys are stored in plaintext.
+	cipher *FieldCipher
 }
 
-// NewRootKeyStore creates a new RKS from the passed querier interface.
-func NewRootKeyStore(db BatchedKeyStore) *RootKeyStore {
+// NewRootKeyStore creates a new RKS from the passed querier interface and the
+// optional field cipher.
+func NewRootKeyStore(db BatchedKeyStore, cipher *FieldCipher) *RootKeyStore {
 	return &RootKeyStore{
-		db: db,
+		db:     db,
+		cipher: cipher,
 	}
 }
 
@@ -95,8 +101,8 @@ func (r *RootKeyStore) Get(ctx context.Context, id []byte) ([]byte, error) {
 			return err
 		}
 
-		rootKey = mac.RootKey
-		return nil
+		rootKey, err = r.cipher.Decrypt(mac.RootKey)
+		return err
 	})
 	if dbErr != nil {
 		return nil, dbErr
@@ -131,8 +137,8 @@ func (r *RootKeyStore) RootKey(ctx context.Context) ([]byte, []byte, error) {
 		mac, err := q.GetRootKey(ctx, id)
 		switch err {
 		case nil:
-			rootKey = mac.RootKey
-			return nil
+			rootKey, err = r.cipher.Decrypt(mac.RootKey)
+			return err
 
 		case sql.ErrNoRows:
 
@@ -146,10 +152,15 @@ func (r *RootKeyStore) RootKey(ctx context.Context) ([]byte, []byte, error) {
 			return err
 		}
 
+		storedKey, err := r.cipher.Encrypt(rootKey)
+		if err != nil {
+			return err
+		}
+
 		// Insert this new root key into the database.
 		return q.InsertRootKey(ctx, sqlc.InsertRootKeyParams{
 			ID:      id,
-			RootKey: rootKey,
+			RootKey: storedKey,
 		})
 	})
 	if dbErr != nil {
@@ -21,7 +21,7 @@ func TestRootKeyStore(t *testing.T) {
 	rksDB := NewTransactionExecutor(db, func(tx *sql.Tx) KeyStore {
 		return db.WithTx(tx)
 	})
-	rks := NewRootKeyStore(rksDB)
+	rks := NewRootKeyStore(rksDB, nil)
 	ctx := context.Background()
 
 	// With our database open, attempt to get a root key for an ID that
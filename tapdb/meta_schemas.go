@@ -0,0 +1,168 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/metaschema"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+type (
+	// NewMetaSchema is used to insert a new custom meta schema.
+	NewMetaSchema = sqlc.InsertMetaSchemaParams
+
+	// MetaSchemaRow is a custom meta schema stored in the DB.
+	MetaSchemaRow = sqlc.MetaSchema
+)
+
+// MetaSchemaStore is the main storage interface for custom asset meta
+// schemas.
+type MetaSchemaStore interface {
+	// InsertMetaSchema inserts a new custom meta schema.
+	InsertMetaSchema(ctx context.Context,
+		arg NewMetaSchema) (MetaSchemaRow, error)
+
+	// FetchMetaSchema fetches the custom meta schema with the given ID.
+	FetchMetaSchema(ctx context.Context,
+		schemaID string) (MetaSchemaRow, error)
+
+	// QueryMetaSchemas returns all custom meta schemas, ordered by the
+	// time they were registered at.
+	QueryMetaSchemas(ctx context.Context) ([]MetaSchemaRow, error)
+}
+
+// BatchedMetaSchemaStore allows for batched DB transactions for the meta
+// schema store.
+type BatchedMetaSchemaStore interface {
+	MetaSchemaStore
+
+	BatchedTx[MetaSchemaStore]
+}
+
+// MetaSchemas is a persistent store for custom asset meta schemas.
+type MetaSchemas struct {
+	db BatchedMetaSchemaStore
+
+	clock clock.Clock
+}
+
+// NewMetaSchemas creates a new custom meta schema store.
+func NewMetaSchemas(db BatchedMetaSchemaStore,
+	clock clock.Clock) *MetaSchemas {
+
+	return &MetaSchemas{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// AddSchema stores a new custom meta schema.
+//
+// NOTE: This is part of the metaschema.Store interface.
+func (m *MetaSchemas) AddSchema(ctx context.Context, id string,
+	definition []byte) (*metaschema.Schema, error) {
+
+	var schema metaschema.Schema
+	var writeTx AssetStoreTxOptions
+	dbErr := m.db.ExecTx(ctx, &writeTx, func(q MetaSchemaStore) error {
+		row, err := q.InsertMetaSchema(ctx, NewMetaSchema{
+			SchemaID:     id,
+			Definition:   definition,
+			RegisteredAt: m.clock.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		schema = parseMetaSchema(row)
+
+		return nil
+	})
+	if dbErr != nil {
+		var uniqueConstraintErr *ErrSqlUniqueConstraintViolation
+		if errors.As(dbErr, &uniqueConstraintErr) {
+			return nil, fmt.Errorf("%w: %s", metaschema.ErrExists,
+				id)
+		}
+
+		return nil, fmt.Errorf("unable to add meta schema: %w", dbErr)
+	}
+
+	return &schema, nil
+}
+
+// FetchSchema returns the custom meta schema with the given ID.
+//
+// NOTE: This is part of the metaschema.Store interface.
+func (m *MetaSchemas) FetchSchema(ctx context.Context,
+	id string) (*metaschema.Schema, error) {
+
+	var schema metaschema.Schema
+	readTx := NewAssetStoreReadTx()
+	dbErr := m.db.ExecTx(ctx, &readTx, func(q MetaSchemaStore) error {
+		row, err := q.FetchMetaSchema(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		schema = parseMetaSchema(row)
+
+		return nil
+	})
+	switch {
+	case errors.Is(dbErr, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %s", metaschema.ErrNotFound, id)
+
+	case dbErr != nil:
+		return nil, fmt.Errorf("unable to fetch meta schema: %w",
+			dbErr)
+	}
+
+	return &schema, nil
+}
+
+// ListSchemas returns all custom meta schemas, ordered by the time they were
+// registered at.
+//
+// NOTE: This is part of the metaschema.Store interface.
+func (m *MetaSchemas) ListSchemas(
+	ctx context.Context) ([]metaschema.Schema, error) {
+
+	var schemas []metaschema.Schema
+	readTx := NewAssetStoreReadTx()
+	dbErr := m.db.ExecTx(ctx, &readTx, func(q MetaSchemaStore) error {
+		rows, err := q.QueryMetaSchemas(ctx)
+		if err != nil {
+			return err
+		}
+
+		schemas = make([]metaschema.Schema, 0, len(rows))
+		for _, row := range rows {
+			schemas = append(schemas, parseMetaSchema(row))
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to list meta schemas: %w", dbErr)
+	}
+
+	return schemas, nil
+}
+
+// parseMetaSchema converts a meta schema DB row into a schema.
+func parseMetaSchema(row MetaSchemaRow) metaschema.Schema {
+	return metaschema.Schema{
+		ID:           row.SchemaID,
+		Definition:   row.Definition,
+		RegisteredAt: row.RegisteredAt.UTC(),
+	}
+}
+
+// A compile-time assertion to ensure MetaSchemas meets the metaschema.Store
+// interface.
+var _ metaschema.Store = (*MetaSchemas)(nil)
@@ -0,0 +1,62 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/metaschema"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// newMetaSchemasFromDB makes a new meta schema store backed by the passed
+// database.
+func newMetaSchemasFromDB(db *BaseDB, clock clock.Clock) *MetaSchemas {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) MetaSchemaStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewMetaSchemas(dbTxer, clock)
+}
+
+// TestMetaSchemas tests that custom meta schemas can be added, fetched and
+// listed, and that a schema ID can only be registered once.
+func TestMetaSchemas(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	testClock := clock.NewTestClock(time.Unix(1700000000, 0))
+	store := newMetaSchemasFromDB(db.BaseDB, testClock)
+
+	_, err := store.FetchSchema(ctx, "nft/v1")
+	require.ErrorIs(t, err, metaschema.ErrNotFound)
+
+	nftDef := []byte(`{"type": "object"}`)
+	nft, err := store.AddSchema(ctx, "nft/v1", nftDef)
+	require.NoError(t, err)
+	require.Equal(t, "nft/v1", nft.ID)
+	require.Equal(t, nftDef, nft.Definition)
+	require.Equal(t, testClock.Now().UTC(), nft.RegisteredAt)
+
+	testClock.SetTime(testClock.Now().Add(time.Hour))
+	_, err = store.AddSchema(ctx, "ticket/v1", []byte(`{}`))
+	require.NoError(t, err)
+
+	_, err = store.AddSchema(ctx, "nft/v1", []byte(`{}`))
+	require.ErrorIs(t, err, metaschema.ErrExists)
+
+	dbNft, err := store.FetchSchema(ctx, "nft/v1")
+	require.NoError(t, err)
+	require.Equal(t, nft, dbNft)
+
+	schemas, err := store.ListSchemas(ctx)
+	require.NoError(t, err)
+	require.Len(t, schemas, 2)
+	require.Equal(t, "nft/v1", schemas[0].ID)
+	require.Equal(t, "ticket/v1", schemas[1].ID)
+}
@@ -0,0 +1,204 @@
+package tapdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+type (
+	// NewAssetMetaUpdate is used to insert a new asset meta update.
+	NewAssetMetaUpdate = sqlc.InsertAssetMetaUpdateParams
+
+	// AssetMetaUpdateQuery is used to query for asset meta updates.
+	AssetMetaUpdateQuery = sqlc.QueryAssetMetaUpdatesParams
+
+	// AssetMetaUpdateRow is a single asset meta update stored in the DB.
+	AssetMetaUpdateRow = sqlc.QueryAssetMetaUpdatesRow
+)
+
+var (
+	// ErrMetaUpdateOutdated is returned if a metadata update doesn't have a
+	// higher version than the latest known update of the same group.
+	ErrMetaUpdateOutdated = errors.New("meta update version not higher " +
+		"than latest known version")
+)
+
+// MetaUpdateStore is the main storage interface for signed asset group
+// metadata updates.
+type MetaUpdateStore interface {
+	// InsertAssetMetaUpdate inserts a new asset meta update.
+	InsertAssetMetaUpdate(ctx context.Context, arg NewAssetMetaUpdate) error
+
+	// QueryAssetMetaUpdates returns the meta updates of a group, ordered
+	// from the latest to the oldest version.
+	QueryAssetMetaUpdates(ctx context.Context,
+		arg AssetMetaUpdateQuery) ([]AssetMetaUpdateRow, error)
+}
+
+// BatchedMetaUpdateStore allows for batched DB transactions for the meta
+// update store.
+type BatchedMetaUpdateStore interface {
+	MetaUpdateStore
+
+	BatchedTx[MetaUpdateStore]
+}
+
+// AssetMetaUpdates is a persistent store for the signed metadata updates of
+// asset groups.
+type AssetMetaUpdates struct {
+	db BatchedMetaUpdateStore
+
+	clock clock.Clock
+}
+
+// NewAssetMetaUpdates creates a new meta update store.
+func NewAssetMetaUpdates(db BatchedMetaUpdateStore,
+	clock clock.Clock) *AssetMetaUpdates {
+
+	return &AssetMetaUpdates{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// LatestVersion returns the version of the latest known metadata update of the
+// given group. If no update is known, false is returned.
+func (a *AssetMetaUpdates) LatestVersion(ctx context.Context,
+	groupKey *btcec.PublicKey) (uint64, bool, error) {
+
+	updates, err := a.FetchMetaUpdates(ctx, groupKey, true)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(updates) == 0 {
+		return 0, false, nil
+	}
+
+	return updates[0].Version, true, nil
+}
+
+// InsertMetaUpdate stores the given metadata update. The update must already
+// be verified by the caller. If the update doesn't have a higher version than
+// the latest known update of the group, ErrMetaUpdateOutdated is returned.
+func (a *AssetMetaUpdates) InsertMetaUpdate(ctx context.Context,
+	update *proof.MetaUpdate) error {
+
+	if update.Version > math.MaxInt64 {
+		return fmt.Errorf("meta update version too large")
+	}
+
+	groupKey := update.GroupKey.SerializeCompressed()
+
+	var writeTx AssetStoreTxOptions
+	return a.db.ExecTx(ctx, &writeTx, func(q MetaUpdateStore) error {
+		latest, err := q.QueryAssetMetaUpdates(
+			ctx, AssetMetaUpdateQuery{
+				TweakedGroupKey: groupKey,
+				NumLimit:        1,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to query latest meta "+
+				"update: %w", err)
+		}
+
+		if len(latest) > 0 &&
+			int64(update.Version) <= latest[0].Version {
+
+			return fmt.Errorf("%w: got %d, latest is %d",
+				ErrMetaUpdateOutdated, update.Version,
+				latest[0].Version)
+		}
+
+		return q.InsertAssetMetaUpdate(ctx, NewAssetMetaUpdate{
+			TweakedGroupKey: groupKey,
+			Version:         int64(update.Version),
+			MetaType:        int16(update.Meta.Type),
+			MetaData:        update.Meta.Data,
+			SignerKey:       update.SignerKey.SerializeCompressed(),
+			Signature:       update.Signature.Serialize(),
+			CreatedAt:       a.clock.Now().UTC(),
+		})
+	})
+}
+
+// FetchMetaUpdates returns the known metadata updates of the given group,
+// ordered from the latest to the oldest version. If latestOnly is set, at most
+// the latest update is returned.
+func (a *AssetMetaUpdates) FetchMetaUpdates(ctx context.Context,
+	groupKey *btcec.PublicKey, latestOnly bool) ([]*proof.MetaUpdate,
+	error) {
+
+	limit := int32(math.MaxInt32)
+	if latestOnly {
+		limit = 1
+	}
+
+	var updates []*proof.MetaUpdate
+	readTx := NewAssetStoreReadTx()
+	dbErr := a.db.ExecTx(ctx, &readTx, func(q MetaUpdateStore) error {
+		updates = nil
+
+		rows, err := q.QueryAssetMetaUpdates(ctx, AssetMetaUpdateQuery{
+			TweakedGroupKey: groupKey.SerializeCompressed(),
+			NumLimit:        limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			update, err := parseMetaUpdate(row)
+			if err != nil {
+				return err
+			}
+
+			updates = append(updates, update)
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return updates, nil
+}
+
+// parseMetaUpdate parses a metadata update from its database representation.
+func parseMetaUpdate(row AssetMetaUpdateRow) (*proof.MetaUpdate, error) {
+	groupKey, err := btcec.ParsePubKey(row.TweakedGroupKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse group key: %w", err)
+	}
+
+	signerKey, err := btcec.ParsePubKey(row.SignerKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signer key: %w", err)
+	}
+
+	sig, err := schnorr.ParseSignature(row.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signature: %w", err)
+	}
+
+	return &proof.MetaUpdate{
+		GroupKey: groupKey,
+		Version:  uint64(row.Version),
+		Meta: &proof.MetaReveal{
+			Type: proof.MetaType(row.MetaType),
+			Data: row.MetaData,
+		},
+		SignerKey: signerKey,
+		Signature: sig,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssetMetaUpdates tests that meta updates can be stored and fetched, and
+// that outdated versions are rejected.
+func TestAssetMetaUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) MetaUpdateStore {
+			return db.WithTx(tx)
+		},
+	)
+	store := NewAssetMetaUpdates(
+		dbTxer, clock.NewTestClock(time.Unix(1_000_000, 0)),
+	)
+
+	groupKey := test.RandPubKey(t)
+	signerPriv := test.RandPrivKey()
+
+	newUpdate := func(version uint64, data string) *proof.MetaUpdate {
+		update := &proof.MetaUpdate{
+			GroupKey: groupKey,
+			Version:  version,
+			Meta: &proof.MetaReveal{
+				Type: proof.MetaJson,
+				Data: []byte(data),
+			},
+			SignerKey: signerPriv.PubKey(),
+		}
+
+		digest, err := update.SigDigest()
+		require.NoError(t, err)
+		update.Signature, err = schnorr.Sign(signerPriv, digest[:])
+		require.NoError(t, err)
+
+		return update
+	}
+
+	// Without any updates, there's no latest version.
+	_, found, err := store.LatestVersion(ctx, groupKey)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	update1 := newUpdate(1, `{"icon":"a"}`)
+	update2 := newUpdate(2, `{"icon":"b"}`)
+	require.NoError(t, store.InsertMetaUpdate(ctx, update1))
+	require.NoError(t, store.InsertMetaUpdate(ctx, update2))
+
+	// Updates with a version that isn't higher than the latest one are
+	// rejected.
+	err = store.InsertMetaUpdate(ctx, newUpdate(2, `{"icon":"c"}`))
+	require.ErrorIs(t, err, ErrMetaUpdateOutdated)
+	err = store.InsertMetaUpdate(ctx, newUpdate(1, `{"icon":"c"}`))
+	require.ErrorIs(t, err, ErrMetaUpdateOutdated)
+
+	latest, found, err := store.LatestVersion(ctx, groupKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 2, latest)
+
+	updates, err := store.FetchMetaUpdates(ctx, groupKey, false)
+	require.NoError(t, err)
+	require.Equal(t, []*proof.MetaUpdate{update2, update1}, updates)
+
+	updates, err = store.FetchMetaUpdates(ctx, groupKey, true)
+	require.NoError(t, err)
+	require.Equal(t, []*proof.MetaUpdate{update2}, updates)
+
+	// Updates of other groups aren't returned.
+	updates, err = store.FetchMetaUpdates(ctx, test.RandPubKey(t), false)
+	require.NoError(t, err)
+	require.Empty(t, updates)
+}
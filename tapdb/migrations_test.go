@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/stretchr/testify/require"
 )
 
@@ -293,3 +295,61 @@ func TestMigration20(t *testing.T) {
 	blob2 := p2[asset2Key]
 	require.Equal(t, []byte{0xee, 0xee}, []byte(blob2))
 }
+
+// TestMigration45 tests that the migration to version 45 backfills the
+// pre-aggregated universe stats from the existing universe events.
+func TestMigration45(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewTestDBWithVersion(t, 44)
+
+	issuanceID := newStatsTestUniverse(
+		t, db.BaseDB, false, universe.ProofTypeIssuance,
+	)
+	transferID := newStatsTestUniverse(
+		t, db.BaseDB, false, universe.ProofTypeTransfer,
+	)
+
+	// We'll log a new asset on the first day, and two transfers on the
+	// next day.
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logProofEvent := func(id universe.Identifier, eventTime time.Time) {
+		err := db.InsertNewProofEvent(ctx, NewProofEvent{
+			EventTime:      eventTime,
+			EventTimestamp: eventTime.Unix(),
+			AssetID:        id.AssetID[:],
+			ProofType:      id.ProofType.String(),
+		})
+		require.NoError(t, err)
+	}
+	logProofEvent(issuanceID, day1)
+	logProofEvent(transferID, day1.Add(24*time.Hour))
+	logProofEvent(transferID, day1.Add(25*time.Hour))
+
+	err := db.ExecuteMigrations(TargetVersion(45))
+	require.NoError(t, err)
+
+	dailyStats, err := db.QueryUniverseDailyStats(ctx, DailyStatsQuery{
+		StartTime: 0,
+		EndTime:   day1.Add(48 * time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []DailyStats{
+		{
+			DayStart:  statsDayStart(day1),
+			NewAssets: 1,
+			NewProofs: 1,
+		},
+		{
+			DayStart:  statsDayStart(day1.Add(24 * time.Hour)),
+			NewProofs: 2,
+		},
+	}, dailyStats)
+
+	transferStats, err := db.QueryTopUniverseTransferStats(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, transferStats, 1)
+	require.Equal(t, transferID.String(), transferStats[0].Namespace)
+	require.Equal(t, transferID.AssetID[:], transferStats[0].AssetID)
+	require.EqualValues(t, 2, transferStats[0].NumTransfers)
+}
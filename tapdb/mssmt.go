@@ -316,12 +316,7 @@ func (t *taprootAssetTreeStoreTx) GetChildren(height int, hashKey mssmt.NodeHash
 
 		// Since both children are nil, we can assume this is a leaf.
 		if row.LHashKey == nil && row.RHashKey == nil {
-			leaf := mssmt.NewLeafNode(
-				row.Value, uint64(row.Sum),
-			)
-
-			// Precompute the node hash key.
-			leaf.NodeHash()
+			leaf := newStoredLeafNode(row.Value, uint64(row.Sum))
 
 			// We store the key for compacted leafs.
 			if row.Key != nil {
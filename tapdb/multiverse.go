@@ -106,6 +106,10 @@ type BatchedMultiverse interface {
 type MultiverseStoreConfig struct {
 	// Caches is the set of cache configurations for the multiverse store.
 	Caches MultiverseCacheConfig
+
+	// ProofStore stores the raw proofs of the universe leaves. If it is
+	// nil, the raw proofs are stored inline in the universe trees.
+	ProofStore *UniverseProofStore
 }
 
 // DefaultMultiverseStoreConfig returns the default configuration for the
@@ -132,6 +136,8 @@ type MultiverseStore struct {
 
 	leafKeysCache *universeLeafPageCache
 
+	rootCache *universeRootCache
+
 	// transferProofDistributor is an event distributor that will be used to
 	// notify subscribers about new proof leaves that are added to the
 	// multiverse. This is used to notify the custodian about new incoming
@@ -161,10 +167,26 @@ func NewMultiverseStore(db BatchedMultiverse,
 			cfg.Caches.LeavesNumCachedUniverses,
 			cfg.Caches.LeavesPerUniverse,
 		),
+		rootCache: newUniverseRootCache(
+			cfg.Caches.UniverseRootsCacheSize,
+		),
 		transferProofDistributor: fn.NewEventDistributor[proof.Blob](),
 	}
 }
 
+// CacheStats returns a snapshot of the hit/miss counters of all multiverse
+// caches.
+func (b *MultiverseStore) CacheStats() []CacheStats {
+	return []CacheStats{
+		b.syncerCache.stats(),
+		b.rootNodeCache.stats(),
+		b.rootCache.multiverseLogger.stats(),
+		b.rootCache.rootLogger.stats(),
+		b.proofCache.stats(),
+		b.leafKeysCache.stats(),
+	}
+}
+
 // namespaceForProof returns the multiverse namespace used for the given proof
 // type.
 func namespaceForProof(proofType universe.ProofType) (string, error) {
@@ -193,8 +215,17 @@ func (b *MultiverseStore) MultiverseRootNode(ctx context.Context,
 		return none, err
 	}
 
+	// The multiverse root only changes when a proof is inserted or a
+	// universe is deleted, so we'll serve it from the cache if we can.
+	cachedRoot := b.rootCache.fetchMultiverseRoot(proofType)
+	if cachedRoot.IsSome() {
+		return cachedRoot, nil
+	}
+
 	var rootNode universe.MultiverseRoot
 
+	cacheEpoch := b.rootCache.currentEpoch()
+
 	readTx := NewBaseUniverseReadTx()
 	dbErr := b.db.ExecTx(ctx, &readTx, func(db BaseMultiverseStore) error {
 		multiverseRoot, err := db.FetchMultiverseRoot(ctx, multiverseNS)
@@ -226,6 +257,8 @@ func (b *MultiverseStore) MultiverseRootNode(ctx context.Context,
 		return none, dbErr
 	}
 
+	b.rootCache.cacheMultiverseRoot(cacheEpoch, rootNode)
+
 	return fn.Some(rootNode), nil
 }
 
@@ -281,10 +314,18 @@ func (b *MultiverseStore) UniverseRootNode(ctx context.Context,
 			"even after filling the cache", id)
 	}
 
+	// Next, we'll check the root cache, which holds the most recently
+	// queried roots.
+	cachedRoot := b.rootCache.fetchRoot(id)
+	if cachedRoot.IsSome() {
+		return cachedRoot.UnwrapOr(universe.Root{}), nil
+	}
+
 	var universeRoot UniverseRoot
 
 	universeNamespace := id.String()
 
+	cacheEpoch := b.rootCache.currentEpoch()
 	readTx := NewBaseUniverseReadTx()
 
 	dbErr := b.db.ExecTx(ctx, &readTx, func(db BaseMultiverseStore) error {
@@ -315,6 +356,8 @@ func (b *MultiverseStore) UniverseRootNode(ctx context.Context,
 		AssetName: universeRoot.AssetName,
 	}
 
+	b.rootCache.cacheRoot(cacheEpoch, dbRoot)
+
 	return dbRoot, nil
 }
 
@@ -360,6 +403,84 @@ func (b *MultiverseStore) UniverseLeafKeys(ctx context.Context,
 	return leafKeys, nil
 }
 
+// SubtreeNodes returns, for each of the given paths, the nodes of the given
+// universe tree that are found the given number of levels below the node at
+// that path.
+func (b *MultiverseStore) SubtreeNodes(ctx context.Context,
+	id universe.Identifier, paths []mssmt.NodePath,
+	levels int) ([]universe.SubtreeNode, error) {
+
+	namespace := id.String()
+
+	var nodes []universe.SubtreeNode
+
+	readTx := NewBaseUniverseReadTx()
+	dbErr := b.db.ExecTx(ctx, &readTx, func(db BaseMultiverseStore) error {
+		nodes = nil
+
+		universeTree := mssmt.NewCompactedTree(
+			newTreeStoreWrapperTx(db, namespace),
+		)
+
+		for _, path := range paths {
+			treeNodes, err := universeTree.SubtreeNodes(
+				ctx, path, levels,
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, treeNode := range treeNodes {
+				node := universe.SubtreeNode{
+					Path: treeNode.Path,
+					Node: treeNode.Node,
+				}
+
+				// If the subtree only holds a single leaf, we
+				// look up the leaf key, so the leaf can be
+				// fetched directly.
+				if treeNode.LeafKey != nil {
+					leafKey, err := fetchLeafKey(
+						ctx, db, namespace,
+						*treeNode.LeafKey,
+					)
+					if err != nil {
+						return err
+					}
+
+					node.LeafKey = fn.Some(leafKey)
+				}
+
+				nodes = append(nodes, node)
+			}
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return nodes, nil
+}
+
+// fetchLeafKey fetches the universe leaf key of the leaf that is stored at the
+// given MS-SMT key within the given universe namespace.
+func fetchLeafKey(ctx context.Context, db BaseUniverseStore, namespace string,
+	smtKey [32]byte) (universe.LeafKey, error) {
+
+	dbKey, err := db.FetchUniverseLeafKey(ctx, UniverseLeafKeyQuery{
+		Namespace:   namespace,
+		LeafNodeKey: smtKey[:],
+	})
+	if err != nil {
+		return universe.LeafKey{}, fmt.Errorf("unable to fetch leaf "+
+			"key %x: %w", smtKey[:], err)
+	}
+
+	return parseLeafKey(dbKey.MintingPoint, dbKey.ScriptKeyBytes)
+}
+
 // RootNodes returns the complete set of known base universe root nodes for the
 // set of base universes tracked in the multiverse.
 func (b *MultiverseStore) RootNodes(ctx context.Context,
@@ -615,17 +736,43 @@ func (b *MultiverseStore) FetchProofLeaf(ctx context.Context,
 		return proofsFromCache, nil
 	}
 
-	var (
-		readTx = NewBaseUniverseReadTx()
-		proofs []*universe.Proof
-	)
-
 	multiverseNS, err := namespaceForProof(id.ProofType)
 	if err != nil {
 		return nil, err
 	}
 
-	dbErr := b.db.ExecTx(ctx, &readTx, func(tx BaseMultiverseStore) error {
+	// Proof leaves are never modified once inserted, so we can serve them
+	// from a read replica if one is configured. The replica might lag
+	// behind the primary though, so if it doesn't know the proof (yet),
+	// we'll ask the primary database again.
+	replicaReadTx := NewBaseUniverseReplicaReadTx()
+	proofs, err := b.fetchProofLeaf(
+		ctx, &replicaReadTx, id, universeKey, multiverseNS,
+	)
+	if errors.Is(err, universe.ErrNoUniverseProofFound) {
+		readTx := NewBaseUniverseReadTx()
+		proofs, err = b.fetchProofLeaf(
+			ctx, &readTx, id, universeKey, multiverseNS,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert the proofs we just read up into the main cache.
+	b.proofCache.insertProofs(id, universeKey, proofs)
+
+	return proofs, nil
+}
+
+// fetchProofLeaf fetches the proof leaves for the target key from the database
+// using a transaction with the given options.
+func (b *MultiverseStore) fetchProofLeaf(ctx context.Context,
+	readTx TxOptions, id universe.Identifier, universeKey universe.LeafKey,
+	multiverseNS string) ([]*universe.Proof, error) {
+
+	var proofs []*universe.Proof
+	dbErr := b.db.ExecTx(ctx, readTx, func(tx BaseMultiverseStore) error {
 		var err error
 		proofs, err = universeFetchProofLeaf(ctx, id, universeKey, tx)
 		if err != nil {
@@ -670,8 +817,12 @@ func (b *MultiverseStore) FetchProofLeaf(ctx context.Context,
 		return nil, dbErr
 	}
 
-	// Insert the proofs we just read up into the main cache.
-	b.proofCache.insertProofs(id, universeKey, proofs)
+	// Offloaded raw proofs are only fetched after the transaction, so we
+	// don't hold it open while waiting for the blob store.
+	err := b.cfg.ProofStore.resolveProofs(ctx, proofs)
+	if err != nil {
+		return nil, err
+	}
 
 	return proofs, nil
 }
@@ -756,6 +907,12 @@ func (b *MultiverseStore) UpsertProofLeaf(ctx context.Context,
 	id universe.Identifier, key universe.LeafKey, leaf *universe.Leaf,
 	metaReveal *proof.MetaReveal) (*universe.Proof, error) {
 
+	// The raw proof is offloaded before the DB transaction, if configured.
+	leafNode, err := b.cfg.ProofStore.leafNode(ctx, leaf)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		writeTx       BaseMultiverseOptions
 		issuanceProof *universe.Proof
@@ -766,7 +923,7 @@ func (b *MultiverseStore) UpsertProofLeaf(ctx context.Context,
 		// tree.
 		var err error
 		issuanceProof, err = universeUpsertProofLeaf(
-			ctx, dbTx, id, key, leaf, metaReveal,
+			ctx, dbTx, id, key, leaf, leafNode, metaReveal,
 		)
 		if err != nil {
 			return err
@@ -781,12 +938,13 @@ func (b *MultiverseStore) UpsertProofLeaf(ctx context.Context,
 
 	// Invalidate the cache since we just updated the root.
 	b.rootNodeCache.wipeCache()
+	b.rootCache.invalidate(id)
 	b.proofCache.delProofsForAsset(id)
 	b.leafKeysCache.wipeCache(id.String())
 	b.syncerCache.addOrReplace(universe.Root{
 		ID:        id,
 		AssetName: leaf.Asset.Tag,
-		Node:      issuanceProof.MultiverseRoot,
+		Node:      issuanceProof.UniverseRoot,
 	})
 
 	// Notify subscribers about the new proof leaf, now that we're sure we
@@ -805,14 +963,28 @@ func (b *MultiverseStore) UpsertProofLeaf(ctx context.Context,
 func (b *MultiverseStore) UpsertProofLeafBatch(ctx context.Context,
 	items []*universe.Item) error {
 
-	insertProof := func(item *universe.Item,
+	// The raw proofs are offloaded before the DB transaction, if
+	// configured.
+	leafNodes := make([]*mssmt.LeafNode, len(items))
+	for idx := range items {
+		var err error
+		leafNodes[idx], err = b.cfg.ProofStore.leafNode(
+			ctx, items[idx].Leaf,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	insertProof := func(idx int,
 		dbTx BaseMultiverseStore) (*universe.Proof, error) {
 
 		// Upsert proof leaf into the asset (group) specific universe
 		// tree.
+		item := items[idx]
 		return universeUpsertProofLeaf(
 			ctx, dbTx, item.ID, item.Key, item.Leaf,
-			item.MetaReveal,
+			leafNodes[idx], item.MetaReveal,
 		)
 	}
 
@@ -824,8 +996,7 @@ func (b *MultiverseStore) UpsertProofLeafBatch(ctx context.Context,
 		ctx, &writeTx, func(store BaseMultiverseStore) error {
 			uniProofs = make([]*universe.Proof, len(items))
 			for idx := range items {
-				item := items[idx]
-				uniProof, err := insertProof(item, store)
+				uniProof, err := insertProof(idx, store)
 				if err != nil {
 					return err
 				}
@@ -843,6 +1014,11 @@ func (b *MultiverseStore) UpsertProofLeafBatch(ctx context.Context,
 	// TODO(roasbeef): want to write thru but then need db query again?
 
 	b.rootNodeCache.wipeCache()
+	b.rootCache.invalidate(
+		fn.Map(items, func(item *universe.Item) universe.Identifier {
+			return item.ID
+		})...,
+	)
 
 	// Notify subscribers about the new proof leaves, now that we're sure we
 	// have written them to the database. But we only care about transfer
@@ -908,6 +1084,7 @@ func (b *MultiverseStore) DeleteUniverse(ctx context.Context,
 
 	// Wipe the cache items from this node.
 	b.rootNodeCache.wipeCache()
+	b.rootCache.invalidate(id)
 
 	b.proofCache.Delete(id.String())
 	b.leafKeysCache.wipeCache(id.String())
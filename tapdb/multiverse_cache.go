@@ -52,6 +52,13 @@ type MultiverseCacheConfig struct {
 	// serves all paginated queries for root nodes that use different
 	// parameters than the syncer cache.
 	RootNodePageCacheSize uint64 `long:"root-node-page-cache-size" description:"The size of the root node page cache for all requests that aren't served by the syncer cache."`
+
+	// UniverseRootsCacheSize is the number of individual universe roots
+	// that are cached, in addition to the multiverse root of each proof
+	// type. Cached roots are invalidated whenever a proof is inserted into
+	// or a universe is deleted from the multiverse. A value of zero
+	// disables the cache.
+	UniverseRootsCacheSize uint64 `long:"universe-roots-cache-size" description:"The number of individual universe roots that are cached, in addition to the multiverse roots. A value of zero disables the universe and multiverse root cache."`
 }
 
 // DefaultMultiverseCacheConfig returns the default configuration for the
@@ -64,6 +71,7 @@ func DefaultMultiverseCacheConfig() MultiverseCacheConfig {
 		SyncerCacheEnabled:       false,
 		SyncerCachePreAllocSize:  100_000,
 		RootNodePageCacheSize:    20 * universe.MaxPageSize,
+		UniverseRootsCacheSize:   100_000,
 	}
 }
 
@@ -696,3 +704,160 @@ func (u *universeLeafPageCache) wipeCache(id universeIDKey) {
 
 	u.leafCache.Delete(id)
 }
+
+// cachedUniverseRoot is a universe root that can be stored in an LRU cache.
+type cachedUniverseRoot universe.Root
+
+// Size returns the size of the cached universe root. Since we scale the cache
+// by the number of items and not the total memory size, we can simply return 1
+// here to count each root as 1 item.
+func (c cachedUniverseRoot) Size() (uint64, error) {
+	return 1, nil
+}
+
+// universeRootCache caches the multiverse root of each proof type and the
+// roots of individual universes, so they don't need to be read from disk for
+// every query.
+type universeRootCache struct {
+	sync.Mutex
+
+	enabled bool
+
+	// epoch is incremented on every invalidation. Roots read from disk are
+	// only cached if no invalidation happened while they were being read,
+	// so a slow reader can't put a stale root into the cache.
+	epoch uint64
+
+	multiverseRoots map[universe.ProofType]universe.MultiverseRoot
+
+	roots *lru.Cache[universe.IdentifierKey, cachedUniverseRoot]
+
+	multiverseLogger *cacheLogger
+
+	rootLogger *cacheLogger
+}
+
+// newUniverseRootCache creates a new universe root cache that holds up to the
+// given number of universe roots. A size of zero disables the cache.
+func newUniverseRootCache(cacheSize uint64) *universeRootCache {
+	return &universeRootCache{
+		enabled: cacheSize > 0,
+		multiverseRoots: make(
+			map[universe.ProofType]universe.MultiverseRoot,
+		),
+		roots: lru.NewCache[universe.IdentifierKey, cachedUniverseRoot](
+			cacheSize,
+		),
+		multiverseLogger: newCacheLogger("multiverse_roots"),
+		rootLogger:       newCacheLogger("universe_root_nodes"),
+	}
+}
+
+// currentEpoch returns the current invalidation epoch, which must be passed to
+// the cache methods when storing roots read from disk.
+func (r *universeRootCache) currentEpoch() uint64 {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.epoch
+}
+
+// fetchMultiverseRoot returns the cached multiverse root for the given proof
+// type, if there is one.
+func (r *universeRootCache) fetchMultiverseRoot(
+	proofType universe.ProofType) fn.Option[universe.MultiverseRoot] {
+
+	if !r.enabled {
+		return fn.None[universe.MultiverseRoot]()
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	root, ok := r.multiverseRoots[proofType]
+	if !ok {
+		r.multiverseLogger.Miss()
+		return fn.None[universe.MultiverseRoot]()
+	}
+
+	r.multiverseLogger.Hit()
+
+	return fn.Some(root)
+}
+
+// cacheMultiverseRoot stores the given multiverse root that was read from disk
+// at the given epoch.
+func (r *universeRootCache) cacheMultiverseRoot(epoch uint64,
+	root universe.MultiverseRoot) {
+
+	if !r.enabled {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if epoch != r.epoch {
+		return
+	}
+
+	r.multiverseRoots[root.ProofType] = root
+}
+
+// fetchRoot returns the cached universe root for the given ID, if there is
+// one.
+func (r *universeRootCache) fetchRoot(
+	id universe.Identifier) fn.Option[universe.Root] {
+
+	if !r.enabled {
+		return fn.None[universe.Root]()
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	root, err := r.roots.Get(id.Key())
+	if err != nil {
+		r.rootLogger.Miss()
+		return fn.None[universe.Root]()
+	}
+
+	r.rootLogger.Hit()
+
+	return fn.Some(universe.Root(root))
+}
+
+// cacheRoot stores the given universe root that was read from disk at the
+// given epoch.
+func (r *universeRootCache) cacheRoot(epoch uint64, root universe.Root) {
+	if !r.enabled {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if epoch != r.epoch {
+		return
+	}
+
+	_, _ = r.roots.Put(root.ID.Key(), cachedUniverseRoot(root))
+}
+
+// invalidate removes the roots of the given universes and the multiverse
+// roots of their proof types from the cache.
+func (r *universeRootCache) invalidate(ids ...universe.Identifier) {
+	if !r.enabled {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.epoch++
+
+	for _, id := range ids {
+		r.roots.Delete(id.Key())
+		delete(r.multiverseRoots, id.ProofType)
+	}
+}
@@ -157,6 +157,105 @@ func TestMultiverseSyncerCache(t *testing.T) {
 	}
 }
 
+// TestMultiverseSyncerCacheUpsertProofLeaf tests that inserting a single proof
+// leaf adds the root of the leaf's universe to the syncer cache, not the root
+// of the multiverse tree.
+func TestMultiverseSyncerCacheUpsertProofLeaf(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	multiverse, _ := newTestMultiverse(t)
+	multiverse.syncerCache.enabled = true
+	multiverse.cfg.Caches.SyncerCacheEnabled = true
+
+	// We insert leaves into multiple universes, so the multiverse root is
+	// distinct from each universe root.
+	const numAssets = 3
+	items := make([]*universe.Item, numAssets)
+	cachedRoots := make([]*universe.Root, numAssets)
+	for i := range items {
+		items[i] = genRandomAsset(t)
+		issuanceProof, err := multiverse.UpsertProofLeaf(
+			ctx, items[i].ID, items[i].Key, items[i].Leaf, nil,
+		)
+		require.NoError(t, err)
+
+		cachedRoots[i] = multiverse.syncerCache.fetchRoot(items[i].ID)
+		require.NotNil(t, cachedRoots[i])
+		require.True(t, mssmt.IsEqualNode(
+			issuanceProof.UniverseRoot, cachedRoots[i].Node,
+		))
+	}
+
+	// The cached roots must match the roots we read from the database
+	// with the syncer cache disabled.
+	multiverse.syncerCache.enabled = false
+	multiverse.cfg.Caches.SyncerCacheEnabled = false
+	for i, item := range items {
+		dbRoot, err := multiverse.UniverseRootNode(ctx, item.ID)
+		require.NoError(t, err)
+		require.True(t, mssmt.IsEqualNode(dbRoot, cachedRoots[i].Node))
+	}
+}
+
+// TestMultiverseRootCache tests that the multiverse and universe roots are
+// served from the cache and invalidated when new proofs are inserted.
+func TestMultiverseRootCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	multiverse, _ := newTestMultiverse(t)
+	rootCache := multiverse.rootCache
+
+	item := genRandomAsset(t)
+	_, err := multiverse.UpsertProofLeaf(
+		ctx, item.ID, item.Key, item.Leaf, nil,
+	)
+	require.NoError(t, err)
+
+	// The first query for each root is a miss, the second one a hit.
+	fetchRoots := func() (universe.MultiverseRoot, universe.Root) {
+		multiverseRoot, err := multiverse.MultiverseRootNode(
+			ctx, item.ID.ProofType,
+		)
+		require.NoError(t, err)
+
+		root, err := multiverse.UniverseRootNode(ctx, item.ID)
+		require.NoError(t, err)
+
+		return multiverseRoot.UnwrapOr(universe.MultiverseRoot{}), root
+	}
+
+	multiverseRoot, root := fetchRoots()
+	cachedMultiverseRoot, cachedRoot := fetchRoots()
+	require.True(t, mssmt.IsEqualNode(multiverseRoot, cachedMultiverseRoot))
+	require.True(t, mssmt.IsEqualNode(root, cachedRoot))
+	require.EqualValues(t, 1, rootCache.multiverseLogger.hit.Load())
+	require.EqualValues(t, 1, rootCache.multiverseLogger.miss.Load())
+	require.EqualValues(t, 1, rootCache.rootLogger.hit.Load())
+	require.EqualValues(t, 1, rootCache.rootLogger.miss.Load())
+
+	// Inserting a new leaf into the same universe must invalidate both
+	// roots, so we get the new roots on the next query.
+	newItem := genRandomAsset(t)
+	_, err = multiverse.UpsertProofLeaf(
+		ctx, item.ID, newItem.Key, newItem.Leaf, nil,
+	)
+	require.NoError(t, err)
+
+	newMultiverseRoot, newRoot := fetchRoots()
+	require.False(t, mssmt.IsEqualNode(multiverseRoot, newMultiverseRoot))
+	require.False(t, mssmt.IsEqualNode(root, newRoot))
+	require.EqualValues(t, 2, rootCache.multiverseLogger.miss.Load())
+	require.EqualValues(t, 2, rootCache.rootLogger.miss.Load())
+
+	// A root that was read before an invalidation must not be cached.
+	epoch := rootCache.currentEpoch()
+	rootCache.invalidate(item.ID)
+	rootCache.cacheRoot(epoch, newRoot)
+	require.True(t, rootCache.fetchRoot(item.ID).IsNone())
+}
+
 func genRandomAsset(t *testing.T) *universe.Item {
 	proofType := universe.ProofTypeIssuance
 	if test.RandBool() {
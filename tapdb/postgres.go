@@ -57,6 +57,62 @@ type PostgresConfig struct {
 	ConnMaxLifetime    time.Duration `long:"connmaxlifetime" description:"Max amount of time a connection can be reused for before it is closed. Valid time units are {s, m, h}."`
 	ConnMaxIdleTime    time.Duration `long:"connmaxidletime" description:"Max amount of time a connection can be idle for before it is closed. Valid time units are {s, m, h}."`
 	RequireSSL         bool          `long:"requiressl" description:"Whether to require using SSL (mode: require) when connecting to the server."`
+	EnableEventBus     bool          `long:"enableeventbus" description:"Whether to use Postgres' LISTEN/NOTIFY mechanism to publish and receive events about new proofs, transfers and addresses, so multiple components and tapd instances using the same database don't need to poll for changes."`
+
+	ReadReplica PostgresReplicaConfig `group:"readreplica" namespace:"readreplica"`
+}
+
+// PostgresReplicaConfig holds the configuration of an optional read-only
+// Postgres replica. Any connection parameter that isn't set is inherited from
+// the primary database configuration.
+//
+// nolint:lll
+type PostgresReplicaConfig struct {
+	Host               string `long:"host" description:"Read replica server hostname. If set, heavy read queries of the universe server (universe stats and universe proof lookups) are executed on the replica while all writes go to the primary database."`
+	Port               int    `long:"port" description:"Read replica server port. Defaults to the port of the primary database."`
+	User               string `long:"user" description:"Read replica database user. Defaults to the user of the primary database."`
+	Password           string `long:"password" description:"Read replica database user's password. Defaults to the password of the primary database."`
+	DBName             string `long:"dbname" description:"Read replica database name to use. Defaults to the database name of the primary database."`
+	MaxOpenConnections int    `long:"maxconnections" description:"Max open connections to keep alive to the read replica server. Defaults to the value of the primary database."`
+	RequireSSL         bool   `long:"requiressl" description:"Whether to require using SSL (mode: require) when connecting to the read replica server."`
+	Balances           bool   `long:"balances" description:"Whether to also execute asset balance queries on the read replica. The replica can lag behind the primary database, so a balance might not yet reflect a transfer that was made just before. Only enable this if all clients can tolerate stale balances."`
+}
+
+// replicaConfig returns the full configuration of the read replica, with all
+// unset values inherited from the primary database configuration. If no read
+// replica is configured, nil is returned.
+func (s *PostgresConfig) replicaConfig() *PostgresConfig {
+	replica := s.ReadReplica
+	if replica.Host == "" {
+		return nil
+	}
+
+	cfg := *s
+	cfg.Host = replica.Host
+	cfg.RequireSSL = replica.RequireSSL
+
+	// Migrations are applied to the primary database only, the replica
+	// receives them through replication.
+	cfg.SkipMigrations = true
+	cfg.EnableEventBus = false
+
+	if replica.Port != 0 {
+		cfg.Port = replica.Port
+	}
+	if replica.User != "" {
+		cfg.User = replica.User
+	}
+	if replica.Password != "" {
+		cfg.Password = replica.Password
+	}
+	if replica.DBName != "" {
+		cfg.DBName = replica.DBName
+	}
+	if replica.MaxOpenConnections > 0 {
+		cfg.MaxOpenConnections = replica.MaxOpenConnections
+	}
+
+	return &cfg
 }
 
 // DSN returns the dns to connect to the database.
@@ -89,6 +145,45 @@ type PostgresStore struct {
 func NewPostgresStore(cfg *PostgresConfig) (*PostgresStore, error) {
 	log.Infof("Using SQL database '%s'", cfg.DSN(true))
 
+	rawDb, err := openPostgresDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := sqlc.NewPostgres(rawDb)
+	s := &PostgresStore{
+		cfg: cfg,
+		BaseDB: &BaseDB{
+			DB:      rawDb,
+			Queries: queries,
+		},
+	}
+
+	if replicaCfg := cfg.replicaConfig(); replicaCfg != nil {
+		log.Infof("Using SQL read replica '%s'", replicaCfg.DSN(true))
+
+		s.readReplica, err = openPostgresDB(replicaCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error opening read replica: %w",
+				err)
+		}
+	}
+
+	// Now that the database is open, populate the database with our set of
+	// schemas based on our embedded in-memory file system.
+	if !cfg.SkipMigrations {
+		if err := s.ExecuteMigrations(TargetLatest); err != nil {
+			return nil, fmt.Errorf("error executing migrations: "+
+				"%w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// openPostgresDB opens a connection pool to the Postgres database described by
+// the given config.
+func openPostgresDB(cfg *PostgresConfig) (*sql.DB, error) {
 	rawDb, err := sql.Open("pgx", cfg.DSN(false))
 	if err != nil {
 		return nil, err
@@ -119,25 +214,7 @@ func NewPostgresStore(cfg *PostgresConfig) (*PostgresStore, error) {
 	rawDb.SetConnMaxLifetime(connMaxLifetime)
 	rawDb.SetConnMaxIdleTime(connMaxIdleTime)
 
-	queries := sqlc.NewPostgres(rawDb)
-	s := &PostgresStore{
-		cfg: cfg,
-		BaseDB: &BaseDB{
-			DB:      rawDb,
-			Queries: queries,
-		},
-	}
-
-	// Now that the database is open, populate the database with our set of
-	// schemas based on our embedded in-memory file system.
-	if !cfg.SkipMigrations {
-		if err := s.ExecuteMigrations(TargetLatest); err != nil {
-			return nil, fmt.Errorf("error executing migrations: "+
-				"%w", err)
-		}
-	}
-
-	return s, nil
+	return rawDb, nil
 }
 
 // ExecuteMigrations runs migrations for the Postgres database, depending on the
@@ -166,7 +243,7 @@ func (s *PostgresStore) ExecuteMigrations(target MigrationTarget,
 
 // NewTestPostgresDB is a helper function that creates a Postgres database for
 // testing.
-func NewTestPostgresDB(t *testing.T) *PostgresStore {
+func NewTestPostgresDB(t testing.TB) *PostgresStore {
 	t.Helper()
 
 	t.Logf("Creating new Postgres DB for testing")
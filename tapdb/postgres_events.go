@@ -0,0 +1,473 @@
+package tapdb
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lib/pq"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+const (
+	// defaultEventBusMinReconnect is the minimum time the event bus waits
+	// before re-establishing a lost listener connection.
+	defaultEventBusMinReconnect = 100 * time.Millisecond
+
+	// defaultEventBusMaxReconnect is the maximum time the event bus waits
+	// before re-establishing a lost listener connection.
+	defaultEventBusMaxReconnect = 30 * time.Second
+
+	// defaultEventBusPingInterval is the interval in which the listener
+	// connection is checked for liveness if no notifications arrive.
+	defaultEventBusPingInterval = time.Minute
+)
+
+// DBEventChannel is the name of a Postgres notification channel a database
+// event is published on.
+type DBEventChannel string
+
+const (
+	// DBEventChannelNewProof is the channel on which a notification is
+	// published whenever a new asset proof was stored.
+	DBEventChannelNewProof DBEventChannel = "tapd_new_proof"
+
+	// DBEventChannelNewTransfer is the channel on which a notification is
+	// published whenever an outbound transfer was logged or confirmed.
+	DBEventChannelNewTransfer DBEventChannel = "tapd_new_transfer"
+
+	// DBEventChannelNewAddr is the channel on which a notification is
+	// published whenever a new address was created.
+	DBEventChannelNewAddr DBEventChannel = "tapd_new_addr"
+
+	// DBEventChannelReconnected is a pseudo channel that is never
+	// published to. Events with this channel are delivered to subscribers
+	// after the listener connection was re-established, as notifications
+	// might have been missed in the meantime. Subscribers should query the
+	// database for any state they might have missed.
+	DBEventChannelReconnected DBEventChannel = "tapd_reconnected"
+)
+
+// dbEventChannels is the list of all channels the event bus listens on.
+var dbEventChannels = []DBEventChannel{
+	DBEventChannelNewProof,
+	DBEventChannelNewTransfer,
+	DBEventChannelNewAddr,
+}
+
+// NewProofPayload is the payload of a DBEventChannelNewProof notification.
+type NewProofPayload struct {
+	// AssetID is the hex encoded asset ID of the asset the proof is for.
+	AssetID string `json:"asset_id"`
+
+	// ScriptKey is the hex encoded script key of the asset the proof is
+	// for.
+	ScriptKey string `json:"script_key"`
+
+	// OutPoint is the anchor outpoint of the asset the proof is for.
+	OutPoint string `json:"outpoint"`
+}
+
+// newProofPayload creates the notification payload for the proof identified by
+// the given locator.
+func newProofPayload(loc proof.Locator) NewProofPayload {
+	payload := NewProofPayload{
+		ScriptKey: hex.EncodeToString(
+			loc.ScriptKey.SerializeCompressed(),
+		),
+	}
+	if loc.AssetID != nil {
+		payload.AssetID = loc.AssetID.String()
+	}
+	if loc.OutPoint != nil {
+		payload.OutPoint = loc.OutPoint.String()
+	}
+
+	return payload
+}
+
+// Locator returns the locator of the proof the payload announces.
+func (p *NewProofPayload) Locator() (proof.Locator, error) {
+	var loc proof.Locator
+
+	scriptKeyBytes, err := hex.DecodeString(p.ScriptKey)
+	if err != nil {
+		return loc, fmt.Errorf("invalid script key: %w", err)
+	}
+	scriptKey, err := btcec.ParsePubKey(scriptKeyBytes)
+	if err != nil {
+		return loc, fmt.Errorf("invalid script key: %w", err)
+	}
+	loc.ScriptKey = *scriptKey
+
+	if p.AssetID != "" {
+		assetIDBytes, err := hex.DecodeString(p.AssetID)
+		if err != nil {
+			return loc, fmt.Errorf("invalid asset ID: %w", err)
+		}
+		if len(assetIDBytes) != len(asset.ID{}) {
+			return loc, fmt.Errorf("invalid asset ID length %d",
+				len(assetIDBytes))
+		}
+
+		var assetID asset.ID
+		copy(assetID[:], assetIDBytes)
+		loc.AssetID = &assetID
+	}
+
+	if p.OutPoint != "" {
+		outPoint, err := wire.NewOutPointFromString(p.OutPoint)
+		if err != nil {
+			return loc, fmt.Errorf("invalid outpoint: %w", err)
+		}
+		loc.OutPoint = outPoint
+	}
+
+	return loc, nil
+}
+
+// TransferStatus is the status of a transfer announced by a
+// DBEventChannelNewTransfer notification.
+type TransferStatus string
+
+const (
+	// TransferStatusPending indicates the transfer was logged but its
+	// anchor transaction isn't confirmed yet.
+	TransferStatusPending TransferStatus = "pending"
+
+	// TransferStatusConfirmed indicates the anchor transaction of the
+	// transfer confirmed.
+	TransferStatusConfirmed TransferStatus = "confirmed"
+)
+
+// NewTransferPayload is the payload of a DBEventChannelNewTransfer
+// notification.
+type NewTransferPayload struct {
+	// AnchorTxid is the transaction ID of the transfer's anchor
+	// transaction.
+	AnchorTxid string `json:"anchor_txid"`
+
+	// Status is the new status of the transfer.
+	Status TransferStatus `json:"status"`
+}
+
+// NewAddrPayload is the payload of a DBEventChannelNewAddr notification.
+type NewAddrPayload struct {
+	// TaprootOutputKey is the hex encoded x-only taproot output key of the
+	// new address.
+	TaprootOutputKey string `json:"taproot_output_key"`
+}
+
+// dbEventEnvelope is the JSON encoded content of a notification. It wraps the
+// actual payload with the ID of the event bus that published it, so an event
+// bus can tell its own events apart from those of other tapd instances.
+type dbEventEnvelope struct {
+	// Origin is the ID of the event bus that published the event.
+	Origin string `json:"origin"`
+
+	// Payload is the JSON encoded payload of the event.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// DBEvent is a notification about a change in the database that was received
+// by the event bus.
+type DBEvent struct {
+	// Channel is the channel the event was published on.
+	Channel DBEventChannel
+
+	// Local is true if the event was published by the same event bus that
+	// received it. The components of the publishing tapd instance are
+	// usually notified about such changes in-process already.
+	Local bool
+
+	// Payload is the JSON encoded payload of the event. The type of the
+	// payload depends on the channel (e.g. NewProofPayload for
+	// DBEventChannelNewProof).
+	Payload []byte
+
+	// received is the time the event was received by the event bus.
+	received time.Time
+}
+
+// Timestamp returns the time the event was received.
+//
+// NOTE: This is part of the fn.Event interface.
+func (e *DBEvent) Timestamp() time.Time {
+	return e.received
+}
+
+// DecodePayload decodes the JSON payload of the event into the given target.
+func (e *DBEvent) DecodePayload(target any) error {
+	return json.Unmarshal(e.Payload, target)
+}
+
+// A compile-time assertion to ensure DBEvent satisfies the fn.Event
+// interface.
+var _ fn.Event = (*DBEvent)(nil)
+
+// DBEventNotifier is used by the stores to announce committed changes to
+// other components (and other tapd instances) sharing the same database.
+type DBEventNotifier interface {
+	// NotifyDBEvent publishes the given payload on the given channel.
+	NotifyDBEvent(ctx context.Context, channel DBEventChannel,
+		payload any) error
+}
+
+// NoOpDBEventNotifier is a DBEventNotifier that doesn't publish anything. It
+// is used for database backends that don't support notifications.
+type NoOpDBEventNotifier struct{}
+
+// NotifyDBEvent publishes the given payload on the given channel.
+//
+// NOTE: This is part of the DBEventNotifier interface.
+func (NoOpDBEventNotifier) NotifyDBEvent(context.Context, DBEventChannel,
+	any) error {
+
+	return nil
+}
+
+// A compile-time assertion to ensure NoOpDBEventNotifier satisfies the
+// DBEventNotifier interface.
+var _ DBEventNotifier = (*NoOpDBEventNotifier)(nil)
+
+// notifyDBEvent publishes the given payload with the given notifier and only
+// logs a failure. Notifications are published after the change was committed,
+// so a failure to publish must not be reported as a failure of the change
+// itself.
+func notifyDBEvent(ctx context.Context, notifier DBEventNotifier,
+	channel DBEventChannel, payload any) {
+
+	if err := notifier.NotifyDBEvent(ctx, channel, payload); err != nil {
+		log.Warnf("Unable to publish %v DB event: %v", channel, err)
+	}
+}
+
+// PostgresEventBus is an event bus built on Postgres' LISTEN/NOTIFY
+// mechanism. It publishes notifications about committed changes and
+// distributes the notifications published by any tapd instance using the same
+// database to its subscribers, so they don't need to poll the database.
+type PostgresEventBus struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *PostgresConfig
+
+	db *sql.DB
+
+	// id is the random ID of this event bus that is added to all events
+	// it publishes.
+	id string
+
+	listener *pq.Listener
+
+	// eventDistributor distributes the received events to all
+	// subscribers.
+	eventDistributor *fn.EventDistributor[*DBEvent]
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewPostgresEventBus creates a new event bus for the given Postgres store.
+func NewPostgresEventBus(store *PostgresStore) *PostgresEventBus {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+
+	return &PostgresEventBus{
+		cfg:              store.cfg,
+		db:               store.DB,
+		id:               hex.EncodeToString(id[:]),
+		eventDistributor: fn.NewEventDistributor[*DBEvent](),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: DefaultStoreTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// A compile-time assertion to ensure PostgresEventBus satisfies the
+// DBEventNotifier interface.
+var _ DBEventNotifier = (*PostgresEventBus)(nil)
+
+// Start opens the listener connection and starts distributing events to the
+// subscribers.
+func (b *PostgresEventBus) Start() error {
+	var startErr error
+	b.startOnce.Do(func() {
+		log.Infof("Starting Postgres DB event bus")
+
+		b.listener = pq.NewListener(
+			b.cfg.DSN(false), defaultEventBusMinReconnect,
+			defaultEventBusMaxReconnect, b.logListenerEvent,
+		)
+
+		for _, channel := range dbEventChannels {
+			err := b.listener.Listen(string(channel))
+			if err != nil {
+				startErr = fmt.Errorf("unable to listen on "+
+					"channel %v: %w", channel, err)
+
+				_ = b.listener.Close()
+
+				return
+			}
+		}
+
+		b.Wg.Add(1)
+		go b.distributeEvents()
+	})
+
+	return startErr
+}
+
+// Stop closes the listener connection and stops distributing events.
+func (b *PostgresEventBus) Stop() error {
+	var stopErr error
+	b.stopOnce.Do(func() {
+		log.Infof("Stopping Postgres DB event bus")
+
+		close(b.Quit)
+		b.Wg.Wait()
+
+		if b.listener != nil {
+			stopErr = b.listener.Close()
+		}
+	})
+
+	return stopErr
+}
+
+// NotifyDBEvent publishes the given payload on the given channel. The payload
+// is encoded as JSON.
+//
+// NOTE: This is part of the DBEventNotifier interface.
+func (b *PostgresEventBus) NotifyDBEvent(ctx context.Context,
+	channel DBEventChannel, payload any) error {
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to encode payload: %w", err)
+	}
+
+	envelopeBytes, err := json.Marshal(&dbEventEnvelope{
+		Origin:  b.id,
+		Payload: payloadBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to encode event: %w", err)
+	}
+
+	_, err = b.db.ExecContext(
+		ctx, "SELECT pg_notify($1, $2)", string(channel),
+		string(envelopeBytes),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to notify: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterSubscriber adds a new subscriber for receiving DB events. The
+// subscriber receives the events of all channels.
+func (b *PostgresEventBus) RegisterSubscriber(
+	receiver *fn.EventReceiver[*DBEvent]) {
+
+	b.eventDistributor.RegisterSubscriber(receiver)
+}
+
+// RemoveSubscriber removes the given subscriber and also stops it from
+// processing events.
+func (b *PostgresEventBus) RemoveSubscriber(
+	subscriber *fn.EventReceiver[*DBEvent]) error {
+
+	return b.eventDistributor.RemoveSubscriber(subscriber)
+}
+
+// distributeEvents reads the notifications from the listener and distributes
+// them to all subscribers until the event bus is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (b *PostgresEventBus) distributeEvents() {
+	defer b.Wg.Done()
+
+	pingTicker := time.NewTicker(defaultEventBusPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case notification := <-b.listener.Notify:
+			event := &DBEvent{
+				received: time.Now(),
+			}
+
+			// A nil notification is sent by the listener after the
+			// connection was re-established.
+			if notification == nil {
+				event.Channel = DBEventChannelReconnected
+			} else {
+				var envelope dbEventEnvelope
+				err := json.Unmarshal(
+					[]byte(notification.Extra), &envelope,
+				)
+				if err != nil {
+					log.Warnf("Unable to decode DB event "+
+						"on channel %v: %v",
+						notification.Channel, err)
+
+					continue
+				}
+
+				event.Channel = DBEventChannel(
+					notification.Channel,
+				)
+				event.Local = envelope.Origin == b.id
+				event.Payload = envelope.Payload
+			}
+
+			log.Tracef("Received DB event on channel %v: %s",
+				event.Channel, event.Payload)
+
+			b.eventDistributor.NotifySubscribers(event)
+
+		case <-pingTicker.C:
+			// Pinging makes sure a dead connection is detected and
+			// re-established even if no notifications arrive.
+			if err := b.listener.Ping(); err != nil {
+				log.Debugf("DB event bus ping failed: %v", err)
+			}
+
+		case <-b.Quit:
+			return
+		}
+	}
+}
+
+// logListenerEvent logs the connection state changes of the listener.
+func (b *PostgresEventBus) logListenerEvent(event pq.ListenerEventType,
+	err error) {
+
+	switch event {
+	case pq.ListenerEventConnected:
+		log.Debugf("DB event bus connected")
+
+	case pq.ListenerEventDisconnected:
+		log.Warnf("DB event bus disconnected: %v", err)
+
+	case pq.ListenerEventReconnected:
+		log.Infof("DB event bus reconnected")
+
+	case pq.ListenerEventConnectionAttemptFailed:
+		log.Warnf("DB event bus connection attempt failed: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build test_db_postgres
+
+package tapdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresEventBus tests that events published by one event bus are
+// received by the subscribers of another event bus using the same database.
+func TestPostgresEventBus(t *testing.T) {
+	t.Parallel()
+
+	db := NewTestDB(t)
+	ctx := context.Background()
+
+	// We use two event buses to simulate two tapd instances sharing the
+	// same database.
+	publisher := NewPostgresEventBus(db)
+	listener := NewPostgresEventBus(db)
+
+	require.NoError(t, publisher.Start())
+	require.NoError(t, listener.Start())
+	t.Cleanup(func() {
+		require.NoError(t, publisher.Stop())
+		require.NoError(t, listener.Stop())
+	})
+
+	receiver := fn.NewEventReceiver[*DBEvent](fn.DefaultQueueSize)
+	listener.RegisterSubscriber(receiver)
+	t.Cleanup(func() {
+		require.NoError(t, listener.RemoveSubscriber(receiver))
+	})
+
+	txid := test.RandHash()
+	sentPayload := NewTransferPayload{
+		AnchorTxid: txid.String(),
+		Status:     TransferStatusPending,
+	}
+	err := publisher.NotifyDBEvent(
+		ctx, DBEventChannelNewTransfer, sentPayload,
+	)
+	require.NoError(t, err)
+
+	select {
+	case event := <-receiver.NewItemCreated.ChanOut():
+		require.Equal(t, DBEventChannelNewTransfer, event.Channel)
+		require.False(t, event.Local)
+
+		var payload NewTransferPayload
+		require.NoError(t, event.DecodePayload(&payload))
+		require.Equal(t, sentPayload, payload)
+
+	case <-time.After(DefaultStoreTimeout):
+		t.Fatalf("timeout waiting for DB event")
+	}
+}
@@ -0,0 +1,50 @@
+package tapdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresReplicaConfig tests that the read replica config inherits all
+// unset values from the primary database config.
+func TestPostgresReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &PostgresConfig{
+		Host:               "primary",
+		Port:               5432,
+		User:               "tapd",
+		Password:           "secret",
+		DBName:             "tapd",
+		MaxOpenConnections: 20,
+		EnableEventBus:     true,
+	}
+
+	// Without a replica host, no replica is used.
+	require.Nil(t, cfg.replicaConfig())
+
+	cfg.ReadReplica = PostgresReplicaConfig{
+		Host:       "replica",
+		User:       "reader",
+		RequireSSL: true,
+	}
+	replicaCfg := cfg.replicaConfig()
+	require.NotNil(t, replicaCfg)
+
+	require.Equal(t, "replica", replicaCfg.Host)
+	require.Equal(t, "reader", replicaCfg.User)
+	require.True(t, replicaCfg.RequireSSL)
+	require.Equal(t, cfg.Port, replicaCfg.Port)
+	require.Equal(t, cfg.Password, replicaCfg.Password)
+	require.Equal(t, cfg.DBName, replicaCfg.DBName)
+	require.Equal(t, cfg.MaxOpenConnections, replicaCfg.MaxOpenConnections)
+
+	// Migrations and the event bus are only handled by the primary.
+	require.True(t, replicaCfg.SkipMigrations)
+	require.False(t, replicaCfg.EnableEventBus)
+
+	// The primary config must not be modified.
+	require.Equal(t, "primary", cfg.Host)
+	require.False(t, cfg.SkipMigrations)
+}
@@ -0,0 +1,197 @@
+package tapdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+// DBEventSource is a source of database events, for example the
+// PostgresEventBus.
+type DBEventSource interface {
+	// RegisterSubscriber adds a new subscriber for receiving DB events.
+	RegisterSubscriber(receiver *fn.EventReceiver[*DBEvent])
+
+	// RemoveSubscriber removes the given subscriber and also stops it from
+	// processing events.
+	RemoveSubscriber(subscriber *fn.EventReceiver[*DBEvent]) error
+}
+
+// A compile-time assertion to ensure PostgresEventBus satisfies the
+// DBEventSource interface.
+var _ DBEventSource = (*PostgresEventBus)(nil)
+
+// DBEventProofNotifier is a proof.NotifyArchiver that notifies its subscribers
+// about new proofs that were stored by other tapd instances using the same
+// database. It learns about those proofs through DB events, so the subscribers
+// don't need to wait for a proof courier to deliver a proof that is already in
+// the database.
+type DBEventProofNotifier struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	// events is the source of the DB events we're subscribed to.
+	events DBEventSource
+
+	// archive is used to fetch the proofs announced by the DB events.
+	archive proof.NotifyArchiver
+
+	// subscription is our subscription to the DB events.
+	subscription *fn.EventReceiver[*DBEvent]
+
+	// eventDistributor distributes the fetched proofs to all subscribers.
+	eventDistributor *fn.EventDistributor[proof.Blob]
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewDBEventProofNotifier creates a new proof notifier that fetches the proofs
+// announced by the given DB event source from the given archive.
+func NewDBEventProofNotifier(events DBEventSource,
+	archive proof.NotifyArchiver) *DBEventProofNotifier {
+
+	return &DBEventProofNotifier{
+		events:           events,
+		archive:          archive,
+		eventDistributor: fn.NewEventDistributor[proof.Blob](),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: DefaultStoreTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// A compile-time assertion to ensure DBEventProofNotifier satisfies the
+// proof.NotifyArchiver interface.
+var _ proof.NotifyArchiver = (*DBEventProofNotifier)(nil)
+
+// Start subscribes to the DB events and starts notifying the subscribers about
+// new proofs.
+func (n *DBEventProofNotifier) Start() error {
+	n.startOnce.Do(func() {
+		log.Infof("Starting DB event proof notifier")
+
+		n.subscription = fn.NewEventReceiver[*DBEvent](
+			fn.DefaultQueueSize,
+		)
+		n.events.RegisterSubscriber(n.subscription)
+
+		n.Wg.Add(1)
+		go n.processEvents()
+	})
+
+	return nil
+}
+
+// Stop stops notifying the subscribers and removes the DB event subscription.
+func (n *DBEventProofNotifier) Stop() error {
+	var stopErr error
+	n.stopOnce.Do(func() {
+		log.Infof("Stopping DB event proof notifier")
+
+		close(n.Quit)
+		n.Wg.Wait()
+
+		if n.subscription != nil {
+			stopErr = n.events.RemoveSubscriber(n.subscription)
+		}
+	})
+
+	return stopErr
+}
+
+// FetchProof fetches a proof for an asset uniquely identified by the passed
+// Identifier.
+//
+// NOTE: This is part of the proof.NotifyArchiver interface.
+func (n *DBEventProofNotifier) FetchProof(ctx context.Context,
+	id proof.Locator) (proof.Blob, error) {
+
+	return n.archive.FetchProof(ctx, id)
+}
+
+// RegisterSubscriber adds a new subscriber for receiving events. Existing
+// proofs are never delivered, as this notifier is meant to be used alongside
+// the archive itself (e.g. in a proof.MultiArchiveNotifier), which already
+// delivers them.
+//
+// NOTE: This is part of the proof.NotifyArchiver interface.
+func (n *DBEventProofNotifier) RegisterSubscriber(
+	receiver *fn.EventReceiver[proof.Blob], _ bool,
+	_ []*proof.Locator) error {
+
+	n.eventDistributor.RegisterSubscriber(receiver)
+
+	return nil
+}
+
+// RemoveSubscriber removes the given subscriber and also stops it from
+// processing events.
+//
+// NOTE: This is part of the proof.NotifyArchiver interface.
+func (n *DBEventProofNotifier) RemoveSubscriber(
+	subscriber *fn.EventReceiver[proof.Blob]) error {
+
+	return n.eventDistributor.RemoveSubscriber(subscriber)
+}
+
+// processEvents notifies the subscribers about the proofs announced by the DB
+// events until the notifier is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *DBEventProofNotifier) processEvents() {
+	defer n.Wg.Done()
+
+	for {
+		select {
+		case event := <-n.subscription.NewItemCreated.ChanOut():
+			// Proofs stored by our own instance are already
+			// announced in-process by the archive itself.
+			if event.Local {
+				continue
+			}
+			if event.Channel != DBEventChannelNewProof {
+				continue
+			}
+
+			if err := n.notifyNewProof(event); err != nil {
+				log.Warnf("Unable to process new proof DB "+
+					"event: %v", err)
+			}
+
+		case <-n.Quit:
+			return
+		}
+	}
+}
+
+// notifyNewProof fetches the proof announced by the given event and notifies
+// the subscribers about it.
+func (n *DBEventProofNotifier) notifyNewProof(event *DBEvent) error {
+	var payload NewProofPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	loc, err := payload.Locator()
+	if err != nil {
+		return fmt.Errorf("invalid proof locator: %w", err)
+	}
+
+	ctxt, cancel := n.WithCtxQuit()
+	defer cancel()
+
+	blob, err := n.archive.FetchProof(ctxt, loc)
+	if err != nil {
+		return fmt.Errorf("unable to fetch proof: %w", err)
+	}
+
+	n.eventDistributor.NotifySubscribers(blob)
+
+	return nil
+}
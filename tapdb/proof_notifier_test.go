@@ -0,0 +1,79 @@
+package tapdb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDBEventProofNotifier tests that a proof stored by another tapd instance
+// using the same database is delivered to the subscribers of the proof
+// notifier once the corresponding DB event is received.
+func TestDBEventProofNotifier(t *testing.T) {
+	t.Parallel()
+
+	// The publisher simulates another tapd instance that stores a proof in
+	// the shared database.
+	db := NewTestDB(t)
+	publisher := newDbHandleFromDb(db.BaseDB)
+	_, assetStore := newAssetStoreFromDB(db.BaseDB)
+
+	events := fn.NewEventDistributor[*DBEvent]()
+	notifier := NewDBEventProofNotifier(events, assetStore)
+	require.NoError(t, notifier.Start())
+	t.Cleanup(func() {
+		require.NoError(t, notifier.Stop())
+	})
+
+	receiver := fn.NewEventReceiver[proof.Blob](fn.DefaultQueueSize)
+	require.NoError(t, notifier.RegisterSubscriber(receiver, false, nil))
+	t.Cleanup(func() {
+		require.NoError(t, notifier.RemoveSubscriber(receiver))
+	})
+
+	_, testProof := publisher.AddRandomAssetProof(t)
+
+	payload := newProofPayload(testProof.Locator)
+	loc, err := payload.Locator()
+	require.NoError(t, err)
+	require.Equal(t, testProof.Locator.AssetID, loc.AssetID)
+	require.True(t, testProof.Locator.ScriptKey.IsEqual(&loc.ScriptKey))
+
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	// Events published by our own instance and events of other channels
+	// must be ignored.
+	events.NotifySubscribers(&DBEvent{
+		Channel: DBEventChannelNewProof,
+		Local:   true,
+		Payload: payloadBytes,
+	})
+	events.NotifySubscribers(&DBEvent{
+		Channel: DBEventChannelNewAddr,
+		Payload: []byte("{}"),
+	})
+	events.NotifySubscribers(&DBEvent{
+		Channel: DBEventChannelNewProof,
+		Payload: payloadBytes,
+	})
+
+	select {
+	case blob := <-receiver.NewItemCreated.ChanOut():
+		require.Equal(t, testProof.Blob, blob)
+
+	case <-time.After(DefaultStoreTimeout):
+		t.Fatalf("timeout waiting for proof")
+	}
+
+	select {
+	case <-receiver.NewItemCreated.ChanOut():
+		t.Fatalf("unexpected proof notification")
+
+	case <-time.After(100 * time.Millisecond):
+	}
+}
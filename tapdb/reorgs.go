@@ -0,0 +1,177 @@
+package tapdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+type (
+	// NewAnchorTxReorg is used to mark a confirmed anchor transaction as
+	// re-organized out of the chain.
+	NewAnchorTxReorg = sqlc.InsertAnchorTxReorgParams
+
+	// AnchorTxReorg is an anchor transaction that was re-organized out of
+	// the chain.
+	AnchorTxReorg = sqlc.QueryAnchorTxReorgsRow
+)
+
+// ReOrgStore is the storage interface for the re-org state of anchor
+// transactions.
+type ReOrgStore interface {
+	// InsertAnchorTxReorg marks a confirmed anchor transaction as
+	// re-organized out of the chain, remembering the block it was
+	// confirmed in.
+	InsertAnchorTxReorg(ctx context.Context, arg NewAnchorTxReorg) error
+
+	// DeleteAnchorTxReorg clears the re-org state of the anchor
+	// transaction with the given hash.
+	DeleteAnchorTxReorg(ctx context.Context, txid []byte) error
+
+	// QueryAnchorTxReorgs returns all anchor transactions that are marked
+	// as re-organized out of the chain.
+	QueryAnchorTxReorgs(ctx context.Context) ([]AnchorTxReorg, error)
+
+	// ConfirmChainAnchorTx marks a new anchor transaction that was
+	// previously unconfirmed as confirmed.
+	ConfirmChainAnchorTx(ctx context.Context, arg AnchorTxConf) error
+}
+
+// BatchedReOrgStore allows for batched DB transactions for the re-org store.
+type BatchedReOrgStore interface {
+	ReOrgStore
+
+	BatchedTx[ReOrgStore]
+}
+
+// ReOrgLog persists the re-org state of asset anchor transactions.
+type ReOrgLog struct {
+	db BatchedReOrgStore
+
+	clock clock.Clock
+}
+
+// NewReOrgLog creates a new re-org log.
+func NewReOrgLog(db BatchedReOrgStore, clock clock.Clock) *ReOrgLog {
+	return &ReOrgLog{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// LogAnchorTxOrphaned marks the confirmed anchor transaction with the given
+// hash as re-organized out of the chain. The confirmation of the transaction
+// itself is kept until it is mined again, so the transfers it anchors aren't
+// considered pending again.
+//
+// NOTE: This is part of the tapgarden.AnchorTxReOrgLog interface.
+func (r *ReOrgLog) LogAnchorTxOrphaned(ctx context.Context,
+	txid chainhash.Hash) error {
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q ReOrgStore) error {
+		err := q.InsertAnchorTxReorg(ctx, NewAnchorTxReorg{
+			DetectedAt: r.clock.Now().UTC(),
+			Txid:       txid[:],
+		})
+		if err != nil {
+			return fmt.Errorf("unable to log re-org of anchor tx "+
+				"%v: %w", txid, err)
+		}
+
+		return nil
+	})
+}
+
+// LogAnchorTxConfirmed updates the confirmation of the anchor transaction with
+// the given hash to the given block and clears its orphaned state, if any.
+//
+// NOTE: This is part of the tapgarden.AnchorTxReOrgLog interface.
+func (r *ReOrgLog) LogAnchorTxConfirmed(ctx context.Context,
+	txid chainhash.Hash, blockHash chainhash.Hash, blockHeight uint32,
+	txIndex uint32) error {
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q ReOrgStore) error {
+		err := q.ConfirmChainAnchorTx(ctx, AnchorTxConf{
+			Txid:        txid[:],
+			BlockHash:   blockHash[:],
+			BlockHeight: sqlInt32(blockHeight),
+			TxIndex:     sqlInt32(txIndex),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to confirm anchor tx %v: %w",
+				txid, err)
+		}
+
+		err = q.DeleteAnchorTxReorg(ctx, txid[:])
+		if err != nil {
+			return fmt.Errorf("unable to clear re-org of anchor "+
+				"tx %v: %w", txid, err)
+		}
+
+		return nil
+	})
+}
+
+// OrphanedAnchorTxs returns all anchor transactions that were re-organized
+// out of the chain and weren't mined again yet.
+//
+// NOTE: This is part of the tapgarden.AnchorTxReOrgLog interface.
+func (r *ReOrgLog) OrphanedAnchorTxs(
+	ctx context.Context) ([]tapgarden.OrphanedAnchorTx, error) {
+
+	var (
+		orphaned []tapgarden.OrphanedAnchorTx
+		readTx   = NewAssetStoreReadTx()
+	)
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q ReOrgStore) error {
+		reorgs, err := q.QueryAnchorTxReorgs(ctx)
+		if err != nil {
+			return err
+		}
+
+		orphaned, err = fn.MapErr(reorgs, parseAnchorTxReorg)
+
+		return err
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query orphaned anchor txs: "+
+			"%w", dbErr)
+	}
+
+	return orphaned, nil
+}
+
+// parseAnchorTxReorg parses an orphaned anchor transaction from its database
+// representation.
+func parseAnchorTxReorg(
+	reorg AnchorTxReorg) (tapgarden.OrphanedAnchorTx, error) {
+
+	var orphaned tapgarden.OrphanedAnchorTx
+	txid, err := chainhash.NewHash(reorg.Txid)
+	if err != nil {
+		return orphaned, err
+	}
+
+	blockHash, err := chainhash.NewHash(reorg.OrphanedBlockHash)
+	if err != nil {
+		return orphaned, err
+	}
+
+	return tapgarden.OrphanedAnchorTx{
+		Txid:        *txid,
+		BlockHash:   *blockHash,
+		BlockHeight: uint32(reorg.OrphanedBlockHeight),
+		DetectedAt:  reorg.DetectedAt.UTC(),
+	}, nil
+}
+
+// A compile-time assertion to ensure ReOrgLog meets the
+// tapgarden.AnchorTxReOrgLog interface.
+var _ tapgarden.AnchorTxReOrgLog = (*ReOrgLog)(nil)
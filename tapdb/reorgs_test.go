@@ -0,0 +1,96 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tapgarden"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// newReOrgLogFromDB makes a new re-org log backed by the passed database.
+func newReOrgLogFromDB(db *BaseDB, clock clock.Clock) *ReOrgLog {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) ReOrgStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewReOrgLog(dbTxer, clock)
+}
+
+// TestReOrgLog tests that a confirmed anchor transaction that is re-organized
+// out of the chain is tracked as orphaned until it is confirmed again, without
+// losing its previous confirmation in the meantime.
+func TestReOrgLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	now := time.Unix(1_700_000_000, 0).UTC()
+	reOrgLog := newReOrgLogFromDB(db.BaseDB, clock.NewTestClock(now))
+
+	// We start with a confirmed and an unconfirmed anchor transaction.
+	txid := test.RandHash()
+	blockHash := test.RandHash()
+	_, err := db.UpsertChainTx(ctx, ChainTxParams{
+		Txid:        txid[:],
+		RawTx:       []byte{0x01},
+		BlockHeight: sqlInt32(100),
+		BlockHash:   blockHash[:],
+		TxIndex:     sqlInt32(1),
+	})
+	require.NoError(t, err)
+
+	unconfirmedTxid := test.RandHash()
+	_, err = db.UpsertChainTx(ctx, ChainTxParams{
+		Txid:  unconfirmedTxid[:],
+		RawTx: []byte{0x02},
+	})
+	require.NoError(t, err)
+
+	orphaned, err := reOrgLog.OrphanedAnchorTxs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, orphaned)
+
+	// Only a confirmed transaction can be orphaned, and logging the re-org
+	// twice is a no-op.
+	require.NoError(t, reOrgLog.LogAnchorTxOrphaned(ctx, txid))
+	require.NoError(t, reOrgLog.LogAnchorTxOrphaned(ctx, txid))
+	require.NoError(t, reOrgLog.LogAnchorTxOrphaned(ctx, unconfirmedTxid))
+
+	orphaned, err = reOrgLog.OrphanedAnchorTxs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []tapgarden.OrphanedAnchorTx{{
+		Txid:        txid,
+		BlockHash:   blockHash,
+		BlockHeight: 100,
+		DetectedAt:  now,
+	}}, orphaned)
+
+	// The previous confirmation is kept while the transaction is orphaned.
+	chainTx, err := db.FetchChainTx(ctx, txid[:])
+	require.NoError(t, err)
+	require.Equal(t, blockHash[:], chainTx.BlockHash)
+
+	// Once the transaction is mined again, the new confirmation is stored
+	// and the transaction is no longer orphaned.
+	newBlockHash := test.RandHash()
+	require.NoError(t, reOrgLog.LogAnchorTxConfirmed(
+		ctx, txid, newBlockHash, 101, 3,
+	))
+
+	orphaned, err = reOrgLog.OrphanedAnchorTxs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, orphaned)
+
+	chainTx, err = db.FetchChainTx(ctx, txid[:])
+	require.NoError(t, err)
+	require.Equal(t, newBlockHash[:], chainTx.BlockHash)
+	require.Equal(t, sqlInt32(101), chainTx.BlockHeight)
+	require.Equal(t, sqlInt32(3), chainTx.TxIndex)
+}
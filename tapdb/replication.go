@@ -0,0 +1,195 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+)
+
+type (
+	// NewReplicationChange is used to append a new change to the
+	// replication log.
+	NewReplicationChange = sqlc.InsertReplicationChangeParams
+
+	// ReplicationChangeQuery is used to fetch changes from the replication
+	// log.
+	ReplicationChangeQuery = sqlc.FetchReplicationChangesParams
+
+	// ReplicationCursorUpdate is used to update the replication cursor of
+	// a leader.
+	ReplicationCursorUpdate = sqlc.UpsertReplicationCursorParams
+)
+
+// ReplicationStore is the main storage interface for the state replication.
+type ReplicationStore interface {
+	// InsertReplicationChange appends a new change to the replication log
+	// and returns its sequence number.
+	InsertReplicationChange(ctx context.Context,
+		arg NewReplicationChange) (int64, error)
+
+	// FetchReplicationChanges fetches the changes of the replication log
+	// after the given sequence number.
+	FetchReplicationChanges(ctx context.Context,
+		arg ReplicationChangeQuery) ([]sqlc.ReplicationChange, error)
+
+	// FetchReplicationCursor fetches the sequence number of the last
+	// change applied from the given leader.
+	FetchReplicationCursor(ctx context.Context, leaderID string) (int64,
+		error)
+
+	// UpsertReplicationCursor inserts or updates the sequence number of
+	// the last change applied from a leader.
+	UpsertReplicationCursor(ctx context.Context,
+		arg ReplicationCursorUpdate) error
+}
+
+// BatchedReplicationStore allows for batched DB transactions for the
+// replication store.
+type BatchedReplicationStore interface {
+	ReplicationStore
+
+	BatchedTx[ReplicationStore]
+}
+
+// ReplicationLog is a persistent store for the replication log of a leader
+// and the replication cursors of a follower.
+type ReplicationLog struct {
+	db BatchedReplicationStore
+}
+
+// A compile-time assertion to ensure ReplicationLog meets the
+// replication.Store and replication.CursorStore interfaces.
+var (
+	_ replication.Store       = (*ReplicationLog)(nil)
+	_ replication.CursorStore = (*ReplicationLog)(nil)
+)
+
+// NewReplicationLog creates a new replication log store.
+func NewReplicationLog(db BatchedReplicationStore) *ReplicationLog {
+	return &ReplicationLog{
+		db: db,
+	}
+}
+
+// AppendChange appends a change of the given type to the log and returns its
+// sequence number.
+//
+// NOTE: This is part of the replication.Store interface.
+func (r *ReplicationLog) AppendChange(ctx context.Context,
+	changeType replication.ChangeType, payload []byte,
+	timestamp time.Time) (uint64, error) {
+
+	var (
+		writeTx AssetStoreTxOptions
+		seq     int64
+	)
+	dbErr := r.db.ExecTx(ctx, &writeTx, func(q ReplicationStore) error {
+		var err error
+		seq, err = q.InsertReplicationChange(ctx, NewReplicationChange{
+			ChangeType: int16(changeType),
+			Payload:    payload,
+			CreatedAt:  timestamp.UTC(),
+		})
+		return err
+	})
+	if dbErr != nil {
+		return 0, fmt.Errorf("unable to insert replication change: %w",
+			dbErr)
+	}
+
+	return uint64(seq), nil
+}
+
+// FetchChanges returns up to limit changes with a sequence number greater
+// than afterSeq, in the order they were appended.
+//
+// NOTE: This is part of the replication.Store interface.
+func (r *ReplicationLog) FetchChanges(ctx context.Context, afterSeq uint64,
+	limit int32) ([]replication.Change, error) {
+
+	var changes []replication.Change
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q ReplicationStore) error {
+		rows, err := q.FetchReplicationChanges(
+			ctx, ReplicationChangeQuery{
+				AfterSeq: int64(afterSeq),
+				NumLimit: limit,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		changes = make([]replication.Change, len(rows))
+		for idx, row := range rows {
+			changeType := replication.ChangeType(row.ChangeType)
+			changes[idx] = replication.Change{
+				Seq:       uint64(row.Seq),
+				Type:      changeType,
+				Payload:   row.Payload,
+				Timestamp: row.CreatedAt.UTC(),
+			}
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to fetch replication changes: "+
+			"%w", dbErr)
+	}
+
+	return changes, nil
+}
+
+// FetchCursor returns the sequence number of the last change that was applied
+// from the given leader, or zero if no change was applied yet.
+//
+// NOTE: This is part of the replication.CursorStore interface.
+func (r *ReplicationLog) FetchCursor(ctx context.Context,
+	leaderID string) (uint64, error) {
+
+	var lastSeq int64
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q ReplicationStore) error {
+		var err error
+		lastSeq, err = q.FetchReplicationCursor(ctx, leaderID)
+		return err
+	})
+	switch {
+	case errors.Is(dbErr, sql.ErrNoRows):
+		return 0, nil
+
+	case dbErr != nil:
+		return 0, fmt.Errorf("unable to fetch replication cursor: %w",
+			dbErr)
+	}
+
+	return uint64(lastSeq), nil
+}
+
+// UpdateCursor sets the sequence number of the last change that was applied
+// from the given leader.
+//
+// NOTE: This is part of the replication.CursorStore interface.
+func (r *ReplicationLog) UpdateCursor(ctx context.Context, leaderID string,
+	seq uint64) error {
+
+	var writeTx AssetStoreTxOptions
+	dbErr := r.db.ExecTx(ctx, &writeTx, func(q ReplicationStore) error {
+		return q.UpsertReplicationCursor(ctx, ReplicationCursorUpdate{
+			LeaderID: leaderID,
+			LastSeq:  int64(seq),
+		})
+	})
+	if dbErr != nil {
+		return fmt.Errorf("unable to update replication cursor: %w",
+			dbErr)
+	}
+
+	return nil
+}
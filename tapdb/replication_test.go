@@ -0,0 +1,94 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/replication"
+	"github.com/stretchr/testify/require"
+)
+
+// newReplicationLogFromDB makes a new replication log store backed by the
+// passed database.
+func newReplicationLogFromDB(db *BaseDB) *ReplicationLog {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) ReplicationStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewReplicationLog(dbTxer)
+}
+
+// TestReplicationLog tests that changes can be appended to the replication
+// log and fetched in order, and that replication cursors are persisted.
+func TestReplicationLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	store := newReplicationLogFromDB(db.BaseDB)
+
+	// An empty log has no changes.
+	changes, err := store.FetchChanges(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	baseTime := time.Unix(1_700_000_000, 0).UTC()
+	var expected []replication.Change
+	for idx, changeType := range []replication.ChangeType{
+		replication.ChangeTypeProof, replication.ChangeTypeAddr,
+		replication.ChangeTypeTransfer,
+	} {
+		offset := time.Duration(idx) * time.Minute
+		change := replication.Change{
+			Type:      changeType,
+			Payload:   []byte{byte(idx), 0x01, 0x02},
+			Timestamp: baseTime.Add(offset),
+		}
+
+		change.Seq, err = store.AppendChange(
+			ctx, change.Type, change.Payload, change.Timestamp,
+		)
+		require.NoError(t, err)
+
+		// Sequence numbers are strictly increasing.
+		if len(expected) > 0 {
+			require.Greater(t, change.Seq, expected[idx-1].Seq)
+		}
+
+		expected = append(expected, change)
+	}
+
+	// All changes are returned in order.
+	changes, err = store.FetchChanges(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, expected, changes)
+
+	// The changes can be fetched after a sequence number and with a
+	// limit.
+	changes, err = store.FetchChanges(ctx, expected[0].Seq, 1)
+	require.NoError(t, err)
+	require.Equal(t, expected[1:2], changes)
+
+	changes, err = store.FetchChanges(ctx, expected[2].Seq, 10)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// A cursor that was never set is zero.
+	const leaderID = "leader.example.com:10029"
+	cursor, err := store.FetchCursor(ctx, leaderID)
+	require.NoError(t, err)
+	require.Zero(t, cursor)
+
+	// The cursor can be set and updated.
+	require.NoError(t, store.UpdateCursor(ctx, leaderID, 5))
+	require.NoError(t, store.UpdateCursor(ctx, leaderID, 7))
+	require.NoError(t, store.UpdateCursor(ctx, "other", 3))
+
+	cursor, err = store.FetchCursor(ctx, leaderID)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, cursor)
+}
@@ -0,0 +1,190 @@
+package tapdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/retention"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+)
+
+type (
+	// ProofStats is the number and total size of a set of proofs.
+	ProofStats = sqlc.QuerySpentAssetProofStatsRow
+
+	// DeliveredProofStats is the number and total size of a set of
+	// delivered proof suffixes.
+	DeliveredProofStats = sqlc.QueryDeliveredProofSuffixStatsRow
+)
+
+// RetentionStore is the storage interface for pruning old data.
+type RetentionStore interface {
+	// QuerySpentAssetProofStats returns the number and total size of the
+	// proofs of assets that were spent in a transfer before the given
+	// time.
+	QuerySpentAssetProofStats(ctx context.Context,
+		spentBefore time.Time) (ProofStats, error)
+
+	// DeleteSpentAssetProofs deletes the proofs of assets that were spent
+	// in a transfer before the given time.
+	DeleteSpentAssetProofs(ctx context.Context,
+		spentBefore time.Time) (int64, error)
+
+	// QueryDeliveredProofSuffixStats returns the number and total size of
+	// the delivered proof suffixes of confirmed transfers created before
+	// the given time.
+	QueryDeliveredProofSuffixStats(ctx context.Context,
+		deliveredBefore time.Time) (DeliveredProofStats, error)
+
+	// ClearDeliveredProofSuffixes clears the delivered proof suffixes of
+	// confirmed transfers created before the given time.
+	ClearDeliveredProofSuffixes(ctx context.Context,
+		deliveredBefore time.Time) (int64, error)
+
+	// DeleteProofTransferAttemptsBefore deletes all proof courier
+	// delivery log entries created before the given time.
+	DeleteProofTransferAttemptsBefore(ctx context.Context,
+		attemptedBefore time.Time) (int64, error)
+
+	// DeleteUniverseEventsBefore deletes all universe events with a
+	// timestamp before the given unix timestamp.
+	DeleteUniverseEventsBefore(ctx context.Context,
+		eventTimestampBefore int64) (int64, error)
+}
+
+// BatchedRetentionStore allows for batched DB transactions for the retention
+// store.
+type BatchedRetentionStore interface {
+	RetentionStore
+
+	BatchedTx[RetentionStore]
+}
+
+// RetentionDB prunes old data from the database according to a retention
+// policy.
+type RetentionDB struct {
+	db BatchedRetentionStore
+}
+
+// NewRetentionDB creates a new retention store.
+func NewRetentionDB(db BatchedRetentionStore) *RetentionDB {
+	return &RetentionDB{
+		db: db,
+	}
+}
+
+// Prune prunes all data selected by the given policy in a single database
+// transaction and returns the amount of pruned data.
+//
+// NOTE: This is part of the retention.Store interface.
+func (r *RetentionDB) Prune(ctx context.Context,
+	policy retention.Policy) (*retention.Result, error) {
+
+	var (
+		result  retention.Result
+		writeTx AssetStoreTxOptions
+	)
+	dbErr := r.db.ExecTx(ctx, &writeTx, func(q RetentionStore) error {
+		result = retention.Result{}
+
+		err := fn.MapOptionZ(
+			policy.SpentProofsBefore, func(before time.Time) error {
+				return pruneSpentProofs(
+					ctx, q, before.UTC(),
+					&result.SpentProofs,
+				)
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn.MapOptionZ(
+			policy.DeliveredProofsBefore,
+			func(before time.Time) error {
+				return pruneDeliveredProofs(
+					ctx, q, before.UTC(), &result,
+				)
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return fn.MapOptionZ(
+			policy.UniverseEventsBefore,
+			func(before time.Time) error {
+				numEvents, err := q.DeleteUniverseEventsBefore(
+					ctx, before.Unix(),
+				)
+				if err != nil {
+					return fmt.Errorf("unable to delete "+
+						"universe events: %w", err)
+				}
+
+				result.UniverseEvents.Rows = numEvents
+
+				return nil
+			},
+		)
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return &result, nil
+}
+
+// pruneSpentProofs deletes the proofs of assets spent before the given time.
+func pruneSpentProofs(ctx context.Context, q RetentionStore,
+	before time.Time, pruned *retention.Pruned) error {
+
+	stats, err := q.QuerySpentAssetProofStats(ctx, before)
+	if err != nil {
+		return fmt.Errorf("unable to query spent proofs: %w", err)
+	}
+
+	numProofs, err := q.DeleteSpentAssetProofs(ctx, before)
+	if err != nil {
+		return fmt.Errorf("unable to delete spent proofs: %w", err)
+	}
+
+	pruned.Rows = numProofs
+	pruned.Bytes = stats.NumBytes
+
+	return nil
+}
+
+// pruneDeliveredProofs clears the proofs of outputs of confirmed transfers
+// that were delivered before the given time, and deletes the proof courier
+// delivery log entries older than that.
+func pruneDeliveredProofs(ctx context.Context, q RetentionStore,
+	before time.Time, result *retention.Result) error {
+
+	stats, err := q.QueryDeliveredProofSuffixStats(ctx, before)
+	if err != nil {
+		return fmt.Errorf("unable to query delivered proofs: %w", err)
+	}
+
+	numProofs, err := q.ClearDeliveredProofSuffixes(ctx, before)
+	if err != nil {
+		return fmt.Errorf("unable to clear delivered proofs: %w", err)
+	}
+
+	numEntries, err := q.DeleteProofTransferAttemptsBefore(ctx, before)
+	if err != nil {
+		return fmt.Errorf("unable to delete proof courier log: %w", err)
+	}
+
+	result.DeliveredProofs.Rows = numProofs
+	result.DeliveredProofs.Bytes = stats.NumBytes
+	result.TransferLogEntries.Rows = numEntries
+
+	return nil
+}
+
+// A compile-time assertion to ensure RetentionDB meets the retention.Store
+// interface.
+var _ retention.Store = (*RetentionDB)(nil)
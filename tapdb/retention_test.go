@@ -0,0 +1,200 @@
+package tapdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/retention"
+	"github.com/lightninglabs/taproot-assets/tapfreighter"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetentionDBFromDB makes a new retention store backed by the passed
+// database.
+func newRetentionDBFromDB(db *BaseDB) *RetentionDB {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) RetentionStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewRetentionDB(dbTxer)
+}
+
+// TestRetentionPrune tests that the proofs of spent assets, the proofs of
+// delivered transfer outputs and the proof courier log are pruned once they
+// are older than the cutoff of the retention policy.
+func TestRetentionPrune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	_, assetsStore := newAssetStoreFromDB(db.BaseDB)
+	store := newRetentionDBFromDB(db.BaseDB)
+
+	// We create two assets, only the first of which is spent in a
+	// transfer. Each of them has a 100 byte proof.
+	assetGen := newAssetGenerator(t, 2, 0)
+	assetGen.genAssets(t, assetsStore, []assetDesc{{
+		assetGen:    assetGen.assetGens[0],
+		anchorPoint: assetGen.anchorPoints[0],
+		amt:         10,
+		noGroupKey:  true,
+	}, {
+		assetGen:    assetGen.assetGens[1],
+		anchorPoint: assetGen.anchorPoints[1],
+		amt:         20,
+		noGroupKey:  true,
+	}})
+
+	allAssets, err := assetsStore.FetchAllAssets(ctx, false, false, nil)
+	require.NoError(t, err)
+	require.Len(t, allAssets, 2)
+
+	inputAsset := allAssets[0]
+	if inputAsset.Amount != 10 {
+		inputAsset = allAssets[1]
+	}
+
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{})
+	anchorTx.AddTxOut(&wire.TxOut{
+		PkScript: bytes.Repeat([]byte{0x01}, 34),
+		Value:    1000,
+	})
+
+	transferTime := time.Unix(1700000000, 0)
+	inputScriptKey := asset.ToSerialized(inputAsset.ScriptKey.PubKey)
+	parcel := &tapfreighter.OutboundParcel{
+		AnchorTx:           anchorTx,
+		AnchorTxHeightHint: 1450,
+		TransferTime:       transferTime,
+		Inputs: []tapfreighter.TransferInput{{
+			PrevID: asset.PrevID{
+				OutPoint:  assetGen.anchorPoints[0],
+				ID:        inputAsset.ID(),
+				ScriptKey: inputScriptKey,
+			},
+			Amount: inputAsset.Amount,
+		}},
+		Outputs: []tapfreighter.TransferOutput{{
+			Anchor: tapfreighter.Anchor{
+				Value: 1000,
+				OutPoint: wire.OutPoint{
+					Hash:  anchorTx.TxHash(),
+					Index: 0,
+				},
+				InternalKey: keychain.KeyDescriptor{
+					PubKey: test.RandPubKey(t),
+				},
+				TaprootAssetRoot: bytes.Repeat([]byte{0x1}, 32),
+				MerkleRoot:       bytes.Repeat([]byte{0x1}, 32),
+			},
+			ScriptKey: asset.NewScriptKeyBip86(
+				keychain.KeyDescriptor{
+					PubKey: test.RandPubKey(t),
+				},
+			),
+			Amount:                inputAsset.Amount,
+			WitnessData:           []asset.Witness{{}},
+			AssetVersion:          asset.V0,
+			ProofSuffix:           bytes.Repeat([]byte{0x02}, 50),
+			ProofCourierAddr:      []byte("universerpc://a"),
+			ProofDeliveryComplete: fn.Some(true),
+		}},
+	}
+	require.NoError(t, assetsStore.LogPendingParcel(
+		ctx, parcel, [32]byte{1}, time.Now().Add(time.Hour),
+	))
+
+	// The input asset is spent and the transfer confirmed.
+	anchorPoint, err := encodeOutpoint(assetGen.anchorPoints[0])
+	require.NoError(t, err)
+	_, err = db.SetAssetSpent(ctx, SetAssetSpentParams{
+		ScriptKey:   inputScriptKey[:],
+		GenAssetID:  fn.ByteSlice(inputAsset.ID()),
+		AnchorPoint: anchorPoint,
+	})
+	require.NoError(t, err)
+
+	anchorTxHash := anchorTx.TxHash()
+	blockHash := test.RandHash()
+	require.NoError(t, db.ConfirmChainAnchorTx(ctx, AnchorTxConf{
+		Txid:        anchorTxHash[:],
+		BlockHash:   blockHash[:],
+		BlockHeight: sqlInt32(441),
+		TxIndex:     sqlInt32(1),
+	}))
+
+	require.NoError(t, assetsStore.LogProofTransferAttempt(
+		ctx, proof.Locator{
+			ScriptKey: *inputAsset.ScriptKey.PubKey,
+		}, proof.SendTransferType,
+	))
+
+	// An empty policy doesn't prune anything, and neither does a policy
+	// with cutoffs before the transfer.
+	result, err := store.Prune(ctx, retention.Policy{})
+	require.NoError(t, err)
+	require.Equal(t, &retention.Result{}, result)
+
+	before := fn.Some(transferTime.Add(-time.Hour))
+	result, err = store.Prune(ctx, retention.Policy{
+		SpentProofsBefore:     before,
+		DeliveredProofsBefore: before,
+		UniverseEventsBefore:  before,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &retention.Result{}, result)
+
+	// Once the cutoffs are past the transfer and the delivery attempt,
+	// only the proof of the spent asset, the delivered proof and the log
+	// entry are pruned.
+	after := fn.Some(time.Now().Add(time.Hour))
+	policy := retention.Policy{
+		SpentProofsBefore:     after,
+		DeliveredProofsBefore: after,
+		UniverseEventsBefore:  after,
+	}
+	result, err = store.Prune(ctx, policy)
+	require.NoError(t, err)
+	require.Equal(t, &retention.Result{
+		SpentProofs: retention.Pruned{
+			Rows:  1,
+			Bytes: 100,
+		},
+		DeliveredProofs: retention.Pruned{
+			Rows:  1,
+			Bytes: 50,
+		},
+		TransferLogEntries: retention.Pruned{
+			Rows: 1,
+		},
+	}, result)
+	require.EqualValues(t, 150, result.ReclaimedBytes())
+
+	// Pruning is idempotent.
+	result, err = store.Prune(ctx, policy)
+	require.NoError(t, err)
+	require.Equal(t, &retention.Result{}, result)
+
+	// The proof of the unspent asset is still available.
+	unspentAsset := allAssets[1]
+	if unspentAsset.Amount != 20 {
+		unspentAsset = allAssets[0]
+	}
+	_, err = assetsStore.FetchProof(ctx, proof.Locator{
+		AssetID:   fn.Ptr(unspentAsset.ID()),
+		ScriptKey: *unspentAsset.ScriptKey.PubKey,
+	})
+	require.NoError(t, err)
+}
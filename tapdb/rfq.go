@@ -0,0 +1,650 @@
+package tapdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+type (
+	// PeerStatsDelta is used to add to the stats of an RFQ peer.
+	PeerStatsDelta = sqlc.UpsertPeerStatsParams
+
+	// PeerStatsRow is the stats of a single RFQ peer stored in the DB.
+	PeerStatsRow = sqlc.RfqPeerStat
+
+	// SettlementStatsDelta is used to add to the settlement stats of an
+	// asset, peer and day.
+	SettlementStatsDelta = sqlc.UpsertSettlementStatsParams
+
+	// SettlementStatsQuery is used to query settlement stats.
+	SettlementStatsQuery = sqlc.QuerySettlementStatsParams
+
+	// SettlementStatsRow is the settlement stats of a single asset, peer
+	// and day stored in the DB.
+	SettlementStatsRow = sqlc.RfqSettlementStat
+
+	// NewRfqQuote is used to insert an accepted RFQ quote.
+	NewRfqQuote = sqlc.InsertRfqQuoteParams
+
+	// RfqQuoteVolumeDelta is used to add to the realized volume of an RFQ
+	// quote.
+	RfqQuoteVolumeDelta = sqlc.AddRfqQuoteVolumeParams
+
+	// RfqQuoteQuery is used to query RFQ quotes.
+	RfqQuoteQuery = sqlc.QueryRfqQuotesParams
+
+	// RfqQuoteRow is a single RFQ quote stored in the DB.
+	RfqQuoteRow = sqlc.RfqQuote
+
+	// RfqQuoteVolumeQuery is used to query the realized volume of RFQ
+	// quotes.
+	RfqQuoteVolumeQuery = sqlc.QueryRfqQuoteVolumesParams
+
+	// RfqQuoteVolumeRow is the realized volume of the RFQ quotes of a
+	// single asset.
+	RfqQuoteVolumeRow = sqlc.QueryRfqQuoteVolumesRow
+)
+
+// RfqStore is the main storage interface for the RFQ subsystem.
+type RfqStore interface {
+	// UpsertPeerStats adds the given delta to the stats of a peer,
+	// creating them if they don't exist yet.
+	UpsertPeerStats(ctx context.Context, arg PeerStatsDelta) error
+
+	// FetchPeerStats returns the stats of the given peer.
+	FetchPeerStats(ctx context.Context, peer []byte) (PeerStatsRow, error)
+
+	// QueryPeerStats returns the stats of all peers.
+	QueryPeerStats(ctx context.Context) ([]PeerStatsRow, error)
+
+	// UpsertSettlementStats adds the given delta to the settlement stats
+	// of an asset, peer and day, creating them if they don't exist yet.
+	UpsertSettlementStats(ctx context.Context,
+		arg SettlementStatsDelta) error
+
+	// QuerySettlementStats returns the settlement stats matching the
+	// given query.
+	QuerySettlementStats(ctx context.Context,
+		arg SettlementStatsQuery) ([]SettlementStatsRow, error)
+
+	// InsertRfqQuote inserts an accepted quote, unless a quote with the
+	// same ID exists already.
+	InsertRfqQuote(ctx context.Context, arg NewRfqQuote) error
+
+	// AddRfqQuoteVolume adds the given delta to the realized volume of a
+	// quote.
+	AddRfqQuoteVolume(ctx context.Context, arg RfqQuoteVolumeDelta) error
+
+	// QueryRfqQuotes returns the quotes matching the given query.
+	QueryRfqQuotes(ctx context.Context,
+		arg RfqQuoteQuery) ([]RfqQuoteRow, error)
+
+	// QueryRfqQuoteVolumes returns the realized volume of the quotes
+	// matching the given query, aggregated per asset.
+	QueryRfqQuoteVolumes(ctx context.Context,
+		arg RfqQuoteVolumeQuery) ([]RfqQuoteVolumeRow, error)
+}
+
+// BatchedRfqStore allows for batched DB transactions for the RFQ store.
+type BatchedRfqStore interface {
+	RfqStore
+
+	BatchedTx[RfqStore]
+}
+
+// RfqPeerStats is a persistent store for the stats of the peers the RFQ
+// subsystem requests quotes from.
+type RfqPeerStats struct {
+	db BatchedRfqStore
+}
+
+// NewRfqPeerStats creates a new RFQ peer stats store.
+func NewRfqPeerStats(db BatchedRfqStore) *RfqPeerStats {
+	return &RfqPeerStats{
+		db: db,
+	}
+}
+
+// AddPeerStats adds the counters of the given delta to the stored stats of
+// the peer, creating them if they don't exist yet.
+//
+// NOTE: This is part of the rfq.PeerStatsStore interface.
+func (r *RfqPeerStats) AddPeerStats(ctx context.Context,
+	delta rfq.PeerStats) error {
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q RfqStore) error {
+		return q.UpsertPeerStats(ctx, PeerStatsDelta{
+			Peer:                delta.Peer[:],
+			QuotesAccepted:      int64(delta.QuotesAccepted),
+			QuotesRejected:      int64(delta.QuotesRejected),
+			HtlcsSettled:        int64(delta.HtlcsSettled),
+			HtlcsFailed:         int64(delta.HtlcsFailed),
+			RateDeviationPpmSum: delta.RateDeviationPpmSum,
+			RateSamples:         int64(delta.RateSamples),
+			UpdatedAt:           delta.UpdatedAt.UTC(),
+		})
+	})
+}
+
+// FetchPeerStats returns the stats of the given peer. Empty stats are returned
+// if there are none stored for the peer.
+//
+// NOTE: This is part of the rfq.PeerStatsStore interface.
+func (r *RfqPeerStats) FetchPeerStats(ctx context.Context,
+	peer route.Vertex) (*rfq.PeerStats, error) {
+
+	stats := &rfq.PeerStats{
+		Peer: peer,
+	}
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RfqStore) error {
+		row, err := q.FetchPeerStats(ctx, peer[:])
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		stats, err = parsePeerStats(row)
+
+		return err
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to fetch peer stats: %w", dbErr)
+	}
+
+	return stats, nil
+}
+
+// QueryPeerStats returns the stats of all peers.
+//
+// NOTE: This is part of the rfq.PeerStatsStore interface.
+func (r *RfqPeerStats) QueryPeerStats(ctx context.Context) ([]rfq.PeerStats,
+	error) {
+
+	var result []rfq.PeerStats
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RfqStore) error {
+		result = nil
+
+		rows, err := q.QueryPeerStats(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			stats, err := parsePeerStats(row)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, *stats)
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query peer stats: %w", dbErr)
+	}
+
+	return result, nil
+}
+
+// parsePeerStats parses the stats of a peer from their database
+// representation.
+func parsePeerStats(row PeerStatsRow) (*rfq.PeerStats, error) {
+	peer, err := route.NewVertexFromBytes(row.Peer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer key: %w", err)
+	}
+
+	return &rfq.PeerStats{
+		Peer:                peer,
+		QuotesAccepted:      uint64(row.QuotesAccepted),
+		QuotesRejected:      uint64(row.QuotesRejected),
+		HtlcsSettled:        uint64(row.HtlcsSettled),
+		HtlcsFailed:         uint64(row.HtlcsFailed),
+		RateDeviationPpmSum: row.RateDeviationPpmSum,
+		RateSamples:         uint64(row.RateSamples),
+		UpdatedAt:           row.UpdatedAt.UTC(),
+	}, nil
+}
+
+// A compile-time assertion to ensure RfqPeerStats meets the
+// rfq.PeerStatsStore interface.
+var _ rfq.PeerStatsStore = (*RfqPeerStats)(nil)
+
+// RfqSettlementStats is a persistent store for the settled asset HTLC volume
+// of the RFQ order handler.
+type RfqSettlementStats struct {
+	db BatchedRfqStore
+}
+
+// NewRfqSettlementStats creates a new RFQ settlement stats store.
+func NewRfqSettlementStats(db BatchedRfqStore) *RfqSettlementStats {
+	return &RfqSettlementStats{
+		db: db,
+	}
+}
+
+// AddSettlementStats adds the counters of the given delta to the stored stats
+// of the delta's asset, peer and day, creating them if they don't exist yet.
+//
+// NOTE: This is part of the rfq.SettlementStatsStore interface.
+func (r *RfqSettlementStats) AddSettlementStats(ctx context.Context,
+	delta rfq.SettlementStats) error {
+
+	assetKey, err := settlementAssetKey(delta.AssetSpecifier)
+	if err != nil {
+		return err
+	}
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q RfqStore) error {
+		return q.UpsertSettlementStats(ctx, SettlementStatsDelta{
+			AssetKey:    assetKey,
+			Peer:        delta.Peer[:],
+			Day:         delta.Day.Unix(),
+			NumHtlcs:    int64(delta.NumHtlcs),
+			AssetAmount: int64(delta.AssetAmount),
+			AmountMsat:  int64(delta.AmountMsat),
+		})
+	})
+}
+
+// QuerySettlementStats returns the stats matching the given query, ordered by
+// day.
+//
+// NOTE: This is part of the rfq.SettlementStatsStore interface.
+func (r *RfqSettlementStats) QuerySettlementStats(ctx context.Context,
+	query rfq.SettlementStatsQuery) ([]rfq.SettlementStats, error) {
+
+	dbQuery := SettlementStatsQuery{
+		StartDay: query.StartDay.Unix(),
+		EndDay:   query.EndDay.Unix(),
+	}
+	err := fn.MapOptionZ(
+		query.AssetSpecifier, func(specifier asset.Specifier) error {
+			var err error
+			dbQuery.AssetKey, err = settlementAssetKey(specifier)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	query.Peer.WhenSome(func(peer route.Vertex) {
+		dbQuery.Peer = peer[:]
+	})
+
+	var result []rfq.SettlementStats
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RfqStore) error {
+		result = nil
+
+		rows, err := q.QuerySettlementStats(ctx, dbQuery)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			stats, err := parseSettlementStats(row)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, *stats)
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query settlement stats: %w",
+			dbErr)
+	}
+
+	return result, nil
+}
+
+// settlementAssetKey returns the key the settlement stats of the given asset
+// are stored under. This is the compressed group key for grouped assets and
+// the asset ID otherwise.
+func settlementAssetKey(specifier asset.Specifier) ([]byte, error) {
+	switch {
+	case specifier.HasGroupPubKey():
+		groupKey := specifier.UnwrapGroupKeyToPtr()
+		return groupKey.SerializeCompressed(), nil
+
+	case specifier.HasId():
+		assetID := specifier.UnwrapIdToPtr()
+		return assetID[:], nil
+
+	default:
+		return nil, fmt.Errorf("asset specifier is empty")
+	}
+}
+
+// parseSettlementAssetKey parses the asset specifier from the key settlement
+// stats and quotes are stored under.
+func parseSettlementAssetKey(assetKey []byte) (asset.Specifier, error) {
+	switch len(assetKey) {
+	case sha256.Size:
+		var assetID asset.ID
+		copy(assetID[:], assetKey)
+		return asset.NewSpecifierFromId(assetID), nil
+
+	case btcec.PubKeyBytesLenCompressed:
+		groupKey, err := btcec.ParsePubKey(assetKey)
+		if err != nil {
+			return asset.Specifier{}, fmt.Errorf("invalid group "+
+				"key: %w", err)
+		}
+
+		return asset.NewSpecifierFromGroupKey(*groupKey), nil
+
+	default:
+		return asset.Specifier{}, fmt.Errorf("invalid asset key "+
+			"length: %d", len(assetKey))
+	}
+}
+
+// parseSettlementStats parses the settlement stats of an asset, peer and day
+// from their database representation.
+func parseSettlementStats(row SettlementStatsRow) (*rfq.SettlementStats,
+	error) {
+
+	specifier, err := parseSettlementAssetKey(row.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := route.NewVertexFromBytes(row.Peer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer key: %w", err)
+	}
+
+	return &rfq.SettlementStats{
+		AssetSpecifier: specifier,
+		Peer:           peer,
+		Day:            time.Unix(row.Day, 0).UTC(),
+		NumHtlcs:       uint64(row.NumHtlcs),
+		AssetAmount:    uint64(row.AssetAmount),
+		AmountMsat:     lnwire.MilliSatoshi(row.AmountMsat),
+	}, nil
+}
+
+// A compile-time assertion to ensure RfqSettlementStats meets the
+// rfq.SettlementStatsStore interface.
+var _ rfq.SettlementStatsStore = (*RfqSettlementStats)(nil)
+
+// RfqQuotes is a persistent store for the quotes accepted by our node and our
+// peers, and the volume realized under them.
+type RfqQuotes struct {
+	db BatchedRfqStore
+}
+
+// NewRfqQuotes creates a new RFQ quote store.
+func NewRfqQuotes(db BatchedRfqStore) *RfqQuotes {
+	return &RfqQuotes{
+		db: db,
+	}
+}
+
+// AddQuote records an accepted quote. Recording the same quote twice has no
+// effect.
+//
+// NOTE: This is part of the rfq.QuoteStore interface.
+func (r *RfqQuotes) AddQuote(ctx context.Context,
+	quote rfq.QuoteRecord) error {
+
+	assetKey, err := settlementAssetKey(quote.AssetSpecifier)
+	if err != nil {
+		return err
+	}
+
+	rate := quote.AssetRate.Rate
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q RfqStore) error {
+		return q.InsertRfqQuote(ctx, NewRfqQuote{
+			QuoteID:         quote.ID[:],
+			Peer:            quote.Peer[:],
+			IsBuy:           quote.IsBuy,
+			AcceptedByPeer:  quote.AcceptedByPeer,
+			AssetKey:        assetKey,
+			AssetMaxAmount:  int64(quote.AssetMaxAmt),
+			PaymentMaxMsat:  int64(quote.PaymentMaxAmt),
+			RateCoefficient: rate.Coefficient.Bytes(),
+			RateScale:       int32(rate.Scale),
+			Expiry:          quote.AssetRate.Expiry.Unix(),
+			AcceptedAt:      quote.AcceptedAt.Unix(),
+		})
+	})
+}
+
+// AddQuoteVolume adds the volume of the given delta to the realized volume of
+// the quote with the given ID.
+//
+// NOTE: This is part of the rfq.QuoteStore interface.
+func (r *RfqQuotes) AddQuoteVolume(ctx context.Context, id rfqmsg.ID,
+	delta rfq.SettlementStats) error {
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q RfqStore) error {
+		return q.AddRfqQuoteVolume(ctx, RfqQuoteVolumeDelta{
+			QuoteID:     id[:],
+			NumHtlcs:    int64(delta.NumHtlcs),
+			AssetAmount: int64(delta.AssetAmount),
+			AmountMsat:  int64(delta.AmountMsat),
+		})
+	})
+}
+
+// QueryQuotes returns the quotes matching the given query, ordered by the time
+// they were accepted. The state of the quotes is evaluated at the given time.
+//
+// NOTE: This is part of the rfq.QuoteStore interface.
+func (r *RfqQuotes) QueryQuotes(ctx context.Context, query rfq.QuoteQuery,
+	now time.Time) ([]rfq.QuoteRecord, error) {
+
+	filter, err := newRfqQuoteFilter(query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dbQuery := RfqQuoteQuery{
+		StartTime:      filter.StartTime,
+		EndTime:        filter.EndTime,
+		Peer:           filter.Peer,
+		AssetKey:       filter.AssetKey,
+		IsBuy:          filter.IsBuy,
+		AcceptedByPeer: filter.AcceptedByPeer,
+		ActiveAt:       filter.ActiveAt,
+		ExpiredAt:      filter.ExpiredAt,
+		NumLimit:       int32(query.Limit),
+		NumOffset:      int32(query.Offset),
+	}
+
+	var result []rfq.QuoteRecord
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RfqStore) error {
+		result = nil
+
+		rows, err := q.QueryRfqQuotes(ctx, dbQuery)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			quote, err := parseRfqQuote(row)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, *quote)
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query quotes: %w", dbErr)
+	}
+
+	return result, nil
+}
+
+// QueryQuoteVolume returns the realized volume of the quotes matching the
+// given query, aggregated per asset. The state of the quotes is evaluated at
+// the given time.
+//
+// NOTE: This is part of the rfq.QuoteStore interface.
+func (r *RfqQuotes) QueryQuoteVolume(ctx context.Context,
+	query rfq.QuoteQuery, now time.Time) ([]rfq.QuoteVolume, error) {
+
+	filter, err := newRfqQuoteFilter(query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []rfq.QuoteVolume
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RfqStore) error {
+		result = nil
+
+		rows, err := q.QueryRfqQuoteVolumes(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			specifier, err := parseSettlementAssetKey(row.AssetKey)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, rfq.QuoteVolume{
+				AssetSpecifier: specifier,
+				NumQuotes:      uint64(row.NumQuotes),
+				NumHtlcs:       uint64(row.NumHtlcs),
+				AssetAmount:    uint64(row.AssetAmount),
+				AmountMsat: lnwire.MilliSatoshi(
+					row.AmountMsat,
+				),
+			})
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query quote volume: %w",
+			dbErr)
+	}
+
+	return result, nil
+}
+
+// newRfqQuoteFilter converts the filters of a quote query into their database
+// representation. The state of the quotes is evaluated at the given time.
+func newRfqQuoteFilter(query rfq.QuoteQuery,
+	now time.Time) (RfqQuoteVolumeQuery, error) {
+
+	filter := RfqQuoteVolumeQuery{
+		StartTime: query.StartTime.Unix(),
+		EndTime:   query.EndTime.Unix(),
+	}
+	err := fn.MapOptionZ(
+		query.AssetSpecifier, func(specifier asset.Specifier) error {
+			var err error
+			filter.AssetKey, err = settlementAssetKey(specifier)
+			return err
+		},
+	)
+	if err != nil {
+		return filter, err
+	}
+	query.Peer.WhenSome(func(peer route.Vertex) {
+		filter.Peer = peer[:]
+	})
+	query.IsBuy.WhenSome(func(isBuy bool) {
+		filter.IsBuy = sqlBool(isBuy)
+	})
+	query.AcceptedByPeer.WhenSome(func(acceptedByPeer bool) {
+		filter.AcceptedByPeer = sqlBool(acceptedByPeer)
+	})
+
+	// A quote is active as long as its expiry is in the future.
+	query.State.WhenSome(func(state rfq.QuoteState) {
+		switch state {
+		case rfq.QuoteStateActive:
+			filter.ActiveAt = sqlInt64(now.Unix())
+
+		case rfq.QuoteStateExpired:
+			filter.ExpiredAt = sqlInt64(now.Unix())
+		}
+	})
+
+	return filter, nil
+}
+
+// parseRfqQuote parses a quote from its database representation.
+func parseRfqQuote(row RfqQuoteRow) (*rfq.QuoteRecord, error) {
+	var id rfqmsg.ID
+	if len(row.QuoteID) != len(id) {
+		return nil, fmt.Errorf("invalid quote ID length: %d",
+			len(row.QuoteID))
+	}
+	copy(id[:], row.QuoteID)
+
+	peer, err := route.NewVertexFromBytes(row.Peer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer key: %w", err)
+	}
+
+	specifier, err := parseSettlementAssetKey(row.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := rfqmath.BigIntFixedPoint{
+		Coefficient: rfqmath.BigInt{}.FromBytes(row.RateCoefficient),
+		Scale:       uint8(row.RateScale),
+	}
+
+	return &rfq.QuoteRecord{
+		ID:             id,
+		Peer:           peer,
+		IsBuy:          row.IsBuy,
+		AcceptedByPeer: row.AcceptedByPeer,
+		AssetSpecifier: specifier,
+		AssetMaxAmt:    uint64(row.AssetMaxAmount),
+		PaymentMaxAmt:  lnwire.MilliSatoshi(row.PaymentMaxMsat),
+		AssetRate: rfqmsg.NewAssetRate(
+			rate, time.Unix(row.Expiry, 0).UTC(),
+		),
+		AcceptedAt:  time.Unix(row.AcceptedAt, 0).UTC(),
+		NumHtlcs:    uint64(row.NumHtlcs),
+		AssetAmount: uint64(row.AssetAmount),
+		AmountMsat:  lnwire.MilliSatoshi(row.AmountMsat),
+	}, nil
+}
+
+// A compile-time assertion to ensure RfqQuotes meets the rfq.QuoteStore
+// interface.
+var _ rfq.QuoteStore = (*RfqQuotes)(nil)
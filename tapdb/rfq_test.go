@@ -0,0 +1,389 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/rfq"
+	"github.com/lightninglabs/taproot-assets/rfqmath"
+	"github.com/lightninglabs/taproot-assets/rfqmsg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRfqPeerStats tests that the stats of RFQ peers are accumulated and can
+// be queried.
+func TestRfqPeerStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) RfqStore {
+			return db.WithTx(tx)
+		},
+	)
+	store := NewRfqPeerStats(dbTxer)
+
+	peer1 := route.Vertex{2, 1}
+	peer2 := route.Vertex{3, 2}
+
+	// A peer without any stats has empty stats.
+	stats, err := store.FetchPeerStats(ctx, peer1)
+	require.NoError(t, err)
+	require.Equal(t, &rfq.PeerStats{Peer: peer1}, stats)
+
+	now := time.Unix(1_000_000, 0).UTC()
+	require.NoError(t, store.AddPeerStats(ctx, rfq.PeerStats{
+		Peer:           peer1,
+		QuotesAccepted: 2,
+		HtlcsFailed:    1,
+		UpdatedAt:      now,
+	}))
+	require.NoError(t, store.AddPeerStats(ctx, rfq.PeerStats{
+		Peer:                peer1,
+		QuotesRejected:      1,
+		HtlcsSettled:        3,
+		RateDeviationPpmSum: -500,
+		RateSamples:         1,
+		UpdatedAt:           now.Add(time.Minute),
+	}))
+	require.NoError(t, store.AddPeerStats(ctx, rfq.PeerStats{
+		Peer:           peer2,
+		QuotesAccepted: 1,
+		UpdatedAt:      now,
+	}))
+
+	stats, err = store.FetchPeerStats(ctx, peer1)
+	require.NoError(t, err)
+	require.Equal(t, &rfq.PeerStats{
+		Peer:                peer1,
+		QuotesAccepted:      2,
+		QuotesRejected:      1,
+		HtlcsSettled:        3,
+		HtlcsFailed:         1,
+		RateDeviationPpmSum: -500,
+		RateSamples:         1,
+		UpdatedAt:           now.Add(time.Minute),
+	}, stats)
+
+	allStats, err := store.QueryPeerStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, allStats, 2)
+	require.Equal(t, *stats, allStats[0])
+	require.Equal(t, peer2, allStats[1].Peer)
+}
+
+// TestRfqSettlementStats tests that the settlement stats are accumulated per
+// asset, peer and day and can be queried.
+func TestRfqSettlementStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) RfqStore {
+			return db.WithTx(tx)
+		},
+	)
+	store := NewRfqSettlementStats(dbTxer)
+
+	peer1 := route.Vertex{2, 1}
+	peer2 := route.Vertex{3, 2}
+	assetSpecifier := asset.NewSpecifierFromId(asset.ID{1, 2, 3})
+	groupSpecifier := asset.NewSpecifierFromGroupKey(
+		*test.RandPubKey(t),
+	)
+
+	day1 := time.Unix(1_700_006_400, 0).UTC()
+	day2 := day1.Add(24 * time.Hour)
+
+	// An empty asset specifier can't be stored.
+	require.Error(t, store.AddSettlementStats(ctx, rfq.SettlementStats{
+		Peer: peer1,
+		Day:  day1,
+	}))
+
+	deltas := []rfq.SettlementStats{{
+		AssetSpecifier: assetSpecifier,
+		Peer:           peer1,
+		Day:            day1,
+		NumHtlcs:       1,
+		AssetAmount:    100,
+		AmountMsat:     10_000,
+	}, {
+		AssetSpecifier: assetSpecifier,
+		Peer:           peer1,
+		Day:            day1,
+		NumHtlcs:       2,
+		AssetAmount:    300,
+		AmountMsat:     20_000,
+	}, {
+		AssetSpecifier: assetSpecifier,
+		Peer:           peer2,
+		Day:            day2,
+		NumHtlcs:       1,
+		AssetAmount:    50,
+		AmountMsat:     5_000,
+	}, {
+		AssetSpecifier: groupSpecifier,
+		Peer:           peer1,
+		Day:            day2,
+		NumHtlcs:       1,
+		AssetAmount:    10,
+		AmountMsat:     1_000,
+	}}
+	for _, delta := range deltas {
+		require.NoError(t, store.AddSettlementStats(ctx, delta))
+	}
+
+	// Without any filters, all stats in the range are returned ordered by
+	// day.
+	query := rfq.SettlementStatsQuery{
+		StartDay: day1,
+		EndDay:   day2,
+	}
+	allStats, err := store.QuerySettlementStats(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, allStats, 3)
+	require.Equal(t, rfq.SettlementStats{
+		AssetSpecifier: assetSpecifier,
+		Peer:           peer1,
+		Day:            day1,
+		NumHtlcs:       3,
+		AssetAmount:    400,
+		AmountMsat:     30_000,
+	}, allStats[0])
+	require.Equal(t, day2, allStats[1].Day)
+	require.Equal(t, day2, allStats[2].Day)
+
+	// The group key specifier is restored from the DB.
+	stats, err := store.QuerySettlementStats(ctx, rfq.SettlementStatsQuery{
+		AssetSpecifier: fn.Some(groupSpecifier),
+		StartDay:       day1,
+		EndDay:         day2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []rfq.SettlementStats{deltas[3]}, stats)
+
+	// The results can be filtered by peer and day.
+	stats, err = store.QuerySettlementStats(ctx, rfq.SettlementStatsQuery{
+		Peer:     fn.Some(peer1),
+		StartDay: day2,
+		EndDay:   day2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []rfq.SettlementStats{deltas[3]}, stats)
+
+	stats, err = store.QuerySettlementStats(ctx, rfq.SettlementStatsQuery{
+		AssetSpecifier: fn.Some(assetSpecifier),
+		Peer:           fn.Some(peer2),
+		StartDay:       day1,
+		EndDay:         day1,
+	})
+	require.NoError(t, err)
+	require.Empty(t, stats)
+}
+
+// TestRfqQuotes tests that accepted quotes and their realized volume are
+// recorded and can be queried with filters.
+func TestRfqQuotes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) RfqStore {
+			return db.WithTx(tx)
+		},
+	)
+	store := NewRfqQuotes(dbTxer)
+
+	peer1 := route.Vertex{2, 1}
+	peer2 := route.Vertex{3, 2}
+	assetSpecifier := asset.NewSpecifierFromId(asset.ID{1, 2, 3})
+	groupSpecifier := asset.NewSpecifierFromGroupKey(
+		*test.RandPubKey(t),
+	)
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	rate := rfqmath.NewBigIntFixedPoint(42_000_000, 3)
+
+	quotes := []rfq.QuoteRecord{{
+		ID:             rfqmsg.ID{1},
+		Peer:           peer1,
+		IsBuy:          true,
+		AssetSpecifier: assetSpecifier,
+		AssetMaxAmt:    1_000,
+		AssetRate: rfqmsg.NewAssetRate(
+			rate, now.Add(time.Minute),
+		),
+		AcceptedAt: now.Add(-time.Minute),
+	}, {
+		ID:             rfqmsg.ID{2},
+		Peer:           peer2,
+		AssetSpecifier: assetSpecifier,
+		PaymentMaxAmt:  50_000,
+		AssetRate: rfqmsg.NewAssetRate(
+			rate, now.Add(-time.Minute),
+		),
+		AcceptedAt: now.Add(-time.Hour),
+	}, {
+		ID:             rfqmsg.ID{3},
+		Peer:           peer1,
+		IsBuy:          true,
+		AcceptedByPeer: true,
+		AssetSpecifier: groupSpecifier,
+		AssetMaxAmt:    10,
+		AssetRate: rfqmsg.NewAssetRate(
+			rate, now.Add(time.Hour),
+		),
+		AcceptedAt: now.Add(-2 * time.Hour),
+	}}
+	for _, quote := range quotes {
+		require.NoError(t, store.AddQuote(ctx, quote))
+	}
+
+	// Recording a quote again has no effect.
+	require.NoError(t, store.AddQuote(ctx, quotes[0]))
+
+	// An empty asset specifier can't be stored.
+	require.Error(t, store.AddQuote(ctx, rfq.QuoteRecord{
+		ID:   rfqmsg.ID{4},
+		Peer: peer1,
+	}))
+
+	// We add some realized volume to the first two quotes.
+	addVolume := func(id rfqmsg.ID, assetAmt uint64,
+		amtMsat lnwire.MilliSatoshi) {
+
+		err := store.AddQuoteVolume(ctx, id, rfq.SettlementStats{
+			NumHtlcs:    1,
+			AssetAmount: assetAmt,
+			AmountMsat:  amtMsat,
+		})
+		require.NoError(t, err)
+	}
+	addVolume(rfqmsg.ID{1}, 100, 10_000)
+	addVolume(rfqmsg.ID{1}, 300, 20_000)
+	addVolume(rfqmsg.ID{2}, 50, 5_000)
+
+	// Volume of unknown quotes is ignored.
+	addVolume(rfqmsg.ID{9}, 1, 1)
+
+	fullRange := rfq.QuoteQuery{
+		StartTime: now.Add(-24 * time.Hour),
+		EndTime:   now,
+		Limit:     100,
+	}
+	queryIDs := func(query rfq.QuoteQuery) []rfqmsg.ID {
+		result, err := store.QueryQuotes(ctx, query, now)
+		require.NoError(t, err)
+
+		ids := make([]rfqmsg.ID, 0, len(result))
+		for _, quote := range result {
+			ids = append(ids, quote.ID)
+		}
+
+		return ids
+	}
+
+	// Without filters, all quotes are returned ordered by the time they
+	// were accepted.
+	result, err := store.QueryQuotes(ctx, fullRange, now)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	require.Equal(t, rfqmsg.ID{3}, result[0].ID)
+	require.True(t, result[0].AcceptedByPeer)
+	require.Equal(t, groupSpecifier, result[0].AssetSpecifier)
+
+	first := result[2]
+	require.Equal(t, quotes[0].ID, first.ID)
+	require.Equal(t, peer1, first.Peer)
+	require.True(t, first.IsBuy)
+	require.Equal(t, assetSpecifier, first.AssetSpecifier)
+	require.Equal(t, uint64(1_000), first.AssetMaxAmt)
+	require.True(t, first.AssetRate.Rate.Equals(rate))
+	require.Equal(t, quotes[0].AssetRate.Expiry, first.AssetRate.Expiry)
+	require.Equal(t, quotes[0].AcceptedAt, first.AcceptedAt)
+	require.Equal(t, uint64(2), first.NumHtlcs)
+	require.Equal(t, uint64(400), first.AssetAmount)
+	require.Equal(t, lnwire.MilliSatoshi(30_000), first.AmountMsat)
+	require.Equal(t, rfq.QuoteStateActive, first.State(now))
+	require.Equal(t, lnwire.MilliSatoshi(50_000), result[1].PaymentMaxAmt)
+	require.Equal(t, rfq.QuoteStateExpired, result[1].State(now))
+
+	// Now we apply the filters one by one.
+	query := fullRange
+	query.Peer = fn.Some(peer1)
+	require.Equal(t, []rfqmsg.ID{{3}, {1}}, queryIDs(query))
+
+	query = fullRange
+	query.AssetSpecifier = fn.Some(assetSpecifier)
+	require.Equal(t, []rfqmsg.ID{{2}, {1}}, queryIDs(query))
+
+	query = fullRange
+	query.IsBuy = fn.Some(false)
+	require.Equal(t, []rfqmsg.ID{{2}}, queryIDs(query))
+
+	query = fullRange
+	query.AcceptedByPeer = fn.Some(false)
+	require.Equal(t, []rfqmsg.ID{{2}, {1}}, queryIDs(query))
+
+	query = fullRange
+	query.State = fn.Some(rfq.QuoteStateActive)
+	require.Equal(t, []rfqmsg.ID{{3}, {1}}, queryIDs(query))
+
+	query = fullRange
+	query.State = fn.Some(rfq.QuoteStateExpired)
+	require.Equal(t, []rfqmsg.ID{{2}}, queryIDs(query))
+
+	query = fullRange
+	query.StartTime = now.Add(-90 * time.Minute)
+	require.Equal(t, []rfqmsg.ID{{2}, {1}}, queryIDs(query))
+
+	query = fullRange
+	query.Offset = 1
+	query.Limit = 1
+	require.Equal(t, []rfqmsg.ID{{2}}, queryIDs(query))
+
+	// Finally, we query the realized volume per asset.
+	volumes, err := store.QueryQuoteVolume(ctx, fullRange, now)
+	require.NoError(t, err)
+	require.Len(t, volumes, 2)
+
+	idVolume, groupVolume := volumes[0], volumes[1]
+	if idVolume.AssetSpecifier.HasGroupPubKey() {
+		idVolume, groupVolume = groupVolume, idVolume
+	}
+	require.Equal(t, rfq.QuoteVolume{
+		AssetSpecifier: assetSpecifier,
+		NumQuotes:      2,
+		NumHtlcs:       3,
+		AssetAmount:    450,
+		AmountMsat:     35_000,
+	}, idVolume)
+	require.Equal(t, rfq.QuoteVolume{
+		AssetSpecifier: groupSpecifier,
+		NumQuotes:      1,
+	}, groupVolume)
+
+	query = fullRange
+	query.AcceptedByPeer = fn.Some(false)
+	query.State = fn.Some(rfq.QuoteStateActive)
+	volumes, err = store.QueryQuoteVolume(ctx, query, now)
+	require.NoError(t, err)
+	require.Equal(t, []rfq.QuoteVolume{{
+		AssetSpecifier: assetSpecifier,
+		NumQuotes:      1,
+		NumHtlcs:       2,
+		AssetAmount:    400,
+		AmountMsat:     30_000,
+	}}, volumes)
+}
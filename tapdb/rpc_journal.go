@@ -0,0 +1,133 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+)
+
+type (
+	// NewRpcJournalEntry is used to append a new entry to the RPC
+	// journal.
+	NewRpcJournalEntry = sqlc.InsertRpcJournalEntryParams
+
+	// RpcJournalQuery is used to query entries of the RPC journal.
+	RpcJournalQuery = sqlc.QueryRpcJournalEntriesParams
+)
+
+// RpcJournalStore is the main storage interface for the RPC journal.
+type RpcJournalStore interface {
+	// InsertRpcJournalEntry appends a new entry to the RPC journal.
+	InsertRpcJournalEntry(ctx context.Context, arg NewRpcJournalEntry) error
+
+	// QueryRpcJournalEntries queries the entries of the RPC journal.
+	QueryRpcJournalEntries(ctx context.Context,
+		arg RpcJournalQuery) ([]sqlc.RpcJournal, error)
+}
+
+// BatchedRpcJournalStore allows for batched DB transactions for the RPC
+// journal store.
+type BatchedRpcJournalStore interface {
+	RpcJournalStore
+
+	BatchedTx[RpcJournalStore]
+}
+
+// RpcJournal is a persistent, append-only store for the RPC journal.
+type RpcJournal struct {
+	db BatchedRpcJournalStore
+}
+
+// A compile-time assertion to ensure RpcJournal meets the rpcjournal.Store
+// interface.
+var _ rpcjournal.Store = (*RpcJournal)(nil)
+
+// NewRpcJournal creates a new RPC journal store.
+func NewRpcJournal(db BatchedRpcJournalStore) *RpcJournal {
+	return &RpcJournal{
+		db: db,
+	}
+}
+
+// AppendEntries appends the given entries to the journal.
+//
+// NOTE: This is part of the rpcjournal.Store interface.
+func (r *RpcJournal) AppendEntries(ctx context.Context,
+	entries []rpcjournal.Entry) error {
+
+	var writeTx AssetStoreTxOptions
+	return r.db.ExecTx(ctx, &writeTx, func(q RpcJournalStore) error {
+		for _, entry := range entries {
+			err := q.InsertRpcJournalEntry(ctx, NewRpcJournalEntry{
+				Method:     entry.Method,
+				Caller:     entry.Caller,
+				PeerAddr:   entry.PeerAddr,
+				Params:     entry.Params,
+				StatusCode: entry.StatusCode,
+				ErrorMsg:   entry.ErrorMsg,
+				StartTime:  entry.StartTime.UTC(),
+				EndTime:    entry.EndTime.UTC(),
+			})
+			if err != nil {
+				return fmt.Errorf("unable to insert journal "+
+					"entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// QueryEntries returns the journal entries matching the given query, in the
+// order they were appended.
+//
+// NOTE: This is part of the rpcjournal.Store interface.
+func (r *RpcJournal) QueryEntries(ctx context.Context,
+	query rpcjournal.Query) ([]rpcjournal.Entry, error) {
+
+	dbQuery := RpcJournalQuery{
+		StartAfter:  query.StartAfter.UTC(),
+		StartBefore: query.StartBefore.UTC(),
+		NumOffset:   query.Offset,
+		NumLimit:    query.Limit,
+	}
+	query.Method.WhenSome(func(method string) {
+		dbQuery.Method = sql.NullString{
+			String: method,
+			Valid:  true,
+		}
+	})
+
+	var entries []rpcjournal.Entry
+	readTx := NewAssetStoreReadTx()
+	dbErr := r.db.ExecTx(ctx, &readTx, func(q RpcJournalStore) error {
+		rows, err := q.QueryRpcJournalEntries(ctx, dbQuery)
+		if err != nil {
+			return err
+		}
+
+		entries = make([]rpcjournal.Entry, len(rows))
+		for idx, row := range rows {
+			entries[idx] = rpcjournal.Entry{
+				Method:     row.Method,
+				Caller:     row.Caller,
+				PeerAddr:   row.PeerAddr,
+				Params:     row.Params,
+				StatusCode: row.StatusCode,
+				ErrorMsg:   row.ErrorMsg,
+				StartTime:  row.StartTime.UTC(),
+				EndTime:    row.EndTime.UTC(),
+			}
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to query RPC journal: %w", dbErr)
+	}
+
+	return entries, nil
+}
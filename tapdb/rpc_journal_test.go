@@ -0,0 +1,99 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/rpcjournal"
+	"github.com/stretchr/testify/require"
+)
+
+// newRpcJournalFromDB makes a new RPC journal store backed by the passed
+// database.
+func newRpcJournalFromDB(db *BaseDB) *RpcJournal {
+	dbTxer := NewTransactionExecutor(db,
+		func(tx *sql.Tx) RpcJournalStore {
+			return db.WithTx(tx)
+		},
+	)
+
+	return NewRpcJournal(dbTxer)
+}
+
+// TestRpcJournal tests that RPC journal entries can be appended and queried
+// by time range and method.
+func TestRpcJournal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+	store := newRpcJournalFromDB(db.BaseDB)
+
+	const (
+		sendMethod = "/taprpc.TaprootAssets/SendAsset"
+		burnMethod = "/taprpc.TaprootAssets/BurnAsset"
+	)
+
+	baseTime := time.Unix(1_700_000_000, 0).UTC()
+	newEntry := func(method string, offset time.Duration) rpcjournal.Entry {
+		return rpcjournal.Entry{
+			Method:     method,
+			Caller:     "0:0102",
+			PeerAddr:   "127.0.0.1:1234",
+			Params:     `{"tap_addrs":["taptb1..."]}`,
+			StatusCode: "OK",
+			StartTime:  baseTime.Add(offset),
+			EndTime:    baseTime.Add(offset + time.Second),
+		}
+	}
+
+	entries := []rpcjournal.Entry{
+		newEntry(sendMethod, 0),
+		newEntry(burnMethod, time.Minute),
+		newEntry(sendMethod, 2*time.Minute),
+	}
+	entries[1].StatusCode = "Unknown"
+	entries[1].ErrorMsg = "insufficient balance"
+
+	require.NoError(t, store.AppendEntries(ctx, entries[:2]))
+	require.NoError(t, store.AppendEntries(ctx, entries[2:]))
+
+	allQuery := rpcjournal.Query{
+		StartAfter:  baseTime,
+		StartBefore: baseTime.Add(time.Hour),
+		Limit:       rpcjournal.DefaultQueryLimit,
+	}
+
+	// All entries are returned in the order they were appended.
+	dbEntries, err := store.QueryEntries(ctx, allQuery)
+	require.NoError(t, err)
+	require.Equal(t, entries, dbEntries)
+
+	// The entries can be filtered by method.
+	methodQuery := allQuery
+	methodQuery.Method = fn.Some(sendMethod)
+	dbEntries, err = store.QueryEntries(ctx, methodQuery)
+	require.NoError(t, err)
+	require.Equal(
+		t, []rpcjournal.Entry{entries[0], entries[2]}, dbEntries,
+	)
+
+	// The entries can be filtered by time range.
+	timeQuery := allQuery
+	timeQuery.StartAfter = baseTime.Add(time.Second)
+	timeQuery.StartBefore = baseTime.Add(time.Minute)
+	dbEntries, err = store.QueryEntries(ctx, timeQuery)
+	require.NoError(t, err)
+	require.Equal(t, entries[1:2], dbEntries)
+
+	// The entries can be paginated.
+	pageQuery := allQuery
+	pageQuery.Offset = 1
+	pageQuery.Limit = 1
+	dbEntries, err = store.QueryEntries(ctx, pageQuery)
+	require.NoError(t, err)
+	require.Equal(t, entries[1:2], dbEntries)
+}
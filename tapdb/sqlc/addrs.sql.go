@@ -15,7 +15,7 @@ const fetchAddrByTaprootOutputKey = `-- name: FetchAddrByTaprootOutputKey :one
 SELECT
     version, asset_version, genesis_asset_id, group_key, tapscript_sibling,
     taproot_output_key, amount, asset_type, creation_time, managed_from,
-    proof_courier_addr,
+    proof_courier_addr, fallback_proof_courier_addrs, expiry, reusable,
     script_keys.tweaked_script_key,
     script_keys.tweak AS script_key_tweak,
     script_keys.declared_known AS script_key_declared_known,
@@ -36,26 +36,29 @@ WHERE taproot_output_key = $1
 `
 
 type FetchAddrByTaprootOutputKeyRow struct {
-	Version                int16
-	AssetVersion           int16
-	GenesisAssetID         int64
-	GroupKey               []byte
-	TapscriptSibling       []byte
-	TaprootOutputKey       []byte
-	Amount                 int64
-	AssetType              int16
-	CreationTime           time.Time
-	ManagedFrom            sql.NullTime
-	ProofCourierAddr       []byte
-	TweakedScriptKey       []byte
-	ScriptKeyTweak         []byte
-	ScriptKeyDeclaredKnown sql.NullBool
-	RawScriptKey           []byte
-	ScriptKeyFamily        int32
-	ScriptKeyIndex         int32
-	RawTaprootKey          []byte
-	TaprootKeyFamily       int32
-	TaprootKeyIndex        int32
+	Version                   int16
+	AssetVersion              int16
+	GenesisAssetID            int64
+	GroupKey                  []byte
+	TapscriptSibling          []byte
+	TaprootOutputKey          []byte
+	Amount                    int64
+	AssetType                 int16
+	CreationTime              time.Time
+	ManagedFrom               sql.NullTime
+	ProofCourierAddr          []byte
+	FallbackProofCourierAddrs []byte
+	Expiry                    sql.NullTime
+	Reusable                  bool
+	TweakedScriptKey          []byte
+	ScriptKeyTweak            []byte
+	ScriptKeyDeclaredKnown    sql.NullBool
+	RawScriptKey              []byte
+	ScriptKeyFamily           int32
+	ScriptKeyIndex            int32
+	RawTaprootKey             []byte
+	TaprootKeyFamily          int32
+	TaprootKeyIndex           int32
 }
 
 func (q *Queries) FetchAddrByTaprootOutputKey(ctx context.Context, taprootOutputKey []byte) (FetchAddrByTaprootOutputKeyRow, error) {
@@ -73,6 +76,9 @@ func (q *Queries) FetchAddrByTaprootOutputKey(ctx context.Context, taprootOutput
 		&i.CreationTime,
 		&i.ManagedFrom,
 		&i.ProofCourierAddr,
+		&i.FallbackProofCourierAddrs,
+		&i.Expiry,
+		&i.Reusable,
 		&i.TweakedScriptKey,
 		&i.ScriptKeyTweak,
 		&i.ScriptKeyDeclaredKnown,
@@ -206,7 +212,7 @@ const fetchAddrs = `-- name: FetchAddrs :many
 SELECT 
     version, asset_version, genesis_asset_id, group_key, tapscript_sibling,
     taproot_output_key, amount, asset_type, creation_time, managed_from,
-    proof_courier_addr,
+    proof_courier_addr, fallback_proof_courier_addrs, expiry, reusable,
     script_keys.tweaked_script_key,
     script_keys.tweak AS script_key_tweak,
     script_keys.declared_known AS script_key_declared_known,
@@ -240,26 +246,29 @@ type FetchAddrsParams struct {
 }
 
 type FetchAddrsRow struct {
-	Version                int16
-	AssetVersion           int16
-	GenesisAssetID         int64
-	GroupKey               []byte
-	TapscriptSibling       []byte
-	TaprootOutputKey       []byte
-	Amount                 int64
-	AssetType              int16
-	CreationTime           time.Time
-	ManagedFrom            sql.NullTime
-	ProofCourierAddr       []byte
-	TweakedScriptKey       []byte
-	ScriptKeyTweak         []byte
-	ScriptKeyDeclaredKnown sql.NullBool
-	RawScriptKey           []byte
-	ScriptKeyFamily        int32
-	ScriptKeyIndex         int32
-	RawTaprootKey          []byte
-	TaprootKeyFamily       int32
-	TaprootKeyIndex        int32
+	Version                   int16
+	AssetVersion              int16
+	GenesisAssetID            int64
+	GroupKey                  []byte
+	TapscriptSibling          []byte
+	TaprootOutputKey          []byte
+	Amount                    int64
+	AssetType                 int16
+	CreationTime              time.Time
+	ManagedFrom               sql.NullTime
+	ProofCourierAddr          []byte
+	FallbackProofCourierAddrs []byte
+	Expiry                    sql.NullTime
+	Reusable                  bool
+	TweakedScriptKey          []byte
+	ScriptKeyTweak            []byte
+	ScriptKeyDeclaredKnown    sql.NullBool
+	RawScriptKey              []byte
+	ScriptKeyFamily           int32
+	ScriptKeyIndex            int32
+	RawTaprootKey             []byte
+	TaprootKeyFamily          int32
+	TaprootKeyIndex           int32
 }
 
 func (q *Queries) FetchAddrs(ctx context.Context, arg FetchAddrsParams) ([]FetchAddrsRow, error) {
@@ -289,6 +298,9 @@ func (q *Queries) FetchAddrs(ctx context.Context, arg FetchAddrsParams) ([]Fetch
 			&i.CreationTime,
 			&i.ManagedFrom,
 			&i.ProofCourierAddr,
+			&i.FallbackProofCourierAddrs,
+			&i.Expiry,
+			&i.Reusable,
 			&i.TweakedScriptKey,
 			&i.ScriptKeyTweak,
 			&i.ScriptKeyDeclaredKnown,
@@ -312,27 +324,46 @@ func (q *Queries) FetchAddrs(ctx context.Context, arg FetchAddrsParams) ([]Fetch
 	return items, nil
 }
 
+const fetchScriptKeyReservation = `-- name: FetchScriptKeyReservation :one
+SELECT tweaked_script_key, owner, reserved_at
+FROM script_key_reservations
+WHERE tweaked_script_key = $1
+`
+
+func (q *Queries) FetchScriptKeyReservation(ctx context.Context, tweakedScriptKey []byte) (ScriptKeyReservation, error) {
+	row := q.db.QueryRowContext(ctx, fetchScriptKeyReservation, tweakedScriptKey)
+	var i ScriptKeyReservation
+	err := row.Scan(&i.TweakedScriptKey, &i.Owner, &i.ReservedAt)
+	return i, err
+}
+
 const insertAddr = `-- name: InsertAddr :one
 INSERT INTO addrs (
     version, asset_version, genesis_asset_id, group_key, script_key_id,
     taproot_key_id, tapscript_sibling, taproot_output_key, amount, asset_type,
-    creation_time, proof_courier_addr
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id
+    creation_time, proof_courier_addr, fallback_proof_courier_addrs, expiry,
+    reusable
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) RETURNING id
 `
 
 type InsertAddrParams struct {
-	Version          int16
-	AssetVersion     int16
-	GenesisAssetID   int64
-	GroupKey         []byte
-	ScriptKeyID      int64
-	TaprootKeyID     int64
-	TapscriptSibling []byte
-	TaprootOutputKey []byte
-	Amount           int64
-	AssetType        int16
-	CreationTime     time.Time
-	ProofCourierAddr []byte
+	Version                   int16
+	AssetVersion              int16
+	GenesisAssetID            int64
+	GroupKey                  []byte
+	ScriptKeyID               int64
+	TaprootKeyID              int64
+	TapscriptSibling          []byte
+	TaprootOutputKey          []byte
+	Amount                    int64
+	AssetType                 int16
+	CreationTime              time.Time
+	ProofCourierAddr          []byte
+	FallbackProofCourierAddrs []byte
+	Expiry                    sql.NullTime
+	Reusable                  bool
 }
 
 func (q *Queries) InsertAddr(ctx context.Context, arg InsertAddrParams) (int64, error) {
@@ -349,12 +380,34 @@ func (q *Queries) InsertAddr(ctx context.Context, arg InsertAddrParams) (int64,
 		arg.AssetType,
 		arg.CreationTime,
 		arg.ProofCourierAddr,
+		arg.FallbackProofCourierAddrs,
+		arg.Expiry,
+		arg.Reusable,
 	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
+const insertScriptKeyReservation = `-- name: InsertScriptKeyReservation :exec
+INSERT INTO script_key_reservations (
+    tweaked_script_key, owner, reserved_at
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (tweaked_script_key) DO NOTHING
+`
+
+type InsertScriptKeyReservationParams struct {
+	TweakedScriptKey []byte
+	Owner            int16
+	ReservedAt       time.Time
+}
+
+func (q *Queries) InsertScriptKeyReservation(ctx context.Context, arg InsertScriptKeyReservationParams) error {
+	_, err := q.db.ExecContext(ctx, insertScriptKeyReservation, arg.TweakedScriptKey, arg.Owner, arg.ReservedAt)
+	return err
+}
+
 const queryEventIDs = `-- name: QueryEventIDs :many
 SELECT
     addr_events.id as event_id, addrs.taproot_output_key as taproot_output_key
@@ -2061,9 +2061,20 @@ JOIN managed_utxos utxos
        END
 JOIN script_keys
     ON assets.script_key_id = script_keys.script_key_id
+JOIN internal_keys
+    ON script_keys.internal_key_id = internal_keys.key_id
 WHERE spent = FALSE AND 
         (script_keys.tweaked_script_key != $4 OR
-                $4 IS NULL)
+                $4 IS NULL) AND
+        (internal_keys.key_family = $5 OR
+                $5 IS NULL) AND
+        CASE
+            WHEN $6 = true THEN
+                internal_keys.key_family NOT IN (
+                    SELECT key_family FROM asset_accounts
+                )
+            ELSE TRUE
+        END
 GROUP BY assets.genesis_id, genesis_info_view.asset_id,
          genesis_info_view.asset_tag, genesis_info_view.meta_hash,
          genesis_info_view.asset_type, genesis_info_view.output_index,
@@ -2071,10 +2082,12 @@ GROUP BY assets.genesis_id, genesis_info_view.asset_id,
 `
 
 type QueryAssetBalancesByAssetParams struct {
-	AssetIDFilter []byte
-	Leased        interface{}
-	Now           sql.NullTime
-	ExcludeKey    []byte
+	AssetIDFilter      []byte
+	Leased             interface{}
+	Now                sql.NullTime
+	ExcludeKey         []byte
+	ScriptKeyFamily    sql.NullInt32
+	ExcludeAccountKeys interface{}
 }
 
 type QueryAssetBalancesByAssetRow struct {
@@ -2097,6 +2110,8 @@ func (q *Queries) QueryAssetBalancesByAsset(ctx context.Context, arg QueryAssetB
 		arg.Leased,
 		arg.Now,
 		arg.ExcludeKey,
+		arg.ScriptKeyFamily,
+		arg.ExcludeAccountKeys,
 	)
 	if err != nil {
 		return nil, err
@@ -2148,17 +2163,30 @@ JOIN managed_utxos utxos
        END
 JOIN script_keys
     ON assets.script_key_id = script_keys.script_key_id
+JOIN internal_keys
+    ON script_keys.internal_key_id = internal_keys.key_id
 WHERE spent = FALSE AND 
         (script_keys.tweaked_script_key != $4 OR
-                $4 IS NULL)
+                $4 IS NULL) AND
+        (internal_keys.key_family = $5 OR
+                $5 IS NULL) AND
+        CASE
+            WHEN $6 = true THEN
+                internal_keys.key_family NOT IN (
+                    SELECT key_family FROM asset_accounts
+                )
+            ELSE TRUE
+        END
 GROUP BY key_group_info_view.tweaked_group_key
 `
 
 type QueryAssetBalancesByGroupParams struct {
-	KeyGroupFilter []byte
-	Leased         interface{}
-	Now            sql.NullTime
-	ExcludeKey     []byte
+	KeyGroupFilter     []byte
+	Leased             interface{}
+	Now                sql.NullTime
+	ExcludeKey         []byte
+	ScriptKeyFamily    sql.NullInt32
+	ExcludeAccountKeys interface{}
 }
 
 type QueryAssetBalancesByGroupRow struct {
@@ -2172,6 +2200,8 @@ func (q *Queries) QueryAssetBalancesByGroup(ctx context.Context, arg QueryAssetB
 		arg.Leased,
 		arg.Now,
 		arg.ExcludeKey,
+		arg.ScriptKeyFamily,
+		arg.ExcludeAccountKeys,
 	)
 	if err != nil {
 		return nil, err
@@ -2272,8 +2302,73 @@ WHERE (
         WHEN cast($13 as bool) = TRUE
         THEN 0 
         ELSE COALESCE(length(script_keys.tweak), 0)
-    END)
+    END) AND
+    (internal_keys.key_family = $14 OR
+      $14 IS NULL) AND
+    CASE
+        WHEN $15 = true THEN
+            internal_keys.key_family NOT IN (
+                SELECT key_family FROM asset_accounts
+            )
+        ELSE TRUE
+    END
+) AND (
+    COALESCE(txns.block_height, 0) <= COALESCE($16, txns.block_height, 0)
+) AND (
+    $17 IS NULL OR (
+        cast($18 AS BOOLEAN) = FALSE AND
+        CASE cast($19 AS INTEGER)
+            WHEN 1 THEN
+                assets.amount > $20 OR
+                (assets.amount = $20 AND
+                 assets.asset_id > $17)
+            WHEN 2 THEN
+                COALESCE(txns.block_height, 0) > $21 OR
+                (COALESCE(txns.block_height, 0) = $21 AND
+                 assets.asset_id > $17)
+            WHEN 3 THEN
+                genesis_info_view.asset_id > $22 OR
+                (genesis_info_view.asset_id = $22 AND
+                 assets.asset_id > $17)
+            ELSE assets.asset_id > $17
+        END
+    ) OR (
+        cast($18 AS BOOLEAN) = TRUE AND
+        CASE cast($19 AS INTEGER)
+            WHEN 1 THEN
+                assets.amount < $20 OR
+                (assets.amount = $20 AND
+                 assets.asset_id < $17)
+            WHEN 2 THEN
+                COALESCE(txns.block_height, 0) < $21 OR
+                (COALESCE(txns.block_height, 0) = $21 AND
+                 assets.asset_id < $17)
+            WHEN 3 THEN
+                genesis_info_view.asset_id < $22 OR
+                (genesis_info_view.asset_id = $22 AND
+                 assets.asset_id < $17)
+            ELSE assets.asset_id < $17
+        END
+    )
 )
+ORDER BY
+    CASE WHEN cast($19 AS INTEGER) = 1 AND cast($18 AS BOOLEAN) = FALSE THEN
+             assets.amount END ASC,
+    CASE WHEN cast($19 AS INTEGER) = 1 AND cast($18 AS BOOLEAN) = TRUE THEN
+             assets.amount END DESC,
+    CASE WHEN cast($19 AS INTEGER) = 2 AND cast($18 AS BOOLEAN) = FALSE THEN
+             COALESCE(txns.block_height, 0) END ASC,
+    CASE WHEN cast($19 AS INTEGER) = 2 AND cast($18 AS BOOLEAN) = TRUE THEN
+             COALESCE(txns.block_height, 0) END DESC,
+    CASE WHEN cast($19 AS INTEGER) = 3 AND cast($18 AS BOOLEAN) = FALSE THEN
+             genesis_info_view.asset_id END ASC,
+    CASE WHEN cast($19 AS INTEGER) = 3 AND cast($18 AS BOOLEAN) = TRUE THEN
+             genesis_info_view.asset_id END DESC,
+    CASE WHEN cast($18 AS BOOLEAN) = FALSE THEN
+             assets.asset_id END ASC,
+    CASE WHEN cast($18 AS BOOLEAN) = TRUE THEN
+             assets.asset_id END DESC
+LIMIT COALESCE($23, 9223372036854775807)
 `
 
 type QueryAssetsParams struct {
@@ -2290,6 +2385,16 @@ type QueryAssetsParams struct {
 	GenesisID           sql.NullInt64
 	ScriptKeyID         sql.NullInt64
 	Bip86ScriptKeysOnly bool
+	ScriptKeyFamily     sql.NullInt32
+	ExcludeAccountKeys  interface{}
+	MaxAnchorHeight     sql.NullInt32
+	CursorPrimaryKey    sql.NullInt64
+	SortDescending      bool
+	SortBy              int32
+	CursorAmount        sql.NullInt64
+	CursorAnchorHeight  sql.NullInt32
+	CursorAssetID       []byte
+	NumLimit            sql.NullInt32
 }
 
 type QueryAssetsRow struct {
@@ -2358,6 +2463,16 @@ func (q *Queries) QueryAssets(ctx context.Context, arg QueryAssetsParams) ([]Que
 		arg.GenesisID,
 		arg.ScriptKeyID,
 		arg.Bip86ScriptKeysOnly,
+		arg.ScriptKeyFamily,
+		arg.ExcludeAccountKeys,
+		arg.MaxAnchorHeight,
+		arg.CursorPrimaryKey,
+		arg.SortDescending,
+		arg.SortBy,
+		arg.CursorAmount,
+		arg.CursorAnchorHeight,
+		arg.CursorAssetID,
+		arg.NumLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -2421,6 +2536,23 @@ func (q *Queries) QueryAssets(ctx context.Context, arg QueryAssetsParams) ([]Que
 	return items, nil
 }
 
+const replaceChainAnchorTx = `-- name: ReplaceChainAnchorTx :exec
+UPDATE chain_txns
+SET txid = $1, raw_tx = $2
+WHERE txid = $3
+`
+
+type ReplaceChainAnchorTxParams struct {
+	NewTxid []byte
+	RawTx   []byte
+	OldTxid []byte
+}
+
+func (q *Queries) ReplaceChainAnchorTx(ctx context.Context, arg ReplaceChainAnchorTxParams) error {
+	_, err := q.db.ExecContext(ctx, replaceChainAnchorTx, arg.NewTxid, arg.RawTx, arg.OldTxid)
+	return err
+}
+
 const setAssetSpent = `-- name: SetAssetSpent :one
 WITH target_asset(asset_id) AS (
     SELECT assets.asset_id
@@ -2478,6 +2610,23 @@ func (q *Queries) UpdateBatchGenesisTx(ctx context.Context, arg UpdateBatchGenes
 	return err
 }
 
+const updateManagedUTXOOutpoint = `-- name: UpdateManagedUTXOOutpoint :exec
+UPDATE managed_utxos
+SET outpoint = $1, amt_sats = $2
+WHERE outpoint = $3
+`
+
+type UpdateManagedUTXOOutpointParams struct {
+	NewOutpoint []byte
+	AmtSats     int64
+	OldOutpoint []byte
+}
+
+func (q *Queries) UpdateManagedUTXOOutpoint(ctx context.Context, arg UpdateManagedUTXOOutpointParams) error {
+	_, err := q.db.ExecContext(ctx, updateManagedUTXOOutpoint, arg.NewOutpoint, arg.AmtSats, arg.OldOutpoint)
+	return err
+}
+
 const updateMintingBatchState = `-- name: UpdateMintingBatchState :exec
 WITH target_batch AS (
     -- This CTE is used to fetch the ID of a batch, based on the serialized
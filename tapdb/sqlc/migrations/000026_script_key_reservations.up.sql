@@ -0,0 +1,25 @@
+-- script_key_reservations tracks which subsystem of the daemon claimed a given
+-- script key. A script key must only ever be used by a single subsystem (e.g.
+-- an address, a channel funding output or a newly minted asset), otherwise
+-- funds could be attributed to the wrong owner.
+CREATE TABLE IF NOT EXISTS script_key_reservations (
+    -- The tweaked script key that was reserved, in its 33-byte compressed
+    -- form.
+    tweaked_script_key BLOB PRIMARY KEY CHECK(length(tweaked_script_key) = 33),
+
+    -- The subsystem that reserved the script key. This maps to the
+    -- address.ScriptKeyOwner enum.
+    owner SMALLINT NOT NULL,
+
+    -- The time at which the script key was reserved.
+    reserved_at TIMESTAMP NOT NULL
+);
+
+-- All script keys that were used for addresses before this migration was
+-- applied are reserved by the address subsystem.
+INSERT INTO script_key_reservations (tweaked_script_key, owner, reserved_at)
+SELECT script_keys.tweaked_script_key, 0, MIN(addrs.creation_time)
+FROM addrs
+JOIN script_keys
+    ON addrs.script_key_id = script_keys.script_key_id
+GROUP BY script_keys.tweaked_script_key;
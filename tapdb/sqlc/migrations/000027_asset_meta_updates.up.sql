@@ -0,0 +1,30 @@
+-- asset_meta_updates stores the signed metadata updates that issuers publish
+-- for their asset groups. Each update is signed with the raw key the tweaked
+-- group key is derived from.
+CREATE TABLE IF NOT EXISTS asset_meta_updates (
+    id INTEGER PRIMARY KEY,
+
+    -- The tweaked group key of the asset group the update is for, in its
+    -- 33-byte compressed form.
+    tweaked_group_key BLOB NOT NULL CHECK(length(tweaked_group_key) = 33),
+
+    -- The version of the update, which must increase with each update of a
+    -- group.
+    version BIGINT NOT NULL,
+
+    -- The type and data of the updated metadata.
+    meta_type SMALLINT NOT NULL,
+    meta_data BLOB NOT NULL,
+
+    -- The raw group key the update was signed with, in its 33-byte
+    -- compressed form.
+    signer_key BLOB NOT NULL CHECK(length(signer_key) = 33),
+
+    -- The 64-byte Schnorr signature of the update.
+    signature BLOB NOT NULL CHECK(length(signature) = 64),
+
+    -- The time at which the update was stored.
+    created_at TIMESTAMP NOT NULL,
+
+    UNIQUE(tweaked_group_key, version)
+);
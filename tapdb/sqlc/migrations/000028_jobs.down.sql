@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS jobs_state_idx;
+DROP TABLE IF EXISTS jobs;
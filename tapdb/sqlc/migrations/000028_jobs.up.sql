@@ -0,0 +1,27 @@
+-- jobs stores the long-running operations tracked by the job manager, so their
+-- state and progress survive restarts of the daemon.
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY,
+
+    -- The kind of operation the job runs, which determines the handler the
+    -- job is executed with.
+    kind TEXT NOT NULL,
+
+    -- The opaque, kind specific parameters of the job.
+    params BLOB,
+
+    -- The state of the job, as defined by jobs.State.
+    state SMALLINT NOT NULL,
+
+    -- The progress of the job in percent.
+    progress SMALLINT NOT NULL CHECK(progress >= 0 AND progress <= 100),
+
+    -- The error the job failed with, if any.
+    error_msg TEXT NOT NULL,
+
+    -- The time the job was submitted and the time it was last updated.
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS jobs_state_idx ON jobs(state);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rfq_peer_stats;
@@ -0,0 +1,27 @@
+-- rfq_peer_stats stores the metrics the RFQ subsystem collects about the peers
+-- it requests quotes from, which are used to compute a reputation score for
+-- each peer.
+CREATE TABLE IF NOT EXISTS rfq_peer_stats (
+    -- The public key of the peer.
+    peer BLOB PRIMARY KEY CHECK(length(peer) = 33),
+
+    -- The number of quotes the peer accepted with terms that passed our
+    -- validation, and the number of quotes it rejected or accepted with
+    -- invalid terms.
+    quotes_accepted BIGINT NOT NULL DEFAULT 0,
+    quotes_rejected BIGINT NOT NULL DEFAULT 0,
+
+    -- The number of payments that settled using a quote of the peer, and the
+    -- number of HTLCs the peer failed after quoting.
+    htlcs_settled BIGINT NOT NULL DEFAULT 0,
+    htlcs_failed BIGINT NOT NULL DEFAULT 0,
+
+    -- The sum of the deviations of the peer's accepted quote rates from our
+    -- price oracle's rate in parts per million, from our point of view, and
+    -- the number of deviations that were summed up.
+    rate_deviation_ppm_sum BIGINT NOT NULL DEFAULT 0,
+    rate_samples BIGINT NOT NULL DEFAULT 0,
+
+    -- The time the stats were last updated.
+    updated_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS rfq_settlement_stats_day_idx;
+DROP TABLE IF EXISTS rfq_settlement_stats;
@@ -0,0 +1,29 @@
+-- rfq_settlement_stats stores the settled asset HTLC volume of the RFQ order
+-- handler, aggregated per asset, peer and day. The rows are updated
+-- incrementally whenever an HTLC that was accepted under an RFQ policy
+-- settles.
+CREATE TABLE IF NOT EXISTS rfq_settlement_stats (
+    -- The asset the HTLCs carried. This is either an asset ID (32 bytes) or a
+    -- compressed group key (33 bytes), depending on the quote's asset
+    -- specifier.
+    asset_key BLOB NOT NULL CHECK(length(asset_key) IN (32, 33)),
+
+    -- The public key of the peer the quote was negotiated with.
+    peer BLOB NOT NULL CHECK(length(peer) = 33),
+
+    -- The unix timestamp of the start of the UTC day the HTLCs settled on.
+    day BIGINT NOT NULL,
+
+    -- The number of settled HTLCs.
+    num_htlcs BIGINT NOT NULL DEFAULT 0,
+
+    -- The sum of the asset units and the sum of the BTC amounts in
+    -- milli-satoshi of the settled HTLCs.
+    asset_amount BIGINT NOT NULL DEFAULT 0,
+    amount_msat BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (asset_key, peer, day)
+);
+
+CREATE INDEX IF NOT EXISTS rfq_settlement_stats_day_idx
+    ON rfq_settlement_stats (day);
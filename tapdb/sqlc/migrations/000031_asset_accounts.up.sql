@@ -0,0 +1,17 @@
+-- asset_accounts stores the named asset accounts of the daemon. Each account
+-- derives its script keys and anchor internal keys from its own key family,
+-- which allows the assets owned by an account to be told apart from the
+-- assets owned by the implicit default account.
+CREATE TABLE IF NOT EXISTS asset_accounts (
+    id INTEGER PRIMARY KEY,
+
+    -- The unique, human readable name of the account.
+    name TEXT UNIQUE NOT NULL CHECK(length(name) > 0),
+
+    -- The key family (BIP-0043 account) all keys of the account are derived
+    -- from.
+    key_family INTEGER UNIQUE NOT NULL,
+
+    -- The time the account was created at.
+    created_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,17 @@
+-- asset_channel_backups stores the asset channel backups that were restored
+-- after a data loss. The backups contain the asset level funding and
+-- commitment state of a channel, which is used to sweep the assets of the
+-- channel once it is force closed, as lnd's static channel backups don't
+-- cover the custom channel data of asset channels.
+CREATE TABLE IF NOT EXISTS asset_channel_backups (
+    id INTEGER PRIMARY KEY,
+
+    -- The serialized funding outpoint of the channel.
+    chan_point BLOB UNIQUE NOT NULL,
+
+    -- The TLV encoded asset channel backup.
+    backup BLOB NOT NULL,
+
+    -- The time the backup was restored at.
+    restored_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,3 @@
+-- Remove the `proof_delivery_skipped` column from the `asset_transfer_outputs`
+-- table.
+ALTER TABLE asset_transfer_outputs DROP COLUMN proof_delivery_skipped;
@@ -0,0 +1,5 @@
+-- Add a column to track if the automatic proof delivery was skipped for an
+-- asset transfer output, because the proof is delivered out of band by the
+-- sender instead.
+ALTER TABLE asset_transfer_outputs
+ADD COLUMN proof_delivery_skipped BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS rpc_journal_start_time_idx;
+DROP TABLE IF EXISTS rpc_journal;
@@ -0,0 +1,33 @@
+-- rpc_journal is an append-only journal of the mutating RPC calls that were
+-- made to the daemon. It is only written to if the RPC journal is enabled.
+CREATE TABLE IF NOT EXISTS rpc_journal (
+    id INTEGER PRIMARY KEY,
+
+    -- The full gRPC method name of the call.
+    method TEXT NOT NULL,
+
+    -- The identity of the caller, derived from the ID of the macaroon the
+    -- call was authenticated with. Empty if no macaroon was used.
+    caller TEXT NOT NULL,
+
+    -- The network address of the caller.
+    peer_addr TEXT NOT NULL,
+
+    -- The JSON encoded request parameters with secrets redacted.
+    params TEXT NOT NULL,
+
+    -- The gRPC status code the call finished with.
+    status_code TEXT NOT NULL,
+
+    -- The error message of a failed call.
+    error_msg TEXT NOT NULL,
+
+    -- The time the call was received at.
+    start_time TIMESTAMP NOT NULL,
+
+    -- The time the call finished at.
+    end_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rpc_journal_start_time_idx
+    ON rpc_journal(start_time);
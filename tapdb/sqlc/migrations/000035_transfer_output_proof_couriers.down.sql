@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS asset_transfer_output_proof_couriers;
+
+-- Remove the `proof_courier_mode` column from the `asset_transfer_outputs`
+-- table.
+ALTER TABLE asset_transfer_outputs DROP COLUMN proof_courier_mode;
@@ -0,0 +1,33 @@
+-- Add a column to track how the proof of an asset transfer output is
+-- delivered if there are multiple proof couriers for the output. A value of 0
+-- means the couriers are used for failover, a value of 1 means the proof is
+-- delivered to all of them.
+ALTER TABLE asset_transfer_outputs
+ADD COLUMN proof_courier_mode SMALLINT NOT NULL DEFAULT 0;
+
+-- asset_transfer_output_proof_couriers tracks the proof delivery of an asset
+-- transfer output to each of its proof couriers, if the sender specified
+-- additional proof couriers for the transfer.
+CREATE TABLE IF NOT EXISTS asset_transfer_output_proof_couriers (
+    id INTEGER PRIMARY KEY,
+
+    -- The transfer output the proof of which is delivered.
+    output_id BIGINT NOT NULL REFERENCES asset_transfer_outputs(output_id),
+
+    -- The priority of the courier, with 0 being the highest priority.
+    priority INTEGER NOT NULL,
+
+    -- The bytes encoded address of the proof courier.
+    courier_addr BLOB NOT NULL,
+
+    -- Whether the proof was delivered to the courier.
+    delivered BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- The error of the last failed delivery attempt.
+    last_error TEXT NOT NULL DEFAULT '',
+
+    -- The time of the last delivery attempt, if any.
+    last_attempt_time TIMESTAMP,
+
+    UNIQUE(output_id, priority)
+);
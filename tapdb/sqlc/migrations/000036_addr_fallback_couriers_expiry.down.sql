@@ -0,0 +1,5 @@
+-- Remove the `expiry` column from the `addrs` table.
+ALTER TABLE addrs DROP COLUMN expiry;
+
+-- Remove the `fallback_proof_courier_addrs` column from the `addrs` table.
+ALTER TABLE addrs DROP COLUMN fallback_proof_courier_addrs;
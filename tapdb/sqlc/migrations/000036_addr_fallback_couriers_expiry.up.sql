@@ -0,0 +1,8 @@
+-- Add a column to store the ordered list of fallback proof courier addresses
+-- of V2 addresses. The addresses are encoded the same way as in the address
+-- itself. If the address has no fallback proof couriers, this field is NULL.
+ALTER TABLE addrs ADD COLUMN fallback_proof_courier_addrs BLOB;
+
+-- Add a column to store the expiry of V2 addresses. If the address doesn't
+-- expire, this field is NULL.
+ALTER TABLE addrs ADD COLUMN expiry TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS replication_cursors;
+DROP TABLE IF EXISTS replication_changes;
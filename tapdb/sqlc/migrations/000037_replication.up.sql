@@ -0,0 +1,24 @@
+-- replication_changes is the append-only log of state changes a replication
+-- leader streams to its followers. Each change is identified by its sequence
+-- number, which followers use to resume the replication.
+CREATE TABLE IF NOT EXISTS replication_changes (
+    seq INTEGER PRIMARY KEY,
+
+    -- The type of the change (proof, address or transfer).
+    change_type SMALLINT NOT NULL,
+
+    -- The serialized change.
+    payload BLOB NOT NULL,
+
+    -- The time the change was recorded at.
+    created_at TIMESTAMP NOT NULL
+);
+
+-- replication_cursors tracks the sequence number of the last change a
+-- replication follower applied from each leader it replicated from.
+CREATE TABLE IF NOT EXISTS replication_cursors (
+    leader_id TEXT PRIMARY KEY,
+
+    -- The sequence number of the last applied change.
+    last_seq BIGINT NOT NULL
+);
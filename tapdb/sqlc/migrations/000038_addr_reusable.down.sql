@@ -0,0 +1,2 @@
+-- Remove the `reusable` column from the `addrs` table.
+ALTER TABLE addrs DROP COLUMN reusable;
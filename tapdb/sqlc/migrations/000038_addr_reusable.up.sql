@@ -0,0 +1,3 @@
+-- Add a column to mark reusable V2 addresses. The script key of a reusable
+-- address is the raw base key the per-deposit script keys are derived from.
+ALTER TABLE addrs ADD COLUMN reusable BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS script_key_freezes;
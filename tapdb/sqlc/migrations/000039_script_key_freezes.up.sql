@@ -0,0 +1,15 @@
+-- script_key_freezes stores the issuer policy script keys the issuer refuses
+-- to co-sign transfers for. Assets locked to a frozen script key can't be
+-- moved by their holder until the script key is unfrozen again.
+CREATE TABLE IF NOT EXISTS script_key_freezes (
+    id INTEGER PRIMARY KEY,
+
+    -- The tweaked issuer policy script key that is frozen.
+    tweaked_script_key BLOB UNIQUE NOT NULL CHECK(length(tweaked_script_key) = 33),
+
+    -- The optional human readable reason of the freeze.
+    reason TEXT NOT NULL,
+
+    -- The time the script key was frozen at.
+    frozen_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS meta_schemas;
@@ -0,0 +1,14 @@
+-- meta_schemas stores the custom JSON schemas asset metadata can be validated
+-- against at mint time. Built-in schemas are not stored.
+CREATE TABLE IF NOT EXISTS meta_schemas (
+    id INTEGER PRIMARY KEY,
+
+    -- The unique ID of the schema that is declared when minting.
+    schema_id TEXT UNIQUE NOT NULL,
+
+    -- The JSON schema definition.
+    definition BLOB NOT NULL,
+
+    -- The time the schema was registered at.
+    registered_at TIMESTAMP NOT NULL
+);
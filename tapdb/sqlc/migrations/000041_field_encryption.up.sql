@@ -0,0 +1,21 @@
+-- field_encryption stores the parameters of the encryption of sensitive
+-- fields, like macaroon root keys and proofs. The table holds at most a single
+-- row, which is created once the database is first unlocked with encryption
+-- enabled.
+CREATE TABLE IF NOT EXISTS field_encryption (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+
+    -- The source of the secret the encryption key is derived from, either
+    -- the lnd wallet or a passphrase.
+    key_source TEXT NOT NULL,
+
+    -- The random salt used to derive the encryption key from the secret.
+    salt BLOB NOT NULL CHECK(length(salt) = 32),
+
+    -- A known value encrypted with the encryption key, used to check that
+    -- the correct secret was supplied.
+    key_check BLOB NOT NULL,
+
+    -- The time encryption was enabled at.
+    created_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS rfq_quotes_asset_key_idx;
+DROP INDEX IF EXISTS rfq_quotes_accepted_at_idx;
+DROP TABLE IF EXISTS rfq_quotes;
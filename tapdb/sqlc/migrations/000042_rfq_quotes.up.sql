@@ -0,0 +1,51 @@
+-- rfq_quotes stores every RFQ quote that was accepted, either by our node or
+-- by one of our peers, together with the volume that was realized under it.
+CREATE TABLE IF NOT EXISTS rfq_quotes (
+    -- The ID of the quote request the quote was accepted for.
+    quote_id BLOB PRIMARY KEY CHECK(length(quote_id) = 32),
+
+    -- The public key of the peer the quote was negotiated with.
+    peer BLOB NOT NULL CHECK(length(peer) = 33),
+
+    -- Whether this is a buy quote, where the requesting node buys the asset,
+    -- or a sell quote, where the requesting node sells the asset.
+    is_buy BOOLEAN NOT NULL,
+
+    -- Whether the peer accepted a quote our node requested, or our node
+    -- accepted a quote the peer requested.
+    accepted_by_peer BOOLEAN NOT NULL,
+
+    -- The asset of the quote. This is either an asset ID (32 bytes) or a
+    -- compressed group key (33 bytes), depending on the quote's asset
+    -- specifier.
+    asset_key BLOB NOT NULL CHECK(length(asset_key) IN (32, 33)),
+
+    -- The maximum asset amount of a buy quote and the maximum payment amount
+    -- in milli-satoshi of a sell quote. The field that doesn't apply to the
+    -- quote is zero.
+    asset_max_amount BIGINT NOT NULL,
+    payment_max_msat BIGINT NOT NULL,
+
+    -- The accepted rate in asset units per BTC, as the big-endian encoded
+    -- coefficient and the scale of a fixed point number.
+    rate_coefficient BLOB NOT NULL,
+    rate_scale INTEGER NOT NULL,
+
+    -- The unix timestamps of the expiry of the quote and of the time it was
+    -- accepted.
+    expiry BIGINT NOT NULL,
+    accepted_at BIGINT NOT NULL,
+
+    -- The number of settled HTLCs, the sum of their asset units and the sum
+    -- of their BTC amounts in milli-satoshi that were accepted under the
+    -- quote.
+    num_htlcs BIGINT NOT NULL DEFAULT 0,
+    asset_amount BIGINT NOT NULL DEFAULT 0,
+    amount_msat BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS rfq_quotes_accepted_at_idx
+    ON rfq_quotes (accepted_at);
+
+CREATE INDEX IF NOT EXISTS rfq_quotes_asset_key_idx
+    ON rfq_quotes (asset_key);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS anchor_tx_reorgs;
@@ -0,0 +1,16 @@
+-- anchor_tx_reorgs tracks confirmed anchor transactions of which the block was
+-- re-organized out of the chain. The confirmation of the transaction in
+-- chain_txns is only updated once the transaction is mined again, so the
+-- transfers it anchors aren't considered pending in the meantime. The entry
+-- of a transaction is removed once it is confirmed again.
+CREATE TABLE IF NOT EXISTS anchor_tx_reorgs (
+    txn_id BIGINT PRIMARY KEY REFERENCES chain_txns(txn_id),
+
+    -- The hash and height of the block the transaction was confirmed in
+    -- before it was re-organized out of the chain.
+    orphaned_block_hash BLOB NOT NULL,
+    orphaned_block_height INTEGER NOT NULL,
+
+    -- The time the re-org was detected.
+    detected_at TIMESTAMP NOT NULL
+);
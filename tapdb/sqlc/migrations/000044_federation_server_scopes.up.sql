@@ -0,0 +1,24 @@
+-- federation_server_scopes restricts the federation sync with a universe
+-- server to the universes of a set of assets. The sync with a server that
+-- doesn't have any scope entries isn't restricted.
+CREATE TABLE IF NOT EXISTS federation_server_scopes (
+    id INTEGER PRIMARY KEY,
+
+    servers_id BIGINT NOT NULL REFERENCES universe_servers(id)
+        ON DELETE CASCADE,
+
+    -- The ID of an asset that isn't part of an asset group.
+    asset_id BLOB CHECK(length(asset_id) = 32),
+
+    -- The x-only group key of an asset group.
+    group_key BLOB CHECK(length(group_key) = 32),
+
+    -- Exactly one of the asset ID and group key must be set.
+    CHECK (
+        (asset_id IS NOT NULL AND group_key IS NULL) OR
+        (asset_id IS NULL AND group_key IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS federation_server_scopes_servers_id
+    ON federation_server_scopes (servers_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS universe_transfer_stats_num_transfers;
+DROP TABLE IF EXISTS universe_transfer_stats;
+DROP TABLE IF EXISTS universe_server_daily_syncs;
+DROP TABLE IF EXISTS universe_daily_stats;
@@ -0,0 +1,69 @@
+-- universe_daily_stats holds the pre-aggregated number of new assets and
+-- ingested proofs of the local universe per day.
+CREATE TABLE IF NOT EXISTS universe_daily_stats (
+    -- The Unix timestamp of the start of the UTC day the stats are for.
+    day_start BIGINT PRIMARY KEY,
+
+    -- The number of new issuance proofs, each of which issues a new asset.
+    new_assets BIGINT NOT NULL DEFAULT 0,
+
+    -- The number of new issuance and transfer proofs.
+    new_proofs BIGINT NOT NULL DEFAULT 0
+);
+
+-- universe_server_daily_syncs holds the pre-aggregated sync volume with each
+-- server of the universe federation per day. The stats are keyed by the host
+-- of the server, so they are kept if the server is removed.
+CREATE TABLE IF NOT EXISTS universe_server_daily_syncs (
+    server_host TEXT NOT NULL,
+
+    -- The Unix timestamp of the start of the UTC day the stats are for.
+    day_start BIGINT NOT NULL,
+
+    -- The number of successful syncs with the server.
+    num_syncs BIGINT NOT NULL DEFAULT 0,
+
+    -- The number of new universe leaves synced from the server.
+    num_synced_leaves BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (server_host, day_start)
+);
+
+-- universe_transfer_stats holds the pre-aggregated number of transfer proofs
+-- of each transfer universe.
+CREATE TABLE IF NOT EXISTS universe_transfer_stats (
+    -- The namespace of the transfer universe.
+    namespace VARCHAR NOT NULL PRIMARY KEY,
+
+    asset_id BLOB CHECK(length(asset_id) = 32),
+
+    -- The x-only group key of the universe of an asset group.
+    group_key BLOB CHECK(length(group_key) = 32),
+
+    num_transfers BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS universe_transfer_stats_num_transfers
+    ON universe_transfer_stats (num_transfers);
+
+-- Backfill the stats from the existing universe events.
+INSERT INTO universe_daily_stats (day_start, new_assets, new_proofs)
+SELECT
+    events.event_timestamp - (events.event_timestamp % 86400) AS day_start,
+    SUM(CASE WHEN roots.proof_type = 'issuance' THEN 1 ELSE 0 END),
+    COUNT(*)
+FROM universe_events events
+JOIN universe_roots roots
+    ON events.universe_root_id = roots.id
+WHERE events.event_type = 'NEW_PROOF'
+GROUP BY events.event_timestamp - (events.event_timestamp % 86400);
+
+INSERT INTO universe_transfer_stats (
+    namespace, asset_id, group_key, num_transfers
+)
+SELECT roots.namespace_root, roots.asset_id, roots.group_key, COUNT(*)
+FROM universe_events events
+JOIN universe_roots roots
+    ON events.universe_root_id = roots.id
+WHERE events.event_type = 'NEW_PROOF' AND roots.proof_type = 'transfer'
+GROUP BY roots.namespace_root, roots.asset_id, roots.group_key;
@@ -0,0 +1,13 @@
+-- universe_operator_keys holds the operator keys that are pinned for remote
+-- universe servers. The first operator key a server reports is pinned, after
+-- which all universe roots synced from the server must be signed with it. The
+-- keys are keyed by the host of the server, as a server doesn't need to be
+-- part of the federation to be synced with.
+CREATE TABLE IF NOT EXISTS universe_operator_keys (
+    server_host TEXT PRIMARY KEY,
+
+    -- The compressed operator key of the server.
+    operator_key BLOB NOT NULL CHECK(length(operator_key) = 33),
+
+    pinned_at TIMESTAMP NOT NULL
+);
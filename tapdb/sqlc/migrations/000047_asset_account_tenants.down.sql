@@ -0,0 +1,2 @@
+-- Remove the `tenant` column from the `asset_accounts` table.
+ALTER TABLE asset_accounts DROP COLUMN tenant;
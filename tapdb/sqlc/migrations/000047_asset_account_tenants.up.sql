@@ -0,0 +1,4 @@
+-- Add a column to mark the accounts of tenants. A tenant accesses the daemon
+-- with macaroons of its own root key, which only give access to the assets of
+-- its account.
+ALTER TABLE asset_accounts ADD COLUMN tenant BOOLEAN NOT NULL DEFAULT FALSE;
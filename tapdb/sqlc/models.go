@@ -10,20 +10,23 @@ import (
 )
 
 type Addr struct {
-	ID               int64
-	Version          int16
-	AssetVersion     int16
-	GenesisAssetID   int64
-	GroupKey         []byte
-	ScriptKeyID      int64
-	TaprootKeyID     int64
-	TapscriptSibling []byte
-	TaprootOutputKey []byte
-	Amount           int64
-	AssetType        int16
-	CreationTime     time.Time
-	ManagedFrom      sql.NullTime
-	ProofCourierAddr []byte
+	ID                        int64
+	Version                   int16
+	AssetVersion              int16
+	GenesisAssetID            int64
+	GroupKey                  []byte
+	ScriptKeyID               int64
+	TaprootKeyID              int64
+	TapscriptSibling          []byte
+	TaprootOutputKey          []byte
+	Amount                    int64
+	AssetType                 int16
+	CreationTime              time.Time
+	ManagedFrom               sql.NullTime
+	ProofCourierAddr          []byte
+	FallbackProofCourierAddrs []byte
+	Expiry                    sql.NullTime
+	Reusable                  bool
 }
 
 type AddrEvent struct {
@@ -38,6 +41,13 @@ type AddrEvent struct {
 	AssetID             sql.NullInt64
 }
 
+type AnchorTxReorg struct {
+	TxnID               int64
+	OrphanedBlockHash   []byte
+	OrphanedBlockHeight int32
+	DetectedAt          time.Time
+}
+
 type Asset struct {
 	AssetID                  int64
 	GenesisID                int64
@@ -54,6 +64,14 @@ type Asset struct {
 	Spent                    bool
 }
 
+type AssetAccount struct {
+	ID        int64
+	Name      string
+	KeyFamily int32
+	CreatedAt time.Time
+	Tenant    bool
+}
+
 type AssetBurnTransfer struct {
 	BurnID     int64
 	TransferID int32
@@ -63,6 +81,13 @@ type AssetBurnTransfer struct {
 	Amount     int64
 }
 
+type AssetChannelBackup struct {
+	ID         int64
+	ChanPoint  []byte
+	Backup     []byte
+	RestoredAt time.Time
+}
+
 type AssetGroup struct {
 	GroupID         int64
 	TweakedGroupKey []byte
@@ -78,6 +103,17 @@ type AssetGroupWitness struct {
 	GroupKeyID   int64
 }
 
+type AssetMetaUpdate struct {
+	ID              int64
+	TweakedGroupKey []byte
+	Version         int64
+	MetaType        int16
+	MetaData        []byte
+	SignerKey       []byte
+	Signature       []byte
+	CreatedAt       time.Time
+}
+
 type AssetMintingBatch struct {
 	BatchID           int64
 	BatchState        int16
@@ -146,6 +182,18 @@ type AssetTransferOutput struct {
 	RelativeLockTime         sql.NullInt32
 	ProofDeliveryComplete    sql.NullBool
 	Position                 int32
+	ProofDeliverySkipped     bool
+	ProofCourierMode         int16
+}
+
+type AssetTransferOutputProofCourier struct {
+	ID              int64
+	OutputID        int64
+	Priority        int32
+	CourierAddr     []byte
+	Delivered       bool
+	LastError       string
+	LastAttemptTime sql.NullTime
 }
 
 type AssetWitness struct {
@@ -193,6 +241,13 @@ type FederationProofSyncLog struct {
 	ServersID      int64
 }
 
+type FederationServerScope struct {
+	ID        int64
+	ServersID int64
+	AssetID   []byte
+	GroupKey  []byte
+}
+
 type FederationUniSyncConfig struct {
 	Namespace       string
 	AssetID         []byte
@@ -202,6 +257,14 @@ type FederationUniSyncConfig struct {
 	AllowSyncExport bool
 }
 
+type FieldEncryption struct {
+	ID        int64
+	KeySource string
+	Salt      []byte
+	KeyCheck  []byte
+	CreatedAt time.Time
+}
+
 type GenesisAsset struct {
 	GenAssetID     int64
 	AssetID        []byte
@@ -237,6 +300,17 @@ type InternalKey struct {
 	KeyIndex  int32
 }
 
+type Job struct {
+	ID        int64
+	Kind      string
+	Params    []byte
+	State     int16
+	Progress  int16
+	ErrorMsg  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type KeyGroupInfoView struct {
 	WitnessID       int64
 	GenAssetID      int64
@@ -268,6 +342,13 @@ type ManagedUtxo struct {
 	RootVersion      sql.NullInt16
 }
 
+type MetaSchema struct {
+	ID           int64
+	SchemaID     string
+	Definition   []byte
+	RegisteredAt time.Time
+}
+
 type MssmtNode struct {
 	HashKey   []byte
 	LHashKey  []byte
@@ -315,6 +396,67 @@ type ProofTransferLog struct {
 	TimeUnix         time.Time
 }
 
+type ReplicationChange struct {
+	Seq        int64
+	ChangeType int16
+	Payload    []byte
+	CreatedAt  time.Time
+}
+
+type ReplicationCursor struct {
+	LeaderID string
+	LastSeq  int64
+}
+
+type RfqPeerStat struct {
+	Peer                []byte
+	QuotesAccepted      int64
+	QuotesRejected      int64
+	HtlcsSettled        int64
+	HtlcsFailed         int64
+	RateDeviationPpmSum int64
+	RateSamples         int64
+	UpdatedAt           time.Time
+}
+
+type RfqQuote struct {
+	QuoteID         []byte
+	Peer            []byte
+	IsBuy           bool
+	AcceptedByPeer  bool
+	AssetKey        []byte
+	AssetMaxAmount  int64
+	PaymentMaxMsat  int64
+	RateCoefficient []byte
+	RateScale       int32
+	Expiry          int64
+	AcceptedAt      int64
+	NumHtlcs        int64
+	AssetAmount     int64
+	AmountMsat      int64
+}
+
+type RfqSettlementStat struct {
+	AssetKey    []byte
+	Peer        []byte
+	Day         int64
+	NumHtlcs    int64
+	AssetAmount int64
+	AmountMsat  int64
+}
+
+type RpcJournal struct {
+	ID         int64
+	Method     string
+	Caller     string
+	PeerAddr   string
+	Params     string
+	StatusCode string
+	ErrorMsg   string
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
 type ScriptKey struct {
 	ScriptKeyID      int64
 	InternalKeyID    int64
@@ -323,6 +465,19 @@ type ScriptKey struct {
 	DeclaredKnown    sql.NullBool
 }
 
+type ScriptKeyFreeze struct {
+	ID               int64
+	TweakedScriptKey []byte
+	Reason           string
+	FrozenAt         time.Time
+}
+
+type ScriptKeyReservation struct {
+	TweakedScriptKey []byte
+	Owner            int16
+	ReservedAt       time.Time
+}
+
 type TapscriptEdge struct {
 	EdgeID     int64
 	RootHashID int64
@@ -341,6 +496,12 @@ type TapscriptRoot struct {
 	BranchOnly bool
 }
 
+type UniverseDailyStat struct {
+	DayStart  int64
+	NewAssets int64
+	NewProofs int64
+}
+
 type UniverseEvent struct {
 	EventID        int64
 	EventType      string
@@ -359,6 +520,12 @@ type UniverseLeafe struct {
 	LeafNodeNamespace string
 }
 
+type UniverseOperatorKey struct {
+	ServerHost  string
+	OperatorKey []byte
+	PinnedAt    time.Time
+}
+
 type UniverseRoot struct {
 	ID            int64
 	NamespaceRoot string
@@ -373,6 +540,13 @@ type UniverseServer struct {
 	LastSyncTime time.Time
 }
 
+type UniverseServerDailySync struct {
+	ServerHost      string
+	DayStart        int64
+	NumSyncs        int64
+	NumSyncedLeaves int64
+}
+
 type UniverseStat struct {
 	TotalAssetSyncs  int64
 	TotalAssetProofs int64
@@ -380,3 +554,10 @@ type UniverseStat struct {
 	GroupKey         []byte
 	ProofType        string
 }
+
+type UniverseTransferStat struct {
+	Namespace    string
+	AssetID      []byte
+	GroupKey     []byte
+	NumTransfers int64
+}
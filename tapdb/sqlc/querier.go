@@ -11,6 +11,7 @@ import (
 )
 
 type Querier interface {
+	AddRfqQuoteVolume(ctx context.Context, arg AddRfqQuoteVolumeParams) error
 	AllAssets(ctx context.Context) ([]Asset, error)
 	AllInternalKeys(ctx context.Context) ([]InternalKey, error)
 	AllMintingBatches(ctx context.Context) ([]AllMintingBatchesRow, error)
@@ -23,22 +24,30 @@ type Querier interface {
 	AssetsInBatch(ctx context.Context, rawKey []byte) ([]AssetsInBatchRow, error)
 	BindMintingBatchWithTapSibling(ctx context.Context, arg BindMintingBatchWithTapSiblingParams) error
 	BindMintingBatchWithTx(ctx context.Context, arg BindMintingBatchWithTxParams) error
+	ClearDeliveredProofSuffixes(ctx context.Context, deliveredBefore time.Time) (int64, error)
 	ConfirmChainAnchorTx(ctx context.Context, arg ConfirmChainAnchorTxParams) error
 	ConfirmChainTx(ctx context.Context, arg ConfirmChainTxParams) error
 	DeleteAllNodes(ctx context.Context, namespace string) (int64, error)
+	DeleteAnchorTxReorg(ctx context.Context, txid []byte) error
 	DeleteAssetWitnesses(ctx context.Context, assetID int64) error
 	DeleteExpiredUTXOLeases(ctx context.Context, now sql.NullTime) error
 	DeleteFederationProofSyncLog(ctx context.Context, arg DeleteFederationProofSyncLogParams) error
+	DeleteFederationServerScopes(ctx context.Context, serversID int64) error
 	DeleteManagedUTXO(ctx context.Context, outpoint []byte) error
 	DeleteMultiverseLeaf(ctx context.Context, arg DeleteMultiverseLeafParams) error
 	DeleteNode(ctx context.Context, arg DeleteNodeParams) (int64, error)
+	DeleteProofTransferAttemptsBefore(ctx context.Context, attemptedBefore time.Time) (int64, error)
 	DeleteRoot(ctx context.Context, namespace string) (int64, error)
+	DeleteScriptKeyFreeze(ctx context.Context, tweakedScriptKey []byte) (int64, error)
+	DeleteSpentAssetProofs(ctx context.Context, spentBefore time.Time) (int64, error)
 	DeleteTapscriptTreeEdges(ctx context.Context, rootHash []byte) error
 	DeleteTapscriptTreeNodes(ctx context.Context) error
 	DeleteTapscriptTreeRoot(ctx context.Context, rootHash []byte) error
 	DeleteUTXOLease(ctx context.Context, outpoint []byte) error
 	DeleteUniverseEvents(ctx context.Context, namespaceRoot string) error
+	DeleteUniverseEventsBefore(ctx context.Context, eventTimestampBefore int64) (int64, error)
 	DeleteUniverseLeaves(ctx context.Context, namespace string) error
+	DeleteUniverseOperatorKey(ctx context.Context, serverHost string) error
 	DeleteUniverseRoot(ctx context.Context, namespaceRoot string) error
 	DeleteUniverseServer(ctx context.Context, arg DeleteUniverseServerParams) error
 	FetchAddrByTaprootOutputKey(ctx context.Context, taprootOutputKey []byte) (FetchAddrByTaprootOutputKeyRow, error)
@@ -46,6 +55,8 @@ type Querier interface {
 	FetchAddrEventByAddrKeyAndOutpoint(ctx context.Context, arg FetchAddrEventByAddrKeyAndOutpointParams) (FetchAddrEventByAddrKeyAndOutpointRow, error)
 	FetchAddrs(ctx context.Context, arg FetchAddrsParams) ([]FetchAddrsRow, error)
 	FetchAllNodes(ctx context.Context) ([]MssmtNode, error)
+	FetchAssetAccount(ctx context.Context, name string) (AssetAccount, error)
+	FetchAssetChannelBackup(ctx context.Context, chanPoint []byte) (AssetChannelBackup, error)
 	FetchAssetID(ctx context.Context, arg FetchAssetIDParams) ([]int64, error)
 	FetchAssetMeta(ctx context.Context, metaID int64) (FetchAssetMetaRow, error)
 	FetchAssetMetaByHash(ctx context.Context, metaDataHash []byte) (FetchAssetMetaByHashRow, error)
@@ -64,6 +75,7 @@ type Querier interface {
 	FetchChainTx(ctx context.Context, txid []byte) (ChainTxn, error)
 	FetchChildren(ctx context.Context, arg FetchChildrenParams) ([]FetchChildrenRow, error)
 	FetchChildrenSelfJoin(ctx context.Context, arg FetchChildrenSelfJoinParams) ([]FetchChildrenSelfJoinRow, error)
+	FetchFieldEncryption(ctx context.Context) (FieldEncryption, error)
 	FetchGenesisByAssetID(ctx context.Context, assetID []byte) (GenesisInfoView, error)
 	FetchGenesisByID(ctx context.Context, genAssetID int64) (FetchGenesisByIDRow, error)
 	FetchGenesisID(ctx context.Context, arg FetchGenesisIDParams) (int64, error)
@@ -73,28 +85,41 @@ type Querier interface {
 	FetchGroupByGroupKey(ctx context.Context, groupKey []byte) (FetchGroupByGroupKeyRow, error)
 	FetchGroupedAssets(ctx context.Context) ([]FetchGroupedAssetsRow, error)
 	FetchInternalKeyLocator(ctx context.Context, rawKey []byte) (FetchInternalKeyLocatorRow, error)
+	FetchJob(ctx context.Context, id int64) (Job, error)
 	FetchManagedUTXO(ctx context.Context, arg FetchManagedUTXOParams) (FetchManagedUTXORow, error)
 	FetchManagedUTXOs(ctx context.Context) ([]FetchManagedUTXOsRow, error)
+	FetchMetaSchema(ctx context.Context, schemaID string) (MetaSchema, error)
 	FetchMintingBatch(ctx context.Context, rawKey []byte) (FetchMintingBatchRow, error)
 	FetchMintingBatchesByInverseState(ctx context.Context, batchState int16) ([]FetchMintingBatchesByInverseStateRow, error)
 	FetchMultiverseRoot(ctx context.Context, namespaceRoot string) (FetchMultiverseRootRow, error)
+	FetchPeerStats(ctx context.Context, peer []byte) (RfqPeerStat, error)
+	FetchReplicationChanges(ctx context.Context, arg FetchReplicationChangesParams) ([]ReplicationChange, error)
+	FetchReplicationCursor(ctx context.Context, leaderID string) (int64, error)
 	FetchRootNode(ctx context.Context, namespace string) (MssmtNode, error)
 	FetchScriptKeyByTweakedKey(ctx context.Context, tweakedScriptKey []byte) (FetchScriptKeyByTweakedKeyRow, error)
+	FetchScriptKeyFreeze(ctx context.Context, tweakedScriptKey []byte) (ScriptKeyFreeze, error)
 	FetchScriptKeyIDByTweakedKey(ctx context.Context, tweakedScriptKey []byte) (int64, error)
+	FetchScriptKeyReservation(ctx context.Context, tweakedScriptKey []byte) (ScriptKeyReservation, error)
 	FetchSeedlingByID(ctx context.Context, seedlingID int64) (AssetSeedling, error)
 	FetchSeedlingID(ctx context.Context, arg FetchSeedlingIDParams) (int64, error)
 	FetchSeedlingsForBatch(ctx context.Context, rawKey []byte) ([]FetchSeedlingsForBatchRow, error)
 	// Sort the nodes by node_index here instead of returning the indices.
 	FetchTapscriptTree(ctx context.Context, rootHash []byte) ([]FetchTapscriptTreeRow, error)
 	FetchTransferInputs(ctx context.Context, transferID int64) ([]FetchTransferInputsRow, error)
+	FetchTransferOutputProofCouriers(ctx context.Context, outputID int64) ([]AssetTransferOutputProofCourier, error)
 	FetchTransferOutputs(ctx context.Context, transferID int64) ([]FetchTransferOutputsRow, error)
 	FetchUniverseKeys(ctx context.Context, arg FetchUniverseKeysParams) ([]FetchUniverseKeysRow, error)
+	FetchUniverseLeafKey(ctx context.Context, arg FetchUniverseLeafKeyParams) (FetchUniverseLeafKeyRow, error)
+	FetchUniverseOperatorKey(ctx context.Context, serverHost string) ([]byte, error)
 	FetchUniverseRoot(ctx context.Context, namespace string) (FetchUniverseRootRow, error)
 	GenesisAssets(ctx context.Context) ([]GenesisAsset, error)
 	GenesisPoints(ctx context.Context) ([]GenesisPoint, error)
 	GetRootKey(ctx context.Context, id []byte) (Macaroon, error)
 	HasAssetProof(ctx context.Context, tweakedScriptKey []byte) (bool, error)
 	InsertAddr(ctx context.Context, arg InsertAddrParams) (int64, error)
+	InsertAnchorTxReorg(ctx context.Context, arg InsertAnchorTxReorgParams) error
+	InsertAssetAccount(ctx context.Context, arg InsertAssetAccountParams) (int64, error)
+	InsertAssetMetaUpdate(ctx context.Context, arg InsertAssetMetaUpdateParams) error
 	InsertAssetSeedling(ctx context.Context, arg InsertAssetSeedlingParams) error
 	InsertAssetSeedlingIntoBatch(ctx context.Context, arg InsertAssetSeedlingIntoBatchParams) error
 	InsertAssetTransfer(ctx context.Context, arg InsertAssetTransferParams) (int64, error)
@@ -103,21 +128,35 @@ type Querier interface {
 	InsertBranch(ctx context.Context, arg InsertBranchParams) error
 	InsertBurn(ctx context.Context, arg InsertBurnParams) (int64, error)
 	InsertCompactedLeaf(ctx context.Context, arg InsertCompactedLeafParams) error
+	InsertFederationServerScope(ctx context.Context, arg InsertFederationServerScopeParams) error
+	InsertFieldEncryption(ctx context.Context, arg InsertFieldEncryptionParams) error
+	InsertJob(ctx context.Context, arg InsertJobParams) (int64, error)
 	InsertLeaf(ctx context.Context, arg InsertLeafParams) error
+	InsertMetaSchema(ctx context.Context, arg InsertMetaSchemaParams) (MetaSchema, error)
 	InsertNewProofEvent(ctx context.Context, arg InsertNewProofEventParams) error
 	InsertNewSyncEvent(ctx context.Context, arg InsertNewSyncEventParams) error
 	InsertPassiveAsset(ctx context.Context, arg InsertPassiveAssetParams) error
+	InsertReplicationChange(ctx context.Context, arg InsertReplicationChangeParams) (int64, error)
+	InsertRfqQuote(ctx context.Context, arg InsertRfqQuoteParams) error
 	InsertRootKey(ctx context.Context, arg InsertRootKeyParams) error
+	InsertRpcJournalEntry(ctx context.Context, arg InsertRpcJournalEntryParams) error
+	InsertScriptKeyReservation(ctx context.Context, arg InsertScriptKeyReservationParams) error
+	InsertTransferOutputProofCourier(ctx context.Context, arg InsertTransferOutputProofCourierParams) error
+	InsertUniverseOperatorKey(ctx context.Context, arg InsertUniverseOperatorKeyParams) error
 	InsertUniverseServer(ctx context.Context, arg InsertUniverseServerParams) error
 	LogProofTransferAttempt(ctx context.Context, arg LogProofTransferAttemptParams) error
 	LogServerSync(ctx context.Context, arg LogServerSyncParams) error
 	NewMintingBatch(ctx context.Context, arg NewMintingBatchParams) error
+	QueryAnchorTxReorgs(ctx context.Context) ([]QueryAnchorTxReorgsRow, error)
+	QueryAssetAccounts(ctx context.Context) ([]AssetAccount, error)
 	// We use a LEFT JOIN here as not every asset has a group key, so this'll
 	// generate rows that have NULL values for the group key fields if an asset
 	// doesn't have a group key. See the comment in fetchAssetSprouts for a work
 	// around that needs to be used with this query until a sqlc bug is fixed.
 	QueryAssetBalancesByAsset(ctx context.Context, arg QueryAssetBalancesByAssetParams) ([]QueryAssetBalancesByAssetRow, error)
 	QueryAssetBalancesByGroup(ctx context.Context, arg QueryAssetBalancesByGroupParams) ([]QueryAssetBalancesByGroupRow, error)
+	QueryAssetMetaUpdates(ctx context.Context, arg QueryAssetMetaUpdatesParams) ([]QueryAssetMetaUpdatesRow, error)
+	QueryAssetProofBlobs(ctx context.Context, arg QueryAssetProofBlobsParams) ([]QueryAssetProofBlobsRow, error)
 	// BETWEEN is inclusive for both start and end values.
 	QueryAssetStatsPerDayPostgres(ctx context.Context, arg QueryAssetStatsPerDayPostgresParams) ([]QueryAssetStatsPerDayPostgresRow, error)
 	QueryAssetStatsPerDaySqlite(ctx context.Context, arg QueryAssetStatsPerDaySqliteParams) ([]QueryAssetStatsPerDaySqliteRow, error)
@@ -132,32 +171,58 @@ type Querier interface {
 	// specified.
 	QueryAssets(ctx context.Context, arg QueryAssetsParams) ([]QueryAssetsRow, error)
 	QueryBurns(ctx context.Context, arg QueryBurnsParams) ([]QueryBurnsRow, error)
+	QueryDeliveredProofSuffixStats(ctx context.Context, deliveredBefore time.Time) (QueryDeliveredProofSuffixStatsRow, error)
 	QueryEventIDs(ctx context.Context, arg QueryEventIDsParams) ([]QueryEventIDsRow, error)
 	QueryFederationGlobalSyncConfigs(ctx context.Context) ([]FederationGlobalSyncConfig, error)
 	// Join on mssmt_nodes to get leaf related fields.
 	// Join on genesis_info_view to get leaf related fields.
 	QueryFederationProofSyncLog(ctx context.Context, arg QueryFederationProofSyncLogParams) ([]QueryFederationProofSyncLogRow, error)
+	QueryFederationServerScopes(ctx context.Context) ([]QueryFederationServerScopesRow, error)
 	QueryFederationUniSyncConfigs(ctx context.Context) ([]FederationUniSyncConfig, error)
+	QueryJobs(ctx context.Context, maxState sql.NullInt16) ([]Job, error)
+	QueryMacaroonRootKeys(ctx context.Context) ([]Macaroon, error)
+	QueryMetaSchemas(ctx context.Context) ([]MetaSchema, error)
 	QueryMultiverseLeaves(ctx context.Context, arg QueryMultiverseLeavesParams) ([]QueryMultiverseLeavesRow, error)
+	QueryPassiveAssetProofs(ctx context.Context, arg QueryPassiveAssetProofsParams) ([]QueryPassiveAssetProofsRow, error)
 	QueryPassiveAssets(ctx context.Context, transferID int64) ([]QueryPassiveAssetsRow, error)
+	QueryPeerStats(ctx context.Context) ([]RfqPeerStat, error)
 	QueryProofTransferAttempts(ctx context.Context, arg QueryProofTransferAttemptsParams) ([]time.Time, error)
+	QueryRfqQuoteVolumes(ctx context.Context, arg QueryRfqQuoteVolumesParams) ([]QueryRfqQuoteVolumesRow, error)
+	QueryRfqQuotes(ctx context.Context, arg QueryRfqQuotesParams) ([]RfqQuote, error)
+	QueryRpcJournalEntries(ctx context.Context, arg QueryRpcJournalEntriesParams) ([]RpcJournal, error)
+	QueryScriptKeyFreezes(ctx context.Context) ([]ScriptKeyFreeze, error)
+	QuerySettlementStats(ctx context.Context, arg QuerySettlementStatsParams) ([]RfqSettlementStat, error)
+	QuerySpentAssetProofStats(ctx context.Context, spentBefore time.Time) (QuerySpentAssetProofStatsRow, error)
+	QueryTopUniverseTransferStats(ctx context.Context, numLimit int32) ([]UniverseTransferStat, error)
+	QueryTransferOutputProofSuffixes(ctx context.Context, arg QueryTransferOutputProofSuffixesParams) ([]QueryTransferOutputProofSuffixesRow, error)
 	// TODO(roasbeef): use the universe id instead for the grouping? so namespace
 	// root, simplifies queries
 	QueryUniverseAssetStats(ctx context.Context, arg QueryUniverseAssetStatsParams) ([]QueryUniverseAssetStatsRow, error)
+	QueryUniverseDailyStats(ctx context.Context, arg QueryUniverseDailyStatsParams) ([]UniverseDailyStat, error)
 	QueryUniverseLeaves(ctx context.Context, arg QueryUniverseLeavesParams) ([]QueryUniverseLeavesRow, error)
+	QueryUniverseServerDailySyncs(ctx context.Context, arg QueryUniverseServerDailySyncsParams) ([]UniverseServerDailySync, error)
 	QueryUniverseServers(ctx context.Context, arg QueryUniverseServersParams) ([]UniverseServer, error)
 	QueryUniverseStats(ctx context.Context) (QueryUniverseStatsRow, error)
 	ReAnchorPassiveAssets(ctx context.Context, arg ReAnchorPassiveAssetsParams) error
+	ReplaceChainAnchorTx(ctx context.Context, arg ReplaceChainAnchorTxParams) error
 	SetAddrManaged(ctx context.Context, arg SetAddrManagedParams) error
 	SetAssetSpent(ctx context.Context, arg SetAssetSpentParams) (int64, error)
+	SetTransferOutputProofCourierStatus(ctx context.Context, arg SetTransferOutputProofCourierStatusParams) error
 	SetTransferOutputProofDeliveryStatus(ctx context.Context, arg SetTransferOutputProofDeliveryStatusParams) error
 	UniverseLeaves(ctx context.Context) ([]UniverseLeafe, error)
 	UniverseRoots(ctx context.Context, arg UniverseRootsParams) ([]UniverseRootsRow, error)
+	UpdateAssetProofBlob(ctx context.Context, arg UpdateAssetProofBlobParams) error
 	UpdateBatchGenesisTx(ctx context.Context, arg UpdateBatchGenesisTxParams) error
+	UpdateJob(ctx context.Context, arg UpdateJobParams) error
+	UpdateMacaroonRootKey(ctx context.Context, arg UpdateMacaroonRootKeyParams) error
+	UpdateManagedUTXOOutpoint(ctx context.Context, arg UpdateManagedUTXOOutpointParams) error
 	UpdateMintingBatchState(ctx context.Context, arg UpdateMintingBatchStateParams) error
+	UpdatePassiveAssetProof(ctx context.Context, arg UpdatePassiveAssetProofParams) error
+	UpdateTransferOutputProofSuffix(ctx context.Context, arg UpdateTransferOutputProofSuffixParams) error
 	UpdateUTXOLease(ctx context.Context, arg UpdateUTXOLeaseParams) error
 	UpsertAddrEvent(ctx context.Context, arg UpsertAddrEventParams) (int64, error)
 	UpsertAsset(ctx context.Context, arg UpsertAssetParams) (int64, error)
+	UpsertAssetChannelBackup(ctx context.Context, arg UpsertAssetChannelBackupParams) error
 	UpsertAssetGroupKey(ctx context.Context, arg UpsertAssetGroupKeyParams) (int64, error)
 	UpsertAssetGroupWitness(ctx context.Context, arg UpsertAssetGroupWitnessParams) (int64, error)
 	UpsertAssetMeta(ctx context.Context, arg UpsertAssetMetaParams) (int64, error)
@@ -173,13 +238,20 @@ type Querier interface {
 	UpsertManagedUTXO(ctx context.Context, arg UpsertManagedUTXOParams) (int64, error)
 	UpsertMultiverseLeaf(ctx context.Context, arg UpsertMultiverseLeafParams) (int64, error)
 	UpsertMultiverseRoot(ctx context.Context, arg UpsertMultiverseRootParams) (int64, error)
+	UpsertPeerStats(ctx context.Context, arg UpsertPeerStatsParams) error
+	UpsertReplicationCursor(ctx context.Context, arg UpsertReplicationCursorParams) error
 	UpsertRootNode(ctx context.Context, arg UpsertRootNodeParams) error
 	UpsertScriptKey(ctx context.Context, arg UpsertScriptKeyParams) (int64, error)
+	UpsertScriptKeyFreeze(ctx context.Context, arg UpsertScriptKeyFreezeParams) (ScriptKeyFreeze, error)
+	UpsertSettlementStats(ctx context.Context, arg UpsertSettlementStatsParams) error
 	UpsertTapscriptTreeEdge(ctx context.Context, arg UpsertTapscriptTreeEdgeParams) (int64, error)
 	UpsertTapscriptTreeNode(ctx context.Context, rawNode []byte) (int64, error)
 	UpsertTapscriptTreeRootHash(ctx context.Context, arg UpsertTapscriptTreeRootHashParams) (int64, error)
+	UpsertUniverseDailyStats(ctx context.Context, arg UpsertUniverseDailyStatsParams) error
 	UpsertUniverseLeaf(ctx context.Context, arg UpsertUniverseLeafParams) error
 	UpsertUniverseRoot(ctx context.Context, arg UpsertUniverseRootParams) (int64, error)
+	UpsertUniverseServerDailySyncs(ctx context.Context, arg UpsertUniverseServerDailySyncsParams) error
+	UpsertUniverseTransferStats(ctx context.Context, arg UpsertUniverseTransferStatsParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,16 @@
+-- name: InsertAssetAccount :one
+INSERT INTO asset_accounts (
+    name, key_family, created_at, tenant
+) VALUES (
+    @name, @key_family, @created_at, @tenant
+) RETURNING id;
+
+-- name: FetchAssetAccount :one
+SELECT *
+FROM asset_accounts
+WHERE name = @name;
+
+-- name: QueryAssetAccounts :many
+SELECT *
+FROM asset_accounts
+ORDER BY key_family;
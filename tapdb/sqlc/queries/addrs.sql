@@ -2,14 +2,17 @@
 INSERT INTO addrs (
     version, asset_version, genesis_asset_id, group_key, script_key_id,
     taproot_key_id, tapscript_sibling, taproot_output_key, amount, asset_type,
-    creation_time, proof_courier_addr
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id;
+    creation_time, proof_courier_addr, fallback_proof_courier_addrs, expiry,
+    reusable
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) RETURNING id;
 
 -- name: FetchAddrs :many
 SELECT 
     version, asset_version, genesis_asset_id, group_key, tapscript_sibling,
     taproot_output_key, amount, asset_type, creation_time, managed_from,
-    proof_courier_addr,
+    proof_courier_addr, fallback_proof_courier_addrs, expiry, reusable,
     script_keys.tweaked_script_key,
     script_keys.tweak AS script_key_tweak,
     script_keys.declared_known AS script_key_declared_known,
@@ -37,7 +40,7 @@ LIMIT @num_limit OFFSET @num_offset;
 SELECT
     version, asset_version, genesis_asset_id, group_key, tapscript_sibling,
     taproot_output_key, amount, asset_type, creation_time, managed_from,
-    proof_courier_addr,
+    proof_courier_addr, fallback_proof_courier_addrs, expiry, reusable,
     script_keys.tweaked_script_key,
     script_keys.tweak AS script_key_tweak,
     script_keys.declared_known AS script_key_declared_known,
@@ -144,3 +147,15 @@ WHERE addr_events.status >= @status_from
   AND COALESCE(@addr_taproot_key, addrs.taproot_output_key) = addrs.taproot_output_key
   AND addr_events.creation_time >= @created_after
 ORDER by addr_events.creation_time;
+
+-- name: InsertScriptKeyReservation :exec
+INSERT INTO script_key_reservations (
+    tweaked_script_key, owner, reserved_at
+) VALUES (
+    @tweaked_script_key, @owner, @reserved_at
+) ON CONFLICT (tweaked_script_key) DO NOTHING;
+
+-- name: FetchScriptKeyReservation :one
+SELECT tweaked_script_key, owner, reserved_at
+FROM script_key_reservations
+WHERE tweaked_script_key = @tweaked_script_key;
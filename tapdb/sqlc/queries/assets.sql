@@ -334,9 +334,20 @@ JOIN managed_utxos utxos
        END
 JOIN script_keys
     ON assets.script_key_id = script_keys.script_key_id
+JOIN internal_keys
+    ON script_keys.internal_key_id = internal_keys.key_id
 WHERE spent = FALSE AND 
         (script_keys.tweaked_script_key != sqlc.narg('exclude_key') OR
-                sqlc.narg('exclude_key') IS NULL)
+                sqlc.narg('exclude_key') IS NULL) AND
+        (internal_keys.key_family = sqlc.narg('script_key_family') OR
+                sqlc.narg('script_key_family') IS NULL) AND
+        CASE
+            WHEN sqlc.narg('exclude_account_keys') = true THEN
+                internal_keys.key_family NOT IN (
+                    SELECT key_family FROM asset_accounts
+                )
+            ELSE TRUE
+        END
 GROUP BY assets.genesis_id, genesis_info_view.asset_id,
          genesis_info_view.asset_tag, genesis_info_view.meta_hash,
          genesis_info_view.asset_type, genesis_info_view.output_index,
@@ -363,9 +374,20 @@ JOIN managed_utxos utxos
        END
 JOIN script_keys
     ON assets.script_key_id = script_keys.script_key_id
+JOIN internal_keys
+    ON script_keys.internal_key_id = internal_keys.key_id
 WHERE spent = FALSE AND 
         (script_keys.tweaked_script_key != sqlc.narg('exclude_key') OR
-                sqlc.narg('exclude_key') IS NULL)
+                sqlc.narg('exclude_key') IS NULL) AND
+        (internal_keys.key_family = sqlc.narg('script_key_family') OR
+                sqlc.narg('script_key_family') IS NULL) AND
+        CASE
+            WHEN sqlc.narg('exclude_account_keys') = true THEN
+                internal_keys.key_family NOT IN (
+                    SELECT key_family FROM asset_accounts
+                )
+            ELSE TRUE
+        END
 GROUP BY key_group_info_view.tweaked_group_key;
 
 -- name: FetchGroupedAssets :many
@@ -500,8 +522,77 @@ WHERE (
         WHEN cast(@bip86_script_keys_only as bool) = TRUE
         THEN 0 
         ELSE COALESCE(length(script_keys.tweak), 0)
-    END)
-);
+    END) AND
+    (internal_keys.key_family = sqlc.narg('script_key_family') OR
+      sqlc.narg('script_key_family') IS NULL) AND
+    CASE
+        WHEN sqlc.narg('exclude_account_keys') = true THEN
+            internal_keys.key_family NOT IN (
+                SELECT key_family FROM asset_accounts
+            )
+        ELSE TRUE
+    END
+) AND (
+    COALESCE(txns.block_height, 0) <= COALESCE(sqlc.narg('max_anchor_height'), txns.block_height, 0)
+) AND (
+    -- If a cursor is given, we only return the assets that come after it in
+    -- the requested sort order. The primary key of the asset is used as the
+    -- tie breaker between assets with the same sort value.
+    sqlc.narg('cursor_primary_key') IS NULL OR (
+        cast(@sort_descending AS BOOLEAN) = FALSE AND
+        CASE cast(@sort_by AS INTEGER)
+            WHEN 1 THEN
+                assets.amount > sqlc.narg('cursor_amount') OR
+                (assets.amount = sqlc.narg('cursor_amount') AND
+                 assets.asset_id > sqlc.narg('cursor_primary_key'))
+            WHEN 2 THEN
+                COALESCE(txns.block_height, 0) > sqlc.narg('cursor_anchor_height') OR
+                (COALESCE(txns.block_height, 0) = sqlc.narg('cursor_anchor_height') AND
+                 assets.asset_id > sqlc.narg('cursor_primary_key'))
+            WHEN 3 THEN
+                genesis_info_view.asset_id > sqlc.narg('cursor_asset_id') OR
+                (genesis_info_view.asset_id = sqlc.narg('cursor_asset_id') AND
+                 assets.asset_id > sqlc.narg('cursor_primary_key'))
+            ELSE assets.asset_id > sqlc.narg('cursor_primary_key')
+        END
+    ) OR (
+        cast(@sort_descending AS BOOLEAN) = TRUE AND
+        CASE cast(@sort_by AS INTEGER)
+            WHEN 1 THEN
+                assets.amount < sqlc.narg('cursor_amount') OR
+                (assets.amount = sqlc.narg('cursor_amount') AND
+                 assets.asset_id < sqlc.narg('cursor_primary_key'))
+            WHEN 2 THEN
+                COALESCE(txns.block_height, 0) < sqlc.narg('cursor_anchor_height') OR
+                (COALESCE(txns.block_height, 0) = sqlc.narg('cursor_anchor_height') AND
+                 assets.asset_id < sqlc.narg('cursor_primary_key'))
+            WHEN 3 THEN
+                genesis_info_view.asset_id < sqlc.narg('cursor_asset_id') OR
+                (genesis_info_view.asset_id = sqlc.narg('cursor_asset_id') AND
+                 assets.asset_id < sqlc.narg('cursor_primary_key'))
+            ELSE assets.asset_id < sqlc.narg('cursor_primary_key')
+        END
+    )
+)
+ORDER BY
+    CASE WHEN cast(@sort_by AS INTEGER) = 1 AND cast(@sort_descending AS BOOLEAN) = FALSE THEN
+             assets.amount END ASC,
+    CASE WHEN cast(@sort_by AS INTEGER) = 1 AND cast(@sort_descending AS BOOLEAN) = TRUE THEN
+             assets.amount END DESC,
+    CASE WHEN cast(@sort_by AS INTEGER) = 2 AND cast(@sort_descending AS BOOLEAN) = FALSE THEN
+             COALESCE(txns.block_height, 0) END ASC,
+    CASE WHEN cast(@sort_by AS INTEGER) = 2 AND cast(@sort_descending AS BOOLEAN) = TRUE THEN
+             COALESCE(txns.block_height, 0) END DESC,
+    CASE WHEN cast(@sort_by AS INTEGER) = 3 AND cast(@sort_descending AS BOOLEAN) = FALSE THEN
+             genesis_info_view.asset_id END ASC,
+    CASE WHEN cast(@sort_by AS INTEGER) = 3 AND cast(@sort_descending AS BOOLEAN) = TRUE THEN
+             genesis_info_view.asset_id END DESC,
+    CASE WHEN cast(@sort_descending AS BOOLEAN) = FALSE THEN
+             assets.asset_id END ASC,
+    CASE WHEN cast(@sort_descending AS BOOLEAN) = TRUE THEN
+             assets.asset_id END DESC
+-- Without a limit, all matching assets are returned.
+LIMIT COALESCE(sqlc.narg('num_limit'), 9223372036854775807);
 
 -- name: AllAssets :many
 SELECT * 
@@ -846,6 +937,16 @@ UPDATE chain_txns
 SET block_height = $2, block_hash = $3, tx_index = $4
 WHERE txid = $1;
 
+-- name: ReplaceChainAnchorTx :exec
+UPDATE chain_txns
+SET txid = @new_txid, raw_tx = @raw_tx
+WHERE txid = @old_txid;
+
+-- name: UpdateManagedUTXOOutpoint :exec
+UPDATE managed_utxos
+SET outpoint = @new_outpoint, amt_sats = @amt_sats
+WHERE outpoint = @old_outpoint;
+
 -- name: UpsertScriptKey :one
 INSERT INTO script_keys (
     internal_key_id, tweaked_script_key, tweak, declared_known
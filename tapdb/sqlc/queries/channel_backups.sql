@@ -0,0 +1,14 @@
+-- name: UpsertAssetChannelBackup :exec
+INSERT INTO asset_channel_backups (
+    chan_point, backup, restored_at
+) VALUES (
+    @chan_point, @backup, @restored_at
+) ON CONFLICT (chan_point)
+    -- A newer backup of the same channel replaces the older one.
+    DO UPDATE SET backup = EXCLUDED.backup,
+        restored_at = EXCLUDED.restored_at;
+
+-- name: FetchAssetChannelBackup :one
+SELECT *
+FROM asset_channel_backups
+WHERE chan_point = @chan_point;
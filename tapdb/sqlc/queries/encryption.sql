@@ -0,0 +1,58 @@
+-- name: FetchFieldEncryption :one
+SELECT *
+FROM field_encryption
+WHERE id = 1;
+
+-- name: InsertFieldEncryption :exec
+INSERT INTO field_encryption (
+    id, key_source, salt, key_check, created_at
+) VALUES (
+    1, @key_source, @salt, @key_check, @created_at
+);
+
+-- name: QueryAssetProofBlobs :many
+SELECT proof_id, proof_file
+FROM asset_proofs
+WHERE proof_id > @after_id
+ORDER BY proof_id
+LIMIT @num_limit;
+
+-- name: UpdateAssetProofBlob :exec
+UPDATE asset_proofs
+SET proof_file = @proof_file
+WHERE proof_id = @proof_id;
+
+-- name: QueryTransferOutputProofSuffixes :many
+SELECT output_id, proof_suffix
+FROM asset_transfer_outputs
+WHERE output_id > @after_id
+    AND proof_suffix IS NOT NULL
+ORDER BY output_id
+LIMIT @num_limit;
+
+-- name: UpdateTransferOutputProofSuffix :exec
+UPDATE asset_transfer_outputs
+SET proof_suffix = @proof_suffix
+WHERE output_id = @output_id;
+
+-- name: QueryPassiveAssetProofs :many
+SELECT passive_id, new_proof
+FROM passive_assets
+WHERE passive_id > @after_id
+    AND new_proof IS NOT NULL
+ORDER BY passive_id
+LIMIT @num_limit;
+
+-- name: UpdatePassiveAssetProof :exec
+UPDATE passive_assets
+SET new_proof = @new_proof
+WHERE passive_id = @passive_id;
+
+-- name: QueryMacaroonRootKeys :many
+SELECT *
+FROM macaroons;
+
+-- name: UpdateMacaroonRootKey :exec
+UPDATE macaroons
+SET root_key = @root_key
+WHERE id = @id;
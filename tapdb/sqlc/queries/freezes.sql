@@ -0,0 +1,24 @@
+-- name: UpsertScriptKeyFreeze :one
+INSERT INTO script_key_freezes (
+    tweaked_script_key, reason, frozen_at
+) VALUES (
+    @tweaked_script_key, @reason, @frozen_at
+) ON CONFLICT (tweaked_script_key)
+    -- Freezing a frozen script key again only updates the reason, the
+    -- original freeze time is kept.
+    DO UPDATE SET reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: DeleteScriptKeyFreeze :execrows
+DELETE FROM script_key_freezes
+WHERE tweaked_script_key = @tweaked_script_key;
+
+-- name: FetchScriptKeyFreeze :one
+SELECT *
+FROM script_key_freezes
+WHERE tweaked_script_key = @tweaked_script_key;
+
+-- name: QueryScriptKeyFreezes :many
+SELECT *
+FROM script_key_freezes
+ORDER BY frozen_at, id;
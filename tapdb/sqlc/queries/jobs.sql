@@ -0,0 +1,24 @@
+-- name: InsertJob :one
+INSERT INTO jobs (
+    kind, params, state, progress, error_msg, created_at, updated_at
+) VALUES (
+    @kind, @params, @state, @progress, @error_msg, @created_at, @updated_at
+)
+RETURNING id;
+
+-- name: UpdateJob :exec
+UPDATE jobs
+SET state = @state, progress = @progress, error_msg = @error_msg,
+    updated_at = @updated_at
+WHERE id = @id;
+
+-- name: FetchJob :one
+SELECT *
+FROM jobs
+WHERE id = @id;
+
+-- name: QueryJobs :many
+SELECT *
+FROM jobs
+WHERE (state <= sqlc.narg('max_state') OR sqlc.narg('max_state') IS NULL)
+ORDER BY id;
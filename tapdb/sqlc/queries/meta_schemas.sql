@@ -0,0 +1,17 @@
+-- name: InsertMetaSchema :one
+INSERT INTO meta_schemas (
+    schema_id, definition, registered_at
+) VALUES (
+    @schema_id, @definition, @registered_at
+)
+RETURNING *;
+
+-- name: FetchMetaSchema :one
+SELECT *
+FROM meta_schemas
+WHERE schema_id = @schema_id;
+
+-- name: QueryMetaSchemas :many
+SELECT *
+FROM meta_schemas
+ORDER BY registered_at, id;
@@ -0,0 +1,26 @@
+-- name: InsertAnchorTxReorg :exec
+INSERT INTO anchor_tx_reorgs (
+    txn_id, orphaned_block_hash, orphaned_block_height, detected_at
+)
+SELECT txn_id, block_hash, block_height, @detected_at
+FROM chain_txns
+WHERE txid = @txid AND
+      block_hash IS NOT NULL AND
+      block_height IS NOT NULL
+ON CONFLICT (txn_id) DO NOTHING;
+
+-- name: DeleteAnchorTxReorg :exec
+DELETE FROM anchor_tx_reorgs
+WHERE txn_id IN (
+    SELECT txn_id
+    FROM chain_txns
+    WHERE txid = @txid
+);
+
+-- name: QueryAnchorTxReorgs :many
+SELECT txns.txid, reorgs.orphaned_block_hash, reorgs.orphaned_block_height,
+       reorgs.detected_at
+FROM anchor_tx_reorgs reorgs
+JOIN chain_txns txns
+    ON reorgs.txn_id = txns.txn_id
+ORDER BY reorgs.detected_at;
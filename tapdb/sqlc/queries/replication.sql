@@ -0,0 +1,26 @@
+-- name: InsertReplicationChange :one
+INSERT INTO replication_changes (
+    change_type, payload, created_at
+) VALUES (
+    @change_type, @payload, @created_at
+) RETURNING seq;
+
+-- name: FetchReplicationChanges :many
+SELECT *
+FROM replication_changes
+WHERE seq > @after_seq
+ORDER BY seq
+LIMIT @num_limit;
+
+-- name: FetchReplicationCursor :one
+SELECT last_seq
+FROM replication_cursors
+WHERE leader_id = @leader_id;
+
+-- name: UpsertReplicationCursor :exec
+INSERT INTO replication_cursors (
+    leader_id, last_seq
+) VALUES (
+    @leader_id, @last_seq
+) ON CONFLICT (leader_id)
+    DO UPDATE SET last_seq = EXCLUDED.last_seq;
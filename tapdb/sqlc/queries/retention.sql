@@ -0,0 +1,84 @@
+-- name: QuerySpentAssetProofStats :one
+WITH spent_assets AS (
+    SELECT assets.asset_id
+    FROM assets
+    JOIN genesis_assets
+        ON assets.genesis_id = genesis_assets.gen_asset_id
+    JOIN script_keys
+        ON assets.script_key_id = script_keys.script_key_id
+    JOIN managed_utxos utxos
+        ON assets.anchor_utxo_id = utxos.utxo_id
+    JOIN asset_transfer_inputs inputs
+        ON inputs.anchor_point = utxos.outpoint
+        AND inputs.asset_id = genesis_assets.asset_id
+        AND inputs.script_key = script_keys.tweaked_script_key
+    JOIN asset_transfers transfers
+        ON inputs.transfer_id = transfers.id
+    WHERE assets.spent = TRUE
+        AND transfers.transfer_time_unix < @spent_before
+)
+SELECT COUNT(*) AS num_proofs,
+       COALESCE(SUM(LENGTH(proof_file)), 0) AS num_bytes
+FROM asset_proofs
+WHERE asset_id IN (SELECT asset_id FROM spent_assets);
+
+-- name: DeleteSpentAssetProofs :execrows
+WITH spent_assets AS (
+    SELECT assets.asset_id
+    FROM assets
+    JOIN genesis_assets
+        ON assets.genesis_id = genesis_assets.gen_asset_id
+    JOIN script_keys
+        ON assets.script_key_id = script_keys.script_key_id
+    JOIN managed_utxos utxos
+        ON assets.anchor_utxo_id = utxos.utxo_id
+    JOIN asset_transfer_inputs inputs
+        ON inputs.anchor_point = utxos.outpoint
+        AND inputs.asset_id = genesis_assets.asset_id
+        AND inputs.script_key = script_keys.tweaked_script_key
+    JOIN asset_transfers transfers
+        ON inputs.transfer_id = transfers.id
+    WHERE assets.spent = TRUE
+        AND transfers.transfer_time_unix < @spent_before
+)
+DELETE FROM asset_proofs
+WHERE asset_id IN (SELECT asset_id FROM spent_assets);
+
+-- name: QueryDeliveredProofSuffixStats :one
+WITH confirmed_transfers AS (
+    SELECT transfers.id
+    FROM asset_transfers transfers
+    JOIN chain_txns txns
+        ON transfers.anchor_txn_id = txns.txn_id
+    WHERE txns.block_height IS NOT NULL
+        AND transfers.transfer_time_unix < @delivered_before
+)
+SELECT COUNT(*) AS num_proofs,
+       COALESCE(SUM(LENGTH(proof_suffix)), 0) AS num_bytes
+FROM asset_transfer_outputs
+WHERE proof_delivery_complete = TRUE
+    AND proof_suffix IS NOT NULL
+    AND transfer_id IN (SELECT id FROM confirmed_transfers);
+
+-- name: ClearDeliveredProofSuffixes :execrows
+WITH confirmed_transfers AS (
+    SELECT transfers.id
+    FROM asset_transfers transfers
+    JOIN chain_txns txns
+        ON transfers.anchor_txn_id = txns.txn_id
+    WHERE txns.block_height IS NOT NULL
+        AND transfers.transfer_time_unix < @delivered_before
+)
+UPDATE asset_transfer_outputs
+SET proof_suffix = NULL
+WHERE proof_delivery_complete = TRUE
+    AND proof_suffix IS NOT NULL
+    AND transfer_id IN (SELECT id FROM confirmed_transfers);
+
+-- name: DeleteProofTransferAttemptsBefore :execrows
+DELETE FROM proof_transfer_log
+WHERE time_unix < @attempted_before;
+
+-- name: DeleteUniverseEventsBefore :execrows
+DELETE FROM universe_events
+WHERE event_timestamp < @event_timestamp_before;
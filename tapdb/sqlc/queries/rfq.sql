@@ -0,0 +1,104 @@
+-- name: UpsertPeerStats :exec
+INSERT INTO rfq_peer_stats (
+    peer, quotes_accepted, quotes_rejected, htlcs_settled, htlcs_failed,
+    rate_deviation_ppm_sum, rate_samples, updated_at
+) VALUES (
+    @peer, @quotes_accepted, @quotes_rejected, @htlcs_settled, @htlcs_failed,
+    @rate_deviation_ppm_sum, @rate_samples, @updated_at
+)
+ON CONFLICT (peer)
+    DO UPDATE SET
+        quotes_accepted = rfq_peer_stats.quotes_accepted +
+            EXCLUDED.quotes_accepted,
+        quotes_rejected = rfq_peer_stats.quotes_rejected +
+            EXCLUDED.quotes_rejected,
+        htlcs_settled = rfq_peer_stats.htlcs_settled + EXCLUDED.htlcs_settled,
+        htlcs_failed = rfq_peer_stats.htlcs_failed + EXCLUDED.htlcs_failed,
+        rate_deviation_ppm_sum = rfq_peer_stats.rate_deviation_ppm_sum +
+            EXCLUDED.rate_deviation_ppm_sum,
+        rate_samples = rfq_peer_stats.rate_samples + EXCLUDED.rate_samples,
+        updated_at = EXCLUDED.updated_at;
+
+-- name: FetchPeerStats :one
+SELECT *
+FROM rfq_peer_stats
+WHERE peer = @peer;
+
+-- name: QueryPeerStats :many
+SELECT *
+FROM rfq_peer_stats
+ORDER BY peer;
+
+-- name: UpsertSettlementStats :exec
+INSERT INTO rfq_settlement_stats (
+    asset_key, peer, day, num_htlcs, asset_amount, amount_msat
+) VALUES (
+    @asset_key, @peer, @day, @num_htlcs, @asset_amount, @amount_msat
+)
+ON CONFLICT (asset_key, peer, day)
+    DO UPDATE SET
+        num_htlcs = rfq_settlement_stats.num_htlcs + EXCLUDED.num_htlcs,
+        asset_amount = rfq_settlement_stats.asset_amount +
+            EXCLUDED.asset_amount,
+        amount_msat = rfq_settlement_stats.amount_msat +
+            EXCLUDED.amount_msat;
+
+-- name: QuerySettlementStats :many
+SELECT *
+FROM rfq_settlement_stats
+WHERE day >= @start_day AND day <= @end_day AND
+    (asset_key = sqlc.narg('asset_key') OR
+        sqlc.narg('asset_key') IS NULL) AND
+    (peer = sqlc.narg('peer') OR sqlc.narg('peer') IS NULL)
+ORDER BY day, asset_key, peer;
+
+-- name: InsertRfqQuote :exec
+INSERT INTO rfq_quotes (
+    quote_id, peer, is_buy, accepted_by_peer, asset_key, asset_max_amount,
+    payment_max_msat, rate_coefficient, rate_scale, expiry, accepted_at
+) VALUES (
+    @quote_id, @peer, @is_buy, @accepted_by_peer, @asset_key,
+    @asset_max_amount, @payment_max_msat, @rate_coefficient, @rate_scale,
+    @expiry, @accepted_at
+)
+ON CONFLICT (quote_id) DO NOTHING;
+
+-- name: AddRfqQuoteVolume :exec
+UPDATE rfq_quotes
+SET num_htlcs = num_htlcs + @num_htlcs,
+    asset_amount = asset_amount + @asset_amount,
+    amount_msat = amount_msat + @amount_msat
+WHERE quote_id = @quote_id;
+
+-- name: QueryRfqQuotes :many
+SELECT *
+FROM rfq_quotes
+WHERE accepted_at >= @start_time AND accepted_at <= @end_time AND
+    (peer = sqlc.narg('peer') OR sqlc.narg('peer') IS NULL) AND
+    (asset_key = sqlc.narg('asset_key') OR
+        sqlc.narg('asset_key') IS NULL) AND
+    (is_buy = sqlc.narg('is_buy') OR sqlc.narg('is_buy') IS NULL) AND
+    (accepted_by_peer = sqlc.narg('accepted_by_peer') OR
+        sqlc.narg('accepted_by_peer') IS NULL) AND
+    (expiry > sqlc.narg('active_at') OR sqlc.narg('active_at') IS NULL) AND
+    (expiry <= sqlc.narg('expired_at') OR sqlc.narg('expired_at') IS NULL)
+ORDER BY accepted_at, quote_id
+LIMIT @num_limit OFFSET @num_offset;
+
+-- name: QueryRfqQuoteVolumes :many
+SELECT asset_key, COUNT(*) AS num_quotes,
+       COALESCE(SUM(num_htlcs), 0) AS num_htlcs,
+       COALESCE(SUM(asset_amount), 0) AS asset_amount,
+       COALESCE(SUM(amount_msat), 0) AS amount_msat
+FROM rfq_quotes
+WHERE accepted_at >= @start_time AND accepted_at <= @end_time AND
+    (peer = sqlc.narg('peer') OR sqlc.narg('peer') IS NULL) AND
+    (asset_key = sqlc.narg('asset_key') OR
+        sqlc.narg('asset_key') IS NULL) AND
+    (is_buy = sqlc.narg('is_buy') OR sqlc.narg('is_buy') IS NULL) AND
+    (accepted_by_peer = sqlc.narg('accepted_by_peer') OR
+        sqlc.narg('accepted_by_peer') IS NULL) AND
+    (expiry > sqlc.narg('active_at') OR sqlc.narg('active_at') IS NULL) AND
+    (expiry <= sqlc.narg('expired_at') OR sqlc.narg('expired_at') IS NULL)
+GROUP BY asset_key
+ORDER BY asset_key;
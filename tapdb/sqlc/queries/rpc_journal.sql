@@ -0,0 +1,17 @@
+-- name: InsertRpcJournalEntry :exec
+INSERT INTO rpc_journal (
+    method, caller, peer_addr, params, status_code, error_msg, start_time,
+    end_time
+) VALUES (
+    @method, @caller, @peer_addr, @params, @status_code, @error_msg,
+    @start_time, @end_time
+);
+
+-- name: QueryRpcJournalEntries :many
+SELECT *
+FROM rpc_journal
+WHERE start_time >= @start_after
+    AND start_time <= @start_before
+    AND (method = sqlc.narg('method') OR sqlc.narg('method') IS NULL)
+ORDER BY id
+LIMIT @num_limit OFFSET @num_offset;
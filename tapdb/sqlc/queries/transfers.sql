@@ -23,9 +23,11 @@ INSERT INTO asset_transfer_outputs (
     amount, serialized_witnesses, split_commitment_root_hash,
     split_commitment_root_value, proof_suffix, num_passive_assets,
     output_type, proof_courier_addr, asset_version, lock_time,
-    relative_lock_time, proof_delivery_complete, position
+    relative_lock_time, proof_delivery_complete, position,
+    proof_delivery_skipped, proof_courier_mode
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+    $18, $19
 );
 
 -- name: SetTransferOutputProofDeliveryStatus :exec
@@ -41,6 +43,40 @@ UPDATE asset_transfer_outputs
 SET proof_delivery_complete = @delivery_complete
 WHERE output_id = (SELECT output_id FROM target);
 
+-- name: InsertTransferOutputProofCourier :exec
+WITH target(output_id) AS (
+    SELECT output_id
+    FROM asset_transfer_outputs
+    WHERE transfer_id = @transfer_id
+      AND position = @position
+)
+INSERT INTO asset_transfer_output_proof_couriers (
+    output_id, priority, courier_addr
+) VALUES (
+    (SELECT output_id FROM target), @priority, @courier_addr
+);
+
+-- name: SetTransferOutputProofCourierStatus :exec
+WITH target(output_id) AS (
+    SELECT output_id
+    FROM asset_transfer_outputs output
+    JOIN managed_utxos
+      ON output.anchor_utxo = managed_utxos.utxo_id
+    WHERE managed_utxos.outpoint = @serialized_anchor_outpoint
+      AND output.position = @position
+)
+UPDATE asset_transfer_output_proof_couriers
+SET delivered = @delivered, last_error = @last_error,
+    last_attempt_time = @last_attempt_time
+WHERE output_id = (SELECT output_id FROM target)
+  AND priority = @priority;
+
+-- name: FetchTransferOutputProofCouriers :many
+SELECT *
+FROM asset_transfer_output_proof_couriers
+WHERE output_id = $1
+ORDER BY priority;
+
 -- name: QueryAssetTransfers :many
 SELECT
     id, height_hint, txns.txid, txns.block_hash AS anchor_tx_block_hash,
@@ -67,6 +103,30 @@ WHERE
         )
         OR @pending_transfers_only = false OR @pending_transfers_only IS NULL
     )
+
+    -- Optionally filter on the account the spent inputs belonged to.
+    AND (
+        (sqlc.narg('script_key_family') IS NULL AND
+            sqlc.narg('exclude_account_keys') IS NULL)
+        OR EXISTS (
+            SELECT 1
+            FROM asset_transfer_inputs inputs
+            JOIN script_keys
+                ON script_keys.tweaked_script_key = inputs.script_key
+            JOIN internal_keys
+                ON script_keys.internal_key_id = internal_keys.key_id
+            WHERE inputs.transfer_id = transfers.id
+              AND (internal_keys.key_family = sqlc.narg('script_key_family')
+                  OR sqlc.narg('script_key_family') IS NULL)
+              AND CASE
+                  WHEN sqlc.narg('exclude_account_keys') = true THEN
+                      internal_keys.key_family NOT IN (
+                          SELECT key_family FROM asset_accounts
+                      )
+                  ELSE TRUE
+              END
+        )
+    )
 ORDER BY transfer_time_unix;
 
 -- name: FetchTransferInputs :many
@@ -79,8 +139,9 @@ ORDER BY input_id;
 SELECT
     output_id, proof_suffix, amount, serialized_witnesses, script_key_local,
     split_commitment_root_hash, split_commitment_root_value, num_passive_assets,
-    output_type, proof_courier_addr, proof_delivery_complete, position,
-    asset_version, lock_time, relative_lock_time,
+    output_type, proof_courier_addr, proof_delivery_complete,
+    proof_delivery_skipped, proof_courier_mode, position, asset_version,
+    lock_time, relative_lock_time,
     utxos.utxo_id AS anchor_utxo_id,
     utxos.outpoint AS anchor_outpoint,
     utxos.amt_sats AS anchor_value,
@@ -77,6 +77,12 @@ ORDER BY
     CASE WHEN sqlc.narg('sort_direction') = 1 THEN leaves.id END DESC
 LIMIT @num_limit OFFSET @num_offset;
 
+-- name: FetchUniverseLeafKey :one
+SELECT leaves.minting_point, leaves.script_key_bytes
+FROM universe_leaves AS leaves
+WHERE leaves.leaf_node_namespace = @namespace
+    AND leaves.leaf_node_key = @leaf_node_key;
+
 -- name: UniverseLeaves :many
 SELECT * FROM universe_leaves;
 
@@ -119,6 +125,41 @@ WHERE (id = sqlc.narg('id') OR sqlc.narg('id') IS NULL) AND
       (server_host = sqlc.narg('server_host')
            OR sqlc.narg('server_host') IS NULL);
 
+-- name: InsertFederationServerScope :exec
+INSERT INTO federation_server_scopes (
+    servers_id, asset_id, group_key
+) VALUES (
+    @servers_id, sqlc.narg('asset_id'), sqlc.narg('group_key')
+);
+
+-- name: DeleteFederationServerScopes :exec
+DELETE FROM federation_server_scopes
+WHERE servers_id = @servers_id;
+
+-- name: QueryFederationServerScopes :many
+SELECT servers.server_host, scopes.asset_id, scopes.group_key
+FROM federation_server_scopes scopes
+JOIN universe_servers servers
+    ON servers.id = scopes.servers_id
+ORDER BY scopes.id;
+
+-- name: InsertUniverseOperatorKey :exec
+INSERT INTO universe_operator_keys (
+    server_host, operator_key, pinned_at
+) VALUES (
+    @server_host, @operator_key, @pinned_at
+)
+ON CONFLICT (server_host) DO NOTHING;
+
+-- name: FetchUniverseOperatorKey :one
+SELECT operator_key
+FROM universe_operator_keys
+WHERE server_host = @server_host;
+
+-- name: DeleteUniverseOperatorKey :exec
+DELETE FROM universe_operator_keys
+WHERE server_host = @server_host;
+
 -- name: InsertNewSyncEvent :exec
 WITH group_key_root_id AS (
     SELECT id
@@ -333,6 +374,61 @@ WHERE event_type IN ('SYNC', 'NEW_PROOF')
 GROUP BY day
 ORDER BY day;
 
+-- name: UpsertUniverseDailyStats :exec
+INSERT INTO universe_daily_stats (
+    day_start, new_assets, new_proofs
+) VALUES (
+    @day_start, @new_assets, @new_proofs
+)
+ON CONFLICT (day_start)
+    DO UPDATE SET
+        new_assets = universe_daily_stats.new_assets + EXCLUDED.new_assets,
+        new_proofs = universe_daily_stats.new_proofs + EXCLUDED.new_proofs;
+
+-- name: QueryUniverseDailyStats :many
+SELECT day_start, new_assets, new_proofs
+FROM universe_daily_stats
+WHERE day_start >= @start_time AND day_start <= @end_time
+ORDER BY day_start;
+
+-- name: UpsertUniverseServerDailySyncs :exec
+INSERT INTO universe_server_daily_syncs (
+    server_host, day_start, num_syncs, num_synced_leaves
+) VALUES (
+    @server_host, @day_start, @num_syncs, @num_synced_leaves
+)
+ON CONFLICT (server_host, day_start)
+    DO UPDATE SET
+        num_syncs = universe_server_daily_syncs.num_syncs +
+            EXCLUDED.num_syncs,
+        num_synced_leaves = universe_server_daily_syncs.num_synced_leaves +
+            EXCLUDED.num_synced_leaves;
+
+-- name: QueryUniverseServerDailySyncs :many
+SELECT server_host, day_start, num_syncs, num_synced_leaves
+FROM universe_server_daily_syncs
+WHERE day_start >= @start_time AND day_start <= @end_time
+    AND (server_host = sqlc.narg('server_host') OR
+        sqlc.narg('server_host') IS NULL)
+ORDER BY day_start, server_host;
+
+-- name: UpsertUniverseTransferStats :exec
+INSERT INTO universe_transfer_stats (
+    namespace, asset_id, group_key, num_transfers
+) VALUES (
+    @namespace, @asset_id, @group_key, @num_transfers
+)
+ON CONFLICT (namespace)
+    DO UPDATE SET
+        num_transfers = universe_transfer_stats.num_transfers +
+            EXCLUDED.num_transfers;
+
+-- name: QueryTopUniverseTransferStats :many
+SELECT namespace, asset_id, group_key, num_transfers
+FROM universe_transfer_stats
+ORDER BY num_transfers DESC, namespace
+LIMIT @num_limit;
+
 -- name: UpsertFederationGlobalSyncConfig :exec
 INSERT INTO federation_global_sync_config (
     proof_type, allow_sync_insert, allow_sync_export
@@ -509,3 +605,19 @@ JOIN multiverse_roots r
 WHERE r.proof_type = @proof_type AND
       (l.asset_id = @asset_id OR @asset_id IS NULL) AND
       (l.group_key = @group_key OR @group_key IS NULL);
+
+-- name: InsertAssetMetaUpdate :exec
+INSERT INTO asset_meta_updates (
+    tweaked_group_key, version, meta_type, meta_data, signer_key, signature,
+    created_at
+) VALUES (
+    @tweaked_group_key, @version, @meta_type, @meta_data, @signer_key,
+    @signature, @created_at
+);
+
+-- name: QueryAssetMetaUpdates :many
+SELECT tweaked_group_key, version, meta_type, meta_data, signer_key, signature
+FROM asset_meta_updates
+WHERE tweaked_group_key = @tweaked_group_key
+ORDER BY version DESC
+LIMIT @num_limit;
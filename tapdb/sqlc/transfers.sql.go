@@ -121,12 +121,51 @@ func (q *Queries) FetchTransferInputs(ctx context.Context, transferID int64) ([]
 	return items, nil
 }
 
+const fetchTransferOutputProofCouriers = `-- name: FetchTransferOutputProofCouriers :many
+SELECT id, output_id, priority, courier_addr, delivered, last_error, last_attempt_time
+FROM asset_transfer_output_proof_couriers
+WHERE output_id = $1
+ORDER BY priority
+`
+
+func (q *Queries) FetchTransferOutputProofCouriers(ctx context.Context, outputID int64) ([]AssetTransferOutputProofCourier, error) {
+	rows, err := q.db.QueryContext(ctx, fetchTransferOutputProofCouriers, outputID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssetTransferOutputProofCourier
+	for rows.Next() {
+		var i AssetTransferOutputProofCourier
+		if err := rows.Scan(
+			&i.ID,
+			&i.OutputID,
+			&i.Priority,
+			&i.CourierAddr,
+			&i.Delivered,
+			&i.LastError,
+			&i.LastAttemptTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const fetchTransferOutputs = `-- name: FetchTransferOutputs :many
 SELECT
     output_id, proof_suffix, amount, serialized_witnesses, script_key_local,
     split_commitment_root_hash, split_commitment_root_value, num_passive_assets,
-    output_type, proof_courier_addr, proof_delivery_complete, position,
-    asset_version, lock_time, relative_lock_time,
+    output_type, proof_courier_addr, proof_delivery_complete,
+    proof_delivery_skipped, proof_courier_mode, position, asset_version,
+    lock_time, relative_lock_time,
     utxos.utxo_id AS anchor_utxo_id,
     utxos.outpoint AS anchor_outpoint,
     utxos.amt_sats AS anchor_value,
@@ -169,6 +208,8 @@ type FetchTransferOutputsRow struct {
 	OutputType               int16
 	ProofCourierAddr         []byte
 	ProofDeliveryComplete    sql.NullBool
+	ProofDeliverySkipped     bool
+	ProofCourierMode         int16
 	Position                 int32
 	AssetVersion             int32
 	LockTime                 sql.NullInt32
@@ -213,6 +254,8 @@ func (q *Queries) FetchTransferOutputs(ctx context.Context, transferID int64) ([
 			&i.OutputType,
 			&i.ProofCourierAddr,
 			&i.ProofDeliveryComplete,
+			&i.ProofDeliverySkipped,
+			&i.ProofCourierMode,
 			&i.Position,
 			&i.AssetVersion,
 			&i.LockTime,
@@ -307,9 +350,11 @@ INSERT INTO asset_transfer_outputs (
     amount, serialized_witnesses, split_commitment_root_hash,
     split_commitment_root_value, proof_suffix, num_passive_assets,
     output_type, proof_courier_addr, asset_version, lock_time,
-    relative_lock_time, proof_delivery_complete, position
+    relative_lock_time, proof_delivery_complete, position,
+    proof_delivery_skipped, proof_courier_mode
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+    $18, $19
 )
 `
 
@@ -331,6 +376,8 @@ type InsertAssetTransferOutputParams struct {
 	RelativeLockTime         sql.NullInt32
 	ProofDeliveryComplete    sql.NullBool
 	Position                 int32
+	ProofDeliverySkipped     bool
+	ProofCourierMode         int16
 }
 
 func (q *Queries) InsertAssetTransferOutput(ctx context.Context, arg InsertAssetTransferOutputParams) error {
@@ -352,6 +399,8 @@ func (q *Queries) InsertAssetTransferOutput(ctx context.Context, arg InsertAsset
 		arg.RelativeLockTime,
 		arg.ProofDeliveryComplete,
 		arg.Position,
+		arg.ProofDeliverySkipped,
+		arg.ProofCourierMode,
 	)
 	return err
 }
@@ -435,6 +484,37 @@ func (q *Queries) InsertPassiveAsset(ctx context.Context, arg InsertPassiveAsset
 	return err
 }
 
+const insertTransferOutputProofCourier = `-- name: InsertTransferOutputProofCourier :exec
+WITH target(output_id) AS (
+    SELECT output_id
+    FROM asset_transfer_outputs
+    WHERE transfer_id = $1
+      AND position = $2
+)
+INSERT INTO asset_transfer_output_proof_couriers (
+    output_id, priority, courier_addr
+) VALUES (
+    (SELECT output_id FROM target), $3, $4
+)
+`
+
+type InsertTransferOutputProofCourierParams struct {
+	TransferID  int64
+	Position    int32
+	Priority    int32
+	CourierAddr []byte
+}
+
+func (q *Queries) InsertTransferOutputProofCourier(ctx context.Context, arg InsertTransferOutputProofCourierParams) error {
+	_, err := q.db.ExecContext(ctx, insertTransferOutputProofCourier,
+		arg.TransferID,
+		arg.Position,
+		arg.Priority,
+		arg.CourierAddr,
+	)
+	return err
+}
+
 const logProofTransferAttempt = `-- name: LogProofTransferAttempt :exec
 INSERT INTO proof_transfer_log (
     transfer_type, proof_locator_hash, time_unix
@@ -480,12 +560,38 @@ WHERE
         )
         OR $2 = false OR $2 IS NULL
     )
+
+    -- Optionally filter on the account the spent inputs belonged to.
+    AND (
+        ($3 IS NULL AND
+            $4 IS NULL)
+        OR EXISTS (
+            SELECT 1
+            FROM asset_transfer_inputs inputs
+            JOIN script_keys
+                ON script_keys.tweaked_script_key = inputs.script_key
+            JOIN internal_keys
+                ON script_keys.internal_key_id = internal_keys.key_id
+            WHERE inputs.transfer_id = transfers.id
+              AND (internal_keys.key_family = $3
+                  OR $3 IS NULL)
+              AND CASE
+                  WHEN $4 = true THEN
+                      internal_keys.key_family NOT IN (
+                          SELECT key_family FROM asset_accounts
+                      )
+                  ELSE TRUE
+              END
+        )
+    )
 ORDER BY transfer_time_unix
 `
 
 type QueryAssetTransfersParams struct {
 	AnchorTxHash         []byte
 	PendingTransfersOnly interface{}
+	ScriptKeyFamily      sql.NullInt32
+	ExcludeAccountKeys   interface{}
 }
 
 type QueryAssetTransfersRow struct {
@@ -497,7 +603,12 @@ type QueryAssetTransfersRow struct {
 }
 
 func (q *Queries) QueryAssetTransfers(ctx context.Context, arg QueryAssetTransfersParams) ([]QueryAssetTransfersRow, error) {
-	rows, err := q.db.QueryContext(ctx, queryAssetTransfers, arg.AnchorTxHash, arg.PendingTransfersOnly)
+	rows, err := q.db.QueryContext(ctx, queryAssetTransfers,
+		arg.AnchorTxHash,
+		arg.PendingTransfersOnly,
+		arg.ScriptKeyFamily,
+		arg.ExcludeAccountKeys,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -702,6 +813,43 @@ func (q *Queries) ReAnchorPassiveAssets(ctx context.Context, arg ReAnchorPassive
 	return err
 }
 
+const setTransferOutputProofCourierStatus = `-- name: SetTransferOutputProofCourierStatus :exec
+WITH target(output_id) AS (
+    SELECT output_id
+    FROM asset_transfer_outputs output
+    JOIN managed_utxos
+      ON output.anchor_utxo = managed_utxos.utxo_id
+    WHERE managed_utxos.outpoint = $4
+      AND output.position = $5
+)
+UPDATE asset_transfer_output_proof_couriers
+SET delivered = $1, last_error = $2,
+    last_attempt_time = $3
+WHERE output_id = (SELECT output_id FROM target)
+  AND priority = $6
+`
+
+type SetTransferOutputProofCourierStatusParams struct {
+	Delivered                bool
+	LastError                string
+	LastAttemptTime          sql.NullTime
+	SerializedAnchorOutpoint []byte
+	Position                 int32
+	Priority                 int32
+}
+
+func (q *Queries) SetTransferOutputProofCourierStatus(ctx context.Context, arg SetTransferOutputProofCourierStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setTransferOutputProofCourierStatus,
+		arg.Delivered,
+		arg.LastError,
+		arg.LastAttemptTime,
+		arg.SerializedAnchorOutpoint,
+		arg.Position,
+		arg.Priority,
+	)
+	return err
+}
+
 const setTransferOutputProofDeliveryStatus = `-- name: SetTransferOutputProofDeliveryStatus :exec
 WITH target(output_id) AS (
     SELECT output_id
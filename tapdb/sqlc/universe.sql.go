@@ -49,6 +49,16 @@ func (q *Queries) DeleteFederationProofSyncLog(ctx context.Context, arg DeleteFe
 	return err
 }
 
+const deleteFederationServerScopes = `-- name: DeleteFederationServerScopes :exec
+DELETE FROM federation_server_scopes
+WHERE servers_id = $1
+`
+
+func (q *Queries) DeleteFederationServerScopes(ctx context.Context, serversID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFederationServerScopes, serversID)
+	return err
+}
+
 const deleteMultiverseLeaf = `-- name: DeleteMultiverseLeaf :exec
 DELETE FROM multiverse_leaves
 WHERE leaf_node_namespace = $1 AND leaf_node_key = $2
@@ -89,6 +99,16 @@ func (q *Queries) DeleteUniverseLeaves(ctx context.Context, namespace string) er
 	return err
 }
 
+const deleteUniverseOperatorKey = `-- name: DeleteUniverseOperatorKey :exec
+DELETE FROM universe_operator_keys
+WHERE server_host = $1
+`
+
+func (q *Queries) DeleteUniverseOperatorKey(ctx context.Context, serverHost string) error {
+	_, err := q.db.ExecContext(ctx, deleteUniverseOperatorKey, serverHost)
+	return err
+}
+
 const deleteUniverseRoot = `-- name: DeleteUniverseRoot :exec
 DELETE FROM universe_roots
 WHERE namespace_root = $1
@@ -188,6 +208,43 @@ func (q *Queries) FetchUniverseKeys(ctx context.Context, arg FetchUniverseKeysPa
 	return items, nil
 }
 
+const fetchUniverseLeafKey = `-- name: FetchUniverseLeafKey :one
+SELECT leaves.minting_point, leaves.script_key_bytes
+FROM universe_leaves AS leaves
+WHERE leaves.leaf_node_namespace = $1
+    AND leaves.leaf_node_key = $2
+`
+
+type FetchUniverseLeafKeyParams struct {
+	Namespace   string
+	LeafNodeKey []byte
+}
+
+type FetchUniverseLeafKeyRow struct {
+	MintingPoint   []byte
+	ScriptKeyBytes []byte
+}
+
+func (q *Queries) FetchUniverseLeafKey(ctx context.Context, arg FetchUniverseLeafKeyParams) (FetchUniverseLeafKeyRow, error) {
+	row := q.db.QueryRowContext(ctx, fetchUniverseLeafKey, arg.Namespace, arg.LeafNodeKey)
+	var i FetchUniverseLeafKeyRow
+	err := row.Scan(&i.MintingPoint, &i.ScriptKeyBytes)
+	return i, err
+}
+
+const fetchUniverseOperatorKey = `-- name: FetchUniverseOperatorKey :one
+SELECT operator_key
+FROM universe_operator_keys
+WHERE server_host = $1
+`
+
+func (q *Queries) FetchUniverseOperatorKey(ctx context.Context, serverHost string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, fetchUniverseOperatorKey, serverHost)
+	var operator_key []byte
+	err := row.Scan(&operator_key)
+	return operator_key, err
+}
+
 const fetchUniverseRoot = `-- name: FetchUniverseRoot :one
 SELECT universe_roots.asset_id, group_key, proof_type,
        mssmt_nodes.hash_key root_hash, mssmt_nodes.sum root_sum,
@@ -226,6 +283,58 @@ func (q *Queries) FetchUniverseRoot(ctx context.Context, namespace string) (Fetc
 	return i, err
 }
 
+const insertAssetMetaUpdate = `-- name: InsertAssetMetaUpdate :exec
+INSERT INTO asset_meta_updates (
+    tweaked_group_key, version, meta_type, meta_data, signer_key, signature,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    $6, $7
+)
+`
+
+type InsertAssetMetaUpdateParams struct {
+	TweakedGroupKey []byte
+	Version         int64
+	MetaType        int16
+	MetaData        []byte
+	SignerKey       []byte
+	Signature       []byte
+	CreatedAt       time.Time
+}
+
+func (q *Queries) InsertAssetMetaUpdate(ctx context.Context, arg InsertAssetMetaUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, insertAssetMetaUpdate,
+		arg.TweakedGroupKey,
+		arg.Version,
+		arg.MetaType,
+		arg.MetaData,
+		arg.SignerKey,
+		arg.Signature,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertFederationServerScope = `-- name: InsertFederationServerScope :exec
+INSERT INTO federation_server_scopes (
+    servers_id, asset_id, group_key
+) VALUES (
+    $1, $2, $3
+)
+`
+
+type InsertFederationServerScopeParams struct {
+	ServersID int64
+	AssetID   []byte
+	GroupKey  []byte
+}
+
+func (q *Queries) InsertFederationServerScope(ctx context.Context, arg InsertFederationServerScopeParams) error {
+	_, err := q.db.ExecContext(ctx, insertFederationServerScope, arg.ServersID, arg.AssetID, arg.GroupKey)
+	return err
+}
+
 const insertNewProofEvent = `-- name: InsertNewProofEvent :exec
 WITH group_key_root_id AS (
     SELECT id
@@ -324,6 +433,26 @@ func (q *Queries) InsertNewSyncEvent(ctx context.Context, arg InsertNewSyncEvent
 	return err
 }
 
+const insertUniverseOperatorKey = `-- name: InsertUniverseOperatorKey :exec
+INSERT INTO universe_operator_keys (
+    server_host, operator_key, pinned_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (server_host) DO NOTHING
+`
+
+type InsertUniverseOperatorKeyParams struct {
+	ServerHost  string
+	OperatorKey []byte
+	PinnedAt    time.Time
+}
+
+func (q *Queries) InsertUniverseOperatorKey(ctx context.Context, arg InsertUniverseOperatorKeyParams) error {
+	_, err := q.db.ExecContext(ctx, insertUniverseOperatorKey, arg.ServerHost, arg.OperatorKey, arg.PinnedAt)
+	return err
+}
+
 const insertUniverseServer = `-- name: InsertUniverseServer :exec
 INSERT INTO universe_servers(
     server_host, last_sync_time
@@ -358,6 +487,58 @@ func (q *Queries) LogServerSync(ctx context.Context, arg LogServerSyncParams) er
 	return err
 }
 
+const queryAssetMetaUpdates = `-- name: QueryAssetMetaUpdates :many
+SELECT tweaked_group_key, version, meta_type, meta_data, signer_key, signature
+FROM asset_meta_updates
+WHERE tweaked_group_key = $1
+ORDER BY version DESC
+LIMIT $2
+`
+
+type QueryAssetMetaUpdatesParams struct {
+	TweakedGroupKey []byte
+	NumLimit        int32
+}
+
+type QueryAssetMetaUpdatesRow struct {
+	TweakedGroupKey []byte
+	Version         int64
+	MetaType        int16
+	MetaData        []byte
+	SignerKey       []byte
+	Signature       []byte
+}
+
+func (q *Queries) QueryAssetMetaUpdates(ctx context.Context, arg QueryAssetMetaUpdatesParams) ([]QueryAssetMetaUpdatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, queryAssetMetaUpdates, arg.TweakedGroupKey, arg.NumLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueryAssetMetaUpdatesRow
+	for rows.Next() {
+		var i QueryAssetMetaUpdatesRow
+		if err := rows.Scan(
+			&i.TweakedGroupKey,
+			&i.Version,
+			&i.MetaType,
+			&i.MetaData,
+			&i.SignerKey,
+			&i.Signature,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const queryAssetStatsPerDayPostgres = `-- name: QueryAssetStatsPerDayPostgres :many
 SELECT
     to_char(to_timestamp(event_timestamp), 'YYYY-MM-DD') AS day,
@@ -589,6 +770,43 @@ func (q *Queries) QueryFederationProofSyncLog(ctx context.Context, arg QueryFede
 	return items, nil
 }
 
+const queryFederationServerScopes = `-- name: QueryFederationServerScopes :many
+SELECT servers.server_host, scopes.asset_id, scopes.group_key
+FROM federation_server_scopes scopes
+JOIN universe_servers servers
+    ON servers.id = scopes.servers_id
+ORDER BY scopes.id
+`
+
+type QueryFederationServerScopesRow struct {
+	ServerHost string
+	AssetID    []byte
+	GroupKey   []byte
+}
+
+func (q *Queries) QueryFederationServerScopes(ctx context.Context) ([]QueryFederationServerScopesRow, error) {
+	rows, err := q.db.QueryContext(ctx, queryFederationServerScopes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueryFederationServerScopesRow
+	for rows.Next() {
+		var i QueryFederationServerScopesRow
+		if err := rows.Scan(&i.ServerHost, &i.AssetID, &i.GroupKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const queryFederationUniSyncConfigs = `-- name: QueryFederationUniSyncConfigs :many
 SELECT namespace, asset_id, group_key, proof_type, allow_sync_insert, allow_sync_export
 FROM federation_uni_sync_config
@@ -684,6 +902,41 @@ func (q *Queries) QueryMultiverseLeaves(ctx context.Context, arg QueryMultiverse
 	return items, nil
 }
 
+const queryTopUniverseTransferStats = `-- name: QueryTopUniverseTransferStats :many
+SELECT namespace, asset_id, group_key, num_transfers
+FROM universe_transfer_stats
+ORDER BY num_transfers DESC, namespace
+LIMIT $1
+`
+
+func (q *Queries) QueryTopUniverseTransferStats(ctx context.Context, numLimit int32) ([]UniverseTransferStat, error) {
+	rows, err := q.db.QueryContext(ctx, queryTopUniverseTransferStats, numLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UniverseTransferStat
+	for rows.Next() {
+		var i UniverseTransferStat
+		if err := rows.Scan(
+			&i.Namespace,
+			&i.AssetID,
+			&i.GroupKey,
+			&i.NumTransfers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const queryUniverseAssetStats = `-- name: QueryUniverseAssetStats :many
 
 WITH asset_supply AS (
@@ -846,6 +1099,41 @@ func (q *Queries) QueryUniverseAssetStats(ctx context.Context, arg QueryUniverse
 	return items, nil
 }
 
+const queryUniverseDailyStats = `-- name: QueryUniverseDailyStats :many
+SELECT day_start, new_assets, new_proofs
+FROM universe_daily_stats
+WHERE day_start >= $1 AND day_start <= $2
+ORDER BY day_start
+`
+
+type QueryUniverseDailyStatsParams struct {
+	StartTime int64
+	EndTime   int64
+}
+
+func (q *Queries) QueryUniverseDailyStats(ctx context.Context, arg QueryUniverseDailyStatsParams) ([]UniverseDailyStat, error) {
+	rows, err := q.db.QueryContext(ctx, queryUniverseDailyStats, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UniverseDailyStat
+	for rows.Next() {
+		var i UniverseDailyStat
+		if err := rows.Scan(&i.DayStart, &i.NewAssets, &i.NewProofs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const queryUniverseLeaves = `-- name: QueryUniverseLeaves :many
 SELECT leaves.script_key_bytes, gen.gen_asset_id, nodes.value AS genesis_proof, 
        nodes.sum AS sum_amt, gen.asset_id
@@ -905,6 +1193,49 @@ func (q *Queries) QueryUniverseLeaves(ctx context.Context, arg QueryUniverseLeav
 	return items, nil
 }
 
+const queryUniverseServerDailySyncs = `-- name: QueryUniverseServerDailySyncs :many
+SELECT server_host, day_start, num_syncs, num_synced_leaves
+FROM universe_server_daily_syncs
+WHERE day_start >= $1 AND day_start <= $2
+    AND (server_host = $3 OR
+        $3 IS NULL)
+ORDER BY day_start, server_host
+`
+
+type QueryUniverseServerDailySyncsParams struct {
+	StartTime  int64
+	EndTime    int64
+	ServerHost sql.NullString
+}
+
+func (q *Queries) QueryUniverseServerDailySyncs(ctx context.Context, arg QueryUniverseServerDailySyncsParams) ([]UniverseServerDailySync, error) {
+	rows, err := q.db.QueryContext(ctx, queryUniverseServerDailySyncs, arg.StartTime, arg.EndTime, arg.ServerHost)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UniverseServerDailySync
+	for rows.Next() {
+		var i UniverseServerDailySync
+		if err := rows.Scan(
+			&i.ServerHost,
+			&i.DayStart,
+			&i.NumSyncs,
+			&i.NumSyncedLeaves,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const queryUniverseServers = `-- name: QueryUniverseServers :many
 SELECT id, server_host, last_sync_time FROM universe_servers
 WHERE (id = $1 OR $1 IS NULL) AND
@@ -1281,6 +1612,29 @@ func (q *Queries) UpsertMultiverseRoot(ctx context.Context, arg UpsertMultiverse
 	return id, err
 }
 
+const upsertUniverseDailyStats = `-- name: UpsertUniverseDailyStats :exec
+INSERT INTO universe_daily_stats (
+    day_start, new_assets, new_proofs
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (day_start)
+    DO UPDATE SET
+        new_assets = universe_daily_stats.new_assets + EXCLUDED.new_assets,
+        new_proofs = universe_daily_stats.new_proofs + EXCLUDED.new_proofs
+`
+
+type UpsertUniverseDailyStatsParams struct {
+	DayStart  int64
+	NewAssets int64
+	NewProofs int64
+}
+
+func (q *Queries) UpsertUniverseDailyStats(ctx context.Context, arg UpsertUniverseDailyStatsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUniverseDailyStats, arg.DayStart, arg.NewAssets, arg.NewProofs)
+	return err
+}
+
 const upsertUniverseLeaf = `-- name: UpsertUniverseLeaf :exec
 INSERT INTO universe_leaves (
     asset_genesis_id, script_key_bytes, universe_root_id, leaf_node_key, 
@@ -1346,3 +1700,63 @@ func (q *Queries) UpsertUniverseRoot(ctx context.Context, arg UpsertUniverseRoot
 	err := row.Scan(&id)
 	return id, err
 }
+
+const upsertUniverseServerDailySyncs = `-- name: UpsertUniverseServerDailySyncs :exec
+INSERT INTO universe_server_daily_syncs (
+    server_host, day_start, num_syncs, num_synced_leaves
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (server_host, day_start)
+    DO UPDATE SET
+        num_syncs = universe_server_daily_syncs.num_syncs +
+            EXCLUDED.num_syncs,
+        num_synced_leaves = universe_server_daily_syncs.num_synced_leaves +
+            EXCLUDED.num_synced_leaves
+`
+
+type UpsertUniverseServerDailySyncsParams struct {
+	ServerHost      string
+	DayStart        int64
+	NumSyncs        int64
+	NumSyncedLeaves int64
+}
+
+func (q *Queries) UpsertUniverseServerDailySyncs(ctx context.Context, arg UpsertUniverseServerDailySyncsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUniverseServerDailySyncs,
+		arg.ServerHost,
+		arg.DayStart,
+		arg.NumSyncs,
+		arg.NumSyncedLeaves,
+	)
+	return err
+}
+
+const upsertUniverseTransferStats = `-- name: UpsertUniverseTransferStats :exec
+INSERT INTO universe_transfer_stats (
+    namespace, asset_id, group_key, num_transfers
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (namespace)
+    DO UPDATE SET
+        num_transfers = universe_transfer_stats.num_transfers +
+            EXCLUDED.num_transfers
+`
+
+type UpsertUniverseTransferStatsParams struct {
+	Namespace    string
+	AssetID      []byte
+	GroupKey     []byte
+	NumTransfers int64
+}
+
+func (q *Queries) UpsertUniverseTransferStats(ctx context.Context, arg UpsertUniverseTransferStatsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUniverseTransferStats,
+		arg.Namespace,
+		arg.AssetID,
+		arg.GroupKey,
+		arg.NumTransfers,
+	)
+	return err
+}
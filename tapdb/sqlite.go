@@ -60,6 +60,10 @@ type SqliteConfig struct {
 	// DatabaseFileName is the full file path where the database file can be
 	// found.
 	DatabaseFileName string `long:"dbfile" description:"The full path to the database."`
+
+	// Encryption is the source of the secret that the key used to encrypt
+	// the sensitive fields of the database is derived from.
+	Encryption string `long:"encryption" description:"Encrypt macaroon root keys and proofs in the database with a key derived from the lnd wallet or from a passphrase supplied at startup with the UnlockDatabase RPC; once enabled, encryption can't be disabled" choice:"none" choice:"lnd" choice:"passphrase"`
 }
 
 // SqliteStore is a sqlite3 based database for the Taproot Asset daemon.
@@ -251,7 +255,7 @@ func (s *SqliteStore) ExecuteMigrations(target MigrationTarget,
 
 // NewTestSqliteDB is a helper function that creates an SQLite database for
 // testing.
-func NewTestSqliteDB(t *testing.T) *SqliteStore {
+func NewTestSqliteDB(t testing.TB) *SqliteStore {
 	t.Helper()
 
 	// TODO(roasbeef): if we pass :memory: for the file name, then we get
@@ -264,7 +268,7 @@ func NewTestSqliteDB(t *testing.T) *SqliteStore {
 
 // NewTestSqliteDbHandleFromPath is a helper function that creates a SQLite
 // database handle given a database file path.
-func NewTestSqliteDbHandleFromPath(t *testing.T, dbPath string) *SqliteStore {
+func NewTestSqliteDbHandleFromPath(t testing.TB, dbPath string) *SqliteStore {
 	t.Helper()
 
 	sqlDB, err := NewSqliteStore(&SqliteConfig{
@@ -0,0 +1,836 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/mssmt"
+)
+
+const (
+	// migrationBatchSize is the maximum number of rows that are inserted
+	// into the target database with a single statement.
+	migrationBatchSize = 500
+
+	// postgresMaxParams is the maximum number of bind parameters Postgres
+	// accepts for a single statement.
+	postgresMaxParams = 65535
+
+	// schemaMigrationsTable is the name of the table the migration library
+	// uses to track the schema version of a database. It is maintained by
+	// the migrations themselves, so it isn't copied.
+	schemaMigrationsTable = "schema_migrations"
+)
+
+var (
+	// ErrTargetNotEmpty is returned if a table of the target database of a
+	// backend migration already contains rows.
+	ErrTargetNotEmpty = errors.New("target database is not empty")
+
+	// ErrSchemaVersionMismatch is returned if the source or target
+	// database of a backend migration isn't at the latest schema version.
+	ErrSchemaVersionMismatch = errors.New("database schema version " +
+		"mismatch")
+)
+
+// BackendMigrationStats summarizes a completed backend migration.
+type BackendMigrationStats struct {
+	// Tables is the list of copied tables, in the order they were copied.
+	Tables []string
+
+	// RowsCopied is the number of rows that were copied per table.
+	RowsCopied map[string]int64
+
+	// MssmtRootsVerified is the number of MS-SMT roots that were
+	// recomputed from the copied nodes and verified.
+	MssmtRootsVerified int
+}
+
+// migrationQuerier is the subset of the database/sql methods that are needed
+// to read from and write to the databases of a backend migration.
+type migrationQuerier interface {
+	// ExecContext executes a query without returning any rows.
+	ExecContext(ctx context.Context, query string,
+		args ...any) (sql.Result, error)
+
+	// QueryContext executes a query that returns rows.
+	QueryContext(ctx context.Context, query string,
+		args ...any) (*sql.Rows, error)
+
+	// QueryRowContext executes a query that is expected to return at most
+	// one row.
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// MigrateSqliteToPostgres copies all rows of all tables of the given SQLite
+// database to the given Postgres database. Both databases must be at the
+// latest schema version and the tables of the Postgres database must be
+// empty. The rows are copied within a single transaction that is only
+// committed once the row counts of all tables match and the root of every
+// MS-SMT was recomputed from the copied nodes and matches the root stored in
+// the source database. If any of the checks fail, the target database is left
+// untouched.
+//
+// NOTE: The daemon must not be running while the migration is in progress.
+func MigrateSqliteToPostgres(ctx context.Context, src *SqliteStore,
+	dst *PostgresStore) (*BackendMigrationStats, error) {
+
+	srcTx, err := src.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to start source transaction: "+
+			"%w", err)
+	}
+	defer func() {
+		_ = srcTx.Rollback()
+	}()
+
+	if err := checkSchemaVersion(ctx, srcTx); err != nil {
+		return nil, fmt.Errorf("source database: %w", err)
+	}
+	if err := checkSchemaVersion(ctx, dst.DB); err != nil {
+		return nil, fmt.Errorf("target database: %w", err)
+	}
+
+	tables, err := sqliteTablesInOrder(ctx, srcTx)
+	if err != nil {
+		return nil, err
+	}
+
+	dstTx, err := dst.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start target transaction: "+
+			"%w", err)
+	}
+	defer func() {
+		_ = dstTx.Rollback()
+	}()
+
+	stats := &BackendMigrationStats{
+		Tables:     tables,
+		RowsCopied: make(map[string]int64, len(tables)),
+	}
+	for _, table := range tables {
+		numRows, err := countRows(ctx, dstTx, table)
+		if err != nil {
+			return nil, err
+		}
+		if numRows != 0 {
+			return nil, fmt.Errorf("%w: table %s contains %d rows",
+				ErrTargetNotEmpty, table, numRows)
+		}
+
+		log.Infof("Copying table %s", table)
+
+		numRows, err = copyTable(ctx, srcTx, dstTx, table)
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy table %s: %w",
+				table, err)
+		}
+
+		stats.RowsCopied[table] = numRows
+	}
+
+	if err := resetPostgresSequences(ctx, dstTx); err != nil {
+		return nil, err
+	}
+
+	// With all rows copied, we make sure nothing was lost on the way
+	// before committing anything.
+	for _, table := range tables {
+		srcRows, err := countRows(ctx, srcTx, table)
+		if err != nil {
+			return nil, err
+		}
+		dstRows, err := countRows(ctx, dstTx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		if srcRows != dstRows || srcRows != stats.RowsCopied[table] {
+			return nil, fmt.Errorf("row count mismatch for table "+
+				"%s: source=%d, target=%d", table, srcRows,
+				dstRows)
+		}
+	}
+
+	srcRoots, err := fetchMssmtRoots(ctx, srcTx)
+	if err != nil {
+		return nil, err
+	}
+	dstRoots, err := verifyMssmtRoots(ctx, dstTx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify MS-SMT roots of "+
+			"target database: %w", err)
+	}
+	if len(srcRoots) != len(dstRoots) {
+		return nil, fmt.Errorf("MS-SMT root count mismatch: "+
+			"source=%d, target=%d", len(srcRoots), len(dstRoots))
+	}
+	for namespace, srcRoot := range srcRoots {
+		dstRoot, ok := dstRoots[namespace]
+		if !ok || dstRoot != srcRoot {
+			return nil, fmt.Errorf("MS-SMT root mismatch for "+
+				"namespace %s", namespace)
+		}
+	}
+	stats.MssmtRootsVerified = len(dstRoots)
+
+	if err := dstTx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit target transaction: "+
+			"%w", err)
+	}
+
+	return stats, nil
+}
+
+// checkSchemaVersion makes sure the schema of the given database is at the
+// latest migration version and that the last migration completed.
+func checkSchemaVersion(ctx context.Context, db migrationQuerier) error {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := db.QueryRowContext(ctx, fmt.Sprintf(
+		"SELECT version, dirty FROM %s", schemaMigrationsTable,
+	)).Scan(&version, &dirty)
+	if err != nil {
+		return fmt.Errorf("unable to fetch schema version: %w", err)
+	}
+
+	if dirty || version != LatestMigrationVersion {
+		return fmt.Errorf("%w: version=%d, dirty=%v, expected=%d",
+			ErrSchemaVersionMismatch, version, dirty,
+			LatestMigrationVersion)
+	}
+
+	return nil
+}
+
+// sqliteTablesInOrder returns the names of all tables of the given SQLite
+// database, ordered so that every table comes after all the tables it
+// references with a foreign key.
+func sqliteTablesInOrder(ctx context.Context,
+	db migrationQuerier) ([]string, error) {
+
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master "+
+		"WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY "+
+		"name")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tables: %w", err)
+	}
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+
+		if table == schemaMigrationsTable {
+			continue
+		}
+
+		tables = append(tables, table)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	references := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		references[table], err = sqliteReferencedTables(ctx, db, table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// We do a depth first traversal of the references, so each table is
+	// only added once all the tables it references were added.
+	var (
+		ordered  = make([]string, 0, len(tables))
+		added    = make(map[string]bool, len(tables))
+		visiting = make(map[string]bool)
+	)
+	var visit func(table string) error
+	visit = func(table string) error {
+		if added[table] {
+			return nil
+		}
+		if visiting[table] {
+			return fmt.Errorf("circular foreign key reference "+
+				"involving table %s", table)
+		}
+
+		visiting[table] = true
+		for _, referenced := range references[table] {
+			if err := visit(referenced); err != nil {
+				return err
+			}
+		}
+		visiting[table] = false
+
+		added[table] = true
+		ordered = append(ordered, table)
+
+		return nil
+	}
+	for _, table := range tables {
+		if err := visit(table); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// sqliteReferencedTables returns the sorted names of all other tables the
+// given table references with a foreign key.
+func sqliteReferencedTables(ctx context.Context, db migrationQuerier,
+	table string) ([]string, error) {
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT DISTINCT \"table\" FROM pragma_foreign_key_list('%s')",
+		table,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list foreign keys of table "+
+			"%s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var referenced []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		// Self references are resolved by copying the rows in the
+		// order they were inserted.
+		if name == table {
+			continue
+		}
+
+		referenced = append(referenced, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(referenced)
+
+	return referenced, nil
+}
+
+// sqliteColumns returns the names of all columns of the given SQLite table.
+func sqliteColumns(ctx context.Context, db migrationQuerier,
+	table string) ([]string, error) {
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT name FROM pragma_table_info('%s') ORDER BY cid", table,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// postgresColumnTypes returns the data types of all columns of the given
+// Postgres table, keyed by the column name.
+func postgresColumnTypes(ctx context.Context, db migrationQuerier,
+	table string) (map[string]string, error) {
+
+	rows, err := db.QueryContext(ctx, "SELECT column_name, data_type "+
+		"FROM information_schema.columns WHERE table_schema = "+
+		"current_schema() AND table_name = $1", table)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list columns: %w", err)
+	}
+	defer rows.Close()
+
+	columnTypes := make(map[string]string)
+	for rows.Next() {
+		var column, dataType string
+		if err := rows.Scan(&column, &dataType); err != nil {
+			return nil, err
+		}
+
+		columnTypes[column] = dataType
+	}
+
+	return columnTypes, rows.Err()
+}
+
+// countRows returns the number of rows of the given table.
+func countRows(ctx context.Context, db migrationQuerier,
+	table string) (int64, error) {
+
+	var numRows int64
+	err := db.QueryRowContext(
+		ctx, fmt.Sprintf("SELECT COUNT(*) FROM \"%s\"", table),
+	).Scan(&numRows)
+	if err != nil {
+		return 0, fmt.Errorf("unable to count rows of table %s: %w",
+			table, err)
+	}
+
+	return numRows, nil
+}
+
+// copyTable copies all rows of the given table from the SQLite source to the
+// Postgres target database and returns the number of copied rows.
+func copyTable(ctx context.Context, src, dst migrationQuerier,
+	table string) (int64, error) {
+
+	columns, err := sqliteColumns(ctx, src, table)
+	if err != nil {
+		return 0, err
+	}
+	columnTypes, err := postgresColumnTypes(ctx, dst, table)
+	if err != nil {
+		return 0, err
+	}
+
+	quotedColumns := make([]string, len(columns))
+	targetTypes := make([]string, len(columns))
+	for i, column := range columns {
+		dataType, ok := columnTypes[column]
+		if !ok {
+			return 0, fmt.Errorf("column %s doesn't exist in "+
+				"target database", column)
+		}
+
+		quotedColumns[i] = fmt.Sprintf("\"%s\"", column)
+		targetTypes[i] = dataType
+	}
+	columnList := strings.Join(quotedColumns, ", ")
+
+	// The rows are copied in the order they were inserted, which makes
+	// sure rows referencing other rows of the same table are inserted
+	// after the referenced rows.
+	rows, err := src.QueryContext(ctx, fmt.Sprintf(
+		"SELECT %s FROM \"%s\" ORDER BY rowid", columnList, table,
+	))
+	if err != nil {
+		return 0, fmt.Errorf("unable to read rows: %w", err)
+	}
+	defer rows.Close()
+
+	batchSize := migrationBatchSize
+	if batchSize*len(columns) > postgresMaxParams {
+		batchSize = postgresMaxParams / len(columns)
+	}
+
+	var (
+		numRows int64
+		batch   = make([]any, 0, batchSize*len(columns))
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		query := insertRowsQuery(
+			table, columnList, len(columns),
+			len(batch)/len(columns),
+		)
+		if _, err := dst.ExecContext(ctx, query, batch...); err != nil {
+			return fmt.Errorf("unable to insert rows: %w", err)
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return 0, fmt.Errorf("unable to read row: %w", err)
+		}
+
+		for i, value := range values {
+			converted, err := convertSqliteValue(
+				value, targetTypes[i],
+			)
+			if err != nil {
+				return 0, fmt.Errorf("unable to convert "+
+					"column %s: %w", columns[i], err)
+			}
+
+			batch = append(batch, converted)
+		}
+		numRows++
+
+		if len(batch) == cap(batch) {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("unable to read rows: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	return numRows, nil
+}
+
+// insertRowsQuery returns a query that inserts the given number of rows into
+// the given columns of a Postgres table.
+func insertRowsQuery(table, columnList string, numColumns,
+	numRows int) string {
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "INSERT INTO \"%s\" (%s) VALUES ", table,
+		columnList)
+
+	param := 1
+	for row := 0; row < numRows; row++ {
+		if row > 0 {
+			query.WriteString(", ")
+		}
+
+		query.WriteString("(")
+		for column := 0; column < numColumns; column++ {
+			if column > 0 {
+				query.WriteString(", ")
+			}
+
+			fmt.Fprintf(&query, "$%d", param)
+			param++
+		}
+		query.WriteString(")")
+	}
+
+	return query.String()
+}
+
+// convertSqliteValue converts a value read from SQLite, which uses dynamic
+// typing, into a value that can be stored in a Postgres column of the given
+// data type.
+func convertSqliteValue(value any, dataType string) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch dataType {
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		}
+
+	case "bytea":
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+
+	case "text", "character varying":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+
+	case "smallint", "integer", "bigint":
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+
+	case "timestamp without time zone":
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+
+		// Timestamps that couldn't be parsed by the driver are handed
+		// to Postgres as they are.
+		case string:
+			return v, nil
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported column type %s", dataType)
+	}
+
+	return nil, fmt.Errorf("unable to convert %T to %s", value, dataType)
+}
+
+// resetPostgresSequences sets the sequence of every serial column to the
+// value after the largest value of the column, so new rows don't collide with
+// the copied ones.
+func resetPostgresSequences(ctx context.Context, db migrationQuerier) error {
+	rows, err := db.QueryContext(ctx, "SELECT table_name, column_name "+
+		"FROM information_schema.columns WHERE table_schema = "+
+		"current_schema() AND column_default LIKE 'nextval(%'")
+	if err != nil {
+		return fmt.Errorf("unable to list serial columns: %w", err)
+	}
+
+	type serialColumn struct {
+		table  string
+		column string
+	}
+	var serialColumns []serialColumn
+	for rows.Next() {
+		var c serialColumn
+		if err := rows.Scan(&c.table, &c.column); err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		serialColumns = append(serialColumns, c)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, c := range serialColumns {
+		_, err := db.ExecContext(ctx, fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence($1, $2), "+
+				"COALESCE(MAX(\"%s\"), 0) + 1, false) FROM "+
+				"\"%s\"", c.column, c.table,
+		), c.table, c.column)
+		if err != nil {
+			return fmt.Errorf("unable to reset sequence of %s.%s: "+
+				"%w", c.table, c.column, err)
+		}
+	}
+
+	return nil
+}
+
+// fetchMssmtRoots returns the stored root hashes of all MS-SMTs, keyed by
+// their namespace.
+func fetchMssmtRoots(ctx context.Context,
+	db migrationQuerier) (map[string]mssmt.NodeHash, error) {
+
+	rows, err := db.QueryContext(
+		ctx, "SELECT namespace, root_hash FROM mssmt_roots",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch MS-SMT roots: %w", err)
+	}
+	defer rows.Close()
+
+	roots := make(map[string]mssmt.NodeHash)
+	for rows.Next() {
+		var (
+			namespace string
+			rootHash  []byte
+		)
+		if err := rows.Scan(&namespace, &rootHash); err != nil {
+			return nil, err
+		}
+
+		roots[namespace], err = newKey(rootHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root hash for "+
+				"namespace %s: %w", namespace, err)
+		}
+	}
+
+	return roots, rows.Err()
+}
+
+// mssmtNodeRow is a single stored MS-SMT node.
+type mssmtNodeRow struct {
+	lHashKey []byte
+	rHashKey []byte
+	key      []byte
+	value    []byte
+	sum      int64
+}
+
+// verifyMssmtRoots recomputes the root of every MS-SMT from its stored nodes
+// and makes sure it matches the stored root. The verified root hashes are
+// returned, keyed by their namespace.
+func verifyMssmtRoots(ctx context.Context,
+	db migrationQuerier) (map[string]mssmt.NodeHash, error) {
+
+	roots, err := fetchMssmtRoots(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	for namespace, rootHash := range roots {
+		nodes, err := fetchMssmtNodes(ctx, db, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = recomputeMssmtNode(nodes, rootHash, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MS-SMT for namespace "+
+				"%s: %w", namespace, err)
+		}
+	}
+
+	return roots, nil
+}
+
+// fetchMssmtNodes returns all stored nodes of the MS-SMT with the given
+// namespace, keyed by their node hash.
+func fetchMssmtNodes(ctx context.Context, db migrationQuerier,
+	namespace string) (map[mssmt.NodeHash]mssmtNodeRow, error) {
+
+	rows, err := db.QueryContext(ctx, "SELECT hash_key, l_hash_key, "+
+		"r_hash_key, key, value, sum FROM mssmt_nodes WHERE "+
+		"namespace = $1", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch MS-SMT nodes: %w", err)
+	}
+	defer rows.Close()
+
+	nodes := make(map[mssmt.NodeHash]mssmtNodeRow)
+	for rows.Next() {
+		var (
+			hashKey []byte
+			node    mssmtNodeRow
+		)
+		err := rows.Scan(
+			&hashKey, &node.lHashKey, &node.rHashKey, &node.key,
+			&node.value, &node.sum,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeHash, err := newKey(hashKey)
+		if err != nil {
+			return nil, err
+		}
+		nodes[nodeHash] = node
+	}
+
+	return nodes, rows.Err()
+}
+
+// recomputeMssmtNode recomputes the node with the given hash at the given
+// height of an MS-SMT from its stored descendants, and makes sure the hash and
+// sum of the node match the stored values.
+func recomputeMssmtNode(nodes map[mssmt.NodeHash]mssmtNodeRow,
+	nodeHash mssmt.NodeHash, height int) (mssmt.Node, error) {
+
+	row, ok := nodes[nodeHash]
+	if !ok {
+		// Empty subtrees are never stored.
+		if nodeHash == mssmt.EmptyTree[height].NodeHash() {
+			return mssmt.EmptyTree[height], nil
+		}
+
+		return nil, fmt.Errorf("node %v at height %d not found",
+			nodeHash, height)
+	}
+
+	var node mssmt.Node
+	switch {
+	// Since both children are nil, this is a leaf.
+	case row.lHashKey == nil && row.rHashKey == nil:
+		leaf := newStoredLeafNode(row.value, uint64(row.sum))
+
+		// We only store the key for compacted leaves.
+		if row.key == nil {
+			if height != mssmt.MaxTreeLevels {
+				return nil, fmt.Errorf("leaf %v at height %d "+
+					"has no key", nodeHash, height)
+			}
+
+			node = leaf
+			break
+		}
+
+		key, err := newKey(row.key)
+		if err != nil {
+			return nil, err
+		}
+		node = mssmt.NewCompactedLeafNode(height, &key, leaf)
+
+	case height >= mssmt.MaxTreeLevels:
+		return nil, fmt.Errorf("branch %v exceeds the maximum tree "+
+			"height", nodeHash)
+
+	default:
+		lHashKey, err := newKey(row.lHashKey)
+		if err != nil {
+			return nil, err
+		}
+		rHashKey, err := newKey(row.rHashKey)
+		if err != nil {
+			return nil, err
+		}
+
+		left, err := recomputeMssmtNode(nodes, lHashKey, height+1)
+		if err != nil {
+			return nil, err
+		}
+		right, err := recomputeMssmtNode(nodes, rHashKey, height+1)
+		if err != nil {
+			return nil, err
+		}
+
+		// We only keep the hash and sum of the branch, so the
+		// recomputed tree doesn't need to be kept in memory.
+		branch := mssmt.NewBranch(left, right)
+		node = mssmt.NewComputedBranch(
+			branch.NodeHash(), branch.NodeSum(),
+		)
+	}
+
+	if node.NodeHash() != nodeHash {
+		return nil, fmt.Errorf("hash mismatch for node %v at height "+
+			"%d: recomputed %v", nodeHash, height, node.NodeHash())
+	}
+	if node.NodeSum() != uint64(row.sum) {
+		return nil, fmt.Errorf("sum mismatch for node %v at height "+
+			"%d: stored %d, recomputed %d", nodeHash, height,
+			row.sum, node.NodeSum())
+	}
+
+	return node, nil
+}
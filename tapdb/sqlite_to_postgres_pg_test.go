@@ -0,0 +1,66 @@
+//go:build test_db_postgres
+
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrateSqliteToPostgres tests that all rows of a SQLite database are
+// copied to a Postgres database, and that the copied MS-SMTs are verified.
+func TestMigrateSqliteToPostgres(t *testing.T) {
+	ctx := context.Background()
+
+	src := NewTestSqliteDB(t)
+	dst := NewTestPostgresDB(t)
+
+	for i := 0; i < 3; i++ {
+		keyParams := sqlc.UpsertInternalKeyParams{
+			RawKey:    test.RandPubKey(t).SerializeCompressed(),
+			KeyFamily: 1,
+			KeyIndex:  int32(i),
+		}
+		_, err := src.UpsertInternalKey(ctx, keyParams)
+		require.NoError(t, err)
+	}
+
+	txCreator := func(tx *sql.Tx) TreeStore {
+		return src.WithTx(tx)
+	}
+	tree := mssmt.NewCompactedTree(NewTaprootAssetTreeStore(
+		NewTransactionExecutor(src, txCreator), "migrated",
+	))
+	insertRandLeaves(t, tree, 10)
+
+	stats, err := MigrateSqliteToPostgres(ctx, src, dst)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, stats.RowsCopied["internal_keys"])
+	require.Equal(t, 1, stats.MssmtRootsVerified)
+
+	srcRoot, err := tree.Root(ctx)
+	require.NoError(t, err)
+	dstRoots, err := verifyMssmtRoots(ctx, dst.DB)
+	require.NoError(t, err)
+	require.Equal(t, srcRoot.NodeHash(), dstRoots["migrated"])
+
+	// The sequences must have been reset, so new rows don't collide with
+	// the copied ones.
+	keyID, err := dst.UpsertInternalKey(ctx, sqlc.UpsertInternalKeyParams{
+		RawKey:    test.RandPubKey(t).SerializeCompressed(),
+		KeyFamily: 1,
+		KeyIndex:  3,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 4, keyID)
+
+	// A second migration into the now populated database must fail.
+	_, err = MigrateSqliteToPostgres(ctx, src, dst)
+	require.ErrorIs(t, err, ErrTargetNotEmpty)
+}
@@ -0,0 +1,156 @@
+package tapdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/stretchr/testify/require"
+)
+
+// insertRandLeaves inserts the given number of random leaves into the given
+// tree.
+func insertRandLeaves(t *testing.T, tree mssmt.Tree, numLeaves int) {
+	t.Helper()
+
+	ctx := context.Background()
+	for i := 0; i < numLeaves; i++ {
+		var key [32]byte
+		copy(key[:], test.RandBytes(32))
+
+		leaf := mssmt.NewLeafNode(
+			test.RandBytes(64), mssmt.RandLeafAmount(),
+		)
+		_, err := tree.Insert(ctx, key, leaf)
+		require.NoError(t, err)
+	}
+}
+
+// TestSqliteTablesInOrder tests that the tables of a SQLite database are
+// ordered so that every table comes after all the tables it references.
+func TestSqliteTablesInOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestSqliteDB(t)
+
+	tables, err := sqliteTablesInOrder(ctx, db.DB)
+	require.NoError(t, err)
+	require.NotContains(t, tables, schemaMigrationsTable)
+
+	position := make(map[string]int, len(tables))
+	for i, table := range tables {
+		position[table] = i
+	}
+	require.Len(t, position, len(tables))
+
+	for _, table := range tables {
+		referenced, err := sqliteReferencedTables(ctx, db.DB, table)
+		require.NoError(t, err)
+
+		for _, name := range referenced {
+			require.Contains(t, position, name)
+			require.Less(
+				t, position[name], position[table],
+				"%s must be copied before %s", name, table,
+			)
+		}
+	}
+}
+
+// TestVerifyMssmtRoots tests that the roots of compacted and full MS-SMTs are
+// recomputed correctly from their stored nodes, and that modified nodes are
+// detected.
+func TestVerifyMssmtRoots(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := NewTestDB(t)
+
+	txCreator := func(tx *sql.Tx) TreeStore {
+		return db.WithTx(tx)
+	}
+	treeDB := NewTransactionExecutor(db, txCreator)
+
+	compactedTree := mssmt.NewCompactedTree(
+		NewTaprootAssetTreeStore(treeDB, "compacted"),
+	)
+	insertRandLeaves(t, compactedTree, 20)
+
+	fullTree := mssmt.NewFullTree(NewTaprootAssetTreeStore(treeDB, "full"))
+	insertRandLeaves(t, fullTree, 5)
+
+	roots, err := verifyMssmtRoots(ctx, db.DB)
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+
+	compactedRoot, err := compactedTree.Root(ctx)
+	require.NoError(t, err)
+	require.Equal(t, compactedRoot.NodeHash(), roots["compacted"])
+
+	fullRoot, err := fullTree.Root(ctx)
+	require.NoError(t, err)
+	require.Equal(t, fullRoot.NodeHash(), roots["full"])
+
+	// A modified leaf results in a different root, which must be detected.
+	_, err = db.DB.ExecContext(
+		ctx, "UPDATE mssmt_nodes SET value = $1 WHERE namespace = $2 "+
+			"AND key IS NOT NULL", test.RandBytes(64), "compacted",
+	)
+	require.NoError(t, err)
+
+	_, err = verifyMssmtRoots(ctx, db.DB)
+	require.ErrorContains(t, err, "hash mismatch")
+}
+
+// TestConvertSqliteValue tests the conversion of SQLite values to values that
+// can be stored in Postgres columns.
+func TestConvertSqliteValue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	testCases := []struct {
+		value    any
+		dataType string
+		expected any
+		err      bool
+	}{
+		{nil, "boolean", nil, false},
+		{int64(1), "boolean", true, false},
+		{int64(0), "boolean", false, false},
+		{true, "boolean", true, false},
+		{"abc", "bytea", []byte("abc"), false},
+		{[]byte("abc"), "text", "abc", false},
+		{int64(7), "bigint", int64(7), false},
+		{now, "timestamp without time zone", now, false},
+		{int64(0), "timestamp without time zone", time.Unix(0, 0).UTC(),
+			false},
+		{1.5, "bigint", nil, true},
+		{int64(1), "jsonb", nil, true},
+	}
+
+	for _, tc := range testCases {
+		converted, err := convertSqliteValue(tc.value, tc.dataType)
+		if tc.err {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, converted)
+	}
+}
+
+// TestInsertRowsQuery tests the construction of multi row insert queries.
+func TestInsertRowsQuery(t *testing.T) {
+	t.Parallel()
+
+	query := insertRowsQuery("macaroons", `"id", "root_key"`, 2, 2)
+	require.Equal(
+		t, `INSERT INTO "macaroons" ("id", "root_key") VALUES `+
+			`($1, $2), ($3, $4)`, query,
+	)
+}
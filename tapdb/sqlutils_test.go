@@ -268,7 +268,7 @@ func newDbHandleFromDb(db *BaseDB) *DbHandler {
 			return db.WithTx(tx)
 		},
 	)
-	assetMintingStore := NewAssetMintingStore(assetMintingDB)
+	assetMintingStore := NewAssetMintingStore(assetMintingDB, nil)
 
 	// Gain a handle to the active assets store.
 	assetsDB := NewTransactionExecutor(
@@ -286,6 +286,7 @@ func newDbHandleFromDb(db *BaseDB) *DbHandler {
 
 	activeAssetsStore := NewAssetStore(
 		assetsDB, metaDB, testClock, db.Backend(),
+		NoOpDBEventNotifier{}, nil,
 	)
 
 	return &DbHandler{
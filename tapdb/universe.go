@@ -35,6 +35,13 @@ type (
 	// UniverseKeys is the set of leaf keys inserted into a universe.
 	UniverseKeys = sqlc.FetchUniverseKeysRow
 
+	// UniverseLeafKey is the leaf key of a single universe leaf.
+	UniverseLeafKey = sqlc.FetchUniverseLeafKeyRow
+
+	// UniverseLeafKeyQuery is used to query for the leaf key of the
+	// universe leaf stored at a given MS-SMT key.
+	UniverseLeafKeyQuery = sqlc.FetchUniverseLeafKeyParams
+
 	// UniverseLeaf is a universe leaf.
 	UniverseLeaf = sqlc.QueryUniverseLeavesRow
 
@@ -99,6 +106,11 @@ type BaseUniverseStore interface {
 	FetchUniverseKeys(ctx context.Context,
 		arg UniverseLeafKeysQuery) ([]UniverseKeys, error)
 
+	// FetchUniverseLeafKey fetches the leaf key of the universe leaf that
+	// is stored at the given MS-SMT key within a namespace.
+	FetchUniverseLeafKey(ctx context.Context,
+		arg UniverseLeafKeyQuery) (UniverseLeafKey, error)
+
 	// UpsertMultiverseRoot upserts a multiverse root in the database.
 	UpsertMultiverseRoot(ctx context.Context,
 		arg UpsertMultiverseRoot) (int64, error)
@@ -115,6 +127,10 @@ type BaseUniverseStore interface {
 // BaseUniverseStoreOptions is the set of options for universe tree queries.
 type BaseUniverseStoreOptions struct {
 	readOnly bool
+
+	// allowReplica governs if the transaction may be executed on a read
+	// replica of the database.
+	allowReplica bool
 }
 
 // ReadOnly returns true if the transaction is read-only.
@@ -130,6 +146,25 @@ func NewBaseUniverseReadTx() BaseUniverseStoreOptions {
 	}
 }
 
+// AllowReplica returns true if the transaction may be executed on a read
+// replica.
+//
+// NOTE: This implements the ReplicaTxOptions interface.
+func (b *BaseUniverseStoreOptions) AllowReplica() bool {
+	return b.allowReplica
+}
+
+// NewBaseUniverseReplicaReadTx creates a new read-only transaction for the
+// base universe that may be executed on a read replica. This should only be
+// used for lookups that can tolerate slightly stale data, such as serving
+// proofs to remote universe clients.
+func NewBaseUniverseReplicaReadTx() BaseUniverseStoreOptions {
+	return BaseUniverseStoreOptions{
+		readOnly:     true,
+		allowReplica: true,
+	}
+}
+
 // BatchedUniverseTree is a wrapper around the base universe tree that allows us
 // to perform batch queries with all the relevant query interfaces.
 type BatchedUniverseTree interface {
@@ -149,16 +184,22 @@ type BaseUniverseTree struct {
 	id universe.Identifier
 
 	smtNamespace string
+
+	// proofStore stores the raw proofs of the universe leaves.
+	proofStore *UniverseProofStore
 }
 
 // NewBaseUniverseTree creates a new base Universe tree.
-func NewBaseUniverseTree(db BatchedUniverseTree,
-	id universe.Identifier) *BaseUniverseTree {
+func NewBaseUniverseTree(db BatchedUniverseTree, id universe.Identifier,
+	options ...UniverseProofOption) *BaseUniverseTree {
+
+	opts := applyUniverseProofOpts(options)
 
 	return &BaseUniverseTree{
 		db:           db,
 		id:           id,
 		smtNamespace: id.String(),
+		proofStore:   opts.proofStore,
 	}
 }
 
@@ -332,15 +373,19 @@ func (b *BaseUniverseTree) RegisterIssuance(ctx context.Context,
 	key universe.LeafKey, leaf *universe.Leaf,
 	metaReveal *proof.MetaReveal) (*universe.Proof, error) {
 
-	var (
-		writeTx BaseUniverseStoreOptions
+	// The raw proof is offloaded before the DB transaction, if configured.
+	leafNode, err := b.proofStore.leafNode(ctx, leaf)
+	if err != nil {
+		return nil, err
+	}
 
-		err           error
+	var (
+		writeTx       BaseUniverseStoreOptions
 		issuanceProof *universe.Proof
 	)
 	dbErr := b.db.ExecTx(ctx, &writeTx, func(dbTx BaseUniverseStore) error {
 		issuanceProof, err = universeUpsertProofLeaf(
-			ctx, dbTx, b.id, key, leaf, metaReveal,
+			ctx, dbTx, b.id, key, leaf, leafNode, metaReveal,
 		)
 		return err
 	})
@@ -352,7 +397,8 @@ func (b *BaseUniverseTree) RegisterIssuance(ctx context.Context,
 }
 
 // universeUpsertProofLeaf upserts a proof leaf within the universe tree (stored
-// at the proof leaf key).
+// at the proof leaf key). The given MS-SMT leaf node is inserted for the leaf,
+// which references the raw proof if it was offloaded.
 //
 // This function returns the inserted/updated proof leaf and the new universe
 // root.
@@ -361,6 +407,7 @@ func (b *BaseUniverseTree) RegisterIssuance(ctx context.Context,
 // broader DB updates.
 func universeUpsertProofLeaf(ctx context.Context, dbTx BaseUniverseStore,
 	id universe.Identifier, key universe.LeafKey, leaf *universe.Leaf,
+	leafNode *mssmt.LeafNode,
 	metaReveal *proof.MetaReveal) (*universe.Proof, error) {
 
 	namespace := id.String()
@@ -369,10 +416,6 @@ func universeUpsertProofLeaf(ctx context.Context, dbTx BaseUniverseStore,
 	// the minting key, as that'll be the key in the SMT itself.
 	smtKey := key.UniverseKey()
 
-	// The value stored in the MS-SMT will be the serialized Leaf, so we'll
-	// convert that into raw bytes now.
-	leafNode := leaf.SmtLeafNode()
-
 	var groupKeyBytes []byte
 	if id.GroupKey != nil {
 		groupKeyBytes = schnorr.SerializePubKey(id.GroupKey)
@@ -572,6 +615,12 @@ func (b *BaseUniverseTree) FetchIssuanceProof(ctx context.Context,
 		return nil, dbErr
 	}
 
+	// Offloaded raw proofs are only fetched after the transaction, so we
+	// don't hold it open while waiting for the blob store.
+	if err := b.proofStore.resolveProofs(ctx, proofs); err != nil {
+		return nil, err
+	}
+
 	return proofs, nil
 }
 
@@ -581,7 +630,9 @@ func (b *BaseUniverseTree) FetchIssuanceProof(ctx context.Context,
 // proof will be returned for each minting outpoint.
 //
 // NOTE: This function accepts a database transaction and is called when making
-// broader DB updates.
+// broader DB updates. The leaves of the returned proofs only hold the stored
+// MS-SMT leaf values. The caller must resolve them with
+// UniverseProofStore.resolveProofs once the transaction is done.
 func universeFetchProofLeaf(ctx context.Context,
 	id universe.Identifier, universeKey universe.LeafKey,
 	dbTx BaseUniverseStore) ([]*universe.Proof, error) {
@@ -662,17 +713,6 @@ func universeFetchProofLeaf(ctx context.Context,
 			return err
 		}
 
-		// We only need to obtain the asset at this point, so we'll do
-		// a sparse decode here to decode only the asset record.
-		var leafAsset asset.Asset
-		assetRecord := proof.AssetLeafRecord(&leafAsset)
-		err = proof.SparseDecode(
-			bytes.NewReader(leaf.GenesisProof), assetRecord,
-		)
-		if err != nil {
-			return fmt.Errorf("unable to decode proof: %w", err)
-		}
-
 		issuanceProof := &universe.Proof{
 			LeafKey:                universeKey,
 			UniverseRoot:           rootNode,
@@ -682,7 +722,6 @@ func universeFetchProofLeaf(ctx context.Context,
 					Genesis: leafAssetGen,
 				},
 				RawProof: leaf.GenesisProof,
-				Asset:    &leafAsset,
 				Amt:      uint64(leaf.SumAmt),
 			},
 		}
@@ -736,27 +775,14 @@ func mintingKeys(ctx context.Context, dbTx BaseUniverseStore,
 
 	var leafKeys []universe.LeafKey
 	err = fn.ForEachErr(universeKeys, func(key UniverseKeys) error {
-		scriptKeyPub, err := schnorr.ParsePubKey(
-			key.ScriptKeyBytes,
+		leafKey, err := parseLeafKey(
+			key.MintingPoint, key.ScriptKeyBytes,
 		)
 		if err != nil {
 			return err
 		}
-		scriptKey := asset.NewScriptKey(scriptKeyPub)
 
-		var genPoint wire.OutPoint
-		err = readOutPoint(
-			bytes.NewReader(key.MintingPoint), 0, 0,
-			&genPoint,
-		)
-		if err != nil {
-			return err
-		}
-
-		leafKeys = append(leafKeys, universe.LeafKey{
-			OutPoint:  genPoint,
-			ScriptKey: &scriptKey,
-		})
+		leafKeys = append(leafKeys, leafKey)
 
 		return nil
 	})
@@ -767,6 +793,29 @@ func mintingKeys(ctx context.Context, dbTx BaseUniverseStore,
 	return leafKeys, nil
 }
 
+// parseLeafKey parses a universe leaf key from its serialized minting point and
+// script key.
+func parseLeafKey(mintingPoint, scriptKeyBytes []byte) (universe.LeafKey,
+	error) {
+
+	scriptKeyPub, err := schnorr.ParsePubKey(scriptKeyBytes)
+	if err != nil {
+		return universe.LeafKey{}, err
+	}
+	scriptKey := asset.NewScriptKey(scriptKeyPub)
+
+	var genPoint wire.OutPoint
+	err = readOutPoint(bytes.NewReader(mintingPoint), 0, 0, &genPoint)
+	if err != nil {
+		return universe.LeafKey{}, err
+	}
+
+	return universe.LeafKey{
+		OutPoint:  genPoint,
+		ScriptKey: &scriptKey,
+	}, nil
+}
+
 // MintingKeys returns all the keys inserted in the universe.
 func (b *BaseUniverseTree) MintingKeys(ctx context.Context,
 	q universe.UniverseLeafKeysQuery) ([]universe.LeafKey, error) {
@@ -822,23 +871,14 @@ func (b *BaseUniverseTree) MintingLeaves(
 				return err
 			}
 
-			var genProof proof.Proof
-			err = genProof.Decode(bytes.NewReader(
-				dbLeaf.GenesisProof,
-			))
-			if err != nil {
-				return fmt.Errorf("unable to decode proof: %w",
-					err)
-			}
-
 			// Now that we have the leaves, we'll encode them all
-			// into the set of minting leaves.
+			// into the set of minting leaves. The raw proofs are
+			// resolved once the transaction is done.
 			leaf := universe.Leaf{
 				GenesisWithGroup: universe.GenesisWithGroup{
 					Genesis: leafAssetGen,
 				},
 				RawProof: dbLeaf.GenesisProof,
-				Asset:    &genProof.Asset,
 				Amt:      uint64(dbLeaf.SumAmt),
 			}
 			if b.id.GroupKey != nil {
@@ -856,6 +896,15 @@ func (b *BaseUniverseTree) MintingLeaves(
 		return nil, dbErr
 	}
 
+	// Offloaded raw proofs are only fetched after the transaction, so we
+	// don't hold it open while waiting for the blob store.
+	for idx := range leaves {
+		err := b.proofStore.resolveLeaf(ctx, &leaves[idx])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return leaves, nil
 }
 
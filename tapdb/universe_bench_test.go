@@ -0,0 +1,127 @@
+package tapdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/stretchr/testify/require"
+)
+
+// benchLeafCounts is the set of universe tree sizes the universe benchmarks
+// are run against.
+var benchLeafCounts = []int{100, 250}
+
+// BenchmarkUniverseRegisterIssuance measures the DB insert throughput of
+// universe issuance leaves, which includes storing the proof and updating the
+// backing MS-SMT.
+func BenchmarkUniverseRegisterIssuance(b *testing.B) {
+	ctx := context.Background()
+
+	id := randUniverseID(b, false)
+	baseUniverse, _ := newTestUniverse(b, id)
+
+	// Generate all leaves up front, so only the DB inserts are measured.
+	assetGen := asset.RandGenesis(b, asset.Normal)
+	leafKeys := make([]universe.LeafKey, b.N)
+	leaves := make([]universe.Leaf, b.N)
+	for i := 0; i < b.N; i++ {
+		leafKeys[i] = randLeafKey(b)
+		leaves[i] = randMintingLeaf(b, assetGen, id.GroupKey)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := baseUniverse.RegisterIssuance(
+			ctx, leafKeys[i], &leaves[i], nil,
+		)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkUniverseSyncDiff measures the leaf diff step of a universe sync:
+// fetching the leaf keys of a remote and local tree, computing the set of
+// missing keys, then fetching and verifying the missing proofs from the remote
+// tree.
+func BenchmarkUniverseSyncDiff(b *testing.B) {
+	ctx := context.Background()
+
+	for _, numLeaves := range benchLeafCounts {
+		id := randUniverseID(b, false)
+
+		// The remote and local universe need to be backed by different
+		// databases, as they share the same identifier. The local
+		// universe only knows about half of the leaves of the remote.
+		remoteUniverse, _ := newTestUniverse(b, id)
+		localUniverse, _ := newTestUniverse(b, id)
+
+		assetGen := asset.RandGenesis(b, asset.Normal)
+		for i := 0; i < numLeaves; i++ {
+			leafKey := randLeafKey(b)
+			leaf := randMintingLeaf(b, assetGen, id.GroupKey)
+
+			_, err := remoteUniverse.RegisterIssuance(
+				ctx, leafKey, &leaf, nil,
+			)
+			require.NoError(b, err)
+
+			if i%2 == 1 {
+				continue
+			}
+
+			_, err = localUniverse.RegisterIssuance(
+				ctx, leafKey, &leaf, nil,
+			)
+			require.NoError(b, err)
+		}
+
+		remoteRoot, _, err := remoteUniverse.RootNode(ctx)
+		require.NoError(b, err)
+
+		keysQuery := universe.UniverseLeafKeysQuery{
+			Id: id,
+		}
+
+		name := fmt.Sprintf("leaves-%v", numLeaves)
+		b.Run(name, func(b *testing.B) {
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				diffUniverseLeaves(
+					b, remoteUniverse, localUniverse,
+					keysQuery, remoteRoot,
+				)
+			}
+		})
+	}
+}
+
+// diffUniverseLeaves performs the same leaf diff a universe sync does between
+// the given remote and local universe tree.
+func diffUniverseLeaves(b *testing.B,
+	remoteUniverse, localUniverse *BaseUniverseTree,
+	keysQuery universe.UniverseLeafKeysQuery, remoteRoot mssmt.Node) {
+
+	ctx := context.Background()
+
+	remoteKeys, err := remoteUniverse.MintingKeys(ctx, keysQuery)
+	require.NoError(b, err)
+
+	localKeys, err := localUniverse.MintingKeys(ctx, keysQuery)
+	require.NoError(b, err)
+
+	keysToFetch := fn.SetDiff(remoteKeys, localKeys)
+	for _, key := range keysToFetch {
+		uniProofs, err := remoteUniverse.FetchIssuanceProof(ctx, key)
+		require.NoError(b, err)
+
+		require.True(b, uniProofs[0].VerifyRoot(remoteRoot))
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
-	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/clock"
@@ -62,6 +61,28 @@ type (
 
 	// QueryUniServersParams is used to query for universe servers.
 	QueryUniServersParams = sqlc.QueryUniverseServersParams
+
+	// NewFedServerScope is used to add an asset to the scope of a
+	// universe server.
+	NewFedServerScope = sqlc.InsertFederationServerScopeParams
+
+	// FedServerScopeEntry is a single asset of the scope of a universe
+	// server returned from a query.
+	FedServerScopeEntry = sqlc.QueryFederationServerScopesRow
+
+	// NewServerDailySyncs is used to add to the sync volume of a universe
+	// server on a day.
+	NewServerDailySyncs = sqlc.UpsertUniverseServerDailySyncsParams
+
+	// ServerDailySyncs is the sync volume of a universe server on a day.
+	ServerDailySyncs = sqlc.UniverseServerDailySync
+
+	// ServerDailySyncsQuery is used to query the sync volume of the
+	// universe servers on a range of days.
+	ServerDailySyncsQuery = sqlc.QueryUniverseServerDailySyncsParams
+
+	// NewOperatorKey is used to pin the operator key of a universe server.
+	NewOperatorKey = sqlc.InsertUniverseOperatorKeyParams
 )
 
 var (
@@ -144,6 +165,42 @@ type UniverseServerStore interface {
 	QueryUniverseServers(ctx context.Context,
 		arg sqlc.QueryUniverseServersParams) ([]sqlc.UniverseServer,
 		error)
+
+	// InsertFederationServerScope adds an asset ID or group key to the
+	// scope of a universe server.
+	InsertFederationServerScope(ctx context.Context,
+		arg NewFedServerScope) error
+
+	// DeleteFederationServerScopes removes the scope of a universe server.
+	DeleteFederationServerScopes(ctx context.Context, serversID int64) error
+
+	// QueryFederationServerScopes returns the scopes of all universe
+	// servers.
+	QueryFederationServerScopes(
+		ctx context.Context) ([]FedServerScopeEntry, error)
+
+	// UpsertUniverseServerDailySyncs adds to the sync volume of a universe
+	// server on a day.
+	UpsertUniverseServerDailySyncs(ctx context.Context,
+		arg NewServerDailySyncs) error
+
+	// QueryUniverseServerDailySyncs returns the sync volume of the
+	// universe servers on a range of days.
+	QueryUniverseServerDailySyncs(ctx context.Context,
+		arg ServerDailySyncsQuery) ([]ServerDailySyncs, error)
+
+	// InsertUniverseOperatorKey pins the operator key of a universe
+	// server, unless a key is already pinned for it.
+	InsertUniverseOperatorKey(ctx context.Context, arg NewOperatorKey) error
+
+	// FetchUniverseOperatorKey returns the operator key that is pinned for
+	// a universe server.
+	FetchUniverseOperatorKey(ctx context.Context,
+		serverHost string) ([]byte, error)
+
+	// DeleteUniverseOperatorKey removes the pinned operator key of a
+	// universe server.
+	DeleteUniverseOperatorKey(ctx context.Context, serverHost string) error
 }
 
 // UniverseFederationOptions is the database tx object for the universe server store.
@@ -189,11 +246,18 @@ type UniverseFederationDB struct {
 
 	globalCfg *atomic.Pointer[globalSyncCfgs]
 	assetCfgs *atomic.Pointer[assetSyncCfgs]
+
+	// proofStore stores the raw proofs of the universe leaves that are
+	// referenced by the proof sync log.
+	proofStore *UniverseProofStore
 }
 
 // NewUniverseFederationDB makes a new Universe federation DB.
 func NewUniverseFederationDB(db BatchedUniverseServerStore,
-	clock clock.Clock) *UniverseFederationDB {
+	clock clock.Clock,
+	options ...UniverseProofOption) *UniverseFederationDB {
+
+	opts := applyUniverseProofOpts(options)
 
 	var (
 		globalCfgPtr atomic.Pointer[globalSyncCfgs]
@@ -204,10 +268,11 @@ func NewUniverseFederationDB(db BatchedUniverseServerStore,
 	assetCfgsPtr.Store(&assetSyncCfgs{})
 
 	return &UniverseFederationDB{
-		db:        db,
-		clock:     clock,
-		globalCfg: &globalCfgPtr,
-		assetCfgs: &assetCfgsPtr,
+		db:         db,
+		clock:      clock,
+		globalCfg:  &globalCfgPtr,
+		assetCfgs:  &assetCfgsPtr,
+		proofStore: opts.proofStore,
 	}
 }
 
@@ -284,14 +349,131 @@ func (u *UniverseFederationDB) RemoveServers(ctx context.Context,
 				uniID = -1
 			}
 
-			return db.DeleteUniverseServer(ctx, DelUniverseServer{
+			err := db.DeleteUniverseServer(ctx, DelUniverseServer{
 				TargetID:     uniID,
 				TargetServer: a.HostStr(),
 			})
+			if err != nil || a.HostStr() == "" {
+				return err
+			}
+
+			// Removing a server also removes its pinned operator
+			// key, so a server that rotated its key can be trusted
+			// again by removing and re-adding it.
+			return db.DeleteUniverseOperatorKey(ctx, a.HostStr())
 		})
 	})
 }
 
+// QueryFederationServerScopes returns the scopes of all federation servers
+// that have one, keyed by the host of the server.
+func (u *UniverseFederationDB) QueryFederationServerScopes(
+	ctx context.Context) (map[string]universe.FedServerScope, error) {
+
+	var scopes map[string]universe.FedServerScope
+
+	readTx := NewUniverseFederationReadTx()
+	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseServerStore) error {
+		scopes = make(map[string]universe.FedServerScope)
+
+		entries, err := db.QueryFederationServerScopes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			scope := scopes[entry.ServerHost]
+
+			switch {
+			case len(entry.AssetID) > 0:
+				var assetID asset.ID
+				copy(assetID[:], entry.AssetID)
+
+				scope.AssetIDs = append(scope.AssetIDs, assetID)
+
+			case len(entry.GroupKey) > 0:
+				groupKey, err := schnorr.ParsePubKey(
+					entry.GroupKey,
+				)
+				if err != nil {
+					return fmt.Errorf("unable to parse "+
+						"group key: %w", err)
+				}
+
+				scope.GroupKeys = append(
+					scope.GroupKeys, groupKey,
+				)
+			}
+
+			scopes[entry.ServerHost] = scope
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return scopes, nil
+}
+
+// SetFederationServerScope replaces the scope of the given federation server.
+// An empty scope removes any restriction.
+func (u *UniverseFederationDB) SetFederationServerScope(ctx context.Context,
+	addr universe.ServerAddr, scope universe.FedServerScope) error {
+
+	var writeTx UniverseFederationOptions
+	return u.db.ExecTx(ctx, &writeTx, func(db UniverseServerStore) error {
+		servers, err := db.QueryUniverseServers(
+			ctx, QueryUniServersParams{
+				ServerHost: sqlStr(addr.HostStr()),
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if len(servers) == 0 {
+			return fmt.Errorf("%w: %v",
+				universe.ErrUnknownUniverseServer,
+				addr.HostStr())
+		}
+
+		serversID := servers[0].ID
+		err = db.DeleteFederationServerScopes(ctx, serversID)
+		if err != nil {
+			return err
+		}
+
+		for idx := range scope.AssetIDs {
+			err := db.InsertFederationServerScope(
+				ctx, NewFedServerScope{
+					ServersID: serversID,
+					AssetID:   scope.AssetIDs[idx][:],
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, groupKey := range scope.GroupKeys {
+			err := db.InsertFederationServerScope(
+				ctx, NewFedServerScope{
+					ServersID: serversID,
+					GroupKey: schnorr.SerializePubKey(
+						groupKey,
+					),
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // LogNewSyncs logs a new sync event for each server. This can be used to keep
 // track of the last time we synced with a remote server.
 func (u *UniverseFederationDB) LogNewSyncs(ctx context.Context,
@@ -308,6 +490,114 @@ func (u *UniverseFederationDB) LogNewSyncs(ctx context.Context,
 	})
 }
 
+// LogSyncVolume logs a successful sync with the given server, in which the
+// given number of new leaves were synced from the server.
+func (u *UniverseFederationDB) LogSyncVolume(ctx context.Context,
+	addr universe.ServerAddr, numLeaves int) error {
+
+	var writeTx UniverseFederationOptions
+	return u.db.ExecTx(ctx, &writeTx, func(db UniverseServerStore) error {
+		return db.UpsertUniverseServerDailySyncs(
+			ctx, NewServerDailySyncs{
+				ServerHost:      addr.HostStr(),
+				DayStart:        statsDayStart(u.clock.Now()),
+				NumSyncs:        1,
+				NumSyncedLeaves: int64(numLeaves),
+			},
+		)
+	})
+}
+
+// QueryServerSyncStats returns the sync volume with the servers of the
+// federation per day.
+func (u *UniverseFederationDB) QueryServerSyncStats(ctx context.Context,
+	q universe.ServerSyncStatsQuery) ([]*universe.ServerSyncStats, error) {
+
+	var stats []*universe.ServerSyncStats
+
+	readTx := NewUniverseFederationReadTx()
+	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseServerStore) error {
+		dbStats, err := db.QueryUniverseServerDailySyncs(
+			ctx, ServerDailySyncsQuery{
+				StartTime:  statsDayStart(q.StartTime),
+				EndTime:    q.EndTime.UTC().Unix(),
+				ServerHost: fn.MapOptionZ(q.ServerHost, sqlStr),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		stats = make([]*universe.ServerSyncStats, len(dbStats))
+		for idx, s := range dbStats {
+			stats[idx] = &universe.ServerSyncStats{
+				ServerHost:      s.ServerHost,
+				Date:            statsDate(s.DayStart),
+				NumSyncs:        uint64(s.NumSyncs),
+				NumSyncedLeaves: uint64(s.NumSyncedLeaves),
+			}
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return stats, nil
+}
+
+// FetchOperatorKey returns the operator key that is pinned for the given
+// universe server, if any.
+func (u *UniverseFederationDB) FetchOperatorKey(ctx context.Context,
+	addr universe.ServerAddr) (fn.Option[*btcec.PublicKey], error) {
+
+	var operatorKey fn.Option[*btcec.PublicKey]
+
+	readTx := NewUniverseFederationReadTx()
+	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseServerStore) error {
+		keyBytes, err := db.FetchUniverseOperatorKey(
+			ctx, addr.HostStr(),
+		)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		key, err := btcec.ParsePubKey(keyBytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse operator key: %w",
+				err)
+		}
+		operatorKey = fn.Some(key)
+
+		return nil
+	})
+	if dbErr != nil {
+		return fn.None[*btcec.PublicKey](), dbErr
+	}
+
+	return operatorKey, nil
+}
+
+// PinOperatorKey pins the operator key of the given universe server. If a key
+// is already pinned for the server, it is kept as is.
+func (u *UniverseFederationDB) PinOperatorKey(ctx context.Context,
+	addr universe.ServerAddr, key *btcec.PublicKey) error {
+
+	var writeTx UniverseFederationOptions
+	return u.db.ExecTx(ctx, &writeTx, func(db UniverseServerStore) error {
+		return db.InsertUniverseOperatorKey(ctx, NewOperatorKey{
+			ServerHost:  addr.HostStr(),
+			OperatorKey: key.SerializeCompressed(),
+			PinnedAt:    u.clock.Now().UTC(),
+		})
+	})
+}
+
 // UpsertFederationProofSyncLog upserts a federation proof sync log entry for a
 // given universe server and proof.
 func (u *UniverseFederationDB) UpsertFederationProofSyncLog(
@@ -420,6 +710,13 @@ func (u *UniverseFederationDB) QueryFederationProofSyncLog(
 		return nil, err
 	}
 
+	// Offloaded raw proofs are only fetched after the transaction, so we
+	// don't hold it open while waiting for the blob store.
+	err = resolveProofSyncLogEntries(ctx, u.proofStore, proofSyncLogs)
+	if err != nil {
+		return nil, err
+	}
+
 	return proofSyncLogs, nil
 }
 
@@ -479,10 +776,21 @@ func (u *UniverseFederationDB) FetchPendingProofsSyncLog(ctx context.Context,
 		return nil, err
 	}
 
+	// Offloaded raw proofs are only fetched after the transaction, so we
+	// don't hold it open while waiting for the blob store.
+	err = resolveProofSyncLogEntries(ctx, u.proofStore, proofSyncLogs)
+	if err != nil {
+		return nil, err
+	}
+
 	return proofSyncLogs, nil
 }
 
 // fetchProofSyncLogEntry returns a proof sync log entry given a DB row.
+//
+// NOTE: The leaf of the returned entry only holds the stored MS-SMT leaf value.
+// The caller must resolve it with resolveProofSyncLogEntries once the
+// transaction is done.
 func fetchProofSyncLogEntry(ctx context.Context, entry ProofSyncLogEntry,
 	dbTx UniverseServerStore) (*universe.ProofSyncLogEntry, error) {
 
@@ -492,25 +800,11 @@ func fetchProofSyncLogEntry(ctx context.Context, entry ProofSyncLogEntry,
 		return nil, err
 	}
 
-	// We only need to obtain the asset at this point, so we'll do a sparse
-	// decode here to decode only the asset record.
-	var leafAsset asset.Asset
-	assetRecord := proof.AssetLeafRecord(&leafAsset)
-	err = proof.SparseDecode(
-		bytes.NewReader(entry.LeafGenesisProof), assetRecord,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode proof: %w", err)
-	}
-
 	leaf := &universe.Leaf{
 		GenesisWithGroup: universe.GenesisWithGroup{
-			Genesis:  leafAssetGen,
-			GroupKey: leafAsset.GroupKey,
+			Genesis: leafAssetGen,
 		},
 		RawProof: entry.LeafGenesisProof,
-		Asset:    &leafAsset,
-		Amt:      leafAsset.Amount,
 	}
 
 	// Parse leaf key from leaf DB row.
@@ -572,6 +866,29 @@ func fetchProofSyncLogEntry(ctx context.Context, entry ProofSyncLogEntry,
 	}, nil
 }
 
+// resolveProofSyncLogEntries resolves the leaves of the given proof sync log
+// entries and fills in the leaf fields that are taken from the decoded asset.
+// This must be called once the transaction the entries were read in is done,
+// as resolving offloaded raw proofs can take a network round trip.
+func resolveProofSyncLogEntries(ctx context.Context,
+	proofStore *UniverseProofStore,
+	entries []*universe.ProofSyncLogEntry) error {
+
+	resolve := func(e *universe.ProofSyncLogEntry) error {
+		err := proofStore.resolveLeaf(ctx, &e.Leaf)
+		if err != nil {
+			return err
+		}
+
+		e.Leaf.GroupKey = e.Leaf.Asset.GroupKey
+		e.Leaf.Amt = e.Leaf.Asset.Amount
+
+		return nil
+	}
+
+	return fn.ForEachErr(entries, resolve)
+}
+
 // DeleteProofsSyncLogEntries deletes a set of proof sync log entries.
 func (u *UniverseFederationDB) DeleteProofsSyncLogEntries(ctx context.Context,
 	servers ...universe.ServerAddr) error {
@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/internal/test"
 	"github.com/lightninglabs/taproot-assets/tapdb/sqlc"
@@ -513,3 +516,188 @@ func TestFederationConfigCRUD(t *testing.T) {
 	localCfg = fn.MakeSlice(groupNewCfg, assetCfg)
 	require.Equal(t, localCfg, dbLocalCfg)
 }
+
+// TestFederationServerScopes tests that we can set, replace and clear the
+// asset scopes of the federation servers.
+func TestFederationServerScopes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+
+		db    = NewDbHandle(t)
+		fedDB = db.UniverseFederationStore
+	)
+
+	addrs := db.AddRandomServerAddrs(t, 2)
+
+	// Without any scopes set, no server should have a scope.
+	scopes, err := fedDB.QueryFederationServerScopes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+
+	// We can't set the scope of a server that isn't part of the
+	// federation.
+	unknownAddr := universe.NewServerAddrFromStr("unknown.example.com")
+	err = fedDB.SetFederationServerScope(
+		ctx, unknownAddr, universe.FedServerScope{
+			AssetIDs: []asset.ID{asset.RandID(t)},
+		},
+	)
+	require.ErrorIs(t, err, universe.ErrUnknownUniverseServer)
+
+	// We'll now scope the first server to a set of assets and groups.
+	scope := universe.FedServerScope{
+		AssetIDs: []asset.ID{asset.RandID(t), asset.RandID(t)},
+		GroupKeys: []*btcec.PublicKey{
+			test.RandPubKey(t), test.RandPubKey(t),
+		},
+	}
+	err = fedDB.SetFederationServerScope(ctx, addrs[0], scope)
+	require.NoError(t, err)
+
+	// The group keys are stored as x-only keys, so their parity is lost.
+	scopes, err = fedDB.QueryFederationServerScopes(ctx)
+	require.NoError(t, err)
+	require.Len(t, scopes, 1)
+
+	dbScope := scopes[addrs[0].HostStr()]
+	require.Equal(t, scope.AssetIDs, dbScope.AssetIDs)
+	require.Len(t, dbScope.GroupKeys, len(scope.GroupKeys))
+	for idx := range scope.GroupKeys {
+		require.Equal(
+			t, schnorr.SerializePubKey(scope.GroupKeys[idx]),
+			schnorr.SerializePubKey(dbScope.GroupKeys[idx]),
+		)
+	}
+
+	// Setting a new scope replaces the old one.
+	newScope := universe.FedServerScope{
+		AssetIDs: []asset.ID{asset.RandID(t)},
+	}
+	err = fedDB.SetFederationServerScope(ctx, addrs[0], newScope)
+	require.NoError(t, err)
+
+	scopes, err = fedDB.QueryFederationServerScopes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]universe.FedServerScope{
+		addrs[0].HostStr(): newScope,
+	}, scopes)
+
+	// Removing the server also removes its scope.
+	err = fedDB.RemoveServers(ctx, addrs[0])
+	require.NoError(t, err)
+
+	scopes, err = fedDB.QueryFederationServerScopes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+
+	// Finally, setting an empty scope clears the restriction.
+	err = fedDB.SetFederationServerScope(ctx, addrs[1], newScope)
+	require.NoError(t, err)
+	err = fedDB.SetFederationServerScope(
+		ctx, addrs[1], universe.FedServerScope{},
+	)
+	require.NoError(t, err)
+
+	scopes, err = fedDB.QueryFederationServerScopes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+}
+
+// TestFederationSyncStats tests that the sync volume with the federation
+// servers is aggregated per server and day.
+func TestFederationSyncStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	testClock := clock.NewTestClock(day1)
+	fedDB, _ := newTestFederationDb(t, testClock)
+
+	server1 := universe.NewServerAddrFromStr("universe1.example.com")
+	server2 := universe.NewServerAddrFromStr("universe2.example.com")
+
+	// On the first day, we'll sync twice with the first server and once
+	// with the second one.
+	require.NoError(t, fedDB.LogSyncVolume(ctx, server1, 5))
+	require.NoError(t, fedDB.LogSyncVolume(ctx, server1, 0))
+	require.NoError(t, fedDB.LogSyncVolume(ctx, server2, 3))
+
+	// On the next day, we'll sync once more with the first server.
+	testClock.SetTime(day1.Add(24 * time.Hour))
+	require.NoError(t, fedDB.LogSyncVolume(ctx, server1, 1))
+
+	query := universe.ServerSyncStatsQuery{
+		GroupedStatsQuery: universe.GroupedStatsQuery{
+			StartTime: day1,
+			EndTime:   day1.Add(48 * time.Hour),
+		},
+	}
+	stats, err := fedDB.QueryServerSyncStats(ctx, query)
+	require.NoError(t, err)
+	require.Equal(t, []*universe.ServerSyncStats{
+		{
+			ServerHost:      server1.HostStr(),
+			Date:            "2024-05-01",
+			NumSyncs:        2,
+			NumSyncedLeaves: 5,
+		},
+		{
+			ServerHost:      server2.HostStr(),
+			Date:            "2024-05-01",
+			NumSyncs:        1,
+			NumSyncedLeaves: 3,
+		},
+		{
+			ServerHost:      server1.HostStr(),
+			Date:            "2024-05-02",
+			NumSyncs:        1,
+			NumSyncedLeaves: 1,
+		},
+	}, stats)
+
+	// We can also query the stats of a single server.
+	query.ServerHost = fn.Some(server2.HostStr())
+	stats, err = fedDB.QueryServerSyncStats(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, server2.HostStr(), stats[0].ServerHost)
+}
+
+// TestFederationOperatorKeys tests that the operator keys of universe servers
+// can be pinned, and that a pinned key is removed along with its server.
+func TestFederationOperatorKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fedDB, _ := newTestFederationDb(t, clock.NewDefaultClock())
+
+	server := universe.NewServerAddrFromStr("universe.example.com:10029")
+
+	// No key is pinned for a server we never synced with.
+	pinnedKey, err := fedDB.FetchOperatorKey(ctx, server)
+	require.NoError(t, err)
+	require.True(t, pinnedKey.IsNone())
+
+	// Once a key is pinned, it can't be replaced by another key.
+	operatorKey := test.RandPubKey(t)
+	require.NoError(t, fedDB.PinOperatorKey(ctx, server, operatorKey))
+	require.NoError(
+		t, fedDB.PinOperatorKey(ctx, server, test.RandPubKey(t)),
+	)
+
+	pinnedKey, err = fedDB.FetchOperatorKey(ctx, server)
+	require.NoError(t, err)
+	require.True(t, pinnedKey.UnwrapOr(nil).IsEqual(operatorKey))
+
+	// Removing the server from the federation also removes its pinned
+	// key, so a server that rotated its key can be re-added.
+	require.NoError(t, fedDB.AddServers(ctx, server))
+	require.NoError(t, fedDB.RemoveServers(ctx, server))
+
+	pinnedKey, err = fedDB.FetchOperatorKey(ctx, server)
+	require.NoError(t, err)
+	require.True(t, pinnedKey.IsNone())
+}
@@ -0,0 +1,231 @@
+package tapdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+)
+
+const (
+	// offloadedProofSize is the size of the value of a universe leaf of
+	// which the raw proof was offloaded: the magic bytes, followed by the
+	// node hash of the leaf and the reference of the raw proof blob.
+	offloadedProofSize = 4 + sha256.Size + proof.MetaBlobRefSize
+)
+
+var (
+	// offloadedProofMagic are the magic bytes that prefix the value of a
+	// universe leaf of which the raw proof was offloaded to a blob store.
+	// They can't be confused with an inline raw proof, which is prefixed
+	// with proof.PrefixMagicBytes.
+	offloadedProofMagic = [4]byte{'T', 'A', 'P', 'O'}
+
+	// ErrNoUniverseProofStore is returned if the raw proof of a universe
+	// leaf was offloaded, but no blob store is configured to fetch it from.
+	ErrNoUniverseProofStore = errors.New("universe proof was offloaded " +
+		"but no proof store is configured")
+)
+
+// UniverseProofStore stores the raw proofs of universe leaves. Without a blob
+// store, the raw proof is stored inline as the value of the MS-SMT leaf. With
+// a blob store, for example one backed by an S3-compatible object store, the
+// raw proof is offloaded to the blob store and the MS-SMT leaf only holds a
+// reference to it, which is resolved lazily once the proof is queried.
+type UniverseProofStore struct {
+	blobs proof.MetaBlobStore
+}
+
+// NewUniverseProofStore creates a new universe proof store that offloads raw
+// proofs to the given blob store.
+func NewUniverseProofStore(blobs proof.MetaBlobStore) *UniverseProofStore {
+	return &UniverseProofStore{
+		blobs: blobs,
+	}
+}
+
+// offloading returns true if raw proofs are offloaded to a blob store.
+func (s *UniverseProofStore) offloading() bool {
+	return s != nil && s.blobs != nil
+}
+
+// leafNode returns the MS-SMT leaf node of the given universe leaf. If proofs
+// are offloaded, the raw proof is stored in the blob store first and the
+// returned leaf node only references it, while it still has the node hash of
+// the leaf that holds the raw proof inline.
+func (s *UniverseProofStore) leafNode(ctx context.Context,
+	leaf *universe.Leaf) (*mssmt.LeafNode, error) {
+
+	leafNode := leaf.SmtLeafNode()
+	if !s.offloading() {
+		return leafNode, nil
+	}
+
+	ref := proof.NewBlobRef(leaf.RawProof)
+	_, err := s.blobs.PutBlob(
+		ctx, bytes.NewReader(leaf.RawProof), fn.Some(ref),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to offload universe proof: %w",
+			err)
+	}
+
+	nodeHash := leafNode.NodeHash()
+	value := make([]byte, 0, offloadedProofSize)
+	value = append(value, offloadedProofMagic[:]...)
+	value = append(value, nodeHash[:]...)
+	value = append(value, ref.Encode()...)
+
+	return mssmt.NewComputedLeafNode(
+		value, leafNode.NodeSum(), nodeHash,
+	), nil
+}
+
+// rawProof returns the raw proof of a universe leaf given the value stored in
+// its MS-SMT leaf. If the raw proof was offloaded, it is fetched from the blob
+// store.
+func (s *UniverseProofStore) rawProof(ctx context.Context,
+	value []byte) ([]byte, error) {
+
+	ref, _, ok := decodeOffloadedProof(value)
+	if !ok {
+		return value, nil
+	}
+
+	if !s.offloading() {
+		return nil, ErrNoUniverseProofStore
+	}
+
+	r, err := s.blobs.GetBlob(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch universe proof: %w",
+			err)
+	}
+	defer r.Close()
+
+	// The reader verifies that the blob matches its reference, so we
+	// don't need to check the node hash of the leaf.
+	rawProof, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read universe proof: %w",
+			err)
+	}
+
+	return rawProof, nil
+}
+
+// resolveLeaf replaces the stored MS-SMT leaf value of the given universe leaf
+// with its raw proof and decodes the asset of the leaf from it. If the raw
+// proof was offloaded, it is fetched from the blob store, which can take a
+// network round trip. This must therefore be called after the database
+// transaction the leaf was read in, so the transaction isn't held open while
+// waiting for the blob store.
+func (s *UniverseProofStore) resolveLeaf(ctx context.Context,
+	leaf *universe.Leaf) error {
+
+	rawProof, err := s.rawProof(ctx, leaf.RawProof)
+	if err != nil {
+		return err
+	}
+
+	// We only need to obtain the asset at this point, so we'll do a sparse
+	// decode here to decode only the asset record.
+	var leafAsset asset.Asset
+	assetRecord := proof.AssetLeafRecord(&leafAsset)
+	err = proof.SparseDecode(bytes.NewReader(rawProof), assetRecord)
+	if err != nil {
+		return fmt.Errorf("unable to decode proof: %w", err)
+	}
+
+	leaf.RawProof = rawProof
+	leaf.Asset = &leafAsset
+
+	return nil
+}
+
+// resolveProofs resolves the leaves of the given universe proofs, see
+// resolveLeaf.
+func (s *UniverseProofStore) resolveProofs(ctx context.Context,
+	proofs []*universe.Proof) error {
+
+	return fn.ForEachErr(proofs, func(p *universe.Proof) error {
+		return s.resolveLeaf(ctx, p.Leaf)
+	})
+}
+
+// decodeOffloadedProof decodes the blob reference and the node hash of the
+// given value of a MS-SMT leaf. False is returned if the value isn't the
+// reference of an offloaded universe proof.
+func decodeOffloadedProof(value []byte) (proof.BlobRef, mssmt.NodeHash,
+	bool) {
+
+	var nodeHash mssmt.NodeHash
+	if len(value) != offloadedProofSize ||
+		!bytes.HasPrefix(value, offloadedProofMagic[:]) {
+
+		return proof.BlobRef{}, nodeHash, false
+	}
+
+	value = value[len(offloadedProofMagic):]
+	copy(nodeHash[:], value[:sha256.Size])
+
+	ref, err := proof.DecodeBlobRef(value[sha256.Size:])
+	if err != nil {
+		return proof.BlobRef{}, nodeHash, false
+	}
+
+	return ref, nodeHash, true
+}
+
+// newStoredLeafNode creates a MS-SMT leaf node from the value and sum stored
+// in the database. The node hash of a leaf that references an offloaded
+// universe proof can't be computed from its value, so it is taken from the
+// reference instead.
+func newStoredLeafNode(value []byte, sum uint64) *mssmt.LeafNode {
+	if _, nodeHash, ok := decodeOffloadedProof(value); ok {
+		return mssmt.NewComputedLeafNode(value, sum, nodeHash)
+	}
+
+	leaf := mssmt.NewLeafNode(value, sum)
+
+	// Precompute the node hash key.
+	leaf.NodeHash()
+
+	return leaf
+}
+
+// universeProofOpts holds the options of a store that reads or writes the raw
+// proofs of universe leaves.
+type universeProofOpts struct {
+	proofStore *UniverseProofStore
+}
+
+// UniverseProofOption is a functional option for a store that reads or writes
+// the raw proofs of universe leaves.
+type UniverseProofOption func(*universeProofOpts)
+
+// WithUniverseProofStore is a functional option that sets the store that the
+// raw proofs of universe leaves are offloaded to.
+func WithUniverseProofStore(s *UniverseProofStore) UniverseProofOption {
+	return func(o *universeProofOpts) {
+		o.proofStore = s
+	}
+}
+
+// applyUniverseProofOpts applies the given options to the default options.
+func applyUniverseProofOpts(options []UniverseProofOption) universeProofOpts {
+	var opts universeProofOpts
+	for _, o := range options {
+		o(&opts)
+	}
+
+	return opts
+}
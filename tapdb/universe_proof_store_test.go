@@ -0,0 +1,183 @@
+package tapdb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/metablob"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/stretchr/testify/require"
+)
+
+// txTrackingUniverseDB wraps a batched universe DB and records whether a
+// transaction is currently open.
+type txTrackingUniverseDB struct {
+	BatchedUniverseTree
+
+	inTx *atomic.Bool
+}
+
+// ExecTx marks a transaction as open for the duration of the passed txBody.
+func (d *txTrackingUniverseDB) ExecTx(ctx context.Context,
+	txOptions TxOptions, txBody func(BaseUniverseStore) error) error {
+
+	d.inTx.Store(true)
+	defer d.inTx.Store(false)
+
+	return d.BatchedUniverseTree.ExecTx(ctx, txOptions, txBody)
+}
+
+// txTrackingMultiverseDB wraps a batched multiverse DB and records whether a
+// transaction is currently open.
+type txTrackingMultiverseDB struct {
+	BatchedMultiverse
+
+	inTx *atomic.Bool
+}
+
+// ExecTx marks a transaction as open for the duration of the passed txBody.
+func (d *txTrackingMultiverseDB) ExecTx(ctx context.Context,
+	txOptions TxOptions, txBody func(BaseMultiverseStore) error) error {
+
+	d.inTx.Store(true)
+	defer d.inTx.Store(false)
+
+	return d.BatchedMultiverse.ExecTx(ctx, txOptions, txBody)
+}
+
+// txCheckingBlobStore wraps a blob store and records whether a blob was ever
+// read while a DB transaction was open.
+type txCheckingBlobStore struct {
+	proof.MetaBlobStore
+
+	inTx      *atomic.Bool
+	readsInTx atomic.Int32
+}
+
+// GetBlob returns a reader for the blob with the given reference.
+func (s *txCheckingBlobStore) GetBlob(ctx context.Context,
+	ref proof.BlobRef) (io.ReadCloser, error) {
+
+	if s.inTx.Load() {
+		s.readsInTx.Add(1)
+	}
+
+	return s.MetaBlobStore.GetBlob(ctx, ref)
+}
+
+// TestUniverseProofOffloading tests that offloading the raw proofs of universe
+// leaves to a blob store results in the same universe root and inclusion
+// proofs as storing them inline, and that the raw proofs are fetched lazily
+// once a leaf is queried.
+func TestUniverseProofOffloading(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	blobs, err := metablob.NewFSStore(
+		t.TempDir(), proof.FileMaxProofSizeBytes,
+	)
+	require.NoError(t, err)
+
+	// We track whether the blobs are read while a DB transaction is open,
+	// as reading them may block on slow storage.
+	var inTx atomic.Bool
+	checkedBlobs := &txCheckingBlobStore{
+		MetaBlobStore: blobs,
+		inTx:          &inTx,
+	}
+	proofStore := NewUniverseProofStore(checkedBlobs)
+
+	id := randUniverseID(t, false)
+	inlineTree, _ := newTestUniverse(t, id)
+
+	offloadDB := NewTestDB(t)
+	offloadTree, _ := newTestUniverseWithDb(offloadDB.BaseDB, id)
+	offloadTree.proofStore = proofStore
+	offloadTree.db = &txTrackingUniverseDB{
+		BatchedUniverseTree: offloadTree.db,
+		inTx:                &inTx,
+	}
+
+	// We'll insert the same set of leaves into both trees.
+	const numLeaves = 5
+	keys := make([]universe.LeafKey, numLeaves)
+	leaves := make([]universe.Leaf, numLeaves)
+	for i := 0; i < numLeaves; i++ {
+		keys[i] = randLeafKey(t)
+		leaves[i] = randMintingLeaf(
+			t, asset.RandGenesis(t, asset.Normal), id.GroupKey,
+		)
+
+		_, err := inlineTree.RegisterIssuance(
+			ctx, keys[i], &leaves[i], nil,
+		)
+		require.NoError(t, err)
+
+		_, err = offloadTree.RegisterIssuance(
+			ctx, keys[i], &leaves[i], nil,
+		)
+		require.NoError(t, err)
+
+		// The raw proof should now be in the blob store.
+		ref := proof.NewBlobRef(leaves[i].RawProof)
+		has, err := blobs.HasBlob(ctx, ref)
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	// Offloading the raw proofs must not change the universe root.
+	inlineRoot, _, err := inlineTree.RootNode(ctx)
+	require.NoError(t, err)
+	offloadRoot, _, err := offloadTree.RootNode(ctx)
+	require.NoError(t, err)
+	require.True(t, inlineRoot.NodeHash() == offloadRoot.NodeHash())
+	require.Equal(t, inlineRoot.NodeSum(), offloadRoot.NodeSum())
+
+	// Each leaf should be fetched with its raw proof and an inclusion
+	// proof that is valid for the root.
+	for i := 0; i < numLeaves; i++ {
+		uniProofs, err := offloadTree.FetchIssuanceProof(ctx, keys[i])
+		require.NoError(t, err)
+		require.Len(t, uniProofs, 1)
+
+		uniProof := uniProofs[0]
+		require.Equal(t, leaves[i].RawProof, uniProof.Leaf.RawProof)
+		require.True(t, uniProof.VerifyRoot(inlineRoot))
+	}
+
+	// The minting leaves should also carry the resolved raw proofs.
+	mintingLeaves, err := offloadTree.MintingLeaves(ctx)
+	require.NoError(t, err)
+	require.Len(t, mintingLeaves, numLeaves)
+	for _, leaf := range mintingLeaves {
+		var p proof.Proof
+		require.NoError(t, p.Decode(bytes.NewReader(leaf.RawProof)))
+	}
+
+	// The multiverse store should resolve the raw proofs as well.
+	multiverse, _ := newTestMultiverseWithDb(offloadDB.BaseDB)
+	multiverse.cfg.ProofStore = proofStore
+	multiverse.db = &txTrackingMultiverseDB{
+		BatchedMultiverse: multiverse.db,
+		inTx:              &inTx,
+	}
+	uniProofs, err := multiverse.FetchProofLeaf(ctx, id, keys[0])
+	require.NoError(t, err)
+	require.Len(t, uniProofs, 1)
+	require.Equal(t, leaves[0].RawProof, uniProofs[0].Leaf.RawProof)
+
+	// None of the raw proofs should have been read from the blob store
+	// while a DB transaction was open.
+	require.Zero(t, checkedBlobs.readsInTx.Load())
+
+	// Without a blob store, an offloaded proof can't be resolved.
+	noStoreTree, _ := newTestUniverseWithDb(offloadDB.BaseDB, id)
+	_, err = noStoreTree.FetchIssuanceProof(ctx, keys[0])
+	require.ErrorIs(t, err, ErrNoUniverseProofStore)
+}
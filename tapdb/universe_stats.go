@@ -56,6 +56,33 @@ type (
 	// AssetStatsPerDayQueryPg is the query used to fetch the asset stats
 	// for a given day (for Postgres).
 	AssetStatsPerDayQueryPg = sqlc.QueryAssetStatsPerDayPostgresParams
+
+	// NewDailyStats is used to add to the pre-aggregated stats of a day.
+	NewDailyStats = sqlc.UpsertUniverseDailyStatsParams
+
+	// DailyStats is the pre-aggregated stats record of a day.
+	DailyStats = sqlc.UniverseDailyStat
+
+	// DailyStatsQuery is the query used to fetch the pre-aggregated stats
+	// of a range of days.
+	DailyStatsQuery = sqlc.QueryUniverseDailyStatsParams
+
+	// NewTransferStats is used to add to the number of transfer proofs of
+	// a transfer universe.
+	NewTransferStats = sqlc.UpsertUniverseTransferStatsParams
+
+	// TransferStats is the number of transfer proofs of a transfer
+	// universe.
+	TransferStats = sqlc.UniverseTransferStat
+)
+
+const (
+	// secondsPerDay is the number of seconds in a day, used to bucket the
+	// pre-aggregated stats by UTC day.
+	secondsPerDay = 24 * 60 * 60
+
+	// statsDateFormat is the format of the dates the stats are grouped by.
+	statsDateFormat = "2006-01-02"
 )
 
 // UniverseStatsStore is an interface that defines the methods required to
@@ -84,11 +111,33 @@ type UniverseStatsStore interface {
 	// grouped by day in a Postgres specific format.
 	QueryAssetStatsPerDayPostgres(ctx context.Context,
 		q AssetStatsPerDayQueryPg) ([]AssetStatsPerDayPg, error)
+
+	// UpsertUniverseDailyStats adds to the pre-aggregated stats of a day.
+	UpsertUniverseDailyStats(ctx context.Context, arg NewDailyStats) error
+
+	// QueryUniverseDailyStats returns the pre-aggregated stats of a range
+	// of days.
+	QueryUniverseDailyStats(ctx context.Context,
+		arg DailyStatsQuery) ([]DailyStats, error)
+
+	// UpsertUniverseTransferStats adds to the number of transfer proofs of
+	// a transfer universe.
+	UpsertUniverseTransferStats(ctx context.Context,
+		arg NewTransferStats) error
+
+	// QueryTopUniverseTransferStats returns the transfer universes with
+	// the most transfer proofs.
+	QueryTopUniverseTransferStats(ctx context.Context,
+		numLimit int32) ([]TransferStats, error)
 }
 
 // UniverseStatsOptions defines the set of txn options for the universe stats.
 type UniverseStatsOptions struct {
 	readOnly bool
+
+	// allowReplica governs if the transaction may be executed on a read
+	// replica of the database.
+	allowReplica bool
 }
 
 // ReadOnly returns true if the transaction is read-only.
@@ -104,6 +153,24 @@ func NewUniverseStatsReadTx() UniverseStatsOptions {
 	}
 }
 
+// AllowReplica returns true if the transaction may be executed on a read
+// replica.
+//
+// NOTE: This implements the ReplicaTxOptions interface.
+func (u *UniverseStatsOptions) AllowReplica() bool {
+	return u.allowReplica
+}
+
+// NewUniverseStatsReplicaReadTx creates a new read-only transaction for the
+// universe stats instance that may be executed on a read replica. The stats are
+// cached anyway, so slightly stale data is acceptable.
+func NewUniverseStatsReplicaReadTx() UniverseStatsOptions {
+	return UniverseStatsOptions{
+		readOnly:     true,
+		allowReplica: true,
+	}
+}
+
 // BatchedUniverseStats is a wrapper around the set of UniverseSyncEvents that
 // supports batched DB operations.
 type BatchedUniverseStats interface {
@@ -415,13 +482,18 @@ func (u *UniverseStats) LogNewProofEvent(ctx context.Context,
 			groupKeyXOnly = schnorr.SerializePubKey(uniID.GroupKey)
 		}
 
-		return db.InsertNewProofEvent(ctx, NewProofEvent{
+		err := db.InsertNewProofEvent(ctx, NewProofEvent{
 			EventTime:      u.clock.Now().UTC(),
 			EventTimestamp: u.clock.Now().UTC().Unix(),
 			AssetID:        uniID.AssetID[:],
 			GroupKeyXOnly:  groupKeyXOnly,
 			ProofType:      uniID.ProofType.String(),
 		})
+		if err != nil {
+			return err
+		}
+
+		return updateProofStats(ctx, db, u.clock.Now(), uniID)
 	})
 }
 
@@ -452,8 +524,168 @@ func (u *UniverseStats) LogNewProofEvents(ctx context.Context,
 			}
 		}
 
+		return updateProofStats(ctx, db, u.clock.Now(), uniIDs...)
+	})
+}
+
+// statsDayStart returns the Unix timestamp of the start of the UTC day of the
+// given time.
+func statsDayStart(t time.Time) int64 {
+	timestamp := t.UTC().Unix()
+	return timestamp - timestamp%secondsPerDay
+}
+
+// statsDate returns the formatted date of the day that starts at the given
+// Unix timestamp.
+func statsDate(dayStart int64) string {
+	return time.Unix(dayStart, 0).UTC().Format(statsDateFormat)
+}
+
+// updateProofStats adds the new proofs of the given universes to the
+// pre-aggregated stats of the day of the given time and to the transfer stats
+// of their universes.
+func updateProofStats(ctx context.Context, db UniverseStatsStore,
+	now time.Time, uniIDs ...universe.Identifier) error {
+
+	if len(uniIDs) == 0 {
+		return nil
+	}
+
+	var (
+		dailyStats = NewDailyStats{
+			DayStart:  statsDayStart(now),
+			NewProofs: int64(len(uniIDs)),
+		}
+		transfers = make(map[string]*NewTransferStats)
+	)
+	for idx := range uniIDs {
+		uniID := uniIDs[idx]
+
+		switch uniID.ProofType {
+		case universe.ProofTypeIssuance:
+			dailyStats.NewAssets++
+
+		case universe.ProofTypeTransfer:
+			namespace := uniID.String()
+			if stats, ok := transfers[namespace]; ok {
+				stats.NumTransfers++
+				continue
+			}
+
+			stats := &NewTransferStats{
+				Namespace:    namespace,
+				NumTransfers: 1,
+			}
+			if uniID.GroupKey != nil {
+				stats.GroupKey = schnorr.SerializePubKey(
+					uniID.GroupKey,
+				)
+			} else {
+				stats.AssetID = fn.CopySlice(uniID.AssetID[:])
+			}
+			transfers[namespace] = stats
+		}
+	}
+
+	err := db.UpsertUniverseDailyStats(ctx, dailyStats)
+	if err != nil {
+		return fmt.Errorf("unable to update daily stats: %w", err)
+	}
+
+	for _, stats := range transfers {
+		err := db.UpsertUniverseTransferStats(ctx, *stats)
+		if err != nil {
+			return fmt.Errorf("unable to update transfer stats: %w",
+				err)
+		}
+	}
+
+	return nil
+}
+
+// QueryDailyStats returns the pre-aggregated number of new assets and proofs
+// of the local Universe per day.
+func (u *UniverseStats) QueryDailyStats(ctx context.Context,
+	q universe.GroupedStatsQuery) ([]*universe.DailyStats, error) {
+
+	var (
+		readTx  = NewUniverseStatsReplicaReadTx()
+		results []*universe.DailyStats
+	)
+	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseStatsStore) error {
+		stats, err := db.QueryUniverseDailyStats(ctx, DailyStatsQuery{
+			StartTime: statsDayStart(q.StartTime),
+			EndTime:   q.EndTime.UTC().Unix(),
+		})
+		if err != nil {
+			return err
+		}
+
+		results = make([]*universe.DailyStats, len(stats))
+		for idx, s := range stats {
+			results[idx] = &universe.DailyStats{
+				Date:         statsDate(s.DayStart),
+				NumNewAssets: uint64(s.NewAssets),
+				NumNewProofs: uint64(s.NewProofs),
+			}
+		}
+
 		return nil
 	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return results, nil
+}
+
+// QueryTopTransferUniverses returns up to the given number of transfer
+// universes with the most transfer proofs, most transfers first.
+func (u *UniverseStats) QueryTopTransferUniverses(ctx context.Context,
+	limit int) ([]*universe.TransferStats, error) {
+
+	var (
+		readTx  = NewUniverseStatsReplicaReadTx()
+		results []*universe.TransferStats
+	)
+	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseStatsStore) error {
+		stats, err := db.QueryTopUniverseTransferStats(
+			ctx, int32(limit),
+		)
+		if err != nil {
+			return err
+		}
+
+		results = make([]*universe.TransferStats, 0, len(stats))
+		for _, s := range stats {
+			uniID := universe.Identifier{
+				ProofType: universe.ProofTypeTransfer,
+			}
+			copy(uniID.AssetID[:], s.AssetID)
+
+			if len(s.GroupKey) > 0 {
+				uniID.GroupKey, err = schnorr.ParsePubKey(
+					s.GroupKey,
+				)
+				if err != nil {
+					return fmt.Errorf("unable to parse "+
+						"group key: %w", err)
+				}
+			}
+
+			results = append(results, &universe.TransferStats{
+				ID:           uniID,
+				NumTransfers: uint64(s.NumTransfers),
+			})
+		}
+
+		return nil
+	})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return results, nil
 }
 
 // querySyncStats is a helper function that's used to query the sync stats for
@@ -463,7 +695,7 @@ func (u *UniverseStats) querySyncStats(ctx context.Context,
 
 	var dbStats universe.AggregateStats
 
-	readTx := NewUniverseStatsReadTx()
+	readTx := NewUniverseStatsReplicaReadTx()
 	err := u.db.ExecTx(ctx, &readTx, func(db UniverseStatsStore) error {
 		uniStats, err := db.QueryUniverseStats(ctx)
 		if err != nil {
@@ -658,7 +890,7 @@ func (u *UniverseStats) QueryAssetStatsPerDay(ctx context.Context,
 	u.eventsCacheLogger.Miss()
 
 	var (
-		readTx  = NewUniverseStatsReadTx()
+		readTx  = NewUniverseStatsReplicaReadTx()
 		results []*universe.GroupedStats
 	)
 	dbErr := u.db.ExecTx(ctx, &readTx, func(db UniverseStatsStore) error {
@@ -804,7 +1036,7 @@ func (u *UniverseStats) QuerySyncStats(ctx context.Context,
 		query.AssetID = q.AssetIDFilter[:]
 	}
 
-	readTx := NewUniverseStatsReadTx()
+	readTx := NewUniverseStatsReplicaReadTx()
 	err := u.db.ExecTx(ctx, &readTx, func(db UniverseStatsStore) error {
 		// With the query constructed above, we'll now query the DB for
 		// the set of stats for each universe.
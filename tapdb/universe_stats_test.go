@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -604,3 +605,114 @@ func TestUniverseQuerySyncFilters(t *testing.T) {
 		})
 	}
 }
+
+// newStatsTestUniverse creates a universe of the given proof type with a
+// single leaf that proof events can be logged for.
+func newStatsTestUniverse(t *testing.T, db *BaseDB, grouped bool,
+	proofType universe.ProofType) universe.Identifier {
+
+	gen := asset.RandGenesis(t, asset.Normal)
+
+	id := randUniverseID(t, grouped, withProofType(proofType))
+	if !grouped {
+		id.AssetID = gen.ID()
+		id.GroupKey = nil
+	}
+
+	uniTree, _ := newTestUniverseWithDb(db, id)
+	_, err := insertRandLeaf(t, context.Background(), uniTree, &gen)
+	require.NoError(t, err)
+
+	return id
+}
+
+// TestUniverseTimeSeriesStats tests that the pre-aggregated daily and transfer
+// stats are updated when new proof events are logged.
+func TestUniverseTimeSeriesStats(t *testing.T) {
+	t.Parallel()
+
+	db := NewTestDB(t)
+
+	day1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	testClock := clock.NewTestClock(day1)
+	statsDB, _ := newUniverseStatsWithDB(db.BaseDB, testClock)
+
+	ctx := context.Background()
+
+	var (
+		issuanceID = newStatsTestUniverse(
+			t, db.BaseDB, false, universe.ProofTypeIssuance,
+		)
+		transferID = newStatsTestUniverse(
+			t, db.BaseDB, false, universe.ProofTypeTransfer,
+		)
+		groupTransferID = newStatsTestUniverse(
+			t, db.BaseDB, true, universe.ProofTypeTransfer,
+		)
+	)
+
+	// On the first day, we'll log a new asset and two transfers.
+	err := statsDB.LogNewProofEvent(ctx, issuanceID, randLeafKey(t))
+	require.NoError(t, err)
+	err = statsDB.LogNewProofEvents(ctx, transferID, groupTransferID)
+	require.NoError(t, err)
+
+	// On the next day, we'll log two more transfers of the group.
+	testClock.SetTime(day1.Add(24 * time.Hour))
+	err = statsDB.LogNewProofEvents(ctx, groupTransferID, groupTransferID)
+	require.NoError(t, err)
+
+	// The stats of both days should be returned, even though the start
+	// time of the query is later on the first day.
+	dailyStats, err := statsDB.QueryDailyStats(
+		ctx, universe.GroupedStatsQuery{
+			StartTime: day1.Add(time.Hour),
+			EndTime:   day1.Add(48 * time.Hour),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*universe.DailyStats{
+		{
+			Date:         "2024-05-01",
+			NumNewAssets: 1,
+			NumNewProofs: 3,
+		},
+		{
+			Date:         "2024-05-02",
+			NumNewProofs: 2,
+		},
+	}, dailyStats)
+
+	// Days outside the queried range are left out.
+	dailyStats, err = statsDB.QueryDailyStats(
+		ctx, universe.GroupedStatsQuery{
+			StartTime: day1.Add(24 * time.Hour),
+			EndTime:   day1.Add(48 * time.Hour),
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, dailyStats, 1)
+	require.Equal(t, "2024-05-02", dailyStats[0].Date)
+
+	// The group has the most transfers, followed by the single asset.
+	topStats, err := statsDB.QueryTopTransferUniverses(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, topStats, 2)
+
+	require.Equal(t, uint64(3), topStats[0].NumTransfers)
+	require.Equal(t, universe.ProofTypeTransfer, topStats[0].ID.ProofType)
+	require.Equal(
+		t, schnorr.SerializePubKey(groupTransferID.GroupKey),
+		schnorr.SerializePubKey(topStats[0].ID.GroupKey),
+	)
+
+	require.Equal(t, uint64(1), topStats[1].NumTransfers)
+	require.Equal(t, transferID.AssetID, topStats[1].ID.AssetID)
+	require.Nil(t, topStats[1].ID.GroupKey)
+
+	// The number of returned universes is limited.
+	topStats, err = statsDB.QueryTopTransferUniverses(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, topStats, 1)
+	require.Equal(t, uint64(3), topStats[0].NumTransfers)
+}
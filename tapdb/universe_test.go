@@ -68,7 +68,7 @@ func randUniverseID(t testing.TB, forceGroup bool,
 	return id
 }
 
-func newTestUniverse(t *testing.T,
+func newTestUniverse(t testing.TB,
 	id universe.Identifier) (*BaseUniverseTree, sqlc.Querier) {
 
 	db := NewTestDB(t)
@@ -155,14 +155,14 @@ func TestUniverseEmptyTree(t *testing.T) {
 	require.ErrorIs(t, err, universe.ErrNoUniverseRoot)
 }
 
-func randLeafKey(t *testing.T) universe.LeafKey {
+func randLeafKey(t testing.TB) universe.LeafKey {
 	return universe.LeafKey{
 		OutPoint:  test.RandOp(t),
 		ScriptKey: fn.Ptr(asset.NewScriptKey(test.RandPubKey(t))),
 	}
 }
 
-func randProof(t *testing.T, argAsset *asset.Asset) *proof.Proof {
+func randProof(t testing.TB, argAsset *asset.Asset) *proof.Proof {
 	proofAsset := *asset.RandAsset(t, asset.Normal)
 	if argAsset != nil {
 		proofAsset = *argAsset
@@ -187,7 +187,7 @@ func randProof(t *testing.T, argAsset *asset.Asset) *proof.Proof {
 	}
 }
 
-func randMintingLeaf(t *testing.T, assetGen asset.Genesis,
+func randMintingLeaf(t testing.TB, assetGen asset.Genesis,
 	groupKey *btcec.PublicKey) universe.Leaf {
 
 	randProof := randProof(t, nil)
@@ -1075,3 +1075,82 @@ func TestMultiverseRootSum(t *testing.T) {
 		})
 	}
 }
+
+// TestMultiverseSubtreeNodes tests that the subtree nodes of a universe tree
+// are consistent with its root and resolve to the right leaf keys.
+func TestMultiverseSubtreeNodes(t *testing.T) {
+	t.Parallel()
+
+	multiverse, _ := newTestMultiverse(t)
+
+	ctx := context.Background()
+	id := randUniverseID(t, false)
+
+	insertLeaf := func() universe.LeafKey {
+		assetGen := asset.RandGenesis(t, asset.Normal)
+		leaf := randMintingLeaf(t, assetGen, id.GroupKey)
+		leafKey := randLeafKey(t)
+
+		_, err := multiverse.UpsertProofLeaf(
+			ctx, id, leafKey, &leaf, nil,
+		)
+		require.NoError(t, err)
+
+		return leafKey
+	}
+
+	// With a single leaf in the tree, the only non-empty node below the
+	// root must point to that leaf.
+	leafKeys := []universe.LeafKey{insertLeaf()}
+	nodes, err := multiverse.SubtreeNodes(
+		ctx, id, []mssmt.NodePath{mssmt.RootPath}, 4,
+	)
+	require.NoError(t, err)
+	require.Len(t, nodes, 16)
+
+	nonEmpty := fn.Filter(nodes, func(n universe.SubtreeNode) bool {
+		return !n.IsEmpty()
+	})
+	require.Len(t, nonEmpty, 1)
+	leafKey := nonEmpty[0].LeafKey.UnwrapOr(universe.LeafKey{})
+	require.Equal(t, leafKeys[0].UniverseKey(), leafKey.UniverseKey())
+
+	for i := 0; i < 9; i++ {
+		leafKeys = append(leafKeys, insertLeaf())
+	}
+
+	// The nodes below the root must hash up to the universe root.
+	rootNode, err := multiverse.UniverseRootNode(ctx, id)
+	require.NoError(t, err)
+
+	nodes, err = multiverse.SubtreeNodes(
+		ctx, id, []mssmt.NodePath{mssmt.RootPath}, 6,
+	)
+	require.NoError(t, err)
+
+	subtreeRoot, err := mssmt.SubtreeRoot(
+		fn.Map(nodes, func(n universe.SubtreeNode) mssmt.Node {
+			return n.Node
+		}),
+	)
+	require.NoError(t, err)
+	require.True(t, mssmt.IsEqualNode(rootNode.Node, subtreeRoot))
+
+	// Querying the leaves directly must return their leaf keys.
+	leafPaths := fn.Map(leafKeys, func(k universe.LeafKey) mssmt.NodePath {
+		return mssmt.NodePath{
+			Key:   k.UniverseKey(),
+			Depth: mssmt.MaxTreeLevels,
+		}
+	})
+	nodes, err = multiverse.SubtreeNodes(ctx, id, leafPaths, 0)
+	require.NoError(t, err)
+	require.Len(t, nodes, len(leafKeys))
+
+	for i, node := range nodes {
+		leafKey := node.LeafKey.UnwrapOr(universe.LeafKey{})
+		require.Equal(
+			t, leafKeys[i].UniverseKey(), leafKey.UniverseKey(),
+		)
+	}
+}
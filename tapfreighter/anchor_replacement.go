@@ -0,0 +1,92 @@
+package tapfreighter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrIncompatibleReplacement is returned if the anchor transaction of a
+// transfer was replaced by a transaction that doesn't carry the assets of the
+// transfer in the same outputs.
+var ErrIncompatibleReplacement = errors.New("incompatible anchor transaction " +
+	"replacement")
+
+// CheckAnchorTxReplacement checks that the given replacement of the anchor
+// transaction of a parcel, for example one that was fee bumped through RBF,
+// still carries the assets of the transfer. The proofs of the transfer only
+// reference the anchor outputs by their index, so they remain valid for the
+// replacement if it spends all asset inputs and has the exact same set of
+// P2TR outputs at the same indexes. The value of the outputs and any non-P2TR
+// outputs, like a BTC change output, may differ.
+func CheckAnchorTxReplacement(parcel *OutboundParcel,
+	replacement *wire.MsgTx) error {
+
+	spentOutPoints := make(
+		map[wire.OutPoint]struct{}, len(replacement.TxIn),
+	)
+	for _, txIn := range replacement.TxIn {
+		spentOutPoints[txIn.PreviousOutPoint] = struct{}{}
+	}
+
+	for _, input := range parcel.Inputs {
+		if _, ok := spentOutPoints[input.OutPoint]; !ok {
+			return fmt.Errorf("%w: asset input %v isn't spent",
+				ErrIncompatibleReplacement, input.OutPoint)
+		}
+	}
+
+	// taprootScript returns the pkScript of the output with the given
+	// index if it is a P2TR output.
+	taprootScript := func(tx *wire.MsgTx, idx int) []byte {
+		if idx >= len(tx.TxOut) {
+			return nil
+		}
+
+		pkScript := tx.TxOut[idx].PkScript
+		if !txscript.IsPayToTaproot(pkScript) {
+			return nil
+		}
+
+		return pkScript
+	}
+
+	original := parcel.AnchorTx
+	numOutputs := max(len(original.TxOut), len(replacement.TxOut))
+	for idx := 0; idx < numOutputs; idx++ {
+		originalScript := taprootScript(original, idx)
+		replacementScript := taprootScript(replacement, idx)
+		if !bytes.Equal(originalScript, replacementScript) {
+			return fmt.Errorf("%w: P2TR output %d differs",
+				ErrIncompatibleReplacement, idx)
+		}
+	}
+
+	return nil
+}
+
+// ReplaceAnchorTx updates the parcel to the given replacement of its anchor
+// transaction, which must have passed CheckAnchorTxReplacement. The anchor
+// outputs of the transfer keep their index but are moved to the replacement.
+func (o *OutboundParcel) ReplaceAnchorTx(replacement *wire.MsgTx) {
+	replacementTxid := replacement.TxHash()
+	updateAnchor := func(anchor *Anchor) {
+		txOut := replacement.TxOut[anchor.OutPoint.Index]
+
+		anchor.OutPoint.Hash = replacementTxid
+		anchor.Value = btcutil.Amount(txOut.Value)
+	}
+
+	for idx := range o.Outputs {
+		updateAnchor(&o.Outputs[idx].Anchor)
+	}
+	if o.PassiveAssetsAnchor != nil {
+		updateAnchor(o.PassiveAssetsAnchor)
+	}
+
+	o.AnchorTx = replacement
+}
@@ -0,0 +1,151 @@
+package tapfreighter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckAnchorTxReplacement tests that only replacements of an anchor
+// transaction that spend all asset inputs and keep the P2TR outputs at the
+// same indexes are accepted.
+func TestCheckAnchorTxReplacement(t *testing.T) {
+	t.Parallel()
+
+	randTaprootScript := func() []byte {
+		pkScript, err := txscript.PayToTaprootScript(
+			test.RandPubKey(t),
+		)
+		require.NoError(t, err)
+
+		return pkScript
+	}
+
+	assetInput := test.RandOp(t)
+	feeInput := test.RandOp(t)
+
+	original := wire.NewMsgTx(2)
+	original.AddTxIn(&wire.TxIn{PreviousOutPoint: assetInput})
+	original.AddTxIn(&wire.TxIn{PreviousOutPoint: feeInput})
+	original.AddTxOut(&wire.TxOut{
+		Value: 1000, PkScript: randTaprootScript(),
+	})
+	original.AddTxOut(&wire.TxOut{
+		Value: 1000, PkScript: randTaprootScript(),
+	})
+	original.AddTxOut(&wire.TxOut{
+		Value: 50_000, PkScript: bytes.Repeat([]byte{0x00}, 22),
+	})
+
+	newParcel := func() *OutboundParcel {
+		originalTxid := original.TxHash()
+		anchor := func(idx uint32) Anchor {
+			return Anchor{
+				OutPoint: wire.OutPoint{
+					Hash:  originalTxid,
+					Index: idx,
+				},
+				Value: 1000,
+			}
+		}
+
+		return &OutboundParcel{
+			AnchorTx: original,
+			Inputs: []TransferInput{{
+				PrevID: asset.PrevID{
+					OutPoint: assetInput,
+				},
+			}},
+			Outputs: []TransferOutput{{
+				Anchor: anchor(0),
+			}},
+			PassiveAssetsAnchor: fn.Ptr(anchor(1)),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(tx *wire.MsgTx)
+		valid  bool
+	}{{
+		name: "fee bump from change",
+		modify: func(tx *wire.MsgTx) {
+			tx.TxOut[2].Value = 40_000
+		},
+		valid: true,
+	}, {
+		name: "different fee inputs and new change",
+		modify: func(tx *wire.MsgTx) {
+			tx.TxIn[1].PreviousOutPoint = test.RandOp(t)
+			tx.TxOut[2].PkScript = bytes.Repeat([]byte{0x01}, 22)
+		},
+		valid: true,
+	}, {
+		name: "asset input not spent",
+		modify: func(tx *wire.MsgTx) {
+			tx.TxIn[0].PreviousOutPoint = test.RandOp(t)
+		},
+	}, {
+		name: "P2TR output changed",
+		modify: func(tx *wire.MsgTx) {
+			tx.TxOut[1].PkScript = randTaprootScript()
+		},
+	}, {
+		name: "P2TR output removed",
+		modify: func(tx *wire.MsgTx) {
+			tx.TxOut = tx.TxOut[:1]
+		},
+	}, {
+		name: "P2TR output added",
+		modify: func(tx *wire.MsgTx) {
+			tx.AddTxOut(&wire.TxOut{
+				Value: 1000, PkScript: randTaprootScript(),
+			})
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parcel := newParcel()
+			replacement := original.Copy()
+			tc.modify(replacement)
+
+			err := CheckAnchorTxReplacement(parcel, replacement)
+			if !tc.valid {
+				require.ErrorIs(
+					t, err, ErrIncompatibleReplacement,
+				)
+				return
+			}
+			require.NoError(t, err)
+
+			// The anchors of the parcel are moved to the
+			// replacement.
+			parcel.ReplaceAnchorTx(replacement)
+			replacementTxid := replacement.TxHash()
+
+			require.Equal(t, replacement, parcel.AnchorTx)
+			require.Equal(
+				t, wire.OutPoint{Hash: replacementTxid},
+				parcel.Outputs[0].Anchor.OutPoint,
+			)
+			require.Equal(
+				t, wire.OutPoint{
+					Hash:  replacementTxid,
+					Index: 1,
+				}, parcel.PassiveAssetsAnchor.OutPoint,
+			)
+			require.Equal(
+				t, btcutil.Amount(1000),
+				parcel.Outputs[0].Anchor.Value,
+			)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/proof"
@@ -22,9 +24,12 @@ import (
 	"github.com/lightninglabs/taproot-assets/tappsbt"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/tracing"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ProofImporter is used to import proofs into the local proof archive after we
@@ -66,6 +71,11 @@ type ChainPorterConfig struct {
 	// ChainBridge is our bridge to the chain we operate on.
 	ChainBridge ChainBridge
 
+	// SpendNotifier is used to detect a replacement of an unconfirmed
+	// anchor transaction by watching for a spend of its asset inputs. If
+	// it isn't set, replacements aren't detected.
+	SpendNotifier tapgarden.SpendNotifier
+
 	// GroupVerifier is used to verify the validity of the group key for a
 	// genesis proof.
 	GroupVerifier proof.GroupVerifier
@@ -121,6 +131,22 @@ type ChainPorter struct {
 	// subscriberMtx guards the subscribers map.
 	subscriberMtx sync.Mutex
 
+	// drainMtx guards the draining flag and the registration of new state
+	// machines with the inFlight wait group.
+	drainMtx sync.Mutex
+
+	// draining is set once the porter is drained. From then on, no new
+	// parcels are accepted.
+	draining bool
+
+	// drainSignal is closed once the porter is drained, to stop the state
+	// machines that wait for their anchor transaction to confirm.
+	drainSignal chan struct{}
+
+	// inFlight tracks the state machines and proof deliveries that are
+	// currently running.
+	inFlight sync.WaitGroup
+
 	*fn.ContextGuard
 }
 
@@ -134,6 +160,7 @@ func NewChainPorter(cfg *ChainPorterConfig) *ChainPorter {
 		cfg:             cfg,
 		outboundParcels: make(chan Parcel),
 		subscribers:     subscribers,
+		drainSignal:     make(chan struct{}),
 		ContextGuard: &fn.ContextGuard{
 			DefaultTimeout: tapgarden.DefaultTimeout,
 			Quit:           make(chan struct{}),
@@ -267,6 +294,63 @@ func (p *ChainPorter) Stop() error {
 	return stopErr
 }
 
+// Drain stops the porter from accepting new parcels and waits for the parcels
+// in flight to reach a checkpoint they can be resumed from after a restart.
+// Parcels are driven until their anchor transaction is broadcast, and proofs
+// that are being delivered are delivered to their proof courier. Parcels that
+// wait for their anchor transaction to confirm are stopped right away. An
+// error is returned if the context is done before all parcels reached a
+// checkpoint.
+func (p *ChainPorter) Drain(ctx context.Context) error {
+	p.drainMtx.Lock()
+	if !p.draining {
+		log.Infof("Draining ChainPorter")
+
+		p.draining = true
+		close(p.drainSignal)
+	}
+	p.drainMtx.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+
+	case <-ctx.Done():
+		return fmt.Errorf("parcels still in flight: %w", ctx.Err())
+	}
+}
+
+// isDraining returns true if the porter is drained and no longer accepts new
+// parcels.
+func (p *ChainPorter) isDraining() bool {
+	p.drainMtx.Lock()
+	defer p.drainMtx.Unlock()
+
+	return p.draining
+}
+
+// trackInFlight registers a new state machine with the inFlight wait group,
+// unless the porter is drained. It returns false if the state machine must
+// not be started.
+func (p *ChainPorter) trackInFlight() bool {
+	p.drainMtx.Lock()
+	defer p.drainMtx.Unlock()
+
+	if p.draining {
+		return false
+	}
+
+	p.inFlight.Add(1)
+
+	return true
+}
+
 // RequestShipment is the main external entry point to the porter. This request
 // a new transfer take place.
 func (p *ChainPorter) RequestShipment(req Parcel) (*OutboundParcel, error) {
@@ -279,6 +363,10 @@ func (p *ChainPorter) RequestShipment(req Parcel) (*OutboundParcel, error) {
 		return nil, fmt.Errorf("failed to validate parcel: %w", err)
 	}
 
+	if p.isDraining() {
+		return nil, ErrPorterDraining
+	}
+
 	if !fn.SendOrQuit(p.outboundParcels, req, p.Quit) {
 		return nil, fmt.Errorf("ChainPorter shutting down")
 	}
@@ -320,12 +408,24 @@ func (p *ChainPorter) mainEventLoop() {
 			// we want to send to, or a send package is already
 			// initialized.
 			sendPkg := outboundParcel.pkg()
+			kit := outboundParcel.kit()
+
+			// A parcel that made it into the channel before the
+			// porter was drained is rejected as well.
+			if !p.trackInFlight() {
+				kit.errChan <- ErrPorterDraining
+				continue
+			}
 
 			// Advance the state machine for this package as far as
 			// possible in its own goroutine. The status will be
 			// reported through the different channels of the send
 			// package.
-			go p.advanceState(sendPkg, outboundParcel.kit())
+			go func() {
+				defer p.inFlight.Done()
+
+				p.advanceState(sendPkg, kit)
+			}()
 
 		case <-p.Quit:
 			return
@@ -337,6 +437,17 @@ func (p *ChainPorter) mainEventLoop() {
 //
 // NOTE: This method MUST be called as a goroutine.
 func (p *ChainPorter) advanceState(pkg *sendPackage, kit *parcelKit) {
+	// We trace the whole transfer, with a child span for every state we
+	// execute.
+	traceCtx, span := tracer.Start(
+		context.Background(), "ChainPorter.Send",
+		trace.WithAttributes(
+			tracing.TransferStateKey.String(pkg.SendState.String()),
+		),
+	)
+	defer span.End()
+	pkg.traceSpan = span
+
 	// Continue state transitions whilst state complete has not yet
 	// been reached.
 	for pkg.SendState < SendStateComplete {
@@ -352,9 +463,38 @@ func (p *ChainPorter) advanceState(pkg *sendPackage, kit *parcelKit) {
 		default:
 		}
 
+		// Once the anchor transaction is broadcast, the parcel is
+		// resumed from the export log after a restart. So if we're
+		// draining, this is where we stop.
+		if pkg.SendState == SendStateWaitTxConf && p.isDraining() {
+			log.Infof("ChainPorter draining, stopping before "+
+				"state: %v", pkg.SendState)
+
+			return
+		}
+
 		stateToExecute := pkg.SendState
+		_, stateSpan := tracer.Start(
+			traceCtx, "ChainPorter."+stateToExecute.String(),
+		)
 		updatedPkg, err := p.stateStep(*pkg)
+
+		// The anchor transaction is only known after some of the
+		// states were executed, so we add it to the spans as soon as
+		// we know it.
+		if updatedPkg != nil {
+			updatedPkg.anchorTxID().WhenSome(
+				func(txid chainhash.Hash) {
+					attr := tracing.AnchorTxID(txid)
+					span.SetAttributes(attr)
+					stateSpan.SetAttributes(attr)
+				},
+			)
+		}
+		tracing.EndSpan(stateSpan, err)
+
 		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
 			kit.errChan <- err
 			log.Errorf("Error evaluating state (%v): %v",
 				pkg.SendState, err)
@@ -383,8 +523,34 @@ func (p *ChainPorter) advanceState(pkg *sendPackage, kit *parcelKit) {
 
 // waitForTransferTxConf waits for the confirmation of the final transaction
 // within the delta. Once confirmed, the parcel will be marked as delivered on
-// chain, with the goroutine cleaning up its state.
+// chain, with the goroutine cleaning up its state. If the transaction is
+// replaced by a compatible transaction in the meantime, for example because it
+// was fee bumped through RBF, the parcel is moved to the replacement and we
+// wait for its confirmation instead.
 func (p *ChainPorter) waitForTransferTxConf(pkg *sendPackage) error {
+	for {
+		replacement, err := p.waitForAnchorTxConf(pkg)
+		if err != nil {
+			return err
+		}
+
+		// The transaction either confirmed or we're shutting down.
+		if replacement == nil {
+			return nil
+		}
+
+		if err := p.replaceAnchorTx(pkg, replacement); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForAnchorTxConf waits for the confirmation of the current anchor
+// transaction of the parcel. If the asset inputs of the parcel are spent by a
+// different transaction before that, the spending transaction is returned.
+func (p *ChainPorter) waitForAnchorTxConf(pkg *sendPackage) (*wire.MsgTx,
+	error) {
+
 	outboundPkg := pkg.OutboundPkg
 
 	txHash := outboundPkg.AnchorTx.TxHash()
@@ -396,44 +562,155 @@ func (p *ChainPorter) waitForTransferTxConf(pkg *sendPackage) error {
 		outboundPkg.AnchorTxHeightHint, true, nil,
 	)
 	if err != nil {
-		return fmt.Errorf("unable to register for package tx conf: %w",
-			err)
+		confCancel()
+		return nil, fmt.Errorf("unable to register for package tx "+
+			"conf: %w", err)
 	}
 
 	// Launch a goroutine that'll notify us when the transaction confirms.
 	defer confCancel()
 
-	var confEvent *chainntnfs.TxConfirmation
-	select {
-	case confEvent = <-confNtfn.Confirmed:
-		log.Debugf("Got chain confirmation: %v", confEvent.Tx.TxHash())
-		pkg.TransferTxConfEvent = confEvent
-
-		// If the anchoring tx block hash is given, we'll also store it
-		// in the outbound package.
-		pkg.OutboundPkg.AnchorTxBlockHash = fn.MaybeSome(
-			confEvent.BlockHash,
-		)
+	// We also watch for the spend of an asset input, which tells us if the
+	// anchor transaction was replaced by a different transaction.
+	spendChan, spendErrChan := p.registerInputSpendNtfn(
+		confCtx, outboundPkg,
+	)
 
-		pkg.SendState = SendStateStorePostAnchorTxConf
+	for {
+		select {
+		case confEvent := <-confNtfn.Confirmed:
+			if confEvent == nil {
+				return nil, fmt.Errorf("got empty package tx " +
+					"confirmation event in batch")
+			}
 
-	case err := <-errChan:
-		return fmt.Errorf("error whilst waiting for package tx "+
-			"confirmation: %w", err)
+			log.Debugf("Got chain confirmation: %v",
+				confEvent.Tx.TxHash())
+			pkg.TransferTxConfEvent = confEvent
 
-	case <-confCtx.Done():
-		log.Debugf("Skipping TX confirmation, context done")
+			// If the anchoring tx block hash is given, we'll also
+			// store it in the outbound package.
+			pkg.OutboundPkg.AnchorTxBlockHash = fn.MaybeSome(
+				confEvent.BlockHash,
+			)
+			pkg.OutboundPkg.AnchorTxBlockHeight =
+				confEvent.BlockHeight
 
-	case <-p.Quit:
-		log.Debugf("Skipping TX confirmation, exiting")
-		return nil
+			pkg.SendState = SendStateStorePostAnchorTxConf
+
+			return nil, nil
+
+		case spend := <-spendChan:
+			// A spend by our own anchor transaction means we just
+			// need to wait for its confirmation.
+			if spend == nil || *spend.SpenderTxHash == txHash {
+				spendChan = nil
+				continue
+			}
+
+			log.Infof("Transfer anchor tx %v was replaced by %v",
+				txHash, spend.SpenderTxHash)
+
+			return spend.SpendingTx, nil
+
+		case err := <-errChan:
+			return nil, fmt.Errorf("error whilst waiting for "+
+				"package tx confirmation: %w", err)
+
+		case err := <-spendErrChan:
+			return nil, fmt.Errorf("error whilst waiting for "+
+				"package input spend: %w", err)
+
+		case <-confCtx.Done():
+			log.Debugf("Skipping TX confirmation, context done")
+
+			return nil, fmt.Errorf("got empty package tx " +
+				"confirmation event in batch")
+
+		case <-p.Quit:
+			log.Debugf("Skipping TX confirmation, exiting")
+			return nil, nil
+
+		case <-p.drainSignal:
+			log.Debugf("Skipping TX confirmation, draining")
+			return nil, nil
+		}
+	}
+}
+
+// registerInputSpendNtfn registers for the spend of the first asset input of
+// the parcel. If no spend notifier is configured or the input can't be
+// watched, nil channels are returned, which are never selected.
+func (p *ChainPorter) registerInputSpendNtfn(ctx context.Context,
+	parcel *OutboundParcel) (chan *chainntnfs.SpendDetail, chan error) {
+
+	if p.cfg.SpendNotifier == nil || len(parcel.Inputs) == 0 {
+		return nil, nil
+	}
+
+	// We need the pkScript of the input's anchor output, which we find in
+	// the last proof of the input.
+	prevID := parcel.Inputs[0].PrevID
+	inputProofFile, err := p.fetchInputProof(ctx, prevID)
+	if err != nil {
+		log.Warnf("Unable to watch input %v for replacements of the "+
+			"anchor tx: %v", prevID.OutPoint, err)
+		return nil, nil
+	}
+	inputProof, err := inputProofFile.LastProof()
+	if err != nil {
+		log.Warnf("Unable to watch input %v for replacements of the "+
+			"anchor tx: %v", prevID.OutPoint, err)
+		return nil, nil
+	}
+
+	outputIndex := prevID.OutPoint.Index
+	if int(outputIndex) >= len(inputProof.AnchorTx.TxOut) {
+		log.Warnf("Unable to watch input %v for replacements of the "+
+			"anchor tx: invalid output index", prevID.OutPoint)
+		return nil, nil
+	}
+	pkScript := inputProof.AnchorTx.TxOut[outputIndex].PkScript
+
+	spendChan, errChan, err := p.cfg.SpendNotifier.RegisterSpendNtfn(
+		ctx, &prevID.OutPoint, pkScript, parcel.AnchorTxHeightHint,
+	)
+	if err != nil {
+		log.Warnf("Unable to watch input %v for replacements of the "+
+			"anchor tx: %v", prevID.OutPoint, err)
+		return nil, nil
 	}
 
-	if confEvent == nil {
-		return fmt.Errorf("got empty package tx confirmation event " +
-			"in batch")
+	return spendChan, errChan
+}
+
+// replaceAnchorTx moves the parcel to the given replacement of its anchor
+// transaction, both on disk and in memory. The proofs of the transfer are
+// only created once the replacement confirms, so they'll reference the
+// replacement.
+func (p *ChainPorter) replaceAnchorTx(pkg *sendPackage,
+	replacement *wire.MsgTx) error {
+
+	parcel := pkg.OutboundPkg
+	oldTxid := parcel.AnchorTx.TxHash()
+
+	err := CheckAnchorTxReplacement(parcel, replacement)
+	if err != nil {
+		return fmt.Errorf("anchor tx %v was replaced by %v: %w",
+			oldTxid, replacement.TxHash(), err)
+	}
+
+	ctx, cancel := p.WithCtxQuitNoTimeout()
+	defer cancel()
+
+	err = p.cfg.ExportLog.LogAnchorTxReplacement(ctx, oldTxid, replacement)
+	if err != nil {
+		return fmt.Errorf("unable to log anchor tx replacement: %w",
+			err)
 	}
 
+	parcel.ReplaceAnchorTx(replacement)
+
 	return nil
 }
 
@@ -842,13 +1119,303 @@ func reportProofTransfers(notDeliveringOutputs []TransferOutput,
 	}
 }
 
+// skipProofDelivery marks the proof delivery of all outputs of the given parcel
+// that would otherwise have their proof delivered to a peer as skipped.
+func skipProofDelivery(parcel *OutboundParcel) {
+	for idx := range parcel.Outputs {
+		out := &parcel.Outputs[idx]
+		if !out.ProofDeliveryComplete.IsSome() {
+			continue
+		}
+
+		out.ProofDeliveryComplete = fn.None[bool]()
+		out.ProofDeliverySkipped = true
+	}
+}
+
+// addProofCouriers adds the given additional proof couriers to all outputs of
+// the given parcel that have their proof delivered to a peer. The courier of
+// the output itself always has the highest priority, duplicate couriers are
+// ignored.
+func addProofCouriers(parcel *OutboundParcel, courierAddrs []*url.URL,
+	mode ProofCourierMode) {
+
+	for idx := range parcel.Outputs {
+		addOutputProofCouriers(&parcel.Outputs[idx], courierAddrs, mode)
+	}
+}
+
+// addAddrFallbackCouriers adds the fallback proof couriers embedded in the
+// given recipient addresses to the outputs of the given parcel that pay to
+// them. They come right after the courier of the output itself, so they're
+// tried before any additional couriers the sender specified.
+func addAddrFallbackCouriers(parcel *OutboundParcel, addrs []*address.Tap,
+	mode ProofCourierMode) {
+
+	// The script key of an output that pays to a reusable address is
+	// derived from one of the inputs of the transfer.
+	prevOuts := fn.Map(parcel.Inputs, func(in TransferInput) wire.OutPoint {
+		return in.OutPoint
+	})
+
+	for idx := range parcel.Outputs {
+		out := &parcel.Outputs[idx]
+		if out.ScriptKey.PubKey == nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			if len(addr.FallbackProofCourierAddrs) == 0 {
+				continue
+			}
+
+			paysToAddr := out.ScriptKey.PubKey.IsEqual(
+				&addr.ScriptKey,
+			) || addr.IsDepositScriptKey(
+				out.ScriptKey.PubKey, prevOuts,
+				out.Anchor.OutPoint.Index,
+			)
+			if !paysToAddr {
+				continue
+			}
+
+			courierAddrs := fn.Map(
+				addr.FallbackProofCourierAddrs,
+				func(u url.URL) *url.URL {
+					return &u
+				},
+			)
+			addOutputProofCouriers(out, courierAddrs, mode)
+		}
+	}
+}
+
+// addOutputProofCouriers appends the given proof couriers to the couriers of
+// the given output, if the output has its proof delivered to a peer. Duplicate
+// couriers are ignored.
+func addOutputProofCouriers(out *TransferOutput, courierAddrs []*url.URL,
+	mode ProofCourierMode) {
+
+	if !out.ProofDeliveryComplete.IsSome() ||
+		len(out.ProofCourierAddr) == 0 {
+
+		return
+	}
+
+	deliveries := fn.CopySlice(out.ProofCourierDeliveries)
+	if len(deliveries) == 0 {
+		deliveries = []ProofCourierDelivery{{
+			CourierAddr: out.ProofCourierAddr,
+		}}
+	}
+
+	knownAddrs := make(map[string]struct{}, len(deliveries))
+	for _, delivery := range deliveries {
+		knownAddrs[string(delivery.CourierAddr)] = struct{}{}
+	}
+	for _, courierAddr := range courierAddrs {
+		addrStr := courierAddr.String()
+		if _, ok := knownAddrs[addrStr]; ok {
+			continue
+		}
+
+		knownAddrs[addrStr] = struct{}{}
+		deliveries = append(deliveries, ProofCourierDelivery{
+			CourierAddr: []byte(addrStr),
+		})
+	}
+
+	// If all additional couriers were duplicates, the proof is just
+	// delivered to the single courier of the output.
+	if len(deliveries) < 2 {
+		return
+	}
+
+	out.ProofCourierMode = mode
+	out.ProofCourierDeliveries = deliveries
+}
+
+// deliverToCourier delivers the given proof to the proof courier with the
+// given address.
+func (p *ChainPorter) deliverToCourier(ctx context.Context,
+	courierAddr []byte, recipient proof.Recipient,
+	receiverProof *proof.AnnotatedProof) error {
+
+	log.Debugf("Attempting to deliver proof (script_key=%x, "+
+		"proof_courier_addr=%s)",
+		recipient.ScriptKey.SerializeCompressed(), courierAddr)
+
+	proofCourierAddr, err := proof.ParseCourierAddress(string(courierAddr))
+	if err != nil {
+		return fmt.Errorf("failed to parse proof courier address: %w",
+			err)
+	}
+
+	// Initiate proof courier service handle from the proof courier
+	// address.
+	courier, err := p.cfg.ProofCourierDispatcher.NewCourier(
+		ctx, proofCourierAddr, true,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to initiate proof courier service "+
+			"handle: %w", err)
+	}
+
+	defer courier.Close()
+
+	// Update courier events subscribers before attempting to deliver
+	// proof.
+	p.subscriberMtx.Lock()
+	courier.SetSubscribers(p.subscribers)
+	p.subscriberMtx.Unlock()
+
+	// Deliver proof to proof courier service.
+	err = courier.DeliverProof(ctx, recipient, receiverProof)
+	if err != nil {
+		return fmt.Errorf("failed to deliver proof via courier "+
+			"service: %w", err)
+	}
+
+	return nil
+}
+
+// deliverToCouriers delivers the proof of the given output to its proof
+// couriers, according to the output's proof courier mode. Every delivery
+// attempt is recorded in the export log and published as a send event. It
+// returns true if the proof delivery of the output is complete. A failed
+// delivery to a single courier is only returned as an error if it didn't fail
+// with a backoff error, which means it should be retried later.
+func (p *ChainPorter) deliverToCouriers(ctx context.Context,
+	pkg *sendPackage, deliveryMtx *sync.Mutex, out TransferOutput,
+	recipient proof.Recipient,
+	receiverProof *proof.AnnotatedProof) (bool, error) {
+
+	deliveryMtx.Lock()
+	deliveries := fn.CopySlice(out.ProofCourierDeliveries)
+	deliveryMtx.Unlock()
+
+	var (
+		numDelivered int
+		firstErr     error
+	)
+	for idx, delivery := range deliveries {
+		if !delivery.Delivered {
+			deliveryErr := p.deliverToCourier(
+				ctx, delivery.CourierAddr, recipient,
+				receiverProof,
+			)
+
+			err := p.logProofCourierDelivery(
+				ctx, pkg, deliveryMtx, out, uint32(idx),
+				deliveryErr,
+			)
+			if err != nil {
+				return false, err
+			}
+
+			var backoffExecErr *proof.BackoffExecError
+			switch {
+			// The proof was delivered to this courier.
+			case deliveryErr == nil:
+
+			case errors.As(deliveryErr, &backoffExecErr):
+				log.Warnf("Unable to deliver proof to courier "+
+					"%s, will retry later: %v",
+					delivery.CourierAddr, deliveryErr)
+
+				continue
+
+			default:
+				log.Errorf("Unable to deliver proof to "+
+					"courier %s: %v", delivery.CourierAddr,
+					deliveryErr)
+
+				if firstErr == nil {
+					firstErr = deliveryErr
+				}
+
+				continue
+			}
+		}
+
+		// In failover mode, the delivery is complete as soon as one
+		// courier received the proof.
+		numDelivered++
+		if out.ProofCourierMode == ProofCourierModeFailover {
+			return true, nil
+		}
+	}
+
+	if numDelivered == len(deliveries) {
+		return true, nil
+	}
+
+	return false, firstErr
+}
+
+// logProofCourierDelivery records the outcome of a delivery attempt of the
+// proof of the given output to the proof courier with the given priority. The
+// attempt is written to the export log, applied to the package and published
+// to the subscribers as a send event.
+func (p *ChainPorter) logProofCourierDelivery(ctx context.Context,
+	pkg *sendPackage, deliveryMtx *sync.Mutex, out TransferOutput,
+	priority uint32, deliveryErr error) error {
+
+	attemptTime := time.Now().UTC()
+	err := p.cfg.ExportLog.LogProofCourierDelivery(
+		ctx, out.Anchor.OutPoint, out.Position, priority, deliveryErr,
+		attemptTime,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to log proof courier delivery: %w",
+			err)
+	}
+
+	deliveryMtx.Lock()
+	for idx := range pkg.OutboundPkg.Outputs {
+		pkgOut := &pkg.OutboundPkg.Outputs[idx]
+		if pkgOut.Position != out.Position ||
+			int(priority) >= len(pkgOut.ProofCourierDeliveries) {
+
+			continue
+		}
+
+		delivery := &pkgOut.ProofCourierDeliveries[priority]
+		delivery.Delivered = deliveryErr == nil
+		delivery.LastError = ""
+		if deliveryErr != nil {
+			delivery.LastError = deliveryErr.Error()
+		}
+		delivery.LastAttempt = attemptTime
+	}
+	event := newAssetSendEvent(SendStateTransferProofs, *pkg)
+	deliveryMtx.Unlock()
+
+	p.publishSubscriberEvent(event)
+
+	return nil
+}
+
 // transferReceiverProof retrieves the sender and receiver proofs from the
 // archive and then transfers the receiver's proof to the receiver. Upon
 // successful transfer, the asset parcel delivery is marked as complete.
-func (p *ChainPorter) transferReceiverProof(pkg *sendPackage) error {
+func (p *ChainPorter) transferReceiverProof(pkg *sendPackage) (err error) {
 	ctx, cancel := p.WithCtxQuitNoTimeout()
 	defer cancel()
 
+	// The proof delivery is part of the trace of the transfer, even
+	// though the state machine already completed.
+	ctx, span := tracer.Start(
+		trace.ContextWithSpan(ctx, pkg.traceSpan),
+		"ChainPorter.transferReceiverProof",
+	)
+	pkg.anchorTxID().WhenSome(func(txid chainhash.Hash) {
+		span.SetAttributes(tracing.AnchorTxID(txid))
+	})
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	// Classify transfer outputs into those that require proof delivery and
 	// those that do not.
 	var (
@@ -877,6 +1444,10 @@ func (p *ChainPorter) transferReceiverProof(pkg *sendPackage) error {
 	// those that do not.
 	reportProofTransfers(notDeliveringOutputs, pendingDeliveryOutputs)
 
+	// deliveryMtx guards the proof courier delivery state of the outputs
+	// of the package, which is updated by the concurrent deliveries below.
+	var deliveryMtx sync.Mutex
+
 	deliver := func(ctx context.Context, out TransferOutput) error {
 		key := out.ScriptKey.PubKey
 
@@ -895,59 +1466,50 @@ func (p *ChainPorter) transferReceiverProof(pkg *sendPackage) error {
 				"script key %x", key.SerializeCompressed())
 		}
 
-		log.Debugf("Attempting to deliver proof (script_key=%x, "+
-			"proof_courier_addr=%s)", key.SerializeCompressed(),
-			out.ProofCourierAddr)
-
-		proofCourierAddr, err := proof.ParseCourierAddress(
-			string(out.ProofCourierAddr),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to parse proof courier "+
-				"address: %w", err)
-		}
-
-		// Initiate proof courier service handle from the proof
-		// courier address found in the Tap address.
-		courier, err := p.cfg.ProofCourierDispatcher.NewCourier(
-			ctx, proofCourierAddr, true,
-		)
-		if err != nil {
-			return fmt.Errorf("unable to initiate proof courier "+
-				"service handle: %w", err)
-		}
-
-		defer courier.Close()
-
-		// Update courier events subscribers before attempting to
-		// deliver proof.
-		p.subscriberMtx.Lock()
-		courier.SetSubscribers(p.subscribers)
-		p.subscriberMtx.Unlock()
-
-		// Deliver proof to proof courier service.
 		recipient := proof.Recipient{
 			ScriptKey: key,
 			AssetID:   *receiverProof.AssetID,
 			Amount:    out.Amount,
 		}
-		err = courier.DeliverProof(ctx, recipient, receiverProof)
 
-		// If the proof courier returned a backoff error, then
-		// we'll just return nil here so that we can retry
-		// later.
-		var backoffExecErr *proof.BackoffExecError
-		if errors.As(err, &backoffExecErr) {
-			return nil
-		}
-		if err != nil {
-			return fmt.Errorf("failed to deliver proof via "+
-				"courier service: %w", err)
+		// If there are multiple proof couriers for this output, we
+		// deliver the proof according to the output's courier mode.
+		// Otherwise, we only deliver it to the single courier.
+		if len(out.ProofCourierDeliveries) > 0 {
+			complete, err := p.deliverToCouriers(
+				ctx, pkg, &deliveryMtx, out, recipient,
+				receiverProof,
+			)
+			if err != nil {
+				return err
+			}
+
+			// If the delivery isn't complete yet, we'll retry
+			// later.
+			if !complete {
+				return nil
+			}
+		} else {
+			err := p.deliverToCourier(
+				ctx, out.ProofCourierAddr, recipient,
+				receiverProof,
+			)
+
+			// If the proof courier returned a backoff error, then
+			// we'll just return nil here so that we can retry
+			// later.
+			var backoffExecErr *proof.BackoffExecError
+			if errors.As(err, &backoffExecErr) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
 		}
 
 		// The proof has been successfully delivered to the receiver.
 		// Now, we will update our transfer log to reflect this.
-		err = p.cfg.ExportLog.ConfirmProofDelivery(
+		err := p.cfg.ExportLog.ConfirmProofDelivery(
 			ctx, out.Anchor.OutPoint, out.Position,
 		)
 		if err != nil {
@@ -1084,13 +1646,27 @@ func (p *ChainPorter) stateStep(currentPkg sendPackage) (*sendPackage, error) {
 				"address parcel")
 		}
 		fundSendRes, err := p.cfg.AssetWallet.FundAddressSend(
-			ctx, tapsend.Bip86Only, addrParcel.destAddrs...,
+			ctx, tapsend.Bip86Only, addrParcel.account,
+			addrParcel.destAddrs...,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("unable to fund address send: "+
 				"%w", err)
 		}
 
+		// If the sender chose a different proof courier for this
+		// transfer, it replaces the one of the recipient addresses.
+		// Outputs without a proof courier address (e.g. the change)
+		// don't receive a proof, so we leave them as they are.
+		courierAddr := addrParcel.proofDelivery.CourierAddr
+		if courierAddr != nil {
+			for _, vOut := range fundSendRes.VPacket.Outputs {
+				if vOut.ProofDeliveryAddress != nil {
+					vOut.ProofDeliveryAddress = courierAddr
+				}
+			}
+		}
+
 		currentPkg.VirtualPackets = []*tappsbt.VPacket{
 			fundSendRes.VPacket,
 		}
@@ -1149,10 +1725,10 @@ func (p *ChainPorter) stateStep(currentPkg sendPackage) (*sendPackage, error) {
 		)
 
 		// First, use a manual fee rate if specified by the parcel.
-		addrParcel, ok := currentPkg.Parcel.(*AddressParcel)
+		manualFeeRate := parcelFeeRate(currentPkg.Parcel)
 		switch {
-		case ok && addrParcel.transferFeeRate != nil:
-			feeRate = *addrParcel.transferFeeRate
+		case manualFeeRate != nil:
+			feeRate = *manualFeeRate
 			log.Infof("sending with manual fee rate")
 
 		default:
@@ -1184,7 +1760,7 @@ func (p *ChainPorter) stateStep(currentPkg sendPackage) (*sendPackage, error) {
 			switch {
 			// If a fee rate was manually assigned for this parcel,
 			// we err out, otherwise we silently bump the feerate.
-			case addrParcel.transferFeeRate != nil:
+			case manualFeeRate != nil:
 				// This case should already have been handled by
 				// the `checkFeeRateSanity` of `rpcserver.go`.
 				// We check here again to be safe.
@@ -1319,6 +1895,28 @@ func (p *ChainPorter) stateStep(currentPkg sendPackage) (*sendPackage, error) {
 			return nil, fmt.Errorf("unable to prepare parcel for "+
 				"storage: %w", err)
 		}
+
+		// If the sender delivers the proofs out of band, we record
+		// that for every output that would otherwise have its proof
+		// delivered by us.
+		addrParcel, ok := currentPkg.Parcel.(*AddressParcel)
+		if ok && addrParcel.proofDelivery.Skip {
+			skipProofDelivery(parcel)
+		}
+
+		// If the recipient addresses embed fallback proof couriers or
+		// the sender specified additional ones, the proofs are
+		// delivered to those as well.
+		if ok {
+			delivery := addrParcel.proofDelivery
+			addAddrFallbackCouriers(
+				parcel, addrParcel.destAddrs, delivery.Mode,
+			)
+			addProofCouriers(
+				parcel, delivery.AdditionalCourierAddrs,
+				delivery.Mode,
+			)
+		}
 		currentPkg.OutboundPkg = parcel
 
 		// Don't allow shutdown while we're attempting to store proofs.
@@ -1435,9 +2033,13 @@ func (p *ChainPorter) stateStep(currentPkg sendPackage) (*sendPackage, error) {
 		// deliver in the background.
 		currentPkg.SendState = SendStateComplete
 
+		// The delivery is still in flight while we're draining, as
+		// we're called from a running state machine.
 		p.Wg.Add(1)
+		p.inFlight.Add(1)
 		go func() {
 			defer p.Wg.Done()
+			defer p.inFlight.Done()
 
 			err := p.transferReceiverProof(&currentPkg)
 			if err != nil {
@@ -1507,6 +2109,21 @@ func (p *ChainPorter) unlockInputs(ctx context.Context, pkg *sendPackage) {
 	}
 }
 
+// parcelFeeRate returns the manually set fee rate of the given parcel, or nil
+// if the fee rate should be estimated.
+func parcelFeeRate(parcel Parcel) *chainfee.SatPerKWeight {
+	switch p := parcel.(type) {
+	case *AddressParcel:
+		return p.transferFeeRate
+
+	case *PreSignedParcel:
+		return p.transferFeeRate
+
+	default:
+		return nil
+	}
+}
+
 // logPacket logs the virtual packet to the debug log.
 func logPacket(vPkt *tappsbt.VPacket, action string) {
 	firstRecipient, err := vPkt.FirstNonSplitRootOutput()
@@ -1,17 +1,175 @@
 package tapfreighter
 
 import (
+	"context"
 	"math/rand"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightningnetwork/lnd/build"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunChainPorter(t *testing.T) {
 	t.Parallel()
 }
 
+// TestSkipProofDelivery tests that skipping the proof delivery of a parcel
+// only affects the outputs that would otherwise have their proof delivered.
+func TestSkipProofDelivery(t *testing.T) {
+	t.Parallel()
+
+	courierAddr := []byte("universerpc://localhost")
+	parcel := &OutboundParcel{
+		Outputs: []TransferOutput{{
+			ScriptKey:             asset.RandScriptKey(t),
+			ProofCourierAddr:      courierAddr,
+			ProofDeliveryComplete: fn.Some(false),
+		}, {
+			ScriptKey: asset.RandScriptKey(t),
+		}},
+	}
+
+	shouldDeliver, err := parcel.Outputs[0].ShouldDeliverProof()
+	require.NoError(t, err)
+	require.True(t, shouldDeliver)
+
+	skipProofDelivery(parcel)
+
+	require.True(t, parcel.Outputs[0].ProofDeliverySkipped)
+	require.True(t, parcel.Outputs[0].ProofDeliveryComplete.IsNone())
+	require.False(t, parcel.Outputs[1].ProofDeliverySkipped)
+
+	for _, out := range parcel.Outputs {
+		shouldDeliver, err := out.ShouldDeliverProof()
+		require.NoError(t, err)
+		require.False(t, shouldDeliver)
+	}
+}
+
+// TestAddProofCouriers tests that additional proof couriers are only added to
+// outputs that have their proof delivered to a peer, without duplicates.
+func TestAddProofCouriers(t *testing.T) {
+	t.Parallel()
+
+	courierAddr := []byte("universerpc://localhost:10009")
+	parcel := &OutboundParcel{
+		Outputs: []TransferOutput{{
+			ScriptKey:             asset.RandScriptKey(t),
+			ProofCourierAddr:      courierAddr,
+			ProofDeliveryComplete: fn.Some(false),
+		}, {
+			ScriptKey: asset.RandScriptKey(t),
+		}},
+	}
+
+	backupAddr, err := url.Parse("universerpc://localhost:10010")
+	require.NoError(t, err)
+	primaryAddr, err := url.Parse(string(courierAddr))
+	require.NoError(t, err)
+
+	addProofCouriers(
+		parcel, []*url.URL{primaryAddr, backupAddr, backupAddr},
+		ProofCourierModeAll,
+	)
+
+	out := parcel.Outputs[0]
+	require.Equal(t, ProofCourierModeAll, out.ProofCourierMode)
+	require.Equal(t, []ProofCourierDelivery{{
+		CourierAddr: courierAddr,
+	}, {
+		CourierAddr: []byte(backupAddr.String()),
+	}}, out.ProofCourierDeliveries)
+	require.Empty(t, parcel.Outputs[1].ProofCourierDeliveries)
+
+	// A copy of the parcel must not share the delivery state.
+	parcelCopy := parcel.Copy()
+	parcelCopy.Outputs[0].ProofCourierDeliveries[0].Delivered = true
+	require.False(t, parcel.Outputs[0].ProofCourierDeliveries[0].Delivered)
+}
+
+// TestAddAddrFallbackCouriers tests that the fallback proof couriers of a
+// recipient address are added to the output paying to it, before any
+// additional couriers of the sender.
+func TestAddAddrFallbackCouriers(t *testing.T) {
+	t.Parallel()
+
+	addr, _, _ := address.RandAddr(
+		t, &address.RegressionNetTap, address.RandProofCourierAddr(t),
+	)
+	fallbackAddr, err := url.Parse("universerpc://fallback:10029")
+	require.NoError(t, err)
+	addr.Version = address.V2
+	addr.FallbackProofCourierAddrs = []url.URL{*fallbackAddr}
+
+	courierAddr := []byte(addr.ProofCourierAddr.String())
+	addrScriptKey := asset.NewScriptKey(&addr.ScriptKey)
+	parcel := &OutboundParcel{
+		Outputs: []TransferOutput{{
+			ScriptKey:             addrScriptKey,
+			ProofCourierAddr:      courierAddr,
+			ProofDeliveryComplete: fn.Some(false),
+		}, {
+			ScriptKey:             asset.RandScriptKey(t),
+			ProofCourierAddr:      courierAddr,
+			ProofDeliveryComplete: fn.Some(false),
+		}},
+	}
+
+	senderAddr, err := url.Parse("universerpc://sender:10029")
+	require.NoError(t, err)
+
+	addAddrFallbackCouriers(
+		parcel, []*address.Tap{addr.Tap}, ProofCourierModeFailover,
+	)
+	addProofCouriers(
+		parcel, []*url.URL{fallbackAddr, senderAddr},
+		ProofCourierModeFailover,
+	)
+
+	require.Equal(t, []ProofCourierDelivery{{
+		CourierAddr: courierAddr,
+	}, {
+		CourierAddr: []byte(fallbackAddr.String()),
+	}, {
+		CourierAddr: []byte(senderAddr.String()),
+	}}, parcel.Outputs[0].ProofCourierDeliveries)
+
+	// The output that doesn't pay to the address only gets the couriers
+	// of the sender.
+	require.Equal(t, []ProofCourierDelivery{{
+		CourierAddr: courierAddr,
+	}, {
+		CourierAddr: []byte(fallbackAddr.String()),
+	}, {
+		CourierAddr: []byte(senderAddr.String()),
+	}}, parcel.Outputs[1].ProofCourierDeliveries)
+}
+
+// TestAddressParcelExpiry tests that an address parcel to an expired address is
+// rejected.
+func TestAddressParcelExpiry(t *testing.T) {
+	t.Parallel()
+
+	addr, _, _ := address.RandAddr(
+		t, &address.RegressionNetTap, address.RandProofCourierAddr(t),
+	)
+	addr.Version = address.V2
+
+	parcel := NewAddressParcel(
+		nil, fn.None[account.Account](), ProofDelivery{}, addr.Tap,
+	)
+	require.NoError(t, parcel.Validate())
+
+	addr.Expiry = time.Now().Add(-time.Minute)
+	require.ErrorIs(t, parcel.Validate(), address.ErrAddrExpired)
+}
+
 func init() {
 	rand.Seed(time.Now().Unix())
 
@@ -20,3 +178,35 @@ func init() {
 	logWriter.RegisterSubLogger(Subsystem, logger)
 	UseLogger(logger)
 }
+
+// TestChainPorterDrain tests that draining the porter rejects new parcels and
+// waits for the state machines that are still in flight.
+func TestChainPorterDrain(t *testing.T) {
+	t.Parallel()
+
+	p := NewChainPorter(&ChainPorterConfig{})
+
+	// A state machine is started before the porter is drained.
+	require.True(t, p.trackInFlight())
+
+	// As long as the state machine is running, draining times out.
+	ctx := context.Background()
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, p.Drain(timeoutCtx), context.DeadlineExceeded)
+
+	// New state machines aren't started anymore, and the state machines
+	// waiting for a confirmation are signaled to stop.
+	require.True(t, p.isDraining())
+	require.False(t, p.trackInFlight())
+
+	select {
+	case <-p.drainSignal:
+	default:
+		t.Fatalf("drain signal not closed")
+	}
+
+	// Once the state machine reached a checkpoint, draining completes.
+	p.inFlight.Done()
+	require.NoError(t, p.Drain(ctx))
+}
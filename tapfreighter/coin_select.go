@@ -1,9 +1,12 @@
 package tapfreighter
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"fmt"
-	"sort"
+	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -12,10 +15,17 @@ import (
 	"github.com/lightninglabs/taproot-assets/fn"
 )
 
+// maxExactSelectTries is the maximum number of steps the exact amount coin
+// selection performs before giving up.
+const maxExactSelectTries = 100_000
+
 // NewCoinSelect creates a new CoinSelect.
-func NewCoinSelect(coinLister CoinLister) *CoinSelect {
+func NewCoinSelect(coinLister CoinLister,
+	reservations *InputReservations) *CoinSelect {
+
 	return &CoinSelect{
-		coinLister: coinLister,
+		coinLister:   coinLister,
+		reservations: reservations,
 	}
 }
 
@@ -24,6 +34,11 @@ func NewCoinSelect(coinLister CoinLister) *CoinSelect {
 type CoinSelect struct {
 	coinLister CoinLister
 
+	// reservations keeps track of which subsystem of the daemon selected
+	// which inputs. Inputs reserved by one subsystem are never selected
+	// for another one.
+	reservations *InputReservations
+
 	// coinLock is a read/write mutex that is used to ensure that only one
 	// goroutine is attempting to call any coin selection related methods at
 	// any time. This is necessary as some of the calls to the store (e.g.
@@ -45,6 +60,73 @@ func (s *CoinSelect) SelectCoins(ctx context.Context,
 	s.coinLock.Lock()
 	defer s.coinLock.Unlock()
 
+	compatibleCommitments, err := s.listCompatibleCoins(
+		ctx, constraints, maxVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reserver := constraints.Reserver
+	if reserver == "" {
+		reserver = ReserverWallet
+	}
+
+	selectedCoins, err := s.selectForAmount(
+		constraints.MinAmt, compatibleCommitments, strategy,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select coins: %w", err)
+	}
+
+	// We now need to lock/lease/reserve those selected coins so
+	// that they can't be used by other processes.
+	expiry := time.Now().Add(defaultCoinLeaseDuration)
+	coinOutPoints := fn.Map(
+		selectedCoins, func(c *AnchoredCommitment) wire.OutPoint {
+			return c.AnchorPoint
+		},
+	)
+	err = s.reservations.Reserve(reserver, expiry, coinOutPoints...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reserve coins: %w", err)
+	}
+
+	err = s.coinLister.LeaseCoins(
+		ctx, defaultWalletLeaseIdentifier, expiry, coinOutPoints...,
+	)
+	if err != nil {
+		s.reservations.Release(reserver, coinOutPoints...)
+
+		return nil, fmt.Errorf("unable to lease coin: %w", err)
+	}
+
+	return selectedCoins, nil
+}
+
+// ListCoins returns all not yet leased coins that satisfy the given
+// constraints, without leasing them. The minimum amount of the constraints is
+// ignored.
+func (s *CoinSelect) ListCoins(ctx context.Context,
+	constraints CommitmentConstraints,
+	maxVersion commitment.TapCommitmentVersion) ([]*AnchoredCommitment,
+	error) {
+
+	s.coinLock.Lock()
+	defer s.coinLock.Unlock()
+
+	return s.listCompatibleCoins(ctx, constraints, maxVersion)
+}
+
+// listCompatibleCoins returns all not yet leased coins that satisfy the given
+// constraints, are anchored in a commitment of at most the given version and
+// aren't reserved by another subsystem than the one of the constraints. The
+// coin lock must be held when calling this method.
+func (s *CoinSelect) listCompatibleCoins(ctx context.Context,
+	constraints CommitmentConstraints,
+	maxVersion commitment.TapCommitmentVersion) ([]*AnchoredCommitment,
+	error) {
+
 	// Before we select any coins, let's do some cleanup of expired leases.
 	if err := s.coinLister.DeleteExpiredLeases(ctx); err != nil {
 		return nil, fmt.Errorf("unable to delete expired leases: %w",
@@ -55,6 +137,8 @@ func (s *CoinSelect) SelectCoins(ctx context.Context,
 		AssetSpecifier: constraints.AssetSpecifier,
 		MinAmt:         1,
 		CoinSelectType: constraints.CoinSelectType,
+		Account:        constraints.Account,
+		ScriptKey:      constraints.ScriptKey,
 	}
 	eligibleCommitments, err := s.coinLister.ListEligibleCoins(
 		ctx, listConstraints,
@@ -76,39 +160,28 @@ func (s *CoinSelect) SelectCoins(ctx context.Context,
 		"%v", len(anchorInputs), constraints.MinAmt,
 		constraints.String(), anchorInputs)
 
-	// Only select coins anchored in a compatible commitment.
+	reserver := constraints.Reserver
+	if reserver == "" {
+		reserver = ReserverWallet
+	}
+
+	// Only select coins anchored in a compatible commitment that aren't
+	// reserved by another subsystem.
 	compatibleCommitments := fn.Filter(
 		eligibleCommitments, func(c *AnchoredCommitment) bool {
-			return c.Commitment.Version <= maxVersion
+			if c.Commitment.Version > maxVersion {
+				return false
+			}
+
+			owner, ok := s.reservations.ReservedBy(c.AnchorPoint)
+			return !ok || owner == reserver
 		},
 	)
 	if len(compatibleCommitments) == 0 {
 		return nil, ErrMatchingAssetsNotFound
 	}
 
-	selectedCoins, err := s.selectForAmount(
-		constraints.MinAmt, compatibleCommitments, strategy,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to select coins: %w", err)
-	}
-
-	// We now need to lock/lease/reserve those selected coins so
-	// that they can't be used by other processes.
-	expiry := time.Now().Add(defaultCoinLeaseDuration)
-	coinOutPoints := fn.Map(
-		selectedCoins, func(c *AnchoredCommitment) wire.OutPoint {
-			return c.AnchorPoint
-		},
-	)
-	err = s.coinLister.LeaseCoins(
-		ctx, defaultWalletLeaseIdentifier, expiry, coinOutPoints...,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to lease coin: %w", err)
-	}
-
-	return selectedCoins, nil
+	return compatibleCommitments, nil
 }
 
 // LeaseCoins leases/locks/reserves coins for the given lease owner until the
@@ -133,7 +206,21 @@ func (s *CoinSelect) ReleaseCoins(ctx context.Context,
 	s.coinLock.Lock()
 	defer s.coinLock.Unlock()
 
-	return s.coinLister.ReleaseCoins(ctx, utxoOutpoints...)
+	err := s.coinLister.ReleaseCoins(ctx, utxoOutpoints...)
+	if err != nil {
+		return err
+	}
+
+	// The leases in the database aren't bound to a subsystem either, so
+	// we release the reservations regardless of who holds them.
+	s.reservations.ForceRelease(utxoOutpoints...)
+
+	return nil
+}
+
+// Reservations returns all current input reservations, ordered by outpoint.
+func (s *CoinSelect) Reservations() []Reservation {
+	return s.reservations.Reservations()
 }
 
 // selectForAmount selects a subset of the given eligible commitments which
@@ -152,17 +239,11 @@ func (s *CoinSelect) selectForAmount(minTotalAmount uint64,
 	switch strategy {
 	case PreferMaxAmount:
 		// Sort eligible commitments from the largest amount to
-		// smallest.
-		sort.Slice(
-			eligibleCommitments, func(i, j int) bool {
-				isLess := eligibleCommitments[i].Asset.Amount <
-					eligibleCommitments[j].Asset.Amount
-
-				// Negate the result to sort in descending
-				// order.
-				return !isLess
-			},
-		)
+		// smallest. Coins of the same amount are ordered by their
+		// anchor outpoint and asset, so the same set of coins always
+		// results in the same selection, independent of the order
+		// they were listed in.
+		slices.SortStableFunc(eligibleCommitments, compareCoins)
 
 		// Select the first subset of eligible commitments which
 		// cumulatively sum to at least the minimum required amount.
@@ -181,6 +262,37 @@ func (s *CoinSelect) selectForAmount(minTotalAmount uint64,
 			}
 		}
 
+	case PreferMinAmount:
+		// Sort eligible commitments from the smallest amount to the
+		// largest, so the smallest coins are selected first.
+		slices.SortStableFunc(eligibleCommitments, compareCoinsAsc)
+
+		for _, anchoredCommitment := range eligibleCommitments {
+			selectedCommitments = append(
+				selectedCommitments, anchoredCommitment,
+			)
+
+			amountSum += anchoredCommitment.Asset.Amount
+			if amountSum >= minTotalAmount {
+				break
+			}
+		}
+
+	case ExactAmount:
+		slices.SortStableFunc(eligibleCommitments, compareCoins)
+
+		// If all coins together don't reach the target amount, there
+		// is nothing to suggest above the target. We return the same
+		// error as the other strategies in that case.
+		for _, anchoredCommitment := range eligibleCommitments {
+			amountSum += anchoredCommitment.Asset.Amount
+		}
+		if amountSum < minTotalAmount {
+			return nil, ErrMatchingAssetsNotFound
+		}
+
+		return selectExactAmount(minTotalAmount, eligibleCommitments)
+
 	default:
 		return nil, fmt.Errorf("unknown multi coin selection "+
 			"strategy: %v", strategy)
@@ -194,4 +306,142 @@ func (s *CoinSelect) selectForAmount(minTotalAmount uint64,
 	return selectedCommitments, nil
 }
 
+// selectExactAmount selects a subset of the given commitments that sums to
+// exactly the target amount. The commitments must be sorted by descending
+// amount. The search is a depth first branch and bound search that gives up
+// after maxExactSelectTries steps. If no exact match is found, an
+// *ExactAmountError with the nearest achievable amounts seen during the search
+// is returned.
+func selectExactAmount(target uint64,
+	sortedCommitments []*AnchoredCommitment) ([]*AnchoredCommitment,
+	error) {
+
+	// remaining[i] is the sum of all amounts from index i onward, which
+	// allows us to prune branches that can't reach the target anymore.
+	remaining := make([]uint64, len(sortedCommitments)+1)
+	for i := len(sortedCommitments) - 1; i >= 0; i-- {
+		amt := sortedCommitments[i].Asset.Amount
+		remaining[i] = remaining[i+1] + amt
+	}
+
+	var (
+		tries        int
+		selected     []int
+		result       []int
+		nearestBelow fn.Option[uint64]
+		nearestAbove fn.Option[uint64]
+	)
+	updateBelow := func(amt uint64) {
+		if amt > 0 && amt > nearestBelow.UnwrapOr(0) {
+			nearestBelow = fn.Some(amt)
+		}
+	}
+	updateAbove := func(amt uint64) {
+		if amt < nearestAbove.UnwrapOr(math.MaxUint64) {
+			nearestAbove = fn.Some(amt)
+		}
+	}
+
+	var search func(idx int, sum uint64) bool
+	search = func(idx int, sum uint64) bool {
+		tries++
+		switch {
+		case sum == target:
+			result = slices.Clone(selected)
+			return true
+
+		// Adding more coins only increases the sum, so this branch is
+		// done.
+		case sum > target:
+			updateAbove(sum)
+			return false
+
+		// Even adding all remaining coins doesn't reach the target.
+		case sum+remaining[idx] < target:
+			updateBelow(sum + remaining[idx])
+			return false
+
+		case tries > maxExactSelectTries:
+			return false
+		}
+
+		updateBelow(sum)
+
+		// First try to include the coin at the current index.
+		selected = append(selected, idx)
+		amt := sortedCommitments[idx].Asset.Amount
+		if search(idx+1, sum+amt) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+
+		// If we exclude the coin, we can also skip all following coins
+		// of the same amount, as including any of those would result
+		// in a combination we've already tried.
+		next := idx + 1
+		for next < len(sortedCommitments) &&
+			sortedCommitments[next].Asset.Amount == amt {
+
+			next++
+		}
+
+		return search(next, sum)
+	}
+
+	if search(0, 0) {
+		return fn.Map(result, func(idx int) *AnchoredCommitment {
+			return sortedCommitments[idx]
+		}), nil
+	}
+
+	return nil, &ExactAmountError{
+		Amount:       target,
+		NearestBelow: nearestBelow,
+		NearestAbove: nearestAbove,
+	}
+}
+
+// compareCoinsAsc orders the given coins by ascending amount. Coins of the
+// same amount are ordered like in compareCoins.
+func compareCoinsAsc(a, b *AnchoredCommitment) int {
+	if c := cmp.Compare(a.Asset.Amount, b.Asset.Amount); c != 0 {
+		return c
+	}
+
+	return compareCoins(a, b)
+}
+
+// compareCoins orders the given coins by descending amount. Coins of the same
+// amount are ordered by their anchor outpoint, then by asset ID and finally by
+// script key.
+func compareCoins(a, b *AnchoredCommitment) int {
+	if c := cmp.Compare(b.Asset.Amount, a.Asset.Amount); c != 0 {
+		return c
+	}
+
+	aOp, bOp := a.AnchorPoint, b.AnchorPoint
+	if c := bytes.Compare(aOp.Hash[:], bOp.Hash[:]); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(aOp.Index, bOp.Index); c != 0 {
+		return c
+	}
+
+	aID, bID := a.Asset.ID(), b.Asset.ID()
+	if c := bytes.Compare(aID[:], bID[:]); c != 0 {
+		return c
+	}
+
+	// Coins without a script key can't be told apart any further, so we
+	// keep their original order.
+	if a.Asset.ScriptKey.PubKey == nil || b.Asset.ScriptKey.PubKey == nil {
+		return 0
+	}
+
+	return bytes.Compare(
+		a.Asset.ScriptKey.PubKey.SerializeCompressed(),
+		b.Asset.ScriptKey.PubKey.SerializeCompressed(),
+	)
+}
+
 var _ CoinSelector = (*CoinSelect)(nil)
@@ -2,6 +2,8 @@ package tapfreighter
 
 import (
 	"context"
+	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,7 +70,9 @@ func TestCoinSelector(t *testing.T) {
 		ctxb       = context.Background()
 		timeout    = 20 * time.Millisecond
 		coinLister = newMockCoinLister(nil)
-		coinSelect = NewCoinSelect(coinLister)
+		coinSelect = NewCoinSelect(
+			coinLister, NewInputReservations(clock.NewDefaultClock()),
+		)
 	)
 
 	// Make sure the correct methods are called on the coin lister depending
@@ -125,6 +131,40 @@ func TestCoinSelector(t *testing.T) {
 	require.NoError(t, err)
 	_, err = fn.RecvOrTimeout(coinLister.leaseSignals, timeout)
 	require.NoError(t, err)
+
+	// The selected coin is now reserved for the wallet, so it must not be
+	// selected for a burn, even though the coin lister still lists it.
+	_, err = coinSelect.SelectCoins(
+		ctxb, CommitmentConstraints{
+			MinAmt:   1,
+			Reserver: ReserverBurn,
+		}, PreferMaxAmount, commitment.TapCommitmentV1,
+	)
+	require.ErrorIs(t, err, ErrMatchingAssetsNotFound)
+	_, err = fn.RecvOrTimeout(coinLister.deleteSignals, timeout)
+	require.NoError(t, err)
+	_, err = fn.RecvOrTimeout(coinLister.listSignals, timeout)
+	require.NoError(t, err)
+
+	// Once the coin is released, it can be selected for the burn.
+	err = coinSelect.ReleaseCoins(ctxb, selected[0].AnchorPoint)
+	require.NoError(t, err)
+	_, err = fn.RecvOrTimeout(coinLister.releaseSignals, timeout)
+	require.NoError(t, err)
+	require.Empty(t, coinSelect.Reservations())
+
+	selected, err = coinSelect.SelectCoins(
+		ctxb, CommitmentConstraints{
+			MinAmt:   1,
+			Reserver: ReserverBurn,
+		}, PreferMaxAmount, commitment.TapCommitmentV1,
+	)
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+
+	reservations := coinSelect.Reservations()
+	require.Len(t, reservations, 1)
+	require.Equal(t, ReserverBurn, reservations[0].Reserver)
 }
 
 // TestCoinSelection tests that the coin selection logic behaves as expected.
@@ -222,6 +262,43 @@ func TestCoinSelection(t *testing.T) {
 				},
 			},
 		},
+
+		// Test that when the PreferMinAmount strategy is employed
+		// the smallest commitments are selected first.
+		{
+			name:           "prefer min amount",
+			minTotalAmount: 1000,
+			eligibleCommitments: []*AnchoredCommitment{
+				{
+					Asset: &asset.Asset{
+						Amount: 510,
+					},
+				},
+				{
+					Asset: &asset.Asset{
+						Amount: 2000,
+					},
+				},
+				{
+					Asset: &asset.Asset{
+						Amount: 490,
+					},
+				},
+			},
+			strategy: PreferMinAmount,
+			expectedCommitments: []*AnchoredCommitment{
+				{
+					Asset: &asset.Asset{
+						Amount: 490,
+					},
+				},
+				{
+					Asset: &asset.Asset{
+						Amount: 510,
+					},
+				},
+			},
+		},
 		{
 			name:           "not enough assets",
 			minTotalAmount: 1000,
@@ -242,7 +319,11 @@ func TestCoinSelection(t *testing.T) {
 
 		t.Run(tc.name, func(t *testing.T) {
 			coinLister := newMockCoinLister(tc.eligibleCommitments)
-			coinSelect := NewCoinSelect(coinLister)
+			coinSelect := NewCoinSelect(
+				coinLister, NewInputReservations(
+					clock.NewDefaultClock(),
+				),
+			)
 
 			resultCommitments, err := coinSelect.selectForAmount(
 				tc.minTotalAmount, tc.eligibleCommitments,
@@ -264,3 +345,156 @@ func TestCoinSelection(t *testing.T) {
 		})
 	}
 }
+
+// TestSelectForAmountDeterministic tests that coins of the same amount are
+// selected in the same order, independent of the order they are listed in.
+func TestSelectForAmountDeterministic(t *testing.T) {
+	t.Parallel()
+
+	genesis := asset.RandGenesis(t, asset.Normal)
+	newCoin := func() *AnchoredCommitment {
+		coin := &AnchoredCommitment{
+			AnchorPoint: test.RandOp(t),
+			Asset: asset.RandAssetWithValues(
+				t, genesis, nil, asset.RandScriptKey(t),
+			),
+		}
+		coin.Asset.Amount = 100
+
+		return coin
+	}
+
+	coins := []*AnchoredCommitment{
+		newCoin(), newCoin(), newCoin(), newCoin(),
+	}
+
+	// Two coins sharing the same anchor outpoint are told apart by their
+	// script key.
+	coins[3].AnchorPoint = coins[2].AnchorPoint
+
+	coinSelect := NewCoinSelect(
+		newMockCoinLister(nil),
+		NewInputReservations(clock.NewDefaultClock()),
+	)
+	expected, err := coinSelect.selectForAmount(
+		250, slices.Clone(coins), PreferMaxAmount,
+	)
+	require.NoError(t, err)
+	require.Len(t, expected, 3)
+
+	for i := 0; i < 10; i++ {
+		shuffled := slices.Clone(coins)
+		rand.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+
+		selected, err := coinSelect.selectForAmount(
+			250, shuffled, PreferMaxAmount,
+		)
+		require.NoError(t, err)
+		require.Equal(t, expected, selected)
+	}
+}
+
+// TestSelectExactAmount tests that the exact amount strategy only selects
+// coins that sum to exactly the target amount and otherwise suggests the
+// nearest achievable amounts.
+func TestSelectExactAmount(t *testing.T) {
+	t.Parallel()
+
+	newCoins := func(amounts ...uint64) []*AnchoredCommitment {
+		return fn.Map(amounts, func(amt uint64) *AnchoredCommitment {
+			return &AnchoredCommitment{
+				Asset: &asset.Asset{
+					Amount: amt,
+				},
+			}
+		})
+	}
+	sumCoins := func(coins []*AnchoredCommitment) uint64 {
+		var sum uint64
+		for _, coin := range coins {
+			sum += coin.Asset.Amount
+		}
+
+		return sum
+	}
+
+	testCases := []struct {
+		name         string
+		amount       uint64
+		coins        []*AnchoredCommitment
+		numSelected  int
+		nearestBelow fn.Option[uint64]
+		nearestAbove fn.Option[uint64]
+		expectedErr  error
+	}{{
+		name:        "single coin",
+		amount:      500,
+		coins:       newCoins(1000, 500, 200),
+		numSelected: 1,
+	}, {
+		name:        "multiple coins",
+		amount:      700,
+		coins:       newCoins(1000, 500, 300, 200),
+		numSelected: 2,
+	}, {
+		name:        "all coins",
+		amount:      1700,
+		coins:       newCoins(1000, 500, 200),
+		numSelected: 3,
+	}, {
+		name:         "no exact match",
+		amount:       650,
+		coins:        newCoins(1000, 500, 200),
+		nearestBelow: fn.Some[uint64](500),
+		nearestAbove: fn.Some[uint64](700),
+	}, {
+		name:         "only larger amounts",
+		amount:       100,
+		coins:        newCoins(300, 200),
+		nearestAbove: fn.Some[uint64](200),
+	}, {
+		name:        "not enough assets",
+		amount:      2000,
+		coins:       newCoins(1000, 500),
+		expectedErr: ErrMatchingAssetsNotFound,
+	}}
+
+	coinSelect := NewCoinSelect(
+		newMockCoinLister(nil),
+		NewInputReservations(clock.NewDefaultClock()),
+	)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := coinSelect.selectForAmount(
+				tc.amount, tc.coins, ExactAmount,
+			)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			if tc.numSelected == 0 {
+				var exactErr *ExactAmountError
+				require.ErrorAs(t, err, &exactErr)
+				require.Equal(t, tc.amount, exactErr.Amount)
+				require.Equal(
+					t, tc.nearestBelow,
+					exactErr.NearestBelow,
+				)
+				require.Equal(
+					t, tc.nearestAbove,
+					exactErr.NearestAbove,
+				)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, selected, tc.numSelected)
+			require.Equal(t, tc.amount, sumCoins(selected))
+		})
+	}
+}
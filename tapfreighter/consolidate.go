@@ -0,0 +1,378 @@
+package tapfreighter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/account"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// DefaultConsolidationMaxInputs is the default maximum number of coins
+	// that are merged by a single consolidation.
+	DefaultConsolidationMaxInputs = 100
+
+	// DefaultConsolidationMaxProofSize is the default maximum size of the
+	// proof file of a consolidated output in bytes.
+	DefaultConsolidationMaxProofSize = 10 * 1024 * 1024
+)
+
+var (
+	// ErrNothingToConsolidate is returned if there are fewer than two
+	// coins that can be merged within the limits of a consolidation.
+	ErrNothingToConsolidate = errors.New("fewer than two coins can be " +
+		"consolidated within the given limits")
+
+	// ErrConsolidationChanged is returned if the coins available for a
+	// consolidation changed between planning and funding it.
+	ErrConsolidationChanged = errors.New("coins available for " +
+		"consolidation changed, please try again")
+)
+
+// ConsolidationParams are the parameters of an asset coin consolidation.
+type ConsolidationParams struct {
+	// AssetSpecifier specifies the asset whose coins are merged. If it
+	// contains a group key, the coins of all asset IDs of the group are
+	// merged into one output per asset ID.
+	AssetSpecifier asset.Specifier
+
+	// Account is the account the merged coins belong to.
+	Account fn.Option[account.Account]
+
+	// MaxInputs is the maximum number of coins that are merged.
+	MaxInputs int
+
+	// MaxProofSize is the maximum size of the proof file of a merged
+	// output in bytes. Since the proof of a merged output contains the
+	// full proof files of all its inputs, this limits the proof growth.
+	MaxProofSize int64
+}
+
+// ConsolidationPlan describes the coins a consolidation merges.
+type ConsolidationPlan struct {
+	// Params are the parameters the plan was created with.
+	Params ConsolidationParams
+
+	// Coins are the coins that are merged, ordered by ascending amount.
+	Coins []*AnchoredCommitment
+
+	// Amount is the total amount of all merged coins.
+	Amount uint64
+
+	// ProofSize is the estimated size of the largest proof file of the
+	// merged outputs in bytes.
+	ProofSize int64
+
+	// AnchorPoints are the distinct anchor outputs that are spent.
+	AnchorPoints []wire.OutPoint
+
+	// NumRemaining is the number of eligible coins that aren't merged
+	// because of the limits of the consolidation.
+	NumRemaining int
+}
+
+// EstimateWeight returns the estimated weight of the anchor transaction of
+// the consolidation. It assumes that one additional wallet input is needed
+// to pay the fee and that the transaction has a change output.
+func (p *ConsolidationPlan) EstimateWeight() lntypes.WeightUnit {
+	var estimator input.TxWeightEstimator
+	for range p.AnchorPoints {
+		estimator.AddTaprootKeySpendInput(txscript.SigHashDefault)
+	}
+	estimator.AddTaprootKeySpendInput(txscript.SigHashDefault)
+
+	// The merged assets are anchored in a single output, the other
+	// output is the BTC change.
+	estimator.AddP2TROutput()
+	estimator.AddP2TROutput()
+
+	return estimator.Weight()
+}
+
+// EstimateFee returns the estimated fee of the anchor transaction of the
+// consolidation at the given fee rate.
+func (p *ConsolidationPlan) EstimateFee(
+	feeRate chainfee.SatPerKWeight) btcutil.Amount {
+
+	return feeRate.FeeForWeight(p.EstimateWeight())
+}
+
+// PlanConsolidation determines which coins of an asset are merged by a
+// consolidation with the given parameters. The smallest coins are merged
+// first, until either the maximum number of inputs or the maximum proof size
+// is reached. Nothing is leased, so the plan can be used as a preview.
+//
+// NOTE: This is part of the Wallet interface.
+func (f *AssetWallet) PlanConsolidation(ctx context.Context,
+	params ConsolidationParams) (*ConsolidationPlan, error) {
+
+	if params.MaxInputs < 2 {
+		return nil, fmt.Errorf("at least two inputs must be allowed")
+	}
+
+	constraints := CommitmentConstraints{
+		AssetSpecifier: params.AssetSpecifier,
+		CoinSelectType: tapsend.Bip86Only,
+		Account:        params.Account,
+		Reserver:       ReserverWallet,
+	}
+	coins, err := f.cfg.CoinSelector.ListCoins(
+		ctx, constraints, commitment.TapCommitmentV2,
+	)
+	switch {
+	case errors.Is(err, ErrMatchingAssetsNotFound):
+		return nil, ErrNothingToConsolidate
+
+	case err != nil:
+		return nil, err
+	}
+
+	// Collectibles can't be merged, as each of them is unique.
+	coins = fn.Filter(coins, func(c *AnchoredCommitment) bool {
+		return c.Asset.Type == asset.Normal
+	})
+
+	// The coins are merged in the same order the coin selection picks
+	// them when the consolidation is funded.
+	slices.SortStableFunc(coins, compareCoinsAsc)
+
+	plan := &ConsolidationPlan{
+		Params: params,
+	}
+	proofSizes := make(map[asset.ID]int64)
+	anchorPoints := make(map[wire.OutPoint]struct{})
+	for idx, coin := range coins {
+		if len(plan.Coins) >= params.MaxInputs {
+			plan.NumRemaining = len(coins) - idx
+			break
+		}
+
+		proofSize, err := f.coinProofSize(ctx, coin)
+		if err != nil {
+			return nil, err
+		}
+
+		// The proof of a merged output contains the proofs of all
+		// inputs of the same asset ID. We stop at the first coin that
+		// would exceed the limit, as the coin selection can only pick
+		// the smallest coins.
+		assetID := coin.Asset.ID()
+		if proofSizes[assetID]+proofSize > params.MaxProofSize {
+			plan.NumRemaining = len(coins) - idx
+			break
+		}
+		proofSizes[assetID] += proofSize
+		plan.ProofSize = max(plan.ProofSize, proofSizes[assetID])
+
+		plan.Coins = append(plan.Coins, coin)
+		plan.Amount += coin.Asset.Amount
+
+		if _, ok := anchorPoints[coin.AnchorPoint]; !ok {
+			anchorPoints[coin.AnchorPoint] = struct{}{}
+			plan.AnchorPoints = append(
+				plan.AnchorPoints, coin.AnchorPoint,
+			)
+		}
+	}
+
+	if len(plan.Coins) < 2 {
+		return nil, ErrNothingToConsolidate
+	}
+
+	return plan, nil
+}
+
+// coinProofSize returns the size of the proof file of the given coin.
+func (f *AssetWallet) coinProofSize(ctx context.Context,
+	coin *AnchoredCommitment) (int64, error) {
+
+	assetID := coin.Asset.ID()
+	proofBlob, err := f.cfg.AssetProofs.FetchProof(ctx, proof.Locator{
+		AssetID:   &assetID,
+		ScriptKey: *coin.Asset.ScriptKey.PubKey,
+		OutPoint:  &coin.AnchorPoint,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to fetch proof of coin %v: %w",
+			coin.AnchorPoint, err)
+	}
+
+	return int64(len(proofBlob)), nil
+}
+
+// FundConsolidation leases the coins of the given plan and funds one virtual
+// transaction per asset ID that merges all coins of that asset ID into a
+// single output. All outputs are anchored in the same anchor output, so all
+// packets are shipped in one anchor transaction.
+//
+// NOTE: This is part of the Wallet interface.
+func (f *AssetWallet) FundConsolidation(ctx context.Context,
+	plan *ConsolidationPlan) ([]*FundedVPacket, error) {
+
+	constraints := CommitmentConstraints{
+		AssetSpecifier: plan.Params.AssetSpecifier,
+		MinAmt:         plan.Amount,
+		CoinSelectType: tapsend.Bip86Only,
+		Account:        plan.Params.Account,
+		Reserver:       ReserverWallet,
+	}
+	selectedCommitments, err := f.cfg.CoinSelector.SelectCoins(
+		ctx, constraints, PreferMinAmount, commitment.TapCommitmentV2,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we return with an error, we want to release the coins we've
+	// selected.
+	success := false
+	defer func() {
+		if !success {
+			outpoints := fn.Map(
+				selectedCommitments,
+				func(c *AnchoredCommitment) wire.OutPoint {
+					return c.AnchorPoint
+				},
+			)
+			err := f.cfg.CoinSelector.ReleaseCoins(
+				ctx, outpoints...,
+			)
+			if err != nil {
+				log.Errorf("Unable to release coins: %v", err)
+			}
+		}
+	}()
+
+	// The coin selection picks the smallest coins, just like the plan.
+	// If it came to a different result, a coin was spent or received in
+	// the meantime, and the limits of the plan might not hold anymore.
+	sameCoin := func(a, b *AnchoredCommitment) bool {
+		return compareCoins(a, b) == 0
+	}
+	if !slices.EqualFunc(selectedCommitments, plan.Coins, sameCoin) {
+		return nil, ErrConsolidationChanged
+	}
+
+	fundDesc := &tapsend.FundingDescriptor{
+		AssetSpecifier: plan.Params.AssetSpecifier,
+		CoinSelectType: tapsend.Bip86Only,
+		Account:        plan.Params.Account,
+	}
+
+	// All merged outputs go to the same anchor output, so they share its
+	// internal key.
+	internalKey, err := f.cfg.KeyRing.DeriveNextKey(
+		ctx, fundDesc.KeyFamily(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var assetIDs []asset.ID
+	idCoins := make(map[asset.ID][]*AnchoredCommitment)
+	for _, coin := range selectedCommitments {
+		assetID := coin.Asset.ID()
+		if _, ok := idCoins[assetID]; !ok {
+			assetIDs = append(assetIDs, assetID)
+		}
+		idCoins[assetID] = append(idCoins[assetID], coin)
+	}
+
+	groupKey := plan.Params.AssetSpecifier.UnwrapGroupKeyToPtr()
+	fundedPkts := make([]*FundedVPacket, 0, len(assetIDs))
+	for _, assetID := range assetIDs {
+		fundedPkt, err := f.fundConsolidationPacket(
+			ctx, fundDesc, assetID, groupKey, idCoins[assetID],
+			internalKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fund consolidation "+
+				"of asset ID %v: %w", assetID, err)
+		}
+
+		fundedPkts = append(fundedPkts, fundedPkt)
+	}
+
+	log.Infof("Funded consolidation of %d coins with total amount %d in "+
+		"%d anchor outputs into %d outputs", len(selectedCommitments),
+		plan.Amount, len(plan.AnchorPoints), len(fundedPkts))
+
+	// Don't release the coins we've selected, as so far we've been
+	// successful.
+	success = true
+	return fundedPkts, nil
+}
+
+// fundConsolidationPacket funds a virtual transaction that merges the given
+// coins of a single asset ID into one output to a new script key.
+func (f *AssetWallet) fundConsolidationPacket(ctx context.Context,
+	fundDesc *tapsend.FundingDescriptor, assetID asset.ID,
+	groupKey *btcec.PublicKey, coins []*AnchoredCommitment,
+	internalKey keychain.KeyDescriptor) (*FundedVPacket, error) {
+
+	var (
+		amount     uint64
+		maxVersion asset.Version
+	)
+	for _, coin := range coins {
+		amount += coin.Asset.Amount
+		maxVersion = max(maxVersion, coin.Asset.Version)
+	}
+
+	scriptKeyDesc, err := f.cfg.KeyRing.DeriveNextKey(
+		ctx, fundDesc.KeyFamily(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	scriptKey := asset.NewScriptKeyBip86(scriptKeyDesc)
+
+	vPkt := &tappsbt.VPacket{
+		Inputs: []*tappsbt.VInput{{
+			PrevID: asset.PrevID{
+				ID: assetID,
+			},
+		}},
+		Outputs: []*tappsbt.VOutput{{
+			Amount:            amount,
+			Type:              tappsbt.TypeSimple,
+			Interactive:       true,
+			AnchorOutputIndex: 0,
+			AssetVersion:      maxVersion,
+			ScriptKey:         scriptKey,
+		}},
+		ChainParams: f.cfg.ChainParams,
+		Version:     tappsbt.V1,
+	}
+	vPkt.Outputs[0].SetAnchorInternalKey(
+		internalKey, f.cfg.ChainParams.HDCoinType,
+	)
+
+	idFundDesc := &tapsend.FundingDescriptor{
+		AssetSpecifier: asset.NewSpecifierOptionalGroupPubKey(
+			assetID, groupKey,
+		),
+		Amount:         amount,
+		CoinSelectType: fundDesc.CoinSelectType,
+		Account:        fundDesc.Account,
+		ExactAmount:    true,
+	}
+
+	return f.fundPacketWithInputs(ctx, idFundDesc, vPkt, coins)
+}
@@ -0,0 +1,163 @@
+package tapfreighter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanConsolidation tests that a consolidation plan merges the smallest
+// coins first and respects the input and proof size limits.
+func TestPlanConsolidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	genesis := asset.RandGenesis(t, asset.Normal)
+	specifier := asset.NewSpecifierFromId(genesis.ID())
+	proofArchive := proof.NewMockProofArchive()
+
+	// The mock proof archive only supports small output indexes.
+	randOp := func() wire.OutPoint {
+		return wire.OutPoint{
+			Hash: test.RandHash(),
+		}
+	}
+	sharedAnchor := randOp()
+
+	// Each coin has a proof file of 100 bytes.
+	const proofSize = 100
+	coin := func(gen asset.Genesis, amt uint64,
+		anchor wire.OutPoint) *AnchoredCommitment {
+
+		c := &AnchoredCommitment{
+			AnchorPoint: anchor,
+			Commitment: &commitment.TapCommitment{
+				Version: commitment.TapCommitmentV2,
+			},
+			Asset: &asset.Asset{
+				Genesis:   gen,
+				Amount:    amt,
+				ScriptKey: asset.RandScriptKey(t),
+			},
+		}
+
+		assetID := gen.ID()
+		err := proofArchive.ImportProofs(
+			ctx, nil, nil, nil, nil, false, &proof.AnnotatedProof{
+				Locator: proof.Locator{
+					AssetID:   &assetID,
+					ScriptKey: *c.Asset.ScriptKey.PubKey,
+					OutPoint:  &anchor,
+				},
+				Blob: bytes.Repeat([]byte{0x01}, proofSize),
+			},
+		)
+		require.NoError(t, err)
+
+		return c
+	}
+
+	// The two smallest coins share an anchor output. The collectible is
+	// never merged.
+	coins := []*AnchoredCommitment{
+		coin(genesis, 50, randOp()),
+		coin(genesis, 20, sharedAnchor),
+		coin(asset.RandGenesis(t, asset.Collectible), 1, randOp()),
+		coin(genesis, 30, randOp()),
+		coin(genesis, 10, sharedAnchor),
+	}
+
+	testCases := []struct {
+		name                 string
+		maxInputs            int
+		maxProofSize         int64
+		expectedAmounts      []uint64
+		expectedProofSize    int64
+		expectedAnchorPoints int
+		expectedRemaining    int
+		expectedErr          error
+	}{{
+		name:                 "all coins",
+		maxInputs:            DefaultConsolidationMaxInputs,
+		maxProofSize:         DefaultConsolidationMaxProofSize,
+		expectedAmounts:      []uint64{10, 20, 30, 50},
+		expectedProofSize:    4 * proofSize,
+		expectedAnchorPoints: 3,
+	}, {
+		name:                 "input limit",
+		maxInputs:            3,
+		maxProofSize:         DefaultConsolidationMaxProofSize,
+		expectedAmounts:      []uint64{10, 20, 30},
+		expectedProofSize:    3 * proofSize,
+		expectedAnchorPoints: 2,
+		expectedRemaining:    1,
+	}, {
+		name:                 "proof size limit",
+		maxInputs:            DefaultConsolidationMaxInputs,
+		maxProofSize:         2*proofSize + proofSize/2,
+		expectedAmounts:      []uint64{10, 20},
+		expectedProofSize:    2 * proofSize,
+		expectedAnchorPoints: 1,
+		expectedRemaining:    2,
+	}, {
+		name:         "proof size limit too low",
+		maxInputs:    DefaultConsolidationMaxInputs,
+		maxProofSize: proofSize,
+		expectedErr:  ErrNothingToConsolidate,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wallet := NewAssetWallet(&WalletConfig{
+				CoinSelector: NewCoinSelect(
+					newMockCoinLister(coins),
+					NewInputReservations(
+						clock.NewDefaultClock(),
+					),
+				),
+				AssetProofs: proofArchive,
+			})
+
+			plan, err := wallet.PlanConsolidation(
+				ctx, ConsolidationParams{
+					AssetSpecifier: specifier,
+					MaxInputs:      tc.maxInputs,
+					MaxProofSize:   tc.maxProofSize,
+				},
+			)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			amounts := make([]uint64, len(plan.Coins))
+			for idx, c := range plan.Coins {
+				amounts[idx] = c.Asset.Amount
+			}
+
+			var total uint64
+			for _, amt := range tc.expectedAmounts {
+				total += amt
+			}
+
+			require.Equal(t, tc.expectedAmounts, amounts)
+			require.Equal(t, total, plan.Amount)
+			require.Equal(t, tc.expectedProofSize, plan.ProofSize)
+			require.Len(
+				t, plan.AnchorPoints, tc.expectedAnchorPoints,
+			)
+			require.Equal(
+				t, tc.expectedRemaining, plan.NumRemaining,
+			)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package tapfreighter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// DefaultAnchorOutputValue is the default value in satoshis of the
+	// BTC outputs that anchor assets.
+	DefaultAnchorOutputValue = uint64(tapsend.DummyAmtSats)
+
+	// DefaultFeeReserveCount is the default number of fee reserve outputs
+	// that are kept in the wallet, if the fee reserve is enabled.
+	DefaultFeeReserveCount = 4
+)
+
+var (
+	// minAnchorOutputValue is the minimum value of an anchor output, which
+	// is the dust limit of a P2TR output.
+	minAnchorOutputValue = lnwallet.DustLimitForSize(input.P2TRSize)
+
+	// minFeeReserveValue is the minimum value of a fee reserve output,
+	// which is the dust limit of a P2WKH output.
+	minFeeReserveValue = lnwallet.DustLimitForSize(input.P2WPKHSize)
+)
+
+// AnchorConfig configures the BTC value of the outputs that anchor assets and
+// how the BTC change of the anchor transactions is managed.
+type AnchorConfig struct {
+	// OutputValue is the value in satoshis of the BTC outputs that anchor
+	// assets.
+	OutputValue uint64 `long:"output-value" description:"The value in satoshis of the BTC outputs that anchor assets. A higher value makes sure the anchor outputs can still pay for their own fee when they are spent at high fee rates."`
+
+	// FeeReserveValue is the value in satoshis of a single fee reserve
+	// output. A value of zero disables the fee reserve.
+	FeeReserveValue uint64 `long:"fee-reserve-value" description:"The value in satoshis of a single fee reserve output. If set, the BTC change of asset transfers is split to keep fee-reserve-count outputs of this value in the default account of the lnd wallet, from which the fees of later transfers can be paid. A value of zero disables the fee reserve."`
+
+	// FeeReserveCount is the number of fee reserve outputs that are kept
+	// in the wallet.
+	FeeReserveCount int `long:"fee-reserve-count" description:"The number of fee reserve outputs to keep in the lnd wallet."`
+}
+
+// DefaultAnchorConfig returns the default anchor output configuration, which
+// has the fee reserve disabled.
+func DefaultAnchorConfig() AnchorConfig {
+	return AnchorConfig{
+		OutputValue:     DefaultAnchorOutputValue,
+		FeeReserveCount: DefaultFeeReserveCount,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *AnchorConfig) Validate() error {
+	switch {
+	case btcutil.Amount(c.OutputValue) < minAnchorOutputValue:
+		return fmt.Errorf("anchor output value must be at least %d "+
+			"sats", int64(minAnchorOutputValue))
+
+	case c.FeeReserveValue == 0:
+		return nil
+
+	case btcutil.Amount(c.FeeReserveValue) < minFeeReserveValue:
+		return fmt.Errorf("fee reserve value must be at least %d "+
+			"sats", int64(minFeeReserveValue))
+
+	case c.FeeReserveCount <= 0:
+		return fmt.Errorf("fee reserve count must be positive")
+	}
+
+	return nil
+}
+
+// anchorOutputValue returns the value of the outputs that anchor assets. If no
+// value is configured, the default dummy amount is used.
+func (c *AnchorConfig) anchorOutputValue() btcutil.Amount {
+	if c.OutputValue == 0 {
+		return tapsend.DummyAmtSats
+	}
+
+	return btcutil.Amount(c.OutputValue)
+}
+
+// feeReserveOutputCost returns the amount a single fee reserve output takes
+// from the change at the given fee rate. Each reserve output increases the
+// size of the anchor transaction, so it also pays for its own fee.
+func feeReserveOutputCost(reserveValue btcutil.Amount,
+	feeRate chainfee.SatPerKWeight) btcutil.Amount {
+
+	outputWeight := lntypes.WeightUnit(
+		input.P2WPKHSize * blockchain.WitnessScaleFactor,
+	)
+
+	return reserveValue + feeRate.FeeForWeight(outputWeight)
+}
+
+// numFeeReserveOutputs returns how many fee reserve outputs can be split off
+// the given change value at the given fee rate, if the given number of
+// outputs is missing from the reserve. The change never drops below the value
+// of a reserve output, so it doesn't become dust.
+func numFeeReserveOutputs(change, reserveValue btcutil.Amount, missing int,
+	feeRate chainfee.SatPerKWeight) int {
+
+	outputCost := feeReserveOutputCost(reserveValue, feeRate)
+
+	var numOutputs int
+	for numOutputs < missing && change-outputCost >= reserveValue {
+		change -= outputCost
+		numOutputs++
+	}
+
+	return numOutputs
+}
+
+// splitFeeReserve tops up the fee reserve of the wallet by splitting outputs
+// off the change output of the given funded anchor transaction, if the fee
+// reserve is enabled and not full. The split off outputs pay to new addresses
+// of the default account of the wallet, so they can be used to pay for the
+// fees of later transfers.
+func (f *AssetWallet) splitFeeReserve(ctx context.Context,
+	anchorPkt *tapsend.FundedPsbt, feeRate chainfee.SatPerKWeight) error {
+
+	cfg := f.cfg.Anchor
+	if cfg.FeeReserveValue == 0 || anchorPkt.ChangeOutputIndex < 0 {
+		return nil
+	}
+
+	// The reserve outputs are recognized by their value. Outputs locked
+	// by this or any other pending transaction aren't listed, so we might
+	// create more reserve outputs than needed, which is harmless.
+	reserveValue := btcutil.Amount(cfg.FeeReserveValue)
+	utxos, err := f.cfg.Wallet.ListUnspent(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list wallet UTXOs: %w", err)
+	}
+
+	var numReserves int
+	for _, utxo := range utxos {
+		if utxo.Value == reserveValue {
+			numReserves++
+		}
+	}
+	if numReserves >= cfg.FeeReserveCount {
+		return nil
+	}
+
+	tx := anchorPkt.Pkt.UnsignedTx
+	changeOut := tx.TxOut[anchorPkt.ChangeOutputIndex]
+	numOutputs := numFeeReserveOutputs(
+		btcutil.Amount(changeOut.Value), reserveValue,
+		cfg.FeeReserveCount-numReserves, feeRate,
+	)
+	if numOutputs == 0 {
+		log.Debugf("Change of %d sats too small to top up fee reserve "+
+			"(%d of %d outputs)", changeOut.Value, numReserves,
+			cfg.FeeReserveCount)
+
+		return nil
+	}
+
+	for i := 0; i < numOutputs; i++ {
+		addr, err := f.cfg.Wallet.NextChangeAddr(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to derive fee reserve "+
+				"address: %w", err)
+		}
+
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return fmt.Errorf("unable to create fee reserve "+
+				"script: %w", err)
+		}
+
+		tx.AddTxOut(&wire.TxOut{
+			Value:    int64(reserveValue),
+			PkScript: pkScript,
+		})
+		anchorPkt.Pkt.Outputs = append(
+			anchorPkt.Pkt.Outputs, psbt.POutput{},
+		)
+	}
+
+	// The additional outputs and their fee are paid by the change.
+	outputCost := feeReserveOutputCost(reserveValue, feeRate)
+	changeOut.Value -= int64(outputCost) * int64(numOutputs)
+
+	log.Infof("Split %d fee reserve outputs of %d sats off the change, "+
+		"fee reserve now has %d of %d outputs", numOutputs,
+		reserveValue, numReserves+numOutputs, cfg.FeeReserveCount)
+
+	return nil
+}
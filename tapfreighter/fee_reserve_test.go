@@ -0,0 +1,79 @@
+package tapfreighter
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNumFeeReserveOutputs tests that the number of fee reserve outputs split
+// off the change is limited by the missing outputs and the change value.
+func TestNumFeeReserveOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		change   btcutil.Amount
+		missing  int
+		feeRate  chainfee.SatPerKWeight
+		expected int
+	}{{
+		name:     "limited by missing outputs",
+		change:   100_000,
+		missing:  2,
+		expected: 2,
+	}, {
+		name:     "change stays above reserve value",
+		change:   10_000,
+		missing:  10,
+		expected: 4,
+	}, {
+		name:     "output fee is paid by change",
+		change:   10_000,
+		missing:  10,
+		feeRate:  1_000,
+		expected: 3,
+	}, {
+		name:     "change too small",
+		change:   3_999,
+		missing:  1,
+		expected: 0,
+	}, {
+		name:     "reserve full",
+		change:   100_000,
+		missing:  0,
+		expected: 0,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			numOutputs := numFeeReserveOutputs(
+				tc.change, 2_000, tc.missing, tc.feeRate,
+			)
+			require.Equal(t, tc.expected, numOutputs)
+		})
+	}
+}
+
+// TestAnchorConfigValidate tests the validation of the anchor output config.
+func TestAnchorConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultAnchorConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.OutputValue = 329
+	require.ErrorContains(t, cfg.Validate(), "anchor output value")
+
+	cfg = DefaultAnchorConfig()
+	cfg.FeeReserveValue = 100
+	require.ErrorContains(t, cfg.Validate(), "fee reserve value")
+
+	cfg.FeeReserveValue = 10_000
+	require.NoError(t, cfg.Validate())
+
+	cfg.FeeReserveCount = 0
+	require.ErrorContains(t, cfg.Validate(), "fee reserve count")
+}
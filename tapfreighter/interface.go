@@ -3,12 +3,15 @@ package tapfreighter
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -19,6 +22,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
 )
 
 // CommitmentConstraints conveys the constraints on the type of Taproot asset
@@ -37,6 +41,18 @@ type CommitmentConstraints struct {
 
 	// CoinSelectType is the type of coins that should be selected.
 	CoinSelectType tapsend.CoinSelectType
+
+	// Account is the account the selected assets must belong to. If this
+	// is None, then assets of all accounts are eligible.
+	Account fn.Option[account.Account]
+
+	// Reserver is the subsystem the selected coins are reserved for. If
+	// this is empty, the coins are reserved for the wallet.
+	Reserver Reserver
+
+	// ScriptKey is the script key the selected assets must be locked to.
+	// If this is nil, assets of all script keys are eligible.
+	ScriptKey *btcec.PublicKey
 }
 
 // AssetBurn holds data related to a burn of an asset.
@@ -101,6 +117,11 @@ var (
 	ErrMatchingAssetsNotFound = fmt.Errorf("failed to find coin(s) that " +
 		"satisfy given constraints; if previous transfers are un-" +
 		"confirmed, wait for them to confirm before trying again")
+
+	// ErrPorterDraining is returned when a new parcel is requested while
+	// the porter is drained before shutting down.
+	ErrPorterDraining = fmt.Errorf("ChainPorter shutting down, no new " +
+		"parcels are accepted")
 )
 
 // CoinLister attracts over the coin selection process needed to be
@@ -138,8 +159,58 @@ const (
 	// descending amounts and selects the first subset which cumulatively
 	// sums to at least the minimum target amount.
 	PreferMaxAmount MultiCommitmentSelectStrategy = iota
+
+	// ExactAmount is a strategy which only selects a subset of commitments
+	// that cumulatively sums to exactly the target amount, so no change
+	// output is required. If no such subset exists, an *ExactAmountError
+	// is returned that suggests the nearest achievable amounts.
+	ExactAmount
+
+	// PreferMinAmount is a strategy which considers commitments in order
+	// of ascending amounts and selects the first subset which
+	// cumulatively sums to at least the minimum target amount. It is used
+	// to consolidate many small coins.
+	PreferMinAmount
 )
 
+// ExactAmountError is returned by the ExactAmount coin selection strategy if
+// no subset of the eligible coins sums to exactly the target amount.
+type ExactAmountError struct {
+	// Amount is the target amount that couldn't be matched exactly.
+	Amount uint64
+
+	// NearestBelow is the largest achievable amount below the target
+	// amount, if any.
+	NearestBelow fn.Option[uint64]
+
+	// NearestAbove is the smallest achievable amount above the target
+	// amount, if any.
+	NearestAbove fn.Option[uint64]
+}
+
+// Error returns the error message of the exact amount error, including the
+// nearest achievable amounts.
+//
+// NOTE: This is part of the error interface.
+func (e *ExactAmountError) Error() string {
+	msg := fmt.Sprintf("no combination of coins sums to exactly %d",
+		e.Amount)
+
+	var suggestions []string
+	e.NearestBelow.WhenSome(func(amt uint64) {
+		suggestions = append(suggestions, fmt.Sprintf("%d", amt))
+	})
+	e.NearestAbove.WhenSome(func(amt uint64) {
+		suggestions = append(suggestions, fmt.Sprintf("%d", amt))
+	})
+	if len(suggestions) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf("%s, nearest achievable amounts: %s", msg,
+		strings.Join(suggestions, ", "))
+}
+
 // CoinSelector is an interface that describes the functionality used in
 // selecting coins during the asset send process.
 type CoinSelector interface {
@@ -151,6 +222,13 @@ type CoinSelector interface {
 		maxVersion commitment.TapCommitmentVersion,
 	) ([]*AnchoredCommitment, error)
 
+	// ListCoins returns all not yet leased coins that satisfy the given
+	// constraints, without leasing them. The minimum amount of the
+	// constraints is ignored.
+	ListCoins(ctx context.Context, constraints CommitmentConstraints,
+		maxVersion commitment.TapCommitmentVersion,
+	) ([]*AnchoredCommitment, error)
+
 	// ReleaseCoins releases/unlocks coins that were previously leased and
 	// makes them available for coin selection again.
 	ReleaseCoins(ctx context.Context, utxoOutpoints ...wire.OutPoint) error
@@ -200,6 +278,52 @@ type Anchor struct {
 	NumPassiveAssets uint32
 }
 
+// ProofCourierMode describes how a proof is delivered if multiple proof
+// couriers are specified for a transfer output.
+type ProofCourierMode uint8
+
+const (
+	// ProofCourierModeFailover delivers the proof to the proof couriers in
+	// order of their priority, until the first delivery succeeds.
+	ProofCourierModeFailover ProofCourierMode = 0
+
+	// ProofCourierModeAll delivers the proof to all proof couriers. The
+	// delivery is only complete once every courier received the proof.
+	ProofCourierModeAll ProofCourierMode = 1
+)
+
+// String returns a human-readable string for the proof courier mode.
+func (m ProofCourierMode) String() string {
+	switch m {
+	case ProofCourierModeFailover:
+		return "failover"
+
+	case ProofCourierModeAll:
+		return "all"
+
+	default:
+		return fmt.Sprintf("<unknown_mode(%d)>", m)
+	}
+}
+
+// ProofCourierDelivery tracks the delivery of a transfer output proof to a
+// single proof courier.
+type ProofCourierDelivery struct {
+	// CourierAddr is the bytes encoded address of the proof courier.
+	CourierAddr []byte
+
+	// Delivered indicates whether the proof was delivered to the courier.
+	Delivered bool
+
+	// LastError is the error of the last failed delivery attempt. It is
+	// empty if the last attempt succeeded or no attempt was made yet.
+	LastError string
+
+	// LastAttempt is the time of the last delivery attempt. It is the zero
+	// time if no attempt was made yet.
+	LastAttempt time.Time
+}
+
 // TransferOutput represents the database level output to an asset transfer.
 type TransferOutput struct {
 	// Anchor is the new location of the Taproot Asset commitment referenced
@@ -262,6 +386,20 @@ type TransferOutput struct {
 	// - True: The proof has been delivered to the recipient.
 	ProofDeliveryComplete fn.Option[bool]
 
+	// ProofDeliverySkipped indicates that the proof of this output isn't
+	// delivered by the daemon, because the sender delivers it out of band.
+	ProofDeliverySkipped bool
+
+	// ProofCourierMode is the mode the proof is delivered with if multiple
+	// proof couriers are set in ProofCourierDeliveries.
+	ProofCourierMode ProofCourierMode
+
+	// ProofCourierDeliveries tracks the delivery of the proof to each of
+	// the proof couriers of this output, in order of their priority. The
+	// first entry is the courier of ProofCourierAddr. If this is empty,
+	// the proof is only delivered to the courier of ProofCourierAddr.
+	ProofCourierDeliveries []ProofCourierDelivery
+
 	// Position is the position of the output in the transfer output list.
 	Position uint64
 }
@@ -277,6 +415,11 @@ func (out *TransferOutput) ShouldDeliverProof() (bool, error) {
 		return false, nil
 	}
 
+	// The sender asked to deliver the proof themselves.
+	if out.ProofDeliverySkipped {
+		return false, nil
+	}
+
 	// If the proof courier address is unspecified, we don't need to deliver
 	// a proof.
 	if len(out.ProofCourierAddr) == 0 {
@@ -336,6 +479,11 @@ type OutboundParcel struct {
 	// confirmed.
 	AnchorTxBlockHash fn.Option[chainhash.Hash]
 
+	// AnchorTxBlockHeight is the height of the block that contains the
+	// anchor transaction. This is zero until the anchor transaction is
+	// confirmed.
+	AnchorTxBlockHeight uint32
+
 	// TransferTime holds the timestamp of the outbound spend.
 	TransferTime time.Time
 
@@ -372,6 +520,15 @@ func (o *OutboundParcel) Copy() *OutboundParcel {
 		Outputs:            fn.CopySlice(o.Outputs),
 	}
 
+	for idx := range newParcel.Outputs {
+		out := &newParcel.Outputs[idx]
+		if out.ProofCourierDeliveries != nil {
+			out.ProofCourierDeliveries = fn.CopySlice(
+				out.ProofCourierDeliveries,
+			)
+		}
+	}
+
 	if o.AnchorTx != nil {
 		newParcel.AnchorTx = o.AnchorTx.Copy()
 	}
@@ -438,12 +595,25 @@ type ExportLog interface {
 	// transferred.
 	ConfirmProofDelivery(context.Context, wire.OutPoint, uint64) error
 
+	// LogProofCourierDelivery records the outcome of an attempt to deliver
+	// a transfer output proof to the proof courier with the given
+	// priority. A nil error marks the proof as delivered to that courier.
+	LogProofCourierDelivery(ctx context.Context, anchorPoint wire.OutPoint,
+		outputPosition uint64, priority uint32, deliveryErr error,
+		attemptTime time.Time) error
+
 	// LogAnchorTxConfirm updates the send package state on disk to reflect
 	// the confirmation of the anchor transaction, ensuring the on-chain
 	// reference information is up to date.
 	LogAnchorTxConfirm(context.Context, *AssetConfirmEvent,
 		[]*AssetBurn) error
 
+	// LogAnchorTxReplacement replaces the unconfirmed anchor transaction
+	// with the given txid with a new version of the transaction, for
+	// example one that was fee bumped through RBF.
+	LogAnchorTxReplacement(ctx context.Context, oldTxid chainhash.Hash,
+		newTx *wire.MsgTx) error
+
 	// QueryParcels returns the set of confirmed or unconfirmed parcels.
 	QueryParcels(ctx context.Context, anchorTxHash *chainhash.Hash,
 		pending bool) ([]*OutboundParcel, error)
@@ -459,6 +629,14 @@ type WalletAnchor interface {
 	// SignPsbt signs all the inputs it can in the passed-in PSBT packet,
 	// returning a new one with updated signature/witness data.
 	SignPsbt(ctx context.Context, packet *psbt.Packet) (*psbt.Packet, error)
+
+	// ListUnspent lists all confirmed and unconfirmed UTXOs of the default
+	// account of the wallet that aren't locked.
+	ListUnspent(ctx context.Context) ([]*lnwallet.Utxo, error)
+
+	// NextChangeAddr returns a new change address of the default account
+	// of the wallet.
+	NextChangeAddr(ctx context.Context) (btcutil.Address, error)
 }
 
 // KeyRing aliases into the KeyRing of the tapgarden package.
@@ -489,6 +667,11 @@ type Porter interface {
 	// shutdown.
 	Stop() error
 
+	// Drain stops the porter from accepting new parcels and waits for the
+	// parcels in flight to reach a checkpoint they can be resumed from
+	// after a restart, or until the context is done.
+	Drain(ctx context.Context) error
+
 	// EventPublisher is a subscription interface that allows callers to
 	// subscribe to events that are relevant to the Porter.
 	fn.EventPublisher[fn.Event, bool]
@@ -2,6 +2,7 @@ package tapfreighter
 
 import (
 	"github.com/btcsuite/btclog"
+	"github.com/lightninglabs/taproot-assets/tracing"
 )
 
 // Subsystem defines the logging code for this subsystem.
@@ -24,3 +25,6 @@ func DisableLog() {
 func UseLogger(logger btclog.Logger) {
 	log = logger
 }
+
+// tracer is the tracer of the spans of this subsystem.
+var tracer = tracing.Tracer("tapfreighter")
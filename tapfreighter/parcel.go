@@ -3,10 +3,13 @@ package tapfreighter
 import (
 	"bytes"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
@@ -17,6 +20,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 )
 
@@ -126,6 +130,29 @@ type parcelKit struct {
 	errChan chan error
 }
 
+// ProofDelivery describes how the proofs of the recipient outputs of an
+// address parcel are delivered.
+type ProofDelivery struct {
+	// CourierAddr is the proof courier address that is used instead of the
+	// proof courier addresses of the recipient addresses, if set.
+	CourierAddr *url.URL
+
+	// Skip indicates that the proofs aren't delivered to the recipients
+	// by the daemon at all. The sender is then responsible for delivering
+	// the proofs out of band, for example by exporting them once the
+	// transfer confirmed.
+	Skip bool
+
+	// AdditionalCourierAddrs are further proof courier addresses the
+	// proofs are delivered to, in order of their priority. They come after
+	// the proof courier of the recipient address (or CourierAddr, if set).
+	AdditionalCourierAddrs []*url.URL
+
+	// Mode is the mode the proofs are delivered to the proof couriers
+	// with, if there are additional proof courier addresses.
+	Mode ProofCourierMode
+}
+
 // AddressParcel is the main request to issue an asset transfer. This packages a
 // destination address, and also response context.
 type AddressParcel struct {
@@ -138,6 +165,14 @@ type AddressParcel struct {
 	// transferFeeRate is an optional manually-set feerate specified when
 	// requesting an asset transfer.
 	transferFeeRate *chainfee.SatPerKWeight
+
+	// account is the account the transferred assets should be spent from.
+	// If this is None, assets of all accounts can be spent.
+	account fn.Option[account.Account]
+
+	// proofDelivery describes how the proofs of the recipient outputs are
+	// delivered.
+	proofDelivery ProofDelivery
 }
 
 // A compile-time assertion to ensure AddressParcel implements the parcel
@@ -146,6 +181,7 @@ var _ Parcel = (*AddressParcel)(nil)
 
 // NewAddressParcel creates a new AddressParcel.
 func NewAddressParcel(feeRate *chainfee.SatPerKWeight,
+	acct fn.Option[account.Account], proofDelivery ProofDelivery,
 	destAddrs ...*address.Tap) *AddressParcel {
 
 	return &AddressParcel{
@@ -155,6 +191,8 @@ func NewAddressParcel(feeRate *chainfee.SatPerKWeight,
 		},
 		destAddrs:       destAddrs,
 		transferFeeRate: feeRate,
+		account:         acct,
+		proofDelivery:   proofDelivery,
 	}
 }
 
@@ -193,11 +231,62 @@ func (p *AddressParcel) Validate() error {
 			return fmt.Errorf("mixed address versions")
 		}
 
-		// Validate proof courier addresses.
-		err := proof.ValidateCourierAddress(&tapAddr.ProofCourierAddr)
+		// We refuse to send to an address the receiver no longer
+		// expects assets on.
+		if tapAddr.IsExpired(time.Now()) {
+			return fmt.Errorf("address %v expired at %v: %w",
+				tapAddr, tapAddr.Expiry, address.ErrAddrExpired)
+		}
+
+		// Validate proof courier addresses, including the fallback
+		// ones embedded in the address.
+		for _, courierAddr := range tapAddr.ProofCourierAddrs() {
+			err := proof.ValidateCourierAddress(&courierAddr)
+			if err != nil {
+				return fmt.Errorf("invalid proof courier "+
+					"address: %w", err)
+			}
+		}
+	}
+
+	courierAddr := p.proofDelivery.CourierAddr
+	if courierAddr != nil {
+		if p.proofDelivery.Skip {
+			return fmt.Errorf("proof courier address override " +
+				"can't be combined with skipping proof " +
+				"delivery")
+		}
+
+		err := proof.ValidateCourierAddress(courierAddr)
+		if err != nil {
+			return fmt.Errorf("invalid proof courier address "+
+				"override: %w", err)
+		}
+	}
+
+	switch p.proofDelivery.Mode {
+	case ProofCourierModeFailover, ProofCourierModeAll:
+	default:
+		return fmt.Errorf("unknown proof courier mode: %v",
+			p.proofDelivery.Mode)
+	}
+
+	if len(p.proofDelivery.AdditionalCourierAddrs) > 0 &&
+		p.proofDelivery.Skip {
+
+		return fmt.Errorf("additional proof courier addresses can't " +
+			"be combined with skipping proof delivery")
+	}
+	for _, addr := range p.proofDelivery.AdditionalCourierAddrs {
+		if addr == nil {
+			return fmt.Errorf("additional proof courier address " +
+				"missing")
+		}
+
+		err := proof.ValidateCourierAddress(addr)
 		if err != nil {
-			return fmt.Errorf("invalid proof courier address: %w",
-				err)
+			return fmt.Errorf("invalid additional proof courier "+
+				"address: %w", err)
 		}
 	}
 
@@ -262,18 +351,36 @@ type PreSignedParcel struct {
 	// note is a string that provides any user defined description for this
 	// transfer.
 	note string
+
+	// transferFeeRate is an optional manually-set feerate for the anchor
+	// transaction of the transfer.
+	transferFeeRate *chainfee.SatPerKWeight
 }
 
 // A compile-time assertion to ensure PreSignedParcel implements the parcel
 // interface.
 var _ Parcel = (*PreSignedParcel)(nil)
 
+// PreSignedParcelOption is a functional option that allows a caller to modify
+// a pre-signed parcel.
+type PreSignedParcelOption func(*PreSignedParcel)
+
+// WithPreSignedFeeRate sets a manual fee rate for the anchor transaction of a
+// pre-signed parcel. Without it, the fee rate is estimated.
+func WithPreSignedFeeRate(
+	feeRate chainfee.SatPerKWeight) PreSignedParcelOption {
+
+	return func(p *PreSignedParcel) {
+		p.transferFeeRate = &feeRate
+	}
+}
+
 // NewPreSignedParcel creates a new PreSignedParcel.
 func NewPreSignedParcel(vPackets []*tappsbt.VPacket,
-	inputCommitments tappsbt.InputCommitments,
-	note string) *PreSignedParcel {
+	inputCommitments tappsbt.InputCommitments, note string,
+	opts ...PreSignedParcelOption) *PreSignedParcel {
 
-	return &PreSignedParcel{
+	parcel := &PreSignedParcel{
 		parcelKit: &parcelKit{
 			respChan: make(chan *OutboundParcel, 1),
 			errChan:  make(chan error, 1),
@@ -282,6 +389,11 @@ func NewPreSignedParcel(vPackets []*tappsbt.VPacket,
 		inputCommitments: inputCommitments,
 		note:             note,
 	}
+	for _, opt := range opts {
+		opt(parcel)
+	}
+
+	return parcel
 }
 
 // pkg returns the send package that should be delivered.
@@ -475,6 +587,21 @@ type sendPackage struct {
 	// Note is a user provided description for this transfer. This is
 	// currently only used by asset burn transfers.
 	Note string
+
+	// traceSpan is the span that traces the whole transfer. The proof
+	// delivery continues after the state machine completed, so its span
+	// is explicitly started as a child of this span.
+	traceSpan trace.Span
+}
+
+// anchorTxID returns the ID of the anchor transaction of the transfer, if the
+// transaction was already created.
+func (s *sendPackage) anchorTxID() fn.Option[chainhash.Hash] {
+	if s.OutboundPkg == nil || s.OutboundPkg.AnchorTx == nil {
+		return fn.None[chainhash.Hash]()
+	}
+
+	return fn.Some(s.OutboundPkg.AnchorTx.TxHash())
 }
 
 // ConvertToTransfer prepares the finished send data for storing to the database
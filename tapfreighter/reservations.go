@@ -0,0 +1,205 @@
+package tapfreighter
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+var (
+	// ErrInputReserved is returned when an asset input is requested that
+	// is currently reserved by another subsystem.
+	ErrInputReserved = errors.New("asset input reserved by another " +
+		"subsystem")
+)
+
+// Reserver identifies the subsystem of the daemon that reserved an asset
+// input.
+type Reserver string
+
+const (
+	// ReserverWallet is the reserver of inputs selected for sends to
+	// addresses and for virtual packets that are funded for the user.
+	ReserverWallet Reserver = "wallet"
+
+	// ReserverChannel is the reserver of inputs used for funding and
+	// closing asset channels.
+	ReserverChannel Reserver = "channel"
+
+	// ReserverBurn is the reserver of inputs selected for burning assets.
+	ReserverBurn Reserver = "burn"
+)
+
+// Reservation is the reservation of a single asset input.
+type Reservation struct {
+	// OutPoint is the anchor outpoint of the reserved input.
+	OutPoint wire.OutPoint
+
+	// Reserver is the subsystem that reserved the input.
+	Reserver Reserver
+
+	// Expiry is the time at which the reservation expires if it isn't
+	// released before.
+	Expiry time.Time
+}
+
+// InputReservations keeps track of which subsystem of the daemon reserved
+// which asset inputs, so no two subsystems select the same input at the same
+// time. A reservation of multiple inputs either succeeds for all of them or
+// fails without reserving any, and it never waits for another reservation to
+// be released. Concurrent reservations therefore can't deadlock, regardless of
+// the order in which they request their inputs.
+type InputReservations struct {
+	clock clock.Clock
+
+	mu sync.Mutex
+
+	// reservations are the current reservations, keyed by the outpoint of
+	// the reserved input. Expired reservations are removed lazily.
+	reservations map[wire.OutPoint]*Reservation
+}
+
+// NewInputReservations creates a new, empty set of input reservations.
+func NewInputReservations(clock clock.Clock) *InputReservations {
+	return &InputReservations{
+		clock:        clock,
+		reservations: make(map[wire.OutPoint]*Reservation),
+	}
+}
+
+// pruneExpiredLocked removes all expired reservations.
+//
+// NOTE: The mutex must be held when calling this method.
+func (r *InputReservations) pruneExpiredLocked() {
+	now := r.clock.Now()
+	for op, reservation := range r.reservations {
+		if !now.Before(reservation.Expiry) {
+			log.Debugf("Reservation of input %v by %s expired", op,
+				reservation.Reserver)
+
+			delete(r.reservations, op)
+		}
+	}
+}
+
+// Reserve reserves the given inputs for the given reserver until the expiry.
+// Reserving an input again for the same reserver updates the expiry of its
+// reservation. If any of the inputs is reserved by another reserver, none of
+// them is reserved and ErrInputReserved is returned.
+func (r *InputReservations) Reserve(reserver Reserver, expiry time.Time,
+	outPoints ...wire.OutPoint) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pruneExpiredLocked()
+
+	for _, op := range outPoints {
+		reservation, ok := r.reservations[op]
+		if ok && reservation.Reserver != reserver {
+			return fmt.Errorf("%w: input %v is reserved by %s",
+				ErrInputReserved, op, reservation.Reserver)
+		}
+	}
+
+	for _, op := range outPoints {
+		r.reservations[op] = &Reservation{
+			OutPoint: op,
+			Reserver: reserver,
+			Expiry:   expiry,
+		}
+	}
+
+	log.Debugf("Reserved %d inputs for %s until %v: %v", len(outPoints),
+		reserver, expiry, outPoints)
+
+	return nil
+}
+
+// Release releases the reservations of the given inputs that are held by the
+// given reserver and returns the inputs that are no longer reserved. Inputs
+// that are reserved by another reserver keep their reservation and aren't
+// returned.
+func (r *InputReservations) Release(reserver Reserver,
+	outPoints ...wire.OutPoint) []wire.OutPoint {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pruneExpiredLocked()
+
+	released := make([]wire.OutPoint, 0, len(outPoints))
+	for _, op := range outPoints {
+		reservation, ok := r.reservations[op]
+		if ok && reservation.Reserver != reserver {
+			log.Warnf("Not releasing input %v for %s, it is "+
+				"reserved by %s", op, reserver,
+				reservation.Reserver)
+
+			continue
+		}
+
+		delete(r.reservations, op)
+		released = append(released, op)
+	}
+
+	return released
+}
+
+// ForceRelease releases the reservations of the given inputs, regardless of
+// which reserver holds them.
+func (r *InputReservations) ForceRelease(outPoints ...wire.OutPoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, op := range outPoints {
+		delete(r.reservations, op)
+	}
+}
+
+// ReservedBy returns the reserver that currently holds the reservation of the
+// given input, if any.
+func (r *InputReservations) ReservedBy(op wire.OutPoint) (Reserver, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pruneExpiredLocked()
+
+	reservation, ok := r.reservations[op]
+	if !ok {
+		return "", false
+	}
+
+	return reservation.Reserver, true
+}
+
+// Reservations returns all current reservations, ordered by outpoint.
+func (r *InputReservations) Reservations() []Reservation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pruneExpiredLocked()
+
+	reservations := make([]Reservation, 0, len(r.reservations))
+	for _, reservation := range r.reservations {
+		reservations = append(reservations, *reservation)
+	}
+
+	slices.SortFunc(reservations, func(a, b Reservation) int {
+		aOp, bOp := a.OutPoint, b.OutPoint
+		if c := bytes.Compare(aOp.Hash[:], bOp.Hash[:]); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(aOp.Index, bOp.Index)
+	})
+
+	return reservations
+}
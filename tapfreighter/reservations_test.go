@@ -0,0 +1,67 @@
+package tapfreighter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInputReservations tests that inputs can only be reserved by a single
+// reserver at a time, that reservations are all-or-nothing and that they
+// expire.
+func TestInputReservations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	testClock := clock.NewTestClock(now)
+	reservations := NewInputReservations(testClock)
+
+	op1, op2, op3 := test.RandOp(t), test.RandOp(t), test.RandOp(t)
+	expiry := now.Add(time.Minute)
+
+	// The wallet reserves the first two inputs.
+	err := reservations.Reserve(ReserverWallet, expiry, op1, op2)
+	require.NoError(t, err)
+
+	reserver, ok := reservations.ReservedBy(op1)
+	require.True(t, ok)
+	require.Equal(t, ReserverWallet, reserver)
+
+	// A reservation by the channel funding that overlaps with the wallet's
+	// reservation fails as a whole, so the third input isn't reserved
+	// either.
+	err = reservations.Reserve(ReserverChannel, expiry, op3, op2)
+	require.ErrorIs(t, err, ErrInputReserved)
+
+	_, ok = reservations.ReservedBy(op3)
+	require.False(t, ok)
+
+	// The wallet can extend its own reservation.
+	err = reservations.Reserve(ReserverWallet, now.Add(time.Hour), op2)
+	require.NoError(t, err)
+
+	// Another reserver can't release the wallet's reservations.
+	released := reservations.Release(ReserverBurn, op1)
+	require.Empty(t, released)
+	require.Len(t, reservations.Reservations(), 2)
+
+	released = reservations.Release(ReserverWallet, op1)
+	require.Equal(t, []wire.OutPoint{op1}, released)
+
+	// Once the first reservation expires, only the extended one remains.
+	testClock.SetTime(expiry)
+	all := reservations.Reservations()
+	require.Len(t, all, 1)
+	require.Equal(t, op2, all[0].OutPoint)
+
+	// A forced release ignores the reserver.
+	reservations.ForceRelease(op2)
+	require.Empty(t, reservations.Reservations())
+
+	err = reservations.Reserve(ReserverChannel, expiry, op2, op3)
+	require.NoError(t, err)
+}
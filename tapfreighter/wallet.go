@@ -2,9 +2,11 @@ package tapfreighter
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/btcsuite/btcd/blockchain"
@@ -15,6 +17,7 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
@@ -70,6 +73,7 @@ type Wallet interface {
 	// selected assets.
 	FundAddressSend(ctx context.Context,
 		coinSelectType tapsend.CoinSelectType,
+		acct fn.Option[account.Account],
 		receiverAddrs ...*address.Tap) (*FundedVPacket, error)
 
 	// FundPacket funds a virtual transaction, selecting assets to spend
@@ -78,11 +82,31 @@ type Wallet interface {
 	FundPacket(ctx context.Context, fundDesc *tapsend.FundingDescriptor,
 		vPkt *tappsbt.VPacket) (*FundedVPacket, error)
 
+	// FundGroupPackets funds virtual transactions that pay the amount of
+	// the funding descriptor to the single output of the given template,
+	// selecting assets to spend across all asset IDs of the asset group
+	// specified in the descriptor. Because a virtual transaction can only
+	// spend assets of a single asset ID, one funded virtual transaction is
+	// returned for each asset ID that was selected.
+	FundGroupPackets(ctx context.Context,
+		fundDesc *tapsend.FundingDescriptor,
+		vPktTemplate *tappsbt.VPacket) ([]*FundedVPacket, error)
+
 	// FundBurn funds a virtual transaction for burning the given amount of
 	// units of the given asset.
 	FundBurn(ctx context.Context,
 		fundDesc *tapsend.FundingDescriptor) (*FundedVPacket, error)
 
+	// PlanConsolidation determines which coins of an asset are merged by
+	// a consolidation with the given parameters, without leasing them.
+	PlanConsolidation(ctx context.Context,
+		params ConsolidationParams) (*ConsolidationPlan, error)
+
+	// FundConsolidation leases the coins of the given plan and funds the
+	// virtual transactions that merge them, one per asset ID.
+	FundConsolidation(ctx context.Context,
+		plan *ConsolidationPlan) ([]*FundedVPacket, error)
+
 	// SignVirtualPacket signs the virtual transaction of the given packet
 	// and returns the input indexes that were signed.
 	SignVirtualPacket(vPkt *tappsbt.VPacket,
@@ -205,6 +229,10 @@ type WalletConfig struct {
 
 	// ChainParams is the chain params of the chain we operate on.
 	ChainParams *address.ChainParams
+
+	// Anchor configures the BTC value of the anchor outputs and the fee
+	// reserve.
+	Anchor AnchorConfig
 }
 
 // AssetWallet is an implementation of the Wallet interface that can create
@@ -236,11 +264,12 @@ type FundedVPacket struct {
 // FundAddressSend funds a virtual transaction, selecting assets to spend in
 // order to pay the given address. It also returns supporting data which assists
 // in processing the virtual transaction: passive asset re-anchors and the
-// Taproot Asset level commitment of the selected assets.
+// Taproot Asset level commitment of the selected assets. If an account is
+// given, only assets of that account are spent.
 //
 // NOTE: This is part of the Wallet interface.
 func (f *AssetWallet) FundAddressSend(ctx context.Context,
-	coinSelectType tapsend.CoinSelectType,
+	coinSelectType tapsend.CoinSelectType, acct fn.Option[account.Account],
 	receiverAddrs ...*address.Tap) (*FundedVPacket, error) {
 
 	// We start by creating a new virtual transaction that will be used to
@@ -259,6 +288,7 @@ func (f *AssetWallet) FundAddressSend(ctx context.Context,
 	}
 
 	fundDesc.CoinSelectType = coinSelectType
+	fundDesc.Account = acct
 	fundedVPkt, err := f.FundPacket(ctx, fundDesc, vPkt)
 	if err != nil {
 		return nil, err
@@ -376,6 +406,9 @@ func (f *AssetWallet) FundPacket(ctx context.Context,
 		AssetSpecifier: fundDesc.AssetSpecifier,
 		MinAmt:         fundDesc.Amount,
 		CoinSelectType: fundDesc.CoinSelectType,
+		Account:        fundDesc.Account,
+		Reserver:       Reserver(fundDesc.Reserver),
+		ScriptKey:      fundDesc.ScriptKey,
 	}
 
 	anchorVersion, err := tappsbt.CommitmentVersion(vPkt.Version)
@@ -388,7 +421,7 @@ func (f *AssetWallet) FundPacket(ctx context.Context,
 	}
 
 	selectedCommitments, err := f.cfg.CoinSelector.SelectCoins(
-		ctx, constraints, PreferMaxAmount, *anchorVersion,
+		ctx, constraints, selectStrategy(fundDesc), *anchorVersion,
 	)
 	if err != nil {
 		return nil, err
@@ -425,6 +458,195 @@ func (f *AssetWallet) FundPacket(ctx context.Context,
 	return pkt, nil
 }
 
+// FundGroupPackets funds virtual transactions that pay the amount of the
+// funding descriptor to the single output of the given template, selecting
+// assets to spend across all asset IDs of the asset group specified in the
+// descriptor. Because a virtual transaction can only spend assets of a single
+// asset ID, one funded virtual transaction is returned for each asset ID that
+// was selected. The template's output amount is replaced with the share of the
+// respective asset ID.
+func (f *AssetWallet) FundGroupPackets(ctx context.Context,
+	fundDesc *tapsend.FundingDescriptor,
+	vPktTemplate *tappsbt.VPacket) ([]*FundedVPacket, error) {
+
+	// The input and address networks must match.
+	hrp := vPktTemplate.ChainParams.TapHRP
+	if !address.IsForNet(hrp, f.cfg.ChainParams) {
+		return nil, address.ErrMismatchedHRP
+	}
+
+	groupKey := fundDesc.AssetSpecifier.UnwrapGroupKeyToPtr()
+	if groupKey == nil {
+		return nil, fmt.Errorf("group key must be specified")
+	}
+
+	if len(vPktTemplate.Outputs) != 1 {
+		return nil, fmt.Errorf("template must have exactly one output")
+	}
+
+	constraints := CommitmentConstraints{
+		AssetSpecifier: asset.NewSpecifierFromGroupKey(*groupKey),
+		MinAmt:         fundDesc.Amount,
+		CoinSelectType: fundDesc.CoinSelectType,
+		Account:        fundDesc.Account,
+		Reserver:       Reserver(fundDesc.Reserver),
+	}
+
+	anchorVersion, err := tappsbt.CommitmentVersion(vPktTemplate.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if anchorVersion == nil {
+		anchorVersion = fn.Ptr(commitment.TapCommitmentV1)
+	}
+
+	selectedCommitments, err := f.cfg.CoinSelector.SelectCoins(
+		ctx, constraints, selectStrategy(fundDesc), *anchorVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we return with an error, we want to release the coins we've
+	// selected.
+	success := false
+	defer func() {
+		if !success {
+			outpoints := fn.Map(
+				selectedCommitments,
+				func(c *AnchoredCommitment) wire.OutPoint {
+					return c.AnchorPoint
+				},
+			)
+			err := f.cfg.CoinSelector.ReleaseCoins(
+				ctx, outpoints...,
+			)
+			if err != nil {
+				log.Errorf("Unable to release coins: %v", err)
+			}
+		}
+	}()
+
+	idAmounts, err := splitGroupAmount(selectedCommitments, fundDesc.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	fundedPkts := make([]*FundedVPacket, 0, len(idAmounts))
+	for _, idAmount := range idAmounts {
+		idCommitments := fn.Filter(
+			selectedCommitments, func(c *AnchoredCommitment) bool {
+				return c.Asset.ID() == idAmount.id
+			},
+		)
+
+		vPkt := vPktTemplate.Copy()
+		vPkt.Inputs = []*tappsbt.VInput{{
+			PrevID: asset.PrevID{
+				ID: idAmount.id,
+			},
+		}}
+		vPkt.Outputs[0].Amount = idAmount.amount
+
+		idFundDesc := &tapsend.FundingDescriptor{
+			AssetSpecifier: asset.NewSpecifierOptionalGroupPubKey(
+				idAmount.id, groupKey,
+			),
+			Amount:         idAmount.amount,
+			CoinSelectType: fundDesc.CoinSelectType,
+			Account:        fundDesc.Account,
+			Reserver:       fundDesc.Reserver,
+			ExactAmount:    fundDesc.ExactAmount,
+		}
+		fundedPkt, err := f.fundPacketWithInputs(
+			ctx, idFundDesc, vPkt, idCommitments,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fund packet for "+
+				"asset ID %v: %w", idAmount.id, err)
+		}
+
+		fundedPkts = append(fundedPkts, fundedPkt)
+	}
+
+	// Each packet derived its own internal keys for the local anchor
+	// outputs (e.g. the change output). But outputs of different packets
+	// that go to the same anchor output must agree on the anchor
+	// information, so we use the one of the first packet for all of them.
+	anchorOutputs := make(map[uint32]*tappsbt.VOutput)
+	for _, fundedPkt := range fundedPkts {
+		for _, vOut := range fundedPkt.VPacket.Outputs {
+			firstOut, ok := anchorOutputs[vOut.AnchorOutputIndex]
+			if !ok {
+				anchorOutputs[vOut.AnchorOutputIndex] = vOut
+				continue
+			}
+
+			vOut.AnchorOutputInternalKey =
+				firstOut.AnchorOutputInternalKey
+			vOut.AnchorOutputBip32Derivation =
+				firstOut.AnchorOutputBip32Derivation
+			vOut.AnchorOutputTaprootBip32Derivation =
+				firstOut.AnchorOutputTaprootBip32Derivation
+		}
+	}
+
+	success = true
+	return fundedPkts, nil
+}
+
+// assetIDAmount is the amount of a single asset ID within an asset group.
+type assetIDAmount struct {
+	id     asset.ID
+	amount uint64
+}
+
+// splitGroupAmount distributes the given total amount over the asset IDs of
+// the selected commitments. The asset IDs are filled up in the order they
+// first appear in the selection, so the ones with the largest coins are used
+// first, and the last asset ID only receives the remainder.
+func splitGroupAmount(selected []*AnchoredCommitment,
+	total uint64) ([]assetIDAmount, error) {
+
+	var (
+		idAmounts []assetIDAmount
+		idIndex   = make(map[asset.ID]int)
+	)
+	for _, c := range selected {
+		id := c.Asset.ID()
+		idx, ok := idIndex[id]
+		if !ok {
+			idx = len(idAmounts)
+			idIndex[id] = idx
+			idAmounts = append(idAmounts, assetIDAmount{
+				id: id,
+			})
+		}
+
+		idAmounts[idx].amount += c.Asset.Amount
+	}
+
+	remaining := total
+	result := make([]assetIDAmount, 0, len(idAmounts))
+	for _, idAmount := range idAmounts {
+		if remaining == 0 {
+			break
+		}
+
+		idAmount.amount = min(idAmount.amount, remaining)
+		remaining -= idAmount.amount
+
+		result = append(result, idAmount)
+	}
+
+	if remaining != 0 {
+		return nil, ErrMatchingAssetsNotFound
+	}
+
+	return result, nil
+}
+
 // FundBurn funds a virtual transaction for burning the given amount of units of
 // the given asset.
 func (f *AssetWallet) FundBurn(ctx context.Context,
@@ -442,6 +664,7 @@ func (f *AssetWallet) FundBurn(ctx context.Context,
 	constraints := CommitmentConstraints{
 		AssetSpecifier: fundDesc.AssetSpecifier,
 		MinAmt:         fundDesc.Amount,
+		Reserver:       ReserverBurn,
 	}
 	selectedCommitments, err := f.cfg.CoinSelector.SelectCoins(
 		ctx, constraints, PreferMaxAmount, commitment.TapCommitmentV2,
@@ -588,6 +811,18 @@ func (f *AssetWallet) hasOtherAssets(inputCommitments tappsbt.InputCommitments,
 	return false, nil
 }
 
+// selectStrategy returns the coin selection strategy that should be used to
+// fund the given descriptor.
+func selectStrategy(
+	fundDesc *tapsend.FundingDescriptor) MultiCommitmentSelectStrategy {
+
+	if fundDesc.ExactAmount {
+		return ExactAmount
+	}
+
+	return PreferMaxAmount
+}
+
 // fundPacketWithInputs funds a virtual transaction with the given inputs.
 func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 	fundDesc *tapsend.FundingDescriptor, vPkt *tappsbt.VPacket,
@@ -625,6 +860,24 @@ func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 		return nil, err
 	}
 
+	// Outputs that pay to a reusable address get a script key that is
+	// derived from the base key of the address and a per-transfer nonce,
+	// which commits to the first input that was just selected.
+	for outIdx, baseKey := range fundDesc.ReusableAddrKeys {
+		if outIdx >= len(vPkt.Outputs) {
+			return nil, fmt.Errorf("invalid reusable address "+
+				"output index %d", outIdx)
+		}
+
+		vOut := vPkt.Outputs[outIdx]
+		vOut.ScriptKey = asset.NewScriptKey(
+			address.DeriveReusableScriptKey(
+				baseKey, vPkt.Inputs[0].PrevID.OutPoint,
+				vOut.AnchorOutputIndex,
+			),
+		)
+	}
+
 	fullValue, err := tapsend.ValidateInputs(
 		inputCommitments, assetType, fundDesc,
 	)
@@ -632,6 +885,13 @@ func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 		return nil, err
 	}
 
+	// An exact amount send must not leave any change behind.
+	if fundDesc.ExactAmount && !fullValue {
+		return nil, fmt.Errorf("selected inputs sum to %d, but exact "+
+			"amount %d was requested", totalInputAmt,
+			fundDesc.Amount)
+	}
+
 	// We want to know if we are sending to ourselves. We detect that by
 	// looking at the key descriptor of the script key. Because that is not
 	// part of addresses and might not be specified by the user through the
@@ -697,7 +957,7 @@ func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 		}
 		if unSpendable && !fullValue {
 			changeScriptKey, err := f.cfg.KeyRing.DeriveNextKey(
-				ctx, asset.TaprootAssetsKeyFamily,
+				ctx, fundDesc.KeyFamily(),
 			)
 			if err != nil {
 				return nil, err
@@ -738,7 +998,8 @@ func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 	// Before we can prepare output assets for our send, we need to generate
 	// a new internal key for the anchor outputs. We assume any output that
 	// hasn't got an internal key set is going to a local anchor, and we
-	// provide the internal key for that.
+	// provide the internal key for that. The key is derived within the key
+	// scope of the account the transfer is funded from.
 	for idx := range vPkt.Outputs {
 		vOut := vPkt.Outputs[idx]
 		if vOut.AnchorOutputInternalKey != nil {
@@ -746,7 +1007,7 @@ func (f *AssetWallet) fundPacketWithInputs(ctx context.Context,
 		}
 
 		newInternalKey, err := f.cfg.KeyRing.DeriveNextKey(
-			ctx, asset.TaprootAssetsKeyFamily,
+			ctx, fundDesc.KeyFamily(),
 		)
 		if err != nil {
 			return nil, err
@@ -958,6 +1219,11 @@ type SignVirtualPacketOptions struct {
 
 	// WitnessValidator validates a signature after it's been created.
 	WitnessValidator tapscript.WitnessValidator
+
+	// SignInputs, if set, restricts signing to the inputs with the given
+	// indexes. Since the packet isn't fully signed afterward, the
+	// witnesses aren't validated.
+	SignInputs []uint32
 }
 
 // defaultSignVirtualPacketOptions returns the set of default options for the
@@ -992,6 +1258,15 @@ func WithValidator(
 	}
 }
 
+// WithSignInputs restricts the signing to the inputs with the given indexes.
+// This is used if the remaining inputs of the packet belong to other parties
+// that add their witnesses separately.
+func WithSignInputs(inputIdxs ...uint32) SignVirtualPacketOption {
+	return func(o *SignVirtualPacketOptions) {
+		o.SignInputs = inputIdxs
+	}
+}
+
 // SignVirtualPacket signs the virtual transaction of the given packet and
 // returns the input indexes that were signed (referring to the virtual
 // transaction's inputs).
@@ -1005,7 +1280,20 @@ func (f *AssetWallet) SignVirtualPacket(vPkt *tappsbt.VPacket,
 		optFunc(opts)
 	}
 
-	for idx := range vPkt.Inputs {
+	signInputs := opts.SignInputs
+	if signInputs == nil {
+		signInputs = make([]uint32, len(vPkt.Inputs))
+		for idx := range vPkt.Inputs {
+			signInputs[idx] = uint32(idx)
+		}
+	}
+
+	for _, idx := range signInputs {
+		if int(idx) >= len(vPkt.Inputs) {
+			return nil, fmt.Errorf("input index %d out of range",
+				idx)
+		}
+
 		// Conditionally skip the inclusion proof verification. We may
 		// not need to verify the input proof if we're only using the
 		// input to generate a new virtual output proof during
@@ -1022,6 +1310,20 @@ func (f *AssetWallet) SignVirtualPacket(vPkt *tappsbt.VPacket,
 		}
 	}
 
+	// If we only sign some of the inputs, the other parties add their
+	// witnesses later, so we can't validate anything yet.
+	if opts.SignInputs != nil {
+		err := tapsend.SignVirtualInputs(
+			vPkt, f.cfg.Signer, opts.SignInputs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate Taproot "+
+				"Asset witness data: %w", err)
+		}
+
+		return signInputs, nil
+	}
+
 	// Now we'll use the signer to sign all the inputs for the new Taproot
 	// Asset leaves. The witness data for each input will be assigned for
 	// us.
@@ -1033,13 +1335,8 @@ func (f *AssetWallet) SignVirtualPacket(vPkt *tappsbt.VPacket,
 			"witness data: %w", err)
 	}
 
-	// Mark all inputs as signed.
-	signedInputs := make([]uint32, len(vPkt.Inputs))
-	for idx := range vPkt.Inputs {
-		signedInputs[idx] = uint32(idx)
-	}
-
-	return signedInputs, nil
+	// All inputs were signed.
+	return signInputs, nil
 }
 
 // verifyInclusionProof verifies that the given virtual input's asset is
@@ -1273,7 +1570,30 @@ func (f *AssetWallet) CreatePassiveAssets(ctx context.Context,
 		}
 	}
 
-	return maps.Values(passivePackets), nil
+	// We return the packets ordered by the input they spend, so the same
+	// inputs always result in the same set of packets.
+	prevIDs := maps.Keys(passivePackets)
+	slices.SortFunc(prevIDs, comparePrevIDs)
+
+	return fn.Map(prevIDs, func(prevID asset.PrevID) *tappsbt.VPacket {
+		return passivePackets[prevID]
+	}), nil
+}
+
+// comparePrevIDs orders the given previous asset outputs by their anchor
+// outpoint, then by asset ID and finally by script key.
+func comparePrevIDs(a, b asset.PrevID) int {
+	if c := bytes.Compare(a.OutPoint.Hash[:], b.OutPoint.Hash[:]); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.OutPoint.Index, b.OutPoint.Index); c != 0 {
+		return c
+	}
+	if c := bytes.Compare(a.ID[:], b.ID[:]); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(a.ScriptKey[:], b.ScriptKey[:])
 }
 
 // SignPassiveAssets signs the given passive asset packets.
@@ -1319,6 +1639,12 @@ func (f *AssetWallet) AnchorVirtualTransactions(ctx context.Context,
 		return nil, fmt.Errorf("error creating anchor TX: %w", err)
 	}
 
+	// The template outputs are all anchor outputs, so they carry the
+	// configured anchor output value.
+	for _, txOut := range sendPacket.UnsignedTx.TxOut {
+		txOut.Value = int64(f.cfg.Anchor.anchorOutputValue())
+	}
+
 	// TODO(roasbeef): also want to log the total fee to disk for
 	// accounting, etc.
 
@@ -1350,6 +1676,13 @@ func (f *AssetWallet) AnchorVirtualTransactions(ctx context.Context,
 	log.Infof("Received funded PSBT packet")
 	log.Tracef("Packet: %v", spew.Sdump(anchorPkt.Pkt))
 
+	// If the fee reserve of the wallet isn't full, we top it up from the
+	// change of this transaction.
+	err = f.splitFeeReserve(ctx, anchorPkt, params.FeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to split fee reserve: %w", err)
+	}
+
 	// With all the input and output information in the packet, we
 	// can now ask lnd to sign it, and then extract the final
 	// version ourselves.
@@ -0,0 +1,93 @@
+package tapfreighter
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSplitGroupAmount tests that the amount of a group funding request is
+// distributed over the asset IDs of the selected commitments correctly.
+func TestSplitGroupAmount(t *testing.T) {
+	t.Parallel()
+
+	genesisA := asset.RandGenesis(t, asset.Normal)
+	genesisB := asset.RandGenesis(t, asset.Normal)
+	genesisC := asset.RandGenesis(t, asset.Normal)
+
+	coin := func(genesis asset.Genesis, amt uint64) *AnchoredCommitment {
+		return &AnchoredCommitment{
+			Asset: &asset.Asset{
+				Genesis: genesis,
+				Amount:  amt,
+			},
+		}
+	}
+
+	// The coins are ordered as the coin selection returns them, largest
+	// first.
+	selected := []*AnchoredCommitment{
+		coin(genesisA, 500),
+		coin(genesisB, 300),
+		coin(genesisA, 200),
+		coin(genesisC, 100),
+	}
+
+	testCases := []struct {
+		name     string
+		coins    []*AnchoredCommitment
+		total    uint64
+		expected []assetIDAmount
+		err      error
+	}{{
+		name:  "single asset ID",
+		coins: selected[:1],
+		total: 400,
+		expected: []assetIDAmount{
+			{id: genesisA.ID(), amount: 400},
+		},
+	}, {
+		name:  "coins of the same asset ID are combined",
+		coins: selected[:3],
+		total: 1_000,
+		expected: []assetIDAmount{
+			{id: genesisA.ID(), amount: 700},
+			{id: genesisB.ID(), amount: 300},
+		},
+	}, {
+		name:  "last asset ID receives the remainder",
+		coins: selected,
+		total: 1_050,
+		expected: []assetIDAmount{
+			{id: genesisA.ID(), amount: 700},
+			{id: genesisB.ID(), amount: 300},
+			{id: genesisC.ID(), amount: 50},
+		},
+	}, {
+		name:  "unused asset IDs are skipped",
+		coins: selected,
+		total: 700,
+		expected: []assetIDAmount{
+			{id: genesisA.ID(), amount: 700},
+		},
+	}, {
+		name:  "insufficient amount",
+		coins: selected,
+		total: 1_101,
+		err:   ErrMatchingAssetsNotFound,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := splitGroupAmount(tc.coins, tc.total)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
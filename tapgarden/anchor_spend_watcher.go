@@ -0,0 +1,290 @@
+package tapgarden
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// OwnedAnchor is an on-chain output that anchors assets owned by the daemon.
+type OwnedAnchor struct {
+	// OutPoint is the outpoint of the anchor output.
+	OutPoint wire.OutPoint
+
+	// PkScript is the output script of the anchor output.
+	PkScript []byte
+
+	// HeightHint is the height at which the anchor output was confirmed.
+	HeightHint uint32
+}
+
+// AnchorLister lists the on-chain outputs that anchor assets we own.
+type AnchorLister interface {
+	// ListOwnedAnchors returns all confirmed anchor outputs that hold
+	// unspent assets owned by the daemon.
+	ListOwnedAnchors(ctx context.Context) ([]OwnedAnchor, error)
+}
+
+// SpendNotifier is used to get notified about an output being spent.
+type SpendNotifier interface {
+	// RegisterSpendNtfn registers an intent to be notified once the given
+	// outpoint is spent on-chain.
+	RegisterSpendNtfn(ctx context.Context, outpoint *wire.OutPoint,
+		pkScript []byte, heightHint uint32) (
+		chan *chainntnfs.SpendDetail, chan error, error)
+}
+
+// AnchorSpendWatcherConfig houses all the items that the anchor spend watcher
+// needs to carry out its duties.
+type AnchorSpendWatcherConfig struct {
+	// ChainBridge is used to get notified about new blocks.
+	ChainBridge ChainBridge
+
+	// SpendNotifier is used to watch the anchor outputs for spends.
+	SpendNotifier SpendNotifier
+
+	// AnchorLister is used to list the anchor outputs that need to be
+	// watched.
+	AnchorLister AnchorLister
+
+	// IsKnownSpend returns true if the transaction with the given hash
+	// that spent the given outpoint is a transfer that was created by the
+	// daemon itself, or a replacement of a pending transfer spending the
+	// same outpoint.
+	IsKnownSpend func(ctx context.Context, spentOutPoint wire.OutPoint,
+		txHash chainhash.Hash) (bool, error)
+
+	// AlertSender is used to raise an alert if an anchor output is spent
+	// by an unknown transaction.
+	AlertSender alert.Sender
+
+	// ErrChan is the main error channel the watcher will report back
+	// critical errors to the main server.
+	ErrChan chan<- error
+}
+
+// AnchorSpendWatcher watches all anchor outputs holding assets owned by the
+// daemon and raises an alert if one of them is spent by a transaction that the
+// daemon didn't create itself. Such a spend means that either the wallet's
+// keys are being used by another party or software, or that the assets held in
+// the output were destroyed.
+type AnchorSpendWatcher struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *AnchorSpendWatcherConfig
+
+	// watched maps the outpoints of the anchor outputs that are currently
+	// being watched to the function that cancels their spend
+	// notification.
+	//
+	// NOTE: This map is only accessed from the main event loop.
+	watched map[wire.OutPoint]context.CancelFunc
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewAnchorSpendWatcher creates a new anchor spend watcher.
+func NewAnchorSpendWatcher(cfg *AnchorSpendWatcherConfig) *AnchorSpendWatcher {
+	return &AnchorSpendWatcher{
+		cfg:     cfg,
+		watched: make(map[wire.OutPoint]context.CancelFunc),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: DefaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start starts the anchor spend watcher.
+func (w *AnchorSpendWatcher) Start() error {
+	w.startOnce.Do(func() {
+		log.Info("Starting anchor spend watcher")
+
+		w.Wg.Add(1)
+		go w.watchAnchors()
+	})
+
+	return nil
+}
+
+// Stop stops the anchor spend watcher.
+func (w *AnchorSpendWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		log.Info("Stopping anchor spend watcher")
+
+		close(w.Quit)
+		w.Wg.Wait()
+	})
+
+	return nil
+}
+
+// watchAnchors is the main event loop of the watcher. It updates the set of
+// watched anchor outputs on every new block.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *AnchorSpendWatcher) watchAnchors() {
+	defer w.Wg.Done()
+
+	runCtx, cancel := w.WithCtxQuitNoTimeout()
+	defer cancel()
+
+	newBlockChan, blockErr, err := w.cfg.ChainBridge.RegisterBlockEpochNtfn(
+		runCtx,
+	)
+	if err != nil {
+		w.reportErr(fmt.Errorf("unable to register for block "+
+			"epoch notifications: %w", err))
+		return
+	}
+
+	w.updateWatchedAnchors(runCtx)
+
+	for {
+		select {
+		case <-newBlockChan:
+			w.updateWatchedAnchors(runCtx)
+
+		case err := <-blockErr:
+			w.reportErr(fmt.Errorf("unable to receive block "+
+				"epoch notification: %w", err))
+			return
+
+		case <-w.Quit:
+			return
+		}
+	}
+}
+
+// updateWatchedAnchors registers spend notifications for all new anchor
+// outputs and cancels the ones of outputs that no longer hold our assets.
+func (w *AnchorSpendWatcher) updateWatchedAnchors(runCtx context.Context) {
+	ctxt, cancel := w.WithCtxQuit()
+	anchors, err := w.cfg.AnchorLister.ListOwnedAnchors(ctxt)
+	cancel()
+	if err != nil {
+		log.Errorf("Unable to list owned anchor outputs: %v", err)
+		return
+	}
+
+	current := make(map[wire.OutPoint]struct{}, len(anchors))
+	for _, anchor := range anchors {
+		current[anchor.OutPoint] = struct{}{}
+	}
+
+	// Stop watching the outputs that were spent by our own transfers.
+	for op, cancelSpend := range w.watched {
+		if _, ok := current[op]; !ok {
+			cancelSpend()
+			delete(w.watched, op)
+		}
+	}
+
+	notifier := w.cfg.SpendNotifier
+	for idx := range anchors {
+		anchor := anchors[idx]
+		if _, ok := w.watched[anchor.OutPoint]; ok {
+			continue
+		}
+
+		spendCtx, cancelSpend := context.WithCancel(runCtx)
+		spendChan, errChan, err := notifier.RegisterSpendNtfn(
+			spendCtx, &anchor.OutPoint, anchor.PkScript,
+			anchor.HeightHint,
+		)
+		if err != nil {
+			cancelSpend()
+			log.Errorf("Unable to register spend notification for "+
+				"anchor output %v: %v", anchor.OutPoint, err)
+			continue
+		}
+
+		log.Debugf("Watching anchor output %v for spends",
+			anchor.OutPoint)
+
+		w.watched[anchor.OutPoint] = cancelSpend
+
+		w.Wg.Add(1)
+		go w.waitForSpend(spendCtx, anchor, spendChan, errChan)
+	}
+}
+
+// waitForSpend waits for the given anchor output to be spent.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *AnchorSpendWatcher) waitForSpend(ctx context.Context,
+	anchor OwnedAnchor, spendChan chan *chainntnfs.SpendDetail,
+	errChan chan error) {
+
+	defer w.Wg.Done()
+
+	select {
+	case spend := <-spendChan:
+		w.handleSpend(anchor, spend)
+
+	case err := <-errChan:
+		// The error channel also fires if the notification was
+		// cancelled, which is the expected way for it to end.
+		if ctx.Err() == nil {
+			log.Errorf("Error watching anchor output %v for "+
+				"spends: %v", anchor.OutPoint, err)
+		}
+
+	case <-ctx.Done():
+	}
+}
+
+// handleSpend checks whether the given anchor output spend was made by one of
+// our own transfers and raises an alert if it wasn't.
+func (w *AnchorSpendWatcher) handleSpend(anchor OwnedAnchor,
+	spend *chainntnfs.SpendDetail) {
+
+	if spend == nil || spend.SpenderTxHash == nil {
+		return
+	}
+	spenderTxHash := *spend.SpenderTxHash
+
+	ctxt, cancel := w.WithCtxQuit()
+	defer cancel()
+
+	known, err := w.cfg.IsKnownSpend(
+		ctxt, anchor.OutPoint, spenderTxHash,
+	)
+	if err != nil {
+		log.Errorf("Unable to look up spend of anchor output %v by "+
+			"TX %v: %v", anchor.OutPoint, spenderTxHash, err)
+		return
+	}
+
+	if known {
+		log.Debugf("Anchor output %v spent by our own transfer TX %v",
+			anchor.OutPoint, spenderTxHash)
+		return
+	}
+
+	alert.SendIfSet(w.cfg.AlertSender, alert.Alert{
+		Type:     alert.TypeUnexpectedAnchorSpend,
+		Severity: alert.SeverityCritical,
+		Message: fmt.Sprintf("Anchor output %v holding our assets "+
+			"was spent by unknown TX %v at height %d",
+			anchor.OutPoint, spenderTxHash, spend.SpendingHeight),
+		DedupKey: anchor.OutPoint.String(),
+	})
+}
+
+// reportErr reports an error to the main server.
+func (w *AnchorSpendWatcher) reportErr(err error) {
+	select {
+	case w.cfg.ErrChan <- err:
+	case <-w.Quit:
+	}
+}
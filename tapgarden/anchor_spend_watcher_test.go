@@ -0,0 +1,161 @@
+package tapgarden
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/alert"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/stretchr/testify/require"
+)
+
+// spendRegistration is a spend notification registered with the mock spend
+// notifier.
+type spendRegistration struct {
+	ctx       context.Context
+	outpoint  wire.OutPoint
+	spendChan chan *chainntnfs.SpendDetail
+}
+
+// mockSpendNotifier is a mock implementation of the SpendNotifier interface.
+type mockSpendNotifier struct {
+	registrations chan *spendRegistration
+}
+
+func (m *mockSpendNotifier) RegisterSpendNtfn(ctx context.Context,
+	outpoint *wire.OutPoint, _ []byte, _ uint32) (
+	chan *chainntnfs.SpendDetail, chan error, error) {
+
+	reg := &spendRegistration{
+		ctx:       ctx,
+		outpoint:  *outpoint,
+		spendChan: make(chan *chainntnfs.SpendDetail, 1),
+	}
+	m.registrations <- reg
+
+	return reg.spendChan, make(chan error), nil
+}
+
+// mockAnchorLister is a mock implementation of the AnchorLister interface.
+type mockAnchorLister struct {
+	sync.Mutex
+	anchors []OwnedAnchor
+}
+
+func (m *mockAnchorLister) ListOwnedAnchors(
+	context.Context) ([]OwnedAnchor, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	return m.anchors, nil
+}
+
+// mockAlertSender is a mock implementation of the alert.Sender interface.
+type mockAlertSender struct {
+	alerts chan alert.Alert
+}
+
+func (m *mockAlertSender) SendAlert(a alert.Alert) {
+	m.alerts <- a
+}
+
+// TestAnchorSpendWatcher tests that the anchor spend watcher only raises an
+// alert for anchor outputs spent by unknown transactions and stops watching
+// outputs that no longer hold our assets.
+func TestAnchorSpendWatcher(t *testing.T) {
+	t.Parallel()
+
+	ownAnchor := OwnedAnchor{
+		OutPoint:   test.RandOp(t),
+		HeightHint: 100,
+	}
+	otherAnchor := OwnedAnchor{
+		OutPoint:   test.RandOp(t),
+		HeightHint: 100,
+	}
+	lister := &mockAnchorLister{
+		anchors: []OwnedAnchor{ownAnchor, otherAnchor},
+	}
+	ownTxHash := chainhash.Hash(test.RandHash())
+	unknownTxHash := chainhash.Hash(test.RandHash())
+
+	chainBridge := NewMockChainBridge()
+	notifier := &mockSpendNotifier{
+		registrations: make(chan *spendRegistration, 2),
+	}
+	alerts := &mockAlertSender{alerts: make(chan alert.Alert, 1)}
+	watcher := NewAnchorSpendWatcher(&AnchorSpendWatcherConfig{
+		ChainBridge:   chainBridge,
+		SpendNotifier: notifier,
+		AnchorLister:  lister,
+		IsKnownSpend: func(_ context.Context, _ wire.OutPoint,
+			txHash chainhash.Hash) (bool, error) {
+
+			return txHash == ownTxHash, nil
+		},
+		AlertSender: alerts,
+		ErrChan:     make(chan error, 1),
+	})
+	require.NoError(t, watcher.Start())
+	t.Cleanup(func() {
+		require.NoError(t, watcher.Stop())
+	})
+
+	regs := make(map[wire.OutPoint]*spendRegistration)
+	for i := 0; i < 2; i++ {
+		select {
+		case reg := <-notifier.registrations:
+			regs[reg.outpoint] = reg
+
+		case <-time.After(testTimeout):
+			t.Fatalf("spend notification not registered")
+		}
+	}
+	require.Contains(t, regs, ownAnchor.OutPoint)
+	require.Contains(t, regs, otherAnchor.OutPoint)
+
+	// A spend by one of our own transfers doesn't raise an alert.
+	regs[ownAnchor.OutPoint].spendChan <- &chainntnfs.SpendDetail{
+		SpenderTxHash: &ownTxHash,
+	}
+
+	// Once the spent output is no longer listed, its notification is
+	// cancelled.
+	lister.Lock()
+	lister.anchors = []OwnedAnchor{otherAnchor}
+	lister.Unlock()
+	chainBridge.NewBlocks <- 101
+
+	select {
+	case <-regs[ownAnchor.OutPoint].ctx.Done():
+	case <-time.After(testTimeout):
+		t.Fatalf("spend notification not cancelled")
+	}
+
+	// A spend by an unknown transaction raises a critical alert.
+	regs[otherAnchor.OutPoint].spendChan <- &chainntnfs.SpendDetail{
+		SpenderTxHash: &unknownTxHash,
+	}
+
+	select {
+	case a := <-alerts.alerts:
+		require.Equal(t, alert.TypeUnexpectedAnchorSpend, a.Type)
+		require.Equal(t, alert.SeverityCritical, a.Severity)
+		require.Equal(t, otherAnchor.OutPoint.String(), a.DedupKey)
+
+	case <-time.After(testTimeout):
+		t.Fatalf("no alert raised")
+	}
+
+	select {
+	case a := <-alerts.alerts:
+		t.Fatalf("unexpected alert: %v", a)
+	default:
+	}
+}
@@ -0,0 +1,144 @@
+package tapgarden
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// DefaultAutoFinalizeCheckInterval is the default interval at which
+	// the seedling age trigger of the automatic batch finalization is
+	// evaluated.
+	DefaultAutoFinalizeCheckInterval = time.Minute
+)
+
+// AutoFinalizeConfig is the configuration of the triggers that finalize the
+// pending minting batch automatically. Each trigger has an optional fee rate
+// ceiling; if the fee rate the batch would be funded with exceeds the ceiling
+// of a trigger, the trigger is deferred until fees drop.
+//
+//nolint:lll
+type AutoFinalizeConfig struct {
+	// SeedlingCount is the number of seedlings in the pending batch at
+	// which the batch is finalized. A value of zero disables the trigger.
+	SeedlingCount int `long:"seedling-count" description:"Finalize the pending minting batch once it contains this many seedlings. A value of zero disables this trigger."`
+
+	// SeedlingCountMaxFeeRate is the fee rate ceiling of the seedling
+	// count trigger, in sat/vB. A value of zero means no ceiling.
+	SeedlingCountMaxFeeRate uint64 `long:"seedling-count-max-feerate" description:"The maximum fee rate in sat/vB at which the seedling count trigger finalizes a batch. A value of zero means no ceiling."`
+
+	// SeedlingAge is the age of the oldest seedling of the pending batch
+	// at which the batch is finalized. A value of zero disables the
+	// trigger.
+	SeedlingAge time.Duration `long:"seedling-age" description:"Finalize the pending minting batch once its oldest seedling is older than this. A value of zero disables this trigger. Valid time units are {s, m, h}."`
+
+	// SeedlingAgeMaxFeeRate is the fee rate ceiling of the seedling age
+	// trigger, in sat/vB. A value of zero means no ceiling.
+	SeedlingAgeMaxFeeRate uint64 `long:"seedling-age-max-feerate" description:"The maximum fee rate in sat/vB at which the seedling age trigger finalizes a batch. A value of zero means no ceiling."`
+
+	// Schedule is a cron expression of the times at which the pending
+	// batch is finalized. An empty schedule disables the trigger.
+	Schedule string `long:"schedule" description:"A cron expression (minute hour day-of-month month day-of-week, evaluated in UTC) of the times at which the pending minting batch is finalized, for example '0 */6 * * *'. An empty schedule disables this trigger."`
+
+	// ScheduleMaxFeeRate is the fee rate ceiling of the schedule trigger,
+	// in sat/vB. A value of zero means no ceiling.
+	ScheduleMaxFeeRate uint64 `long:"schedule-max-feerate" description:"The maximum fee rate in sat/vB at which the schedule trigger finalizes a batch. If the fee rate is higher at a scheduled time, the batch is kept until the next scheduled time. A value of zero means no ceiling."`
+
+	// CheckInterval is the interval at which the seedling count and
+	// seedling age triggers are evaluated, in addition to evaluating the
+	// seedling count trigger whenever a seedling is added.
+	CheckInterval time.Duration `long:"check-interval" description:"The interval at which the seedling count and seedling age triggers are evaluated. Valid time units are {s, m, h}."`
+}
+
+// DefaultAutoFinalizeConfig returns the default automatic batch finalization
+// configuration, which has all triggers disabled.
+func DefaultAutoFinalizeConfig() AutoFinalizeConfig {
+	return AutoFinalizeConfig{
+		CheckInterval: DefaultAutoFinalizeCheckInterval,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *AutoFinalizeConfig) Validate() error {
+	switch {
+	case c.SeedlingCount < 0:
+		return fmt.Errorf("seedling count must not be negative")
+
+	case c.SeedlingAge < 0:
+		return fmt.Errorf("seedling age must not be negative")
+
+	case c.CheckInterval <= 0 && (c.SeedlingCount > 0 ||
+		c.SeedlingAge > 0):
+
+		return fmt.Errorf("check interval must be positive")
+	}
+
+	if c.Schedule != "" {
+		if _, err := parseCronSchedule(c.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Enabled returns true if any of the triggers is enabled.
+func (c *AutoFinalizeConfig) Enabled() bool {
+	return c.SeedlingCount > 0 || c.SeedlingAge > 0 || c.Schedule != ""
+}
+
+// autoFinalizeTrigger is a condition that finalizes the pending batch
+// automatically.
+type autoFinalizeTrigger uint8
+
+const (
+	// triggerSeedlingCount fires once the pending batch contains the
+	// configured number of seedlings.
+	triggerSeedlingCount autoFinalizeTrigger = iota
+
+	// triggerSeedlingAge fires once the oldest seedling of the pending
+	// batch is older than the configured age.
+	triggerSeedlingAge
+
+	// triggerSchedule fires at the times of the configured schedule.
+	triggerSchedule
+)
+
+// String returns a human-readable name of the trigger.
+func (t autoFinalizeTrigger) String() string {
+	switch t {
+	case triggerSeedlingCount:
+		return "seedling_count"
+
+	case triggerSeedlingAge:
+		return "seedling_age"
+
+	case triggerSchedule:
+		return "schedule"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", t)
+	}
+}
+
+// maxFeeRate returns the fee rate ceiling of the given trigger, or zero if the
+// trigger has no ceiling.
+func (c *AutoFinalizeConfig) maxFeeRate(
+	trigger autoFinalizeTrigger) chainfee.SatPerKWeight {
+
+	var satPerVByte uint64
+	switch trigger {
+	case triggerSeedlingCount:
+		satPerVByte = c.SeedlingCountMaxFeeRate
+
+	case triggerSeedlingAge:
+		satPerVByte = c.SeedlingAgeMaxFeeRate
+
+	case triggerSchedule:
+		satPerVByte = c.ScheduleMaxFeeRate
+	}
+
+	return chainfee.SatPerKVByte(satPerVByte * 1000).FeePerKWeight()
+}
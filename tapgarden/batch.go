@@ -9,6 +9,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
 	"github.com/lightninglabs/taproot-assets/fn"
@@ -303,6 +304,15 @@ func (m *MintingBatch) IsFunded() bool {
 	return m.GenesisPacket != nil
 }
 
+// GenesisAnchorOutPoint returns the outpoint of the genesis transaction output
+// that commits to the minted assets. The batch must already be funded.
+func (m *MintingBatch) GenesisAnchorOutPoint() wire.OutPoint {
+	return wire.OutPoint{
+		Hash:  m.GenesisPacket.Pkt.UnsignedTx.TxHash(),
+		Index: extractAnchorOutputIndex(m.GenesisPacket),
+	}
+}
+
 // HasSeedlings checks if the batch has any seedlings. A batch with no seedlings
 // cannot be sealed nor finalized.
 func (m *MintingBatch) HasSeedlings() bool {
@@ -0,0 +1,204 @@
+package tapgarden
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// CollectionEdition is a single edition of a collectible collection.
+type CollectionEdition struct {
+	// Name is the unique name of the edition, which becomes the asset
+	// name.
+	Name string
+
+	// Meta is the optional metadata of the edition.
+	Meta *proof.MetaReveal
+}
+
+// CollectionParams are the parameters for minting a collection of
+// collectibles that are linked under a single asset group.
+type CollectionParams struct {
+	// AssetVersion is the version of the assets to be created.
+	AssetVersion asset.Version
+
+	// GroupKey is the tweaked key of an existing asset group the
+	// collection is minted into. If not set, the first edition anchors a
+	// new asset group that all other editions are minted into.
+	GroupKey fn.Option[btcec.PublicKey]
+
+	// GroupInternalKey is the optional internal key of the new asset group
+	// anchored by the first edition. It can't be set together with a group
+	// key.
+	GroupInternalKey fn.Option[keychain.KeyDescriptor]
+
+	// Editions are the editions of the collection. Each edition is minted
+	// as a collectible with an amount of one.
+	Editions []CollectionEdition
+
+	// FeeRate is the optional fee rate used to fund the batch if it isn't
+	// funded yet.
+	FeeRate fn.Option[chainfee.SatPerKWeight]
+}
+
+// Validate returns an error if the parameters are invalid.
+func (p *CollectionParams) Validate() error {
+	if len(p.Editions) == 0 {
+		return fmt.Errorf("collection must have at least one edition")
+	}
+
+	if p.GroupKey.IsSome() && p.GroupInternalKey.IsSome() {
+		return fmt.Errorf("cannot specify a group internal key when " +
+			"minting into an existing group")
+	}
+
+	names := make(map[string]struct{}, len(p.Editions))
+	for _, edition := range p.Editions {
+		if _, ok := names[edition.Name]; ok {
+			return fmt.Errorf("duplicate edition name %v",
+				edition.Name)
+		}
+		names[edition.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// seedlings returns the seedlings of all editions of the collection. If no
+// group key is set, the first seedling anchors the new asset group of the
+// collection.
+func (p *CollectionParams) seedlings() []*Seedling {
+	seedlings := make([]*Seedling, 0, len(p.Editions))
+	anchorName := p.Editions[0].Name
+
+	for idx, edition := range p.Editions {
+		seedling := &Seedling{
+			AssetVersion: p.AssetVersion,
+			AssetType:    asset.Collectible,
+			AssetName:    edition.Name,
+			Meta:         edition.Meta,
+			Amount:       1,
+		}
+
+		switch {
+		case p.GroupKey.IsSome():
+			groupKey := p.GroupKey.UnwrapToPtr()
+			seedling.GroupInfo = &asset.AssetGroup{
+				GroupKey: &asset.GroupKey{
+					GroupPubKey: *groupKey,
+				},
+			}
+
+		case idx == 0:
+			seedling.EnableEmission = true
+			seedling.GroupInternalKey =
+				p.GroupInternalKey.UnwrapToPtr()
+
+		default:
+			seedling.GroupAnchor = &anchorName
+		}
+
+		seedlings = append(seedlings, seedling)
+	}
+
+	return seedlings
+}
+
+// MintedEdition is an edition of a collection that was added to a batch.
+type MintedEdition struct {
+	// Name is the name of the edition.
+	Name string
+
+	// AssetID is the ID the edition's asset will have once the batch is
+	// confirmed.
+	AssetID asset.ID
+}
+
+// CollectionResult is the result of adding a collection to a batch.
+type CollectionResult struct {
+	// Batch is the funded pending batch the collection was added to.
+	Batch *MintingBatch
+
+	// Editions are the editions of the collection, in the order they were
+	// requested.
+	Editions []MintedEdition
+}
+
+// mintCollection adds all editions of the given collection to the pending
+// batch, funds the batch if it isn't funded yet, and returns the IDs of the
+// editions' assets. All editions are validated before any of them is added to
+// the batch.
+func (c *ChainPlanter) mintCollection(ctx context.Context,
+	params CollectionParams) (*CollectionResult, error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	seedlings := params.seedlings()
+	for _, seedling := range seedlings {
+		if err := seedling.validateFields(); err != nil {
+			return nil, fmt.Errorf("invalid edition %v: %w",
+				seedling.AssetName, err)
+		}
+
+		if c.pendingBatch == nil {
+			continue
+		}
+
+		_, ok := c.pendingBatch.Seedlings[seedling.AssetName]
+		if ok {
+			return nil, fmt.Errorf("asset with name %v already in "+
+				"batch", seedling.AssetName)
+		}
+	}
+
+	for _, seedling := range seedlings {
+		if err := c.prepAssetSeedling(ctx, seedling); err != nil {
+			return nil, fmt.Errorf("unable to add edition %v: %w",
+				seedling.AssetName, err)
+		}
+
+		log.Infof("Added collection edition: %v", seedling)
+	}
+
+	// The asset IDs commit to the genesis outpoint, so the batch must be
+	// funded before we can return them.
+	if !c.pendingBatch.IsFunded() {
+		fundParams := FundParams{
+			FeeRate: params.FeeRate,
+		}
+		err := c.fundBatch(ctx, fundParams, c.pendingBatch)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fund minting batch: "+
+				"%w", err)
+		}
+	}
+
+	genesisPoint := extractGenesisOutpoint(
+		c.pendingBatch.GenesisPacket.Pkt.UnsignedTx,
+	)
+	anchorOutputIndex := extractAnchorOutputIndex(
+		c.pendingBatch.GenesisPacket,
+	)
+
+	editions := fn.Map(seedlings, func(s *Seedling) MintedEdition {
+		genesis := s.Genesis(genesisPoint, anchorOutputIndex)
+
+		return MintedEdition{
+			Name:    s.AssetName,
+			AssetID: genesis.ID(),
+		}
+	})
+
+	return &CollectionResult{
+		Batch:    c.pendingBatch,
+		Editions: editions,
+	}, nil
+}
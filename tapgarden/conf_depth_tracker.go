@@ -0,0 +1,203 @@
+package tapgarden
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/fn"
+)
+
+// ConfDepthTrackerConfig houses all the items that the confirmation depth
+// tracker needs to carry out its duties.
+type ConfDepthTrackerConfig struct {
+	// ChainBridge is used to get notified about new blocks and to look up
+	// the blocks of the main chain.
+	ChainBridge ChainBridge
+
+	// ErrChan is the main error channel the tracker will report back
+	// critical errors to the main server.
+	ErrChan chan<- error
+}
+
+// ConfDepthTracker tracks the tip of the main chain and reports the current
+// confirmation depth of transactions that were confirmed in a known block.
+// Because the block of a transaction is checked against the main chain on
+// every new block, a transaction that was re-organized out of the chain is
+// reported as unconfirmed instead of with an ever-increasing depth.
+type ConfDepthTracker struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	cfg *ConfDepthTrackerConfig
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// bestHeight is the height of the current tip of the main chain. It is
+	// zero until the first block notification was received.
+	bestHeight uint32
+
+	// mainChain caches the hashes of the main chain blocks that were
+	// looked up since the last new block. The cache is reset on every new
+	// block, as a re-org might have replaced any of them.
+	mainChain map[uint32]chainhash.Hash
+
+	// ContextGuard provides a wait group and main quit channel that can be
+	// used to create guarded contexts.
+	*fn.ContextGuard
+}
+
+// NewConfDepthTracker creates a new confirmation depth tracker.
+func NewConfDepthTracker(cfg *ConfDepthTrackerConfig) *ConfDepthTracker {
+	return &ConfDepthTracker{
+		cfg:       cfg,
+		mainChain: make(map[uint32]chainhash.Hash),
+		ContextGuard: &fn.ContextGuard{
+			DefaultTimeout: DefaultTimeout,
+			Quit:           make(chan struct{}),
+		},
+	}
+}
+
+// Start starts the confirmation depth tracker.
+func (t *ConfDepthTracker) Start() error {
+	t.startOnce.Do(func() {
+		log.Info("Starting confirmation depth tracker")
+
+		t.Wg.Add(1)
+		go t.trackBlocks()
+	})
+
+	return nil
+}
+
+// Stop stops the confirmation depth tracker.
+func (t *ConfDepthTracker) Stop() error {
+	t.stopOnce.Do(func() {
+		log.Info("Stopping confirmation depth tracker")
+
+		close(t.Quit)
+		t.Wg.Wait()
+	})
+
+	return nil
+}
+
+// trackBlocks is the main event loop of the tracker. It updates the tip of
+// the main chain on every new block.
+//
+// NOTE: This MUST be run as a goroutine.
+func (t *ConfDepthTracker) trackBlocks() {
+	defer t.Wg.Done()
+
+	runCtx, cancel := t.WithCtxQuitNoTimeout()
+	defer cancel()
+
+	newBlockChan, blockErr, err := t.cfg.ChainBridge.RegisterBlockEpochNtfn(
+		runCtx,
+	)
+	if err != nil {
+		t.reportErr(fmt.Errorf("unable to register for block "+
+			"epoch notifications: %w", err))
+		return
+	}
+
+	for {
+		select {
+		case height := <-newBlockChan:
+			t.newBlock(uint32(height))
+
+		case err := <-blockErr:
+			t.reportErr(fmt.Errorf("unable to receive block "+
+				"epoch notification: %w", err))
+			return
+
+		case <-t.Quit:
+			return
+		}
+	}
+}
+
+// newBlock updates the tip of the main chain and invalidates the cached main
+// chain blocks.
+func (t *ConfDepthTracker) newBlock(height uint32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	log.Debugf("New best block at height %d, re-checking confirmation "+
+		"depths", height)
+
+	t.bestHeight = height
+	t.mainChain = make(map[uint32]chainhash.Hash)
+}
+
+// ConfDepth returns the number of confirmations of a transaction that was
+// confirmed in the block with the given hash and height. One confirmation
+// means that the block is the current tip of the main chain. Zero is returned
+// if the block is no longer part of the main chain.
+func (t *ConfDepthTracker) ConfDepth(ctx context.Context,
+	blockHash chainhash.Hash, blockHeight uint32) (uint32, error) {
+
+	if blockHeight == 0 {
+		return 0, nil
+	}
+
+	t.mu.Lock()
+	bestHeight := t.bestHeight
+	mainChainHash, known := t.mainChain[blockHeight]
+	t.mu.Unlock()
+
+	// We might not have received the first block notification yet, in
+	// which case we look up the tip ourselves.
+	if bestHeight == 0 {
+		height, err := t.cfg.ChainBridge.CurrentHeight(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("unable to fetch best height: %w",
+				err)
+		}
+
+		bestHeight = height
+	}
+
+	if blockHeight > bestHeight {
+		return 0, nil
+	}
+
+	if !known {
+		hash, err := t.cfg.ChainBridge.GetBlockHash(
+			ctx, int64(blockHeight),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("unable to fetch block hash at "+
+				"height %d: %w", blockHeight, err)
+		}
+
+		t.mu.Lock()
+		// Only cache the hash if no new block arrived in the
+		// meantime, as the lookup might have raced with a re-org.
+		if t.bestHeight == bestHeight {
+			t.mainChain[blockHeight] = hash
+		}
+		t.mu.Unlock()
+
+		mainChainHash = hash
+	}
+
+	// The block was re-organized out of the main chain, so the
+	// transaction is no longer confirmed.
+	if mainChainHash != blockHash {
+		return 0, nil
+	}
+
+	return bestHeight - blockHeight + 1, nil
+}
+
+// reportErr reports an error to the main server.
+func (t *ConfDepthTracker) reportErr(err error) {
+	select {
+	case t.cfg.ErrChan <- err:
+	case <-t.Quit:
+	}
+}
@@ -0,0 +1,94 @@
+package tapgarden
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMainChain is a mock chain bridge with a main chain whose blocks can be
+// replaced to simulate a re-org.
+type mockMainChain struct {
+	*MockChainBridge
+
+	sync.Mutex
+	blocks map[int64]chainhash.Hash
+}
+
+func (m *mockMainChain) GetBlockHash(_ context.Context,
+	height int64) (chainhash.Hash, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	return m.blocks[height], nil
+}
+
+func (m *mockMainChain) setBlock(height int64, hash chainhash.Hash) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.blocks[height] = hash
+}
+
+// TestConfDepthTracker tests that the confirmation depth tracker reports the
+// depth of blocks on the main chain and drops the depth of blocks that were
+// re-organized out to zero.
+func TestConfDepthTracker(t *testing.T) {
+	t.Parallel()
+
+	chain := &mockMainChain{
+		MockChainBridge: NewMockChainBridge(),
+		blocks:          make(map[int64]chainhash.Hash),
+	}
+	tracker := NewConfDepthTracker(&ConfDepthTrackerConfig{
+		ChainBridge: chain,
+		ErrChan:     make(chan error, 1),
+	})
+	require.NoError(t, tracker.Start())
+	t.Cleanup(func() {
+		require.NoError(t, tracker.Stop())
+	})
+
+	ctx := context.Background()
+	blockHash := test.RandHash()
+	chain.setBlock(98, blockHash)
+
+	// assertDepth waits until the tracker reports the expected depth of
+	// the block at height 98.
+	assertDepth := func(expected uint32) {
+		require.Eventually(t, func() bool {
+			depth, err := tracker.ConfDepth(ctx, blockHash, 98)
+			require.NoError(t, err)
+
+			return depth == expected
+		}, testTimeout, 10*time.Millisecond)
+	}
+
+	chain.NewBlocks <- 100
+	assertDepth(3)
+
+	chain.NewBlocks <- 101
+	assertDepth(4)
+
+	// Blocks above the tip and transactions without a block are not
+	// confirmed.
+	depth, err := tracker.ConfDepth(ctx, test.RandHash(), 102)
+	require.NoError(t, err)
+	require.Zero(t, depth)
+
+	depth, err = tracker.ConfDepth(ctx, blockHash, 0)
+	require.NoError(t, err)
+	require.Zero(t, depth)
+
+	// A re-org replaces the block. Once the next block arrives, the
+	// transaction is no longer confirmed.
+	chain.setBlock(98, test.RandHash())
+	chain.NewBlocks <- 102
+	assertDepth(0)
+}
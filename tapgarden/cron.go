@@ -0,0 +1,225 @@
+package tapgarden
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is a set of allowed values of a single field of a cron schedule,
+// stored as a bit mask.
+type cronField uint64
+
+// contains returns true if the given value is allowed by the field.
+func (f cronField) contains(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// cronFieldBounds are the inclusive bounds of the values of a cron field.
+type cronFieldBounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinutes  = cronFieldBounds{name: "minute", min: 0, max: 59}
+	cronHours    = cronFieldBounds{name: "hour", min: 0, max: 23}
+	cronDays     = cronFieldBounds{name: "day of month", min: 1, max: 31}
+	cronMonths   = cronFieldBounds{name: "month", min: 1, max: 12}
+	cronWeekdays = cronFieldBounds{name: "day of week", min: 0, max: 7}
+)
+
+// maxCronSearchYears is the number of years we search ahead for the next
+// activation of a cron schedule before giving up. Schedules such as
+// "0 0 30 2 *" never activate.
+const maxCronSearchYears = 5
+
+// cronSchedule is a parsed cron expression with the five standard fields
+// minute, hour, day of month, month and day of week. Each field accepts a
+// wildcard (*), single values, ranges (a-b), lists (a,b,c) and steps (*/n or
+// a-b/n). Both 0 and 7 denote Sunday. All times are evaluated in UTC.
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays cronField
+
+	// restrictedDays and restrictedWeekdays record whether the day of
+	// month and day of week fields aren't wildcards. If both are
+	// restricted, a day matches if either of the fields matches, as is
+	// customary for cron.
+	restrictedDays, restrictedWeekdays bool
+}
+
+// parseCronSchedule parses a cron expression of five space separated fields.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, "+
+			"got %d", len(fields))
+	}
+
+	var (
+		schedule cronSchedule
+		err      error
+	)
+	schedule.minutes, err = parseCronField(fields[0], cronMinutes)
+	if err != nil {
+		return nil, err
+	}
+	schedule.hours, err = parseCronField(fields[1], cronHours)
+	if err != nil {
+		return nil, err
+	}
+	schedule.days, err = parseCronField(fields[2], cronDays)
+	if err != nil {
+		return nil, err
+	}
+	schedule.months, err = parseCronField(fields[3], cronMonths)
+	if err != nil {
+		return nil, err
+	}
+	schedule.weekdays, err = parseCronField(fields[4], cronWeekdays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sunday can be given as both 0 and 7.
+	if schedule.weekdays.contains(7) {
+		schedule.weekdays |= 1
+	}
+
+	schedule.restrictedDays = !strings.HasPrefix(fields[2], "*")
+	schedule.restrictedWeekdays = !strings.HasPrefix(fields[4], "*")
+
+	return &schedule, nil
+}
+
+// parseCronField parses a single comma separated cron field.
+func parseCronField(field string, bounds cronFieldBounds) (cronField, error) {
+	var result cronField
+	for _, part := range strings.Split(field, ",") {
+		first, last, step, err := parseCronRange(part, bounds)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s field %q: %w",
+				bounds.name, field, err)
+		}
+
+		for value := first; value <= last; value += step {
+			result |= 1 << uint(value)
+		}
+	}
+
+	return result, nil
+}
+
+// parseCronRange parses a single range of a cron field, which is either a
+// wildcard, a value or a range of values, optionally followed by a step.
+func parseCronRange(part string,
+	bounds cronFieldBounds) (int, int, int, error) {
+
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step < 1 {
+			return 0, 0, 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	parseValue := func(s string) (int, error) {
+		value, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+		if value < bounds.min || value > bounds.max {
+			return 0, fmt.Errorf("value %d out of range [%d, %d]",
+				value, bounds.min, bounds.max)
+		}
+
+		return value, nil
+	}
+
+	switch {
+	case rangePart == "*":
+		return bounds.min, bounds.max, step, nil
+
+	case strings.Contains(rangePart, "-"):
+		firstPart, lastPart, _ := strings.Cut(rangePart, "-")
+		first, err := parseValue(firstPart)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		last, err := parseValue(lastPart)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if first > last {
+			return 0, 0, 0, fmt.Errorf("invalid range %q",
+				rangePart)
+		}
+
+		return first, last, step, nil
+
+	default:
+		value, err := parseValue(rangePart)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		// A single value with a step, like 5/15, means starting at the
+		// value up to the maximum.
+		if hasStep {
+			return value, bounds.max, step, nil
+		}
+
+		return value, value, 1, nil
+	}
+}
+
+// matchesDay returns true if the given day matches the day of month and day of
+// week fields of the schedule.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayMatch := s.days.contains(t.Day())
+	weekdayMatch := s.weekdays.contains(int(t.Weekday()))
+
+	if s.restrictedDays && s.restrictedWeekdays {
+		return dayMatch || weekdayMatch
+	}
+
+	return dayMatch && weekdayMatch
+}
+
+// Next returns the first activation of the schedule strictly after the given
+// time, or the zero time if the schedule doesn't activate within the next few
+// years.
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !s.months.contains(int(t.Month())):
+			t = time.Date(
+				t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC,
+			)
+
+		case !s.matchesDay(t):
+			t = time.Date(
+				t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0,
+				time.UTC,
+			)
+
+		case !s.hours.contains(t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+
+		case !s.minutes.contains(t.Minute()):
+			t = t.Add(time.Minute)
+
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
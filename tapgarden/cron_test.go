@@ -0,0 +1,93 @@
+package tapgarden
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCronSchedule tests the parsing of cron expressions and the calculation
+// of their next activation.
+func TestCronSchedule(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday, May 1st 2024, 10:30 UTC.
+	start := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		expr    string
+		next    time.Time
+		wantErr string
+	}{{
+		name: "every minute",
+		expr: "* * * * *",
+		next: time.Date(2024, 5, 1, 10, 31, 0, 0, time.UTC),
+	}, {
+		name: "top of every hour",
+		expr: "0 * * * *",
+		next: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		name: "every six hours",
+		expr: "15 */6 * * *",
+		next: time.Date(2024, 5, 1, 12, 15, 0, 0, time.UTC),
+	}, {
+		name: "list and range",
+		expr: "0,45 8-10 * * *",
+		next: time.Date(2024, 5, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		name: "weekly on sunday as 7",
+		expr: "0 0 * * 7",
+		next: time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "day of month or day of week",
+		expr: "0 0 3 * 4",
+		next: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "next year",
+		expr: "0 12 1 1 *",
+		next: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, {
+		name: "leap day",
+		expr: "0 0 29 2 *",
+		next: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		name: "never",
+		expr: "0 0 30 2 *",
+		next: time.Time{},
+	}, {
+		name:    "too few fields",
+		expr:    "* * * *",
+		wantErr: "must have 5 fields",
+	}, {
+		name:    "out of range",
+		expr:    "60 * * * *",
+		wantErr: "out of range",
+	}, {
+		name:    "invalid step",
+		expr:    "*/0 * * * *",
+		wantErr: "invalid step",
+	}, {
+		name:    "inverted range",
+		expr:    "* 10-8 * * *",
+		wantErr: "invalid range",
+	}, {
+		name:    "not a number",
+		expr:    "* * * jan *",
+		wantErr: "invalid value",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(tc.expr)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.next, schedule.Next(start))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tapgarden
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,14 +9,22 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/alert"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tracing"
+	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AssetReceiveEvent is an event that is sent to a subscriber once the
@@ -43,6 +52,22 @@ type AssetReceiveEvent struct {
 	// Error is an optional error, indicating that something went wrong
 	// during the execution of the Status above.
 	Error error
+
+	// ProofRetrievalAttempt is the number of the failed attempt to
+	// retrieve the proof from the proof courier that this event reports.
+	// It is zero for events that report a status update.
+	ProofRetrievalAttempt int64
+
+	// ProofRetrievalBackoff is the time we wait before the next attempt to
+	// retrieve the proof. This is only set if ProofRetrievalAttempt is
+	// set.
+	ProofRetrievalBackoff time.Duration
+}
+
+// IsProofRetrievalAttempt returns true if the event reports a failed attempt
+// to retrieve the proof from the proof courier instead of a status update.
+func (e *AssetReceiveEvent) IsProofRetrievalAttempt() bool {
+	return e.ProofRetrievalAttempt != 0
 }
 
 // Timestamp returns the timestamp of the event.
@@ -78,6 +103,36 @@ func NewAssetReceiveErrorEvent(err error, addr address.Tap,
 	}
 }
 
+// NewAssetReceiveAttemptEvent creates a new AssetReceiveEvent that reports a
+// failed attempt to retrieve the proof from the proof courier.
+func NewAssetReceiveAttemptEvent(addr address.Tap, outpoint wire.OutPoint,
+	confHeight uint32,
+	backoffEvent *proof.BackoffWaitEvent) *AssetReceiveEvent {
+
+	return &AssetReceiveEvent{
+		timestamp:             backoffEvent.Timestamp(),
+		Address:               addr,
+		OutPoint:              outpoint,
+		Status:                address.StatusTransactionConfirmed,
+		ConfirmationHeight:    confHeight,
+		Error:                 backoffEvent.Error,
+		ProofRetrievalAttempt: backoffEvent.TriesCounter,
+		ProofRetrievalBackoff: backoffEvent.Backoff,
+	}
+}
+
+// ReceivedProofPusher is used to push the proofs of assets we received to the
+// universe servers of our own federation.
+type ReceivedProofPusher interface {
+	// PushProofLeaf inserts the given proof leaf into the local universe
+	// and pushes it out to all federation servers except for the given
+	// ones to skip. The push attempt is logged and retried until it
+	// succeeds.
+	PushProofLeaf(ctx context.Context, id universe.Identifier,
+		key universe.LeafKey, leaf *universe.Leaf,
+		skipServers ...universe.ServerAddr) (*universe.Proof, error)
+}
+
 // CustodianConfig houses all the items that the Custodian needs to carry out
 // its duties.
 type CustodianConfig struct {
@@ -124,6 +179,16 @@ type CustodianConfig struct {
 	// to be confirmed safely with a minimum number of confirmations.
 	ProofWatcher proof.Watcher
 
+	// AlertSender is used to raise an alert if a proof for an asset sent
+	// to us fails to verify. This is optional.
+	AlertSender alert.Sender
+
+	// ProofPusher is used to push the proofs of assets we received to the
+	// universe servers of our own federation, so spending the assets later
+	// on doesn't depend on the proof courier of the sender. If this is
+	// nil, received proofs are not pushed.
+	ProofPusher ReceivedProofPusher
+
 	// ErrChan is the main error channel the custodian will report back
 	// critical errors to the main server.
 	ErrChan chan<- error
@@ -159,6 +224,27 @@ type Custodian struct {
 	// address events of inbound assets.
 	events map[wire.OutPoint]*address.Event
 
+	// courierReceives is a map of the cancel functions of all ongoing
+	// attempts to receive a proof through the proof couriers, keyed by the
+	// outpoint of the event they receive the proof for. A proof might
+	// also reach us through the proof notifier (e.g. if it was stored by
+	// another tapd instance using the same database), in which case we no
+	// longer need to ask the couriers for it.
+	courierReceives map[wire.OutPoint]context.CancelFunc
+
+	// courierReceivesMtx guards the courierReceives map.
+	courierReceivesMtx sync.Mutex
+
+	// reusableAddrs is a map of all reusable addresses, keyed by their
+	// base script key. Deposits to these addresses can't be detected by
+	// the wallet, as their on-chain output key is different for each
+	// deposit, so we scan new blocks for them instead.
+	reusableAddrs map[asset.SerializedKey]*address.AddrWithKeyInfo
+
+	// scanHeight is the height of the last block that was scanned for
+	// deposits to reusable addresses.
+	scanHeight uint32
+
 	// ContextGuard provides a wait group and main quit channel that can be
 	// used to create guarded contexts.
 	*fn.ContextGuard
@@ -178,6 +264,12 @@ func NewCustodian(cfg *CustodianConfig) *Custodian {
 		proofSubscription: proofSub,
 		statusEventsSubs:  statusEventsSubs,
 		events:            make(map[wire.OutPoint]*address.Event),
+		courierReceives: make(
+			map[wire.OutPoint]context.CancelFunc,
+		),
+		reusableAddrs: make(
+			map[asset.SerializedKey]*address.AddrWithKeyInfo,
+		),
 		ContextGuard: &fn.ContextGuard{
 			DefaultTimeout: DefaultTimeout,
 			Quit:           make(chan struct{}),
@@ -334,7 +426,7 @@ func (c *Custodian) watchInboundAssets() {
 			defer c.Wg.Done()
 
 			recErr := c.receiveProof(
-				event.Addr.Tap, event.Outpoint,
+				event.Addr, event.Outpoint,
 				event.ConfirmationHeight,
 			)
 			if recErr != nil {
@@ -378,16 +470,58 @@ func (c *Custodian) watchInboundAssets() {
 		}
 	}
 
+	// Deposits to reusable addresses are found by scanning new blocks. We
+	// load the reusable addresses before the first block arrives, so we
+	// don't skip any deposits while they're still being delivered by the
+	// address subscription.
+	ctxt, cancel = c.WithCtxQuit()
+	addrs, err := c.cfg.AddrBook.ListAddrs(ctxt, address.QueryParams{
+		UnmanagedOnly: true,
+	})
+	cancel()
+	if err != nil {
+		reportErr(err)
+		return
+	}
+	for idx := range addrs {
+		if addrs[idx].Reusable {
+			c.trackReusableAddr(&addrs[idx])
+		}
+	}
+
+	c.scanHeight = lastDetectHeight
+	chainBridge := c.cfg.ChainBridge
+	blockChan, blockErrChan, err := chainBridge.RegisterBlockEpochNtfn(
+		ctxStream,
+	)
+	if err != nil {
+		reportErr(err)
+		return
+	}
+
 	log.Infof("Starting main custodian event loop")
 	for {
 		var err error
 		select {
 		case newAddr := <-c.addrSubscription.NewItemCreated.ChanOut():
+			// Reusable addresses aren't imported into the wallet,
+			// as we never expect assets on their base script key.
+			if newAddr.Reusable {
+				c.trackReusableAddr(newAddr)
+				break
+			}
+
 			err = c.importAddrToWallet(newAddr)
 
 		case tx := <-newTxChan:
 			err = c.inspectWalletTx(&tx)
 
+		case height := <-blockChan:
+			err = c.scanReusableDeposits(uint32(height))
+
+		case err = <-blockErrChan:
+			break
+
 		case newProof := <-c.proofSubscription.NewItemCreated.ChanOut():
 			log.Tracef("New proof received from notifier")
 			err = c.mapProofToEvent(newProof)
@@ -467,7 +601,7 @@ func (c *Custodian) inspectWalletTx(walletTx *lndclient.Transaction) error {
 					defer c.Wg.Done()
 
 					recErr := c.receiveProof(
-						event.Addr.Tap, op,
+						event.Addr, op,
 						event.ConfirmationHeight,
 					)
 					if recErr != nil {
@@ -529,7 +663,7 @@ func (c *Custodian) inspectWalletTx(walletTx *lndclient.Transaction) error {
 			if recErr != nil {
 				c.publishSubscriberStatusEvent(
 					NewAssetReceiveErrorEvent(
-						recErr, *addr, op,
+						recErr, *addr.Tap, op,
 						event.ConfirmationHeight,
 						event.Status,
 					),
@@ -546,36 +680,75 @@ func (c *Custodian) inspectWalletTx(walletTx *lndclient.Transaction) error {
 
 // receiveProof attempts to receive a proof for the given address and outpoint
 // via the proof courier service.
-func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
-	confHeight uint32) error {
+func (c *Custodian) receiveProof(addr *address.AddrWithKeyInfo,
+	op wire.OutPoint, confHeight uint32) (err error) {
 
 	ctx, cancel := c.WithCtxQuitNoTimeout()
 	defer cancel()
 
+	// We register the receive attempt, so it can be aborted if the proof
+	// reaches us by other means in the meantime.
+	c.courierReceivesMtx.Lock()
+	c.courierReceives[op] = cancel
+	c.courierReceivesMtx.Unlock()
+	defer func() {
+		c.courierReceivesMtx.Lock()
+		delete(c.courierReceives, op)
+		c.courierReceivesMtx.Unlock()
+	}()
+
 	assetID := addr.AssetID
 
+	// We trace the whole proof retrieval and import, so it can be
+	// correlated with the trace of the sender through the anchor
+	// transaction.
+	attrs := append(
+		tracing.AnchorOutPoint(op),
+		tracing.AssetIDKey.String(assetID.String()),
+	)
+	ctx, span := tracer.Start(
+		ctx, "Custodian.receiveProof", trace.WithAttributes(attrs...),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	scriptKeyBytes := addr.ScriptKey.SerializeCompressed()
 	log.Debugf("Waiting to receive proof for script key %x", scriptKeyBytes)
 
 	c.publishSubscriberStatusEvent(NewAssetReceiveEvent(
-		*addr, op, confHeight, address.StatusTransactionConfirmed,
+		*addr.Tap, op, confHeight, address.StatusTransactionConfirmed,
 	))
 
-	// Initiate proof courier service handle from the proof courier address
-	// found in the Tap address.
-	courier, err := c.cfg.ProofCourierDispatcher.NewCourier(
-		ctx, &addr.ProofCourierAddr, true,
-	)
+	// Initiate a proof courier service handle for each of the proof
+	// courier addresses found in the Tap address. The sender might have
+	// delivered the proof to any of them, so we look for it at all of
+	// them.
+	couriers, err := c.newAddrCouriers(ctx, addr.Tap)
 	if err != nil {
-		return fmt.Errorf("unable to initiate proof courier service "+
-			"handle: %w", err)
+		return err
 	}
 
-	// Update courier handle events subscribers before attempting to
-	// retrieve proof.
-	c.statusEventsSubsMtx.Lock()
-	courier.SetSubscribers(c.statusEventsSubs)
-	c.statusEventsSubsMtx.Unlock()
+	// We forward the events of the couriers to our own subscribers, so we
+	// can report failed proof retrieval attempts in the context of the
+	// address and outpoint they belong to.
+	courierEvents := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
+	forwardDone := make(chan struct{})
+	defer func() {
+		close(forwardDone)
+		courierEvents.Stop()
+	}()
+
+	for _, courier := range couriers {
+		courier.SetSubscribers(map[uint64]*fn.EventReceiver[fn.Event]{
+			courierEvents.ID(): courierEvents,
+		})
+	}
+
+	c.Wg.Add(1)
+	go c.forwardCourierEvents(
+		courierEvents, forwardDone, addr.Tap, op, confHeight,
+	)
 
 	// Sleep to give the sender an opportunity to transfer the proof to the
 	// proof courier service. Without this delay our first attempt at
@@ -589,9 +762,10 @@ func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
 
 	// Attempt to receive proof via proof courier service.
 	recipient := proof.Recipient{
-		ScriptKey: &addr.ScriptKey,
-		AssetID:   assetID,
-		Amount:    addr.Amount,
+		ScriptKey:      &addr.ScriptKey,
+		AssetID:        assetID,
+		Amount:         addr.Amount,
+		ScriptKeyTweak: &addr.ScriptKeyTweak,
 	}
 	loc := proof.Locator{
 		AssetID:   &assetID,
@@ -599,7 +773,13 @@ func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
 		ScriptKey: addr.ScriptKey,
 		OutPoint:  &op,
 	}
-	addrProof, err := courier.ReceiveProof(ctx, recipient, loc)
+	addrProof, err := receiveFromCouriers(ctx, couriers, recipient, loc)
+	if err != nil && ctx.Err() != nil {
+		log.Debugf("Aborted receiving proof for script key %x from "+
+			"proof courier", scriptKeyBytes)
+
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("unable to receive proof using courier: %w",
 			err)
@@ -610,6 +790,7 @@ func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
 
 	ctx, cancel = c.CtxBlocking()
 	defer cancel()
+	ctx = trace.ContextWithSpan(ctx, span)
 
 	headerVerifier := GenHeaderVerifier(ctx, c.cfg.ChainBridge)
 	err = c.cfg.ProofArchive.ImportProofs(
@@ -617,10 +798,29 @@ func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
 		c.cfg.GroupVerifier, c.cfg.ChainBridge, false, addrProof,
 	)
 	if err != nil {
+		alert.SendIfSet(c.cfg.AlertSender, alert.Alert{
+			Type:     alert.TypeProofVerificationFailure,
+			Severity: alert.SeverityWarning,
+			Message: fmt.Sprintf("Unable to import received proof "+
+				"(script_key=%x, asset_id=%x, outpoint=%v): %v",
+				scriptKeyBytes, assetID[:], op, err),
+			DedupKey: op.String(),
+		})
+
 		return fmt.Errorf("unable to import proofs script_key=%x, "+
 			"asset_id=%x: %w", scriptKeyBytes, assetID[:], err)
 	}
 
+	// Now that the proof is verified, we make sure it is also available
+	// in our own federation. A failure to do so shouldn't prevent us from
+	// taking custody of the asset, so we only log it.
+	err = c.pushReceivedProof(ctx, addr.Tap, addrProof.Blob)
+	if err != nil {
+		log.Warnf("Unable to push received proof to federation "+
+			"(script_key=%x, asset_id=%x): %v", scriptKeyBytes,
+			assetID[:], err)
+	}
+
 	// The proof is now verified and in our local archive. We will now
 	// finalize handling the proof like we would with any other newly
 	// received proof.
@@ -629,11 +829,201 @@ func (c *Custodian) receiveProof(addr *address.Tap, op wire.OutPoint,
 	return nil
 }
 
+// newAddrCouriers creates a proof courier for each of the proof courier
+// addresses of the given address, in order of their priority. A fallback
+// courier that can't be created is skipped, as long as there is at least one
+// courier left.
+func (c *Custodian) newAddrCouriers(ctx context.Context,
+	addr *address.Tap) ([]proof.Courier, error) {
+
+	var (
+		couriers []proof.Courier
+		errs     []error
+	)
+	for _, courierAddr := range addr.ProofCourierAddrs() {
+		courier, err := c.cfg.ProofCourierDispatcher.NewCourier(
+			ctx, &courierAddr, true,
+		)
+		if err != nil {
+			log.Warnf("Unable to initiate proof courier service "+
+				"handle for %v: %v", courierAddr.String(), err)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		couriers = append(couriers, courier)
+	}
+
+	if len(couriers) == 0 {
+		return nil, fmt.Errorf("unable to initiate proof courier "+
+			"service handle: %w", errors.Join(errs...))
+	}
+
+	return couriers, nil
+}
+
+// receiveFromCouriers attempts to receive the proof identified by the given
+// locator from all given proof couriers concurrently. The first proof that is
+// received is returned and the attempts of the remaining couriers are
+// canceled. An error is only returned if none of the couriers delivered the
+// proof.
+func receiveFromCouriers(ctx context.Context, couriers []proof.Courier,
+	recipient proof.Recipient,
+	loc proof.Locator) (*proof.AnnotatedProof, error) {
+
+	if len(couriers) == 1 {
+		return couriers[0].ReceiveProof(ctx, recipient, loc)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		proof *proof.AnnotatedProof
+		err   error
+	}
+
+	// The result channel is buffered, so the goroutines of the couriers
+	// we no longer wait for don't block once they're canceled.
+	results := make(chan result, len(couriers))
+	for _, courier := range couriers {
+		go func(courier proof.Courier) {
+			p, err := courier.ReceiveProof(ctx, recipient, loc)
+			results <- result{proof: p, err: err}
+		}(courier)
+	}
+
+	var errs []error
+	for range couriers {
+		res := <-results
+		if res.err == nil {
+			return res.proof, nil
+		}
+
+		errs = append(errs, res.err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// forwardCourierEvents forwards the events of a proof courier that receives
+// the proof for the given address and outpoint to all status event
+// subscribers. For every backoff wait of a proof retrieval, an additional
+// receive event is published that reports the failed attempt. The forwarding
+// stops once the done channel is closed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *Custodian) forwardCourierEvents(events *fn.EventReceiver[fn.Event],
+	done <-chan struct{}, addr *address.Tap, op wire.OutPoint,
+	confHeight uint32) {
+
+	defer c.Wg.Done()
+
+	for {
+		select {
+		case event := <-events.NewItemCreated.ChanOut():
+			c.publishSubscriberStatusEvent(event)
+
+			backoffEvent, ok := event.(*proof.BackoffWaitEvent)
+			if !ok {
+				continue
+			}
+
+			transferType := backoffEvent.TransferType
+			if transferType != proof.ReceiveTransferType {
+				continue
+			}
+
+			c.publishSubscriberStatusEvent(
+				NewAssetReceiveAttemptEvent(
+					*addr, op, confHeight, backoffEvent,
+				),
+			)
+
+		case <-done:
+			return
+
+		case <-c.Quit:
+			return
+		}
+	}
+}
+
+// pushReceivedProof pushes all proofs of the given proof file to the universe
+// servers of our own federation, starting with the issuance proof so that each
+// transfer proof can be verified against its predecessor. The proof courier
+// of the address we received the asset on is skipped if it is a universe
+// server, since the sender already delivered the proof there.
+func (c *Custodian) pushReceivedProof(ctx context.Context, addr *address.Tap,
+	proofBlob proof.Blob) error {
+
+	if c.cfg.ProofPusher == nil {
+		return nil
+	}
+
+	var skipServers []universe.ServerAddr
+	courierAddr := addr.ProofCourierAddr
+	if courierAddr.Scheme == proof.UniverseRpcCourierType {
+		skipServers = append(
+			skipServers,
+			universe.NewServerAddrFromStr(courierAddr.Host),
+		)
+	}
+
+	var proofFile proof.File
+	err := proofFile.Decode(bytes.NewReader(proofBlob))
+	if err != nil {
+		return fmt.Errorf("unable to decode proof file: %w", err)
+	}
+
+	for idx := 0; idx < proofFile.NumProofs(); idx++ {
+		p, err := proofFile.ProofAt(uint32(idx))
+		if err != nil {
+			return fmt.Errorf("unable to fetch proof %d: %w", idx,
+				err)
+		}
+
+		var proofBuf bytes.Buffer
+		if err := p.Encode(&proofBuf); err != nil {
+			return fmt.Errorf("unable to encode proof: %w", err)
+		}
+
+		uniID := universe.NewUniIDFromAsset(p.Asset)
+		leafKey := universe.LeafKey{
+			OutPoint:  p.OutPoint(),
+			ScriptKey: &p.Asset.ScriptKey,
+		}
+		leaf := &universe.Leaf{
+			GenesisWithGroup: universe.GenesisWithGroup{
+				Genesis:  p.Asset.Genesis,
+				GroupKey: p.Asset.GroupKey,
+			},
+			RawProof: proofBuf.Bytes(),
+			Amt:      p.Asset.Amount,
+			Asset:    &p.Asset,
+		}
+
+		_, err = c.cfg.ProofPusher.PushProofLeaf(
+			ctx, uniID, leafKey, leaf, skipServers...,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to push proof %d: %w", idx,
+				err)
+		}
+	}
+
+	log.Debugf("Pushed %d received proofs to federation (skipping %d "+
+		"servers)", proofFile.NumProofs(), len(skipServers))
+
+	return nil
+}
+
 // mapToTapAddr attempts to match a transaction output to a Taproot Asset
 // address. If a matching address is found, an event is created for it. If an
 // event already exists, it is updated with the current transaction information.
 func (c *Custodian) mapToTapAddr(walletTx *lndclient.Transaction,
-	outputIdx uint32, op wire.OutPoint) (*address.Tap, error) {
+	outputIdx uint32, op wire.OutPoint) (*address.AddrWithKeyInfo, error) {
 
 	taprootKey, err := proof.ExtractTaprootKey(walletTx.Tx, outputIdx)
 	if err != nil {
@@ -710,7 +1100,7 @@ func (c *Custodian) mapToTapAddr(walletTx *lndclient.Transaction,
 	// Let's update our cache of ongoing events.
 	c.events[op] = event
 
-	return addr.Tap, nil
+	return addr, nil
 }
 
 // importAddrToWallet imports the given Taproot Asset address into the
@@ -751,6 +1141,219 @@ func (c *Custodian) importAddrToWallet(addr *address.AddrWithKeyInfo) error {
 	return c.cfg.AddrBook.SetAddrManaged(ctxt, addr, time.Now())
 }
 
+// trackReusableAddr adds the given reusable address to the set of addresses
+// new blocks are scanned for deposits to.
+func (c *Custodian) trackReusableAddr(addr *address.AddrWithKeyInfo) {
+	key := asset.ToSerialized(&addr.ScriptKey)
+	if _, ok := c.reusableAddrs[key]; ok {
+		return
+	}
+
+	log.Infof("Scanning for deposits to reusable address with base "+
+		"script key %x", key[:])
+
+	c.reusableAddrs[key] = addr
+}
+
+// scanReusableDeposits scans all blocks up to the given best height that
+// weren't scanned yet for deposits to our reusable addresses.
+func (c *Custodian) scanReusableDeposits(bestHeight uint32) error {
+	// Without any reusable addresses there's nothing to scan for, so we
+	// only move the scan height forward.
+	if len(c.reusableAddrs) == 0 {
+		c.scanHeight = bestHeight
+		return nil
+	}
+
+	// If we never scanned before, we start with the current block. In
+	// case of a re-org, we scan the new block at the best height again,
+	// which is safe since we never create the same event twice.
+	if c.scanHeight == 0 || c.scanHeight >= bestHeight {
+		c.scanHeight = bestHeight - 1
+	}
+
+	for height := c.scanHeight + 1; height <= bestHeight; height++ {
+		err := c.scanBlockForDeposits(height, bestHeight)
+		if err != nil {
+			return fmt.Errorf("unable to scan block %d for "+
+				"deposits: %w", height, err)
+		}
+
+		c.scanHeight = height
+	}
+
+	return nil
+}
+
+// scanBlockForDeposits scans the block at the given height for outputs that
+// commit to a deposit to one of our reusable addresses. Because the script key
+// of a deposit is derived from one of the inputs of the transfer and the index
+// of the anchor output, we try all inputs for each Taproot output.
+func (c *Custodian) scanBlockForDeposits(height, bestHeight uint32) error {
+	ctxt, cancel := c.WithCtxQuit()
+	defer cancel()
+
+	blockHash, err := c.cfg.ChainBridge.GetBlockHash(ctxt, int64(height))
+	if err != nil {
+		return fmt.Errorf("unable to fetch block hash: %w", err)
+	}
+	block, err := c.cfg.ChainBridge.GetBlock(ctxt, blockHash)
+	if err != nil {
+		return fmt.Errorf("unable to fetch block: %w", err)
+	}
+
+	for txIdx, tx := range block.Transactions {
+		// A coinbase transaction can't spend any assets.
+		if txIdx == 0 {
+			continue
+		}
+
+		for outIdx, txOut := range tx.TxOut {
+			if !txscript.IsPayToTaproot(txOut.PkScript) {
+				continue
+			}
+
+			taprootKey, err := proof.ExtractTaprootKey(
+				tx, uint32(outIdx),
+			)
+			if err != nil {
+				return err
+			}
+
+			addr, prevOut, err := c.matchDeposit(
+				tx, uint32(outIdx), taprootKey,
+			)
+			if err != nil {
+				return err
+			}
+			if addr == nil {
+				continue
+			}
+
+			err = c.receiveDeposit(
+				addr, prevOut, tx, uint32(outIdx), height,
+				bestHeight, blockHash,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchDeposit returns the reusable address and the input outpoint of the
+// given transaction that the output with the given Taproot key is a deposit
+// for. If the output isn't a deposit to any of our reusable addresses, nil is
+// returned.
+func (c *Custodian) matchDeposit(tx *wire.MsgTx, outIdx uint32,
+	taprootKey *btcec.PublicKey) (*address.AddrWithKeyInfo, wire.OutPoint,
+	error) {
+
+	targetKey := schnorr.SerializePubKey(taprootKey)
+	for _, addr := range c.reusableAddrs {
+		for _, txIn := range tx.TxIn {
+			prevOut := txIn.PreviousOutPoint
+			depositAddr, err := addr.DepositAddr(prevOut, outIdx)
+			if err != nil {
+				return nil, wire.OutPoint{}, err
+			}
+
+			outputKey, err := depositAddr.TaprootOutputKey()
+			if err != nil {
+				return nil, wire.OutPoint{}, err
+			}
+
+			if bytes.Equal(
+				schnorr.SerializePubKey(outputKey), targetKey,
+			) {
+
+				return addr, prevOut, nil
+			}
+		}
+	}
+
+	return nil, wire.OutPoint{}, nil
+}
+
+// receiveDeposit stores the address of a deposit to the given reusable address
+// that was found in a block, unless we already know it, and then processes the
+// transaction as if the wallet had detected the deposit output as its own.
+func (c *Custodian) receiveDeposit(addr *address.AddrWithKeyInfo,
+	prevOut wire.OutPoint, tx *wire.MsgTx, outIdx, height,
+	bestHeight uint32, blockHash chainhash.Hash) error {
+
+	depositScriptKey, err := addr.DepositScriptKey(prevOut, outIdx)
+	if err != nil {
+		return err
+	}
+	depositAddr, err := addr.DepositAddr(prevOut, outIdx)
+	if err != nil {
+		return err
+	}
+	outputKey, err := depositAddr.TaprootOutputKey()
+	if err != nil {
+		return err
+	}
+
+	// Block here, a shutdown can wait on this operation.
+	ctxt, cancel := c.CtxBlocking()
+	defer cancel()
+
+	_, err = c.cfg.AddrBook.AddrByTaprootOutput(ctxt, outputKey)
+	switch {
+	// This is a new deposit, so we store its address. This also imports
+	// the on-chain output key of the deposit into the wallet.
+	case errors.Is(err, address.ErrNoAddr):
+		log.Infof("Found deposit to reusable address %v in %v:%d", addr,
+			tx.TxHash(), outIdx)
+
+		_, err = c.cfg.AddrBook.NewAddressWithKeys(
+			ctxt, addr.Version, addr.AssetID, addr.Amount,
+			depositScriptKey, addr.InternalKeyDesc,
+			addr.TapscriptSibling, addr.ProofCourierAddr,
+			address.WithAssetVersion(addr.AssetVersion),
+			address.WithFallbackProofCourierAddrs(
+				addr.FallbackProofCourierAddrs...,
+			),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to store deposit address: %w",
+				err)
+		}
+
+	case err != nil:
+		return fmt.Errorf("unable to look up deposit address: %w", err)
+	}
+
+	// The wallet might only learn about the deposit output after the
+	// transaction confirmed, so we hand the transaction to the same logic
+	// that handles wallet transactions, marking the deposit output as
+	// ours.
+	walletTx := &lndclient.Transaction{
+		Tx:            tx,
+		TxHash:        tx.TxHash().String(),
+		Confirmations: int32(bestHeight-height) + 1,
+		BlockHash:     blockHash.String(),
+		BlockHeight:   int32(height),
+		OutputDetails: make([]*lnrpc.OutputDetail, len(tx.TxOut)),
+	}
+	const p2trType = lnrpc.OutputScriptType_SCRIPT_TYPE_WITNESS_V1_TAPROOT
+	for idx, txOut := range tx.TxOut {
+		walletTx.OutputDetails[idx] = &lnrpc.OutputDetail{
+			OutputIndex:  int64(idx),
+			Amount:       txOut.Value,
+			IsOurAddress: idx == int(outIdx),
+		}
+		if idx == int(outIdx) {
+			walletTx.OutputDetails[idx].OutputType = p2trType
+		}
+	}
+
+	return c.inspectWalletTx(walletTx)
+}
+
 // checkProofAvailable checks the proof storage if a proof for the given event
 // is already available. If it is, and it checks out, the event is updated.
 func (c *Custodian) checkProofAvailable(event *address.Event) (bool, error) {
@@ -980,6 +1583,15 @@ func (c *Custodian) assertProofInLocalArchive(p *proof.AnnotatedProof) error {
 			c.cfg.GroupVerifier, c.cfg.ChainBridge, false, p,
 		)
 		if err != nil {
+			alert.SendIfSet(c.cfg.AlertSender, alert.Alert{
+				Type:     alert.TypeProofVerificationFailure,
+				Severity: alert.SeverityCritical,
+				Message: fmt.Sprintf("Unable to import proof "+
+					"of owned asset (outpoint=%v): %v",
+					p.Locator.OutPoint, err),
+				DedupKey: fmt.Sprintf("%v", p.Locator.OutPoint),
+			})
+
 			return fmt.Errorf("error importing proof file into "+
 				"main archive: %w", err)
 		}
@@ -1026,6 +1638,16 @@ func (c *Custodian) setReceiveCompleted(event *address.Event,
 	// anymore.
 	delete(c.events, event.Outpoint)
 
+	// If we're still trying to receive the proof from the proof couriers,
+	// we can stop doing so now.
+	c.courierReceivesMtx.Lock()
+	if cancelReceive, ok := c.courierReceives[event.Outpoint]; ok {
+		log.Debugf("Proof for %v received, aborting proof courier "+
+			"receive", event.Outpoint)
+		cancelReceive()
+	}
+	c.courierReceivesMtx.Unlock()
+
 	// At this point the "receive" process is complete. We will now notify
 	// all status event subscribers.
 	// At this point the "receive" process is complete. We will now notify
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -50,7 +53,9 @@ func newAddrBookForDB(db *tapdb.BaseDB, keyRing *tapgarden.MockKeyRing,
 
 	addrTx := tapdb.NewTransactionExecutor(db, txCreator)
 	testClock := clock.NewTestClock(time.Now())
-	tapdbBook := tapdb.NewTapAddressBook(addrTx, chainParams, testClock)
+	tapdbBook := tapdb.NewTapAddressBook(
+		addrTx, chainParams, testClock, tapdb.NoOpDBEventNotifier{},
+	)
 	book := address.NewBook(address.BookConfig{
 		Store:        tapdbBook,
 		Syncer:       syncer,
@@ -122,6 +127,7 @@ func newProofArchiveForDB(t *testing.T, db *tapdb.BaseDB) (*proof.MultiArchiver,
 	testClock := clock.NewTestClock(time.Now())
 	assetStore := tapdb.NewAssetStore(
 		assetDB, metaDB, testClock, db.Backend(),
+		tapdb.NoOpDBEventNotifier{}, nil,
 	)
 
 	proofArchive := proof.NewMultiArchiver(
@@ -146,6 +152,7 @@ type custodianHarness struct {
 	c            *tapgarden.Custodian
 	cfg          *tapgarden.CustodianConfig
 	errChan      chan error
+	db           *tapdb.BaseDB
 	chainBridge  *tapgarden.MockChainBridge
 	walletAnchor *tapgarden.MockWalletAnchor
 	keyRing      *tapgarden.MockKeyRing
@@ -179,6 +186,13 @@ func (h *custodianHarness) assertStartup() {
 	)
 	require.NoError(h.t, err)
 
+	// Make sure we register for new blocks on startup, to find deposits to
+	// reusable addresses.
+	_, err = fn.RecvOrTimeout(
+		h.chainBridge.BlockEpochSignal, testTimeout,
+	)
+	require.NoError(h.t, err)
+
 	// Make sure we don't have an error on startup.
 	select {
 	case err := <-h.errChan:
@@ -341,6 +355,7 @@ func newHarness(t *testing.T,
 		c:            tapgarden.NewCustodian(cfg),
 		cfg:          cfg,
 		errChan:      errChan,
+		db:           db.BaseDB,
 		chainBridge:  chainBridge,
 		walletAnchor: walletAnchor,
 		keyRing:      keyRing,
@@ -634,6 +649,86 @@ func TestBookAssetSyncer(t *testing.T) {
 	close(quitAssetWatcher)
 }
 
+// TestBookInspectAddr makes sure that the address book decodes addresses for
+// other networks and unknown assets, reporting the problems as warnings.
+func TestBookInspectAddr(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t, nil)
+	ctx := context.Background()
+
+	// An address for a known asset on our own network should be decoded
+	// without any warnings.
+	addr, genesis := randAddr(h)
+	encoded, err := addr.EncodeAddress()
+	require.NoError(t, err)
+
+	inspection, err := h.addrBook.InspectAddr(ctx, encoded)
+	require.NoError(t, err)
+	require.Empty(t, inspection.Warnings)
+	require.Equal(t, chainParams.Name, inspection.Net.Name)
+	require.Equal(t, genesis.ID(), inspection.AssetGroup.Genesis.ID())
+	require.Equal(t, addr.ScriptKey, inspection.Addr.ScriptKey)
+
+	// The same address for a different network should still be decoded,
+	// but with a warning.
+	otherNetAddr := addr.Tap.Copy()
+	otherNetAddr.ChainParams = &address.TestNet3Tap
+	encoded, err = otherNetAddr.EncodeAddress()
+	require.NoError(t, err)
+
+	inspection, err = h.addrBook.InspectAddr(ctx, encoded)
+	require.NoError(t, err)
+	require.Len(t, inspection.Warnings, 1)
+	require.Contains(t, inspection.Warnings[0], "network")
+	require.Equal(
+		t, address.TestNet3Tap.Name, inspection.Net.Name,
+	)
+	require.NotNil(t, inspection.AssetGroup)
+
+	// An expired address for an asset that is neither known locally nor
+	// to the asset syncer should result in two warnings.
+	newAsset := asset.RandAsset(t, asset.Normal)
+	newAsset.GroupKey = nil
+	expiredAddr, err := address.New(
+		address.V2, newAsset.Genesis, nil, nil, *test.RandPubKey(t),
+		*test.RandPubKey(t), 1, nil, chainParams,
+		address.RandProofCourierAddr(t),
+		address.WithExpiry(time.Now().Add(-time.Hour)),
+	)
+	require.NoError(t, err)
+	encoded, err = expiredAddr.EncodeAddress()
+	require.NoError(t, err)
+
+	inspection, err = h.addrBook.InspectAddr(ctx, encoded)
+	require.NoError(t, err)
+	require.Len(t, inspection.Warnings, 2)
+	require.Contains(t, inspection.Warnings[0], "expired")
+	require.Contains(t, inspection.Warnings[1], "unknown")
+	require.Nil(t, inspection.AssetGroup)
+
+	// Once the asset syncer knows about the asset, we should fetch its
+	// genesis from the universe.
+	quitAssetWatcher := make(chan struct{})
+	defer close(quitAssetWatcher)
+	insertAssetInfo(
+		t, ctx, quitAssetWatcher, h.tapdbBook, h.syncer,
+	)
+	h.syncer.AddAsset(*newAsset)
+
+	inspection, err = h.addrBook.InspectAddr(ctx, encoded)
+	require.NoError(t, err)
+	require.Len(t, inspection.Warnings, 1)
+	require.Equal(t, newAsset.ID(), inspection.AssetGroup.Genesis.ID())
+
+	// Garbage and addresses for unknown networks should be rejected.
+	_, err = h.addrBook.InspectAddr(ctx, "not an address")
+	require.ErrorIs(t, err, address.ErrInvalidBech32m)
+
+	_, err = h.addrBook.InspectAddr(ctx, "bc1"+encoded[6:])
+	require.ErrorIs(t, err, address.ErrUnsupportedHRP)
+}
+
 // TestTransactionHandling tests that the custodian correctly handles incoming
 // transactions.
 func TestTransactionHandling(t *testing.T) {
@@ -680,6 +775,62 @@ func TestTransactionHandling(t *testing.T) {
 	require.EqualValues(t, mockProof.Blob, dbProof)
 }
 
+// TestReceivedProofPush tests that the custodian pushes a received proof to
+// the universe servers of its own federation, skipping the universe server
+// that acted as the proof courier.
+func TestReceivedProofPush(t *testing.T) {
+	h := newHarness(t, nil)
+
+	pusher := &tapgarden.MockReceivedProofPusher{}
+	h.cfg.ProofPusher = pusher
+	h.c = tapgarden.NewCustodian(h.cfg)
+
+	// The address uses a universe server as its proof courier, which
+	// already has the proof once the sender delivered it.
+	ctx := context.Background()
+	addr, genesis := randAddr(h)
+	addr.ProofCourierAddr = url.URL{
+		Scheme: proof.UniverseRpcCourierType,
+		Host:   "universe.example.com:10029",
+	}
+	err := h.tapdbBook.InsertAddrs(ctx, *addr)
+	require.NoError(t, err)
+
+	outputIdx, tx := randWalletTx(addr)
+	tx.Confirmations = 1
+	h.walletAnchor.Transactions = append(h.walletAnchor.Transactions, *tx)
+
+	mockProof := randProof(t, outputIdx, tx.Tx, genesis, addr)
+	err = h.courier.DeliverProof(nil, proof.Recipient{}, mockProof)
+	require.NoError(t, err)
+
+	require.NoError(t, h.c.Start())
+	t.Cleanup(func() {
+		require.NoError(t, h.c.Stop())
+	})
+	h.assertStartup()
+	h.assertAddrsRegistered(addr)
+	h.assertEventsPresent(1, address.StatusCompleted)
+
+	// The proof file only contains the issuance proof, which should've
+	// been pushed to all servers but the proof courier.
+	pushed := pusher.Pushed()
+	require.Len(t, pushed, 1)
+
+	pushedLeaf := pushed[0]
+	require.Equal(t, universe.ProofTypeIssuance, pushedLeaf.ID.ProofType)
+	require.Equal(t, *mockProof.Locator.OutPoint, pushedLeaf.Key.OutPoint)
+	require.Equal(
+		t, addr.ScriptKey.SerializeCompressed(),
+		pushedLeaf.Key.ScriptKey.PubKey.SerializeCompressed(),
+	)
+	require.Equal(t, addr.Amount, pushedLeaf.Leaf.Amt)
+
+	require.Len(t, pushedLeaf.SkipServers, 1)
+	skipServer := pushedLeaf.SkipServers[0]
+	require.Equal(t, addr.ProofCourierAddr.Host, skipServer.HostStr())
+}
+
 // TestTransactionConfirmedOnly tests that the custodian only starts the proof
 // courier once a transaction has been confirmed. We also test that it correctly
 // re-tries fetching proofs using a proof courier after it has been restarted.
@@ -819,6 +970,388 @@ func TestProofInMultiverseOnly(t *testing.T) {
 	h.assertStartup()
 }
 
+// failingCourier is a proof courier that never finds a proof, but reports a
+// backoff wait event for its single failed retrieval attempt.
+type failingCourier struct {
+	*proof.MockProofCourier
+
+	subscribers map[uint64]*fn.EventReceiver[fn.Event]
+}
+
+// SetSubscribers sets the set of subscribers that will be notified of proof
+// courier related events.
+func (f *failingCourier) SetSubscribers(
+	subscribers map[uint64]*fn.EventReceiver[fn.Event]) {
+
+	f.subscribers = subscribers
+}
+
+// ReceiveProof reports a failed retrieval attempt and returns an error.
+func (f *failingCourier) ReceiveProof(context.Context, proof.Recipient,
+	proof.Locator) (*proof.AnnotatedProof, error) {
+
+	event := proof.NewBackoffWaitEvent(
+		time.Second, 1, proof.ReceiveTransferType,
+		proof.ErrProofNotFound,
+	)
+	for _, sub := range f.subscribers {
+		sub.NewItemCreated.ChanIn() <- event
+	}
+
+	return nil, proof.ErrProofNotFound
+}
+
+// TestProofRetrievalAttemptEvents tests that failed attempts to retrieve a
+// proof from the proof courier are reported to subscribers in the context of
+// the address they belong to.
+func TestProofRetrievalAttemptEvents(t *testing.T) {
+	h := newHarness(t, nil)
+	h.cfg.ProofCourierDispatcher = &proof.MockProofCourierDispatcher{
+		Courier: &failingCourier{
+			MockProofCourier: proof.NewMockProofCourier(),
+		},
+	}
+	h.c = tapgarden.NewCustodian(h.cfg)
+
+	ctx := context.Background()
+	addr, _ := randAddr(h)
+	err := h.tapdbBook.InsertAddrs(ctx, *addr)
+	require.NoError(t, err)
+
+	receiver := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
+	t.Cleanup(receiver.Stop)
+	require.NoError(t, h.c.RegisterSubscriber(receiver, false, time.Time{}))
+
+	require.NoError(t, h.c.Start())
+	t.Cleanup(func() {
+		require.NoError(t, h.c.Stop())
+	})
+	h.assertStartup()
+	h.assertAddrsRegistered(addr)
+
+	outputIdx, tx := randWalletTx(addr)
+	tx.Confirmations = 1
+	h.walletAnchor.SubscribeTx <- *tx
+
+	// We expect the raw backoff event to be forwarded, followed by the
+	// receive event that reports the failed attempt.
+	var (
+		backoffEvent *proof.BackoffWaitEvent
+		attemptEvent *tapgarden.AssetReceiveEvent
+	)
+	for attemptEvent == nil {
+		select {
+		case event := <-receiver.NewItemCreated.ChanOut():
+			switch e := event.(type) {
+			case *proof.BackoffWaitEvent:
+				backoffEvent = e
+
+			case *tapgarden.AssetReceiveEvent:
+				if e.IsProofRetrievalAttempt() {
+					attemptEvent = e
+				}
+			}
+
+		case <-time.After(testTimeout):
+			t.Fatalf("no proof retrieval attempt event received")
+		}
+	}
+
+	require.NotNil(t, backoffEvent)
+	require.Equal(t, addr.Tap.ScriptKey, attemptEvent.Address.ScriptKey)
+	require.Equal(t, tx.Tx.TxHash(), attemptEvent.OutPoint.Hash)
+	require.EqualValues(t, outputIdx, attemptEvent.OutPoint.Index)
+	require.Equal(
+		t, address.StatusTransactionConfirmed, attemptEvent.Status,
+	)
+	require.EqualValues(t, 1, attemptEvent.ProofRetrievalAttempt)
+	require.Equal(t, time.Second, attemptEvent.ProofRetrievalBackoff)
+	require.ErrorIs(t, attemptEvent.Error, proof.ErrProofNotFound)
+}
+
+// blockingCourier is a proof courier that never finds a proof, but blocks
+// until the attempt to receive it is canceled. The same courier might be used
+// for multiple proof courier addresses.
+type blockingCourier struct {
+	*proof.MockProofCourier
+
+	// started is closed once the first receive attempt started.
+	started     chan struct{}
+	startedOnce sync.Once
+
+	// canceled is closed once the first receive attempt was canceled.
+	canceled     chan struct{}
+	canceledOnce sync.Once
+}
+
+// ReceiveProof blocks until the given context is canceled.
+func (b *blockingCourier) ReceiveProof(ctx context.Context, _ proof.Recipient,
+	_ proof.Locator) (*proof.AnnotatedProof, error) {
+
+	b.startedOnce.Do(func() {
+		close(b.started)
+	})
+	<-ctx.Done()
+	b.canceledOnce.Do(func() {
+		close(b.canceled)
+	})
+
+	return nil, ctx.Err()
+}
+
+// TestProofFromDBEvent tests that the custodian completes an inbound transfer
+// with a proof that was stored by another tapd instance using the same
+// database, once the DB event announcing the proof is received. The ongoing
+// attempt to receive the proof from the proof courier is then aborted.
+func TestProofFromDBEvent(t *testing.T) {
+	h := newHarness(t, nil)
+	courier := &blockingCourier{
+		MockProofCourier: proof.NewMockProofCourier(),
+		started:          make(chan struct{}),
+		canceled:         make(chan struct{}),
+	}
+	h.cfg.ProofCourierDispatcher = &proof.MockProofCourierDispatcher{
+		Courier: courier,
+	}
+
+	dbEvents := fn.NewEventDistributor[*tapdb.DBEvent]()
+	dbNotifier := tapdb.NewDBEventProofNotifier(dbEvents, h.assetDB)
+	require.NoError(t, dbNotifier.Start())
+	t.Cleanup(func() {
+		require.NoError(t, dbNotifier.Stop())
+	})
+
+	h.cfg.ProofNotifier = proof.NewMultiArchiveNotifier(
+		h.assetDB, h.multiverse, dbNotifier,
+	)
+	h.c = tapgarden.NewCustodian(h.cfg)
+
+	ctx := context.Background()
+	addr, genesis := randAddr(h)
+	err := h.tapdbBook.InsertAddrs(ctx, *addr)
+	require.NoError(t, err)
+
+	require.NoError(t, h.c.Start())
+	t.Cleanup(func() {
+		require.NoError(t, h.c.Stop())
+	})
+	h.assertStartup()
+	h.assertAddrsRegistered(addr)
+
+	// A confirmed transaction makes the custodian ask the proof courier
+	// for the proof, which it doesn't have.
+	outputIdx, tx := randWalletTx(addr)
+	tx.Confirmations = 1
+	h.walletAnchor.SubscribeTx <- *tx
+	h.assertEventsPresent(1, address.StatusTransactionConfirmed)
+
+	_, err = fn.RecvOrTimeout(courier.started, testTimeout)
+	require.NoError(t, err)
+
+	// Another tapd instance now stores the proof in the shared database,
+	// which we simulate with a second asset store. The custodian isn't
+	// notified about it in-process.
+	_, otherStore, _ := newProofArchiveForDB(t, h.db)
+	otherArchive := proof.NewMultiArchiver(
+		newMockVerifier(t), testTimeout, otherStore,
+	)
+	mockProof := randProof(t, outputIdx, tx.Tx, genesis, addr)
+	err = otherArchive.ImportProofs(
+		ctx, proof.MockHeaderVerifier, proof.MockMerkleVerifier,
+		proof.MockGroupVerifier, proof.MockChainLookup, false,
+		mockProof,
+	)
+	require.NoError(t, err)
+
+	// Once the DB event announcing the proof is received, the transfer is
+	// completed and the proof courier no longer asked for the proof.
+	payload, err := json.Marshal(&tapdb.NewProofPayload{
+		AssetID: genesis.ID().String(),
+		ScriptKey: hex.EncodeToString(
+			addr.ScriptKey.SerializeCompressed(),
+		),
+		OutPoint: mockProof.Locator.OutPoint.String(),
+	})
+	require.NoError(t, err)
+	dbEvents.NotifySubscribers(&tapdb.DBEvent{
+		Channel: tapdb.DBEventChannelNewProof,
+		Payload: payload,
+	})
+
+	h.assertEventsPresent(1, address.StatusCompleted)
+
+	_, err = fn.RecvOrTimeout(courier.canceled, testTimeout)
+	require.NoError(t, err)
+
+	select {
+	case err := <-h.errChan:
+		t.Fatalf("unexpected custodian error: %v", err)
+
+	default:
+	}
+}
+
+// addrCourierDispatcher is a proof courier dispatcher that returns a different
+// proof courier for each proof courier address scheme.
+type addrCourierDispatcher struct {
+	couriers map[string]proof.Courier
+}
+
+// NewCourier returns the proof courier for the scheme of the given address.
+func (d *addrCourierDispatcher) NewCourier(_ context.Context, addr *url.URL,
+	_ bool) (proof.Courier, error) {
+
+	courier, ok := d.couriers[addr.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unknown courier scheme %v", addr.Scheme)
+	}
+
+	return courier, nil
+}
+
+// TestFallbackCourierReceive tests that the custodian receives a proof from a
+// fallback proof courier of a V2 address if the main proof courier doesn't
+// have it.
+func TestFallbackCourierReceive(t *testing.T) {
+	h := newHarness(t, nil)
+	h.cfg.ProofCourierDispatcher = &addrCourierDispatcher{
+		couriers: map[string]proof.Courier{
+			"mock": &failingCourier{
+				MockProofCourier: proof.NewMockProofCourier(),
+			},
+			"fallback": h.courier,
+		},
+	}
+	h.c = tapgarden.NewCustodian(h.cfg)
+
+	ctx := context.Background()
+	addr, genesis := randAddr(h)
+
+	// We turn the address into a V2 address with a fallback courier, which
+	// means we also need to re-derive its Taproot output key.
+	addr.Version = address.V2
+	addr.FallbackProofCourierAddrs = []url.URL{{Scheme: "fallback"}}
+	addr.Expiry = time.Time{}
+	outputKey, err := addr.Tap.TaprootOutputKey()
+	require.NoError(t, err)
+	addr.TaprootOutputKey = *outputKey
+
+	require.NoError(t, h.tapdbBook.InsertAddrs(ctx, *addr))
+
+	outputIdx, tx := randWalletTx(addr)
+	tx.Confirmations = 1
+	h.walletAnchor.Transactions = append(h.walletAnchor.Transactions, *tx)
+
+	mockProof := randProof(t, outputIdx, tx.Tx, genesis, addr)
+	err = h.courier.DeliverProof(nil, proof.Recipient{}, mockProof)
+	require.NoError(t, err)
+
+	require.NoError(t, h.c.Start())
+	t.Cleanup(func() {
+		require.NoError(t, h.c.Stop())
+	})
+	h.assertStartup()
+	h.assertAddrsRegistered(addr)
+
+	h.assertEventsPresent(1, address.StatusCompleted)
+
+	dbProof, err := h.assetDB.FetchProof(ctx, mockProof.Locator)
+	require.NoError(t, err)
+	require.EqualValues(t, mockProof.Blob, dbProof)
+}
+
+// TestReusableAddrDeposit tests that the custodian finds a deposit to a
+// reusable address by scanning new blocks, and receives the asset on the
+// script key derived for that deposit.
+func TestReusableAddrDeposit(t *testing.T) {
+	h := newHarness(t, nil)
+
+	ctx := context.Background()
+	addr, genesis := randAddr(h)
+
+	// We turn the address into a reusable V2 address, which means we also
+	// need to re-derive its Taproot output key.
+	addr.Version = address.V2
+	addr.Reusable = true
+	addr.FallbackProofCourierAddrs = nil
+	addr.Expiry = time.Time{}
+	outputKey, err := addr.Tap.TaprootOutputKey()
+	require.NoError(t, err)
+	addr.TaprootOutputKey = *outputKey
+
+	require.NoError(t, h.tapdbBook.InsertAddrs(ctx, *addr))
+
+	// The sender derives the script key of the deposit from the asset
+	// input it spends and the anchor output index of the deposit.
+	const outputIdx = 1
+	prevOut := wire.OutPoint{
+		Hash:  test.RandHash(),
+		Index: rand.Uint32(),
+	}
+	depositTap, err := addr.DepositAddr(prevOut, outputIdx)
+	require.NoError(t, err)
+	depositKey, err := depositTap.TaprootOutputKey()
+	require.NoError(t, err)
+	depositAddr := &address.AddrWithKeyInfo{
+		Tap:              depositTap,
+		TaprootOutputKey: *depositKey,
+	}
+
+	depositScript, err := tapscript.PayToTaprootScript(depositKey)
+	require.NoError(t, err)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: prevOut})
+	tx.AddTxOut(&wire.TxOut{
+		PkScript: test.RandBytes(34),
+		Value:    1000,
+	})
+	tx.AddTxOut(&wire.TxOut{
+		PkScript: depositScript,
+		Value:    1000,
+	})
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(&wire.TxIn{})
+	coinbase.AddTxOut(&wire.TxOut{
+		PkScript: depositScript,
+		Value:    1000,
+	})
+
+	const blockHeight = 100
+	h.chainBridge.AddBlock(blockHeight, &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase, tx},
+	})
+
+	mockProof := randProof(t, outputIdx, tx, genesis, depositAddr)
+	err = h.courier.DeliverProof(nil, proof.Recipient{}, mockProof)
+	require.NoError(t, err)
+
+	require.NoError(t, h.c.Start())
+	t.Cleanup(func() {
+		require.NoError(t, h.c.Stop())
+	})
+	h.assertStartup()
+
+	// The reusable address itself isn't imported into the wallet. Only
+	// once we find the deposit in a new block, its output key should be
+	// imported.
+	select {
+	case h.chainBridge.NewBlocks <- blockHeight:
+	case <-time.After(testTimeout):
+		t.Fatalf("timeout sending new block")
+	}
+	h.assertAddrsRegistered(depositAddr)
+
+	events := h.assertEventsPresent(1, address.StatusCompleted)
+	require.Equal(t, tx.TxHash(), events[0].Outpoint.Hash)
+	require.EqualValues(t, outputIdx, events[0].Outpoint.Index)
+
+	dbProof, err := h.assetDB.FetchProof(ctx, mockProof.Locator)
+	require.NoError(t, err)
+	require.EqualValues(t, mockProof.Blob, dbProof)
+}
+
 // TestAddrMatchesAsset tests that the AddrMatchesAsset function works
 // correctly.
 func TestAddrMatchesAsset(t *testing.T) {
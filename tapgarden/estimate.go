@@ -0,0 +1,193 @@
+package tapgarden
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// SeedlingEstimate is the preview of an asset that would be minted by a batch.
+type SeedlingEstimate struct {
+	// AssetName is the name of the asset.
+	AssetName string
+
+	// AssetType is the type of the asset.
+	AssetType asset.Type
+
+	// Amount is the amount of units that would be minted.
+	Amount uint64
+
+	// AssetID is the ID the asset would have if the batch was anchored at
+	// the estimated genesis outpoint.
+	AssetID asset.ID
+}
+
+// BatchEstimate is the result of a dry run of the finalization of a minting
+// batch. It describes the genesis transaction that would be broadcast, without
+// any of it being broadcast or persisted.
+type BatchEstimate struct {
+	// BatchKey is the internal key of the estimated batch.
+	BatchKey asset.SerializedKey
+
+	// Funded indicates whether the batch was already funded. If it was,
+	// the estimated genesis transaction is the one that will be broadcast.
+	// Otherwise, the wallet may select different inputs once the batch is
+	// actually funded, which changes the genesis outpoint and asset IDs.
+	Funded bool
+
+	// Weight is the estimated weight of the signed genesis transaction.
+	Weight lntypes.WeightUnit
+
+	// VSize is the estimated virtual size of the signed genesis
+	// transaction.
+	VSize lntypes.VByte
+
+	// FeeRate is the fee rate the genesis transaction was funded with. For
+	// a batch that was already funded, this is the effective fee rate of
+	// the funded transaction.
+	FeeRate chainfee.SatPerKWeight
+
+	// Fee is the on-chain fee paid by the genesis transaction.
+	Fee btcutil.Amount
+
+	// GenesisOutPoint is the first input of the genesis transaction, which
+	// all asset IDs of the batch commit to.
+	GenesisOutPoint wire.OutPoint
+
+	// AnchorOutputIndex is the index of the genesis transaction output that
+	// commits to the minted assets.
+	AnchorOutputIndex uint32
+
+	// ChangeOutputIndex is the index of the change output of the genesis
+	// transaction, or -1 if there is none.
+	ChangeOutputIndex int32
+
+	// Seedlings is the preview of the assets that would be minted, sorted
+	// by their name.
+	Seedlings []SeedlingEstimate
+}
+
+// estimateGenesisTxWeight returns the weight the given funded genesis
+// transaction will have once all of its inputs are signed by the wallet.
+func estimateGenesisTxWeight(
+	genesisPkt *tapsend.FundedPsbt) (lntypes.WeightUnit, error) {
+
+	var estimator input.TxWeightEstimator
+	for idx, pIn := range genesisPkt.Pkt.Inputs {
+		if pIn.WitnessUtxo == nil {
+			return 0, fmt.Errorf("input %d is missing its witness "+
+				"UTXO", idx)
+		}
+
+		pkScript := pIn.WitnessUtxo.PkScript
+		switch {
+		case txscript.IsPayToTaproot(pkScript):
+			estimator.AddTaprootKeySpendInput(pIn.SighashType)
+
+		case txscript.IsPayToWitnessPubKeyHash(pkScript):
+			estimator.AddP2WKHInput()
+
+		// The wallet only creates nested P2WKH outputs as P2SH
+		// outputs.
+		case txscript.IsPayToScriptHash(pkScript):
+			estimator.AddNestedP2WKHInput()
+
+		default:
+			return 0, fmt.Errorf("input %d has unsupported script "+
+				"type: %x", idx, pkScript)
+		}
+	}
+
+	// The anchor output still carries the dummy script, which has the
+	// same size as the final P2TR anchor script.
+	for _, txOut := range genesisPkt.Pkt.UnsignedTx.TxOut {
+		estimator.AddTxOutput(txOut)
+	}
+
+	return estimator.Weight(), nil
+}
+
+// genesisTxFee returns the on-chain fee paid by the given funded genesis
+// transaction.
+func genesisTxFee(genesisPkt *tapsend.FundedPsbt) (btcutil.Amount, error) {
+	var inputSum, outputSum btcutil.Amount
+	for idx, pIn := range genesisPkt.Pkt.Inputs {
+		if pIn.WitnessUtxo == nil {
+			return 0, fmt.Errorf("input %d is missing its witness "+
+				"UTXO", idx)
+		}
+
+		inputSum += btcutil.Amount(pIn.WitnessUtxo.Value)
+	}
+
+	for _, txOut := range genesisPkt.Pkt.UnsignedTx.TxOut {
+		outputSum += btcutil.Amount(txOut.Value)
+	}
+
+	if outputSum > inputSum {
+		return 0, fmt.Errorf("genesis outputs (%v) exceed inputs (%v)",
+			outputSum, inputSum)
+	}
+
+	return inputSum - outputSum, nil
+}
+
+// newBatchEstimate creates the estimate of the given batch being anchored in
+// the given funded genesis transaction. If the fee rate is zero, the effective
+// fee rate of the genesis transaction is used.
+func newBatchEstimate(batch *MintingBatch, genesisPkt *tapsend.FundedPsbt,
+	feeRate chainfee.SatPerKWeight) (*BatchEstimate, error) {
+
+	weight, err := estimateGenesisTxWeight(genesisPkt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to estimate genesis TX weight: "+
+			"%w", err)
+	}
+
+	fee, err := genesisTxFee(genesisPkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if feeRate == 0 {
+		feeRate = chainfee.NewSatPerKWeight(fee, weight)
+	}
+
+	genesisPoint := extractGenesisOutpoint(genesisPkt.Pkt.UnsignedTx)
+	anchorOutputIndex := extractAnchorOutputIndex(genesisPkt)
+
+	seedlings := make([]SeedlingEstimate, 0, len(batch.Seedlings))
+	for _, seedling := range batch.Seedlings {
+		genesis := seedling.Genesis(genesisPoint, anchorOutputIndex)
+		seedlings = append(seedlings, SeedlingEstimate{
+			AssetName: seedling.AssetName,
+			AssetType: seedling.AssetType,
+			Amount:    seedling.Amount,
+			AssetID:   genesis.ID(),
+		})
+	}
+	sort.Slice(seedlings, func(i, j int) bool {
+		return seedlings[i].AssetName < seedlings[j].AssetName
+	})
+
+	return &BatchEstimate{
+		BatchKey:          asset.ToSerialized(batch.BatchKey.PubKey),
+		Funded:            batch.IsFunded(),
+		Weight:            weight,
+		VSize:             weight.ToVB(),
+		FeeRate:           feeRate,
+		Fee:               fee,
+		GenesisOutPoint:   genesisPoint,
+		AnchorOutputIndex: anchorOutputIndex,
+		ChangeOutputIndex: genesisPkt.ChangeOutputIndex,
+		Seedlings:         seedlings,
+	}, nil
+}
@@ -0,0 +1,129 @@
+package tapgarden
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+)
+
+var (
+	// ErrGroupWitnessRequired is returned when a batch is sealed without
+	// a witness for a grouped seedling whose group internal key is held by
+	// an external signer.
+	ErrGroupWitnessRequired = errors.New("group internal key is held by " +
+		"an external signer, a group witness must be provided")
+)
+
+// GroupSigningRequest is a request for an external signer, such as an HSM, to
+// produce the asset group witness of a seedling whose group internal key isn't
+// held by the wallet.
+type GroupSigningRequest struct {
+	// AssetName is the name of the seedling the witness is for.
+	AssetName string
+
+	// PendingAssetGroup is the group key request and group virtual TX
+	// that must be signed.
+	PendingAssetGroup
+}
+
+// Psbt returns the group virtual TX of the request as a PSBT with a single
+// input, which spends the tweaked group key with the key spend path. The input
+// carries the BIP-0032 derivation of the group internal key, and the asset ID
+// as the single tweak that must be applied to the key before signing. The
+// signer is expected to set the Taproot key spend signature of the input.
+func (r *GroupSigningRequest) Psbt(coinType uint32) (*psbt.Packet, error) {
+	if r.RawKey.PubKey == nil {
+		return nil, fmt.Errorf("group internal key is missing")
+	}
+
+	pkt, err := psbt.NewFromUnsignedTx(r.Tx.Copy())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create group PSBT: %w", err)
+	}
+	if len(pkt.Inputs) != 1 {
+		return nil, fmt.Errorf("group virtual TX must have exactly " +
+			"one input")
+	}
+
+	bip32Deriv, trBip32Deriv := tappsbt.Bip32DerivationFromKeyDesc(
+		r.RawKey, coinType,
+	)
+	internalKey := input.TweakPubKeyWithTweak(r.RawKey.PubKey, r.GenID[:])
+
+	pIn := &pkt.Inputs[0]
+	pIn.WitnessUtxo = &wire.TxOut{
+		Value:    r.PrevOut.Value,
+		PkScript: bytes.Clone(r.PrevOut.PkScript),
+	}
+	pIn.SighashType = txscript.SigHashDefault
+	pIn.Bip32Derivation = []*psbt.Bip32Derivation{bip32Deriv}
+	pIn.TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{
+		trBip32Deriv,
+	}
+	pIn.TaprootInternalKey = schnorr.SerializePubKey(internalKey)
+	pIn.TaprootMerkleRoot = bytes.Clone(r.TapscriptRoot)
+	pIn.Unknowns = append(pIn.Unknowns, &psbt.Unknown{
+		Key:   btcwallet.PsbtKeyTypeInputSignatureTweakSingle,
+		Value: bytes.Clone(r.GenID[:]),
+	})
+
+	return pkt, nil
+}
+
+// GroupWitnessFromPsbt extracts the asset group witness from a group signing
+// PSBT created by GroupSigningRequest.Psbt that was signed by an external
+// signer. The witness is not validated; this happens when the batch is sealed.
+func GroupWitnessFromPsbt(pkt *psbt.Packet) (asset.PendingGroupWitness,
+	error) {
+
+	var zero asset.PendingGroupWitness
+
+	if len(pkt.Inputs) != 1 {
+		return zero, fmt.Errorf("group PSBT must have exactly one " +
+			"input")
+	}
+
+	pIn := pkt.Inputs[0]
+	if len(pIn.TaprootKeySpendSig) == 0 {
+		return zero, fmt.Errorf("group PSBT is missing the key spend " +
+			"signature")
+	}
+
+	// The asset ID is the single tweak of the group internal key, which we
+	// stored in the input when creating the PSBT.
+	var (
+		genID    *asset.ID
+		tweakKey = btcwallet.PsbtKeyTypeInputSignatureTweakSingle
+	)
+	for _, unknown := range pIn.Unknowns {
+		if !bytes.Equal(unknown.Key, tweakKey) {
+			continue
+		}
+
+		if len(unknown.Value) != len(asset.ID{}) {
+			return zero, fmt.Errorf("invalid asset ID length %d",
+				len(unknown.Value))
+		}
+
+		var id asset.ID
+		copy(id[:], unknown.Value)
+		genID = &id
+	}
+	if genID == nil {
+		return zero, fmt.Errorf("group PSBT is missing the asset ID")
+	}
+
+	return asset.PendingGroupWitness{
+		GenID:   *genID,
+		Witness: wire.TxWitness{bytes.Clone(pIn.TaprootKeySpendSig)},
+	}, nil
+}
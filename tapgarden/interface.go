@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
@@ -53,6 +54,23 @@ type Planter interface {
 	// the current batch, if one exists.
 	FinalizeBatch(params FinalizeParams) (*MintingBatch, error)
 
+	// EstimateBatch performs a dry run of the finalization of the current
+	// batch, and returns the size, fee and output layout of the genesis
+	// transaction that would be broadcast. Nothing is broadcast or
+	// persisted.
+	EstimateBatch(params FinalizeParams) (*BatchEstimate, error)
+
+	// GroupSigningRequests returns the signing requests for the asset
+	// group witnesses of the current batch that must be produced by an
+	// external signer, because the wallet doesn't hold the group internal
+	// key.
+	GroupSigningRequests() ([]*GroupSigningRequest, error)
+
+	// MintCollection adds all editions of a collectible collection to the
+	// current batch, funding the batch if needed, and returns the IDs of
+	// the editions' assets.
+	MintCollection(params CollectionParams) (*CollectionResult, error)
+
 	// CancelBatch signals that the asset minter should cancel the
 	// current batch, if one exists.
 	CancelBatch() (*btcec.PublicKey, error)
@@ -399,6 +417,42 @@ type KeyRing interface {
 	IsLocalKey(context.Context, keychain.KeyDescriptor) bool
 }
 
+// OrphanedAnchorTx is a confirmed anchor transaction of which the block was
+// re-organized out of the chain and that wasn't mined again yet.
+type OrphanedAnchorTx struct {
+	// Txid is the hash of the anchor transaction.
+	Txid chainhash.Hash
+
+	// BlockHash is the hash of the orphaned block the transaction was
+	// confirmed in.
+	BlockHash chainhash.Hash
+
+	// BlockHeight is the height of the orphaned block.
+	BlockHeight uint32
+
+	// DetectedAt is the time the re-org was detected.
+	DetectedAt time.Time
+}
+
+// AnchorTxReOrgLog is used to persist the re-org state of anchor transactions,
+// so the assets they anchor are known to be unconfirmed across restarts.
+type AnchorTxReOrgLog interface {
+	// LogAnchorTxOrphaned marks the confirmed anchor transaction with the
+	// given hash as re-organized out of the chain.
+	LogAnchorTxOrphaned(ctx context.Context, txid chainhash.Hash) error
+
+	// LogAnchorTxConfirmed updates the confirmation of the anchor
+	// transaction with the given hash to the given block and clears its
+	// orphaned state, if any.
+	LogAnchorTxConfirmed(ctx context.Context, txid chainhash.Hash,
+		blockHash chainhash.Hash, blockHeight uint32,
+		txIndex uint32) error
+
+	// OrphanedAnchorTxs returns all anchor transactions that were
+	// re-organized out of the chain and weren't mined again yet.
+	OrphanedAnchorTxs(ctx context.Context) ([]OrphanedAnchorTx, error)
+}
+
 var (
 	// ErrNoGenesis is returned when fetching an asset genesis fails.
 	ErrNoGenesis = errors.New("unable to fetch genesis asset")
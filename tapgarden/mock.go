@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -20,11 +21,13 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/internal/test"
 	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/universe"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -318,13 +321,17 @@ type MockChainBridge struct {
 
 	NewBlocks chan int32
 
-	ReqCount atomic.Int32
-	ConfReqs map[int]*chainntnfs.ConfirmationEvent
+	ReqCount   atomic.Int32
+	ConfReqs   map[int]*chainntnfs.ConfirmationEvent
+	ReOrgChans map[int]chan struct{}
 
 	failFeeEstimates atomic.Bool
 	errConf          atomic.Int32
 	emptyConf        atomic.Int32
 	confErr          chan error
+
+	blocksMtx sync.Mutex
+	blocks    map[int64]*wire.MsgBlock
 }
 
 func NewMockChainBridge() *MockChainBridge {
@@ -332,12 +339,25 @@ func NewMockChainBridge() *MockChainBridge {
 		FeeEstimateSignal: make(chan struct{}),
 		PublishReq:        make(chan *wire.MsgTx),
 		ConfReqs:          make(map[int]*chainntnfs.ConfirmationEvent),
+		ReOrgChans:        make(map[int]chan struct{}),
 		ConfReqSignal:     make(chan int),
 		BlockEpochSignal:  make(chan struct{}, 1),
 		NewBlocks:         make(chan int32),
+		blocks:            make(map[int64]*wire.MsgBlock),
 	}
 }
 
+// AddBlock adds a block at the given height that is returned by GetBlock and
+// GetBlockHash.
+// AddBlock adds a block at the given height, to be returned by GetBlock and
+// GetBlockHash.
+func (m *MockChainBridge) AddBlock(height int64, block *wire.MsgBlock) {
+	m.blocksMtx.Lock()
+	defer m.blocksMtx.Unlock()
+
+	m.blocks[height] = block
+}
+
 func (m *MockChainBridge) FailFeeEstimatesOnce() {
 	m.failFeeEstimates.Store(true)
 }
@@ -381,7 +401,8 @@ func (m *MockChainBridge) SendConfNtfn(reqNo int, blockHash *chainhash.Hash,
 
 func (m *MockChainBridge) RegisterConfirmationsNtfn(ctx context.Context,
 	_ *chainhash.Hash, _ []byte, _, _ uint32, _ bool,
-	_ chan struct{}) (*chainntnfs.ConfirmationEvent, chan error, error) {
+	reOrgChan chan struct{}) (*chainntnfs.ConfirmationEvent, chan error,
+	error) {
 
 	select {
 	case <-ctx.Done():
@@ -401,6 +422,7 @@ func (m *MockChainBridge) RegisterConfirmationsNtfn(ctx context.Context,
 
 	currentReqCount := m.ReqCount.Load()
 	m.ConfReqs[int(currentReqCount)] = req
+	m.ReOrgChans[int(currentReqCount)] = reOrgChan
 
 	select {
 	case m.ConfReqSignal <- int(currentReqCount):
@@ -437,6 +459,15 @@ func (m *MockChainBridge) RegisterBlockEpochNtfn(
 func (m *MockChainBridge) GetBlock(ctx context.Context,
 	hash chainhash.Hash) (*wire.MsgBlock, error) {
 
+	m.blocksMtx.Lock()
+	defer m.blocksMtx.Unlock()
+
+	for _, block := range m.blocks {
+		if block.BlockHash() == hash {
+			return block, nil
+		}
+	}
+
 	return &wire.MsgBlock{}, nil
 }
 
@@ -445,6 +476,13 @@ func (m *MockChainBridge) GetBlock(ctx context.Context,
 func (m *MockChainBridge) GetBlockHash(ctx context.Context,
 	blockHeight int64) (chainhash.Hash, error) {
 
+	m.blocksMtx.Lock()
+	defer m.blocksMtx.Unlock()
+
+	if block, ok := m.blocks[blockHeight]; ok {
+		return block.BlockHash(), nil
+	}
+
 	return chainhash.Hash{}, nil
 }
 
@@ -677,8 +715,11 @@ func (m *MockKeyRing) DeriveKey(ctx context.Context,
 	return keychain.KeyDescriptor{}, nil
 }
 
-func (m *MockKeyRing) IsLocalKey(context.Context, keychain.KeyDescriptor) bool {
-	return true
+func (m *MockKeyRing) IsLocalKey(_ context.Context,
+	desc keychain.KeyDescriptor) bool {
+
+	priv, ok := m.Keys[desc.KeyLocator]
+	return ok && desc.PubKey != nil && priv.PubKey().IsEqual(desc.PubKey)
 }
 
 type MockGenSigner struct {
@@ -743,6 +784,45 @@ func (m *MockProofArchive) ImportProofs(context.Context,
 	return nil
 }
 
+// MockScriptKeyReserver is a mock implementation of the
+// address.ScriptKeyReserver interface that keeps all reservations in memory.
+type MockScriptKeyReserver struct {
+	sync.Mutex
+
+	reservations map[asset.SerializedKey]address.ScriptKeyOwner
+}
+
+// NewMockScriptKeyReserver creates a new mock script key reserver.
+func NewMockScriptKeyReserver() *MockScriptKeyReserver {
+	return &MockScriptKeyReserver{
+		reservations: make(
+			map[asset.SerializedKey]address.ScriptKeyOwner,
+		),
+	}
+}
+
+// ReserveScriptKey reserves the given tweaked script key for the given owner.
+func (m *MockScriptKeyReserver) ReserveScriptKey(_ context.Context,
+	scriptKey *btcec.PublicKey, owner address.ScriptKeyOwner) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := asset.ToSerialized(scriptKey)
+	existingOwner, ok := m.reservations[key]
+	if ok && existingOwner != owner {
+		return address.ErrScriptKeyReserved
+	}
+
+	m.reservations[key] = owner
+
+	return nil
+}
+
+// A compile-time assertion to ensure that MockScriptKeyReserver meets the
+// address.ScriptKeyReserver interface.
+var _ address.ScriptKeyReserver = (*MockScriptKeyReserver)(nil)
+
 type MockProofWatcher struct {
 }
 
@@ -766,6 +846,56 @@ func (m *MockProofWatcher) DefaultUpdateCallback() proof.UpdateCallback {
 	}
 }
 
+// PushedProofLeaf is a proof leaf that was pushed to the
+// MockReceivedProofPusher.
+type PushedProofLeaf struct {
+	ID          universe.Identifier
+	Key         universe.LeafKey
+	Leaf        *universe.Leaf
+	SkipServers []universe.ServerAddr
+}
+
+// MockReceivedProofPusher is a mock implementation of the ReceivedProofPusher
+// interface that records all pushed proof leaves.
+type MockReceivedProofPusher struct {
+	sync.Mutex
+
+	pushed []PushedProofLeaf
+}
+
+// PushProofLeaf records the given proof leaf.
+func (m *MockReceivedProofPusher) PushProofLeaf(_ context.Context,
+	id universe.Identifier, key universe.LeafKey, leaf *universe.Leaf,
+	skipServers ...universe.ServerAddr) (*universe.Proof, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.pushed = append(m.pushed, PushedProofLeaf{
+		ID:          id,
+		Key:         key,
+		Leaf:        leaf,
+		SkipServers: skipServers,
+	})
+
+	return &universe.Proof{
+		Leaf:    leaf,
+		LeafKey: key,
+	}, nil
+}
+
+// Pushed returns all proof leaves that were pushed so far.
+func (m *MockReceivedProofPusher) Pushed() []PushedProofLeaf {
+	m.Lock()
+	defer m.Unlock()
+
+	return append([]PushedProofLeaf(nil), m.pushed...)
+}
+
+// A compile-time assertion to ensure that MockReceivedProofPusher meets the
+// ReceivedProofPusher interface.
+var _ ReceivedProofPusher = (*MockReceivedProofPusher)(nil)
+
 type FallibleTapscriptTreeMgr struct {
 	store               MintingStore
 	FailLoad, FailStore bool
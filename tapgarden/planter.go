@@ -10,16 +10,19 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
 	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"golang.org/x/exp/maps"
 )
@@ -74,6 +77,10 @@ type GardenKit struct {
 	// UniversePushBatchSize is the number of minted items to push to the
 	// local universe in a single batch.
 	UniversePushBatchSize int
+
+	// ScriptKeyReserver is used to make sure the script keys of newly
+	// minted assets aren't already in use by another subsystem.
+	ScriptKeyReserver address.ScriptKeyReserver
 }
 
 // PlanterConfig is the main config for the ChainPlanter.
@@ -87,6 +94,17 @@ type PlanterConfig struct {
 	// critical errors to the main server.
 	ErrChan chan<- error
 
+	// AutoFinalize configures the triggers that finalize the pending batch
+	// automatically.
+	AutoFinalize AutoFinalizeConfig
+
+	// Clock is used to evaluate the automatic batch finalization triggers.
+	Clock clock.Clock
+
+	// AnchorOutputValue is the value of the genesis output that anchors
+	// the minted assets. If zero, GenesisAmtSats is used.
+	AnchorOutputValue btcutil.Amount
+
 	// TODO(roasbeef): something notification related?
 }
 
@@ -153,6 +171,7 @@ type UnsealedSeedling struct {
 type FinalizeParams struct {
 	FeeRate        fn.Option[chainfee.SatPerKWeight]
 	SiblingTapTree fn.Option[asset.TapscriptTreeNodes]
+	VanityID       fn.Option[VanityIDParams]
 }
 
 // FundParams are the options available to change how a batch is funded, and how
@@ -160,6 +179,7 @@ type FinalizeParams struct {
 type FundParams struct {
 	FeeRate        fn.Option[chainfee.SatPerKWeight]
 	SiblingTapTree fn.Option[asset.TapscriptTreeNodes]
+	VanityID       fn.Option[VanityIDParams]
 }
 
 // SealParams change how asset groups in a minting batch are created.
@@ -219,6 +239,9 @@ const (
 	reqTypeCancelBatch
 	reqTypeFundBatch
 	reqTypeSealBatch
+	reqTypeEstimateBatch
+	reqTypeGroupSigningRequests
+	reqTypeMintCollection
 )
 
 // ChainPlanter is responsible for accepting new incoming requests to create
@@ -260,6 +283,15 @@ type ChainPlanter struct {
 	// subscriberMtx guards the subscribers map.
 	subscriberMtx sync.Mutex
 
+	// oldestSeedlingBatch is the key of the batch oldestSeedlingTime
+	// refers to.
+	oldestSeedlingBatch BatchKey
+
+	// oldestSeedlingTime is the time the first seedling of the pending
+	// batch was queued at, which the seedling age trigger of the automatic
+	// batch finalization is evaluated against.
+	oldestSeedlingTime time.Time
+
 	// ContextGuard provides a wait group and main quit channel that can be
 	// used to create guarded contexts.
 	*fn.ContextGuard
@@ -524,31 +556,18 @@ func (c *ChainPlanter) newBatch() (*MintingBatch, error) {
 	return newBatch, nil
 }
 
-// fundGenesisPsbt generates a PSBT packet we'll use to create an asset.  In
-// order to be able to create an asset, we need an initial genesis outpoint. To
-// obtain this we'll ask the wallet to fund a PSBT template for GenesisAmtSats
-// (all outputs need to hold some BTC to not be dust), and with a dummy script.
-// We need to use a dummy script as we can't know the actual script key since
-// that's dependent on the genesis outpoint.
-func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
+// genesisFeeRate returns the fee rate that should be used to fund the genesis
+// transaction of the batch with the given key. A manually assigned fee rate is
+// used as is, otherwise the fee rate is estimated and bumped to the minimum
+// relay fee if necessary.
+func (c *ChainPlanter) genesisFeeRate(ctx context.Context,
 	batchKey asset.SerializedKey,
-	manualFeeRate *chainfee.SatPerKWeight) (*tapsend.FundedPsbt, error) {
-
-	log.Infof("Attempting to fund batch: %x", batchKey)
+	manualFeeRate *chainfee.SatPerKWeight) (chainfee.SatPerKWeight, error) {
 
-	// Construct a 1-output TX as a template for our genesis TX, which the
-	// backing wallet will fund.
-	txTemplate := wire.NewMsgTx(2)
-	txTemplate.AddTxOut(tapsend.CreateDummyOutput())
-	genesisPkt, err := psbt.NewFromUnsignedTx(txTemplate)
-	if err != nil {
-		return nil, fmt.Errorf("unable to make psbt packet: %w", err)
-	}
-
-	log.Infof("creating skeleton PSBT for batch: %x", batchKey)
-	log.Tracef("PSBT: %v", spew.Sdump(genesisPkt))
-
-	var feeRate chainfee.SatPerKWeight
+	var (
+		feeRate chainfee.SatPerKWeight
+		err     error
+	)
 	switch {
 	// If a fee rate was manually assigned for this batch, use that instead
 	// of a fee rate estimate.
@@ -563,8 +582,7 @@ func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
 			ctx, GenesisConfTarget,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("unable to estimate fee: %w",
-				err)
+			return 0, fmt.Errorf("unable to estimate fee: %w", err)
 		}
 
 		log.Infof("estimated fee rate for batch: %x, %s",
@@ -573,7 +591,7 @@ func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
 
 	minRelayFee, err := c.cfg.Wallet.MinRelayFee(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to obtain minrelayfee: %w", err)
+		return 0, fmt.Errorf("unable to obtain minrelayfee: %w", err)
 	}
 
 	// If the fee rate is below the minimum relay fee, we'll
@@ -586,7 +604,7 @@ func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
 			// This case should already have been handled by the
 			// `checkFeeRateSanity` of `rpcserver.go`. We check here
 			// again to be safe.
-			return nil, fmt.Errorf("feerate does not meet "+
+			return 0, fmt.Errorf("feerate does not meet "+
 				"minrelayfee: (fee_rate=%s, minrelayfee=%s)",
 				feeRate.String(), minRelayFee.String())
 		default:
@@ -597,6 +615,39 @@ func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
 		}
 	}
 
+	return feeRate, nil
+}
+
+// fundGenesisPsbt generates a PSBT packet we'll use to create an asset.  In
+// order to be able to create an asset, we need an initial genesis outpoint. To
+// obtain this we'll ask the wallet to fund a PSBT template for GenesisAmtSats
+// (all outputs need to hold some BTC to not be dust), and with a dummy script.
+// We need to use a dummy script as we can't know the actual script key since
+// that's dependent on the genesis outpoint.
+func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
+	batchKey asset.SerializedKey,
+	feeRate chainfee.SatPerKWeight) (*tapsend.FundedPsbt, error) {
+
+	log.Infof("Attempting to fund batch: %x", batchKey)
+
+	// Construct a 1-output TX as a template for our genesis TX, which the
+	// backing wallet will fund.
+	genesisOut := tapsend.CreateDummyOutput()
+	genesisOut.Value = int64(GenesisAmtSats)
+	if c.cfg.AnchorOutputValue != 0 {
+		genesisOut.Value = int64(c.cfg.AnchorOutputValue)
+	}
+
+	txTemplate := wire.NewMsgTx(2)
+	txTemplate.AddTxOut(genesisOut)
+	genesisPkt, err := psbt.NewFromUnsignedTx(txTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to make psbt packet: %w", err)
+	}
+
+	log.Infof("creating skeleton PSBT for batch: %x", batchKey)
+	log.Tracef("PSBT: %v", spew.Sdump(genesisPkt))
+
 	fundedGenesisPkt, err := c.cfg.Wallet.FundPsbt(
 		ctx, genesisPkt, 1, feeRate, -1,
 	)
@@ -610,6 +661,20 @@ func (c *ChainPlanter) fundGenesisPsbt(ctx context.Context,
 	return fundedGenesisPkt, nil
 }
 
+// fundBatchGenesis funds the genesis TX of the given batch. If a vanity asset
+// ID was requested, we search for a genesis that produces it.
+func (c *ChainPlanter) fundBatchGenesis(ctx context.Context,
+	batch *MintingBatch, feeRate chainfee.SatPerKWeight,
+	vanityID fn.Option[VanityIDParams]) (*tapsend.FundedPsbt, error) {
+
+	if vanityParams := vanityID.UnwrapToPtr(); vanityParams != nil {
+		return c.grindGenesisPsbt(ctx, batch, feeRate, *vanityParams)
+	}
+
+	batchKey := asset.ToSerialized(batch.BatchKey.PubKey)
+	return c.fundGenesisPsbt(ctx, batchKey, feeRate)
+}
+
 // filterSeedlingsWithGroup separates a set of seedlings into two sets based on
 // their relation to an asset group, which has not been constructed yet.
 func filterSeedlingsWithGroup(
@@ -1196,6 +1261,28 @@ func (c *ChainPlanter) gardener() {
 
 	log.Infof("Gardener for ChainPlanter now active!")
 
+	// If any automatic batch finalization triggers are configured, we'll
+	// evaluate them periodically or at their scheduled times.
+	var (
+		autoCfg       = c.cfg.AutoFinalize
+		checkTicker   <-chan time.Time
+		scheduleTimer <-chan time.Time
+		schedule      *cronSchedule
+	)
+	if autoCfg.SeedlingCount > 0 || autoCfg.SeedlingAge > 0 {
+		checkTicker = c.cfg.Clock.TickAfter(autoCfg.CheckInterval)
+	}
+	if autoCfg.Schedule != "" {
+		var err error
+		schedule, err = parseCronSchedule(autoCfg.Schedule)
+		if err != nil {
+			log.Errorf("Invalid batch finalization schedule: %v",
+				err)
+		} else {
+			scheduleTimer = c.nextScheduledFinalize(schedule)
+		}
+	}
+
 	for {
 		select {
 		// A request for new asset issuance just arrived, add this to
@@ -1230,6 +1317,25 @@ func (c *ChainPlanter) gardener() {
 				NewState:     MintingStateSeed,
 			}
 
+			c.seedlingsQueued()
+
+		// It's time to check the periodic automatic batch finalization
+		// triggers.
+		case <-checkTicker:
+			c.checkAutoFinalize(
+				triggerSeedlingCount, triggerSeedlingAge,
+			)
+
+			checkTicker = c.cfg.Clock.TickAfter(
+				autoCfg.CheckInterval,
+			)
+
+		// A scheduled batch finalization time was reached.
+		case <-scheduleTimer:
+			c.checkAutoFinalize(triggerSchedule)
+
+			scheduleTimer = c.nextScheduledFinalize(schedule)
+
 		// A caretaker has finished processing their batch to full
 		// Taproot Asset maturity. We'll clean up our local state, and
 		// signal that it can exit.
@@ -1349,10 +1455,6 @@ func (c *ChainPlanter) gardener() {
 					break
 				}
 
-				batchKey := c.pendingBatch.BatchKey.PubKey
-				batchKeySerial := asset.ToSerialized(batchKey)
-				log.Infof("Finalizing batch %x", batchKeySerial)
-
 				finalizeReqParams, err :=
 					typedParam[FinalizeParams](req)
 				if err != nil {
@@ -1361,46 +1463,80 @@ func (c *ChainPlanter) gardener() {
 					break
 				}
 
-				caretaker, err := c.finalizeBatch(
+				batch, err := c.finalizePendingBatch(
 					*finalizeReqParams,
 				)
 				if err != nil {
-					freezeErr := fmt.Errorf("unable to "+
-						"finalize minting batch: %w",
-						err)
-					log.Warnf(freezeErr.Error())
-					req.Error(freezeErr)
+					req.Error(err)
 					break
 				}
 
-				// We now wait for the caretaker to either
-				// broadcast the batch or fail to do so.
-				select {
-				case <-caretaker.cfg.BroadcastCompleteChan:
-					req.Resolve(caretaker.cfg.Batch)
+				req.Resolve(batch)
 
-				case err := <-caretaker.cfg.BroadcastErrChan:
-					req.Error(err)
-					// Unrecoverable error, stop caretaker
-					// directly. The pending batch will not
-					// be saved.
-					stopErr := caretaker.Stop()
-					if stopErr != nil {
-						log.Warnf("Unable to stop "+
-							"caretaker "+
-							"gracefully: %v", err)
-					}
+			case reqTypeEstimateBatch:
+				estimateReqParams, err :=
+					typedParam[FinalizeParams](req)
+				if err != nil {
+					req.Error(fmt.Errorf("bad estimate "+
+						"params: %w", err))
+					break
+				}
 
-					delete(c.caretakers, batchKeySerial)
+				ctx, cancel := c.WithCtxQuit()
+				estimate, err := c.estimateBatch(
+					ctx, *estimateReqParams,
+				)
+				cancel()
+				if err != nil {
+					req.Error(fmt.Errorf("unable to "+
+						"estimate minting batch: %w",
+						err))
+					break
+				}
 
-				case <-c.Quit:
-					return
+				req.Resolve(estimate)
+
+			case reqTypeGroupSigningRequests:
+				ctx, cancel := c.WithCtxQuit()
+				signingReqs, err := c.groupSigningRequests(ctx)
+				cancel()
+				if err != nil {
+					req.Error(fmt.Errorf("unable to "+
+						"create group signing "+
+						"requests: %w", err))
+					break
 				}
 
-				// Now that we have a caretaker launched for
-				// this batch and broadcast its minting
-				// transaction, we can remove the pending batch.
-				c.pendingBatch = nil
+				req.Resolve(signingReqs)
+
+			case reqTypeMintCollection:
+				collectionParams, err :=
+					typedParam[CollectionParams](req)
+				if err != nil {
+					req.Error(fmt.Errorf("bad collection "+
+						"params: %w", err))
+					break
+				}
+
+				ctx, cancel := c.WithCtxQuit()
+				result, err := c.mintCollection(
+					ctx, *collectionParams,
+				)
+				cancel()
+
+				// Some editions may have been added even if
+				// the collection as a whole failed.
+				if c.pendingBatch != nil {
+					c.seedlingsQueued()
+				}
+
+				if err != nil {
+					req.Error(fmt.Errorf("unable to mint "+
+						"collection: %w", err))
+					break
+				}
+
+				req.Resolve(result)
 
 			case reqTypeCancelBatch:
 				batchKey, err := c.canCancelBatch()
@@ -1427,6 +1563,23 @@ func (c *ChainPlanter) gardener() {
 	}
 }
 
+// seedlingsQueued updates the automatic batch finalization state after new
+// seedlings were added to the pending batch.
+func (c *ChainPlanter) seedlingsQueued() {
+	// Remember when the first seedling of the pending batch was queued for
+	// the seedling age trigger.
+	batchKey := asset.ToSerialized(c.pendingBatch.BatchKey.PubKey)
+	if batchKey != c.oldestSeedlingBatch {
+		c.oldestSeedlingBatch = batchKey
+		c.oldestSeedlingTime = c.cfg.Clock.Now()
+	}
+
+	// The new seedlings may be the ones that fill up the batch.
+	if c.cfg.AutoFinalize.SeedlingCount > 0 {
+		c.checkAutoFinalize(triggerSeedlingCount)
+	}
+}
+
 // fundBatch attempts to fund a minting batch and create a funded genesis PSBT.
 // This PSBT is a template that the caretaker will modify when finalizing the
 // batch. If a feerate or tapscript sibling are provided, those will be used
@@ -1464,7 +1617,15 @@ func (c *ChainPlanter) fundBatch(ctx context.Context, params FundParams,
 
 		// Fund the batch with the specified fee rate.
 		batchKey := asset.ToSerialized(batch.BatchKey.PubKey)
-		batchTX, err := c.fundGenesisPsbt(ctx, batchKey, feeRate)
+		genesisFeeRate, err := c.genesisFeeRate(ctx, batchKey, feeRate)
+		if err != nil {
+			return fmt.Errorf("unable to fund minting PSBT for "+
+				"batch: %x %w", batchKey[:], err)
+		}
+
+		batchTX, err := c.fundBatchGenesis(
+			ctx, batch, genesisFeeRate, params.VanityID,
+		)
 		if err != nil {
 			return fmt.Errorf("unable to fund minting PSBT for "+
 				"batch: %x %w", batchKey[:], err)
@@ -1527,6 +1688,67 @@ func (c *ChainPlanter) fundBatch(ctx context.Context, params FundParams,
 	return nil
 }
 
+// groupSigningRequests returns the signing requests for the asset group
+// witnesses of the pending batch whose group internal key isn't held by our
+// wallet. The batch must be funded, as the genesis outpoint determines the
+// asset IDs and therefore the group virtual TXs that are signed.
+func (c *ChainPlanter) groupSigningRequests(
+	ctx context.Context) ([]*GroupSigningRequest, error) {
+
+	workingBatch := c.pendingBatch
+	switch {
+	case workingBatch == nil:
+		return nil, fmt.Errorf("no pending batch")
+
+	case !workingBatch.IsFunded():
+		return nil, fmt.Errorf("batch is not funded")
+	}
+
+	groupSeedlings, _ := filterSeedlingsWithGroup(workingBatch.Seedlings)
+	if len(groupSeedlings) == 0 {
+		return nil, nil
+	}
+
+	anchorOutputIndex := extractAnchorOutputIndex(
+		workingBatch.GenesisPacket,
+	)
+	genesisPoint := extractGenesisOutpoint(
+		workingBatch.GenesisPacket.Pkt.UnsignedTx,
+	)
+
+	groupReqs, genTXs, err := buildGroupReqs(
+		genesisPoint, anchorOutputIndex, c.cfg.GenTxBuilder,
+		groupSeedlings,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build group requests: "+
+			"%w", err)
+	}
+	if len(groupReqs) != len(genTXs) {
+		return nil, fmt.Errorf("mismatched number of group requests " +
+			"and virtual TXs")
+	}
+
+	var signingReqs []*GroupSigningRequest
+	for i := range groupReqs {
+		// Witnesses for keys of our wallet are derived when the batch
+		// is sealed, so they don't need an external signer.
+		if c.cfg.KeyRing.IsLocalKey(ctx, groupReqs[i].RawKey) {
+			continue
+		}
+
+		signingReqs = append(signingReqs, &GroupSigningRequest{
+			AssetName: groupReqs[i].NewAsset.Genesis.Tag,
+			PendingAssetGroup: PendingAssetGroup{
+				GroupKeyRequest: groupReqs[i],
+				GroupVirtualTx:  genTXs[i],
+			},
+		})
+	}
+
+	return signingReqs, nil
+}
+
 // sealBatch will verify that each grouped asset in the pending batch has an
 // asset group witness, and will attempt to create asset group witnesses when
 // possible if they are not provided. After all asset group witnesses have been
@@ -1643,6 +1865,12 @@ func (c *ChainPlanter) sealBatch(ctx context.Context, params SealParams,
 				Witness:       groupWitness.Witness,
 			}
 
+		// Without a witness, we can only derive one if the group
+		// internal key is held by our wallet.
+		case !c.cfg.KeyRing.IsLocalKey(ctx, groupReq.RawKey):
+			return nil, fmt.Errorf("%w: asset %v",
+				ErrGroupWitnessRequired, reqAssetID)
+
 		default:
 			// Derive the asset group witness.
 			groupKey, err = asset.DeriveGroupKey(
@@ -1718,7 +1946,8 @@ func (c *ChainPlanter) finalizeBatch(params FinalizeParams) (*BatchCaretaker,
 	// Before modifying the pending batch, check if the batch was already
 	// funded. If so, reject any provided parameters, as they would conflict
 	// with those previously used for batch funding.
-	haveParams := params.FeeRate.IsSome() || params.SiblingTapTree.IsSome()
+	haveParams := params.FeeRate.IsSome() ||
+		params.SiblingTapTree.IsSome() || params.VanityID.IsSome()
 	if haveParams && c.pendingBatch.IsFunded() {
 		return nil, fmt.Errorf("cannot provide finalize parameters " +
 			"if batch already funded")
@@ -1781,6 +2010,232 @@ func (c *ChainPlanter) finalizeBatch(params FinalizeParams) (*BatchCaretaker,
 	return caretaker, nil
 }
 
+// finalizePendingBatch finalizes the pending batch and waits for its caretaker
+// to broadcast the genesis transaction of the batch.
+func (c *ChainPlanter) finalizePendingBatch(
+	params FinalizeParams) (*MintingBatch, error) {
+
+	batchKey := c.pendingBatch.BatchKey.PubKey
+	batchKeySerial := asset.ToSerialized(batchKey)
+	log.Infof("Finalizing batch %x", batchKeySerial)
+
+	caretaker, err := c.finalizeBatch(params)
+	if err != nil {
+		freezeErr := fmt.Errorf("unable to finalize minting batch: %w",
+			err)
+		log.Warnf(freezeErr.Error())
+		return nil, freezeErr
+	}
+
+	// We now wait for the caretaker to either broadcast the batch or fail
+	// to do so.
+	var broadcastErr error
+	select {
+	case <-caretaker.cfg.BroadcastCompleteChan:
+
+	case broadcastErr = <-caretaker.cfg.BroadcastErrChan:
+		// Unrecoverable error, stop caretaker directly. The pending
+		// batch will not be saved.
+		stopErr := caretaker.Stop()
+		if stopErr != nil {
+			log.Warnf("Unable to stop caretaker gracefully: %v",
+				stopErr)
+		}
+
+		delete(c.caretakers, batchKeySerial)
+
+	case <-c.Quit:
+		return nil, fmt.Errorf("chain planter shutting down")
+	}
+
+	// Now that we have a caretaker launched for this batch and broadcast
+	// its minting transaction, we can remove the pending batch.
+	c.pendingBatch = nil
+
+	if broadcastErr != nil {
+		return nil, broadcastErr
+	}
+
+	return caretaker.cfg.Batch, nil
+}
+
+// nextScheduledFinalize returns a channel that is sent on at the next time of
+// the given batch finalization schedule, or nil if the schedule never
+// activates again.
+func (c *ChainPlanter) nextScheduledFinalize(
+	schedule *cronSchedule) <-chan time.Time {
+
+	now := c.cfg.Clock.Now()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		log.Warnf("Batch finalization schedule %q never activates",
+			c.cfg.AutoFinalize.Schedule)
+		return nil
+	}
+
+	log.Debugf("Next scheduled batch finalization at %v", next)
+
+	return c.cfg.Clock.TickAfter(next.Sub(now))
+}
+
+// firedTriggers returns the subset of the given automatic batch finalization
+// triggers that fired for the pending batch.
+func (c *ChainPlanter) firedTriggers(
+	triggers []autoFinalizeTrigger) []autoFinalizeTrigger {
+
+	autoCfg := c.cfg.AutoFinalize
+	batchKey := asset.ToSerialized(c.pendingBatch.BatchKey.PubKey)
+
+	var fired []autoFinalizeTrigger
+	for _, trigger := range triggers {
+		switch trigger {
+		case triggerSeedlingCount:
+			if autoCfg.SeedlingCount > 0 &&
+				len(c.pendingBatch.Seedlings) >=
+					autoCfg.SeedlingCount {
+
+				fired = append(fired, trigger)
+			}
+
+		case triggerSeedlingAge:
+			if autoCfg.SeedlingAge == 0 ||
+				batchKey != c.oldestSeedlingBatch {
+
+				continue
+			}
+
+			age := c.cfg.Clock.Now().Sub(c.oldestSeedlingTime)
+			if age >= autoCfg.SeedlingAge {
+				fired = append(fired, trigger)
+			}
+
+		case triggerSchedule:
+			fired = append(fired, trigger)
+		}
+	}
+
+	return fired
+}
+
+// checkAutoFinalize finalizes the pending batch if any of the given automatic
+// batch finalization triggers fired, unless the fee rate the batch would be
+// funded with exceeds the fee rate ceilings of all fired triggers.
+func (c *ChainPlanter) checkAutoFinalize(triggers ...autoFinalizeTrigger) {
+	batch := c.pendingBatch
+	if batch == nil || !batch.HasSeedlings() {
+		return
+	}
+
+	fired := c.firedTriggers(triggers)
+	if len(fired) == 0 {
+		return
+	}
+
+	batchKey := asset.ToSerialized(batch.BatchKey.PubKey)
+
+	// The fee rate of a funded batch is already fixed, so the fee rate
+	// ceilings only apply to batches that still need to be funded.
+	var params FinalizeParams
+	if !batch.IsFunded() {
+		ctx, cancel := c.WithCtxQuit()
+		feeRate, err := c.genesisFeeRate(ctx, batchKey, nil)
+		cancel()
+		if err != nil {
+			log.Warnf("Unable to determine fee rate for automatic "+
+				"finalization of batch %x: %v", batchKey[:],
+				err)
+			return
+		}
+
+		var allowed []autoFinalizeTrigger
+		for _, trigger := range fired {
+			maxFeeRate := c.cfg.AutoFinalize.maxFeeRate(trigger)
+			if maxFeeRate == 0 || feeRate <= maxFeeRate {
+				allowed = append(allowed, trigger)
+			}
+		}
+
+		if len(allowed) == 0 {
+			log.Infof("Deferring automatic finalization of batch "+
+				"%x (triggers=%v), fee rate %v exceeds ceiling",
+				batchKey[:], fired, feeRate)
+			return
+		}
+
+		fired = allowed
+		params.FeeRate = fn.Some(feeRate)
+	}
+
+	log.Infof("Automatically finalizing batch %x (triggers=%v)",
+		batchKey[:], fired)
+
+	_, err := c.finalizePendingBatch(params)
+	if err != nil {
+		log.Errorf("Unable to automatically finalize batch %x: %v",
+			batchKey[:], err)
+	}
+}
+
+// estimateBatch performs a dry run of the finalization of the pending batch. If
+// the batch isn't funded yet, a genesis transaction is funded only to estimate
+// it, and the inputs locked for it are unlocked again right away. Nothing is
+// persisted or broadcast.
+func (c *ChainPlanter) estimateBatch(ctx context.Context,
+	params FinalizeParams) (*BatchEstimate, error) {
+
+	batch := c.pendingBatch
+	if batch == nil {
+		return nil, fmt.Errorf("no pending batch")
+	}
+
+	if !batch.HasSeedlings() {
+		return nil, fmt.Errorf("no seedlings in batch")
+	}
+
+	// The same parameter restrictions as for finalizing the batch apply.
+	haveParams := params.FeeRate.IsSome() ||
+		params.SiblingTapTree.IsSome() || params.VanityID.IsSome()
+	if haveParams && batch.IsFunded() {
+		return nil, fmt.Errorf("cannot provide finalize parameters " +
+			"if batch already funded")
+	}
+
+	// If the batch was already funded, its genesis transaction is final,
+	// and its effective fee rate is reported.
+	if batch.IsFunded() {
+		return newBatchEstimate(batch, batch.GenesisPacket, 0)
+	}
+
+	batchKey := asset.ToSerialized(batch.BatchKey.PubKey)
+	feeRate, err := c.genesisFeeRate(
+		ctx, batchKey, params.FeeRate.UnwrapToPtr(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisPkt, err := c.fundBatchGenesis(
+		ctx, batch, feeRate, params.VanityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The funded transaction is never going to be broadcast, so we release
+	// the inputs the wallet locked for it.
+	defer func() {
+		for _, op := range genesisPkt.LockedUTXOs {
+			err := c.cfg.Wallet.UnlockInput(ctx, op)
+			if err != nil {
+				log.Warnf("Unable to unlock input %v: %v", op,
+					err)
+			}
+		}
+	}()
+
+	return newBatchEstimate(batch, genesisPkt, feeRate)
+}
+
 // PendingBatch returns the current pending batch. If there's no pending batch,
 // then an error is returned.
 func (c *ChainPlanter) PendingBatch() (*MintingBatch, error) {
@@ -1856,6 +2311,49 @@ func (c *ChainPlanter) FinalizeBatch(params FinalizeParams) (*MintingBatch,
 	return <-req.resp, <-req.err
 }
 
+// GroupSigningRequests returns the signing requests for the asset group
+// witnesses of the current batch that must be produced by an external signer.
+func (c *ChainPlanter) GroupSigningRequests() ([]*GroupSigningRequest, error) {
+	req := newStateReq[[]*GroupSigningRequest](reqTypeGroupSigningRequests)
+
+	if !fn.SendOrQuit[stateRequest](c.stateReqs, req, c.Quit) {
+		return nil, fmt.Errorf("chain planter shutting down")
+	}
+
+	return <-req.resp, <-req.err
+}
+
+// MintCollection adds all editions of a collectible collection to the current
+// batch and funds the batch if needed. The IDs of the editions' assets are
+// returned.
+func (c *ChainPlanter) MintCollection(
+	params CollectionParams) (*CollectionResult, error) {
+
+	req := newStateParamReq[*CollectionResult](
+		reqTypeMintCollection, params,
+	)
+
+	if !fn.SendOrQuit[stateRequest](c.stateReqs, req, c.Quit) {
+		return nil, fmt.Errorf("chain planter shutting down")
+	}
+
+	return <-req.resp, <-req.err
+}
+
+// EstimateBatch sends a signal to the planter to perform a dry run of the
+// finalization of the current batch.
+func (c *ChainPlanter) EstimateBatch(params FinalizeParams) (*BatchEstimate,
+	error) {
+
+	req := newStateParamReq[*BatchEstimate](reqTypeEstimateBatch, params)
+
+	if !fn.SendOrQuit[stateRequest](c.stateReqs, req, c.Quit) {
+		return nil, fmt.Errorf("chain planter shutting down")
+	}
+
+	return <-req.resp, <-req.err
+}
+
 // CancelBatch sends a signal to the planter to cancel the current batch.
 func (c *ChainPlanter) CancelBatch() (*btcec.PublicKey, error) {
 	req := newStateReq[*btcec.PublicKey](reqTypeCancelBatch)
@@ -1975,6 +2473,16 @@ func (c *ChainPlanter) prepAssetSeedling(ctx context.Context,
 		req.ScriptKey = asset.NewScriptKeyBip86(scriptKey)
 	}
 
+	// The script key may have been provided externally, so we need to
+	// make sure it isn't already used by an address or a channel.
+	err := c.cfg.ScriptKeyReserver.ReserveScriptKey(
+		ctx, req.ScriptKey.PubKey, address.ScriptKeyOwnerMint,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to reserve script key for "+
+			"seedling: %s %w", req.AssetName, err)
+	}
+
 	// Now that we know the seedling is valid, we'll check to see if a batch
 	// already exists.
 	switch {
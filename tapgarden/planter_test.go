@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightninglabs/taproot-assets/tapsend"
 	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lntest/wait"
@@ -74,7 +76,7 @@ func newMintingStore(t *testing.T) tapgarden.MintingStore {
 	}
 
 	assetDB := tapdb.NewTransactionExecutor(db, txCreator)
-	return tapdb.NewAssetMintingStore(assetDB)
+	return tapdb.NewAssetMintingStore(assetDB, nil)
 }
 
 // mintingTestHarness holds and manages all the set of deplanes needed to
@@ -97,6 +99,10 @@ type mintingTestHarness struct {
 
 	txValidator tapscript.TxValidator
 
+	clock *clock.TestClock
+
+	autoFinalize tapgarden.AutoFinalizeConfig
+
 	planter *tapgarden.ChainPlanter
 
 	proofFiles *proof.MockProofArchive
@@ -130,6 +136,8 @@ func newMintingTestHarness(t *testing.T,
 		genSigner:    genSigner,
 		genTxBuilder: &tapscript.GroupTxBuilder{},
 		txValidator:  &tap.ValidatorV0{},
+		clock:        clock.NewTestClock(time.Now()),
+		autoFinalize: tapgarden.DefaultAutoFinalizeConfig(),
 		errChan:      make(chan error, 10),
 	}
 }
@@ -154,9 +162,13 @@ func (t *mintingTestHarness) refreshChainPlanter() {
 			TxValidator:  t.txValidator,
 			ProofFiles:   t.proofFiles,
 			ProofWatcher: t.proofWatcher,
+
+			ScriptKeyReserver: tapgarden.NewMockScriptKeyReserver(),
 		},
 		ProofUpdates: t.proofFiles,
 		ErrChan:      t.errChan,
+		AutoFinalize: t.autoFinalize,
+		Clock:        t.clock,
 	})
 	require.NoError(t, t.planter.Start())
 }
@@ -1876,6 +1888,387 @@ func testFundSealBeforeFinalize(t *mintingTestHarness) {
 	t.assertMintOutputKey(mintedBatch, &defaultTapHash)
 }
 
+// testExternalGroupKeySigning tests that a batch with a group internal key
+// held by an external signer can only be sealed with a witness produced from
+// the exported group signing request.
+func testExternalGroupKeySigning(t *mintingTestHarness) {
+	// First, create a new chain planter instance using the supplied test
+	// harness.
+	t.refreshChainPlanter()
+
+	var (
+		wg               sync.WaitGroup
+		respChan         = make(chan *FundBatchResp, 1)
+		finalizeRespChan = make(chan *FinalizeBatchResp, 1)
+	)
+
+	// The group internal key of the first seedling is held by an external
+	// signer, so its private key is not added to the key ring.
+	externalKeyDesc, externalKeyPriv := test.RandKeyDesc(t)
+
+	const numSeedlings = 3
+	seedlings := t.newRandSeedlings(numSeedlings)
+	seedlings[0].EnableEmission = true
+	seedlings[0].GroupInternalKey = &externalKeyDesc
+	externalSeedling := seedlings[0].AssetName
+
+	// Before the batch is funded, there are no signing requests.
+	_, err := t.planter.GroupSigningRequests()
+	require.ErrorContains(t, err, "no pending batch")
+
+	t.fundBatch(&wg, respChan, nil)
+	t.assertKeyDerived()
+	t.assertGenesisTxFunded(nil)
+	t.assertFundBatch(&wg, respChan, "")
+
+	t.queueSeedlingsInBatch(true, seedlings...)
+	t.assertPendingBatchExists(numSeedlings)
+
+	// Sealing the batch without a witness for the external key fails, as
+	// the wallet can't sign for it.
+	_, err = t.planter.SealBatch(tapgarden.SealParams{})
+	require.ErrorIs(t, err, tapgarden.ErrGroupWitnessRequired)
+
+	// Only the seedling with the external key needs a signature.
+	signingReqs, err := t.planter.GroupSigningRequests()
+	require.NoError(t, err)
+	require.Len(t, signingReqs, 1)
+
+	signingReq := signingReqs[0]
+	require.Equal(t, externalSeedling, signingReq.AssetName)
+	require.True(
+		t, signingReq.RawKey.PubKey.IsEqual(externalKeyDesc.PubKey),
+	)
+
+	// The exported PSBT carries everything the external signer needs.
+	pkt, err := signingReq.Psbt(chaincfg.RegressionNetParams.HDCoinType)
+	require.NoError(t, err)
+	require.Len(t, pkt.Inputs, 1)
+	require.Equal(t, signingReq.PrevOut, *pkt.Inputs[0].WitnessUtxo)
+	require.Len(t, pkt.Inputs[0].TaprootBip32Derivation, 1)
+
+	// A PSBT without a signature is rejected.
+	_, err = tapgarden.GroupWitnessFromPsbt(pkt)
+	require.ErrorContains(t, err, "missing the key spend signature")
+
+	// Let the external signer sign the group virtual TX, and round trip
+	// the signed PSBT through its serialization.
+	externalSigner := asset.NewMockGenesisSigner(externalKeyPriv)
+	signedGroupKey, err := asset.DeriveGroupKey(
+		externalSigner, signingReq.GroupVirtualTx,
+		signingReq.GroupKeyRequest, nil,
+	)
+	require.NoError(t, err)
+	pkt.Inputs[0].TaprootKeySpendSig = signedGroupKey.Witness[0]
+
+	var buf bytes.Buffer
+	require.NoError(t, pkt.Serialize(&buf))
+	signedPkt, err := psbt.NewFromRawBytes(&buf, false)
+	require.NoError(t, err)
+
+	groupWitness, err := tapgarden.GroupWitnessFromPsbt(signedPkt)
+	require.NoError(t, err)
+	require.Equal(t, signingReq.GenID, groupWitness.GenID)
+
+	// An invalid signature is rejected when sealing the batch.
+	invalidWitness := asset.PendingGroupWitness{
+		GenID:   groupWitness.GenID,
+		Witness: wire.TxWitness{test.RandBytes(64)},
+	}
+	_, err = t.planter.SealBatch(tapgarden.SealParams{
+		GroupWitnesses: []asset.PendingGroupWitness{invalidWitness},
+	})
+	require.Error(t, err)
+
+	// With the signature of the external signer, the batch can be sealed.
+	sealedBatch, err := t.planter.SealBatch(tapgarden.SealParams{
+		GroupWitnesses: []asset.PendingGroupWitness{groupWitness},
+	})
+	require.NoError(t, err)
+
+	sealedGroup := sealedBatch.Seedlings[externalSeedling].GroupInfo
+	require.NotNil(t, sealedGroup)
+	require.Equal(t, groupWitness.Witness, sealedGroup.GroupKey.Witness)
+	require.True(t, sealedGroup.GroupKey.RawKey.PubKey.IsEqual(
+		externalKeyDesc.PubKey,
+	))
+
+	// Finally, finalize the batch, which completes the caretaker flow.
+	t.finalizeBatch(&wg, finalizeRespChan, nil)
+	t.assertBatchProgressing()
+	t.assertNoPendingBatch()
+
+	sendConfNtfn := t.progressCaretaker(true, nil, nil)
+	t.assertFinalizeBatch(&wg, finalizeRespChan, "")
+
+	t.assertSeedlingsMatchSprouts(seedlings)
+
+	sendConfNtfn()
+
+	t.assertNumCaretakersActive(0)
+	t.assertLastBatchState(1, tapgarden.BatchStateFinalized)
+}
+
+// testVanityAssetID tests that a batch can be funded such that the ID of one
+// of its assets starts with a requested prefix.
+func testVanityAssetID(t *mintingTestHarness) {
+	// First, create a new chain planter instance using the supplied test
+	// harness.
+	t.refreshChainPlanter()
+
+	var (
+		wg       sync.WaitGroup
+		respChan = make(chan *FundBatchResp, 1)
+		feeRate  = chainfee.FeePerKwFloor * 2
+	)
+
+	const numSeedlings = 2
+	seedlings := t.newRandSeedlings(numSeedlings)
+	vanityAsset := seedlings[1].AssetName
+
+	t.queueSeedlingsInBatch(false, seedlings...)
+	t.assertPendingBatchExists(numSeedlings)
+
+	// The mock wallet funds each attempt with a different input, so the
+	// planter keeps funding until the asset ID matches. We drain the
+	// funding requests until the batch is funded.
+	drainFunding := func() func() int {
+		var (
+			attempts int
+			done     = make(chan struct{})
+			stopped  = make(chan struct{})
+		)
+		go func() {
+			defer close(stopped)
+			for {
+				select {
+				case <-t.wallet.FundPsbtSignal:
+					attempts++
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		return func() int {
+			close(done)
+			<-stopped
+			return attempts
+		}
+	}
+
+	// A search that times out immediately gives up after the first
+	// funding attempt.
+	stopDraining := drainFunding()
+	t.fundBatch(&wg, respChan, &tapgarden.FundParams{
+		FeeRate: fn.Some(feeRate),
+		VanityID: fn.Some(tapgarden.VanityIDParams{
+			AssetName: vanityAsset,
+			Prefix:    "ffff",
+			Timeout:   time.Nanosecond,
+		}),
+	})
+	t.assertFundBatch(&wg, respChan, "timed out after 1 funding attempts")
+	require.Equal(t, 1, stopDraining())
+
+	// The asset must be part of the batch.
+	t.fundBatch(&wg, respChan, &tapgarden.FundParams{
+		FeeRate: fn.Some(feeRate),
+		VanityID: fn.Some(tapgarden.VanityIDParams{
+			AssetName: "unknown",
+			Prefix:    "ab",
+		}),
+	})
+	t.assertFundBatch(&wg, respChan, "vanity asset unknown not found")
+
+	// A prefix of a single character is found quickly.
+	const prefix = "A"
+	stopDraining = drainFunding()
+	t.fundBatch(&wg, respChan, &tapgarden.FundParams{
+		FeeRate: fn.Some(feeRate),
+		VanityID: fn.Some(tapgarden.VanityIDParams{
+			AssetName: vanityAsset,
+			Prefix:    prefix,
+		}),
+	})
+	fundedBatch := t.assertFundBatch(&wg, respChan, "")
+	require.GreaterOrEqual(t, stopDraining(), 1)
+
+	// The mock wallet doesn't report a change output, so the anchor
+	// output is always the first output.
+	genesisPoint := fundedBatch.GenesisPacket.Pkt.UnsignedTx.TxIn[0].
+		PreviousOutPoint
+	vanityGenesis := fundedBatch.Seedlings[vanityAsset].Genesis(
+		genesisPoint, 0,
+	)
+	vanityID := vanityGenesis.ID()
+	require.True(t, strings.HasPrefix(
+		hex.EncodeToString(vanityID[:]), strings.ToLower(prefix),
+	))
+
+	// The funded genesis TX is persisted, so the asset keeps its ID.
+	batches, err := t.planter.ListBatches(tapgarden.ListBatchesParams{})
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	persistedTx := batches[0].GenesisPacket.Pkt.UnsignedTx
+	require.Equal(t, genesisPoint, persistedTx.TxIn[0].PreviousOutPoint)
+
+	// Once funded, a vanity asset ID can't be requested anymore.
+	_, err = t.planter.EstimateBatch(tapgarden.FinalizeParams{
+		VanityID: fn.Some(tapgarden.VanityIDParams{
+			AssetName: vanityAsset,
+			Prefix:    prefix,
+		}),
+	})
+	require.ErrorContains(t, err, "batch already funded")
+}
+
+// newCollectionEditions returns the given number of editions with random
+// names and JSON metadata.
+func (t *mintingTestHarness) newCollectionEditions(
+	numEditions int) []tapgarden.CollectionEdition {
+
+	editions := make([]tapgarden.CollectionEdition, numEditions)
+	for i := range editions {
+		var n [16]byte
+		test.RandRead(t, n[:])
+
+		editions[i] = tapgarden.CollectionEdition{
+			Name: hex.EncodeToString(n[:]),
+			Meta: &proof.MetaReveal{
+				Type: proof.MetaJson,
+				Data: []byte(fmt.Sprintf(`{"edition": %d}`, i)),
+			},
+		}
+	}
+
+	return editions
+}
+
+// mintCollection adds a collection to the pending batch in the background and
+// unblocks the expected key derivations and funding.
+func (t *mintingTestHarness) mintCollection(params tapgarden.CollectionParams,
+	numKeys int, fund bool) (*tapgarden.CollectionResult, error) {
+
+	t.Helper()
+
+	type collectionResp struct {
+		result *tapgarden.CollectionResult
+		err    error
+	}
+	respChan := make(chan collectionResp, 1)
+	go func() {
+		result, err := t.planter.MintCollection(params)
+		respChan <- collectionResp{result, err}
+	}()
+
+	for i := 0; i < numKeys; i++ {
+		t.assertKeyDerived()
+	}
+
+	if fund {
+		_, err := fn.RecvOrTimeout(
+			t.wallet.FundPsbtSignal, defaultTimeout,
+		)
+		require.NoError(t, err)
+	}
+
+	resp, err := fn.RecvOrTimeout(respChan, defaultTimeout)
+	require.NoError(t, err)
+
+	return resp.result, resp.err
+}
+
+func testMintCollection(t *mintingTestHarness) {
+	// First, create a new chain planter instance using the supplied test
+	// harness.
+	t.refreshChainPlanter()
+
+	feeRate := fn.Some(chainfee.FeePerKwFloor * 2)
+
+	// Invalid collections are rejected before anything is added to a
+	// batch.
+	_, err := t.mintCollection(tapgarden.CollectionParams{}, 0, false)
+	require.ErrorContains(t, err, "at least one edition")
+
+	editions := t.newCollectionEditions(3)
+	_, err = t.mintCollection(tapgarden.CollectionParams{
+		Editions: []tapgarden.CollectionEdition{
+			editions[0], editions[1], editions[0],
+		},
+	}, 0, false)
+	require.ErrorContains(t, err, "duplicate edition name")
+	t.assertNoPendingBatch()
+
+	// A new collection derives the batch key, a script key per edition
+	// and the group internal key of the first edition. The batch is then
+	// funded.
+	result, err := t.mintCollection(tapgarden.CollectionParams{
+		Editions: editions,
+		FeeRate:  feeRate,
+	}, len(editions)+2, true)
+	require.NoError(t, err)
+
+	batch := result.Batch
+	require.True(t, batch.IsFunded())
+	require.Len(t, batch.Seedlings, len(editions))
+	require.Len(t, result.Editions, len(editions))
+
+	// The mock wallet doesn't report a change output, so the anchor
+	// output is always the first output.
+	genesisPoint := batch.GenesisPacket.Pkt.UnsignedTx.TxIn[0].
+		PreviousOutPoint
+
+	anchorName := editions[0].Name
+	for i, edition := range editions {
+		seedling := batch.Seedlings[edition.Name]
+		require.NotNil(t, seedling)
+		require.Equal(t, asset.Collectible, seedling.AssetType)
+		require.EqualValues(t, 1, seedling.Amount)
+		require.Equal(t, edition.Meta, seedling.Meta)
+
+		// The first edition anchors the group of the collection.
+		if i == 0 {
+			require.True(t, seedling.EnableEmission)
+			require.Nil(t, seedling.GroupAnchor)
+		} else {
+			require.False(t, seedling.EnableEmission)
+			require.Equal(t, anchorName, *seedling.GroupAnchor)
+		}
+
+		require.Equal(t, edition.Name, result.Editions[i].Name)
+		require.Equal(
+			t, seedling.Genesis(genesisPoint, 0).ID(),
+			result.Editions[i].AssetID,
+		)
+	}
+
+	// Names must be unique within the batch.
+	_, err = t.mintCollection(tapgarden.CollectionParams{
+		Editions: editions[:1],
+	}, 0, false)
+	require.ErrorContains(t, err, "already in batch")
+
+	// A second collection is added to the funded batch without funding it
+	// again, so its assets share the genesis outpoint.
+	moreEditions := t.newCollectionEditions(2)
+	result, err = t.mintCollection(tapgarden.CollectionParams{
+		Editions: moreEditions,
+	}, len(moreEditions)+1, false)
+	require.NoError(t, err)
+	require.Len(t, result.Batch.Seedlings, len(editions)+len(moreEditions))
+
+	for i, edition := range moreEditions {
+		seedling := result.Batch.Seedlings[edition.Name]
+		require.Equal(
+			t, seedling.Genesis(genesisPoint, 0).ID(),
+			result.Editions[i].AssetID,
+		)
+	}
+
+	t.assertPendingBatchExists(len(editions) + len(moreEditions))
+}
+
 func testFundSealOnRestart(t *mintingTestHarness) {
 	// First, create a new chain planter instance using the supplied test
 	// harness.
@@ -2002,6 +2395,215 @@ func testFundSealOnRestart(t *mintingTestHarness) {
 	t.assertLastBatchState(batchCount, tapgarden.BatchStateFinalized)
 }
 
+// testEstimateBatch tests that a dry run of the batch finalization estimates
+// the genesis transaction without funding or finalizing the batch.
+func testEstimateBatch(t *mintingTestHarness) {
+	// First, create a new chain planter instance using the supplied test
+	// harness.
+	t.refreshChainPlanter()
+
+	// Estimating without a pending batch should fail.
+	_, err := t.planter.EstimateBatch(tapgarden.FinalizeParams{})
+	require.ErrorContains(t, err, "no pending batch")
+
+	// Create an initial batch of 5 seedlings.
+	const numSeedlings = 5
+	seedlings := t.queueInitialBatch(numSeedlings)
+
+	type estimateResp struct {
+		estimate *tapgarden.BatchEstimate
+		err      error
+	}
+
+	// Estimate the unfunded batch with a manual fee rate. The planter
+	// should fund a genesis transaction just for the estimate.
+	manualFee := chainfee.FeePerKwFloor * 2
+	respChan := make(chan estimateResp, 1)
+	go func() {
+		estimate, err := t.planter.EstimateBatch(
+			tapgarden.FinalizeParams{
+				FeeRate: fn.Some(manualFee),
+			},
+		)
+		respChan <- estimateResp{estimate, err}
+	}()
+
+	genesisPkt := t.assertGenesisTxFunded(&manualFee)
+	resp, err := fn.RecvOrTimeout(respChan, defaultTimeout)
+	require.NoError(t, err)
+	require.NoError(t, resp.err)
+
+	// Our genesis TX in unit tests is always 1 P2TR in, 1 P2TR out & 1
+	// P2WSH out, which has a weight of 616 WU once signed.
+	estimate := resp.estimate
+	genesisPoint := genesisPkt.Pkt.UnsignedTx.TxIn[0].PreviousOutPoint
+	require.False(t, estimate.Funded)
+	require.EqualValues(t, 616, estimate.Weight)
+	require.EqualValues(t, 154, estimate.VSize)
+	require.Equal(t, manualFee, estimate.FeeRate)
+	require.Equal(t, t.assertBatchGenesisTx(genesisPkt), estimate.Fee)
+	require.Equal(t, genesisPoint, estimate.GenesisOutPoint)
+	require.EqualValues(t, 0, estimate.AnchorOutputIndex)
+	require.Len(t, estimate.Seedlings, numSeedlings)
+
+	seedlingsByName := make(map[string]*tapgarden.Seedling)
+	for _, seedling := range seedlings {
+		seedlingsByName[seedling.AssetName] = seedling
+	}
+
+	for idx, seedlingEstimate := range estimate.Seedlings {
+		if idx > 0 {
+			require.Less(
+				t, estimate.Seedlings[idx-1].AssetName,
+				seedlingEstimate.AssetName,
+			)
+		}
+
+		seedling := seedlingsByName[seedlingEstimate.AssetName]
+		require.NotNil(t, seedling)
+		require.Equal(t, seedling.Amount, seedlingEstimate.Amount)
+		require.Equal(
+			t, seedling.Genesis(genesisPoint, 0).ID(),
+			seedlingEstimate.AssetID,
+		)
+	}
+
+	// The dry run must not have funded the pending batch.
+	pendingBatch, err := t.planter.PendingBatch()
+	require.NoError(t, err)
+	require.False(t, pendingBatch.IsFunded())
+	t.assertLastBatchState(1, tapgarden.BatchStatePending)
+
+	// Now we fund the batch. An estimate of the funded batch should use
+	// its genesis transaction and report its effective fee rate.
+	var (
+		wg           sync.WaitGroup
+		fundRespChan = make(chan *FundBatchResp, 1)
+	)
+	t.fundBatch(&wg, fundRespChan, &tapgarden.FundParams{
+		FeeRate: fn.Some(manualFee),
+	})
+	fundedPkt := t.assertGenesisTxFunded(&manualFee)
+	t.assertFundBatch(&wg, fundRespChan, "")
+
+	estimate, err = t.planter.EstimateBatch(tapgarden.FinalizeParams{})
+	require.NoError(t, err)
+
+	fundedFee := t.assertBatchGenesisTx(fundedPkt)
+	require.True(t, estimate.Funded)
+	require.Equal(t, fundedFee, estimate.Fee)
+	require.Equal(
+		t, chainfee.NewSatPerKWeight(fundedFee, estimate.Weight),
+		estimate.FeeRate,
+	)
+	require.Equal(
+		t, fundedPkt.Pkt.UnsignedTx.TxIn[0].PreviousOutPoint,
+		estimate.GenesisOutPoint,
+	)
+
+	// Finalize parameters are rejected for a funded batch, just like when
+	// finalizing it.
+	_, err = t.planter.EstimateBatch(tapgarden.FinalizeParams{
+		FeeRate: fn.Some(manualFee),
+	})
+	require.ErrorContains(t, err, "batch already funded")
+
+	// The batch itself should still be pending.
+	t.assertPendingBatchExists(numSeedlings)
+	t.assertLastBatchState(1, tapgarden.BatchStatePending)
+}
+
+// assertAutoFinalized asserts that the planter automatically finalized the
+// pending batch with the fee rate estimated by the mock chain bridge, and
+// progresses the caretaker of the batch to completion.
+func (t *mintingTestHarness) assertAutoFinalized() {
+	t.Helper()
+
+	// The planter first estimates the fee rate to check it against the
+	// fee rate ceiling of the trigger, and then funds the batch with it.
+	_, err := fn.RecvOrTimeout(t.chain.FeeEstimateSignal, defaultTimeout)
+	require.NoError(t, err)
+
+	feeRate := chainfee.FeePerKwFloor
+	sendConfNtfn := t.progressCaretaker(false, nil, &feeRate)
+	sendConfNtfn()
+
+	// Once the caretaker is done, the batch should be finalized.
+	t.assertNoError()
+	t.assertNumCaretakersActive(0)
+	t.assertNoPendingBatch()
+	t.assertLastBatchState(
+		t.numBatches(), tapgarden.BatchStateFinalized,
+	)
+}
+
+// numBatches returns the number of batches in the minting store.
+func (t *mintingTestHarness) numBatches() int {
+	batches, err := t.store.FetchAllBatches(context.Background())
+	require.NoError(t, err)
+
+	return len(batches)
+}
+
+// testAutoFinalizeBatch tests that the planter finalizes the pending batch
+// automatically once one of the configured triggers fires, unless the fee rate
+// exceeds the fee rate ceiling of the trigger.
+func testAutoFinalizeBatch(t *mintingTestHarness) {
+	// We start with a planter that finalizes a batch once it has three
+	// seedlings, but only at a fee rate of at most 1 sat/vB. The mock
+	// chain bridge estimates the fee floor, which is slightly above that.
+	t.autoFinalize = tapgarden.AutoFinalizeConfig{
+		SeedlingCount:           3,
+		SeedlingCountMaxFeeRate: 1,
+		CheckInterval:           time.Hour,
+	}
+	t.refreshChainPlanter()
+
+	// The third seedling fires the trigger, but the batch should be kept
+	// pending because of the fee rate ceiling.
+	t.queueSeedlingsInBatch(false, t.newRandSeedlings(3)...)
+	_, err := fn.RecvOrTimeout(t.chain.FeeEstimateSignal, defaultTimeout)
+	require.NoError(t, err)
+	t.assertPendingBatchExists(3)
+
+	// Without a fee rate ceiling, a new batch is finalized once it has
+	// three seedlings.
+	t.cancelMintingBatch(false)
+	t.autoFinalize.SeedlingCountMaxFeeRate = 0
+	t.refreshChainPlanter()
+
+	t.queueSeedlingsInBatch(false, t.newRandSeedlings(3)...)
+	t.assertAutoFinalized()
+
+	// Next, we finalize batches once their oldest seedling is ten minutes
+	// old. Seedling age is checked every minute.
+	t.autoFinalize = tapgarden.AutoFinalizeConfig{
+		SeedlingAge:   10 * time.Minute,
+		CheckInterval: time.Minute,
+	}
+	t.refreshChainPlanter()
+
+	startTime := t.clock.Now()
+	t.queueSeedlingsInBatch(false, t.newRandSeedlings(2)...)
+	t.assertPendingBatchExists(2)
+
+	t.clock.SetTime(startTime.Add(10 * time.Minute))
+	t.assertAutoFinalized()
+
+	// Finally, we finalize batches at the top of every hour.
+	t.autoFinalize = tapgarden.AutoFinalizeConfig{
+		Schedule: "0 * * * *",
+	}
+	t.clock.SetTime(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC))
+	t.refreshChainPlanter()
+
+	t.queueSeedlingsInBatch(false, t.newRandSeedlings(2)...)
+	t.assertPendingBatchExists(2)
+
+	t.clock.SetTime(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC))
+	t.assertAutoFinalized()
+}
+
 // mintingStoreTestCase is used to programmatically run a series of test cases
 // that are parametrized based on a fresh minting store.
 type mintingStoreTestCase struct {
@@ -2039,6 +2641,26 @@ var testCases = []mintingStoreTestCase{
 		name:     "fund_seal_on_restart",
 		testFunc: testFundSealOnRestart,
 	},
+	{
+		name:     "estimate_batch",
+		testFunc: testEstimateBatch,
+	},
+	{
+		name:     "external_group_key_signing",
+		testFunc: testExternalGroupKeySigning,
+	},
+	{
+		name:     "vanity_asset_id",
+		testFunc: testVanityAssetID,
+	},
+	{
+		name:     "mint_collection",
+		testFunc: testMintCollection,
+	},
+	{
+		name:     "auto_finalize_batch",
+		testFunc: testAutoFinalizeBatch,
+	},
 }
 
 // TestBatchedAssetIssuance runs a test of tests to ensure that the set of
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
@@ -55,6 +56,82 @@ func (a *anchorTxNotification) firstRegistration() *proofRegistration {
 	return a.proofsRegistrations[0]
 }
 
+// AnchorTxReOrgStatus is the status of an anchor transaction that was affected
+// by a re-org.
+type AnchorTxReOrgStatus uint8
+
+const (
+	// AnchorTxOrphaned indicates that the block the anchor transaction was
+	// confirmed in was re-organized out of the chain. The proofs of the
+	// transaction are unconfirmed until it is mined again.
+	AnchorTxOrphaned AnchorTxReOrgStatus = 0
+
+	// AnchorTxReAnchored indicates that the anchor transaction was
+	// confirmed in a new block after a re-org and the proofs of the
+	// transaction were updated to the new block.
+	AnchorTxReAnchored AnchorTxReOrgStatus = 1
+)
+
+// String returns a human-readable string for the re-org status.
+func (s AnchorTxReOrgStatus) String() string {
+	switch s {
+	case AnchorTxOrphaned:
+		return "orphaned"
+
+	case AnchorTxReAnchored:
+		return "re-anchored"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", s)
+	}
+}
+
+// AnchorTxReOrgEvent is an event that is sent to subscribers of the re-org
+// watcher whenever a watched anchor transaction is affected by a re-org.
+type AnchorTxReOrgEvent struct {
+	// timestamp is the time the event was created.
+	timestamp time.Time
+
+	// Txid is the hash of the anchor transaction.
+	Txid chainhash.Hash
+
+	// Status is the re-org status of the anchor transaction.
+	Status AnchorTxReOrgStatus
+
+	// BlockHash is the hash of the block the anchor transaction was
+	// confirmed in. For an orphaned transaction this is the block that was
+	// re-organized out of the chain, for a re-anchored transaction the new
+	// block.
+	BlockHash chainhash.Hash
+
+	// BlockHeight is the height of the block above.
+	BlockHeight uint32
+
+	// NumProofs is the number of watched proofs that are anchored in the
+	// transaction.
+	NumProofs int
+}
+
+// Timestamp returns the timestamp of the event.
+func (e *AnchorTxReOrgEvent) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// NewAnchorTxReOrgEvent creates a new AnchorTxReOrgEvent.
+func NewAnchorTxReOrgEvent(txid chainhash.Hash, status AnchorTxReOrgStatus,
+	blockHash chainhash.Hash, blockHeight uint32,
+	numProofs int) *AnchorTxReOrgEvent {
+
+	return &AnchorTxReOrgEvent{
+		timestamp:   time.Now().UTC(),
+		Txid:        txid,
+		Status:      status,
+		BlockHash:   blockHash,
+		BlockHeight: blockHeight,
+		NumProofs:   numProofs,
+	}
+}
+
 // ReOrgWatcherConfig houses all the items that the re-org watcher needs to
 // carry out its duties.
 type ReOrgWatcherConfig struct {
@@ -75,6 +152,10 @@ type ReOrgWatcherConfig struct {
 	NonBuriedAssetFetcher func(ctx context.Context,
 		minHeight int32) ([]*asset.ChainAsset, error)
 
+	// ReOrgLog is used to persist the re-org state of watched anchor
+	// transactions. If it isn't set, the state is only kept in memory.
+	ReOrgLog AnchorTxReOrgLog
+
 	// SafeDepth is the number of confirmations we require before we
 	// consider a transaction to be safely buried in the chain.
 	SafeDepth int32
@@ -97,11 +178,21 @@ type ReOrgWatcher struct {
 
 	incomingProofs chan *proofRegistration
 	incomingConfs  chan *chainntnfs.TxConfirmation
+	incomingReOrgs chan chainhash.Hash
 
 	// pendingProofs is a list of all proofs that are currently being
 	// watched for re-orgs, keyed by their anchor transaction hash.
 	pendingProofs map[chainhash.Hash]*anchorTxNotification
 
+	// orphaned is the set of watched anchor transactions of which the
+	// block was re-organized out of the chain and that weren't mined
+	// again yet.
+	orphaned map[chainhash.Hash]struct{}
+
+	// eventDistributor is used to notify subscribers about anchor
+	// transactions that are affected by a re-org.
+	eventDistributor *fn.EventDistributor[fn.Event]
+
 	// ContextGuard provides a wait group and main quit channel that can be
 	// used to create guarded contexts.
 	*fn.ContextGuard
@@ -109,11 +200,15 @@ type ReOrgWatcher struct {
 
 // NewReOrgWatcher creates a new re-org watcher based on the passed config.
 func NewReOrgWatcher(cfg *ReOrgWatcherConfig) *ReOrgWatcher {
+	pendingProofs := make(map[chainhash.Hash]*anchorTxNotification)
 	return &ReOrgWatcher{
-		cfg:            cfg,
-		incomingProofs: make(chan *proofRegistration),
-		incomingConfs:  make(chan *chainntnfs.TxConfirmation),
-		pendingProofs:  make(map[chainhash.Hash]*anchorTxNotification),
+		cfg:              cfg,
+		incomingProofs:   make(chan *proofRegistration),
+		incomingConfs:    make(chan *chainntnfs.TxConfirmation),
+		incomingReOrgs:   make(chan chainhash.Hash),
+		pendingProofs:    pendingProofs,
+		orphaned:         make(map[chainhash.Hash]struct{}),
+		eventDistributor: fn.NewEventDistributor[fn.Event](),
 		ContextGuard: &fn.ContextGuard{
 			DefaultTimeout: DefaultTimeout,
 			Quit:           make(chan struct{}),
@@ -127,6 +222,31 @@ func (w *ReOrgWatcher) Start() error {
 	w.startOnce.Do(func() {
 		log.Info("Starting re-org watcher")
 
+		ctx, cancel := w.WithCtxQuitNoTimeout()
+		defer cancel()
+
+		// Before we start the main event handler loop, we load the
+		// anchor transactions that were re-organized out of the chain
+		// and weren't mined again before we shut down.
+		if w.cfg.ReOrgLog != nil {
+			orphanedTxs, err := w.cfg.ReOrgLog.OrphanedAnchorTxs(
+				ctx,
+			)
+			if err != nil {
+				startErr = fmt.Errorf("unable to fetch "+
+					"orphaned anchor txs: %w", err)
+				return
+			}
+
+			for _, orphanedTx := range orphanedTxs {
+				log.Infof("Anchor TX %v is still orphaned "+
+					"after re-org of block %v",
+					orphanedTx.Txid, orphanedTx.BlockHash)
+
+				w.orphaned[orphanedTx.Txid] = struct{}{}
+			}
+		}
+
 		// Start the main event handler loop that will process new
 		// proofs and watch their anchor transactions until they reach
 		// a safe confirmation depth.
@@ -136,9 +256,6 @@ func (w *ReOrgWatcher) Start() error {
 		// Now that we have started the watcher, we can load all assets
 		// that are not yet sufficiently deep buried and watch their
 		// anchor transactions for re-orgs.
-		ctx, cancel := w.WithCtxQuitNoTimeout()
-		defer cancel()
-
 		currentHeight, err := w.cfg.ChainBridge.CurrentHeight(ctx)
 		if err != nil {
 			startErr = fmt.Errorf("unable to get current "+
@@ -263,19 +380,21 @@ func (w *ReOrgWatcher) waitForConf(ctx context.Context, txHash chainhash.Hash,
 				case <-w.Quit:
 				}
 
-			// If the transaction was re-organized out, we need to
-			// update the proof and store it in the proof archive.
+			// If the transaction was re-organized out, we mark
+			// its proofs as unconfirmed. We continue to watch the
+			// transaction, as we expect another confirmation to
+			// come in once the transaction is included in a new
+			// block in the re-organized chain, which is when the
+			// proofs are updated.
 			case <-reOrgChan:
 				log.Infof("Anchor TX %v was re-organized out "+
 					"of the chain, will update proof "+
 					"with next confirmation", txHash)
 
-				// We continue to watch the transaction until
-				// it reaches a safe confirmation depth. We
-				// basically only use this signal to log the
-				// message above. We expect another confirmation
-				// to come in once the transaction is included
-				// in a new block in the re-organized chain.
+				select {
+				case w.incomingReOrgs <- txHash:
+				case <-w.Quit:
+				}
 
 			case err := <-errChan:
 				if !fn.IsRpcErr(
@@ -345,6 +464,78 @@ func (w *ReOrgWatcher) updateProofs(proofNtfn *anchorTxNotification,
 	return nil
 }
 
+// orphan marks the given anchor transaction as re-organized out of the chain
+// and notifies the subscribers about it.
+func (w *ReOrgWatcher) orphan(txHash chainhash.Hash,
+	txNtfn *anchorTxNotification) error {
+
+	if _, ok := w.orphaned[txHash]; ok {
+		return nil
+	}
+
+	if w.cfg.ReOrgLog != nil {
+		ctxt, cancel := w.CtxBlocking()
+		defer cancel()
+
+		err := w.cfg.ReOrgLog.LogAnchorTxOrphaned(ctxt, txHash)
+		if err != nil {
+			return fmt.Errorf("unable to log orphaned anchor TX "+
+				"%v: %w", txHash, err)
+		}
+	}
+
+	w.orphaned[txHash] = struct{}{}
+
+	firstReg := txNtfn.firstRegistration()
+	w.eventDistributor.NotifySubscribers(NewAnchorTxReOrgEvent(
+		txHash, AnchorTxOrphaned, firstReg.blockHash,
+		uint32(firstReg.blockHeight), txNtfn.numProofs(),
+	))
+
+	return nil
+}
+
+// reAnchor records the new confirmation of the given anchor transaction after
+// a re-org, clears its orphaned state and notifies the subscribers about it.
+func (w *ReOrgWatcher) reAnchor(txNtfn *anchorTxNotification,
+	conf *chainntnfs.TxConfirmation) error {
+
+	txHash := conf.Tx.TxHash()
+	confHash := *conf.BlockHash
+
+	if w.cfg.ReOrgLog != nil {
+		ctxt, cancel := w.CtxBlocking()
+		defer cancel()
+
+		err := w.cfg.ReOrgLog.LogAnchorTxConfirmed(
+			ctxt, txHash, confHash, conf.BlockHeight, conf.TxIndex,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to log confirmation of "+
+				"anchor TX %v: %w", txHash, err)
+		}
+	}
+
+	delete(w.orphaned, txHash)
+
+	// The proofs are now anchored in the new block, which is also the
+	// block we count the confirmations from.
+	for _, r := range txNtfn.proofsRegistrations {
+		r.blockHash = confHash
+		r.blockHeight = int32(conf.BlockHeight)
+	}
+
+	log.Infof("Anchor TX %v was re-anchored in block %v at height %d",
+		txHash, confHash, conf.BlockHeight)
+
+	w.eventDistributor.NotifySubscribers(NewAnchorTxReOrgEvent(
+		txHash, AnchorTxReAnchored, confHash, conf.BlockHeight,
+		txNtfn.numProofs(),
+	))
+
+	return nil
+}
+
 // watchTransactions processes new proofs given to the watcher and watches their
 // anchor transactions until they reach a safe confirmation depth.
 func (w *ReOrgWatcher) watchTransactions() {
@@ -425,11 +616,14 @@ func (w *ReOrgWatcher) watchTransactions() {
 
 			// Do we actually have a different block than the one
 			// we already have in the proof? If not, we can just
-			// ignore this confirmation (this should not happen in
-			// normal circumstances and would be an indication of
-			// the chain backend being misconfigured).
+			// ignore this confirmation, unless the transaction was
+			// orphaned and is now confirmed in the same block
+			// again (this should not happen in normal
+			// circumstances and would be an indication of the
+			// chain backend being misconfigured).
 			firstReg := txNtfn.firstRegistration()
-			if firstReg.blockHash == confHash {
+			_, wasOrphaned := w.orphaned[txHash]
+			if firstReg.blockHash == confHash && !wasOrphaned {
 				log.Debugf("Anchor TX %v was already "+
 					"confirmed in block %v, ignoring "+
 					"confirmation for block %v", txHash,
@@ -439,10 +633,33 @@ func (w *ReOrgWatcher) watchTransactions() {
 
 			// We can now update the proofs with the new block and
 			// inform the caller if necessary.
-			err = w.updateProofs(txNtfn, conf)
+			if firstReg.blockHash != confHash {
+				err = w.updateProofs(txNtfn, conf)
+				if err != nil {
+					w.reportErr(fmt.Errorf("error "+
+						"updating proofs: %w", err))
+					return
+				}
+			}
+
+			err = w.reAnchor(txNtfn, conf)
+			if err != nil {
+				w.reportErr(err)
+				return
+			}
+
+		case txHash := <-w.incomingReOrgs:
+			txNtfn, ok := w.pendingProofs[txHash]
+			if !ok {
+				log.Debugf("Received re-org for anchor TX "+
+					"we're (no longer?) watching: %v",
+					txHash)
+				continue
+			}
+
+			err := w.orphan(txHash, txNtfn)
 			if err != nil {
-				w.reportErr(fmt.Errorf("error updating "+
-					"proofs: %w", err))
+				w.reportErr(err)
 				return
 			}
 
@@ -451,6 +668,12 @@ func (w *ReOrgWatcher) watchTransactions() {
 			w.bestHeight.Store(newBlock)
 
 			for txid := range w.pendingProofs {
+				// An orphaned transaction needs to be watched
+				// until it is mined again.
+				if _, ok := w.orphaned[txid]; ok {
+					continue
+				}
+
 				proofNtfn := w.pendingProofs[txid]
 				firstReg := proofNtfn.firstRegistration()
 				confs := newBlock - firstReg.blockHeight
@@ -597,6 +820,24 @@ func (w *ReOrgWatcher) DefaultUpdateCallback() proof.UpdateCallback {
 	}
 }
 
+// RegisterSubscriber adds a new subscriber that is notified about watched
+// anchor transactions that are affected by a re-org.
+func (w *ReOrgWatcher) RegisterSubscriber(
+	receiver *fn.EventReceiver[fn.Event], _, _ bool) error {
+
+	w.eventDistributor.RegisterSubscriber(receiver)
+
+	return nil
+}
+
+// RemoveSubscriber removes the given subscriber and stops it from processing
+// events.
+func (w *ReOrgWatcher) RemoveSubscriber(
+	subscriber *fn.EventReceiver[fn.Event]) error {
+
+	return w.eventDistributor.RemoveSubscriber(subscriber)
+}
+
 // reportErr reports an error to the main server.
 func (w *ReOrgWatcher) reportErr(err error) {
 	select {
@@ -604,3 +845,7 @@ func (w *ReOrgWatcher) reportErr(err error) {
 	case <-w.Quit:
 	}
 }
+
+// A compile-time assertion to make sure ReOrgWatcher satisfies the
+// fn.EventPublisher interface.
+var _ fn.EventPublisher[fn.Event, bool] = (*ReOrgWatcher)(nil)
@@ -2,6 +2,7 @@ package tapgarden
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -68,6 +69,78 @@ func newReOrgWatcherHarness(t *testing.T) *reOrgWatcherHarness {
 	}
 }
 
+// mockReOrgLog is an in-memory implementation of the AnchorTxReOrgLog.
+type mockReOrgLog struct {
+	sync.Mutex
+
+	orphaned map[chainhash.Hash]OrphanedAnchorTx
+
+	confirmed map[chainhash.Hash]chainhash.Hash
+}
+
+func newMockReOrgLog() *mockReOrgLog {
+	return &mockReOrgLog{
+		orphaned:  make(map[chainhash.Hash]OrphanedAnchorTx),
+		confirmed: make(map[chainhash.Hash]chainhash.Hash),
+	}
+}
+
+func (m *mockReOrgLog) LogAnchorTxOrphaned(_ context.Context,
+	txid chainhash.Hash) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.orphaned[txid] = OrphanedAnchorTx{
+		Txid:       txid,
+		DetectedAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (m *mockReOrgLog) LogAnchorTxConfirmed(_ context.Context,
+	txid chainhash.Hash, blockHash chainhash.Hash, _ uint32,
+	_ uint32) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.orphaned, txid)
+	m.confirmed[txid] = blockHash
+
+	return nil
+}
+
+func (m *mockReOrgLog) OrphanedAnchorTxs(
+	_ context.Context) ([]OrphanedAnchorTx, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	orphaned := make([]OrphanedAnchorTx, 0, len(m.orphaned))
+	for _, orphanedTx := range m.orphaned {
+		orphaned = append(orphaned, orphanedTx)
+	}
+
+	return orphaned, nil
+}
+
+func (m *mockReOrgLog) isOrphaned(txid chainhash.Hash) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	_, ok := m.orphaned[txid]
+	return ok
+}
+
+func (m *mockReOrgLog) confirmedIn(txid chainhash.Hash) chainhash.Hash {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.confirmed[txid]
+}
+
 func makeTx() *wire.MsgTx {
 	anchorTx := wire.NewMsgTx(2)
 	anchorTx.TxOut = []*wire.TxOut{{
@@ -199,3 +272,101 @@ func TestWatchProofs(t *testing.T) {
 		return len(h.w.pendingProofs) == 0
 	})
 }
+
+// TestWatchProofsOrphaned makes sure that an anchor transaction of which the
+// block is re-organized out of the chain is marked as orphaned and watched
+// until it is mined again, at which point its proofs are updated.
+func TestWatchProofsOrphaned(t *testing.T) {
+	t.Parallel()
+
+	h := newReOrgWatcherHarness(t)
+	reOrgLog := newMockReOrgLog()
+	h.cfg.ReOrgLog = reOrgLog
+
+	receiver := fn.NewEventReceiver[fn.Event](fn.DefaultQueueSize)
+	t.Cleanup(receiver.Stop)
+	require.NoError(t, h.w.RegisterSubscriber(receiver, false, false))
+
+	require.NoError(t, h.w.Start())
+	h.assertStartup()
+
+	anchorTx := makeTx()
+	txHash := anchorTx.TxHash()
+	proofs := []*proof.Proof{makeProof(anchorTx)}
+	oldBlockHash := proofs[0].BlockHeader.BlockHash()
+
+	var cbCalled atomic.Int32
+	cb := func(proofs []*proof.Proof) error {
+		cbCalled.Add(1)
+		return nil
+	}
+
+	require.NoError(t, h.w.WatchProofs(proofs, cb))
+	confReq, err := fn.RecvOrTimeout(
+		h.chainBridge.ConfReqSignal, testTimeout,
+	)
+	require.NoError(h.t, err)
+
+	// assertEvent makes sure the subscriber is notified about the given
+	// re-org status of the anchor TX.
+	assertEvent := func(status AnchorTxReOrgStatus,
+		blockHash chainhash.Hash, blockHeight uint32) {
+
+		event, err := fn.RecvOrTimeout(
+			receiver.NewItemCreated.ChanOut(), testTimeout,
+		)
+		require.NoError(t, err)
+
+		reOrgEvent, ok := (*event).(*AnchorTxReOrgEvent)
+		require.True(t, ok)
+		require.Equal(t, &AnchorTxReOrgEvent{
+			timestamp:   reOrgEvent.Timestamp(),
+			Txid:        txHash,
+			Status:      status,
+			BlockHash:   blockHash,
+			BlockHeight: blockHeight,
+			NumProofs:   1,
+		}, reOrgEvent)
+	}
+
+	// The block of the anchor TX is re-organized out of the chain, which
+	// marks the TX as orphaned.
+	h.chainBridge.ReOrgChans[*confReq] <- struct{}{}
+	assertEvent(
+		AnchorTxOrphaned, oldBlockHash, testInitialBlockHeight,
+	)
+	require.True(t, reOrgLog.isOrphaned(txHash))
+
+	// Even though the chain grows past the safe depth of the original
+	// block, the orphaned TX is still watched.
+	h.chainBridge.NewBlocks <- testInitialBlockHeight + testSafeDepth*2
+
+	// Once the TX is mined again, the proofs are updated and the TX is no
+	// longer orphaned.
+	newBlock := makeBlock(anchorTx)
+	newBlockHash := newBlock.BlockHash()
+	confEvent := h.chainBridge.ConfReqs[*confReq]
+	confEvent.Confirmed <- &chainntnfs.TxConfirmation{
+		BlockHash:   &newBlockHash,
+		BlockHeight: testReOrgBlockHeight,
+		TxIndex:     1,
+		Tx:          anchorTx,
+		Block:       newBlock,
+	}
+
+	assertEvent(
+		AnchorTxReAnchored, newBlockHash, testReOrgBlockHeight,
+	)
+	require.EqualValues(t, 1, cbCalled.Load())
+	require.False(t, reOrgLog.isOrphaned(txHash))
+	require.Equal(t, newBlockHash, reOrgLog.confirmedIn(txHash))
+	require.Equal(t, newBlock.Header, proofs[0].BlockHeader)
+
+	// The confirmations are now counted from the new block.
+	h.chainBridge.NewBlocks <- testReOrgBlockHeight + testSafeDepth
+
+	require.NoError(t, h.w.Stop())
+	h.eventually(func() bool {
+		return len(h.w.pendingProofs) == 0
+	})
+}
@@ -0,0 +1,261 @@
+package tapgarden
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// MaxVanityPrefixLen is the maximum number of hex characters of a
+	// vanity asset ID prefix. Each character multiplies the expected number
+	// of candidates by 16, while every funding attempt only yields a few
+	// candidates, so longer prefixes are not practical.
+	MaxVanityPrefixLen = 4
+
+	// DefaultVanityTimeout is the default time spent searching for a
+	// genesis that produces the requested vanity asset ID prefix.
+	DefaultVanityTimeout = 30 * time.Second
+
+	// hexChars are the characters allowed in a vanity prefix.
+	hexChars = "0123456789abcdefABCDEF"
+)
+
+var (
+	// ErrVanityIDNotFound is returned when no genesis producing the
+	// requested vanity asset ID prefix was found within the search bounds.
+	ErrVanityIDNotFound = errors.New("no genesis found for vanity asset " +
+		"ID prefix")
+)
+
+// VanityIDParams are the options for selecting the genesis of a batch such
+// that the ID of one of its assets starts with a given prefix.
+//
+// The asset ID commits to the genesis outpoint, which is the first input of
+// the genesis TX, and to the index of the anchor output. The search therefore
+// considers each funded input as the first input, and both outputs of the
+// genesis TX as the anchor output. If none of these candidates match, the
+// batch is funded again while the previous inputs are still locked, which
+// makes the wallet select different inputs. The search ends once a match is
+// found, the wallet has no more inputs to offer, or the timeout expires.
+type VanityIDParams struct {
+	// AssetName is the name of the seedling whose asset ID must start
+	// with the prefix. The seedling must be part of the batch when it is
+	// funded.
+	AssetName string
+
+	// Prefix is the case-insensitive hex prefix the asset ID must start
+	// with.
+	Prefix string
+
+	// Timeout is the maximum time spent searching. If zero, the default
+	// timeout is used.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the parameters are invalid.
+func (p *VanityIDParams) Validate() error {
+	switch {
+	case p.AssetName == "":
+		return fmt.Errorf("vanity asset name must be set")
+
+	case len(p.Prefix) == 0:
+		return fmt.Errorf("vanity prefix must be set")
+
+	case len(p.Prefix) > MaxVanityPrefixLen:
+		return fmt.Errorf("vanity prefix must be at most %d hex "+
+			"characters", MaxVanityPrefixLen)
+
+	case p.Timeout < 0:
+		return fmt.Errorf("vanity timeout must not be negative")
+	}
+
+	// The prefix may have an odd length, so we check the characters
+	// individually instead of decoding it.
+	for _, char := range p.Prefix {
+		if !strings.ContainsRune(hexChars, char) {
+			return fmt.Errorf("vanity prefix %q is not hex",
+				p.Prefix)
+		}
+	}
+
+	return nil
+}
+
+// matches returns true if the given asset ID starts with the prefix.
+func (p *VanityIDParams) matches(id asset.ID) bool {
+	return strings.HasPrefix(
+		hex.EncodeToString(id[:]), strings.ToLower(p.Prefix),
+	)
+}
+
+// genesisCandidate is a rearrangement of a funded genesis TX.
+type genesisCandidate struct {
+	// firstInput is the index of the input that becomes the first input,
+	// and therefore the genesis outpoint.
+	firstInput int
+
+	// swapOutputs indicates whether the anchor and change outputs swap
+	// places.
+	swapOutputs bool
+}
+
+// genesisCandidates returns all rearrangements of the given funded genesis TX
+// that result in a different genesis.
+func genesisCandidates(genesisPkt *tapsend.FundedPsbt) []genesisCandidate {
+	// The outputs can only swap places if we know which one is the change
+	// output.
+	canSwap := len(genesisPkt.Pkt.UnsignedTx.TxOut) == 2 &&
+		genesisPkt.ChangeOutputIndex >= 0
+
+	var candidates []genesisCandidate
+	for idx := range genesisPkt.Pkt.UnsignedTx.TxIn {
+		candidates = append(candidates, genesisCandidate{
+			firstInput: idx,
+		})
+
+		if canSwap {
+			candidates = append(candidates, genesisCandidate{
+				firstInput:  idx,
+				swapOutputs: true,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// genesis returns the genesis outpoint and anchor output index the candidate
+// results in.
+func (g genesisCandidate) genesis(
+	genesisPkt *tapsend.FundedPsbt) (wire.OutPoint, uint32) {
+
+	tx := genesisPkt.Pkt.UnsignedTx
+	genesisPoint := tx.TxIn[g.firstInput].PreviousOutPoint
+
+	anchorOutputIndex := extractAnchorOutputIndex(genesisPkt)
+	if g.swapOutputs {
+		anchorOutputIndex = 1 - anchorOutputIndex
+	}
+
+	return genesisPoint, anchorOutputIndex
+}
+
+// apply rearranges the given funded genesis TX according to the candidate.
+func (g genesisCandidate) apply(genesisPkt *tapsend.FundedPsbt) {
+	pkt := genesisPkt.Pkt
+	tx := pkt.UnsignedTx
+
+	i := g.firstInput
+	tx.TxIn[0], tx.TxIn[i] = tx.TxIn[i], tx.TxIn[0]
+	pkt.Inputs[0], pkt.Inputs[i] = pkt.Inputs[i], pkt.Inputs[0]
+
+	if g.swapOutputs {
+		tx.TxOut[0], tx.TxOut[1] = tx.TxOut[1], tx.TxOut[0]
+		pkt.Outputs[0], pkt.Outputs[1] = pkt.Outputs[1], pkt.Outputs[0]
+		genesisPkt.ChangeOutputIndex = 1 - genesisPkt.ChangeOutputIndex
+	}
+}
+
+// grindGenesisPsbt funds the genesis TX of the given batch such that the ID of
+// the asset specified by the parameters starts with the requested prefix. The
+// inputs of all funded TXs except the returned one are unlocked again.
+func (c *ChainPlanter) grindGenesisPsbt(ctx context.Context,
+	batch *MintingBatch, feeRate chainfee.SatPerKWeight,
+	params VanityIDParams) (*tapsend.FundedPsbt, error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	seedling, ok := batch.Seedlings[params.AssetName]
+	if !ok {
+		return nil, fmt.Errorf("vanity asset %v not found in batch",
+			params.AssetName)
+	}
+
+	timeout := params.Timeout
+	if timeout == 0 {
+		timeout = DefaultVanityTimeout
+	}
+	searchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		batchKey = asset.ToSerialized(batch.BatchKey.PubKey)
+		funded   []*tapsend.FundedPsbt
+		winner   *tapsend.FundedPsbt
+	)
+
+	// Release the inputs of all funded TXs that we don't use.
+	defer func() {
+		for _, genesisPkt := range funded {
+			if genesisPkt == winner {
+				continue
+			}
+
+			for _, op := range genesisPkt.LockedUTXOs {
+				err := c.cfg.Wallet.UnlockInput(ctx, op)
+				if err != nil {
+					log.Warnf("Unable to unlock input "+
+						"%v: %v", op, err)
+				}
+			}
+		}
+	}()
+
+	for attempt := 1; ; attempt++ {
+		genesisPkt, err := c.fundGenesisPsbt(
+			searchCtx, batchKey, feeRate,
+		)
+		switch {
+		// The search timed out while funding.
+		case err != nil && searchCtx.Err() != nil:
+			return nil, fmt.Errorf("%w: timed out after %d "+
+				"funding attempts", ErrVanityIDNotFound,
+				attempt-1)
+
+		// The wallet has no more inputs to offer.
+		case err != nil:
+			return nil, fmt.Errorf("%w: stopped after %d "+
+				"funding attempts: %v", ErrVanityIDNotFound,
+				attempt-1, err)
+		}
+		funded = append(funded, genesisPkt)
+
+		for _, candidate := range genesisCandidates(genesisPkt) {
+			genesisPoint, anchorOutputIndex := candidate.genesis(
+				genesisPkt,
+			)
+			genesis := seedling.Genesis(
+				genesisPoint, anchorOutputIndex,
+			)
+			if !params.matches(genesis.ID()) {
+				continue
+			}
+
+			candidate.apply(genesisPkt)
+			winner = genesisPkt
+
+			log.Infof("Found vanity asset ID %v for batch %x "+
+				"after %d funding attempts", genesis.ID(),
+				batchKey[:], attempt)
+
+			return genesisPkt, nil
+		}
+
+		if searchCtx.Err() != nil {
+			return nil, fmt.Errorf("%w: timed out after %d "+
+				"funding attempts", ErrVanityIDNotFound,
+				attempt)
+		}
+	}
+}
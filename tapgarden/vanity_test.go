@@ -0,0 +1,141 @@
+package tapgarden
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVanityIDParamsValidate tests the validation of vanity asset ID
+// parameters.
+func TestVanityIDParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		params  VanityIDParams
+		wantErr string
+	}{{
+		name: "valid",
+		params: VanityIDParams{
+			AssetName: "vanity",
+			Prefix:    "Cafe",
+		},
+	}, {
+		name: "odd length",
+		params: VanityIDParams{
+			AssetName: "vanity",
+			Prefix:    "abc",
+		},
+	}, {
+		name: "no asset name",
+		params: VanityIDParams{
+			Prefix: "ab",
+		},
+		wantErr: "asset name must be set",
+	}, {
+		name: "no prefix",
+		params: VanityIDParams{
+			AssetName: "vanity",
+		},
+		wantErr: "prefix must be set",
+	}, {
+		name: "prefix too long",
+		params: VanityIDParams{
+			AssetName: "vanity",
+			Prefix:    "abcde",
+		},
+		wantErr: "at most 4 hex characters",
+	}, {
+		name: "not hex",
+		params: VanityIDParams{
+			AssetName: "vanity",
+			Prefix:    "xy",
+		},
+		wantErr: "is not hex",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.params.Validate()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestGenesisCandidates tests that every candidate of a funded genesis TX
+// results in the genesis it was selected for once applied.
+func TestGenesisCandidates(t *testing.T) {
+	t.Parallel()
+
+	inputs := []wire.OutPoint{
+		test.RandOp(t), test.RandOp(t), test.RandOp(t),
+	}
+	newFundedPsbt := func(changeIdx int32) *tapsend.FundedPsbt {
+		tx := wire.NewMsgTx(2)
+		for _, op := range inputs {
+			tx.AddTxIn(&wire.TxIn{
+				PreviousOutPoint: op,
+			})
+		}
+		tx.AddTxOut(tapsend.CreateDummyOutput())
+		tx.AddTxOut(&wire.TxOut{Value: 1000})
+
+		pkt, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+
+		return &tapsend.FundedPsbt{
+			Pkt:               pkt,
+			ChangeOutputIndex: changeIdx,
+		}
+	}
+
+	// Without a known change output, only the inputs are rearranged.
+	candidates := genesisCandidates(newFundedPsbt(-1))
+	require.Len(t, candidates, 3)
+
+	candidates = genesisCandidates(newFundedPsbt(1))
+	require.Len(t, candidates, 6)
+
+	seenGenesis := make(map[asset.ID]struct{})
+	for _, candidate := range candidates {
+		genesisPkt := newFundedPsbt(1)
+		genesisPkt.Pkt.Inputs[candidate.firstInput].SighashType = 1
+
+		genesisPoint, anchorOutputIndex := candidate.genesis(genesisPkt)
+		candidate.apply(genesisPkt)
+
+		// Once applied, the genesis is extracted from the TX as usual,
+		// and the PSBT inputs still match the TX inputs.
+		tx := genesisPkt.Pkt.UnsignedTx
+		require.Equal(t, genesisPoint, extractGenesisOutpoint(tx))
+		require.Equal(
+			t, anchorOutputIndex,
+			extractAnchorOutputIndex(genesisPkt),
+		)
+		require.EqualValues(t, 1, genesisPkt.Pkt.Inputs[0].SighashType)
+		require.Equal(
+			t, tapsend.GenesisDummyScript,
+			tx.TxOut[anchorOutputIndex].PkScript,
+		)
+
+		genesis := asset.Genesis{
+			FirstPrevOut: genesisPoint,
+			OutputIndex:  anchorOutputIndex,
+		}
+		seenGenesis[genesis.ID()] = struct{}{}
+	}
+
+	// Each candidate results in a different asset ID.
+	require.Len(t, seenGenesis, len(candidates))
+}
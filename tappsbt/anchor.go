@@ -0,0 +1,143 @@
+package tappsbt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// psbtKeyTypeProprietary is the BIP-0174 key type of proprietary
+	// fields, which is the same for the global, input and output maps.
+	psbtKeyTypeProprietary = 0xfc
+
+	// PsbtProprietarySubtypeOutputTaprootMerkleRoot is the proprietary
+	// subtype of the Taproot Merkle root of an anchor output. This is the
+	// tweak that is applied to the internal key of the output.
+	PsbtProprietarySubtypeOutputTaprootMerkleRoot = 0x00
+
+	// PsbtProprietarySubtypeOutputAssetRoot is the proprietary subtype of
+	// the Taproot Asset commitment root hash of an anchor output.
+	PsbtProprietarySubtypeOutputAssetRoot = 0x01
+)
+
+// PsbtProprietaryIdentifier is the identifier of the BIP-0174 proprietary
+// fields used on BTC level anchor transaction PSBTs that are exported for
+// signing with an external signer.
+var PsbtProprietaryIdentifier = []byte("tap")
+
+// anchorOutputFields maps the custom fields of anchor outputs to the subtypes
+// of the proprietary fields they're exported as.
+var anchorOutputFields = []struct {
+	key     []byte
+	subtype uint64
+}{{
+	key:     PsbtKeyTypeOutputTaprootMerkleRoot,
+	subtype: PsbtProprietarySubtypeOutputTaprootMerkleRoot,
+}, {
+	key:     PsbtKeyTypeOutputAssetRoot,
+	subtype: PsbtProprietarySubtypeOutputAssetRoot,
+}}
+
+// ProprietaryKey returns the key of the BIP-0174 proprietary field with the
+// Taproot Assets identifier and the given subtype.
+func ProprietaryKey(subtype uint64) []byte {
+	var b bytes.Buffer
+	b.WriteByte(psbtKeyTypeProprietary)
+
+	// Writing to a bytes.Buffer never fails.
+	_ = wire.WriteVarInt(&b, 0, uint64(len(PsbtProprietaryIdentifier)))
+	b.Write(PsbtProprietaryIdentifier)
+	_ = wire.WriteVarInt(&b, 0, subtype)
+
+	return b.Bytes()
+}
+
+// ExportAnchorPsbt returns a copy of the given BTC level anchor transaction
+// PSBT that can be signed with an external signer, like a hardware wallet.
+// External signers and the software around them may strip unknown fields, so
+// the custom fields of the anchor outputs are replaced by BIP-0174 proprietary
+// fields. This includes the Taproot Merkle root that tweaks the internal key of
+// each anchor output, which allows a signer to verify the anchor outputs it
+// holds the internal key of. The asset anchor inputs only use standard fields
+// already.
+func ExportAnchorPsbt(packet *psbt.Packet) (*psbt.Packet, error) {
+	exported, err := copyPsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range exported.Outputs {
+		pOut := &exported.Outputs[idx]
+		for _, field := range anchorOutputFields {
+			value := ExtractCustomField(pOut.Unknowns, field.key)
+			if value == nil {
+				continue
+			}
+
+			pOut.Unknowns = removeCustomField(
+				pOut.Unknowns, field.key,
+			)
+			pOut.Unknowns = AddCustomField(
+				pOut.Unknowns, ProprietaryKey(field.subtype),
+				value,
+			)
+		}
+	}
+
+	return exported, nil
+}
+
+// ImportAnchorPsbt converts the proprietary fields of the anchor outputs of a
+// PSBT that was exported with ExportAnchorPsbt back into custom fields. PSBTs
+// without proprietary fields are left unchanged, so it's safe to call this on
+// any anchor transaction PSBT. The packet is modified in place.
+func ImportAnchorPsbt(packet *psbt.Packet) {
+	for idx := range packet.Outputs {
+		pOut := &packet.Outputs[idx]
+		for _, field := range anchorOutputFields {
+			key := ProprietaryKey(field.subtype)
+			value := ExtractCustomField(pOut.Unknowns, key)
+			if value == nil {
+				continue
+			}
+
+			pOut.Unknowns = removeCustomField(pOut.Unknowns, key)
+			pOut.Unknowns = AddCustomField(
+				pOut.Unknowns, field.key, value,
+			)
+		}
+	}
+}
+
+// removeCustomField returns the given unknown values without the custom field
+// with the given key.
+func removeCustomField(unknowns []*psbt.Unknown,
+	key []byte) []*psbt.Unknown {
+
+	var result []*psbt.Unknown
+	for _, unknown := range unknowns {
+		if !bytes.Equal(unknown.Key, key) {
+			result = append(result, unknown)
+		}
+	}
+
+	return result
+}
+
+// copyPsbt returns a deep copy of the given PSBT packet.
+func copyPsbt(packet *psbt.Packet) (*psbt.Packet, error) {
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		return nil, fmt.Errorf("error serializing PSBT: %w", err)
+	}
+
+	packetCopy, err := psbt.NewFromRawBytes(&b, false)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding PSBT: %w", err)
+	}
+
+	return packetCopy, nil
+}
@@ -0,0 +1,107 @@
+package tappsbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProprietaryKey tests the encoding of the proprietary field keys.
+func TestProprietaryKey(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(
+		t, []byte{0xfc, 0x03, 't', 'a', 'p', 0x00},
+		ProprietaryKey(PsbtProprietarySubtypeOutputTaprootMerkleRoot),
+	)
+	require.Equal(
+		t, []byte{0xfc, 0x03, 't', 'a', 'p', 0x01},
+		ProprietaryKey(PsbtProprietarySubtypeOutputAssetRoot),
+	)
+}
+
+// TestExportImportAnchorPsbt tests that the custom fields of anchor outputs
+// survive an export and import round trip, including a serialization in
+// between.
+func TestExportImportAnchorPsbt(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+	})
+	tx.AddTxOut(&wire.TxOut{Value: 1_000, PkScript: []byte{0x51}})
+	tx.AddTxOut(&wire.TxOut{Value: 2_000, PkScript: []byte{0x51}})
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+
+	merkleRoot := test.RandBytes(32)
+	assetRoot := test.RandBytes(32)
+	otherField := &psbt.Unknown{Key: []byte{0x42}, Value: []byte{1}}
+	pOut := &packet.Outputs[0]
+	pOut.TaprootInternalKey = schnorr.SerializePubKey(test.RandPubKey(t))
+	pOut.Unknowns = AddCustomField(
+		pOut.Unknowns, PsbtKeyTypeOutputTaprootMerkleRoot, merkleRoot,
+	)
+	pOut.Unknowns = AddCustomField(
+		pOut.Unknowns, PsbtKeyTypeOutputAssetRoot, assetRoot,
+	)
+	pOut.Unknowns = append(pOut.Unknowns, otherField)
+
+	exported, err := ExportAnchorPsbt(packet)
+	require.NoError(t, err)
+
+	// The original packet must not be modified.
+	require.Equal(t, merkleRoot, ExtractCustomField(
+		packet.Outputs[0].Unknowns, PsbtKeyTypeOutputTaprootMerkleRoot,
+	))
+
+	// The exported packet only carries the proprietary fields.
+	exportedOut := exported.Outputs[0]
+	require.Nil(t, ExtractCustomField(
+		exportedOut.Unknowns, PsbtKeyTypeOutputTaprootMerkleRoot,
+	))
+	require.Nil(t, ExtractCustomField(
+		exportedOut.Unknowns, PsbtKeyTypeOutputAssetRoot,
+	))
+	require.Equal(t, merkleRoot, ExtractCustomField(
+		exportedOut.Unknowns, ProprietaryKey(
+			PsbtProprietarySubtypeOutputTaprootMerkleRoot,
+		),
+	))
+	require.Equal(t, assetRoot, ExtractCustomField(
+		exportedOut.Unknowns, ProprietaryKey(
+			PsbtProprietarySubtypeOutputAssetRoot,
+		),
+	))
+	require.Contains(t, exportedOut.Unknowns, otherField)
+	require.Empty(t, exported.Outputs[1].Unknowns)
+
+	// Simulate the round trip through an external signer.
+	var b bytes.Buffer
+	require.NoError(t, exported.Serialize(&b))
+	signed, err := psbt.NewFromRawBytes(&b, false)
+	require.NoError(t, err)
+
+	ImportAnchorPsbt(signed)
+	signedOut := signed.Outputs[0]
+	require.Equal(t, merkleRoot, ExtractCustomField(
+		signedOut.Unknowns, PsbtKeyTypeOutputTaprootMerkleRoot,
+	))
+	require.Equal(t, assetRoot, ExtractCustomField(
+		signedOut.Unknowns, PsbtKeyTypeOutputAssetRoot,
+	))
+	require.Len(t, signedOut.Unknowns, 3)
+	require.Equal(t, pOut.TaprootInternalKey, signedOut.TaprootInternalKey)
+
+	// Importing a packet that wasn't exported is a no-op.
+	before := packet.Outputs[0].Unknowns
+	ImportAnchorPsbt(packet)
+	require.Equal(t, before, packet.Outputs[0].Unknowns)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/proof"
 	"github.com/lightningnetwork/lnd/tlv"
 )
@@ -24,8 +25,67 @@ var (
 	// ErrKeyNotFound is returned when a key is not found among the unknown
 	// fields of a packet.
 	ErrKeyNotFound = errors.New("tappsbt: key not found")
+
+	// ErrUnknownField is returned when a packet contains a field the
+	// decoder doesn't understand and unknown fields aren't allowed.
+	ErrUnknownField = errors.New("tappsbt: unknown field")
+
+	// ErrUnknownCompatFlags is returned when a packet has a required
+	// compatibility flag the decoder doesn't understand.
+	ErrUnknownCompatFlags = errors.New("tappsbt: unknown required " +
+		"compatibility flags")
 )
 
+// UnknownFieldMode defines how the decoder handles fields of a virtual packet
+// it doesn't understand.
+type UnknownFieldMode uint8
+
+const (
+	// UnknownFieldsPreserve keeps the unknown fields on the decoded packet
+	// and its inputs and outputs, so they're encoded again unchanged.
+	UnknownFieldsPreserve UnknownFieldMode = iota
+
+	// UnknownFieldsReject rejects packets that contain unknown fields.
+	UnknownFieldsReject
+)
+
+// DecodeOption allows the caller to modify how a virtual packet is decoded.
+type DecodeOption func(*decodeOpts)
+
+// decodeOpts is the set of options that modify how a virtual packet is
+// decoded.
+type decodeOpts struct {
+	unknownFields UnknownFieldMode
+	maxVersion    VPacketVersion
+}
+
+// defaultDecodeOpts returns the default set of options for decoding a virtual
+// packet.
+func defaultDecodeOpts() *decodeOpts {
+	return &decodeOpts{
+		unknownFields: UnknownFieldsPreserve,
+		maxVersion:    LatestVPacketVersion,
+	}
+}
+
+// WithUnknownFieldMode is a DecodeOption that sets how fields the decoder
+// doesn't understand are handled.
+func WithUnknownFieldMode(mode UnknownFieldMode) DecodeOption {
+	return func(o *decodeOpts) {
+		o.unknownFields = mode
+	}
+}
+
+// WithMaxVersion is a DecodeOption that limits the packet versions the decoder
+// accepts, for example to the version negotiated with a counterparty. Versions
+// above the latest version this implementation understands are always
+// rejected.
+func WithMaxVersion(version VPacketVersion) DecodeOption {
+	return func(o *decodeOpts) {
+		o.maxVersion = version
+	}
+}
+
 // decoderFunc is a function type for decoding a virtual PSBT item from a byte
 // slice key and value.
 type decoderFunc func(key, byteVal []byte) error
@@ -40,18 +100,27 @@ type decoderMapping struct {
 // from a byte slice. If the format is invalid, an error is returned. If the
 // argument b64 is true, the passed byte slice is decoded from base64 encoding
 // before processing.
-func NewFromRawBytes(r io.Reader, b64 bool) (*VPacket, error) {
+func NewFromRawBytes(r io.Reader, b64 bool,
+	opts ...DecodeOption) (*VPacket, error) {
+
 	packet, err := psbt.NewFromRawBytes(r, b64)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding PSBT: %w", err)
 	}
 
-	return NewFromPsbt(packet)
+	return NewFromPsbt(packet, opts...)
 }
 
 // NewFromPsbt returns a new instance of a VPacket struct created by reading the
 // custom fields on the given PSBT packet.
-func NewFromPsbt(packet *psbt.Packet) (*VPacket, error) {
+func NewFromPsbt(packet *psbt.Packet, opts ...DecodeOption) (*VPacket,
+	error) {
+
+	decodeOpts := defaultDecodeOpts()
+	for _, opt := range opts {
+		opt(decodeOpts)
+	}
+
 	// We want an explicit "isVirtual" boolean marker.
 	isVirtual, err := findCustomFieldsByKeyPrefix(
 		packet.Unknowns, PsbtKeyTypeGlobalTapIsVirtualTx,
@@ -86,24 +155,63 @@ func NewFromPsbt(packet *psbt.Packet) (*VPacket, error) {
 			err)
 	}
 
-	version := versionField.Value[0]
-	switch version {
-	case uint8(V0), uint8(V1):
-	default:
+	if len(versionField.Value) != 1 {
+		return nil, fmt.Errorf("%w: invalid encoding",
+			ErrInvalidVPacketVersion)
+	}
+
+	version := VPacketVersion(versionField.Value[0])
+	if version > LatestVPacketVersion || version > decodeOpts.maxVersion {
 		return nil, fmt.Errorf("%w: %d", ErrInvalidVPacketVersion,
 			version)
 	}
 
+	// The compatibility flags are optional and tell us how strictly the
+	// creator wants the packet to be decoded.
+	var compatFlags uint64
+	compatField, err := findCustomFieldsByKeyPrefix(
+		packet.Unknowns, PsbtKeyTypeGlobalTapCompatFlags,
+	)
+	if err == nil {
+		err = tlvDecoder(&compatFlags, tlv.DUint64)(
+			compatField.Key, compatField.Value,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding compatibility "+
+				"flags: %w", err)
+		}
+	}
+
+	flags := CompatFlags(compatFlags)
+	if unknown := flags.UnknownRequired(); unknown != 0 {
+		return nil, fmt.Errorf("%w: %x", ErrUnknownCompatFlags, unknown)
+	}
+
+	rejectUnknown := decodeOpts.unknownFields == UnknownFieldsReject ||
+		flags&CompatRequireKnownFields != 0
+
+	globalUnknowns, err := unknownFields(
+		packet.Unknowns, rejectUnknown, PsbtKeyTypeGlobalTapIsVirtualTx,
+		PsbtKeyTypeGlobalTapChainParamsHRP,
+		PsbtKeyTypeGlobalTapPsbtVersion,
+		PsbtKeyTypeGlobalTapCompatFlags,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding global fields: %w", err)
+	}
+
 	vPkt := &VPacket{
-		Version:     VPacketVersion(version),
+		Version:     version,
 		ChainParams: chainParams,
 		Inputs:      make([]*VInput, len(packet.Inputs)),
 		Outputs:     make([]*VOutput, len(packet.Outputs)),
+		CompatFlags: flags,
+		Unknowns:    globalUnknowns,
 	}
 
 	for idx := range packet.Inputs {
 		vIn := &VInput{}
-		err = vIn.decode(packet.Inputs[idx])
+		err = vIn.decode(packet.Inputs[idx], rejectUnknown)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding virtual input "+
 				"%d: %w", idx, err)
@@ -116,6 +224,7 @@ func NewFromPsbt(packet *psbt.Packet) (*VPacket, error) {
 		vOut := &VOutput{}
 		err = vOut.decode(
 			packet.Outputs[idx], packet.UnsignedTx.TxOut[idx],
+			rejectUnknown,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding virtual output "+
@@ -128,14 +237,16 @@ func NewFromPsbt(packet *psbt.Packet) (*VPacket, error) {
 	return vPkt, nil
 }
 
-// decode decodes the given PInput into the current VInput.
-func (i *VInput) decode(pIn psbt.PInput) error {
+// decode decodes the given PInput into the current VInput. Fields the decoder
+// doesn't understand are either rejected or kept in the unknowns of the input.
+func (i *VInput) decode(pIn psbt.PInput, rejectUnknown bool) error {
 	i.PInput = pIn
 
 	var (
 		prevID            *asset.PrevID
 		anchorValue       uint64
 		anchorSigHashType uint64
+		muSig2            MuSig2Info
 	)
 
 	mapping := []decoderMapping{{
@@ -176,6 +287,22 @@ func (i *VInput) decode(pIn psbt.PInput) error {
 	}, {
 		key:     PsbtKeyTypeInputAltLeaves,
 		decoder: altLeavesDecoder(&i.AltLeaves),
+	}, {
+		key: PsbtKeyTypeInputTapMuSig2ParticipantKeys,
+		decoder: tlvDecoder(
+			&muSig2.ParticipantKeys, pubKeyListDecoder,
+		),
+	}, {
+		key:     PsbtKeyTypeInputTapMuSig2TapscriptRoot,
+		decoder: tlvDecoder(&muSig2.TapscriptRoot, tlv.DVarBytes),
+	}, {
+		key:     PsbtKeyTypeInputTapMuSig2PubNonces,
+		decoder: tlvDecoder(&muSig2.PubNonces, pubNonceListDecoder),
+	}, {
+		key: PsbtKeyTypeInputTapMuSig2PartialSigs,
+		decoder: tlvDecoder(
+			&muSig2.PartialSigs, partialSigListDecoder,
+		),
 	}}
 
 	for idx := range mapping {
@@ -203,19 +330,35 @@ func (i *VInput) decode(pIn psbt.PInput) error {
 	i.Anchor.Value = btcutil.Amount(anchorValue)
 	i.Anchor.SigHashType = txscript.SigHashType(anchorSigHashType)
 
+	// The MuSig2 information is only meaningful if the participants are
+	// known.
+	if len(muSig2.ParticipantKeys) > 0 {
+		i.MuSig2 = &muSig2
+	}
+
 	// The asset leaf encoding doesn't store the full script key info, only
 	// the top level Taproot key. In order to be able to sign for it, we
 	// need all the info populated properly.
 	if err := i.deserializeScriptKey(); err != nil {
 		return err
 	}
-	i.Unknowns = nil
+
+	unknowns, err := unknownFields(
+		i.Unknowns, rejectUnknown, mappingKeys(mapping)...,
+	)
+	if err != nil {
+		return err
+	}
+	i.Unknowns = unknowns
 
 	return nil
 }
 
 // decode decodes the given POutput and wire.TxOut into the current VOutput.
-func (o *VOutput) decode(pOut psbt.POutput, txOut *wire.TxOut) error {
+// Fields the decoder doesn't understand are either rejected or kept in the
+// unknowns of the output.
+func (o *VOutput) decode(pOut psbt.POutput, txOut *wire.TxOut,
+	rejectUnknown bool) error {
 	o.Amount = uint64(txOut.Value)
 
 	if len(txOut.PkScript) != schnorr.PubKeyBytesLen+2 {
@@ -331,6 +474,13 @@ func (o *VOutput) decode(pOut psbt.POutput, txOut *wire.TxOut) error {
 	// into their target type now.
 	o.AnchorOutputIndex = uint32(anchorOutputIndex)
 
+	o.Unknowns, err = unknownFields(
+		pOut.Unknowns, rejectUnknown, mappingKeys(mapping)...,
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -482,6 +632,42 @@ func findCustomFieldsByKeyPrefix(customFields []*customPsbtField,
 		ErrKeyNotFound, keyPrefix)
 }
 
+// mappingKeys returns the key types of the given decoder mappings.
+func mappingKeys(mapping []decoderMapping) [][]byte {
+	keys := make([][]byte, len(mapping))
+	for idx := range mapping {
+		keys[idx] = mapping[idx].key
+	}
+
+	return keys
+}
+
+// unknownFields returns the fields of the given list whose key doesn't start
+// with any of the given known key types. If unknown fields should be
+// rejected, an error is returned instead if there are any.
+func unknownFields(customFields []*customPsbtField, rejectUnknown bool,
+	knownKeys ...[]byte) ([]*customPsbtField, error) {
+
+	var unknowns []*customPsbtField
+	for _, customField := range customFields {
+		known := fn.Any(knownKeys, func(key []byte) bool {
+			return bytes.HasPrefix(customField.Key, key)
+		})
+		if known {
+			continue
+		}
+
+		if rejectUnknown {
+			return nil, fmt.Errorf("%w: key %x", ErrUnknownField,
+				customField.Key)
+		}
+
+		unknowns = append(unknowns, customField)
+	}
+
+	return unknowns, nil
+}
+
 // vOutputTypeDecoder is a TLV decoder function that decodes from the given
 // reader into a VOutputType.
 func vOutputTypeDecoder(r io.Reader, val any, buf *[8]byte, l uint64) error {
@@ -132,10 +132,150 @@ func TestGlobalUnknownFields(t *testing.T) {
 	}
 	extraPacket.Unknowns = append(extraPacket.Unknowns, extraUnknown)
 
-	// The decoded VPacket should not contain the extra Unknown field, but
-	// the decoder should succeed.
-	_, err = NewFromPsbt(extraPacket)
+	// The decoder should succeed and preserve the extra Unknown field.
+	extraVPacket, err := NewFromPsbt(extraPacket)
 	require.NoError(t, err)
+	require.Equal(t, []*psbt.Unknown{extraUnknown}, extraVPacket.Unknowns)
+}
+
+// TestUnknownFieldModes tests that unknown fields are preserved through an
+// encoding round trip by default, and rejected by a strict decoder or if the
+// creator of the packet requires it.
+func TestUnknownFieldModes(t *testing.T) {
+	t.Parallel()
+
+	vPkt := RandPacket(t, false, false)
+	packet, err := vPkt.EncodeAsPsbt()
+	require.NoError(t, err)
+
+	// We add a field that is unknown to tapd on the global, input and
+	// output level. The output field uses the BIP-0174 proprietary key
+	// type.
+	globalUnknown := &psbt.Unknown{
+		Key:   []byte{0xaa},
+		Value: []byte("global"),
+	}
+	inputUnknown := &psbt.Unknown{
+		Key:   []byte{0xab, 0x01},
+		Value: []byte("input"),
+	}
+	outputUnknown := &psbt.Unknown{
+		Key:   []byte{0xfc, 0x03, 'f', 'o', 'o', 0x00},
+		Value: []byte("output"),
+	}
+	packet.Unknowns = append(packet.Unknowns, globalUnknown)
+	packet.Inputs[0].Unknowns = append(
+		packet.Inputs[0].Unknowns, inputUnknown,
+	)
+	packet.Outputs[0].Unknowns = append(
+		packet.Outputs[0].Unknowns, outputUnknown,
+	)
+
+	var packetBuf bytes.Buffer
+	require.NoError(t, packet.Serialize(&packetBuf))
+	packetBytes := packetBuf.Bytes()
+
+	decoded, err := NewFromRawBytes(bytes.NewReader(packetBytes), false)
+	require.NoError(t, err)
+	require.Equal(t, []*psbt.Unknown{globalUnknown}, decoded.Unknowns)
+	require.Equal(
+		t, []*psbt.Unknown{inputUnknown}, decoded.Inputs[0].Unknowns,
+	)
+	require.Equal(
+		t, []*psbt.Unknown{outputUnknown}, decoded.Outputs[0].Unknowns,
+	)
+
+	// Encoding the packet again must keep the unknown fields, so decoding
+	// it a second time results in the same packet.
+	var roundTripBuf bytes.Buffer
+	require.NoError(t, decoded.Serialize(&roundTripBuf))
+	roundTrip, err := NewFromRawBytes(&roundTripBuf, false)
+	require.NoError(t, err)
+	require.Equal(t, decoded.Unknowns, roundTrip.Unknowns)
+	assertEqualPackets(t, decoded, roundTrip)
+
+	// A strict decoder rejects the packet.
+	_, err = NewFromRawBytes(
+		bytes.NewReader(packetBytes), false,
+		WithUnknownFieldMode(UnknownFieldsReject),
+	)
+	require.ErrorIs(t, err, ErrUnknownField)
+
+	// The original packet without unknown fields is accepted by a strict
+	// decoder.
+	var validBuf bytes.Buffer
+	require.NoError(t, vPkt.Serialize(&validBuf))
+	_, err = NewFromRawBytes(
+		&validBuf, false, WithUnknownFieldMode(UnknownFieldsReject),
+	)
+	require.NoError(t, err)
+
+	// If the creator requires all fields to be known, a preserving
+	// decoder must reject the packet as well.
+	decoded.CompatFlags = CompatRequireKnownFields
+	var requiredBuf bytes.Buffer
+	require.NoError(t, decoded.Serialize(&requiredBuf))
+	_, err = NewFromRawBytes(&requiredBuf, false)
+	require.ErrorIs(t, err, ErrUnknownField)
+}
+
+// TestCompatFlags tests the encoding of the compatibility flags and that
+// unknown required flags are rejected while unknown optional flags are
+// ignored.
+func TestCompatFlags(t *testing.T) {
+	t.Parallel()
+
+	decodeWithFlags := func(flags CompatFlags) (*VPacket, error) {
+		vPkt := RandPacket(t, false, false)
+		vPkt.CompatFlags = flags
+
+		var b bytes.Buffer
+		require.NoError(t, vPkt.Serialize(&b))
+
+		return NewFromRawBytes(&b, false)
+	}
+
+	decoded, err := decodeWithFlags(CompatPreserveUnknownFields)
+	require.NoError(t, err)
+	require.Equal(t, CompatPreserveUnknownFields, decoded.CompatFlags)
+	require.Nil(t, decoded.Unknowns)
+
+	// An unknown optional (odd) flag is ignored but kept.
+	decoded, err = decodeWithFlags(1 << 3)
+	require.NoError(t, err)
+	require.Equal(t, CompatFlags(1<<3), decoded.CompatFlags)
+
+	// An unknown required (even) flag is rejected.
+	_, err = decodeWithFlags(1 << 2)
+	require.ErrorIs(t, err, ErrUnknownCompatFlags)
+}
+
+// TestDecodeVersion tests that the decoder rejects packet versions above the
+// negotiated maximum version and malformed version fields.
+func TestDecodeVersion(t *testing.T) {
+	t.Parallel()
+
+	vPkt := RandPacket(t, false, false)
+	vPkt.Version = V1
+	packet, err := vPkt.EncodeAsPsbt()
+	require.NoError(t, err)
+
+	_, err = NewFromPsbt(packet)
+	require.NoError(t, err)
+
+	_, err = NewFromPsbt(packet, WithMaxVersion(V0))
+	require.ErrorIs(t, err, ErrInvalidVPacketVersion)
+
+	// A version field without a value must be rejected instead of
+	// causing a panic.
+	versionField, err := findCustomFieldsByKeyPrefix(
+		packet.Unknowns, PsbtKeyTypeGlobalTapPsbtVersion,
+	)
+	require.NoError(t, err)
+	versionField.Value = nil
+
+	_, err = NewFromPsbt(packet)
+	require.ErrorIs(t, err, ErrInvalidVPacketVersion)
 }
 
 // TestEncodingDecoding tests the decoding of a virtual packet from raw bytes.
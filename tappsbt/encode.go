@@ -7,6 +7,7 @@ import (
 	"io"
 	"math"
 	"net/url"
+	"slices"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -73,6 +74,21 @@ func (p *VPacket) EncodeAsPsbt() (*psbt.Packet, error) {
 		},
 	}
 
+	// The compatibility flags are only encoded if any are set, so packets
+	// without flags stay readable by older decoders.
+	if p.CompatFlags != 0 {
+		compatFlags := uint64(p.CompatFlags)
+		customFields, err := tlvEncoder(&compatFlags, tlv.EUint64)(
+			PsbtKeyTypeGlobalTapCompatFlags,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding compatibility "+
+				"flags: %w", err)
+		}
+		packet.Unknowns = append(packet.Unknowns, customFields...)
+	}
+	packet.Unknowns = append(packet.Unknowns, p.Unknowns...)
+
 	for idx := range p.Inputs {
 		pIn, err := p.Inputs[idx].encode()
 		if err != nil {
@@ -124,7 +140,10 @@ func (p *VPacket) B64Encode() (string, error) {
 
 // encode encodes the current VInput struct into a PInput and a wire.TxIn.
 func (i *VInput) encode() (psbt.PInput, error) {
+	// The input might carry unknown fields from decoding, which we must
+	// not modify when appending our own.
 	pIn := i.PInput
+	pIn.Unknowns = slices.Clip(pIn.Unknowns)
 
 	var (
 		prevID      = &i.PrevID
@@ -189,6 +208,22 @@ func (i *VInput) encode() (psbt.PInput, error) {
 			key:     PsbtKeyTypeInputAltLeaves,
 			encoder: altLeavesEncoder(i.AltLeaves),
 		},
+		{
+			key:     PsbtKeyTypeInputTapMuSig2ParticipantKeys,
+			encoder: muSig2KeysEncoder(i.MuSig2),
+		},
+		{
+			key:     PsbtKeyTypeInputTapMuSig2TapscriptRoot,
+			encoder: muSig2TapscriptRootEncoder(i.MuSig2),
+		},
+		{
+			key:     PsbtKeyTypeInputTapMuSig2PubNonces,
+			encoder: muSig2NoncesEncoder(i.MuSig2),
+		},
+		{
+			key:     PsbtKeyTypeInputTapMuSig2PartialSigs,
+			encoder: muSig2PartialSigsEncoder(i.MuSig2),
+		},
 	}
 
 	for idx := range mapping {
@@ -320,6 +355,7 @@ func (o *VOutput) encode(coinType uint32) (psbt.POutput, *wire.TxOut, error) {
 			pOut.Unknowns = append(pOut.Unknowns, customFields...)
 		}
 	}
+	pOut.Unknowns = append(pOut.Unknowns, o.Unknowns...)
 
 	return pOut, txOut, nil
 }
@@ -0,0 +1,45 @@
+package tappsbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzPacketDecode(f *testing.F) {
+	// Seed the corpus with the regtest packet and the encoded packets of
+	// the valid test vectors.
+	packetHex, err := os.ReadFile(packetHexFileName)
+	require.NoError(f, err)
+
+	packetBytes, err := hex.DecodeString(
+		strings.Trim(string(packetHex), "\n"),
+	)
+	require.NoError(f, err)
+	f.Add(packetBytes)
+
+	testVectors := &TestVectors{}
+	test.ParseTestVectors(f, generatedTestVectorName, &testVectors)
+	for _, validCase := range testVectors.ValidTestCases {
+		packetBytes, err := base64.StdEncoding.DecodeString(
+			validCase.Expected,
+		)
+		require.NoError(f, err)
+
+		f.Add(packetBytes)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = NewFromRawBytes(bytes.NewReader(data), false)
+		_, _ = NewFromRawBytes(
+			bytes.NewReader(data), false,
+			WithUnknownFieldMode(UnknownFieldsReject),
+		)
+	})
+}
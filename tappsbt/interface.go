@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/url"
+	"slices"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
@@ -30,6 +31,7 @@ var (
 	PsbtKeyTypeGlobalTapIsVirtualTx    = []byte{0x70}
 	PsbtKeyTypeGlobalTapChainParamsHRP = []byte{0x71}
 	PsbtKeyTypeGlobalTapPsbtVersion    = []byte{0x72}
+	PsbtKeyTypeGlobalTapCompatFlags    = []byte{0x73}
 
 	PsbtKeyTypeInputTapPrevID                             = []byte{0x70}
 	PsbtKeyTypeInputTapAnchorValue                        = []byte{0x71}
@@ -43,6 +45,10 @@ var (
 	PsbtKeyTypeInputTapAsset                              = []byte{0x79}
 	PsbtKeyTypeInputTapAssetProof                         = []byte{0x7a}
 	PsbtKeyTypeInputAltLeaves                             = []byte{0x7b}
+	PsbtKeyTypeInputTapMuSig2ParticipantKeys              = []byte{0x7c}
+	PsbtKeyTypeInputTapMuSig2TapscriptRoot                = []byte{0x7d}
+	PsbtKeyTypeInputTapMuSig2PubNonces                    = []byte{0x7e}
+	PsbtKeyTypeInputTapMuSig2PartialSigs                  = []byte{0x7f}
 
 	PsbtKeyTypeOutputTapType                               = []byte{0x70}
 	PsbtKeyTypeOutputTapIsInteractive                      = []byte{0x71}
@@ -93,8 +99,47 @@ const (
 
 	// V1 VPackets require V2 TapCommitments for all VOutputs.
 	V1 VPacketVersion = 1
+
+	// LatestVPacketVersion is the latest VPacket version this
+	// implementation can decode.
+	LatestVPacketVersion = V1
+)
+
+// CompatFlags is a bit field the creator of a virtual packet uses to tell
+// other implementations how the packet must be decoded. Following the "it's OK
+// to be odd" rule, even bits are required and odd bits are optional. A decoder
+// must reject a packet with a required bit it doesn't know, but may ignore
+// unknown optional bits.
+type CompatFlags uint64
+
+const (
+	// CompatRequireKnownFields is a required flag signaling that the
+	// packet must only be processed by decoders that understand all of its
+	// fields. A packet with this flag is rejected if it contains unknown
+	// fields, no matter which UnknownFieldMode the decoder uses.
+	CompatRequireKnownFields CompatFlags = 1 << 0
+
+	// CompatPreserveUnknownFields is an optional flag signaling that the
+	// creator of the packet preserves unknown fields when decoding it, so
+	// the fields a counterparty adds survive a round trip through the
+	// creator.
+	CompatPreserveUnknownFields CompatFlags = 1 << 1
+
+	// knownCompatFlags are all compatibility flags this implementation
+	// understands.
+	knownCompatFlags = CompatRequireKnownFields |
+		CompatPreserveUnknownFields
+
+	// requiredCompatFlags is the mask of all required, even bits.
+	requiredCompatFlags CompatFlags = 0x5555555555555555
 )
 
+// UnknownRequired returns the required flags this implementation doesn't
+// understand.
+func (f CompatFlags) UnknownRequired() CompatFlags {
+	return f & requiredCompatFlags &^ knownCompatFlags
+}
+
 // VOutPredicate is a function that can be used to filter virtual outputs.
 type VOutPredicate func(*VOutput) bool
 
@@ -181,6 +226,14 @@ type VPacket struct {
 
 	// Version is the version of the virtual transaction.
 	Version VPacketVersion
+
+	// CompatFlags are the compatibility flags that tell other
+	// implementations how the packet must be decoded.
+	CompatFlags CompatFlags
+
+	// Unknowns are the global fields of the packet that weren't understood
+	// when decoding it. They are encoded again unchanged.
+	Unknowns []*psbt.Unknown
 }
 
 // Copy creates a deep copy of the VPacket.
@@ -190,6 +243,8 @@ func (p *VPacket) Copy() *VPacket {
 		Outputs:     fn.CopyAll(p.Outputs),
 		ChainParams: p.ChainParams,
 		Version:     p.Version,
+		CompatFlags: p.CompatFlags,
+		Unknowns:    slices.Clone(p.Unknowns),
 	}
 }
 
@@ -400,6 +455,11 @@ type VInput struct {
 	// data-carrying leaves are used for a purpose distinct from
 	// representing individual Taproot Assets.
 	AltLeaves []AltLeafAsset
+
+	// MuSig2 contains the participant keys, public nonces and partial
+	// signatures of an input whose script key is the MuSig2 aggregate key
+	// of several participants. It is nil for all other inputs.
+	MuSig2 *MuSig2Info
 }
 
 // Copy creates a deep copy of the VInput.
@@ -414,6 +474,7 @@ func (i *VInput) Copy() *VInput {
 		// it here is fine.
 		Proof:     i.Proof,
 		AltLeaves: asset.CopyAltLeaves(i.AltLeaves),
+		MuSig2:    i.MuSig2.Copy(),
 	}
 }
 
@@ -600,6 +661,10 @@ type VOutput struct {
 	// data-carrying leaves are used for a purpose distinct from
 	// representing individual Taproot Assets.
 	AltLeaves []AltLeafAsset
+
+	// Unknowns are the fields of the output that weren't understood when
+	// decoding it. They are encoded again unchanged.
+	Unknowns []*psbt.Unknown
 }
 
 // Copy creates a deep copy of the VOutput.
@@ -628,6 +693,7 @@ func (o *VOutput) Copy() *VOutput {
 		ProofDeliveryAddress:         o.ProofDeliveryAddress,
 		ProofSuffix:                  o.ProofSuffix,
 		AltLeaves:                    asset.CopyAltLeaves(o.AltLeaves),
+		Unknowns:                     slices.Clone(o.Unknowns),
 	}
 }
 
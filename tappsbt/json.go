@@ -0,0 +1,925 @@
+package tappsbt
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+)
+
+// JSONVPacket is the canonical JSON representation of a virtual packet. The
+// format is the same one used by the BIP test vectors. Only the PSBT input
+// fields that are used by virtual packets (the BIP-0032 derivation paths, the
+// Taproot internal key and merkle root) are part of the JSON representation.
+type JSONVPacket struct {
+	Inputs         []*JSONVInput  `json:"inputs"`
+	Outputs        []*JSONVOutput `json:"outputs"`
+	Version        uint8          `json:"version"`
+	ChainParamsHRP string         `json:"chain_params_hrp"`
+}
+
+// NewJSONVPacket creates the JSON representation of the given virtual packet.
+func NewJSONVPacket(p *VPacket) (*JSONVPacket, error) {
+	if p.ChainParams == nil {
+		return nil, fmt.Errorf("virtual packet is missing chain params")
+	}
+
+	jp := &JSONVPacket{
+		Version:        uint8(p.Version),
+		ChainParamsHRP: p.ChainParams.TapHRP,
+	}
+
+	for idx := range p.Inputs {
+		ji, err := NewJSONVInput(p.Inputs[idx])
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode input %d: %w",
+				idx, err)
+		}
+
+		jp.Inputs = append(jp.Inputs, ji)
+	}
+
+	for idx := range p.Outputs {
+		jo, err := NewJSONVOutput(
+			p.Outputs[idx], p.ChainParams.HDCoinType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode output %d: %w",
+				idx, err)
+		}
+
+		jp.Outputs = append(jp.Outputs, jo)
+	}
+
+	return jp, nil
+}
+
+// ToVPacket converts the JSON representation back into a virtual packet.
+func (jp *JSONVPacket) ToVPacket() (*VPacket, error) {
+	if jp.ChainParamsHRP == "" {
+		return nil, fmt.Errorf("%w: missing chain params HRP",
+			asset.ErrInvalidJSON)
+	}
+	if !address.IsBech32MTapPrefix(jp.ChainParamsHRP + "1") {
+		return nil, fmt.Errorf("%w: invalid chain params HRP",
+			asset.ErrInvalidJSON)
+	}
+
+	chainParams, err := address.Net(jp.ChainParamsHRP)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &VPacket{
+		Version:     VPacketVersion(jp.Version),
+		ChainParams: chainParams,
+	}
+
+	for idx := range jp.Inputs {
+		vIn, err := jp.Inputs[idx].ToVInput()
+		if err != nil {
+			return nil, fmt.Errorf("invalid input %d: %w", idx, err)
+		}
+
+		p.Inputs = append(p.Inputs, vIn)
+	}
+
+	for idx := range jp.Outputs {
+		vOut, err := jp.Outputs[idx].ToVOutput()
+		if err != nil {
+			return nil, fmt.Errorf("invalid output %d: %w", idx,
+				err)
+		}
+
+		p.Outputs = append(p.Outputs, vOut)
+	}
+
+	return p, nil
+}
+
+// JSONVInput is the JSON representation of a virtual packet input.
+type JSONVInput struct {
+	Bip32Derivation   []*JSONBip32Derivation   `json:"bip32_derivation"`
+	TrBip32Derivation []*JSONTrBip32Derivation `json:"tr_bip32_derivation"`
+	TrInternalKey     string                   `json:"tr_internal_key"`
+	TrMerkleRoot      string                   `json:"tr_merkle_root"`
+	PrevID            *asset.JSONPrevID        `json:"prev_id"`
+	Anchor            *JSONAnchor              `json:"anchor"`
+	Asset             *asset.JSONAsset         `json:"asset"`
+	Proof             *proof.JSONProof         `json:"proof"`
+	AltLeaves         []*asset.JSONAsset       `json:"alt_leaves"`
+	MuSig2            *JSONMuSig2Info          `json:"musig2,omitempty"`
+}
+
+// NewJSONVInput creates the JSON representation of the given virtual input.
+func NewJSONVInput(i *VInput) (*JSONVInput, error) {
+	ji := &JSONVInput{
+		Bip32Derivation: newJSONBip32Derivations(i.Bip32Derivation),
+		TrBip32Derivation: newJSONTrBip32Derivations(
+			i.TaprootBip32Derivation,
+		),
+		TrInternalKey: hex.EncodeToString(i.TaprootInternalKey),
+		TrMerkleRoot:  hex.EncodeToString(i.TaprootMerkleRoot),
+		PrevID:        asset.NewJSONPrevID(&i.PrevID),
+		Anchor:        NewJSONAnchor(&i.Anchor),
+	}
+
+	var err error
+	if i.asset != nil {
+		ji.Asset, err = asset.NewJSONAsset(i.asset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode asset: %w",
+				err)
+		}
+	}
+
+	if i.Proof != nil {
+		ji.Proof, err = proof.NewJSONProof(i.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode proof: %w",
+				err)
+		}
+	}
+
+	ji.AltLeaves, err = newJSONAltLeaves(i.AltLeaves)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.MuSig2 != nil {
+		ji.MuSig2 = NewJSONMuSig2Info(i.MuSig2)
+	}
+
+	return ji, nil
+}
+
+// ToVInput converts the JSON representation back into a virtual input.
+func (ji *JSONVInput) ToVInput() (*VInput, error) {
+	internalKey, err := decodeOptionalHex(ji.TrInternalKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid internal key: %w", err)
+	}
+
+	merkleRoot, err := decodeOptionalHex(ji.TrMerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	if ji.Anchor == nil {
+		return nil, fmt.Errorf("%w: missing anchor",
+			asset.ErrInvalidJSON)
+	}
+
+	anchor, err := ji.Anchor.ToAnchor()
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor: %w", err)
+	}
+
+	vi := &VInput{
+		PInput: psbt.PInput{
+			TaprootInternalKey: internalKey,
+			TaprootMerkleRoot:  merkleRoot,
+		},
+		Anchor: *anchor,
+	}
+
+	if ji.PrevID != nil {
+		prevID, err := ji.PrevID.ToPrevID()
+		if err != nil {
+			return nil, fmt.Errorf("invalid prev ID: %w", err)
+		}
+
+		if prevID != nil {
+			vi.PrevID = *prevID
+		}
+	}
+
+	vi.Bip32Derivation, err = toBip32Derivations(ji.Bip32Derivation)
+	if err != nil {
+		return nil, err
+	}
+
+	vi.TaprootBip32Derivation, err = toTrBip32Derivations(
+		ji.TrBip32Derivation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if ji.Asset != nil {
+		vi.asset, err = ji.Asset.ToAsset()
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset: %w", err)
+		}
+
+		// The script key derivation information is not contained in
+		// the asset itself, we need to fetch that from the other
+		// fields.
+		if err := vi.deserializeScriptKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ji.Proof != nil {
+		vi.Proof, err = ji.Proof.ToProof()
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof: %w", err)
+		}
+	}
+
+	vi.AltLeaves, err = toAltLeaves(ji.AltLeaves)
+	if err != nil {
+		return nil, err
+	}
+
+	if ji.MuSig2 != nil {
+		vi.MuSig2, err = ji.MuSig2.ToMuSig2Info()
+		if err != nil {
+			return nil, fmt.Errorf("invalid MuSig2 info: %w", err)
+		}
+	}
+
+	return vi, nil
+}
+
+// JSONMuSig2PubNonce is the JSON representation of a participant's public
+// MuSig2 nonce.
+type JSONMuSig2PubNonce struct {
+	ParticipantKey string `json:"participant_key"`
+	Nonce          string `json:"nonce"`
+}
+
+// JSONMuSig2PartialSig is the JSON representation of a participant's MuSig2
+// partial signature.
+type JSONMuSig2PartialSig struct {
+	ParticipantKey string `json:"participant_key"`
+	Sig            string `json:"sig"`
+}
+
+// JSONMuSig2Info is the JSON representation of a virtual input's MuSig2
+// information.
+type JSONMuSig2Info struct {
+	ParticipantKeys []string                `json:"participant_keys"`
+	TapscriptRoot   string                  `json:"tapscript_root"`
+	PubNonces       []*JSONMuSig2PubNonce   `json:"pub_nonces"`
+	PartialSigs     []*JSONMuSig2PartialSig `json:"partial_sigs"`
+}
+
+// NewJSONMuSig2Info creates the JSON representation of the given MuSig2
+// information.
+func NewJSONMuSig2Info(m *MuSig2Info) *JSONMuSig2Info {
+	jm := &JSONMuSig2Info{
+		ParticipantKeys: fn.Map(m.ParticipantKeys, asset.HexPubKey),
+		TapscriptRoot:   hex.EncodeToString(m.TapscriptRoot),
+	}
+
+	for _, nonce := range m.PubNonces {
+		jm.PubNonces = append(jm.PubNonces, &JSONMuSig2PubNonce{
+			ParticipantKey: asset.HexPubKey(nonce.ParticipantKey),
+			Nonce:          hex.EncodeToString(nonce.Nonce[:]),
+		})
+	}
+
+	for _, sig := range m.PartialSigs {
+		jm.PartialSigs = append(jm.PartialSigs, &JSONMuSig2PartialSig{
+			ParticipantKey: asset.HexPubKey(sig.ParticipantKey),
+			Sig:            hex.EncodeToString(sig.Sig[:]),
+		})
+	}
+
+	return jm
+}
+
+// ToMuSig2Info converts the JSON representation back into MuSig2 information.
+func (jm *JSONMuSig2Info) ToMuSig2Info() (*MuSig2Info, error) {
+	m := &MuSig2Info{}
+
+	var err error
+	for _, keyHex := range jm.ParticipantKeys {
+		key, err := asset.ParseHexPubKey(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant key: %w",
+				err)
+		}
+
+		m.ParticipantKeys = append(m.ParticipantKeys, key)
+	}
+
+	m.TapscriptRoot, err = decodeOptionalHex(jm.TapscriptRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tapscript root: %w", err)
+	}
+
+	for _, jNonce := range jm.PubNonces {
+		var nonce MuSig2PubNonce
+		nonce.ParticipantKey, err = asset.ParseHexPubKey(
+			jNonce.ParticipantKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant key: %w",
+				err)
+		}
+
+		err = decodeFixedHex(jNonce.Nonce, nonce.Nonce[:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce: %w", err)
+		}
+
+		m.PubNonces = append(m.PubNonces, nonce)
+	}
+
+	for _, jSig := range jm.PartialSigs {
+		var sig MuSig2PartialSig
+		sig.ParticipantKey, err = asset.ParseHexPubKey(
+			jSig.ParticipantKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant key: %w",
+				err)
+		}
+
+		err = decodeFixedHex(jSig.Sig, sig.Sig[:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signature: %w",
+				err)
+		}
+
+		m.PartialSigs = append(m.PartialSigs, sig)
+	}
+
+	return m, nil
+}
+
+// decodeFixedHex decodes the given hex string into the target, which must have
+// exactly the length of the decoded bytes.
+func decodeFixedHex(hexStr string, target []byte) error {
+	b, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return err
+	}
+
+	if len(b) != len(target) {
+		return fmt.Errorf("expected %d bytes, got %d", len(target),
+			len(b))
+	}
+	copy(target, b)
+
+	return nil
+}
+
+// JSONAnchor is the JSON representation of a virtual input's anchor.
+type JSONAnchor struct {
+	Value             int64                    `json:"value"`
+	PkScript          string                   `json:"pk_script"`
+	SigHashType       uint32                   `json:"sig_hash_type"`
+	InternalKey       string                   `json:"internal_key"`
+	MerkleRoot        string                   `json:"merkle_root"`
+	TapscriptSibling  string                   `json:"tapscript_sibling"`
+	Bip32Derivation   []*JSONBip32Derivation   `json:"bip32_derivation"`
+	TrBip32Derivation []*JSONTrBip32Derivation `json:"tr_bip32_derivation"`
+}
+
+// NewJSONAnchor creates the JSON representation of the given anchor.
+func NewJSONAnchor(a *Anchor) *JSONAnchor {
+	return &JSONAnchor{
+		Value:            int64(a.Value),
+		PkScript:         hex.EncodeToString(a.PkScript),
+		SigHashType:      uint32(a.SigHashType),
+		InternalKey:      asset.HexPubKey(a.InternalKey),
+		MerkleRoot:       hex.EncodeToString(a.MerkleRoot),
+		TapscriptSibling: hex.EncodeToString(a.TapscriptSibling),
+		Bip32Derivation:  newJSONBip32Derivations(a.Bip32Derivation),
+		TrBip32Derivation: newJSONTrBip32Derivations(
+			a.TrBip32Derivation,
+		),
+	}
+}
+
+// ToAnchor converts the JSON representation back into an anchor.
+func (ja *JSONAnchor) ToAnchor() (*Anchor, error) {
+	a := &Anchor{
+		Value:       btcutil.Amount(ja.Value),
+		SigHashType: txscript.SigHashType(ja.SigHashType),
+	}
+
+	var err error
+	a.PkScript, err = decodeOptionalHex(ja.PkScript)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pk script: %w", err)
+	}
+
+	a.MerkleRoot, err = decodeOptionalHex(ja.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	a.TapscriptSibling, err = decodeOptionalHex(ja.TapscriptSibling)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tapscript sibling: %w", err)
+	}
+
+	if ja.InternalKey != "" {
+		a.InternalKey, err = asset.ParseHexPubKey(ja.InternalKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid internal key: %w", err)
+		}
+	}
+
+	a.Bip32Derivation, err = toBip32Derivations(ja.Bip32Derivation)
+	if err != nil {
+		return nil, err
+	}
+
+	a.TrBip32Derivation, err = toTrBip32Derivations(ja.TrBip32Derivation)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// JSONBip32Derivation is the JSON representation of a BIP-0032 derivation.
+type JSONBip32Derivation struct {
+	PubKey      string   `json:"pub_key"`
+	Fingerprint uint32   `json:"fingerprint"`
+	Bip32Path   []uint32 `json:"bip32_path"`
+}
+
+// NewJSONBip32Derivation creates the JSON representation of the given
+// BIP-0032 derivation.
+func NewJSONBip32Derivation(b *psbt.Bip32Derivation) *JSONBip32Derivation {
+	return &JSONBip32Derivation{
+		PubKey:      hex.EncodeToString(b.PubKey),
+		Fingerprint: b.MasterKeyFingerprint,
+		Bip32Path:   b.Bip32Path,
+	}
+}
+
+// ToBip32Derivation converts the JSON representation back into a BIP-0032
+// derivation.
+func (jd *JSONBip32Derivation) ToBip32Derivation() (*psbt.Bip32Derivation,
+	error) {
+
+	pubKey, err := hex.DecodeString(jd.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation pub key: %w", err)
+	}
+
+	return &psbt.Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: jd.Fingerprint,
+		Bip32Path:            jd.Bip32Path,
+	}, nil
+}
+
+// JSONTrBip32Derivation is the JSON representation of a Taproot BIP-0032
+// derivation.
+type JSONTrBip32Derivation struct {
+	XOnlyPubKey string   `json:"pub_key"`
+	LeafHashes  []string `json:"leaf_hashes"`
+	Fingerprint uint32   `json:"fingerprint"`
+	Bip32Path   []uint32 `json:"bip32_path"`
+}
+
+// NewJSONTrBip32Derivation creates the JSON representation of the given
+// Taproot BIP-0032 derivation.
+func NewJSONTrBip32Derivation(
+	b *psbt.TaprootBip32Derivation) *JSONTrBip32Derivation {
+
+	jd := &JSONTrBip32Derivation{
+		XOnlyPubKey: hex.EncodeToString(b.XOnlyPubKey),
+		Fingerprint: b.MasterKeyFingerprint,
+		Bip32Path:   b.Bip32Path,
+	}
+
+	for idx := range b.LeafHashes {
+		jd.LeafHashes = append(
+			jd.LeafHashes, hex.EncodeToString(b.LeafHashes[idx]),
+		)
+	}
+
+	return jd
+}
+
+// ToTrBip32Derivation converts the JSON representation back into a Taproot
+// BIP-0032 derivation.
+func (jd *JSONTrBip32Derivation) ToTrBip32Derivation() (
+	*psbt.TaprootBip32Derivation, error) {
+
+	xOnlyPubKey, err := hex.DecodeString(jd.XOnlyPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation pub key: %w", err)
+	}
+
+	d := &psbt.TaprootBip32Derivation{
+		XOnlyPubKey:          xOnlyPubKey,
+		MasterKeyFingerprint: jd.Fingerprint,
+		Bip32Path:            jd.Bip32Path,
+	}
+
+	// Older versions of the test vectors contain empty leaf hashes, which
+	// we skip as they can't be valid anyway.
+	for idx := range jd.LeafHashes {
+		if jd.LeafHashes[idx] == "" {
+			continue
+		}
+
+		leafHash, err := hex.DecodeString(jd.LeafHashes[idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid leaf hash: %w", err)
+		}
+
+		d.LeafHashes = append(d.LeafHashes, leafHash)
+	}
+
+	return d, nil
+}
+
+// JSONVOutput is the JSON representation of a virtual packet output. The
+// script key is represented by the pk_script and the derivation information of
+// its internal key, the same way it is represented in the PSBT encoding.
+//
+//nolint:lll
+type JSONVOutput struct {
+	Amount                        uint64                   `json:"amount"`
+	Type                          uint8                    `json:"type"`
+	AssetVersion                  uint32                   `json:"asset_version"`
+	Interactive                   bool                     `json:"interactive"`
+	AnchorOutputIndex             uint32                   `json:"anchor_output_index"`
+	AnchorOutputInternalKey       string                   `json:"anchor_output_internal_key"`
+	AnchorOutputBip32Derivation   []*JSONBip32Derivation   `json:"anchor_output_bip32_derivation"`
+	AnchorOutputTrBip32Derivation []*JSONTrBip32Derivation `json:"anchor_output_tr_bip32_derivation"`
+	AnchorOutputTapscriptSibling  string                   `json:"anchor_output_tapscript_sibling"`
+	Asset                         *asset.JSONAsset         `json:"asset"`
+	SplitAsset                    *asset.JSONAsset         `json:"split_asset"`
+	PkScript                      string                   `json:"pk_script"`
+	Bip32Derivation               []*JSONBip32Derivation   `json:"bip32_derivation"`
+	TrBip32Derivation             []*JSONTrBip32Derivation `json:"tr_bip32_derivation"`
+	TrInternalKey                 string                   `json:"tr_internal_key"`
+	TrMerkleRoot                  string                   `json:"tr_merkle_root"`
+	ProofDeliveryAddress          string                   `json:"proof_delivery_address"`
+	ProofSuffix                   *proof.JSONProof         `json:"proof_suffix"`
+	RelativeLockTime              uint64                   `json:"relative_lock_time"`
+	LockTime                      uint64                   `json:"lock_time"`
+	AltLeaves                     []*asset.JSONAsset       `json:"alt_leaves"`
+}
+
+// NewJSONVOutput creates the JSON representation of the given virtual output.
+// The coin type is used to create the derivation paths of the script key.
+func NewJSONVOutput(v *VOutput, coinType uint32) (*JSONVOutput, error) {
+	if v.ScriptKey.PubKey == nil {
+		return nil, fmt.Errorf("output is missing script key")
+	}
+
+	pkScript, err := payToTaprootScript(v.ScriptKey.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pk script: %w", err)
+	}
+
+	sibling, err := commitment.EncodeHexTapscriptPreimage(
+		v.AnchorOutputTapscriptSibling,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode tapscript sibling: %w",
+			err)
+	}
+
+	jo := &JSONVOutput{
+		Amount:            v.Amount,
+		Type:              uint8(v.Type),
+		AssetVersion:      uint32(v.AssetVersion),
+		Interactive:       v.Interactive,
+		AnchorOutputIndex: v.AnchorOutputIndex,
+		AnchorOutputInternalKey: asset.HexPubKey(
+			v.AnchorOutputInternalKey,
+		),
+		AnchorOutputBip32Derivation: newJSONBip32Derivations(
+			v.AnchorOutputBip32Derivation,
+		),
+		AnchorOutputTrBip32Derivation: newJSONTrBip32Derivations(
+			v.AnchorOutputTaprootBip32Derivation,
+		),
+		AnchorOutputTapscriptSibling: sibling,
+		PkScript:                     hex.EncodeToString(pkScript),
+		RelativeLockTime:             v.RelativeLockTime,
+		LockTime:                     v.LockTime,
+	}
+
+	if v.Asset != nil {
+		jo.Asset, err = asset.NewJSONAsset(v.Asset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode asset: %w",
+				err)
+		}
+	}
+
+	if v.SplitAsset != nil {
+		jo.SplitAsset, err = asset.NewJSONAsset(v.SplitAsset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode split "+
+				"asset: %w", err)
+		}
+	}
+
+	if v.ScriptKey.TweakedScriptKey != nil {
+		bip32Derivation, trBip32Derivation :=
+			Bip32DerivationFromKeyDesc(v.ScriptKey.RawKey, coinType)
+		jo.Bip32Derivation = []*JSONBip32Derivation{
+			NewJSONBip32Derivation(bip32Derivation),
+		}
+		jo.TrBip32Derivation = []*JSONTrBip32Derivation{
+			NewJSONTrBip32Derivation(trBip32Derivation),
+		}
+		jo.TrInternalKey = hex.EncodeToString(
+			trBip32Derivation.XOnlyPubKey,
+		)
+		jo.TrMerkleRoot = hex.EncodeToString(v.ScriptKey.Tweak)
+	}
+
+	if v.ProofDeliveryAddress != nil {
+		jo.ProofDeliveryAddress = v.ProofDeliveryAddress.String()
+	}
+
+	if v.ProofSuffix != nil {
+		jo.ProofSuffix, err = proof.NewJSONProof(v.ProofSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode proof "+
+				"suffix: %w", err)
+		}
+	}
+
+	jo.AltLeaves, err = newJSONAltLeaves(v.AltLeaves)
+	if err != nil {
+		return nil, err
+	}
+
+	return jo, nil
+}
+
+// ToVOutput converts the JSON representation back into a virtual output.
+func (jo *JSONVOutput) ToVOutput() (*VOutput, error) {
+	pkScript, err := hex.DecodeString(jo.PkScript)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pk script: %w", err)
+	}
+	if len(pkScript) != schnorr.PubKeyBytesLen+2 {
+		return nil, fmt.Errorf("%w: invalid pk script length, "+
+			"expected %d, got %d", asset.ErrInvalidJSON,
+			schnorr.PubKeyBytesLen+2, len(pkScript))
+	}
+
+	scriptKey, err := schnorr.ParsePubKey(pkScript[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid script key: %w", err)
+	}
+
+	sibling, err := commitment.DecodeHexTapscriptPreimage(
+		jo.AnchorOutputTapscriptSibling,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &VOutput{
+		Amount:                       jo.Amount,
+		Type:                         VOutputType(jo.Type),
+		Interactive:                  jo.Interactive,
+		AssetVersion:                 asset.Version(jo.AssetVersion),
+		AnchorOutputIndex:            jo.AnchorOutputIndex,
+		AnchorOutputTapscriptSibling: sibling,
+		ScriptKey: asset.ScriptKey{
+			PubKey: scriptKey,
+		},
+		RelativeLockTime: jo.RelativeLockTime,
+		LockTime:         jo.LockTime,
+	}
+
+	if jo.AnchorOutputInternalKey != "" {
+		v.AnchorOutputInternalKey, err = asset.ParseHexPubKey(
+			jo.AnchorOutputInternalKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anchor output "+
+				"internal key: %w", err)
+		}
+	}
+
+	v.AnchorOutputBip32Derivation, err = toBip32Derivations(
+		jo.AnchorOutputBip32Derivation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	v.AnchorOutputTaprootBip32Derivation, err = toTrBip32Derivations(
+		jo.AnchorOutputTrBip32Derivation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if jo.Asset != nil {
+		v.Asset, err = jo.Asset.ToAsset()
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset: %w", err)
+		}
+	}
+
+	if jo.SplitAsset != nil {
+		v.SplitAsset, err = jo.SplitAsset.ToAsset()
+		if err != nil {
+			return nil, fmt.Errorf("invalid split asset: %w", err)
+		}
+	}
+
+	if len(jo.Bip32Derivation) > 0 && jo.TrInternalKey != "" {
+		firstDerivation := jo.Bip32Derivation[0]
+		bip32Derivation, err := firstDerivation.ToBip32Derivation()
+		if err != nil {
+			return nil, err
+		}
+
+		rawKeyDesc, err := KeyDescFromBip32Derivation(bip32Derivation)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding script key "+
+				"derivation info: %w", err)
+		}
+
+		tweak, err := decodeOptionalHex(jo.TrMerkleRoot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merkle root: %w", err)
+		}
+
+		v.ScriptKey.TweakedScriptKey = &asset.TweakedScriptKey{
+			RawKey: rawKeyDesc,
+			Tweak:  tweak,
+		}
+	}
+
+	if jo.ProofDeliveryAddress != "" {
+		v.ProofDeliveryAddress, err = url.Parse(jo.ProofDeliveryAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof delivery "+
+				"address: %w", err)
+		}
+	}
+
+	if jo.ProofSuffix != nil {
+		v.ProofSuffix, err = jo.ProofSuffix.ToProof()
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof suffix: %w", err)
+		}
+	}
+
+	v.AltLeaves, err = toAltLeaves(jo.AltLeaves)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// EncodeJSON encodes the given virtual packet in its canonical JSON
+// representation.
+func EncodeJSON(p *VPacket) ([]byte, error) {
+	jsonPacket, err := NewJSONVPacket(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonPacket)
+}
+
+// DecodeJSON decodes a virtual packet from its canonical JSON representation.
+func DecodeJSON(jsonBytes []byte) (*VPacket, error) {
+	var jsonPacket JSONVPacket
+	if err := json.Unmarshal(jsonBytes, &jsonPacket); err != nil {
+		return nil, fmt.Errorf("%w: %w", asset.ErrInvalidJSON, err)
+	}
+
+	return jsonPacket.ToVPacket()
+}
+
+// newJSONBip32Derivations creates the JSON representation of the given
+// BIP-0032 derivations.
+func newJSONBip32Derivations(
+	derivations []*psbt.Bip32Derivation) []*JSONBip32Derivation {
+
+	var result []*JSONBip32Derivation
+	for idx := range derivations {
+		result = append(
+			result, NewJSONBip32Derivation(derivations[idx]),
+		)
+	}
+
+	return result
+}
+
+// toBip32Derivations converts the JSON representation of BIP-0032
+// derivations back into PSBT derivations.
+func toBip32Derivations(
+	derivations []*JSONBip32Derivation) ([]*psbt.Bip32Derivation, error) {
+
+	var result []*psbt.Bip32Derivation
+	for idx := range derivations {
+		d, err := derivations[idx].ToBip32Derivation()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, d)
+	}
+
+	return result, nil
+}
+
+// newJSONTrBip32Derivations creates the JSON representation of the given
+// Taproot BIP-0032 derivations.
+func newJSONTrBip32Derivations(
+	derivations []*psbt.TaprootBip32Derivation) []*JSONTrBip32Derivation {
+
+	var result []*JSONTrBip32Derivation
+	for idx := range derivations {
+		result = append(
+			result, NewJSONTrBip32Derivation(derivations[idx]),
+		)
+	}
+
+	return result
+}
+
+// toTrBip32Derivations converts the JSON representation of Taproot BIP-0032
+// derivations back into PSBT derivations.
+func toTrBip32Derivations(derivations []*JSONTrBip32Derivation) (
+	[]*psbt.TaprootBip32Derivation, error) {
+
+	var result []*psbt.TaprootBip32Derivation
+	for idx := range derivations {
+		d, err := derivations[idx].ToTrBip32Derivation()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, d)
+	}
+
+	return result, nil
+}
+
+// newJSONAltLeaves creates the JSON representation of the given alt leaves.
+// Only alt leaves of the concrete type *asset.Asset are supported.
+func newJSONAltLeaves(altLeaves []AltLeafAsset) ([]*asset.JSONAsset, error) {
+	var result []*asset.JSONAsset
+	for idx := range altLeaves {
+		leaf, ok := altLeaves[idx].(*asset.Asset)
+		if !ok {
+			return nil, fmt.Errorf("alt leaf must be of type "+
+				"*asset.Asset, got %T", altLeaves[idx])
+		}
+
+		jsonLeaf, err := asset.NewJSONAsset(leaf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode alt leaf: %w",
+				err)
+		}
+
+		result = append(result, jsonLeaf)
+	}
+
+	return result, nil
+}
+
+// toAltLeaves converts the JSON representation of alt leaves back into assets.
+func toAltLeaves(jsonLeaves []*asset.JSONAsset) ([]AltLeafAsset, error) {
+	var result []AltLeafAsset
+	for idx := range jsonLeaves {
+		leaf, err := jsonLeaves[idx].ToAsset()
+		if err != nil {
+			return nil, fmt.Errorf("invalid alt leaf: %w", err)
+		}
+
+		result = append(result, leaf)
+	}
+
+	return result, nil
+}
+
+// decodeOptionalHex decodes the given hex string, returning nil for an empty
+// string.
+func decodeOptionalHex(hexStr string) ([]byte, error) {
+	if hexStr == "" {
+		return nil, nil
+	}
+
+	return hex.DecodeString(hexStr)
+}
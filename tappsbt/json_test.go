@@ -0,0 +1,88 @@
+package tappsbt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonTestVectors is a minimal version of the BIP test vector format that
+// keeps the packets in their raw JSON form.
+type jsonTestVectors struct {
+	ValidTestCases []struct {
+		Packet   json.RawMessage `json:"packet"`
+		Expected string          `json:"expected"`
+		Comment  string          `json:"comment"`
+	} `json:"valid_test_cases"`
+}
+
+// TestJSONTestVectors tests that the packets in the BIP test vectors can be
+// decoded with the canonical JSON encoding and result in the expected PSBT
+// encoding.
+func TestJSONTestVectors(t *testing.T) {
+	t.Parallel()
+
+	var testVectors jsonTestVectors
+	test.ParseTestVectors(t, generatedTestVectorName, &testVectors)
+
+	require.NotEmpty(t, testVectors.ValidTestCases)
+	for _, validCase := range testVectors.ValidTestCases {
+		p, err := DecodeJSON(validCase.Packet)
+		require.NoError(t, err, validCase.Comment)
+
+		packetString, err := p.B64Encode()
+		require.NoError(t, err, validCase.Comment)
+		require.Equal(
+			t, validCase.Expected, packetString, validCase.Comment,
+		)
+
+		assertJSONPacketRoundTrip(t, p)
+	}
+}
+
+// TestJSONRandPacket tests that random packets survive a round trip through
+// the canonical JSON encoding.
+func TestJSONRandPacket(t *testing.T) {
+	t.Parallel()
+
+	for _, altLeaves := range []bool{false, true} {
+		p := RandPacket(t, test.RandBool(), altLeaves)
+		assertJSONPacketRoundTrip(t, p)
+	}
+}
+
+// TestJSONInvalidPacket tests that invalid JSON packets are rejected.
+func TestJSONInvalidPacket(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeJSON([]byte(`{"version": 0}`))
+	require.ErrorContains(t, err, "missing chain params HRP")
+
+	_, err = DecodeJSON([]byte(`{"chain_params_hrp": "foo"}`))
+	require.ErrorContains(t, err, "invalid chain params HRP")
+
+	_, err = DecodeJSON([]byte(`{"chain_params_hrp": "tapbc", ` +
+		`"outputs": [{"pk_script": "51"}]}`))
+	require.ErrorContains(t, err, "invalid pk script length")
+}
+
+// assertJSONPacketRoundTrip asserts that the given packet results in the same
+// PSBT encoding after a round trip through the canonical JSON encoding.
+func assertJSONPacketRoundTrip(t *testing.T, p *VPacket) {
+	t.Helper()
+
+	expected, err := p.B64Encode()
+	require.NoError(t, err)
+
+	jsonBytes, err := EncodeJSON(p)
+	require.NoError(t, err)
+
+	decoded, err := DecodeJSON(jsonBytes)
+	require.NoError(t, err)
+
+	actual, err := decoded.B64Encode()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
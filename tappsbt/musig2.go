@@ -0,0 +1,416 @@
+package tappsbt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// MuSig2PubNonce is the public MuSig2 nonce of a single participant.
+type MuSig2PubNonce struct {
+	// ParticipantKey is the individual key of the participant.
+	ParticipantKey *btcec.PublicKey
+
+	// Nonce is the public nonce of the participant.
+	Nonce [musig2.PubNonceSize]byte
+}
+
+// MuSig2PartialSig is the MuSig2 partial signature of a single participant.
+type MuSig2PartialSig struct {
+	// ParticipantKey is the individual key of the participant.
+	ParticipantKey *btcec.PublicKey
+
+	// Sig is the partial signature (the s value) of the participant.
+	Sig [32]byte
+}
+
+// MuSig2Info contains the information needed to sign a virtual input whose
+// script key is the MuSig2 aggregate key of several participants through its
+// key path.
+type MuSig2Info struct {
+	// ParticipantKeys are the individual keys of all participants. The
+	// keys are sorted before they are aggregated.
+	ParticipantKeys []*btcec.PublicKey
+
+	// TapscriptRoot is the root of the tapscript tree the aggregate key is
+	// tweaked with. If it is empty, the aggregate key is tweaked as a
+	// BIP-0086 key.
+	TapscriptRoot []byte
+
+	// PubNonces are the public nonces the participants submitted so far.
+	PubNonces []MuSig2PubNonce
+
+	// PartialSigs are the partial signatures the participants submitted
+	// so far.
+	PartialSigs []MuSig2PartialSig
+}
+
+// Copy returns a deep copy of the MuSig2 information.
+func (m *MuSig2Info) Copy() *MuSig2Info {
+	if m == nil {
+		return nil
+	}
+
+	return &MuSig2Info{
+		ParticipantKeys: append(
+			[]*btcec.PublicKey{}, m.ParticipantKeys...,
+		),
+		TapscriptRoot: fn.CopySlice(m.TapscriptRoot),
+		PubNonces:     append([]MuSig2PubNonce{}, m.PubNonces...),
+		PartialSigs:   append([]MuSig2PartialSig{}, m.PartialSigs...),
+	}
+}
+
+// HasParticipant returns true if the given key is one of the participant keys.
+func (m *MuSig2Info) HasParticipant(key *btcec.PublicKey) bool {
+	return fn.Any(m.ParticipantKeys, key.IsEqual)
+}
+
+// PubNonce returns the public nonce of the given participant, if it was
+// submitted already.
+func (m *MuSig2Info) PubNonce(key *btcec.PublicKey) (
+	[musig2.PubNonceSize]byte, bool) {
+
+	for _, nonce := range m.PubNonces {
+		if nonce.ParticipantKey.IsEqual(key) {
+			return nonce.Nonce, true
+		}
+	}
+
+	return [musig2.PubNonceSize]byte{}, false
+}
+
+// SetPubNonce sets the public nonce of the given participant, replacing any
+// nonce the participant submitted before. Partial signatures created with a
+// previous set of nonces are no longer valid, so all of them are removed.
+func (m *MuSig2Info) SetPubNonce(key *btcec.PublicKey,
+	nonce [musig2.PubNonceSize]byte) error {
+
+	if !m.HasParticipant(key) {
+		return fmt.Errorf("key %x is not a MuSig2 participant",
+			key.SerializeCompressed())
+	}
+
+	for idx := range m.PubNonces {
+		if m.PubNonces[idx].ParticipantKey.IsEqual(key) {
+			if m.PubNonces[idx].Nonce != nonce {
+				m.PartialSigs = nil
+			}
+			m.PubNonces[idx].Nonce = nonce
+
+			return nil
+		}
+	}
+
+	m.PartialSigs = nil
+	m.PubNonces = append(m.PubNonces, MuSig2PubNonce{
+		ParticipantKey: key,
+		Nonce:          nonce,
+	})
+
+	return nil
+}
+
+// CombinedNonce returns the aggregate of the public nonces of all
+// participants, or false if not all participants submitted their nonce yet.
+func (m *MuSig2Info) CombinedNonce() ([musig2.PubNonceSize]byte, bool,
+	error) {
+
+	var combinedNonce [musig2.PubNonceSize]byte
+	nonces := make([][musig2.PubNonceSize]byte, 0, len(m.ParticipantKeys))
+	for _, key := range m.ParticipantKeys {
+		nonce, ok := m.PubNonce(key)
+		if !ok {
+			return combinedNonce, false, nil
+		}
+
+		nonces = append(nonces, nonce)
+	}
+
+	combinedNonce, err := musig2.AggregateNonces(nonces)
+	if err != nil {
+		return combinedNonce, false, err
+	}
+
+	return combinedNonce, true, nil
+}
+
+// PartialSig returns the partial signature of the given participant, if it was
+// submitted already.
+func (m *MuSig2Info) PartialSig(key *btcec.PublicKey) (*musig2.PartialSignature,
+	bool) {
+
+	for _, sig := range m.PartialSigs {
+		if !sig.ParticipantKey.IsEqual(key) {
+			continue
+		}
+
+		partialSig := &musig2.PartialSignature{}
+		if err := partialSig.Decode(bytes.NewReader(
+			sig.Sig[:],
+		)); err != nil {
+			return nil, false
+		}
+
+		return partialSig, true
+	}
+
+	return nil, false
+}
+
+// SetPartialSig sets the partial signature of the given participant, replacing
+// any signature the participant submitted before.
+func (m *MuSig2Info) SetPartialSig(key *btcec.PublicKey,
+	sig *musig2.PartialSignature) error {
+
+	if !m.HasParticipant(key) {
+		return fmt.Errorf("key %x is not a MuSig2 participant",
+			key.SerializeCompressed())
+	}
+
+	var (
+		rawSig [32]byte
+		b      bytes.Buffer
+	)
+	if err := sig.Encode(&b); err != nil {
+		return err
+	}
+	copy(rawSig[:], b.Bytes())
+
+	for idx := range m.PartialSigs {
+		if m.PartialSigs[idx].ParticipantKey.IsEqual(key) {
+			m.PartialSigs[idx].Sig = rawSig
+
+			return nil
+		}
+	}
+
+	m.PartialSigs = append(m.PartialSigs, MuSig2PartialSig{
+		ParticipantKey: key,
+		Sig:            rawSig,
+	})
+
+	return nil
+}
+
+// muSig2KeysEncoder is an encoder that does nothing if the given MuSig2
+// information is nil and otherwise encodes the participant keys.
+func muSig2KeysEncoder(m *MuSig2Info) encoderFunc {
+	if m == nil {
+		return func([]byte) ([]*customPsbtField, error) {
+			return nil, nil
+		}
+	}
+
+	return tlvEncoder(&m.ParticipantKeys, pubKeyListEncoder)
+}
+
+// muSig2TapscriptRootEncoder is an encoder that does nothing if the given
+// MuSig2 information is nil or doesn't have a tapscript root.
+func muSig2TapscriptRootEncoder(m *MuSig2Info) encoderFunc {
+	if m == nil || len(m.TapscriptRoot) == 0 {
+		return func([]byte) ([]*customPsbtField, error) {
+			return nil, nil
+		}
+	}
+
+	return tlvEncoder(&m.TapscriptRoot, tlv.EVarBytes)
+}
+
+// muSig2NoncesEncoder is an encoder that does nothing if the given MuSig2
+// information is nil or doesn't have any public nonces.
+func muSig2NoncesEncoder(m *MuSig2Info) encoderFunc {
+	if m == nil || len(m.PubNonces) == 0 {
+		return func([]byte) ([]*customPsbtField, error) {
+			return nil, nil
+		}
+	}
+
+	return tlvEncoder(&m.PubNonces, pubNonceListEncoder)
+}
+
+// muSig2PartialSigsEncoder is an encoder that does nothing if the given MuSig2
+// information is nil or doesn't have any partial signatures.
+func muSig2PartialSigsEncoder(m *MuSig2Info) encoderFunc {
+	if m == nil || len(m.PartialSigs) == 0 {
+		return func([]byte) ([]*customPsbtField, error) {
+			return nil, nil
+		}
+	}
+
+	return tlvEncoder(&m.PartialSigs, partialSigListEncoder)
+}
+
+// pubKeyListEncoder encodes a list of compressed public keys.
+func pubKeyListEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*[]*btcec.PublicKey); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+
+		for _, key := range *t {
+			_, err := w.Write(key.SerializeCompressed())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "[]*btcec.PublicKey")
+}
+
+// pubKeyListDecoder decodes a list of compressed public keys.
+func pubKeyListDecoder(r io.Reader, val any, buf *[8]byte, _ uint64) error {
+	if t, ok := val.(*[]*btcec.PublicKey); ok {
+		numKeys, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		var keys []*btcec.PublicKey
+		for i := uint64(0); i < numKeys; i++ {
+			key, err := readPubKey(r)
+			if err != nil {
+				return err
+			}
+
+			keys = append(keys, key)
+		}
+		*t = keys
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "[]*btcec.PublicKey", 0, 0)
+}
+
+// pubNonceListEncoder encodes a list of participant keys and their public
+// nonces.
+func pubNonceListEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*[]MuSig2PubNonce); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+
+		for _, nonce := range *t {
+			key := nonce.ParticipantKey.SerializeCompressed()
+			if _, err := w.Write(key); err != nil {
+				return err
+			}
+			if _, err := w.Write(nonce.Nonce[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "[]MuSig2PubNonce")
+}
+
+// pubNonceListDecoder decodes a list of participant keys and their public
+// nonces.
+func pubNonceListDecoder(r io.Reader, val any, buf *[8]byte, _ uint64) error {
+	if t, ok := val.(*[]MuSig2PubNonce); ok {
+		numNonces, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		var nonces []MuSig2PubNonce
+		for i := uint64(0); i < numNonces; i++ {
+			var nonce MuSig2PubNonce
+			nonce.ParticipantKey, err = readPubKey(r)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.ReadFull(r, nonce.Nonce[:])
+			if err != nil {
+				return err
+			}
+
+			nonces = append(nonces, nonce)
+		}
+		*t = nonces
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "[]MuSig2PubNonce", 0, 0)
+}
+
+// partialSigListEncoder encodes a list of participant keys and their partial
+// signatures.
+func partialSigListEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*[]MuSig2PartialSig); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+
+		for _, sig := range *t {
+			key := sig.ParticipantKey.SerializeCompressed()
+			if _, err := w.Write(key); err != nil {
+				return err
+			}
+			if _, err := w.Write(sig.Sig[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "[]MuSig2PartialSig")
+}
+
+// partialSigListDecoder decodes a list of participant keys and their partial
+// signatures.
+func partialSigListDecoder(r io.Reader, val any, buf *[8]byte,
+	_ uint64) error {
+
+	if t, ok := val.(*[]MuSig2PartialSig); ok {
+		numSigs, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		var sigs []MuSig2PartialSig
+		for i := uint64(0); i < numSigs; i++ {
+			var sig MuSig2PartialSig
+			sig.ParticipantKey, err = readPubKey(r)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.ReadFull(r, sig.Sig[:])
+			if err != nil {
+				return err
+			}
+
+			sigs = append(sigs, sig)
+		}
+		*t = sigs
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "[]MuSig2PartialSig", 0, 0)
+}
+
+// readPubKey reads a single compressed public key.
+func readPubKey(r io.Reader) (*btcec.PublicKey, error) {
+	var keyBytes [btcec.PubKeyBytesLenCompressed]byte
+	if _, err := io.ReadFull(r, keyBytes[:]); err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(keyBytes[:])
+}
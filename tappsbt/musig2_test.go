@@ -0,0 +1,82 @@
+package tappsbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMuSig2InfoEncoding tests that the MuSig2 information of a virtual input
+// survives a round trip through the binary and the JSON encoding.
+func TestMuSig2InfoEncoding(t *testing.T) {
+	t.Parallel()
+
+	pkt := RandPacket(t, test.RandBool(), false)
+
+	nonces := []*musig2.Nonces{}
+	muSig2 := &MuSig2Info{
+		TapscriptRoot: test.RandBytes(32),
+	}
+	for i := 0; i < 3; i++ {
+		key := test.RandPubKey(t)
+		muSig2.ParticipantKeys = append(muSig2.ParticipantKeys, key)
+
+		nonce, err := musig2.GenNonces(musig2.WithPublicKey(key))
+		require.NoError(t, err)
+		nonces = append(nonces, nonce)
+	}
+
+	// The first two participants submit their nonces, the first one also
+	// its partial signature.
+	for i := 0; i < 2; i++ {
+		err := muSig2.SetPubNonce(
+			muSig2.ParticipantKeys[i], nonces[i].PubNonce,
+		)
+		require.NoError(t, err)
+	}
+
+	var s btcec.ModNScalar
+	s.SetByteSlice(test.RandBytes(32))
+	sig := musig2.PartialSignature{S: &s}
+	err := muSig2.SetPartialSig(muSig2.ParticipantKeys[0], &sig)
+	require.NoError(t, err)
+
+	pkt.Inputs[0].MuSig2 = muSig2
+
+	var buf bytes.Buffer
+	require.NoError(t, pkt.Serialize(&buf))
+
+	decoded, err := NewFromRawBytes(&buf, false)
+	require.NoError(t, err)
+	require.Equal(t, muSig2, decoded.Inputs[0].MuSig2)
+	require.Nil(t, decoded.Inputs[1].MuSig2)
+
+	assertJSONPacketRoundTrip(t, pkt)
+
+	// Not all nonces are known yet, so there is no combined nonce.
+	_, ok, err := muSig2.CombinedNonce()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	partialSig, ok := muSig2.PartialSig(muSig2.ParticipantKeys[0])
+	require.True(t, ok)
+	require.True(t, partialSig.S.Equals(sig.S))
+
+	// Once the last nonce is added, the previous partial signatures are
+	// dropped since they were created for a different combined nonce.
+	err = muSig2.SetPubNonce(muSig2.ParticipantKeys[2], nonces[2].PubNonce)
+	require.NoError(t, err)
+	require.Empty(t, muSig2.PartialSigs)
+
+	_, ok, err = muSig2.CombinedNonce()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Keys that aren't participants are rejected.
+	err = muSig2.SetPubNonce(test.RandPubKey(t), nonces[0].PubNonce)
+	require.ErrorContains(t, err, "not a MuSig2 participant")
+}
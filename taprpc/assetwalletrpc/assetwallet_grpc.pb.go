@@ -77,6 +77,147 @@ type AssetWalletClient interface {
 	// recognized by the wallet automatically. Declaring a script key will make any
 	// assets sent to the script key be recognized as being local assets.
 	DeclareScriptKey(ctx context.Context, in *DeclareScriptKeyRequest, opts ...grpc.CallOption) (*DeclareScriptKeyResponse, error)
+	// NewAccount creates a new named asset account. Each account derives the
+	// keys of its addresses and transfers from its own key family, which gives it
+	// a separate key scope and balance view. Assets that don't belong to a named
+	// account belong to the implicit "default" account. If the account is
+	// created for a tenant, a macaroon of a root key of its own is baked for the
+	// tenant, which only gives access to the assets of the account.
+	NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error)
+	// ListAccounts lists all named asset accounts.
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// NewIssuerPolicyScriptKey creates the script key of a regulated asset that
+	// is controlled by both its holder and its issuer, and declares it to the
+	// wallet. Regular transfers of assets locked to the script key require a
+	// signature of both the holder and the issuer, while the issuer alone can
+	// claw the assets back. The script key can be used to mint assets or to
+	// create addresses.
+	NewIssuerPolicyScriptKey(ctx context.Context, in *NewIssuerPolicyScriptKeyRequest, opts ...grpc.CallOption) (*NewIssuerPolicyScriptKeyResponse, error)
+	// SignIssuerPolicyPsbt signs a funded virtual PSBT that spends assets locked
+	// to an issuer policy script key with all keys of the policy that the wallet
+	// controls. The signatures are added as partial signatures, so the PSBT can
+	// be passed on to the other signer. Once all required signatures are
+	// present, the witnesses are finalized. The issuer refuses to co-sign the
+	// transfer of assets locked to a frozen script key.
+	SignIssuerPolicyPsbt(ctx context.Context, in *SignIssuerPolicyPsbtRequest, opts ...grpc.CallOption) (*SignIssuerPolicyPsbtResponse, error)
+	// FreezeScriptKey freezes an issuer policy script key, which makes the
+	// issuer refuse to co-sign regular transfers of the assets locked to it.
+	FreezeScriptKey(ctx context.Context, in *FreezeScriptKeyRequest, opts ...grpc.CallOption) (*FreezeScriptKeyResponse, error)
+	// UnfreezeScriptKey unfreezes a frozen issuer policy script key.
+	UnfreezeScriptKey(ctx context.Context, in *UnfreezeScriptKeyRequest, opts ...grpc.CallOption) (*UnfreezeScriptKeyResponse, error)
+	// ListFrozenScriptKeys lists all frozen issuer policy script keys.
+	ListFrozenScriptKeys(ctx context.Context, in *ListFrozenScriptKeysRequest, opts ...grpc.CallOption) (*ListFrozenScriptKeysResponse, error)
+	// NewMuSig2ScriptKey creates the script key that is the MuSig2 aggregate of
+	// the given participant keys and declares it to the wallet. Assets locked to
+	// the script key can only be spent cooperatively by all participants through
+	// the CreateMuSig2Nonce, SignMuSig2Input and CombineMuSig2Sigs RPCs.
+	NewMuSig2ScriptKey(ctx context.Context, in *NewMuSig2ScriptKeyRequest, opts ...grpc.CallOption) (*NewMuSig2ScriptKeyResponse, error)
+	// CreateMuSig2Nonce starts a MuSig2 signing session for an input of a funded
+	// virtual PSBT that spends an asset locked to a MuSig2 script key. The
+	// participant keys and the public nonce of the wallet's participant key are
+	// added to the input of the virtual PSBT, which is then passed on to the
+	// other participants.
+	CreateMuSig2Nonce(ctx context.Context, in *CreateMuSig2NonceRequest, opts ...grpc.CallOption) (*CreateMuSig2NonceResponse, error)
+	// SignMuSig2Input creates the MuSig2 partial signature of the wallet's
+	// participant key for an input of a virtual PSBT once the public nonces of
+	// all participants are present. The partial signature is added to the input
+	// of the virtual PSBT.
+	SignMuSig2Input(ctx context.Context, in *SignMuSig2InputRequest, opts ...grpc.CallOption) (*SignMuSig2InputResponse, error)
+	// CombineMuSig2Sigs combines the MuSig2 partial signatures of all
+	// participants of an input of a virtual PSBT into its final witness. Once
+	// all inputs have a witness, the virtual transaction is validated.
+	CombineMuSig2Sigs(ctx context.Context, in *CombineMuSig2SigsRequest, opts ...grpc.CallOption) (*CombineMuSig2SigsResponse, error)
+	// NewHashLockScriptKey creates an HTLC-style script key and declares it to
+	// the wallet. Assets locked to the script key can either be swept by the
+	// claimer by revealing the preimage of the payment hash, or by the refunder
+	// once the CSV delay has passed. Locking assets to the script key and BTC to
+	// an on-chain HTLC with the same payment hash allows the two to be swapped
+	// atomically.
+	NewHashLockScriptKey(ctx context.Context, in *NewHashLockScriptKeyRequest, opts ...grpc.CallOption) (*NewHashLockScriptKeyResponse, error)
+	// SweepHashLock sweeps all assets of the given asset ID that are locked to a
+	// hash lock script key to a new script key of the wallet, through either the
+	// success or the timeout path. The wallet must control the key of the path.
+	SweepHashLock(ctx context.Context, in *SweepHashLockRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error)
+	// NewTimeLockScriptKey creates a script key that locks assets to a key until
+	// an absolute lock time and/or a relative lock time have passed. If no key is
+	// given, a new key of the wallet is derived. The script key is only declared
+	// to the wallet if the wallet controls the key, so assets sent to it are
+	// shown as owned.
+	NewTimeLockScriptKey(ctx context.Context, in *NewTimeLockScriptKeyRequest, opts ...grpc.CallOption) (*NewTimeLockScriptKeyResponse, error)
+	// SendTimeLocked sends an asset to multiple recipients in one transfer, each
+	// of which receives its amount locked to its own time lock script key, for
+	// example to vest assets. Each recipient output is anchored in its own BTC
+	// output. The proofs of recipients that aren't part of this wallet must be
+	// exported and handed over out of band.
+	SendTimeLocked(ctx context.Context, in *SendTimeLockedRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error)
+	// SweepTimeLock sweeps all assets of the given asset ID that are locked to a
+	// time lock script key to a new script key of the wallet, once the lock
+	// times have passed. The wallet must control the key of the time lock.
+	SweepTimeLock(ctx context.Context, in *SweepTimeLockRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error)
+	// CreateSigningSession creates a multi-party signing session with the funded
+	// virtual PSBTs of the creating party. Other parties can add their inputs and
+	// outputs to the session before it is signed. Sessions are kept in memory
+	// only and expire after 24 hours.
+	CreateSigningSession(ctx context.Context, in *CreateSigningSessionRequest, opts ...grpc.CallOption) (*CreateSigningSessionResponse, error)
+	// ContributeToSession adds the inputs and outputs of the funded virtual
+	// PSBTs of another party to an open signing session. Virtual PSBTs that
+	// spend the same asset as a virtual PSBT of the session are merged into it.
+	ContributeToSession(ctx context.Context, in *ContributeToSessionRequest, opts ...grpc.CallOption) (*ContributeToSessionResponse, error)
+	// StartSessionSigning closes a signing session for contributions and
+	// prepares its virtual transactions for signing. Inputs that are locked to a
+	// MuSig2 key of several participants can be declared, so their participants
+	// can exchange their nonces and partial signatures through the session.
+	StartSessionSigning(ctx context.Context, in *StartSessionSigningRequest, opts ...grpc.CallOption) (*StartSessionSigningResponse, error)
+	// SubmitSessionSignatures adds the witnesses of a party's inputs, as created
+	// by SignVirtualPsbt with the partial flag set, and MuSig2 nonces and partial
+	// signatures to a signing session.
+	SubmitSessionSignatures(ctx context.Context, in *SubmitSessionSignaturesRequest, opts ...grpc.CallOption) (*SubmitSessionSignaturesResponse, error)
+	// FinalizeSession combines the MuSig2 partial signatures of a signing
+	// session and validates the witnesses of all its virtual transactions.
+	FinalizeSession(ctx context.Context, in *FinalizeSessionRequest, opts ...grpc.CallOption) (*FinalizeSessionResponse, error)
+	// AnchorSession commits the finalized virtual transactions of a signing
+	// session to a BTC level anchor transaction that is funded by this daemon's
+	// wallet. The anchor transaction must then be signed by the parties owning
+	// its inputs and published with PublishSession.
+	AnchorSession(ctx context.Context, in *AnchorSessionRequest, opts ...grpc.CallOption) (*AnchorSessionResponse, error)
+	// PublishSession adds the signatures of the given signed anchor transactions
+	// to the anchor transaction of a signing session and, once it is fully
+	// signed, publishes it and logs the transfer.
+	PublishSession(ctx context.Context, in *PublishSessionRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error)
+	// GetSigningSession returns the current state of a signing session.
+	GetSigningSession(ctx context.Context, in *GetSigningSessionRequest, opts ...grpc.CallOption) (*GetSigningSessionResponse, error)
+	// CreateSwapOffer creates an offer to sell an asset for BTC or another asset
+	// to another daemon in an atomic swap. The returned quote must be passed to
+	// the buyer, who accepts it with AcceptSwapOffer. Swaps are kept in memory
+	// only.
+	CreateSwapOffer(ctx context.Context, in *CreateSwapOfferRequest, opts ...grpc.CallOption) (*CreateSwapOfferResponse, error)
+	// AcceptSwapOffer accepts the quote of a seller and derives the keys the
+	// asset is sent to. The returned acceptance must be passed to the seller,
+	// who creates its proposal with ProposeSwap.
+	AcceptSwapOffer(ctx context.Context, in *AcceptSwapOfferRequest, opts ...grpc.CallOption) (*AcceptSwapOfferResponse, error)
+	// ProposeSwap funds and signs the virtual transaction that sends the asset
+	// of an offered swap to the buyer. The returned proposal must be passed to
+	// the buyer, who funds the anchor transaction with FundSwap.
+	ProposeSwap(ctx context.Context, in *ProposeSwapRequest, opts ...grpc.CallOption) (*ProposeSwapResponse, error)
+	// FundSwap validates the seller's proposal of a swap and commits its virtual
+	// transactions to an anchor transaction that also pays the seller. In an
+	// asset-for-asset swap, the seller is paid by a virtual transaction that
+	// sends the price asset and is funded and signed by this daemon, too. The
+	// anchor transaction is funded and signed by this daemon's wallet. The
+	// returned funding must be passed to the seller, who publishes the anchor
+	// transaction with PublishSwap.
+	FundSwap(ctx context.Context, in *FundSwapRequest, opts ...grpc.CallOption) (*FundSwapResponse, error)
+	// PublishSwap validates the buyer's funding of a swap and, if the anchor
+	// transaction pays the agreed price, signs the seller's inputs, publishes
+	// the anchor transaction and logs the transfer.
+	PublishSwap(ctx context.Context, in *PublishSwapRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error)
+	// CompleteSwap waits for the anchor transaction of a swap to confirm and
+	// imports the proof of the asset this daemon received. The buyer completes
+	// a funded swap, the seller a published asset-for-asset swap. The buyer of
+	// an asset-for-asset swap also logs the transfer of its price asset.
+	CompleteSwap(ctx context.Context, in *CompleteSwapRequest, opts ...grpc.CallOption) (*CompleteSwapResponse, error)
+	// ListSwaps lists all swaps this daemon takes part in.
+	ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error)
 }
 
 type assetWalletClient struct {
@@ -204,6 +345,285 @@ func (c *assetWalletClient) DeclareScriptKey(ctx context.Context, in *DeclareScr
 	return out, nil
 }
 
+func (c *assetWalletClient) NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error) {
+	out := new(NewAccountResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/NewAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) NewIssuerPolicyScriptKey(ctx context.Context, in *NewIssuerPolicyScriptKeyRequest, opts ...grpc.CallOption) (*NewIssuerPolicyScriptKeyResponse, error) {
+	out := new(NewIssuerPolicyScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/NewIssuerPolicyScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SignIssuerPolicyPsbt(ctx context.Context, in *SignIssuerPolicyPsbtRequest, opts ...grpc.CallOption) (*SignIssuerPolicyPsbtResponse, error) {
+	out := new(SignIssuerPolicyPsbtResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SignIssuerPolicyPsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) FreezeScriptKey(ctx context.Context, in *FreezeScriptKeyRequest, opts ...grpc.CallOption) (*FreezeScriptKeyResponse, error) {
+	out := new(FreezeScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/FreezeScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) UnfreezeScriptKey(ctx context.Context, in *UnfreezeScriptKeyRequest, opts ...grpc.CallOption) (*UnfreezeScriptKeyResponse, error) {
+	out := new(UnfreezeScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/UnfreezeScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) ListFrozenScriptKeys(ctx context.Context, in *ListFrozenScriptKeysRequest, opts ...grpc.CallOption) (*ListFrozenScriptKeysResponse, error) {
+	out := new(ListFrozenScriptKeysResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/ListFrozenScriptKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) NewMuSig2ScriptKey(ctx context.Context, in *NewMuSig2ScriptKeyRequest, opts ...grpc.CallOption) (*NewMuSig2ScriptKeyResponse, error) {
+	out := new(NewMuSig2ScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/NewMuSig2ScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) CreateMuSig2Nonce(ctx context.Context, in *CreateMuSig2NonceRequest, opts ...grpc.CallOption) (*CreateMuSig2NonceResponse, error) {
+	out := new(CreateMuSig2NonceResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/CreateMuSig2Nonce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SignMuSig2Input(ctx context.Context, in *SignMuSig2InputRequest, opts ...grpc.CallOption) (*SignMuSig2InputResponse, error) {
+	out := new(SignMuSig2InputResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SignMuSig2Input", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) CombineMuSig2Sigs(ctx context.Context, in *CombineMuSig2SigsRequest, opts ...grpc.CallOption) (*CombineMuSig2SigsResponse, error) {
+	out := new(CombineMuSig2SigsResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/CombineMuSig2Sigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) NewHashLockScriptKey(ctx context.Context, in *NewHashLockScriptKeyRequest, opts ...grpc.CallOption) (*NewHashLockScriptKeyResponse, error) {
+	out := new(NewHashLockScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/NewHashLockScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SweepHashLock(ctx context.Context, in *SweepHashLockRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+	out := new(taprpc.SendAssetResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SweepHashLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) NewTimeLockScriptKey(ctx context.Context, in *NewTimeLockScriptKeyRequest, opts ...grpc.CallOption) (*NewTimeLockScriptKeyResponse, error) {
+	out := new(NewTimeLockScriptKeyResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/NewTimeLockScriptKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SendTimeLocked(ctx context.Context, in *SendTimeLockedRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+	out := new(taprpc.SendAssetResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SendTimeLocked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SweepTimeLock(ctx context.Context, in *SweepTimeLockRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+	out := new(taprpc.SendAssetResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SweepTimeLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) CreateSigningSession(ctx context.Context, in *CreateSigningSessionRequest, opts ...grpc.CallOption) (*CreateSigningSessionResponse, error) {
+	out := new(CreateSigningSessionResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/CreateSigningSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) ContributeToSession(ctx context.Context, in *ContributeToSessionRequest, opts ...grpc.CallOption) (*ContributeToSessionResponse, error) {
+	out := new(ContributeToSessionResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/ContributeToSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) StartSessionSigning(ctx context.Context, in *StartSessionSigningRequest, opts ...grpc.CallOption) (*StartSessionSigningResponse, error) {
+	out := new(StartSessionSigningResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/StartSessionSigning", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) SubmitSessionSignatures(ctx context.Context, in *SubmitSessionSignaturesRequest, opts ...grpc.CallOption) (*SubmitSessionSignaturesResponse, error) {
+	out := new(SubmitSessionSignaturesResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/SubmitSessionSignatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) FinalizeSession(ctx context.Context, in *FinalizeSessionRequest, opts ...grpc.CallOption) (*FinalizeSessionResponse, error) {
+	out := new(FinalizeSessionResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/FinalizeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) AnchorSession(ctx context.Context, in *AnchorSessionRequest, opts ...grpc.CallOption) (*AnchorSessionResponse, error) {
+	out := new(AnchorSessionResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/AnchorSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) PublishSession(ctx context.Context, in *PublishSessionRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+	out := new(taprpc.SendAssetResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/PublishSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) GetSigningSession(ctx context.Context, in *GetSigningSessionRequest, opts ...grpc.CallOption) (*GetSigningSessionResponse, error) {
+	out := new(GetSigningSessionResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/GetSigningSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) CreateSwapOffer(ctx context.Context, in *CreateSwapOfferRequest, opts ...grpc.CallOption) (*CreateSwapOfferResponse, error) {
+	out := new(CreateSwapOfferResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/CreateSwapOffer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) AcceptSwapOffer(ctx context.Context, in *AcceptSwapOfferRequest, opts ...grpc.CallOption) (*AcceptSwapOfferResponse, error) {
+	out := new(AcceptSwapOfferResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/AcceptSwapOffer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) ProposeSwap(ctx context.Context, in *ProposeSwapRequest, opts ...grpc.CallOption) (*ProposeSwapResponse, error) {
+	out := new(ProposeSwapResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/ProposeSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) FundSwap(ctx context.Context, in *FundSwapRequest, opts ...grpc.CallOption) (*FundSwapResponse, error) {
+	out := new(FundSwapResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/FundSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) PublishSwap(ctx context.Context, in *PublishSwapRequest, opts ...grpc.CallOption) (*taprpc.SendAssetResponse, error) {
+	out := new(taprpc.SendAssetResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/PublishSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) CompleteSwap(ctx context.Context, in *CompleteSwapRequest, opts ...grpc.CallOption) (*CompleteSwapResponse, error) {
+	out := new(CompleteSwapResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/CompleteSwap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetWalletClient) ListSwaps(ctx context.Context, in *ListSwapsRequest, opts ...grpc.CallOption) (*ListSwapsResponse, error) {
+	out := new(ListSwapsResponse)
+	err := c.cc.Invoke(ctx, "/assetwalletrpc.AssetWallet/ListSwaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AssetWalletServer is the server API for AssetWallet service.
 // All implementations must embed UnimplementedAssetWalletServer
 // for forward compatibility
@@ -266,6 +686,147 @@ type AssetWalletServer interface {
 	// recognized by the wallet automatically. Declaring a script key will make any
 	// assets sent to the script key be recognized as being local assets.
 	DeclareScriptKey(context.Context, *DeclareScriptKeyRequest) (*DeclareScriptKeyResponse, error)
+	// NewAccount creates a new named asset account. Each account derives the
+	// keys of its addresses and transfers from its own key family, which gives it
+	// a separate key scope and balance view. Assets that don't belong to a named
+	// account belong to the implicit "default" account. If the account is
+	// created for a tenant, a macaroon of a root key of its own is baked for the
+	// tenant, which only gives access to the assets of the account.
+	NewAccount(context.Context, *NewAccountRequest) (*NewAccountResponse, error)
+	// ListAccounts lists all named asset accounts.
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// NewIssuerPolicyScriptKey creates the script key of a regulated asset that
+	// is controlled by both its holder and its issuer, and declares it to the
+	// wallet. Regular transfers of assets locked to the script key require a
+	// signature of both the holder and the issuer, while the issuer alone can
+	// claw the assets back. The script key can be used to mint assets or to
+	// create addresses.
+	NewIssuerPolicyScriptKey(context.Context, *NewIssuerPolicyScriptKeyRequest) (*NewIssuerPolicyScriptKeyResponse, error)
+	// SignIssuerPolicyPsbt signs a funded virtual PSBT that spends assets locked
+	// to an issuer policy script key with all keys of the policy that the wallet
+	// controls. The signatures are added as partial signatures, so the PSBT can
+	// be passed on to the other signer. Once all required signatures are
+	// present, the witnesses are finalized. The issuer refuses to co-sign the
+	// transfer of assets locked to a frozen script key.
+	SignIssuerPolicyPsbt(context.Context, *SignIssuerPolicyPsbtRequest) (*SignIssuerPolicyPsbtResponse, error)
+	// FreezeScriptKey freezes an issuer policy script key, which makes the
+	// issuer refuse to co-sign regular transfers of the assets locked to it.
+	FreezeScriptKey(context.Context, *FreezeScriptKeyRequest) (*FreezeScriptKeyResponse, error)
+	// UnfreezeScriptKey unfreezes a frozen issuer policy script key.
+	UnfreezeScriptKey(context.Context, *UnfreezeScriptKeyRequest) (*UnfreezeScriptKeyResponse, error)
+	// ListFrozenScriptKeys lists all frozen issuer policy script keys.
+	ListFrozenScriptKeys(context.Context, *ListFrozenScriptKeysRequest) (*ListFrozenScriptKeysResponse, error)
+	// NewMuSig2ScriptKey creates the script key that is the MuSig2 aggregate of
+	// the given participant keys and declares it to the wallet. Assets locked to
+	// the script key can only be spent cooperatively by all participants through
+	// the CreateMuSig2Nonce, SignMuSig2Input and CombineMuSig2Sigs RPCs.
+	NewMuSig2ScriptKey(context.Context, *NewMuSig2ScriptKeyRequest) (*NewMuSig2ScriptKeyResponse, error)
+	// CreateMuSig2Nonce starts a MuSig2 signing session for an input of a funded
+	// virtual PSBT that spends an asset locked to a MuSig2 script key. The
+	// participant keys and the public nonce of the wallet's participant key are
+	// added to the input of the virtual PSBT, which is then passed on to the
+	// other participants.
+	CreateMuSig2Nonce(context.Context, *CreateMuSig2NonceRequest) (*CreateMuSig2NonceResponse, error)
+	// SignMuSig2Input creates the MuSig2 partial signature of the wallet's
+	// participant key for an input of a virtual PSBT once the public nonces of
+	// all participants are present. The partial signature is added to the input
+	// of the virtual PSBT.
+	SignMuSig2Input(context.Context, *SignMuSig2InputRequest) (*SignMuSig2InputResponse, error)
+	// CombineMuSig2Sigs combines the MuSig2 partial signatures of all
+	// participants of an input of a virtual PSBT into its final witness. Once
+	// all inputs have a witness, the virtual transaction is validated.
+	CombineMuSig2Sigs(context.Context, *CombineMuSig2SigsRequest) (*CombineMuSig2SigsResponse, error)
+	// NewHashLockScriptKey creates an HTLC-style script key and declares it to
+	// the wallet. Assets locked to the script key can either be swept by the
+	// claimer by revealing the preimage of the payment hash, or by the refunder
+	// once the CSV delay has passed. Locking assets to the script key and BTC to
+	// an on-chain HTLC with the same payment hash allows the two to be swapped
+	// atomically.
+	NewHashLockScriptKey(context.Context, *NewHashLockScriptKeyRequest) (*NewHashLockScriptKeyResponse, error)
+	// SweepHashLock sweeps all assets of the given asset ID that are locked to a
+	// hash lock script key to a new script key of the wallet, through either the
+	// success or the timeout path. The wallet must control the key of the path.
+	SweepHashLock(context.Context, *SweepHashLockRequest) (*taprpc.SendAssetResponse, error)
+	// NewTimeLockScriptKey creates a script key that locks assets to a key until
+	// an absolute lock time and/or a relative lock time have passed. If no key is
+	// given, a new key of the wallet is derived. The script key is only declared
+	// to the wallet if the wallet controls the key, so assets sent to it are
+	// shown as owned.
+	NewTimeLockScriptKey(context.Context, *NewTimeLockScriptKeyRequest) (*NewTimeLockScriptKeyResponse, error)
+	// SendTimeLocked sends an asset to multiple recipients in one transfer, each
+	// of which receives its amount locked to its own time lock script key, for
+	// example to vest assets. Each recipient output is anchored in its own BTC
+	// output. The proofs of recipients that aren't part of this wallet must be
+	// exported and handed over out of band.
+	SendTimeLocked(context.Context, *SendTimeLockedRequest) (*taprpc.SendAssetResponse, error)
+	// SweepTimeLock sweeps all assets of the given asset ID that are locked to a
+	// time lock script key to a new script key of the wallet, once the lock
+	// times have passed. The wallet must control the key of the time lock.
+	SweepTimeLock(context.Context, *SweepTimeLockRequest) (*taprpc.SendAssetResponse, error)
+	// CreateSigningSession creates a multi-party signing session with the funded
+	// virtual PSBTs of the creating party. Other parties can add their inputs and
+	// outputs to the session before it is signed. Sessions are kept in memory
+	// only and expire after 24 hours.
+	CreateSigningSession(context.Context, *CreateSigningSessionRequest) (*CreateSigningSessionResponse, error)
+	// ContributeToSession adds the inputs and outputs of the funded virtual
+	// PSBTs of another party to an open signing session. Virtual PSBTs that
+	// spend the same asset as a virtual PSBT of the session are merged into it.
+	ContributeToSession(context.Context, *ContributeToSessionRequest) (*ContributeToSessionResponse, error)
+	// StartSessionSigning closes a signing session for contributions and
+	// prepares its virtual transactions for signing. Inputs that are locked to a
+	// MuSig2 key of several participants can be declared, so their participants
+	// can exchange their nonces and partial signatures through the session.
+	StartSessionSigning(context.Context, *StartSessionSigningRequest) (*StartSessionSigningResponse, error)
+	// SubmitSessionSignatures adds the witnesses of a party's inputs, as created
+	// by SignVirtualPsbt with the partial flag set, and MuSig2 nonces and partial
+	// signatures to a signing session.
+	SubmitSessionSignatures(context.Context, *SubmitSessionSignaturesRequest) (*SubmitSessionSignaturesResponse, error)
+	// FinalizeSession combines the MuSig2 partial signatures of a signing
+	// session and validates the witnesses of all its virtual transactions.
+	FinalizeSession(context.Context, *FinalizeSessionRequest) (*FinalizeSessionResponse, error)
+	// AnchorSession commits the finalized virtual transactions of a signing
+	// session to a BTC level anchor transaction that is funded by this daemon's
+	// wallet. The anchor transaction must then be signed by the parties owning
+	// its inputs and published with PublishSession.
+	AnchorSession(context.Context, *AnchorSessionRequest) (*AnchorSessionResponse, error)
+	// PublishSession adds the signatures of the given signed anchor transactions
+	// to the anchor transaction of a signing session and, once it is fully
+	// signed, publishes it and logs the transfer.
+	PublishSession(context.Context, *PublishSessionRequest) (*taprpc.SendAssetResponse, error)
+	// GetSigningSession returns the current state of a signing session.
+	GetSigningSession(context.Context, *GetSigningSessionRequest) (*GetSigningSessionResponse, error)
+	// CreateSwapOffer creates an offer to sell an asset for BTC or another asset
+	// to another daemon in an atomic swap. The returned quote must be passed to
+	// the buyer, who accepts it with AcceptSwapOffer. Swaps are kept in memory
+	// only.
+	CreateSwapOffer(context.Context, *CreateSwapOfferRequest) (*CreateSwapOfferResponse, error)
+	// AcceptSwapOffer accepts the quote of a seller and derives the keys the
+	// asset is sent to. The returned acceptance must be passed to the seller,
+	// who creates its proposal with ProposeSwap.
+	AcceptSwapOffer(context.Context, *AcceptSwapOfferRequest) (*AcceptSwapOfferResponse, error)
+	// ProposeSwap funds and signs the virtual transaction that sends the asset
+	// of an offered swap to the buyer. The returned proposal must be passed to
+	// the buyer, who funds the anchor transaction with FundSwap.
+	ProposeSwap(context.Context, *ProposeSwapRequest) (*ProposeSwapResponse, error)
+	// FundSwap validates the seller's proposal of a swap and commits its virtual
+	// transactions to an anchor transaction that also pays the seller. In an
+	// asset-for-asset swap, the seller is paid by a virtual transaction that
+	// sends the price asset and is funded and signed by this daemon, too. The
+	// anchor transaction is funded and signed by this daemon's wallet. The
+	// returned funding must be passed to the seller, who publishes the anchor
+	// transaction with PublishSwap.
+	FundSwap(context.Context, *FundSwapRequest) (*FundSwapResponse, error)
+	// PublishSwap validates the buyer's funding of a swap and, if the anchor
+	// transaction pays the agreed price, signs the seller's inputs, publishes
+	// the anchor transaction and logs the transfer.
+	PublishSwap(context.Context, *PublishSwapRequest) (*taprpc.SendAssetResponse, error)
+	// CompleteSwap waits for the anchor transaction of a swap to confirm and
+	// imports the proof of the asset this daemon received. The buyer completes
+	// a funded swap, the seller a published asset-for-asset swap. The buyer of
+	// an asset-for-asset swap also logs the transfer of its price asset.
+	CompleteSwap(context.Context, *CompleteSwapRequest) (*CompleteSwapResponse, error)
+	// ListSwaps lists all swaps this daemon takes part in.
+	ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error)
 	mustEmbedUnimplementedAssetWalletServer()
 }
 
@@ -312,6 +873,99 @@ func (UnimplementedAssetWalletServer) RemoveUTXOLease(context.Context, *RemoveUT
 func (UnimplementedAssetWalletServer) DeclareScriptKey(context.Context, *DeclareScriptKeyRequest) (*DeclareScriptKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeclareScriptKey not implemented")
 }
+func (UnimplementedAssetWalletServer) NewAccount(context.Context, *NewAccountRequest) (*NewAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewAccount not implemented")
+}
+func (UnimplementedAssetWalletServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedAssetWalletServer) NewIssuerPolicyScriptKey(context.Context, *NewIssuerPolicyScriptKeyRequest) (*NewIssuerPolicyScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIssuerPolicyScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) SignIssuerPolicyPsbt(context.Context, *SignIssuerPolicyPsbtRequest) (*SignIssuerPolicyPsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignIssuerPolicyPsbt not implemented")
+}
+func (UnimplementedAssetWalletServer) FreezeScriptKey(context.Context, *FreezeScriptKeyRequest) (*FreezeScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) UnfreezeScriptKey(context.Context, *UnfreezeScriptKeyRequest) (*UnfreezeScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) ListFrozenScriptKeys(context.Context, *ListFrozenScriptKeysRequest) (*ListFrozenScriptKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFrozenScriptKeys not implemented")
+}
+func (UnimplementedAssetWalletServer) NewMuSig2ScriptKey(context.Context, *NewMuSig2ScriptKeyRequest) (*NewMuSig2ScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewMuSig2ScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) CreateMuSig2Nonce(context.Context, *CreateMuSig2NonceRequest) (*CreateMuSig2NonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMuSig2Nonce not implemented")
+}
+func (UnimplementedAssetWalletServer) SignMuSig2Input(context.Context, *SignMuSig2InputRequest) (*SignMuSig2InputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMuSig2Input not implemented")
+}
+func (UnimplementedAssetWalletServer) CombineMuSig2Sigs(context.Context, *CombineMuSig2SigsRequest) (*CombineMuSig2SigsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombineMuSig2Sigs not implemented")
+}
+func (UnimplementedAssetWalletServer) NewHashLockScriptKey(context.Context, *NewHashLockScriptKeyRequest) (*NewHashLockScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewHashLockScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) SweepHashLock(context.Context, *SweepHashLockRequest) (*taprpc.SendAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SweepHashLock not implemented")
+}
+func (UnimplementedAssetWalletServer) NewTimeLockScriptKey(context.Context, *NewTimeLockScriptKeyRequest) (*NewTimeLockScriptKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewTimeLockScriptKey not implemented")
+}
+func (UnimplementedAssetWalletServer) SendTimeLocked(context.Context, *SendTimeLockedRequest) (*taprpc.SendAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTimeLocked not implemented")
+}
+func (UnimplementedAssetWalletServer) SweepTimeLock(context.Context, *SweepTimeLockRequest) (*taprpc.SendAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SweepTimeLock not implemented")
+}
+func (UnimplementedAssetWalletServer) CreateSigningSession(context.Context, *CreateSigningSessionRequest) (*CreateSigningSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSigningSession not implemented")
+}
+func (UnimplementedAssetWalletServer) ContributeToSession(context.Context, *ContributeToSessionRequest) (*ContributeToSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContributeToSession not implemented")
+}
+func (UnimplementedAssetWalletServer) StartSessionSigning(context.Context, *StartSessionSigningRequest) (*StartSessionSigningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSessionSigning not implemented")
+}
+func (UnimplementedAssetWalletServer) SubmitSessionSignatures(context.Context, *SubmitSessionSignaturesRequest) (*SubmitSessionSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSessionSignatures not implemented")
+}
+func (UnimplementedAssetWalletServer) FinalizeSession(context.Context, *FinalizeSessionRequest) (*FinalizeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeSession not implemented")
+}
+func (UnimplementedAssetWalletServer) AnchorSession(context.Context, *AnchorSessionRequest) (*AnchorSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnchorSession not implemented")
+}
+func (UnimplementedAssetWalletServer) PublishSession(context.Context, *PublishSessionRequest) (*taprpc.SendAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishSession not implemented")
+}
+func (UnimplementedAssetWalletServer) GetSigningSession(context.Context, *GetSigningSessionRequest) (*GetSigningSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningSession not implemented")
+}
+func (UnimplementedAssetWalletServer) CreateSwapOffer(context.Context, *CreateSwapOfferRequest) (*CreateSwapOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSwapOffer not implemented")
+}
+func (UnimplementedAssetWalletServer) AcceptSwapOffer(context.Context, *AcceptSwapOfferRequest) (*AcceptSwapOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptSwapOffer not implemented")
+}
+func (UnimplementedAssetWalletServer) ProposeSwap(context.Context, *ProposeSwapRequest) (*ProposeSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeSwap not implemented")
+}
+func (UnimplementedAssetWalletServer) FundSwap(context.Context, *FundSwapRequest) (*FundSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FundSwap not implemented")
+}
+func (UnimplementedAssetWalletServer) PublishSwap(context.Context, *PublishSwapRequest) (*taprpc.SendAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishSwap not implemented")
+}
+func (UnimplementedAssetWalletServer) CompleteSwap(context.Context, *CompleteSwapRequest) (*CompleteSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteSwap not implemented")
+}
+func (UnimplementedAssetWalletServer) ListSwaps(context.Context, *ListSwapsRequest) (*ListSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSwaps not implemented")
+}
 func (UnimplementedAssetWalletServer) mustEmbedUnimplementedAssetWalletServer() {}
 
 // UnsafeAssetWalletServer may be embedded to opt out of forward compatibility for this service.
@@ -559,40 +1213,598 @@ func _AssetWallet_DeclareScriptKey_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// AssetWallet_ServiceDesc is the grpc.ServiceDesc for AssetWallet service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AssetWallet_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "assetwalletrpc.AssetWallet",
-	HandlerType: (*AssetWalletServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "FundVirtualPsbt",
-			Handler:    _AssetWallet_FundVirtualPsbt_Handler,
-		},
-		{
-			MethodName: "SignVirtualPsbt",
-			Handler:    _AssetWallet_SignVirtualPsbt_Handler,
-		},
-		{
-			MethodName: "AnchorVirtualPsbts",
-			Handler:    _AssetWallet_AnchorVirtualPsbts_Handler,
-		},
-		{
-			MethodName: "CommitVirtualPsbts",
-			Handler:    _AssetWallet_CommitVirtualPsbts_Handler,
-		},
-		{
-			MethodName: "PublishAndLogTransfer",
-			Handler:    _AssetWallet_PublishAndLogTransfer_Handler,
-		},
-		{
-			MethodName: "NextInternalKey",
-			Handler:    _AssetWallet_NextInternalKey_Handler,
-		},
-		{
-			MethodName: "NextScriptKey",
-			Handler:    _AssetWallet_NextScriptKey_Handler,
+func _AssetWallet_NewAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).NewAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/NewAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).NewAccount(ctx, req.(*NewAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_NewIssuerPolicyScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIssuerPolicyScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).NewIssuerPolicyScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/NewIssuerPolicyScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).NewIssuerPolicyScriptKey(ctx, req.(*NewIssuerPolicyScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SignIssuerPolicyPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignIssuerPolicyPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SignIssuerPolicyPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SignIssuerPolicyPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SignIssuerPolicyPsbt(ctx, req.(*SignIssuerPolicyPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_FreezeScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).FreezeScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/FreezeScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).FreezeScriptKey(ctx, req.(*FreezeScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_UnfreezeScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).UnfreezeScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/UnfreezeScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).UnfreezeScriptKey(ctx, req.(*UnfreezeScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_ListFrozenScriptKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFrozenScriptKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).ListFrozenScriptKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/ListFrozenScriptKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).ListFrozenScriptKeys(ctx, req.(*ListFrozenScriptKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_NewMuSig2ScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewMuSig2ScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).NewMuSig2ScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/NewMuSig2ScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).NewMuSig2ScriptKey(ctx, req.(*NewMuSig2ScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_CreateMuSig2Nonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMuSig2NonceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).CreateMuSig2Nonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/CreateMuSig2Nonce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).CreateMuSig2Nonce(ctx, req.(*CreateMuSig2NonceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SignMuSig2Input_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMuSig2InputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SignMuSig2Input(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SignMuSig2Input",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SignMuSig2Input(ctx, req.(*SignMuSig2InputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_CombineMuSig2Sigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombineMuSig2SigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).CombineMuSig2Sigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/CombineMuSig2Sigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).CombineMuSig2Sigs(ctx, req.(*CombineMuSig2SigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_NewHashLockScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewHashLockScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).NewHashLockScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/NewHashLockScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).NewHashLockScriptKey(ctx, req.(*NewHashLockScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SweepHashLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SweepHashLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SweepHashLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SweepHashLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SweepHashLock(ctx, req.(*SweepHashLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_NewTimeLockScriptKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewTimeLockScriptKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).NewTimeLockScriptKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/NewTimeLockScriptKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).NewTimeLockScriptKey(ctx, req.(*NewTimeLockScriptKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SendTimeLocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTimeLockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SendTimeLocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SendTimeLocked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SendTimeLocked(ctx, req.(*SendTimeLockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SweepTimeLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SweepTimeLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SweepTimeLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SweepTimeLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SweepTimeLock(ctx, req.(*SweepTimeLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_CreateSigningSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSigningSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).CreateSigningSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/CreateSigningSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).CreateSigningSession(ctx, req.(*CreateSigningSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_ContributeToSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContributeToSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).ContributeToSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/ContributeToSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).ContributeToSession(ctx, req.(*ContributeToSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_StartSessionSigning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSessionSigningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).StartSessionSigning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/StartSessionSigning",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).StartSessionSigning(ctx, req.(*StartSessionSigningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_SubmitSessionSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitSessionSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).SubmitSessionSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/SubmitSessionSignatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).SubmitSessionSignatures(ctx, req.(*SubmitSessionSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_FinalizeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).FinalizeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/FinalizeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).FinalizeSession(ctx, req.(*FinalizeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_AnchorSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnchorSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).AnchorSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/AnchorSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).AnchorSession(ctx, req.(*AnchorSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_PublishSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).PublishSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/PublishSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).PublishSession(ctx, req.(*PublishSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_GetSigningSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).GetSigningSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/GetSigningSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).GetSigningSession(ctx, req.(*GetSigningSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_CreateSwapOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSwapOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).CreateSwapOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/CreateSwapOffer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).CreateSwapOffer(ctx, req.(*CreateSwapOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_AcceptSwapOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptSwapOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).AcceptSwapOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/AcceptSwapOffer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).AcceptSwapOffer(ctx, req.(*AcceptSwapOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_ProposeSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).ProposeSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/ProposeSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).ProposeSwap(ctx, req.(*ProposeSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_FundSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).FundSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/FundSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).FundSwap(ctx, req.(*FundSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_PublishSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).PublishSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/PublishSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).PublishSwap(ctx, req.(*PublishSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_CompleteSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).CompleteSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/CompleteSwap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).CompleteSwap(ctx, req.(*CompleteSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetWallet_ListSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetWalletServer).ListSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/assetwalletrpc.AssetWallet/ListSwaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetWalletServer).ListSwaps(ctx, req.(*ListSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssetWallet_ServiceDesc is the grpc.ServiceDesc for AssetWallet service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssetWallet_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "assetwalletrpc.AssetWallet",
+	HandlerType: (*AssetWalletServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FundVirtualPsbt",
+			Handler:    _AssetWallet_FundVirtualPsbt_Handler,
+		},
+		{
+			MethodName: "SignVirtualPsbt",
+			Handler:    _AssetWallet_SignVirtualPsbt_Handler,
+		},
+		{
+			MethodName: "AnchorVirtualPsbts",
+			Handler:    _AssetWallet_AnchorVirtualPsbts_Handler,
+		},
+		{
+			MethodName: "CommitVirtualPsbts",
+			Handler:    _AssetWallet_CommitVirtualPsbts_Handler,
+		},
+		{
+			MethodName: "PublishAndLogTransfer",
+			Handler:    _AssetWallet_PublishAndLogTransfer_Handler,
+		},
+		{
+			MethodName: "NextInternalKey",
+			Handler:    _AssetWallet_NextInternalKey_Handler,
+		},
+		{
+			MethodName: "NextScriptKey",
+			Handler:    _AssetWallet_NextScriptKey_Handler,
 		},
 		{
 			MethodName: "QueryInternalKey",
@@ -618,6 +1830,130 @@ var AssetWallet_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeclareScriptKey",
 			Handler:    _AssetWallet_DeclareScriptKey_Handler,
 		},
+		{
+			MethodName: "NewAccount",
+			Handler:    _AssetWallet_NewAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _AssetWallet_ListAccounts_Handler,
+		},
+		{
+			MethodName: "NewIssuerPolicyScriptKey",
+			Handler:    _AssetWallet_NewIssuerPolicyScriptKey_Handler,
+		},
+		{
+			MethodName: "SignIssuerPolicyPsbt",
+			Handler:    _AssetWallet_SignIssuerPolicyPsbt_Handler,
+		},
+		{
+			MethodName: "FreezeScriptKey",
+			Handler:    _AssetWallet_FreezeScriptKey_Handler,
+		},
+		{
+			MethodName: "UnfreezeScriptKey",
+			Handler:    _AssetWallet_UnfreezeScriptKey_Handler,
+		},
+		{
+			MethodName: "ListFrozenScriptKeys",
+			Handler:    _AssetWallet_ListFrozenScriptKeys_Handler,
+		},
+		{
+			MethodName: "NewMuSig2ScriptKey",
+			Handler:    _AssetWallet_NewMuSig2ScriptKey_Handler,
+		},
+		{
+			MethodName: "CreateMuSig2Nonce",
+			Handler:    _AssetWallet_CreateMuSig2Nonce_Handler,
+		},
+		{
+			MethodName: "SignMuSig2Input",
+			Handler:    _AssetWallet_SignMuSig2Input_Handler,
+		},
+		{
+			MethodName: "CombineMuSig2Sigs",
+			Handler:    _AssetWallet_CombineMuSig2Sigs_Handler,
+		},
+		{
+			MethodName: "NewHashLockScriptKey",
+			Handler:    _AssetWallet_NewHashLockScriptKey_Handler,
+		},
+		{
+			MethodName: "SweepHashLock",
+			Handler:    _AssetWallet_SweepHashLock_Handler,
+		},
+		{
+			MethodName: "NewTimeLockScriptKey",
+			Handler:    _AssetWallet_NewTimeLockScriptKey_Handler,
+		},
+		{
+			MethodName: "SendTimeLocked",
+			Handler:    _AssetWallet_SendTimeLocked_Handler,
+		},
+		{
+			MethodName: "SweepTimeLock",
+			Handler:    _AssetWallet_SweepTimeLock_Handler,
+		},
+		{
+			MethodName: "CreateSigningSession",
+			Handler:    _AssetWallet_CreateSigningSession_Handler,
+		},
+		{
+			MethodName: "ContributeToSession",
+			Handler:    _AssetWallet_ContributeToSession_Handler,
+		},
+		{
+			MethodName: "StartSessionSigning",
+			Handler:    _AssetWallet_StartSessionSigning_Handler,
+		},
+		{
+			MethodName: "SubmitSessionSignatures",
+			Handler:    _AssetWallet_SubmitSessionSignatures_Handler,
+		},
+		{
+			MethodName: "FinalizeSession",
+			Handler:    _AssetWallet_FinalizeSession_Handler,
+		},
+		{
+			MethodName: "AnchorSession",
+			Handler:    _AssetWallet_AnchorSession_Handler,
+		},
+		{
+			MethodName: "PublishSession",
+			Handler:    _AssetWallet_PublishSession_Handler,
+		},
+		{
+			MethodName: "GetSigningSession",
+			Handler:    _AssetWallet_GetSigningSession_Handler,
+		},
+		{
+			MethodName: "CreateSwapOffer",
+			Handler:    _AssetWallet_CreateSwapOffer_Handler,
+		},
+		{
+			MethodName: "AcceptSwapOffer",
+			Handler:    _AssetWallet_AcceptSwapOffer_Handler,
+		},
+		{
+			MethodName: "ProposeSwap",
+			Handler:    _AssetWallet_ProposeSwap_Handler,
+		},
+		{
+			MethodName: "FundSwap",
+			Handler:    _AssetWallet_FundSwap_Handler,
+		},
+		{
+			MethodName: "PublishSwap",
+			Handler:    _AssetWallet_PublishSwap_Handler,
+		},
+		{
+			MethodName: "CompleteSwap",
+			Handler:    _AssetWallet_CompleteSwap_Handler,
+		},
+		{
+			MethodName: "ListSwaps",
+			Handler:    _AssetWallet_ListSwaps_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "assetwalletrpc/assetwallet.proto",
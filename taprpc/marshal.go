@@ -27,6 +27,13 @@ var (
 	ProofDeliveryStatusNotApplicable = ProofDeliveryStatus_PROOF_DELIVERY_STATUS_NOT_APPLICABLE
 	ProofDeliveryStatusComplete      = ProofDeliveryStatus_PROOF_DELIVERY_STATUS_COMPLETE
 	ProofDeliveryStatusPending       = ProofDeliveryStatus_PROOF_DELIVERY_STATUS_PENDING
+	ProofDeliveryStatusSkipped       = ProofDeliveryStatus_PROOF_DELIVERY_STATUS_SKIPPED
+)
+
+// Shorthand for the asset transfer output proof courier mode enum.
+var (
+	ProofCourierModeFailover = ProofCourierMode_PROOF_COURIER_MODE_FAILOVER
+	ProofCourierModeAll      = ProofCourierMode_PROOF_COURIER_MODE_ALL
 )
 
 // KeyLookup is used to determine whether a key is under the control of the
@@ -167,7 +174,7 @@ func MarshalAssetVersion(version asset.Version) (AssetVersion, error) {
 
 // UnmarshalAddressVerion parses an address version from the RPC variant.
 func UnmarshalAddressVersion(version AddrVersion) (address.Version, error) {
-	// For now we'll only support two address versions. The ones in the
+	// For now we'll only support three address versions. The ones in the
 	// future should be reserved for future use, so we disallow unknown
 	// versions.
 	switch version {
@@ -180,6 +187,9 @@ func UnmarshalAddressVersion(version AddrVersion) (address.Version, error) {
 	case AddrVersion_ADDR_VERSION_V1:
 		return address.V1, nil
 
+	case AddrVersion_ADDR_VERSION_V2:
+		return address.V2, nil
+
 	default:
 		return 0, fmt.Errorf("unknown address version: %v", version)
 	}
@@ -188,7 +198,7 @@ func UnmarshalAddressVersion(version AddrVersion) (address.Version, error) {
 // MarshalAddressVerion marshals the native address version into the RPC
 // variant.
 func MarshalAddressVersion(version address.Version) (AddrVersion, error) {
-	// For now we'll only support two address versions. The ones in the
+	// For now we'll only support three address versions. The ones in the
 	// future should be reserved for future use, so we disallow unknown
 	// versions.
 	switch version {
@@ -198,6 +208,9 @@ func MarshalAddressVersion(version address.Version) (AddrVersion, error) {
 	case address.V1:
 		return AddrVersion_ADDR_VERSION_V1, nil
 
+	case address.V2:
+		return AddrVersion_ADDR_VERSION_V2, nil
+
 	default:
 		return 0, fmt.Errorf("unknown address version: %v", version)
 	}
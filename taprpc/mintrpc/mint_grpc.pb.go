@@ -25,6 +25,12 @@ type MintClient interface {
 	// batch. This call will block until the operation succeeds (asset is staged
 	// in the batch) or fails.
 	MintAsset(ctx context.Context, in *MintAssetRequest, opts ...grpc.CallOption) (*MintAssetResponse, error)
+	// tapcli: `assets mint collection`
+	// MintCollection adds a collection of collectibles to the pending batch. Each
+	// edition is minted as a collectible with an amount of one, and all editions
+	// are linked under a single asset group. The batch is funded if it isn't
+	// funded yet, so that the IDs of the editions' assets can be returned.
+	MintCollection(ctx context.Context, in *MintCollectionRequest, opts ...grpc.CallOption) (*MintCollectionResponse, error)
 	// tapcli `assets mint fund`
 	// FundBatch will attempt to fund the current pending batch with a genesis
 	// input, or create a new funded batch if no batch exists yet. This RPC is only
@@ -39,6 +45,21 @@ type MintClient interface {
 	// that require an external signer. Otherwise, FinalizeBatch can be called
 	// directly.
 	SealBatch(ctx context.Context, in *SealBatchRequest, opts ...grpc.CallOption) (*SealBatchResponse, error)
+	// tapcli: `assets mint grouppsbts`
+	// ExportGroupPsbts exports the asset group witnesses of the current pending
+	// batch that must be produced by an external signer, such as an HSM, because
+	// the group internal key is not held by the wallet. Each witness is exported
+	// as a PSBT that spends the tweaked group key with the key spend path. The
+	// batch must be funded with FundBatch first, as the genesis outpoint
+	// determines the asset IDs that are signed.
+	ExportGroupPsbts(ctx context.Context, in *ExportGroupPsbtsRequest, opts ...grpc.CallOption) (*ExportGroupPsbtsResponse, error)
+	// tapcli: `assets mint submitgroupsigs`
+	// SubmitGroupSignatures seals the current pending batch with the asset group
+	// witnesses of the given group PSBTs, which were exported by ExportGroupPsbts
+	// and signed by an external signer. Witnesses of assets not covered by a PSBT
+	// are derived by the wallet. Afterwards, FinalizeBatch can be called to
+	// broadcast the batch.
+	SubmitGroupSignatures(ctx context.Context, in *SubmitGroupSignaturesRequest, opts ...grpc.CallOption) (*SubmitGroupSignaturesResponse, error)
 	// tapcli: `assets mint finalize`
 	// FinalizeBatch will attempt to finalize the current pending batch.
 	FinalizeBatch(ctx context.Context, in *FinalizeBatchRequest, opts ...grpc.CallOption) (*FinalizeBatchResponse, error)
@@ -53,6 +74,15 @@ type MintClient interface {
 	// SubscribeMintEvents allows a caller to subscribe to mint events for asset
 	// creation batches.
 	SubscribeMintEvents(ctx context.Context, in *SubscribeMintEventsRequest, opts ...grpc.CallOption) (Mint_SubscribeMintEventsClient, error)
+	// tapcli: `assets mint schemas register`
+	// RegisterMetaSchema registers a custom JSON schema that the metadata of new
+	// assets can be validated against by setting its ID as the meta_schema_id
+	// when minting. Registered schemas can't be replaced.
+	RegisterMetaSchema(ctx context.Context, in *RegisterMetaSchemaRequest, opts ...grpc.CallOption) (*RegisterMetaSchemaResponse, error)
+	// tapcli: `assets mint schemas list`
+	// ListMetaSchemas lists the built-in and custom JSON schemas asset metadata
+	// can be validated against.
+	ListMetaSchemas(ctx context.Context, in *ListMetaSchemasRequest, opts ...grpc.CallOption) (*ListMetaSchemasResponse, error)
 }
 
 type mintClient struct {
@@ -72,6 +102,15 @@ func (c *mintClient) MintAsset(ctx context.Context, in *MintAssetRequest, opts .
 	return out, nil
 }
 
+func (c *mintClient) MintCollection(ctx context.Context, in *MintCollectionRequest, opts ...grpc.CallOption) (*MintCollectionResponse, error) {
+	out := new(MintCollectionResponse)
+	err := c.cc.Invoke(ctx, "/mintrpc.Mint/MintCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mintClient) FundBatch(ctx context.Context, in *FundBatchRequest, opts ...grpc.CallOption) (*FundBatchResponse, error) {
 	out := new(FundBatchResponse)
 	err := c.cc.Invoke(ctx, "/mintrpc.Mint/FundBatch", in, out, opts...)
@@ -90,6 +129,24 @@ func (c *mintClient) SealBatch(ctx context.Context, in *SealBatchRequest, opts .
 	return out, nil
 }
 
+func (c *mintClient) ExportGroupPsbts(ctx context.Context, in *ExportGroupPsbtsRequest, opts ...grpc.CallOption) (*ExportGroupPsbtsResponse, error) {
+	out := new(ExportGroupPsbtsResponse)
+	err := c.cc.Invoke(ctx, "/mintrpc.Mint/ExportGroupPsbts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintClient) SubmitGroupSignatures(ctx context.Context, in *SubmitGroupSignaturesRequest, opts ...grpc.CallOption) (*SubmitGroupSignaturesResponse, error) {
+	out := new(SubmitGroupSignaturesResponse)
+	err := c.cc.Invoke(ctx, "/mintrpc.Mint/SubmitGroupSignatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mintClient) FinalizeBatch(ctx context.Context, in *FinalizeBatchRequest, opts ...grpc.CallOption) (*FinalizeBatchResponse, error) {
 	out := new(FinalizeBatchResponse)
 	err := c.cc.Invoke(ctx, "/mintrpc.Mint/FinalizeBatch", in, out, opts...)
@@ -149,6 +206,24 @@ func (x *mintSubscribeMintEventsClient) Recv() (*MintEvent, error) {
 	return m, nil
 }
 
+func (c *mintClient) RegisterMetaSchema(ctx context.Context, in *RegisterMetaSchemaRequest, opts ...grpc.CallOption) (*RegisterMetaSchemaResponse, error) {
+	out := new(RegisterMetaSchemaResponse)
+	err := c.cc.Invoke(ctx, "/mintrpc.Mint/RegisterMetaSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintClient) ListMetaSchemas(ctx context.Context, in *ListMetaSchemasRequest, opts ...grpc.CallOption) (*ListMetaSchemasResponse, error) {
+	out := new(ListMetaSchemasResponse)
+	err := c.cc.Invoke(ctx, "/mintrpc.Mint/ListMetaSchemas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MintServer is the server API for Mint service.
 // All implementations must embed UnimplementedMintServer
 // for forward compatibility
@@ -160,6 +235,12 @@ type MintServer interface {
 	// batch. This call will block until the operation succeeds (asset is staged
 	// in the batch) or fails.
 	MintAsset(context.Context, *MintAssetRequest) (*MintAssetResponse, error)
+	// tapcli: `assets mint collection`
+	// MintCollection adds a collection of collectibles to the pending batch. Each
+	// edition is minted as a collectible with an amount of one, and all editions
+	// are linked under a single asset group. The batch is funded if it isn't
+	// funded yet, so that the IDs of the editions' assets can be returned.
+	MintCollection(context.Context, *MintCollectionRequest) (*MintCollectionResponse, error)
 	// tapcli `assets mint fund`
 	// FundBatch will attempt to fund the current pending batch with a genesis
 	// input, or create a new funded batch if no batch exists yet. This RPC is only
@@ -174,6 +255,21 @@ type MintServer interface {
 	// that require an external signer. Otherwise, FinalizeBatch can be called
 	// directly.
 	SealBatch(context.Context, *SealBatchRequest) (*SealBatchResponse, error)
+	// tapcli: `assets mint grouppsbts`
+	// ExportGroupPsbts exports the asset group witnesses of the current pending
+	// batch that must be produced by an external signer, such as an HSM, because
+	// the group internal key is not held by the wallet. Each witness is exported
+	// as a PSBT that spends the tweaked group key with the key spend path. The
+	// batch must be funded with FundBatch first, as the genesis outpoint
+	// determines the asset IDs that are signed.
+	ExportGroupPsbts(context.Context, *ExportGroupPsbtsRequest) (*ExportGroupPsbtsResponse, error)
+	// tapcli: `assets mint submitgroupsigs`
+	// SubmitGroupSignatures seals the current pending batch with the asset group
+	// witnesses of the given group PSBTs, which were exported by ExportGroupPsbts
+	// and signed by an external signer. Witnesses of assets not covered by a PSBT
+	// are derived by the wallet. Afterwards, FinalizeBatch can be called to
+	// broadcast the batch.
+	SubmitGroupSignatures(context.Context, *SubmitGroupSignaturesRequest) (*SubmitGroupSignaturesResponse, error)
 	// tapcli: `assets mint finalize`
 	// FinalizeBatch will attempt to finalize the current pending batch.
 	FinalizeBatch(context.Context, *FinalizeBatchRequest) (*FinalizeBatchResponse, error)
@@ -188,6 +284,15 @@ type MintServer interface {
 	// SubscribeMintEvents allows a caller to subscribe to mint events for asset
 	// creation batches.
 	SubscribeMintEvents(*SubscribeMintEventsRequest, Mint_SubscribeMintEventsServer) error
+	// tapcli: `assets mint schemas register`
+	// RegisterMetaSchema registers a custom JSON schema that the metadata of new
+	// assets can be validated against by setting its ID as the meta_schema_id
+	// when minting. Registered schemas can't be replaced.
+	RegisterMetaSchema(context.Context, *RegisterMetaSchemaRequest) (*RegisterMetaSchemaResponse, error)
+	// tapcli: `assets mint schemas list`
+	// ListMetaSchemas lists the built-in and custom JSON schemas asset metadata
+	// can be validated against.
+	ListMetaSchemas(context.Context, *ListMetaSchemasRequest) (*ListMetaSchemasResponse, error)
 	mustEmbedUnimplementedMintServer()
 }
 
@@ -198,12 +303,21 @@ type UnimplementedMintServer struct {
 func (UnimplementedMintServer) MintAsset(context.Context, *MintAssetRequest) (*MintAssetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MintAsset not implemented")
 }
+func (UnimplementedMintServer) MintCollection(context.Context, *MintCollectionRequest) (*MintCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MintCollection not implemented")
+}
 func (UnimplementedMintServer) FundBatch(context.Context, *FundBatchRequest) (*FundBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FundBatch not implemented")
 }
 func (UnimplementedMintServer) SealBatch(context.Context, *SealBatchRequest) (*SealBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SealBatch not implemented")
 }
+func (UnimplementedMintServer) ExportGroupPsbts(context.Context, *ExportGroupPsbtsRequest) (*ExportGroupPsbtsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGroupPsbts not implemented")
+}
+func (UnimplementedMintServer) SubmitGroupSignatures(context.Context, *SubmitGroupSignaturesRequest) (*SubmitGroupSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitGroupSignatures not implemented")
+}
 func (UnimplementedMintServer) FinalizeBatch(context.Context, *FinalizeBatchRequest) (*FinalizeBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinalizeBatch not implemented")
 }
@@ -216,6 +330,12 @@ func (UnimplementedMintServer) ListBatches(context.Context, *ListBatchRequest) (
 func (UnimplementedMintServer) SubscribeMintEvents(*SubscribeMintEventsRequest, Mint_SubscribeMintEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeMintEvents not implemented")
 }
+func (UnimplementedMintServer) RegisterMetaSchema(context.Context, *RegisterMetaSchemaRequest) (*RegisterMetaSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterMetaSchema not implemented")
+}
+func (UnimplementedMintServer) ListMetaSchemas(context.Context, *ListMetaSchemasRequest) (*ListMetaSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetaSchemas not implemented")
+}
 func (UnimplementedMintServer) mustEmbedUnimplementedMintServer() {}
 
 // UnsafeMintServer may be embedded to opt out of forward compatibility for this service.
@@ -247,6 +367,24 @@ func _Mint_MintAsset_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mint_MintCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServer).MintCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mintrpc.Mint/MintCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServer).MintCollection(ctx, req.(*MintCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Mint_FundBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FundBatchRequest)
 	if err := dec(in); err != nil {
@@ -283,6 +421,42 @@ func _Mint_SealBatch_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mint_ExportGroupPsbts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGroupPsbtsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServer).ExportGroupPsbts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mintrpc.Mint/ExportGroupPsbts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServer).ExportGroupPsbts(ctx, req.(*ExportGroupPsbtsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mint_SubmitGroupSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitGroupSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServer).SubmitGroupSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mintrpc.Mint/SubmitGroupSignatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServer).SubmitGroupSignatures(ctx, req.(*SubmitGroupSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Mint_FinalizeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FinalizeBatchRequest)
 	if err := dec(in); err != nil {
@@ -358,6 +532,42 @@ func (x *mintSubscribeMintEventsServer) Send(m *MintEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Mint_RegisterMetaSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterMetaSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServer).RegisterMetaSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mintrpc.Mint/RegisterMetaSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServer).RegisterMetaSchema(ctx, req.(*RegisterMetaSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mint_ListMetaSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetaSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServer).ListMetaSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mintrpc.Mint/ListMetaSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServer).ListMetaSchemas(ctx, req.(*ListMetaSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Mint_ServiceDesc is the grpc.ServiceDesc for Mint service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -369,6 +579,10 @@ var Mint_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MintAsset",
 			Handler:    _Mint_MintAsset_Handler,
 		},
+		{
+			MethodName: "MintCollection",
+			Handler:    _Mint_MintCollection_Handler,
+		},
 		{
 			MethodName: "FundBatch",
 			Handler:    _Mint_FundBatch_Handler,
@@ -377,6 +591,14 @@ var Mint_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SealBatch",
 			Handler:    _Mint_SealBatch_Handler,
 		},
+		{
+			MethodName: "ExportGroupPsbts",
+			Handler:    _Mint_ExportGroupPsbts_Handler,
+		},
+		{
+			MethodName: "SubmitGroupSignatures",
+			Handler:    _Mint_SubmitGroupSignatures_Handler,
+		},
 		{
 			MethodName: "FinalizeBatch",
 			Handler:    _Mint_FinalizeBatch_Handler,
@@ -389,6 +611,14 @@ var Mint_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBatches",
 			Handler:    _Mint_ListBatches_Handler,
 		},
+		{
+			MethodName: "RegisterMetaSchema",
+			Handler:    _Mint_RegisterMetaSchema_Handler,
+		},
+		{
+			MethodName: "ListMetaSchemas",
+			Handler:    _Mint_ListMetaSchemas_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -76,6 +76,169 @@ func (QuoteRespStatus) EnumDescriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{0}
 }
 
+type QuoteDirection int32
+
+const (
+	// QUOTE_DIRECTION_ANY matches quotes of both directions. It is only used
+	// in filters.
+	QuoteDirection_QUOTE_DIRECTION_ANY QuoteDirection = 0
+	// QUOTE_DIRECTION_BUY is the direction of buy quotes, where the
+	// requesting node buys the asset.
+	QuoteDirection_QUOTE_DIRECTION_BUY QuoteDirection = 1
+	// QUOTE_DIRECTION_SELL is the direction of sell quotes, where the
+	// requesting node sells the asset.
+	QuoteDirection_QUOTE_DIRECTION_SELL QuoteDirection = 2
+)
+
+// Enum value maps for QuoteDirection.
+var (
+	QuoteDirection_name = map[int32]string{
+		0: "QUOTE_DIRECTION_ANY",
+		1: "QUOTE_DIRECTION_BUY",
+		2: "QUOTE_DIRECTION_SELL",
+	}
+	QuoteDirection_value = map[string]int32{
+		"QUOTE_DIRECTION_ANY":  0,
+		"QUOTE_DIRECTION_BUY":  1,
+		"QUOTE_DIRECTION_SELL": 2,
+	}
+)
+
+func (x QuoteDirection) Enum() *QuoteDirection {
+	p := new(QuoteDirection)
+	*p = x
+	return p
+}
+
+func (x QuoteDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuoteDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfqrpc_rfq_proto_enumTypes[1].Descriptor()
+}
+
+func (QuoteDirection) Type() protoreflect.EnumType {
+	return &file_rfqrpc_rfq_proto_enumTypes[1]
+}
+
+func (x QuoteDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuoteDirection.Descriptor instead.
+func (QuoteDirection) EnumDescriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{1}
+}
+
+type QuoteAcceptor int32
+
+const (
+	// QUOTE_ACCEPTOR_ANY matches quotes accepted by any node. It is only used
+	// in filters.
+	QuoteAcceptor_QUOTE_ACCEPTOR_ANY QuoteAcceptor = 0
+	// QUOTE_ACCEPTOR_LOCAL is used for quotes our node accepted for a request
+	// of a peer.
+	QuoteAcceptor_QUOTE_ACCEPTOR_LOCAL QuoteAcceptor = 1
+	// QUOTE_ACCEPTOR_PEER is used for quotes a peer accepted for a request of
+	// our node.
+	QuoteAcceptor_QUOTE_ACCEPTOR_PEER QuoteAcceptor = 2
+)
+
+// Enum value maps for QuoteAcceptor.
+var (
+	QuoteAcceptor_name = map[int32]string{
+		0: "QUOTE_ACCEPTOR_ANY",
+		1: "QUOTE_ACCEPTOR_LOCAL",
+		2: "QUOTE_ACCEPTOR_PEER",
+	}
+	QuoteAcceptor_value = map[string]int32{
+		"QUOTE_ACCEPTOR_ANY":   0,
+		"QUOTE_ACCEPTOR_LOCAL": 1,
+		"QUOTE_ACCEPTOR_PEER":  2,
+	}
+)
+
+func (x QuoteAcceptor) Enum() *QuoteAcceptor {
+	p := new(QuoteAcceptor)
+	*p = x
+	return p
+}
+
+func (x QuoteAcceptor) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuoteAcceptor) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfqrpc_rfq_proto_enumTypes[2].Descriptor()
+}
+
+func (QuoteAcceptor) Type() protoreflect.EnumType {
+	return &file_rfqrpc_rfq_proto_enumTypes[2]
+}
+
+func (x QuoteAcceptor) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuoteAcceptor.Descriptor instead.
+func (QuoteAcceptor) EnumDescriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{2}
+}
+
+type QuoteState int32
+
+const (
+	// QUOTE_STATE_ANY matches quotes in any state. It is only used in
+	// filters.
+	QuoteState_QUOTE_STATE_ANY QuoteState = 0
+	// QUOTE_STATE_ACTIVE is the state of quotes that haven't expired yet.
+	QuoteState_QUOTE_STATE_ACTIVE QuoteState = 1
+	// QUOTE_STATE_EXPIRED is the state of quotes that have expired.
+	QuoteState_QUOTE_STATE_EXPIRED QuoteState = 2
+)
+
+// Enum value maps for QuoteState.
+var (
+	QuoteState_name = map[int32]string{
+		0: "QUOTE_STATE_ANY",
+		1: "QUOTE_STATE_ACTIVE",
+		2: "QUOTE_STATE_EXPIRED",
+	}
+	QuoteState_value = map[string]int32{
+		"QUOTE_STATE_ANY":     0,
+		"QUOTE_STATE_ACTIVE":  1,
+		"QUOTE_STATE_EXPIRED": 2,
+	}
+)
+
+func (x QuoteState) Enum() *QuoteState {
+	p := new(QuoteState)
+	*p = x
+	return p
+}
+
+func (x QuoteState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuoteState) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfqrpc_rfq_proto_enumTypes[3].Descriptor()
+}
+
+func (QuoteState) Type() protoreflect.EnumType {
+	return &file_rfqrpc_rfq_proto_enumTypes[3]
+}
+
+func (x QuoteState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuoteState.Descriptor instead.
+func (QuoteState) EnumDescriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{3}
+}
+
 type AssetSpecifier struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1294,14 +1457,14 @@ func (x *QueryPeerAcceptedQuotesResponse) GetSellQuotes() []*PeerAcceptedSellQuo
 	return nil
 }
 
-type SubscribeRfqEventNtfnsRequest struct {
+type QueryPeerReputationsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SubscribeRfqEventNtfnsRequest) Reset() {
-	*x = SubscribeRfqEventNtfnsRequest{}
+func (x *QueryPeerReputationsRequest) Reset() {
+	*x = QueryPeerReputationsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rfqrpc_rfq_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1309,13 +1472,13 @@ func (x *SubscribeRfqEventNtfnsRequest) Reset() {
 	}
 }
 
-func (x *SubscribeRfqEventNtfnsRequest) String() string {
+func (x *QueryPeerReputationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeRfqEventNtfnsRequest) ProtoMessage() {}
+func (*QueryPeerReputationsRequest) ProtoMessage() {}
 
-func (x *SubscribeRfqEventNtfnsRequest) ProtoReflect() protoreflect.Message {
+func (x *QueryPeerReputationsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rfqrpc_rfq_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1327,24 +1490,43 @@ func (x *SubscribeRfqEventNtfnsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeRfqEventNtfnsRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeRfqEventNtfnsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryPeerReputationsRequest.ProtoReflect.Descriptor instead.
+func (*QueryPeerReputationsRequest) Descriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{16}
 }
 
-type PeerAcceptedBuyQuoteEvent struct {
+type PeerReputation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unix timestamp in microseconds.
-	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// The asset buy quote that was accepted by out peer.
-	PeerAcceptedBuyQuote *PeerAcceptedBuyQuote `protobuf:"bytes,2,opt,name=peer_accepted_buy_quote,json=peerAcceptedBuyQuote,proto3" json:"peer_accepted_buy_quote,omitempty"`
-}
-
-func (x *PeerAcceptedBuyQuoteEvent) Reset() {
-	*x = PeerAcceptedBuyQuoteEvent{}
+	// peer is the public key of the peer.
+	Peer string `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	// score is the reputation score of the peer, between 0 and 100. Peers
+	// without any history have a neutral score of 50.
+	Score uint32 `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	// quotes_accepted is the number of quotes the peer accepted with terms
+	// that passed our validation.
+	QuotesAccepted uint64 `protobuf:"varint,3,opt,name=quotes_accepted,json=quotesAccepted,proto3" json:"quotes_accepted,omitempty"`
+	// quotes_rejected is the number of quotes the peer rejected or accepted
+	// with terms that failed our validation.
+	QuotesRejected uint64 `protobuf:"varint,4,opt,name=quotes_rejected,json=quotesRejected,proto3" json:"quotes_rejected,omitempty"`
+	// htlcs_settled is the number of payments that settled using a quote of
+	// the peer.
+	HtlcsSettled uint64 `protobuf:"varint,5,opt,name=htlcs_settled,json=htlcsSettled,proto3" json:"htlcs_settled,omitempty"`
+	// htlcs_failed is the number of HTLCs the peer failed after quoting.
+	HtlcsFailed uint64 `protobuf:"varint,6,opt,name=htlcs_failed,json=htlcsFailed,proto3" json:"htlcs_failed,omitempty"`
+	// avg_rate_deviation_ppm is the average deviation of the peer's accepted
+	// quote rates from our price oracle's rate in parts per million. A
+	// positive value means the rates were to our advantage.
+	AvgRateDeviationPpm int64 `protobuf:"varint,7,opt,name=avg_rate_deviation_ppm,json=avgRateDeviationPpm,proto3" json:"avg_rate_deviation_ppm,omitempty"`
+	// updated_at is the unix timestamp in seconds at which the metrics of the
+	// peer were last updated.
+	UpdatedAt int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *PeerReputation) Reset() {
+	*x = PeerReputation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rfqrpc_rfq_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1352,13 +1534,13 @@ func (x *PeerAcceptedBuyQuoteEvent) Reset() {
 	}
 }
 
-func (x *PeerAcceptedBuyQuoteEvent) String() string {
+func (x *PeerReputation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerAcceptedBuyQuoteEvent) ProtoMessage() {}
+func (*PeerReputation) ProtoMessage() {}
 
-func (x *PeerAcceptedBuyQuoteEvent) ProtoReflect() protoreflect.Message {
+func (x *PeerReputation) ProtoReflect() protoreflect.Message {
 	mi := &file_rfqrpc_rfq_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1370,38 +1552,78 @@ func (x *PeerAcceptedBuyQuoteEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerAcceptedBuyQuoteEvent.ProtoReflect.Descriptor instead.
-func (*PeerAcceptedBuyQuoteEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use PeerReputation.ProtoReflect.Descriptor instead.
+func (*PeerReputation) Descriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PeerAcceptedBuyQuoteEvent) GetTimestamp() uint64 {
+func (x *PeerReputation) GetPeer() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *PeerReputation) GetScore() uint32 {
+	if x != nil {
+		return x.Score
 	}
 	return 0
 }
 
-func (x *PeerAcceptedBuyQuoteEvent) GetPeerAcceptedBuyQuote() *PeerAcceptedBuyQuote {
+func (x *PeerReputation) GetQuotesAccepted() uint64 {
 	if x != nil {
-		return x.PeerAcceptedBuyQuote
+		return x.QuotesAccepted
 	}
-	return nil
+	return 0
 }
 
-type PeerAcceptedSellQuoteEvent struct {
+func (x *PeerReputation) GetQuotesRejected() uint64 {
+	if x != nil {
+		return x.QuotesRejected
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetHtlcsSettled() uint64 {
+	if x != nil {
+		return x.HtlcsSettled
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetHtlcsFailed() uint64 {
+	if x != nil {
+		return x.HtlcsFailed
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetAvgRateDeviationPpm() int64 {
+	if x != nil {
+		return x.AvgRateDeviationPpm
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type QueryPeerReputationsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unix timestamp in microseconds.
-	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// The asset sell quote that was accepted by out peer.
-	PeerAcceptedSellQuote *PeerAcceptedSellQuote `protobuf:"bytes,2,opt,name=peer_accepted_sell_quote,json=peerAcceptedSellQuote,proto3" json:"peer_accepted_sell_quote,omitempty"`
+	// peers is the list of peers our node has a quote history with.
+	Peers []*PeerReputation `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
 }
 
-func (x *PeerAcceptedSellQuoteEvent) Reset() {
-	*x = PeerAcceptedSellQuoteEvent{}
+func (x *QueryPeerReputationsResponse) Reset() {
+	*x = QueryPeerReputationsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rfqrpc_rfq_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1409,13 +1631,13 @@ func (x *PeerAcceptedSellQuoteEvent) Reset() {
 	}
 }
 
-func (x *PeerAcceptedSellQuoteEvent) String() string {
+func (x *QueryPeerReputationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerAcceptedSellQuoteEvent) ProtoMessage() {}
+func (*QueryPeerReputationsResponse) ProtoMessage() {}
 
-func (x *PeerAcceptedSellQuoteEvent) ProtoReflect() protoreflect.Message {
+func (x *QueryPeerReputationsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rfqrpc_rfq_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1427,39 +1649,42 @@ func (x *PeerAcceptedSellQuoteEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerAcceptedSellQuoteEvent.ProtoReflect.Descriptor instead.
-func (*PeerAcceptedSellQuoteEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryPeerReputationsResponse.ProtoReflect.Descriptor instead.
+func (*QueryPeerReputationsResponse) Descriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PeerAcceptedSellQuoteEvent) GetTimestamp() uint64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-func (x *PeerAcceptedSellQuoteEvent) GetPeerAcceptedSellQuote() *PeerAcceptedSellQuote {
+func (x *QueryPeerReputationsResponse) GetPeers() []*PeerReputation {
 	if x != nil {
-		return x.PeerAcceptedSellQuote
+		return x.Peers
 	}
 	return nil
 }
 
-type AcceptHtlcEvent struct {
+type QuerySettlementStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unix timestamp in microseconds.
-	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// scid is the short channel ID of the channel over which the payment for
-	// the quote is made.
-	Scid uint64 `protobuf:"varint,2,opt,name=scid,proto3" json:"scid,omitempty"`
-}
-
-func (x *AcceptHtlcEvent) Reset() {
-	*x = AcceptHtlcEvent{}
+	// asset_specifier is an optional filter that restricts the result to the
+	// given asset. The stats of grouped assets are stored under the group key
+	// if the quotes specified it, so they must be queried by group key.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,1,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// peer_pub_key is an optional filter that restricts the result to the
+	// given peer.
+	PeerPubKey []byte `protobuf:"bytes,2,opt,name=peer_pub_key,json=peerPubKey,proto3" json:"peer_pub_key,omitempty"`
+	// start_time is the unix timestamp in seconds of the start of the
+	// queried range. The stats of the whole day the timestamp falls on are
+	// included. Defaults to the start of the day 30 days before end_time.
+	StartTime int64 `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// end_time is the unix timestamp in seconds of the end of the queried
+	// range. The stats of the whole day the timestamp falls on are included.
+	// Defaults to the current time.
+	EndTime int64 `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *QuerySettlementStatsRequest) Reset() {
+	*x = QuerySettlementStatsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rfqrpc_rfq_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1467,13 +1692,13 @@ func (x *AcceptHtlcEvent) Reset() {
 	}
 }
 
-func (x *AcceptHtlcEvent) String() string {
+func (x *QuerySettlementStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptHtlcEvent) ProtoMessage() {}
+func (*QuerySettlementStatsRequest) ProtoMessage() {}
 
-func (x *AcceptHtlcEvent) ProtoReflect() protoreflect.Message {
+func (x *QuerySettlementStatsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rfqrpc_rfq_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1485,40 +1710,66 @@ func (x *AcceptHtlcEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptHtlcEvent.ProtoReflect.Descriptor instead.
-func (*AcceptHtlcEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuerySettlementStatsRequest.ProtoReflect.Descriptor instead.
+func (*QuerySettlementStatsRequest) Descriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *AcceptHtlcEvent) GetTimestamp() uint64 {
+func (x *QuerySettlementStatsRequest) GetAssetSpecifier() *AssetSpecifier {
 	if x != nil {
-		return x.Timestamp
+		return x.AssetSpecifier
+	}
+	return nil
+}
+
+func (x *QuerySettlementStatsRequest) GetPeerPubKey() []byte {
+	if x != nil {
+		return x.PeerPubKey
+	}
+	return nil
+}
+
+func (x *QuerySettlementStatsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
 	}
 	return 0
 }
 
-func (x *AcceptHtlcEvent) GetScid() uint64 {
+func (x *QuerySettlementStatsRequest) GetEndTime() int64 {
 	if x != nil {
-		return x.Scid
+		return x.EndTime
 	}
 	return 0
 }
 
-type RfqEvent struct {
+type SettlementStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Event:
-	//
-	//	*RfqEvent_PeerAcceptedBuyQuote
-	//	*RfqEvent_PeerAcceptedSellQuote
-	//	*RfqEvent_AcceptHtlc
-	Event isRfqEvent_Event `protobuf_oneof:"event"`
-}
-
-func (x *RfqEvent) Reset() {
-	*x = RfqEvent{}
+	// asset_specifier is the asset the settled HTLCs carried, as specified
+	// by the quotes they were accepted under.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,1,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// peer is the public key of the peer the quotes were negotiated with.
+	Peer string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// day is the unix timestamp in seconds of the start of the UTC day the
+	// HTLCs settled on.
+	Day int64 `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	// num_htlcs is the number of settled HTLCs.
+	NumHtlcs uint64 `protobuf:"varint,4,opt,name=num_htlcs,json=numHtlcs,proto3" json:"num_htlcs,omitempty"`
+	// asset_amount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64 `protobuf:"varint,5,opt,name=asset_amount,json=assetAmount,proto3" json:"asset_amount,omitempty"`
+	// amount_msat is the sum of the BTC amounts in milli-satoshi of the
+	// settled HTLCs.
+	AmountMsat uint64 `protobuf:"varint,6,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+	// avg_rate is the average realized rate of the settled HTLCs in asset
+	// units per BTC. This is not set if the BTC amount is zero.
+	AvgRate *FixedPoint `protobuf:"bytes,7,opt,name=avg_rate,json=avgRate,proto3" json:"avg_rate,omitempty"`
+}
+
+func (x *SettlementStats) Reset() {
+	*x = SettlementStats{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rfqrpc_rfq_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1526,13 +1777,13 @@ func (x *RfqEvent) Reset() {
 	}
 }
 
-func (x *RfqEvent) String() string {
+func (x *SettlementStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RfqEvent) ProtoMessage() {}
+func (*SettlementStats) ProtoMessage() {}
 
-func (x *RfqEvent) ProtoReflect() protoreflect.Message {
+func (x *SettlementStats) ProtoReflect() protoreflect.Message {
 	mi := &file_rfqrpc_rfq_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1544,58 +1795,1235 @@ func (x *RfqEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RfqEvent.ProtoReflect.Descriptor instead.
-func (*RfqEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use SettlementStats.ProtoReflect.Descriptor instead.
+func (*SettlementStats) Descriptor() ([]byte, []int) {
 	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{20}
 }
 
-func (m *RfqEvent) GetEvent() isRfqEvent_Event {
-	if m != nil {
-		return m.Event
+func (x *SettlementStats) GetAssetSpecifier() *AssetSpecifier {
+	if x != nil {
+		return x.AssetSpecifier
 	}
 	return nil
 }
 
-func (x *RfqEvent) GetPeerAcceptedBuyQuote() *PeerAcceptedBuyQuoteEvent {
-	if x, ok := x.GetEvent().(*RfqEvent_PeerAcceptedBuyQuote); ok {
-		return x.PeerAcceptedBuyQuote
+func (x *SettlementStats) GetPeer() string {
+	if x != nil {
+		return x.Peer
 	}
-	return nil
+	return ""
 }
 
-func (x *RfqEvent) GetPeerAcceptedSellQuote() *PeerAcceptedSellQuoteEvent {
-	if x, ok := x.GetEvent().(*RfqEvent_PeerAcceptedSellQuote); ok {
-		return x.PeerAcceptedSellQuote
+func (x *SettlementStats) GetDay() int64 {
+	if x != nil {
+		return x.Day
 	}
-	return nil
+	return 0
 }
 
-func (x *RfqEvent) GetAcceptHtlc() *AcceptHtlcEvent {
-	if x, ok := x.GetEvent().(*RfqEvent_AcceptHtlc); ok {
-		return x.AcceptHtlc
+func (x *SettlementStats) GetNumHtlcs() uint64 {
+	if x != nil {
+		return x.NumHtlcs
 	}
-	return nil
+	return 0
 }
 
-type isRfqEvent_Event interface {
-	isRfqEvent_Event()
+func (x *SettlementStats) GetAssetAmount() uint64 {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return 0
 }
 
-type RfqEvent_PeerAcceptedBuyQuote struct {
-	// peer_accepted_buy_quote is an event that is emitted when a peer
-	// accepted (incoming) asset buy quote message is received.
-	PeerAcceptedBuyQuote *PeerAcceptedBuyQuoteEvent `protobuf:"bytes,1,opt,name=peer_accepted_buy_quote,json=peerAcceptedBuyQuote,proto3,oneof"`
+func (x *SettlementStats) GetAmountMsat() uint64 {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return 0
 }
 
-type RfqEvent_PeerAcceptedSellQuote struct {
-	// peer_accepted_sell_offer is an event that is emitted when a peer
-	// accepted (incoming) asset sell quote message is received.
-	PeerAcceptedSellQuote *PeerAcceptedSellQuoteEvent `protobuf:"bytes,2,opt,name=peer_accepted_sell_quote,json=peerAcceptedSellQuote,proto3,oneof"`
+func (x *SettlementStats) GetAvgRate() *FixedPoint {
+	if x != nil {
+		return x.AvgRate
+	}
+	return nil
 }
 
-type RfqEvent_AcceptHtlc struct {
-	// accept_htlc is an event that is sent when a HTLC is accepted by the
-	// RFQ service.
+type QuerySettlementStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// stats is the list of settlement stats matching the query, ordered by
+	// day.
+	Stats []*SettlementStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *QuerySettlementStatsResponse) Reset() {
+	*x = QuerySettlementStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuerySettlementStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySettlementStatsResponse) ProtoMessage() {}
+
+func (x *QuerySettlementStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySettlementStatsResponse.ProtoReflect.Descriptor instead.
+func (*QuerySettlementStatsResponse) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QuerySettlementStatsResponse) GetStats() []*SettlementStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type QuoteFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// asset_specifier is an optional filter that restricts the result to the
+	// given asset. Quotes that specify a group key are stored under the group
+	// key, so they must be queried by group key.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,1,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// peer_pub_key is an optional filter that restricts the result to the
+	// given peer.
+	PeerPubKey []byte `protobuf:"bytes,2,opt,name=peer_pub_key,json=peerPubKey,proto3" json:"peer_pub_key,omitempty"`
+	// direction is an optional filter that restricts the result to buy or
+	// sell quotes.
+	Direction QuoteDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=rfqrpc.QuoteDirection" json:"direction,omitempty"`
+	// acceptor is an optional filter that restricts the result to quotes
+	// accepted by our node or by our peers.
+	Acceptor QuoteAcceptor `protobuf:"varint,4,opt,name=acceptor,proto3,enum=rfqrpc.QuoteAcceptor" json:"acceptor,omitempty"`
+	// state is an optional filter that restricts the result to active or
+	// expired quotes.
+	State QuoteState `protobuf:"varint,5,opt,name=state,proto3,enum=rfqrpc.QuoteState" json:"state,omitempty"`
+	// start_time is the unix timestamp in seconds of the start of the range
+	// the quotes were accepted in. Defaults to 30 days before end_time.
+	StartTime int64 `protobuf:"varint,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// end_time is the unix timestamp in seconds of the end of the range the
+	// quotes were accepted in. Defaults to the current time.
+	EndTime int64 `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *QuoteFilter) Reset() {
+	*x = QuoteFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteFilter) ProtoMessage() {}
+
+func (x *QuoteFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteFilter.ProtoReflect.Descriptor instead.
+func (*QuoteFilter) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QuoteFilter) GetAssetSpecifier() *AssetSpecifier {
+	if x != nil {
+		return x.AssetSpecifier
+	}
+	return nil
+}
+
+func (x *QuoteFilter) GetPeerPubKey() []byte {
+	if x != nil {
+		return x.PeerPubKey
+	}
+	return nil
+}
+
+func (x *QuoteFilter) GetDirection() QuoteDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return QuoteDirection_QUOTE_DIRECTION_ANY
+}
+
+func (x *QuoteFilter) GetAcceptor() QuoteAcceptor {
+	if x != nil {
+		return x.Acceptor
+	}
+	return QuoteAcceptor_QUOTE_ACCEPTOR_ANY
+}
+
+func (x *QuoteFilter) GetState() QuoteState {
+	if x != nil {
+		return x.State
+	}
+	return QuoteState_QUOTE_STATE_ANY
+}
+
+func (x *QuoteFilter) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *QuoteFilter) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+type QueryQuotesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// filter restricts the returned quotes.
+	Filter *QuoteFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// offset is the number of matching quotes to skip.
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the maximum number of quotes to return. Defaults to 1000.
+	Limit uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *QueryQuotesRequest) Reset() {
+	*x = QueryQuotesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryQuotesRequest) ProtoMessage() {}
+
+func (x *QueryQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryQuotesRequest.ProtoReflect.Descriptor instead.
+func (*QueryQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *QueryQuotesRequest) GetFilter() *QuoteFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *QueryQuotesRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *QueryQuotesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecordedQuote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id is the unique identifier of the quote request the quote was
+	// accepted for.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// peer is the public key of the peer the quote was negotiated with.
+	Peer string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// direction is the direction of the quote.
+	Direction QuoteDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=rfqrpc.QuoteDirection" json:"direction,omitempty"`
+	// acceptor is the node that accepted the quote.
+	Acceptor QuoteAcceptor `protobuf:"varint,4,opt,name=acceptor,proto3,enum=rfqrpc.QuoteAcceptor" json:"acceptor,omitempty"`
+	// asset_specifier is the asset of the quote.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,5,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// asset_max_amount is the maximum asset amount of a buy quote.
+	AssetMaxAmount uint64 `protobuf:"varint,6,opt,name=asset_max_amount,json=assetMaxAmount,proto3" json:"asset_max_amount,omitempty"`
+	// payment_max_amt_msat is the maximum payment amount in milli-satoshi of
+	// a sell quote.
+	PaymentMaxAmtMsat uint64 `protobuf:"varint,7,opt,name=payment_max_amt_msat,json=paymentMaxAmtMsat,proto3" json:"payment_max_amt_msat,omitempty"`
+	// rate is the accepted rate in asset units per BTC.
+	Rate *FixedPoint `protobuf:"bytes,8,opt,name=rate,proto3" json:"rate,omitempty"`
+	// expiry is the unix timestamp in seconds of the expiry of the quote.
+	Expiry int64 `protobuf:"varint,9,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// accepted_at is the unix timestamp in seconds of the time the quote
+	// was accepted.
+	AcceptedAt int64 `protobuf:"varint,10,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	// state is the current state of the quote.
+	State QuoteState `protobuf:"varint,11,opt,name=state,proto3,enum=rfqrpc.QuoteState" json:"state,omitempty"`
+	// num_htlcs is the number of settled HTLCs that were accepted under the
+	// quote. The HTLCs are only tracked for quotes our node accepted.
+	NumHtlcs uint64 `protobuf:"varint,12,opt,name=num_htlcs,json=numHtlcs,proto3" json:"num_htlcs,omitempty"`
+	// asset_amount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64 `protobuf:"varint,13,opt,name=asset_amount,json=assetAmount,proto3" json:"asset_amount,omitempty"`
+	// amount_msat is the sum of the BTC amounts in milli-satoshi of the
+	// settled HTLCs.
+	AmountMsat uint64 `protobuf:"varint,14,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+}
+
+func (x *RecordedQuote) Reset() {
+	*x = RecordedQuote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordedQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordedQuote) ProtoMessage() {}
+
+func (x *RecordedQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordedQuote.ProtoReflect.Descriptor instead.
+func (*RecordedQuote) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RecordedQuote) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *RecordedQuote) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *RecordedQuote) GetDirection() QuoteDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return QuoteDirection_QUOTE_DIRECTION_ANY
+}
+
+func (x *RecordedQuote) GetAcceptor() QuoteAcceptor {
+	if x != nil {
+		return x.Acceptor
+	}
+	return QuoteAcceptor_QUOTE_ACCEPTOR_ANY
+}
+
+func (x *RecordedQuote) GetAssetSpecifier() *AssetSpecifier {
+	if x != nil {
+		return x.AssetSpecifier
+	}
+	return nil
+}
+
+func (x *RecordedQuote) GetAssetMaxAmount() uint64 {
+	if x != nil {
+		return x.AssetMaxAmount
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetPaymentMaxAmtMsat() uint64 {
+	if x != nil {
+		return x.PaymentMaxAmtMsat
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetRate() *FixedPoint {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *RecordedQuote) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetAcceptedAt() int64 {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetState() QuoteState {
+	if x != nil {
+		return x.State
+	}
+	return QuoteState_QUOTE_STATE_ANY
+}
+
+func (x *RecordedQuote) GetNumHtlcs() uint64 {
+	if x != nil {
+		return x.NumHtlcs
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetAssetAmount() uint64 {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return 0
+}
+
+func (x *RecordedQuote) GetAmountMsat() uint64 {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return 0
+}
+
+type QueryQuotesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// quotes is the list of quotes matching the query, ordered by the time
+	// they were accepted.
+	Quotes []*RecordedQuote `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+}
+
+func (x *QueryQuotesResponse) Reset() {
+	*x = QueryQuotesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryQuotesResponse) ProtoMessage() {}
+
+func (x *QueryQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryQuotesResponse.ProtoReflect.Descriptor instead.
+func (*QueryQuotesResponse) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryQuotesResponse) GetQuotes() []*RecordedQuote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+type QueryQuoteVolumeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// filter restricts the quotes the volume is aggregated over.
+	Filter *QuoteFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *QueryQuoteVolumeRequest) Reset() {
+	*x = QueryQuoteVolumeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryQuoteVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryQuoteVolumeRequest) ProtoMessage() {}
+
+func (x *QueryQuoteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryQuoteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*QueryQuoteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryQuoteVolumeRequest) GetFilter() *QuoteFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type QuoteVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// asset_specifier is the asset of the quotes.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,1,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// num_quotes is the number of quotes.
+	NumQuotes uint64 `protobuf:"varint,2,opt,name=num_quotes,json=numQuotes,proto3" json:"num_quotes,omitempty"`
+	// num_htlcs is the number of settled HTLCs that were accepted under the
+	// quotes.
+	NumHtlcs uint64 `protobuf:"varint,3,opt,name=num_htlcs,json=numHtlcs,proto3" json:"num_htlcs,omitempty"`
+	// asset_amount is the sum of the asset units of the settled HTLCs.
+	AssetAmount uint64 `protobuf:"varint,4,opt,name=asset_amount,json=assetAmount,proto3" json:"asset_amount,omitempty"`
+	// amount_msat is the sum of the BTC amounts in milli-satoshi of the
+	// settled HTLCs.
+	AmountMsat uint64 `protobuf:"varint,5,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+	// avg_rate is the average realized rate of the settled HTLCs in asset
+	// units per BTC. This is not set if the BTC amount is zero.
+	AvgRate *FixedPoint `protobuf:"bytes,6,opt,name=avg_rate,json=avgRate,proto3" json:"avg_rate,omitempty"`
+}
+
+func (x *QuoteVolume) Reset() {
+	*x = QuoteVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteVolume) ProtoMessage() {}
+
+func (x *QuoteVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteVolume.ProtoReflect.Descriptor instead.
+func (*QuoteVolume) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QuoteVolume) GetAssetSpecifier() *AssetSpecifier {
+	if x != nil {
+		return x.AssetSpecifier
+	}
+	return nil
+}
+
+func (x *QuoteVolume) GetNumQuotes() uint64 {
+	if x != nil {
+		return x.NumQuotes
+	}
+	return 0
+}
+
+func (x *QuoteVolume) GetNumHtlcs() uint64 {
+	if x != nil {
+		return x.NumHtlcs
+	}
+	return 0
+}
+
+func (x *QuoteVolume) GetAssetAmount() uint64 {
+	if x != nil {
+		return x.AssetAmount
+	}
+	return 0
+}
+
+func (x *QuoteVolume) GetAmountMsat() uint64 {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return 0
+}
+
+func (x *QuoteVolume) GetAvgRate() *FixedPoint {
+	if x != nil {
+		return x.AvgRate
+	}
+	return nil
+}
+
+type QueryQuoteVolumeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// volumes is the realized volume per asset, ordered by asset.
+	Volumes []*QuoteVolume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+}
+
+func (x *QueryQuoteVolumeResponse) Reset() {
+	*x = QueryQuoteVolumeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryQuoteVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryQuoteVolumeResponse) ProtoMessage() {}
+
+func (x *QueryQuoteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryQuoteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*QueryQuoteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QueryQuoteVolumeResponse) GetVolumes() []*QuoteVolume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+type QueryInventoryRiskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *QueryInventoryRiskRequest) Reset() {
+	*x = QueryInventoryRiskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryInventoryRiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryInventoryRiskRequest) ProtoMessage() {}
+
+func (x *QueryInventoryRiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryInventoryRiskRequest.ProtoReflect.Descriptor instead.
+func (*QueryInventoryRiskRequest) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{29}
+}
+
+type AssetInventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// asset_specifier is the asset the exposure is reported for. Assets of
+	// quotes that only specify a group key are reported under that group
+	// key.
+	AssetSpecifier *AssetSpecifier `protobuf:"bytes,1,opt,name=asset_specifier,json=assetSpecifier,proto3" json:"asset_specifier,omitempty"`
+	// local_balance is the sum of the local asset balances of the active
+	// asset channels.
+	LocalBalance uint64 `protobuf:"varint,2,opt,name=local_balance,json=localBalance,proto3" json:"local_balance,omitempty"`
+	// remote_balance is the sum of the remote asset balances of the active
+	// asset channels.
+	RemoteBalance uint64 `protobuf:"varint,3,opt,name=remote_balance,json=remoteBalance,proto3" json:"remote_balance,omitempty"`
+	// open_sell_units is the maximum number of asset units the node committed
+	// to sell under the unexpired quotes it accepted.
+	OpenSellUnits uint64 `protobuf:"varint,4,opt,name=open_sell_units,json=openSellUnits,proto3" json:"open_sell_units,omitempty"`
+	// open_buy_units is the maximum number of asset units the node committed
+	// to buy under the unexpired quotes it accepted.
+	OpenBuyUnits uint64 `protobuf:"varint,5,opt,name=open_buy_units,json=openBuyUnits,proto3" json:"open_buy_units,omitempty"`
+	// in_flight_outgoing_units is the number of asset units the node sells in
+	// HTLCs that are still in flight. They are no longer part of the local
+	// balance.
+	InFlightOutgoingUnits uint64 `protobuf:"varint,6,opt,name=in_flight_outgoing_units,json=inFlightOutgoingUnits,proto3" json:"in_flight_outgoing_units,omitempty"`
+	// in_flight_incoming_units is the number of asset units the node buys in
+	// HTLCs that are still in flight. They aren't part of the local balance
+	// yet.
+	InFlightIncomingUnits uint64 `protobuf:"varint,7,opt,name=in_flight_incoming_units,json=inFlightIncomingUnits,proto3" json:"in_flight_incoming_units,omitempty"`
+	// projected_balance is the local balance the node ends up with if all
+	// in-flight HTLCs settle and all open quotes are used up to their
+	// maximum amount.
+	ProjectedBalance int64 `protobuf:"varint,8,opt,name=projected_balance,json=projectedBalance,proto3" json:"projected_balance,omitempty"`
+	// has_target is true if an inventory target is configured for the asset.
+	HasTarget bool `protobuf:"varint,9,opt,name=has_target,json=hasTarget,proto3" json:"has_target,omitempty"`
+	// target_units is the configured inventory target of the asset.
+	TargetUnits uint64 `protobuf:"varint,10,opt,name=target_units,json=targetUnits,proto3" json:"target_units,omitempty"`
+	// target_deviation is by how many units the projected balance exceeds
+	// (if positive) or falls short of (if negative) the inventory target.
+	TargetDeviation int64 `protobuf:"varint,11,opt,name=target_deviation,json=targetDeviation,proto3" json:"target_deviation,omitempty"`
+}
+
+func (x *AssetInventory) Reset() {
+	*x = AssetInventory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetInventory) ProtoMessage() {}
+
+func (x *AssetInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetInventory.ProtoReflect.Descriptor instead.
+func (*AssetInventory) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AssetInventory) GetAssetSpecifier() *AssetSpecifier {
+	if x != nil {
+		return x.AssetSpecifier
+	}
+	return nil
+}
+
+func (x *AssetInventory) GetLocalBalance() uint64 {
+	if x != nil {
+		return x.LocalBalance
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetRemoteBalance() uint64 {
+	if x != nil {
+		return x.RemoteBalance
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetOpenSellUnits() uint64 {
+	if x != nil {
+		return x.OpenSellUnits
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetOpenBuyUnits() uint64 {
+	if x != nil {
+		return x.OpenBuyUnits
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetInFlightOutgoingUnits() uint64 {
+	if x != nil {
+		return x.InFlightOutgoingUnits
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetInFlightIncomingUnits() uint64 {
+	if x != nil {
+		return x.InFlightIncomingUnits
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetProjectedBalance() int64 {
+	if x != nil {
+		return x.ProjectedBalance
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetHasTarget() bool {
+	if x != nil {
+		return x.HasTarget
+	}
+	return false
+}
+
+func (x *AssetInventory) GetTargetUnits() uint64 {
+	if x != nil {
+		return x.TargetUnits
+	}
+	return 0
+}
+
+func (x *AssetInventory) GetTargetDeviation() int64 {
+	if x != nil {
+		return x.TargetDeviation
+	}
+	return 0
+}
+
+type QueryInventoryRiskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// inventory is the exposure of the node per asset.
+	Inventory []*AssetInventory `protobuf:"bytes,1,rep,name=inventory,proto3" json:"inventory,omitempty"`
+}
+
+func (x *QueryInventoryRiskResponse) Reset() {
+	*x = QueryInventoryRiskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryInventoryRiskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryInventoryRiskResponse) ProtoMessage() {}
+
+func (x *QueryInventoryRiskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryInventoryRiskResponse.ProtoReflect.Descriptor instead.
+func (*QueryInventoryRiskResponse) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *QueryInventoryRiskResponse) GetInventory() []*AssetInventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+type SubscribeRfqEventNtfnsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubscribeRfqEventNtfnsRequest) Reset() {
+	*x = SubscribeRfqEventNtfnsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRfqEventNtfnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRfqEventNtfnsRequest) ProtoMessage() {}
+
+func (x *SubscribeRfqEventNtfnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRfqEventNtfnsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRfqEventNtfnsRequest) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{32}
+}
+
+type PeerAcceptedBuyQuoteEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unix timestamp in microseconds.
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The asset buy quote that was accepted by out peer.
+	PeerAcceptedBuyQuote *PeerAcceptedBuyQuote `protobuf:"bytes,2,opt,name=peer_accepted_buy_quote,json=peerAcceptedBuyQuote,proto3" json:"peer_accepted_buy_quote,omitempty"`
+}
+
+func (x *PeerAcceptedBuyQuoteEvent) Reset() {
+	*x = PeerAcceptedBuyQuoteEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerAcceptedBuyQuoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerAcceptedBuyQuoteEvent) ProtoMessage() {}
+
+func (x *PeerAcceptedBuyQuoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerAcceptedBuyQuoteEvent.ProtoReflect.Descriptor instead.
+func (*PeerAcceptedBuyQuoteEvent) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PeerAcceptedBuyQuoteEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PeerAcceptedBuyQuoteEvent) GetPeerAcceptedBuyQuote() *PeerAcceptedBuyQuote {
+	if x != nil {
+		return x.PeerAcceptedBuyQuote
+	}
+	return nil
+}
+
+type PeerAcceptedSellQuoteEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unix timestamp in microseconds.
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The asset sell quote that was accepted by out peer.
+	PeerAcceptedSellQuote *PeerAcceptedSellQuote `protobuf:"bytes,2,opt,name=peer_accepted_sell_quote,json=peerAcceptedSellQuote,proto3" json:"peer_accepted_sell_quote,omitempty"`
+}
+
+func (x *PeerAcceptedSellQuoteEvent) Reset() {
+	*x = PeerAcceptedSellQuoteEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerAcceptedSellQuoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerAcceptedSellQuoteEvent) ProtoMessage() {}
+
+func (x *PeerAcceptedSellQuoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerAcceptedSellQuoteEvent.ProtoReflect.Descriptor instead.
+func (*PeerAcceptedSellQuoteEvent) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PeerAcceptedSellQuoteEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PeerAcceptedSellQuoteEvent) GetPeerAcceptedSellQuote() *PeerAcceptedSellQuote {
+	if x != nil {
+		return x.PeerAcceptedSellQuote
+	}
+	return nil
+}
+
+type AcceptHtlcEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unix timestamp in microseconds.
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// scid is the short channel ID of the channel over which the payment for
+	// the quote is made.
+	Scid uint64 `protobuf:"varint,2,opt,name=scid,proto3" json:"scid,omitempty"`
+}
+
+func (x *AcceptHtlcEvent) Reset() {
+	*x = AcceptHtlcEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptHtlcEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptHtlcEvent) ProtoMessage() {}
+
+func (x *AcceptHtlcEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptHtlcEvent.ProtoReflect.Descriptor instead.
+func (*AcceptHtlcEvent) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AcceptHtlcEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AcceptHtlcEvent) GetScid() uint64 {
+	if x != nil {
+		return x.Scid
+	}
+	return 0
+}
+
+type RfqEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//
+	//	*RfqEvent_PeerAcceptedBuyQuote
+	//	*RfqEvent_PeerAcceptedSellQuote
+	//	*RfqEvent_AcceptHtlc
+	Event isRfqEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *RfqEvent) Reset() {
+	*x = RfqEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rfqrpc_rfq_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RfqEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RfqEvent) ProtoMessage() {}
+
+func (x *RfqEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rfqrpc_rfq_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RfqEvent.ProtoReflect.Descriptor instead.
+func (*RfqEvent) Descriptor() ([]byte, []int) {
+	return file_rfqrpc_rfq_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *RfqEvent) GetEvent() isRfqEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *RfqEvent) GetPeerAcceptedBuyQuote() *PeerAcceptedBuyQuoteEvent {
+	if x, ok := x.GetEvent().(*RfqEvent_PeerAcceptedBuyQuote); ok {
+		return x.PeerAcceptedBuyQuote
+	}
+	return nil
+}
+
+func (x *RfqEvent) GetPeerAcceptedSellQuote() *PeerAcceptedSellQuoteEvent {
+	if x, ok := x.GetEvent().(*RfqEvent_PeerAcceptedSellQuote); ok {
+		return x.PeerAcceptedSellQuote
+	}
+	return nil
+}
+
+func (x *RfqEvent) GetAcceptHtlc() *AcceptHtlcEvent {
+	if x, ok := x.GetEvent().(*RfqEvent_AcceptHtlc); ok {
+		return x.AcceptHtlc
+	}
+	return nil
+}
+
+type isRfqEvent_Event interface {
+	isRfqEvent_Event()
+}
+
+type RfqEvent_PeerAcceptedBuyQuote struct {
+	// peer_accepted_buy_quote is an event that is emitted when a peer
+	// accepted (incoming) asset buy quote message is received.
+	PeerAcceptedBuyQuote *PeerAcceptedBuyQuoteEvent `protobuf:"bytes,1,opt,name=peer_accepted_buy_quote,json=peerAcceptedBuyQuote,proto3,oneof"`
+}
+
+type RfqEvent_PeerAcceptedSellQuote struct {
+	// peer_accepted_sell_offer is an event that is emitted when a peer
+	// accepted (incoming) asset sell quote message is received.
+	PeerAcceptedSellQuote *PeerAcceptedSellQuoteEvent `protobuf:"bytes,2,opt,name=peer_accepted_sell_quote,json=peerAcceptedSellQuote,proto3,oneof"`
+}
+
+type RfqEvent_AcceptHtlc struct {
+	// accept_htlc is an event that is sent when a HTLC is accepted by the
+	// RFQ service.
 	AcceptHtlc *AcceptHtlcEvent `protobuf:"bytes,3,opt,name=accept_htlc,json=acceptHtlc,proto3,oneof"`
 }
 
@@ -1762,93 +3190,326 @@ var file_rfqrpc_rfq_proto_rawDesc = []byte{
 	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x66, 0x71, 0x72,
 	0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53,
 	0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x6c, 0x51, 0x75,
-	0x6f, 0x74, 0x65, 0x73, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x19, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63,
-	0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x12, 0x53, 0x0a, 0x17, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x65, 0x64, 0x5f, 0x62, 0x75, 0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65,
-	0x52, 0x14, 0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x75,
-	0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x1a, 0x50, 0x65, 0x65, 0x72, 0x41,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x12, 0x56, 0x0a, 0x18, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63, 0x65,
-	0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51,
-	0x75, 0x6f, 0x74, 0x65, 0x52, 0x15, 0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x41,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c,
+	0x6f, 0x74, 0x65, 0x73, 0x22, 0x1d, 0x0a, 0x1b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xa8, 0x02, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x73, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x74, 0x6c, 0x63, 0x73, 0x5f, 0x73, 0x65, 0x74, 0x74,
+	0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x68, 0x74, 0x6c, 0x63, 0x73,
+	0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x74, 0x6c, 0x63, 0x73,
+	0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x68,
+	0x74, 0x6c, 0x63, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x16, 0x61, 0x76,
+	0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x61, 0x76, 0x67, 0x52,
+	0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x70, 0x6d, 0x12,
+	0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4c,
+	0x0a, 0x1c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x22, 0xba, 0x01, 0x0a,
+	0x1b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0f,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a,
+	0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x88, 0x02, 0x0a, 0x0f, 0x53, 0x65,
+	0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3f, 0x0a,
+	0x0f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0e,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x64, 0x61, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x68, 0x74, 0x6c, 0x63,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x48, 0x74, 0x6c, 0x63,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61, 0x73, 0x73, 0x65, 0x74, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d,
+	0x73, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x61, 0x76, 0x67, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x46, 0x69, 0x78, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x76, 0x67,
+	0x52, 0x61, 0x74, 0x65, 0x22, 0x4d, 0x0a, 0x1c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74,
+	0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74,
+	0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x22, 0xbd, 0x02, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72,
+	0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x52, 0x0e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72,
+	0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x66, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
+	0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x12,
+	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x6f, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x66, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0xa4, 0x04, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
+	0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x31, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x6f, 0x72, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72,
+	0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x52, 0x0e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2f,
+	0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d,
+	0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x78, 0x41, 0x6d, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12,
+	0x26, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x78, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x12, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75,
+	0x6d, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e,
+	0x75, 0x6d, 0x48, 0x74, 0x6c, 0x63, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x22, 0x44, 0x0a, 0x13, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x65, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x73, 0x22, 0x46, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72,
+	0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xfd, 0x01, 0x0a, 0x0b, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75,
+	0x6d, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x6e, 0x75, 0x6d, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d,
+	0x5f, 0x68, 0x74, 0x6c, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75,
+	0x6d, 0x48, 0x74, 0x6c, 0x63, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x61, 0x76,
+	0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72,
+	0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x78, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x07, 0x61, 0x76, 0x67, 0x52, 0x61, 0x74, 0x65, 0x22, 0x49, 0x0a, 0x18, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x07, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0xf7, 0x03, 0x0a, 0x0e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x70,
+	0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x6e,
+	0x53, 0x65, 0x6c, 0x6c, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x70, 0x65,
+	0x6e, 0x5f, 0x62, 0x75, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x6f, 0x70, 0x65, 0x6e, 0x42, 0x75, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12,
+	0x37, 0x0a, 0x18, 0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6f, 0x75, 0x74,
+	0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x15, 0x69, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x75, 0x74, 0x67, 0x6f,
+	0x69, 0x6e, 0x67, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x18, 0x69, 0x6e, 0x5f, 0x66,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x75,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x69, 0x6e, 0x46, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x55, 0x6e, 0x69, 0x74,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x68, 0x61, 0x73, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x68, 0x61, 0x73, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a, 0x1a, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x69, 0x73,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x69, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72,
+	0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x09, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x22,
+	0x1f, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x66, 0x71, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x8e, 0x01, 0x0a, 0x19, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c,
 	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04,
-	0x73, 0x63, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x63, 0x69, 0x64,
-	0x22, 0x8a, 0x02, 0x0a, 0x08, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x5a, 0x0a,
-	0x17, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x62,
-	0x75, 0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
-	0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65,
-	0x70, 0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x48, 0x00, 0x52, 0x14, 0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
-	0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x5d, 0x0a, 0x18, 0x70, 0x65, 0x65,
-	0x72, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f,
-	0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x72, 0x66,
-	0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
-	0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
-	0x00, 0x52, 0x15, 0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53,
-	0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x65,
-	0x70, 0x74, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x48, 0x74, 0x6c,
-	0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x48, 0x74, 0x6c, 0x63, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x5a, 0x0a,
-	0x0f, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x41, 0x53, 0x53, 0x45,
-	0x54, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56,
-	0x41, 0x4c, 0x49, 0x44, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x59, 0x10, 0x01, 0x12, 0x1a, 0x0a,
-	0x16, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x4f, 0x52, 0x41, 0x43, 0x4c, 0x45, 0x5f, 0x51, 0x55,
-	0x45, 0x52, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x10, 0x02, 0x32, 0xa8, 0x04, 0x0a, 0x03, 0x52, 0x66,
-	0x71, 0x12, 0x55, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41,
-	0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
-	0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x41,
-	0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e,
-	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53,
-	0x65, 0x6c, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65,
-	0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65,
-	0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63,
-	0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x66, 0x71, 0x72,
-	0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f,
-	0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x10,
-	0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72,
-	0x12, 0x1f, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73,
-	0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73,
-	0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65, 0x72,
-	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x26,
-	0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65,
-	0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x53, 0x0a, 0x17,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x75,
+	0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x14, 0x70, 0x65, 0x65,
+	0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x22, 0x92, 0x01, 0x0a, 0x1a, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x56,
+	0x0a, 0x18, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f,
+	0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52,
+	0x15, 0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c,
+	0x6c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x48, 0x74, 0x6c, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x63, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x63, 0x69, 0x64, 0x22, 0x8a, 0x02, 0x0a, 0x08,
+	0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x5a, 0x0a, 0x17, 0x70, 0x65, 0x65, 0x72,
+	0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x75, 0x79, 0x5f, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x72, 0x66, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42,
+	0x75, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x14,
+	0x70, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x42, 0x75, 0x79, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x12, 0x5d, 0x0a, 0x18, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x15, 0x70, 0x65,
+	0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x6c, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x68, 0x74,
+	0x6c, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x48, 0x74, 0x6c, 0x63, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x5a, 0x0a, 0x0f, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x13, 0x49,
+	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x54, 0x5f, 0x52, 0x41, 0x54,
+	0x45, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x45, 0x58, 0x50, 0x49, 0x52, 0x59, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x52, 0x49, 0x43,
+	0x45, 0x5f, 0x4f, 0x52, 0x41, 0x43, 0x4c, 0x45, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x45,
+	0x52, 0x52, 0x10, 0x02, 0x2a, 0x5c, 0x0a, 0x0e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12,
+	0x17, 0x0a, 0x13, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x42, 0x55, 0x59, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x51, 0x55, 0x4f, 0x54,
+	0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x4c, 0x4c,
+	0x10, 0x02, 0x2a, 0x5a, 0x0a, 0x0d, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x12, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x43,
+	0x45, 0x50, 0x54, 0x4f, 0x52, 0x5f, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x51,
+	0x55, 0x4f, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x4f, 0x52, 0x5f, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x41,
+	0x43, 0x43, 0x45, 0x50, 0x54, 0x4f, 0x52, 0x5f, 0x50, 0x45, 0x45, 0x52, 0x10, 0x02, 0x2a, 0x52,
+	0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x13, 0x0a, 0x0f,
+	0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x4e, 0x59, 0x10,
+	0x00, 0x12, 0x16, 0x0a, 0x12, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x51, 0x55, 0x4f,
+	0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44,
+	0x10, 0x02, 0x32, 0xea, 0x07, 0x0a, 0x03, 0x52, 0x66, 0x71, 0x12, 0x55, 0x0a, 0x10, 0x41, 0x64,
+	0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f,
+	0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x42, 0x75, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x42, 0x75, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x58, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x41,
+	0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x12, 0x20, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x72, 0x66, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x66, 0x71,
+	0x72, 0x70, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x79, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x17,
 	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
-	0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x53, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x66, 0x71, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x12, 0x25, 0x2e, 0x72, 0x66, 0x71, 0x72,
-	0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x66, 0x71, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x10, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73,
-	0x2f, 0x74, 0x61, 0x70, 0x72, 0x6f, 0x6f, 0x74, 0x2d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x2f,
-	0x74, 0x61, 0x70, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x23, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x14, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46,
+	0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x2e,
+	0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x66, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1f, 0x2e, 0x72, 0x66, 0x71,
+	0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x66,
+	0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a,
+	0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x69, 0x73, 0x6b, 0x12, 0x21, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x69, 0x73, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x69,
+	0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x16, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e,
+	0x74, 0x66, 0x6e, 0x73, 0x12, 0x25, 0x2e, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e,
+	0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x72, 0x66,
+	0x71, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x66, 0x71, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42,
+	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72,
+	0x6f, 0x6f, 0x74, 0x2d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72, 0x70,
+	0x63, 0x2f, 0x72, 0x66, 0x71, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1863,70 +3524,121 @@ func file_rfqrpc_rfq_proto_rawDescGZIP() []byte {
 	return file_rfqrpc_rfq_proto_rawDescData
 }
 
-var file_rfqrpc_rfq_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rfqrpc_rfq_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_rfqrpc_rfq_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_rfqrpc_rfq_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_rfqrpc_rfq_proto_goTypes = []interface{}{
 	(QuoteRespStatus)(0),                    // 0: rfqrpc.QuoteRespStatus
-	(*AssetSpecifier)(nil),                  // 1: rfqrpc.AssetSpecifier
-	(*FixedPoint)(nil),                      // 2: rfqrpc.FixedPoint
-	(*AddAssetBuyOrderRequest)(nil),         // 3: rfqrpc.AddAssetBuyOrderRequest
-	(*AddAssetBuyOrderResponse)(nil),        // 4: rfqrpc.AddAssetBuyOrderResponse
-	(*AddAssetSellOrderRequest)(nil),        // 5: rfqrpc.AddAssetSellOrderRequest
-	(*AddAssetSellOrderResponse)(nil),       // 6: rfqrpc.AddAssetSellOrderResponse
-	(*AddAssetSellOfferRequest)(nil),        // 7: rfqrpc.AddAssetSellOfferRequest
-	(*AddAssetSellOfferResponse)(nil),       // 8: rfqrpc.AddAssetSellOfferResponse
-	(*AddAssetBuyOfferRequest)(nil),         // 9: rfqrpc.AddAssetBuyOfferRequest
-	(*AddAssetBuyOfferResponse)(nil),        // 10: rfqrpc.AddAssetBuyOfferResponse
-	(*QueryPeerAcceptedQuotesRequest)(nil),  // 11: rfqrpc.QueryPeerAcceptedQuotesRequest
-	(*PeerAcceptedBuyQuote)(nil),            // 12: rfqrpc.PeerAcceptedBuyQuote
-	(*PeerAcceptedSellQuote)(nil),           // 13: rfqrpc.PeerAcceptedSellQuote
-	(*InvalidQuoteResponse)(nil),            // 14: rfqrpc.InvalidQuoteResponse
-	(*RejectedQuoteResponse)(nil),           // 15: rfqrpc.RejectedQuoteResponse
-	(*QueryPeerAcceptedQuotesResponse)(nil), // 16: rfqrpc.QueryPeerAcceptedQuotesResponse
-	(*SubscribeRfqEventNtfnsRequest)(nil),   // 17: rfqrpc.SubscribeRfqEventNtfnsRequest
-	(*PeerAcceptedBuyQuoteEvent)(nil),       // 18: rfqrpc.PeerAcceptedBuyQuoteEvent
-	(*PeerAcceptedSellQuoteEvent)(nil),      // 19: rfqrpc.PeerAcceptedSellQuoteEvent
-	(*AcceptHtlcEvent)(nil),                 // 20: rfqrpc.AcceptHtlcEvent
-	(*RfqEvent)(nil),                        // 21: rfqrpc.RfqEvent
+	(QuoteDirection)(0),                     // 1: rfqrpc.QuoteDirection
+	(QuoteAcceptor)(0),                      // 2: rfqrpc.QuoteAcceptor
+	(QuoteState)(0),                         // 3: rfqrpc.QuoteState
+	(*AssetSpecifier)(nil),                  // 4: rfqrpc.AssetSpecifier
+	(*FixedPoint)(nil),                      // 5: rfqrpc.FixedPoint
+	(*AddAssetBuyOrderRequest)(nil),         // 6: rfqrpc.AddAssetBuyOrderRequest
+	(*AddAssetBuyOrderResponse)(nil),        // 7: rfqrpc.AddAssetBuyOrderResponse
+	(*AddAssetSellOrderRequest)(nil),        // 8: rfqrpc.AddAssetSellOrderRequest
+	(*AddAssetSellOrderResponse)(nil),       // 9: rfqrpc.AddAssetSellOrderResponse
+	(*AddAssetSellOfferRequest)(nil),        // 10: rfqrpc.AddAssetSellOfferRequest
+	(*AddAssetSellOfferResponse)(nil),       // 11: rfqrpc.AddAssetSellOfferResponse
+	(*AddAssetBuyOfferRequest)(nil),         // 12: rfqrpc.AddAssetBuyOfferRequest
+	(*AddAssetBuyOfferResponse)(nil),        // 13: rfqrpc.AddAssetBuyOfferResponse
+	(*QueryPeerAcceptedQuotesRequest)(nil),  // 14: rfqrpc.QueryPeerAcceptedQuotesRequest
+	(*PeerAcceptedBuyQuote)(nil),            // 15: rfqrpc.PeerAcceptedBuyQuote
+	(*PeerAcceptedSellQuote)(nil),           // 16: rfqrpc.PeerAcceptedSellQuote
+	(*InvalidQuoteResponse)(nil),            // 17: rfqrpc.InvalidQuoteResponse
+	(*RejectedQuoteResponse)(nil),           // 18: rfqrpc.RejectedQuoteResponse
+	(*QueryPeerAcceptedQuotesResponse)(nil), // 19: rfqrpc.QueryPeerAcceptedQuotesResponse
+	(*QueryPeerReputationsRequest)(nil),     // 20: rfqrpc.QueryPeerReputationsRequest
+	(*PeerReputation)(nil),                  // 21: rfqrpc.PeerReputation
+	(*QueryPeerReputationsResponse)(nil),    // 22: rfqrpc.QueryPeerReputationsResponse
+	(*QuerySettlementStatsRequest)(nil),     // 23: rfqrpc.QuerySettlementStatsRequest
+	(*SettlementStats)(nil),                 // 24: rfqrpc.SettlementStats
+	(*QuerySettlementStatsResponse)(nil),    // 25: rfqrpc.QuerySettlementStatsResponse
+	(*QuoteFilter)(nil),                     // 26: rfqrpc.QuoteFilter
+	(*QueryQuotesRequest)(nil),              // 27: rfqrpc.QueryQuotesRequest
+	(*RecordedQuote)(nil),                   // 28: rfqrpc.RecordedQuote
+	(*QueryQuotesResponse)(nil),             // 29: rfqrpc.QueryQuotesResponse
+	(*QueryQuoteVolumeRequest)(nil),         // 30: rfqrpc.QueryQuoteVolumeRequest
+	(*QuoteVolume)(nil),                     // 31: rfqrpc.QuoteVolume
+	(*QueryQuoteVolumeResponse)(nil),        // 32: rfqrpc.QueryQuoteVolumeResponse
+	(*QueryInventoryRiskRequest)(nil),       // 33: rfqrpc.QueryInventoryRiskRequest
+	(*AssetInventory)(nil),                  // 34: rfqrpc.AssetInventory
+	(*QueryInventoryRiskResponse)(nil),      // 35: rfqrpc.QueryInventoryRiskResponse
+	(*SubscribeRfqEventNtfnsRequest)(nil),   // 36: rfqrpc.SubscribeRfqEventNtfnsRequest
+	(*PeerAcceptedBuyQuoteEvent)(nil),       // 37: rfqrpc.PeerAcceptedBuyQuoteEvent
+	(*PeerAcceptedSellQuoteEvent)(nil),      // 38: rfqrpc.PeerAcceptedSellQuoteEvent
+	(*AcceptHtlcEvent)(nil),                 // 39: rfqrpc.AcceptHtlcEvent
+	(*RfqEvent)(nil),                        // 40: rfqrpc.RfqEvent
 }
 var file_rfqrpc_rfq_proto_depIdxs = []int32{
-	1,  // 0: rfqrpc.AddAssetBuyOrderRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
-	12, // 1: rfqrpc.AddAssetBuyOrderResponse.accepted_quote:type_name -> rfqrpc.PeerAcceptedBuyQuote
-	14, // 2: rfqrpc.AddAssetBuyOrderResponse.invalid_quote:type_name -> rfqrpc.InvalidQuoteResponse
-	15, // 3: rfqrpc.AddAssetBuyOrderResponse.rejected_quote:type_name -> rfqrpc.RejectedQuoteResponse
-	1,  // 4: rfqrpc.AddAssetSellOrderRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
-	13, // 5: rfqrpc.AddAssetSellOrderResponse.accepted_quote:type_name -> rfqrpc.PeerAcceptedSellQuote
-	14, // 6: rfqrpc.AddAssetSellOrderResponse.invalid_quote:type_name -> rfqrpc.InvalidQuoteResponse
-	15, // 7: rfqrpc.AddAssetSellOrderResponse.rejected_quote:type_name -> rfqrpc.RejectedQuoteResponse
-	1,  // 8: rfqrpc.AddAssetSellOfferRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
-	1,  // 9: rfqrpc.AddAssetBuyOfferRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
-	2,  // 10: rfqrpc.PeerAcceptedBuyQuote.ask_asset_rate:type_name -> rfqrpc.FixedPoint
-	2,  // 11: rfqrpc.PeerAcceptedSellQuote.bid_asset_rate:type_name -> rfqrpc.FixedPoint
+	4,  // 0: rfqrpc.AddAssetBuyOrderRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	15, // 1: rfqrpc.AddAssetBuyOrderResponse.accepted_quote:type_name -> rfqrpc.PeerAcceptedBuyQuote
+	17, // 2: rfqrpc.AddAssetBuyOrderResponse.invalid_quote:type_name -> rfqrpc.InvalidQuoteResponse
+	18, // 3: rfqrpc.AddAssetBuyOrderResponse.rejected_quote:type_name -> rfqrpc.RejectedQuoteResponse
+	4,  // 4: rfqrpc.AddAssetSellOrderRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	16, // 5: rfqrpc.AddAssetSellOrderResponse.accepted_quote:type_name -> rfqrpc.PeerAcceptedSellQuote
+	17, // 6: rfqrpc.AddAssetSellOrderResponse.invalid_quote:type_name -> rfqrpc.InvalidQuoteResponse
+	18, // 7: rfqrpc.AddAssetSellOrderResponse.rejected_quote:type_name -> rfqrpc.RejectedQuoteResponse
+	4,  // 8: rfqrpc.AddAssetSellOfferRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	4,  // 9: rfqrpc.AddAssetBuyOfferRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	5,  // 10: rfqrpc.PeerAcceptedBuyQuote.ask_asset_rate:type_name -> rfqrpc.FixedPoint
+	5,  // 11: rfqrpc.PeerAcceptedSellQuote.bid_asset_rate:type_name -> rfqrpc.FixedPoint
 	0,  // 12: rfqrpc.InvalidQuoteResponse.status:type_name -> rfqrpc.QuoteRespStatus
-	12, // 13: rfqrpc.QueryPeerAcceptedQuotesResponse.buy_quotes:type_name -> rfqrpc.PeerAcceptedBuyQuote
-	13, // 14: rfqrpc.QueryPeerAcceptedQuotesResponse.sell_quotes:type_name -> rfqrpc.PeerAcceptedSellQuote
-	12, // 15: rfqrpc.PeerAcceptedBuyQuoteEvent.peer_accepted_buy_quote:type_name -> rfqrpc.PeerAcceptedBuyQuote
-	13, // 16: rfqrpc.PeerAcceptedSellQuoteEvent.peer_accepted_sell_quote:type_name -> rfqrpc.PeerAcceptedSellQuote
-	18, // 17: rfqrpc.RfqEvent.peer_accepted_buy_quote:type_name -> rfqrpc.PeerAcceptedBuyQuoteEvent
-	19, // 18: rfqrpc.RfqEvent.peer_accepted_sell_quote:type_name -> rfqrpc.PeerAcceptedSellQuoteEvent
-	20, // 19: rfqrpc.RfqEvent.accept_htlc:type_name -> rfqrpc.AcceptHtlcEvent
-	3,  // 20: rfqrpc.Rfq.AddAssetBuyOrder:input_type -> rfqrpc.AddAssetBuyOrderRequest
-	5,  // 21: rfqrpc.Rfq.AddAssetSellOrder:input_type -> rfqrpc.AddAssetSellOrderRequest
-	7,  // 22: rfqrpc.Rfq.AddAssetSellOffer:input_type -> rfqrpc.AddAssetSellOfferRequest
-	9,  // 23: rfqrpc.Rfq.AddAssetBuyOffer:input_type -> rfqrpc.AddAssetBuyOfferRequest
-	11, // 24: rfqrpc.Rfq.QueryPeerAcceptedQuotes:input_type -> rfqrpc.QueryPeerAcceptedQuotesRequest
-	17, // 25: rfqrpc.Rfq.SubscribeRfqEventNtfns:input_type -> rfqrpc.SubscribeRfqEventNtfnsRequest
-	4,  // 26: rfqrpc.Rfq.AddAssetBuyOrder:output_type -> rfqrpc.AddAssetBuyOrderResponse
-	6,  // 27: rfqrpc.Rfq.AddAssetSellOrder:output_type -> rfqrpc.AddAssetSellOrderResponse
-	8,  // 28: rfqrpc.Rfq.AddAssetSellOffer:output_type -> rfqrpc.AddAssetSellOfferResponse
-	10, // 29: rfqrpc.Rfq.AddAssetBuyOffer:output_type -> rfqrpc.AddAssetBuyOfferResponse
-	16, // 30: rfqrpc.Rfq.QueryPeerAcceptedQuotes:output_type -> rfqrpc.QueryPeerAcceptedQuotesResponse
-	21, // 31: rfqrpc.Rfq.SubscribeRfqEventNtfns:output_type -> rfqrpc.RfqEvent
-	26, // [26:32] is the sub-list for method output_type
-	20, // [20:26] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	15, // 13: rfqrpc.QueryPeerAcceptedQuotesResponse.buy_quotes:type_name -> rfqrpc.PeerAcceptedBuyQuote
+	16, // 14: rfqrpc.QueryPeerAcceptedQuotesResponse.sell_quotes:type_name -> rfqrpc.PeerAcceptedSellQuote
+	21, // 15: rfqrpc.QueryPeerReputationsResponse.peers:type_name -> rfqrpc.PeerReputation
+	4,  // 16: rfqrpc.QuerySettlementStatsRequest.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	4,  // 17: rfqrpc.SettlementStats.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	5,  // 18: rfqrpc.SettlementStats.avg_rate:type_name -> rfqrpc.FixedPoint
+	24, // 19: rfqrpc.QuerySettlementStatsResponse.stats:type_name -> rfqrpc.SettlementStats
+	4,  // 20: rfqrpc.QuoteFilter.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	1,  // 21: rfqrpc.QuoteFilter.direction:type_name -> rfqrpc.QuoteDirection
+	2,  // 22: rfqrpc.QuoteFilter.acceptor:type_name -> rfqrpc.QuoteAcceptor
+	3,  // 23: rfqrpc.QuoteFilter.state:type_name -> rfqrpc.QuoteState
+	26, // 24: rfqrpc.QueryQuotesRequest.filter:type_name -> rfqrpc.QuoteFilter
+	1,  // 25: rfqrpc.RecordedQuote.direction:type_name -> rfqrpc.QuoteDirection
+	2,  // 26: rfqrpc.RecordedQuote.acceptor:type_name -> rfqrpc.QuoteAcceptor
+	4,  // 27: rfqrpc.RecordedQuote.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	5,  // 28: rfqrpc.RecordedQuote.rate:type_name -> rfqrpc.FixedPoint
+	3,  // 29: rfqrpc.RecordedQuote.state:type_name -> rfqrpc.QuoteState
+	28, // 30: rfqrpc.QueryQuotesResponse.quotes:type_name -> rfqrpc.RecordedQuote
+	26, // 31: rfqrpc.QueryQuoteVolumeRequest.filter:type_name -> rfqrpc.QuoteFilter
+	4,  // 32: rfqrpc.QuoteVolume.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	5,  // 33: rfqrpc.QuoteVolume.avg_rate:type_name -> rfqrpc.FixedPoint
+	31, // 34: rfqrpc.QueryQuoteVolumeResponse.volumes:type_name -> rfqrpc.QuoteVolume
+	4,  // 35: rfqrpc.AssetInventory.asset_specifier:type_name -> rfqrpc.AssetSpecifier
+	34, // 36: rfqrpc.QueryInventoryRiskResponse.inventory:type_name -> rfqrpc.AssetInventory
+	15, // 37: rfqrpc.PeerAcceptedBuyQuoteEvent.peer_accepted_buy_quote:type_name -> rfqrpc.PeerAcceptedBuyQuote
+	16, // 38: rfqrpc.PeerAcceptedSellQuoteEvent.peer_accepted_sell_quote:type_name -> rfqrpc.PeerAcceptedSellQuote
+	37, // 39: rfqrpc.RfqEvent.peer_accepted_buy_quote:type_name -> rfqrpc.PeerAcceptedBuyQuoteEvent
+	38, // 40: rfqrpc.RfqEvent.peer_accepted_sell_quote:type_name -> rfqrpc.PeerAcceptedSellQuoteEvent
+	39, // 41: rfqrpc.RfqEvent.accept_htlc:type_name -> rfqrpc.AcceptHtlcEvent
+	6,  // 42: rfqrpc.Rfq.AddAssetBuyOrder:input_type -> rfqrpc.AddAssetBuyOrderRequest
+	8,  // 43: rfqrpc.Rfq.AddAssetSellOrder:input_type -> rfqrpc.AddAssetSellOrderRequest
+	10, // 44: rfqrpc.Rfq.AddAssetSellOffer:input_type -> rfqrpc.AddAssetSellOfferRequest
+	12, // 45: rfqrpc.Rfq.AddAssetBuyOffer:input_type -> rfqrpc.AddAssetBuyOfferRequest
+	14, // 46: rfqrpc.Rfq.QueryPeerAcceptedQuotes:input_type -> rfqrpc.QueryPeerAcceptedQuotesRequest
+	20, // 47: rfqrpc.Rfq.QueryPeerReputations:input_type -> rfqrpc.QueryPeerReputationsRequest
+	23, // 48: rfqrpc.Rfq.QuerySettlementStats:input_type -> rfqrpc.QuerySettlementStatsRequest
+	27, // 49: rfqrpc.Rfq.QueryQuotes:input_type -> rfqrpc.QueryQuotesRequest
+	30, // 50: rfqrpc.Rfq.QueryQuoteVolume:input_type -> rfqrpc.QueryQuoteVolumeRequest
+	33, // 51: rfqrpc.Rfq.QueryInventoryRisk:input_type -> rfqrpc.QueryInventoryRiskRequest
+	36, // 52: rfqrpc.Rfq.SubscribeRfqEventNtfns:input_type -> rfqrpc.SubscribeRfqEventNtfnsRequest
+	7,  // 53: rfqrpc.Rfq.AddAssetBuyOrder:output_type -> rfqrpc.AddAssetBuyOrderResponse
+	9,  // 54: rfqrpc.Rfq.AddAssetSellOrder:output_type -> rfqrpc.AddAssetSellOrderResponse
+	11, // 55: rfqrpc.Rfq.AddAssetSellOffer:output_type -> rfqrpc.AddAssetSellOfferResponse
+	13, // 56: rfqrpc.Rfq.AddAssetBuyOffer:output_type -> rfqrpc.AddAssetBuyOfferResponse
+	19, // 57: rfqrpc.Rfq.QueryPeerAcceptedQuotes:output_type -> rfqrpc.QueryPeerAcceptedQuotesResponse
+	22, // 58: rfqrpc.Rfq.QueryPeerReputations:output_type -> rfqrpc.QueryPeerReputationsResponse
+	25, // 59: rfqrpc.Rfq.QuerySettlementStats:output_type -> rfqrpc.QuerySettlementStatsResponse
+	29, // 60: rfqrpc.Rfq.QueryQuotes:output_type -> rfqrpc.QueryQuotesResponse
+	32, // 61: rfqrpc.Rfq.QueryQuoteVolume:output_type -> rfqrpc.QueryQuoteVolumeResponse
+	35, // 62: rfqrpc.Rfq.QueryInventoryRisk:output_type -> rfqrpc.QueryInventoryRiskResponse
+	40, // 63: rfqrpc.Rfq.SubscribeRfqEventNtfns:output_type -> rfqrpc.RfqEvent
+	53, // [53:64] is the sub-list for method output_type
+	42, // [42:53] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_rfqrpc_rfq_proto_init() }
@@ -2128,7 +3840,7 @@ func file_rfqrpc_rfq_proto_init() {
 			}
 		}
 		file_rfqrpc_rfq_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeRfqEventNtfnsRequest); i {
+			switch v := v.(*QueryPeerReputationsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2140,7 +3852,7 @@ func file_rfqrpc_rfq_proto_init() {
 			}
 		}
 		file_rfqrpc_rfq_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerAcceptedBuyQuoteEvent); i {
+			switch v := v.(*PeerReputation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2152,7 +3864,7 @@ func file_rfqrpc_rfq_proto_init() {
 			}
 		}
 		file_rfqrpc_rfq_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerAcceptedSellQuoteEvent); i {
+			switch v := v.(*QueryPeerReputationsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2164,7 +3876,7 @@ func file_rfqrpc_rfq_proto_init() {
 			}
 		}
 		file_rfqrpc_rfq_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AcceptHtlcEvent); i {
+			switch v := v.(*QuerySettlementStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2176,6 +3888,198 @@ func file_rfqrpc_rfq_proto_init() {
 			}
 		}
 		file_rfqrpc_rfq_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SettlementStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuerySettlementStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryQuotesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordedQuote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryQuotesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryQuoteVolumeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteVolume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryQuoteVolumeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryInventoryRiskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetInventory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryInventoryRiskResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRfqEventNtfnsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerAcceptedBuyQuoteEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerAcceptedSellQuoteEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcceptHtlcEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rfqrpc_rfq_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RfqEvent); i {
 			case 0:
 				return &v.state
@@ -2204,7 +4108,7 @@ func file_rfqrpc_rfq_proto_init() {
 		(*AddAssetSellOrderResponse_InvalidQuote)(nil),
 		(*AddAssetSellOrderResponse_RejectedQuote)(nil),
 	}
-	file_rfqrpc_rfq_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_rfqrpc_rfq_proto_msgTypes[36].OneofWrappers = []interface{}{
 		(*RfqEvent_PeerAcceptedBuyQuote)(nil),
 		(*RfqEvent_PeerAcceptedSellQuote)(nil),
 		(*RfqEvent_AcceptHtlc)(nil),
@@ -2214,8 +4118,8 @@ func file_rfqrpc_rfq_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rfqrpc_rfq_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   21,
+			NumEnums:      4,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -61,6 +61,28 @@ type RfqClient interface {
 	// QueryPeerAcceptedQuotes is used to query for quotes that were requested by
 	// our node and have been accepted our peers.
 	QueryPeerAcceptedQuotes(ctx context.Context, in *QueryPeerAcceptedQuotesRequest, opts ...grpc.CallOption) (*QueryPeerAcceptedQuotesResponse, error)
+	// tapcli: `rfq peerreputations`
+	// QueryPeerReputations is used to query the reputation scores and the
+	// underlying metrics of the peers our node requested quotes from.
+	QueryPeerReputations(ctx context.Context, in *QueryPeerReputationsRequest, opts ...grpc.CallOption) (*QueryPeerReputationsResponse, error)
+	// tapcli: `rfq settlementstats`
+	// QuerySettlementStats is used to query the settled asset HTLC volume of
+	// the RFQ order handler, aggregated per asset, peer and day.
+	QuerySettlementStats(ctx context.Context, in *QuerySettlementStatsRequest, opts ...grpc.CallOption) (*QuerySettlementStatsResponse, error)
+	// tapcli: `rfq quotes`
+	// QueryQuotes is used to query the active and historical quotes that were
+	// accepted by our node or by our peers, together with the volume realized
+	// under them.
+	QueryQuotes(ctx context.Context, in *QueryQuotesRequest, opts ...grpc.CallOption) (*QueryQuotesResponse, error)
+	// tapcli: `rfq quotevolume`
+	// QueryQuoteVolume is used to query the volume realized under the quotes
+	// matching a filter, aggregated per asset.
+	QueryQuoteVolume(ctx context.Context, in *QueryQuoteVolumeRequest, opts ...grpc.CallOption) (*QueryQuoteVolumeResponse, error)
+	// tapcli: `rfq inventory`
+	// QueryInventoryRisk is used to query the current exposure of the node per
+	// asset from the open quotes it accepted, the in-flight HTLCs and the asset
+	// channel balances, compared against the configured inventory targets.
+	QueryInventoryRisk(ctx context.Context, in *QueryInventoryRiskRequest, opts ...grpc.CallOption) (*QueryInventoryRiskResponse, error)
 	// SubscribeRfqEventNtfns is used to subscribe to RFQ events.
 	SubscribeRfqEventNtfns(ctx context.Context, in *SubscribeRfqEventNtfnsRequest, opts ...grpc.CallOption) (Rfq_SubscribeRfqEventNtfnsClient, error)
 }
@@ -118,6 +140,51 @@ func (c *rfqClient) QueryPeerAcceptedQuotes(ctx context.Context, in *QueryPeerAc
 	return out, nil
 }
 
+func (c *rfqClient) QueryPeerReputations(ctx context.Context, in *QueryPeerReputationsRequest, opts ...grpc.CallOption) (*QueryPeerReputationsResponse, error) {
+	out := new(QueryPeerReputationsResponse)
+	err := c.cc.Invoke(ctx, "/rfqrpc.Rfq/QueryPeerReputations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rfqClient) QuerySettlementStats(ctx context.Context, in *QuerySettlementStatsRequest, opts ...grpc.CallOption) (*QuerySettlementStatsResponse, error) {
+	out := new(QuerySettlementStatsResponse)
+	err := c.cc.Invoke(ctx, "/rfqrpc.Rfq/QuerySettlementStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rfqClient) QueryQuotes(ctx context.Context, in *QueryQuotesRequest, opts ...grpc.CallOption) (*QueryQuotesResponse, error) {
+	out := new(QueryQuotesResponse)
+	err := c.cc.Invoke(ctx, "/rfqrpc.Rfq/QueryQuotes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rfqClient) QueryQuoteVolume(ctx context.Context, in *QueryQuoteVolumeRequest, opts ...grpc.CallOption) (*QueryQuoteVolumeResponse, error) {
+	out := new(QueryQuoteVolumeResponse)
+	err := c.cc.Invoke(ctx, "/rfqrpc.Rfq/QueryQuoteVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rfqClient) QueryInventoryRisk(ctx context.Context, in *QueryInventoryRiskRequest, opts ...grpc.CallOption) (*QueryInventoryRiskResponse, error) {
+	out := new(QueryInventoryRiskResponse)
+	err := c.cc.Invoke(ctx, "/rfqrpc.Rfq/QueryInventoryRisk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rfqClient) SubscribeRfqEventNtfns(ctx context.Context, in *SubscribeRfqEventNtfnsRequest, opts ...grpc.CallOption) (Rfq_SubscribeRfqEventNtfnsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Rfq_ServiceDesc.Streams[0], "/rfqrpc.Rfq/SubscribeRfqEventNtfns", opts...)
 	if err != nil {
@@ -197,6 +264,28 @@ type RfqServer interface {
 	// QueryPeerAcceptedQuotes is used to query for quotes that were requested by
 	// our node and have been accepted our peers.
 	QueryPeerAcceptedQuotes(context.Context, *QueryPeerAcceptedQuotesRequest) (*QueryPeerAcceptedQuotesResponse, error)
+	// tapcli: `rfq peerreputations`
+	// QueryPeerReputations is used to query the reputation scores and the
+	// underlying metrics of the peers our node requested quotes from.
+	QueryPeerReputations(context.Context, *QueryPeerReputationsRequest) (*QueryPeerReputationsResponse, error)
+	// tapcli: `rfq settlementstats`
+	// QuerySettlementStats is used to query the settled asset HTLC volume of
+	// the RFQ order handler, aggregated per asset, peer and day.
+	QuerySettlementStats(context.Context, *QuerySettlementStatsRequest) (*QuerySettlementStatsResponse, error)
+	// tapcli: `rfq quotes`
+	// QueryQuotes is used to query the active and historical quotes that were
+	// accepted by our node or by our peers, together with the volume realized
+	// under them.
+	QueryQuotes(context.Context, *QueryQuotesRequest) (*QueryQuotesResponse, error)
+	// tapcli: `rfq quotevolume`
+	// QueryQuoteVolume is used to query the volume realized under the quotes
+	// matching a filter, aggregated per asset.
+	QueryQuoteVolume(context.Context, *QueryQuoteVolumeRequest) (*QueryQuoteVolumeResponse, error)
+	// tapcli: `rfq inventory`
+	// QueryInventoryRisk is used to query the current exposure of the node per
+	// asset from the open quotes it accepted, the in-flight HTLCs and the asset
+	// channel balances, compared against the configured inventory targets.
+	QueryInventoryRisk(context.Context, *QueryInventoryRiskRequest) (*QueryInventoryRiskResponse, error)
 	// SubscribeRfqEventNtfns is used to subscribe to RFQ events.
 	SubscribeRfqEventNtfns(*SubscribeRfqEventNtfnsRequest, Rfq_SubscribeRfqEventNtfnsServer) error
 	mustEmbedUnimplementedRfqServer()
@@ -221,6 +310,21 @@ func (UnimplementedRfqServer) AddAssetBuyOffer(context.Context, *AddAssetBuyOffe
 func (UnimplementedRfqServer) QueryPeerAcceptedQuotes(context.Context, *QueryPeerAcceptedQuotesRequest) (*QueryPeerAcceptedQuotesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryPeerAcceptedQuotes not implemented")
 }
+func (UnimplementedRfqServer) QueryPeerReputations(context.Context, *QueryPeerReputationsRequest) (*QueryPeerReputationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryPeerReputations not implemented")
+}
+func (UnimplementedRfqServer) QuerySettlementStats(context.Context, *QuerySettlementStatsRequest) (*QuerySettlementStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuerySettlementStats not implemented")
+}
+func (UnimplementedRfqServer) QueryQuotes(context.Context, *QueryQuotesRequest) (*QueryQuotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryQuotes not implemented")
+}
+func (UnimplementedRfqServer) QueryQuoteVolume(context.Context, *QueryQuoteVolumeRequest) (*QueryQuoteVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryQuoteVolume not implemented")
+}
+func (UnimplementedRfqServer) QueryInventoryRisk(context.Context, *QueryInventoryRiskRequest) (*QueryInventoryRiskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryInventoryRisk not implemented")
+}
 func (UnimplementedRfqServer) SubscribeRfqEventNtfns(*SubscribeRfqEventNtfnsRequest, Rfq_SubscribeRfqEventNtfnsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeRfqEventNtfns not implemented")
 }
@@ -327,6 +431,96 @@ func _Rfq_QueryPeerAcceptedQuotes_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rfq_QueryPeerReputations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPeerReputationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RfqServer).QueryPeerReputations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rfqrpc.Rfq/QueryPeerReputations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RfqServer).QueryPeerReputations(ctx, req.(*QueryPeerReputationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rfq_QuerySettlementStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySettlementStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RfqServer).QuerySettlementStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rfqrpc.Rfq/QuerySettlementStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RfqServer).QuerySettlementStats(ctx, req.(*QuerySettlementStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rfq_QueryQuotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryQuotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RfqServer).QueryQuotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rfqrpc.Rfq/QueryQuotes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RfqServer).QueryQuotes(ctx, req.(*QueryQuotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rfq_QueryQuoteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryQuoteVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RfqServer).QueryQuoteVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rfqrpc.Rfq/QueryQuoteVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RfqServer).QueryQuoteVolume(ctx, req.(*QueryQuoteVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rfq_QueryInventoryRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryInventoryRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RfqServer).QueryInventoryRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rfqrpc.Rfq/QueryInventoryRisk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RfqServer).QueryInventoryRisk(ctx, req.(*QueryInventoryRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rfq_SubscribeRfqEventNtfns_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRfqEventNtfnsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -375,6 +569,26 @@ var Rfq_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryPeerAcceptedQuotes",
 			Handler:    _Rfq_QueryPeerAcceptedQuotes_Handler,
 		},
+		{
+			MethodName: "QueryPeerReputations",
+			Handler:    _Rfq_QueryPeerReputations_Handler,
+		},
+		{
+			MethodName: "QuerySettlementStats",
+			Handler:    _Rfq_QuerySettlementStats_Handler,
+		},
+		{
+			MethodName: "QueryQuotes",
+			Handler:    _Rfq_QueryQuotes_Handler,
+		},
+		{
+			MethodName: "QueryQuoteVolume",
+			Handler:    _Rfq_QueryQuoteVolume_Handler,
+		},
+		{
+			MethodName: "QueryInventoryRisk",
+			Handler:    _Rfq_QueryInventoryRisk_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
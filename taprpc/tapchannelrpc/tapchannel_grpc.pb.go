@@ -34,8 +34,39 @@ type TaprootAssetChannelsClient interface {
 	SendPayment(ctx context.Context, in *SendPaymentRequest, opts ...grpc.CallOption) (TaprootAssetChannels_SendPaymentClient, error)
 	// AddInvoice is a wrapper around lnd's lnrpc.AddInvoice method with asset
 	// specific parameters. It allows RPC users to create invoices that correspond
-	// to the specified asset amount.
+	// to the specified asset amount. A buy quote for the asset amount is
+	// negotiated with the channel peer and the quote's SCID is added to the
+	// invoice as a route hint, so no separate RFQ calls are required.
 	AddInvoice(ctx context.Context, in *AddInvoiceRequest, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// PayAssetInvoice pays a BOLT11 invoice with assets from an asset channel.
+	// A sell quote for the invoice amount is negotiated with a channel peer that
+	// supports the given asset ID, the accepted quote is added to the first hop
+	// custom records of the payment and the payment is dispatched through lnd.
+	// The accepted quote and the payment status updates are streamed back to the
+	// caller. Unlike SendPayment, this RPC doesn't require the caller to construct
+	// a full lnd payment request.
+	PayAssetInvoice(ctx context.Context, in *PayAssetInvoiceRequest, opts ...grpc.CallOption) (TaprootAssetChannels_PayAssetInvoiceClient, error)
+	// ExportAssetChannelBackup exports the asset level state of one or all asset
+	// channels that is needed to sweep the assets of a channel after it was force
+	// closed. This includes the funding output proofs, the latest commitment
+	// state of both parties and the script keys of all asset outputs. The backup
+	// complements lnd's static channel backup, which doesn't contain any asset
+	// channel data. The backup reflects the channel state at the time of the
+	// export, so it should be exported again after the channel was updated.
+	ExportAssetChannelBackup(ctx context.Context, in *ExportAssetChannelBackupRequest, opts ...grpc.CallOption) (*ExportAssetChannelBackupResponse, error)
+	// RestoreAssetChannelBackup restores an asset channel backup that was
+	// previously exported with ExportAssetChannelBackup. The restored state is
+	// used to reconstruct the asset leaves and sweep the assets of the channels
+	// once they are force closed. This should be called before restoring lnd's
+	// static channel backup of the same channels.
+	RestoreAssetChannelBackup(ctx context.Context, in *RestoreAssetChannelBackupRequest, opts ...grpc.CallOption) (*RestoreAssetChannelBackupResponse, error)
+	// QueryAssetPaymentLimits returns the maximum amounts of an asset that can
+	// currently be received through an asset invoice and paid out through the
+	// active asset channels. The limits are derived from the inbound and
+	// outbound asset balances of the channels and the quotes accepted by the
+	// channel peers, so they can be used to cap the amounts of invoices and
+	// payments before creating them.
+	QueryAssetPaymentLimits(ctx context.Context, in *QueryAssetPaymentLimitsRequest, opts ...grpc.CallOption) (*QueryAssetPaymentLimitsResponse, error)
 }
 
 type taprootAssetChannelsClient struct {
@@ -105,6 +136,65 @@ func (c *taprootAssetChannelsClient) AddInvoice(ctx context.Context, in *AddInvo
 	return out, nil
 }
 
+func (c *taprootAssetChannelsClient) PayAssetInvoice(ctx context.Context, in *PayAssetInvoiceRequest, opts ...grpc.CallOption) (TaprootAssetChannels_PayAssetInvoiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssetChannels_ServiceDesc.Streams[1], "/tapchannelrpc.TaprootAssetChannels/PayAssetInvoice", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetChannelsPayAssetInvoiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaprootAssetChannels_PayAssetInvoiceClient interface {
+	Recv() (*SendPaymentResponse, error)
+	grpc.ClientStream
+}
+
+type taprootAssetChannelsPayAssetInvoiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetChannelsPayAssetInvoiceClient) Recv() (*SendPaymentResponse, error) {
+	m := new(SendPaymentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taprootAssetChannelsClient) ExportAssetChannelBackup(ctx context.Context, in *ExportAssetChannelBackupRequest, opts ...grpc.CallOption) (*ExportAssetChannelBackupResponse, error) {
+	out := new(ExportAssetChannelBackupResponse)
+	err := c.cc.Invoke(ctx, "/tapchannelrpc.TaprootAssetChannels/ExportAssetChannelBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetChannelsClient) RestoreAssetChannelBackup(ctx context.Context, in *RestoreAssetChannelBackupRequest, opts ...grpc.CallOption) (*RestoreAssetChannelBackupResponse, error) {
+	out := new(RestoreAssetChannelBackupResponse)
+	err := c.cc.Invoke(ctx, "/tapchannelrpc.TaprootAssetChannels/RestoreAssetChannelBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetChannelsClient) QueryAssetPaymentLimits(ctx context.Context, in *QueryAssetPaymentLimitsRequest, opts ...grpc.CallOption) (*QueryAssetPaymentLimitsResponse, error) {
+	out := new(QueryAssetPaymentLimitsResponse)
+	err := c.cc.Invoke(ctx, "/tapchannelrpc.TaprootAssetChannels/QueryAssetPaymentLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaprootAssetChannelsServer is the server API for TaprootAssetChannels service.
 // All implementations must embed UnimplementedTaprootAssetChannelsServer
 // for forward compatibility
@@ -125,8 +215,39 @@ type TaprootAssetChannelsServer interface {
 	SendPayment(*SendPaymentRequest, TaprootAssetChannels_SendPaymentServer) error
 	// AddInvoice is a wrapper around lnd's lnrpc.AddInvoice method with asset
 	// specific parameters. It allows RPC users to create invoices that correspond
-	// to the specified asset amount.
+	// to the specified asset amount. A buy quote for the asset amount is
+	// negotiated with the channel peer and the quote's SCID is added to the
+	// invoice as a route hint, so no separate RFQ calls are required.
 	AddInvoice(context.Context, *AddInvoiceRequest) (*AddInvoiceResponse, error)
+	// PayAssetInvoice pays a BOLT11 invoice with assets from an asset channel.
+	// A sell quote for the invoice amount is negotiated with a channel peer that
+	// supports the given asset ID, the accepted quote is added to the first hop
+	// custom records of the payment and the payment is dispatched through lnd.
+	// The accepted quote and the payment status updates are streamed back to the
+	// caller. Unlike SendPayment, this RPC doesn't require the caller to construct
+	// a full lnd payment request.
+	PayAssetInvoice(*PayAssetInvoiceRequest, TaprootAssetChannels_PayAssetInvoiceServer) error
+	// ExportAssetChannelBackup exports the asset level state of one or all asset
+	// channels that is needed to sweep the assets of a channel after it was force
+	// closed. This includes the funding output proofs, the latest commitment
+	// state of both parties and the script keys of all asset outputs. The backup
+	// complements lnd's static channel backup, which doesn't contain any asset
+	// channel data. The backup reflects the channel state at the time of the
+	// export, so it should be exported again after the channel was updated.
+	ExportAssetChannelBackup(context.Context, *ExportAssetChannelBackupRequest) (*ExportAssetChannelBackupResponse, error)
+	// RestoreAssetChannelBackup restores an asset channel backup that was
+	// previously exported with ExportAssetChannelBackup. The restored state is
+	// used to reconstruct the asset leaves and sweep the assets of the channels
+	// once they are force closed. This should be called before restoring lnd's
+	// static channel backup of the same channels.
+	RestoreAssetChannelBackup(context.Context, *RestoreAssetChannelBackupRequest) (*RestoreAssetChannelBackupResponse, error)
+	// QueryAssetPaymentLimits returns the maximum amounts of an asset that can
+	// currently be received through an asset invoice and paid out through the
+	// active asset channels. The limits are derived from the inbound and
+	// outbound asset balances of the channels and the quotes accepted by the
+	// channel peers, so they can be used to cap the amounts of invoices and
+	// payments before creating them.
+	QueryAssetPaymentLimits(context.Context, *QueryAssetPaymentLimitsRequest) (*QueryAssetPaymentLimitsResponse, error)
 	mustEmbedUnimplementedTaprootAssetChannelsServer()
 }
 
@@ -146,6 +267,18 @@ func (UnimplementedTaprootAssetChannelsServer) SendPayment(*SendPaymentRequest,
 func (UnimplementedTaprootAssetChannelsServer) AddInvoice(context.Context, *AddInvoiceRequest) (*AddInvoiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddInvoice not implemented")
 }
+func (UnimplementedTaprootAssetChannelsServer) PayAssetInvoice(*PayAssetInvoiceRequest, TaprootAssetChannels_PayAssetInvoiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method PayAssetInvoice not implemented")
+}
+func (UnimplementedTaprootAssetChannelsServer) ExportAssetChannelBackup(context.Context, *ExportAssetChannelBackupRequest) (*ExportAssetChannelBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAssetChannelBackup not implemented")
+}
+func (UnimplementedTaprootAssetChannelsServer) RestoreAssetChannelBackup(context.Context, *RestoreAssetChannelBackupRequest) (*RestoreAssetChannelBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAssetChannelBackup not implemented")
+}
+func (UnimplementedTaprootAssetChannelsServer) QueryAssetPaymentLimits(context.Context, *QueryAssetPaymentLimitsRequest) (*QueryAssetPaymentLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAssetPaymentLimits not implemented")
+}
 func (UnimplementedTaprootAssetChannelsServer) mustEmbedUnimplementedTaprootAssetChannelsServer() {}
 
 // UnsafeTaprootAssetChannelsServer may be embedded to opt out of forward compatibility for this service.
@@ -234,6 +367,81 @@ func _TaprootAssetChannels_AddInvoice_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssetChannels_PayAssetInvoice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PayAssetInvoiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaprootAssetChannelsServer).PayAssetInvoice(m, &taprootAssetChannelsPayAssetInvoiceServer{stream})
+}
+
+type TaprootAssetChannels_PayAssetInvoiceServer interface {
+	Send(*SendPaymentResponse) error
+	grpc.ServerStream
+}
+
+type taprootAssetChannelsPayAssetInvoiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetChannelsPayAssetInvoiceServer) Send(m *SendPaymentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaprootAssetChannels_ExportAssetChannelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAssetChannelBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetChannelsServer).ExportAssetChannelBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapchannelrpc.TaprootAssetChannels/ExportAssetChannelBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetChannelsServer).ExportAssetChannelBackup(ctx, req.(*ExportAssetChannelBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssetChannels_RestoreAssetChannelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAssetChannelBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetChannelsServer).RestoreAssetChannelBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapchannelrpc.TaprootAssetChannels/RestoreAssetChannelBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetChannelsServer).RestoreAssetChannelBackup(ctx, req.(*RestoreAssetChannelBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssetChannels_QueryAssetPaymentLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAssetPaymentLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetChannelsServer).QueryAssetPaymentLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapchannelrpc.TaprootAssetChannels/QueryAssetPaymentLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetChannelsServer).QueryAssetPaymentLimits(ctx, req.(*QueryAssetPaymentLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaprootAssetChannels_ServiceDesc is the grpc.ServiceDesc for TaprootAssetChannels service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -253,6 +461,18 @@ var TaprootAssetChannels_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddInvoice",
 			Handler:    _TaprootAssetChannels_AddInvoice_Handler,
 		},
+		{
+			MethodName: "ExportAssetChannelBackup",
+			Handler:    _TaprootAssetChannels_ExportAssetChannelBackup_Handler,
+		},
+		{
+			MethodName: "RestoreAssetChannelBackup",
+			Handler:    _TaprootAssetChannels_RestoreAssetChannelBackup_Handler,
+		},
+		{
+			MethodName: "QueryAssetPaymentLimits",
+			Handler:    _TaprootAssetChannels_QueryAssetPaymentLimits_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -260,6 +480,11 @@ var TaprootAssetChannels_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TaprootAssetChannels_SendPayment_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PayAssetInvoice",
+			Handler:       _TaprootAssetChannels_PayAssetInvoice_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "tapchannelrpc/tapchannel.proto",
 }
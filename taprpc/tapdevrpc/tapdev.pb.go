@@ -612,6 +612,475 @@ func (*ReceiveAssetEvent_ProofTransferBackoffWaitEvent) isReceiveAssetEvent_Even
 
 func (*ReceiveAssetEvent_AssetReceiveCompleteEvent) isReceiveAssetEvent_Event() {}
 
+type DumpChannelCommitmentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The channel point of the channel to dump the commitments of, in the
+	// form of txid:output_index. If empty, the commitments of all asset
+	// channels are returned.
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint,proto3" json:"chan_point,omitempty"`
+}
+
+func (x *DumpChannelCommitmentsRequest) Reset() {
+	*x = DumpChannelCommitmentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpChannelCommitmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpChannelCommitmentsRequest) ProtoMessage() {}
+
+func (x *DumpChannelCommitmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpChannelCommitmentsRequest.ProtoReflect.Descriptor instead.
+func (*DumpChannelCommitmentsRequest) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DumpChannelCommitmentsRequest) GetChanPoint() string {
+	if x != nil {
+		return x.ChanPoint
+	}
+	return ""
+}
+
+type ChannelAssetOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the asset.
+	AssetId []byte `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// The amount of units of the asset.
+	Amount uint64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// The anchor outpoint of the asset's last transition proof, in the form
+	// of txid:output_index.
+	AnchorOutpoint string `protobuf:"bytes,3,opt,name=anchor_outpoint,json=anchorOutpoint,proto3" json:"anchor_outpoint,omitempty"`
+}
+
+func (x *ChannelAssetOutput) Reset() {
+	*x = ChannelAssetOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelAssetOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelAssetOutput) ProtoMessage() {}
+
+func (x *ChannelAssetOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelAssetOutput.ProtoReflect.Descriptor instead.
+func (*ChannelAssetOutput) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChannelAssetOutput) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *ChannelAssetOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ChannelAssetOutput) GetAnchorOutpoint() string {
+	if x != nil {
+		return x.AnchorOutpoint
+	}
+	return ""
+}
+
+type HtlcAssetAllocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the HTLC within the channel.
+	HtlcIndex uint64 `protobuf:"varint,1,opt,name=htlc_index,json=htlcIndex,proto3" json:"htlc_index,omitempty"`
+	// The asset outputs carried by the HTLC.
+	Assets []*ChannelAssetOutput `protobuf:"bytes,2,rep,name=assets,proto3" json:"assets,omitempty"`
+	// The script of the auxiliary tapscript leaf of the HTLC output, if
+	// known.
+	AuxLeafScript []byte `protobuf:"bytes,3,opt,name=aux_leaf_script,json=auxLeafScript,proto3" json:"aux_leaf_script,omitempty"`
+	// The script of the auxiliary tapscript leaf of the second level HTLC
+	// transaction, if known.
+	SecondLevelAuxLeafScript []byte `protobuf:"bytes,4,opt,name=second_level_aux_leaf_script,json=secondLevelAuxLeafScript,proto3" json:"second_level_aux_leaf_script,omitempty"`
+}
+
+func (x *HtlcAssetAllocation) Reset() {
+	*x = HtlcAssetAllocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HtlcAssetAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HtlcAssetAllocation) ProtoMessage() {}
+
+func (x *HtlcAssetAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HtlcAssetAllocation.ProtoReflect.Descriptor instead.
+func (*HtlcAssetAllocation) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HtlcAssetAllocation) GetHtlcIndex() uint64 {
+	if x != nil {
+		return x.HtlcIndex
+	}
+	return 0
+}
+
+func (x *HtlcAssetAllocation) GetAssets() []*ChannelAssetOutput {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *HtlcAssetAllocation) GetAuxLeafScript() []byte {
+	if x != nil {
+		return x.AuxLeafScript
+	}
+	return nil
+}
+
+func (x *HtlcAssetAllocation) GetSecondLevelAuxLeafScript() []byte {
+	if x != nil {
+		return x.SecondLevelAuxLeafScript
+	}
+	return nil
+}
+
+type CommitmentAllocations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The asset outputs of the local party's balance.
+	LocalAssets []*ChannelAssetOutput `protobuf:"bytes,1,rep,name=local_assets,json=localAssets,proto3" json:"local_assets,omitempty"`
+	// The asset outputs of the remote party's balance.
+	RemoteAssets []*ChannelAssetOutput `protobuf:"bytes,2,rep,name=remote_assets,json=remoteAssets,proto3" json:"remote_assets,omitempty"`
+	// The asset allocations of in-flight outgoing HTLCs.
+	OutgoingHtlcs []*HtlcAssetAllocation `protobuf:"bytes,3,rep,name=outgoing_htlcs,json=outgoingHtlcs,proto3" json:"outgoing_htlcs,omitempty"`
+	// The asset allocations of in-flight incoming HTLCs.
+	IncomingHtlcs []*HtlcAssetAllocation `protobuf:"bytes,4,rep,name=incoming_htlcs,json=incomingHtlcs,proto3" json:"incoming_htlcs,omitempty"`
+	// The script of the auxiliary tapscript leaf of the local balance output,
+	// if any.
+	LocalAuxLeafScript []byte `protobuf:"bytes,5,opt,name=local_aux_leaf_script,json=localAuxLeafScript,proto3" json:"local_aux_leaf_script,omitempty"`
+	// The script of the auxiliary tapscript leaf of the remote balance
+	// output, if any.
+	RemoteAuxLeafScript []byte `protobuf:"bytes,6,opt,name=remote_aux_leaf_script,json=remoteAuxLeafScript,proto3" json:"remote_aux_leaf_script,omitempty"`
+}
+
+func (x *CommitmentAllocations) Reset() {
+	*x = CommitmentAllocations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitmentAllocations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitmentAllocations) ProtoMessage() {}
+
+func (x *CommitmentAllocations) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitmentAllocations.ProtoReflect.Descriptor instead.
+func (*CommitmentAllocations) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CommitmentAllocations) GetLocalAssets() []*ChannelAssetOutput {
+	if x != nil {
+		return x.LocalAssets
+	}
+	return nil
+}
+
+func (x *CommitmentAllocations) GetRemoteAssets() []*ChannelAssetOutput {
+	if x != nil {
+		return x.RemoteAssets
+	}
+	return nil
+}
+
+func (x *CommitmentAllocations) GetOutgoingHtlcs() []*HtlcAssetAllocation {
+	if x != nil {
+		return x.OutgoingHtlcs
+	}
+	return nil
+}
+
+func (x *CommitmentAllocations) GetIncomingHtlcs() []*HtlcAssetAllocation {
+	if x != nil {
+		return x.IncomingHtlcs
+	}
+	return nil
+}
+
+func (x *CommitmentAllocations) GetLocalAuxLeafScript() []byte {
+	if x != nil {
+		return x.LocalAuxLeafScript
+	}
+	return nil
+}
+
+func (x *CommitmentAllocations) GetRemoteAuxLeafScript() []byte {
+	if x != nil {
+		return x.RemoteAuxLeafScript
+	}
+	return nil
+}
+
+type CommitmentChainState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The latest commitment that was signed or received.
+	Current *CommitmentAllocations `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// The commitment that preceded the current one, if known.
+	Previous *CommitmentAllocations `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	// The unix timestamp of when the current commitment was recorded.
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *CommitmentChainState) Reset() {
+	*x = CommitmentChainState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitmentChainState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitmentChainState) ProtoMessage() {}
+
+func (x *CommitmentChainState) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitmentChainState.ProtoReflect.Descriptor instead.
+func (*CommitmentChainState) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CommitmentChainState) GetCurrent() *CommitmentAllocations {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *CommitmentChainState) GetPrevious() *CommitmentAllocations {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *CommitmentChainState) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ChannelCommitments struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The channel point of the channel, in the form of txid:output_index.
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint,proto3" json:"chan_point,omitempty"`
+	// The state of our own commitment chain.
+	LocalCommitment *CommitmentChainState `protobuf:"bytes,2,opt,name=local_commitment,json=localCommitment,proto3" json:"local_commitment,omitempty"`
+	// The state of the remote party's commitment chain.
+	RemoteCommitment *CommitmentChainState `protobuf:"bytes,3,opt,name=remote_commitment,json=remoteCommitment,proto3" json:"remote_commitment,omitempty"`
+}
+
+func (x *ChannelCommitments) Reset() {
+	*x = ChannelCommitments{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelCommitments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelCommitments) ProtoMessage() {}
+
+func (x *ChannelCommitments) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelCommitments.ProtoReflect.Descriptor instead.
+func (*ChannelCommitments) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChannelCommitments) GetChanPoint() string {
+	if x != nil {
+		return x.ChanPoint
+	}
+	return ""
+}
+
+func (x *ChannelCommitments) GetLocalCommitment() *CommitmentChainState {
+	if x != nil {
+		return x.LocalCommitment
+	}
+	return nil
+}
+
+func (x *ChannelCommitments) GetRemoteCommitment() *CommitmentChainState {
+	if x != nil {
+		return x.RemoteCommitment
+	}
+	return nil
+}
+
+type DumpChannelCommitmentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The recorded commitments of the requested channels.
+	Channels []*ChannelCommitments `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *DumpChannelCommitmentsResponse) Reset() {
+	*x = DumpChannelCommitmentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tapdevrpc_tapdev_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpChannelCommitmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpChannelCommitmentsResponse) ProtoMessage() {}
+
+func (x *DumpChannelCommitmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tapdevrpc_tapdev_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpChannelCommitmentsResponse.ProtoReflect.Descriptor instead.
+func (*DumpChannelCommitmentsResponse) Descriptor() ([]byte, []int) {
+	return file_tapdevrpc_tapdev_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DumpChannelCommitmentsResponse) GetChannels() []*ChannelCommitments {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 var File_tapdevrpc_tapdev_proto protoreflect.FileDescriptor
 
 var file_tapdevrpc_tapdev_proto_rawDesc = []byte{
@@ -686,37 +1155,123 @@ var file_tapdevrpc_tapdev_proto_rawDesc = []byte{
 	0x73, 0x73, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
 	0x65, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x19, 0x61, 0x73, 0x73, 0x65,
 	0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x52,
-	0x0a, 0x11, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x54, 0x52, 0x41,
-	0x4e, 0x53, 0x46, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x10,
-	0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53,
-	0x46, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45,
-	0x10, 0x01, 0x32, 0xb9, 0x02, 0x0a, 0x06, 0x54, 0x61, 0x70, 0x44, 0x65, 0x76, 0x12, 0x4c, 0x0a,
-	0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1d, 0x2e, 0x74,
-	0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x61,
-	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6b, 0x0a, 0x1c, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65,
-	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x61,
-	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e,
-	0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x61,
-	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65,
-	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x74, 0x0a, 0x1f, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x73, 0x73, 0x65,
-	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x12, 0x31, 0x2e, 0x74, 0x61,
-	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
-	0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69,
-	0x76, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x3a,
-	0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67,
-	0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72, 0x6f,
-	0x6f, 0x74, 0x2d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72, 0x70, 0x63,
-	0x2f, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x3e,
+	0x0a, 0x1d, 0x44, 0x75, 0x6d, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x70,
+	0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x22, 0xd3, 0x01, 0x0a, 0x13, 0x48, 0x74, 0x6c, 0x63, 0x41, 0x73, 0x73, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x74, 0x6c, 0x63,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x68, 0x74,
+	0x6c, 0x63, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x35, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76,
+	0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x61, 0x75, 0x78, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x75, 0x78, 0x4c, 0x65, 0x61, 0x66,
+	0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x3e, 0x0a, 0x1c, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x61, 0x75, 0x78, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x18, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x41, 0x75, 0x78, 0x4c, 0x65, 0x61, 0x66,
+	0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x22, 0x93, 0x03, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x40, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72,
+	0x70, 0x63, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x73, 0x12, 0x42, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x64,
+	0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69,
+	0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x48, 0x74, 0x6c, 0x63, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
+	0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x48, 0x74, 0x6c, 0x63, 0x73, 0x12, 0x45, 0x0a,
+	0x0e, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x74, 0x6c, 0x63, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70,
+	0x63, 0x2e, 0x48, 0x74, 0x6c, 0x63, 0x41, 0x73, 0x73, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x48,
+	0x74, 0x6c, 0x63, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x75,
+	0x78, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x12, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x75, 0x78, 0x4c, 0x65, 0x61,
+	0x66, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x5f, 0x61, 0x75, 0x78, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41,
+	0x75, 0x78, 0x4c, 0x65, 0x61, 0x66, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x22, 0xaf, 0x01, 0x0a,
+	0x14, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72,
+	0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x3c, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xcd,
+	0x01, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x5f, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x4a, 0x0a, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x4c, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x61,
+	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x10, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x5b,
+	0x0a, 0x1e, 0x44, 0x75, 0x6d, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x2a, 0x52, 0x0a, 0x11, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46,
+	0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x1f,
+	0x0a, 0x1b, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x10, 0x01, 0x32,
+	0xa8, 0x03, 0x0a, 0x06, 0x54, 0x61, 0x70, 0x44, 0x65, 0x76, 0x12, 0x4c, 0x0a, 0x0b, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1d, 0x2e, 0x74, 0x61, 0x70, 0x64,
+	0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65,
+	0x76, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6b, 0x0a, 0x1c, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65,
+	0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x65,
+	0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65,
+	0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x74, 0x0a, 0x1f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x4e, 0x74, 0x66, 0x6e, 0x73, 0x12, 0x31, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65,
+	0x76, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4e,
+	0x74, 0x66, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x61,
+	0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x6d, 0x0a, 0x16, 0x44,
+	0x75, 0x6d, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70,
+	0x63, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x29, 0x2e, 0x74, 0x61, 0x70, 0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x75, 0x6d, 0x70,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x69,
+	0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72, 0x6f, 0x6f, 0x74, 0x2d, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x73, 0x2f, 0x74, 0x61, 0x70, 0x72, 0x70, 0x63, 0x2f, 0x74, 0x61, 0x70,
+	0x64, 0x65, 0x76, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -732,7 +1287,7 @@ func file_tapdevrpc_tapdev_proto_rawDescGZIP() []byte {
 }
 
 var file_tapdevrpc_tapdev_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tapdevrpc_tapdev_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_tapdevrpc_tapdev_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_tapdevrpc_tapdev_proto_goTypes = []interface{}{
 	(ProofTransferType)(0),                         // 0: tapdevrpc.ProofTransferType
 	(*ImportProofRequest)(nil),                     // 1: tapdevrpc.ImportProofRequest
@@ -744,26 +1299,45 @@ var file_tapdevrpc_tapdev_proto_goTypes = []interface{}{
 	(*SubscribeReceiveAssetEventNtfnsRequest)(nil), // 7: tapdevrpc.SubscribeReceiveAssetEventNtfnsRequest
 	(*AssetReceiveCompleteEvent)(nil),              // 8: tapdevrpc.AssetReceiveCompleteEvent
 	(*ReceiveAssetEvent)(nil),                      // 9: tapdevrpc.ReceiveAssetEvent
-	(*taprpc.Addr)(nil),                            // 10: taprpc.Addr
+	(*DumpChannelCommitmentsRequest)(nil),          // 10: tapdevrpc.DumpChannelCommitmentsRequest
+	(*ChannelAssetOutput)(nil),                     // 11: tapdevrpc.ChannelAssetOutput
+	(*HtlcAssetAllocation)(nil),                    // 12: tapdevrpc.HtlcAssetAllocation
+	(*CommitmentAllocations)(nil),                  // 13: tapdevrpc.CommitmentAllocations
+	(*CommitmentChainState)(nil),                   // 14: tapdevrpc.CommitmentChainState
+	(*ChannelCommitments)(nil),                     // 15: tapdevrpc.ChannelCommitments
+	(*DumpChannelCommitmentsResponse)(nil),         // 16: tapdevrpc.DumpChannelCommitmentsResponse
+	(*taprpc.Addr)(nil),                            // 17: taprpc.Addr
 }
 var file_tapdevrpc_tapdev_proto_depIdxs = []int32{
 	5,  // 0: tapdevrpc.SendAssetEvent.execute_send_state_event:type_name -> tapdevrpc.ExecuteSendStateEvent
 	6,  // 1: tapdevrpc.SendAssetEvent.proof_transfer_backoff_wait_event:type_name -> tapdevrpc.ProofTransferBackoffWaitEvent
 	0,  // 2: tapdevrpc.ProofTransferBackoffWaitEvent.transfer_type:type_name -> tapdevrpc.ProofTransferType
-	10, // 3: tapdevrpc.AssetReceiveCompleteEvent.address:type_name -> taprpc.Addr
+	17, // 3: tapdevrpc.AssetReceiveCompleteEvent.address:type_name -> taprpc.Addr
 	6,  // 4: tapdevrpc.ReceiveAssetEvent.proof_transfer_backoff_wait_event:type_name -> tapdevrpc.ProofTransferBackoffWaitEvent
 	8,  // 5: tapdevrpc.ReceiveAssetEvent.asset_receive_complete_event:type_name -> tapdevrpc.AssetReceiveCompleteEvent
-	1,  // 6: tapdevrpc.TapDev.ImportProof:input_type -> tapdevrpc.ImportProofRequest
-	3,  // 7: tapdevrpc.TapDev.SubscribeSendAssetEventNtfns:input_type -> tapdevrpc.SubscribeSendAssetEventNtfnsRequest
-	7,  // 8: tapdevrpc.TapDev.SubscribeReceiveAssetEventNtfns:input_type -> tapdevrpc.SubscribeReceiveAssetEventNtfnsRequest
-	2,  // 9: tapdevrpc.TapDev.ImportProof:output_type -> tapdevrpc.ImportProofResponse
-	4,  // 10: tapdevrpc.TapDev.SubscribeSendAssetEventNtfns:output_type -> tapdevrpc.SendAssetEvent
-	9,  // 11: tapdevrpc.TapDev.SubscribeReceiveAssetEventNtfns:output_type -> tapdevrpc.ReceiveAssetEvent
-	9,  // [9:12] is the sub-list for method output_type
-	6,  // [6:9] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	11, // 6: tapdevrpc.HtlcAssetAllocation.assets:type_name -> tapdevrpc.ChannelAssetOutput
+	11, // 7: tapdevrpc.CommitmentAllocations.local_assets:type_name -> tapdevrpc.ChannelAssetOutput
+	11, // 8: tapdevrpc.CommitmentAllocations.remote_assets:type_name -> tapdevrpc.ChannelAssetOutput
+	12, // 9: tapdevrpc.CommitmentAllocations.outgoing_htlcs:type_name -> tapdevrpc.HtlcAssetAllocation
+	12, // 10: tapdevrpc.CommitmentAllocations.incoming_htlcs:type_name -> tapdevrpc.HtlcAssetAllocation
+	13, // 11: tapdevrpc.CommitmentChainState.current:type_name -> tapdevrpc.CommitmentAllocations
+	13, // 12: tapdevrpc.CommitmentChainState.previous:type_name -> tapdevrpc.CommitmentAllocations
+	14, // 13: tapdevrpc.ChannelCommitments.local_commitment:type_name -> tapdevrpc.CommitmentChainState
+	14, // 14: tapdevrpc.ChannelCommitments.remote_commitment:type_name -> tapdevrpc.CommitmentChainState
+	15, // 15: tapdevrpc.DumpChannelCommitmentsResponse.channels:type_name -> tapdevrpc.ChannelCommitments
+	1,  // 16: tapdevrpc.TapDev.ImportProof:input_type -> tapdevrpc.ImportProofRequest
+	3,  // 17: tapdevrpc.TapDev.SubscribeSendAssetEventNtfns:input_type -> tapdevrpc.SubscribeSendAssetEventNtfnsRequest
+	7,  // 18: tapdevrpc.TapDev.SubscribeReceiveAssetEventNtfns:input_type -> tapdevrpc.SubscribeReceiveAssetEventNtfnsRequest
+	10, // 19: tapdevrpc.TapDev.DumpChannelCommitments:input_type -> tapdevrpc.DumpChannelCommitmentsRequest
+	2,  // 20: tapdevrpc.TapDev.ImportProof:output_type -> tapdevrpc.ImportProofResponse
+	4,  // 21: tapdevrpc.TapDev.SubscribeSendAssetEventNtfns:output_type -> tapdevrpc.SendAssetEvent
+	9,  // 22: tapdevrpc.TapDev.SubscribeReceiveAssetEventNtfns:output_type -> tapdevrpc.ReceiveAssetEvent
+	16, // 23: tapdevrpc.TapDev.DumpChannelCommitments:output_type -> tapdevrpc.DumpChannelCommitmentsResponse
+	20, // [20:24] is the sub-list for method output_type
+	16, // [16:20] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_tapdevrpc_tapdev_proto_init() }
@@ -880,6 +1454,90 @@ func file_tapdevrpc_tapdev_proto_init() {
 				return nil
 			}
 		}
+		file_tapdevrpc_tapdev_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpChannelCommitmentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelAssetOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HtlcAssetAllocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitmentAllocations); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitmentChainState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelCommitments); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tapdevrpc_tapdev_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpChannelCommitmentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_tapdevrpc_tapdev_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*SendAssetEvent_ExecuteSendStateEvent)(nil),
@@ -895,7 +1553,7 @@ func file_tapdevrpc_tapdev_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tapdevrpc_tapdev_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   9,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
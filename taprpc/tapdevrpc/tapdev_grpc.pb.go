@@ -29,6 +29,13 @@ type TapDevClient interface {
 	// SubscribeReceiveAssetEventNtfns registers a subscription to the event
 	// notification stream which relates to the asset receive process.
 	SubscribeReceiveAssetEventNtfns(ctx context.Context, in *SubscribeReceiveAssetEventNtfnsRequest, opts ...grpc.CallOption) (TapDev_SubscribeReceiveAssetEventNtfnsClient, error)
+	// tapcli: `dev dumpcommitments`
+	// DumpChannelCommitments returns the current and previous asset commitment
+	// allocations of both sides of an asset channel, including the asset
+	// balances of in-flight HTLCs and the auxiliary leaves they are committed
+	// to. Only commitments that were signed or received since the daemon was
+	// started are known.
+	DumpChannelCommitments(ctx context.Context, in *DumpChannelCommitmentsRequest, opts ...grpc.CallOption) (*DumpChannelCommitmentsResponse, error)
 }
 
 type tapDevClient struct {
@@ -112,6 +119,15 @@ func (x *tapDevSubscribeReceiveAssetEventNtfnsClient) Recv() (*ReceiveAssetEvent
 	return m, nil
 }
 
+func (c *tapDevClient) DumpChannelCommitments(ctx context.Context, in *DumpChannelCommitmentsRequest, opts ...grpc.CallOption) (*DumpChannelCommitmentsResponse, error) {
+	out := new(DumpChannelCommitmentsResponse)
+	err := c.cc.Invoke(ctx, "/tapdevrpc.TapDev/DumpChannelCommitments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TapDevServer is the server API for TapDev service.
 // All implementations must embed UnimplementedTapDevServer
 // for forward compatibility
@@ -127,6 +143,13 @@ type TapDevServer interface {
 	// SubscribeReceiveAssetEventNtfns registers a subscription to the event
 	// notification stream which relates to the asset receive process.
 	SubscribeReceiveAssetEventNtfns(*SubscribeReceiveAssetEventNtfnsRequest, TapDev_SubscribeReceiveAssetEventNtfnsServer) error
+	// tapcli: `dev dumpcommitments`
+	// DumpChannelCommitments returns the current and previous asset commitment
+	// allocations of both sides of an asset channel, including the asset
+	// balances of in-flight HTLCs and the auxiliary leaves they are committed
+	// to. Only commitments that were signed or received since the daemon was
+	// started are known.
+	DumpChannelCommitments(context.Context, *DumpChannelCommitmentsRequest) (*DumpChannelCommitmentsResponse, error)
 	mustEmbedUnimplementedTapDevServer()
 }
 
@@ -143,6 +166,9 @@ func (UnimplementedTapDevServer) SubscribeSendAssetEventNtfns(*SubscribeSendAsse
 func (UnimplementedTapDevServer) SubscribeReceiveAssetEventNtfns(*SubscribeReceiveAssetEventNtfnsRequest, TapDev_SubscribeReceiveAssetEventNtfnsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeReceiveAssetEventNtfns not implemented")
 }
+func (UnimplementedTapDevServer) DumpChannelCommitments(context.Context, *DumpChannelCommitmentsRequest) (*DumpChannelCommitmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpChannelCommitments not implemented")
+}
 func (UnimplementedTapDevServer) mustEmbedUnimplementedTapDevServer() {}
 
 // UnsafeTapDevServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +242,24 @@ func (x *tapDevSubscribeReceiveAssetEventNtfnsServer) Send(m *ReceiveAssetEvent)
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TapDev_DumpChannelCommitments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpChannelCommitmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapDevServer).DumpChannelCommitments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tapdevrpc.TapDev/DumpChannelCommitments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapDevServer).DumpChannelCommitments(ctx, req.(*DumpChannelCommitmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TapDev_ServiceDesc is the grpc.ServiceDesc for TapDev service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +271,10 @@ var TapDev_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ImportProof",
 			Handler:    _TapDev_ImportProof_Handler,
 		},
+		{
+			MethodName: "DumpChannelCommitments",
+			Handler:    _TapDev_DumpChannelCommitments_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
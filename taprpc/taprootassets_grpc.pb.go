@@ -25,6 +25,11 @@ type TaprootAssetsClient interface {
 	// ListUtxos lists the UTXOs managed by the target daemon, and the assets they
 	// hold.
 	ListUtxos(ctx context.Context, in *ListUtxosRequest, opts ...grpc.CallOption) (*ListUtxosResponse, error)
+	// tapcli: `assets descriptors`
+	// ExportAnchorDescriptors exports output descriptors for all anchor outputs
+	// that hold assets owned by the target daemon, so they can be watched for
+	// unexpected spends by external, descriptor based bitcoin monitoring.
+	ExportAnchorDescriptors(ctx context.Context, in *ExportAnchorDescriptorsRequest, opts ...grpc.CallOption) (*ExportAnchorDescriptorsResponse, error)
 	// tapcli: `assets groups`
 	// ListGroups lists the asset groups known to the target daemon, and the assets
 	// held in each group.
@@ -35,6 +40,12 @@ type TaprootAssetsClient interface {
 	// tapcli: `assets transfers`
 	// ListTransfers lists outbound asset transfers tracked by the target daemon.
 	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	// tapcli: `assets ledger`
+	// ExportLedger exports a time-ordered ledger of all asset movements of the
+	// daemon: mints, sends, receives to addresses and burns. The ledger can be
+	// exported as CSV or JSON and is paginated with a cursor. Optionally, each
+	// entry is valued using the configured price oracle.
+	ExportLedger(ctx context.Context, in *ExportLedgerRequest, opts ...grpc.CallOption) (*ExportLedgerResponse, error)
 	// tapcli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -56,6 +67,13 @@ type TaprootAssetsClient interface {
 	// DecodeAddr decode a Taproot Asset address into a partial asset message that
 	// represents the asset it wants to receive.
 	DecodeAddr(ctx context.Context, in *DecodeAddrRequest, opts ...grpc.CallOption) (*Addr, error)
+	// tapcli: `addrs inspect`
+	// InspectAddr fully decodes a Taproot Asset address, even if it is for a
+	// different network. If the asset of the address is unknown to this node,
+	// its genesis and group information is fetched from the universe servers of
+	// the federation. Problems found with the address are reported as
+	// human-readable warnings.
+	InspectAddr(ctx context.Context, in *InspectAddrRequest, opts ...grpc.CallOption) (*InspectAddrResponse, error)
 	// tapcli: `addrs receives`
 	// List all receives for incoming asset transfers for addresses that were
 	// created previously.
@@ -64,6 +82,12 @@ type TaprootAssetsClient interface {
 	// VerifyProof attempts to verify a given proof file that claims to be anchored
 	// at the specified genesis point.
 	VerifyProof(ctx context.Context, in *ProofFile, opts ...grpc.CallOption) (*VerifyProofResponse, error)
+	// tapcli: `proofs compatibility`
+	// CompatibilityReport returns the versions of the consensus rules (VM
+	// versions) this node knows how to enforce. If a proof file is given, it
+	// also reports the VM versions the proofs of the file declare and whether
+	// this node is able to validate all of them.
+	CompatibilityReport(ctx context.Context, in *CompatibilityReportRequest, opts ...grpc.CallOption) (*CompatibilityReportResponse, error)
 	// tapcli: `proofs decode`
 	// DecodeProof attempts to decode a given proof file into human readable
 	// format.
@@ -72,6 +96,19 @@ type TaprootAssetsClient interface {
 	// ExportProof exports the latest raw proof file anchored at the specified
 	// script_key.
 	ExportProof(ctx context.Context, in *ExportProofRequest, opts ...grpc.CallOption) (*ProofFile, error)
+	// tapcli: `proofs exportall`
+	// ExportAllProofs streams a gzip compressed tarball that contains the proof
+	// files of all unspent assets owned by the wallet, the address book and the
+	// descriptors of the script and internal keys of the assets. The archive can
+	// be imported with ImportProofArchive to migrate the wallet to another tapd
+	// instance or database backend that uses the same lnd wallet.
+	ExportAllProofs(ctx context.Context, in *ExportAllProofsRequest, opts ...grpc.CallOption) (TaprootAssets_ExportAllProofsClient, error)
+	// tapcli: `proofs importarchive`
+	// ImportProofArchive imports an archive created by ExportAllProofs. The key
+	// descriptors are imported first, followed by the proofs, which are verified
+	// against the chain, and the addresses. Proofs and addresses that are
+	// already known are skipped, so an import can be repeated.
+	ImportProofArchive(ctx context.Context, opts ...grpc.CallOption) (TaprootAssets_ImportProofArchiveClient, error)
 	// tapcli: `assets send`
 	// SendAsset uses one or multiple passed Taproot Asset address(es) to attempt
 	// to complete an asset send. The method returns information w.r.t the on chain
@@ -97,6 +134,16 @@ type TaprootAssetsClient interface {
 	// FetchAssetMeta allows a caller to fetch the reveal meta data for an asset
 	// either by the asset ID for that asset, or a meta hash.
 	FetchAssetMeta(ctx context.Context, in *FetchAssetMetaRequest, opts ...grpc.CallOption) (*AssetMeta, error)
+	// tapcli: `assets metablob upload`
+	// UploadMetaBlob streams a metadata blob that is too large to be committed
+	// to directly into the content-addressed meta blob store. The returned asset
+	// meta only references the blob and can be used to mint an asset that
+	// commits to the blob.
+	UploadMetaBlob(ctx context.Context, opts ...grpc.CallOption) (TaprootAssets_UploadMetaBlobClient, error)
+	// tapcli: `assets metablob fetch`
+	// FetchMetaBlob streams a metadata blob from the content-addressed meta blob
+	// store. The blob is verified against its reference while it is streamed.
+	FetchMetaBlob(ctx context.Context, in *FetchMetaBlobRequest, opts ...grpc.CallOption) (TaprootAssets_FetchMetaBlobClient, error)
 	// tapcli: `events receive`
 	// SubscribeReceiveEvents allows a caller to subscribe to receive events for
 	// incoming asset transfers.
@@ -105,6 +152,57 @@ type TaprootAssetsClient interface {
 	// SubscribeSendEvents allows a caller to subscribe to send events for outgoing
 	// asset transfers.
 	SubscribeSendEvents(ctx context.Context, in *SubscribeSendEventsRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeSendEventsClient, error)
+	// tapcli: `jobs list`
+	// ListJobs lists the long-running jobs tracked by the daemon (for example
+	// full Universe federation syncs), including their state and progress.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// tapcli: `jobs cancel`
+	// CancelJob cancels a running job.
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error)
+	// tapcli: `jobs watch`
+	// SubscribeJobUpdates allows a caller to subscribe to the state and progress
+	// updates of jobs. If a specific job is watched, the stream ends once that
+	// job has finished.
+	SubscribeJobUpdates(ctx context.Context, in *SubscribeJobUpdatesRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeJobUpdatesClient, error)
+	// tapcli: `rpcjournal export`
+	// ExportRpcJournal exports the entries of the RPC journal, which records
+	// all mutating RPC calls if the journal is enabled.
+	ExportRpcJournal(ctx context.Context, in *ExportRpcJournalRequest, opts ...grpc.CallOption) (*ExportRpcJournalResponse, error)
+	// SubscribeReplicationChanges streams the asset, address and transfer state
+	// changes recorded by a replication leader to a follower. All changes after
+	// the given sequence number are sent first, followed by new changes as they
+	// are recorded. The node must be configured as a replication leader.
+	SubscribeReplicationChanges(ctx context.Context, in *SubscribeReplicationChangesRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeReplicationChangesClient, error)
+	// tapcli: `compact`
+	// Compact prunes old data from the database according to the retention
+	// policy: the proofs of spent assets, the proofs of delivered transfer
+	// outputs together with the proof courier log, and universe events. The
+	// configured retention age of each category can be overridden for a single
+	// call. The amount of pruned data and reclaimed space is returned.
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
+	// tapcli: `unlock`
+	// UnlockDatabase unlocks a database whose sensitive fields are encrypted
+	// with a key derived from a passphrase. Until the database is unlocked, this
+	// is the only call the daemon accepts, and it doesn't require a macaroon, as
+	// the macaroon root keys are encrypted as well. On the first unlock, all
+	// existing sensitive fields are encrypted with the given passphrase.
+	UnlockDatabase(ctx context.Context, in *UnlockDatabaseRequest, opts ...grpc.CallOption) (*UnlockDatabaseResponse, error)
+	// tapcli: `assets recover`
+	// RecoverAssets starts a background job that recovers the assets of a wallet
+	// whose lnd node was restored from its seed. The script keys of the Taproot
+	// Assets key family are derived up to the gap limit after the last used key,
+	// and the given universe is scanned for proofs paying them. The proofs of
+	// all unspent assets found are imported together with their keys. The
+	// progress of the recovery can be followed with the job RPCs.
+	RecoverAssets(ctx context.Context, in *RecoverAssetsRequest, opts ...grpc.CallOption) (*RecoverAssetsResponse, error)
+	// tapcli: `assets consolidate`
+	// ConsolidateAssets merges many small asset UTXOs of the same asset or asset
+	// group into as few outputs as possible, by sending them to the wallet itself
+	// in a single anchor transaction. The smallest UTXOs are merged first, until
+	// either the maximum number of inputs or the maximum proof file size of a
+	// merged output is reached. With dry_run set, only the size and fee of the
+	// consolidation are estimated.
+	ConsolidateAssets(ctx context.Context, in *ConsolidateAssetsRequest, opts ...grpc.CallOption) (*ConsolidateAssetsResponse, error)
 }
 
 type taprootAssetsClient struct {
@@ -133,6 +231,15 @@ func (c *taprootAssetsClient) ListUtxos(ctx context.Context, in *ListUtxosReques
 	return out, nil
 }
 
+func (c *taprootAssetsClient) ExportAnchorDescriptors(ctx context.Context, in *ExportAnchorDescriptorsRequest, opts ...grpc.CallOption) (*ExportAnchorDescriptorsResponse, error) {
+	out := new(ExportAnchorDescriptorsResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ExportAnchorDescriptors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taprootAssetsClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
 	out := new(ListGroupsResponse)
 	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ListGroups", in, out, opts...)
@@ -160,6 +267,15 @@ func (c *taprootAssetsClient) ListTransfers(ctx context.Context, in *ListTransfe
 	return out, nil
 }
 
+func (c *taprootAssetsClient) ExportLedger(ctx context.Context, in *ExportLedgerRequest, opts ...grpc.CallOption) (*ExportLedgerResponse, error) {
+	out := new(ExportLedgerResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ExportLedger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taprootAssetsClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
 	out := new(StopResponse)
 	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/StopDaemon", in, out, opts...)
@@ -205,6 +321,15 @@ func (c *taprootAssetsClient) DecodeAddr(ctx context.Context, in *DecodeAddrRequ
 	return out, nil
 }
 
+func (c *taprootAssetsClient) InspectAddr(ctx context.Context, in *InspectAddrRequest, opts ...grpc.CallOption) (*InspectAddrResponse, error) {
+	out := new(InspectAddrResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/InspectAddr", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taprootAssetsClient) AddrReceives(ctx context.Context, in *AddrReceivesRequest, opts ...grpc.CallOption) (*AddrReceivesResponse, error) {
 	out := new(AddrReceivesResponse)
 	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/AddrReceives", in, out, opts...)
@@ -223,6 +348,15 @@ func (c *taprootAssetsClient) VerifyProof(ctx context.Context, in *ProofFile, op
 	return out, nil
 }
 
+func (c *taprootAssetsClient) CompatibilityReport(ctx context.Context, in *CompatibilityReportRequest, opts ...grpc.CallOption) (*CompatibilityReportResponse, error) {
+	out := new(CompatibilityReportResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/CompatibilityReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taprootAssetsClient) DecodeProof(ctx context.Context, in *DecodeProofRequest, opts ...grpc.CallOption) (*DecodeProofResponse, error) {
 	out := new(DecodeProofResponse)
 	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/DecodeProof", in, out, opts...)
@@ -241,6 +375,72 @@ func (c *taprootAssetsClient) ExportProof(ctx context.Context, in *ExportProofRe
 	return out, nil
 }
 
+func (c *taprootAssetsClient) ExportAllProofs(ctx context.Context, in *ExportAllProofsRequest, opts ...grpc.CallOption) (TaprootAssets_ExportAllProofsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[0], "/taprpc.TaprootAssets/ExportAllProofs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsExportAllProofsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaprootAssets_ExportAllProofsClient interface {
+	Recv() (*ProofArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsExportAllProofsClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsExportAllProofsClient) Recv() (*ProofArchiveChunk, error) {
+	m := new(ProofArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taprootAssetsClient) ImportProofArchive(ctx context.Context, opts ...grpc.CallOption) (TaprootAssets_ImportProofArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[1], "/taprpc.TaprootAssets/ImportProofArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsImportProofArchiveClient{stream}
+	return x, nil
+}
+
+type TaprootAssets_ImportProofArchiveClient interface {
+	Send(*ProofArchiveChunk) error
+	CloseAndRecv() (*ImportProofArchiveResponse, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsImportProofArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsImportProofArchiveClient) Send(m *ProofArchiveChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taprootAssetsImportProofArchiveClient) CloseAndRecv() (*ImportProofArchiveResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportProofArchiveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *taprootAssetsClient) SendAsset(ctx context.Context, in *SendAssetRequest, opts ...grpc.CallOption) (*SendAssetResponse, error) {
 	out := new(SendAssetResponse)
 	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/SendAsset", in, out, opts...)
@@ -286,8 +486,74 @@ func (c *taprootAssetsClient) FetchAssetMeta(ctx context.Context, in *FetchAsset
 	return out, nil
 }
 
+func (c *taprootAssetsClient) UploadMetaBlob(ctx context.Context, opts ...grpc.CallOption) (TaprootAssets_UploadMetaBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[2], "/taprpc.TaprootAssets/UploadMetaBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsUploadMetaBlobClient{stream}
+	return x, nil
+}
+
+type TaprootAssets_UploadMetaBlobClient interface {
+	Send(*UploadMetaBlobRequest) error
+	CloseAndRecv() (*UploadMetaBlobResponse, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsUploadMetaBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsUploadMetaBlobClient) Send(m *UploadMetaBlobRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taprootAssetsUploadMetaBlobClient) CloseAndRecv() (*UploadMetaBlobResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadMetaBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taprootAssetsClient) FetchMetaBlob(ctx context.Context, in *FetchMetaBlobRequest, opts ...grpc.CallOption) (TaprootAssets_FetchMetaBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[3], "/taprpc.TaprootAssets/FetchMetaBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsFetchMetaBlobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaprootAssets_FetchMetaBlobClient interface {
+	Recv() (*MetaBlobChunk, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsFetchMetaBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsFetchMetaBlobClient) Recv() (*MetaBlobChunk, error) {
+	m := new(MetaBlobChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *taprootAssetsClient) SubscribeReceiveEvents(ctx context.Context, in *SubscribeReceiveEventsRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeReceiveEventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[0], "/taprpc.TaprootAssets/SubscribeReceiveEvents", opts...)
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[4], "/taprpc.TaprootAssets/SubscribeReceiveEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +585,7 @@ func (x *taprootAssetsSubscribeReceiveEventsClient) Recv() (*ReceiveEvent, error
 }
 
 func (c *taprootAssetsClient) SubscribeSendEvents(ctx context.Context, in *SubscribeSendEventsRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeSendEventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[1], "/taprpc.TaprootAssets/SubscribeSendEvents", opts...)
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[5], "/taprpc.TaprootAssets/SubscribeSendEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -350,6 +616,133 @@ func (x *taprootAssetsSubscribeSendEventsClient) Recv() (*SendEvent, error) {
 	return m, nil
 }
 
+func (c *taprootAssetsClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error) {
+	out := new(CancelJobResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) SubscribeJobUpdates(ctx context.Context, in *SubscribeJobUpdatesRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeJobUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[6], "/taprpc.TaprootAssets/SubscribeJobUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsSubscribeJobUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaprootAssets_SubscribeJobUpdatesClient interface {
+	Recv() (*Job, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsSubscribeJobUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsSubscribeJobUpdatesClient) Recv() (*Job, error) {
+	m := new(Job)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taprootAssetsClient) ExportRpcJournal(ctx context.Context, in *ExportRpcJournalRequest, opts ...grpc.CallOption) (*ExportRpcJournalResponse, error) {
+	out := new(ExportRpcJournalResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ExportRpcJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) SubscribeReplicationChanges(ctx context.Context, in *SubscribeReplicationChangesRequest, opts ...grpc.CallOption) (TaprootAssets_SubscribeReplicationChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaprootAssets_ServiceDesc.Streams[7], "/taprpc.TaprootAssets/SubscribeReplicationChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taprootAssetsSubscribeReplicationChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaprootAssets_SubscribeReplicationChangesClient interface {
+	Recv() (*ReplicationChange, error)
+	grpc.ClientStream
+}
+
+type taprootAssetsSubscribeReplicationChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *taprootAssetsSubscribeReplicationChangesClient) Recv() (*ReplicationChange, error) {
+	m := new(ReplicationChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taprootAssetsClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/Compact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) UnlockDatabase(ctx context.Context, in *UnlockDatabaseRequest, opts ...grpc.CallOption) (*UnlockDatabaseResponse, error) {
+	out := new(UnlockDatabaseResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/UnlockDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) RecoverAssets(ctx context.Context, in *RecoverAssetsRequest, opts ...grpc.CallOption) (*RecoverAssetsResponse, error) {
+	out := new(RecoverAssetsResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/RecoverAssets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taprootAssetsClient) ConsolidateAssets(ctx context.Context, in *ConsolidateAssetsRequest, opts ...grpc.CallOption) (*ConsolidateAssetsResponse, error) {
+	out := new(ConsolidateAssetsResponse)
+	err := c.cc.Invoke(ctx, "/taprpc.TaprootAssets/ConsolidateAssets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaprootAssetsServer is the server API for TaprootAssets service.
 // All implementations must embed UnimplementedTaprootAssetsServer
 // for forward compatibility
@@ -361,6 +754,11 @@ type TaprootAssetsServer interface {
 	// ListUtxos lists the UTXOs managed by the target daemon, and the assets they
 	// hold.
 	ListUtxos(context.Context, *ListUtxosRequest) (*ListUtxosResponse, error)
+	// tapcli: `assets descriptors`
+	// ExportAnchorDescriptors exports output descriptors for all anchor outputs
+	// that hold assets owned by the target daemon, so they can be watched for
+	// unexpected spends by external, descriptor based bitcoin monitoring.
+	ExportAnchorDescriptors(context.Context, *ExportAnchorDescriptorsRequest) (*ExportAnchorDescriptorsResponse, error)
 	// tapcli: `assets groups`
 	// ListGroups lists the asset groups known to the target daemon, and the assets
 	// held in each group.
@@ -371,6 +769,12 @@ type TaprootAssetsServer interface {
 	// tapcli: `assets transfers`
 	// ListTransfers lists outbound asset transfers tracked by the target daemon.
 	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	// tapcli: `assets ledger`
+	// ExportLedger exports a time-ordered ledger of all asset movements of the
+	// daemon: mints, sends, receives to addresses and burns. The ledger can be
+	// exported as CSV or JSON and is paginated with a cursor. Optionally, each
+	// entry is valued using the configured price oracle.
+	ExportLedger(context.Context, *ExportLedgerRequest) (*ExportLedgerResponse, error)
 	// tapcli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -392,6 +796,13 @@ type TaprootAssetsServer interface {
 	// DecodeAddr decode a Taproot Asset address into a partial asset message that
 	// represents the asset it wants to receive.
 	DecodeAddr(context.Context, *DecodeAddrRequest) (*Addr, error)
+	// tapcli: `addrs inspect`
+	// InspectAddr fully decodes a Taproot Asset address, even if it is for a
+	// different network. If the asset of the address is unknown to this node,
+	// its genesis and group information is fetched from the universe servers of
+	// the federation. Problems found with the address are reported as
+	// human-readable warnings.
+	InspectAddr(context.Context, *InspectAddrRequest) (*InspectAddrResponse, error)
 	// tapcli: `addrs receives`
 	// List all receives for incoming asset transfers for addresses that were
 	// created previously.
@@ -400,6 +811,12 @@ type TaprootAssetsServer interface {
 	// VerifyProof attempts to verify a given proof file that claims to be anchored
 	// at the specified genesis point.
 	VerifyProof(context.Context, *ProofFile) (*VerifyProofResponse, error)
+	// tapcli: `proofs compatibility`
+	// CompatibilityReport returns the versions of the consensus rules (VM
+	// versions) this node knows how to enforce. If a proof file is given, it
+	// also reports the VM versions the proofs of the file declare and whether
+	// this node is able to validate all of them.
+	CompatibilityReport(context.Context, *CompatibilityReportRequest) (*CompatibilityReportResponse, error)
 	// tapcli: `proofs decode`
 	// DecodeProof attempts to decode a given proof file into human readable
 	// format.
@@ -408,6 +825,19 @@ type TaprootAssetsServer interface {
 	// ExportProof exports the latest raw proof file anchored at the specified
 	// script_key.
 	ExportProof(context.Context, *ExportProofRequest) (*ProofFile, error)
+	// tapcli: `proofs exportall`
+	// ExportAllProofs streams a gzip compressed tarball that contains the proof
+	// files of all unspent assets owned by the wallet, the address book and the
+	// descriptors of the script and internal keys of the assets. The archive can
+	// be imported with ImportProofArchive to migrate the wallet to another tapd
+	// instance or database backend that uses the same lnd wallet.
+	ExportAllProofs(*ExportAllProofsRequest, TaprootAssets_ExportAllProofsServer) error
+	// tapcli: `proofs importarchive`
+	// ImportProofArchive imports an archive created by ExportAllProofs. The key
+	// descriptors are imported first, followed by the proofs, which are verified
+	// against the chain, and the addresses. Proofs and addresses that are
+	// already known are skipped, so an import can be repeated.
+	ImportProofArchive(TaprootAssets_ImportProofArchiveServer) error
 	// tapcli: `assets send`
 	// SendAsset uses one or multiple passed Taproot Asset address(es) to attempt
 	// to complete an asset send. The method returns information w.r.t the on chain
@@ -433,6 +863,16 @@ type TaprootAssetsServer interface {
 	// FetchAssetMeta allows a caller to fetch the reveal meta data for an asset
 	// either by the asset ID for that asset, or a meta hash.
 	FetchAssetMeta(context.Context, *FetchAssetMetaRequest) (*AssetMeta, error)
+	// tapcli: `assets metablob upload`
+	// UploadMetaBlob streams a metadata blob that is too large to be committed
+	// to directly into the content-addressed meta blob store. The returned asset
+	// meta only references the blob and can be used to mint an asset that
+	// commits to the blob.
+	UploadMetaBlob(TaprootAssets_UploadMetaBlobServer) error
+	// tapcli: `assets metablob fetch`
+	// FetchMetaBlob streams a metadata blob from the content-addressed meta blob
+	// store. The blob is verified against its reference while it is streamed.
+	FetchMetaBlob(*FetchMetaBlobRequest, TaprootAssets_FetchMetaBlobServer) error
 	// tapcli: `events receive`
 	// SubscribeReceiveEvents allows a caller to subscribe to receive events for
 	// incoming asset transfers.
@@ -441,6 +881,57 @@ type TaprootAssetsServer interface {
 	// SubscribeSendEvents allows a caller to subscribe to send events for outgoing
 	// asset transfers.
 	SubscribeSendEvents(*SubscribeSendEventsRequest, TaprootAssets_SubscribeSendEventsServer) error
+	// tapcli: `jobs list`
+	// ListJobs lists the long-running jobs tracked by the daemon (for example
+	// full Universe federation syncs), including their state and progress.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// tapcli: `jobs cancel`
+	// CancelJob cancels a running job.
+	CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error)
+	// tapcli: `jobs watch`
+	// SubscribeJobUpdates allows a caller to subscribe to the state and progress
+	// updates of jobs. If a specific job is watched, the stream ends once that
+	// job has finished.
+	SubscribeJobUpdates(*SubscribeJobUpdatesRequest, TaprootAssets_SubscribeJobUpdatesServer) error
+	// tapcli: `rpcjournal export`
+	// ExportRpcJournal exports the entries of the RPC journal, which records
+	// all mutating RPC calls if the journal is enabled.
+	ExportRpcJournal(context.Context, *ExportRpcJournalRequest) (*ExportRpcJournalResponse, error)
+	// SubscribeReplicationChanges streams the asset, address and transfer state
+	// changes recorded by a replication leader to a follower. All changes after
+	// the given sequence number are sent first, followed by new changes as they
+	// are recorded. The node must be configured as a replication leader.
+	SubscribeReplicationChanges(*SubscribeReplicationChangesRequest, TaprootAssets_SubscribeReplicationChangesServer) error
+	// tapcli: `compact`
+	// Compact prunes old data from the database according to the retention
+	// policy: the proofs of spent assets, the proofs of delivered transfer
+	// outputs together with the proof courier log, and universe events. The
+	// configured retention age of each category can be overridden for a single
+	// call. The amount of pruned data and reclaimed space is returned.
+	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
+	// tapcli: `unlock`
+	// UnlockDatabase unlocks a database whose sensitive fields are encrypted
+	// with a key derived from a passphrase. Until the database is unlocked, this
+	// is the only call the daemon accepts, and it doesn't require a macaroon, as
+	// the macaroon root keys are encrypted as well. On the first unlock, all
+	// existing sensitive fields are encrypted with the given passphrase.
+	UnlockDatabase(context.Context, *UnlockDatabaseRequest) (*UnlockDatabaseResponse, error)
+	// tapcli: `assets recover`
+	// RecoverAssets starts a background job that recovers the assets of a wallet
+	// whose lnd node was restored from its seed. The script keys of the Taproot
+	// Assets key family are derived up to the gap limit after the last used key,
+	// and the given universe is scanned for proofs paying them. The proofs of
+	// all unspent assets found are imported together with their keys. The
+	// progress of the recovery can be followed with the job RPCs.
+	RecoverAssets(context.Context, *RecoverAssetsRequest) (*RecoverAssetsResponse, error)
+	// tapcli: `assets consolidate`
+	// ConsolidateAssets merges many small asset UTXOs of the same asset or asset
+	// group into as few outputs as possible, by sending them to the wallet itself
+	// in a single anchor transaction. The smallest UTXOs are merged first, until
+	// either the maximum number of inputs or the maximum proof file size of a
+	// merged output is reached. With dry_run set, only the size and fee of the
+	// consolidation are estimated.
+	ConsolidateAssets(context.Context, *ConsolidateAssetsRequest) (*ConsolidateAssetsResponse, error)
 	mustEmbedUnimplementedTaprootAssetsServer()
 }
 
@@ -454,6 +945,9 @@ func (UnimplementedTaprootAssetsServer) ListAssets(context.Context, *ListAssetRe
 func (UnimplementedTaprootAssetsServer) ListUtxos(context.Context, *ListUtxosRequest) (*ListUtxosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUtxos not implemented")
 }
+func (UnimplementedTaprootAssetsServer) ExportAnchorDescriptors(context.Context, *ExportAnchorDescriptorsRequest) (*ExportAnchorDescriptorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAnchorDescriptors not implemented")
+}
 func (UnimplementedTaprootAssetsServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
 }
@@ -463,6 +957,9 @@ func (UnimplementedTaprootAssetsServer) ListBalances(context.Context, *ListBalan
 func (UnimplementedTaprootAssetsServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
 }
+func (UnimplementedTaprootAssetsServer) ExportLedger(context.Context, *ExportLedgerRequest) (*ExportLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportLedger not implemented")
+}
 func (UnimplementedTaprootAssetsServer) StopDaemon(context.Context, *StopRequest) (*StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopDaemon not implemented")
 }
@@ -478,18 +975,30 @@ func (UnimplementedTaprootAssetsServer) NewAddr(context.Context, *NewAddrRequest
 func (UnimplementedTaprootAssetsServer) DecodeAddr(context.Context, *DecodeAddrRequest) (*Addr, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DecodeAddr not implemented")
 }
+func (UnimplementedTaprootAssetsServer) InspectAddr(context.Context, *InspectAddrRequest) (*InspectAddrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectAddr not implemented")
+}
 func (UnimplementedTaprootAssetsServer) AddrReceives(context.Context, *AddrReceivesRequest) (*AddrReceivesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddrReceives not implemented")
 }
 func (UnimplementedTaprootAssetsServer) VerifyProof(context.Context, *ProofFile) (*VerifyProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyProof not implemented")
 }
+func (UnimplementedTaprootAssetsServer) CompatibilityReport(context.Context, *CompatibilityReportRequest) (*CompatibilityReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompatibilityReport not implemented")
+}
 func (UnimplementedTaprootAssetsServer) DecodeProof(context.Context, *DecodeProofRequest) (*DecodeProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DecodeProof not implemented")
 }
 func (UnimplementedTaprootAssetsServer) ExportProof(context.Context, *ExportProofRequest) (*ProofFile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportProof not implemented")
 }
+func (UnimplementedTaprootAssetsServer) ExportAllProofs(*ExportAllProofsRequest, TaprootAssets_ExportAllProofsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAllProofs not implemented")
+}
+func (UnimplementedTaprootAssetsServer) ImportProofArchive(TaprootAssets_ImportProofArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProofArchive not implemented")
+}
 func (UnimplementedTaprootAssetsServer) SendAsset(context.Context, *SendAssetRequest) (*SendAssetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendAsset not implemented")
 }
@@ -505,12 +1014,45 @@ func (UnimplementedTaprootAssetsServer) GetInfo(context.Context, *GetInfoRequest
 func (UnimplementedTaprootAssetsServer) FetchAssetMeta(context.Context, *FetchAssetMetaRequest) (*AssetMeta, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchAssetMeta not implemented")
 }
+func (UnimplementedTaprootAssetsServer) UploadMetaBlob(TaprootAssets_UploadMetaBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMetaBlob not implemented")
+}
+func (UnimplementedTaprootAssetsServer) FetchMetaBlob(*FetchMetaBlobRequest, TaprootAssets_FetchMetaBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method FetchMetaBlob not implemented")
+}
 func (UnimplementedTaprootAssetsServer) SubscribeReceiveEvents(*SubscribeReceiveEventsRequest, TaprootAssets_SubscribeReceiveEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeReceiveEvents not implemented")
 }
 func (UnimplementedTaprootAssetsServer) SubscribeSendEvents(*SubscribeSendEventsRequest, TaprootAssets_SubscribeSendEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeSendEvents not implemented")
 }
+func (UnimplementedTaprootAssetsServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedTaprootAssetsServer) CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedTaprootAssetsServer) SubscribeJobUpdates(*SubscribeJobUpdatesRequest, TaprootAssets_SubscribeJobUpdatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeJobUpdates not implemented")
+}
+func (UnimplementedTaprootAssetsServer) ExportRpcJournal(context.Context, *ExportRpcJournalRequest) (*ExportRpcJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRpcJournal not implemented")
+}
+func (UnimplementedTaprootAssetsServer) SubscribeReplicationChanges(*SubscribeReplicationChangesRequest, TaprootAssets_SubscribeReplicationChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeReplicationChanges not implemented")
+}
+func (UnimplementedTaprootAssetsServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (UnimplementedTaprootAssetsServer) UnlockDatabase(context.Context, *UnlockDatabaseRequest) (*UnlockDatabaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockDatabase not implemented")
+}
+func (UnimplementedTaprootAssetsServer) RecoverAssets(context.Context, *RecoverAssetsRequest) (*RecoverAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverAssets not implemented")
+}
+func (UnimplementedTaprootAssetsServer) ConsolidateAssets(context.Context, *ConsolidateAssetsRequest) (*ConsolidateAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsolidateAssets not implemented")
+}
 func (UnimplementedTaprootAssetsServer) mustEmbedUnimplementedTaprootAssetsServer() {}
 
 // UnsafeTaprootAssetsServer may be embedded to opt out of forward compatibility for this service.
@@ -560,6 +1102,24 @@ func _TaprootAssets_ListUtxos_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_ExportAnchorDescriptors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAnchorDescriptorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).ExportAnchorDescriptors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/ExportAnchorDescriptors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).ExportAnchorDescriptors(ctx, req.(*ExportAnchorDescriptorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaprootAssets_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListGroupsRequest)
 	if err := dec(in); err != nil {
@@ -614,6 +1174,24 @@ func _TaprootAssets_ListTransfers_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_ExportLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).ExportLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/ExportLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).ExportLedger(ctx, req.(*ExportLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaprootAssets_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StopRequest)
 	if err := dec(in); err != nil {
@@ -704,6 +1282,24 @@ func _TaprootAssets_DecodeAddr_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_InspectAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectAddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).InspectAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/InspectAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).InspectAddr(ctx, req.(*InspectAddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaprootAssets_AddrReceives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddrReceivesRequest)
 	if err := dec(in); err != nil {
@@ -740,6 +1336,24 @@ func _TaprootAssets_VerifyProof_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_CompatibilityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompatibilityReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).CompatibilityReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/CompatibilityReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).CompatibilityReport(ctx, req.(*CompatibilityReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaprootAssets_DecodeProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DecodeProofRequest)
 	if err := dec(in); err != nil {
@@ -776,6 +1390,53 @@ func _TaprootAssets_ExportProof_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_ExportAllProofs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAllProofsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaprootAssetsServer).ExportAllProofs(m, &taprootAssetsExportAllProofsServer{stream})
+}
+
+type TaprootAssets_ExportAllProofsServer interface {
+	Send(*ProofArchiveChunk) error
+	grpc.ServerStream
+}
+
+type taprootAssetsExportAllProofsServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsExportAllProofsServer) Send(m *ProofArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaprootAssets_ImportProofArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaprootAssetsServer).ImportProofArchive(&taprootAssetsImportProofArchiveServer{stream})
+}
+
+type TaprootAssets_ImportProofArchiveServer interface {
+	SendAndClose(*ImportProofArchiveResponse) error
+	Recv() (*ProofArchiveChunk, error)
+	grpc.ServerStream
+}
+
+type taprootAssetsImportProofArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsImportProofArchiveServer) SendAndClose(m *ImportProofArchiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taprootAssetsImportProofArchiveServer) Recv() (*ProofArchiveChunk, error) {
+	m := new(ProofArchiveChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _TaprootAssets_SendAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendAssetRequest)
 	if err := dec(in); err != nil {
@@ -866,6 +1527,53 @@ func _TaprootAssets_FetchAssetMeta_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaprootAssets_UploadMetaBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaprootAssetsServer).UploadMetaBlob(&taprootAssetsUploadMetaBlobServer{stream})
+}
+
+type TaprootAssets_UploadMetaBlobServer interface {
+	SendAndClose(*UploadMetaBlobResponse) error
+	Recv() (*UploadMetaBlobRequest, error)
+	grpc.ServerStream
+}
+
+type taprootAssetsUploadMetaBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsUploadMetaBlobServer) SendAndClose(m *UploadMetaBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taprootAssetsUploadMetaBlobServer) Recv() (*UploadMetaBlobRequest, error) {
+	m := new(UploadMetaBlobRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TaprootAssets_FetchMetaBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchMetaBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaprootAssetsServer).FetchMetaBlob(m, &taprootAssetsFetchMetaBlobServer{stream})
+}
+
+type TaprootAssets_FetchMetaBlobServer interface {
+	Send(*MetaBlobChunk) error
+	grpc.ServerStream
+}
+
+type taprootAssetsFetchMetaBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsFetchMetaBlobServer) Send(m *MetaBlobChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _TaprootAssets_SubscribeReceiveEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeReceiveEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -908,6 +1616,174 @@ func (x *taprootAssetsSubscribeSendEventsServer) Send(m *SendEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TaprootAssets_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/CancelJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_SubscribeJobUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeJobUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaprootAssetsServer).SubscribeJobUpdates(m, &taprootAssetsSubscribeJobUpdatesServer{stream})
+}
+
+type TaprootAssets_SubscribeJobUpdatesServer interface {
+	Send(*Job) error
+	grpc.ServerStream
+}
+
+type taprootAssetsSubscribeJobUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsSubscribeJobUpdatesServer) Send(m *Job) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaprootAssets_ExportRpcJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRpcJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).ExportRpcJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/ExportRpcJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).ExportRpcJournal(ctx, req.(*ExportRpcJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_SubscribeReplicationChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeReplicationChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaprootAssetsServer).SubscribeReplicationChanges(m, &taprootAssetsSubscribeReplicationChangesServer{stream})
+}
+
+type TaprootAssets_SubscribeReplicationChangesServer interface {
+	Send(*ReplicationChange) error
+	grpc.ServerStream
+}
+
+type taprootAssetsSubscribeReplicationChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *taprootAssetsSubscribeReplicationChangesServer) Send(m *ReplicationChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaprootAssets_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/Compact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_UnlockDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).UnlockDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/UnlockDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).UnlockDatabase(ctx, req.(*UnlockDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_RecoverAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).RecoverAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/RecoverAssets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).RecoverAssets(ctx, req.(*RecoverAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaprootAssets_ConsolidateAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsolidateAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaprootAssetsServer).ConsolidateAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/taprpc.TaprootAssets/ConsolidateAssets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaprootAssetsServer).ConsolidateAssets(ctx, req.(*ConsolidateAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaprootAssets_ServiceDesc is the grpc.ServiceDesc for TaprootAssets service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -923,6 +1799,10 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUtxos",
 			Handler:    _TaprootAssets_ListUtxos_Handler,
 		},
+		{
+			MethodName: "ExportAnchorDescriptors",
+			Handler:    _TaprootAssets_ExportAnchorDescriptors_Handler,
+		},
 		{
 			MethodName: "ListGroups",
 			Handler:    _TaprootAssets_ListGroups_Handler,
@@ -935,6 +1815,10 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransfers",
 			Handler:    _TaprootAssets_ListTransfers_Handler,
 		},
+		{
+			MethodName: "ExportLedger",
+			Handler:    _TaprootAssets_ExportLedger_Handler,
+		},
 		{
 			MethodName: "StopDaemon",
 			Handler:    _TaprootAssets_StopDaemon_Handler,
@@ -955,6 +1839,10 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DecodeAddr",
 			Handler:    _TaprootAssets_DecodeAddr_Handler,
 		},
+		{
+			MethodName: "InspectAddr",
+			Handler:    _TaprootAssets_InspectAddr_Handler,
+		},
 		{
 			MethodName: "AddrReceives",
 			Handler:    _TaprootAssets_AddrReceives_Handler,
@@ -963,6 +1851,10 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyProof",
 			Handler:    _TaprootAssets_VerifyProof_Handler,
 		},
+		{
+			MethodName: "CompatibilityReport",
+			Handler:    _TaprootAssets_CompatibilityReport_Handler,
+		},
 		{
 			MethodName: "DecodeProof",
 			Handler:    _TaprootAssets_DecodeProof_Handler,
@@ -991,8 +1883,56 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FetchAssetMeta",
 			Handler:    _TaprootAssets_FetchAssetMeta_Handler,
 		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _TaprootAssets_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _TaprootAssets_CancelJob_Handler,
+		},
+		{
+			MethodName: "ExportRpcJournal",
+			Handler:    _TaprootAssets_ExportRpcJournal_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _TaprootAssets_Compact_Handler,
+		},
+		{
+			MethodName: "UnlockDatabase",
+			Handler:    _TaprootAssets_UnlockDatabase_Handler,
+		},
+		{
+			MethodName: "RecoverAssets",
+			Handler:    _TaprootAssets_RecoverAssets_Handler,
+		},
+		{
+			MethodName: "ConsolidateAssets",
+			Handler:    _TaprootAssets_ConsolidateAssets_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAllProofs",
+			Handler:       _TaprootAssets_ExportAllProofs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportProofArchive",
+			Handler:       _TaprootAssets_ImportProofArchive_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadMetaBlob",
+			Handler:       _TaprootAssets_UploadMetaBlob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FetchMetaBlob",
+			Handler:       _TaprootAssets_FetchMetaBlob_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeReceiveEvents",
 			Handler:       _TaprootAssets_SubscribeReceiveEvents_Handler,
@@ -1003,6 +1943,16 @@ var TaprootAssets_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TaprootAssets_SubscribeSendEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeJobUpdates",
+			Handler:       _TaprootAssets_SubscribeJobUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeReplicationChanges",
+			Handler:       _TaprootAssets_SubscribeReplicationChanges_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "taprootassets.proto",
 }
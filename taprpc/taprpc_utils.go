@@ -1,6 +1,8 @@
 package taprpc
 
 import (
+	"regexp"
+
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -41,4 +43,16 @@ var (
 		AllowPartial:   false,
 		UseHexForBytes: true,
 	}
+
+	// ClientStreamingURIs is a list of the REST URIs of all RPCs that use a
+	// request-streaming interface. The WebSocket proxy needs to know them
+	// to forward each message received over the WebSocket as a separate
+	// request, as this can't be inferred from the REST proxy at runtime.
+	ClientStreamingURIs = []*regexp.Regexp{
+		regexp.MustCompile("^/v1/taproot-assets/assets/meta/blob$"),
+		regexp.MustCompile("^/v1/taproot-assets/universe/meta/blob$"),
+		regexp.MustCompile(
+			"^/v1/taproot-assets/proofs/import/archive$",
+		),
+	}
 )
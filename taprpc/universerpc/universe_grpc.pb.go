@@ -43,6 +43,14 @@ type UniverseClient interface {
 	// the asset within the Taproot Asset commitment for the given asset_id or
 	// group_key.
 	AssetLeafKeys(ctx context.Context, in *AssetLeafKeysRequest, opts ...grpc.CallOption) (*AssetLeafKeyResponse, error)
+	// tapcli: `universe subtree`
+	// SubtreeNodes queries for the inner nodes of the Universe MS-SMT tree for a
+	// given asset_id or group_key. For each of the given paths, the nodes that
+	// are found the given number of levels below the node at that path are
+	// returned. Comparing these nodes with the nodes of another Universe allows
+	// to find the leaves the two Universes differ in, without fetching all the
+	// leaf keys.
+	SubtreeNodes(ctx context.Context, in *SubtreeNodesRequest, opts ...grpc.CallOption) (*SubtreeNodesResponse, error)
 	// tapcli: `universe leaves`
 	// AssetLeaves queries for the set of asset leaves (the values in the Universe
 	// MS-SMT tree) for a given asset_id or group_key. These represents either
@@ -69,6 +77,14 @@ type UniverseClient interface {
 	// UniverseKey. If found, a connection is made to a remote Universe server to
 	// attempt to upload the asset leaf.
 	PushProof(ctx context.Context, in *PushProofRequest, opts ...grpc.CallOption) (*PushProofResponse, error)
+	// PushMetaBlob uploads the metadata blob referenced by the asset meta of an
+	// issued asset. The first message must set the hash and size of the blob.
+	// Blobs are only accepted if an asset that commits to them is known to the
+	// Universe server.
+	PushMetaBlob(ctx context.Context, opts ...grpc.CallOption) (Universe_PushMetaBlobClient, error)
+	// PullMetaBlob downloads the metadata blob referenced by the asset meta of an
+	// issued asset.
+	PullMetaBlob(ctx context.Context, in *PullMetaBlobRequest, opts ...grpc.CallOption) (Universe_PullMetaBlobClient, error)
 	// tapcli: `universe info`
 	// Info returns a set of information about the current state of the Universe.
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
@@ -79,6 +95,12 @@ type UniverseClient interface {
 	// the latest known root for each asset, performing tree based reconciliation
 	// to arrive at a new shared root.
 	SyncUniverse(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	// tapcli: `universe federation sync`
+	// SyncFederation starts a background job that fully syncs the local Universe
+	// with all servers of the Universe federation. The returned job ID can be
+	// used to follow the progress of the sync or to cancel it with the job RPCs
+	// of the main service.
+	SyncFederation(ctx context.Context, in *SyncFederationRequest, opts ...grpc.CallOption) (*SyncFederationResponse, error)
 	// tapcli: `universe federation list`
 	// ListFederationServers lists the set of servers that make up the federation
 	// of the local Universe server. This servers are used to push out new proofs,
@@ -93,6 +115,12 @@ type UniverseClient interface {
 	// DeleteFederationServer removes a server from the federation of the local
 	// Universe server.
 	DeleteFederationServer(ctx context.Context, in *DeleteFederationServerRequest, opts ...grpc.CallOption) (*DeleteFederationServerResponse, error)
+	// tapcli: `universe federation scope`
+	// SetFederationServerScope restricts the sync with a server of the federation
+	// of the local Universe server to the universes of a set of assets. Only
+	// proofs of these assets are synced from and pushed to the server. An empty
+	// scope removes any restriction.
+	SetFederationServerScope(ctx context.Context, in *SetFederationServerScopeRequest, opts ...grpc.CallOption) (*SetFederationServerScopeResponse, error)
 	// tapcli: `universe stats`
 	// UniverseStats returns a set of aggregate statistics for the current state
 	// of the Universe. Stats returned include: total number of syncs, total
@@ -108,6 +136,19 @@ type UniverseClient interface {
 	// QueryEvents returns the number of sync and proof events for a given time
 	// period, grouped by day.
 	QueryEvents(ctx context.Context, in *QueryEventsRequest, opts ...grpc.CallOption) (*QueryEventsResponse, error)
+	// tapcli: `universe stats daily`
+	// QueryDailyStats returns the number of new assets and ingested proofs of
+	// the local Universe for a given time period, grouped by day. The stats are
+	// pre-aggregated, so this is cheap to call periodically.
+	QueryDailyStats(ctx context.Context, in *QueryDailyStatsRequest, opts ...grpc.CallOption) (*QueryDailyStatsResponse, error)
+	// tapcli: `universe stats servers`
+	// QueryServerSyncStats returns the sync volume with each server of the
+	// federation of the local Universe for a given time period, grouped by day.
+	QueryServerSyncStats(ctx context.Context, in *QueryServerSyncStatsRequest, opts ...grpc.CallOption) (*QueryServerSyncStatsResponse, error)
+	// tapcli: `universe stats top`
+	// QueryTopTransferUniverses returns the transfer universes with the most
+	// transfer proofs in the local Universe, most transfers first.
+	QueryTopTransferUniverses(ctx context.Context, in *QueryTopTransferUniversesRequest, opts ...grpc.CallOption) (*QueryTopTransferUniversesResponse, error)
 	// SetFederationSyncConfig sets the configuration of the universe federation
 	// sync.
 	SetFederationSyncConfig(ctx context.Context, in *SetFederationSyncConfigRequest, opts ...grpc.CallOption) (*SetFederationSyncConfigResponse, error)
@@ -115,6 +156,27 @@ type UniverseClient interface {
 	// QueryFederationSyncConfig queries the universe federation sync configuration
 	// settings.
 	QueryFederationSyncConfig(ctx context.Context, in *QueryFederationSyncConfigRequest, opts ...grpc.CallOption) (*QueryFederationSyncConfigResponse, error)
+	// tapcli: `universe metaupdates publish`
+	// PublishMetaUpdate creates a new metadata update for an asset group issued
+	// by this node. The update is signed with the raw group key held by the
+	// node's wallet and stored in the local universe, from where it is served to
+	// clients.
+	PublishMetaUpdate(ctx context.Context, in *PublishMetaUpdateRequest, opts ...grpc.CallOption) (*PublishMetaUpdateResponse, error)
+	// InsertMetaUpdate inserts a metadata update that was signed by the issuer of
+	// an asset group into the local universe. The signature is verified against
+	// the known group key, and only updates with a version higher than the
+	// latest known version are accepted.
+	InsertMetaUpdate(ctx context.Context, in *InsertMetaUpdateRequest, opts ...grpc.CallOption) (*InsertMetaUpdateResponse, error)
+	// tapcli: `universe metaupdates query`
+	// QueryMetaUpdates returns the signed metadata updates known for an asset
+	// group, ordered from the latest to the oldest version.
+	QueryMetaUpdates(ctx context.Context, in *QueryMetaUpdatesRequest, opts ...grpc.CallOption) (*QueryMetaUpdatesResponse, error)
+	// tapcli: `universe metaupdates sync`
+	// SyncMetaUpdates fetches the metadata updates of an asset group from a
+	// remote universe server. Each update is verified against the locally known
+	// group key, and the valid ones that are newer than the latest locally known
+	// update are stored.
+	SyncMetaUpdates(ctx context.Context, in *SyncMetaUpdatesRequest, opts ...grpc.CallOption) (*SyncMetaUpdatesResponse, error)
 }
 
 type universeClient struct {
@@ -170,6 +232,15 @@ func (c *universeClient) AssetLeafKeys(ctx context.Context, in *AssetLeafKeysReq
 	return out, nil
 }
 
+func (c *universeClient) SubtreeNodes(ctx context.Context, in *SubtreeNodesRequest, opts ...grpc.CallOption) (*SubtreeNodesResponse, error) {
+	out := new(SubtreeNodesResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/SubtreeNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *universeClient) AssetLeaves(ctx context.Context, in *ID, opts ...grpc.CallOption) (*AssetLeafResponse, error) {
 	out := new(AssetLeafResponse)
 	err := c.cc.Invoke(ctx, "/universerpc.Universe/AssetLeaves", in, out, opts...)
@@ -206,6 +277,72 @@ func (c *universeClient) PushProof(ctx context.Context, in *PushProofRequest, op
 	return out, nil
 }
 
+func (c *universeClient) PushMetaBlob(ctx context.Context, opts ...grpc.CallOption) (Universe_PushMetaBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Universe_ServiceDesc.Streams[0], "/universerpc.Universe/PushMetaBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &universePushMetaBlobClient{stream}
+	return x, nil
+}
+
+type Universe_PushMetaBlobClient interface {
+	Send(*PushMetaBlobRequest) error
+	CloseAndRecv() (*PushMetaBlobResponse, error)
+	grpc.ClientStream
+}
+
+type universePushMetaBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *universePushMetaBlobClient) Send(m *PushMetaBlobRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *universePushMetaBlobClient) CloseAndRecv() (*PushMetaBlobResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PushMetaBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *universeClient) PullMetaBlob(ctx context.Context, in *PullMetaBlobRequest, opts ...grpc.CallOption) (Universe_PullMetaBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Universe_ServiceDesc.Streams[1], "/universerpc.Universe/PullMetaBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &universePullMetaBlobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Universe_PullMetaBlobClient interface {
+	Recv() (*PullMetaBlobChunk, error)
+	grpc.ClientStream
+}
+
+type universePullMetaBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *universePullMetaBlobClient) Recv() (*PullMetaBlobChunk, error) {
+	m := new(PullMetaBlobChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *universeClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
 	out := new(InfoResponse)
 	err := c.cc.Invoke(ctx, "/universerpc.Universe/Info", in, out, opts...)
@@ -224,6 +361,15 @@ func (c *universeClient) SyncUniverse(ctx context.Context, in *SyncRequest, opts
 	return out, nil
 }
 
+func (c *universeClient) SyncFederation(ctx context.Context, in *SyncFederationRequest, opts ...grpc.CallOption) (*SyncFederationResponse, error) {
+	out := new(SyncFederationResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/SyncFederation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *universeClient) ListFederationServers(ctx context.Context, in *ListFederationServersRequest, opts ...grpc.CallOption) (*ListFederationServersResponse, error) {
 	out := new(ListFederationServersResponse)
 	err := c.cc.Invoke(ctx, "/universerpc.Universe/ListFederationServers", in, out, opts...)
@@ -251,6 +397,15 @@ func (c *universeClient) DeleteFederationServer(ctx context.Context, in *DeleteF
 	return out, nil
 }
 
+func (c *universeClient) SetFederationServerScope(ctx context.Context, in *SetFederationServerScopeRequest, opts ...grpc.CallOption) (*SetFederationServerScopeResponse, error) {
+	out := new(SetFederationServerScopeResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/SetFederationServerScope", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *universeClient) UniverseStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	out := new(StatsResponse)
 	err := c.cc.Invoke(ctx, "/universerpc.Universe/UniverseStats", in, out, opts...)
@@ -278,6 +433,33 @@ func (c *universeClient) QueryEvents(ctx context.Context, in *QueryEventsRequest
 	return out, nil
 }
 
+func (c *universeClient) QueryDailyStats(ctx context.Context, in *QueryDailyStatsRequest, opts ...grpc.CallOption) (*QueryDailyStatsResponse, error) {
+	out := new(QueryDailyStatsResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/QueryDailyStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeClient) QueryServerSyncStats(ctx context.Context, in *QueryServerSyncStatsRequest, opts ...grpc.CallOption) (*QueryServerSyncStatsResponse, error) {
+	out := new(QueryServerSyncStatsResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/QueryServerSyncStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeClient) QueryTopTransferUniverses(ctx context.Context, in *QueryTopTransferUniversesRequest, opts ...grpc.CallOption) (*QueryTopTransferUniversesResponse, error) {
+	out := new(QueryTopTransferUniversesResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/QueryTopTransferUniverses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *universeClient) SetFederationSyncConfig(ctx context.Context, in *SetFederationSyncConfigRequest, opts ...grpc.CallOption) (*SetFederationSyncConfigResponse, error) {
 	out := new(SetFederationSyncConfigResponse)
 	err := c.cc.Invoke(ctx, "/universerpc.Universe/SetFederationSyncConfig", in, out, opts...)
@@ -296,6 +478,42 @@ func (c *universeClient) QueryFederationSyncConfig(ctx context.Context, in *Quer
 	return out, nil
 }
 
+func (c *universeClient) PublishMetaUpdate(ctx context.Context, in *PublishMetaUpdateRequest, opts ...grpc.CallOption) (*PublishMetaUpdateResponse, error) {
+	out := new(PublishMetaUpdateResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/PublishMetaUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeClient) InsertMetaUpdate(ctx context.Context, in *InsertMetaUpdateRequest, opts ...grpc.CallOption) (*InsertMetaUpdateResponse, error) {
+	out := new(InsertMetaUpdateResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/InsertMetaUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeClient) QueryMetaUpdates(ctx context.Context, in *QueryMetaUpdatesRequest, opts ...grpc.CallOption) (*QueryMetaUpdatesResponse, error) {
+	out := new(QueryMetaUpdatesResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/QueryMetaUpdates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universeClient) SyncMetaUpdates(ctx context.Context, in *SyncMetaUpdatesRequest, opts ...grpc.CallOption) (*SyncMetaUpdatesResponse, error) {
+	out := new(SyncMetaUpdatesResponse)
+	err := c.cc.Invoke(ctx, "/universerpc.Universe/SyncMetaUpdates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UniverseServer is the server API for Universe service.
 // All implementations must embed UnimplementedUniverseServer
 // for forward compatibility
@@ -325,6 +543,14 @@ type UniverseServer interface {
 	// the asset within the Taproot Asset commitment for the given asset_id or
 	// group_key.
 	AssetLeafKeys(context.Context, *AssetLeafKeysRequest) (*AssetLeafKeyResponse, error)
+	// tapcli: `universe subtree`
+	// SubtreeNodes queries for the inner nodes of the Universe MS-SMT tree for a
+	// given asset_id or group_key. For each of the given paths, the nodes that
+	// are found the given number of levels below the node at that path are
+	// returned. Comparing these nodes with the nodes of another Universe allows
+	// to find the leaves the two Universes differ in, without fetching all the
+	// leaf keys.
+	SubtreeNodes(context.Context, *SubtreeNodesRequest) (*SubtreeNodesResponse, error)
 	// tapcli: `universe leaves`
 	// AssetLeaves queries for the set of asset leaves (the values in the Universe
 	// MS-SMT tree) for a given asset_id or group_key. These represents either
@@ -351,6 +577,14 @@ type UniverseServer interface {
 	// UniverseKey. If found, a connection is made to a remote Universe server to
 	// attempt to upload the asset leaf.
 	PushProof(context.Context, *PushProofRequest) (*PushProofResponse, error)
+	// PushMetaBlob uploads the metadata blob referenced by the asset meta of an
+	// issued asset. The first message must set the hash and size of the blob.
+	// Blobs are only accepted if an asset that commits to them is known to the
+	// Universe server.
+	PushMetaBlob(Universe_PushMetaBlobServer) error
+	// PullMetaBlob downloads the metadata blob referenced by the asset meta of an
+	// issued asset.
+	PullMetaBlob(*PullMetaBlobRequest, Universe_PullMetaBlobServer) error
 	// tapcli: `universe info`
 	// Info returns a set of information about the current state of the Universe.
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
@@ -361,6 +595,12 @@ type UniverseServer interface {
 	// the latest known root for each asset, performing tree based reconciliation
 	// to arrive at a new shared root.
 	SyncUniverse(context.Context, *SyncRequest) (*SyncResponse, error)
+	// tapcli: `universe federation sync`
+	// SyncFederation starts a background job that fully syncs the local Universe
+	// with all servers of the Universe federation. The returned job ID can be
+	// used to follow the progress of the sync or to cancel it with the job RPCs
+	// of the main service.
+	SyncFederation(context.Context, *SyncFederationRequest) (*SyncFederationResponse, error)
 	// tapcli: `universe federation list`
 	// ListFederationServers lists the set of servers that make up the federation
 	// of the local Universe server. This servers are used to push out new proofs,
@@ -375,6 +615,12 @@ type UniverseServer interface {
 	// DeleteFederationServer removes a server from the federation of the local
 	// Universe server.
 	DeleteFederationServer(context.Context, *DeleteFederationServerRequest) (*DeleteFederationServerResponse, error)
+	// tapcli: `universe federation scope`
+	// SetFederationServerScope restricts the sync with a server of the federation
+	// of the local Universe server to the universes of a set of assets. Only
+	// proofs of these assets are synced from and pushed to the server. An empty
+	// scope removes any restriction.
+	SetFederationServerScope(context.Context, *SetFederationServerScopeRequest) (*SetFederationServerScopeResponse, error)
 	// tapcli: `universe stats`
 	// UniverseStats returns a set of aggregate statistics for the current state
 	// of the Universe. Stats returned include: total number of syncs, total
@@ -390,6 +636,19 @@ type UniverseServer interface {
 	// QueryEvents returns the number of sync and proof events for a given time
 	// period, grouped by day.
 	QueryEvents(context.Context, *QueryEventsRequest) (*QueryEventsResponse, error)
+	// tapcli: `universe stats daily`
+	// QueryDailyStats returns the number of new assets and ingested proofs of
+	// the local Universe for a given time period, grouped by day. The stats are
+	// pre-aggregated, so this is cheap to call periodically.
+	QueryDailyStats(context.Context, *QueryDailyStatsRequest) (*QueryDailyStatsResponse, error)
+	// tapcli: `universe stats servers`
+	// QueryServerSyncStats returns the sync volume with each server of the
+	// federation of the local Universe for a given time period, grouped by day.
+	QueryServerSyncStats(context.Context, *QueryServerSyncStatsRequest) (*QueryServerSyncStatsResponse, error)
+	// tapcli: `universe stats top`
+	// QueryTopTransferUniverses returns the transfer universes with the most
+	// transfer proofs in the local Universe, most transfers first.
+	QueryTopTransferUniverses(context.Context, *QueryTopTransferUniversesRequest) (*QueryTopTransferUniversesResponse, error)
 	// SetFederationSyncConfig sets the configuration of the universe federation
 	// sync.
 	SetFederationSyncConfig(context.Context, *SetFederationSyncConfigRequest) (*SetFederationSyncConfigResponse, error)
@@ -397,6 +656,27 @@ type UniverseServer interface {
 	// QueryFederationSyncConfig queries the universe federation sync configuration
 	// settings.
 	QueryFederationSyncConfig(context.Context, *QueryFederationSyncConfigRequest) (*QueryFederationSyncConfigResponse, error)
+	// tapcli: `universe metaupdates publish`
+	// PublishMetaUpdate creates a new metadata update for an asset group issued
+	// by this node. The update is signed with the raw group key held by the
+	// node's wallet and stored in the local universe, from where it is served to
+	// clients.
+	PublishMetaUpdate(context.Context, *PublishMetaUpdateRequest) (*PublishMetaUpdateResponse, error)
+	// InsertMetaUpdate inserts a metadata update that was signed by the issuer of
+	// an asset group into the local universe. The signature is verified against
+	// the known group key, and only updates with a version higher than the
+	// latest known version are accepted.
+	InsertMetaUpdate(context.Context, *InsertMetaUpdateRequest) (*InsertMetaUpdateResponse, error)
+	// tapcli: `universe metaupdates query`
+	// QueryMetaUpdates returns the signed metadata updates known for an asset
+	// group, ordered from the latest to the oldest version.
+	QueryMetaUpdates(context.Context, *QueryMetaUpdatesRequest) (*QueryMetaUpdatesResponse, error)
+	// tapcli: `universe metaupdates sync`
+	// SyncMetaUpdates fetches the metadata updates of an asset group from a
+	// remote universe server. Each update is verified against the locally known
+	// group key, and the valid ones that are newer than the latest locally known
+	// update are stored.
+	SyncMetaUpdates(context.Context, *SyncMetaUpdatesRequest) (*SyncMetaUpdatesResponse, error)
 	mustEmbedUnimplementedUniverseServer()
 }
 
@@ -419,6 +699,9 @@ func (UnimplementedUniverseServer) DeleteAssetRoot(context.Context, *DeleteRootQ
 func (UnimplementedUniverseServer) AssetLeafKeys(context.Context, *AssetLeafKeysRequest) (*AssetLeafKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssetLeafKeys not implemented")
 }
+func (UnimplementedUniverseServer) SubtreeNodes(context.Context, *SubtreeNodesRequest) (*SubtreeNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubtreeNodes not implemented")
+}
 func (UnimplementedUniverseServer) AssetLeaves(context.Context, *ID) (*AssetLeafResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssetLeaves not implemented")
 }
@@ -431,12 +714,21 @@ func (UnimplementedUniverseServer) InsertProof(context.Context, *AssetProof) (*A
 func (UnimplementedUniverseServer) PushProof(context.Context, *PushProofRequest) (*PushProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PushProof not implemented")
 }
+func (UnimplementedUniverseServer) PushMetaBlob(Universe_PushMetaBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method PushMetaBlob not implemented")
+}
+func (UnimplementedUniverseServer) PullMetaBlob(*PullMetaBlobRequest, Universe_PullMetaBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method PullMetaBlob not implemented")
+}
 func (UnimplementedUniverseServer) Info(context.Context, *InfoRequest) (*InfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
 }
 func (UnimplementedUniverseServer) SyncUniverse(context.Context, *SyncRequest) (*SyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncUniverse not implemented")
 }
+func (UnimplementedUniverseServer) SyncFederation(context.Context, *SyncFederationRequest) (*SyncFederationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncFederation not implemented")
+}
 func (UnimplementedUniverseServer) ListFederationServers(context.Context, *ListFederationServersRequest) (*ListFederationServersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFederationServers not implemented")
 }
@@ -446,6 +738,9 @@ func (UnimplementedUniverseServer) AddFederationServer(context.Context, *AddFede
 func (UnimplementedUniverseServer) DeleteFederationServer(context.Context, *DeleteFederationServerRequest) (*DeleteFederationServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationServer not implemented")
 }
+func (UnimplementedUniverseServer) SetFederationServerScope(context.Context, *SetFederationServerScopeRequest) (*SetFederationServerScopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFederationServerScope not implemented")
+}
 func (UnimplementedUniverseServer) UniverseStats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UniverseStats not implemented")
 }
@@ -455,12 +750,33 @@ func (UnimplementedUniverseServer) QueryAssetStats(context.Context, *AssetStatsQ
 func (UnimplementedUniverseServer) QueryEvents(context.Context, *QueryEventsRequest) (*QueryEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryEvents not implemented")
 }
+func (UnimplementedUniverseServer) QueryDailyStats(context.Context, *QueryDailyStatsRequest) (*QueryDailyStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDailyStats not implemented")
+}
+func (UnimplementedUniverseServer) QueryServerSyncStats(context.Context, *QueryServerSyncStatsRequest) (*QueryServerSyncStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryServerSyncStats not implemented")
+}
+func (UnimplementedUniverseServer) QueryTopTransferUniverses(context.Context, *QueryTopTransferUniversesRequest) (*QueryTopTransferUniversesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryTopTransferUniverses not implemented")
+}
 func (UnimplementedUniverseServer) SetFederationSyncConfig(context.Context, *SetFederationSyncConfigRequest) (*SetFederationSyncConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetFederationSyncConfig not implemented")
 }
 func (UnimplementedUniverseServer) QueryFederationSyncConfig(context.Context, *QueryFederationSyncConfigRequest) (*QueryFederationSyncConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryFederationSyncConfig not implemented")
 }
+func (UnimplementedUniverseServer) PublishMetaUpdate(context.Context, *PublishMetaUpdateRequest) (*PublishMetaUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishMetaUpdate not implemented")
+}
+func (UnimplementedUniverseServer) InsertMetaUpdate(context.Context, *InsertMetaUpdateRequest) (*InsertMetaUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertMetaUpdate not implemented")
+}
+func (UnimplementedUniverseServer) QueryMetaUpdates(context.Context, *QueryMetaUpdatesRequest) (*QueryMetaUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryMetaUpdates not implemented")
+}
+func (UnimplementedUniverseServer) SyncMetaUpdates(context.Context, *SyncMetaUpdatesRequest) (*SyncMetaUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMetaUpdates not implemented")
+}
 func (UnimplementedUniverseServer) mustEmbedUnimplementedUniverseServer() {}
 
 // UnsafeUniverseServer may be embedded to opt out of forward compatibility for this service.
@@ -564,6 +880,24 @@ func _Universe_AssetLeafKeys_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_SubtreeNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubtreeNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).SubtreeNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/SubtreeNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).SubtreeNodes(ctx, req.(*SubtreeNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Universe_AssetLeaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
@@ -636,6 +970,53 @@ func _Universe_PushProof_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_PushMetaBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UniverseServer).PushMetaBlob(&universePushMetaBlobServer{stream})
+}
+
+type Universe_PushMetaBlobServer interface {
+	SendAndClose(*PushMetaBlobResponse) error
+	Recv() (*PushMetaBlobRequest, error)
+	grpc.ServerStream
+}
+
+type universePushMetaBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *universePushMetaBlobServer) SendAndClose(m *PushMetaBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *universePushMetaBlobServer) Recv() (*PushMetaBlobRequest, error) {
+	m := new(PushMetaBlobRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Universe_PullMetaBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PullMetaBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UniverseServer).PullMetaBlob(m, &universePullMetaBlobServer{stream})
+}
+
+type Universe_PullMetaBlobServer interface {
+	Send(*PullMetaBlobChunk) error
+	grpc.ServerStream
+}
+
+type universePullMetaBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *universePullMetaBlobServer) Send(m *PullMetaBlobChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Universe_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InfoRequest)
 	if err := dec(in); err != nil {
@@ -672,6 +1053,24 @@ func _Universe_SyncUniverse_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_SyncFederation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncFederationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).SyncFederation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/SyncFederation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).SyncFederation(ctx, req.(*SyncFederationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Universe_ListFederationServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListFederationServersRequest)
 	if err := dec(in); err != nil {
@@ -726,6 +1125,24 @@ func _Universe_DeleteFederationServer_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_SetFederationServerScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFederationServerScopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).SetFederationServerScope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/SetFederationServerScope",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).SetFederationServerScope(ctx, req.(*SetFederationServerScopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Universe_UniverseStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsRequest)
 	if err := dec(in); err != nil {
@@ -780,6 +1197,60 @@ func _Universe_QueryEvents_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_QueryDailyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDailyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).QueryDailyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/QueryDailyStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).QueryDailyStats(ctx, req.(*QueryDailyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universe_QueryServerSyncStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryServerSyncStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).QueryServerSyncStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/QueryServerSyncStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).QueryServerSyncStats(ctx, req.(*QueryServerSyncStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universe_QueryTopTransferUniverses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTopTransferUniversesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).QueryTopTransferUniverses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/QueryTopTransferUniverses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).QueryTopTransferUniverses(ctx, req.(*QueryTopTransferUniversesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Universe_SetFederationSyncConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetFederationSyncConfigRequest)
 	if err := dec(in); err != nil {
@@ -816,6 +1287,78 @@ func _Universe_QueryFederationSyncConfig_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Universe_PublishMetaUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishMetaUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).PublishMetaUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/PublishMetaUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).PublishMetaUpdate(ctx, req.(*PublishMetaUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universe_InsertMetaUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertMetaUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).InsertMetaUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/InsertMetaUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).InsertMetaUpdate(ctx, req.(*InsertMetaUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universe_QueryMetaUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMetaUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).QueryMetaUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/QueryMetaUpdates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).QueryMetaUpdates(ctx, req.(*QueryMetaUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universe_SyncMetaUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMetaUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniverseServer).SyncMetaUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/universerpc.Universe/SyncMetaUpdates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniverseServer).SyncMetaUpdates(ctx, req.(*SyncMetaUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Universe_ServiceDesc is the grpc.ServiceDesc for Universe service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -843,6 +1386,10 @@ var Universe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AssetLeafKeys",
 			Handler:    _Universe_AssetLeafKeys_Handler,
 		},
+		{
+			MethodName: "SubtreeNodes",
+			Handler:    _Universe_SubtreeNodes_Handler,
+		},
 		{
 			MethodName: "AssetLeaves",
 			Handler:    _Universe_AssetLeaves_Handler,
@@ -867,6 +1414,10 @@ var Universe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncUniverse",
 			Handler:    _Universe_SyncUniverse_Handler,
 		},
+		{
+			MethodName: "SyncFederation",
+			Handler:    _Universe_SyncFederation_Handler,
+		},
 		{
 			MethodName: "ListFederationServers",
 			Handler:    _Universe_ListFederationServers_Handler,
@@ -879,6 +1430,10 @@ var Universe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFederationServer",
 			Handler:    _Universe_DeleteFederationServer_Handler,
 		},
+		{
+			MethodName: "SetFederationServerScope",
+			Handler:    _Universe_SetFederationServerScope_Handler,
+		},
 		{
 			MethodName: "UniverseStats",
 			Handler:    _Universe_UniverseStats_Handler,
@@ -891,6 +1446,18 @@ var Universe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryEvents",
 			Handler:    _Universe_QueryEvents_Handler,
 		},
+		{
+			MethodName: "QueryDailyStats",
+			Handler:    _Universe_QueryDailyStats_Handler,
+		},
+		{
+			MethodName: "QueryServerSyncStats",
+			Handler:    _Universe_QueryServerSyncStats_Handler,
+		},
+		{
+			MethodName: "QueryTopTransferUniverses",
+			Handler:    _Universe_QueryTopTransferUniverses_Handler,
+		},
 		{
 			MethodName: "SetFederationSyncConfig",
 			Handler:    _Universe_SetFederationSyncConfig_Handler,
@@ -899,7 +1466,34 @@ var Universe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryFederationSyncConfig",
 			Handler:    _Universe_QueryFederationSyncConfig_Handler,
 		},
+		{
+			MethodName: "PublishMetaUpdate",
+			Handler:    _Universe_PublishMetaUpdate_Handler,
+		},
+		{
+			MethodName: "InsertMetaUpdate",
+			Handler:    _Universe_InsertMetaUpdate_Handler,
+		},
+		{
+			MethodName: "QueryMetaUpdates",
+			Handler:    _Universe_QueryMetaUpdates_Handler,
+		},
+		{
+			MethodName: "SyncMetaUpdates",
+			Handler:    _Universe_SyncMetaUpdates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PushMetaBlob",
+			Handler:       _Universe_PushMetaBlob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PullMetaBlob",
+			Handler:       _Universe_PullMetaBlob_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "universerpc/universe.proto",
 }
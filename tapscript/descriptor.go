@@ -0,0 +1,100 @@
+package tapscript
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+const (
+	// descriptorInputCharset is the set of characters that can be used in
+	// an output descriptor, in the order defined by BIP-0380.
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+
+	// descriptorChecksumCharset is the set of characters used to encode
+	// an output descriptor checksum.
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// descriptorPolyMod is the polynomial modulus function of the BIP-0380
+// descriptor checksum.
+func descriptorPolyMod(c uint64, val uint64) uint64 {
+	c0 := c >> 35
+	c = ((c & 0x7ffffffff) << 5) ^ val
+
+	generators := []uint64{
+		0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a,
+		0x644d626ffd,
+	}
+	for i, generator := range generators {
+		if (c0>>i)&1 == 1 {
+			c ^= generator
+		}
+	}
+
+	return c
+}
+
+// DescriptorChecksum computes the BIP-0380 checksum of the given output
+// descriptor.
+func DescriptorChecksum(desc string) (string, error) {
+	var (
+		c        uint64 = 1
+		cls      uint64
+		clsCount int
+	)
+	for _, ch := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, ch)
+		if pos < 0 {
+			return "", fmt.Errorf("invalid descriptor character %q",
+				ch)
+		}
+
+		c = descriptorPolyMod(c, uint64(pos&31))
+		cls = cls*3 + uint64(pos>>5)
+		clsCount++
+
+		if clsCount == 3 {
+			c = descriptorPolyMod(c, cls)
+			cls = 0
+			clsCount = 0
+		}
+	}
+
+	if clsCount > 0 {
+		c = descriptorPolyMod(c, cls)
+	}
+	for i := 0; i < 8; i++ {
+		c = descriptorPolyMod(c, 0)
+	}
+	c ^= 1
+
+	var checksum [8]byte
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[(c>>(5*(7-i)))&31]
+	}
+
+	return string(checksum[:]), nil
+}
+
+// RawTrDescriptor returns the BIP-0386 rawtr() output descriptor, including
+// its checksum, of the P2TR output with the given taproot output key. Since
+// the Taproot Asset commitment can't be expressed in the descriptor language,
+// this watch-only form is what allows external wallets to monitor asset anchor
+// outputs.
+func RawTrDescriptor(outputKey *btcec.PublicKey) string {
+	desc := fmt.Sprintf("rawtr(%s)", hex.EncodeToString(
+		schnorr.SerializePubKey(outputKey),
+	))
+
+	// The descriptor only contains hex characters and parentheses, so
+	// computing the checksum can't fail.
+	checksum, _ := DescriptorChecksum(desc)
+
+	return desc + "#" + checksum
+}
@@ -0,0 +1,54 @@
+package tapscript
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDescriptorChecksum tests the descriptor checksum against the test
+// vectors of BIP-0380.
+func TestDescriptorChecksum(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		checksum string
+	}{{
+		desc:     "raw(deadbeef)",
+		checksum: "89f8spxm",
+	}, {
+		desc: "pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75" +
+			"RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4A" +
+			"LHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)",
+		checksum: "ml40v0wf",
+	}}
+
+	for _, tc := range testCases {
+		checksum, err := DescriptorChecksum(tc.desc)
+		require.NoError(t, err)
+		require.Equal(t, tc.checksum, checksum)
+	}
+
+	_, err := DescriptorChecksum("raw(deadbeef)\n")
+	require.ErrorContains(t, err, "invalid descriptor character")
+}
+
+// TestRawTrDescriptor tests that the rawtr() descriptor of an output key is
+// formatted correctly.
+func TestRawTrDescriptor(t *testing.T) {
+	t.Parallel()
+
+	keyHex := "a34b99f22c790c4e36b2b3c2c35a36db06226e41c692fc82b8b56ac1c" +
+		"540c5bd"
+	keyBytes, err := hex.DecodeString(keyHex)
+	require.NoError(t, err)
+	outputKey, err := schnorr.ParsePubKey(keyBytes)
+	require.NoError(t, err)
+
+	require.Equal(
+		t, "rawtr("+keyHex+")#rp4l9zcu", RawTrDescriptor(outputKey),
+	)
+}
@@ -0,0 +1,259 @@
+package tapscript
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// HashLockPath is a spend path of a hash lock script tree.
+type HashLockPath uint8
+
+const (
+	// HashLockSuccess is the spend path that allows the claimer to sweep
+	// the asset by revealing the preimage of the payment hash.
+	HashLockSuccess HashLockPath = 0
+
+	// HashLockTimeout is the spend path that allows the refunder to sweep
+	// the asset back once the CSV delay has passed.
+	HashLockTimeout HashLockPath = 1
+)
+
+// String returns a human-readable string for the spend path.
+func (p HashLockPath) String() string {
+	switch p {
+	case HashLockSuccess:
+		return "success"
+
+	case HashLockTimeout:
+		return "timeout"
+
+	default:
+		return fmt.Sprintf("<unknown_path(%d)>", p)
+	}
+}
+
+// HashLockScriptTree is the script tree of an HTLC-style asset-level script
+// key. Locking an asset to it and BTC to an equivalent on-chain HTLC that uses
+// the same payment hash allows the two to be swapped atomically. The internal
+// key is the NUMS key, so the asset can only be spent through one of the two
+// leaves:
+//
+//   - the success leaf OP_SIZE 32 OP_EQUALVERIFY OP_SHA256 <payment_hash>
+//     OP_EQUALVERIFY <claim> OP_CHECKSIG
+//   - the timeout leaf <refund> OP_CHECKSIGVERIFY <csv_delay>
+//     OP_CHECKSEQUENCEVERIFY
+type HashLockScriptTree struct {
+	input.ScriptTree
+
+	// PaymentHash is the SHA256 hash of the preimage that needs to be
+	// revealed to spend through the success path.
+	PaymentHash [sha256.Size]byte
+
+	// ClaimKey is the key that can spend through the success path.
+	ClaimKey *btcec.PublicKey
+
+	// RefundKey is the key that can spend through the timeout path.
+	RefundKey *btcec.PublicKey
+
+	// CsvDelay is the number of blocks the asset must have been confirmed
+	// for before it can be spent through the timeout path.
+	CsvDelay uint32
+
+	// SuccessLeaf is the leaf of the success spend path.
+	SuccessLeaf txscript.TapLeaf
+
+	// TimeoutLeaf is the leaf of the timeout spend path.
+	TimeoutLeaf txscript.TapLeaf
+}
+
+// NewHashLockScriptTree creates the hash lock script tree for the given
+// payment hash, keys and CSV delay.
+func NewHashLockScriptTree(paymentHash [sha256.Size]byte, claimKey,
+	refundKey *btcec.PublicKey, csvDelay uint32) (*HashLockScriptTree,
+	error) {
+
+	if claimKey == nil || refundKey == nil {
+		return nil, fmt.Errorf("claim and refund key must be set")
+	}
+
+	// We only support block based relative lock times, so the delay must
+	// fit into the lock time bits of the sequence.
+	if csvDelay == 0 || csvDelay > wire.SequenceLockTimeMask {
+		return nil, fmt.Errorf("CSV delay must be between 1 and %d "+
+			"blocks", wire.SequenceLockTimeMask)
+	}
+
+	successScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_SIZE).
+		AddInt64(sha256.Size).
+		AddOp(txscript.OP_EQUALVERIFY).
+		AddOp(txscript.OP_SHA256).
+		AddData(paymentHash[:]).
+		AddOp(txscript.OP_EQUALVERIFY).
+		AddData(schnorr.SerializePubKey(claimKey)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutScript, err := txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(refundKey)).
+		AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddInt64(int64(csvDelay)).
+		AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	successLeaf := txscript.NewBaseTapLeaf(successScript)
+	timeoutLeaf := txscript.NewBaseTapLeaf(timeoutScript)
+
+	tapscriptTree := txscript.AssembleTaprootScriptTree(
+		successLeaf, timeoutLeaf,
+	)
+	tapScriptRoot := tapscriptTree.RootNode.TapHash()
+
+	// We use the NUMS key as the internal key to make sure the key spend
+	// path can't be used to bypass the hash lock.
+	taprootKey := txscript.ComputeTaprootOutputKey(
+		&input.TaprootNUMSKey, tapScriptRoot[:],
+	)
+
+	return &HashLockScriptTree{
+		ScriptTree: input.ScriptTree{
+			InternalKey:   &input.TaprootNUMSKey,
+			TaprootKey:    taprootKey,
+			TapscriptTree: tapscriptTree,
+			TapscriptRoot: tapScriptRoot[:],
+		},
+		PaymentHash: paymentHash,
+		ClaimKey:    claimKey,
+		RefundKey:   refundKey,
+		CsvDelay:    csvDelay,
+		SuccessLeaf: successLeaf,
+		TimeoutLeaf: timeoutLeaf,
+	}, nil
+}
+
+// ScriptKey returns the asset-level script key of the tree.
+func (t *HashLockScriptTree) ScriptKey() asset.ScriptKey {
+	scriptKey := asset.NewScriptKey(t.TaprootKey)
+	scriptKey.TweakedScriptKey = &asset.TweakedScriptKey{
+		RawKey: keychain.KeyDescriptor{
+			PubKey: t.InternalKey,
+		},
+		Tweak: t.TapscriptRoot,
+	}
+
+	return scriptKey
+}
+
+// Leaf returns the leaf of the given spend path.
+func (t *HashLockScriptTree) Leaf(path HashLockPath) (txscript.TapLeaf,
+	error) {
+
+	switch path {
+	case HashLockSuccess:
+		return t.SuccessLeaf, nil
+
+	case HashLockTimeout:
+		return t.TimeoutLeaf, nil
+
+	default:
+		return txscript.TapLeaf{}, fmt.Errorf("unknown hash lock "+
+			"path: %v", path)
+	}
+}
+
+// SigningKey returns the key that needs to sign for the given spend path.
+func (t *HashLockScriptTree) SigningKey(
+	path HashLockPath) (*btcec.PublicKey, error) {
+
+	switch path {
+	case HashLockSuccess:
+		return t.ClaimKey, nil
+
+	case HashLockTimeout:
+		return t.RefundKey, nil
+
+	default:
+		return nil, fmt.Errorf("unknown hash lock path: %v", path)
+	}
+}
+
+// ControlBlock returns the serialized control block that proves the inclusion
+// of the given spend path's leaf in the tree.
+func (t *HashLockScriptTree) ControlBlock(path HashLockPath) ([]byte, error) {
+	leaf, err := t.Leaf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	leafIdx, ok := t.TapscriptTree.LeafProofIndex[leaf.TapHash()]
+	if !ok {
+		return nil, fmt.Errorf("leaf of path %v not found in tree",
+			path)
+	}
+
+	proof := t.TapscriptTree.LeafMerkleProofs[leafIdx]
+	controlBlock := proof.ToControlBlock(t.InternalKey)
+
+	return controlBlock.ToBytes()
+}
+
+// CheckPreimage returns an error if the given preimage doesn't hash to the
+// tree's payment hash.
+func (t *HashLockScriptTree) CheckPreimage(preimage []byte) error {
+	if len(preimage) != sha256.Size {
+		return fmt.Errorf("preimage must be %d bytes, got %d",
+			sha256.Size, len(preimage))
+	}
+
+	hash := sha256.Sum256(preimage)
+	if !bytes.Equal(hash[:], t.PaymentHash[:]) {
+		return fmt.Errorf("preimage doesn't match payment hash")
+	}
+
+	return nil
+}
+
+// Witness assembles the witness that spends the tree through the given spend
+// path. The preimage is only required for the success path.
+func (t *HashLockScriptTree) Witness(path HashLockPath, sig,
+	preimage []byte) (wire.TxWitness, error) {
+
+	leaf, err := t.Leaf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	controlBlock, err := t.ControlBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == HashLockTimeout {
+		return wire.TxWitness{sig, leaf.Script, controlBlock}, nil
+	}
+
+	if err := t.CheckPreimage(preimage); err != nil {
+		return nil, err
+	}
+
+	// The script checks the preimage at the top of the stack first, so it
+	// is pushed after the signature.
+	return wire.TxWitness{
+		sig, preimage, leaf.Script, controlBlock,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package tapscript
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHashLockScriptTree tests that the success path of the hash lock script
+// tree requires the preimage and the claim key, and that the timeout path
+// requires the refund key and the CSV delay to have passed.
+func TestHashLockScriptTree(t *testing.T) {
+	t.Parallel()
+
+	const csvDelay = 144
+
+	claimKey := test.RandPrivKey()
+	refundKey := test.RandPrivKey()
+	preimage := test.RandBytes(32)
+	paymentHash := sha256.Sum256(preimage)
+
+	tree, err := NewHashLockScriptTree(
+		paymentHash, claimKey.PubKey(), refundKey.PubKey(), csvDelay,
+	)
+	require.NoError(t, err)
+
+	scriptKey := tree.ScriptKey()
+	require.Equal(t, tree.TapscriptRoot, scriptKey.TweakedScriptKey.Tweak)
+
+	pkScript, err := txscript.PayToTaprootScript(tree.TaprootKey)
+	require.NoError(t, err)
+	prevOut := &wire.TxOut{Value: 1000, PkScript: pkScript}
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(
+		prevOut.PkScript, prevOut.Value,
+	)
+
+	// spend executes the witness of the given path with a signature of the
+	// given key, the given preimage and the given input sequence.
+	spend := func(path HashLockPath, key *btcec.PrivateKey,
+		preimage []byte, sequence uint32) error {
+
+		spendTx := wire.NewMsgTx(2)
+		spendTx.AddTxIn(&wire.TxIn{Sequence: sequence})
+		spendTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: pkScript})
+		sigHashes := txscript.NewTxSigHashes(spendTx, fetcher)
+
+		leaf, err := tree.Leaf(path)
+		require.NoError(t, err)
+
+		sig, err := txscript.RawTxInTapscriptSignature(
+			spendTx, sigHashes, 0, prevOut.Value, prevOut.PkScript,
+			leaf, txscript.SigHashDefault, key,
+		)
+		require.NoError(t, err)
+
+		witness, err := tree.Witness(path, sig, preimage)
+		if err != nil {
+			return err
+		}
+		spendTx.TxIn[0].Witness = witness
+
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, spendTx, 0,
+			txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, fetcher,
+		)
+		require.NoError(t, err)
+
+		return vm.Execute()
+	}
+
+	require.NoError(t, spend(HashLockSuccess, claimKey, preimage, 0))
+	require.NoError(t, spend(HashLockTimeout, refundKey, nil, csvDelay))
+
+	// The success path requires the correct preimage and the claim key.
+	err = spend(HashLockSuccess, claimKey, test.RandBytes(32), 0)
+	require.ErrorContains(t, err, "doesn't match payment hash")
+	require.Error(t, spend(HashLockSuccess, refundKey, preimage, 0))
+
+	// The timeout path requires the refund key and can't be used before
+	// the CSV delay has passed.
+	require.Error(t, spend(HashLockTimeout, claimKey, nil, csvDelay))
+	require.Error(t, spend(HashLockTimeout, refundKey, nil, csvDelay-1))
+
+	// A delay that doesn't fit into the sequence is rejected.
+	_, err = NewHashLockScriptTree(
+		paymentHash, claimKey.PubKey(), refundKey.PubKey(),
+		wire.SequenceLockTimeMask+1,
+	)
+	require.ErrorContains(t, err, "CSV delay")
+}
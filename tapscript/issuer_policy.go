@@ -0,0 +1,222 @@
+package tapscript
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// IssuerPolicyPath is a spend path of an issuer policy script tree.
+type IssuerPolicyPath uint8
+
+const (
+	// IssuerPolicyCoSign is the spend path of regular transfers, which
+	// requires a signature of both the holder and the issuer. The issuer
+	// can freeze an asset by refusing to co-sign its transfers.
+	IssuerPolicyCoSign IssuerPolicyPath = 0
+
+	// IssuerPolicyClawback is the spend path that allows the issuer to
+	// move the asset without the holder's consent.
+	IssuerPolicyClawback IssuerPolicyPath = 1
+)
+
+// String returns a human-readable string for the spend path.
+func (p IssuerPolicyPath) String() string {
+	switch p {
+	case IssuerPolicyCoSign:
+		return "co-sign"
+
+	case IssuerPolicyClawback:
+		return "clawback"
+
+	default:
+		return fmt.Sprintf("<unknown_path(%d)>", p)
+	}
+}
+
+// IssuerPolicyScriptTree is the script tree of an asset-level script key that
+// is controlled by both the asset's holder and its issuer. The internal key is
+// the NUMS key, so the asset can only be spent through one of the two leaves:
+//
+//   - the co-sign leaf <holder> OP_CHECKSIGVERIFY <issuer> OP_CHECKSIG
+//   - the clawback leaf <issuer> OP_CHECKSIG
+type IssuerPolicyScriptTree struct {
+	input.ScriptTree
+
+	// HolderKey is the key of the asset's holder.
+	HolderKey *btcec.PublicKey
+
+	// IssuerKey is the key of the asset's issuer.
+	IssuerKey *btcec.PublicKey
+
+	// CoSignLeaf is the leaf of the co-sign spend path.
+	CoSignLeaf txscript.TapLeaf
+
+	// ClawbackLeaf is the leaf of the clawback spend path.
+	ClawbackLeaf txscript.TapLeaf
+}
+
+// NewIssuerPolicyScriptTree creates the issuer policy script tree for the
+// given holder and issuer keys.
+func NewIssuerPolicyScriptTree(holderKey,
+	issuerKey *btcec.PublicKey) (*IssuerPolicyScriptTree, error) {
+
+	if holderKey == nil || issuerKey == nil {
+		return nil, fmt.Errorf("holder and issuer key must be set")
+	}
+
+	coSignScript, err := txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(holderKey)).
+		AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddData(schnorr.SerializePubKey(issuerKey)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	clawbackScript, err := txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(issuerKey)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	coSignLeaf := txscript.NewBaseTapLeaf(coSignScript)
+	clawbackLeaf := txscript.NewBaseTapLeaf(clawbackScript)
+
+	tapscriptTree := txscript.AssembleTaprootScriptTree(
+		coSignLeaf, clawbackLeaf,
+	)
+	tapScriptRoot := tapscriptTree.RootNode.TapHash()
+
+	// We use the NUMS key as the internal key to make sure the key spend
+	// path can't be used to bypass the policy.
+	taprootKey := txscript.ComputeTaprootOutputKey(
+		&input.TaprootNUMSKey, tapScriptRoot[:],
+	)
+
+	return &IssuerPolicyScriptTree{
+		ScriptTree: input.ScriptTree{
+			InternalKey:   &input.TaprootNUMSKey,
+			TaprootKey:    taprootKey,
+			TapscriptTree: tapscriptTree,
+			TapscriptRoot: tapScriptRoot[:],
+		},
+		HolderKey:    holderKey,
+		IssuerKey:    issuerKey,
+		CoSignLeaf:   coSignLeaf,
+		ClawbackLeaf: clawbackLeaf,
+	}, nil
+}
+
+// ScriptKey returns the asset-level script key of the tree.
+func (t *IssuerPolicyScriptTree) ScriptKey() asset.ScriptKey {
+	scriptKey := asset.NewScriptKey(t.TaprootKey)
+	scriptKey.TweakedScriptKey = &asset.TweakedScriptKey{
+		RawKey: keychain.KeyDescriptor{
+			PubKey: t.InternalKey,
+		},
+		Tweak: t.TapscriptRoot,
+	}
+
+	return scriptKey
+}
+
+// Leaf returns the leaf of the given spend path.
+func (t *IssuerPolicyScriptTree) Leaf(
+	path IssuerPolicyPath) (txscript.TapLeaf, error) {
+
+	switch path {
+	case IssuerPolicyCoSign:
+		return t.CoSignLeaf, nil
+
+	case IssuerPolicyClawback:
+		return t.ClawbackLeaf, nil
+
+	default:
+		return txscript.TapLeaf{}, fmt.Errorf("unknown issuer policy "+
+			"path: %v", path)
+	}
+}
+
+// RequiredKeys returns the keys that need to sign for the given spend path, in
+// the order the leaf script checks their signatures.
+func (t *IssuerPolicyScriptTree) RequiredKeys(
+	path IssuerPolicyPath) ([]*btcec.PublicKey, error) {
+
+	switch path {
+	case IssuerPolicyCoSign:
+		return []*btcec.PublicKey{t.HolderKey, t.IssuerKey}, nil
+
+	case IssuerPolicyClawback:
+		return []*btcec.PublicKey{t.IssuerKey}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown issuer policy path: %v", path)
+	}
+}
+
+// ControlBlock returns the serialized control block that proves the inclusion
+// of the given spend path's leaf in the tree.
+func (t *IssuerPolicyScriptTree) ControlBlock(
+	path IssuerPolicyPath) ([]byte, error) {
+
+	leaf, err := t.Leaf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	leafIdx, ok := t.TapscriptTree.LeafProofIndex[leaf.TapHash()]
+	if !ok {
+		return nil, fmt.Errorf("leaf of path %v not found in tree",
+			path)
+	}
+
+	proof := t.TapscriptTree.LeafMerkleProofs[leafIdx]
+	controlBlock := proof.ToControlBlock(t.InternalKey)
+
+	return controlBlock.ToBytes()
+}
+
+// Witness assembles the witness that spends the tree through the given spend
+// path. The signatures must be given in the order of RequiredKeys.
+func (t *IssuerPolicyScriptTree) Witness(path IssuerPolicyPath,
+	sigs [][]byte) (wire.TxWitness, error) {
+
+	requiredKeys, err := t.RequiredKeys(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sigs) != len(requiredKeys) {
+		return nil, fmt.Errorf("%v path requires %d signatures, got %d",
+			path, len(requiredKeys), len(sigs))
+	}
+
+	leaf, err := t.Leaf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	controlBlock, err := t.ControlBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The script checks the signature at the top of the stack first, so
+	// the signatures are pushed in reverse order.
+	witness := make(wire.TxWitness, 0, len(sigs)+2)
+	for idx := len(sigs) - 1; idx >= 0; idx-- {
+		witness = append(witness, sigs[idx])
+	}
+
+	return append(witness, leaf.Script, controlBlock), nil
+}
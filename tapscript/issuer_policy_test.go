@@ -0,0 +1,86 @@
+package tapscript
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIssuerPolicyScriptTree tests that the spend paths of the issuer policy
+// script tree only accept the signatures of the keys they require.
+func TestIssuerPolicyScriptTree(t *testing.T) {
+	t.Parallel()
+
+	holderKey := test.RandPrivKey()
+	issuerKey := test.RandPrivKey()
+	otherKey := test.RandPrivKey()
+
+	tree, err := NewIssuerPolicyScriptTree(
+		holderKey.PubKey(), issuerKey.PubKey(),
+	)
+	require.NoError(t, err)
+
+	scriptKey := tree.ScriptKey()
+	require.Equal(t, tree.TapscriptRoot, scriptKey.TweakedScriptKey.Tweak)
+
+	pkScript, err := txscript.PayToTaprootScript(tree.TaprootKey)
+	require.NoError(t, err)
+	prevOut := &wire.TxOut{Value: 1000, PkScript: pkScript}
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{})
+	spendTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: pkScript})
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(
+		prevOut.PkScript, prevOut.Value,
+	)
+	sigHashes := txscript.NewTxSigHashes(spendTx, fetcher)
+
+	// spend executes the witness of the given path with signatures of the
+	// given keys.
+	spend := func(path IssuerPolicyPath,
+		keys ...*btcec.PrivateKey) error {
+
+		leaf, err := tree.Leaf(path)
+		require.NoError(t, err)
+
+		sigs := make([][]byte, len(keys))
+		for idx, key := range keys {
+			sigs[idx], err = txscript.RawTxInTapscriptSignature(
+				spendTx, sigHashes, 0, prevOut.Value,
+				prevOut.PkScript, leaf, txscript.SigHashDefault,
+				key,
+			)
+			require.NoError(t, err)
+		}
+
+		witness, err := tree.Witness(path, sigs)
+		if err != nil {
+			return err
+		}
+		spendTx.TxIn[0].Witness = witness
+
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, spendTx, 0,
+			txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, fetcher,
+		)
+		require.NoError(t, err)
+
+		return vm.Execute()
+	}
+
+	require.NoError(t, spend(IssuerPolicyCoSign, holderKey, issuerKey))
+	require.NoError(t, spend(IssuerPolicyClawback, issuerKey))
+
+	// The holder can't spend alone, neither through the co-sign nor the
+	// clawback path.
+	require.Error(t, spend(IssuerPolicyCoSign, holderKey))
+	require.Error(t, spend(IssuerPolicyCoSign, holderKey, otherKey))
+	require.Error(t, spend(IssuerPolicyCoSign, issuerKey, holderKey))
+	require.Error(t, spend(IssuerPolicyClawback, holderKey))
+}
@@ -0,0 +1,205 @@
+package tapscript
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// MuSig2AggregateKey returns the MuSig2 aggregate of the given participant
+// keys. The keys are sorted before they are aggregated. The aggregate key is
+// tweaked with the given tapscript root, or as a BIP-0086 key if the root is
+// empty.
+func MuSig2AggregateKey(participantKeys []*btcec.PublicKey,
+	tapscriptRoot []byte) (*musig2.AggregateKey, error) {
+
+	if len(participantKeys) < 2 {
+		return nil, fmt.Errorf("MuSig2 requires at least two " +
+			"participants")
+	}
+
+	for idx, key := range participantKeys {
+		for _, other := range participantKeys[idx+1:] {
+			if key.IsEqual(other) {
+				return nil, fmt.Errorf("duplicate participant "+
+					"key %x", key.SerializeCompressed())
+			}
+		}
+	}
+
+	tweakOpt := musig2.WithBIP86KeyTweak()
+	if len(tapscriptRoot) > 0 {
+		tweakOpt = musig2.WithTaprootKeyTweak(tapscriptRoot)
+	}
+
+	aggKey, _, _, err := musig2.AggregateKeys(
+		copyKeys(participantKeys), true, tweakOpt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to aggregate keys: %w", err)
+	}
+
+	return aggKey, nil
+}
+
+// MuSig2ScriptKey returns the asset-level script key that is the MuSig2
+// aggregate of the given participant keys, tweaked with the given tapscript
+// root or as a BIP-0086 key if the root is empty.
+func MuSig2ScriptKey(participantKeys []*btcec.PublicKey,
+	tapscriptRoot []byte) (asset.ScriptKey, error) {
+
+	aggKey, err := MuSig2AggregateKey(participantKeys, tapscriptRoot)
+	if err != nil {
+		return asset.ScriptKey{}, err
+	}
+
+	scriptKey := asset.NewScriptKey(aggKey.FinalKey)
+	scriptKey.TweakedScriptKey = &asset.TweakedScriptKey{
+		RawKey: keychain.KeyDescriptor{
+			PubKey: aggKey.PreTweakedKey,
+		},
+	}
+	if len(tapscriptRoot) > 0 {
+		scriptKey.TweakedScriptKey.Tweak = tapscriptRoot
+	}
+
+	return scriptKey, nil
+}
+
+// IsMuSig2ScriptKey returns true if the given participant keys aggregate to
+// the given script key.
+func IsMuSig2ScriptKey(scriptKey *btcec.PublicKey,
+	participantKeys []*btcec.PublicKey, tapscriptRoot []byte) bool {
+
+	aggKey, err := MuSig2AggregateKey(participantKeys, tapscriptRoot)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(
+		schnorr.SerializePubKey(aggKey.FinalKey),
+		schnorr.SerializePubKey(scriptKey),
+	)
+}
+
+// MuSig2SignOpts returns the options a participant needs to pass to
+// musig2.Sign to create a partial signature for the aggregate key of the given
+// tapscript root.
+func MuSig2SignOpts(tapscriptRoot []byte) []musig2.SignOption {
+	tweakOpt := musig2.WithBip86SignTweak()
+	if len(tapscriptRoot) > 0 {
+		tweakOpt = musig2.WithTaprootSignTweak(tapscriptRoot)
+	}
+
+	return []musig2.SignOption{musig2.WithSortedKeys(), tweakOpt}
+}
+
+// VerifyMuSig2PartialSig verifies the partial signature of a single
+// participant over the given message.
+func VerifyMuSig2PartialSig(sig *musig2.PartialSignature,
+	pubNonce, combinedNonce [musig2.PubNonceSize]byte,
+	participantKeys []*btcec.PublicKey, signerKey *btcec.PublicKey,
+	tapscriptRoot []byte, msg [32]byte) bool {
+
+	return sig.Verify(
+		pubNonce, combinedNonce, copyKeys(participantKeys), signerKey,
+		msg, MuSig2SignOpts(tapscriptRoot)...,
+	)
+}
+
+// CombineMuSig2Sigs combines the partial signatures of all participants into
+// the final Schnorr signature over the given message and verifies it against
+// the aggregate key.
+func CombineMuSig2Sigs(participantKeys []*btcec.PublicKey,
+	tapscriptRoot []byte, msg [32]byte,
+	combinedNonce [musig2.PubNonceSize]byte,
+	partialSigs []*musig2.PartialSignature) (*schnorr.Signature, error) {
+
+	if len(partialSigs) != len(participantKeys) {
+		return nil, fmt.Errorf("expected %d partial signatures, got %d",
+			len(participantKeys), len(partialSigs))
+	}
+
+	aggKey, err := MuSig2AggregateKey(participantKeys, tapscriptRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := muSig2SigningNonce(combinedNonce, aggKey.FinalKey, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	combineOpt := musig2.WithBip86TweakedCombine(
+		msg, copyKeys(participantKeys), true,
+	)
+	if len(tapscriptRoot) > 0 {
+		combineOpt = musig2.WithTaprootTweakedCombine(
+			msg, copyKeys(participantKeys), tapscriptRoot, true,
+		)
+	}
+
+	sig := musig2.CombineSigs(nonce, partialSigs, combineOpt)
+	if !sig.Verify(msg[:], aggKey.FinalKey) {
+		return nil, fmt.Errorf("combined MuSig2 signature is invalid")
+	}
+
+	return sig, nil
+}
+
+// muSig2SigningNonce computes the final nonce R = R1 + b*R2 of a MuSig2
+// signature from the combined public nonce, where b is the nonce coefficient
+// that binds the nonce to the aggregate key and the message.
+func muSig2SigningNonce(combinedNonce [musig2.PubNonceSize]byte,
+	aggKey *btcec.PublicKey, msg [32]byte) (*btcec.PublicKey, error) {
+
+	var nonceMsg bytes.Buffer
+	nonceMsg.Write(combinedNonce[:])
+	nonceMsg.Write(schnorr.SerializePubKey(aggKey))
+	nonceMsg.Write(msg[:])
+	nonceHash := chainhash.TaggedHash(
+		musig2.NonceBlindTag, nonceMsg.Bytes(),
+	)
+
+	var nonceCoef btcec.ModNScalar
+	nonceCoef.SetByteSlice(nonceHash[:])
+
+	r1, err := btcec.ParseJacobian(
+		combinedNonce[:btcec.PubKeyBytesLenCompressed],
+	)
+	if err != nil {
+		return nil, err
+	}
+	r2, err := btcec.ParseJacobian(
+		combinedNonce[btcec.PubKeyBytesLenCompressed:],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce btcec.JacobianPoint
+	btcec.ScalarMultNonConst(&nonceCoef, &r2, &r2)
+	btcec.AddNonConst(&r1, &r2, &nonce)
+
+	// If the nonce is the point at infinity, the generator is used
+	// instead.
+	if (nonce.X.IsZero() && nonce.Y.IsZero()) || nonce.Z.IsZero() {
+		btcec.Generator().AsJacobian(&nonce)
+	}
+
+	nonce.ToAffine()
+
+	return btcec.NewPublicKey(&nonce.X, &nonce.Y), nil
+}
+
+// copyKeys returns a copy of the given key slice. The musig2 package sorts the
+// keys it is given in place, which must not reorder the caller's keys.
+func copyKeys(keys []*btcec.PublicKey) []*btcec.PublicKey {
+	return append([]*btcec.PublicKey{}, keys...)
+}
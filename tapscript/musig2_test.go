@@ -0,0 +1,113 @@
+package tapscript
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMuSig2ScriptKey tests that participants can cooperatively sign for a
+// MuSig2 script key and that their partial signatures combine into a valid
+// signature.
+func TestMuSig2ScriptKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		tapscriptRoot []byte
+	}{{
+		name: "bip86",
+	}, {
+		name:          "tapscript root",
+		tapscriptRoot: test.RandBytes(32),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testMuSig2ScriptKey(t, tc.tapscriptRoot)
+		})
+	}
+}
+
+func testMuSig2ScriptKey(t *testing.T, tapscriptRoot []byte) {
+	privKeys := make([]*btcec.PrivateKey, 3)
+	pubKeys := make([]*btcec.PublicKey, 3)
+	for idx := range privKeys {
+		privKeys[idx] = test.RandPrivKey()
+		pubKeys[idx] = privKeys[idx].PubKey()
+	}
+
+	scriptKey, err := MuSig2ScriptKey(pubKeys, tapscriptRoot)
+	require.NoError(t, err)
+	require.True(t, IsMuSig2ScriptKey(
+		scriptKey.PubKey, pubKeys, tapscriptRoot,
+	))
+	require.False(t, IsMuSig2ScriptKey(
+		scriptKey.PubKey, pubKeys[:2], tapscriptRoot,
+	))
+
+	// Without a tapscript root, the script key is the BIP-0086 tweaked
+	// aggregate key, just like a regular single-key script key.
+	if len(tapscriptRoot) == 0 {
+		bip86Key := asset.NewScriptKeyBip86(
+			scriptKey.TweakedScriptKey.RawKey,
+		)
+		require.True(t, bip86Key.PubKey.IsEqual(scriptKey.PubKey))
+	}
+
+	msg := [32]byte(test.RandBytes(32))
+	nonces := make([]*musig2.Nonces, len(privKeys))
+	pubNonces := make([][musig2.PubNonceSize]byte, len(privKeys))
+	for idx := range privKeys {
+		nonces[idx], err = musig2.GenNonces(
+			musig2.WithPublicKey(pubKeys[idx]),
+		)
+		require.NoError(t, err)
+		pubNonces[idx] = nonces[idx].PubNonce
+	}
+
+	combinedNonce, err := musig2.AggregateNonces(pubNonces)
+	require.NoError(t, err)
+
+	partialSigs := make([]*musig2.PartialSignature, len(privKeys))
+	for idx := range privKeys {
+		partialSigs[idx], err = musig2.Sign(
+			nonces[idx].SecNonce, privKeys[idx], combinedNonce,
+			copyKeys(pubKeys), msg,
+			MuSig2SignOpts(tapscriptRoot)...,
+		)
+		require.NoError(t, err)
+
+		require.True(t, VerifyMuSig2PartialSig(
+			partialSigs[idx], pubNonces[idx], combinedNonce,
+			pubKeys, pubKeys[idx], tapscriptRoot, msg,
+		))
+	}
+
+	sig, err := CombineMuSig2Sigs(
+		pubKeys, tapscriptRoot, msg, combinedNonce, partialSigs,
+	)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(msg[:], scriptKey.PubKey))
+
+	// A partial signature of the wrong participant doesn't combine into a
+	// valid signature.
+	partialSigs[0] = partialSigs[1]
+	_, err = CombineMuSig2Sigs(
+		pubKeys, tapscriptRoot, msg, combinedNonce, partialSigs,
+	)
+	require.ErrorContains(t, err, "invalid")
+
+	// At least two distinct participants are required.
+	_, err = MuSig2ScriptKey(pubKeys[:1], tapscriptRoot)
+	require.ErrorContains(t, err, "at least two")
+
+	_, err = MuSig2ScriptKey(
+		[]*btcec.PublicKey{pubKeys[0], pubKeys[0]}, tapscriptRoot,
+	)
+	require.ErrorContains(t, err, "duplicate")
+}
@@ -0,0 +1,150 @@
+package tapscript
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// TimeLockScriptTree is the script tree of an asset-level script key that
+// locks an asset to a key until an absolute lock time and/or a relative lock
+// time have passed, for example to vest assets to a recipient. The internal
+// key is the NUMS key, so the asset can only be spent through the single leaf
+//
+//	[<lock_time> OP_CHECKLOCKTIMEVERIFY OP_DROP]
+//	[<csv_delay> OP_CHECKSEQUENCEVERIFY OP_DROP]
+//	<key> OP_CHECKSIG
+//
+// where the lock time checks are only present if the respective lock time is
+// set.
+type TimeLockScriptTree struct {
+	input.ScriptTree
+
+	// Key is the key that can spend the asset once the lock times have
+	// passed.
+	Key *btcec.PublicKey
+
+	// LockTime is the block height from which on the asset can be spent.
+	// Zero means there is no absolute lock time.
+	LockTime uint32
+
+	// CsvDelay is the number of blocks the asset must have been confirmed
+	// for before it can be spent. Zero means there is no relative lock
+	// time.
+	CsvDelay uint32
+
+	// Leaf is the single leaf of the tree.
+	Leaf txscript.TapLeaf
+}
+
+// NewTimeLockScriptTree creates the time lock script tree for the given key
+// and lock times. At least one of the lock times must be set.
+func NewTimeLockScriptTree(key *btcec.PublicKey, lockTime,
+	csvDelay uint32) (*TimeLockScriptTree, error) {
+
+	if key == nil {
+		return nil, fmt.Errorf("key must be set")
+	}
+
+	if lockTime == 0 && csvDelay == 0 {
+		return nil, fmt.Errorf("lock time or CSV delay must be set")
+	}
+
+	// We only support block based lock times, so the lock time must be
+	// below the threshold from which on it is interpreted as a timestamp,
+	// and the delay must fit into the lock time bits of the sequence.
+	if lockTime >= txscript.LockTimeThreshold {
+		return nil, fmt.Errorf("lock time must be a block height "+
+			"below %d", uint32(txscript.LockTimeThreshold))
+	}
+	if csvDelay > wire.SequenceLockTimeMask {
+		return nil, fmt.Errorf("CSV delay must be at most %d blocks",
+			wire.SequenceLockTimeMask)
+	}
+
+	builder := txscript.NewScriptBuilder()
+	if lockTime != 0 {
+		builder.AddInt64(int64(lockTime)).
+			AddOp(txscript.OP_CHECKLOCKTIMEVERIFY).
+			AddOp(txscript.OP_DROP)
+	}
+	if csvDelay != 0 {
+		builder.AddInt64(int64(csvDelay)).
+			AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+			AddOp(txscript.OP_DROP)
+	}
+	script, err := builder.
+		AddData(schnorr.SerializePubKey(key)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := txscript.NewBaseTapLeaf(script)
+	tapscriptTree := txscript.AssembleTaprootScriptTree(leaf)
+	tapScriptRoot := tapscriptTree.RootNode.TapHash()
+
+	// We use the NUMS key as the internal key to make sure the key spend
+	// path can't be used to bypass the lock times.
+	taprootKey := txscript.ComputeTaprootOutputKey(
+		&input.TaprootNUMSKey, tapScriptRoot[:],
+	)
+
+	return &TimeLockScriptTree{
+		ScriptTree: input.ScriptTree{
+			InternalKey:   &input.TaprootNUMSKey,
+			TaprootKey:    taprootKey,
+			TapscriptTree: tapscriptTree,
+			TapscriptRoot: tapScriptRoot[:],
+		},
+		Key:      key,
+		LockTime: lockTime,
+		CsvDelay: csvDelay,
+		Leaf:     leaf,
+	}, nil
+}
+
+// ScriptKey returns the asset-level script key of the tree.
+func (t *TimeLockScriptTree) ScriptKey() asset.ScriptKey {
+	scriptKey := asset.NewScriptKey(t.TaprootKey)
+	scriptKey.TweakedScriptKey = &asset.TweakedScriptKey{
+		RawKey: keychain.KeyDescriptor{
+			PubKey: t.InternalKey,
+		},
+		Tweak: t.TapscriptRoot,
+	}
+
+	return scriptKey
+}
+
+// ControlBlock returns the serialized control block that proves the inclusion
+// of the leaf in the tree.
+func (t *TimeLockScriptTree) ControlBlock() ([]byte, error) {
+	proof := t.TapscriptTree.LeafMerkleProofs[0]
+	controlBlock := proof.ToControlBlock(t.InternalKey)
+
+	return controlBlock.ToBytes()
+}
+
+// Witness assembles the witness that spends the tree with the given signature.
+func (t *TimeLockScriptTree) Witness(sig []byte) (wire.TxWitness, error) {
+	controlBlock, err := t.ControlBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.TxWitness{sig, t.Leaf.Script, controlBlock}, nil
+}
+
+// IsMature returns true if an asset locked to the tree that was confirmed at
+// the given height can be spent in a block at the given height.
+func (t *TimeLockScriptTree) IsMature(confirmHeight, height uint32) bool {
+	return height >= t.LockTime && height >= confirmHeight+t.CsvDelay
+}
@@ -0,0 +1,106 @@
+package tapscript
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTimeLockScriptTree tests that the time lock script tree can only be
+// spent with a signature of its key once both the absolute and the relative
+// lock time have passed.
+func TestTimeLockScriptTree(t *testing.T) {
+	t.Parallel()
+
+	const (
+		lockTime = 800_000
+		csvDelay = 144
+	)
+
+	key := test.RandPrivKey()
+
+	// spend executes the witness of the given tree with a signature of the
+	// given key, the given transaction lock time and input sequence.
+	spend := func(tree *TimeLockScriptTree, key *btcec.PrivateKey,
+		txLockTime, sequence uint32) error {
+
+		pkScript, err := txscript.PayToTaprootScript(tree.TaprootKey)
+		require.NoError(t, err)
+		prevOut := &wire.TxOut{Value: 1000, PkScript: pkScript}
+
+		fetcher := txscript.NewCannedPrevOutputFetcher(
+			prevOut.PkScript, prevOut.Value,
+		)
+
+		spendTx := wire.NewMsgTx(2)
+		spendTx.LockTime = txLockTime
+		spendTx.AddTxIn(&wire.TxIn{Sequence: sequence})
+		spendTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: pkScript})
+		sigHashes := txscript.NewTxSigHashes(spendTx, fetcher)
+
+		sig, err := txscript.RawTxInTapscriptSignature(
+			spendTx, sigHashes, 0, prevOut.Value, prevOut.PkScript,
+			tree.Leaf, txscript.SigHashDefault, key,
+		)
+		require.NoError(t, err)
+
+		witness, err := tree.Witness(sig)
+		require.NoError(t, err)
+		spendTx.TxIn[0].Witness = witness
+
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, spendTx, 0,
+			txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, fetcher,
+		)
+		require.NoError(t, err)
+
+		return vm.Execute()
+	}
+
+	tree, err := NewTimeLockScriptTree(key.PubKey(), lockTime, csvDelay)
+	require.NoError(t, err)
+
+	scriptKey := tree.ScriptKey()
+	require.Equal(t, tree.TapscriptRoot, scriptKey.TweakedScriptKey.Tweak)
+
+	// Both lock times must have passed and only the key can sign.
+	require.NoError(t, spend(tree, key, lockTime, csvDelay))
+	require.Error(t, spend(tree, key, lockTime-1, csvDelay))
+	require.Error(t, spend(tree, key, lockTime, csvDelay-1))
+	require.Error(t, spend(tree, test.RandPrivKey(), lockTime, csvDelay))
+
+	// A tree with only one of the lock times only checks that one.
+	absTree, err := NewTimeLockScriptTree(key.PubKey(), lockTime, 0)
+	require.NoError(t, err)
+	require.NoError(t, spend(absTree, key, lockTime, 0))
+	require.Error(t, spend(absTree, key, lockTime-1, 0))
+
+	relTree, err := NewTimeLockScriptTree(key.PubKey(), 0, csvDelay)
+	require.NoError(t, err)
+	require.NoError(t, spend(relTree, key, 0, csvDelay))
+	require.Error(t, spend(relTree, key, 0, csvDelay-1))
+
+	// The tree is mature once both lock times have passed.
+	require.False(t, tree.IsMature(lockTime-csvDelay, lockTime-1))
+	require.False(t, tree.IsMature(lockTime, lockTime+csvDelay-1))
+	require.True(t, tree.IsMature(lockTime, lockTime+csvDelay))
+
+	// Invalid lock times are rejected.
+	_, err = NewTimeLockScriptTree(key.PubKey(), 0, 0)
+	require.ErrorContains(t, err, "must be set")
+
+	_, err = NewTimeLockScriptTree(
+		key.PubKey(), txscript.LockTimeThreshold, 0,
+	)
+	require.ErrorContains(t, err, "block height")
+
+	_, err = NewTimeLockScriptTree(
+		key.PubKey(), 0, wire.SequenceLockTimeMask+1,
+	)
+	require.ErrorContains(t, err, "CSV delay")
+}
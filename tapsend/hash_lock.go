@@ -0,0 +1,132 @@
+package tapsend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// SignHashLockPacket signs all inputs of the given packet, which must be locked
+// to the given hash lock script tree, with the given key for the leaf of the
+// given spend path. The witnesses of the new asset are then assembled and
+// validated. The preimage is only required for the success path. To spend
+// through the timeout path, the relative lock time of the new asset must be at
+// least the CSV delay of the tree. Since the lock time of split assets isn't
+// covered by the split commitment, a timeout sweep must spend the full value of
+// its inputs.
+func SignHashLockPacket(vPkt *tappsbt.VPacket,
+	tree *tapscript.HashLockScriptTree, path tapscript.HashLockPath,
+	preimage []byte, keyDesc keychain.KeyDescriptor,
+	signer tapscript.Signer, validator tapscript.WitnessValidator) error {
+
+	leaf, err := tree.Leaf(path)
+	if err != nil {
+		return err
+	}
+
+	controlBlock, err := tree.ControlBlock(path)
+	if err != nil {
+		return err
+	}
+
+	signingKey, err := tree.SigningKey(path)
+	if err != nil {
+		return err
+	}
+
+	if !keyDesc.PubKey.IsEqual(signingKey) {
+		return fmt.Errorf("key %x can't sign for the %v path",
+			keyDesc.PubKey.SerializeCompressed(), path)
+	}
+
+	if path == tapscript.HashLockSuccess {
+		if err := tree.CheckPreimage(preimage); err != nil {
+			return err
+		}
+	}
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	// The virtual transaction uses the relative lock time of the new asset
+	// as the input sequence, which is what the CSV check of the timeout
+	// leaf is executed against.
+	lockTime := sigCtx.newAsset.RelativeLockTime
+	if path == tapscript.HashLockTimeout &&
+		lockTime < uint64(tree.CsvDelay) {
+
+		return fmt.Errorf("relative lock time %d is below CSV delay %d",
+			lockTime, tree.CsvDelay)
+	}
+
+	hashLockKey := schnorr.SerializePubKey(tree.TaprootKey)
+	xOnlySigningKey := schnorr.SerializePubKey(signingKey)
+	leafHash := leaf.TapHash()
+	for idx := range vPkt.Inputs {
+		vIn := vPkt.Inputs[idx]
+
+		scriptKey := vIn.Asset().ScriptKey.PubKey
+		xOnlyScriptKey := schnorr.SerializePubKey(scriptKey)
+		if !bytes.Equal(xOnlyScriptKey, hashLockKey) {
+			return fmt.Errorf("input %d is not locked to the hash "+
+				"lock script key", idx)
+		}
+
+		vIn.TaprootMerkleRoot = tree.TapscriptRoot
+		vIn.TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+			ControlBlock: controlBlock,
+			Script:       leaf.Script,
+			LeafVersion:  leaf.LeafVersion,
+		}}
+
+		err := signScriptPathInput(
+			vIn, sigCtx.inputVirtualTx(idx), leaf, keyDesc, signer,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to sign input %d: %w", idx,
+				err)
+		}
+
+		sig := findScriptSpendSig(vIn, xOnlySigningKey, leafHash[:])
+		witness, err := tree.Witness(path, sig, preimage)
+		if err != nil {
+			return err
+		}
+
+		sigCtx.newAsset.PrevWitnesses[idx].TxWitness = witness
+	}
+
+	if err := sigCtx.validate(vPkt, validator); err != nil {
+		return fmt.Errorf("invalid hash lock witness: %w", err)
+	}
+
+	return nil
+}
+
+// HashLockWitnessPreimage extracts the preimage revealed by a witness that
+// spends a hash lock script tree through the success path. False is returned
+// if the witness doesn't spend through the success path of the given tree.
+func HashLockWitnessPreimage(tree *tapscript.HashLockScriptTree,
+	witness wire.TxWitness) ([]byte, bool) {
+
+	if len(witness) != 4 ||
+		!bytes.Equal(witness[2], tree.SuccessLeaf.Script) {
+
+		return nil, false
+	}
+
+	preimage := witness[1]
+	if tree.CheckPreimage(preimage) != nil {
+		return nil, false
+	}
+
+	return preimage, true
+}
@@ -0,0 +1,125 @@
+package tapsend_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignHashLockPacket tests that a packet spending an asset locked to a
+// hash lock script key can be swept through both spend paths, and that the
+// preimage can be extracted from the witness of the success path.
+func TestSignHashLockPacket(t *testing.T) {
+	t.Parallel()
+
+	const csvDelay = 10
+
+	claimKey := test.RandPrivKey()
+	refundKey := test.RandPrivKey()
+	preimage := test.RandBytes(32)
+
+	tree, err := tapscript.NewHashLockScriptTree(
+		sha256.Sum256(preimage), claimKey.PubKey(), refundKey.PubKey(),
+		csvDelay,
+	)
+	require.NoError(t, err)
+
+	// newPacket creates a full value packet that sweeps an asset locked to
+	// the hash lock script key, with the given relative lock time.
+	newPacket := func(lockTime uint64) (*tappsbt.VPacket, *spendData) {
+		state := initSpendScenario(t)
+
+		state.asset2.ScriptKey = tree.ScriptKey()
+		state.asset2PrevID.ScriptKey = asset.ToSerialized(
+			state.asset2.ScriptKey.PubKey,
+		)
+		state.asset2InputAssets = commitment.InputSet{
+			state.asset2PrevID: &state.asset2,
+		}
+
+		pkt := createPacket(
+			state.address2, state.asset2PrevID, state,
+			state.asset2InputAssets, true,
+		)
+		pkt.Outputs[0].RelativeLockTime = lockTime
+
+		err := tapsend.PrepareOutputAssets(context.Background(), pkt)
+		require.NoError(t, err)
+
+		return pkt, &state
+	}
+
+	// sign signs the packet for the given path with the given key.
+	sign := func(pkt *tappsbt.VPacket, state *spendData,
+		path tapscript.HashLockPath, key *btcec.PrivateKey,
+		preimage []byte) error {
+
+		keyDesc := keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		}
+
+		return tapsend.SignHashLockPacket(
+			pkt, tree, path, preimage, keyDesc,
+			tapscript.NewMockSigner(key), state.witnessValidator,
+		)
+	}
+
+	// The claimer can sweep the asset by revealing the preimage, which
+	// the refunder can then extract from the witness.
+	pkt, state := newPacket(0)
+	err = sign(pkt, state, tapscript.HashLockSuccess, claimKey, preimage)
+	require.NoError(t, err)
+
+	witness := pkt.Outputs[0].Asset.PrevWitnesses[0].TxWitness
+	revealed, ok := tapsend.HashLockWitnessPreimage(tree, witness)
+	require.True(t, ok)
+	require.Equal(t, preimage, revealed)
+
+	// A wrong preimage or key can't be used for the success path.
+	pkt, state = newPacket(0)
+	err = sign(
+		pkt, state, tapscript.HashLockSuccess, claimKey,
+		test.RandBytes(32),
+	)
+	require.ErrorContains(t, err, "doesn't match payment hash")
+
+	err = sign(pkt, state, tapscript.HashLockSuccess, refundKey, preimage)
+	require.ErrorContains(t, err, "can't sign for the success path")
+
+	// The refunder can only sweep the asset through the timeout path once
+	// the new asset is locked for the CSV delay.
+	pkt, state = newPacket(csvDelay - 1)
+	err = sign(pkt, state, tapscript.HashLockTimeout, refundKey, nil)
+	require.ErrorContains(t, err, "below CSV delay")
+
+	pkt, state = newPacket(csvDelay)
+	err = sign(pkt, state, tapscript.HashLockTimeout, refundKey, nil)
+	require.NoError(t, err)
+
+	witness = pkt.Outputs[0].Asset.PrevWitnesses[0].TxWitness
+	_, ok = tapsend.HashLockWitnessPreimage(tree, witness)
+	require.False(t, ok)
+
+	// An input that isn't locked to the hash lock script key can't be
+	// signed.
+	state2 := initSpendScenario(t)
+	pkt = createPacket(
+		state2.address1, state2.asset2PrevID, state2,
+		state2.asset2InputAssets, false,
+	)
+	require.NoError(t, tapsend.PrepareOutputAssets(
+		context.Background(), pkt,
+	))
+	err = sign(pkt, &state2, tapscript.HashLockSuccess, claimKey, preimage)
+	require.ErrorContains(t, err, "not locked to the hash lock")
+}
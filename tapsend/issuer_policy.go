@@ -0,0 +1,183 @@
+package tapsend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// SignIssuerPolicyPacket signs all inputs of the given packet, which must be
+// locked to the given issuer policy script tree, with the given keys for the
+// leaf of the given spend path. The signatures are stored as partial script
+// spend signatures in the inputs, so the packet can be passed on to the other
+// signer of the path. Once all signatures the path requires are present, the
+// witnesses of the new asset are assembled and validated. The returned boolean
+// indicates whether the packet is fully signed.
+func SignIssuerPolicyPacket(vPkt *tappsbt.VPacket,
+	tree *tapscript.IssuerPolicyScriptTree, path tapscript.IssuerPolicyPath,
+	keys []keychain.KeyDescriptor, signer tapscript.Signer,
+	validator tapscript.WitnessValidator) (bool, error) {
+
+	leaf, err := tree.Leaf(path)
+	if err != nil {
+		return false, err
+	}
+
+	controlBlock, err := tree.ControlBlock(path)
+	if err != nil {
+		return false, err
+	}
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return false, err
+	}
+
+	policyKey := schnorr.SerializePubKey(tree.TaprootKey)
+	for idx := range vPkt.Inputs {
+		vIn := vPkt.Inputs[idx]
+
+		scriptKey := vIn.Asset().ScriptKey.PubKey
+		if !bytes.Equal(schnorr.SerializePubKey(scriptKey), policyKey) {
+			return false, fmt.Errorf("input %d is not locked to "+
+				"the issuer policy script key", idx)
+		}
+
+		vIn.TaprootMerkleRoot = tree.TapscriptRoot
+		vIn.TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+			ControlBlock: controlBlock,
+			Script:       leaf.Script,
+			LeafVersion:  leaf.LeafVersion,
+		}}
+
+		for _, keyDesc := range keys {
+			err := signScriptPathInput(
+				vIn, sigCtx.inputVirtualTx(idx), leaf, keyDesc,
+				signer,
+			)
+			if err != nil {
+				return false, fmt.Errorf("unable to sign "+
+					"input %d: %w", idx, err)
+			}
+		}
+	}
+
+	// With our signatures added, we check whether all required signatures
+	// are present, in which case we can finalize the witnesses.
+	requiredKeys, err := tree.RequiredKeys(path)
+	if err != nil {
+		return false, err
+	}
+
+	leafHash := leaf.TapHash()
+	witnesses := make([]wire.TxWitness, len(vPkt.Inputs))
+	for idx := range vPkt.Inputs {
+		vIn := vPkt.Inputs[idx]
+
+		sigs := make([][]byte, 0, len(requiredKeys))
+		for _, key := range requiredKeys {
+			sig := findScriptSpendSig(
+				vIn, schnorr.SerializePubKey(key), leafHash[:],
+			)
+			if sig == nil {
+				return false, nil
+			}
+
+			sigs = append(sigs, sig)
+		}
+
+		witnesses[idx], err = tree.Witness(path, sigs)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for idx := range witnesses {
+		sigCtx.newAsset.PrevWitnesses[idx].TxWitness = witnesses[idx]
+	}
+
+	if err := sigCtx.validate(vPkt, validator); err != nil {
+		return false, fmt.Errorf("invalid issuer policy witness: %w",
+			err)
+	}
+
+	return true, nil
+}
+
+// signScriptPathInput creates the signature of the given key over the given
+// input-specific virtual transaction and stores it as a partial script spend
+// signature of the input, replacing any previous signature of the same key.
+func signScriptPathInput(vIn *tappsbt.VInput, virtualTx *wire.MsgTx,
+	leaf txscript.TapLeaf, keyDesc keychain.KeyDescriptor,
+	signer tapscript.Signer) error {
+
+	prevOut, err := tapscript.InputAssetPrevOut(*vIn.Asset())
+	if err != nil {
+		return err
+	}
+
+	spendDesc := lndclient.SignDescriptor{
+		KeyDesc:       keyDesc,
+		SignMethod:    input.TaprootScriptSpendSignMethod,
+		WitnessScript: leaf.Script,
+		Output:        prevOut,
+		HashType:      vIn.SighashType,
+	}
+
+	sig, err := signer.SignVirtualTx(&spendDesc, virtualTx, prevOut)
+	if err != nil {
+		return err
+	}
+
+	sigBytes := sig.Serialize()
+	if vIn.SighashType != txscript.SigHashDefault {
+		sigBytes = append(sigBytes, byte(vIn.SighashType))
+	}
+
+	xOnlyKey := schnorr.SerializePubKey(keyDesc.PubKey)
+	leafHash := leaf.TapHash()
+	scriptSpendSig := &psbt.TaprootScriptSpendSig{
+		XOnlyPubKey: xOnlyKey,
+		LeafHash:    leafHash[:],
+		Signature:   sigBytes,
+		SigHash:     vIn.SighashType,
+	}
+
+	for idx, existing := range vIn.TaprootScriptSpendSig {
+		if bytes.Equal(existing.XOnlyPubKey, xOnlyKey) &&
+			bytes.Equal(existing.LeafHash, leafHash[:]) {
+
+			vIn.TaprootScriptSpendSig[idx] = scriptSpendSig
+			return nil
+		}
+	}
+
+	vIn.TaprootScriptSpendSig = append(
+		vIn.TaprootScriptSpendSig, scriptSpendSig,
+	)
+
+	return nil
+}
+
+// findScriptSpendSig returns the partial script spend signature of the given
+// key for the given leaf, or nil if the input doesn't have one.
+func findScriptSpendSig(vIn *tappsbt.VInput, xOnlyKey, leafHash []byte) []byte {
+	for _, sig := range vIn.TaprootScriptSpendSig {
+		if bytes.Equal(sig.XOnlyPubKey, xOnlyKey) &&
+			bytes.Equal(sig.LeafHash, leafHash) {
+
+			return sig.Signature
+		}
+	}
+
+	return nil
+}
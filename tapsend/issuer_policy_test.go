@@ -0,0 +1,120 @@
+package tapsend_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignIssuerPolicyPacket tests that a packet spending an asset locked to
+// an issuer policy script key is only fully signed once all keys of the spend
+// path have signed.
+func TestSignIssuerPolicyPacket(t *testing.T) {
+	t.Parallel()
+
+	holderKey := test.RandPrivKey()
+	issuerKey := test.RandPrivKey()
+
+	tree, err := tapscript.NewIssuerPolicyScriptTree(
+		holderKey.PubKey(), issuerKey.PubKey(),
+	)
+	require.NoError(t, err)
+
+	// newPacket creates a split packet that spends an asset locked to the
+	// issuer policy script key.
+	newPacket := func() (*tappsbt.VPacket, *spendData) {
+		state := initSpendScenario(t)
+
+		state.asset2.ScriptKey = tree.ScriptKey()
+		state.asset2PrevID.ScriptKey = asset.ToSerialized(
+			state.asset2.ScriptKey.PubKey,
+		)
+		state.asset2InputAssets = commitment.InputSet{
+			state.asset2PrevID: &state.asset2,
+		}
+
+		pkt := createPacket(
+			state.address1, state.asset2PrevID, state,
+			state.asset2InputAssets, false,
+		)
+		err := tapsend.PrepareOutputAssets(context.Background(), pkt)
+		require.NoError(t, err)
+
+		return pkt, &state
+	}
+
+	// sign signs the packet for the given path with the given key.
+	sign := func(pkt *tappsbt.VPacket, state *spendData,
+		path tapscript.IssuerPolicyPath,
+		key *btcec.PrivateKey) (bool, error) {
+
+		keyDesc := keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		}
+
+		return tapsend.SignIssuerPolicyPacket(
+			pkt, tree, path, []keychain.KeyDescriptor{keyDesc},
+			tapscript.NewMockSigner(key), state.witnessValidator,
+		)
+	}
+
+	// A regular transfer needs the signature of the holder and the issuer.
+	pkt, state := newPacket()
+	signed, err := sign(pkt, state, tapscript.IssuerPolicyCoSign, holderKey)
+	require.NoError(t, err)
+	require.False(t, signed)
+	require.Len(t, pkt.Inputs[0].TaprootScriptSpendSig, 1)
+
+	// The partial signature of the holder survives the serialization of
+	// the packet, so it can be handed to the issuer.
+	var buf bytes.Buffer
+	require.NoError(t, pkt.Serialize(&buf))
+	pkt, err = tappsbt.Decode(buf.Bytes())
+	require.NoError(t, err)
+
+	signed, err = sign(pkt, state, tapscript.IssuerPolicyCoSign, issuerKey)
+	require.NoError(t, err)
+	require.True(t, signed)
+
+	witness := pkt.Outputs[0].Asset.PrevWitnesses[0].TxWitness
+	require.Len(t, witness, 4)
+	require.Equal(t, tree.CoSignLeaf.Script, witness[2])
+
+	// The issuer alone can claw back the asset.
+	pkt, state = newPacket()
+	signed, err = sign(
+		pkt, state, tapscript.IssuerPolicyClawback, issuerKey,
+	)
+	require.NoError(t, err)
+	require.True(t, signed)
+
+	// The holder's signature isn't enough for the clawback path.
+	pkt, state = newPacket()
+	signed, err = sign(
+		pkt, state, tapscript.IssuerPolicyClawback, holderKey,
+	)
+	require.NoError(t, err)
+	require.False(t, signed)
+
+	// An input that isn't locked to the policy script key can't be signed.
+	state2 := initSpendScenario(t)
+	pkt = createPacket(
+		state2.address1, state2.asset2PrevID, state2,
+		state2.asset2InputAssets, false,
+	)
+	require.NoError(t, tapsend.PrepareOutputAssets(
+		context.Background(), pkt,
+	))
+	_, err = sign(pkt, &state2, tapscript.IssuerPolicyCoSign, holderKey)
+	require.ErrorContains(t, err, "not locked to the issuer policy")
+}
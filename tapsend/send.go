@@ -20,6 +20,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btclog"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/taproot-assets/account"
 	"github.com/lightninglabs/taproot-assets/address"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/commitment"
@@ -27,6 +28,7 @@ import (
 	"github.com/lightninglabs/taproot-assets/tappsbt"
 	"github.com/lightninglabs/taproot-assets/tapscript"
 	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"golang.org/x/exp/maps"
 )
@@ -203,6 +205,36 @@ type FundingDescriptor struct {
 
 	// CoinSelectType specifies the type of coins that should be selected.
 	CoinSelectType CoinSelectType
+
+	// Account is the account the transferred assets are selected from and
+	// the change and anchor keys are derived for. If this is None, the
+	// default account is used.
+	Account fn.Option[account.Account]
+
+	// Reserver identifies the subsystem of the daemon the selected inputs
+	// are reserved for. If this is empty, the inputs are reserved for the
+	// wallet.
+	Reserver string
+
+	// ExactAmount indicates that the selected inputs must sum to exactly
+	// the amount, so the transfer doesn't need an asset change output.
+	ExactAmount bool
+
+	// ScriptKey restricts the selected inputs to assets locked to the given
+	// script key. If this is nil, assets of all script keys are eligible.
+	ScriptKey *btcec.PublicKey
+
+	// ReusableAddrKeys maps the index of each virtual output that pays to
+	// a reusable address to the base script key of that address. The
+	// script keys of those outputs can only be derived once the inputs of
+	// the transfer are selected.
+	ReusableAddrKeys map[int]*btcec.PublicKey
+}
+
+// KeyFamily returns the key family new keys for the transfer should be derived
+// from.
+func (r *FundingDescriptor) KeyFamily() keychain.KeyFamily {
+	return r.Account.UnwrapOr(account.Default).KeyFamily
 }
 
 // TapCommitmentKey is the key that maps to the root commitment for the asset
@@ -267,6 +299,17 @@ func DescribeAddrs(addrs []*address.Tap) (*FundingDescriptor, error) {
 	}
 	for idx := range addrs {
 		desc.Amount += addrs[idx].Amount
+
+		if !addrs[idx].Reusable {
+			continue
+		}
+
+		// The outputs for the addresses follow the change output of
+		// the virtual packet created by tappsbt.FromAddresses.
+		if desc.ReusableAddrKeys == nil {
+			desc.ReusableAddrKeys = make(map[int]*btcec.PublicKey)
+		}
+		desc.ReusableAddrKeys[idx+1] = &addrs[idx].ScriptKey
 	}
 
 	return desc, nil
@@ -750,6 +793,176 @@ func PrepareOutputAssets(ctx context.Context, vPkt *tappsbt.VPacket) error {
 func SignVirtualTransaction(vPkt *tappsbt.VPacket, signer tapscript.Signer,
 	validator tapscript.WitnessValidator) error {
 
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	for idx := range vPkt.Inputs {
+		in := vPkt.Inputs[idx]
+
+		// For each input asset leaf, we need to produce a witness.
+		// Update the input of the virtual TX, generate a witness, and
+		// attach it to the copy of the new Asset.
+		inputSpecificVirtualTx := sigCtx.inputVirtualTx(idx)
+
+		// Sign the virtual transaction based on the input script
+		// information (key spend or script spend).
+		newWitness, err := CreateTaprootSignature(
+			in, inputSpecificVirtualTx, 0, signer,
+		)
+		if err != nil {
+			return fmt.Errorf("error creating taproot "+
+				"signature: %w", err)
+		}
+
+		sigCtx.newAsset.PrevWitnesses[idx].TxWitness = newWitness
+	}
+
+	return sigCtx.validate(vPkt, validator)
+}
+
+// SignVirtualInputs creates the witnesses of the inputs with the given indexes
+// and attaches them to the new asset. In contrast to SignVirtualTransaction,
+// the witnesses aren't validated, since the remaining inputs of the packet are
+// expected to be signed by other parties. Once all witnesses are attached, they
+// can be validated with ValidateVirtualWitnesses.
+func SignVirtualInputs(vPkt *tappsbt.VPacket, signer tapscript.Signer,
+	inputIdxs []uint32) error {
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	for _, idx := range inputIdxs {
+		if int(idx) >= len(vPkt.Inputs) {
+			return fmt.Errorf("input index %d out of range", idx)
+		}
+
+		newWitness, err := CreateTaprootSignature(
+			vPkt.Inputs[idx], sigCtx.inputVirtualTx(int(idx)), 0,
+			signer,
+		)
+		if err != nil {
+			return fmt.Errorf("error creating taproot signature "+
+				"for input %d: %w", idx, err)
+		}
+
+		sigCtx.newAsset.PrevWitnesses[idx].TxWitness = newWitness
+	}
+
+	return nil
+}
+
+// VirtualInputSigHash returns the message that a key spend signature of the
+// input with the given index must commit to, using the default sighash type.
+// This allows the witness of an input to be created outside of the wallet, for
+// example by the participants of a MuSig2 signing session.
+func VirtualInputSigHash(vPkt *tappsbt.VPacket, idx uint32) ([32]byte,
+	error) {
+
+	var sigHash [32]byte
+	if int(idx) >= len(vPkt.Inputs) {
+		return sigHash, fmt.Errorf("input index %d out of range", idx)
+	}
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return sigHash, err
+	}
+
+	msg, err := tapscript.InputKeySpendSigHash(
+		sigCtx.virtualTx.Copy(), vPkt.Inputs[idx].Asset(),
+		sigCtx.newAsset, idx, txscript.SigHashDefault,
+	)
+	if err != nil {
+		return sigHash, err
+	}
+
+	copy(sigHash[:], msg)
+
+	return sigHash, nil
+}
+
+// VirtualWitnesses returns the hash of the virtual transaction of the packet,
+// which doesn't commit to any witnesses, and the witnesses that are currently
+// attached to each of its inputs. Inputs without a witness have an empty entry.
+func VirtualWitnesses(vPkt *tappsbt.VPacket) (chainhash.Hash,
+	[]wire.TxWitness, error) {
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return chainhash.Hash{}, nil, err
+	}
+
+	witnesses := make([]wire.TxWitness, len(vPkt.Inputs))
+	for idx := range vPkt.Inputs {
+		witnesses[idx] = sigCtx.newAsset.PrevWitnesses[idx].TxWitness
+	}
+
+	return sigCtx.virtualTx.TxHash(), witnesses, nil
+}
+
+// SetVirtualWitness attaches the given witness to the input with the given
+// index, replacing any witness the input already has.
+func SetVirtualWitness(vPkt *tappsbt.VPacket, idx uint32,
+	witness wire.TxWitness) error {
+
+	if int(idx) >= len(vPkt.Inputs) {
+		return fmt.Errorf("input index %d out of range", idx)
+	}
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	sigCtx.newAsset.PrevWitnesses[idx].TxWitness = witness
+
+	return nil
+}
+
+// ValidateVirtualWitnesses validates the witnesses that are attached to the
+// inputs of the packet and, in case of a split transfer, updates the split
+// assets with the signed root asset.
+func ValidateVirtualWitnesses(vPkt *tappsbt.VPacket,
+	validator tapscript.WitnessValidator) error {
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	for idx := range vPkt.Inputs {
+		if len(sigCtx.newAsset.PrevWitnesses[idx].TxWitness) == 0 {
+			return fmt.Errorf("input %d is missing a witness", idx)
+		}
+	}
+
+	return sigCtx.validate(vPkt, validator)
+}
+
+// virtualSigningContext bundles the assets and the virtual transaction that
+// are needed to create and validate the witnesses of a virtual packet.
+type virtualSigningContext struct {
+	// newAsset is the asset that receives the witnesses.
+	newAsset *asset.Asset
+
+	// splitAssets are the split assets of a split transfer.
+	splitAssets []*commitment.SplitAsset
+
+	// prevAssets are the assets spent by the packet.
+	prevAssets commitment.InputSet
+
+	// virtualTx is the virtual transaction of the transfer.
+	virtualTx *wire.MsgTx
+}
+
+// newVirtualSigningContext creates the signing context of the given packet.
+func newVirtualSigningContext(
+	vPkt *tappsbt.VPacket) (*virtualSigningContext, error) {
+
 	inputs := vPkt.Inputs
 	outputs := vPkt.Outputs
 
@@ -757,7 +970,7 @@ func SignVirtualTransaction(vPkt *tappsbt.VPacket, signer tapscript.Signer,
 	// the root asset, which is located at the change output.
 	isSplit, err := vPkt.HasSplitCommitment()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Identify new output asset. For splits, the new asset that receives
@@ -767,8 +980,8 @@ func SignVirtualTransaction(vPkt *tappsbt.VPacket, signer tapscript.Signer,
 	if isSplit {
 		splitOut, err := vPkt.SplitRootOutput()
 		if err != nil {
-			return fmt.Errorf("no split root output found for "+
-				"split transaction: %w", err)
+			return nil, fmt.Errorf("no split root output found "+
+				"for split transaction: %w", err)
 		}
 		newAsset = splitOut.Asset
 
@@ -805,60 +1018,59 @@ func SignVirtualTransaction(vPkt *tappsbt.VPacket, signer tapscript.Signer,
 	// transfer.
 	virtualTx, _, err := tapscript.VirtualTx(newAsset, prevAssets)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for idx := range inputs {
-		in := inputs[idx]
-
-		// For each input asset leaf, we need to produce a witness.
-		// Update the input of the virtual TX, generate a witness, and
-		// attach it to the copy of the new Asset.
-		virtualTxCopy := virtualTx.Copy()
-		inputSpecificVirtualTx := asset.VirtualTxWithInput(
-			virtualTxCopy, newAsset.LockTime,
-			newAsset.RelativeLockTime, uint32(idx), nil,
-		)
+	return &virtualSigningContext{
+		newAsset:    newAsset,
+		splitAssets: splitAssets,
+		prevAssets:  prevAssets,
+		virtualTx:   virtualTx,
+	}, nil
+}
 
-		// Sign the virtual transaction based on the input script
-		// information (key spend or script spend).
-		newWitness, err := CreateTaprootSignature(
-			in, inputSpecificVirtualTx, 0, signer,
-		)
-		if err != nil {
-			return fmt.Errorf("error creating taproot "+
-				"signature: %w", err)
-		}
+// inputVirtualTx returns the virtual transaction that the witness of the input
+// with the given index signs.
+func (c *virtualSigningContext) inputVirtualTx(idx int) *wire.MsgTx {
+	virtualTxCopy := c.virtualTx.Copy()
+	return asset.VirtualTxWithInput(
+		virtualTxCopy, c.newAsset.LockTime,
+		c.newAsset.RelativeLockTime, uint32(idx), nil,
+	)
+}
 
-		newAsset.PrevWitnesses[idx].TxWitness = newWitness
-	}
+// validate validates the witnesses of the new asset and, in case of a split
+// transfer, updates the split assets with the signed root asset.
+func (c *virtualSigningContext) validate(vPkt *tappsbt.VPacket,
+	validator tapscript.WitnessValidator) error {
 
-	err = validator.ValidateWitnesses(newAsset, splitAssets, prevAssets)
+	err := validator.ValidateWitnesses(
+		c.newAsset, c.splitAssets, c.prevAssets,
+	)
 	if err != nil {
 		return err
 	}
 
-	if isSplit {
-		// Update each split asset to store the root asset with the
-		// witness attached, so the receiver can verify inclusion of the
-		// root asset.
-		for idx := range outputs {
-			splitAsset := outputs[idx].Asset
+	if len(c.splitAssets) == 0 {
+		return nil
+	}
 
-			// The output that houses the root asset in case of a
-			// split has a special field for the split asset. That
-			// asset is no longer needed (and isn't committed to
-			// anywhere), but in order for it to be validated
-			// externally, we still want to include it and therefore
-			// also want to update it with the signed root asset.
-			if outputs[idx].Type.IsSplitRoot() {
-				splitAsset = outputs[idx].SplitAsset
-			}
+	// Update each split asset to store the root asset with the witness
+	// attached, so the receiver can verify inclusion of the root asset.
+	for idx := range vPkt.Outputs {
+		splitAsset := vPkt.Outputs[idx].Asset
 
-			splitCommitment :=
-				splitAsset.PrevWitnesses[0].SplitCommitment
-			splitCommitment.RootAsset = *newAsset.Copy()
+		// The output that houses the root asset in case of a split has
+		// a special field for the split asset. That asset is no longer
+		// needed (and isn't committed to anywhere), but in order for it
+		// to be validated externally, we still want to include it and
+		// therefore also want to update it with the signed root asset.
+		if vPkt.Outputs[idx].Type.IsSplitRoot() {
+			splitAsset = vPkt.Outputs[idx].SplitAsset
 		}
+
+		splitCommitment := splitAsset.PrevWitnesses[0].SplitCommitment
+		splitCommitment.RootAsset = *c.newAsset.Copy()
 	}
 
 	return nil
@@ -0,0 +1,126 @@
+package tapsend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+)
+
+// ValidateSwapOutput makes sure the given virtual packet only spends inputs of
+// the given asset and sends exactly the given amount of it to a single output
+// with the given script key and anchor internal key, without any time lock.
+// This is the output the receiving party of one leg of an atomic swap expects.
+func ValidateSwapOutput(vPkt *tappsbt.VPacket, assetID asset.ID,
+	amount uint64, scriptKey, internalKey *btcec.PublicKey) error {
+
+	for idx, vIn := range vPkt.Inputs {
+		if vIn.PrevID.ID != assetID {
+			return fmt.Errorf("input %d spends asset %v instead "+
+				"of %v", idx, vIn.PrevID.ID, assetID)
+		}
+	}
+
+	var numOutputs int
+	for idx, vOut := range vPkt.Outputs {
+		if vOut.ScriptKey.PubKey == nil ||
+			!vOut.ScriptKey.PubKey.IsEqual(scriptKey) {
+
+			continue
+		}
+		numOutputs++
+
+		if vOut.Amount != amount {
+			return fmt.Errorf("output %d sends %d units instead "+
+				"of %d", idx, vOut.Amount, amount)
+		}
+
+		anchorKey := vOut.AnchorOutputInternalKey
+		if anchorKey == nil || !anchorKey.IsEqual(internalKey) {
+			return fmt.Errorf("output %d isn't anchored to the "+
+				"expected internal key", idx)
+		}
+
+		if vOut.LockTime != 0 || vOut.RelativeLockTime != 0 {
+			return fmt.Errorf("output %d is time locked", idx)
+		}
+	}
+	if numOutputs != 1 {
+		return fmt.Errorf("expected exactly one output to the script "+
+			"key, got %d", numOutputs)
+	}
+
+	return nil
+}
+
+// ValidateSwapAnchors makes sure the packets of the two parties of an atomic
+// swap don't share any anchor outputs. Each party only logs the transfer of
+// its own packets, which requires every anchor output to only commit to the
+// outputs of a single party.
+func ValidateSwapAnchors(ours, theirs []*tappsbt.VPacket) error {
+	ourAnchors := make(map[uint32]struct{})
+	for _, vPkt := range ours {
+		for _, vOut := range vPkt.Outputs {
+			ourAnchors[vOut.AnchorOutputIndex] = struct{}{}
+		}
+	}
+
+	for _, vPkt := range theirs {
+		for _, vOut := range vPkt.Outputs {
+			_, ok := ourAnchors[vOut.AnchorOutputIndex]
+			if ok {
+				return fmt.Errorf("%w: anchor output %d is "+
+					"shared by both parties",
+					ErrInvalidOutputIndexes,
+					vOut.AnchorOutputIndex)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateOutputProofFile creates the full proof file of an output that was
+// created by a transfer of another party, from the output's proof suffix and
+// the proof files of the inputs that were spent. The suffix must already be
+// updated with the block the anchor transaction confirmed in.
+func CreateOutputProofFile(proofSuffix *proof.Proof,
+	inputProofs []proof.Blob) (proof.Blob, error) {
+
+	if len(inputProofs) == 0 {
+		return nil, fmt.Errorf("no input proofs given")
+	}
+
+	proofFile, err := proof.DecodeFile(inputProofs[0])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding input proof: %w", err)
+	}
+
+	// If there are more inputs, then this is a merge, and we need to add
+	// those additional files to the suffix as well.
+	for idx := 1; idx < len(inputProofs); idx++ {
+		additionalFile, err := proof.DecodeFile(inputProofs[idx])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding input proof "+
+				"%d: %w", idx, err)
+		}
+
+		proofSuffix.AdditionalInputs = append(
+			proofSuffix.AdditionalInputs, *additionalFile,
+		)
+	}
+
+	if err := proofFile.AppendProof(*proofSuffix); err != nil {
+		return nil, fmt.Errorf("error appending proof: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := proofFile.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("error encoding proof: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
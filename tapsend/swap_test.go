@@ -0,0 +1,105 @@
+package tapsend_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/stretchr/testify/require"
+)
+
+// newSwapPacket creates a packet that spends the given asset and sends the
+// given amount to the given keys at anchor output 1, with change anchored at
+// output 0.
+func newSwapPacket(t *testing.T, assetID asset.ID, amount uint64,
+	scriptKey, internalKey *btcec.PublicKey) *tappsbt.VPacket {
+
+	return &tappsbt.VPacket{
+		Inputs: []*tappsbt.VInput{{
+			PrevID: asset.PrevID{
+				OutPoint: test.RandOp(t),
+				ID:       assetID,
+			},
+		}},
+		Outputs: []*tappsbt.VOutput{{
+			Amount:                  10,
+			Type:                    tappsbt.TypeSplitRoot,
+			ScriptKey:               asset.RandScriptKey(t),
+			AnchorOutputIndex:       0,
+			AnchorOutputInternalKey: test.RandPubKey(t),
+		}, {
+			Amount:                  amount,
+			ScriptKey:               asset.NewScriptKey(scriptKey),
+			AnchorOutputIndex:       1,
+			AnchorOutputInternalKey: internalKey,
+		}},
+	}
+}
+
+// TestValidateSwapOutput tests that the output of a swap leg must send exactly
+// the expected amount of the expected asset to the expected keys.
+func TestValidateSwapOutput(t *testing.T) {
+	t.Parallel()
+
+	var assetID asset.ID
+	copy(assetID[:], test.RandBytes(32))
+	scriptKey := test.RandPubKey(t)
+	internalKey := test.RandPubKey(t)
+
+	validate := func(vPkt *tappsbt.VPacket) error {
+		return tapsend.ValidateSwapOutput(
+			vPkt, assetID, 40, scriptKey, internalKey,
+		)
+	}
+
+	vPkt := newSwapPacket(t, assetID, 40, scriptKey, internalKey)
+	require.NoError(t, validate(vPkt))
+
+	vPkt = newSwapPacket(t, asset.ID{1}, 40, scriptKey, internalKey)
+	require.ErrorContains(t, validate(vPkt), "spends asset")
+
+	vPkt = newSwapPacket(t, assetID, 39, scriptKey, internalKey)
+	require.ErrorContains(t, validate(vPkt), "sends 39 units instead of 40")
+
+	vPkt = newSwapPacket(t, assetID, 40, scriptKey, test.RandPubKey(t))
+	require.ErrorContains(t, validate(vPkt), "expected internal key")
+
+	vPkt = newSwapPacket(t, assetID, 40, test.RandPubKey(t), internalKey)
+	require.ErrorContains(t, validate(vPkt), "got 0")
+
+	vPkt = newSwapPacket(t, assetID, 40, scriptKey, internalKey)
+	vPkt.Outputs[1].RelativeLockTime = 10
+	require.ErrorContains(t, validate(vPkt), "time locked")
+
+	vPkt = newSwapPacket(t, assetID, 40, scriptKey, internalKey)
+	vPkt.Outputs = append(vPkt.Outputs, vPkt.Outputs[1])
+	require.ErrorContains(t, validate(vPkt), "got 2")
+}
+
+// TestValidateSwapAnchors tests that the packets of the two parties of a swap
+// can't share anchor outputs.
+func TestValidateSwapAnchors(t *testing.T) {
+	t.Parallel()
+
+	ours := newSwapPacket(
+		t, asset.ID{1}, 40, test.RandPubKey(t), test.RandPubKey(t),
+	)
+	theirs := newSwapPacket(
+		t, asset.ID{2}, 30, test.RandPubKey(t), test.RandPubKey(t),
+	)
+
+	err := tapsend.ValidateSwapAnchors(
+		[]*tappsbt.VPacket{ours}, []*tappsbt.VPacket{theirs},
+	)
+	require.ErrorIs(t, err, tapsend.ErrInvalidOutputIndexes)
+
+	theirs.Outputs[0].AnchorOutputIndex = 2
+	theirs.Outputs[1].AnchorOutputIndex = 3
+	err = tapsend.ValidateSwapAnchors(
+		[]*tappsbt.VPacket{ours}, []*tappsbt.VPacket{theirs},
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,175 @@
+package tapsend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// TimeLockedOutput describes a recipient output of a transfer that locks the
+// sent asset to a time lock script key, for example to vest the asset to the
+// recipient.
+type TimeLockedOutput struct {
+	// Amount is the amount of the asset sent to the output.
+	Amount uint64
+
+	// Tree is the time lock script tree of the output's script key.
+	Tree *tapscript.TimeLockScriptTree
+
+	// AnchorInternalKey is the internal key of the BTC output that anchors
+	// the asset. If the key isn't set, a new key of the wallet is used.
+	AnchorInternalKey *keychain.KeyDescriptor
+}
+
+// CreateTimeLockedPacket creates a virtual packet that sends the asset with the
+// given ID to the given time locked outputs, each of which is anchored in its
+// own BTC output, starting at anchor output index 1. Like for sends to
+// addresses, an un-spendable split root output is added at anchor output
+// index 0, which becomes the change output during funding if the inputs
+// exceed the sent amount.
+func CreateTimeLockedPacket(id asset.ID, outputs []*TimeLockedOutput,
+	assetVersion asset.Version,
+	chainParams *address.ChainParams) (*tappsbt.VPacket, error) {
+
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("at least one time locked output must " +
+			"be specified")
+	}
+
+	vPkt := &tappsbt.VPacket{
+		Inputs: []*tappsbt.VInput{{
+			PrevID: asset.PrevID{
+				ID: id,
+			},
+		}},
+		Outputs: []*tappsbt.VOutput{{
+			Amount:            0,
+			Type:              tappsbt.TypeSplitRoot,
+			AnchorOutputIndex: 0,
+			ScriptKey:         asset.NUMSScriptKey,
+		}},
+		ChainParams: chainParams,
+		Version:     tappsbt.V1,
+	}
+
+	for idx, out := range outputs {
+		if out.Amount == 0 {
+			return nil, fmt.Errorf("time locked output %d has "+
+				"zero amount", idx)
+		}
+
+		if out.Tree == nil {
+			return nil, fmt.Errorf("time locked output %d has no "+
+				"script tree", idx)
+		}
+
+		// The recipients are aware of the time lock script keys, so we
+		// treat the outputs as interactive.
+		vOut := &tappsbt.VOutput{
+			Amount:            out.Amount,
+			AssetVersion:      assetVersion,
+			Type:              tappsbt.TypeSimple,
+			Interactive:       true,
+			AnchorOutputIndex: uint32(idx + 1),
+			ScriptKey:         out.Tree.ScriptKey(),
+		}
+		if out.AnchorInternalKey != nil {
+			vOut.SetAnchorInternalKey(
+				*out.AnchorInternalKey, chainParams.HDCoinType,
+			)
+		}
+
+		vPkt.Outputs = append(vPkt.Outputs, vOut)
+	}
+
+	return vPkt, nil
+}
+
+// SignTimeLockPacket signs all inputs of the given packet, which must be locked
+// to the given time lock script tree, with the given key. The witnesses of the
+// new asset are then assembled and validated. The lock time of the new asset
+// must be at least the lock time of the tree and its relative lock time at
+// least the CSV delay of the tree, as those are what the lock time checks of
+// the leaf are executed against. Since the lock times of split assets aren't
+// covered by the split commitment, a sweep must spend the full value of its
+// inputs.
+func SignTimeLockPacket(vPkt *tappsbt.VPacket,
+	tree *tapscript.TimeLockScriptTree, keyDesc keychain.KeyDescriptor,
+	signer tapscript.Signer, validator tapscript.WitnessValidator) error {
+
+	if !keyDesc.PubKey.IsEqual(tree.Key) {
+		return fmt.Errorf("key %x can't sign for the time lock",
+			keyDesc.PubKey.SerializeCompressed())
+	}
+
+	controlBlock, err := tree.ControlBlock()
+	if err != nil {
+		return err
+	}
+
+	sigCtx, err := newVirtualSigningContext(vPkt)
+	if err != nil {
+		return err
+	}
+
+	newAsset := sigCtx.newAsset
+	if newAsset.LockTime < uint64(tree.LockTime) {
+		return fmt.Errorf("lock time %d is below time lock %d",
+			newAsset.LockTime, tree.LockTime)
+	}
+	if newAsset.RelativeLockTime < uint64(tree.CsvDelay) {
+		return fmt.Errorf("relative lock time %d is below CSV delay %d",
+			newAsset.RelativeLockTime, tree.CsvDelay)
+	}
+
+	timeLockKey := schnorr.SerializePubKey(tree.TaprootKey)
+	xOnlySigningKey := schnorr.SerializePubKey(tree.Key)
+	leafHash := tree.Leaf.TapHash()
+	for idx := range vPkt.Inputs {
+		vIn := vPkt.Inputs[idx]
+
+		scriptKey := vIn.Asset().ScriptKey.PubKey
+		xOnlyScriptKey := schnorr.SerializePubKey(scriptKey)
+		if !bytes.Equal(xOnlyScriptKey, timeLockKey) {
+			return fmt.Errorf("input %d is not locked to the time "+
+				"lock script key", idx)
+		}
+
+		vIn.TaprootMerkleRoot = tree.TapscriptRoot
+		vIn.TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+			ControlBlock: controlBlock,
+			Script:       tree.Leaf.Script,
+			LeafVersion:  tree.Leaf.LeafVersion,
+		}}
+
+		err := signScriptPathInput(
+			vIn, sigCtx.inputVirtualTx(idx), tree.Leaf, keyDesc,
+			signer,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to sign input %d: %w", idx,
+				err)
+		}
+
+		sig := findScriptSpendSig(vIn, xOnlySigningKey, leafHash[:])
+		witness, err := tree.Witness(sig)
+		if err != nil {
+			return err
+		}
+
+		newAsset.PrevWitnesses[idx].TxWitness = witness
+	}
+
+	if err := sigCtx.validate(vPkt, validator); err != nil {
+		return fmt.Errorf("invalid time lock witness: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package tapsend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateTimeLockedPacket tests that a packet sending to multiple time
+// locked outputs anchors each output in its own BTC output next to the split
+// root output.
+func TestCreateTimeLockedPacket(t *testing.T) {
+	t.Parallel()
+
+	chainParams := &address.RegressionNetTap
+	assetID := asset.RandID(t)
+
+	tree1, err := tapscript.NewTimeLockScriptTree(
+		test.RandPubKey(t), 1000, 0,
+	)
+	require.NoError(t, err)
+
+	tree2, err := tapscript.NewTimeLockScriptTree(
+		test.RandPubKey(t), 2000, 10,
+	)
+	require.NoError(t, err)
+
+	internalKey := keychain.KeyDescriptor{
+		PubKey: test.RandPubKey(t),
+	}
+
+	vPkt, err := tapsend.CreateTimeLockedPacket(
+		assetID, []*tapsend.TimeLockedOutput{{
+			Amount:            100,
+			Tree:              tree1,
+			AnchorInternalKey: &internalKey,
+		}, {
+			Amount: 200,
+			Tree:   tree2,
+		}}, asset.V1, chainParams,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, vPkt.Inputs, 1)
+	require.Equal(t, assetID, vPkt.Inputs[0].PrevID.ID)
+	require.Len(t, vPkt.Outputs, 3)
+
+	splitRoot, err := vPkt.SplitRootOutput()
+	require.NoError(t, err)
+	require.Zero(t, splitRoot.AnchorOutputIndex)
+
+	for idx, tree := range []*tapscript.TimeLockScriptTree{tree1, tree2} {
+		vOut := vPkt.Outputs[idx+1]
+		require.EqualValues(t, idx+1, vOut.AnchorOutputIndex)
+		require.True(t, vOut.Interactive)
+		require.Equal(t, asset.V1, vOut.AssetVersion)
+		require.Equal(
+			t, tree.ScriptKey().PubKey, vOut.ScriptKey.PubKey,
+		)
+	}
+
+	require.Equal(t, uint64(100), vPkt.Outputs[1].Amount)
+	require.True(t, internalKey.PubKey.IsEqual(
+		vPkt.Outputs[1].AnchorOutputInternalKey,
+	))
+	require.Nil(t, vPkt.Outputs[2].AnchorOutputInternalKey)
+
+	// Outputs without an amount or a script tree are rejected.
+	_, err = tapsend.CreateTimeLockedPacket(
+		assetID, []*tapsend.TimeLockedOutput{{
+			Tree: tree1,
+		}}, asset.V1, chainParams,
+	)
+	require.ErrorContains(t, err, "zero amount")
+
+	_, err = tapsend.CreateTimeLockedPacket(
+		assetID, []*tapsend.TimeLockedOutput{{
+			Amount: 100,
+		}}, asset.V1, chainParams,
+	)
+	require.ErrorContains(t, err, "no script tree")
+
+	_, err = tapsend.CreateTimeLockedPacket(
+		assetID, nil, asset.V1, chainParams,
+	)
+	require.ErrorContains(t, err, "at least one")
+}
+
+// TestSignTimeLockPacket tests that a packet spending an asset locked to a
+// time lock script key can only be signed once the new asset carries the lock
+// times of the tree.
+func TestSignTimeLockPacket(t *testing.T) {
+	t.Parallel()
+
+	const (
+		lockTime = 1000
+		csvDelay = 10
+	)
+
+	key := test.RandPrivKey()
+	tree, err := tapscript.NewTimeLockScriptTree(
+		key.PubKey(), lockTime, csvDelay,
+	)
+	require.NoError(t, err)
+
+	// newPacket creates a full value packet that sweeps an asset locked to
+	// the time lock script key, with the given lock times.
+	newPacket := func(lockTime,
+		relativeLockTime uint64) (*tappsbt.VPacket, *spendData) {
+
+		state := initSpendScenario(t)
+
+		state.asset2.ScriptKey = tree.ScriptKey()
+		state.asset2PrevID.ScriptKey = asset.ToSerialized(
+			state.asset2.ScriptKey.PubKey,
+		)
+		state.asset2InputAssets = commitment.InputSet{
+			state.asset2PrevID: &state.asset2,
+		}
+
+		pkt := createPacket(
+			state.address2, state.asset2PrevID, state,
+			state.asset2InputAssets, true,
+		)
+		pkt.Outputs[0].LockTime = lockTime
+		pkt.Outputs[0].RelativeLockTime = relativeLockTime
+
+		err := tapsend.PrepareOutputAssets(context.Background(), pkt)
+		require.NoError(t, err)
+
+		return pkt, &state
+	}
+
+	// sign signs the packet with the given key.
+	sign := func(pkt *tappsbt.VPacket, state *spendData,
+		key *btcec.PrivateKey) error {
+
+		keyDesc := keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		}
+
+		return tapsend.SignTimeLockPacket(
+			pkt, tree, keyDesc, tapscript.NewMockSigner(key),
+			state.witnessValidator,
+		)
+	}
+
+	// The new asset must carry both lock times of the tree.
+	pkt, state := newPacket(lockTime-1, csvDelay)
+	require.ErrorContains(t, sign(pkt, state, key), "below time lock")
+
+	pkt, state = newPacket(lockTime, csvDelay-1)
+	require.ErrorContains(t, sign(pkt, state, key), "below CSV delay")
+
+	// Only the key of the tree can sign.
+	pkt, state = newPacket(lockTime, csvDelay)
+	err = sign(pkt, state, test.RandPrivKey())
+	require.ErrorContains(t, err, "can't sign for the time lock")
+
+	require.NoError(t, sign(pkt, state, key))
+
+	witness := pkt.Outputs[0].Asset.PrevWitnesses[0].TxWitness
+	require.Len(t, witness, 3)
+	require.Equal(t, tree.Leaf.Script, witness[1])
+
+	// An input that isn't locked to the time lock script key can't be
+	// signed.
+	state2 := initSpendScenario(t)
+	pkt = createPacket(
+		state2.address1, state2.asset2PrevID, state2,
+		state2.asset2InputAssets, false,
+	)
+	pkt.Outputs[0].LockTime = lockTime
+	pkt.Outputs[0].RelativeLockTime = csvDelay
+	require.NoError(t, tapsend.PrepareOutputAssets(
+		context.Background(), pkt,
+	))
+	err = sign(pkt, &state2, key)
+	require.ErrorContains(t, err, "not locked to the time lock")
+}
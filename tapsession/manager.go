@@ -0,0 +1,726 @@
+package tapsession
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// DefaultSessionExpiry is the default duration after which a signing
+	// session is removed.
+	DefaultSessionExpiry = 24 * time.Hour
+)
+
+// ManagerConfig is the configuration of the signing session manager.
+type ManagerConfig struct {
+	// Clock is the clock used to expire sessions.
+	Clock clock.Clock
+
+	// SessionExpiry is the duration after which a session is removed.
+	SessionExpiry time.Duration
+
+	// WitnessValidator is used to validate the witnesses of the virtual
+	// transactions once a session is finalized.
+	WitnessValidator tapscript.WitnessValidator
+}
+
+// Nonce is the public MuSig2 nonce of a participant of a MuSig2 input.
+type Nonce struct {
+	InputRef
+
+	// ParticipantKey is the key of the participant.
+	ParticipantKey *btcec.PublicKey
+
+	// PubNonce is the public nonce of the participant.
+	PubNonce [musig2.PubNonceSize]byte
+}
+
+// PartialSig is the MuSig2 partial signature of a participant of a MuSig2
+// input.
+type PartialSig struct {
+	InputRef
+
+	// ParticipantKey is the key of the participant.
+	ParticipantKey *btcec.PublicKey
+
+	// Sig is the partial signature of the participant.
+	Sig *musig2.PartialSignature
+}
+
+// Signatures are the signatures a party submits to a session.
+type Signatures struct {
+	// Packets are the virtual transactions of the session with the
+	// witnesses of the party's inputs attached. They must be in the same
+	// order as the session's packets.
+	Packets []*tappsbt.VPacket
+
+	// Nonces are the public nonces of the party for MuSig2 inputs.
+	Nonces []Nonce
+
+	// PartialSigs are the partial signatures of the party for MuSig2
+	// inputs.
+	PartialSigs []PartialSig
+}
+
+// CommitFunc commits the given finalized virtual transactions to a funded
+// anchor transaction.
+type CommitFunc func(ctx context.Context,
+	packets []*tappsbt.VPacket) (*AnchorCommitment, error)
+
+// PublishFunc publishes the fully signed anchor transaction of the given
+// commitment and logs the transfer.
+type PublishFunc func(ctx context.Context, commitment *AnchorCommitment) error
+
+// Manager keeps track of the multi-party signing sessions this daemon
+// coordinates. Sessions are only kept in memory, since they are short-lived
+// and can be re-created by their parties.
+type Manager struct {
+	cfg ManagerConfig
+
+	// sessions contains all sessions that didn't expire yet, keyed by their
+	// ID.
+	sessions map[ID]*Session
+
+	// sessionsMtx guards the sessions map and the sessions within.
+	sessionsMtx sync.Mutex
+}
+
+// NewManager creates a new signing session manager.
+func NewManager(cfg ManagerConfig) *Manager {
+	if cfg.SessionExpiry == 0 {
+		cfg.SessionExpiry = DefaultSessionExpiry
+	}
+
+	return &Manager{
+		cfg:      cfg,
+		sessions: make(map[ID]*Session),
+	}
+}
+
+// CreateSession creates a new session with the given funded virtual
+// transactions of the creating party.
+func (m *Manager) CreateSession(packets []*tappsbt.VPacket) (*Session,
+	error) {
+
+	var id ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("unable to create session ID: %w", err)
+	}
+
+	now := m.cfg.Clock.Now()
+	session := &Session{
+		ID:           id,
+		State:        StateOpen,
+		MuSig2Inputs: make(map[InputRef]*MuSig2Input),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(m.cfg.SessionExpiry),
+	}
+	if err := addPackets(session, packets); err != nil {
+		return nil, err
+	}
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	m.pruneExpired()
+	m.sessions[id] = session
+
+	log.Infof("Created signing session %v with %d virtual packets", id,
+		len(session.Packets))
+
+	return session.Copy(), nil
+}
+
+// Contribute adds the inputs and outputs of the given funded virtual
+// transactions of another party to an open session. Packets that spend the
+// same asset as an existing packet of the session are merged into it.
+func (m *Manager) Contribute(id ID, packets []*tappsbt.VPacket) (*Session,
+	error) {
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session, err := m.fetchSession(id, StateOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	// We add the packets to a copy of the session, so a failed
+	// contribution doesn't leave a partially merged session behind.
+	updated := session.Copy()
+	if err := addPackets(updated, packets); err != nil {
+		return nil, err
+	}
+	session.Packets = updated.Packets
+
+	log.Debugf("Added %d virtual packets to signing session %v",
+		len(packets), id)
+
+	return session.Copy(), nil
+}
+
+// StartSigning closes the session for contributions and prepares the output
+// assets of its virtual transactions, which are then ready to be signed. The
+// given inputs are signed by their participants through the MuSig2 protocol.
+func (m *Manager) StartSigning(ctx context.Context, id ID,
+	musig2Inputs []MuSig2InputSpec) (*Session, error) {
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session, err := m.fetchSession(id, StateOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	packets := copyPackets(session.Packets)
+	if err := tapsend.ValidateVPacketVersions(packets); err != nil {
+		return nil, err
+	}
+	if err := tapsend.AssertInputAnchorsEqual(packets); err != nil {
+		return nil, err
+	}
+	if err := tapsend.AssertOutputAnchorsEqual(packets); err != nil {
+		return nil, err
+	}
+
+	for idx, vPkt := range packets {
+		if err := tapsend.PrepareOutputAssets(ctx, vPkt); err != nil {
+			return nil, fmt.Errorf("unable to prepare outputs of "+
+				"packet %d: %w", idx, err)
+		}
+	}
+
+	inputs := make(map[InputRef]*MuSig2Input, len(musig2Inputs))
+	for _, spec := range musig2Inputs {
+		ref := spec.InputRef
+		if _, ok := inputs[ref]; ok {
+			return nil, fmt.Errorf("duplicate MuSig2 input %v",
+				ref)
+		}
+
+		vIn, err := sessionInput(packets, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		err = spec.validate(vIn.Asset().ScriptKey.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		sigHash, err := tapsend.VirtualInputSigHash(
+			packets[ref.PacketIndex], ref.InputIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute sighash of "+
+				"MuSig2 input %v: %w", ref, err)
+		}
+
+		inputs[ref] = &MuSig2Input{
+			MuSig2InputSpec: spec,
+			SigHash:         sigHash,
+			Nonces: make(
+				map[[33]byte][musig2.PubNonceSize]byte,
+			),
+			PartialSigs: make(
+				map[[33]byte]*musig2.PartialSignature,
+			),
+		}
+	}
+
+	session.Packets = packets
+	session.MuSig2Inputs = inputs
+	session.State = StateSigning
+
+	log.Infof("Started signing of session %v with %d MuSig2 inputs", id,
+		len(inputs))
+
+	return session.Copy(), nil
+}
+
+// SubmitSignatures adds the witnesses, MuSig2 nonces and MuSig2 partial
+// signatures of a party to a session that is being signed. Witnesses of inputs
+// that were already signed are ignored.
+func (m *Manager) SubmitSignatures(id ID, sigs Signatures) (*Session,
+	error) {
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session, err := m.fetchSession(id, StateSigning)
+	if err != nil {
+		return nil, err
+	}
+
+	// Just like contributions, signatures are added to a copy of the
+	// session first, so an invalid submission is rejected as a whole.
+	updated := session.Copy()
+
+	if len(sigs.Packets) > 0 &&
+		len(sigs.Packets) != len(updated.Packets) {
+
+		return nil, fmt.Errorf("expected %d signed packets, got %d",
+			len(updated.Packets), len(sigs.Packets))
+	}
+
+	for idx, signedPkt := range sigs.Packets {
+		err := mergeWitnesses(updated.Packets[idx], signedPkt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to add witnesses of "+
+				"packet %d: %w", idx, err)
+		}
+	}
+
+	for _, nonce := range sigs.Nonces {
+		in, ok := updated.MuSig2Inputs[nonce.InputRef]
+		if !ok {
+			return nil, fmt.Errorf("input %v is not a MuSig2 "+
+				"input", nonce.InputRef)
+		}
+
+		err := in.addNonce(nonce.ParticipantKey, nonce.PubNonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, partialSig := range sigs.PartialSigs {
+		in, ok := updated.MuSig2Inputs[partialSig.InputRef]
+		if !ok {
+			return nil, fmt.Errorf("input %v is not a MuSig2 "+
+				"input", partialSig.InputRef)
+		}
+
+		err := in.addPartialSig(
+			partialSig.ParticipantKey, partialSig.Sig,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	session.Packets = updated.Packets
+	session.MuSig2Inputs = updated.MuSig2Inputs
+
+	log.Debugf("Added %d signed packets, %d nonces and %d partial "+
+		"signatures to signing session %v", len(sigs.Packets),
+		len(sigs.Nonces), len(sigs.PartialSigs), id)
+
+	return session.Copy(), nil
+}
+
+// Finalize combines the MuSig2 partial signatures of the session into the
+// witnesses of their inputs and validates the witnesses of all virtual
+// transactions. The session is then ready to be anchored.
+func (m *Manager) Finalize(id ID) (*Session, error) {
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session, err := m.fetchSession(id, StateSigning)
+	if err != nil {
+		return nil, err
+	}
+
+	packets := copyPackets(session.Packets)
+	for ref, in := range session.MuSig2Inputs {
+		sig, ok, err := in.finalSig()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("not all participants of "+
+				"MuSig2 input %v submitted their partial "+
+				"signature", ref)
+		}
+
+		err = tapsend.SetVirtualWitness(
+			packets[ref.PacketIndex], ref.InputIndex,
+			wire.TxWitness{sig.Serialize()},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for idx, vPkt := range packets {
+		err := tapsend.ValidateVirtualWitnesses(
+			vPkt, m.cfg.WitnessValidator,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witnesses in packet "+
+				"%d: %w", idx, err)
+		}
+	}
+
+	session.Packets = packets
+	session.State = StateFinalized
+
+	log.Infof("Finalized signing session %v", id)
+
+	return session.Copy(), nil
+}
+
+// Commit commits the finalized virtual transactions of the session to a funded
+// anchor transaction by calling the given function. The anchor transaction
+// then needs to be signed by the parties that own its inputs.
+func (m *Manager) Commit(ctx context.Context, id ID,
+	commit CommitFunc) (*Session, error) {
+
+	session, err := m.claimSession(id, StateFinalized)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := commit(ctx, session.Packets)
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session = m.sessions[id]
+	session.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	session.Commitment = commitment
+	session.State = StateCommitted
+
+	log.Infof("Committed signing session %v to anchor transaction %v",
+		id, commitment.AnchorPsbt.UnsignedTx.TxHash())
+
+	return session.Copy(), nil
+}
+
+// Publish adds the signatures of the given signed anchor transactions to the
+// anchor transaction of the session and, once it is fully signed, publishes it
+// by calling the given function.
+func (m *Manager) Publish(ctx context.Context, id ID,
+	signedPsbts []*psbt.Packet, publish PublishFunc) (*Session, error) {
+
+	session, err := m.claimSession(id, StateCommitted)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment := session.Commitment
+	err = mergeAnchorSignatures(commitment.AnchorPsbt, signedPsbts)
+	if err == nil {
+		err = publish(ctx, commitment)
+	}
+
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session = m.sessions[id]
+	session.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := commitment.AnchorPsbt.UnsignedTx.TxHash()
+	session.Commitment = commitment
+	session.AnchorTxHash = &txHash
+	session.State = StateAnchored
+
+	log.Infof("Published anchor transaction %v of signing session %v",
+		txHash, id)
+
+	return session.Copy(), nil
+}
+
+// FetchSession returns the session with the given ID.
+func (m *Manager) FetchSession(id ID) (*Session, error) {
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	m.pruneExpired()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, id)
+	}
+
+	return session.Copy(), nil
+}
+
+// fetchSession returns the session with the given ID, making sure it is in
+// the given state and isn't busy.
+//
+// NOTE: The sessions mutex must be held when calling this method.
+func (m *Manager) fetchSession(id ID, state State) (*Session, error) {
+	m.pruneExpired()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, id)
+	}
+
+	if session.busy {
+		return nil, fmt.Errorf("%w: %v", ErrSessionBusy, id)
+	}
+
+	if session.State != state {
+		return nil, fmt.Errorf("%w: session %v is %v, expected %v",
+			ErrInvalidState, id, session.State, state)
+	}
+
+	return session, nil
+}
+
+// claimSession marks the session with the given ID as busy, so it can be
+// worked on without holding the sessions mutex, and returns a copy of it. The
+// caller must clear the busy flag once done.
+func (m *Manager) claimSession(id ID, state State) (*Session, error) {
+	m.sessionsMtx.Lock()
+	defer m.sessionsMtx.Unlock()
+
+	session, err := m.fetchSession(id, state)
+	if err != nil {
+		return nil, err
+	}
+
+	session.busy = true
+
+	return session.Copy(), nil
+}
+
+// pruneExpired removes all expired sessions that aren't busy.
+//
+// NOTE: The sessions mutex must be held when calling this method.
+func (m *Manager) pruneExpired() {
+	now := m.cfg.Clock.Now()
+	for id, session := range m.sessions {
+		if session.busy || now.Before(session.ExpiresAt) {
+			continue
+		}
+
+		log.Debugf("Removing expired signing session %v", id)
+		delete(m.sessions, id)
+	}
+}
+
+// sessionInput returns the virtual input the given reference points to.
+func sessionInput(packets []*tappsbt.VPacket,
+	ref InputRef) (*tappsbt.VInput, error) {
+
+	if int(ref.PacketIndex) >= len(packets) {
+		return nil, fmt.Errorf("packet index of input %v out of range",
+			ref)
+	}
+
+	vPkt := packets[ref.PacketIndex]
+	if int(ref.InputIndex) >= len(vPkt.Inputs) {
+		return nil, fmt.Errorf("input index of input %v out of range",
+			ref)
+	}
+
+	return vPkt.Inputs[ref.InputIndex], nil
+}
+
+// addPackets adds copies of the given packets to the session. A packet that
+// spends the same asset as an existing packet is merged into it.
+func addPackets(session *Session, packets []*tappsbt.VPacket) error {
+	if len(packets) == 0 {
+		return fmt.Errorf("no virtual packets specified")
+	}
+
+	// We keep track of all inputs, so the same asset can't be spent twice
+	// in a session.
+	spent := make(map[asset.PrevID]struct{})
+	for _, vPkt := range session.Packets {
+		for _, vIn := range vPkt.Inputs {
+			spent[vIn.PrevID] = struct{}{}
+		}
+	}
+
+	for idx := range packets {
+		vPkt := packets[idx].Copy()
+
+		if len(vPkt.Inputs) == 0 || len(vPkt.Outputs) == 0 {
+			return fmt.Errorf("packet %d must have at least one "+
+				"input and output", idx)
+		}
+
+		assetID := vPkt.Inputs[0].PrevID.ID
+		for _, vIn := range vPkt.Inputs {
+			if vIn.PrevID.ID != assetID {
+				return fmt.Errorf("packet %d spends more than "+
+					"one asset", idx)
+			}
+
+			if _, ok := spent[vIn.PrevID]; ok {
+				return fmt.Errorf("input %v is already spent "+
+					"in the session", vIn.PrevID.OutPoint)
+			}
+			spent[vIn.PrevID] = struct{}{}
+		}
+
+		if len(session.Packets) > 0 {
+			hrp := session.Packets[0].ChainParams.TapHRP
+			if vPkt.ChainParams.TapHRP != hrp {
+				return fmt.Errorf("packet %d is for a "+
+					"different network", idx)
+			}
+		}
+
+		target := findPacket(session.Packets, assetID)
+		if target == nil {
+			session.Packets = append(session.Packets, vPkt)
+			continue
+		}
+
+		if err := mergePacket(target, vPkt); err != nil {
+			return fmt.Errorf("unable to merge packet %d: %w", idx,
+				err)
+		}
+	}
+
+	return nil
+}
+
+// findPacket returns the packet that spends the asset with the given ID, or
+// nil if there is none.
+func findPacket(packets []*tappsbt.VPacket,
+	assetID asset.ID) *tappsbt.VPacket {
+
+	for _, vPkt := range packets {
+		if vPkt.Inputs[0].PrevID.ID == assetID {
+			return vPkt
+		}
+	}
+
+	return nil
+}
+
+// mergePacket appends the inputs and outputs of the given packet to the target
+// packet that spends the same asset. Since a virtual transaction can only have
+// a single split root, the split root of the merged packet is turned into a
+// simple output, or dropped if it is a zero value tombstone.
+func mergePacket(target, vPkt *tappsbt.VPacket) error {
+	if target.Version != vPkt.Version {
+		return fmt.Errorf("packet version %d doesn't match version %d",
+			vPkt.Version, target.Version)
+	}
+
+	hasSplitRoot := false
+	for _, vOut := range target.Outputs {
+		if vOut.Type.IsSplitRoot() {
+			hasSplitRoot = true
+		}
+	}
+
+	target.Inputs = append(target.Inputs, vPkt.Inputs...)
+	for _, vOut := range vPkt.Outputs {
+		if vOut.Type.IsSplitRoot() && hasSplitRoot {
+			if vOut.Amount == 0 {
+				continue
+			}
+
+			vOut.Type = tappsbt.TypeSimple
+		}
+
+		target.Outputs = append(target.Outputs, vOut)
+	}
+
+	return nil
+}
+
+// mergeWitnesses attaches the witnesses of the signed packet to the inputs of
+// the target packet that don't have a witness yet. Both packets must describe
+// the same virtual transaction.
+func mergeWitnesses(target, signedPkt *tappsbt.VPacket) error {
+	if len(target.Inputs) != len(signedPkt.Inputs) ||
+		len(target.Outputs) != len(signedPkt.Outputs) {
+
+		return fmt.Errorf("signed packet doesn't match session packet")
+	}
+
+	txHash, witnesses, err := tapsend.VirtualWitnesses(target)
+	if err != nil {
+		return err
+	}
+
+	signedTxHash, signedWitnesses, err := tapsend.VirtualWitnesses(
+		signedPkt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if txHash != signedTxHash {
+		return fmt.Errorf("signed virtual transaction %v doesn't "+
+			"match session virtual transaction %v", signedTxHash,
+			txHash)
+	}
+
+	for idx := range signedWitnesses {
+		if len(signedWitnesses[idx]) == 0 || len(witnesses[idx]) > 0 {
+			continue
+		}
+
+		err := tapsend.SetVirtualWitness(
+			target, uint32(idx), signedWitnesses[idx],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeAnchorSignatures adds the final witnesses and key spend signatures of
+// the inputs of the signed anchor transactions to the given anchor transaction
+// and finalizes it.
+func mergeAnchorSignatures(anchorPsbt *psbt.Packet,
+	signedPsbts []*psbt.Packet) error {
+
+	txHash := anchorPsbt.UnsignedTx.TxHash()
+	for _, signed := range signedPsbts {
+		signedTxHash := signed.UnsignedTx.TxHash()
+		if signedTxHash != txHash {
+			return fmt.Errorf("signed anchor transaction %v "+
+				"doesn't match %v", signedTxHash, txHash)
+		}
+
+		for idx := range signed.Inputs {
+			signedIn := &signed.Inputs[idx]
+			pIn := &anchorPsbt.Inputs[idx]
+
+			switch {
+			case len(pIn.FinalScriptWitness) > 0:
+				continue
+
+			case len(signedIn.FinalScriptWitness) > 0:
+				pIn.FinalScriptSig = signedIn.FinalScriptSig
+				pIn.FinalScriptWitness =
+					signedIn.FinalScriptWitness
+
+			case len(signedIn.TaprootKeySpendSig) > 0:
+				pIn.TaprootKeySpendSig =
+					signedIn.TaprootKeySpendSig
+			}
+		}
+	}
+
+	if err := psbt.MaybeFinalizeAll(anchorPsbt); err != nil {
+		return fmt.Errorf("anchor transaction isn't fully signed: %w",
+			err)
+	}
+
+	return nil
+}
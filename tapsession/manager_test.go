@@ -0,0 +1,329 @@
+package tapsession
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/vm"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// vmValidator validates witnesses with the Taproot Asset VM.
+type vmValidator struct{}
+
+// ValidateWitnesses validates the created witnesses of an asset transfer.
+func (vmValidator) ValidateWitnesses(newAsset *asset.Asset,
+	splitAssets []*commitment.SplitAsset,
+	prevAssets commitment.InputSet) error {
+
+	return vm.ValidateWitnesses(newAsset, splitAssets, prevAssets)
+}
+
+// newTestPacket creates a packet that spends the given input assets to outputs
+// with the given amounts, which are anchored in consecutive anchor outputs
+// starting at the given index. If split is set, the first output is the split
+// root.
+func newTestPacket(t *testing.T, inputs []*asset.Asset, anchorIdx uint32,
+	split bool, amounts ...uint64) *tappsbt.VPacket {
+
+	internalKey := test.RandPubKey(t)
+	vPkt := &tappsbt.VPacket{
+		ChainParams: &address.RegressionNetTap,
+	}
+	for idx, inputAsset := range inputs {
+		vPkt.Inputs = append(vPkt.Inputs, &tappsbt.VInput{
+			PrevID: asset.PrevID{
+				OutPoint: test.RandOp(t),
+				ID:       inputAsset.ID(),
+				ScriptKey: asset.ToSerialized(
+					inputAsset.ScriptKey.PubKey,
+				),
+			},
+		})
+		vPkt.SetInputAsset(idx, inputAsset)
+	}
+
+	for idx, amt := range amounts {
+		vOut := &tappsbt.VOutput{
+			Amount:                  amt,
+			Type:                    tappsbt.TypeSimple,
+			Interactive:             !split,
+			ScriptKey:               asset.RandScriptKey(t),
+			AnchorOutputIndex:       anchorIdx + uint32(idx),
+			AnchorOutputInternalKey: internalKey,
+		}
+		if split && idx == 0 {
+			vOut.Type = tappsbt.TypeSplitRoot
+		}
+
+		vPkt.Outputs = append(vPkt.Outputs, vOut)
+	}
+
+	return vPkt
+}
+
+// signInputs signs the inputs with the given indexes of a copy of the session's
+// first packet with the given key.
+func signInputs(t *testing.T, session *Session, key *btcec.PrivateKey,
+	inputIdxs ...uint32) []*tappsbt.VPacket {
+
+	packets := copyPackets(session.Packets)
+	err := tapsend.SignVirtualInputs(
+		packets[0], tapscript.NewMockSigner(key), inputIdxs,
+	)
+	require.NoError(t, err)
+
+	return packets
+}
+
+// TestSessionMultiPartySigning tests a session in which two parties spend the
+// same asset in a single virtual transaction, while a third input of another
+// asset is spent by a MuSig2 key spend of two other participants.
+func TestSessionMultiPartySigning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	testClock := clock.NewTestClock(time.Now())
+	manager := NewManager(ManagerConfig{
+		Clock:            testClock,
+		WitnessValidator: vmValidator{},
+	})
+
+	aliceKey, bobKey := test.RandPrivKey(), test.RandPrivKey()
+	carolKey, daveKey := test.RandPrivKey(), test.RandPrivKey()
+
+	newAsset := func(gen asset.Genesis, amt uint64,
+		scriptKey asset.ScriptKey) *asset.Asset {
+
+		return asset.NewAssetNoErr(
+			t, gen, amt, 0, 0, scriptKey, nil,
+		)
+	}
+	bip86Key := func(key *btcec.PrivateKey) asset.ScriptKey {
+		return asset.NewScriptKeyBip86(keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		})
+	}
+
+	// Alice and Bob both spend some of the same asset.
+	gen := asset.RandGenesis(t, asset.Normal)
+	alicePkt := newTestPacket(
+		t, []*asset.Asset{newAsset(gen, 100, bip86Key(aliceKey))}, 0,
+		true, 40, 60,
+	)
+	bobPkt := newTestPacket(
+		t, []*asset.Asset{newAsset(gen, 50, bip86Key(bobKey))}, 2,
+		true, 20, 30,
+	)
+
+	// Carol and Dave spend an asset that is locked to their MuSig2 key.
+	musig2Keys := []*btcec.PublicKey{carolKey.PubKey(), daveKey.PubKey()}
+	aggKey, _, _, err := musig2.AggregateKeys(
+		musig2Keys, true, musig2.WithBIP86KeyTweak(),
+	)
+	require.NoError(t, err)
+
+	escrowGen := asset.RandGenesis(t, asset.Normal)
+	escrowPkt := newTestPacket(
+		t, []*asset.Asset{newAsset(
+			escrowGen, 10, asset.NewScriptKey(aggKey.FinalKey),
+		)}, 4, false, 10,
+	)
+
+	session, err := manager.CreateSession(
+		[]*tappsbt.VPacket{alicePkt},
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, session.State)
+
+	session, err = manager.Contribute(
+		session.ID, []*tappsbt.VPacket{bobPkt, escrowPkt},
+	)
+	require.NoError(t, err)
+
+	// Bob's packet was merged into Alice's packet, and his split root was
+	// turned into a simple output.
+	require.Len(t, session.Packets, 2)
+	require.Len(t, session.Packets[0].Inputs, 2)
+	require.Len(t, session.Packets[0].Outputs, 4)
+	require.Equal(t, tappsbt.TypeSimple, session.Packets[0].Outputs[2].Type)
+
+	// The same input can't be contributed twice.
+	_, err = manager.Contribute(session.ID, []*tappsbt.VPacket{bobPkt})
+	require.ErrorContains(t, err, "already spent")
+
+	// The participant keys of a MuSig2 input must match its script key.
+	escrowRef := InputRef{PacketIndex: 1}
+	_, err = manager.StartSigning(ctx, session.ID, []MuSig2InputSpec{{
+		InputRef: escrowRef,
+		ParticipantKeys: []*btcec.PublicKey{
+			carolKey.PubKey(), aliceKey.PubKey(),
+		},
+	}})
+	require.ErrorContains(t, err, "don't aggregate to script key")
+
+	session, err = manager.StartSigning(ctx, session.ID, []MuSig2InputSpec{{
+		InputRef:        escrowRef,
+		ParticipantKeys: musig2Keys,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, StateSigning, session.State)
+
+	// No more contributions are accepted once the signing started.
+	_, err = manager.Contribute(session.ID, []*tappsbt.VPacket{bobPkt})
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	// Alice and Bob each sign their own input.
+	for idx, key := range []*btcec.PrivateKey{aliceKey, bobKey} {
+		packets := signInputs(t, session, key, uint32(idx))
+		_, err := manager.SubmitSignatures(session.ID, Signatures{
+			Packets: packets,
+		})
+		require.NoError(t, err)
+	}
+
+	// The session can't be finalized before the MuSig2 input is signed.
+	_, err = manager.Finalize(session.ID)
+	require.ErrorContains(t, err, "submitted their partial signature")
+
+	// Carol and Dave exchange their nonces through the session and then
+	// sign the sighash of the MuSig2 input.
+	session, err = manager.FetchSession(session.ID)
+	require.NoError(t, err)
+	escrowInput := session.MuSig2Inputs[escrowRef]
+
+	signers := []*btcec.PrivateKey{carolKey, daveKey}
+	secNonces := make([]*musig2.Nonces, len(signers))
+	for idx, key := range signers {
+		secNonces[idx], err = musig2.GenNonces(
+			musig2.WithPublicKey(key.PubKey()),
+		)
+		require.NoError(t, err)
+
+		_, err = manager.SubmitSignatures(session.ID, Signatures{
+			Nonces: []Nonce{{
+				InputRef:       escrowRef,
+				ParticipantKey: key.PubKey(),
+				PubNonce:       secNonces[idx].PubNonce,
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	session, err = manager.FetchSession(session.ID)
+	require.NoError(t, err)
+	escrowSession := session.MuSig2Inputs[escrowRef]
+	combinedNonce, ok, err := escrowSession.CombinedNonce()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	partialSigs := make([]*musig2.PartialSignature, len(signers))
+	for idx, key := range signers {
+		partialSigs[idx], err = musig2.Sign(
+			secNonces[idx].SecNonce, key, combinedNonce,
+			musig2Keys, escrowInput.SigHash,
+			musig2.WithSortedKeys(), musig2.WithBip86SignTweak(),
+		)
+		require.NoError(t, err)
+	}
+
+	// A partial signature that doesn't belong to the participant is
+	// rejected.
+	_, err = manager.SubmitSignatures(session.ID, Signatures{
+		PartialSigs: []PartialSig{{
+			InputRef:       escrowRef,
+			ParticipantKey: carolKey.PubKey(),
+			Sig:            partialSigs[1],
+		}},
+	})
+	require.ErrorContains(t, err, "invalid partial signature")
+
+	for idx, key := range signers {
+		_, err := manager.SubmitSignatures(session.ID, Signatures{
+			PartialSigs: []PartialSig{{
+				InputRef:       escrowRef,
+				ParticipantKey: key.PubKey(),
+				Sig:            partialSigs[idx],
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	session, err = manager.Finalize(session.ID)
+	require.NoError(t, err)
+	require.Equal(t, StateFinalized, session.State)
+
+	for _, vPkt := range session.Packets {
+		require.NoError(t, tapsend.ValidateVirtualWitnesses(
+			vPkt, vmValidator{},
+		))
+	}
+
+	// The finalized packets are committed to an anchor transaction with
+	// two inputs that are owned by different parties.
+	anchorTx := wire.NewMsgTx(2)
+	anchorTx.AddTxIn(&wire.TxIn{PreviousOutPoint: test.RandOp(t)})
+	anchorTx.AddTxIn(&wire.TxIn{PreviousOutPoint: test.RandOp(t)})
+	anchorTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x51}})
+	anchorPsbt, err := psbt.NewFromUnsignedTx(anchorTx)
+	require.NoError(t, err)
+
+	session, err = manager.Commit(
+		ctx, session.ID, func(_ context.Context,
+			packets []*tappsbt.VPacket) (*AnchorCommitment, error) {
+
+			return &AnchorCommitment{
+				AnchorPsbt:        anchorPsbt,
+				ActivePackets:     packets,
+				ChangeOutputIndex: -1,
+			}, nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateCommitted, session.State)
+
+	// Each party signs its own anchor input.
+	signedPsbts := make([]*psbt.Packet, 2)
+	for idx := range signedPsbts {
+		signedPsbts[idx] = copyPsbt(anchorPsbt)
+		signedPsbts[idx].Inputs[idx].FinalScriptWitness = []byte{
+			0x01, 0x01, 0x01,
+		}
+	}
+
+	published := 0
+	publish := func(context.Context, *AnchorCommitment) error {
+		published++
+		return nil
+	}
+
+	// The anchor transaction isn't published before it's fully signed.
+	_, err = manager.Publish(ctx, session.ID, signedPsbts[:1], publish)
+	require.ErrorContains(t, err, "isn't fully signed")
+	require.Zero(t, published)
+
+	session, err = manager.Publish(ctx, session.ID, signedPsbts, publish)
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+	require.Equal(t, StateAnchored, session.State)
+	require.Equal(t, anchorTx.TxHash(), *session.AnchorTxHash)
+
+	// Once the session expires, it is removed.
+	testClock.SetTime(testClock.Now().Add(DefaultSessionExpiry))
+	_, err = manager.FetchSession(session.ID)
+	require.ErrorIs(t, err, ErrSessionNotFound)
+}
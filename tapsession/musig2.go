@@ -0,0 +1,145 @@
+package tapsession
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+)
+
+// hasParticipant returns true if the given key is one of the participant keys
+// of the input.
+func (m *MuSig2InputSpec) hasParticipant(key *btcec.PublicKey) bool {
+	for _, participant := range m.ParticipantKeys {
+		if participant.IsEqual(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate makes sure the participant keys of the input aggregate to the
+// given script key.
+func (m *MuSig2InputSpec) validate(scriptKey *btcec.PublicKey) error {
+	_, err := tapscript.MuSig2AggregateKey(
+		m.ParticipantKeys, m.TapscriptRoot,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid MuSig2 input %v: %w", m.InputRef,
+			err)
+	}
+
+	if !tapscript.IsMuSig2ScriptKey(
+		scriptKey, m.ParticipantKeys, m.TapscriptRoot,
+	) {
+
+		return fmt.Errorf("participant keys of MuSig2 input %v don't "+
+			"aggregate to script key %x", m.InputRef,
+			scriptKey.SerializeCompressed())
+	}
+
+	return nil
+}
+
+// addNonce adds the public nonce of the given participant.
+func (m *MuSig2Input) addNonce(key *btcec.PublicKey,
+	nonce [musig2.PubNonceSize]byte) error {
+
+	if !m.hasParticipant(key) {
+		return fmt.Errorf("key %x is not a participant of MuSig2 "+
+			"input %v", key.SerializeCompressed(), m.InputRef)
+	}
+
+	mapKey := participantKey(key)
+	if existing, ok := m.Nonces[mapKey]; ok {
+		if existing != nonce {
+			return fmt.Errorf("participant %x already submitted "+
+				"a different nonce for MuSig2 input %v",
+				key.SerializeCompressed(), m.InputRef)
+		}
+
+		return nil
+	}
+
+	// Parsing the nonce makes sure it consists of two valid points.
+	if _, err := musig2.AggregateNonces(
+		[][musig2.PubNonceSize]byte{nonce},
+	); err != nil {
+		return fmt.Errorf("invalid nonce for MuSig2 input %v: %w",
+			m.InputRef, err)
+	}
+
+	m.Nonces[mapKey] = nonce
+
+	return nil
+}
+
+// addPartialSig verifies and adds the partial signature of the given
+// participant. All participants must have submitted their nonce before.
+func (m *MuSig2Input) addPartialSig(key *btcec.PublicKey,
+	sig *musig2.PartialSignature) error {
+
+	if !m.hasParticipant(key) {
+		return fmt.Errorf("key %x is not a participant of MuSig2 "+
+			"input %v", key.SerializeCompressed(), m.InputRef)
+	}
+
+	combinedNonce, ok, err := m.CombinedNonce()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("not all participants of MuSig2 input %v "+
+			"submitted their nonce", m.InputRef)
+	}
+
+	pubNonce := m.Nonces[participantKey(key)]
+	if !tapscript.VerifyMuSig2PartialSig(
+		sig, pubNonce, combinedNonce, m.ParticipantKeys, key,
+		m.TapscriptRoot, m.SigHash,
+	) {
+
+		return fmt.Errorf("invalid partial signature of participant "+
+			"%x for MuSig2 input %v", key.SerializeCompressed(),
+			m.InputRef)
+	}
+
+	m.PartialSigs[participantKey(key)] = sig
+
+	return nil
+}
+
+// finalSig combines the partial signatures of all participants into the final
+// Schnorr signature over the input's sighash, or returns false if not all
+// participants submitted their partial signature yet.
+func (m *MuSig2Input) finalSig() (*schnorr.Signature, bool, error) {
+	if len(m.PartialSigs) != len(m.ParticipantKeys) {
+		return nil, false, nil
+	}
+
+	combinedNonce, ok, err := m.CombinedNonce()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	partialSigs := make([]*musig2.PartialSignature, 0, len(m.PartialSigs))
+	for _, key := range m.ParticipantKeys {
+		partialSigs = append(partialSigs, m.PartialSigs[participantKey(
+			key,
+		)])
+	}
+
+	sig, err := tapscript.CombineMuSig2Sigs(
+		m.ParticipantKeys, m.TapscriptRoot, m.SigHash, combinedNonce,
+		partialSigs,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to combine signatures "+
+			"of MuSig2 input %v: %w", m.InputRef, err)
+	}
+
+	return sig, true, nil
+}
@@ -0,0 +1,302 @@
+package tapsession
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+)
+
+var (
+	// ErrSessionNotFound is returned if a session with the given ID
+	// doesn't exist or already expired.
+	ErrSessionNotFound = errors.New("signing session not found")
+
+	// ErrInvalidState is returned if an operation is attempted on a
+	// session that isn't in the state the operation requires.
+	ErrInvalidState = errors.New("invalid signing session state")
+
+	// ErrSessionBusy is returned if the session is currently being
+	// committed to or published in an anchor transaction.
+	ErrSessionBusy = errors.New("signing session is busy")
+)
+
+// ID is the unique ID of a signing session.
+type ID [32]byte
+
+// String returns the hex encoded session ID.
+func (i ID) String() string {
+	return fmt.Sprintf("%x", i[:])
+}
+
+// State is the state of a signing session.
+type State uint8
+
+const (
+	// StateOpen is the state of a session that accepts the contribution of
+	// inputs and outputs from its parties.
+	StateOpen State = 0
+
+	// StateSigning is the state of a session whose virtual transactions
+	// are final and are being signed by its parties.
+	StateSigning State = 1
+
+	// StateFinalized is the state of a session whose virtual transactions
+	// are fully signed and validated.
+	StateFinalized State = 2
+
+	// StateCommitted is the state of a session whose virtual transactions
+	// were committed to a funded anchor transaction that now needs to be
+	// signed by the parties owning its inputs.
+	StateCommitted State = 3
+
+	// StateAnchored is the state of a session whose anchor transaction was
+	// published.
+	StateAnchored State = 4
+)
+
+// String returns the human-readable name of the state.
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+
+	case StateSigning:
+		return "signing"
+
+	case StateFinalized:
+		return "finalized"
+
+	case StateCommitted:
+		return "committed"
+
+	case StateAnchored:
+		return "anchored"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// InputRef references a virtual input of a session by the index of its packet
+// and its index within that packet.
+type InputRef struct {
+	// PacketIndex is the index of the virtual packet.
+	PacketIndex uint32
+
+	// InputIndex is the index of the input within the virtual packet.
+	InputIndex uint32
+}
+
+// String returns a human-readable representation of the input reference.
+func (r InputRef) String() string {
+	return fmt.Sprintf("%d:%d", r.PacketIndex, r.InputIndex)
+}
+
+// MuSig2InputSpec describes a virtual input whose script key is the MuSig2
+// aggregate of the keys of several participants and that is spent through its
+// key path.
+type MuSig2InputSpec struct {
+	InputRef
+
+	// ParticipantKeys are the individual keys of all participants. The
+	// keys are sorted before they are aggregated.
+	ParticipantKeys []*btcec.PublicKey
+
+	// TapscriptRoot is the root of the tapscript tree the aggregate key is
+	// tweaked with. If it is empty, the aggregate key is tweaked as a
+	// BIP-0086 key.
+	TapscriptRoot []byte
+}
+
+// MuSig2Input is a virtual input that is signed by its participants through
+// the MuSig2 protocol.
+type MuSig2Input struct {
+	MuSig2InputSpec
+
+	// SigHash is the message the participants sign.
+	SigHash [32]byte
+
+	// Nonces are the public nonces of the participants, keyed by their
+	// compressed participant key.
+	Nonces map[[33]byte][musig2.PubNonceSize]byte
+
+	// PartialSigs are the partial signatures of the participants, keyed by
+	// their compressed participant key.
+	PartialSigs map[[33]byte]*musig2.PartialSignature
+}
+
+// copyMuSig2Input returns a copy of the given MuSig2 input.
+func copyMuSig2Input(in *MuSig2Input) *MuSig2Input {
+	inCopy := &MuSig2Input{
+		MuSig2InputSpec: in.MuSig2InputSpec,
+		SigHash:         in.SigHash,
+		Nonces: make(
+			map[[33]byte][musig2.PubNonceSize]byte, len(in.Nonces),
+		),
+		PartialSigs: make(
+			map[[33]byte]*musig2.PartialSignature,
+			len(in.PartialSigs),
+		),
+	}
+	for key, nonce := range in.Nonces {
+		inCopy.Nonces[key] = nonce
+	}
+	for key, sig := range in.PartialSigs {
+		inCopy.PartialSigs[key] = sig
+	}
+
+	return inCopy
+}
+
+// CombinedNonce returns the aggregate of the public nonces of all
+// participants, or false if not all participants submitted their nonce yet.
+func (m *MuSig2Input) CombinedNonce() ([musig2.PubNonceSize]byte, bool,
+	error) {
+
+	var combinedNonce [musig2.PubNonceSize]byte
+	if len(m.Nonces) != len(m.ParticipantKeys) {
+		return combinedNonce, false, nil
+	}
+
+	nonces := make([][musig2.PubNonceSize]byte, 0, len(m.Nonces))
+	for _, key := range m.ParticipantKeys {
+		nonces = append(nonces, m.Nonces[participantKey(key)])
+	}
+
+	combinedNonce, err := musig2.AggregateNonces(nonces)
+	if err != nil {
+		return combinedNonce, false, err
+	}
+
+	return combinedNonce, true, nil
+}
+
+// AnchorCommitment is the funded anchor transaction the virtual transactions
+// of a session were committed to.
+type AnchorCommitment struct {
+	// AnchorPsbt is the funded anchor transaction.
+	AnchorPsbt *psbt.Packet
+
+	// ActivePackets are the virtual transactions of the session, updated
+	// with the proofs of being committed to the anchor transaction.
+	ActivePackets []*tappsbt.VPacket
+
+	// PassivePackets are the virtual transactions of the assets that are
+	// carried along from the anchor inputs of the session.
+	PassivePackets []*tappsbt.VPacket
+
+	// ChangeOutputIndex is the index of the BTC change output of the
+	// anchor transaction or -1 if there is none.
+	ChangeOutputIndex int32
+
+	// LockedUTXOs are the wallet UTXOs that were locked to fund the
+	// anchor transaction.
+	LockedUTXOs []wire.OutPoint
+}
+
+// Session is a multi-party signing session in which independent parties
+// construct and sign a set of virtual transactions that are anchored in a
+// single BTC transaction.
+type Session struct {
+	// ID is the unique ID of the session.
+	ID ID
+
+	// State is the current state of the session.
+	State State
+
+	// Packets are the virtual transactions of the session. There is at
+	// most one packet per asset.
+	Packets []*tappsbt.VPacket
+
+	// MuSig2Inputs are the inputs that are signed through the MuSig2
+	// protocol, keyed by their reference.
+	MuSig2Inputs map[InputRef]*MuSig2Input
+
+	// Commitment is the anchor transaction the packets were committed to,
+	// once the session reaches the committed state.
+	Commitment *AnchorCommitment
+
+	// AnchorTxHash is the hash of the published anchor transaction, once
+	// the session reaches the anchored state.
+	AnchorTxHash *chainhash.Hash
+
+	// CreatedAt is the time the session was created.
+	CreatedAt time.Time
+
+	// ExpiresAt is the time after which the session is removed.
+	ExpiresAt time.Time
+
+	// busy is set while the session is being committed to or published in
+	// an anchor transaction.
+	busy bool
+}
+
+// Copy returns a deep copy of the session.
+func (s *Session) Copy() *Session {
+	sessionCopy := &Session{
+		ID:           s.ID,
+		State:        s.State,
+		Packets:      copyPackets(s.Packets),
+		MuSig2Inputs: make(map[InputRef]*MuSig2Input),
+		AnchorTxHash: s.AnchorTxHash,
+		CreatedAt:    s.CreatedAt,
+		ExpiresAt:    s.ExpiresAt,
+	}
+	for ref, in := range s.MuSig2Inputs {
+		sessionCopy.MuSig2Inputs[ref] = copyMuSig2Input(in)
+	}
+
+	if s.Commitment != nil {
+		sessionCopy.Commitment = &AnchorCommitment{
+			AnchorPsbt: copyPsbt(s.Commitment.AnchorPsbt),
+			ActivePackets: copyPackets(
+				s.Commitment.ActivePackets,
+			),
+			PassivePackets: copyPackets(
+				s.Commitment.PassivePackets,
+			),
+			ChangeOutputIndex: s.Commitment.ChangeOutputIndex,
+			LockedUTXOs: append(
+				[]wire.OutPoint{}, s.Commitment.LockedUTXOs...,
+			),
+		}
+	}
+
+	return sessionCopy
+}
+
+// copyPackets returns a deep copy of the given virtual packets.
+func copyPackets(packets []*tappsbt.VPacket) []*tappsbt.VPacket {
+	packetsCopy := make([]*tappsbt.VPacket, len(packets))
+	for idx := range packets {
+		packetsCopy[idx] = packets[idx].Copy()
+	}
+
+	return packetsCopy
+}
+
+// copyPsbt returns a copy of the given PSBT. The inputs and outputs are copied
+// one level deep, which is enough since their fields are only ever replaced.
+func copyPsbt(pkt *psbt.Packet) *psbt.Packet {
+	pktCopy := *pkt
+	pktCopy.UnsignedTx = pkt.UnsignedTx.Copy()
+	pktCopy.Inputs = append([]psbt.PInput{}, pkt.Inputs...)
+	pktCopy.Outputs = append([]psbt.POutput{}, pkt.Outputs...)
+
+	return &pktCopy
+}
+
+// participantKey returns the map key of the given participant key.
+func participantKey(key *btcec.PublicKey) [33]byte {
+	var mapKey [33]byte
+	copy(mapKey[:], key.SerializeCompressed())
+
+	return mapKey
+}
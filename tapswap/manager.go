@@ -0,0 +1,865 @@
+package tapswap
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightningnetwork/lnd/clock"
+)
+
+const (
+	// DefaultSwapRetention is the default duration a swap is kept after
+	// its quote expired.
+	DefaultSwapRetention = 24 * time.Hour
+)
+
+// VerifyProofFunc verifies the given proof file and returns the snapshot of
+// the asset it proves.
+type VerifyProofFunc func(ctx context.Context,
+	proofFile proof.Blob) (*proof.AssetSnapshot, error)
+
+// ManagerConfig is the configuration of the swap manager.
+type ManagerConfig struct {
+	// Clock is the clock used to expire quotes and swaps.
+	Clock clock.Clock
+
+	// SwapRetention is the duration a swap is kept after its quote
+	// expired.
+	SwapRetention time.Duration
+
+	// WitnessValidator is used to validate the witnesses of the virtual
+	// transactions the seller proposes.
+	WitnessValidator tapscript.WitnessValidator
+
+	// VerifyProof is used to verify the proofs of the assets the seller
+	// proposes to sell.
+	VerifyProof VerifyProofFunc
+}
+
+// AcceptFunc derives the keys the buyer receives the asset of the given quote
+// with.
+type AcceptFunc func(ctx context.Context, quote Quote) (*Acceptance, error)
+
+// ProposeFunc funds and signs the virtual transactions that send the asset of
+// the given quote to the keys of the given acceptance.
+type ProposeFunc func(ctx context.Context, quote Quote,
+	acceptance Acceptance) (*Proposal, error)
+
+// FundFunc commits the virtual transactions of the proposal of the given swap
+// to an anchor transaction that also pays the seller, funds it and signs the
+// buyer's inputs. In an asset-for-asset swap, the buyer pays by committing its
+// own signed virtual transactions that send the price asset to the seller.
+type FundFunc func(ctx context.Context, swap *Swap) (*Funding, error)
+
+// PublishFunc signs the seller's inputs of the anchor transaction of the given
+// funding, publishes it and logs the transfer of the seller's assets.
+type PublishFunc func(ctx context.Context, swap *Swap, funding *Funding) error
+
+// CompleteFunc waits for the anchor transaction of the given swap to confirm
+// and imports the proof of the asset we received. The buyer of an
+// asset-for-asset swap also logs the transfer of its price asset.
+type CompleteFunc func(ctx context.Context, swap *Swap) error
+
+// Manager keeps track of the atomic swaps of assets for BTC or other assets
+// this daemon takes part in. The messages of a swap are exchanged out of band
+// between its two parties. Swaps are only kept in memory, since they are
+// short-lived and the assets and coins they lock are released once their
+// leases expire.
+type Manager struct {
+	cfg ManagerConfig
+
+	// swaps contains all swaps that weren't pruned yet, keyed by their
+	// ID.
+	swaps map[ID]*Swap
+
+	// swapsMtx guards the swaps map and the swaps within.
+	swapsMtx sync.Mutex
+}
+
+// NewManager creates a new swap manager.
+func NewManager(cfg ManagerConfig) *Manager {
+	if cfg.SwapRetention == 0 {
+		cfg.SwapRetention = DefaultSwapRetention
+	}
+
+	return &Manager{
+		cfg:   cfg,
+		swaps: make(map[ID]*Swap),
+	}
+}
+
+// CreateOffer creates a new swap in which we sell an asset for BTC or another
+// asset under the terms of the given quote.
+func (m *Manager) CreateOffer(quote Quote) (*Swap, error) {
+	now := m.cfg.Clock.Now()
+	if err := validateQuote(quote, now); err != nil {
+		return nil, err
+	}
+
+	var id ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("unable to create swap ID: %w", err)
+	}
+
+	swap := &Swap{
+		ID:        id,
+		Role:      RoleSeller,
+		State:     StateOffered,
+		Quote:     quote,
+		CreatedAt: now,
+	}
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	m.pruneExpired()
+	m.swaps[id] = swap
+
+	log.Infof("Created swap offer %v to sell %d units of asset %v for %v",
+		id, quote.AssetAmount, quote.AssetID, quote.priceString())
+
+	return swap.Copy(), nil
+}
+
+// AcceptOffer accepts the offer with the given ID and quote of a seller, in
+// which we buy the asset for BTC or another asset. The keys the asset is sent
+// to are derived by calling the given function.
+func (m *Manager) AcceptOffer(ctx context.Context, id ID, quote Quote,
+	accept AcceptFunc) (*Swap, error) {
+
+	now := m.cfg.Clock.Now()
+	if err := validateQuote(quote, now); err != nil {
+		return nil, err
+	}
+
+	m.swapsMtx.Lock()
+	m.pruneExpired()
+	_, exists := m.swaps[id]
+	m.swapsMtx.Unlock()
+
+	if exists {
+		return nil, fmt.Errorf("swap %v already exists", id)
+	}
+
+	acceptance, err := accept(ctx, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	if _, ok := m.swaps[id]; ok {
+		return nil, fmt.Errorf("swap %v already exists", id)
+	}
+
+	swap := &Swap{
+		ID:         id,
+		Role:       RoleBuyer,
+		State:      StateAccepted,
+		Quote:      quote,
+		Acceptance: acceptance,
+		CreatedAt:  now,
+	}
+	m.swaps[id] = swap
+
+	log.Infof("Accepted swap offer %v to buy %d units of asset %v for %v",
+		id, quote.AssetAmount, quote.AssetID, quote.priceString())
+
+	return swap.Copy(), nil
+}
+
+// Propose creates the seller's proposal for the offered swap with the given ID
+// and the buyer's acceptance by calling the given function.
+func (m *Manager) Propose(ctx context.Context, id ID, acceptance Acceptance,
+	propose ProposeFunc) (*Swap, error) {
+
+	if acceptance.ScriptKey == nil || acceptance.InternalKey == nil {
+		return nil, fmt.Errorf("acceptance must contain script and " +
+			"internal key")
+	}
+
+	swap, err := m.claimSwap(id, RoleSeller, StateOffered)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := propose(ctx, swap.Quote, acceptance)
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	swap = m.swaps[id]
+	swap.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	swap.Acceptance = &acceptance
+	swap.Proposal = proposal
+	swap.State = StateProposed
+
+	log.Infof("Proposed swap %v with %d active and %d passive virtual "+
+		"packets", id, len(proposal.ActivePackets),
+		len(proposal.PassivePackets))
+
+	return swap.Copy(), nil
+}
+
+// Fund validates the seller's proposal for the accepted swap with the given ID
+// and funds the anchor transaction that pays the seller by calling the given
+// function. In an asset-for-asset swap, the function also signs the virtual
+// transaction that sends the price asset to the seller.
+func (m *Manager) Fund(ctx context.Context, id ID, proposal *Proposal,
+	fund FundFunc) (*Swap, error) {
+
+	swap, err := m.claimSwap(id, RoleBuyer, StateAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal = &Proposal{
+		ActivePackets:   copyPackets(proposal.ActivePackets),
+		PassivePackets:  copyPackets(proposal.PassivePackets),
+		InputProofs:     proposal.InputProofs,
+		PaymentPkScript: proposal.PaymentPkScript,
+		PriceKeys:       proposal.PriceKeys,
+	}
+
+	var funding *Funding
+	err = m.validateProposal(ctx, swap, proposal)
+	if err == nil {
+		swap.Proposal = proposal
+		funding, err = fund(ctx, swap.Copy())
+	}
+	if err == nil {
+		err = validatePayment(swap, funding)
+	}
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	swap = m.swaps[id]
+	swap.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := funding.AnchorPsbt.UnsignedTx.TxHash()
+	swap.Proposal = proposal
+	swap.Funding = funding
+	swap.AnchorTxHash = &txHash
+	swap.State = StateFunded
+
+	log.Infof("Funded anchor transaction %v of swap %v", txHash, id)
+
+	return swap.Copy(), nil
+}
+
+// Publish validates the buyer's funding of the proposed swap with the given ID
+// and, if it pays us and anchors the proposed virtual transactions, signs and
+// publishes the anchor transaction by calling the given function. In an
+// asset-for-asset swap, the buyer's price asset transfer must be fully signed
+// and spend assets with valid proofs.
+func (m *Manager) Publish(ctx context.Context, id ID, funding *Funding,
+	publish PublishFunc) (*Swap, error) {
+
+	if funding == nil || funding.AnchorPsbt == nil {
+		return nil, fmt.Errorf("funding is missing anchor transaction")
+	}
+
+	swap, err := m.claimSwap(id, RoleSeller, StateProposed)
+	if err != nil {
+		return nil, err
+	}
+
+	// The funding is validated before it is copied, since copying the
+	// packets changes how their empty anchor derivation paths compare to
+	// those of the anchor transaction.
+	err = m.validateFunding(ctx, swap, funding)
+	if err == nil {
+		funding = &Funding{
+			AnchorPsbt:     copyPsbt(funding.AnchorPsbt),
+			ActivePackets:  copyPackets(funding.ActivePackets),
+			PassivePackets: copyPackets(funding.PassivePackets),
+			PricePackets:   copyPackets(funding.PricePackets),
+			PricePassivePackets: copyPackets(
+				funding.PricePassivePackets,
+			),
+			PriceInputProofs: funding.PriceInputProofs,
+		}
+		err = publish(ctx, swap.Copy(), funding)
+	}
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	swap = m.swaps[id]
+	swap.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := funding.AnchorPsbt.UnsignedTx.TxHash()
+	swap.Funding = funding
+	swap.AnchorTxHash = &txHash
+	swap.State = StatePublished
+
+	log.Infof("Published anchor transaction %v of swap %v", txHash, id)
+
+	return swap.Copy(), nil
+}
+
+// Complete imports the asset we received in the swap with the given ID once
+// its anchor transaction confirmed, by calling the given function. The buyer
+// completes a swap once it funded it, the seller of an asset-for-asset swap
+// once it published it.
+func (m *Manager) Complete(ctx context.Context, id ID,
+	complete CompleteFunc) (*Swap, error) {
+
+	role, state := RoleBuyer, StateFunded
+	swap, err := m.FetchSwap(id)
+	if err != nil {
+		return nil, err
+	}
+	if swap.Role == RoleSeller && swap.Quote.IsAssetSwap() {
+		role, state = RoleSeller, StatePublished
+	}
+
+	swap, err = m.claimSwap(id, role, state)
+	if err != nil {
+		return nil, err
+	}
+
+	err = complete(ctx, swap)
+
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	swap = m.swaps[id]
+	swap.busy = false
+	if err != nil {
+		return nil, err
+	}
+
+	swap.State = StateCompleted
+
+	log.Infof("Completed swap %v", id)
+
+	return swap.Copy(), nil
+}
+
+// FetchSwap returns the swap with the given ID.
+func (m *Manager) FetchSwap(id ID) (*Swap, error) {
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	m.pruneExpired()
+
+	swap, ok := m.swaps[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrSwapNotFound, id)
+	}
+
+	return swap.Copy(), nil
+}
+
+// ListSwaps returns all swaps that weren't pruned yet, ordered by their
+// creation time.
+func (m *Manager) ListSwaps() []*Swap {
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	m.pruneExpired()
+
+	swaps := make([]*Swap, 0, len(m.swaps))
+	for _, swap := range m.swaps {
+		swaps = append(swaps, swap.Copy())
+	}
+	sort.Slice(swaps, func(i, j int) bool {
+		return swaps[i].CreatedAt.Before(swaps[j].CreatedAt)
+	})
+
+	return swaps
+}
+
+// claimSwap marks the swap with the given ID as busy, so it can be worked on
+// without holding the swaps mutex, and returns a copy of it. The swap must be
+// in the given role and state and its quote must not have expired. The caller
+// must clear the busy flag once done.
+func (m *Manager) claimSwap(id ID, role Role, state State) (*Swap, error) {
+	m.swapsMtx.Lock()
+	defer m.swapsMtx.Unlock()
+
+	m.pruneExpired()
+
+	swap, ok := m.swaps[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrSwapNotFound, id)
+	}
+
+	if swap.busy {
+		return nil, fmt.Errorf("%w: %v", ErrSwapBusy, id)
+	}
+
+	if swap.Role != role || swap.State != state {
+		return nil, fmt.Errorf("%w: swap %v is %v as %v, expected %v "+
+			"as %v", ErrInvalidState, id, swap.State, swap.Role,
+			state, role)
+	}
+
+	// Once the buyer funded the anchor transaction, it can be published by
+	// the seller at any time, so both parties must be able to complete the
+	// swap regardless of the quote's expiry.
+	if state < StateFunded && swap.expired(m.cfg.Clock.Now()) {
+		return nil, fmt.Errorf("%w: %v", ErrSwapExpired, id)
+	}
+
+	swap.busy = true
+
+	return swap.Copy(), nil
+}
+
+// pruneExpired removes all swaps that aren't busy and whose quote expired
+// longer than the retention duration ago.
+//
+// NOTE: The swaps mutex must be held when calling this method.
+func (m *Manager) pruneExpired() {
+	now := m.cfg.Clock.Now()
+	for id, swap := range m.swaps {
+		pruneTime := swap.Quote.Expiry.Add(m.cfg.SwapRetention)
+		if swap.busy || now.Before(pruneTime) {
+			continue
+		}
+
+		log.Debugf("Removing expired swap %v", id)
+		delete(m.swaps, id)
+	}
+}
+
+// validateProposal makes sure the seller's proposal sends the asset of the
+// quote to the keys of our acceptance, is fully signed and spends assets with
+// valid proofs.
+func (m *Manager) validateProposal(ctx context.Context, swap *Swap,
+	proposal *Proposal) error {
+
+	quote := swap.Quote
+	if len(proposal.ActivePackets) != 1 {
+		return fmt.Errorf("proposal must have exactly one active " +
+			"packet")
+	}
+
+	switch {
+	case quote.IsAssetSwap():
+		if len(proposal.PaymentPkScript) != 0 {
+			return fmt.Errorf("proposal of asset-for-asset swap " +
+				"must not have a payment script")
+		}
+
+		priceKeys := proposal.PriceKeys
+		if priceKeys == nil || priceKeys.ScriptKey == nil ||
+			priceKeys.InternalKey == nil {
+
+			return fmt.Errorf("proposal is missing price keys")
+		}
+
+	case len(proposal.PaymentPkScript) == 0:
+		return fmt.Errorf("proposal is missing payment script")
+
+	// We can't create the exclusion proofs for a P2TR payment output,
+	// since we don't know its internal key.
+	case txscript.IsPayToTaproot(proposal.PaymentPkScript):
+		return fmt.Errorf("P2TR payment scripts are not supported")
+
+	case proposal.PriceKeys != nil:
+		return fmt.Errorf("proposal of asset-for-BTC swap must not " +
+			"have price keys")
+	}
+
+	// Exactly one output must send the amount of the quote to our keys,
+	// without any time lock.
+	vPkt := proposal.ActivePackets[0]
+	err := tapsend.ValidateSwapOutput(
+		vPkt, quote.AssetID, quote.AssetAmount,
+		swap.Acceptance.ScriptKey, swap.Acceptance.InternalKey,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid proposal: %w", err)
+	}
+
+	// The assets must be fully signed by the seller, so it can't hold back
+	// the asset once we signed the anchor transaction.
+	return m.validateTransfer(
+		ctx, vPkt, proposal.PassivePackets, proposal.InputProofs,
+	)
+}
+
+// validateTransfer makes sure the given active and passive packets of the
+// counterparty are fully signed and that the active packet spends assets with
+// valid proofs.
+func (m *Manager) validateTransfer(ctx context.Context, vPkt *tappsbt.VPacket,
+	passivePackets []*tappsbt.VPacket, inputProofs []proof.Blob) error {
+
+	if len(inputProofs) != len(vPkt.Inputs) {
+		return fmt.Errorf("got %d input proofs for %d inputs",
+			len(inputProofs), len(vPkt.Inputs))
+	}
+
+	allPackets := append([]*tappsbt.VPacket{vPkt}, passivePackets...)
+	for idx, pkt := range allPackets {
+		err := tapsend.ValidateVirtualWitnesses(
+			pkt, m.cfg.WitnessValidator,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid witnesses in packet %d: %w",
+				idx, err)
+		}
+	}
+
+	// Finally, the assets that are spent must have a valid provenance.
+	for idx, vIn := range vPkt.Inputs {
+		snapshot, err := m.cfg.VerifyProof(ctx, inputProofs[idx])
+		if err != nil {
+			return fmt.Errorf("invalid proof of input %d: %w", idx,
+				err)
+		}
+
+		err = matchSnapshot(vIn, snapshot)
+		if err != nil {
+			return fmt.Errorf("proof of input %d doesn't match: %w",
+				idx, err)
+		}
+	}
+
+	return nil
+}
+
+// matchSnapshot makes sure the verified asset snapshot is the asset the given
+// virtual input spends.
+func matchSnapshot(vIn *tappsbt.VInput, snapshot *proof.AssetSnapshot) error {
+	inputAsset := vIn.Asset()
+	switch {
+	case snapshot.OutPoint != vIn.PrevID.OutPoint:
+		return fmt.Errorf("outpoint %v doesn't match %v",
+			snapshot.OutPoint, vIn.PrevID.OutPoint)
+
+	case snapshot.Asset.ID() != vIn.PrevID.ID:
+		return fmt.Errorf("asset ID %v doesn't match %v",
+			snapshot.Asset.ID(), vIn.PrevID.ID)
+
+	case asset.ToSerialized(snapshot.Asset.ScriptKey.PubKey) !=
+		vIn.PrevID.ScriptKey:
+
+		return fmt.Errorf("script key doesn't match")
+
+	case snapshot.Asset.Amount != inputAsset.Amount:
+		return fmt.Errorf("amount %d doesn't match %d",
+			snapshot.Asset.Amount, inputAsset.Amount)
+	}
+
+	return nil
+}
+
+// validatePayment makes sure the funding pays the price of the quote to the
+// seller, either with BTC in the anchor transaction or with the price asset.
+func validatePayment(swap *Swap, funding *Funding) error {
+	if funding == nil || funding.AnchorPsbt == nil {
+		return fmt.Errorf("funding is missing anchor transaction")
+	}
+
+	if swap.Quote.IsAssetSwap() {
+		return validateAssetPayment(swap, funding)
+	}
+
+	var paid btcutil.Amount
+	for _, txOut := range funding.AnchorPsbt.UnsignedTx.TxOut {
+		if bytes.Equal(txOut.PkScript, swap.Proposal.PaymentPkScript) {
+			paid += btcutil.Amount(txOut.Value)
+		}
+	}
+	if paid < swap.Quote.Price {
+		return fmt.Errorf("anchor transaction pays %v instead of %v",
+			paid, swap.Quote.Price)
+	}
+
+	if len(funding.PricePackets) != 0 ||
+		len(funding.PricePassivePackets) != 0 {
+
+		return fmt.Errorf("funding of asset-for-BTC swap must not " +
+			"have price packets")
+	}
+
+	return nil
+}
+
+// validateAssetPayment makes sure the price packet of the funding of an
+// asset-for-asset swap sends the price asset to the price keys of the seller
+// and doesn't share any anchor outputs with the proposed packets.
+func validateAssetPayment(swap *Swap, funding *Funding) error {
+	price, err := swap.Quote.AssetPrice.UnwrapOrErr(
+		fmt.Errorf("quote is missing asset price"),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(funding.PricePackets) != 1 {
+		return fmt.Errorf("funding must have exactly one price packet")
+	}
+
+	pricePkt := funding.PricePackets[0]
+	if len(funding.PriceInputProofs) != len(pricePkt.Inputs) {
+		return fmt.Errorf("funding has %d price input proofs for %d "+
+			"inputs", len(funding.PriceInputProofs),
+			len(pricePkt.Inputs))
+	}
+
+	priceKeys := swap.Proposal.PriceKeys
+	err = tapsend.ValidateSwapOutput(
+		pricePkt, price.AssetID, price.Amount, priceKeys.ScriptKey,
+		priceKeys.InternalKey,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid price packet: %w", err)
+	}
+
+	sellerPackets := append(
+		[]*tappsbt.VPacket{}, funding.ActivePackets...,
+	)
+	sellerPackets = append(sellerPackets, funding.PassivePackets...)
+	buyerPackets := append([]*tappsbt.VPacket{}, funding.PricePackets...)
+	buyerPackets = append(buyerPackets, funding.PricePassivePackets...)
+
+	return tapsend.ValidateSwapAnchors(sellerPackets, buyerPackets)
+}
+
+// validateFunding makes sure the anchor transaction of the funding pays the
+// price of the quote to the seller and anchors the virtual transactions of the
+// proposal unchanged. The price asset of an asset-for-asset swap must be fully
+// signed and have a valid provenance.
+func (m *Manager) validateFunding(ctx context.Context, swap *Swap,
+	funding *Funding) error {
+
+	if err := validatePayment(swap, funding); err != nil {
+		return err
+	}
+
+	proposal := swap.Proposal
+	err := matchPackets(proposal.ActivePackets, funding.ActivePackets)
+	if err != nil {
+		return fmt.Errorf("active packets don't match proposal: %w",
+			err)
+	}
+
+	err = matchPackets(proposal.PassivePackets, funding.PassivePackets)
+	if err != nil {
+		return fmt.Errorf("passive packets don't match proposal: %w",
+			err)
+	}
+
+	if swap.Quote.IsAssetSwap() {
+		err := m.validateTransfer(
+			ctx, funding.PricePackets[0],
+			funding.PricePassivePackets, funding.PriceInputProofs,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid price transfer: %w", err)
+		}
+	}
+
+	allPackets := append([]*tappsbt.VPacket{}, funding.ActivePackets...)
+	allPackets = append(allPackets, funding.PassivePackets...)
+	allPackets = append(allPackets, funding.PricePackets...)
+	allPackets = append(allPackets, funding.PricePassivePackets...)
+	err = tapsend.ValidateAnchorOutputs(
+		funding.AnchorPsbt, allPackets, false,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid anchor outputs: %w", err)
+	}
+
+	return nil
+}
+
+// matchPackets makes sure the committed packets describe the same signed
+// virtual transactions as the proposed packets and are anchored in the same
+// way. Otherwise, the counterparty could anchor our change in an output it
+// controls.
+func matchPackets(proposed, committed []*tappsbt.VPacket) error {
+	if len(proposed) != len(committed) {
+		return fmt.Errorf("expected %d packets, got %d", len(proposed),
+			len(committed))
+	}
+
+	for idx := range proposed {
+		txHash, witnesses, err := tapsend.VirtualWitnesses(
+			proposed[idx],
+		)
+		if err != nil {
+			return err
+		}
+
+		committedTxHash, committedWitnesses, err :=
+			tapsend.VirtualWitnesses(committed[idx])
+		if err != nil {
+			return err
+		}
+
+		if txHash != committedTxHash {
+			return fmt.Errorf("virtual transaction %v of packet "+
+				"%d doesn't match %v", committedTxHash, idx,
+				txHash)
+		}
+
+		for inIdx := range witnesses {
+			if !witnessesEqual(
+				witnesses[inIdx], committedWitnesses[inIdx],
+			) {
+
+				return fmt.Errorf("witness of input %d of "+
+					"packet %d doesn't match", inIdx, idx)
+			}
+		}
+
+		for outIdx := range proposed[idx].Outputs {
+			err := matchAnchor(
+				proposed[idx].Outputs[outIdx],
+				committed[idx].Outputs[outIdx],
+			)
+			if err != nil {
+				return fmt.Errorf("output %d of packet %d: %w",
+					outIdx, idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// witnessesEqual returns true if the two witnesses are equal.
+func witnessesEqual(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if !bytes.Equal(a[idx], b[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchAnchor makes sure the committed output is anchored in the same anchor
+// output as the proposed output.
+func matchAnchor(proposed, committed *tappsbt.VOutput) error {
+	if proposed.AnchorOutputIndex != committed.AnchorOutputIndex {
+		return fmt.Errorf("anchor output index %d doesn't match %d",
+			committed.AnchorOutputIndex, proposed.AnchorOutputIndex)
+	}
+
+	if !keysEqual(
+		proposed.AnchorOutputInternalKey,
+		committed.AnchorOutputInternalKey,
+	) {
+
+		return fmt.Errorf("anchor output internal key doesn't match")
+	}
+
+	proposedSibling, err := siblingHash(
+		proposed.AnchorOutputTapscriptSibling,
+	)
+	if err != nil {
+		return err
+	}
+
+	committedSibling, err := siblingHash(
+		committed.AnchorOutputTapscriptSibling,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(proposedSibling, committedSibling) {
+		return fmt.Errorf("anchor output tapscript sibling doesn't " +
+			"match")
+	}
+
+	return nil
+}
+
+// keysEqual returns true if both keys are nil or equal.
+func keysEqual(a, b *btcec.PublicKey) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.IsEqual(b)
+}
+
+// siblingHash returns the hash of the given tapscript sibling, or nil if there
+// is no sibling.
+func siblingHash(sibling *commitment.TapscriptPreimage) ([]byte, error) {
+	if sibling == nil {
+		return nil, nil
+	}
+
+	hash, err := sibling.TapHash()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tapscript sibling: %w", err)
+	}
+
+	return hash[:], nil
+}
+
+// validateQuote makes sure the given quote is complete and didn't expire at
+// the given time.
+func validateQuote(quote Quote, now time.Time) error {
+	switch {
+	case quote.AssetAmount == 0:
+		return fmt.Errorf("asset amount must be positive")
+
+	case !now.Before(quote.Expiry):
+		return fmt.Errorf("quote expired at %v", quote.Expiry)
+	}
+
+	if !quote.IsAssetSwap() {
+		if quote.Price <= 0 {
+			return fmt.Errorf("price must be positive")
+		}
+
+		return nil
+	}
+
+	// An asset-for-asset swap is only paid with the price asset.
+	price := quote.AssetPrice.UnwrapOr(AssetPrice{})
+	switch {
+	case quote.Price != 0:
+		return fmt.Errorf("asset-for-asset swap must not have a BTC " +
+			"price")
+
+	case price.Amount == 0:
+		return fmt.Errorf("price asset amount must be positive")
+
+	case price.AssetID == quote.AssetID:
+		return fmt.Errorf("price asset must differ from the sold " +
+			"asset")
+	}
+
+	return nil
+}
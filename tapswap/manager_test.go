@@ -0,0 +1,615 @@
+package tapswap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/commitment"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+	"github.com/lightninglabs/taproot-assets/tapscript"
+	"github.com/lightninglabs/taproot-assets/tapsend"
+	"github.com/lightninglabs/taproot-assets/vm"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// vmValidator validates witnesses with the Taproot Asset VM.
+type vmValidator struct{}
+
+// ValidateWitnesses validates the created witnesses of an asset transfer.
+func (vmValidator) ValidateWitnesses(newAsset *asset.Asset,
+	splitAssets []*commitment.SplitAsset,
+	prevAssets commitment.InputSet) error {
+
+	return vm.ValidateWitnesses(newAsset, splitAssets, prevAssets)
+}
+
+// swapHarness holds the state of a test swap between a seller and a buyer.
+type swapHarness struct {
+	t *testing.T
+
+	clock *clock.TestClock
+
+	seller *Manager
+	buyer  *Manager
+
+	sellerKey   *btcec.PrivateKey
+	inputAsset  *asset.Asset
+	inputPrevID asset.PrevID
+
+	paymentPkScript []byte
+
+	// buyerKey owns the price asset the buyer pays with in an
+	// asset-for-asset swap, which the seller receives with its price keys.
+	buyerKey     *btcec.PrivateKey
+	priceAsset   *asset.Asset
+	pricePrevID  asset.PrevID
+	sellerPrices *Acceptance
+}
+
+var (
+	// inputProof is the proof blob of the seller's asset.
+	inputProof = proof.Blob{0x01}
+
+	// priceProof is the proof blob of the buyer's price asset.
+	priceProof = proof.Blob{0x02}
+)
+
+// newTestAsset creates a new asset owned by the given key and the previous ID
+// of its anchor output.
+func newTestAsset(t *testing.T, key *btcec.PrivateKey) (*asset.Asset,
+	asset.PrevID) {
+
+	newAsset := asset.NewAssetNoErr(
+		t, asset.RandGenesis(t, asset.Normal), 100, 0, 0,
+		asset.NewScriptKeyBip86(keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		}), nil,
+	)
+	prevID := asset.PrevID{
+		OutPoint:  test.RandOp(t),
+		ID:        newAsset.ID(),
+		ScriptKey: asset.ToSerialized(newAsset.ScriptKey.PubKey),
+	}
+
+	return newAsset, prevID
+}
+
+// newSwapHarness creates a seller and a buyer manager and an asset the seller
+// owns.
+func newSwapHarness(t *testing.T) *swapHarness {
+	h := &swapHarness{
+		t:         t,
+		clock:     clock.NewTestClock(time.Now()),
+		sellerKey: test.RandPrivKey(),
+		buyerKey:  test.RandPrivKey(),
+		sellerPrices: &Acceptance{
+			ScriptKey:   test.RandPubKey(t),
+			InternalKey: test.RandPubKey(t),
+		},
+	}
+
+	h.inputAsset, h.inputPrevID = newTestAsset(t, h.sellerKey)
+	h.priceAsset, h.pricePrevID = newTestAsset(t, h.buyerKey)
+
+	paymentKey := test.RandPubKey(t)
+	paymentPkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(paymentKey.SerializeCompressed())).
+		Script()
+	require.NoError(t, err)
+	h.paymentPkScript = paymentPkScript
+
+	// The proofs are verified by returning the snapshot of the asset the
+	// blob stands for.
+	verifyProof := func(_ context.Context,
+		blob proof.Blob) (*proof.AssetSnapshot, error) {
+
+		if bytes.Equal(blob, priceProof) {
+			return &proof.AssetSnapshot{
+				Asset:    h.priceAsset,
+				OutPoint: h.pricePrevID.OutPoint,
+			}, nil
+		}
+
+		return &proof.AssetSnapshot{
+			Asset:    h.inputAsset,
+			OutPoint: h.inputPrevID.OutPoint,
+		}, nil
+	}
+
+	cfg := ManagerConfig{
+		Clock:            h.clock,
+		WitnessValidator: vmValidator{},
+		VerifyProof:      verifyProof,
+	}
+	h.seller = NewManager(cfg)
+	h.buyer = NewManager(cfg)
+
+	return h
+}
+
+// newSplitPacket creates a packet that sends the given amount of the given
+// input asset to the given keys and the rest to a change output. The change is
+// anchored at the given index, the sent amount at the index after it. If sign
+// is false, the input isn't signed.
+func newSplitPacket(t *testing.T, inputAsset *asset.Asset,
+	prevID asset.PrevID, key *btcec.PrivateKey, amount uint64,
+	keys Acceptance, changeIndex uint32, sign bool) *tappsbt.VPacket {
+
+	anchorKey := test.RandPubKey(t)
+	anchorPkScript, err := txscript.PayToTaprootScript(anchorKey)
+	require.NoError(t, err)
+
+	scriptKey := asset.NewScriptKey(keys.ScriptKey)
+	vPkt := &tappsbt.VPacket{
+		ChainParams: &address.RegressionNetTap,
+		Inputs: []*tappsbt.VInput{{
+			PrevID: prevID,
+			Anchor: tappsbt.Anchor{
+				Value:       1000,
+				PkScript:    anchorPkScript,
+				InternalKey: anchorKey,
+			},
+		}},
+		Outputs: []*tappsbt.VOutput{{
+			Amount:                  inputAsset.Amount - amount,
+			Type:                    tappsbt.TypeSplitRoot,
+			ScriptKey:               asset.RandScriptKey(t),
+			AnchorOutputIndex:       changeIndex,
+			AnchorOutputInternalKey: test.RandPubKey(t),
+		}, {
+			Amount:                  amount,
+			Type:                    tappsbt.TypeSimple,
+			ScriptKey:               scriptKey,
+			AnchorOutputIndex:       changeIndex + 1,
+			AnchorOutputInternalKey: keys.InternalKey,
+		}},
+	}
+	vPkt.SetInputAsset(0, inputAsset)
+
+	err = tapsend.PrepareOutputAssets(context.Background(), vPkt)
+	require.NoError(t, err)
+
+	// Validating the witness also attaches the signed root asset to the
+	// split outputs.
+	if sign {
+		err = tapsend.SignVirtualInputs(
+			vPkt, tapscript.NewMockSigner(key), []uint32{0},
+		)
+		require.NoError(t, err)
+
+		err = tapsend.ValidateVirtualWitnesses(vPkt, vmValidator{})
+		require.NoError(t, err)
+	}
+
+	return vPkt
+}
+
+// propose returns a propose function that sends the quoted amount of the
+// input asset to the acceptance keys and the rest back to the seller. If sign
+// is false, the input isn't signed.
+func (h *swapHarness) propose(sign bool) ProposeFunc {
+	return func(_ context.Context, quote Quote,
+		acceptance Acceptance) (*Proposal, error) {
+
+		vPkt := newSplitPacket(
+			h.t, h.inputAsset, h.inputPrevID, h.sellerKey,
+			quote.AssetAmount, acceptance, 0, sign,
+		)
+
+		proposal := &Proposal{
+			ActivePackets:   []*tappsbt.VPacket{vPkt},
+			InputProofs:     []proof.Blob{inputProof},
+			PaymentPkScript: h.paymentPkScript,
+		}
+		if quote.IsAssetSwap() {
+			proposal.PaymentPkScript = nil
+			proposal.PriceKeys = h.sellerPrices
+		}
+
+		return proposal, nil
+	}
+}
+
+// commitPackets commits the given packets to the outputs of the given anchor
+// transaction and creates their proof suffixes.
+func commitPackets(t *testing.T, anchorPsbt *psbt.Packet,
+	packets []*tappsbt.VPacket) {
+
+	outputCommitments, err := tapsend.CreateOutputCommitments(packets)
+	require.NoError(t, err)
+
+	for _, vPkt := range packets {
+		err := tapsend.UpdateTaprootOutputKeys(
+			anchorPsbt, vPkt, outputCommitments,
+		)
+		require.NoError(t, err)
+	}
+
+	for _, vPkt := range packets {
+		for idx := range vPkt.Outputs {
+			suffix, err := tapsend.CreateProofSuffix(
+				anchorPsbt.UnsignedTx, anchorPsbt.Outputs,
+				vPkt, outputCommitments, idx, packets,
+			)
+			require.NoError(t, err)
+
+			vPkt.Outputs[idx].ProofSuffix = suffix
+		}
+	}
+}
+
+// fund returns a fund function that commits the proposed packets to an anchor
+// transaction that pays the given amount to the seller.
+func (h *swapHarness) fund(payment btcutil.Amount) FundFunc {
+	return func(_ context.Context, swap *Swap) (*Funding, error) {
+		t := h.t
+		packets := swap.Proposal.ActivePackets
+
+		anchorPsbt, err := tapsend.PrepareAnchoringTemplate(packets)
+		require.NoError(t, err)
+
+		anchorPsbt.UnsignedTx.AddTxOut(&wire.TxOut{
+			Value:    int64(payment),
+			PkScript: swap.Proposal.PaymentPkScript,
+		})
+		anchorPsbt.Outputs = append(anchorPsbt.Outputs, psbt.POutput{})
+
+		commitPackets(t, anchorPsbt, packets)
+
+		return &Funding{
+			AnchorPsbt:    anchorPsbt,
+			ActivePackets: packets,
+		}, nil
+	}
+}
+
+// fundAsset returns a fund function that commits the proposed packets and a
+// price packet, which sends the given amount of the price asset to the
+// seller's price keys, to an anchor transaction. The change of the price asset
+// is anchored at the given output index.
+func (h *swapHarness) fundAsset(amount uint64, changeIndex uint32) FundFunc {
+	return func(_ context.Context, swap *Swap) (*Funding, error) {
+		t := h.t
+		pricePkt := newSplitPacket(
+			t, h.priceAsset, h.pricePrevID, h.buyerKey, amount,
+			*swap.Proposal.PriceKeys, changeIndex, true,
+		)
+
+		proposed := swap.Proposal.ActivePackets
+		packets := append([]*tappsbt.VPacket{}, proposed...)
+		packets = append(packets, pricePkt)
+
+		anchorPsbt, err := tapsend.PrepareAnchoringTemplate(packets)
+		require.NoError(t, err)
+
+		commitPackets(t, anchorPsbt, packets)
+
+		return &Funding{
+			AnchorPsbt:       anchorPsbt,
+			ActivePackets:    proposed,
+			PricePackets:     []*tappsbt.VPacket{pricePkt},
+			PriceInputProofs: []proof.Blob{priceProof},
+		}, nil
+	}
+}
+
+// transmit serializes and deserializes the given funding, just like it is
+// exchanged between the parties through the RPC interface.
+func transmit(t *testing.T, funding *Funding) *Funding {
+	var buf bytes.Buffer
+	require.NoError(t, funding.AnchorPsbt.Serialize(&buf))
+	anchorPsbt, err := psbt.NewFromRawBytes(&buf, false)
+	require.NoError(t, err)
+
+	return &Funding{
+		AnchorPsbt:       anchorPsbt,
+		ActivePackets:    transmitPackets(t, funding.ActivePackets),
+		PricePackets:     transmitPackets(t, funding.PricePackets),
+		PriceInputProofs: funding.PriceInputProofs,
+	}
+}
+
+// transmitPackets serializes and deserializes the given virtual packets.
+func transmitPackets(t *testing.T,
+	packets []*tappsbt.VPacket) []*tappsbt.VPacket {
+
+	var transmitted []*tappsbt.VPacket
+	for _, vPkt := range packets {
+		var buf bytes.Buffer
+		require.NoError(t, vPkt.Serialize(&buf))
+
+		decoded, err := tappsbt.Decode(buf.Bytes())
+		require.NoError(t, err)
+
+		transmitted = append(transmitted, decoded)
+	}
+
+	return transmitted
+}
+
+// TestSwap tests an asset-for-BTC swap between a seller and a buyer, making
+// sure the buyer only funds a valid proposal and the seller only publishes an
+// anchor transaction that pays it and anchors its change unchanged.
+func TestSwap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := newSwapHarness(t)
+
+	quote := Quote{
+		AssetID:     h.inputAsset.ID(),
+		AssetAmount: 40,
+		Price:       50_000,
+		Expiry:      h.clock.Now().Add(time.Hour),
+	}
+
+	// An offer must have a price.
+	_, err := h.seller.CreateOffer(Quote{
+		AssetID:     quote.AssetID,
+		AssetAmount: quote.AssetAmount,
+		Expiry:      quote.Expiry,
+	})
+	require.ErrorContains(t, err, "price must be positive")
+
+	offer, err := h.seller.CreateOffer(quote)
+	require.NoError(t, err)
+	require.Equal(t, RoleSeller, offer.Role)
+	require.Equal(t, StateOffered, offer.State)
+
+	buyerKeys := &Acceptance{
+		ScriptKey:   test.RandPubKey(t),
+		InternalKey: test.RandPubKey(t),
+	}
+	accept := func(context.Context, Quote) (*Acceptance, error) {
+		return buyerKeys, nil
+	}
+
+	accepted, err := h.buyer.AcceptOffer(ctx, offer.ID, quote, accept)
+	require.NoError(t, err)
+	require.Equal(t, RoleBuyer, accepted.Role)
+	require.Equal(t, StateAccepted, accepted.State)
+
+	// The same offer can't be accepted twice, and the buyer can't make
+	// the seller's moves.
+	_, err = h.buyer.AcceptOffer(ctx, offer.ID, quote, accept)
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = h.buyer.Propose(ctx, offer.ID, *buyerKeys, h.propose(true))
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	// The buyer refuses a proposal that isn't signed or that sends the
+	// asset to another key. The swap can still be funded afterwards.
+	unsigned, err := h.propose(false)(ctx, quote, *buyerKeys)
+	require.NoError(t, err)
+	_, err = h.buyer.Fund(ctx, offer.ID, unsigned, h.fund(quote.Price))
+	require.ErrorContains(t, err, "missing a witness")
+
+	otherKeys := Acceptance{
+		ScriptKey:   test.RandPubKey(t),
+		InternalKey: buyerKeys.InternalKey,
+	}
+	wrongKey, err := h.propose(true)(ctx, quote, otherKeys)
+	require.NoError(t, err)
+	_, err = h.buyer.Fund(ctx, offer.ID, wrongKey, h.fund(quote.Price))
+	require.ErrorContains(t, err, "exactly one output to the script key")
+
+	proposed, err := h.seller.Propose(
+		ctx, offer.ID, *buyerKeys, h.propose(true),
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateProposed, proposed.State)
+
+	// The buyer's own funding is checked to pay the price.
+	_, err = h.buyer.Fund(
+		ctx, offer.ID, proposed.Proposal, h.fund(quote.Price-1),
+	)
+	require.ErrorContains(t, err, "anchor transaction pays")
+
+	funded, err := h.buyer.Fund(
+		ctx, offer.ID, proposed.Proposal, h.fund(quote.Price),
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateFunded, funded.State)
+
+	// The seller refuses to publish an anchor transaction that anchors its
+	// change with another internal key.
+	published := 0
+	publish := func(context.Context, *Swap, *Funding) error {
+		published++
+		return nil
+	}
+
+	tampered := transmit(t, funded.Funding)
+	tampered.ActivePackets[0].Outputs[0].AnchorOutputInternalKey =
+		test.RandPubKey(t)
+	_, err = h.seller.Publish(ctx, offer.ID, tampered, publish)
+	require.ErrorContains(t, err, "internal key doesn't match")
+	require.Zero(t, published)
+
+	sold, err := h.seller.Publish(
+		ctx, offer.ID, transmit(t, funded.Funding), publish,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+	require.Equal(t, StatePublished, sold.State)
+	require.Equal(t, *funded.AnchorTxHash, *sold.AnchorTxHash)
+
+	// The seller receives BTC, so it has no asset to complete the swap
+	// with.
+	_, err = h.seller.Complete(
+		ctx, offer.ID, func(context.Context, *Swap) error {
+			return nil
+		},
+	)
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	// The buyer can complete the swap even once the quote expired.
+	h.clock.SetTime(quote.Expiry)
+	bought, err := h.buyer.Complete(
+		ctx, offer.ID, func(context.Context, *Swap) error {
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateCompleted, bought.State)
+	require.Len(t, h.buyer.ListSwaps(), 1)
+
+	// Once the retention passed, the swaps are removed.
+	h.clock.SetTime(quote.Expiry.Add(DefaultSwapRetention))
+	_, err = h.seller.FetchSwap(offer.ID)
+	require.ErrorIs(t, err, ErrSwapNotFound)
+	require.Empty(t, h.buyer.ListSwaps())
+}
+
+// TestSwapExpiry tests that a swap can't progress once its quote expired.
+func TestSwapExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := newSwapHarness(t)
+
+	quote := Quote{
+		AssetID:     h.inputAsset.ID(),
+		AssetAmount: 40,
+		Price:       50_000,
+		Expiry:      h.clock.Now().Add(time.Minute),
+	}
+	offer, err := h.seller.CreateOffer(quote)
+	require.NoError(t, err)
+
+	h.clock.SetTime(quote.Expiry)
+
+	keys := Acceptance{
+		ScriptKey:   test.RandPubKey(t),
+		InternalKey: test.RandPubKey(t),
+	}
+	_, err = h.seller.Propose(ctx, offer.ID, keys, h.propose(true))
+	require.ErrorIs(t, err, ErrSwapExpired)
+
+	_, err = h.buyer.AcceptOffer(
+		ctx, offer.ID, quote,
+		func(context.Context, Quote) (*Acceptance, error) {
+			return &keys, nil
+		},
+	)
+	require.ErrorContains(t, err, "quote expired")
+}
+
+// TestAssetSwap tests an asset-for-asset swap between a seller and a buyer,
+// making sure the seller only publishes an anchor transaction that anchors a
+// fully signed and valid transfer of the price asset.
+func TestAssetSwap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	h := newSwapHarness(t)
+
+	quote := Quote{
+		AssetID:     h.inputAsset.ID(),
+		AssetAmount: 40,
+		AssetPrice: fn.Some(AssetPrice{
+			AssetID: h.priceAsset.ID(),
+			Amount:  30,
+		}),
+		Expiry: h.clock.Now().Add(time.Hour),
+	}
+
+	// An asset-for-asset swap can't also have a BTC price and can't swap
+	// an asset for itself.
+	invalid := quote
+	invalid.Price = 50_000
+	_, err := h.seller.CreateOffer(invalid)
+	require.ErrorContains(t, err, "must not have a BTC price")
+
+	invalid = quote
+	invalid.AssetPrice = fn.Some(AssetPrice{
+		AssetID: quote.AssetID,
+		Amount:  30,
+	})
+	_, err = h.seller.CreateOffer(invalid)
+	require.ErrorContains(t, err, "must differ from the sold asset")
+
+	offer, err := h.seller.CreateOffer(quote)
+	require.NoError(t, err)
+
+	buyerKeys := &Acceptance{
+		ScriptKey:   test.RandPubKey(t),
+		InternalKey: test.RandPubKey(t),
+	}
+	_, err = h.buyer.AcceptOffer(
+		ctx, offer.ID, quote,
+		func(context.Context, Quote) (*Acceptance, error) {
+			return buyerKeys, nil
+		},
+	)
+	require.NoError(t, err)
+
+	proposed, err := h.seller.Propose(
+		ctx, offer.ID, *buyerKeys, h.propose(true),
+	)
+	require.NoError(t, err)
+	require.Equal(t, h.sellerPrices, proposed.Proposal.PriceKeys)
+
+	// The buyer's own funding is checked to pay the price asset.
+	_, err = h.buyer.Fund(
+		ctx, offer.ID, proposed.Proposal, h.fundAsset(29, 2),
+	)
+	require.ErrorContains(t, err, "sends 29 units instead of 30")
+
+	funded, err := h.buyer.Fund(
+		ctx, offer.ID, proposed.Proposal, h.fundAsset(30, 2),
+	)
+	require.NoError(t, err)
+	require.Equal(t, StateFunded, funded.State)
+
+	// The seller refuses to publish an anchor transaction if the proof of
+	// the price asset doesn't match the asset that is spent.
+	published := 0
+	publish := func(context.Context, *Swap, *Funding) error {
+		published++
+		return nil
+	}
+
+	tampered := transmit(t, funded.Funding)
+	tampered.PriceInputProofs = []proof.Blob{inputProof}
+	_, err = h.seller.Publish(ctx, offer.ID, tampered, publish)
+	require.ErrorContains(t, err, "invalid price transfer")
+	require.Zero(t, published)
+
+	sold, err := h.seller.Publish(
+		ctx, offer.ID, transmit(t, funded.Funding), publish,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+	require.Len(t, sold.Funding.PricePackets, 1)
+
+	// Both parties receive an asset, so both complete the swap, even once
+	// the quote expired.
+	h.clock.SetTime(quote.Expiry)
+	complete := func(context.Context, *Swap) error {
+		return nil
+	}
+
+	bought, err := h.buyer.Complete(ctx, offer.ID, complete)
+	require.NoError(t, err)
+	require.Equal(t, StateCompleted, bought.State)
+
+	sold, err = h.seller.Complete(ctx, offer.ID, complete)
+	require.NoError(t, err)
+	require.Equal(t, StateCompleted, sold.State)
+}
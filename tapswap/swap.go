@@ -0,0 +1,360 @@
+package tapswap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tappsbt"
+)
+
+var (
+	// ErrSwapNotFound is returned if a swap with the given ID doesn't
+	// exist or already expired.
+	ErrSwapNotFound = errors.New("swap not found")
+
+	// ErrInvalidState is returned if an operation is attempted on a swap
+	// that isn't in the state the operation requires.
+	ErrInvalidState = errors.New("invalid swap state")
+
+	// ErrSwapBusy is returned if the swap is currently being worked on by
+	// another operation.
+	ErrSwapBusy = errors.New("swap is busy")
+
+	// ErrSwapExpired is returned if the quote of a swap expired before
+	// the swap was funded.
+	ErrSwapExpired = errors.New("swap quote expired")
+)
+
+// ID is the unique ID of a swap that is shared by both of its parties.
+type ID [32]byte
+
+// String returns the hex encoded swap ID.
+func (i ID) String() string {
+	return fmt.Sprintf("%x", i[:])
+}
+
+// Role is the role of this daemon in a swap.
+type Role uint8
+
+const (
+	// RoleSeller is the role of the party that sells the asset for BTC.
+	RoleSeller Role = 0
+
+	// RoleBuyer is the role of the party that buys the asset with BTC.
+	RoleBuyer Role = 1
+)
+
+// String returns the human-readable name of the role.
+func (r Role) String() string {
+	switch r {
+	case RoleSeller:
+		return "seller"
+
+	case RoleBuyer:
+		return "buyer"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// State is the state of a swap.
+type State uint8
+
+const (
+	// StateOffered is the state of a swap whose quote was offered by the
+	// seller.
+	StateOffered State = 0
+
+	// StateAccepted is the state of a swap whose quote was accepted by the
+	// buyer, who now waits for the seller's proposal.
+	StateAccepted State = 1
+
+	// StateProposed is the state of a swap for which the seller signed the
+	// virtual transactions that send the asset to the buyer.
+	StateProposed State = 2
+
+	// StateFunded is the state of a swap whose anchor transaction was
+	// funded and signed by the buyer, who now waits for the seller to
+	// publish it.
+	StateFunded State = 3
+
+	// StatePublished is the state of a swap whose anchor transaction was
+	// signed and published by the seller.
+	StatePublished State = 4
+
+	// StateCompleted is the state of a swap whose received asset was
+	// imported after the anchor transaction confirmed. Sellers only
+	// complete asset-for-asset swaps, since they receive BTC otherwise.
+	StateCompleted State = 5
+)
+
+// String returns the human-readable name of the state.
+func (s State) String() string {
+	switch s {
+	case StateOffered:
+		return "offered"
+
+	case StateAccepted:
+		return "accepted"
+
+	case StateProposed:
+		return "proposed"
+
+	case StateFunded:
+		return "funded"
+
+	case StatePublished:
+		return "published"
+
+	case StateCompleted:
+		return "completed"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// AssetPrice is the price of an asset-for-asset swap, which the buyer pays
+// with units of another asset.
+type AssetPrice struct {
+	// AssetID is the ID of the asset the buyer pays with.
+	AssetID asset.ID
+
+	// Amount is the amount of asset units the buyer pays.
+	Amount uint64
+}
+
+// Quote are the terms of a swap both parties agreed on.
+type Quote struct {
+	// AssetID is the ID of the asset that is sold.
+	AssetID asset.ID
+
+	// AssetAmount is the amount of asset units that is sold.
+	AssetAmount uint64
+
+	// Price is the amount of satoshis the buyer pays for the asset units.
+	// It is zero for asset-for-asset swaps.
+	Price btcutil.Amount
+
+	// AssetPrice is the asset the buyer pays for the asset units, if this
+	// is an asset-for-asset swap.
+	AssetPrice fn.Option[AssetPrice]
+
+	// Expiry is the time after which the quote is no longer valid.
+	Expiry time.Time
+}
+
+// IsAssetSwap returns true if the buyer pays with another asset instead of
+// BTC.
+func (q Quote) IsAssetSwap() bool {
+	return q.AssetPrice.IsSome()
+}
+
+// priceString returns a human-readable description of the price of the
+// quote.
+func (q Quote) priceString() string {
+	price := q.Price.String()
+	q.AssetPrice.WhenSome(func(p AssetPrice) {
+		price = fmt.Sprintf("%d units of asset %v", p.Amount, p.AssetID)
+	})
+
+	return price
+}
+
+// Acceptance is the buyer's answer to an offer, which contains the keys the
+// asset is sent to.
+type Acceptance struct {
+	// ScriptKey is the asset-level script key of the buyer's output.
+	ScriptKey *btcec.PublicKey
+
+	// InternalKey is the internal key of the buyer's anchor output.
+	InternalKey *btcec.PublicKey
+}
+
+// Proposal is the seller's answer to an acceptance, which contains the signed
+// virtual transactions that send the asset to the buyer.
+type Proposal struct {
+	// ActivePackets are the signed virtual transactions that send the
+	// asset to the buyer. There is exactly one active packet.
+	ActivePackets []*tappsbt.VPacket
+
+	// PassivePackets are the signed virtual transactions of the assets
+	// that are carried along from the seller's anchor inputs.
+	PassivePackets []*tappsbt.VPacket
+
+	// InputProofs are the proof files of the inputs of the active packet,
+	// in the order of the inputs. The buyer needs them to verify the
+	// provenance of the asset and to create the proof file of its output.
+	InputProofs []proof.Blob
+
+	// PaymentPkScript is the output script the seller wants to be paid
+	// to. It must not be a P2TR script, since the buyer couldn't prove
+	// that the payment output doesn't commit to any assets. It is empty
+	// for asset-for-asset swaps.
+	PaymentPkScript []byte
+
+	// PriceKeys are the keys the seller receives the price asset with, if
+	// this is an asset-for-asset swap.
+	PriceKeys *Acceptance
+}
+
+// Funding is the buyer's answer to a proposal, which contains the anchor
+// transaction that pays the seller and anchors the asset. In an
+// asset-for-asset swap, the anchor transaction also anchors the buyer's
+// virtual transactions that send the price asset to the seller.
+type Funding struct {
+	// AnchorPsbt is the anchor transaction, with the buyer's inputs
+	// signed.
+	AnchorPsbt *psbt.Packet
+
+	// ActivePackets are the virtual transactions of the proposal, updated
+	// with the proofs of being committed to the anchor transaction.
+	ActivePackets []*tappsbt.VPacket
+
+	// PassivePackets are the passive virtual transactions of the
+	// proposal, updated with the proofs of being committed to the anchor
+	// transaction.
+	PassivePackets []*tappsbt.VPacket
+
+	// PricePackets are the signed virtual transactions that send the price
+	// asset of an asset-for-asset swap to the seller. There is exactly one
+	// price packet in an asset-for-asset swap.
+	PricePackets []*tappsbt.VPacket
+
+	// PricePassivePackets are the signed virtual transactions of the assets
+	// that are carried along from the buyer's anchor inputs of the price
+	// asset.
+	PricePassivePackets []*tappsbt.VPacket
+
+	// PriceInputProofs are the proof files of the inputs of the price
+	// packet, in the order of the inputs. The seller needs them to verify
+	// the provenance of the price asset and to create the proof file of
+	// its output.
+	PriceInputProofs []proof.Blob
+}
+
+// Swap is an atomic swap of an asset for BTC or another asset between two
+// daemons. Both the asset and the payment are transferred in the same anchor
+// transaction, so either both or neither of them are transferred.
+type Swap struct {
+	// ID is the unique ID of the swap.
+	ID ID
+
+	// Role is the role of this daemon in the swap.
+	Role Role
+
+	// State is the current state of the swap.
+	State State
+
+	// Quote are the agreed terms of the swap.
+	Quote Quote
+
+	// Acceptance is the buyer's acceptance of the quote, once the swap
+	// reaches the accepted state.
+	Acceptance *Acceptance
+
+	// Proposal is the seller's proposal, once the swap reaches the
+	// proposed state.
+	Proposal *Proposal
+
+	// Funding is the buyer's funding of the anchor transaction, once the
+	// swap reaches the funded state.
+	Funding *Funding
+
+	// AnchorTxHash is the hash of the anchor transaction, once the swap is
+	// funded.
+	AnchorTxHash *chainhash.Hash
+
+	// CreatedAt is the time the swap was created.
+	CreatedAt time.Time
+
+	// busy is set while the swap is being worked on outside of the
+	// manager's mutex.
+	busy bool
+}
+
+// Copy returns a deep copy of the swap.
+func (s *Swap) Copy() *Swap {
+	swapCopy := &Swap{
+		ID:           s.ID,
+		Role:         s.Role,
+		State:        s.State,
+		Quote:        s.Quote,
+		AnchorTxHash: s.AnchorTxHash,
+		CreatedAt:    s.CreatedAt,
+	}
+
+	if s.Acceptance != nil {
+		acceptance := *s.Acceptance
+		swapCopy.Acceptance = &acceptance
+	}
+
+	if s.Proposal != nil {
+		swapCopy.Proposal = &Proposal{
+			ActivePackets:  copyPackets(s.Proposal.ActivePackets),
+			PassivePackets: copyPackets(s.Proposal.PassivePackets),
+			InputProofs: append(
+				[]proof.Blob{}, s.Proposal.InputProofs...,
+			),
+			PaymentPkScript: s.Proposal.PaymentPkScript,
+		}
+
+		if s.Proposal.PriceKeys != nil {
+			priceKeys := *s.Proposal.PriceKeys
+			swapCopy.Proposal.PriceKeys = &priceKeys
+		}
+	}
+
+	if s.Funding != nil {
+		funding := s.Funding
+		swapCopy.Funding = &Funding{
+			AnchorPsbt:     copyPsbt(funding.AnchorPsbt),
+			ActivePackets:  copyPackets(funding.ActivePackets),
+			PassivePackets: copyPackets(funding.PassivePackets),
+			PricePackets:   copyPackets(funding.PricePackets),
+			PricePassivePackets: copyPackets(
+				funding.PricePassivePackets,
+			),
+			PriceInputProofs: append(
+				[]proof.Blob{}, funding.PriceInputProofs...,
+			),
+		}
+	}
+
+	return swapCopy
+}
+
+// expired returns true if the quote of the swap expired at the given time.
+func (s *Swap) expired(now time.Time) bool {
+	return !now.Before(s.Quote.Expiry)
+}
+
+// copyPackets returns a deep copy of the given virtual packets.
+func copyPackets(packets []*tappsbt.VPacket) []*tappsbt.VPacket {
+	packetsCopy := make([]*tappsbt.VPacket, len(packets))
+	for idx := range packets {
+		packetsCopy[idx] = packets[idx].Copy()
+	}
+
+	return packetsCopy
+}
+
+// copyPsbt returns a copy of the given PSBT. The inputs and outputs are copied
+// one level deep, which is enough since their fields are only ever replaced.
+func copyPsbt(pkt *psbt.Packet) *psbt.Packet {
+	pktCopy := *pkt
+	pktCopy.UnsignedTx = pkt.UnsignedTx.Copy()
+	pktCopy.Inputs = append([]psbt.PInput{}, pkt.Inputs...)
+	pktCopy.Outputs = append([]psbt.POutput{}, pkt.Outputs...)
+
+	return &pktCopy
+}
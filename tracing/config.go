@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultServiceName is the default name of the service the exported
+	// spans are attributed to.
+	DefaultServiceName = "tapd"
+
+	// DefaultSampleRatio is the default fraction of the traces that are
+	// sampled.
+	DefaultSampleRatio = 1.0
+
+	// DefaultShutdownTimeout is the default maximum time we wait for the
+	// remaining spans to be exported on shutdown.
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+// CliConfig is a struct that holds tapd cli configuration options for the
+// export of OpenTelemetry traces.
+//
+// nolint: lll
+type CliConfig struct {
+	Endpoint string `long:"endpoint" description:"The host:port of the OpenTelemetry collector the traces are exported to using OTLP over gRPC; if empty, no traces are exported"`
+
+	Insecure bool `long:"insecure" description:"If true, the connection to the OpenTelemetry collector doesn't use TLS"`
+
+	ServiceName string `long:"service-name" description:"The name of the service the exported spans are attributed to"`
+
+	SampleRatio float64 `long:"sample-ratio" description:"The fraction of the traces that are sampled, between 0 and 1"`
+}
+
+// DefaultCliConfig returns the default tracing configuration.
+func DefaultCliConfig() *CliConfig {
+	return &CliConfig{
+		ServiceName: DefaultServiceName,
+		SampleRatio: DefaultSampleRatio,
+	}
+}
+
+// Enabled returns true if traces are exported.
+func (c *CliConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *CliConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(c.Endpoint); err != nil {
+		return fmt.Errorf("invalid tracing endpoint: %w", err)
+	}
+
+	if c.ServiceName == "" {
+		return fmt.Errorf("tracing service name must be set")
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 " +
+			"and 1")
+	}
+
+	return nil
+}
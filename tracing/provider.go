@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Provider exports the spans created by the subsystems of the daemon to an
+// OpenTelemetry collector.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProviderFromConfig creates a new trace provider from the given cli
+// configuration. If no traces should be exported, nil is returned.
+func NewProviderFromConfig(cfg *CliConfig) (*Provider, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	// The exporter connects to the collector lazily, so this doesn't
+	// block if the collector isn't reachable yet.
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create trace exporter: %w",
+			err)
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	)
+	sampler := sdktrace.ParentBased(
+		sdktrace.TraceIDRatioBased(cfg.SampleRatio),
+	)
+
+	return &Provider{
+		provider: sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sampler),
+		),
+	}, nil
+}
+
+// Start installs the provider as the global trace provider, so the spans of
+// all subsystems are exported.
+func (p *Provider) Start() error {
+	log.Infof("Starting trace export")
+
+	otel.SetTracerProvider(p.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warnf("Trace export error: %v", err)
+	}))
+
+	return nil
+}
+
+// Stop exports the remaining spans and shuts down the exporter.
+func (p *Provider) Stop() error {
+	log.Infof("Stopping trace export")
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), DefaultShutdownTimeout,
+	)
+	defer cancel()
+
+	return p.provider.Shutdown(ctx)
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationPrefix is the prefix of the names of the tracers of
+	// the subsystems.
+	instrumentationPrefix = "github.com/lightninglabs/taproot-assets/"
+)
+
+const (
+	// AnchorTxIDKey is the attribute key of the ID of the anchor
+	// transaction of a transfer. Transfers are identified by their anchor
+	// transaction, so this attribute is set on the spans of both the
+	// sender and the receiver of a transfer.
+	AnchorTxIDKey = attribute.Key("tapd.anchor_txid")
+
+	// OutPointKey is the attribute key of an anchor outpoint.
+	OutPointKey = attribute.Key("tapd.outpoint")
+
+	// AssetIDKey is the attribute key of an asset ID.
+	AssetIDKey = attribute.Key("tapd.asset_id")
+
+	// ScriptKeyKey is the attribute key of a script key.
+	ScriptKeyKey = attribute.Key("tapd.script_key")
+
+	// TransferStateKey is the attribute key of the state of a transfer.
+	TransferStateKey = attribute.Key("tapd.transfer_state")
+
+	// UniverseIDKey is the attribute key of a universe identifier.
+	UniverseIDKey = attribute.Key("tapd.universe_id")
+
+	// UniverseServerKey is the attribute key of the address of a universe
+	// server.
+	UniverseServerKey = attribute.Key("tapd.universe_server")
+
+	// SyncTypeKey is the attribute key of the type of a universe sync.
+	SyncTypeKey = attribute.Key("tapd.sync_type")
+)
+
+// Tracer returns the tracer of the subsystem with the given name. The spans of
+// the tracer are only exported if a trace provider was started, before or
+// after the tracer was created.
+func Tracer(subsystem string) trace.Tracer {
+	return otel.Tracer(instrumentationPrefix + subsystem)
+}
+
+// AnchorTxID returns the anchor transaction ID attribute of the given
+// transaction ID.
+func AnchorTxID(txid chainhash.Hash) attribute.KeyValue {
+	return AnchorTxIDKey.String(txid.String())
+}
+
+// AnchorOutPoint returns the outpoint and the anchor transaction ID attributes
+// of the given anchor outpoint.
+func AnchorOutPoint(op wire.OutPoint) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		OutPointKey.String(op.String()),
+		AnchorTxID(op.Hash),
+	}
+}
+
+// EndSpan marks the span as failed if the given error is non-nil and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestCliConfigValidate tests the validation of the tracing configuration.
+func TestCliConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		modify      func(*CliConfig)
+		expectedErr string
+	}{{
+		name:   "disabled",
+		modify: func(*CliConfig) {},
+	}, {
+		name: "valid endpoint",
+		modify: func(c *CliConfig) {
+			c.Endpoint = "localhost:4317"
+		},
+	}, {
+		name: "invalid endpoint",
+		modify: func(c *CliConfig) {
+			c.Endpoint = "localhost"
+		},
+		expectedErr: "invalid tracing endpoint",
+	}, {
+		name: "missing service name",
+		modify: func(c *CliConfig) {
+			c.Endpoint = "localhost:4317"
+			c.ServiceName = ""
+		},
+		expectedErr: "service name must be set",
+	}, {
+		name: "invalid sample ratio",
+		modify: func(c *CliConfig) {
+			c.Endpoint = "localhost:4317"
+			c.SampleRatio = 1.5
+		},
+		expectedErr: "sample ratio must be between 0 and 1",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultCliConfig()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestNewProviderFromConfigDisabled tests that no provider is created if no
+// traces should be exported.
+func TestNewProviderFromConfigDisabled(t *testing.T) {
+	t.Parallel()
+
+	provider, err := NewProviderFromConfig(DefaultCliConfig())
+	require.NoError(t, err)
+	require.Nil(t, provider)
+}
+
+// TestEndSpan tests that ending a span with an error marks it as failed.
+func TestEndSpan(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	tracer := provider.Tracer("test")
+
+	op := wire.OutPoint{Hash: chainhash.Hash{1, 2, 3}, Index: 1}
+	_, span := tracer.Start(context.Background(), "success")
+	span.SetAttributes(AnchorOutPoint(op)...)
+	EndSpan(span, nil)
+
+	_, span = tracer.Start(context.Background(), "failure")
+	EndSpan(span, errors.New("courier unavailable"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.ElementsMatch(t, []attribute.KeyValue{
+		OutPointKey.String(op.String()),
+		AnchorTxIDKey.String(op.Hash.String()),
+	}, spans[0].Attributes())
+
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Equal(t, "courier unavailable", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -56,6 +57,12 @@ type FederationConfig struct {
 	// ServerChecker is a function that can be used to check if a server is
 	// operational and not the local daemon.
 	ServerChecker func(ServerAddr) error
+
+	// MirrorServer is the remote universe server that is replicated if the
+	// local universe runs in mirror mode. A mirror only syncs with and
+	// pushes to this server, and it replicates all of its issuance and
+	// transfer proofs regardless of the federation sync configs.
+	MirrorServer fn.Option[ServerAddr]
 }
 
 // FederationPushReq is used to push out new updates to all or some members of
@@ -82,6 +89,11 @@ type FederationPushReq struct {
 	// failure.
 	LogProofSync bool
 
+	// SkipServers is the set of federation servers the proof leaf should
+	// not be pushed to, for example because they are already known to
+	// have it.
+	SkipServers []ServerAddr
+
 	err chan error
 }
 
@@ -210,16 +222,20 @@ func (f *FederationEnvoy) syncServerState(ctx context.Context,
 		return err
 	}
 
-	if len(diff) == 0 {
-		return nil
+	var numLeaves int
+	for _, d := range diff {
+		numLeaves += len(d.NewLeafProofs)
 	}
 
 	// If we synced anything from the server, then we'll log that here.
-	log.Infof("Synced new Universe leaves from server=%v, diff_size=%v",
-		spew.Sdump(addr), len(diff))
+	if len(diff) > 0 {
+		log.Infof("Synced new Universe leaves from server=%v, "+
+			"diff_size=%v", spew.Sdump(addr), len(diff))
+	}
 
-	// Log a new sync event in the background now that we know we were able
-	// to contract the remote server.
+	// Log the sync volume, and a new sync event if we synced anything, in
+	// the background now that we know we were able to contract the remote
+	// server.
 	f.Wg.Add(1)
 	go func() {
 		defer f.Wg.Done()
@@ -227,7 +243,16 @@ func (f *FederationEnvoy) syncServerState(ctx context.Context,
 		ctx, cancel := f.WithCtxQuit()
 		defer cancel()
 
-		err := f.cfg.FederationDB.LogNewSyncs(ctx, addr)
+		err := f.cfg.FederationDB.LogSyncVolume(ctx, addr, numLeaves)
+		if err != nil {
+			log.Warnf("unable to log sync volume: %v", err)
+		}
+
+		if len(diff) == 0 {
+			return
+		}
+
+		err = f.cfg.FederationDB.LogNewSyncs(ctx, addr)
 		if err != nil {
 			log.Warnf("unable to log new sync: %v", err)
 		}
@@ -508,11 +533,22 @@ func (f *FederationEnvoy) handleTickEvent() error {
 		syncContextTimeout = f.cfg.SyncInterval
 	}
 
+	scopes, err := db.QueryFederationServerScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
+	}
+
 	for idx := range logEntries {
 		entry := logEntries[idx]
 
-		servers := []ServerAddr{
-			entry.ServerAddr,
+		// Skip any pending pushes to servers that the universe of the
+		// proof is no longer within the scope of.
+		servers := filterServerScopes(
+			[]ServerAddr{entry.ServerAddr}, scopes, entry.UniID,
+		)
+		if len(servers) == 0 {
+			continue
 		}
 
 		ctxPush, cancelPush := f.CtxBlockingCustomTimeout(
@@ -568,6 +604,32 @@ func (f *FederationEnvoy) handlePushRequest(pushReq *FederationPushReq) error {
 		return nil
 	}
 
+	// Remove any servers the caller asked us to skip from the set of target
+	// servers.
+	if len(pushReq.SkipServers) > 0 {
+		skipServers := make(map[string]struct{})
+		for idx := range pushReq.SkipServers {
+			skipServer := pushReq.SkipServers[idx]
+			skipServers[skipServer.HostStr()] = struct{}{}
+		}
+
+		fedServers = fn.Filter(fedServers, func(a ServerAddr) bool {
+			_, skip := skipServers[a.HostStr()]
+			return !skip
+		})
+	}
+
+	// Only push the proof to the servers that the universe of the proof
+	// is within the scope of.
+	scopes, err := f.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		err = fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
+		pushReq.err <- err
+		return err
+	}
+	fedServers = filterServerScopes(fedServers, scopes, pushReq.ID)
+
 	if pushReq.LogProofSync {
 		// We are attempting to sync using the logged proof sync
 		// procedure. We will therefore narrow down the set of target
@@ -637,13 +699,27 @@ func (f *FederationEnvoy) handleBatchPushRequest(
 		return nil
 	}
 
+	scopes, err := f.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		err = fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
+		pushReq.err <- err
+		return err
+	}
+
 	// With the response sent above, we'll push this out to all the Universe
-	// servers in the background.
+	// servers in the background. Each leaf is only pushed to the servers
+	// that its universe is within the scope of.
 	for idx := range pushReq.Batch {
 		item := pushReq.Batch[idx]
 
+		itemServers := filterServerScopes(fedServers, scopes, item.ID)
+		if len(itemServers) == 0 {
+			continue
+		}
+
 		f.pushProofToFederation(
-			ctx, item.ID, item.Key, item.Leaf, fedServers,
+			ctx, item.ID, item.Key, item.Leaf, itemServers,
 			item.LogProofSync,
 		)
 	}
@@ -680,6 +756,31 @@ func (f *FederationEnvoy) UpsertProofLeaf(_ context.Context, id Identifier,
 	return fn.RecvResp(pushReq.resp, pushReq.err, f.Quit)
 }
 
+// PushProofLeaf upserts a proof leaf within the target universe tree of the
+// local registrar and then pushes it out to all servers of the federation,
+// except for the given servers to skip. Unlike UpsertProofLeaf, the push
+// attempt is always logged, so it is retried until it succeeds and its status
+// can be queried from the federation proof sync log.
+func (f *FederationEnvoy) PushProofLeaf(_ context.Context, id Identifier,
+	key LeafKey, leaf *Leaf, skipServers ...ServerAddr) (*Proof, error) {
+
+	pushReq := &FederationPushReq{
+		ID:           id,
+		Key:          key,
+		Leaf:         leaf,
+		LogProofSync: true,
+		SkipServers:  skipServers,
+		resp:         make(chan *Proof, 1),
+		err:          make(chan error, 1),
+	}
+
+	if !fn.SendOrQuit(f.pushRequests, pushReq, f.Quit) {
+		return nil, fmt.Errorf("unable to push new proof event")
+	}
+
+	return fn.RecvResp(pushReq.resp, pushReq.err, f.Quit)
+}
+
 // UpsertProofLeafBatch inserts a batch of proof leaves within the target
 // universe tree. We assume the proofs within the batch have already been
 // checked that they don't yet exist in the local database.
@@ -718,10 +819,18 @@ func (f *FederationEnvoy) AddServer(addrs ...ServerAddr) error {
 	return f.SyncServers(addrs)
 }
 
-// QuerySyncConfigs returns the current sync configs for the federation.
+// QuerySyncConfigs returns the current sync configs for the federation. In
+// mirror mode, all universes are synced and exported, regardless of the sync
+// configs stored in the database.
 func (f *FederationEnvoy) QuerySyncConfigs(
 	ctx context.Context) (*SyncConfigs, error) {
 
+	if f.cfg.MirrorServer.IsSome() {
+		return &SyncConfigs{
+			GlobalSyncConfigs: syncAllGlobalConfigs(),
+		}, nil
+	}
+
 	// Obtain the general and universe specific federation sync configs.
 	queryFedSyncConfigs := f.cfg.FederationDB.QueryFederationSyncConfigs
 	globalConfigs, uniSyncConfigs, err := queryFedSyncConfigs(ctx)
@@ -747,8 +856,17 @@ func (f *FederationEnvoy) SyncServers(serverAddrs []ServerAddr) error {
 		return err
 	}
 
+	scopes, err := f.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
+	}
+
 	syncServer := func(ctx context.Context, serverAddr ServerAddr) error {
-		err := f.syncServerState(ctx, serverAddr, *syncConfigs)
+		serverConfigs := *syncConfigs
+		serverConfigs.ServerScope = scopes[serverAddr.HostStr()]
+
+		err := f.syncServerState(ctx, serverAddr, serverConfigs)
 		if err != nil {
 			log.Warnf("encountered an error whilst syncing with "+
 				"server=%v: %v", spew.Sdump(serverAddr), err)
@@ -764,13 +882,81 @@ func (f *FederationEnvoy) SyncServers(serverAddrs []ServerAddr) error {
 	return nil
 }
 
+// SyncAllServers fully syncs the local universe with all servers of the
+// federation, one server after the other. The overall progress in percent is
+// reported after each server. Unlike the periodic sync, the sync stops as soon
+// as the context is cancelled and an error is returned if any of the servers
+// couldn't be synced.
+func (f *FederationEnvoy) SyncAllServers(ctx context.Context,
+	report func(percent uint8)) error {
+
+	fedServers, err := f.cfg.FederationDB.UniverseServers(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to fetch set of universe servers: "+
+			"%w", err)
+	}
+	fedServers = f.mirrorFilter(fedServers)
+
+	syncConfigs, err := f.QuerySyncConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	scopes, err := f.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
+	}
+
+	log.Infof("Fully syncing with %v federation members", len(fedServers))
+
+	var failedServers []string
+	for idx, addr := range fedServers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		serverConfigs := *syncConfigs
+		serverConfigs.ServerScope = scopes[addr.HostStr()]
+
+		err := f.syncServerState(ctx, addr, serverConfigs)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+
+		case err != nil:
+			log.Warnf("encountered an error whilst syncing with "+
+				"server=%v: %v", spew.Sdump(addr), err)
+
+			failedServers = append(failedServers, addr.HostStr())
+		}
+
+		report(uint8((idx + 1) * 100 / len(fedServers)))
+	}
+
+	if len(failedServers) > 0 {
+		return fmt.Errorf("unable to sync with servers: %v",
+			strings.Join(failedServers, ", "))
+	}
+
+	return nil
+}
+
 // SetConfigSyncAllAssets sets the global (default) sync config to sync all
 // assets.
 func (f *FederationEnvoy) SetConfigSyncAllAssets() error {
 	ctx, cancel := f.WithCtxQuit()
 	defer cancel()
 
-	globalSyncConfigs := []*FedGlobalSyncConfig{
+	return f.cfg.FederationDB.UpsertFederationSyncConfig(
+		ctx, syncAllGlobalConfigs(), nil,
+	)
+}
+
+// syncAllGlobalConfigs returns the global sync configs that sync all issuance
+// and transfer proofs in both directions.
+func syncAllGlobalConfigs() []*FedGlobalSyncConfig {
+	return []*FedGlobalSyncConfig{
 		{
 			ProofType:       ProofTypeIssuance,
 			AllowSyncInsert: true,
@@ -782,10 +968,35 @@ func (f *FederationEnvoy) SetConfigSyncAllAssets() error {
 			AllowSyncExport: true,
 		},
 	}
+}
 
-	return f.cfg.FederationDB.UpsertFederationSyncConfig(
-		ctx, globalSyncConfigs, nil,
-	)
+// mirrorFilter filters the given set of federation servers down to the
+// mirrored server if the local universe runs in mirror mode. If the mirrored
+// server isn't known to the federation DB yet, it is returned as is.
+func (f *FederationEnvoy) mirrorFilter(fedServers []ServerAddr) []ServerAddr {
+	f.cfg.MirrorServer.WhenSome(func(mirror ServerAddr) {
+		mirrored := fn.Filter(fedServers, func(a ServerAddr) bool {
+			return a.HostStr() == mirror.HostStr()
+		})
+		if len(mirrored) == 0 {
+			mirrored = []ServerAddr{mirror}
+		}
+
+		fedServers = mirrored
+	})
+
+	return fedServers
+}
+
+// filterServerScopes returns the subset of the given federation servers that
+// the universe with the given ID is within the scope of.
+func filterServerScopes(fedServers []ServerAddr,
+	scopes map[string]FedServerScope, uniID Identifier) []ServerAddr {
+
+	return fn.Filter(fedServers, func(a ServerAddr) bool {
+		scope, ok := scopes[a.HostStr()]
+		return !ok || scope.Contains(uniID)
+	})
 }
 
 // tryFetchServers attempts to fetch the set of universe servers in the
@@ -801,7 +1012,7 @@ func (f *FederationEnvoy) tryFetchServers() ([]ServerAddr, error) {
 	}
 	cancel()
 
-	return fedServers, nil
+	return f.mirrorFilter(fedServers), nil
 }
 
 // SyncAssetInfo queries the universes in our federation for genesis and asset
@@ -827,9 +1038,13 @@ func (f *FederationEnvoy) SyncAssetInfo(ctx context.Context,
 		AllowSyncInsert: true,
 		AllowSyncExport: false,
 	}
-	fullConfig := SyncConfigs{
-		UniSyncConfigs: []*FedUniSyncConfig{&assetConfig},
+
+	scopes, err := f.cfg.FederationDB.QueryFederationServerScopes(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to query federation server scopes: "+
+			"%w", err)
 	}
+
 	// We'll sync with Universe servers in parallel and collect the diffs
 	// from any successful syncs. There can only be one diff per server, as
 	// we're only syncing one universe root.
@@ -839,6 +1054,10 @@ func (f *FederationEnvoy) SyncAssetInfo(ctx context.Context,
 	// remote universe. Asset group import and verification is handled as
 	// part of the universe sync.
 	syncFromUni := func(ctxs context.Context, addr ServerAddr) error {
+		fullConfig := SyncConfigs{
+			UniSyncConfigs: []*FedUniSyncConfig{&assetConfig},
+			ServerScope:    scopes[addr.HostStr()],
+		}
 		syncDiff, err := f.cfg.UniverseSyncer.SyncUniverse(
 			ctxs, addr, SyncIssuance, fullConfig,
 		)
@@ -919,11 +1138,21 @@ type SyncConfigs struct {
 
 	// UniSyncConfigs are the universe specific configs.
 	UniSyncConfigs []*FedUniSyncConfig
+
+	// ServerScope restricts the sync with a single federation server to
+	// the universes of a set of assets. It takes precedence over both the
+	// global and the universe specific configs.
+	ServerScope FedServerScope
 }
 
 // IsSyncInsertEnabled returns true if the given universe is configured to allow
 // insert (into this server) synchronization with the federation.
 func (s *SyncConfigs) IsSyncInsertEnabled(id Identifier) bool {
+	// Universes outside the scope of the server are never synced.
+	if !s.ServerScope.Contains(id) {
+		return false
+	}
+
 	// Check for universe specific config. This takes precedence over the
 	// global config.
 	for _, cfg := range s.UniSyncConfigs {
@@ -945,6 +1174,11 @@ func (s *SyncConfigs) IsSyncInsertEnabled(id Identifier) bool {
 // IsSyncExportEnabled returns true if the given universe is configured to allow
 // export (from this server) synchronization with the federation.
 func (s *SyncConfigs) IsSyncExportEnabled(id Identifier) bool {
+	// Universes outside the scope of the server are never synced.
+	if !s.ServerScope.Contains(id) {
+		return false
+	}
+
 	// Check for universe specific config. This takes precedence over the
 	// global config.
 	for _, cfg := range s.UniSyncConfigs {
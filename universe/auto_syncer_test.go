@@ -0,0 +1,133 @@
+package universe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFederationEnvoyMirror tests that a universe mirror only syncs with the
+// mirrored server and syncs and exports all universes.
+func TestFederationEnvoyMirror(t *testing.T) {
+	t.Parallel()
+
+	fedServers := []ServerAddr{
+		NewServerAddr(1, "universe.example.com:10029"),
+		NewServerAddr(2, "mirrored.example.com:10029"),
+	}
+
+	// Without a mirror, all federation servers are used.
+	envoy := NewFederationEnvoy(FederationConfig{})
+	require.Equal(t, fedServers, envoy.mirrorFilter(fedServers))
+
+	// A mirror only uses the mirrored server, as stored in the federation
+	// DB.
+	mirror := NewServerAddrFromStr("mirrored.example.com:10029")
+	envoy = NewFederationEnvoy(FederationConfig{
+		MirrorServer: fn.Some(mirror),
+	})
+	require.Equal(t, fedServers[1:], envoy.mirrorFilter(fedServers))
+
+	// If the mirrored server isn't stored yet, it is used as configured.
+	require.Equal(
+		t, []ServerAddr{mirror}, envoy.mirrorFilter(fedServers[:1]),
+	)
+
+	// The sync configs of a mirror allow syncing and exporting all
+	// universes without querying the federation DB.
+	syncConfigs, err := envoy.QuerySyncConfigs(context.Background())
+	require.NoError(t, err)
+
+	for _, proofType := range []ProofType{
+		ProofTypeIssuance, ProofTypeTransfer,
+	} {
+		id := Identifier{ProofType: proofType}
+		require.True(t, syncConfigs.IsSyncInsertEnabled(id))
+		require.True(t, syncConfigs.IsSyncExportEnabled(id))
+	}
+}
+
+// TestFederationServerScope tests that the scope of a federation server
+// restricts the universes that are synced with it.
+func TestFederationServerScope(t *testing.T) {
+	t.Parallel()
+
+	var (
+		assetID  = asset.RandID(t)
+		groupKey = test.RandPubKey(t)
+
+		assetUni = Identifier{
+			AssetID:   assetID,
+			ProofType: ProofTypeIssuance,
+		}
+		groupUni = Identifier{
+			AssetID:   asset.RandID(t),
+			GroupKey:  groupKey,
+			ProofType: ProofTypeTransfer,
+		}
+		otherUni = Identifier{
+			AssetID:   asset.RandID(t),
+			ProofType: ProofTypeIssuance,
+		}
+	)
+
+	// An empty scope contains all universes.
+	var scope FedServerScope
+	require.True(t, scope.IsEmpty())
+	for _, id := range []Identifier{assetUni, groupUni, otherUni} {
+		require.True(t, scope.Contains(id))
+	}
+
+	// Grouped assets are matched by their group key only, regardless of
+	// its parity.
+	scope = FedServerScope{
+		AssetIDs:  []asset.ID{assetID, groupUni.AssetID},
+		GroupKeys: []*btcec.PublicKey{groupKey},
+	}
+	require.True(t, scope.Contains(assetUni))
+	require.True(t, scope.Contains(groupUni))
+	require.False(t, scope.Contains(otherUni))
+
+	xOnlyKey, err := schnorr.ParsePubKey(schnorr.SerializePubKey(groupKey))
+	require.NoError(t, err)
+	require.True(t, scope.Contains(Identifier{GroupKey: xOnlyKey}))
+	require.False(t, scope.Contains(Identifier{
+		AssetID:  groupUni.AssetID,
+		GroupKey: test.RandPubKey(t),
+	}))
+
+	// The scope takes precedence over the sync configs.
+	syncConfigs := SyncConfigs{
+		GlobalSyncConfigs: syncAllGlobalConfigs(),
+		ServerScope:       scope,
+	}
+	for _, id := range []Identifier{assetUni, groupUni} {
+		require.True(t, syncConfigs.IsSyncInsertEnabled(id))
+		require.True(t, syncConfigs.IsSyncExportEnabled(id))
+	}
+	require.False(t, syncConfigs.IsSyncInsertEnabled(otherUni))
+	require.False(t, syncConfigs.IsSyncExportEnabled(otherUni))
+
+	// Proofs are only pushed to the servers that don't have a scope or of
+	// which the scope contains the universe of the proof.
+	fedServers := []ServerAddr{
+		NewServerAddr(1, "universe.example.com:10029"),
+		NewServerAddr(2, "scoped.example.com:10029"),
+	}
+	scopes := map[string]FedServerScope{
+		fedServers[1].HostStr(): scope,
+	}
+	require.Equal(
+		t, fedServers, filterServerScopes(fedServers, scopes, assetUni),
+	)
+	require.Equal(
+		t, fedServers[:1],
+		filterServerScopes(fedServers, scopes, otherUni),
+	)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/taproot-assets/alert"
 	"github.com/lightninglabs/taproot-assets/asset"
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/mssmt"
@@ -48,6 +49,10 @@ type ArchiveConfig struct {
 	// lookup interface that is required to validate proofs.
 	ChainLookupGenerator proof.ChainLookupGenerator
 
+	// AlertSender is used to raise an alert if two conflicting proofs for
+	// the same universe leaf are encountered. This is optional.
+	AlertSender alert.Sender
+
 	// TODO(roasbeef): query re genesis asset known?
 
 	// TODO(roasbeef): load all at once, or lazy load dynamic?
@@ -70,6 +75,10 @@ type Archive struct {
 	sync.RWMutex
 }
 
+// A compile time check to ensure that the archive can be used as the local
+// side of an incremental universe sync.
+var _ SubtreeDiffEngine = (*Archive)(nil)
+
 // NewArchive creates a new universe archive based on the passed config.
 func NewArchive(cfg ArchiveConfig) *Archive {
 	a := &Archive{
@@ -218,6 +227,23 @@ func (a *Archive) MultiverseRoot(ctx context.Context, proofType ProofType,
 	return fn.Some(multiverseRoot), nil
 }
 
+// alertEquivocation raises an alert about two conflicting proofs for the
+// given universe leaf.
+func (a *Archive) alertEquivocation(id Identifier, key LeafKey) {
+	leafKey := key.UniverseKey()
+	log.Warnf("Conflicting proof for universe leaf detected: id=%v, "+
+		"leaf_key=%x", id.StringForLog(), leafKey[:])
+
+	alert.SendIfSet(a.cfg.AlertSender, alert.Alert{
+		Type:     alert.TypeUniverseEquivocation,
+		Severity: alert.SeverityCritical,
+		Message: fmt.Sprintf("Conflicting proof for universe leaf "+
+			"received (id=%v, leaf_key=%x)", id.StringForLog(),
+			leafKey[:]),
+		DedupKey: fmt.Sprintf("%v:%x", id.String(), leafKey[:]),
+	})
+}
+
 // UpsertProofLeaf attempts to upsert a proof for an asset issuance or transfer
 // event. This method will return an error if the passed proof is invalid. If
 // the leaf is already known, then no action is taken and the existing
@@ -272,6 +298,14 @@ func (a *Archive) UpsertProofLeaf(ctx context.Context, id Identifier,
 		if existingProof.BlockHeader.BlockHash() ==
 			newProof.BlockHeader.BlockHash() {
 
+			// A proof anchored in the same block that commits to
+			// a different asset for the same leaf key means that
+			// conflicting versions of the leaf are circulating,
+			// which we want to bring to the operator's attention.
+			if !existingProof.Asset.DeepEqual(&newProof.Asset) {
+				a.alertEquivocation(id, key)
+			}
+
 			return issuanceProof, nil
 		}
 
@@ -302,6 +336,7 @@ func (a *Archive) UpsertProofLeaf(ctx context.Context, id Identifier,
 
 	assetSnapshot, err := a.verifyIssuanceProof(
 		ctx, id, key, &newProof, prevAssetSnapshot,
+		a.cfg.HeaderVerifier, a.cfg.MerkleVerifier,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to verify proof: %w", err)
@@ -336,7 +371,9 @@ func (a *Archive) UpsertProofLeaf(ctx context.Context, id Identifier,
 // proof, returning the asset snapshot if so.
 func (a *Archive) verifyIssuanceProof(ctx context.Context, id Identifier,
 	key LeafKey, newProof *proof.Proof,
-	prevAssetSnapshot *proof.AssetSnapshot) (*proof.AssetSnapshot, error) {
+	prevAssetSnapshot *proof.AssetSnapshot,
+	headerVerifier proof.HeaderVerifier,
+	merkleVerifier proof.MerkleVerifier) (*proof.AssetSnapshot, error) {
 
 	lookup, err := a.cfg.ChainLookupGenerator.GenProofChainLookup(newProof)
 	if err != nil {
@@ -345,8 +382,8 @@ func (a *Archive) verifyIssuanceProof(ctx context.Context, id Identifier,
 	}
 
 	assetSnapshot, err := newProof.Verify(
-		ctx, prevAssetSnapshot, a.cfg.HeaderVerifier,
-		a.cfg.MerkleVerifier, a.cfg.GroupVerifier, lookup,
+		ctx, prevAssetSnapshot, headerVerifier, merkleVerifier,
+		a.cfg.GroupVerifier, lookup,
 	)
 	if err != nil {
 		var skBytes []byte
@@ -461,6 +498,13 @@ func (a *Archive) UpsertProofLeafBatch(ctx context.Context,
 
 	batchDeps := extractBatchDeps(items)
 
+	// The proofs of a batch are often anchored in the same blocks, so we
+	// share the chain lookups of the header and merkle proof verification
+	// between them.
+	cache := proof.NewVerifierCache(
+		a.cfg.HeaderVerifier, a.cfg.MerkleVerifier,
+	)
+
 	verifyBatch := func(batchItems []*Item) error {
 		err := fn.ParSlice(
 			ctx, batchItems, func(ctx context.Context,
@@ -483,7 +527,8 @@ func (a *Archive) UpsertProofLeafBatch(ctx context.Context,
 
 				assetSnapshot, err := a.verifyIssuanceProof(
 					ctx, i.ID, i.Key, assetProof,
-					prevAssets,
+					prevAssets, cache.VerifyHeader,
+					cache.VerifyMerkleProof,
 				)
 				if err != nil {
 					return err
@@ -669,6 +714,30 @@ func (a *Archive) UniverseLeafKeys(ctx context.Context,
 	return a.cfg.Multiverse.UniverseLeafKeys(ctx, q)
 }
 
+// SubtreeNodes returns, for each of the given paths, the nodes of the given
+// universe tree that are found the given number of levels below the node at
+// that path.
+func (a *Archive) SubtreeNodes(ctx context.Context, id Identifier,
+	paths []mssmt.NodePath, levels int) ([]SubtreeNode, error) {
+
+	log.Debugf("Retrieving subtree nodes for Universe: id=%v, "+
+		"num_paths=%v, levels=%v", id.StringForLog(), len(paths),
+		levels)
+
+	if levels < 0 || levels > mssmt.MaxSubtreeLevels {
+		return nil, fmt.Errorf("invalid number of levels %d, must be "+
+			"between 0 and %d", levels, mssmt.MaxSubtreeLevels)
+	}
+
+	numNodes := len(paths) * (1 << levels)
+	if numNodes > MaxSubtreeNodes {
+		return nil, fmt.Errorf("too many subtree nodes requested: %d, "+
+			"max is %d", numNodes, MaxSubtreeNodes)
+	}
+
+	return a.cfg.Multiverse.SubtreeNodes(ctx, id, paths, levels)
+}
+
 // MintingLeaves returns the set of minting leaves known for the specified base
 // universe.
 func (a *Archive) MintingLeaves(ctx context.Context,
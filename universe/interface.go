@@ -1,6 +1,7 @@
 package universe
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -30,6 +31,10 @@ var (
 	// to the DB already exists.
 	ErrDuplicateUniverse = fmt.Errorf("universe server already added")
 
+	// ErrUnknownUniverseServer is returned when the Universe server being
+	// referenced isn't part of the federation.
+	ErrUnknownUniverseServer = fmt.Errorf("unknown universe server")
+
 	// ErrNoUniverseProofFound is returned when a user attempts to look up
 	// a key in the universe that actually points to the empty leaf.
 	ErrNoUniverseProofFound = fmt.Errorf("no universe proof found")
@@ -39,6 +44,10 @@ const (
 	// MaxPageSize is the maximum page size that can be used when querying
 	// for asset roots and leaves.
 	MaxPageSize = 16384
+
+	// MaxSubtreeNodes is the maximum number of subtree nodes that can be
+	// queried for at once.
+	MaxSubtreeNodes = 4096
 )
 
 // IdentifierKey is the compact representation of a universe identifier that can
@@ -454,6 +463,12 @@ type MultiverseArchive interface {
 	// proof type.
 	MultiverseRootNode(ctx context.Context,
 		proofType ProofType) (fn.Option[MultiverseRoot], error)
+
+	// SubtreeNodes returns, for each of the given paths, the nodes of the
+	// given universe tree that are found the given number of levels below
+	// the node at that path.
+	SubtreeNodes(ctx context.Context, id Identifier,
+		paths []mssmt.NodePath, levels int) ([]SubtreeNode, error)
 }
 
 // Registrar is an interface that allows a caller to upsert a proof leaf in a
@@ -674,6 +689,40 @@ type DiffEngine interface {
 	Close() error
 }
 
+// SubtreeNode is a node of a universe tree together with its position in the
+// tree.
+type SubtreeNode struct {
+	// Path is the path of the node within the universe tree.
+	Path mssmt.NodePath
+
+	// Node is the node found at the path.
+	Node mssmt.Node
+
+	// LeafKey is the key of the only leaf within the subtree rooted at
+	// this node. It is None if the subtree is empty or holds more than one
+	// leaf.
+	LeafKey fn.Option[LeafKey]
+}
+
+// IsEmpty returns true if the subtree rooted at the node is empty.
+func (s *SubtreeNode) IsEmpty() bool {
+	return s.Node.NodeHash() == mssmt.EmptyTree[s.Path.Depth].NodeHash()
+}
+
+// SubtreeDiffEngine is a DiffEngine that can also return the inner nodes of a
+// universe tree. Comparing those nodes with the nodes of the local tree allows
+// to find the leaves two universes differ in, without fetching all the leaf
+// keys of a universe.
+type SubtreeDiffEngine interface {
+	DiffEngine
+
+	// SubtreeNodes returns, for each of the given paths, the nodes of the
+	// given universe tree that are found the given number of levels below
+	// the node at that path.
+	SubtreeNodes(ctx context.Context, id Identifier,
+		paths []mssmt.NodePath, levels int) ([]SubtreeNode, error)
+}
+
 // Commitment is an on chain universe commitment. This includes the merkle
 // proof for a transaction which anchors the target universe root.
 type Commitment struct {
@@ -835,6 +884,72 @@ type FedUniSyncConfig struct {
 	AllowSyncExport bool
 }
 
+// FedServerScope restricts the federation sync with a single Universe server to
+// the universes of a set of assets. Both new leaves pulled from the server and
+// proofs pushed to it are restricted. The sync with a server isn't restricted
+// if its scope is empty.
+type FedServerScope struct {
+	// AssetIDs are the IDs of the assets that don't belong to an asset
+	// group and of which the universes are synced with the server.
+	AssetIDs []asset.ID
+
+	// GroupKeys are the keys of the asset groups of which the universes
+	// are synced with the server.
+	GroupKeys []*btcec.PublicKey
+}
+
+// IsEmpty returns true if the scope doesn't restrict the sync.
+func (s *FedServerScope) IsEmpty() bool {
+	return len(s.AssetIDs) == 0 && len(s.GroupKeys) == 0
+}
+
+// Contains returns true if the universe with the given ID is within the scope.
+// Assets that belong to an asset group are only matched by their group key.
+func (s *FedServerScope) Contains(id Identifier) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	if id.GroupKey != nil {
+		groupKey := schnorr.SerializePubKey(id.GroupKey)
+		return fn.Any(s.GroupKeys, func(k *btcec.PublicKey) bool {
+			return bytes.Equal(schnorr.SerializePubKey(k), groupKey)
+		})
+	}
+
+	return fn.Any(s.AssetIDs, func(assetID asset.ID) bool {
+		return assetID == id.AssetID
+	})
+}
+
+// FederationSyncStatsDB is used to track the sync volume with the Universe
+// servers of a federation.
+type FederationSyncStatsDB interface {
+	// LogSyncVolume logs a successful sync with the given server, in which
+	// the given number of new leaves were synced from the server.
+	LogSyncVolume(ctx context.Context, addr ServerAddr,
+		numLeaves int) error
+
+	// QueryServerSyncStats returns the sync volume with the servers of the
+	// federation per day.
+	QueryServerSyncStats(ctx context.Context,
+		q ServerSyncStatsQuery) ([]*ServerSyncStats, error)
+}
+
+// FederationServerScopeDB is used to manage the asset scopes of the Universe
+// servers of a federation.
+type FederationServerScopeDB interface {
+	// QueryFederationServerScopes returns the scopes of all federation
+	// servers that have one, keyed by the host of the server.
+	QueryFederationServerScopes(
+		ctx context.Context) (map[string]FedServerScope, error)
+
+	// SetFederationServerScope replaces the scope of the given federation
+	// server. An empty scope removes any restriction.
+	SetFederationServerScope(ctx context.Context, addr ServerAddr,
+		scope FedServerScope) error
+}
+
 // FederationSyncConfigDB is used to manage the set of Universe servers as part
 // of a federation.
 type FederationSyncConfigDB interface {
@@ -958,6 +1073,9 @@ type FederationDB interface {
 	FederationLog
 	FederationProofSyncLog
 	FederationSyncConfigDB
+	FederationServerScopeDB
+	FederationSyncStatsDB
+	FederationOperatorKeyDB
 }
 
 // SyncStatsSort is an enum used to specify the sort order of the returned sync
@@ -1125,6 +1243,58 @@ type GroupedStats struct {
 	Date string
 }
 
+// DailyStats holds the pre-aggregated stats of the local Universe for a single
+// day.
+type DailyStats struct {
+	// Date is the string formatted date (YYYY-MM-DD) that the stats are
+	// for.
+	Date string
+
+	// NumNewAssets is the number of new issuance proofs, each of which
+	// issues a new asset.
+	NumNewAssets uint64
+
+	// NumNewProofs is the number of new issuance and transfer proofs.
+	NumNewProofs uint64
+}
+
+// TransferStats holds the number of transfer proofs of a single transfer
+// universe.
+type TransferStats struct {
+	// ID is the identifier of the transfer universe.
+	ID Identifier
+
+	// NumTransfers is the number of transfer proofs in the universe.
+	NumTransfers uint64
+}
+
+// ServerSyncStatsQuery packages a set of query parameters to retrieve the
+// sync volume with the servers of the federation.
+type ServerSyncStatsQuery struct {
+	GroupedStatsQuery
+
+	// ServerHost is the host of the server to query the stats of. If not
+	// set, the stats of all servers are returned.
+	ServerHost fn.Option[string]
+}
+
+// ServerSyncStats holds the sync volume with a single server of the
+// federation for a single day.
+type ServerSyncStats struct {
+	// ServerHost is the host of the server.
+	ServerHost string
+
+	// Date is the string formatted date (YYYY-MM-DD) that the stats are
+	// for.
+	Date string
+
+	// NumSyncs is the number of successful syncs with the server.
+	NumSyncs uint64
+
+	// NumSyncedLeaves is the number of new leaves synced from the server.
+	NumSyncedLeaves uint64
+}
+
 // Telemetry it a type used by the Universe syncer and base universe to export
 // telemetry information about the sync process. This logs events of new
 // proofs, and also sync events for entire asset trees.
@@ -1168,4 +1338,14 @@ type Telemetry interface {
 	// day.
 	QueryAssetStatsPerDay(ctx context.Context,
 		q GroupedStatsQuery) ([]*GroupedStats, error)
+
+	// QueryDailyStats returns the pre-aggregated number of new assets and
+	// proofs of the local Universe per day.
+	QueryDailyStats(ctx context.Context,
+		q GroupedStatsQuery) ([]*DailyStats, error)
+
+	// QueryTopTransferUniverses returns up to the given number of transfer
+	// universes with the most transfer proofs, most transfers first.
+	QueryTopTransferUniverses(ctx context.Context,
+		limit int) ([]*TransferStats, error)
 }
@@ -0,0 +1,154 @@
+package universe
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// OperatorKeyFamily is the key family of the long-lived operator key
+	// that a universe server signs its roots with. The key family is
+	// separate from the Taproot Assets key family, so the operator key is
+	// never used for anything else.
+	OperatorKeyFamily keychain.KeyFamily = 213
+
+	// rootSigMsgPrefix is the prefix of the message that is signed for a
+	// universe root. It makes sure a root signature can't be mistaken for
+	// a signature over any other message of the operator key.
+	rootSigMsgPrefix = "taproot-assets universe root"
+)
+
+var (
+	// ErrOperatorKeyMismatch is returned when a universe server reports
+	// a different operator key than the one pinned for it.
+	ErrOperatorKeyMismatch = errors.New("universe operator key mismatch")
+
+	// ErrInvalidRootSig is returned when a universe root isn't signed by
+	// the operator key of the universe server that returned it.
+	ErrInvalidRootSig = errors.New("invalid universe root signature")
+)
+
+// RootSigMessage returns the message that is signed by the operator of a
+// universe server for the given universe root. It commits to the universe ID,
+// including its proof type, and to the root hash and sum of the universe.
+func RootSigMessage(root Root) ([]byte, error) {
+	if root.Node == nil {
+		return nil, fmt.Errorf("root node must be set")
+	}
+
+	var (
+		buf      bytes.Buffer
+		rootSum  [8]byte
+		id       = root.ID.Bytes()
+		rootHash = root.NodeHash()
+	)
+	binary.BigEndian.PutUint64(rootSum[:], root.NodeSum())
+
+	buf.WriteString(rootSigMsgPrefix)
+	buf.Write(id[:])
+	buf.WriteByte(byte(root.ID.ProofType))
+	buf.Write(rootHash[:])
+	buf.Write(rootSum[:])
+
+	return buf.Bytes(), nil
+}
+
+// VerifyRootSig checks that the given universe root was signed by the given
+// operator key. The signature is over the single SHA-256 hash of the root
+// message, which matches what lnd's SignMessage RPC signs for Schnorr
+// signatures.
+func VerifyRootSig(root Root, sig *schnorr.Signature,
+	operatorKey *btcec.PublicKey) error {
+
+	if sig == nil || operatorKey == nil {
+		return ErrInvalidRootSig
+	}
+
+	msg, err := RootSigMessage(root)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(msg)
+	if !sig.Verify(digest[:], operatorKey) {
+		return fmt.Errorf("%w: root of universe %v",
+			ErrInvalidRootSig, root.ID.StringForLog())
+	}
+
+	return nil
+}
+
+// RootSigner signs the universe roots that the local universe server
+// publishes with its operator key.
+type RootSigner interface {
+	// OperatorKey returns the long-lived operator key of the local
+	// universe server.
+	OperatorKey() *btcec.PublicKey
+
+	// SignRoot signs the given universe root with the operator key.
+	SignRoot(ctx context.Context, root Root) (*schnorr.Signature, error)
+}
+
+// FederationOperatorKeyDB is used to pin the operator keys of the universe
+// servers that the local universe syncs with.
+type FederationOperatorKeyDB interface {
+	// FetchOperatorKey returns the operator key that is pinned for the
+	// given universe server, if any.
+	FetchOperatorKey(ctx context.Context,
+		addr ServerAddr) (fn.Option[*btcec.PublicKey], error)
+
+	// PinOperatorKey pins the operator key of the given universe server.
+	// If a key is already pinned for the server, it is kept as is.
+	PinOperatorKey(ctx context.Context, addr ServerAddr,
+		key *btcec.PublicKey) error
+}
+
+// CheckOperatorKey checks the operator key that a universe server reports
+// against the key that is pinned for it. The first key a server reports is
+// pinned (trust on first use), after which the server must keep reporting the
+// same key, so a compromised server that signs with a different key is
+// detected. A server that doesn't report any key is only accepted as long as
+// no key is pinned for it.
+func CheckOperatorKey(ctx context.Context, db FederationOperatorKeyDB,
+	addr ServerAddr, key *btcec.PublicKey) error {
+
+	pinnedKey, err := db.FetchOperatorKey(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("unable to fetch pinned operator key: %w",
+			err)
+	}
+
+	if pinnedKey.IsNone() {
+		if key == nil {
+			return nil
+		}
+
+		log.Infof("Pinning operator key %x of universe server %v",
+			key.SerializeCompressed(), addr.HostStr())
+
+		return db.PinOperatorKey(ctx, addr, key)
+	}
+
+	pinned := pinnedKey.UnwrapOr(nil)
+	switch {
+	case key == nil:
+		return fmt.Errorf("%w: server %v no longer reports its "+
+			"operator key", ErrOperatorKeyMismatch, addr.HostStr())
+
+	case !pinned.IsEqual(key):
+		return fmt.Errorf("%w: server %v reported %x, pinned %x",
+			ErrOperatorKeyMismatch, addr.HostStr(),
+			key.SerializeCompressed(), pinned.SerializeCompressed())
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package universe
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOperatorKeyDB is an in-memory implementation of the
+// FederationOperatorKeyDB interface.
+type mockOperatorKeyDB struct {
+	keys map[string]*btcec.PublicKey
+}
+
+func (m *mockOperatorKeyDB) FetchOperatorKey(_ context.Context,
+	addr ServerAddr) (fn.Option[*btcec.PublicKey], error) {
+
+	key, ok := m.keys[addr.HostStr()]
+	if !ok {
+		return fn.None[*btcec.PublicKey](), nil
+	}
+
+	return fn.Some(key), nil
+}
+
+func (m *mockOperatorKeyDB) PinOperatorKey(_ context.Context, addr ServerAddr,
+	key *btcec.PublicKey) error {
+
+	if _, ok := m.keys[addr.HostStr()]; !ok {
+		m.keys[addr.HostStr()] = key
+	}
+
+	return nil
+}
+
+// signRoot signs the given universe root the same way lnd's SignMessage RPC
+// does.
+func signRoot(t *testing.T, root Root,
+	privKey *btcec.PrivateKey) *schnorr.Signature {
+
+	msg, err := RootSigMessage(root)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(msg)
+	sig, err := schnorr.Sign(privKey, digest[:])
+	require.NoError(t, err)
+
+	return sig
+}
+
+// TestRootSig tests that a universe root signature commits to the universe ID
+// and the root node.
+func TestRootSig(t *testing.T) {
+	t.Parallel()
+
+	var (
+		operatorKey = test.RandPrivKey()
+		rootHash    = mssmt.NodeHash(test.RandHash())
+		otherHash   = mssmt.NodeHash(test.RandHash())
+	)
+	root := Root{
+		ID: Identifier{
+			AssetID:   asset.RandID(t),
+			ProofType: ProofTypeIssuance,
+		},
+		Node: mssmt.NewComputedBranch(rootHash, 1000),
+	}
+	sig := signRoot(t, root, operatorKey)
+
+	require.NoError(t, VerifyRootSig(root, sig, operatorKey.PubKey()))
+
+	// The signature must be made by the operator key.
+	err := VerifyRootSig(root, sig, test.RandPubKey(t))
+	require.ErrorIs(t, err, ErrInvalidRootSig)
+
+	err = VerifyRootSig(root, nil, operatorKey.PubKey())
+	require.ErrorIs(t, err, ErrInvalidRootSig)
+
+	// The signature can't be used for another proof type, root hash or
+	// root sum.
+	otherType := root
+	otherType.ID.ProofType = ProofTypeTransfer
+	err = VerifyRootSig(otherType, sig, operatorKey.PubKey())
+	require.ErrorIs(t, err, ErrInvalidRootSig)
+
+	otherRoot := root
+	otherRoot.Node = mssmt.NewComputedBranch(otherHash, 1000)
+	err = VerifyRootSig(otherRoot, sig, operatorKey.PubKey())
+	require.ErrorIs(t, err, ErrInvalidRootSig)
+
+	otherSum := root
+	otherSum.Node = mssmt.NewComputedBranch(rootHash, 1001)
+	err = VerifyRootSig(otherSum, sig, operatorKey.PubKey())
+	require.ErrorIs(t, err, ErrInvalidRootSig)
+
+	// An empty root can't be signed.
+	_, err = RootSigMessage(Root{ID: root.ID})
+	require.Error(t, err)
+}
+
+// TestCheckOperatorKey tests that the operator key of a universe server is
+// pinned on first use and checked on any later use.
+func TestCheckOperatorKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := &mockOperatorKeyDB{
+		keys: make(map[string]*btcec.PublicKey),
+	}
+
+	var (
+		addr        = NewServerAddrFromStr("universe.example.com:10029")
+		operatorKey = test.RandPubKey(t)
+	)
+
+	// A server without an operator key is accepted as long as no key is
+	// pinned for it.
+	require.NoError(t, CheckOperatorKey(ctx, db, addr, nil))
+	require.Empty(t, db.keys)
+
+	// The first key the server reports is pinned.
+	require.NoError(t, CheckOperatorKey(ctx, db, addr, operatorKey))
+	require.True(t, db.keys[addr.HostStr()].IsEqual(operatorKey))
+
+	require.NoError(t, CheckOperatorKey(ctx, db, addr, operatorKey))
+
+	// Once pinned, the server must keep reporting the same key.
+	err := CheckOperatorKey(ctx, db, addr, test.RandPubKey(t))
+	require.ErrorIs(t, err, ErrOperatorKeyMismatch)
+
+	err = CheckOperatorKey(ctx, db, addr, nil)
+	require.ErrorIs(t, err, ErrOperatorKeyMismatch)
+
+	// Keys are pinned per server.
+	otherAddr := NewServerAddrFromStr("other.example.com:10029")
+	otherKey := test.RandPubKey(t)
+	require.NoError(t, CheckOperatorKey(ctx, db, otherAddr, otherKey))
+	require.True(t, db.keys[addr.HostStr()].IsEqual(operatorKey))
+}
@@ -12,11 +12,17 @@ import (
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/mssmt"
 	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightninglabs/taproot-assets/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
 	defaultPageSize = int32(MaxPageSize)
+
+	// subtreeSyncLevels is the number of tree levels that are queried at
+	// once when finding the diverging leaves of two universe trees.
+	subtreeSyncLevels = 4
 )
 
 var (
@@ -110,9 +116,14 @@ func (s *SimpleSyncer) executeSync(ctx context.Context, diffEngine DiffEngine,
 	)
 	switch {
 	// If we have been given a specific set of Universes to sync, then we'll
-	// only fetch roots for those universes. We wont filter out any
-	// Universes here as we assume that the caller has already done so.
+	// only fetch roots for those universes. Apart from the scope of the
+	// server, we wont filter out any Universes here as we assume that the
+	// caller has already done so.
 	case len(idsToSync) != 0:
+		idsToSync = fn.Filter(
+			idsToSync, syncConfigs.ServerScope.Contains,
+		)
+
 		targetRoots, err = fetchRootsForIDs(ctx, idsToSync, diffEngine)
 		if err != nil {
 			return nil, err
@@ -214,11 +225,21 @@ func fetchRootsForIDs(ctx context.Context, idsToSync []Identifier,
 // syncRoot attempts to sync the local Universe with the remote diff engine for
 // a specific base root.
 func (s *SimpleSyncer) syncRoot(ctx context.Context, remoteRoot Root,
-	diffEngine DiffEngine, result chan<- AssetSyncDiff) error {
+	diffEngine DiffEngine, result chan<- AssetSyncDiff) (err error) {
 
-	// First, we'll compare the remote root against the local root.
 	uniID := remoteRoot.ID
+	ctx, span := tracer.Start(
+		ctx, "Syncer.syncRoot", trace.WithAttributes(
+			tracing.UniverseIDKey.String(uniID.String()),
+		),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	// First, we'll compare the remote root against the local root.
 	localRoot, err := s.cfg.LocalDiffEngine.RootNode(ctx, uniID)
+	haveLocalRoot := err == nil
 	switch {
 	// If we don't have this root, then we don't have anything to compare
 	// to, so we'll proceed as normal.
@@ -241,27 +262,14 @@ func (s *SimpleSyncer) syncRoot(ctx context.Context, remoteRoot Root,
 		uniID.String())
 
 	// Otherwise, we'll need to perform a diff operation to find the set of
-	// keys we need to fetch. We'll start by fetching the set of keys from
-	// both the local and remote Universe.
-	var (
-		remoteUniKeys []LeafKey
-		localUniKeys  []LeafKey
+	// keys we need to fetch.
+	keysToFetch, err := s.diffLeafKeys(
+		ctx, uniID, remoteRoot, haveLocalRoot, diffEngine,
 	)
-
-	remoteUniKeys, err = s.fetchAllLeafKeys(ctx, diffEngine, uniID)
-	if err != nil {
-		return err
-	}
-
-	localUniKeys, err = s.fetchAllLeafKeys(ctx, s.cfg.LocalDiffEngine, uniID)
 	if err != nil {
 		return err
 	}
 
-	// With the set of keys fetched, we can now find the set of keys that
-	// need to be synced.
-	keysToFetch := fn.SetDiff(remoteUniKeys, localUniKeys)
-
 	log.Infof("UniverseRoot(%v): diff_size=%v", uniID.String(),
 		len(keysToFetch))
 	log.Tracef("UniverseRoot(%v): diff_size=%v, diff=%v", uniID.String(),
@@ -285,9 +293,19 @@ func (s *SimpleSyncer) syncRoot(ctx context.Context, remoteRoot Root,
 	// proofs from the remote party.
 	err = fn.ParSlice(
 		ctx, keysToFetch, func(ctx context.Context, key LeafKey) error {
+			// Each fetched leaf is traced with its anchor
+			// outpoint, so the sync of the proofs of a transfer
+			// can be found through its anchor transaction.
+			ctx, leafSpan := tracer.Start(
+				ctx, "Syncer.fetchProofLeaf",
+				trace.WithAttributes(
+					tracing.AnchorOutPoint(key.OutPoint)...,
+				),
+			)
 			newProof, err := diffEngine.FetchProofLeaf(
 				ctx, uniID, key,
 			)
+			tracing.EndSpan(leafSpan, err)
 			if err != nil {
 				return err
 			}
@@ -503,11 +521,21 @@ func (s *SimpleSyncer) batchStreamNewItems(ctx context.Context,
 // universe, governed by the sync type and the set of universe IDs to sync.
 func (s *SimpleSyncer) SyncUniverse(ctx context.Context, host ServerAddr,
 	syncType SyncType, syncConfigs SyncConfigs,
-	idsToSync ...Identifier) ([]AssetSyncDiff, error) {
+	idsToSync ...Identifier) (_ []AssetSyncDiff, err error) {
 
 	log.Infof("Attempting to sync universe: host=%v, sync_type=%v, ids=%v",
 		host.HostStr(), syncType, spew.Sdump(idsToSync))
 
+	ctx, span := tracer.Start(
+		ctx, "Syncer.SyncUniverse", trace.WithAttributes(
+			tracing.UniverseServerKey.String(host.HostStr()),
+			tracing.SyncTypeKey.String(syncType.String()),
+		),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	// Next, we'll attempt to create a new diff engine for the remote
 	// Universe.
 	diffEngine, err := s.cfg.NewRemoteDiffEngine(host)
@@ -561,6 +589,256 @@ func (s *SimpleSyncer) fetchAllRoots(ctx context.Context,
 	return roots, nil
 }
 
+// diffLeafKeys returns the keys of the leaves the remote universe has that are
+// missing in our local universe.
+func (s *SimpleSyncer) diffLeafKeys(ctx context.Context, uniID Identifier,
+	remoteRoot Root, haveLocalRoot bool,
+	diffEngine DiffEngine) ([]LeafKey, error) {
+
+	// If we already have a version of this universe and both sides are
+	// able to return the inner nodes of their trees, we only need to walk
+	// down the subtrees that differ to find the missing leaves.
+	localSubtrees, localOk := s.cfg.LocalDiffEngine.(SubtreeDiffEngine)
+	remoteSubtrees, remoteOk := diffEngine.(SubtreeDiffEngine)
+	if haveLocalRoot && localOk && remoteOk {
+		keysToFetch, err := subtreeDiff(
+			ctx, uniID, remoteRoot, localSubtrees, remoteSubtrees,
+		)
+		switch {
+		// The remote universe server might not support this yet, in
+		// which case we'll fall back to comparing all the leaf keys.
+		case errors.Is(err, ErrUnsupportedSync):
+			log.Debugf("UniverseRoot(%v): remote doesn't support "+
+				"subtree diff, fetching all leaf keys",
+				uniID.String())
+
+		case err != nil:
+			return nil, fmt.Errorf("unable to diff subtrees: %w",
+				err)
+
+		default:
+			return keysToFetch, nil
+		}
+	}
+
+	// We'll start by fetching the set of keys from both the local and
+	// remote Universe.
+	remoteUniKeys, err := s.fetchAllLeafKeys(ctx, diffEngine, uniID)
+	if err != nil {
+		return nil, err
+	}
+
+	localUniKeys, err := s.fetchAllLeafKeys(
+		ctx, s.cfg.LocalDiffEngine, uniID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the set of keys fetched, we can now find the set of keys that
+	// need to be synced.
+	return fn.SetDiff(remoteUniKeys, localUniKeys), nil
+}
+
+// subtreeDiff finds the keys of the leaves the remote universe has that are
+// missing in the local universe, by walking down both universe trees in
+// lockstep and only descending into the subtrees that differ.
+func subtreeDiff(ctx context.Context, uniID Identifier, remoteRoot Root,
+	local, remote SubtreeDiffEngine) ([]LeafKey, error) {
+
+	// We'll query the nodes a few levels at a time, and as many subtrees
+	// in a single request as the node limit allows.
+	const levels = subtreeSyncLevels
+	batchSize := MaxSubtreeNodes >> levels
+
+	var (
+		// parents maps each path we still need to descend into to the
+		// remote node at that path. We use it to make sure the nodes
+		// the remote returns actually belong to its root.
+		parents = map[mssmt.NodePath]mssmt.Node{
+			mssmt.RootPath: remoteRoot.Node,
+		}
+		paths      = []mssmt.NodePath{mssmt.RootPath}
+		leafNodes  []SubtreeNode
+		numQueried int
+	)
+	for len(paths) > 0 {
+		var nextPaths []mssmt.NodePath
+		for start := 0; start < len(paths); start += batchSize {
+			end := min(start+batchSize, len(paths))
+			batch := paths[start:end]
+
+			// All paths of a round are at the same depth, so we
+			// can't go further down than the leaves.
+			depth := min(levels, mssmt.MaxTreeLevels-batch[0].Depth)
+
+			remoteNodes, err := remote.SubtreeNodes(
+				ctx, uniID, batch, depth,
+			)
+			if err != nil {
+				return nil, err
+			}
+			localNodes, err := local.SubtreeNodes(
+				ctx, uniID, batch, depth,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			numNodes := len(batch) << depth
+			if len(remoteNodes) != numNodes ||
+				len(localNodes) != numNodes {
+
+				return nil, fmt.Errorf("unexpected number of "+
+					"subtree nodes, expected %d, got %d "+
+					"remote and %d local", numNodes,
+					len(remoteNodes), len(localNodes))
+			}
+			numQueried += numNodes
+
+			for i, path := range batch {
+				from, to := i<<depth, (i+1)<<depth
+				err := verifySubtreeNodes(
+					parents[path], path, depth,
+					remoteNodes[from:to],
+				)
+				if err != nil {
+					return nil, err
+				}
+				delete(parents, path)
+			}
+
+			for i := range remoteNodes {
+				remoteNode, localNode := remoteNodes[i],
+					localNodes[i]
+				isLeaf := remoteNode.Path.Depth ==
+					mssmt.MaxTreeLevels
+
+				switch {
+				case mssmt.IsEqualNode(
+					remoteNode.Node, localNode.Node,
+				):
+					continue
+
+				// We don't care about leaves we have that the
+				// remote doesn't.
+				case remoteNode.IsEmpty():
+					continue
+
+				// The remote subtree only holds a single leaf,
+				// so there's no need to walk down any further.
+				case remoteNode.LeafKey.IsSome():
+					leafNodes = append(
+						leafNodes, remoteNode,
+					)
+
+				// A leaf must always come with its key, there's
+				// nothing further down to walk to.
+				case isLeaf:
+					return nil, fmt.Errorf("remote leaf " +
+						"without leaf key")
+
+				default:
+					nextPaths = append(
+						nextPaths, remoteNode.Path,
+					)
+					parents[remoteNode.Path] =
+						remoteNode.Node
+				}
+			}
+		}
+
+		paths = nextPaths
+	}
+
+	// The local subtree of a leaf we're missing might still contain that
+	// same leaf next to other leaves, so we check all candidates against
+	// our local universe directly.
+	var keysToFetch []LeafKey
+	for start := 0; start < len(leafNodes); start += MaxSubtreeNodes {
+		end := min(start+MaxSubtreeNodes, len(leafNodes))
+		batch := leafNodes[start:end]
+
+		leafKeys := make([]LeafKey, len(batch))
+		leafPaths := make([]mssmt.NodePath, len(batch))
+		for i, node := range batch {
+			leafKeys[i] = node.LeafKey.UnwrapOr(LeafKey{})
+			leafPaths[i] = mssmt.NodePath{
+				Key:   leafKeys[i].UniverseKey(),
+				Depth: mssmt.MaxTreeLevels,
+			}
+		}
+
+		localLeaves, err := local.SubtreeNodes(ctx, uniID, leafPaths, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(localLeaves) != len(batch) {
+			return nil, fmt.Errorf("unexpected number of local "+
+				"leaves, expected %d, got %d", len(batch),
+				len(localLeaves))
+		}
+		numQueried += len(batch)
+
+		for i, node := range batch {
+			smtNode := mssmt.SubtreeNode{
+				Path:    node.Path,
+				Node:    node.Node,
+				LeafKey: &leafPaths[i].Key,
+			}
+			if smtNode.HoldsLeaf(localLeaves[i].Node) {
+				continue
+			}
+
+			keysToFetch = append(keysToFetch, leafKeys[i])
+		}
+	}
+
+	log.Debugf("UniverseRoot(%v): subtree diff queried %d nodes",
+		uniID.String(), numQueried)
+
+	return keysToFetch, nil
+}
+
+// verifySubtreeNodes makes sure the given nodes found the given number of
+// levels below the given path hash up to the parent node we previously received
+// for that path.
+func verifySubtreeNodes(parent mssmt.Node, path mssmt.NodePath, levels int,
+	nodes []SubtreeNode) error {
+
+	if parent == nil {
+		return fmt.Errorf("unexpected subtree path %v", path)
+	}
+
+	descendants := path.Descendants(levels)
+	if len(descendants) != len(nodes) {
+		return fmt.Errorf("invalid number of subtree nodes %d",
+			len(nodes))
+	}
+	for i, node := range nodes {
+		if node.Path != descendants[i] {
+			return fmt.Errorf("unexpected subtree node path at "+
+				"depth %d", node.Path.Depth)
+		}
+	}
+
+	root, err := mssmt.SubtreeRoot(
+		fn.Map(nodes, func(n SubtreeNode) mssmt.Node {
+			return n.Node
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !mssmt.IsEqualNode(root, parent) {
+		return fmt.Errorf("subtree nodes at depth %d don't match "+
+			"remote root", path.Depth)
+	}
+
+	return nil
+}
+
 // fetchAllLeafKeys fetches all the leaf keys from the remote Universe. This
 // function is used in order to isolate any logic related to the specifics of
 // how we fetch the data from the universe server.
@@ -0,0 +1,143 @@
+package universe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/fn"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSubtreeDiffEngine is a SubtreeDiffEngine that is backed by an in-memory
+// MS-SMT.
+type mockSubtreeDiffEngine struct {
+	DiffEngine
+
+	tree *mssmt.CompactedTree
+
+	leafKeys map[[32]byte]LeafKey
+
+	// tamper is called on the nodes before they are returned, if set.
+	tamper func([]SubtreeNode)
+}
+
+func newMockSubtreeDiffEngine() *mockSubtreeDiffEngine {
+	return &mockSubtreeDiffEngine{
+		tree:     mssmt.NewCompactedTree(mssmt.NewDefaultStore()),
+		leafKeys: make(map[[32]byte]LeafKey),
+	}
+}
+
+func (m *mockSubtreeDiffEngine) insert(t *testing.T, key LeafKey,
+	leaf *mssmt.LeafNode) {
+
+	smtKey := key.UniverseKey()
+	_, err := m.tree.Insert(context.Background(), smtKey, leaf)
+	require.NoError(t, err)
+
+	m.leafKeys[smtKey] = key
+}
+
+func (m *mockSubtreeDiffEngine) root(t *testing.T) Root {
+	root, err := m.tree.Root(context.Background())
+	require.NoError(t, err)
+
+	return Root{Node: root}
+}
+
+func (m *mockSubtreeDiffEngine) SubtreeNodes(ctx context.Context,
+	_ Identifier, paths []mssmt.NodePath,
+	levels int) ([]SubtreeNode, error) {
+
+	var nodes []SubtreeNode
+	for _, path := range paths {
+		treeNodes, err := m.tree.SubtreeNodes(ctx, path, levels)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, treeNode := range treeNodes {
+			node := SubtreeNode{
+				Path: treeNode.Path,
+				Node: treeNode.Node,
+			}
+			if treeNode.LeafKey != nil {
+				node.LeafKey = fn.Some(
+					m.leafKeys[*treeNode.LeafKey],
+				)
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	if m.tamper != nil {
+		m.tamper(nodes)
+	}
+
+	return nodes, nil
+}
+
+func randSyncLeaf(t *testing.T) (LeafKey, *mssmt.LeafNode) {
+	scriptKey := asset.RandScriptKey(t)
+	key := LeafKey{
+		OutPoint: wire.OutPoint{
+			Hash:  test.RandHash(),
+			Index: test.RandInt[uint32](),
+		},
+		ScriptKey: &scriptKey,
+	}
+
+	return key, mssmt.NewLeafNode(test.RandBytes(32), 1)
+}
+
+// TestSubtreeDiff tests that the subtree diff finds exactly the leaves the
+// remote universe has that are missing locally.
+func TestSubtreeDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	local := newMockSubtreeDiffEngine()
+	remote := newMockSubtreeDiffEngine()
+
+	for i := 0; i < 300; i++ {
+		key, leaf := randSyncLeaf(t)
+		local.insert(t, key, leaf)
+		remote.insert(t, key, leaf)
+	}
+	for i := 0; i < 5; i++ {
+		key, leaf := randSyncLeaf(t)
+		local.insert(t, key, leaf)
+	}
+
+	var expected [][32]byte
+	for i := 0; i < 10; i++ {
+		key, leaf := randSyncLeaf(t)
+		remote.insert(t, key, leaf)
+		expected = append(expected, key.UniverseKey())
+	}
+
+	keys, err := subtreeDiff(
+		ctx, Identifier{}, remote.root(t), local, remote,
+	)
+	require.NoError(t, err)
+
+	found := fn.Map(keys, func(k LeafKey) [32]byte {
+		return k.UniverseKey()
+	})
+	require.ElementsMatch(t, expected, found)
+
+	// If the remote returns nodes that don't belong to its root, the diff
+	// must fail.
+	remote.tamper = func(nodes []SubtreeNode) {
+		nodes[0].Node = mssmt.NewComputedBranch(
+			mssmt.NodeHash(test.RandHash()), 1,
+		)
+	}
+	_, err = subtreeDiff(ctx, Identifier{}, remote.root(t), local, remote)
+	require.ErrorContains(t, err, "don't match remote root")
+}
@@ -0,0 +1,207 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/neutrino/cache/lru"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultUploadLimiterCacheSize is the default maximum number of IP
+	// addresses and universe keys the upload limiter keeps track of.
+	DefaultUploadLimiterCacheSize = 10_000
+)
+
+var (
+	// ErrUploadRateLimited is returned if a proof upload exceeds the
+	// configured per-IP or per-key upload rate.
+	ErrUploadRateLimited = errors.New("proof upload rate limit exceeded")
+)
+
+// UploadLimiterConfig is the configuration of the proof upload rate limiting
+// and anti-spam measures of the universe server.
+//
+//nolint:lll
+type UploadLimiterConfig struct {
+	// PeerRate is the maximum number of proof uploads per second that are
+	// permitted from a single IP address. A value of zero disables per-IP
+	// rate limiting.
+	PeerRate rate.Limit `long:"ip-rate" description:"The maximum number of proof uploads per second that are permitted from a single IP address. A value of zero disables per-IP rate limiting."`
+
+	// PeerBurst is the burst budget for the per-IP proof upload rate
+	// limiting.
+	PeerBurst int `long:"ip-burst" description:"The burst budget for the per-IP proof upload rate limiting."`
+
+	// KeyRate is the maximum number of proof uploads per second that are
+	// permitted for a single universe key (asset ID or group key). A value
+	// of zero disables per-key rate limiting.
+	KeyRate rate.Limit `long:"key-rate" description:"The maximum number of proof uploads per second that are permitted for a single universe key (asset ID or group key). A value of zero disables per-key rate limiting."`
+
+	// KeyBurst is the burst budget for the per-key proof upload rate
+	// limiting.
+	KeyBurst int `long:"key-burst" description:"The burst budget for the per-key proof upload rate limiting."`
+
+	// PoWBits is the number of leading zero bits of hashcash style
+	// proof-of-work that is required for each proof upload. A value of
+	// zero disables the proof-of-work requirement.
+	PoWBits uint32 `long:"pow-bits" description:"The number of leading zero bits of hashcash style proof-of-work that is required for each proof upload. A value of zero disables the proof-of-work requirement."`
+
+	// CacheSize is the maximum number of IP addresses and universe keys
+	// that are tracked for rate limiting. If this number is exceeded, the
+	// least recently seen entries are evicted.
+	CacheSize uint64 `long:"cache-size" description:"The maximum number of IP addresses and universe keys that are tracked for rate limiting."`
+}
+
+// DefaultUploadLimiterConfig returns the default configuration of the proof
+// upload limiter, which doesn't restrict proof uploads.
+func DefaultUploadLimiterConfig() UploadLimiterConfig {
+	return UploadLimiterConfig{
+		PeerBurst: 10,
+		KeyBurst:  10,
+		CacheSize: DefaultUploadLimiterCacheSize,
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *UploadLimiterConfig) Validate() error {
+	switch {
+	case c.PeerRate < 0 || c.KeyRate < 0:
+		return fmt.Errorf("upload rate must not be negative")
+
+	case c.PeerRate > 0 && c.PeerBurst < 1:
+		return fmt.Errorf("per-IP upload burst must be at least 1")
+
+	case c.KeyRate > 0 && c.KeyBurst < 1:
+		return fmt.Errorf("per-key upload burst must be at least 1")
+
+	case c.PoWBits > proof.MaxUploadPoWBits:
+		return fmt.Errorf("upload proof-of-work bits must not exceed "+
+			"%d", proof.MaxUploadPoWBits)
+
+	case (c.PeerRate > 0 || c.KeyRate > 0) && c.CacheSize == 0:
+		return fmt.Errorf("upload limiter cache size must be set")
+	}
+
+	return nil
+}
+
+// cachedLimiter is a rate limiter that can be stored in an LRU cache.
+type cachedLimiter struct {
+	*rate.Limiter
+}
+
+// Size returns the size of the cached limiter. Since we scale the cache by the
+// number of items and not the total memory size, we can simply return 1 here
+// to count each limiter as 1 item.
+func (c cachedLimiter) Size() (uint64, error) {
+	return 1, nil
+}
+
+// limiterSet is a bounded set of rate limiters, one for each key.
+type limiterSet[K comparable] struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters *lru.Cache[K, cachedLimiter]
+}
+
+// newLimiterSet creates a new limiter set. If the limit is zero, nil is
+// returned, which allows all events.
+func newLimiterSet[K comparable](limit rate.Limit, burst int,
+	size uint64) *limiterSet[K] {
+
+	if limit == 0 {
+		return nil
+	}
+
+	return &limiterSet[K]{
+		limit:    limit,
+		burst:    burst,
+		limiters: lru.NewCache[K, cachedLimiter](size),
+	}
+}
+
+// allow returns true if an event for the given key is permitted right now.
+func (l *limiterSet[K]) allow(key K) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, err := l.limiters.Get(key)
+	if err != nil {
+		limiter = cachedLimiter{
+			Limiter: rate.NewLimiter(l.limit, l.burst),
+		}
+		_, _ = l.limiters.Put(key, limiter)
+	}
+
+	return limiter.Allow()
+}
+
+// UploadLimiter enforces the per-IP and per-key rate limits and the
+// proof-of-work requirement for proof uploads to the universe server.
+type UploadLimiter struct {
+	cfg UploadLimiterConfig
+
+	peers *limiterSet[string]
+
+	keys *limiterSet[[32]byte]
+}
+
+// NewUploadLimiter creates a new proof upload limiter.
+func NewUploadLimiter(cfg UploadLimiterConfig) *UploadLimiter {
+	return &UploadLimiter{
+		cfg: cfg,
+		peers: newLimiterSet[string](
+			cfg.PeerRate, cfg.PeerBurst, cfg.CacheSize,
+		),
+		keys: newLimiterSet[[32]byte](
+			cfg.KeyRate, cfg.KeyBurst, cfg.CacheSize,
+		),
+	}
+}
+
+// PoWBits returns the number of leading zero bits of proof-of-work that is
+// required for each proof upload.
+func (u *UploadLimiter) PoWBits() uint32 {
+	return u.cfg.PoWBits
+}
+
+// AllowPeer returns an error if the given IP address has exceeded its proof
+// upload rate.
+func (u *UploadLimiter) AllowPeer(ip string) error {
+	if !u.peers.allow(ip) {
+		return fmt.Errorf("%w for IP %s", ErrUploadRateLimited, ip)
+	}
+
+	return nil
+}
+
+// AllowUpload returns an error if the given proof upload either doesn't carry
+// the required proof-of-work or exceeds the proof upload rate of its universe
+// key.
+func (u *UploadLimiter) AllowUpload(id Identifier, proofBlob []byte,
+	powNonce uint64) error {
+
+	// We check the proof-of-work first, so uploads without valid work
+	// don't use up the rate budget of the universe key.
+	err := proof.VerifyUploadPoW(proofBlob, powNonce, u.cfg.PoWBits)
+	if err != nil {
+		return err
+	}
+
+	if !u.keys.allow(id.Bytes()) {
+		return fmt.Errorf("%w for universe %v", ErrUploadRateLimited,
+			id.StringForLog())
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package universe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUploadLimiter tests that the upload limiter enforces the per-IP and
+// per-key rate limits and the proof-of-work requirement.
+func TestUploadLimiter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := UploadLimiterConfig{
+		PeerRate:  0.001,
+		PeerBurst: 2,
+		KeyRate:   0.001,
+		KeyBurst:  1,
+		PoWBits:   8,
+		CacheSize: 10,
+	}
+	require.NoError(t, cfg.Validate())
+
+	limiter := NewUploadLimiter(cfg)
+	require.EqualValues(t, 8, limiter.PoWBits())
+
+	// Each IP address has its own burst budget.
+	require.NoError(t, limiter.AllowPeer("10.0.0.1"))
+	require.NoError(t, limiter.AllowPeer("10.0.0.1"))
+	require.ErrorIs(t, limiter.AllowPeer("10.0.0.1"), ErrUploadRateLimited)
+	require.NoError(t, limiter.AllowPeer("10.0.0.2"))
+
+	// An upload without valid proof-of-work is rejected, without using up
+	// the rate budget of the universe key.
+	id := Identifier{
+		AssetID:   asset.ID(test.RandHash()),
+		ProofType: ProofTypeIssuance,
+	}
+	proofBlob := test.RandBytes(100)
+
+	nonce, err := proof.SolveUploadPoW(ctx, proofBlob, cfg.PoWBits)
+	require.NoError(t, err)
+	require.NoError(
+		t, proof.VerifyUploadPoW(proofBlob, nonce, cfg.PoWBits),
+	)
+
+	badNonce := nonce + 1
+	for proof.VerifyUploadPoW(proofBlob, badNonce, cfg.PoWBits) == nil {
+		badNonce++
+	}
+	require.ErrorIs(
+		t, limiter.AllowUpload(id, proofBlob, badNonce),
+		proof.ErrInsufficientUploadPoW,
+	)
+
+	// The first upload with valid proof-of-work is accepted, the second
+	// one for the same universe key exceeds the burst budget.
+	require.NoError(t, limiter.AllowUpload(id, proofBlob, nonce))
+	require.ErrorIs(
+		t, limiter.AllowUpload(id, proofBlob, nonce),
+		ErrUploadRateLimited,
+	)
+
+	// Uploads to a different universe have their own budget.
+	otherID := Identifier{
+		AssetID:   asset.ID(test.RandHash()),
+		ProofType: ProofTypeIssuance,
+	}
+	require.NoError(t, limiter.AllowUpload(otherID, proofBlob, nonce))
+
+	// With the default config, uploads aren't restricted at all.
+	limiter = NewUploadLimiter(DefaultUploadLimiterConfig())
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.AllowPeer("10.0.0.1"))
+		require.NoError(t, limiter.AllowUpload(id, proofBlob, 0))
+	}
+}
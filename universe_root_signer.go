@@ -0,0 +1,109 @@
+package taprootassets
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/neutrino/cache/lru"
+	"github.com/lightninglabs/taproot-assets/universe"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// maxNumRootSigsInCache is the maximum number of universe root
+	// signatures that are kept in the cache of the root signer.
+	maxNumRootSigsInCache = 100_000
+)
+
+// cacheableRootSig is a wrapper around a Schnorr signature that can be used as
+// a value in an LRU cache.
+type cacheableRootSig struct {
+	*schnorr.Signature
+}
+
+// Size returns the size of the cacheable root signature. Since we scale the
+// cache by the number of items and not the total memory size, we can simply
+// return 1 here to count each signature as 1 item.
+func (c cacheableRootSig) Size() (uint64, error) {
+	return 1, nil
+}
+
+// LndRootSigner is an implementation of the universe.RootSigner interface
+// that signs universe roots with an operator key held by lnd. The root of a
+// universe only changes when new proofs are inserted, so the signatures are
+// cached to only ask lnd to sign each root once.
+type LndRootSigner struct {
+	signer lndclient.SignerClient
+
+	keyDesc keychain.KeyDescriptor
+
+	sigCache *lru.Cache[[sha256.Size]byte, cacheableRootSig]
+}
+
+// NewLndRootSigner derives the operator key of the local universe server with
+// lnd and creates a new root signer for it.
+func NewLndRootSigner(ctx context.Context,
+	lnd *lndclient.LndServices) (*LndRootSigner, error) {
+
+	keyDesc, err := lnd.WalletKit.DeriveKey(ctx, &keychain.KeyLocator{
+		Family: universe.OperatorKeyFamily,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive universe operator "+
+			"key: %w", err)
+	}
+
+	return &LndRootSigner{
+		signer:  lnd.Signer,
+		keyDesc: *keyDesc,
+		sigCache: lru.NewCache[[sha256.Size]byte, cacheableRootSig](
+			maxNumRootSigsInCache,
+		),
+	}, nil
+}
+
+// OperatorKey returns the long-lived operator key of the local universe
+// server.
+func (l *LndRootSigner) OperatorKey() *btcec.PublicKey {
+	return l.keyDesc.PubKey
+}
+
+// SignRoot signs the given universe root with the operator key.
+func (l *LndRootSigner) SignRoot(ctx context.Context,
+	root universe.Root) (*schnorr.Signature, error) {
+
+	msg, err := universe.RootSigMessage(root)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(msg)
+	if cachedSig, err := l.sigCache.Get(digest); err == nil {
+		return cachedSig.Signature, nil
+	}
+
+	sigBytes, err := l.signer.SignMessage(
+		ctx, msg, l.keyDesc.KeyLocator, lndclient.SignSchnorr(nil),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign universe root: %w", err)
+	}
+
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse universe root "+
+			"signature: %w", err)
+	}
+
+	_, _ = l.sigCache.Put(digest, cacheableRootSig{sig})
+
+	return sig, nil
+}
+
+// A compile time assertion to ensure LndRootSigner meets the
+// universe.RootSigner interface.
+var _ universe.RootSigner = (*LndRootSigner)(nil)
@@ -5,17 +5,27 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/mssmt"
 	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
 	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"golang.org/x/exp/maps"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // RpcUniverseDiff is an implementation of the universe.DiffEngine interface
 // that uses an RPC connection to target Universe.
 type RpcUniverseDiff struct {
 	conn *universeClientConn
+
+	// operatorKey is the operator key of the remote universe server. If
+	// it is set, all universe roots returned by the server must be signed
+	// with it.
+	operatorKey *btcec.PublicKey
 }
 
 // NewRpcUniverseDiff creates a new RpcUniverseDiff instance that dials out to
@@ -34,6 +44,75 @@ func NewRpcUniverseDiff(
 	}, nil
 }
 
+// NewPinnedRpcUniverseDiff returns a constructor for RpcUniverseDiff instances
+// that verify that the universe roots returned by the remote universe server
+// are signed by its operator key. The operator key is pinned in the given DB
+// the first time a server is synced with, after which the server must keep
+// signing its roots with the same key.
+func NewPinnedRpcUniverseDiff(operatorKeys universe.FederationOperatorKeyDB,
+) func(universe.ServerAddr) (universe.DiffEngine, error) {
+
+	return func(serverAddr universe.ServerAddr) (universe.DiffEngine,
+		error) {
+
+		conn, err := ConnectUniverse(serverAddr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to universe "+
+				"RPC server: %w", err)
+		}
+
+		operatorKey, err := checkOperatorKey(
+			conn, operatorKeys, serverAddr,
+		)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		return &RpcUniverseDiff{
+			conn:        conn,
+			operatorKey: operatorKey,
+		}, nil
+	}
+}
+
+// checkOperatorKey fetches the operator key of the remote universe server and
+// checks it against the key that is pinned for the server. The returned key is
+// nil if the server doesn't have an operator key.
+func checkOperatorKey(conn *universeClientConn,
+	operatorKeys universe.FederationOperatorKeyDB,
+	serverAddr universe.ServerAddr) (*btcec.PublicKey, error) {
+
+	ctxt, cancel := context.WithTimeout(
+		context.Background(), universe.DefaultTimeout,
+	)
+	defer cancel()
+
+	info, err := conn.Info(ctxt, &unirpc.InfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting info from server %v: %w",
+			serverAddr.HostStr(), err)
+	}
+
+	var operatorKey *btcec.PublicKey
+	if len(info.OperatorKey) > 0 {
+		operatorKey, err = btcec.ParsePubKey(info.OperatorKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse operator key "+
+				"of server %v: %w", serverAddr.HostStr(), err)
+		}
+	}
+
+	err = universe.CheckOperatorKey(
+		ctxt, operatorKeys, serverAddr, operatorKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return operatorKey, nil
+}
+
 func unmarshalMerkleSumNode(root *unirpc.MerkleSumNode) mssmt.Node {
 	var nodeHash mssmt.NodeHash
 	copy(nodeHash[:], root.RootHash)
@@ -55,23 +134,33 @@ func unmarshalUniverseRoot(
 	}, nil
 }
 
-func unmarshalUniverseRoots(
-	roots []*unirpc.UniverseRoot) ([]universe.Root, error) {
+// unmarshalSignedUniverseRoot unmarshals the given universe root and, if the
+// remote universe server has an operator key, verifies that the root is signed
+// by it.
+func (r *RpcUniverseDiff) unmarshalSignedUniverseRoot(
+	rpcRoot *unirpc.UniverseRoot) (universe.Root, error) {
 
-	uniRoots := make([]universe.Root, 0, len(roots))
-	for _, root := range roots {
-		id, err := UnmarshalUniID(root.Id)
-		if err != nil {
-			return nil, err
-		}
+	root, err := unmarshalUniverseRoot(rpcRoot)
+	if err != nil {
+		return universe.Root{}, err
+	}
 
-		uniRoots = append(uniRoots, universe.Root{
-			ID:   id,
-			Node: unmarshalMerkleSumNode(root.MssmtRoot),
-		})
+	if r.operatorKey == nil {
+		return root, nil
 	}
 
-	return uniRoots, nil
+	sig, err := schnorr.ParseSignature(rpcRoot.OperatorSig)
+	if err != nil {
+		return universe.Root{}, fmt.Errorf("%w: %v",
+			universe.ErrInvalidRootSig, err)
+	}
+
+	err = universe.VerifyRootSig(root, sig, r.operatorKey)
+	if err != nil {
+		return universe.Root{}, err
+	}
+
+	return root, nil
 }
 
 // RootNodes returns the complete set of known root nodes for the set
@@ -91,8 +180,9 @@ func (r *RpcUniverseDiff) RootNodes(ctx context.Context,
 		return nil, err
 	}
 
-	return unmarshalUniverseRoots(
+	return fn.MapErr(
 		maps.Values(universeRoots.UniverseRoots),
+		r.unmarshalSignedUniverseRoot,
 	)
 }
 
@@ -114,10 +204,10 @@ func (r *RpcUniverseDiff) RootNode(ctx context.Context,
 	}
 
 	if id.ProofType == universe.ProofTypeIssuance {
-		return unmarshalUniverseRoot(universeRoot.IssuanceRoot)
+		return r.unmarshalSignedUniverseRoot(universeRoot.IssuanceRoot)
 	}
 
-	return unmarshalUniverseRoot(universeRoot.TransferRoot)
+	return r.unmarshalSignedUniverseRoot(universeRoot.TransferRoot)
 }
 
 // UniverseLeafKeys returns all the keys inserted in the universe.
@@ -210,6 +300,65 @@ func (r *RpcUniverseDiff) FetchProofLeaf(ctx context.Context,
 	return []*universe.Proof{uniProof}, nil
 }
 
+// SubtreeNodes returns, for each of the given paths, the nodes of the given
+// universe tree that are found the given number of levels below the node at
+// that path.
+func (r *RpcUniverseDiff) SubtreeNodes(ctx context.Context,
+	id universe.Identifier, paths []mssmt.NodePath,
+	levels int) ([]universe.SubtreeNode, error) {
+
+	uniID, err := MarshalUniID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.conn.SubtreeNodes(ctx, &unirpc.SubtreeNodesRequest{
+		Id:     uniID,
+		Paths:  fn.Map(paths, marshalSubtreePath),
+		Levels: uint32(levels),
+	})
+
+	// Older universe servers don't know about this call yet, in which case
+	// the syncer needs to fall back to fetching all leaf keys.
+	statusErr, isStatusErr := status.FromError(err)
+	switch {
+	case isStatusErr && statusErr.Code() == codes.Unimplemented:
+		return nil, fmt.Errorf("%w: %v", universe.ErrUnsupportedSync,
+			err)
+
+	case err != nil:
+		return nil, err
+	}
+
+	nodes := make([]universe.SubtreeNode, len(resp.Nodes))
+	for i, node := range resp.Nodes {
+		path, err := unmarshalSubtreePath(node.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if node.Node == nil {
+			return nil, fmt.Errorf("subtree node must be set")
+		}
+
+		nodes[i] = universe.SubtreeNode{
+			Path: path,
+			Node: unmarshalMerkleSumNode(node.Node),
+		}
+
+		if node.LeafKey != nil {
+			leafKey, err := unmarshalLeafKey(node.LeafKey)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes[i].LeafKey = fn.Some(leafKey)
+		}
+	}
+
+	return nodes, nil
+}
+
 // Close closes the underlying RPC connection to the remote universe server.
 func (r *RpcUniverseDiff) Close() error {
 	if err := r.conn.Close(); err != nil {
@@ -222,5 +371,5 @@ func (r *RpcUniverseDiff) Close() error {
 }
 
 // A compile time interface to ensure that RpcUniverseDiff implements the
-// universe.DiffEngine interface.
-var _ universe.DiffEngine = (*RpcUniverseDiff)(nil)
+// universe.SubtreeDiffEngine interface.
+var _ universe.SubtreeDiffEngine = (*RpcUniverseDiff)(nil)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/lightninglabs/taproot-assets/fn"
 	"github.com/lightninglabs/taproot-assets/mssmt"
+	"github.com/lightninglabs/taproot-assets/proof"
 	unirpc "github.com/lightninglabs/taproot-assets/taprpc/universerpc"
 	"github.com/lightninglabs/taproot-assets/universe"
 	"google.golang.org/grpc"
@@ -101,7 +102,8 @@ func (r *RpcUniverseRegistrar) UpsertProofLeaf(ctx context.Context,
 	}
 
 	// With the RPC req prepared, we'll now send it off to the remote
-	// Universe serve as a new proof insertion request.
+	// Universe serve as a new proof insertion request. The client adds the
+	// proof-of-work the remote Universe server requires, if any.
 	proofResp, err := r.conn.InsertProof(ctx, &unirpc.AssetProof{
 		Key:       uniKey,
 		AssetLeaf: assetLeaf,
@@ -198,8 +200,12 @@ func ConnectUniverse(
 			"%w", err)
 	}
 
+	// The client adds the proof-of-work the server requires to all proof
+	// uploads, caching the required difficulty.
+	client := proof.NewUploadPoWClient(unirpc.NewUniverseClient(rawConn))
+
 	return &universeClientConn{
 		ClientConn:     rawConn,
-		UniverseClient: unirpc.NewUniverseClient(rawConn),
+		UniverseClient: client,
 	}, nil
 }
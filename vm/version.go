@@ -0,0 +1,50 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedVersion is returned when a state transition is meant to be
+// validated under a version of the consensus rules that this implementation
+// doesn't know of.
+var ErrUnsupportedVersion = errors.New("unsupported VM version")
+
+// Version denotes the version of the consensus rules the virtual machine
+// enforces when validating a state transition. New rules are only ever
+// enforced for state transitions that declare a version that introduced them,
+// so state transitions created under older rules remain valid. This allows
+// new rules to be rolled out without splitting the validation of an asset's
+// provenance between nodes that know of them and nodes that don't.
+type Version uint32
+
+const (
+	// V0 is the initial version of the consensus rules.
+	V0 Version = 0
+
+	// LatestVersion is the latest version of the consensus rules that
+	// this implementation knows how to enforce.
+	LatestVersion = V0
+)
+
+// IsSupported returns true if this implementation knows how to enforce the
+// consensus rules of the version.
+func (v Version) IsSupported() bool {
+	return v <= LatestVersion
+}
+
+// String returns a human-readable representation of the version.
+func (v Version) String() string {
+	return fmt.Sprintf("v%d", uint32(v))
+}
+
+// SupportedVersions returns all versions of the consensus rules that this
+// implementation knows how to enforce, in ascending order.
+func SupportedVersions() []Version {
+	versions := make([]Version, 0, LatestVersion+1)
+	for v := V0; v <= LatestVersion; v++ {
+		versions = append(versions, v)
+	}
+
+	return versions
+}
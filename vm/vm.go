@@ -44,6 +44,9 @@ type Engine struct {
 	// chainLookup is an interface that can be used to look up certain
 	// information on chain.
 	chainLookup asset.ChainLookup
+
+	// version is the version of the consensus rules the engine enforces.
+	version Version
 }
 
 // newEngineOptions is a struct that is used to customize how a new engine is to
@@ -52,6 +55,7 @@ type newEngineOptions struct {
 	skipTimeLockValidation bool
 	blockHeight            fn.Option[uint32]
 	chainLookup            asset.ChainLookup
+	version                Version
 }
 
 // NewEngineOpt is used to modify how a new engine is to be created.
@@ -88,6 +92,14 @@ func WithSkipTimeLockValidation() NewEngineOpt {
 	}
 }
 
+// WithVersion can be used to create an engine that enforces the consensus
+// rules of the given version instead of the initial version.
+func WithVersion(version Version) NewEngineOpt {
+	return func(o *newEngineOptions) {
+		o.version = version
+	}
+}
+
 // New returns a new virtual machine capable of executing and verifying Taproot
 // Asset state transitions.
 func New(newAsset *asset.Asset, splitAssets []*commitment.SplitAsset,
@@ -105,6 +117,7 @@ func New(newAsset *asset.Asset, splitAssets []*commitment.SplitAsset,
 		skipTimeLockValidation: options.skipTimeLockValidation,
 		blockHeight:            options.blockHeight,
 		chainLookup:            options.chainLookup,
+		version:                options.version,
 	}, nil
 }
 
@@ -497,6 +510,12 @@ func (vm *Engine) validateStateTransition() error {
 // Execute attempts to execute an asset's state transition to determine whether
 // it was valid or not represented by the error returned.
 func (vm *Engine) Execute() error {
+	// We can't tell whether a state transition is valid if it's meant to
+	// be validated under rules we don't know of.
+	if !vm.version.IsSupported() {
+		return fmt.Errorf("%w: %v", ErrUnsupportedVersion, vm.version)
+	}
+
 	// A genesis asset should have a single witness and a PrevID of all
 	// zeros and empty witness and split commitment proof.
 	if vm.newAsset.HasGenesisWitness() {
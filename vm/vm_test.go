@@ -1185,6 +1185,28 @@ func verifyTestCase(t testing.TB, expectedErr error, compareErrString bool,
 	checkErr(err)
 }
 
+// TestVMVersion tests that the engine only executes state transitions under
+// versions of the consensus rules it knows of.
+func TestVMVersion(t *testing.T) {
+	t.Parallel()
+
+	newAsset, _, _, _ := genesisStateTransition(
+		asset.Normal, true, false,
+	)(t)
+
+	for _, version := range SupportedVersions() {
+		engine, err := New(newAsset, nil, nil, WithVersion(version))
+		require.NoError(t, err)
+		require.NoError(t, engine.Execute())
+	}
+
+	engine, err := New(
+		newAsset, nil, nil, WithVersion(LatestVersion+1),
+	)
+	require.NoError(t, err)
+	require.ErrorIs(t, engine.Execute(), ErrUnsupportedVersion)
+}
+
 // TestBIPTestVectors tests that the BIP test vectors are passing.
 func TestBIPTestVectors(t *testing.T) {
 	t.Parallel()
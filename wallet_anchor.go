@@ -187,6 +187,28 @@ func (l *LndRpcWalletAnchor) ListUnspentImportScripts(
 	)
 }
 
+// ListUnspent lists all confirmed and unconfirmed UTXOs of the default account
+// of the wallet that aren't locked.
+func (l *LndRpcWalletAnchor) ListUnspent(
+	ctx context.Context) ([]*lnwallet.Utxo, error) {
+
+	return l.lnd.WalletKit.ListUnspent(
+		ctx, 0, math.MaxInt32,
+		lndclient.WithUnspentAccount(lnwallet.DefaultAccountName),
+	)
+}
+
+// NextChangeAddr returns a new P2WKH change address of the default account of
+// the wallet.
+func (l *LndRpcWalletAnchor) NextChangeAddr(
+	ctx context.Context) (btcutil.Address, error) {
+
+	return l.lnd.WalletKit.NextAddr(
+		ctx, lnwallet.DefaultAccountName,
+		walletrpc.AddressType_WITNESS_PUBKEY_HASH, true,
+	)
+}
+
 // SubscribeTransactions creates a uni-directional stream from the server to the
 // client in which any newly discovered transactions relevant to the wallet are
 // sent over.
@@ -0,0 +1,437 @@
+package walletarchive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// Version is the current version of the wallet archive format.
+	Version uint32 = 0
+
+	// manifestName is the name of the archive entry that holds the
+	// manifest.
+	manifestName = "manifest.json"
+
+	// addrsName is the name of the archive entry that holds the
+	// addresses.
+	addrsName = "addrs.json"
+
+	// scriptKeysName is the name of the archive entry that holds the
+	// script key descriptors.
+	scriptKeysName = "script_keys.json"
+
+	// internalKeysName is the name of the archive entry that holds the
+	// internal key descriptors.
+	internalKeysName = "internal_keys.json"
+
+	// proofsDir is the directory within the archive that holds the proof
+	// files.
+	proofsDir = "proofs/"
+
+	// maxRecordsSize is the maximum size of an archive entry that isn't a
+	// proof file.
+	maxRecordsSize = 64 * 1024 * 1024
+)
+
+var (
+	// ErrUnknownVersion is returned when decoding an archive with a
+	// version that isn't known.
+	ErrUnknownVersion = errors.New("unknown wallet archive version")
+
+	// ErrMissingManifest is returned when decoding an archive that doesn't
+	// contain a manifest.
+	ErrMissingManifest = errors.New("wallet archive manifest missing")
+)
+
+// KeyDescriptor is the archived form of a key descriptor.
+type KeyDescriptor struct {
+	// PubKey is the hex encoded public key.
+	PubKey string `json:"pub_key"`
+
+	// Family is the key family the key was derived from.
+	Family uint32 `json:"family"`
+
+	// Index is the index the key was derived at.
+	Index uint32 `json:"index"`
+}
+
+// NewKeyDescriptor creates the archived form of the given key descriptor.
+func NewKeyDescriptor(desc keychain.KeyDescriptor) KeyDescriptor {
+	return KeyDescriptor{
+		PubKey: hex.EncodeToString(desc.PubKey.SerializeCompressed()),
+		Family: uint32(desc.Family),
+		Index:  desc.Index,
+	}
+}
+
+// Parse parses the archived key descriptor.
+func (k KeyDescriptor) Parse() (keychain.KeyDescriptor, error) {
+	pubKey, err := parsePubKey(k.PubKey)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamily(k.Family),
+			Index:  k.Index,
+		},
+		PubKey: pubKey,
+	}, nil
+}
+
+// ScriptKey is the archived form of a script key and the key descriptor it is
+// derived from.
+type ScriptKey struct {
+	// ScriptKey is the hex encoded tweaked script key.
+	ScriptKey string `json:"script_key"`
+
+	// RawKey is the raw key the script key is derived from.
+	RawKey KeyDescriptor `json:"raw_key"`
+
+	// Tweak is the hex encoded tweak that is applied to the raw key, if
+	// any.
+	Tweak string `json:"tweak,omitempty"`
+
+	// DeclaredKnown is true if the script key was declared as known to
+	// the wallet.
+	DeclaredKnown bool `json:"declared_known"`
+}
+
+// NewScriptKey creates the archived form of the given script key, which must
+// have its key descriptor set.
+func NewScriptKey(scriptKey asset.ScriptKey) (ScriptKey, error) {
+	if scriptKey.PubKey == nil || scriptKey.TweakedScriptKey == nil {
+		return ScriptKey{}, fmt.Errorf("script key descriptor missing")
+	}
+
+	return ScriptKey{
+		ScriptKey: hex.EncodeToString(
+			scriptKey.PubKey.SerializeCompressed(),
+		),
+		RawKey:        NewKeyDescriptor(scriptKey.RawKey),
+		Tweak:         hex.EncodeToString(scriptKey.Tweak),
+		DeclaredKnown: scriptKey.DeclaredKnown,
+	}, nil
+}
+
+// Parse parses the archived script key.
+func (s ScriptKey) Parse() (asset.ScriptKey, error) {
+	pubKey, err := parsePubKey(s.ScriptKey)
+	if err != nil {
+		return asset.ScriptKey{}, fmt.Errorf("invalid script key: %w",
+			err)
+	}
+
+	rawKey, err := s.RawKey.Parse()
+	if err != nil {
+		return asset.ScriptKey{}, fmt.Errorf("invalid raw script "+
+			"key: %w", err)
+	}
+
+	tweak, err := hex.DecodeString(s.Tweak)
+	if err != nil {
+		return asset.ScriptKey{}, fmt.Errorf("invalid script key "+
+			"tweak: %w", err)
+	}
+	if len(tweak) == 0 {
+		tweak = nil
+	}
+
+	return asset.ScriptKey{
+		PubKey: pubKey,
+		TweakedScriptKey: &asset.TweakedScriptKey{
+			RawKey:        rawKey,
+			Tweak:         tweak,
+			DeclaredKnown: s.DeclaredKnown,
+		},
+	}, nil
+}
+
+// Addr is the archived form of an address together with the key information
+// that is needed to receive assets on it.
+type Addr struct {
+	// Addr is the bech32m encoded address.
+	Addr string `json:"addr"`
+
+	// ScriptKey is the script key of the address.
+	ScriptKey ScriptKey `json:"script_key"`
+
+	// InternalKey is the internal key of the address.
+	InternalKey KeyDescriptor `json:"internal_key"`
+}
+
+// NewAddr creates the archived form of the given address.
+func NewAddr(addr *address.AddrWithKeyInfo) (Addr, error) {
+	addrStr, err := addr.EncodeAddress()
+	if err != nil {
+		return Addr{}, fmt.Errorf("unable to encode address: %w", err)
+	}
+
+	scriptKey, err := NewScriptKey(asset.ScriptKey{
+		PubKey:           &addr.ScriptKey,
+		TweakedScriptKey: &addr.ScriptKeyTweak,
+	})
+	if err != nil {
+		return Addr{}, err
+	}
+
+	return Addr{
+		Addr:        addrStr,
+		ScriptKey:   scriptKey,
+		InternalKey: NewKeyDescriptor(addr.InternalKeyDesc),
+	}, nil
+}
+
+// Parse decodes the archived address and returns it together with its script
+// key and internal key descriptor.
+func (a Addr) Parse(params *address.ChainParams) (*address.Tap,
+	asset.ScriptKey, keychain.KeyDescriptor, error) {
+
+	var (
+		scriptKey   asset.ScriptKey
+		internalKey keychain.KeyDescriptor
+	)
+
+	addr, err := address.DecodeAddress(a.Addr, params)
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("unable to "+
+			"decode address: %w", err)
+	}
+
+	scriptKey, err = a.ScriptKey.Parse()
+	if err != nil {
+		return nil, scriptKey, internalKey, err
+	}
+
+	internalKey, err = a.InternalKey.Parse()
+	if err != nil {
+		return nil, scriptKey, internalKey, fmt.Errorf("invalid "+
+			"internal key: %w", err)
+	}
+
+	switch {
+	case !scriptKey.PubKey.IsEqual(&addr.ScriptKey):
+		return nil, scriptKey, internalKey, fmt.Errorf("script key " +
+			"doesn't match address")
+
+	case !internalKey.PubKey.IsEqual(&addr.InternalKey):
+		return nil, scriptKey, internalKey, fmt.Errorf("internal " +
+			"key doesn't match address")
+	}
+
+	return addr, scriptKey, internalKey, nil
+}
+
+// Manifest describes the content of a wallet archive.
+type Manifest struct {
+	// Version is the version of the archive format.
+	Version uint32 `json:"version"`
+
+	// Network is the name of the network the wallet belongs to.
+	Network string `json:"network"`
+
+	// CreatedAt is the time the archive was created at.
+	CreatedAt time.Time `json:"created_at"`
+
+	// NumProofs is the number of proof files in the archive.
+	NumProofs int `json:"num_proofs"`
+}
+
+// Archive is a snapshot of the state of a wallet that is needed to migrate it
+// to another tapd instance or database backend: the proof files of all owned
+// assets, the address book and the descriptors of the script and internal
+// keys of the owned assets. The keys themselves are derived by lnd, so the
+// target instance must be backed by the same lnd wallet.
+type Archive struct {
+	// Manifest describes the content of the archive.
+	Manifest Manifest
+
+	// Addrs are the addresses of the wallet.
+	Addrs []Addr
+
+	// ScriptKeys are the script keys of the owned assets.
+	ScriptKeys []ScriptKey
+
+	// InternalKeys are the internal keys of the anchor outputs of the
+	// owned assets.
+	InternalKeys []KeyDescriptor
+
+	// Proofs are the proof files of the owned assets.
+	Proofs []proof.Blob
+}
+
+// Encode writes the archive as a gzip compressed tarball to the given writer.
+func (a *Archive) Encode(w io.Writer) error {
+	manifest := a.Manifest
+	manifest.Version = Version
+	manifest.NumProofs = len(a.Proofs)
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	writeEntry := func(name string, data []byte) error {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(data)),
+			Mode:     0600,
+			ModTime:  manifest.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to write header of %s: %w",
+				name, err)
+		}
+
+		if _, err := tarWriter.Write(data); err != nil {
+			return fmt.Errorf("unable to write %s: %w", name, err)
+		}
+
+		return nil
+	}
+	writeJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to encode %s: %w", name, err)
+		}
+
+		return writeEntry(name, data)
+	}
+
+	if err := writeJSON(manifestName, &manifest); err != nil {
+		return err
+	}
+	if err := writeJSON(addrsName, a.Addrs); err != nil {
+		return err
+	}
+	if err := writeJSON(scriptKeysName, a.ScriptKeys); err != nil {
+		return err
+	}
+	if err := writeJSON(internalKeysName, a.InternalKeys); err != nil {
+		return err
+	}
+
+	for idx, blob := range a.Proofs {
+		name := fmt.Sprintf(
+			"%s%06d%s", proofsDir, idx,
+			proof.TaprootAssetsFileSuffix,
+		)
+		if err := writeEntry(name, blob); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// Decode reads an archive that was written by Encode from the given reader.
+func Decode(r io.Reader) (*Archive, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open wallet archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	var (
+		archive     Archive
+		tarReader   = tar.NewReader(gzipReader)
+		hasManifest bool
+	)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read wallet "+
+				"archive: %w", err)
+		}
+
+		isProof := strings.HasPrefix(header.Name, proofsDir)
+
+		maxSize := int64(maxRecordsSize)
+		if isProof {
+			maxSize = proof.FileMaxSizeBytes
+		}
+		if header.Size < 0 || header.Size > maxSize {
+			return nil, fmt.Errorf("archive entry %s exceeds "+
+				"maximum size of %d bytes", header.Name,
+				maxSize)
+		}
+
+		data := make([]byte, header.Size)
+		if _, err := io.ReadFull(tarReader, data); err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w",
+				header.Name, err)
+		}
+
+		switch {
+		case header.Name == manifestName:
+			err = json.Unmarshal(data, &archive.Manifest)
+			hasManifest = true
+
+		case header.Name == addrsName:
+			err = json.Unmarshal(data, &archive.Addrs)
+
+		case header.Name == scriptKeysName:
+			err = json.Unmarshal(data, &archive.ScriptKeys)
+
+		case header.Name == internalKeysName:
+			err = json.Unmarshal(data, &archive.InternalKeys)
+
+		case isProof:
+			archive.Proofs = append(archive.Proofs, data)
+
+		default:
+			err = fmt.Errorf("unknown entry")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive entry %s: %w",
+				header.Name, err)
+		}
+	}
+
+	switch {
+	case !hasManifest:
+		return nil, ErrMissingManifest
+
+	case archive.Manifest.Version != Version:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion,
+			archive.Manifest.Version)
+
+	case archive.Manifest.NumProofs != len(archive.Proofs):
+		return nil, fmt.Errorf("wallet archive contains %d proofs, "+
+			"manifest lists %d", len(archive.Proofs),
+			archive.Manifest.NumProofs)
+	}
+
+	return &archive, nil
+}
+
+// parsePubKey parses a hex encoded public key.
+func parsePubKey(pubKeyHex string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(pubKeyBytes)
+}
@@ -0,0 +1,133 @@
+package walletarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/taproot-assets/address"
+	"github.com/lightninglabs/taproot-assets/asset"
+	"github.com/lightninglabs/taproot-assets/internal/test"
+	"github.com/lightninglabs/taproot-assets/proof"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArchiveRoundTrip tests that an archive can be decoded after it was
+// encoded, and that the archived records can be parsed again.
+func TestArchiveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	params := &address.RegressionNetTap
+	addr, _, _ := address.RandAddr(
+		t, params, address.RandProofCourierAddr(t),
+	)
+	archivedAddr, err := NewAddr(addr)
+	require.NoError(t, err)
+
+	keyDesc, _ := test.RandKeyDesc(t)
+	scriptKey := asset.ScriptKey{
+		PubKey: test.RandPubKey(t),
+		TweakedScriptKey: &asset.TweakedScriptKey{
+			RawKey:        keyDesc,
+			Tweak:         test.RandBytes(32),
+			DeclaredKnown: true,
+		},
+	}
+	archivedScriptKey, err := NewScriptKey(scriptKey)
+	require.NoError(t, err)
+
+	// A script key without a descriptor can't be archived.
+	_, err = NewScriptKey(asset.RandScriptKey(t))
+	require.Error(t, err)
+
+	archive := &Archive{
+		Manifest: Manifest{
+			Network:   params.Name,
+			CreatedAt: time.Unix(1700000000, 0).UTC(),
+		},
+		Addrs:        []Addr{archivedAddr},
+		ScriptKeys:   []ScriptKey{archivedScriptKey},
+		InternalKeys: []KeyDescriptor{NewKeyDescriptor(keyDesc)},
+		Proofs: []proof.Blob{
+			test.RandBytes(100), test.RandBytes(1000),
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, archive.Encode(&buf))
+
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+
+	archive.Manifest.NumProofs = len(archive.Proofs)
+	require.Equal(t, archive, decoded)
+
+	parsedAddr, parsedScriptKey, parsedInternalKey, err :=
+		decoded.Addrs[0].Parse(params)
+	require.NoError(t, err)
+	require.Equal(t, addr.Tap.ScriptKey, parsedAddr.ScriptKey)
+	require.Equal(t, addr.ScriptKeyTweak, *parsedScriptKey.TweakedScriptKey)
+	require.Equal(t, addr.InternalKeyDesc, parsedInternalKey)
+
+	parsedKey, err := decoded.ScriptKeys[0].Parse()
+	require.NoError(t, err)
+	require.Equal(t, scriptKey, parsedKey)
+
+	// An address with a script key that doesn't match the archived one is
+	// rejected.
+	decoded.Addrs[0].ScriptKey = archivedScriptKey
+	_, _, _, err = decoded.Addrs[0].Parse(params)
+	require.ErrorContains(t, err, "script key doesn't match")
+}
+
+// tarball creates a gzip compressed tarball with the given entries.
+func tarball(t *testing.T, entries map[string][]byte) *bytes.Buffer {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, data := range entries {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(data)),
+			Mode:     0600,
+		}))
+		_, err := tarWriter.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	return &buf
+}
+
+// TestDecodeInvalidArchive tests that invalid archives are rejected.
+func TestDecodeInvalidArchive(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode(bytes.NewReader([]byte("not an archive")))
+	require.Error(t, err)
+
+	_, err = Decode(tarball(t, map[string][]byte{
+		addrsName: []byte("[]"),
+	}))
+	require.ErrorIs(t, err, ErrMissingManifest)
+
+	_, err = Decode(tarball(t, map[string][]byte{
+		manifestName: []byte(`{"version": 1}`),
+	}))
+	require.ErrorIs(t, err, ErrUnknownVersion)
+
+	_, err = Decode(tarball(t, map[string][]byte{
+		manifestName: []byte(`{"version": 0, "num_proofs": 1}`),
+	}))
+	require.ErrorContains(t, err, "contains 0 proofs")
+
+	_, err = Decode(tarball(t, map[string][]byte{
+		manifestName:   []byte(`{"version": 0}`),
+		"unknown.json": []byte("{}"),
+	}))
+	require.ErrorContains(t, err, "unknown entry")
+}